@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	githubql "github.com/shurcooL/githubv4"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// The REST API for branch protection does not expose required deployments,
+// so they are managed through the branch protection rule in the GraphQL API.
+
+// branchProtectionRule is the part of a GraphQL branch protection rule that
+// configures the required deployments.
+type branchProtectionRule struct {
+	ID                             githubql.ID
+	Pattern                        githubql.String
+	RequiresDeployments            githubql.Boolean
+	RequiredDeploymentEnvironments []githubql.String
+}
+
+type branchProtectionRulesQuery struct {
+	Repository struct {
+		BranchProtectionRules struct {
+			Nodes []branchProtectionRule
+		} `graphql:"branchProtectionRules(first: 100)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type updateBranchProtectionRuleMutation struct {
+	UpdateBranchProtectionRule struct {
+		BranchProtectionRule struct {
+			ID githubql.ID
+		}
+	} `graphql:"updateBranchProtectionRule(input: $input)"`
+}
+
+// UpdateBranchProtectionRuleInput is the input of the updateBranchProtectionRule
+// mutation. The vendored githubv4 input lacks the deployment fields, and the
+// name of the type is used as the GraphQL type of the input variable.
+type UpdateBranchProtectionRuleInput struct {
+	BranchProtectionRuleID         githubql.ID       `json:"branchProtectionRuleId"`
+	RequiresDeployments            githubql.Boolean  `json:"requiresDeployments"`
+	RequiredDeploymentEnvironments []githubql.String `json:"requiredDeploymentEnvironments"`
+}
+
+// branchProtectionRule returns the rule that protects exactly the branch, or
+// nil if the branch is not protected.
+func (p *protector) branchProtectionRule(org, repo, branch string) (*branchProtectionRule, error) {
+	var q branchProtectionRulesQuery
+	vars := map[string]interface{}{
+		"owner": githubql.String(org),
+		"name":  githubql.String(repo),
+	}
+	if err := p.client.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+		return nil, fmt.Errorf("query branch protection rules: %w", err)
+	}
+	for _, rule := range q.Repository.BranchProtectionRules.Nodes {
+		if string(rule.Pattern) == branch {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+// currentDeployments returns the environments the branch currently requires
+// deployments to.
+func (p *protector) currentDeployments(org, repo, branch string) ([]string, error) {
+	rule, err := p.branchProtectionRule(org, repo, branch)
+	if err != nil || rule == nil || !rule.RequiresDeployments {
+		return nil, err
+	}
+	var environments []string
+	for _, environment := range rule.RequiredDeploymentEnvironments {
+		environments = append(environments, string(environment))
+	}
+	return environments, nil
+}
+
+func equalDeployments(current, desired []string) bool {
+	return sets.New[string](current...).Equal(sets.New[string](desired...))
+}
+
+// updateDeployments configures the environments the protected branch requires
+// deployments to.
+func (p *protector) updateDeployments(org, repo, branch string, environments []string) error {
+	rule, err := p.branchProtectionRule(org, repo, branch)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return fmt.Errorf("no branch protection rule for %s", branch)
+	}
+	input := UpdateBranchProtectionRuleInput{
+		BranchProtectionRuleID:         rule.ID,
+		RequiresDeployments:            githubql.Boolean(len(environments) > 0),
+		RequiredDeploymentEnvironments: []githubql.String{},
+	}
+	for _, environment := range sets.List(sets.New[string](environments...)) {
+		input.RequiredDeploymentEnvironments = append(input.RequiredDeploymentEnvironments, githubql.String(environment))
+	}
+	var m updateBranchProtectionRuleMutation
+	return p.client.MutateWithGitHubAppsSupport(context.Background(), &m, input, nil, org)
+}
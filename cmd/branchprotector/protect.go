@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/url"
@@ -27,7 +28,10 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	githubql "github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -36,7 +40,10 @@ import (
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 )
 
 const (
@@ -49,9 +56,11 @@ type options struct {
 	confirm                bool
 	verifyRestrictions     bool
 	enableAppsRestrictions bool
+	reconcileInterval      time.Duration
 
-	github           flagutil.GitHubOptions
-	githubEnablement flagutil.GitHubEnablementOptions
+	github                 flagutil.GitHubOptions
+	githubEnablement       flagutil.GitHubEnablementOptions
+	instrumentationOptions flagutil.InstrumentationOptions
 }
 
 func (o *options) Validate() error {
@@ -67,6 +76,10 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if o.reconcileInterval < 0 {
+		return fmt.Errorf("--reconcile-interval must not be negative, got %v", o.reconcileInterval)
+	}
+
 	return nil
 }
 
@@ -76,7 +89,9 @@ func gatherOptions() options {
 	fs.BoolVar(&o.confirm, "confirm", false, "Mutate github if set")
 	fs.BoolVar(&o.verifyRestrictions, "verify-restrictions", false, "Verify the restrictions section of the request for authorized apps/collaborators/teams")
 	fs.BoolVar(&o.enableAppsRestrictions, "enable-apps-restrictions", false, "Enable feature to enforce apps restrictions in branch protection rules")
+	fs.DurationVar(&o.reconcileInterval, "reconcile-interval", 0, "If set, keep running and re-apply the policy at this interval, reverting manual changes to branch protection. Runs once if unset.")
 	o.config.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
 	o.github.AddCustomizedFlags(fs, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
 	o.githubEnablement.AddFlags(fs)
 	fs.Parse(os.Args[1:])
@@ -88,6 +103,9 @@ type requirements struct {
 	Repo    string
 	Branch  string
 	Request *github.BranchProtectionRequest
+	// Deployments lists the environments the branch requires deployments to,
+	// they are left unmanaged if nil.
+	Deployments *[]string
 }
 
 // Errors holds a list of errors, including a method to concurrently append.
@@ -123,18 +141,16 @@ func main() {
 		logrus.WithError(err).Fatal("Error getting GitHub client.")
 	}
 
-	p := protector{
-		client:                 githubClient,
-		cfg:                    cfg,
-		updates:                make(chan requirements),
-		errors:                 Errors{},
-		completedRepos:         make(map[string]bool),
-		done:                   make(chan []error),
-		verifyRestrictions:     o.verifyRestrictions,
-		enableAppsRestrictions: o.enableAppsRestrictions,
-		enabled:                o.githubEnablement.EnablementChecker(),
+	if o.reconcileInterval > 0 {
+		defer interrupts.WaitForGracefulShutdown()
+		pprof.Instrument(o.instrumentationOptions)
+		metrics.ExposeMetrics("branchprotector", cfg.PushGateway, o.instrumentationOptions.MetricsPort)
+		reconcile(o, ca.Config, githubClient)
+		return
 	}
 
+	p := newProtector(o, githubClient, cfg)
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
@@ -143,10 +159,7 @@ func main() {
 		os.Exit(1)
 	}()
 
-	go p.configureBranches()
-	p.protect()
-	close(p.updates)
-	errors := <-p.done
+	errors := p.sync()
 	if n := len(errors); n > 0 {
 		for i, err := range errors {
 			logrus.WithError(err).Error(i)
@@ -155,6 +168,21 @@ func main() {
 	}
 }
 
+func newProtector(o options, client client, cfg *config.Config) *protector {
+	return &protector{
+		client:                 client,
+		cfg:                    cfg,
+		updates:                make(chan requirements),
+		errors:                 Errors{},
+		completedRepos:         make(map[string]bool),
+		done:                   make(chan []error),
+		verifyRestrictions:     o.verifyRestrictions,
+		enableAppsRestrictions: o.enableAppsRestrictions,
+		dryRun:                 !o.confirm,
+		enabled:                o.githubEnablement.EnablementChecker(),
+	}
+}
+
 type client interface {
 	GetBranchProtection(org, repo, branch string) (*github.BranchProtection, error)
 	RemoveBranchProtection(org, repo, branch string) error
@@ -165,6 +193,8 @@ type client interface {
 	ListAppInstallationsForOrg(org string) ([]github.AppInstallation, error)
 	ListCollaborators(org, repo string) ([]github.User, error)
 	ListRepoTeams(org, repo string) ([]github.Team, error)
+	QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error
+	MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error
 }
 
 type protector struct {
@@ -176,7 +206,19 @@ type protector struct {
 	done                   chan []error
 	verifyRestrictions     bool
 	enableAppsRestrictions bool
-	enabled                func(org, repo string) bool
+	// dryRun logs the difference of the current and the desired protection of branches to update.
+	dryRun  bool
+	enabled func(org, repo string) bool
+	// outOfSync counts the branches per repo whose protection differed from the policy.
+	outOfSync map[config.OrgRepo]int
+}
+
+// sync applies the branch protection policy once and returns the errors encountered.
+func (p *protector) sync() []error {
+	go p.configureBranches()
+	p.protect()
+	close(p.updates)
+	return <-p.done
 }
 
 func (p *protector) configureBranches() {
@@ -190,6 +232,14 @@ func (p *protector) configureBranches() {
 
 		if err := p.client.UpdateBranchProtection(u.Org, u.Repo, u.Branch, *u.Request); err != nil {
 			p.errors.add(fmt.Errorf("update %s/%s=%s protection to %v failed: %w", u.Org, u.Repo, u.Branch, *u.Request, err))
+			continue
+		}
+
+		if u.Deployments == nil || p.dryRun {
+			continue
+		}
+		if err := p.updateDeployments(u.Org, u.Repo, u.Branch, *u.Deployments); err != nil {
+			p.errors.add(fmt.Errorf("update %s/%s=%s required deployments to %v failed: %w", u.Org, u.Repo, u.Branch, *u.Deployments, err))
 		}
 	}
 	p.done <- p.errors.errs
@@ -502,16 +552,37 @@ func (p *protector) UpdateBranch(orgName, repo string, branchName string, branch
 		return fmt.Errorf("get current branch protection: %w", err)
 	}
 
-	if equalBranchProtections(currentBP, req) {
+	// Required deployments are only managed for protected branches whose policy configures them.
+	var deployments *[]string
+	var currentDeployments []string
+	if req != nil && bp.RequiredDeployments != nil {
+		deployments = &bp.RequiredDeployments
+		if protected {
+			if currentDeployments, err = p.currentDeployments(orgName, repo, branchName); err != nil {
+				return fmt.Errorf("get current required deployments: %w", err)
+			}
+		}
+	}
+
+	deploymentsInSync := deployments == nil || equalDeployments(currentDeployments, *deployments)
+	if equalBranchProtections(currentBP, req) && deploymentsInSync {
 		logrus.Debugf("%s/%s=%s: current branch protection matches policy, skipping", orgName, repo, branchName)
 		return nil
 	}
+	p.recordOutOfSync(orgName, repo)
+	if p.dryRun {
+		logrus.Infof("%s/%s=%s: branch protection differs from policy (-current +desired):\n%s", orgName, repo, branchName, cmp.Diff(requestFromState(currentBP), req))
+		if !deploymentsInSync {
+			logrus.Infof("%s/%s=%s: required deployments differ from policy (-current +desired):\n%s", orgName, repo, branchName, cmp.Diff(currentDeployments, *deployments))
+		}
+	}
 
 	p.updates <- requirements{
-		Org:     orgName,
-		Repo:    repo,
-		Branch:  branchName,
-		Request: req,
+		Org:         orgName,
+		Repo:        repo,
+		Branch:      branchName,
+		Request:     req,
+		Deployments: deployments,
 	}
 	return nil
 }
@@ -527,24 +598,115 @@ func equalBranchProtections(state *github.BranchProtection, request *github.Bran
 			equalRestrictions(state.Restrictions, request.Restrictions) &&
 			equalAllowForcePushes(state.AllowForcePushes, request.AllowForcePushes) &&
 			equalRequiredLinearHistory(state.RequiredLinearHistory, request.RequiredLinearHistory) &&
-			equalAllowDeletions(state.AllowDeletions, request.AllowDeletions)
+			equalAllowDeletions(state.AllowDeletions, request.AllowDeletions) &&
+			equalLockBranch(state.LockBranch, request.LockBranch)
 	default:
 		return false
 	}
 }
 
+// requestFromState renders the current branch protection as a request, to
+// show how it differs from the request for the policy.
+func requestFromState(state *github.BranchProtection) *github.BranchProtectionRequest {
+	if state == nil {
+		return nil
+	}
+	enforceAdmins := state.EnforceAdmins.Enabled
+	req := &github.BranchProtectionRequest{
+		RequiredStatusChecks:  state.RequiredStatusChecks,
+		EnforceAdmins:         &enforceAdmins,
+		RequiredLinearHistory: state.RequiredLinearHistory.Enabled,
+		AllowForcePushes:      state.AllowForcePushes.Enabled,
+		AllowDeletions:        state.AllowDeletions.Enabled,
+		LockBranch:            state.LockBranch.Enabled,
+	}
+	if reviews := state.RequiredPullRequestReviews; reviews != nil {
+		req.RequiredPullRequestReviews = &github.RequiredPullRequestReviewsRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+		}
+		if reviews.DismissalRestrictions != nil {
+			req.RequiredPullRequestReviews.DismissalRestrictions = github.DismissalRestrictionsRequest{
+				Users: userLogins(reviews.DismissalRestrictions.Users),
+				Teams: teamSlugs(reviews.DismissalRestrictions.Teams),
+			}
+		}
+		if reviews.BypassRestrictions != nil {
+			req.RequiredPullRequestReviews.BypassRestrictions = github.BypassRestrictionsRequest{
+				Users: userLogins(reviews.BypassRestrictions.Users),
+				Teams: teamSlugs(reviews.BypassRestrictions.Teams),
+			}
+		}
+	}
+	if restrictions := state.Restrictions; restrictions != nil {
+		apps := make([]string, 0, len(restrictions.Apps))
+		for _, app := range restrictions.Apps {
+			apps = append(apps, app.Slug)
+		}
+		req.Restrictions = &github.RestrictionsRequest{
+			Apps:  &apps,
+			Users: userLogins(restrictions.Users),
+			Teams: teamSlugs(restrictions.Teams),
+		}
+	}
+	return req
+}
+
+func userLogins(users []github.User) *[]string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, github.NormLogin(user.Login))
+	}
+	return &logins
+}
+
+func teamSlugs(teams []github.Team) *[]string {
+	slugs := make([]string, 0, len(teams))
+	for _, team := range teams {
+		slugs = append(slugs, team.Slug)
+	}
+	return &slugs
+}
+
 func equalRequiredStatusChecks(state, request *github.RequiredStatusChecks) bool {
 	switch {
 	case state == request:
 		return true
 	case state != nil && request != nil:
 		return state.Strict == request.Strict &&
-			equalStringSlices(&state.Contexts, &request.Contexts)
+			equalStringSlices(&state.Contexts, &request.Contexts) &&
+			equalChecks(state.Checks, request.Checks)
 	default:
 		return false
 	}
 }
 
+// equalChecks compares the apps that must provide the required contexts. Checks
+// are only compared if requested, as GitHub reports them for every context.
+func equalChecks(state, request []github.RequiredStatusCheck) bool {
+	if request == nil {
+		return true
+	}
+	if len(state) != len(request) {
+		return false
+	}
+	stateApps := map[string]*int{}
+	for _, check := range state {
+		stateApps[check.Context] = check.AppID
+	}
+	for _, check := range request {
+		stateApp, ok := stateApps[check.Context]
+		if !ok {
+			return false
+		}
+		if check.AppID != nil && (stateApp == nil || *stateApp != *check.AppID) {
+			return false
+		}
+	}
+	return true
+}
+
 func equalStringSlices(s1, s2 *[]string) bool {
 	switch {
 	case s1 == s2:
@@ -574,6 +736,10 @@ func equalAllowDeletions(state github.AllowDeletions, request bool) bool {
 	return state.Enabled == request
 }
 
+func equalLockBranch(state github.LockBranch, request bool) bool {
+	return state.Enabled == request
+}
+
 func equalAllowForcePushes(state github.AllowForcePushes, request bool) bool {
 	return state.Enabled == request
 }
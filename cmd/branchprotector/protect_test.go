@@ -17,15 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	githubql "github.com/shurcooL/githubv4"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/prow/pkg/config"
@@ -67,6 +71,17 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "negative reconcile interval",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:            flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				reconcileInterval: -time.Minute,
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -89,6 +104,8 @@ type fakeClient struct {
 	appInstallations  []github.AppInstallation
 	collaborators     []github.User
 	teams             []github.Team
+	// deployments maps the branches protected by a rule to the environments they require deployments to.
+	deployments map[string][]string
 }
 
 func (c fakeClient) GetRepo(org string, repo string) (github.FullRepo, error) {
@@ -177,6 +194,52 @@ func (c *fakeClient) ListRepoTeams(org, repo string) ([]github.Team, error) {
 	return c.teams, nil
 }
 
+func (c *fakeClient) QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error {
+	query, ok := q.(*branchProtectionRulesQuery)
+	if !ok {
+		return fmt.Errorf("unexpected query type %T", q)
+	}
+	prefix := fmt.Sprintf("%s/%s=", vars["owner"], vars["name"])
+	rules := sets.New[string]()
+	for ctx := range c.branchProtections {
+		rules.Insert(ctx)
+	}
+	for ctx := range c.updated {
+		rules.Insert(ctx)
+	}
+	for _, ctx := range sets.List(rules) {
+		if !strings.HasPrefix(ctx, prefix) {
+			continue
+		}
+		rule := branchProtectionRule{
+			ID:                  githubql.ID(ctx),
+			Pattern:             githubql.String(strings.TrimPrefix(ctx, prefix)),
+			RequiresDeployments: githubql.Boolean(len(c.deployments[ctx]) > 0),
+		}
+		for _, environment := range c.deployments[ctx] {
+			rule.RequiredDeploymentEnvironments = append(rule.RequiredDeploymentEnvironments, githubql.String(environment))
+		}
+		query.Repository.BranchProtectionRules.Nodes = append(query.Repository.BranchProtectionRules.Nodes, rule)
+	}
+	return nil
+}
+
+func (c *fakeClient) MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error {
+	in, ok := input.(UpdateBranchProtectionRuleInput)
+	if !ok {
+		return fmt.Errorf("unexpected input type %T", input)
+	}
+	if c.deployments == nil {
+		c.deployments = map[string][]string{}
+	}
+	var environments []string
+	for _, environment := range in.RequiredDeploymentEnvironments {
+		environments = append(environments, string(environment))
+	}
+	c.deployments[in.BranchProtectionRuleID.(string)] = environments
+	return nil
+}
+
 func TestConfigureBranches(t *testing.T) {
 	yes := true
 
@@ -1682,6 +1745,96 @@ branch-protection:
 	}
 }
 
+func TestSyncRecordsOutOfSync(t *testing.T) {
+	fc := fakeClient{
+		repos: map[string][]github.Repo{
+			"org": {{Name: "repo", FullName: "org/repo"}, {Name: "other", FullName: "org/other"}},
+		},
+		branches: map[string][]github.Branch{
+			"org/repo":  {{Name: "main", Protected: true}, {Name: "drifted", Protected: true}},
+			"org/other": {{Name: "main", Protected: true}},
+		},
+		branchProtections: map[string]github.BranchProtection{
+			"org/repo=main":  {EnforceAdmins: github.EnforceAdmins{Enabled: true}},
+			"org/other=main": {EnforceAdmins: github.EnforceAdmins{Enabled: true}},
+		},
+	}
+
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(`
+branch-protection:
+  protect: true
+  enforce_admins: true
+  orgs:
+    org:
+`), &cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	p := newProtector(options{}, &fc, &cfg)
+	p.enabled = func(org, repo string) bool { return true }
+
+	if errs := p.sync(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+	expected := map[config.OrgRepo]int{{Org: "org", Repo: "repo"}: 1}
+	if diff := cmp.Diff(expected, p.outOfSync); diff != "" {
+		t.Errorf("out of sync branches differ from expected (-want +got):\n%s", diff)
+	}
+	if _, ok := fc.updated["org/repo=drifted"]; !ok || len(fc.updated) != 1 {
+		t.Errorf("expected only the drifted branch to be updated, got: %v", fc.updated)
+	}
+}
+
+func TestSyncRequiredDeployments(t *testing.T) {
+	fc := fakeClient{
+		repos: map[string][]github.Repo{
+			"org": {{Name: "repo", FullName: "org/repo"}},
+		},
+		branches: map[string][]github.Branch{
+			"org/repo": {{Name: "main", Protected: true}, {Name: "release", Protected: true}, {Name: "new"}},
+		},
+		branchProtections: map[string]github.BranchProtection{
+			"org/repo=main":    {EnforceAdmins: github.EnforceAdmins{Enabled: true}},
+			"org/repo=release": {EnforceAdmins: github.EnforceAdmins{Enabled: true}},
+		},
+		deployments: map[string][]string{
+			"org/repo=main":    {"staging"},
+			"org/repo=release": {"staging", "production"},
+		},
+	}
+
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(`
+branch-protection:
+  protect: true
+  enforce_admins: true
+  required_deployments:
+  - production
+  - staging
+  orgs:
+    org:
+`), &cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	p := newProtector(options{confirm: true}, &fc, &cfg)
+	p.enabled = func(org, repo string) bool { return true }
+
+	if errs := p.sync(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+	expected := map[string][]string{
+		"org/repo=main":    {"production", "staging"},
+		"org/repo=release": {"staging", "production"},
+		"org/repo=new":     {"production", "staging"},
+	}
+	if diff := cmp.Diff(expected, fc.deployments); diff != "" {
+		t.Errorf("required deployments differ from expected (-want +got):\n%s", diff)
+	}
+	if _, ok := fc.updated["org/repo=release"]; ok {
+		t.Errorf("expected the release branch in sync with the policy not to be updated, got: %v", fc.updated)
+	}
+}
+
 func TestIgnorePrivateSecurityRepos(t *testing.T) {
 	testBranches := []string{"organization/repository=branch", "organization/repo-ghsa-1234abcd=branch"}
 	repos := map[string]map[string]bool{}
@@ -1888,6 +2041,17 @@ func TestEqualBranchProtection(t *testing.T) {
 				AllowForcePushes: true,
 			},
 		},
+		{
+			name: "LockBranch is recognized",
+			state: &github.BranchProtection{
+				LockBranch: github.LockBranch{
+					Enabled: false,
+				},
+			},
+			request: &github.BranchProtectionRequest{
+				LockBranch: true,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1897,7 +2061,38 @@ func TestEqualBranchProtection(t *testing.T) {
 	}
 }
 
+func TestRequestFromState(t *testing.T) {
+	app := 1
+	state := &github.BranchProtection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:   true,
+			Contexts: []string{"a", "b"},
+			Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &app}, {Context: "b"}},
+		},
+		EnforceAdmins: github.EnforceAdmins{Enabled: true},
+		RequiredPullRequestReviews: &github.RequiredPullRequestReviews{
+			RequiredApprovingReviewCount: 1,
+			DismissalRestrictions: &github.DismissalRestrictions{
+				Users: []github.User{{Login: "User"}},
+			},
+		},
+		Restrictions: &github.Restrictions{
+			Apps:  []github.App{{Slug: "app"}},
+			Teams: []github.Team{{Slug: "team"}},
+		},
+		LockBranch: github.LockBranch{Enabled: true},
+	}
+	request := requestFromState(state)
+	if !equalBranchProtections(state, request) {
+		t.Errorf("request rendered from state does not match state: %v", request)
+	}
+	if request := requestFromState(nil); request != nil {
+		t.Errorf("expected no request for unprotected branch, got %v", request)
+	}
+}
+
 func TestEqualStatusChecks(t *testing.T) {
+	app, otherApp := 1, 2
 	var testCases = []struct {
 		name     string
 		state    *github.RequiredStatusChecks
@@ -1957,6 +2152,53 @@ func TestEqualStatusChecks(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "checks are ignored if not requested",
+			state: &github.RequiredStatusChecks{
+				Contexts: []string{"a"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &app}},
+			},
+			request: &github.RequiredStatusChecks{
+				Contexts: []string{"a"},
+			},
+			expected: true,
+		},
+		{
+			name: "matching checks work",
+			state: &github.RequiredStatusChecks{
+				Contexts: []string{"a", "b"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &app}, {Context: "b", AppID: &otherApp}},
+			},
+			request: &github.RequiredStatusChecks{
+				Contexts: []string{"a", "b"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &app}, {Context: "b"}},
+			},
+			expected: true,
+		},
+		{
+			name: "not matching on check app",
+			state: &github.RequiredStatusChecks{
+				Contexts: []string{"a"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &otherApp}},
+			},
+			request: &github.RequiredStatusChecks{
+				Contexts: []string{"a"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &app}},
+			},
+			expected: false,
+		},
+		{
+			name: "not matching on check without app",
+			state: &github.RequiredStatusChecks{
+				Contexts: []string{"a"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a"}},
+			},
+			request: &github.RequiredStatusChecks{
+				Contexts: []string{"a"},
+				Checks:   []github.RequiredStatusCheck{{Context: "a", AppID: &app}},
+			},
+			expected: false,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/interrupts"
+)
+
+// Prometheus Metrics
+var (
+	branchProtectorMetrics = struct {
+		outOfSync    *prometheus.GaugeVec
+		syncErrors   prometheus.Gauge
+		syncDuration prometheus.Gauge
+		lastSync     prometheus.Gauge
+	}{
+		outOfSync: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "branchprotector_out_of_sync_branches",
+			Help: "Number of branches whose protection differed from the policy in the last sync.",
+		}, []string{
+			"org",
+			"repo",
+		}),
+		syncErrors: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "branchprotector_sync_errors",
+			Help: "Number of errors which occurred in the last sync.",
+		}),
+		syncDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "branchprotector_sync_duration_seconds",
+			Help: "Time used by the last sync.",
+		}),
+		lastSync: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "branchprotector_last_sync_timestamp_seconds",
+			Help: "Unix time at which the last sync finished.",
+		}),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(branchProtectorMetrics.outOfSync)
+	prometheus.MustRegister(branchProtectorMetrics.syncErrors)
+	prometheus.MustRegister(branchProtectorMetrics.syncDuration)
+	prometheus.MustRegister(branchProtectorMetrics.lastSync)
+}
+
+// reconcile applies the branch protection policy every interval until an
+// interrupt is received. Branches whose protection was changed manually
+// since the last sync are reverted to the policy. The config is reloaded for
+// every sync.
+func reconcile(o options, cfg config.Getter, client client) {
+	interrupts.TickLiteral(func() {
+		start := time.Now()
+		p := newProtector(o, client, cfg())
+		errs := p.sync()
+		for _, err := range errs {
+			logrus.WithError(err).Error("Error protecting branches.")
+		}
+		recordMetrics(p.outOfSync, len(errs), time.Since(start))
+		logrus.WithFields(logrus.Fields{
+			"duration":    time.Since(start),
+			"errors":      len(errs),
+			"out-of-sync": outOfSyncTotal(p.outOfSync),
+		}).Info("Synced branch protection.")
+	}, o.reconcileInterval)
+}
+
+// recordOutOfSync counts a branch whose protection differs from the policy.
+func (p *protector) recordOutOfSync(org, repo string) {
+	if p.outOfSync == nil {
+		p.outOfSync = map[config.OrgRepo]int{}
+	}
+	p.outOfSync[config.OrgRepo{Org: org, Repo: repo}]++
+}
+
+func recordMetrics(outOfSync map[config.OrgRepo]int, errors int, duration time.Duration) {
+	branchProtectorMetrics.outOfSync.Reset()
+	for orgRepo, count := range outOfSync {
+		branchProtectorMetrics.outOfSync.WithLabelValues(orgRepo.Org, orgRepo.Repo).Set(float64(count))
+	}
+	branchProtectorMetrics.syncErrors.Set(float64(errors))
+	branchProtectorMetrics.syncDuration.Set(duration.Seconds())
+	branchProtectorMetrics.lastSync.SetToCurrentTime()
+}
+
+func outOfSyncTotal(outOfSync map[config.OrgRepo]int) int {
+	var total int
+	for _, count := range outOfSync {
+		total += count
+	}
+	return total
+}
@@ -34,6 +34,7 @@ func makeRequest(policy branchprotection.Policy, enableAppsRestrictions bool) gi
 		RequiredLinearHistory:      makeBool(policy.RequiredLinearHistory),
 		AllowForcePushes:           makeBool(policy.AllowForcePushes),
 		AllowDeletions:             makeBool(policy.AllowDeletions),
+		LockBranch:                 makeBool(policy.LockBranch),
 	}
 
 }
@@ -59,15 +60,32 @@ func makeBool(val *bool) bool {
 // makeChecks renders a ContextPolicy into the corresponding GitHub api object.
 //
 // Returns nil when input policy is nil.
-// Otherwise returns non-nil Contexts (empty if unset) and Strict if Strict is true
+// Otherwise returns non-nil Contexts (empty if unset) and Strict if Strict is true.
+// If any checks are configured, Checks lists all contexts, with the app for those
+// configured as checks, as GitHub only considers Checks if it is set.
 func makeChecks(cp *branchprotection.ContextPolicy) *github.RequiredStatusChecks {
 	if cp == nil {
 		return nil
 	}
-	return &github.RequiredStatusChecks{
-		Contexts: append([]string{}, sets.List(sets.New[string](cp.Contexts...))...),
+	contexts := sets.New[string](cp.Contexts...)
+	for _, check := range cp.Checks {
+		contexts.Insert(check.Context)
+	}
+	rsc := &github.RequiredStatusChecks{
+		Contexts: append([]string{}, sets.List(contexts)...),
 		Strict:   makeBool(cp.Strict),
 	}
+	if len(cp.Checks) == 0 {
+		return rsc
+	}
+	apps := map[string]*int{}
+	for _, check := range cp.Checks {
+		apps[check.Context] = check.AppID
+	}
+	for _, context := range rsc.Contexts {
+		rsc.Checks = append(rsc.Checks, github.RequiredStatusCheck{Context: context, AppID: apps[context]})
+	}
+	return rsc
 }
 
 // makeDismissalRestrictions renders restrictions into the corresponding GitHub api object.
@@ -130,6 +130,7 @@ func TestMakeReviews(t *testing.T) {
 func TestMakeRequest(t *testing.T) {
 	yes := true
 	no := false
+	app := 15368
 	cases := []struct {
 		name                    string
 		disableAppsRestrictions bool
@@ -223,6 +224,35 @@ func TestMakeRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Checks => Checks for all contexts",
+			policy: branchprotection.Policy{
+				RequiredStatusChecks: &branchprotection.ContextPolicy{
+					Contexts: []string{"prow-job"},
+					Checks:   []branchprotection.CheckPolicy{{Context: "build", AppID: &app}},
+				},
+			},
+			expected: github.BranchProtectionRequest{
+				EnforceAdmins: &no,
+				RequiredStatusChecks: &github.RequiredStatusChecks{
+					Contexts: []string{"build", "prow-job"},
+					Checks: []github.RequiredStatusCheck{
+						{Context: "build", AppID: &app},
+						{Context: "prow-job"},
+					},
+				},
+			},
+		},
+		{
+			name: "LockBranch works",
+			policy: branchprotection.Policy{
+				LockBranch: &yes,
+			},
+			expected: github.BranchProtectionRequest{
+				EnforceAdmins: &no,
+				LockBranch:    true,
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
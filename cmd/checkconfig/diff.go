@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// jobChange is an added, removed or changed job.
+type jobChange struct {
+	jobType string
+	repo    string
+	name    string
+	// fields are the paths of the fields that changed. Empty for added
+	// and removed jobs.
+	fields  []string
+	added   bool
+	removed bool
+}
+
+func (c jobChange) String() string {
+	job := fmt.Sprintf("%s `%s`", c.jobType, c.name)
+	if c.repo != "" {
+		job += fmt.Sprintf(" of `%s`", c.repo)
+	}
+	switch {
+	case c.added:
+		return "Added " + job
+	case c.removed:
+		return "Removed " + job
+	}
+	return fmt.Sprintf("Changed %s: %s", job, codeList(c.fields))
+}
+
+// pluginChange lists the plugins enabled and disabled for an org or repo.
+type pluginChange struct {
+	orgRepo  string
+	enabled  []string
+	disabled []string
+}
+
+func (c pluginChange) String() string {
+	var changes []string
+	if len(c.enabled) > 0 {
+		changes = append(changes, "enabled "+codeList(c.enabled))
+	}
+	if len(c.disabled) > 0 {
+		changes = append(changes, "disabled "+codeList(c.disabled))
+	}
+	return fmt.Sprintf("`%s`: %s", c.orgRepo, strings.Join(changes, ", "))
+}
+
+// configDiff is the semantic diff between two revisions of the config.
+type configDiff struct {
+	jobs               []jobChange
+	plugins            []pluginChange
+	addedTideQueries   []string
+	removedTideQueries []string
+}
+
+// diffConfig computes the diff from base to head. The plugin configs are
+// only compared if both of them are set.
+func diffConfig(base, head *config.Config, basePlugins, headPlugins *plugins.Configuration) (*configDiff, error) {
+	jobs, err := diffJobs(base.JobConfig, head.JobConfig)
+	if err != nil {
+		return nil, err
+	}
+	d := &configDiff{jobs: jobs}
+	if basePlugins != nil && headPlugins != nil {
+		d.plugins = diffPlugins(basePlugins, headPlugins)
+	}
+	d.addedTideQueries, d.removedTideQueries = diffTideQueries(base.Tide.Queries, head.Tide.Queries)
+	return d, nil
+}
+
+// Markdown renders the diff so that it can be posted as a comment.
+func (d *configDiff) Markdown() string {
+	var b strings.Builder
+	b.WriteString("## Prow config changes\n")
+	if len(d.jobs) == 0 && len(d.plugins) == 0 && len(d.addedTideQueries) == 0 && len(d.removedTideQueries) == 0 {
+		b.WriteString("\nNo semantic changes.\n")
+		return b.String()
+	}
+	if len(d.jobs) > 0 {
+		b.WriteString("\n### Jobs\n\n")
+		for _, c := range d.jobs {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	if len(d.plugins) > 0 {
+		b.WriteString("\n### Plugins\n\n")
+		for _, c := range d.plugins {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	if len(d.addedTideQueries) > 0 || len(d.removedTideQueries) > 0 {
+		b.WriteString("\n### Tide queries\n\n")
+		for _, q := range d.addedTideQueries {
+			fmt.Fprintf(&b, "- Added `%s`\n", q)
+		}
+		for _, q := range d.removedTideQueries {
+			fmt.Fprintf(&b, "- Removed `%s`\n", q)
+		}
+	}
+	return b.String()
+}
+
+// diffJobs compares the jobs of base and head. Jobs are identified by their
+// type, repo and name; jobs that share all three, like presubmits for
+// different branches, are matched in the order they are configured in.
+func diffJobs(base, head config.JobConfig) ([]jobChange, error) {
+	baseJobs, err := jobsByKey(base)
+	if err != nil {
+		return nil, err
+	}
+	headJobs, err := jobsByKey(head)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := sets.KeySet(baseJobs).Union(sets.KeySet(headJobs)).UnsortedList()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+
+	var changes []jobChange
+	for _, key := range keys {
+		before, after := baseJobs[key], headJobs[key]
+		change := jobChange{jobType: key.jobType, repo: key.repo, name: key.name}
+		switch {
+		case before == nil:
+			change.added = true
+		case after == nil:
+			change.removed = true
+		default:
+			change.fields = changedFields("", before, after)
+			if len(change.fields) == 0 {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+type jobKey struct {
+	jobType string
+	repo    string
+	name    string
+	index   int
+}
+
+func (k jobKey) less(o jobKey) bool {
+	if k.jobType != o.jobType {
+		return k.jobType < o.jobType
+	}
+	if k.repo != o.repo {
+		return k.repo < o.repo
+	}
+	if k.name != o.name {
+		return k.name < o.name
+	}
+	return k.index < o.index
+}
+
+// jobsByKey returns the JSON representation of all jobs in jc.
+func jobsByKey(jc config.JobConfig) (map[jobKey]interface{}, error) {
+	jobs := map[jobKey]interface{}{}
+	add := func(jobType, repo, name string, job interface{}) error {
+		key := jobKey{jobType: jobType, repo: repo, name: name}
+		for _, exists := jobs[key]; exists; _, exists = jobs[key] {
+			key.index++
+		}
+		raw, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", jobType, name, err)
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("failed to unmarshal %s %s: %w", jobType, name, err)
+		}
+		jobs[key] = value
+		return nil
+	}
+	for repo, presubmits := range jc.PresubmitsStatic {
+		for _, job := range presubmits {
+			if err := add("presubmit", repo, job.Name, job); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for repo, postsubmits := range jc.PostsubmitsStatic {
+		for _, job := range postsubmits {
+			if err := add("postsubmit", repo, job.Name, job); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, job := range jc.Periodics {
+		if err := add("periodic", "", job.Name, job); err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
+// changedFields returns the paths of the fields that differ between the
+// JSON values a and b.
+func changedFields(path string, a, b interface{}) []string {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		var changed []string
+		for _, key := range sets.List(sets.KeySet(a).Union(sets.KeySet(b))) {
+			field := key
+			if path != "" {
+				field = path + "." + key
+			}
+			changed = append(changed, changedFields(field, a[key], b[key])...)
+		}
+		return changed
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return []string{path}
+		}
+		var changed []string
+		for i := range a {
+			changed = append(changed, changedFields(fmt.Sprintf("%s[%d]", path, i), a[i], b[i])...)
+		}
+		return changed
+	}
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	return []string{path}
+}
+
+// diffPlugins compares the plugins and external plugins enabled for each
+// org and repo.
+func diffPlugins(base, head *plugins.Configuration) []pluginChange {
+	basePlugins, headPlugins := enabledPlugins(base), enabledPlugins(head)
+	var changes []pluginChange
+	for _, orgRepo := range sets.List(sets.KeySet(basePlugins).Union(sets.KeySet(headPlugins))) {
+		before, after := basePlugins[orgRepo], headPlugins[orgRepo]
+		change := pluginChange{
+			orgRepo:  orgRepo,
+			enabled:  sets.List(after.Difference(before)),
+			disabled: sets.List(before.Difference(after)),
+		}
+		if len(change.enabled) > 0 || len(change.disabled) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func enabledPlugins(c *plugins.Configuration) map[string]sets.Set[string] {
+	enabled := map[string]sets.Set[string]{}
+	for orgRepo, orgPlugins := range c.Plugins {
+		enabled[orgRepo] = sets.New[string](orgPlugins.Plugins...)
+	}
+	for orgRepo, externalPlugins := range c.ExternalPlugins {
+		if enabled[orgRepo] == nil {
+			enabled[orgRepo] = sets.New[string]()
+		}
+		for _, plugin := range externalPlugins {
+			enabled[orgRepo].Insert(plugin.Name)
+		}
+	}
+	return enabled
+}
+
+// diffTideQueries compares the GitHub search queries of the Tide queries.
+func diffTideQueries(base, head config.TideQueries) (added, removed []string) {
+	baseQueries, headQueries := sets.New[string](), sets.New[string]()
+	for _, q := range base {
+		baseQueries.Insert(q.Query())
+	}
+	for _, q := range head {
+		headQueries.Insert(q.Query())
+	}
+	return sets.List(headQueries.Difference(baseQueries)), sets.List(baseQueries.Difference(headQueries))
+}
+
+func codeList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, "`"+item+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
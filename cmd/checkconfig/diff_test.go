@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestDiffJobs(t *testing.T) {
+	presubmit := func(name string, image string, branches ...string) config.Presubmit {
+		return config.Presubmit{
+			JobBase: config.JobBase{
+				Name: name,
+				Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{Image: image}}},
+			},
+			Brancher: config.Brancher{Branches: branches},
+		}
+	}
+	testCases := []struct {
+		name     string
+		base     config.JobConfig
+		head     config.JobConfig
+		expected []jobChange
+	}{
+		{
+			name: "unchanged jobs",
+			base: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {presubmit("unit", "golang")}}},
+			head: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {presubmit("unit", "golang")}}},
+		},
+		{
+			name: "added and removed jobs",
+			base: config.JobConfig{
+				PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {presubmit("unit", "golang")}},
+				Periodics:        []config.Periodic{{JobBase: config.JobBase{Name: "cleanup"}}},
+			},
+			head: config.JobConfig{
+				PresubmitsStatic:  map[string][]config.Presubmit{"org/other": {presubmit("unit", "golang")}},
+				PostsubmitsStatic: map[string][]config.Postsubmit{"org/repo": {{JobBase: config.JobBase{Name: "push"}}}},
+			},
+			expected: []jobChange{
+				{jobType: "periodic", name: "cleanup", removed: true},
+				{jobType: "postsubmit", repo: "org/repo", name: "push", added: true},
+				{jobType: "presubmit", repo: "org/other", name: "unit", added: true},
+				{jobType: "presubmit", repo: "org/repo", name: "unit", removed: true},
+			},
+		},
+		{
+			name: "changed fields",
+			base: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {presubmit("unit", "golang:1.21")}}},
+			head: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {presubmit("unit", "golang:1.22", "main")}}},
+			expected: []jobChange{
+				{jobType: "presubmit", repo: "org/repo", name: "unit", fields: []string{"branches", "spec.containers[0].image"}},
+			},
+		},
+		{
+			name: "jobs with the same name are matched in order",
+			base: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {
+				presubmit("unit", "golang:1.21", "main"),
+				presubmit("unit", "golang:1.21", "release"),
+			}}},
+			head: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {
+				presubmit("unit", "golang:1.21", "main"),
+				presubmit("unit", "golang:1.22", "release"),
+			}}},
+			expected: []jobChange{
+				{jobType: "presubmit", repo: "org/repo", name: "unit", fields: []string{"spec.containers[0].image"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := diffJobs(tc.base, tc.head)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual, cmp.AllowUnexported(jobChange{})); diff != "" {
+				t.Errorf("job changes differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffPlugins(t *testing.T) {
+	base := &plugins.Configuration{
+		Plugins: plugins.Plugins{
+			"org":      {Plugins: []string{"lgtm", "approve"}},
+			"org/repo": {Plugins: []string{"wip"}},
+		},
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{"org": {{Name: "needs-rebase"}}},
+	}
+	head := &plugins.Configuration{
+		Plugins: plugins.Plugins{
+			"org":   {Plugins: []string{"lgtm", "hold"}},
+			"other": {Plugins: []string{"size"}},
+		},
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{"org": {{Name: "needs-rebase"}, {Name: "cherrypicker"}}},
+	}
+	expected := []pluginChange{
+		{orgRepo: "org", enabled: []string{"cherrypicker", "hold"}, disabled: []string{"approve"}},
+		{orgRepo: "org/repo", enabled: []string{}, disabled: []string{"wip"}},
+		{orgRepo: "other", enabled: []string{"size"}, disabled: []string{}},
+	}
+	if diff := cmp.Diff(expected, diffPlugins(base, head), cmp.AllowUnexported(pluginChange{})); diff != "" {
+		t.Errorf("plugin changes differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		base     map[string]string
+		head     map[string]string
+		expected string
+	}{
+		{
+			name: "no changes",
+			base: map[string]string{
+				"config.yaml":  "tide:\n  queries:\n  - repos: [org/repo]\n    labels: [lgtm]\n",
+				"jobs.yaml":    "periodics:\n- name: cleanup\n  interval: 1h\n  spec:\n    containers:\n    - image: alpine\n",
+				"plugins.yaml": "plugins:\n  org:\n    plugins: [lgtm]\n",
+			},
+			head: map[string]string{
+				"config.yaml":  "tide:\n  queries:\n  - repos: [org/repo]\n    labels: [lgtm]\n",
+				"jobs.yaml":    "periodics:\n- name: cleanup\n  interval: 1h\n  spec:\n    containers:\n    - image: alpine\n",
+				"plugins.yaml": "plugins:\n  org:\n    plugins: [lgtm]\n",
+			},
+			expected: "## Prow config changes\n\nNo semantic changes.\n",
+		},
+		{
+			name: "all kinds of changes",
+			base: map[string]string{
+				"config.yaml":  "tide:\n  queries:\n  - repos: [org/repo]\n    labels: [lgtm]\n",
+				"jobs.yaml":    "periodics:\n- name: cleanup\n  interval: 1h\n  spec:\n    containers:\n    - image: alpine\n",
+				"plugins.yaml": "plugins:\n  org:\n    plugins: [lgtm]\n",
+			},
+			head: map[string]string{
+				"config.yaml": "tide:\n  queries:\n  - repos: [org/repo]\n    labels: [lgtm, approved]\n",
+				"jobs.yaml": "periodics:\n- name: cleanup\n  interval: 2h\n  spec:\n    containers:\n    - image: alpine\n" +
+					"presubmits:\n  org/repo:\n  - name: unit\n    spec:\n      containers:\n      - image: golang\n",
+				"plugins.yaml": "plugins:\n  org:\n    plugins: [lgtm, hold]\n",
+			},
+			expected: `## Prow config changes
+
+### Jobs
+
+- Changed periodic ` + "`cleanup`: `interval`" + `
+- Added presubmit ` + "`unit` of `org/repo`" + `
+
+### Plugins
+
+- ` + "`org`: enabled `hold`" + `
+
+### Tide queries
+
+- Added ` + "`is:pr state:open archived:false label:\"approved\" label:\"lgtm\" repo:\"org/repo\"`" + `
+- Removed ` + "`is:pr state:open archived:false label:\"lgtm\" repo:\"org/repo\"`" + `
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			baseDir, headDir := t.TempDir(), t.TempDir()
+			for dir, files := range map[string]map[string]string{baseDir: tc.base, headDir: tc.head} {
+				for name, content := range files {
+					if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+						t.Fatalf("failed to write %s: %v", name, err)
+					}
+				}
+			}
+			o := options{
+				diff:                     true,
+				diffBaseConfigPath:       filepath.Join(baseDir, "config.yaml"),
+				diffBaseJobConfigPath:    filepath.Join(baseDir, "jobs.yaml"),
+				diffBasePluginConfigPath: filepath.Join(baseDir, "plugins.yaml"),
+			}
+			o.config.ConfigPath = filepath.Join(headDir, "config.yaml")
+			o.config.JobConfigPath = filepath.Join(headDir, "jobs.yaml")
+			o.pluginsConfig.PluginConfigPath = filepath.Join(headDir, "plugins.yaml")
+
+			var out bytes.Buffer
+			if err := printDiff(o, &out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, out.String()); diff != "" {
+				t.Errorf("diff differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
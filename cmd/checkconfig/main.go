@@ -75,6 +75,16 @@ type options struct {
 	prowYAMLRepoName string
 	prowYAMLPath     string
 
+	extensionOrg  string
+	extensionPath string
+
+	diff                     bool
+	diffBaseConfigPath       string
+	diffBaseJobConfigPath    string
+	diffBasePluginConfigPath string
+
+	policyPath string
+
 	warnings               flagutil.Strings
 	excludeWarnings        flagutil.Strings
 	requiredJobAnnotations flagutil.Strings
@@ -184,6 +194,15 @@ func (o *options) DefaultAndValidate() error {
 	if o.prowYAMLPath != "" && o.prowYAMLRepoName == "" {
 		return errors.New("--prow-yaml-repo-path requires --prow-yaml-repo-name to be set")
 	}
+	if o.extensionPath != "" && o.extensionOrg == "" {
+		return errors.New("--extension-path requires --extension-org to be set")
+	}
+	if o.diff && o.diffBaseConfigPath == "" {
+		return errors.New("--diff requires --diff-base-config-path to be set")
+	}
+	if !o.diff && (o.diffBaseConfigPath != "" || o.diffBaseJobConfigPath != "" || o.diffBasePluginConfigPath != "") {
+		return errors.New("--diff-base-config-path, --diff-base-job-config-path and --diff-base-plugin-config require --diff to be set")
+	}
 	for _, warning := range o.warnings.Strings() {
 		found := false
 		for _, registeredWarning := range allWarnings {
@@ -212,6 +231,13 @@ func (o *options) gatherOptions(flag *flag.FlagSet, args []string) error {
 	o.pluginsConfig.CheckUnknownPlugins = true
 	flag.StringVar(&o.prowYAMLRepoName, "prow-yaml-repo-name", "", "Name of the repo whose .prow.yaml should be checked.")
 	flag.StringVar(&o.prowYAMLPath, "prow-yaml-path", "", "Path to the .prow.yaml file to check. Requires --prow-yaml-repo-name to be set. Omit to look for either .prow.yaml or a .prow directory in the current working directory (recommended).")
+	flag.StringVar(&o.extensionOrg, "extension-org", "", "Org whose config extension should be checked.")
+	flag.StringVar(&o.extensionPath, "extension-path", "", "Path to the config extension to check. Requires --extension-org to be set. Omit to check the config extension in the current working directory.")
+	flag.BoolVar(&o.diff, "diff", false, "If set, print a semantic diff from the config passed with the --diff-base-* flags to the config passed with the regular flags as Markdown instead of validating the config.")
+	flag.StringVar(&o.diffBaseConfigPath, "diff-base-config-path", "", "Path to the prow config to diff against. Requires --diff to be set.")
+	flag.StringVar(&o.diffBaseJobConfigPath, "diff-base-job-config-path", "", "Path to the job config to diff against. Requires --diff to be set.")
+	flag.StringVar(&o.diffBasePluginConfigPath, "diff-base-plugin-config", "", "Path to the plugin config to diff against. Plugins are only diffed if this and --plugin-config are set. Requires --diff to be set.")
+	flag.StringVar(&o.policyPath, "policy-path", "", "Path to a file with policies all jobs have to follow. Violations fail the validation.")
 	flag.Var(&o.warnings, "warnings", "Warnings to validate. Use repeatedly to provide a list of warnings")
 	flag.Var(&o.excludeWarnings, "exclude-warning", "Warnings to exclude. Use repeatedly to provide a list of warnings to exclude")
 	flag.Var(&o.requiredJobAnnotations, "required-job-annotations", "Required annotation names that job has to include in a definition. Use repeatedly to provide a list of required annotations")
@@ -240,6 +266,13 @@ func main() {
 		logrus.Fatalf("Error parsing options - %v", err)
 	}
 
+	if o.diff {
+		if err := printDiff(o, os.Stdout); err != nil {
+			logrus.WithError(err).Fatal("Failed to diff config")
+		}
+		return
+	}
+
 	if err := validate(o); err != nil {
 		switch e := err.(type) {
 		case utilerrors.Aggregate:
@@ -253,6 +286,40 @@ func main() {
 	}
 }
 
+// printDiff writes the semantic diff from the base config to the config to
+// out.
+func printDiff(o options, out stdio.Writer) error {
+	base, err := config.Load(o.diffBaseConfigPath, o.diffBaseJobConfigPath, nil, o.config.SupplementalProwConfigsFileNameSuffix)
+	if err != nil {
+		return fmt.Errorf("error loading base prow config: %w", err)
+	}
+	head, err := config.Load(o.config.ConfigPath, o.config.JobConfigPath, o.config.SupplementalProwConfigDirs.Strings(), o.config.SupplementalProwConfigsFileNameSuffix)
+	if err != nil {
+		return fmt.Errorf("error loading prow config: %w", err)
+	}
+
+	var basePlugins, headPlugins *plugins.Configuration
+	if o.diffBasePluginConfigPath != "" && o.pluginsConfig.PluginConfigPath != "" {
+		baseAgent := &plugins.ConfigAgent{}
+		if err := baseAgent.Load(o.diffBasePluginConfigPath, nil, o.pluginsConfig.SupplementalPluginsConfigsFileNameSuffix, false, false, true); err != nil {
+			return fmt.Errorf("error loading base plugin config: %w", err)
+		}
+		basePlugins = baseAgent.Config()
+		headAgent := &plugins.ConfigAgent{}
+		if err := headAgent.Load(o.pluginsConfig.PluginConfigPath, o.pluginsConfig.SupplementalPluginsConfigDirs.Strings(), o.pluginsConfig.SupplementalPluginsConfigsFileNameSuffix, false, false, true); err != nil {
+			return fmt.Errorf("error loading plugin config: %w", err)
+		}
+		headPlugins = headAgent.Config()
+	}
+
+	diff, err := diffConfig(base, head, basePlugins, headPlugins)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, diff.Markdown())
+	return err
+}
+
 func validate(o options) error {
 	// use all warnings by default
 	if len(o.warnings.Strings()) == 0 || o.includeDefaultWarnings {
@@ -287,6 +354,24 @@ func validate(o options) error {
 		pcfg = pluginAgent.Config()
 	}
 
+	if o.extensionOrg != "" {
+		if err := validateExtension(cfg, pcfg, o.extensionPath, o.extensionOrg); err != nil {
+			return fmt.Errorf("error validating config extension: %w", err)
+		}
+	}
+
+	policies := cfg.JobPolicies
+	if o.policyPath != "" {
+		filePolicies, err := loadPolicies(o.policyPath)
+		if err != nil {
+			return fmt.Errorf("error loading policies: %w", err)
+		}
+		policies = append(append([]config.JobPolicy{}, policies...), filePolicies...)
+	}
+	if err := config.CheckJobPolicies(policies, cfg.JobConfig); err != nil {
+		return err
+	}
+
 	// the following checks are useful in finding user errors but their
 	// presence won't lead to strictly incorrect behavior, so we can
 	// detect them here but don't necessarily want to stop config re-load
@@ -654,7 +739,7 @@ func validateTideRequirements(cfg *config.Config, pcfg *plugins.Configuration, i
 	}
 	forbids := matcher{
 		matches: func(label string, query config.TideQuery) bool {
-			return sets.New[string](query.MissingLabels...).Has(label)
+			return query.IsMissingLabel(label)
 		},
 		verb: "forbid",
 	}
@@ -972,17 +1057,15 @@ func validateNeedsOkToTestLabel(cfg *config.Config) error {
 	for i, query := range cfg.Tide.Queries {
 		for _, label := range query.Labels {
 			if label == lgtm.LGTMLabel {
-				for _, label := range query.MissingLabels {
-					if label == labels.NeedsOkToTest {
-						queryErrors = append(queryErrors, fmt.Errorf(
-							"the tide query at position %d"+
-								"forbids the %q label and requires the %q label, "+
-								"which is not recommended; "+
-								"see https://docs.prow.k8s.io/docs/components/core/tide/maintainers/#best-practices "+
-								"for more information",
-							i, labels.NeedsOkToTest, lgtm.LGTMLabel),
-						)
-					}
+				if query.IsMissingLabel(labels.NeedsOkToTest) {
+					queryErrors = append(queryErrors, fmt.Errorf(
+						"the tide query at position %d"+
+							"forbids the %q label and requires the %q label, "+
+							"which is not recommended; "+
+							"see https://docs.prow.k8s.io/docs/components/core/tide/maintainers/#best-practices "+
+							"for more information",
+						i, labels.NeedsOkToTest, lgtm.LGTMLabel),
+					)
 				}
 			}
 		}
@@ -1177,6 +1260,41 @@ func validateInRepoConfig(cfg *config.Config, filepath, repoIdentifier string, s
 	return nil
 }
 
+// validateExtension validates the config extension of an org in dir against
+// the central config, the same way the config-loader does before syncing it.
+func validateExtension(cfg *config.Config, pcfg *plugins.Configuration, dir, org string) error {
+	if dir == "" {
+		var err error
+		if dir, err = os.Getwd(); err != nil {
+			return fmt.Errorf("failed to get current working directory: %w", err)
+		}
+	}
+
+	jc, err := config.ReadExtensionJobConfig(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read job config: %w", err)
+	}
+	if jc != nil {
+		if err := cfg.ValidateExtension(org, jc); err != nil {
+			return fmt.Errorf("invalid job config: %w", err)
+		}
+	}
+
+	_, pc, err := plugins.ReadExtensionConfig(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin config: %w", err)
+	}
+	if pc != nil {
+		if pcfg == nil {
+			return errors.New("validating the plugin config requires --plugin-config to be set")
+		}
+		if err := pcfg.ValidateExtension(org, pc); err != nil {
+			return fmt.Errorf("invalid plugin config: %w", err)
+		}
+	}
+	return nil
+}
+
 func validateTideContextPolicy(cfg *config.Config) error {
 	// We can not know all possible branches without asking GitHub, so instead we verify
 	// all branches that are explicitly configured on any job. This will hopefully catch
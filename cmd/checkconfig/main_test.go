@@ -1483,6 +1483,30 @@ func TestOptions(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "diff without diff-base-config-path is invalid",
+			args: []string{
+				"--config-path=prow/config.yaml",
+				"--diff",
+			},
+			expectedError: true,
+		},
+		{
+			name: "diff-base-config-path without diff is invalid",
+			args: []string{
+				"--config-path=prow/config.yaml",
+				"--diff-base-config-path=base/config.yaml",
+			},
+			expectedError: true,
+		},
+		{
+			name: "extension-path without extension-org is invalid",
+			args: []string{
+				"--config-path=prow/config.yaml",
+				"--extension-path=prow",
+			},
+			expectedError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1629,6 +1653,73 @@ func TestValidateInRepoConfig(t *testing.T) {
 	}
 }
 
+func TestValidateExtension(t *testing.T) {
+	testCases := []struct {
+		name        string
+		files       map[string]string
+		expectedErr string
+	}{
+		{
+			name: "valid extension",
+			files: map[string]string{
+				"jobs/widget.yaml": `presubmits: {"acme/widget": [{"name": "unit", "spec": {"containers": [{}]}}]}`,
+				"plugins.yaml":     `plugins: {"acme/widget": {"plugins": ["lgtm"]}}`,
+			},
+		},
+		{
+			name: "empty extension",
+		},
+		{
+			name:        "job of another org",
+			files:       map[string]string{"jobs/widget.yaml": `presubmits: {"other/widget": [{"name": "unit", "spec": {"containers": [{}]}}]}`},
+			expectedErr: "invalid job config: jobs of repository other/widget are not allowed for org acme",
+		},
+		{
+			name:        "unknown field in job config",
+			files:       map[string]string{"jobs/widget.yaml": `presubmits: {"acme/widget": [{"name": "unit", "never_run": true, "spec": {"containers": [{}]}}]}`},
+			expectedErr: "unknown field \"never_run\"",
+		},
+		{
+			name:        "plugins configured centrally",
+			files:       map[string]string{"plugins.yaml": `plugins: {"acme/central": {"plugins": ["lgtm"]}}`},
+			expectedErr: "invalid plugin config: plugins.acme/central is already configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte("extensions:\n  sources:\n  - org: acme\n    repo: prow-config\n"), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			cfg, err := config.Load(configFile, "", nil, "")
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			pcfg := &plugins.Configuration{Plugins: plugins.Plugins{"acme/central": {Plugins: []string{"wip"}}}}
+
+			err = validateExtension(cfg, pcfg, dir, "acme")
+			var errString string
+			if err != nil {
+				errString = err.Error()
+			}
+			if (tc.expectedErr == "") != (errString == "") || !strings.Contains(errString, tc.expectedErr) {
+				t.Errorf("expected error %q does not match actual error %q", tc.expectedErr, errString)
+			}
+		})
+	}
+}
+
 func TestValidateTideContextPolicy(t *testing.T) {
 	cfg := func(m ...func(*config.Config)) *config.Config {
 		cfg := &config.Config{}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// policyConfig is the content of the file passed with --policy-path.
+type policyConfig struct {
+	Policies []config.JobPolicy `json:"policies"`
+}
+
+// loadPolicies reads and validates the policy file at path.
+func loadPolicies(path string) ([]config.JobPolicy, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var pc policyConfig
+	if err := yaml.UnmarshalStrict(raw, &pc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy file: %w", err)
+	}
+	if err := config.ValidateJobPolicies("policies", pc.Policies); err != nil {
+		return nil, err
+	}
+	return pc.Policies, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestLoadPolicies(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expected    []config.JobPolicy
+		expectedErr string
+	}{
+		{
+			name: "valid policies",
+			content: `policies:
+- name: decorated
+  orgs: [acme]
+  job_types: [presubmit]
+  rules:
+    require_decoration: true
+- name: resources
+  rules:
+    required_resource_requests: [cpu, memory]
+`,
+			expected: []config.JobPolicy{
+				{Name: "decorated", Orgs: []string{"acme"}, JobTypes: []prowapi.ProwJobType{prowapi.PresubmitJob}, Rules: config.JobPolicyRules{RequireDecoration: true}},
+				{Name: "resources", Rules: config.JobPolicyRules{RequiredResourceRequests: []coreapi.ResourceName{coreapi.ResourceCPU, coreapi.ResourceMemory}}},
+			},
+		},
+		{
+			name:        "unknown rule",
+			content:     "policies:\n- name: decorated\n  rules:\n    require_decorated: true\n",
+			expectedErr: `failed to unmarshal policy file: error unmarshaling JSON: while decoding JSON: json: unknown field "require_decorated"`,
+		},
+		{
+			name:        "missing name",
+			content:     "policies:\n- rules:\n    require_decoration: true\n",
+			expectedErr: "policies[0] has no name",
+		},
+		{
+			name:        "duplicate name",
+			content:     "policies:\n- name: decorated\n  rules:\n    require_decoration: true\n- name: decorated\n  rules:\n    require_decoration: true\n",
+			expectedErr: `policy "decorated" is defined more than once`,
+		},
+		{
+			name:        "invalid job type",
+			content:     "policies:\n- name: decorated\n  job_types: [batch]\n  rules:\n    require_decoration: true\n",
+			expectedErr: `policy "decorated": invalid job type "batch", must be one of presubmit, postsubmit or periodic`,
+		},
+		{
+			name:        "invalid repo",
+			content:     "policies:\n- name: decorated\n  repos: [acme]\n  rules:\n    require_decoration: true\n",
+			expectedErr: `policy "decorated": repo "acme" is not in org/repo format`,
+		},
+		{
+			name:        "no rules",
+			content:     "policies:\n- name: decorated\n  orgs: [acme]\n",
+			expectedErr: `policy "decorated" has no rules`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policies.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("failed to write policies: %v", err)
+			}
+			policies, err := loadPolicies(path)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if diff := cmp.Diff(tc.expectedErr, errMsg); diff != "" {
+				t.Fatalf("error differs from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expected, policies); diff != "" {
+				t.Errorf("policies differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+type githubClient interface {
+	FindIssues(query, sort string, asc bool) ([]github.Issue, error)
+	CreateComment(org, repo string, number int, comment string) error
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	CloseIssueAsNotPlanned(org, repo string, number int) error
+	ClosePullRequest(org, repo string, number int) error
+	LockIssue(org, repo string, number int, reason string) error
+}
+
+// meta is the data the comment template is executed on.
+type meta struct {
+	Org    string
+	Repo   string
+	Number int
+	Issue  github.Issue
+}
+
+// commenter applies the actions of the options to every match of the query.
+type commenter struct {
+	gc      githubClient
+	o       options
+	comment *template.Template
+	out     io.Writer
+	sleep   func(time.Duration)
+}
+
+func newCommenter(gc githubClient, o options, out io.Writer) (*commenter, error) {
+	c := &commenter{gc: gc, o: o, out: out, sleep: time.Sleep}
+	if o.comment != "" {
+		t, err := template.New("comment").Parse(o.comment)
+		if err != nil {
+			return nil, fmt.Errorf("--comment is not a valid template: %w", err)
+		}
+		c.comment = t
+	}
+	return c, nil
+}
+
+// makeQuery restricts the query of the options to open issues of
+// non-archived repositories that were not updated recently, unless the
+// options say otherwise.
+func (o *options) makeQuery(now time.Time) string {
+	parts := []string{o.query}
+	if !o.includeArchived {
+		parts = append(parts, "archived:false")
+	}
+	if !o.includeClosed {
+		parts = append(parts, "is:open")
+	}
+	if o.updated > 0 {
+		parts = append(parts, "updated:<="+now.Add(-o.updated).UTC().Format(time.RFC3339))
+	}
+	return strings.Join(parts, " ")
+}
+
+// run reports the actions on every match and applies them if --confirm is set.
+// Matches are handled least recently updated first, pausing for --pace
+// between them.
+func (c *commenter) run(now time.Time) error {
+	query := c.o.makeQuery(now)
+	issues, err := c.gc.FindIssues(query, "updated", true)
+	if err != nil {
+		return fmt.Errorf("failed to search for %q: %w", query, err)
+	}
+	fmt.Fprintf(c.out, "Found %d matches for %q.\n", len(issues), query)
+	if c.o.ceiling > 0 && len(issues) > c.o.ceiling {
+		fmt.Fprintf(c.out, "Only acting on the first %d matches because of --ceiling.\n", c.o.ceiling)
+		issues = issues[:c.o.ceiling]
+	}
+
+	var errs []error
+	for i, issue := range issues {
+		if c.o.confirm && i > 0 && c.o.pace > 0 {
+			c.sleep(c.o.pace)
+		}
+		if err := c.handle(issue); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !c.o.confirm {
+		fmt.Fprintf(c.out, "Dry run, nothing was changed. Run again with --confirm to act on %d matches.\n", len(issues))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *commenter) handle(issue github.Issue) error {
+	org, repo, err := issue.OrgRepo()
+	if err != nil {
+		return err
+	}
+	number := issue.Number
+	log := logrus.WithFields(logrus.Fields{"org": org, "repo": repo, "number": number})
+	prefix := fmt.Sprintf("%s/%s#%d", org, repo, number)
+
+	type step struct {
+		description string
+		apply       func() error
+	}
+	var steps []step
+	if c.comment != nil {
+		var buf bytes.Buffer
+		if err := c.comment.Execute(&buf, meta{Org: org, Repo: repo, Number: number, Issue: issue}); err != nil {
+			return fmt.Errorf("%s: failed to render comment: %w", prefix, err)
+		}
+		comment := buf.String()
+		steps = append(steps, step{
+			description: "comment:\n" + indent(comment),
+			apply:       func() error { return c.gc.CreateComment(org, repo, number, comment) },
+		})
+	}
+	for _, label := range c.o.addLabels.Strings() {
+		if issue.HasLabel(label) {
+			continue
+		}
+		steps = append(steps, step{
+			description: "add label " + label,
+			apply:       func() error { return c.gc.AddLabel(org, repo, number, label) },
+		})
+	}
+	for _, label := range c.o.removeLabels.Strings() {
+		if !issue.HasLabel(label) {
+			continue
+		}
+		steps = append(steps, step{
+			description: "remove label " + label,
+			apply:       func() error { return c.gc.RemoveLabel(org, repo, number, label) },
+		})
+	}
+	if c.o.close && issue.State != "closed" {
+		steps = append(steps, step{
+			description: "close",
+			apply: func() error {
+				if issue.IsPullRequest() {
+					return c.gc.ClosePullRequest(org, repo, number)
+				}
+				return c.gc.CloseIssueAsNotPlanned(org, repo, number)
+			},
+		})
+	}
+	if c.o.lock {
+		steps = append(steps, step{
+			description: "lock",
+			apply:       func() error { return c.gc.LockIssue(org, repo, number, c.o.lockReason) },
+		})
+	}
+
+	fmt.Fprintf(c.out, "%s %s\n", prefix, issue.Title)
+	for _, s := range steps {
+		fmt.Fprintf(c.out, "  %s\n", s.description)
+		if !c.o.confirm {
+			continue
+		}
+		if err := s.apply(); err != nil {
+			log.WithError(err).Errorf("Failed to %s.", strings.SplitN(s.description, ":", 2)[0])
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+	}
+	return nil
+}
+
+func indent(s string) string {
+	return "    " + strings.ReplaceAll(strings.TrimSuffix(s, "\n"), "\n", "\n    ")
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+type fakeGitHub struct {
+	issues []github.Issue
+	query  string
+	calls  []string
+}
+
+func (f *fakeGitHub) FindIssues(query, sort string, asc bool) ([]github.Issue, error) {
+	f.query = query
+	return f.issues, nil
+}
+
+func (f *fakeGitHub) CreateComment(org, repo string, number int, comment string) error {
+	f.calls = append(f.calls, fmt.Sprintf("CreateComment %s/%s#%d %s", org, repo, number, comment))
+	return nil
+}
+
+func (f *fakeGitHub) AddLabel(org, repo string, number int, label string) error {
+	f.calls = append(f.calls, fmt.Sprintf("AddLabel %s/%s#%d %s", org, repo, number, label))
+	return nil
+}
+
+func (f *fakeGitHub) RemoveLabel(org, repo string, number int, label string) error {
+	f.calls = append(f.calls, fmt.Sprintf("RemoveLabel %s/%s#%d %s", org, repo, number, label))
+	return nil
+}
+
+func (f *fakeGitHub) CloseIssueAsNotPlanned(org, repo string, number int) error {
+	f.calls = append(f.calls, fmt.Sprintf("CloseIssueAsNotPlanned %s/%s#%d", org, repo, number))
+	return nil
+}
+
+func (f *fakeGitHub) ClosePullRequest(org, repo string, number int) error {
+	f.calls = append(f.calls, fmt.Sprintf("ClosePullRequest %s/%s#%d", org, repo, number))
+	return nil
+}
+
+func (f *fakeGitHub) LockIssue(org, repo string, number int, reason string) error {
+	f.calls = append(f.calls, fmt.Sprintf("LockIssue %s/%s#%d %s", org, repo, number, reason))
+	return nil
+}
+
+func testOptions(t *testing.T, args ...string) options {
+	o := gatherOptions(flag.NewFlagSet("commenter", flag.ContinueOnError), append(args, "--github-token-path=")...)
+	if err := o.Validate(); err != nil {
+		t.Fatalf("invalid options %v: %v", args, err)
+	}
+	return o
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr bool
+	}{
+		{
+			name: "comment",
+			args: []string{"--query=org:acme", "--comment=Hello {{.Issue.User.Login}}"},
+		},
+		{
+			name:        "no query",
+			args:        []string{"--close"},
+			expectedErr: true,
+		},
+		{
+			name:        "no action",
+			args:        []string{"--query=org:acme"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid template",
+			args:        []string{"--query=org:acme", "--comment={{.Org"},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown lock reason",
+			args:        []string{"--query=org:acme", "--lock", "--lock-reason=bored"},
+			expectedErr: true,
+		},
+		{
+			name:        "lock reason without lock",
+			args:        []string{"--query=org:acme", "--close", "--lock-reason=resolved"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := gatherOptions(flag.NewFlagSet("commenter", flag.ContinueOnError), append(tc.args, "--github-token-path=")...)
+			if err := o.Validate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []github.Issue{
+		{Number: 1, Title: "Old bug", State: "open", HTMLURL: "https://github.com/acme/widget/issues/1", User: github.User{Login: "alice"}, Labels: []github.Label{{Name: "triage/needs-information"}}},
+		{Number: 2, Title: "Old PR", State: "open", HTMLURL: "https://github.com/acme/gadget/pull/2", User: github.User{Login: "bob"}, PullRequest: &struct{}{}, Labels: []github.Label{{Name: "campaign/cleanup"}}},
+		{Number: 3, Title: "Beyond the ceiling", State: "open", HTMLURL: "https://github.com/acme/widget/issues/3"},
+	}
+	args := []string{
+		"--query=org:acme label:kind/bug",
+		"--updated=720h",
+		"--comment=Thanks @{{.Issue.User.Login}}, closing {{.Org}}/{{.Repo}}#{{.Number}}.",
+		"--add-label=campaign/cleanup",
+		"--remove-label=triage/needs-information",
+		"--close",
+		"--lock",
+		"--lock-reason=resolved",
+		"--ceiling=2",
+		"--pace=5s",
+	}
+	expectedQuery := "org:acme label:kind/bug archived:false is:open updated:<=2026-05-02T00:00:00Z"
+	expectedOut := `Found 3 matches for "org:acme label:kind/bug archived:false is:open updated:<=2026-05-02T00:00:00Z".
+Only acting on the first 2 matches because of --ceiling.
+acme/widget#1 Old bug
+  comment:
+    Thanks @alice, closing acme/widget#1.
+  add label campaign/cleanup
+  remove label triage/needs-information
+  close
+  lock
+acme/gadget#2 Old PR
+  comment:
+    Thanks @bob, closing acme/gadget#2.
+  close
+  lock
+`
+
+	testCases := []struct {
+		name          string
+		confirm       bool
+		expectedOut   string
+		expectedCalls []string
+		expectedSleep []time.Duration
+	}{
+		{
+			name:        "dry run only reports",
+			expectedOut: expectedOut + "Dry run, nothing was changed. Run again with --confirm to act on 2 matches.\n",
+		},
+		{
+			name:        "confirmed",
+			confirm:     true,
+			expectedOut: expectedOut,
+			expectedCalls: []string{
+				"CreateComment acme/widget#1 Thanks @alice, closing acme/widget#1.",
+				"AddLabel acme/widget#1 campaign/cleanup",
+				"RemoveLabel acme/widget#1 triage/needs-information",
+				"CloseIssueAsNotPlanned acme/widget#1",
+				"LockIssue acme/widget#1 resolved",
+				"CreateComment acme/gadget#2 Thanks @bob, closing acme/gadget#2.",
+				"ClosePullRequest acme/gadget#2",
+				"LockIssue acme/gadget#2 resolved",
+			},
+			expectedSleep: []time.Duration{5 * time.Second},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := testOptions(t, append(args, fmt.Sprintf("--confirm=%t", tc.confirm))...)
+			gc := &fakeGitHub{issues: issues}
+			var out bytes.Buffer
+			c, err := newCommenter(gc, o, &out)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var slept []time.Duration
+			c.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+			if err := c.run(now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gc.query != expectedQuery {
+				t.Errorf("expected query %q, got %q", expectedQuery, gc.query)
+			}
+			if diff := cmp.Diff(tc.expectedOut, out.String()); diff != "" {
+				t.Errorf("output differs from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedCalls, gc.calls); diff != "" {
+				t.Errorf("calls differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSleep, slept); diff != "" {
+				t.Errorf("pauses differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// commenter applies bulk actions to the issues and pull requests matching a
+// GitHub search query: it comments, adds and removes labels, closes and locks
+// them. It only reports what it would do unless --confirm is set.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+)
+
+const (
+	defaultTokens = 300
+	defaultBurst  = 100
+)
+
+var lockReasons = sets.New[string]("off-topic", "too heated", "resolved", "spam")
+
+type options struct {
+	github flagutil.GitHubOptions
+
+	query           string
+	updated         time.Duration
+	includeClosed   bool
+	includeArchived bool
+
+	comment      string
+	addLabels    flagutil.Strings
+	removeLabels flagutil.Strings
+	close        bool
+	lock         bool
+	lockReason   string
+
+	ceiling int
+	pace    time.Duration
+	confirm bool
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{}
+
+	fs.StringVar(&o.query, "query", "", "GitHub search query of the issues and pull requests to act on, e.g. 'org:acme label:kind/bug'.")
+	fs.DurationVar(&o.updated, "updated", 0, "If set, only act on issues and pull requests that were not updated for this long.")
+	fs.BoolVar(&o.includeClosed, "include-closed", false, "Also act on closed issues and pull requests.")
+	fs.BoolVar(&o.includeArchived, "include-archived", false, "Also act on issues and pull requests of archived repositories.")
+	fs.StringVar(&o.comment, "comment", "", "Go template of the comment to post, executed on the .Org, .Repo, .Number and .Issue of each match.")
+	fs.Var(&o.addLabels, "add-label", "Label to add. Can be passed multiple times.")
+	fs.Var(&o.removeLabels, "remove-label", "Label to remove, if present. Can be passed multiple times.")
+	fs.BoolVar(&o.close, "close", false, "Close the matches. Issues are closed as not planned.")
+	fs.BoolVar(&o.lock, "lock", false, "Lock the conversation of the matches.")
+	fs.StringVar(&o.lockReason, "lock-reason", "", fmt.Sprintf("Reason for locking, one of %v.", sets.List(lockReasons)))
+	fs.IntVar(&o.ceiling, "ceiling", 0, "Maximum number of matches to act on. Unlimited if 0.")
+	fs.DurationVar(&o.pace, "pace", time.Second, "Pause between matches, to spread the API calls of large campaigns.")
+	fs.BoolVar(&o.confirm, "confirm", false, "Apply the actions. Otherwise only report them.")
+
+	o.github.AddCustomizedFlags(fs, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
+	if err := fs.Parse(args); err != nil {
+		logrus.WithError(err).Fatal("could not parse input")
+	}
+	return o
+}
+
+func (o *options) Validate() error {
+	if o.query == "" {
+		return errors.New("--query must be set")
+	}
+	if o.comment == "" && len(o.addLabels.Strings()) == 0 && len(o.removeLabels.Strings()) == 0 && !o.close && !o.lock {
+		return errors.New("at least one of --comment, --add-label, --remove-label, --close or --lock must be set")
+	}
+	if o.comment != "" {
+		if _, err := template.New("comment").Parse(o.comment); err != nil {
+			return fmt.Errorf("--comment is not a valid template: %w", err)
+		}
+	}
+	if o.lockReason != "" {
+		if !o.lock {
+			return errors.New("--lock-reason requires --lock")
+		}
+		if !lockReasons.Has(o.lockReason) {
+			return fmt.Errorf("--lock-reason must be one of %v", sets.List(lockReasons))
+		}
+	}
+	if o.updated < 0 || o.ceiling < 0 || o.pace < 0 {
+		return errors.New("--updated, --ceiling and --pace must not be negative")
+	}
+	return o.github.Validate(!o.confirm)
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	gc, err := o.github.GitHubClient(!o.confirm)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting GitHub client.")
+	}
+
+	c, err := newCommenter(gc, o, os.Stdout)
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+	if err := c.run(time.Now()); err != nil {
+		logrus.WithError(err).Fatal("Errors occurred.")
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	coreapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// loader syncs the config extensions of all orgs to a config map.
+type loader struct {
+	gitClient     git.ClientFactory
+	configMaps    corev1.ConfigMapInterface
+	configMapName string
+	pluginsSuffix string
+	config        config.Getter
+	plugins       func() *plugins.Configuration
+}
+
+// extension is the synced content of the config extension of an org.
+type extension struct {
+	jobs    []byte
+	plugins []byte
+}
+
+// sync fetches and validates the config extensions of all orgs and updates
+// the config map with the valid ones. The last valid version of an extension
+// is kept if the current one is invalid, so that a bad change in the
+// repository of an org does not remove its jobs.
+func (l *loader) sync(ctx context.Context) error {
+	cfg := l.config()
+	cm, err := l.configMaps.Get(ctx, l.configMapName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get config map %s: %w", l.configMapName, err)
+		}
+		cm = nil
+	}
+
+	data := map[string]string{}
+	for _, source := range cfg.Extensions.Sources {
+		log := logrus.WithFields(logrus.Fields{"org": source.Org, "repo": source.Repo, "base_ref": source.BaseRef})
+		jobsKey, pluginsKey := config.ExtensionJobConfigFile(source.Org), source.Org+l.pluginsSuffix
+		ext, err := l.load(cfg, source)
+		if err != nil {
+			log.WithError(err).Error("Keeping the last valid version of the invalid config extension.")
+			if cm != nil {
+				for _, key := range []string{jobsKey, pluginsKey} {
+					if value, ok := cm.Data[key]; ok {
+						data[key] = value
+					}
+				}
+			}
+			continue
+		}
+		if ext.jobs != nil {
+			data[jobsKey] = string(ext.jobs)
+		}
+		if ext.plugins != nil {
+			data[pluginsKey] = string(ext.plugins)
+		}
+		log.Debug("Loaded config extension.")
+	}
+
+	if cm == nil {
+		_, err := l.configMaps.Create(ctx, &coreapi.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: l.configMapName},
+			Data:       data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create config map %s: %w", l.configMapName, err)
+		}
+		return nil
+	}
+	if maps.Equal(cm.Data, data) {
+		return nil
+	}
+	cm.Data = data
+	if _, err := l.configMaps.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update config map %s: %w", l.configMapName, err)
+	}
+	logrus.WithField("configmap", l.configMapName).Info("Updated config extensions.")
+	return nil
+}
+
+// load fetches the config extension of an org and validates it against the
+// rest of the config.
+func (l *loader) load(cfg *config.Config, source config.ExtensionSource) (*extension, error) {
+	repo, err := l.gitClient.ClientFor(source.Org, source.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone %s/%s: %w", source.Org, source.Repo, err)
+	}
+	defer func() {
+		if err := repo.Clean(); err != nil {
+			logrus.WithError(err).Warn("Failed to clean up repository.")
+		}
+	}()
+	if err := repo.Checkout(source.BaseRef); err != nil {
+		return nil, fmt.Errorf("failed to check out %s: %w", source.BaseRef, err)
+	}
+	dir := filepath.Join(repo.Directory(), source.Path)
+
+	var ext extension
+	jc, err := config.ReadExtensionJobConfig(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job config: %w", err)
+	}
+	if jc != nil {
+		// Serialize the job config before validating it, as the
+		// validation defaults the jobs.
+		if ext.jobs, err = yaml.Marshal(jc); err != nil {
+			return nil, fmt.Errorf("failed to marshal job config: %w", err)
+		}
+		if err := cfg.ValidateExtension(source.Org, jc); err != nil {
+			return nil, fmt.Errorf("invalid job config: %w", err)
+		}
+	}
+
+	raw, pc, err := plugins.ReadExtensionConfig(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin config: %w", err)
+	}
+	if pc != nil {
+		if err := l.plugins().ValidateExtension(source.Org, pc); err != nil {
+			return nil, fmt.Errorf("invalid plugin config: %w", err)
+		}
+		ext.plugins = raw
+	}
+	return &ext, nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/git/localgit"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const validJobs = `presubmits:
+  acme/widget:
+  - name: widget-unit
+    always_run: true
+    spec:
+      containers:
+      - image: golang
+        command: ["go", "test", "./..."]
+`
+
+func TestSync(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string][]byte
+		existing map[string]string
+		expected map[string]string
+	}{
+		{
+			name: "valid extension is synced",
+			files: map[string][]byte{
+				"prow/jobs/widget.yaml": []byte(validJobs),
+				"prow/plugins.yaml":     []byte("plugins:\n  acme/widget:\n    plugins:\n    - lgtm\n"),
+			},
+			expected: map[string]string{
+				"acme.yaml":              "presubmits:\n  acme/widget:\n  - always_run: true\n    name: widget-unit\n    spec:\n      containers:\n      - command:\n        - go\n        - test\n        - ./...\n        image: golang\n        name: \"\"\n        resources: {}\n",
+				"acme_pluginconfig.yaml": "plugins:\n  acme/widget:\n    plugins:\n    - lgtm\n",
+			},
+		},
+		{
+			name:     "extension without plugin config",
+			files:    map[string][]byte{"prow/jobs/widget.yaml": []byte(validJobs)},
+			existing: map[string]string{"acme_pluginconfig.yaml": "plugins: {}\n"},
+			expected: map[string]string{
+				"acme.yaml": "presubmits:\n  acme/widget:\n  - always_run: true\n    name: widget-unit\n    spec:\n      containers:\n      - command:\n        - go\n        - test\n        - ./...\n        image: golang\n        name: \"\"\n        resources: {}\n",
+			},
+		},
+		{
+			name:     "jobs of other orgs keep the last valid version",
+			files:    map[string][]byte{"prow/jobs/widget.yaml": []byte("presubmits:\n  other/repo:\n  - name: steal\n    always_run: true\n    spec:\n      containers:\n      - image: alpine\n")},
+			existing: map[string]string{"acme.yaml": "periodics: []\n"},
+			expected: map[string]string{"acme.yaml": "periodics: []\n"},
+		},
+		{
+			name:     "jobs conflicting with the central config keep the last valid version",
+			files:    map[string][]byte{"prow/jobs/widget.yaml": []byte("presubmits:\n  acme/widget:\n  - name: central-unit\n    always_run: true\n    spec:\n      containers:\n      - image: alpine\n")},
+			existing: map[string]string{"acme.yaml": "periodics: []\n"},
+			expected: map[string]string{"acme.yaml": "periodics: []\n"},
+		},
+		{
+			name: "plugins configured centrally keep the last valid version",
+			files: map[string][]byte{
+				"prow/jobs/widget.yaml": []byte(validJobs),
+				"prow/plugins.yaml":     []byte("plugins:\n  acme/central:\n    plugins:\n    - lgtm\n"),
+			},
+			existing: map[string]string{"acme.yaml": "periodics: []\n"},
+			expected: map[string]string{"acme.yaml": "periodics: []\n"},
+		},
+		{
+			name:     "extensions of removed sources are dropped",
+			existing: map[string]string{"removed.yaml": "periodics: []\n"},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lg, gitClient, err := localgit.NewV2()
+			if err != nil {
+				t.Fatalf("failed to create local git: %v", err)
+			}
+			defer func() {
+				if err := lg.Clean(); err != nil {
+					t.Errorf("failed to clean up local git: %v", err)
+				}
+				if err := gitClient.Clean(); err != nil {
+					t.Errorf("failed to clean up git client: %v", err)
+				}
+			}()
+			if err := lg.MakeFakeRepo("acme", "prow-config"); err != nil {
+				t.Fatalf("failed to create repo: %v", err)
+			}
+			if err := lg.CheckoutNewBranch("acme", "prow-config", "main"); err != nil {
+				t.Fatalf("failed to create branch: %v", err)
+			}
+			if len(tc.files) > 0 {
+				if err := lg.AddCommit("acme", "prow-config", tc.files); err != nil {
+					t.Fatalf("failed to add commit: %v", err)
+				}
+			}
+
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					PresubmitsStatic: map[string][]config.Presubmit{
+						"acme/widget": {{
+							JobBase:  config.JobBase{Name: "central-unit", Namespace: ptr.To("test-pods"), Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{Image: "alpine"}}}},
+							Reporter: config.Reporter{Context: "central-unit"},
+						}},
+					},
+				},
+				ProwConfig: config.ProwConfig{
+					PodNamespace: "test-pods",
+					Extensions: config.Extensions{
+						Sources: []config.ExtensionSource{{
+							Org:             "acme",
+							Repo:            "prow-config",
+							BaseRef:         "main",
+							Path:            "prow",
+							AllowedClusters: []string{"default"},
+						}},
+					},
+				},
+			}
+			pcfg := &plugins.Configuration{Plugins: plugins.Plugins{"acme/central": {Plugins: []string{"approve"}}}}
+
+			kubeClient := fake.NewSimpleClientset()
+			if tc.existing != nil {
+				if _, err := kubeClient.CoreV1().ConfigMaps("prow").Create(context.Background(), &coreapi.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "config-extensions"},
+					Data:       tc.existing,
+				}, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("failed to create config map: %v", err)
+				}
+			}
+
+			l := &loader{
+				gitClient:     gitClient,
+				configMaps:    kubeClient.CoreV1().ConfigMaps("prow"),
+				configMapName: "config-extensions",
+				pluginsSuffix: "_pluginconfig.yaml",
+				config:        func() *config.Config { return cfg },
+				plugins:       func() *plugins.Configuration { return pcfg },
+			}
+			if err := l.sync(context.Background()); err != nil {
+				t.Fatalf("failed to sync: %v", err)
+			}
+
+			cm, err := kubeClient.CoreV1().ConfigMaps("prow").Get(context.Background(), "config-extensions", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get config map: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, cm.Data); diff != "" {
+				t.Errorf("synced config extensions differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+)
+
+type options struct {
+	config        configflagutil.ConfigOptions
+	pluginsConfig pluginsflagutil.PluginOptions
+
+	github                 prowflagutil.GitHubOptions
+	kubernetes             prowflagutil.KubernetesOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+	dryRun                 bool
+
+	namespace     string
+	configMapName string
+	interval      time.Duration
+	cacheDir      string
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{config: configflagutil.ConfigOptions{ConfigPath: "/etc/config/config.yaml"}}
+
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to Kubernetes.")
+	fs.StringVar(&o.namespace, "namespace", "default", "Namespace of the config map to sync the config extensions to.")
+	fs.StringVar(&o.configMapName, "configmap", "config-extensions", "Name of the config map to sync the config extensions to.")
+	fs.DurationVar(&o.interval, "interval", 5*time.Minute, "Interval at which the config extensions are fetched.")
+	fs.StringVar(&o.cacheDir, "cache-dir", "", "Directory to cache the repositories holding the config extensions in.")
+	o.pluginsConfig.PluginConfigPathDefault = "/etc/plugins/plugins.yaml"
+	for _, group := range []prowflagutil.OptionGroup{&o.config, &o.pluginsConfig, &o.github, &o.kubernetes, &o.instrumentationOptions} {
+		group.AddFlags(fs)
+	}
+
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	for _, group := range []prowflagutil.OptionGroup{&o.config, &o.pluginsConfig, &o.github, &o.kubernetes, &o.instrumentationOptions} {
+		if err := group.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
+	if o.configMapName == "" {
+		return errors.New("--configmap must be set")
+	}
+	if o.interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	return nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
+
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error starting config agent.")
+	}
+	pluginAgent, err := o.pluginsConfig.PluginAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error starting plugin configuration agent.")
+	}
+
+	gitClient, err := o.github.GitClientFactory("", &o.cacheDir, o.dryRun, o.cacheDir != "")
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting Git client.")
+	}
+	interrupts.OnInterrupt(func() {
+		if err := gitClient.Clean(); err != nil {
+			logrus.WithError(err).Error("Could not clean up git client cache.")
+		}
+	})
+
+	client, err := o.kubernetes.InfrastructureClusterClient(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting Kubernetes client.")
+	}
+
+	metrics.ExposeMetrics("config-loader", configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
+
+	l := &loader{
+		gitClient:     gitClient,
+		configMaps:    client.CoreV1().ConfigMaps(o.namespace),
+		configMapName: o.configMapName,
+		pluginsSuffix: o.pluginsConfig.SupplementalPluginsConfigsFileNameSuffix,
+		config:        configAgent.Config,
+		plugins:       pluginAgent.Config,
+	}
+	health.ServeReady()
+	interrupts.TickLiteral(func() {
+		start := time.Now()
+		if err := l.sync(context.Background()); err != nil {
+			logrus.WithError(err).Error("Error syncing config extensions.")
+		}
+		logrus.WithField("duration", time.Since(start)).Info("Synced config extensions")
+	}, o.interval)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// config-rollout rolls the staged versions of the Prow config and job config
+// ConfigMaps out to the live ConfigMaps in stages, and rolls them back if the
+// error budgets are exceeded during a stage.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+)
+
+const (
+	defaultPluginErrorQuery = `sum(increase(prow_plugin_handle_errors[%[1]s])) / sum(increase(prow_plugin_handle_duration_seconds_count[%[1]s]))`
+	defaultJobErrorQuery    = `sum(increase(prowjob_state_transitions{state="error"}[%[1]s])) / sum(increase(prowjob_state_transitions{state=~"success|failure|error|aborted"}[%[1]s]))`
+)
+
+type options struct {
+	kubernetes             prowflagutil.KubernetesOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+
+	namespace         string
+	configMaps        prowflagutil.Strings
+	stagedSuffix      string
+	stages            string
+	observationPeriod time.Duration
+	syncPeriod        time.Duration
+
+	prometheusURL      string
+	maxPluginErrorRate float64
+	maxJobErrorRate    float64
+	pluginErrorQuery   string
+	jobErrorQuery      string
+	parsedStages       []int
+	dryRun             bool
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{configMaps: prowflagutil.NewStrings("config", "job-config")}
+
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to Kubernetes.")
+	fs.StringVar(&o.namespace, "namespace", "default", "Namespace of the ConfigMaps.")
+	fs.Var(&o.configMaps, "config-map", "Name of a live ConfigMap to roll staged versions out to. Can be set multiple times.")
+	fs.StringVar(&o.stagedSuffix, "staged-suffix", "-staged", "Suffix of the name of the ConfigMap that holds the staged version of a live ConfigMap.")
+	fs.StringVar(&o.stages, "stages", "10,50,100", "Comma-separated percentages of the repos to roll the jobs of a staged version out to, in order. The last stage must be 100.")
+	fs.DurationVar(&o.observationPeriod, "observation-period", 15*time.Minute, "How long to observe the error budgets during a stage before moving on to the next one.")
+	fs.DurationVar(&o.syncPeriod, "sync-period", time.Minute, "How often to check the staged versions and the error budgets.")
+	fs.StringVar(&o.prometheusURL, "prometheus-url", "", "Address of the Prometheus server to evaluate the error budgets with.")
+	fs.Float64Var(&o.maxPluginErrorRate, "max-plugin-error-rate", 0.05, "Maximum ratio of hook plugin handler errors to handled events during a stage.")
+	fs.Float64Var(&o.maxJobErrorRate, "max-job-error-rate", 0.1, "Maximum ratio of ProwJobs that finish in the error state during a stage.")
+	fs.StringVar(&o.pluginErrorQuery, "plugin-error-query", defaultPluginErrorQuery, "PromQL query of the plugin error rate. %[1]s is replaced with the range of the stage.")
+	fs.StringVar(&o.jobErrorQuery, "job-error-query", defaultJobErrorQuery, "PromQL query of the job error rate. %[1]s is replaced with the range of the stage.")
+	o.kubernetes.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
+
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	for _, group := range []prowflagutil.OptionGroup{&o.kubernetes, &o.instrumentationOptions} {
+		if err := group.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
+	if len(o.configMaps.Strings()) == 0 {
+		return errors.New("--config-map must be set at least once")
+	}
+	if o.stagedSuffix == "" {
+		return errors.New("--staged-suffix must not be empty")
+	}
+	if o.prometheusURL == "" {
+		return errors.New("--prometheus-url is required")
+	}
+	stages, err := parseStages(o.stages)
+	if err != nil {
+		return fmt.Errorf("invalid --stages: %w", err)
+	}
+	o.parsedStages = stages
+	return nil
+}
+
+// parseStages parses increasing percentages that end with 100.
+func parseStages(raw string) ([]int, error) {
+	var stages []int
+	for _, s := range strings.Split(raw, ",") {
+		percent, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		if percent <= 0 || percent > 100 || (len(stages) > 0 && percent <= stages[len(stages)-1]) {
+			return nil, fmt.Errorf("percentages must increase between 1 and 100, got %s", raw)
+		}
+		stages = append(stages, percent)
+	}
+	if stages[len(stages)-1] != 100 {
+		return nil, fmt.Errorf("the last stage must be 100, got %s", raw)
+	}
+	return stages, nil
+}
+
+type prometheusQuerier struct {
+	api promv1.API
+}
+
+// query returns the value of an instant query that yields a single value.
+// Queries without a result, e.g. because no events happened, yield NaN.
+func (p *prometheusQuerier) query(ctx context.Context, query string) (float64, error) {
+	result, warnings, err := p.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if len(warnings) > 0 {
+		logrus.WithField("query", query).WithField("warnings", warnings).Warn("Prometheus returned warnings.")
+	}
+	switch v := result.(type) {
+	case *model.Scalar:
+		return float64(v.Value), nil
+	case model.Vector:
+		if len(v) == 0 {
+			return math.NaN(), nil
+		}
+		return float64(v[0].Value), nil
+	}
+	return 0, fmt.Errorf("unexpected result type %s", result.Type())
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
+	metrics.ExposeMetrics("config-rollout", config.PushGateway{}, o.instrumentationOptions.MetricsPort)
+
+	client, err := o.kubernetes.InfrastructureClusterClient(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting Kubernetes client.")
+	}
+	promClient, err := api.NewClient(api.Config{Address: o.prometheusURL})
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting Prometheus client.")
+	}
+
+	c := &controller{
+		client: client.CoreV1().ConfigMaps(o.namespace),
+		monitor: &budgetMonitor{
+			querier: &prometheusQuerier{api: promv1.NewAPI(promClient)},
+			budgets: []errorBudget{
+				{name: "plugin error rate", query: o.pluginErrorQuery, max: o.maxPluginErrorRate},
+				{name: "job error rate", query: o.jobErrorQuery, max: o.maxJobErrorRate},
+			},
+		},
+		stages:            o.parsedStages,
+		observationPeriod: o.observationPeriod,
+		stagedSuffix:      o.stagedSuffix,
+		now:               time.Now,
+		dryRun:            o.dryRun,
+	}
+	health.ServeReady()
+
+	interrupts.TickLiteral(func() {
+		for _, name := range o.configMaps.Strings() {
+			if err := c.sync(interrupts.Context(), name); err != nil {
+				logrus.WithError(err).WithField("configmap", name).Error("Error syncing rollout.")
+			}
+		}
+	}, o.syncPeriod)
+}
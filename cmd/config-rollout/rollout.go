@@ -0,0 +1,459 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	coreapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// versionAnnotation holds the hash of the staged ConfigMap that is rolled
+	// out to the live ConfigMap.
+	versionAnnotation = "prow.k8s.io/rollout-version"
+	// stateAnnotation holds the state of the rollout of that version.
+	stateAnnotation = "prow.k8s.io/rollout-state"
+	// stageAnnotation holds the index of the current stage of the rollout.
+	stageAnnotation = "prow.k8s.io/rollout-stage"
+	// stageStartedAnnotation holds the time the current stage started.
+	stageStartedAnnotation = "prow.k8s.io/rollout-stage-started"
+
+	stateProgressing = "progressing"
+	stateComplete    = "complete"
+	stateRolledBack  = "rolled-back"
+
+	// previousSuffix is the suffix of the ConfigMap that keeps the live data
+	// from before the rollout, to roll back to.
+	previousSuffix = "-previous"
+)
+
+var (
+	rolloutPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "config_rollout_percent",
+		Help: "Percentage of the repos the staged version of a ConfigMap is rolled out to.",
+	}, []string{"configmap"})
+	rollbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "config_rollout_rollbacks",
+		Help: "Number of rollouts of a ConfigMap that were rolled back because an error budget was exceeded.",
+	}, []string{"configmap"})
+)
+
+func init() {
+	prometheus.MustRegister(rolloutPercent)
+	prometheus.MustRegister(rollbacks)
+}
+
+// monitor reports the error budgets that were exceeded during a window.
+type monitor interface {
+	exceeded(ctx context.Context, window time.Duration) ([]string, error)
+}
+
+type controller struct {
+	client            corev1.ConfigMapInterface
+	monitor           monitor
+	stages            []int
+	observationPeriod time.Duration
+	stagedSuffix      string
+	now               func() time.Time
+	dryRun            bool
+}
+
+// sync advances the rollout of the staged version of a ConfigMap by at most
+// one step: it starts a rollout when the staged version changed, rolls back
+// when an error budget was exceeded during the current stage, and moves on to
+// the next stage once the current stage was observed long enough.
+func (c *controller) sync(ctx context.Context, name string) error {
+	log := logrus.WithField("configmap", name)
+	staged, err := c.client.Get(ctx, name+c.stagedSuffix, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		log.Debug("No staged version.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get staged ConfigMap: %w", err)
+	}
+	live, err := c.client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get live ConfigMap: %w", err)
+	}
+
+	version := hashData(staged)
+	log = log.WithField("version", version)
+	if live.Annotations[versionAnnotation] != version {
+		// The previous ConfigMap keeps the data of the last complete version
+		// while a rollout is in progress, even if the staged version changes
+		// again before it completes.
+		if live.Annotations[stateAnnotation] != stateProgressing {
+			if err := c.savePrevious(ctx, live); err != nil {
+				return err
+			}
+		}
+		log.Info("Starting rollout of the staged version.")
+		return c.applyStage(ctx, name, live, staged, version, 0)
+	}
+	if live.Annotations[stateAnnotation] != stateProgressing {
+		return nil
+	}
+
+	stage, err := strconv.Atoi(live.Annotations[stageAnnotation])
+	if err != nil || stage < 0 || stage >= len(c.stages) {
+		return fmt.Errorf("invalid rollout stage %q", live.Annotations[stageAnnotation])
+	}
+	started, err := time.Parse(time.RFC3339, live.Annotations[stageStartedAnnotation])
+	if err != nil {
+		return fmt.Errorf("invalid rollout stage start %q: %w", live.Annotations[stageStartedAnnotation], err)
+	}
+	window := c.now().Sub(started)
+	exceeded, err := c.monitor.exceeded(ctx, window)
+	if err != nil {
+		// Neither advance nor roll back without knowing the error budgets.
+		return fmt.Errorf("failed to check the error budgets: %w", err)
+	}
+	if len(exceeded) > 0 {
+		log.WithField("exceeded", exceeded).Warn("Error budgets were exceeded, rolling back.")
+		return c.rollback(ctx, name, live)
+	}
+	if window < c.observationPeriod {
+		return nil
+	}
+	if stage == len(c.stages)-1 {
+		log.Info("Rollout is complete.")
+		live.Annotations[stateAnnotation] = stateComplete
+		return c.update(ctx, live)
+	}
+	log.WithField("percent", c.stages[stage+1]).Info("Advancing rollout to the next stage.")
+	return c.applyStage(ctx, name, live, staged, version, stage+1)
+}
+
+func (c *controller) savePrevious(ctx context.Context, live *coreapi.ConfigMap) error {
+	previous := &coreapi.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: live.Name + previousSuffix, Namespace: live.Namespace},
+		Data:       live.Data,
+		BinaryData: live.BinaryData,
+	}
+	if c.dryRun {
+		logrus.WithField("configmap", previous.Name).Info("[dry-run] Would save the live data.")
+		return nil
+	}
+	existing, err := c.client.Get(ctx, previous.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		_, err = c.client.Create(ctx, previous, metav1.CreateOptions{})
+	case err == nil:
+		previous.ResourceVersion = existing.ResourceVersion
+		_, err = c.client.Update(ctx, previous, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save the live data to %s: %w", previous.Name, err)
+	}
+	return nil
+}
+
+func (c *controller) getPrevious(ctx context.Context, name string) (*coreapi.ConfigMap, error) {
+	previous, err := c.client.Get(ctx, name+previousSuffix, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the previous data: %w", err)
+	}
+	return previous, nil
+}
+
+func (c *controller) applyStage(ctx context.Context, name string, live, staged *coreapi.ConfigMap, version string, stage int) error {
+	previous := live
+	if !c.dryRun {
+		var err error
+		if previous, err = c.getPrevious(ctx, name); err != nil {
+			return err
+		}
+	}
+	percent := c.stages[stage]
+	data, err := mergeData(previous.Data, staged.Data, percent)
+	if err != nil {
+		return err
+	}
+	binaryData, err := mergeData(previous.BinaryData, staged.BinaryData, percent)
+	if err != nil {
+		return err
+	}
+	live.Data = data
+	live.BinaryData = binaryData
+	if live.Annotations == nil {
+		live.Annotations = map[string]string{}
+	}
+	live.Annotations[versionAnnotation] = version
+	live.Annotations[stateAnnotation] = stateProgressing
+	live.Annotations[stageAnnotation] = strconv.Itoa(stage)
+	live.Annotations[stageStartedAnnotation] = c.now().Format(time.RFC3339)
+	if err := c.update(ctx, live); err != nil {
+		return err
+	}
+	rolloutPercent.WithLabelValues(name).Set(float64(percent))
+	return nil
+}
+
+func (c *controller) rollback(ctx context.Context, name string, live *coreapi.ConfigMap) error {
+	previous, err := c.getPrevious(ctx, name)
+	if err != nil {
+		return err
+	}
+	live.Data = previous.Data
+	live.BinaryData = previous.BinaryData
+	live.Annotations[stateAnnotation] = stateRolledBack
+	delete(live.Annotations, stageAnnotation)
+	delete(live.Annotations, stageStartedAnnotation)
+	if err := c.update(ctx, live); err != nil {
+		return err
+	}
+	rolloutPercent.WithLabelValues(name).Set(0)
+	rollbacks.WithLabelValues(name).Inc()
+	return nil
+}
+
+func (c *controller) update(ctx context.Context, cm *coreapi.ConfigMap) error {
+	if c.dryRun {
+		logrus.WithField("configmap", cm.Name).WithField("annotations", cm.Annotations).Info("[dry-run] Would update the live ConfigMap.")
+		return nil
+	}
+	if _, err := c.client.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s: %w", cm.Name, err)
+	}
+	return nil
+}
+
+// mergeData returns the data of a stage. The canary is selected by repo: the
+// jobs of the repos selected for the canary percentage are taken from the
+// staged version of a job config file and the jobs of the other repos from the
+// previous version. Everything else, e.g. presets or the Prow config itself,
+// can't be split by repo and is taken from the staged version in every stage.
+func mergeData[V string | []byte](previous, staged map[string]V, percent int) (map[string]V, error) {
+	if percent >= 100 {
+		return staged, nil
+	}
+	keys := map[string]bool{}
+	for key := range previous {
+		keys[key] = true
+	}
+	for key := range staged {
+		keys[key] = true
+	}
+	merged := map[string]V{}
+	for _, key := range sortedKeys(keys) {
+		stagedValue, inStaged := staged[key]
+		value, isJobConfig, err := mergeJobConfig(string(previous[key]), string(stagedValue), percent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge the jobs of %s: %w", key, err)
+		}
+		switch {
+		case isJobConfig && (inStaged || value != ""):
+			merged[key] = V(value)
+		case !isJobConfig && inStaged:
+			merged[key] = stagedValue
+		}
+	}
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return merged, nil
+}
+
+var jobSections = []string{"presubmits", "postsubmits", "periodics"}
+
+// mergeJobConfig merges two versions of a job config file for a canary
+// percentage. It reports false if neither version holds jobs. The jobs are
+// kept as generic YAML so that no fields are lost.
+func mergeJobConfig(previous, staged string, percent int) (string, bool, error) {
+	var prev, stag map[string]interface{}
+	if yaml.Unmarshal([]byte(previous), &prev) != nil || yaml.Unmarshal([]byte(staged), &stag) != nil {
+		return "", false, nil
+	}
+	isJobConfig := false
+	for _, section := range jobSections {
+		_, inPrevious := prev[section]
+		_, inStaged := stag[section]
+		isJobConfig = isJobConfig || inPrevious || inStaged
+	}
+	if !isJobConfig {
+		return "", false, nil
+	}
+
+	merged := map[string]interface{}{}
+	for key, value := range stag {
+		merged[key] = value
+	}
+	for _, section := range []string{"presubmits", "postsubmits"} {
+		delete(merged, section)
+		prevJobs, ok := asMap(prev[section])
+		stagJobs, ok2 := asMap(stag[section])
+		if !ok || !ok2 {
+			return "", false, fmt.Errorf("%s are not keyed by repo", section)
+		}
+		jobs := map[string]interface{}{}
+		for repo, repoJobs := range prevJobs {
+			if !inCanary(repo, percent) {
+				jobs[repo] = repoJobs
+			}
+		}
+		for repo, repoJobs := range stagJobs {
+			if inCanary(repo, percent) {
+				jobs[repo] = repoJobs
+			}
+		}
+		if len(jobs) > 0 {
+			merged[section] = jobs
+		}
+	}
+	delete(merged, "periodics")
+	prevPeriodics, ok := asList(prev["periodics"])
+	stagPeriodics, ok2 := asList(stag["periodics"])
+	if !ok || !ok2 {
+		return "", false, errors.New("periodics are not a list")
+	}
+	var periodics []interface{}
+	for _, periodic := range prevPeriodics {
+		if !inCanary(periodicRepo(periodic), percent) {
+			periodics = append(periodics, periodic)
+		}
+	}
+	for _, periodic := range stagPeriodics {
+		if inCanary(periodicRepo(periodic), percent) {
+			periodics = append(periodics, periodic)
+		}
+	}
+	if len(periodics) > 0 {
+		merged["periodics"] = periodics
+	}
+
+	if len(merged) == 0 {
+		return "", true, nil
+	}
+	raw, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", true, err
+	}
+	return string(raw), true, nil
+}
+
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+	m, ok := value.(map[string]interface{})
+	return m, ok
+}
+
+func asList(value interface{}) ([]interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+	l, ok := value.([]interface{})
+	return l, ok
+}
+
+// periodicRepo returns the repo a periodic is canaried with: the repo of its
+// first extra ref, or its name if it has none.
+func periodicRepo(periodic interface{}) string {
+	job, _ := periodic.(map[string]interface{})
+	if refs, _ := job["extra_refs"].([]interface{}); len(refs) > 0 {
+		if ref, ok := refs[0].(map[string]interface{}); ok {
+			return fmt.Sprintf("%v/%v", ref["org"], ref["repo"])
+		}
+	}
+	name, _ := job["name"].(string)
+	return name
+}
+
+// inCanary selects a stable subset of the repos for a percentage: a repo that
+// is selected for a percentage is selected for all higher percentages.
+func inCanary(repo string, percent int) bool {
+	h := fnv.New32a()
+	h.Write([]byte(repo))
+	return int(h.Sum32()%100) < percent
+}
+
+// hashData identifies the version of the data of a ConfigMap.
+func hashData(cm *coreapi.ConfigMap) string {
+	h := sha256.New()
+	for _, key := range sortedKeys(cm.Data) {
+		fmt.Fprintf(h, "%s\x00%s\x00", key, cm.Data[key])
+	}
+	for _, key := range sortedKeys(cm.BinaryData) {
+		fmt.Fprintf(h, "%s\x00%s\x00", key, cm.BinaryData[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// errorBudget is the maximum value a PromQL ratio may reach during a stage.
+type errorBudget struct {
+	name  string
+	query string
+	max   float64
+}
+
+type querier interface {
+	query(ctx context.Context, query string) (float64, error)
+}
+
+type budgetMonitor struct {
+	querier querier
+	budgets []errorBudget
+}
+
+// exceeded evaluates the queries of the budgets over the window. The range of
+// the window is substituted for the %[1]s verbs of the queries.
+func (m *budgetMonitor) exceeded(ctx context.Context, window time.Duration) ([]string, error) {
+	// Prometheus needs at least a few scrapes to compute an increase.
+	if window < time.Minute {
+		window = time.Minute
+	}
+	rangeSelector := fmt.Sprintf("%ds", int(window.Seconds()))
+	var exceeded []string
+	for _, budget := range m.budgets {
+		value, err := m.querier.query(ctx, fmt.Sprintf(budget.query, rangeSelector))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate the %s budget: %w", budget.name, err)
+		}
+		// A ratio without events in the window is NaN.
+		if !math.IsNaN(value) && value > budget.max {
+			exceeded = append(exceeded, fmt.Sprintf("%s: %.3f > %.3f", budget.name, value, budget.max))
+		}
+	}
+	return exceeded, nil
+}
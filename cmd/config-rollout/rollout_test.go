@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+type fakeMonitor struct {
+	exceededBudgets []string
+	err             error
+}
+
+func (f *fakeMonitor) exceeded(context.Context, time.Duration) ([]string, error) {
+	return f.exceededBudgets, f.err
+}
+
+func configMap(name string, data map[string]string) *coreapi.ConfigMap {
+	return &coreapi.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Data: data}
+}
+
+// repos returns two repos of which exactly one is in the canary of 50%.
+func repos() (canary, other string) {
+	for i := 0; canary == "" || other == ""; i++ {
+		repo := fmt.Sprintf("org/repo-%d", i)
+		if inCanary(repo, 50) {
+			canary = repo
+		} else {
+			other = repo
+		}
+	}
+	return canary, other
+}
+
+// jobConfig returns a job config file with a presubmit of the given version
+// for each of the repos, formatted like the merged job config files.
+func jobConfig(t *testing.T, versions map[string]string) string {
+	presubmits := map[string]interface{}{}
+	for repo, version := range versions {
+		presubmits[repo] = []interface{}{map[string]interface{}{"name": "test-" + version}}
+	}
+	raw, err := yaml.Marshal(map[string]interface{}{"presubmits": presubmits})
+	if err != nil {
+		t.Fatalf("failed to marshal job config: %v", err)
+	}
+	return string(raw)
+}
+
+func TestSync(t *testing.T) {
+	canary, other := repos()
+	data := func(canaryVersion, otherVersion string) map[string]string {
+		return map[string]string{"jobs.yaml": jobConfig(t, map[string]string{canary: canaryVersion, other: otherVersion})}
+	}
+	oldData := data("old", "old")
+	newData := data("new", "new")
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(configMap("job-config", oldData), configMap("job-config-staged", newData)).CoreV1().ConfigMaps("default")
+	monitor := &fakeMonitor{}
+	c := &controller{
+		client:            client,
+		monitor:           monitor,
+		stages:            []int{50, 100},
+		observationPeriod: 10 * time.Minute,
+		stagedSuffix:      "-staged",
+		now:               func() time.Time { return now },
+	}
+	sync := func(expectedData map[string]string, expectedState string) {
+		t.Helper()
+		if err := c.sync(context.Background(), "job-config"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		live, err := client.Get(context.Background(), "job-config", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get live ConfigMap: %v", err)
+		}
+		if diff := cmp.Diff(expectedData, live.Data); diff != "" {
+			t.Errorf("live data differs from expected (-want +got):\n%s", diff)
+		}
+		if state := live.Annotations[stateAnnotation]; state != expectedState {
+			t.Errorf("expected state %q, got %q", expectedState, state)
+		}
+	}
+
+	// The first stage rolls the staged version out to the canary repos.
+	sync(data("new", "old"), stateProgressing)
+	// The stage is observed for the observation period.
+	now = now.Add(5 * time.Minute)
+	sync(data("new", "old"), stateProgressing)
+	now = now.Add(5 * time.Minute)
+	sync(newData, stateProgressing)
+	now = now.Add(10 * time.Minute)
+	sync(newData, stateComplete)
+
+	// A new staged version that exceeds the error budgets is rolled back to
+	// the last complete version.
+	if _, err := client.Update(context.Background(), configMap("job-config-staged", data("broken", "broken")), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update staged ConfigMap: %v", err)
+	}
+	sync(data("broken", "new"), stateProgressing)
+	monitor.exceededBudgets = []string{"job error rate: 0.5 > 0.1"}
+	now = now.Add(time.Minute)
+	sync(newData, stateRolledBack)
+	// The rolled back version is not retried.
+	monitor.exceededBudgets = nil
+	sync(newData, stateRolledBack)
+
+	// Without the error budgets, the rollout neither advances nor rolls back.
+	if _, err := client.Update(context.Background(), configMap("job-config-staged", data("fixed", "fixed")), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update staged ConfigMap: %v", err)
+	}
+	sync(data("fixed", "new"), stateProgressing)
+	monitor.err = errors.New("prometheus is down")
+	now = now.Add(time.Hour)
+	if err := c.sync(context.Background(), "job-config"); err == nil {
+		t.Error("expected an error without the error budgets")
+	}
+	monitor.err = nil
+	sync(data("fixed", "fixed"), stateProgressing)
+}
+
+func TestMergeData(t *testing.T) {
+	canary, other := repos()
+	periodics := func(canaryVersion, otherVersion string) string {
+		return fmt.Sprintf(`periodics:
+- extra_refs:
+  - org: %s
+    repo: %s
+  name: other-%s
+- extra_refs:
+  - org: %s
+    repo: %s
+  name: canary-%s
+`, strings.Split(other, "/")[0], strings.Split(other, "/")[1], otherVersion, strings.Split(canary, "/")[0], strings.Split(canary, "/")[1], canaryVersion)
+	}
+	testCases := []struct {
+		name     string
+		previous map[string]string
+		staged   map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "jobs are selected by repo",
+			previous: map[string]string{"jobs.yaml": jobConfig(t, map[string]string{canary: "old", other: "old"})},
+			staged:   map[string]string{"jobs.yaml": jobConfig(t, map[string]string{canary: "new", other: "new"})},
+			expected: map[string]string{"jobs.yaml": jobConfig(t, map[string]string{canary: "new", other: "old"})},
+		},
+		{
+			name:     "periodics are selected by the repo of their first extra ref",
+			previous: map[string]string{"periodics.yaml": periodics("old", "old")},
+			staged:   map[string]string{"periodics.yaml": periodics("new", "new")},
+			expected: map[string]string{"periodics.yaml": periodics("new", "old")},
+		},
+		{
+			name:     "jobs of a removed file are kept for the other repos",
+			previous: map[string]string{"jobs.yaml": jobConfig(t, map[string]string{canary: "old", other: "old"})},
+			expected: map[string]string{"jobs.yaml": jobConfig(t, map[string]string{other: "old"})},
+		},
+		{
+			name:     "other values are taken from the staged version",
+			previous: map[string]string{"config.yaml": "tide:\n  sync_period: 1m\n", "removed.yaml": "plank: {}\n"},
+			staged:   map[string]string{"config.yaml": "tide:\n  sync_period: 2m\n"},
+			expected: map[string]string{"config.yaml": "tide:\n  sync_period: 2m\n"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := mergeData(tc.previous, tc.staged, 50)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, merged); diff != "" {
+				t.Errorf("merged data differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncWithoutStagedVersion(t *testing.T) {
+	client := fake.NewSimpleClientset(configMap("config", map[string]string{"config.yaml": "old"})).CoreV1().ConfigMaps("default")
+	c := &controller{client: client, monitor: &fakeMonitor{}, stages: []int{100}, stagedSuffix: "-staged", now: time.Now}
+	if err := c.sync(context.Background(), "config"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	live, err := client.Get(context.Background(), "config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get live ConfigMap: %v", err)
+	}
+	if len(live.Annotations) != 0 {
+		t.Errorf("expected the live ConfigMap to be untouched, got annotations %v", live.Annotations)
+	}
+}
+
+func TestInCanary(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		for percent := 1; percent < 100; percent++ {
+			if inCanary(key, percent) && !inCanary(key, percent+1) {
+				t.Fatalf("key %s is in the canary of %d%% but not of %d%%", key, percent, percent+1)
+			}
+		}
+		if !inCanary(key, 100) {
+			t.Fatalf("key %s is not in the canary of 100%%", key)
+		}
+	}
+}
+
+func TestParseStages(t *testing.T) {
+	testCases := []struct {
+		raw         string
+		expected    []int
+		expectedErr bool
+	}{
+		{raw: "10,50,100", expected: []int{10, 50, 100}},
+		{raw: "100", expected: []int{100}},
+		{raw: "10,50", expectedErr: true},
+		{raw: "50,10,100", expectedErr: true},
+		{raw: "0,100", expectedErr: true},
+		{raw: "ten,100", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			stages, err := parseStages(tc.raw)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expected, stages); diff != "" {
+				t.Errorf("stages differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeQuerier map[string]float64
+
+func (f fakeQuerier) query(_ context.Context, query string) (float64, error) {
+	value, ok := f[query]
+	if !ok {
+		return 0, fmt.Errorf("unexpected query %q", query)
+	}
+	return value, nil
+}
+
+func TestBudgetMonitor(t *testing.T) {
+	m := &budgetMonitor{
+		querier: fakeQuerier{
+			"errors[60s]":  0.2,
+			"idle[60s]":    math.NaN(),
+			"healthy[60s]": 0.01,
+		},
+		budgets: []errorBudget{
+			{name: "errors", query: "errors[%[1]s]", max: 0.1},
+			{name: "idle", query: "idle[%[1]s]", max: 0.1},
+			{name: "healthy", query: "healthy[%[1]s]", max: 0.1},
+		},
+	}
+	exceeded, err := m.exceeded(context.Background(), 10*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"errors: 0.200 > 0.100"}, exceeded); diff != "" {
+		t.Errorf("exceeded budgets differ from expected (-want +got):\n%s", diff)
+	}
+}
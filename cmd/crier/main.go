@@ -47,6 +47,9 @@ import (
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/readiness"
+	"sigs.k8s.io/prow/pkg/scm"
 	slackclient "sigs.k8s.io/prow/pkg/slack"
 )
 
@@ -56,16 +59,18 @@ type options struct {
 	github           prowflagutil.GitHubOptions
 	githubEnablement prowflagutil.GitHubEnablementOptions
 	gerrit           prowflagutil.GerritOptions
+	bitbucketServer  prowflagutil.BitbucketServerOptions
 
 	config configflagutil.ConfigOptions
 
-	gerritWorkers         int
-	pubsubWorkers         int
-	githubWorkers         int
-	slackWorkers          int
-	blobStorageWorkers    int
-	k8sBlobStorageWorkers int
-	resultStoreWorkers    int
+	gerritWorkers          int
+	pubsubWorkers          int
+	githubWorkers          int
+	bitbucketServerWorkers int
+	slackWorkers           int
+	blobStorageWorkers     int
+	k8sBlobStorageWorkers  int
+	resultStoreWorkers     int
 
 	slackTokenFile            string
 	additionalSlackTokenFiles slackclient.HostsFlag
@@ -83,7 +88,7 @@ type options struct {
 }
 
 func (o *options) validate() error {
-	if o.gerritWorkers+o.pubsubWorkers+o.githubWorkers+o.slackWorkers+o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers <= 0 {
+	if o.gerritWorkers+o.pubsubWorkers+o.githubWorkers+o.bitbucketServerWorkers+o.slackWorkers+o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers <= 0 {
 		return errors.New("crier need to have at least one report worker to start")
 	}
 
@@ -106,6 +111,15 @@ func (o *options) validate() error {
 		}
 	}
 
+	if o.bitbucketServerWorkers > 0 {
+		if !o.bitbucketServer.Enabled() {
+			return errors.New("--bitbucket-server-token-path is required with --bitbucket-server-workers")
+		}
+		if err := o.bitbucketServer.Validate(o.dryrun); err != nil {
+			return err
+		}
+	}
+
 	if o.slackWorkers > 0 {
 		if o.slackTokenFile == "" && len(o.additionalSlackTokenFiles) == 0 {
 			return errors.New("one of --slack-token-file or --additional-slack-token-files must be set")
@@ -126,6 +140,7 @@ func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	fs.IntVar(&o.gerritWorkers, "gerrit-workers", 0, "Number of gerrit report workers (0 means disabled)")
 	fs.IntVar(&o.pubsubWorkers, "pubsub-workers", 0, "Number of pubsub report workers (0 means disabled)")
 	fs.IntVar(&o.githubWorkers, "github-workers", 0, "Number of github report workers (0 means disabled)")
+	fs.IntVar(&o.bitbucketServerWorkers, "bitbucket-server-workers", 0, "Number of Bitbucket Server report workers (0 means disabled)")
 	fs.IntVar(&o.slackWorkers, "slack-workers", 0, "Number of Slack report workers (0 means disabled)")
 	fs.Var(&o.additionalSlackTokenFiles, "additional-slack-token-files", "Map of additional slack token files. example: --additional-slack-token-files=foo=/etc/foo-slack-tokens/token, repeat flag for each host")
 	fs.IntVar(&o.blobStorageWorkers, "blob-storage-workers", 0, "Number of blob storage report workers (0 means disabled)")
@@ -142,6 +157,7 @@ func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	o.config.AddFlags(fs)
 	o.github.AddFlags(fs)
 	o.gerrit.AddFlags(fs)
+	o.bitbucketServer.AddFlags(fs)
 	o.client.AddFlags(fs)
 	o.storage.AddFlags(fs)
 	o.instrumentationOptions.AddFlags(fs)
@@ -168,6 +184,7 @@ func main() {
 	o := parseOptions()
 
 	pprof.Instrument(o.instrumentationOptions)
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
 
 	configAgent, err := o.config.ConfigAgent()
 	if err != nil {
@@ -246,9 +263,26 @@ func main() {
 		}
 	}
 
+	var opener io.Opener
+	if o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers > 0 {
+		opener, err = o.storage.StorageClient(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener")
+		}
+	}
+
 	if o.pubsubWorkers > 0 {
 		hasReporter = true
-		if err := crier.New(mgr, pubsubreporter.NewReporter(cfg), o.pubsubWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+		pubsubOpener := opener
+		if pubsubOpener == nil {
+			// The opener is only used to list the artifacts of schema version 2
+			// messages, which fall back to the default artifact locations.
+			if pubsubOpener, err = o.storage.StorageClient(context.Background()); err != nil {
+				logrus.WithError(err).Warn("Error creating opener, pubsub reporter won't list artifacts")
+				pubsubOpener = nil
+			}
+		}
+		if err := crier.New(mgr, pubsubreporter.NewReporterWithOpener(cfg, pubsubOpener), o.pubsubWorkers, o.githubEnablement.EnablementChecker()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct pubsub reporter controller")
 		}
 	}
@@ -264,6 +298,13 @@ func main() {
 		if err != nil {
 			logrus.WithError(err).Fatal("Error getting GitHub client.")
 		}
+		if policy := cfg().GitHubOptions.ThrottlePolicy("crier"); policy != nil {
+			if err := githubClient.ApplyThrottlePolicy(*policy); err != nil {
+				logrus.WithError(err).Fatal("Error throttling GitHub client.")
+			}
+		}
+
+		readiness.Register("github", readiness.GitHub(githubClient))
 
 		hasReporter = true
 		githubReporter := githubreporter.NewReporter(githubClient, cfg, prowapi.ProwJobAgent(o.reportAgent), mgr.GetCache())
@@ -272,11 +313,16 @@ func main() {
 		}
 	}
 
-	var opener io.Opener
-	if o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers > 0 {
-		opener, err = o.storage.StorageClient(context.Background())
+	if o.bitbucketServerWorkers > 0 {
+		provider, err := o.bitbucketServer.Provider(o.dryrun, nil)
 		if err != nil {
-			logrus.WithError(err).Fatal("Error creating opener")
+			logrus.WithError(err).Fatal("Error getting Bitbucket Server client.")
+		}
+
+		hasReporter = true
+		bitbucketServerReporter := githubreporter.NewSCMReporter(provider.Name(), scm.NewGitHubClient(provider, nil), cfg, prowapi.ProwJobAgent(o.reportAgent), mgr.GetCache())
+		if err := crier.New(mgr, bitbucketServerReporter, o.bitbucketServerWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+			logrus.WithError(err).Fatal("failed to construct bitbucket server reporter controller")
 		}
 	}
 
@@ -317,6 +363,12 @@ func main() {
 		logrus.Fatalf("should have at least one controller to start crier.")
 	}
 
+	// Only report ready once the config is loaded, the informers are synced
+	// and the dependencies were reachable.
+	readiness.Register("config", readiness.Config(cfg))
+	readiness.Register("informers", readiness.CacheSynced(mgr.GetCache()))
+	health.ServeReady()
+
 	// Push metrics to the configured prometheus pushgateway endpoint or serve them
 	metrics.ExposeMetrics("crier", cfg().PushGateway, o.instrumentationOptions.MetricsPort)
 
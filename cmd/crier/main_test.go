@@ -46,6 +46,15 @@ func TestOptions(t *testing.T) {
 			name: "config-path is empty string, reject",
 			args: []string{"--pubsub-workers=1", "--config-path="},
 		},
+		//Bitbucket Server Reporter
+		{
+			name: "bitbucket server missing --bitbucket-server-token-path, rejects",
+			args: []string{"--bitbucket-server-workers=1", "--config-path=foo"},
+		},
+		{
+			name: "bitbucket server with invalid --bitbucket-server-endpoint, rejects",
+			args: []string{"--bitbucket-server-workers=1", "--bitbucket-server-token-path=tkpath", "--bitbucket-server-endpoint=bitbucket", "--config-path=foo"},
+		},
 		//Gerrit Reporter
 		{
 			name: "gerrit supports multiple workers",
@@ -21,18 +21,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/oidcauth"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
-func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *githuboauth.Agent, oa *oidcauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.TODO()
 		name := r.URL.Query().Get("prowjob")
@@ -59,7 +63,7 @@ func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *
 			}
 			// Using same permission validation as rerun, could be future work to add validation
 			// unique to Abort
-			allowed, user, err, code := isAllowedToRerun(r, cfg, goa, ghc, *pj, cli, pluginAgent, l)
+			allowed, user, err, code := isAllowedToRerun(r, cfg, goa, oa, ghc, *pj, cli, pluginAgent, l)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Could not verify if allowed to abort: %v.", err), code)
 				l.WithError(err).Debug("Could not verify if allowed to abort.")
@@ -78,18 +82,10 @@ func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *
 			} else {
 				abortDescription = fmt.Sprintf("Successfully aborted %v.", name)
 			}
-			pj.Status.State = prowapi.AbortedState
-			pj.Status.Description = abortDescription
-			jsonPJ, err := json.Marshal(pj)
+			pj, err := abortProwJob(ctx, prowJobClient, pj, abortDescription)
 			if err != nil {
-				http.Error(w, fmt.Sprintf("Error marshal source job: %v.", err), http.StatusInternalServerError)
-				l.WithError(err).Errorf("Error marshal source job.")
-				return
-			}
-			pj, err := prowJobClient.Patch(ctx, pj.Name, ktypes.MergePatchType, jsonPJ, metav1.PatchOptions{})
-			if err != nil {
-				http.Error(w, fmt.Sprintf("Could not patch aborted job: %v.", err), http.StatusInternalServerError)
-				l.WithError(err).Errorf("Could not patch aborted job.")
+				http.Error(w, fmt.Sprintf("Could not abort job: %v.", err), http.StatusInternalServerError)
+				l.WithError(err).Errorf("Could not abort job.")
 				return
 			}
 			l.Info(abortDescription)
@@ -103,3 +99,94 @@ func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *
 		}
 	}
 }
+
+// abortProwJob marks the job as aborted with the given description.
+func abortProwJob(ctx context.Context, prowJobClient prowv1.ProwJobInterface, pj *prowapi.ProwJob, description string) (*prowapi.ProwJob, error) {
+	pj.Status.State = prowapi.AbortedState
+	pj.Status.Description = description
+	pj.Status.FailureReason = prowapi.AbortedFailure
+	pj.Status.FailureMessage = description
+	jsonPJ, err := json.Marshal(pj)
+	if err != nil {
+		return nil, fmt.Errorf("error marshal source job: %w", err)
+	}
+	return prowJobClient.Patch(ctx, pj.Name, ktypes.MergePatchType, jsonPJ, metav1.PatchOptions{})
+}
+
+// handleAbortPR aborts all triggered and pending jobs of a pull request that
+// the user is allowed to abort. If a SHA is given, only the jobs for that SHA
+// are aborted.
+func handleAbortPR(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *githuboauth.Agent, oa *oidcauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.TODO()
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		org, repo, sha := r.URL.Query().Get("org"), r.URL.Query().Get("repo"), r.URL.Query().Get("sha")
+		number, err := strconv.Atoi(r.URL.Query().Get("pr"))
+		if org == "" || repo == "" || err != nil {
+			http.Error(w, "Request did not provide the 'org', 'repo' and 'pr' query parameters.", http.StatusBadRequest)
+			return
+		}
+		l := log.WithFields(logrus.Fields{"org": org, "repo": repo, "pr": number, "sha": sha})
+		selector := labels.SelectorFromSet(labels.Set{
+			kube.OrgLabel:  org,
+			kube.RepoLabel: repo,
+			kube.PullLabel: strconv.Itoa(number),
+		})
+		pjs, err := prowJobClient.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not list jobs: %v.", err), http.StatusInternalServerError)
+			l.WithError(err).Error("Could not list jobs.")
+			return
+		}
+
+		var aborted, denied int
+		for i := range pjs.Items {
+			pj := &pjs.Items[i]
+			if pj.Status.State != prowapi.TriggeredState && pj.Status.State != prowapi.PendingState {
+				continue
+			}
+			if pj.Spec.Refs == nil || len(pj.Spec.Refs.Pulls) == 0 || (sha != "" && pj.Spec.Refs.Pulls[0].SHA != sha) {
+				continue
+			}
+			jl := l.WithField("prowjob", pj.Name)
+			allowed, user, err, code := isAllowedToRerun(r, cfg, goa, oa, ghc, *pj, cli, pluginAgent, jl)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Could not verify if allowed to abort: %v.", err), code)
+				jl.WithError(err).Debug("Could not verify if allowed to abort.")
+				return
+			}
+			if !allowed {
+				denied++
+				continue
+			}
+			var abortDescription string
+			if len(user) > 0 {
+				abortDescription = fmt.Sprintf("%v aborted all jobs of PR #%d.", user, number)
+			} else {
+				abortDescription = fmt.Sprintf("Aborted all jobs of PR #%d.", number)
+			}
+			if _, err := abortProwJob(ctx, prowJobClient, pj, abortDescription); err != nil {
+				http.Error(w, fmt.Sprintf("Could not abort job %s: %v.", pj.Name, err), http.StatusInternalServerError)
+				jl.WithError(err).Error("Could not abort job.")
+				return
+			}
+			jl.Info(abortDescription)
+			aborted++
+		}
+		l.WithFields(logrus.Fields{"aborted": aborted, "denied": denied}).Info("Attempted to abort all jobs of PR")
+		if aborted == 0 && denied > 0 {
+			http.Error(w, "You don't have permission to abort the jobs of this PR.", http.StatusUnauthorized)
+			return
+		}
+		message := fmt.Sprintf("Aborted %d jobs.", aborted)
+		if denied > 0 {
+			message = fmt.Sprintf("Aborted %d jobs, you don't have permission to abort %d more.", aborted, denied)
+		}
+		if _, err = w.Write([]byte(message)); err != nil {
+			l.WithError(err).Debug("Error writing to abort response.")
+		}
+	}
+}
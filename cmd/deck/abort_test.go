@@ -24,14 +24,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/gorilla/sessions"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
 	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
@@ -151,7 +155,7 @@ func TestAbort(t *testing.T) {
 					State: tc.jobState,
 				},
 			})
-			authCfgGetter := func(refs *prowapi.ProwJobSpec) *prowapi.RerunAuthConfig {
+			authCfgGetter := func(*prowapi.ProwJob) *prowapi.RerunAuthConfig {
 				return &prowapi.RerunAuthConfig{
 					AllowAnyone: tc.allowAnyone,
 					GitHubUsers: tc.authorized,
@@ -185,7 +189,7 @@ func TestAbort(t *testing.T) {
 			rc := fakegithub.NewFakeClient()
 			rc.OrgMembers = map[string][]string{"org": {"org-member"}}
 			pca := plugins.NewFakeConfigAgent()
-			handler := handleAbort(fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/abort"))
+			handler := handleAbort(fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), authCfgGetter, goa, nil, ghc, rc, &pca, logrus.WithField("handler", "/abort"))
 			handler.ServeHTTP(rr, req)
 			if rr.Code != tc.httpCode {
 				t.Fatalf("Bad error code: %d", rr.Code)
@@ -213,3 +217,119 @@ func TestAbort(t *testing.T) {
 		})
 	}
 }
+
+func TestAbortPR(t *testing.T) {
+	job := func(name, sha string, state prowapi.ProwJobState, authorized ...string) runtime.Object {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "prowjobs",
+				Labels:    map[string]string{kube.OrgLabel: "org", kube.RepoLabel: "repo", kube.PullLabel: "1"},
+			},
+			Spec: prowapi.ProwJobSpec{
+				Job:  name,
+				Type: prowapi.PresubmitJob,
+				Refs: &prowapi.Refs{
+					Org:   "org",
+					Repo:  "repo",
+					Pulls: []prowapi.Pull{{Number: 1, SHA: sha}},
+				},
+				RerunAuthConfig: &prowapi.RerunAuthConfig{GitHubUsers: authorized},
+			},
+			Status: prowapi.ProwJobStatus{State: state},
+		}
+	}
+	testCases := []struct {
+		name            string
+		query           string
+		login           string
+		httpCode        int
+		expectedAborted []string
+	}{
+		{
+			name:            "abort all jobs of the PR",
+			query:           "org=org&repo=repo&pr=1",
+			login:           "authorized",
+			httpCode:        http.StatusOK,
+			expectedAborted: []string{"old-pending", "new-pending", "new-triggered"},
+		},
+		{
+			name:            "abort jobs for a SHA",
+			query:           "org=org&repo=repo&pr=1&sha=old",
+			login:           "authorized",
+			httpCode:        http.StatusOK,
+			expectedAborted: []string{"old-pending"},
+		},
+		{
+			name:            "only abort the jobs the user is allowed to abort",
+			query:           "org=org&repo=repo&pr=1",
+			login:           "sig-lead",
+			httpCode:        http.StatusOK,
+			expectedAborted: []string{"new-triggered"},
+		},
+		{
+			name:     "user not allowed to abort any job",
+			query:    "org=org&repo=repo&pr=1",
+			login:    "random-dude",
+			httpCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing PR",
+			query:    "org=org&repo=repo",
+			login:    "authorized",
+			httpCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeProwJobClient := fake.NewSimpleClientset(
+				job("old-pending", "old", prowapi.PendingState, "authorized"),
+				job("old-success", "old", prowapi.SuccessState, "authorized"),
+				job("new-pending", "new", prowapi.PendingState, "authorized"),
+				job("new-triggered", "new", prowapi.TriggeredState, "authorized", "sig-lead"),
+			)
+			authCfgGetter := func(*prowapi.ProwJob) *prowapi.RerunAuthConfig {
+				return &prowapi.RerunAuthConfig{}
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/abort-pr?"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("Error making request: %v", err)
+			}
+			mockCookieStore := sessions.NewCookieStore([]byte("secret-key"))
+			session, err := sessions.GetRegistry(req).Get(mockCookieStore, "access-token-session")
+			if err != nil {
+				t.Fatalf("Error making access token session: %v", err)
+			}
+			session.Values["access-token"] = &oauth2.Token{AccessToken: "validtoken"}
+
+			rr := httptest.NewRecorder()
+			goa := githuboauth.NewAgent(&githuboauth.Config{CookieStore: mockCookieStore}, &logrus.Entry{})
+			ghc := &fakeAuthenticatedUserIdentifier{login: tc.login}
+			pca := plugins.NewFakeConfigAgent()
+			handler := handleAbortPR(fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), authCfgGetter, goa, nil, ghc, fakegithub.NewFakeClient(), &pca, logrus.WithField("handler", "/abort-pr"))
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.httpCode {
+				t.Fatalf("Bad error code: %d: %s", rr.Code, rr.Body.String())
+			}
+
+			pjs, err := fakeProwJobClient.ProwV1().ProwJobs("prowjobs").List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list jobs: %v", err)
+			}
+			var aborted []string
+			for _, pj := range pjs.Items {
+				if pj.Status.State == prowapi.AbortedState {
+					aborted = append(aborted, pj.Name)
+					if expected := fmt.Sprintf("%s aborted all jobs of PR #1.", tc.login); pj.Status.Description != expected {
+						t.Errorf("Wrong description, expected %q, got %q", expected, pj.Status.Description)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.expectedAborted, aborted, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("aborted jobs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
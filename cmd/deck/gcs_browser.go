@@ -0,0 +1,336 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/oidcauth"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const gcsBrowserPrefix = "/gcs-browser/"
+
+// buildIDSegment matches the path segments that can be the directory of a
+// job run, which holds the prowjob.json the authorization is based on.
+var buildIDSegment = regexp.MustCompile(`^[0-9]+$`)
+
+type gcsBrowserTemplate struct {
+	// Path is the browsed path, e.g. gs/bucket/logs/job/.
+	Path    string
+	Parent  string
+	Entries []gcsBrowserEntry
+}
+
+type gcsBrowserEntry struct {
+	Name  string
+	Link  string
+	IsDir bool
+	Size  int64
+}
+
+// handleGCSBrowser serves the artifacts in the storage buckets Deck has
+// access to. The url must look like this:
+//
+// /gcs-browser/<storage-provider>/<bucket-name>/<path>
+//
+// Paths ending in a slash are listed as directories, all other paths are
+// served as files and support range requests. Users need to be allowed to
+// rerun the job that uploaded the artifacts, paths outside of a job run are
+// authorized with the default rerun auth config.
+func handleGCSBrowser(o options, cfg config.Getter, prowJobClient prowv1.ProwJobInterface, opener io.Opener, acfg authCfgGetter, goa *githuboauth.Agent, oa *oidcauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		setHeadersNoCaching(w)
+		src := strings.TrimPrefix(r.URL.Path, gcsBrowserPrefix)
+		l := log.WithField("path", src)
+		if err := validateStoragePath(cfg, src); err != nil {
+			http.Error(w, err.Error(), httpStatusForError(err))
+			return
+		}
+		storageProvider, rest, _ := strings.Cut(src, "/")
+		storagePath := storageProvider + "://" + rest
+
+		pj, err := gcsBrowserProwJob(r.Context(), cfg, prowJobClient, opener, storagePath)
+		if err != nil {
+			l.WithError(err).Warn("Failed to read the ProwJob of the artifacts.")
+			http.Error(w, "Failed to read the ProwJob of the artifacts.", http.StatusInternalServerError)
+			return
+		}
+		allowed, _, err, code := isAllowedToRerun(r, acfg, goa, oa, ghc, *pj, cli, pluginAgent, l)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not verify if allowed to view the artifacts: %v.", err), code)
+			l.WithError(err).Debug("Could not verify if allowed to view the artifacts.")
+			return
+		}
+		if !allowed {
+			http.Error(w, "You don't have permission to view these artifacts.", http.StatusUnauthorized)
+			return
+		}
+
+		if strings.HasSuffix(src, "/") || strings.Count(src, "/") < 2 {
+			tmpl, err := listGCSBrowserDir(r.Context(), opener, strings.TrimSuffix(src, "/")+"/")
+			if err != nil {
+				l.WithError(err).Warn("Failed to list artifacts.")
+				http.Error(w, "Failed to list artifacts.", http.StatusInternalServerError)
+				return
+			}
+			handleSimpleTemplate(o, cfg, "gcs-browser.html", tmpl)(w, r)
+			return
+		}
+		serveGCSBrowserFile(w, r, opener, storagePath, l)
+	}
+}
+
+// gcsBrowserProwJob returns the ProwJob of the job run the storage path
+// belongs to, or an empty ProwJob if the path is not part of a job run.
+// Jobs can write to the bucket, so the prowjob.json of the run is only used
+// to find the ProwJob in the cluster. Once that was garbage collected, the
+// uploaded ProwJob is used with the rerun auth config of the job config.
+func gcsBrowserProwJob(ctx context.Context, cfg config.Getter, prowJobClient prowv1.ProwJobInterface, opener io.Opener, storagePath string) (*prowapi.ProwJob, error) {
+	provider, rest, _ := strings.Cut(storagePath, "://")
+	segments := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+	// The first segment is the bucket, the deepest run directory is checked
+	// first as pull request numbers are part of the path of presubmits.
+	for i := len(segments) - 1; i > 0; i-- {
+		if !buildIDSegment.MatchString(segments[i]) {
+			continue
+		}
+		key := fmt.Sprintf("%s://%s", provider, path.Join(append(segments[:i+1:i+1], prowapi.ProwJobFile)...))
+		content, err := io.ReadContent(ctx, logrus.WithField("path", key), opener, key)
+		if err != nil {
+			if io.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		var pj prowapi.ProwJob
+		if err := json.Unmarshal(content, &pj); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+		}
+		if pj.Name != "" {
+			clusterPJ, err := prowJobClient.Get(ctx, pj.Name, metav1.GetOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get ProwJob %s: %w", pj.Name, err)
+			}
+			if err == nil {
+				if clusterPJ.Spec.Job != pj.Spec.Job || clusterPJ.Status.BuildID != segments[i] {
+					return nil, fmt.Errorf("%s names ProwJob %s of another job run", key, pj.Name)
+				}
+				return clusterPJ, nil
+			}
+		}
+		pj.Spec.RerunAuthConfig = jobRerunAuthConfig(cfg(), pj.Spec)
+		return &pj, nil
+	}
+	return &prowapi.ProwJob{}, nil
+}
+
+// jobRerunAuthConfig returns the rerun auth config of the job in the job
+// config, or nil if the job is not configured (anymore).
+func jobRerunAuthConfig(c *config.Config, spec prowapi.ProwJobSpec) *prowapi.RerunAuthConfig {
+	switch spec.Type {
+	case prowapi.PresubmitJob, prowapi.BatchJob:
+		if spec.Refs == nil {
+			return nil
+		}
+		for _, p := range c.GetPresubmitsStatic(spec.Refs.OrgRepoString()) {
+			if p.Name == spec.Job {
+				return p.RerunAuthConfig
+			}
+		}
+	case prowapi.PostsubmitJob:
+		if spec.Refs == nil {
+			return nil
+		}
+		for _, p := range c.GetPostsubmitsStatic(spec.Refs.OrgRepoString()) {
+			if p.Name == spec.Job {
+				return p.RerunAuthConfig
+			}
+		}
+	case prowapi.PeriodicJob:
+		for _, p := range c.AllPeriodics() {
+			if p.Name == spec.Job {
+				return p.RerunAuthConfig
+			}
+		}
+	}
+	return nil
+}
+
+func listGCSBrowserDir(ctx context.Context, opener io.Opener, src string) (*gcsBrowserTemplate, error) {
+	storageProvider, rest, _ := strings.Cut(src, "/")
+	bucket, _, _ := strings.Cut(rest, "/")
+	it, err := opener.Iterator(ctx, storageProvider+"://"+rest, "/")
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &gcsBrowserTemplate{Path: src}
+	if parent := path.Dir(strings.TrimSuffix(src, "/")); strings.Contains(parent, "/") {
+		tmpl.Parent = gcsBrowserPrefix + parent + "/"
+	}
+	for {
+		attrs, err := it.Next(ctx)
+		if errors.Is(err, stdio.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(attrs.Name, strings.TrimPrefix(rest, bucket+"/"))
+		if name == "" {
+			continue
+		}
+		link := gcsBrowserPrefix + path.Join(storageProvider, bucket, attrs.Name)
+		if attrs.IsDir {
+			link += "/"
+		}
+		tmpl.Entries = append(tmpl.Entries, gcsBrowserEntry{
+			Name:  name,
+			Link:  link,
+			IsDir: attrs.IsDir,
+			Size:  attrs.Size,
+		})
+	}
+	sort.Slice(tmpl.Entries, func(i, j int) bool {
+		return tmpl.Entries[i].Name < tmpl.Entries[j].Name
+	})
+	return tmpl, nil
+}
+
+// serveGCSBrowserFile serves a file, or a single range of it if requested.
+func serveGCSBrowserFile(w http.ResponseWriter, r *http.Request, opener io.Opener, storagePath string, l *logrus.Entry) {
+	attrs, err := opener.Attributes(r.Context(), storagePath)
+	if err != nil {
+		if io.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		l.WithError(err).Warn("Failed to get the attributes of the artifact.")
+		http.Error(w, "Failed to get the attributes of the artifact.", http.StatusInternalServerError)
+		return
+	}
+
+	// Artifacts are uploaded by jobs, so they must not be able to run
+	// scripts with access to Deck.
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Accept-Ranges", "bytes")
+	contentType := attrs.ContentType
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	if attrs.ContentEncoding != "" {
+		w.Header().Set("Content-Encoding", attrs.ContentEncoding)
+	}
+
+	offset, length, status := int64(0), attrs.Size, http.StatusOK
+	if header := r.Header.Get("Range"); header != "" && attrs.ContentEncoding == "" {
+		var ok bool
+		if offset, length, ok = parseByteRange(header, attrs.Size); !ok {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", attrs.Size))
+			http.Error(w, "Invalid range.", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		status = http.StatusPartialContent
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, attrs.Size))
+	}
+	if attrs.ContentEncoding == "" {
+		w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	}
+	if r.Method == http.MethodHead {
+		w.WriteHeader(status)
+		return
+	}
+
+	var reader stdio.ReadCloser
+	if status == http.StatusPartialContent {
+		reader, err = opener.RangeReader(r.Context(), storagePath, offset, length)
+	} else {
+		reader, err = opener.Reader(r.Context(), storagePath)
+	}
+	if err != nil {
+		l.WithError(err).Warn("Failed to read the artifact.")
+		http.Error(w, "Failed to read the artifact.", http.StatusInternalServerError)
+		return
+	}
+	defer reader.Close()
+	w.WriteHeader(status)
+	if _, err := stdio.Copy(w, reader); err != nil {
+		l.WithError(err).Debug("Failed to serve the artifact.")
+	}
+}
+
+// parseByteRange parses a single byte range of a Range header, e.g.
+// bytes=0-1023, bytes=1024- or bytes=-1024, into its offset and length.
+func parseByteRange(header string, size int64) (offset, length int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false
+	}
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, size > 0
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return 0, 0, false
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end - start + 1, true
+}
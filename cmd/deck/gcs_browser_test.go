@@ -0,0 +1,346 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/sessions"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestParseByteRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		wantOffset int64
+		wantLength int64
+		wantOK     bool
+	}{
+		{name: "closed range", header: "bytes=2-5", wantOffset: 2, wantLength: 4, wantOK: true},
+		{name: "open range", header: "bytes=4-", wantOffset: 4, wantLength: 6, wantOK: true},
+		{name: "end past the size is truncated", header: "bytes=8-100", wantOffset: 8, wantLength: 2, wantOK: true},
+		{name: "suffix range", header: "bytes=-3", wantOffset: 7, wantLength: 3, wantOK: true},
+		{name: "suffix range larger than the size", header: "bytes=-30", wantOffset: 0, wantLength: 10, wantOK: true},
+		{name: "start past the size", header: "bytes=10-"},
+		{name: "end before start", header: "bytes=5-2"},
+		{name: "multiple ranges", header: "bytes=0-1,4-5"},
+		{name: "other unit", header: "lines=0-1"},
+		{name: "garbage", header: "bytes=a-b"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			offset, length, ok := parseByteRange(tc.header, 10)
+			if ok != tc.wantOK {
+				t.Fatalf("expected ok %t, got %t", tc.wantOK, ok)
+			}
+			if ok && (offset != tc.wantOffset || length != tc.wantLength) {
+				t.Errorf("expected offset %d and length %d, got %d and %d", tc.wantOffset, tc.wantLength, offset, length)
+			}
+		})
+	}
+}
+
+func TestHandleGCSBrowser(t *testing.T) {
+	gcsServer := fakestorage.NewServer([]fakestorage.Object{
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/public-job/100/prowjob.json",
+			Content:    []byte(`{"spec": {"job": "public-job"}}`),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/public-job/100/build-log.txt",
+			Content:    []byte("0123456789"),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/200/prowjob.json",
+			Content:    []byte(`{"spec": {"job": "private-job"}}`),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/200/artifacts/junit.xml",
+			Content:    []byte("<testsuites/>"),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/300/prowjob.json",
+			Content:    []byte(`{"spec": {"type": "periodic", "job": "private-job", "rerun_auth_config": {"allow_anyone": true}}}`),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/300/build-log.txt",
+			Content:    []byte("secret"),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/400/prowjob.json",
+			Content:    []byte(`{"metadata": {"name": "pj-400"}, "spec": {"job": "private-job", "rerun_auth_config": {"allow_anyone": true}}}`),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/400/build-log.txt",
+			Content:    []byte("secret"),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/500/prowjob.json",
+			Content:    []byte(`{"metadata": {"name": "pj-100"}, "spec": {"job": "public-job"}}`),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/private-job/500/build-log.txt",
+			Content:    []byte("secret"),
+		},
+	})
+	defer gcsServer.Stop()
+	opener := io.NewGCSOpener(gcsServer.Client())
+	prowJobClient := fake.NewSimpleClientset(
+		&prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "pj-100", Namespace: "prowjobs"},
+			Spec:       prowapi.ProwJobSpec{Job: "public-job"},
+			Status:     prowapi.ProwJobStatus{BuildID: "100"},
+		},
+		&prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "pj-400", Namespace: "prowjobs"},
+			Spec:       prowapi.ProwJobSpec{Job: "private-job"},
+			Status:     prowapi.ProwJobStatus{BuildID: "400"},
+		},
+	).ProwV1().ProwJobs("prowjobs")
+
+	boolTrue := true
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Deck: config.Deck{
+				SkipStoragePathValidation: &boolTrue,
+			},
+		},
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{
+				Name:            "private-job",
+				RerunAuthConfig: &prowapi.RerunAuthConfig{GitHubUsers: []string{"configured"}},
+			}}},
+		},
+	})
+	authCfgGetter := func(pj *prowapi.ProwJob) *prowapi.RerunAuthConfig {
+		if pj.Spec.Job == "public-job" {
+			return &prowapi.RerunAuthConfig{AllowAnyone: true}
+		}
+		return &prowapi.RerunAuthConfig{GitHubUsers: []string{"authorized"}}
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		rangeHeader      string
+		login            string
+		wantCode         int
+		wantBody         string
+		wantContentRange string
+	}{
+		{
+			name:     "file of a public job",
+			path:     "gs/kubernetes-jenkins/logs/public-job/100/build-log.txt",
+			wantCode: http.StatusOK,
+			wantBody: "0123456789",
+		},
+		{
+			name:             "range of a file",
+			path:             "gs/kubernetes-jenkins/logs/public-job/100/build-log.txt",
+			rangeHeader:      "bytes=2-5",
+			wantCode:         http.StatusPartialContent,
+			wantBody:         "2345",
+			wantContentRange: "bytes 2-5/10",
+		},
+		{
+			name:             "tail of a file",
+			path:             "gs/kubernetes-jenkins/logs/public-job/100/build-log.txt",
+			rangeHeader:      "bytes=-3",
+			wantCode:         http.StatusPartialContent,
+			wantBody:         "789",
+			wantContentRange: "bytes 7-9/10",
+		},
+		{
+			name:             "unsatisfiable range",
+			path:             "gs/kubernetes-jenkins/logs/public-job/100/build-log.txt",
+			rangeHeader:      "bytes=20-",
+			wantCode:         http.StatusRequestedRangeNotSatisfiable,
+			wantContentRange: "bytes */10",
+		},
+		{
+			name:     "head of a file has no body",
+			method:   http.MethodHead,
+			path:     "gs/kubernetes-jenkins/logs/public-job/100/build-log.txt",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "missing file",
+			path:     "gs/kubernetes-jenkins/logs/public-job/100/finished.json",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "file of a private job for an authorized user",
+			path:     "gs/kubernetes-jenkins/logs/private-job/200/artifacts/junit.xml",
+			login:    "authorized",
+			wantCode: http.StatusOK,
+			wantBody: "<testsuites/>",
+		},
+		{
+			name:     "file of a private job for another user",
+			path:     "gs/kubernetes-jenkins/logs/private-job/200/artifacts/junit.xml",
+			login:    "stranger",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "rerun auth config of a tampered prowjob.json is ignored",
+			path:     "gs/kubernetes-jenkins/logs/private-job/300/build-log.txt",
+			login:    "stranger",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "rerun auth config of the job config is used instead",
+			path:     "gs/kubernetes-jenkins/logs/private-job/300/build-log.txt",
+			login:    "configured",
+			wantCode: http.StatusOK,
+			wantBody: "secret",
+		},
+		{
+			name:     "ProwJob in the cluster is used instead of a tampered prowjob.json",
+			path:     "gs/kubernetes-jenkins/logs/private-job/400/build-log.txt",
+			login:    "stranger",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "prowjob.json naming the ProwJob of another run is rejected",
+			path:     "gs/kubernetes-jenkins/logs/private-job/500/build-log.txt",
+			login:    "stranger",
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "path outside of a job run uses the default auth config",
+			path:     "gs/kubernetes-jenkins/logs/private-job/latest-build.txt",
+			login:    "authorized",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "only reads are allowed",
+			method:   http.MethodPost,
+			path:     "gs/kubernetes-jenkins/logs/public-job/100/build-log.txt",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, gcsBrowserPrefix+tc.path, nil)
+			if tc.rangeHeader != "" {
+				req.Header.Set("Range", tc.rangeHeader)
+			}
+			req.AddCookie(&http.Cookie{
+				Name:    "github_login",
+				Value:   tc.login,
+				Path:    "/",
+				Expires: time.Now().Add(time.Hour * 24 * 30),
+				Secure:  true,
+			})
+			mockCookieStore := sessions.NewCookieStore([]byte("secret-key"))
+			session, err := sessions.GetRegistry(req).Get(mockCookieStore, "access-token-session")
+			if err != nil {
+				t.Fatalf("Error making access token session: %v", err)
+			}
+			session.Values["access-token"] = &oauth2.Token{AccessToken: "validtoken"}
+			goa := githuboauth.NewAgent(&githuboauth.Config{CookieStore: mockCookieStore}, &logrus.Entry{})
+			pca := plugins.NewFakeConfigAgent()
+
+			rr := httptest.NewRecorder()
+			handler := handleGCSBrowser(options{}, ca.Config, prowJobClient, opener, authCfgGetter, goa, nil, &fakeAuthenticatedUserIdentifier{login: tc.login}, fakegithub.NewFakeClient(), &pca, logrus.WithField("handler", gcsBrowserPrefix))
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.wantCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.wantCode, rr.Code, rr.Body.String())
+			}
+			if tc.wantCode != http.StatusOK && tc.wantCode != http.StatusPartialContent && tc.wantContentRange == "" {
+				return
+			}
+			if got := rr.Header().Get("Content-Range"); got != tc.wantContentRange {
+				t.Errorf("expected Content-Range %q, got %q", tc.wantContentRange, got)
+			}
+			if tc.wantBody != "" || method == http.MethodHead {
+				if got := rr.Body.String(); got != tc.wantBody {
+					t.Errorf("expected body %q, got %q", tc.wantBody, got)
+				}
+			}
+			if rr.Code != http.StatusRequestedRangeNotSatisfiable && rr.Header().Get("Content-Security-Policy") != "sandbox" {
+				t.Errorf("expected artifacts to be sandboxed, got headers %v", rr.Header())
+			}
+		})
+	}
+}
+
+func TestListGCSBrowserDir(t *testing.T) {
+	gcsServer := fakestorage.NewServer([]fakestorage.Object{
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/ci-e2e/100/build-log.txt",
+			Content:    []byte("0123456789"),
+		},
+		{
+			BucketName: "kubernetes-jenkins",
+			Name:       "logs/ci-e2e/100/artifacts/junit.xml",
+			Content:    []byte("<testsuites/>"),
+		},
+	})
+	defer gcsServer.Stop()
+
+	got, err := listGCSBrowserDir(context.Background(), io.NewGCSOpener(gcsServer.Client()), "gs/kubernetes-jenkins/logs/ci-e2e/100/")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	want := &gcsBrowserTemplate{
+		Path:   "gs/kubernetes-jenkins/logs/ci-e2e/100/",
+		Parent: "/gcs-browser/gs/kubernetes-jenkins/logs/ci-e2e/",
+		Entries: []gcsBrowserEntry{
+			{Name: "artifacts/", Link: "/gcs-browser/gs/kubernetes-jenkins/logs/ci-e2e/100/artifacts/", IsDir: true},
+			{Name: "build-log.txt", Link: "/gcs-browser/gs/kubernetes-jenkins/logs/ci-e2e/100/build-log.txt", Size: 10},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listing differs from expected (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/deck/jobs"
+	pkgio "sigs.k8s.io/prow/pkg/io"
+)
+
+const (
+	logSearchBuildLog = "build-log.txt"
+	// logSearchDefaultRange is the time range that is searched if none is given.
+	logSearchDefaultRange = 7 * 24 * time.Hour
+	logSearchMaxRange     = 30 * 24 * time.Hour
+	// logSearchMaxJobs and logSearchMaxRuns bound the number of logs that are
+	// read for a search, logSearchMaxMatches the lines returned for a run.
+	logSearchMaxJobs       = 10
+	logSearchMaxRuns       = 100
+	logSearchMaxMatches    = 10
+	logSearchMaxLineLength = 500
+	logSearchConcurrency   = 10
+	logSearchTimeout       = time.Minute
+	// logSearchInterval and logSearchBurst rate limit the searches of all
+	// users, as every search may read hundreds of logs.
+	logSearchInterval = 10 * time.Second
+	logSearchBurst    = 5
+)
+
+type logSearchTemplate struct {
+	Jobs    string
+	Pattern string
+	Range   string
+	Error   string
+
+	// Searched is the number of runs whose logs were searched.
+	Searched int
+	Runs     []logSearchRun
+}
+
+type logSearchRun struct {
+	Job          string
+	ID           string
+	SpyglassLink string
+	Started      time.Time
+	Result       string
+	Matches      []logSearchMatch
+}
+
+type logSearchMatch struct {
+	Line int
+	Text string
+}
+
+// jobPathResolver returns the storage path of the history of a job, e.g.
+// gs/bucket/logs/job, as used by the job history.
+type jobPathResolver func(job string) (string, error)
+
+// jobAgentPathResolver resolves the storage path of a job from its most recent
+// ProwJob, so only jobs that are visible in this Deck can be searched.
+func jobAgentPathResolver(ja *jobs.JobAgent, jobPath func(src string) (string, error)) jobPathResolver {
+	return func(job string) (string, error) {
+		var latest *prowapi.ProwJob
+		for _, pj := range ja.ProwJobs() {
+			if pj.Spec.Job != job || pj.Status.BuildID == "" {
+				continue
+			}
+			if latest == nil || pj.Status.StartTime.After(latest.Status.StartTime.Time) {
+				pj := pj
+				latest = &pj
+			}
+		}
+		if latest == nil {
+			return "", fmt.Errorf("no recent runs of job %q", job)
+		}
+		return jobPath(path.Join("prowjob", job, latest.Status.BuildID))
+	}
+}
+
+// parseLogSearchQuery parses the jobs, the pattern and the time range of a
+// log search, e.g. /log-search?job=foo,bar&pattern=panic:&range=24h.
+func parseLogSearchQuery(query url.Values) (jobNames []string, re *regexp.Regexp, timeRange time.Duration, err error) {
+	seen := map[string]bool{}
+	for _, value := range query["job"] {
+		for _, job := range strings.Split(value, ",") {
+			if job = strings.TrimSpace(job); job != "" && !seen[job] {
+				seen[job] = true
+				jobNames = append(jobNames, job)
+			}
+		}
+	}
+	if len(jobNames) == 0 {
+		return nil, nil, 0, errors.New("at least one job is required")
+	}
+	if len(jobNames) > logSearchMaxJobs {
+		return nil, nil, 0, fmt.Errorf("at most %d jobs can be searched at once", logSearchMaxJobs)
+	}
+	pattern := query.Get("pattern")
+	if pattern == "" {
+		return nil, nil, 0, errors.New("a pattern is required")
+	}
+	if re, err = regexp.Compile(pattern); err != nil {
+		return nil, nil, 0, fmt.Errorf("invalid pattern: %w", err)
+	}
+	timeRange = logSearchDefaultRange
+	if raw := query.Get("range"); raw != "" {
+		if timeRange, err = time.ParseDuration(raw); err != nil {
+			return nil, nil, 0, fmt.Errorf("invalid range: %w", err)
+		}
+		if timeRange <= 0 || timeRange > logSearchMaxRange {
+			return nil, nil, 0, fmt.Errorf("range must be positive and at most %s", logSearchMaxRange)
+		}
+	}
+	return jobNames, re, timeRange, nil
+}
+
+// searchLogs searches the build logs of the runs of the jobs that started
+// within the time range for a pattern, and returns the runs with matching
+// lines, most recent first.
+func searchLogs(ctx context.Context, query url.Values, cfg config.Getter, opener pkgio.Opener, resolve jobPathResolver) logSearchTemplate {
+	tmpl := logSearchTemplate{
+		Jobs:    strings.Join(query["job"], ","),
+		Pattern: query.Get("pattern"),
+		Range:   query.Get("range"),
+	}
+	if tmpl.Jobs == "" && tmpl.Pattern == "" {
+		// Only show the form.
+		return tmpl
+	}
+	jobNames, re, timeRange, err := parseLogSearchQuery(query)
+	if err != nil {
+		tmpl.Error = err.Error()
+		return tmpl
+	}
+	ctx, cancel := context.WithTimeout(ctx, logSearchTimeout)
+	defer cancel()
+	since := time.Now().Add(-timeRange)
+
+	var errs []string
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, logSearchConcurrency)
+	for _, job := range jobNames {
+		bucket, root, ids, err := listJobRuns(ctx, cfg, opener, resolve, job)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		for _, id := range ids {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(job, id string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				run, searched, err := searchRun(ctx, bucket, root, id, re, since)
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					logrus.WithError(err).WithField("job", job).WithField("build-id", id).Debug("Failed to search the build log.")
+					return
+				}
+				if searched {
+					tmpl.Searched++
+				}
+				if len(run.Matches) > 0 {
+					run.Job = job
+					tmpl.Runs = append(tmpl.Runs, run)
+				}
+			}(job, id)
+		}
+	}
+	wg.Wait()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		errs = append(errs, "the search timed out, results are incomplete")
+	}
+	tmpl.Error = strings.Join(errs, "; ")
+	sort.SliceStable(tmpl.Runs, func(i, j int) bool {
+		return tmpl.Runs[i].Started.After(tmpl.Runs[j].Started)
+	})
+	return tmpl
+}
+
+// listJobRuns returns the IDs of the most recent runs of a job.
+func listJobRuns(ctx context.Context, cfg config.Getter, opener pkgio.Opener, resolve jobPathResolver, job string) (blobStorageBucket, string, []string, error) {
+	jobPath, err := resolve(job)
+	if err != nil {
+		return blobStorageBucket{}, "", nil, err
+	}
+	// Job paths have the same format as the job history paths.
+	storageProvider, bucketName, root, _, err := parseJobHistURL(&url.URL{Path: path.Join("/job-history", jobPath)})
+	if err != nil {
+		return blobStorageBucket{}, "", nil, err
+	}
+	if bucketAlias, exists := cfg().Deck.Spyglass.BucketAliases[bucketName]; exists {
+		bucketName = bucketAlias
+	}
+	bucket, err := newBlobStorageBucket(bucketName, storageProvider, cfg(), opener)
+	if err != nil {
+		return blobStorageBucket{}, "", nil, err
+	}
+	listCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	buildIDs, err := bucket.listBuildIDs(listCtx, root)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return blobStorageBucket{}, "", nil, fmt.Errorf("failed to get build ids of job %q: %w", job, err)
+	}
+	sort.Sort(sort.Reverse(uint64slice(buildIDs)))
+	if len(buildIDs) > logSearchMaxRuns {
+		buildIDs = buildIDs[:logSearchMaxRuns]
+	}
+	ids := make([]string, 0, len(buildIDs))
+	for _, id := range buildIDs {
+		ids = append(ids, strconv.FormatUint(id, 10))
+	}
+	return bucket, root, ids, nil
+}
+
+// searchRun searches the build log of a run if it started after since.
+func searchRun(ctx context.Context, bucket blobStorageBucket, root, id string, re *regexp.Regexp, since time.Time) (logSearchRun, bool, error) {
+	run := logSearchRun{ID: id}
+	dir, err := bucket.getPath(ctx, root, id, "")
+	if err != nil {
+		return run, false, err
+	}
+	b, err := getBuildData(ctx, bucket, dir)
+	if err != nil && b.Started.IsZero() {
+		return run, false, err
+	}
+	if b.Started.Before(since) {
+		return run, false, nil
+	}
+	run.Started = b.Started
+	run.Result = strings.ToUpper(b.Result)
+	run.SpyglassLink = path.Join(spyglassPrefix, bucket.storageProvider, bucket.name, dir)
+	run.Matches, err = bucket.grepObject(ctx, path.Join(dir, logSearchBuildLog), re, logSearchMaxMatches)
+	if pkgio.IsNotExist(err) {
+		// The run did not upload its log yet.
+		return run, false, nil
+	}
+	return run, err == nil, err
+}
+
+// grepObject returns up to max lines of an object that match re.
+func (bucket blobStorageBucket) grepObject(ctx context.Context, key string, re *regexp.Regexp, max int) ([]logSearchMatch, error) {
+	u := url.URL{
+		Scheme: bucket.storageProvider,
+		Host:   bucket.name,
+		Path:   key,
+	}
+	rc, err := bucket.Opener.Reader(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var matches []logSearchMatch
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if !re.Match(scanner.Bytes()) {
+			continue
+		}
+		text := scanner.Text()
+		if len(text) > logSearchMaxLineLength {
+			text = text[:logSearchMaxLineLength] + "..."
+		}
+		matches = append(matches, logSearchMatch{Line: line, Text: text})
+		if len(matches) >= max {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return matches, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return matches, nil
+}
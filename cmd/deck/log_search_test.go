@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestParseLogSearchQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     url.Values
+		wantJobs  []string
+		wantRange time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "single job with the default range",
+			query:     url.Values{"job": {"ci-e2e"}, "pattern": {"panic"}},
+			wantJobs:  []string{"ci-e2e"},
+			wantRange: logSearchDefaultRange,
+		},
+		{
+			name:      "repeated and comma separated jobs are deduplicated",
+			query:     url.Values{"job": {"ci-e2e, ci-unit", "ci-e2e"}, "pattern": {"panic"}, "range": {"24h"}},
+			wantJobs:  []string{"ci-e2e", "ci-unit"},
+			wantRange: 24 * time.Hour,
+		},
+		{
+			name:    "no job",
+			query:   url.Values{"pattern": {"panic"}},
+			wantErr: true,
+		},
+		{
+			name:    "too many jobs",
+			query:   url.Values{"job": {"a,b,c,d,e,f,g,h,i,j,k"}, "pattern": {"panic"}},
+			wantErr: true,
+		},
+		{
+			name:    "no pattern",
+			query:   url.Values{"job": {"ci-e2e"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			query:   url.Values{"job": {"ci-e2e"}, "pattern": {"panic("}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid range",
+			query:   url.Values{"job": {"ci-e2e"}, "pattern": {"panic"}, "range": {"a week"}},
+			wantErr: true,
+		},
+		{
+			name:    "range too long",
+			query:   url.Values{"job": {"ci-e2e"}, "pattern": {"panic"}, "range": {"1000h"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobs, _, timeRange, err := parseLogSearchQuery(tc.query)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantJobs, jobs); diff != "" {
+				t.Errorf("jobs differ from expected (-want +got):\n%s", diff)
+			}
+			if timeRange != tc.wantRange {
+				t.Errorf("expected range %s, got %s", tc.wantRange, timeRange)
+			}
+		})
+	}
+}
+
+func TestSearchLogs(t *testing.T) {
+	now := time.Now()
+	run := func(job, id string, started time.Time, result, log string) []fakestorage.Object {
+		dir := fmt.Sprintf("logs/%s/%s/", job, id)
+		objects := []fakestorage.Object{
+			{
+				BucketName: "kubernetes-jenkins",
+				Name:       dir + "started.json",
+				Content:    []byte(fmt.Sprintf(`{"timestamp": %d}`, started.Unix())),
+			},
+			{
+				BucketName: "kubernetes-jenkins",
+				Name:       dir + "finished.json",
+				Content:    []byte(fmt.Sprintf(`{"timestamp": %d, "result": %q}`, started.Add(time.Minute).Unix(), result)),
+			},
+		}
+		if log != "" {
+			objects = append(objects, fakestorage.Object{
+				BucketName: "kubernetes-jenkins",
+				Name:       dir + "build-log.txt",
+				Content:    []byte(log),
+			})
+		}
+		return objects
+	}
+	var objects []fakestorage.Object
+	objects = append(objects, run("ci-e2e", "100", now.Add(-48*time.Hour), "FAILURE", "starting\npanic: nil pointer\ndone\n")...)
+	objects = append(objects, run("ci-e2e", "101", now.Add(-2*time.Hour), "SUCCESS", "starting\ndone\n")...)
+	objects = append(objects, run("ci-e2e", "102", now.Add(-time.Hour), "FAILURE", "panic: first\nstarting\npanic: second\n")...)
+	objects = append(objects, run("ci-e2e", "103", now.Add(-time.Minute), "PENDING", "")...)
+	objects = append(objects, run("ci-unit", "200", now.Add(-3*time.Hour), "FAILURE", "panic: unit\n")...)
+
+	gcsServer := fakestorage.NewServer(objects)
+	defer gcsServer.Stop()
+
+	boolTrue := true
+	ca := &config.Agent{}
+	ca.Set(&config.Config{
+		ProwConfig: config.ProwConfig{
+			Deck: config.Deck{
+				SkipStoragePathValidation: &boolTrue,
+			},
+		},
+	})
+	resolve := func(job string) (string, error) {
+		if job == "unknown" {
+			return "", fmt.Errorf("no recent runs of job %q", job)
+		}
+		return "gs/kubernetes-jenkins/logs/" + job, nil
+	}
+
+	tests := []struct {
+		name  string
+		query url.Values
+		want  logSearchTemplate
+	}{
+		{
+			name:  "no query only shows the form",
+			query: url.Values{},
+			want:  logSearchTemplate{},
+		},
+		{
+			name:  "invalid query",
+			query: url.Values{"job": {"ci-e2e"}},
+			want: logSearchTemplate{
+				Jobs:  "ci-e2e",
+				Error: "a pattern is required",
+			},
+		},
+		{
+			name:  "matches within the range, most recent first",
+			query: url.Values{"job": {"ci-e2e,ci-unit,unknown"}, "pattern": {"^panic: "}, "range": {"24h"}},
+			want: logSearchTemplate{
+				Jobs:     "ci-e2e,ci-unit,unknown",
+				Pattern:  "^panic: ",
+				Range:    "24h",
+				Error:    `no recent runs of job "unknown"`,
+				Searched: 3,
+				Runs: []logSearchRun{
+					{
+						Job:          "ci-e2e",
+						ID:           "102",
+						SpyglassLink: "/view/gs/kubernetes-jenkins/logs/ci-e2e/102",
+						Started:      time.Unix(now.Add(-time.Hour).Unix(), 0),
+						Result:       "FAILURE",
+						Matches:      []logSearchMatch{{Line: 1, Text: "panic: first"}, {Line: 3, Text: "panic: second"}},
+					},
+					{
+						Job:          "ci-unit",
+						ID:           "200",
+						SpyglassLink: "/view/gs/kubernetes-jenkins/logs/ci-unit/200",
+						Started:      time.Unix(now.Add(-3*time.Hour).Unix(), 0),
+						Result:       "FAILURE",
+						Matches:      []logSearchMatch{{Line: 1, Text: "panic: unit"}},
+					},
+				},
+			},
+		},
+		{
+			name:  "default range includes older runs",
+			query: url.Values{"job": {"ci-e2e"}, "pattern": {"nil pointer"}},
+			want: logSearchTemplate{
+				Jobs:     "ci-e2e",
+				Pattern:  "nil pointer",
+				Searched: 3,
+				Runs: []logSearchRun{
+					{
+						Job:          "ci-e2e",
+						ID:           "100",
+						SpyglassLink: "/view/gs/kubernetes-jenkins/logs/ci-e2e/100",
+						Started:      time.Unix(now.Add(-48*time.Hour).Unix(), 0),
+						Result:       "FAILURE",
+						Matches:      []logSearchMatch{{Line: 2, Text: "panic: nil pointer"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := searchLogs(context.Background(), tc.query, ca.Config, io.NewGCSOpener(gcsServer.Client()), resolve)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("search results differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleLogSearchIsRateLimited(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	// Use up the burst, so that the next search exceeds the limit.
+	limiter.Allow()
+	handler := handleLogSearch(options{}, nil, nil, nil, limiter, logrus.WithField("handler", "/log-search"))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/log-search?job=ci-e2e&pattern=panic", nil))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the search to be rate limited, got status %d", rr.Code)
+	}
+}
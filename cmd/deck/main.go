@@ -46,6 +46,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
 	coreapi "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -74,10 +75,12 @@ import (
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/oidcauth"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/plugins"
 	"sigs.k8s.io/prow/pkg/prstatus"
+	"sigs.k8s.io/prow/pkg/readiness"
 	"sigs.k8s.io/prow/pkg/simplifypath"
 	"sigs.k8s.io/prow/pkg/spyglass"
 	spyglassapi "sigs.k8s.io/prow/pkg/spyglass/api"
@@ -93,6 +96,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/links"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/metadata"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/podinfo"
+	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/resources"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/restcoverage"
 )
 
@@ -124,6 +128,7 @@ type options struct {
 	hookURL               string
 	oauthURL              string
 	githubOAuthConfigFile string
+	oidcConfigFile        string
 	cookieSecretFile      string
 	redirectHTTPTo        string
 	hiddenOnly            bool
@@ -136,6 +141,7 @@ type options struct {
 	storage               prowflagutil.StorageClientOptions
 	gcsCookieAuth         bool
 	rerunCreatesJob       bool
+	gcsBrowser            bool
 	allowInsecure         bool
 	controllerManager     prowflagutil.ControllerManagerOptions
 	dryRun                bool
@@ -158,6 +164,10 @@ func (o *options) Validate() error {
 		}
 	}
 
+	if o.oidcConfigFile != "" && o.cookieSecretFile == "" {
+		return errors.New("an OIDC config file was provided but required flag --cookie-secret was unset")
+	}
+
 	if (o.hiddenOnly && o.showHidden) || (o.tenantIDs.Strings() != nil && (o.hiddenOnly || o.showHidden)) {
 		return errors.New("'--hidden-only', '--tenant-id', and '--show-hidden' are mutually exclusive, 'hidden-only' shows only hidden job, '--tenant-id' shows all jobs with matching ID and 'show-hidden' shows both hidden and non-hidden jobs")
 	}
@@ -170,6 +180,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.StringVar(&o.hookURL, "hook-url", "", "Path to hook plugin help endpoint.")
 	fs.StringVar(&o.oauthURL, "oauth-url", "", "Path to deck user dashboard endpoint.")
 	fs.StringVar(&o.githubOAuthConfigFile, "github-oauth-config-file", "/etc/github/secret", "Path to the file containing the GitHub App Client secret.")
+	fs.StringVar(&o.oidcConfigFile, "oidc-config-file", "", "Path to the file containing the OpenID Connect config. If set, users can log in with OIDC at /oidc-login.")
 	fs.StringVar(&o.cookieSecretFile, "cookie-secret", "", "Path to the file containing the cookie secret key.")
 	// use when behind a load balancer
 	fs.StringVar(&o.redirectHTTPTo, "redirect-http-to", "", "Host to redirect http->https to based on x-forwarded-proto == http.")
@@ -183,6 +194,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.StringVar(&o.templateFilesLocation, "template-files-location", fmt.Sprintf("%s%s", os.Getenv("KO_DATA_PATH"), defaultTemplateFilesLocation), "Path to the template files")
 	fs.BoolVar(&o.gcsCookieAuth, "gcs-cookie-auth", false, "Use storage.cloud.google.com instead of signed URLs")
 	fs.BoolVar(&o.rerunCreatesJob, "rerun-creates-job", false, "Change the re-run option in Deck to actually create the job. **WARNING:** Only use this with non-public deck instances, otherwise strangers can DOS your Prow instance")
+	fs.BoolVar(&o.gcsBrowser, "gcs-browser", false, "Serve the artifacts of jobs at /gcs-browser/ to users allowed to rerun the jobs.")
 	fs.BoolVar(&o.allowInsecure, "allow-insecure", false, "Allows insecure requests for CSRF and GitHub oauth.")
 	fs.BoolVar(&o.dryRun, "dry-run", false, "Whether or not to make mutating API calls to GitHub.")
 	fs.Var(&o.tenantIDs, "tenant-id", "The tenantID(s) used by the ProwJobs that should be displayed by this instance of Deck. This flag can be repeated.")
@@ -211,7 +223,7 @@ var (
 	traceHandler        = metrics.TraceHandler(simplifier, httpRequestDuration, httpResponseSize)
 )
 
-type authCfgGetter func(*prowapi.ProwJobSpec) *prowapi.RerunAuthConfig
+type authCfgGetter func(*prowapi.ProwJob) *prowapi.RerunAuthConfig
 
 func init() {
 	prometheus.MustRegister(httpRequestDuration)
@@ -227,6 +239,8 @@ var simplifier = simplifypath.NewSimplifier(l("", // shadow element mimicking th
 	l("favicon.ico"),
 	l("github-login",
 		l("redirect")),
+	l("gcs-browser",
+		simplifypath.VGreedy("path")),
 	l("github-link"),
 	l("git-provider-link"),
 	l("job-history",
@@ -321,6 +335,12 @@ func main() {
 	mux.Handle("/tide-history", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "tide-history.html", nil)))
 	mux.Handle("/plugins", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "plugins.html", nil)))
 
+	// The status page is populated by polling the components configured in deck.status_components.
+	sa := newStatusAgent(cfg)
+	sa.start()
+	mux.Handle("/status", gziphandler.GzipHandler(handleStatusPage(o, cfg, sa)))
+	mux.Handle("/status.js", gziphandler.GzipHandler(handleComponentStatus(sa, logrus.WithField("handler", "/status.js"))))
+
 	runLocal := o.pregeneratedData != ""
 
 	var fallbackHandler func(http.ResponseWriter, *http.Request)
@@ -381,6 +401,7 @@ func main() {
 		if synced := mgr.GetCache().WaitForCacheSync(mgrSyncCtx); !synced {
 			logrus.Fatal("Timed out waiting for cachesync")
 		}
+		readiness.Register("informers", readiness.CacheSynced(mgr.GetCache()))
 
 		// The watch apimachinery doesn't support restarts, so just exit the binary if a kubeconfig changes
 		// to make the kubelet restart us.
@@ -396,11 +417,13 @@ func main() {
 		// We use the GH client to resolve GH teams when determining who is permitted to rerun a job.
 		// When inrepoconfig is enabled, both the GitHubClient and the gitClient are used to resolve
 		// presubmits dynamically which we need for the PR history page.
-		if o.github.TokenPath != "" || o.github.AppID != "" {
-			githubClient, err = o.github.GitHubClient(o.dryRun)
+		if o.github.TokenPath != "" || o.github.UsesAppsAuth() {
+			gc, err := o.github.GitHubClient(o.dryRun)
 			if err != nil {
 				logrus.WithError(err).Fatal("Error getting GitHub client.")
 			}
+			githubClient = gc
+			readiness.Register("github", readiness.GitHub(gc))
 			gitClient, err = o.github.GitClientFactory("", &o.config.InRepoConfigCacheDirBase, o.dryRun, false)
 			if err != nil {
 				logrus.WithError(err).Fatal("Error getting Git client.")
@@ -422,21 +445,24 @@ func main() {
 		}
 	}
 
-	authCfgGetter := func(jobSpec *prowapi.ProwJobSpec) *prowapi.RerunAuthConfig {
-		return cfg().Deck.GetRerunAuthConfig(jobSpec)
+	authCfgGetter := func(pj *prowapi.ProwJob) *prowapi.RerunAuthConfig {
+		if authConfig := cfg().Deck.GetTenantRerunAuthConfig(pj); authConfig != nil {
+			return authConfig
+		}
+		return cfg().Deck.GetRerunAuthConfig(&pj.Spec)
 	}
 
+	indexHandler := handleSimpleTemplate(o, cfg, "index.html", struct {
+		SpyglassEnabled bool
+		ReRunCreatesJob bool
+	}{
+		SpyglassEnabled: o.spyglass,
+		ReRunCreatesJob: o.rerunCreatesJob})
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
 			fallbackHandler(w, r)
 			return
 		}
-		indexHandler := handleSimpleTemplate(o, cfg, "index.html", struct {
-			SpyglassEnabled bool
-			ReRunCreatesJob bool
-		}{
-			SpyglassEnabled: o.spyglass,
-			ReRunCreatesJob: o.rerunCreatesJob})
 		indexHandler(w, r)
 	})
 
@@ -446,7 +472,8 @@ func main() {
 	// setup prod only handlers. These handlers can work with runlocal as long
 	// as ja is properly mocked, more specifically pjListingClient inside ja
 	mux.Handle("/data.js", gziphandler.GzipHandler(handleData(ja, logrus.WithField("handler", "/data.js"))))
-	mux.Handle("/prowjobs.js", gziphandler.GzipHandler(handleProwJobs(ja, logrus.WithField("handler", "/prowjobs.js"))))
+	mux.Handle("/prowjobs.js", gziphandler.GzipHandler(handleProwJobs(ja, nil, logrus.WithField("handler", "/prowjobs.js"))))
+	tenantPages := &tenantViews{index: indexHandler, ja: ja}
 	mux.Handle("/badge.svg", gziphandler.GzipHandler(handleBadge(ja)))
 	mux.Handle("/log", gziphandler.GzipHandler(handleLog(ja, logrus.WithField("handler", "/log"))))
 
@@ -457,10 +484,16 @@ func main() {
 	if runLocal {
 		mux = localOnlyMain(cfg, o, mux)
 	} else {
-		mux = prodOnlyMain(cfg, pluginAgent, authCfgGetter, githubClient, o, mux)
+		mux = prodOnlyMain(cfg, pluginAgent, authCfgGetter, githubClient, tenantPages, o, mux)
 	}
+	mux.Handle("/t/", gziphandler.GzipHandler(handleTenant(cfg, *tenantPages, logrus.WithField("handler", "/t/"))))
 
-	// signal to the world that we're ready
+	// signal to the world that we're ready once the configs are loaded and
+	// the dependencies were reachable
+	readiness.Register("config", readiness.Config(cfg))
+	if pluginAgent != nil {
+		readiness.Register("plugin-config", readiness.Config(pluginAgent.Config))
+	}
 	health.ServeReady()
 
 	// cookie secret will be used for CSRF protection and should be exactly 32 bytes
@@ -494,7 +527,7 @@ func main() {
 
 	// if we allow direct reruns, we must protect against CSRF in all post requests using the cookie secret as a token
 	// for more information about CSRF, see https://docs.prow.k8s.io/docs/components/core/deck/csrf/
-	empty := prowapi.ProwJobSpec{}
+	empty := prowapi.ProwJob{}
 	if o.rerunCreatesJob && csrfToken == nil && !authCfgGetter(&empty).IsAllowAnyone() {
 		logrus.Fatal("Rerun creates job cannot be enabled without CSRF protection, which requires --cookie-secret to be exactly 32 bytes")
 		return
@@ -553,7 +586,7 @@ func (fjc *fakePjListingClientWrapper) List(ctx context.Context, pjl *prowapi.Pr
 }
 
 // prodOnlyMain contains logic only used when running deployed, not locally
-func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGetter authCfgGetter, githubClient deckGitHubClient, o options, mux *http.ServeMux) *http.ServeMux {
+func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGetter authCfgGetter, githubClient deckGitHubClient, tenantPages *tenantViews, o options, mux *http.ServeMux) *http.ServeMux {
 	prowJobClient, err := o.kubernetes.ProwJobClient(cfg().ProwJobNamespace, false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting ProwJob client for infrastructure cluster.")
@@ -561,6 +594,8 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 
 	// prowjob still needs prowJobClient for retrieving log
 	mux.Handle("/prowjob", gziphandler.GzipHandler(handleProwJob(prowJobClient, logrus.WithField("handler", "/prowjob"))))
+	// Show the YAML, pod spec and provenance of a ProwJob on /prowjob-details.
+	mux.Handle("/prowjob-details", gziphandler.GzipHandler(handleProwJobDetails(o, cfg, prowJobClient, logrus.WithField("handler", "/prowjob-details"))))
 
 	if o.hookURL != "" {
 		mux.Handle("/plugin-help.js",
@@ -585,9 +620,11 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 		}
 		go func() {
 			ta.start()
-			mux.Handle("/tide.js", gziphandler.GzipHandler(handleTidePools(cfg, ta, logrus.WithField("handler", "/tide.js"))))
+			mux.Handle("/tide.js", gziphandler.GzipHandler(handleTidePools(cfg, ta, nil, logrus.WithField("handler", "/tide.js"))))
 			mux.Handle("/tide-history.js", gziphandler.GzipHandler(handleTideHistory(ta, logrus.WithField("handler", "/tide-history.js"))))
 		}()
+		tenantPages.tide = handleSimpleTemplate(o, cfg, "tide.html", nil)
+		tenantPages.ta = ta
 	}
 
 	secure := !o.allowInsecure
@@ -604,11 +641,6 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 			logrus.WithError(err).Fatal("Could not read github oauth config file.")
 		}
 
-		cookieSecretRaw, err := loadToken(o.cookieSecretFile)
-		if err != nil {
-			logrus.WithError(err).Fatal("Could not read cookie secret file.")
-		}
-
 		var githubOAuthConfig githuboauth.Config
 		if err := yaml.Unmarshal(githubOAuthConfigRaw, &githubOAuthConfig); err != nil {
 			logrus.WithError(err).Fatal("Error unmarshalling github oauth config")
@@ -617,15 +649,7 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 			logrus.Fatal("Error invalid github oauth config")
 		}
 
-		decodedSecret, err := base64.StdEncoding.DecodeString(string(cookieSecretRaw))
-		if err != nil {
-			logrus.WithError(err).Fatal("Error decoding cookie secret")
-		}
-		if len(decodedSecret) == 0 {
-			logrus.Fatal("Cookie secret should not be empty")
-		}
-		cookie := sessions.NewCookieStore(decodedSecret)
-		githubOAuthConfig.InitGitHubOAuthConfig(cookie)
+		githubOAuthConfig.InitGitHubOAuthConfig(loadCookieStore(o.cookieSecretFile))
 
 		goa = githuboauth.NewAgent(&githubOAuthConfig, logrus.WithField("client", "githuboauth"))
 		oauthClient := githuboauth.NewClient(&oauth2.Config{
@@ -648,14 +672,49 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 		}
 		mux.Handle("/pr-data.js", handleNotCached(
 			prStatusAgent.HandlePrStatus(prStatusAgent, clientCreator)))
+		tenantPages.pr = handleSimpleTemplate(o, cfg, "pr.html", nil)
+		tenantPages.prData = func(repos []string) http.Handler {
+			agent := prstatus.NewDashboardAgent(repos, &githubOAuthConfig, logrus.WithField("client", "pr-status"))
+			return handleNotCached(agent.HandlePrStatus(agent, clientCreator))
+		}
 		// Handles login request.
 		mux.Handle("/github-login", goa.HandleLogin(oauthClient, secure))
 		// Handles redirect from GitHub OAuth server.
 		mux.Handle("/github-login/redirect", goa.HandleRedirect(oauthClient, githuboauth.NewAuthenticatedUserIdentifier(&o.github), secure))
 	}
 
-	mux.Handle("/rerun", gziphandler.GzipHandler(handleRerun(cfg, prowJobClient, o.rerunCreatesJob, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/rerun"))))
-	mux.Handle("/abort", gziphandler.GzipHandler(handleAbort(prowJobClient, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/abort"))))
+	// Enable OIDC login if an OIDC config is provided.
+	var oa *oidcauth.Agent
+	if o.oidcConfigFile != "" {
+		oidcConfigRaw, err := loadToken(o.oidcConfigFile)
+		if err != nil {
+			logrus.WithError(err).Fatal("Could not read OIDC config file.")
+		}
+		var oidcConfig oidcauth.Config
+		if err := yaml.Unmarshal(oidcConfigRaw, &oidcConfig); err != nil {
+			logrus.WithError(err).Fatal("Error unmarshalling OIDC config")
+		}
+		oidcConfig.CookieStore = loadCookieStore(o.cookieSecretFile)
+		oa, err = oidcauth.NewAgent(&oidcConfig, &http.Client{Timeout: time.Minute}, logrus.WithField("client", "oidcauth"))
+		if err != nil {
+			logrus.WithError(err).Fatal("Error setting up OIDC login")
+		}
+		mux.Handle("/oidc-login", oa.HandleLogin(secure))
+		mux.Handle("/oidc-login/redirect", oa.HandleRedirect(secure))
+		mux.Handle("/oidc-logout", oa.HandleLogout())
+	}
+
+	mux.Handle("/rerun", gziphandler.GzipHandler(handleRerun(cfg, prowJobClient, o.rerunCreatesJob, authCfgGetter, goa, oa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/rerun"))))
+	mux.Handle("/abort", gziphandler.GzipHandler(handleAbort(prowJobClient, authCfgGetter, goa, oa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/abort"))))
+	mux.Handle("/abort-pr", gziphandler.GzipHandler(handleAbortPR(prowJobClient, authCfgGetter, goa, oa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/abort-pr"))))
+	if o.gcsBrowser {
+		opener, err := io.NewOpener(context.TODO(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener")
+		}
+		// Not gzipped as it serves range requests.
+		mux.Handle(gcsBrowserPrefix, handleGCSBrowser(o, cfg, prowJobClient, opener, authCfgGetter, goa, oa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", gcsBrowserPrefix)))
+	}
 
 	// optionally inject http->https redirect handler when behind loadbalancer
 	if o.redirectHTTPTo != "" {
@@ -697,6 +756,7 @@ func initSpyglass(cfg config.Getter, o options, mux *http.ServeMux, ja *jobs.Job
 	mux.Handle("/view/", gziphandler.GzipHandler(handleRequestJobViews(sg, cfg, o, logrus.WithField("handler", "/view"))))
 	mux.Handle("/job-history/", gziphandler.GzipHandler(handleJobHistory(o, cfg, opener, logrus.WithField("handler", "/job-history"))))
 	mux.Handle("/pr-history/", gziphandler.GzipHandler(handlePRHistory(o, cfg, opener, gitHubClient, gitClient, logrus.WithField("handler", "/pr-history"))))
+	mux.Handle("/log-search", gziphandler.GzipHandler(handleLogSearch(o, cfg, opener, jobAgentPathResolver(ja, sg.JobPath), rate.NewLimiter(rate.Every(logSearchInterval), logSearchBurst), logrus.WithField("handler", "/log-search"))))
 	if err := initLocalLensHandler(cfg, o, sg); err != nil {
 		logrus.WithError(err).Fatal("Failed to initialize local lens handler")
 	}
@@ -777,10 +837,21 @@ func handleNotCached(next http.Handler) http.HandlerFunc {
 	}
 }
 
-func handleProwJobs(ja *jobs.JobAgent, log *logrus.Entry) http.HandlerFunc {
+// handleProwJobs serves the ProwJobs of the job agent. If filter is set, only
+// the ProwJobs it matches are served.
+func handleProwJobs(ja *jobs.JobAgent, filter func(*prowapi.ProwJob) bool, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
 		jobs := ja.ProwJobs()
+		if filter != nil {
+			var filtered []prowapi.ProwJob
+			for i := range jobs {
+				if filter(&jobs[i]) {
+					filtered = append(filtered, jobs[i])
+				}
+			}
+			jobs = filtered
+		}
 		omit := r.URL.Query().Get("omit")
 
 		if set := sets.New[string](strings.Split(omit, ",")...); set.Len() > 0 {
@@ -824,6 +895,59 @@ func handleProwJobs(ja *jobs.JobAgent, log *logrus.Entry) http.HandlerFunc {
 	}
 }
 
+// tenantViews are the pages Deck serves for each tenant. The Tide and PR
+// pages are only served if Deck serves them for everyone.
+type tenantViews struct {
+	index http.HandlerFunc
+	ja    *jobs.JobAgent
+	tide  http.Handler
+	ta    *tideAgent
+	pr    http.Handler
+	// prData serves the PRs of the user in the given repos.
+	prData func(repos []string) http.Handler
+}
+
+// handleTenant serves the scoped views of the tenants configured in Deck:
+// /t/<name>/ is the job list of the tenant and /t/<name>/prowjobs.js the
+// ProwJobs it shows. /t/<name>/tide and /t/<name>/pr show the Tide pools and
+// the PRs of the user in the repos of the tenant.
+func handleTenant(cfg config.Getter, views tenantViews, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, rest, hasRest := strings.Cut(strings.TrimPrefix(r.URL.Path, "/t/"), "/")
+		tenant := cfg().Deck.GetTenant(name)
+		if tenant == nil {
+			http.NotFound(w, r)
+			return
+		}
+		log := log.WithField("tenant", name)
+		switch {
+		case !hasRest:
+			// The pages load their data relative to their own path.
+			http.Redirect(w, r, "/t/"+name+"/", http.StatusMovedPermanently)
+		case rest == "":
+			views.index(w, r)
+		case rest == "prowjobs.js":
+			handleProwJobs(views.ja, tenant.Matches, log)(w, r)
+		case rest == "tide" && views.tide != nil:
+			views.tide.ServeHTTP(w, r)
+		case rest == "tide.js" && views.ta != nil:
+			handleTidePools(cfg, views.ta, tenant, log)(w, r)
+		case rest == "pr" && views.pr != nil:
+			views.pr.ServeHTTP(w, r)
+		case rest == "pr-data.js" && views.prData != nil:
+			var repos []string
+			for _, repo := range sets.List(cfg().AllRepos) {
+				if org, name, ok := strings.Cut(repo, "/"); ok && tenant.MatchesRepo(org, name) {
+					repos = append(repos, repo)
+				}
+			}
+			views.prData(repos).ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
 func handleData(ja *jobs.JobAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
@@ -905,6 +1029,27 @@ func handleJobHistory(o options, cfg config.Getter, opener io.Opener, log *logru
 	}
 }
 
+// handleLogSearch handles requests to search the build logs of recent runs
+// of jobs for a pattern. The url must look like this:
+//
+// /log-search?job=<job>[,<job>...]&pattern=<regexp>[&range=<duration>]
+//
+// Searches are rate limited by the limiter, requests for the search form are not.
+func handleLogSearch(o options, cfg config.Getter, opener io.Opener, resolve jobPathResolver, limiter *rate.Limiter, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		if query := r.URL.Query(); (strings.Join(query["job"], "") != "" || query.Get("pattern") != "") && !limiter.Allow() {
+			http.Error(w, "Too many log searches, try again later.", http.StatusTooManyRequests)
+			return
+		}
+		tmpl := searchLogs(r.Context(), r.URL.Query(), cfg, opener, resolve)
+		if tmpl.Error != "" {
+			log.WithField("url", r.URL.String()).Debugf("Log search incomplete: %s", tmpl.Error)
+		}
+		handleSimpleTemplate(o, cfg, "log-search.html", tmpl)(w, r)
+	}
+}
+
 // handlePRHistory handles requests to get the test history if a given PR
 // The url must look like this:
 //
@@ -1020,7 +1165,7 @@ lensesLoop:
 		jobHistLink = path.Join("/job-history", jobPath)
 	}
 
-	var prowJobLink string
+	var prowJobLink, prowJobDetailsURL string
 	prowJob, prowJobName, prowJobState, err := sg.ProwJob(src)
 	if err == nil {
 		if prowJobName != "" {
@@ -1032,6 +1177,7 @@ lensesLoop:
 			query.Set("prowjob", prowJobName)
 			u.RawQuery = query.Encode()
 			prowJobLink = u.String()
+			prowJobDetailsURL = prowJobDetailsLink(prowJobName)
 		}
 	} else {
 		log.WithError(err).Warningf("Error getting ProwJob name for source %q.", src)
@@ -1052,7 +1198,7 @@ lensesLoop:
 
 	artifactsLink := ""
 	bucket := ""
-	if jobPath != "" && (strings.HasPrefix(jobPath, providers.GS) || strings.HasPrefix(jobPath, providers.S3)) {
+	if jobPath != "" && (strings.HasPrefix(jobPath, providers.GS) || strings.HasPrefix(jobPath, providers.S3) || strings.HasPrefix(jobPath, providers.Azure)) {
 		bucket = strings.Split(jobPath, "/")[1] // The provider (gs) will be in index 0, followed by the bucket name
 	}
 	gcswebPrefix := cfg().Deck.Spyglass.GetGCSBrowserPrefix(org, repo, bucket)
@@ -1121,6 +1267,7 @@ lensesLoop:
 		LensArtifacts   map[int][]string
 		JobHistLink     string
 		ProwJobLink     string
+		DetailsLink     string
 		ArtifactsLink   string
 		PRHistLink      string
 		Announcement    template.HTML
@@ -1141,6 +1288,7 @@ lensesLoop:
 		LensArtifacts:   lensCache,
 		JobHistLink:     jobHistLink,
 		ProwJobLink:     prowJobLink,
+		DetailsLink:     prowJobDetailsURL,
 		ArtifactsLink:   artifactsLink,
 		PRHistLink:      prHistLink,
 		Announcement:    template.HTML(announcement),
@@ -1286,10 +1434,21 @@ func handleRemoteLens(lens config.LensFileConfig, w http.ResponseWriter, r *http
 	}).ServeHTTP(w, r)
 }
 
-func handleTidePools(cfg config.Getter, ta *tideAgent, log *logrus.Entry) http.HandlerFunc {
+// handleTidePools serves the Tide queries and pools. If tenant is set, only
+// the queries and pools of the repos of the tenant are served.
+func handleTidePools(cfg config.Getter, ta *tideAgent, tenant *config.DeckTenant, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
 		queryConfigs := ta.filterQueries(cfg().Tide.Queries)
+		if tenant != nil {
+			var tenantQueries []config.TideQuery
+			for _, qc := range queryConfigs {
+				if tenantQuery(tenant, qc) {
+					tenantQueries = append(tenantQueries, qc)
+				}
+			}
+			queryConfigs = tenantQueries
+		}
 		queries := make([]string, 0, len(queryConfigs))
 		for _, qc := range queryConfigs {
 			queries = append(queries, qc.Query())
@@ -1297,16 +1456,30 @@ func handleTidePools(cfg config.Getter, ta *tideAgent, log *logrus.Entry) http.H
 
 		ta.Lock()
 		pools := ta.pools
+		mergeTrains := ta.mergeTrains
 		ta.Unlock()
 
 		var poolsForDeck []tide.PoolForDeck
 		for _, pool := range pools {
+			if tenant != nil && !tenant.MatchesRepo(pool.Org, pool.Repo) {
+				continue
+			}
 			poolsForDeck = append(poolsForDeck, *tide.PoolToPoolForDeck(&pool))
 		}
+		if tenant != nil {
+			var tenantTrains []tide.MergeTrain
+			for _, train := range mergeTrains {
+				if tenant.MatchesRepo(train.Org, train.Repo) {
+					tenantTrains = append(tenantTrains, train)
+				}
+			}
+			mergeTrains = tenantTrains
+		}
 		payload := tidePools{
 			Queries:     queries,
 			TideQueries: queryConfigs,
 			Pools:       poolsForDeck,
+			MergeTrains: mergeTrains,
 		}
 		pd, err := json.Marshal(payload)
 		if err != nil {
@@ -1317,6 +1490,21 @@ func handleTidePools(cfg config.Getter, ta *tideAgent, log *logrus.Entry) http.H
 	}
 }
 
+// tenantQuery returns whether the Tide query covers any repo of the tenant.
+func tenantQuery(tenant *config.DeckTenant, qc config.TideQuery) bool {
+	for _, org := range qc.Orgs {
+		if tenant.MatchesOrg(org) {
+			return true
+		}
+	}
+	for _, repo := range qc.Repos {
+		if org, name, ok := strings.Cut(repo, "/"); ok && tenant.MatchesRepo(org, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func handleTideHistory(ta *tideAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
@@ -1564,6 +1752,23 @@ func HandleGitProviderLink(githubHost string, secure bool) http.HandlerFunc {
 	}
 }
 
+// loadCookieStore returns a cookie store keyed with the base64 encoded secret
+// in the given file.
+func loadCookieStore(cookieSecretFile string) *sessions.CookieStore {
+	cookieSecretRaw, err := loadToken(cookieSecretFile)
+	if err != nil {
+		logrus.WithError(err).Fatal("Could not read cookie secret file.")
+	}
+	decodedSecret, err := base64.StdEncoding.DecodeString(string(cookieSecretRaw))
+	if err != nil {
+		logrus.WithError(err).Fatal("Error decoding cookie secret")
+	}
+	if len(decodedSecret) == 0 {
+		logrus.Fatal("Cookie secret should not be empty")
+	}
+	return sessions.NewCookieStore(decodedSecret)
+}
+
 func isValidatedGitOAuthConfig(githubOAuthConfig *githuboauth.Config) bool {
 	return githubOAuthConfig.ClientID != "" && githubOAuthConfig.ClientSecret != "" &&
 		githubOAuthConfig.RedirectURL != ""
@@ -329,7 +329,7 @@ func TestHandleProwJobs(t *testing.T) {
 	fakeJa := jobs.NewJobAgent(context.Background(), kc, false, true, []string{}, map[string]jobs.PodLogClient{}, fca{}.Config)
 	fakeJa.Start()
 
-	handler := handleProwJobs(fakeJa, logrus.WithField("handler", "/prowjobs.js"))
+	handler := handleProwJobs(fakeJa, nil, logrus.WithField("handler", "/prowjobs.js"))
 	req, err := http.NewRequest(http.MethodGet, "/prowjobs.js?omit=annotations,labels,decoration_config,pod_spec", nil)
 	if err != nil {
 		t.Fatalf("Error making request: %v", err)
@@ -375,6 +375,107 @@ func TestHandleProwJobs(t *testing.T) {
 	}
 }
 
+func TestHandleTenant(t *testing.T) {
+	kc := fkc{
+		prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"team": "a"}},
+			Spec:       prowapi.ProwJobSpec{Job: "a", Refs: &prowapi.Refs{Org: "acme", Repo: "widget"}},
+		},
+		prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: map[string]string{"team": "b"}},
+			Spec:       prowapi.ProwJobSpec{Job: "b", Refs: &prowapi.Refs{Org: "acme", Repo: "widget"}},
+		},
+		prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Labels: map[string]string{"team": "a"}},
+			Spec:       prowapi.ProwJobSpec{Job: "c", Refs: &prowapi.Refs{Org: "other", Repo: "repo"}},
+		},
+	}
+	cfg := fca{c: config.Config{ProwConfig: config.ProwConfig{
+		Deck: config.Deck{
+			Tenants: []config.DeckTenant{{Name: "team-a", Repos: []string{"acme"}, LabelSelector: "team=a"}},
+		},
+		Tide: config.Tide{TideGitHubConfig: config.TideGitHubConfig{Queries: config.TideQueries{
+			{Orgs: []string{"acme"}},
+			{Repos: []string{"other/repo"}},
+		}}},
+	}}}
+	ja := jobs.NewJobAgent(context.Background(), kc, false, true, []string{}, map[string]jobs.PodLogClient{}, cfg.Config)
+	ja.Start()
+	ta := &tideAgent{
+		hiddenRepos: func() []string { return []string{} },
+		cfg:         cfg.Config,
+		pools: []tide.Pool{
+			{Org: "acme", Repo: "widget", Branch: "main"},
+			{Org: "other", Repo: "repo", Branch: "main"},
+		},
+	}
+	page := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, name) }
+	}
+	handler := handleTenant(cfg.Config, tenantViews{index: page("index"), ja: ja, tide: page("tide"), ta: ta}, logrus.WithField("handler", "/t/"))
+
+	testCases := []struct {
+		path          string
+		expectedCode  int
+		expectedBody  string
+		expectedJobs  []string
+		expectedPools []string
+	}{
+		{path: "/t/team-a", expectedCode: http.StatusMovedPermanently},
+		{path: "/t/team-a/", expectedCode: http.StatusOK, expectedBody: "index"},
+		{path: "/t/team-a/prowjobs.js", expectedCode: http.StatusOK, expectedJobs: []string{"a"}},
+		{path: "/t/team-a/tide", expectedCode: http.StatusOK, expectedBody: "tide"},
+		{path: "/t/team-a/tide.js", expectedCode: http.StatusOK, expectedPools: []string{"acme/widget"}},
+		{path: "/t/team-a/pr", expectedCode: http.StatusNotFound},
+		{path: "/t/team-a/other", expectedCode: http.StatusNotFound},
+		{path: "/t/team-b/", expectedCode: http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d", tc.expectedCode, rr.Code)
+			}
+			if tc.expectedBody != "" && rr.Body.String() != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, rr.Body.String())
+			}
+			if tc.expectedPools != nil {
+				var res tidePools
+				if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+					t.Fatalf("Error unmarshalling: %v", err)
+				}
+				var actual []string
+				for _, pool := range res.Pools {
+					actual = append(actual, pool.Org+"/"+pool.Repo)
+				}
+				if diff := cmp.Diff(tc.expectedPools, actual); diff != "" {
+					t.Errorf("pools differ from expected (-want +got):\n%s", diff)
+				}
+				if expected := []string{`is:pr state:open archived:false org:"acme"`}; !reflect.DeepEqual(expected, res.Queries) {
+					t.Errorf("expected queries %v, got %v", expected, res.Queries)
+				}
+			}
+			if tc.expectedJobs == nil {
+				return
+			}
+			var res struct {
+				Items []prowapi.ProwJob `json:"items"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+				t.Fatalf("Error unmarshalling: %v", err)
+			}
+			var actual []string
+			for _, pj := range res.Items {
+				actual = append(actual, pj.Spec.Job)
+			}
+			if diff := cmp.Diff(tc.expectedJobs, actual); diff != "" {
+				t.Errorf("jobs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestProwJob just checks that the result can be unmarshaled properly, has
 // the same status, and has equal spec.
 func TestProwJob(t *testing.T) {
@@ -476,7 +577,7 @@ func TestTide(t *testing.T) {
 	if ta.pools[0].Org != "o" {
 		t.Errorf("Wrong org in pool. Got %s, expected o in %v", ta.pools[0].Org, ta.pools)
 	}
-	handler := handleTidePools(ca.Config, &ta, logrus.WithField("handler", "/tide.js"))
+	handler := handleTidePools(ca.Config, &ta, nil, logrus.WithField("handler", "/tide.js"))
 	req, err := http.NewRequest(http.MethodGet, "/tide.js", nil)
 	if err != nil {
 		t.Fatalf("Error making request: %v", err)
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+)
+
+// provenanceEntry describes one aspect of what created a ProwJob.
+type provenanceEntry struct {
+	Name  string
+	Value string
+	// Link is optional.
+	Link string
+}
+
+// prowJobPage is rendered on the details page of a ProwJob.
+type prowJobPage struct {
+	Name       string
+	Job        string
+	Provenance []provenanceEntry
+	ProwJob    string
+	Pod        string
+	// PodError explains why the pod spec could not be resolved.
+	PodError string
+	// Diff holds the changes of the spec compared to the ProwJob that was
+	// rerun, it is empty for ProwJobs that are no reruns.
+	Diff string
+}
+
+// prowJobDetailsLink returns the link to the details page of a ProwJob.
+func prowJobDetailsLink(name string) string {
+	return "/prowjob-details?" + url.Values{"prowjob": []string{name}}.Encode()
+}
+
+// prowJobProvenance lists who or what created the ProwJob, based on the
+// labels and annotations components add when creating ProwJobs.
+func prowJobProvenance(pj *prowapi.ProwJob) []provenanceEntry {
+	entries := []provenanceEntry{
+		{Name: "Type", Value: string(pj.Spec.Type)},
+		{Name: "Created", Value: pj.Status.StartTime.UTC().Format(time.RFC3339)},
+	}
+	add := func(name, value, link string) {
+		if value != "" {
+			entries = append(entries, provenanceEntry{Name: name, Value: value, Link: link})
+		}
+	}
+	add("Triggered by", pj.Annotations[kube.TriggeredByAnnotation], "")
+	add("Trigger comment", pj.Annotations[kube.TriggerCommentAnnotation], pj.Annotations[kube.TriggerCommentAnnotation])
+	if pj.Labels[kube.RetestLabel] == "true" {
+		add("Retest", "true", "")
+	}
+	if _, ok := pj.Labels[kube.CreatedByTideLabel]; ok {
+		add("Created by", "tide", "")
+	}
+	add("Scheduled run", pj.Annotations[kube.LastRunAnnotation], "")
+	add("Event trigger", pj.Annotations[kube.EventTriggerAnnotation], "")
+	add("Run after success of", pj.Annotations[kube.RunAfterSuccessAnnotation], "")
+	if rerunOf := pj.Annotations[kube.RerunOfAnnotation]; rerunOf != "" {
+		add("Rerun of", rerunOf, prowJobDetailsLink(rerunOf))
+	}
+	add("Rerun overrides", pj.Annotations[kube.RerunOverridesAnnotation], "")
+	add("Pub/Sub message ID", pj.Annotations[kube.PubSubMessageIDAnnotation], "")
+	add("Webhook delivery", pj.Labels[github.EventGUID], "")
+	return entries
+}
+
+// specDiff returns a unified diff of the specs of the ProwJobs.
+func specDiff(from, to *prowapi.ProwJob) (string, error) {
+	a, err := yaml.Marshal(from.Spec)
+	if err != nil {
+		return "", err
+	}
+	b, err := yaml.Marshal(to.Spec)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a)),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: from.Name,
+		ToFile:   to.Name,
+		Context:  3,
+	})
+}
+
+// newProwJobPage collects the details of the ProwJob. The ProwJob that was
+// rerun is only used for the diff and may be nil.
+func newProwJobPage(pj, rerunOf *prowapi.ProwJob) (*prowJobPage, error) {
+	pj = pj.DeepCopy()
+	pj.ManagedFields = nil
+	page := &prowJobPage{
+		Name:       pj.Name,
+		Job:        pj.Spec.Job,
+		Provenance: prowJobProvenance(pj),
+	}
+	raw, err := yaml.Marshal(pj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ProwJob: %w", err)
+	}
+	page.ProwJob = string(raw)
+
+	if pj.Spec.Agent != prowapi.KubernetesAgent {
+		page.PodError = fmt.Sprintf("ProwJobs of the %s agent do not run as pods.", pj.Spec.Agent)
+	} else if pod, err := decorate.ProwJobToPod(*pj); err != nil {
+		page.PodError = fmt.Sprintf("The pod spec could not be resolved: %v", err)
+	} else {
+		raw, err := yaml.Marshal(pod.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pod spec: %w", err)
+		}
+		page.Pod = string(raw)
+	}
+
+	if rerunOf != nil {
+		if page.Diff, err = specDiff(rerunOf, pj); err != nil {
+			return nil, fmt.Errorf("failed to diff spec: %w", err)
+		}
+	}
+	return page, nil
+}
+
+// handleProwJobDetails serves the details page of a ProwJob, with its YAML,
+// the resolved pod spec and who or what created it.
+func handleProwJobDetails(o options, cfg config.Getter, prowJobClient prowv1.ProwJobInterface, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		name := r.URL.Query().Get("prowjob")
+		l := log.WithField("prowjob", name)
+		if name == "" {
+			http.Error(w, "request did not provide the 'prowjob' query parameter", http.StatusBadRequest)
+			return
+		}
+		pj, err := prowJobClient.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("ProwJob not found: %v", err), http.StatusNotFound)
+			if !kerrors.IsNotFound(err) {
+				l.WithError(err).Debug("ProwJob not found.")
+			}
+			return
+		}
+		var rerunOf *prowapi.ProwJob
+		if original := pj.Annotations[kube.RerunOfAnnotation]; original != "" {
+			// The original ProwJob may have been garbage collected already.
+			if rerunOf, err = prowJobClient.Get(context.TODO(), original, metav1.GetOptions{}); err != nil {
+				l.WithError(err).Debug("Failed to get the ProwJob that was rerun.")
+				rerunOf = nil
+			}
+		}
+		page, err := newProwJobPage(pj, rerunOf)
+		if err != nil {
+			l.WithError(err).Error("Failed to render ProwJob details.")
+			http.Error(w, "failed to render ProwJob details", http.StatusInternalServerError)
+			return
+		}
+		handleSimpleTemplate(o, cfg, "prowjob.html", page)(w, r)
+	}
+}
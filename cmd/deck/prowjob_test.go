@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestProwJobProvenance(t *testing.T) {
+	start := metav1.NewTime(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	testCases := []struct {
+		name     string
+		pj       prowapi.ProwJob
+		expected []provenanceEntry
+	}{
+		{
+			name: "comment",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						kube.RetestLabel: "true",
+						github.EventGUID: "guid",
+					},
+					Annotations: map[string]string{
+						kube.TriggeredByAnnotation:    "alice",
+						kube.TriggerCommentAnnotation: "https://github.com/org/repo/pull/1#issuecomment-1",
+					},
+				},
+				Spec:   prowapi.ProwJobSpec{Type: prowapi.PresubmitJob},
+				Status: prowapi.ProwJobStatus{StartTime: start},
+			},
+			expected: []provenanceEntry{
+				{Name: "Type", Value: "presubmit"},
+				{Name: "Created", Value: "2026-10-17T12:00:00Z"},
+				{Name: "Triggered by", Value: "alice"},
+				{Name: "Trigger comment", Value: "https://github.com/org/repo/pull/1#issuecomment-1", Link: "https://github.com/org/repo/pull/1#issuecomment-1"},
+				{Name: "Retest", Value: "true"},
+				{Name: "Webhook delivery", Value: "guid"},
+			},
+		},
+		{
+			name: "cron",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{kube.LastRunAnnotation: "2026-10-17T11:00:00Z"},
+				},
+				Spec:   prowapi.ProwJobSpec{Type: prowapi.PeriodicJob},
+				Status: prowapi.ProwJobStatus{StartTime: start},
+			},
+			expected: []provenanceEntry{
+				{Name: "Type", Value: "periodic"},
+				{Name: "Created", Value: "2026-10-17T12:00:00Z"},
+				{Name: "Scheduled run", Value: "2026-10-17T11:00:00Z"},
+			},
+		},
+		{
+			name: "rerun of a Pub/Sub job",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						kube.TriggeredByAnnotation:     "bob",
+						kube.RerunOfAnnotation:         "original",
+						kube.PubSubMessageIDAnnotation: "1234",
+					},
+				},
+				Spec:   prowapi.ProwJobSpec{Type: prowapi.PeriodicJob},
+				Status: prowapi.ProwJobStatus{StartTime: start},
+			},
+			expected: []provenanceEntry{
+				{Name: "Type", Value: "periodic"},
+				{Name: "Created", Value: "2026-10-17T12:00:00Z"},
+				{Name: "Triggered by", Value: "bob"},
+				{Name: "Rerun of", Value: "original", Link: "/prowjob-details?prowjob=original"},
+				{Name: "Pub/Sub message ID", Value: "1234"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, prowJobProvenance(&tc.pj)); diff != "" {
+				t.Errorf("provenance differs from expected:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewProwJobPage(t *testing.T) {
+	original := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "original"},
+		Spec: prowapi.ProwJobSpec{
+			Type:  prowapi.PeriodicJob,
+			Agent: prowapi.KubernetesAgent,
+			Job:   "job",
+			PodSpec: &coreapi.PodSpec{Containers: []coreapi.Container{{
+				Image: "image",
+				Args:  []string{"--focus=all"},
+			}}},
+		},
+	}
+	rerun := original.DeepCopy()
+	rerun.Name = "rerun"
+	rerun.Annotations = map[string]string{kube.RerunOfAnnotation: "original"}
+	rerun.Spec.PodSpec.Containers[0].Args = []string{"--focus=e2e"}
+
+	page, err := newProwJobPage(rerun, original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.PodError != "" {
+		t.Errorf("unexpected pod error: %s", page.PodError)
+	}
+	if !strings.Contains(page.Pod, "restartPolicy: Never") || !strings.Contains(page.Pod, "--focus=e2e") {
+		t.Errorf("expected the resolved pod spec, got:\n%s", page.Pod)
+	}
+	if !strings.Contains(page.ProwJob, "name: rerun") {
+		t.Errorf("expected the ProwJob YAML, got:\n%s", page.ProwJob)
+	}
+	for _, line := range []string{"--- original", "+++ rerun", "-    - --focus=all", "+    - --focus=e2e"} {
+		if !strings.Contains(page.Diff, line) {
+			t.Errorf("expected the diff to contain %q, got:\n%s", line, page.Diff)
+		}
+	}
+
+	jenkins := &prowapi.ProwJob{Spec: prowapi.ProwJobSpec{Agent: prowapi.JenkinsAgent}}
+	if page, err = newProwJobPage(jenkins, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.PodError == "" || page.Pod != "" || page.Diff != "" {
+		t.Errorf("expected only a pod error for a jenkins job, got pod %q, error %q and diff %q", page.Pod, page.PodError, page.Diff)
+	}
+}
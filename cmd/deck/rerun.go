@@ -18,11 +18,15 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -34,6 +38,7 @@ import (
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githuboauth"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/oidcauth"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/plugins"
 	"sigs.k8s.io/prow/pkg/plugins/trigger"
@@ -55,6 +60,21 @@ var (
 	)
 )
 
+// setRerunProvenance records which ProwJob was rerun by whom, replacing the
+// provenance copied from the original ProwJob.
+func setRerunProvenance(pj *prowapi.ProwJob, name, user string) {
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	delete(pj.Annotations, kube.TriggerCommentAnnotation)
+	delete(pj.Annotations, kube.PubSubMessageIDAnnotation)
+	delete(pj.Annotations, kube.TriggeredByAnnotation)
+	if user != "" {
+		pj.Annotations[kube.TriggeredByAnnotation] = user
+	}
+	pj.Annotations[kube.RerunOfAnnotation] = name
+}
+
 func verifyRerunRefs(refs *prowapi.Refs) error {
 	var errs []error
 	if refs == nil {
@@ -197,10 +217,10 @@ func canTriggerJob(user string, pj prowapi.ProwJob, cfg *prowapi.RerunAuthConfig
 		if pluginsCfg == nil {
 			log.Info("No plugin config was provided so we cannot check if the user would be allowed to use /test.")
 		} else {
-			pcfg := pluginsCfg()
 			pull := pj.Spec.Refs.Pulls[0]
 			org := pj.Spec.Refs.Org
 			repo := pj.Spec.Refs.Repo
+			pcfg := pluginsCfg().ForRepo(org, repo)
 			_, allowed, err := trigger.TrustedPullRequest(cli, pcfg.TriggerFor(org, repo), user, org, repo, pull.Number, nil)
 			return allowed, err
 		}
@@ -208,8 +228,8 @@ func canTriggerJob(user string, pj prowapi.ProwJob, cfg *prowapi.RerunAuthConfig
 	return false, nil
 }
 
-func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, pj prowapi.ProwJob, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) (bool, string, error, int) {
-	authConfig := acfg(&pj.Spec)
+func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agent, oa *oidcauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, pj prowapi.ProwJob, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) (bool, string, error, int) {
+	authConfig := acfg(&pj)
 	var allowed bool
 	var login string
 	if pj.Spec.RerunAuthConfig.IsAllowAnyone() || authConfig.IsAllowAnyone() {
@@ -217,8 +237,20 @@ func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agen
 		// jobs so that GH oauth doesn't need to be set up for private Prows.
 		allowed = true
 	} else {
-		if goa == nil {
-			return allowed, "", errors.New("GitHub oauth must be configured to rerun jobs unless 'allow_anyone: true' is specified."), http.StatusInternalServerError
+		if goa == nil && oa == nil {
+			return allowed, "", errors.New("GitHub oauth or OIDC must be configured to rerun jobs unless 'allow_anyone: true' is specified."), http.StatusInternalServerError
+		}
+		if oa != nil {
+			// Users that logged in with OIDC are authorized by their groups.
+			identity, err := oa.GetIdentity(r)
+			if err == nil {
+				log.WithField("user", identity.Login).WithField("groups", identity.Groups).Debug("Authorizing OIDC user.")
+				allowed = authConfig.IsAuthorizedByGroups(identity.Groups) || pj.Spec.RerunAuthConfig.IsAuthorizedByGroups(identity.Groups)
+				return allowed, identity.Login, nil, http.StatusOK
+			}
+			if goa == nil {
+				return allowed, "", errors.New("Error retrieving OIDC identity."), http.StatusUnauthorized
+			}
 		}
 		var err error
 		login, err = goa.GetLogin(r, ghc)
@@ -234,6 +266,122 @@ func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agen
 	return allowed, login, nil, http.StatusOK
 }
 
+// loggedInUser returns the login of the user if they logged in with OIDC or
+// GitHub and an empty string otherwise.
+func loggedInUser(r *http.Request, goa *githuboauth.Agent, oa *oidcauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier) string {
+	if oa != nil {
+		if identity, err := oa.GetIdentity(r); err == nil {
+			return identity.Login
+		}
+	}
+	if goa != nil {
+		if login, err := goa.GetLogin(r, ghc); err == nil {
+			return login
+		}
+	}
+	return ""
+}
+
+// rerunOverrides are the changes a user requested for a rerun.
+type rerunOverrides struct {
+	// Env are environment variables to set in the containers of the job.
+	Env map[string]string `json:"env,omitempty"`
+	// Args are arguments to append to the arguments of the first container.
+	Args []string `json:"args,omitempty"`
+}
+
+func (o rerunOverrides) empty() bool {
+	return len(o.Env) == 0 && len(o.Args) == 0
+}
+
+// parseRerunOverrides reads the overrides from the body of the request. A
+// request without a body has no overrides, a request with a body must send
+// them as JSON.
+func parseRerunOverrides(r *http.Request) (rerunOverrides, error) {
+	var overrides rerunOverrides
+	if r.Body == nil {
+		return overrides, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return overrides, fmt.Errorf("failed to read overrides: %w", err)
+	}
+	if len(body) == 0 {
+		return overrides, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return overrides, fmt.Errorf("overrides must be sent as application/json, not %q", r.Header.Get("Content-Type"))
+	}
+	if err := json.Unmarshal(body, &overrides); err != nil {
+		return overrides, fmt.Errorf("failed to decode overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// applyRerunOverrides applies the overrides to the job if its rerun_overrides
+// allow them and marks it as a modified rerun, which never reports.
+func applyRerunOverrides(pj *prowapi.ProwJob, overrides rerunOverrides) error {
+	allowed := pj.Spec.RerunOverrides
+	if allowed == nil {
+		return errors.New("the job does not allow overrides")
+	}
+	if pj.Spec.PodSpec == nil || len(pj.Spec.PodSpec.Containers) == 0 {
+		return errors.New("overrides are only supported for jobs with a pod spec")
+	}
+	envNames := sets.List(sets.KeySet(overrides.Env))
+	var errs []error
+	for _, name := range envNames {
+		if !allowed.EnvAllowed(name) {
+			errs = append(errs, fmt.Errorf("environment variable %s may not be overridden", name))
+		}
+	}
+	for _, arg := range overrides.Args {
+		if !allowed.ArgAllowed(arg) {
+			errs = append(errs, fmt.Errorf("argument %s may not be added", arg))
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	// The pod spec may be shared with the original job.
+	podSpec := pj.Spec.PodSpec.DeepCopy()
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		for _, name := range envNames {
+			env := corev1.EnvVar{Name: name, Value: overrides.Env[name]}
+			found := false
+			for j := range container.Env {
+				if container.Env[j].Name == name {
+					container.Env[j] = env
+					found = true
+				}
+			}
+			if !found {
+				container.Env = append(container.Env, env)
+			}
+		}
+	}
+	podSpec.Containers[0].Args = append(podSpec.Containers[0].Args, overrides.Args...)
+	pj.Spec.PodSpec = podSpec
+	pj.Spec.Report = false
+
+	raw, err := json.Marshal(overrides)
+	if err != nil {
+		return fmt.Errorf("failed to marshal overrides: %w", err)
+	}
+	if pj.Labels == nil {
+		pj.Labels = map[string]string{}
+	}
+	pj.Labels[kube.ModifiedRerunLabel] = "true"
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[kube.RerunOverridesAnnotation] = string(raw)
+	return nil
+}
+
 // Valid value for query parameter mode in rerun route
 const (
 	LATEST = "latest"
@@ -242,7 +390,7 @@ const (
 // handleRerun triggers a rerun of the given job if that features is enabled, it receives a
 // POST request, and the user has the necessary permissions. Otherwise, it writes the config
 // for a new job but does not trigger it.
-func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, createProwJob bool, acfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, createProwJob bool, acfg authCfgGetter, goa *githuboauth.Agent, oa *oidcauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		name := r.URL.Query().Get("prowjob")
 		mode := r.URL.Query().Get("mode")
@@ -303,7 +451,7 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 				http.Error(w, "Direct rerun feature is not enabled. Enable with the '--rerun-creates-job' flag.", http.StatusMethodNotAllowed)
 				return
 			}
-			allowed, user, err, code := isAllowedToRerun(r, acfg, goa, ghc, newPJ, cli, pluginAgent, l)
+			allowed, user, err, code := isAllowedToRerun(r, acfg, goa, oa, ghc, newPJ, cli, pluginAgent, l)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Could not verify if allowed to rerun: %v.", err), code)
 				l.WithError(err).Debug("Could not verify if allowed to rerun.")
@@ -316,13 +464,37 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 				}
 				return
 			}
+			overrides, err := parseRerunOverrides(r)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid overrides: %v", err), http.StatusBadRequest)
+				return
+			}
+			var withOverrides string
+			if !overrides.empty() {
+				// Overrides must be attributable to a user, even if anyone may
+				// rerun the job.
+				if user == "" {
+					user = loggedInUser(r, goa, oa, ghc)
+				}
+				if user == "" {
+					http.Error(w, "Rerunning a job with overrides requires logging in.", http.StatusForbidden)
+					return
+				}
+				if err := applyRerunOverrides(&newPJ, overrides); err != nil {
+					http.Error(w, fmt.Sprintf("Invalid overrides: %v", err), http.StatusBadRequest)
+					return
+				}
+				withOverrides = " with overrides"
+				l = l.WithField("overrides", newPJ.Annotations[kube.RerunOverridesAnnotation])
+			}
 			var rerunDescription string
 			if len(user) > 0 {
-				rerunDescription = fmt.Sprintf("%v successfully reran %v.", user, name)
+				rerunDescription = fmt.Sprintf("%v successfully reran %v%s.", user, name, withOverrides)
 			} else {
 				rerunDescription = fmt.Sprintf("Successfully reran %v.", name)
 			}
 			newPJ.Status.Description = rerunDescription
+			setRerunProvenance(&newPJ, name, user)
 			created, err := prowJobClient.Create(context.TODO(), &newPJ, metav1.CreateOptions{})
 			if err != nil {
 				l.WithError(err).Error("Error creating job.")
@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
@@ -236,7 +238,7 @@ func TestRerun(t *testing.T) {
 					State: prowapi.PendingState,
 				},
 			})
-			authCfgGetter := func(refs *prowapi.ProwJobSpec) *prowapi.RerunAuthConfig {
+			authCfgGetter := func(*prowapi.ProwJob) *prowapi.RerunAuthConfig {
 				return &prowapi.RerunAuthConfig{
 					AllowAnyone: tc.allowAnyone,
 					GitHubUsers: tc.authorized,
@@ -273,7 +275,7 @@ func TestRerun(t *testing.T) {
 			cfg := func() *config.Config {
 				return &config.Config{ProwConfig: config.ProwConfig{Scheduler: config.Scheduler{Enabled: tc.enableScheduling}}}
 			}
-			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
+			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, authCfgGetter, goa, nil, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
 			handler.ServeHTTP(rr, req)
 			if rr.Code != tc.httpCode {
 				t.Fatalf("Bad error code: %d", rr.Code)
@@ -315,6 +317,236 @@ func TestRerun(t *testing.T) {
 	}
 }
 
+func TestApplyRerunOverrides(t *testing.T) {
+	spec := func() prowapi.ProwJobSpec {
+		return prowapi.ProwJobSpec{
+			Job:    "whoa",
+			Report: true,
+			PodSpec: &corev1.PodSpec{Containers: []corev1.Container{
+				{Args: []string{"test"}, Env: []corev1.EnvVar{{Name: "VERBOSE", Value: "false"}}},
+				{Name: "sidecar"},
+			}},
+			RerunOverrides: &prowapi.RerunOverrides{Env: []string{"VERBOSE", "FOCUS"}, Args: []string{"--focus", "-v"}},
+		}
+	}
+	testCases := []struct {
+		name        string
+		modify      func(*prowapi.ProwJobSpec)
+		overrides   rerunOverrides
+		expected    func(*prowapi.ProwJob)
+		expectedErr string
+	}{
+		{
+			name:      "overrides are applied",
+			overrides: rerunOverrides{Env: map[string]string{"VERBOSE": "true", "FOCUS": "e2e"}, Args: []string{"--focus=e2e", "-v"}},
+			expected: func(pj *prowapi.ProwJob) {
+				pj.Spec.Report = false
+				pj.Spec.PodSpec = &corev1.PodSpec{Containers: []corev1.Container{
+					{Args: []string{"test", "--focus=e2e", "-v"}, Env: []corev1.EnvVar{{Name: "VERBOSE", Value: "true"}, {Name: "FOCUS", Value: "e2e"}}},
+					{Name: "sidecar", Env: []corev1.EnvVar{{Name: "FOCUS", Value: "e2e"}, {Name: "VERBOSE", Value: "true"}}},
+				}}
+				pj.Labels = map[string]string{kube.ModifiedRerunLabel: "true"}
+				pj.Annotations = map[string]string{kube.RerunOverridesAnnotation: `{"env":{"FOCUS":"e2e","VERBOSE":"true"},"args":["--focus=e2e","-v"]}`}
+			},
+		},
+		{
+			name:        "job without rerun_overrides",
+			modify:      func(spec *prowapi.ProwJobSpec) { spec.RerunOverrides = nil },
+			overrides:   rerunOverrides{Env: map[string]string{"VERBOSE": "true"}},
+			expectedErr: "the job does not allow overrides",
+		},
+		{
+			name:        "job without pod spec",
+			modify:      func(spec *prowapi.ProwJobSpec) { spec.PodSpec = nil },
+			overrides:   rerunOverrides{Env: map[string]string{"VERBOSE": "true"}},
+			expectedErr: "overrides are only supported for jobs with a pod spec",
+		},
+		{
+			name:        "overrides that are not allowed",
+			overrides:   rerunOverrides{Env: map[string]string{"TOKEN": "secret"}, Args: []string{"--focusing", "--skip=e2e"}},
+			expectedErr: "[environment variable TOKEN may not be overridden, argument --focusing may not be added, argument --skip=e2e may not be added]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{Spec: spec()}
+			if tc.modify != nil {
+				tc.modify(&pj.Spec)
+			}
+			original := pj.DeepCopy()
+			err := applyRerunOverrides(pj, tc.overrides)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if diff := cmp.Diff(tc.expectedErr, errMsg); diff != "" {
+				t.Fatalf("error differs from expected (-want +got):\n%s", diff)
+			}
+			expected := original.DeepCopy()
+			if tc.expected != nil {
+				tc.expected(expected)
+			}
+			if diff := cmp.Diff(expected, pj); diff != "" {
+				t.Errorf("job differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetRerunProvenance(t *testing.T) {
+	pj := prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		kube.TriggeredByAnnotation:    "alice",
+		kube.TriggerCommentAnnotation: "https://github.com/org/repo/pull/1#issuecomment-1",
+		kube.ProwJobAnnotation:        "job",
+	}}}
+	setRerunProvenance(&pj, "original", "bob")
+	expected := map[string]string{
+		kube.TriggeredByAnnotation: "bob",
+		kube.RerunOfAnnotation:     "original",
+		kube.ProwJobAnnotation:     "job",
+	}
+	if diff := cmp.Diff(expected, pj.Annotations); diff != "" {
+		t.Errorf("annotations differ from expected:\n%s", diff)
+	}
+}
+
+func TestRerunWithOverrides(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        string
+		contentType string
+		allowAnyone bool
+		noOAuth     bool
+		httpCode    int
+		expectedEnv []corev1.EnvVar
+		unmodified  bool
+	}{
+		{
+			name:        "rerun with overrides",
+			body:        `{"env": {"FOCUS": "e2e"}}`,
+			httpCode:    http.StatusOK,
+			expectedEnv: []corev1.EnvVar{{Name: "FOCUS", Value: "e2e"}},
+		},
+		{
+			name:        "content type with parameters",
+			body:        `{"env": {"FOCUS": "e2e"}}`,
+			contentType: "application/json; charset=utf-8",
+			httpCode:    http.StatusOK,
+			expectedEnv: []corev1.EnvVar{{Name: "FOCUS", Value: "e2e"}},
+		},
+		{
+			name:        "overrides that are not JSON",
+			body:        `env=FOCUS`,
+			contentType: "application/x-www-form-urlencoded",
+			httpCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "empty form body has no overrides",
+			contentType: "application/x-www-form-urlencoded; charset=UTF-8",
+			httpCode:    http.StatusOK,
+			unmodified:  true,
+		},
+		{
+			name:     "overrides that are not allowed",
+			body:     `{"env": {"TOKEN": "secret"}}`,
+			httpCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid overrides",
+			body:     `{"env": ["FOCUS"]}`,
+			httpCode: http.StatusBadRequest,
+		},
+		{
+			name:        "overrides require a login",
+			body:        `{"env": {"FOCUS": "e2e"}}`,
+			allowAnyone: true,
+			noOAuth:     true,
+			httpCode:    http.StatusForbidden,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeProwJobClient := fake.NewSimpleClientset(&prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "wowsuch", Namespace: "prowjobs"},
+				Spec: prowapi.ProwJobSpec{
+					Job:            "whoa",
+					Type:           prowapi.PeriodicJob,
+					Report:         true,
+					PodSpec:        &corev1.PodSpec{Containers: []corev1.Container{{}}},
+					RerunOverrides: &prowapi.RerunOverrides{Env: []string{"FOCUS"}},
+				},
+			})
+			authCfgGetter := func(*prowapi.ProwJob) *prowapi.RerunAuthConfig {
+				return &prowapi.RerunAuthConfig{AllowAnyone: tc.allowAnyone, GitHubUsers: []string{"authorized"}}
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/rerun?prowjob=wowsuch", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("Error making request: %v", err)
+			}
+			if tc.contentType == "" {
+				tc.contentType = "application/json"
+			}
+			req.Header.Set("Content-Type", tc.contentType)
+			var goa *githuboauth.Agent
+			if !tc.noOAuth {
+				req.AddCookie(&http.Cookie{Name: "github_login", Value: "authorized", Path: "/"})
+				mockCookieStore := sessions.NewCookieStore([]byte("secret-key"))
+				session, err := sessions.GetRegistry(req).Get(mockCookieStore, "access-token-session")
+				if err != nil {
+					t.Fatalf("Error making access token session: %v", err)
+				}
+				session.Values["access-token"] = &oauth2.Token{AccessToken: "validtoken"}
+				goa = githuboauth.NewAgent(&githuboauth.Config{CookieStore: mockCookieStore}, &logrus.Entry{})
+			}
+			pca := plugins.NewFakeConfigAgent()
+			cfg := func() *config.Config { return &config.Config{} }
+			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), true, authCfgGetter, goa, nil, &fakeAuthenticatedUserIdentifier{login: "authorized"}, fakegithub.NewFakeClient(), &pca, logrus.WithField("handler", "/rerun"))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.httpCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.httpCode, rr.Code, rr.Body.String())
+			}
+
+			pjs, err := fakeProwJobClient.ProwV1().ProwJobs("prowjobs").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list prowjobs: %v", err)
+			}
+			var created []prowapi.ProwJob
+			for _, pj := range pjs.Items {
+				if pj.Name != "wowsuch" {
+					created = append(created, pj)
+				}
+			}
+			if tc.unmodified {
+				if len(created) != 1 || created[0].Spec.PodSpec.Containers[0].Env != nil || created[0].Labels[kube.ModifiedRerunLabel] != "" {
+					t.Errorf("expected one unmodified rerun, got %+v", created)
+				}
+				return
+			}
+			if tc.expectedEnv == nil {
+				if len(created) != 0 {
+					t.Errorf("expected no rerun, got %d", len(created))
+				}
+				return
+			}
+			if len(created) != 1 {
+				t.Fatalf("expected one rerun, got %d", len(created))
+			}
+			rerun := created[0]
+			if diff := cmp.Diff(tc.expectedEnv, rerun.Spec.PodSpec.Containers[0].Env); diff != "" {
+				t.Errorf("env differs from expected (-want +got):\n%s", diff)
+			}
+			if rerun.Spec.Report || rerun.Labels[kube.ModifiedRerunLabel] != "true" {
+				t.Errorf("expected a modified rerun that does not report, got report: %t, labels: %v", rerun.Spec.Report, rerun.Labels)
+			}
+			if expected := "authorized successfully reran wowsuch with overrides."; rerun.Status.Description != expected {
+				t.Errorf("expected description %q, got %q", expected, rerun.Status.Description)
+			}
+		})
+	}
+}
+
 // TestLatestRerun just checks that the result can be unmarshaled properly, has an
 // updated status, and has equal spec.
 func TestLatestRerun(t *testing.T) {
@@ -524,7 +756,7 @@ func TestLatestRerun(t *testing.T) {
 					State: prowapi.PendingState,
 				},
 			})
-			authCfgGetter := func(refs *prowapi.ProwJobSpec) *prowapi.RerunAuthConfig {
+			authCfgGetter := func(*prowapi.ProwJob) *prowapi.RerunAuthConfig {
 				return &prowapi.RerunAuthConfig{
 					AllowAnyone: tc.allowAnyone,
 					GitHubUsers: tc.authorized,
@@ -563,7 +795,7 @@ func TestLatestRerun(t *testing.T) {
 				cfg.Scheduler.Enabled = tc.enableScheduling
 				return cfg
 			}
-			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
+			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, authCfgGetter, goa, nil, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
 			handler.ServeHTTP(rr, req)
 			if rr.Code != tc.httpCode {
 				t.Fatalf("Bad error code: %d", rr.Code)
@@ -663,13 +895,18 @@ func TestCanTriggerJob(t *testing.T) {
 	trustedUser := "trusted"
 	untrustedUser := "untrusted"
 
+	repoTrustedUser := "repo-trusted"
+
 	pcfg := &plugins.Configuration{
 		Triggers: []plugins.Trigger{{Repos: []string{org}}},
+		RepoConfigs: map[string]*plugins.Configuration{
+			"org/repo": {Triggers: []plugins.Trigger{{Repos: []string{org}, TrustedOrg: "other"}}},
+		},
 	}
 	pcfgGetter := func() *plugins.Configuration { return pcfg }
 
 	ghc := fakegithub.NewFakeClient()
-	ghc.OrgMembers = map[string][]string{org: {trustedUser}}
+	ghc.OrgMembers = map[string][]string{org: {trustedUser}, "other": {repoTrustedUser}}
 
 	pj := prowapi.ProwJob{
 		Spec: prowapi.ProwJobSpec{
@@ -696,6 +933,11 @@ func TestCanTriggerJob(t *testing.T) {
 			user:          trustedUser,
 			expectAllowed: true,
 		},
+		{
+			name:          "User trusted by the repo options can re-run",
+			user:          repoTrustedUser,
+			expectAllowed: true,
+		},
 	}
 
 	log := logrus.NewEntry(logrus.StandardLogger())
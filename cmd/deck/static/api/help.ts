@@ -1,9 +1,22 @@
+export type ArgumentType = "string" | "int" | "enum" | "user" | "context" | "text";
+
+export interface Argument {
+  Name: string;
+  Type: ArgumentType;
+  Description?: string;
+  Optional?: boolean;
+  Repeated?: boolean;
+  Values?: string[];
+}
+
 export interface Command {
   Usage: string;
   Featured: boolean;
   Description: string;
   Examples: string[];
   WhoCanUse: string;
+  Name?: string;
+  Arguments?: Argument[];
 }
 
 export interface PluginHelp {
@@ -1,5 +1,6 @@
 export type ProwJobType = "presubmit" | "postsubmit" | "batch" | "periodic";
 export type ProwJobState = "triggered" | "pending" | "success" | "failure" | "aborted" | "error" | "unknown" | "";
+export type ProwJobFailureReason = "infra-error" | "clone-failure" | "timeout" | "test-failure" | "pod-evicted" | "preempted" | "aborted" | "";
 export type ProwJobAgent = "kubernetes" | "jenkins" | "tekton-pipeline";
 
 // Pull describes a pull request at a particular point in time.
@@ -98,7 +99,9 @@ export interface ProwJobSpec {
   context?: string;
   rerun_command?: string;
   max_concurrency?: number;
+  priority_class?: string;
   error_on_eviction?: boolean;
+  retry?: RetryConfig;
   pod_spec?: PodSpec;
   build_spec?: object;
   jenkins_spec?: object;
@@ -117,11 +120,22 @@ export interface ProwJobStatus {
   completionTime?: string;
   state?: ProwJobState;
   description?: string;
+  failure_reason?: ProwJobFailureReason;
+  failure_message?: string;
   url?: string;
   pod_name?: string;
   build_id?: string;
   jenkins_build_id?: string;
   prev_report_states?: { [key: string]: ProwJobState };
+  retries?: number;
+  next_retry_time?: string;
+}
+
+// RetryConfig mirrors the RetryConfig struct defined in prow/apis/prowjobs/v1/types.go.
+export interface RetryConfig {
+  max_retries?: number;
+  backoff?: string;
+  retry_on?: ProwJobState[];
 }
 
 // PodSpec is a description of a pod.
@@ -41,8 +41,28 @@ export interface TidePool {
   Blockers: Blocker[];
 }
 
+export interface MergeTrainCar {
+  Number: number;
+  Title: string;
+  Author: string;
+  SHA: string;
+  Batch: number;
+  ETA?: string;
+}
+
+export interface MergeTrain {
+  Org: string;
+  Repo: string;
+  Branch: string;
+
+  Cars: MergeTrainCar[];
+  // TestDuration is in nanoseconds.
+  TestDuration: number;
+}
+
 export interface TideData {
   Queries: string[];
   TideQueries: TideQuery[];
   Pools: TidePool[];
+  MergeTrains?: MergeTrain[];
 }
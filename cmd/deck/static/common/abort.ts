@@ -1,7 +1,35 @@
-import {ProwJobState} from "../api/prow";
+import {ProwJobState, Pull} from "../api/prow";
 import {showAlert, showToast, State} from "./common";
 
-export function createAbortProwJobIcon(modal: HTMLElement, parentEl: Element, job: string, state: ProwJobState, prowjob: string, csrfToken: string): HTMLElement {
+// AbortPull identifies the pull request of a presubmit, whose jobs can all be
+// aborted at once.
+export interface AbortPull {
+  org: string;
+  repo: string;
+  pull: Pull;
+}
+
+async function postAbort(url: string, csrfToken: string, what: string): Promise<void> {
+  try {
+    const result = await fetch(url, {
+      headers: {
+        'Content-type': 'application/x-www-form-urlencoded; charset=UTF-8',
+        'X-CSRF-Token': csrfToken,
+      },
+      method: 'post',
+    });
+    const data = await result.text();
+    if (result.status >= 400) {
+      showAlert(data);
+    } else {
+      showToast(data);
+    }
+  } catch (e) {
+    showAlert(`Could not send request to abort ${what}: ${e}`);
+  }
+}
+
+export function createAbortProwJobIcon(modal: HTMLElement, parentEl: Element, job: string, state: ProwJobState, prowjob: string, csrfToken: string, abortPull?: AbortPull): HTMLElement {
   const url = `${location.protocol}//${location.host}/abort?prowjob=${prowjob}`;
   const abortButton = document.createElement('button');
   abortButton.classList.add('mdl-button', 'mdl-js-button', 'mdl-button--icon');
@@ -51,24 +79,22 @@ export function createAbortProwJobIcon(modal: HTMLElement, parentEl: Element, jo
         event_category: 'engagement',
         transport_type: 'beacon',
       });
-      try {
-        const result = await fetch(url, {
-          headers: {
-            'Content-type': 'application/x-www-form-urlencoded; charset=UTF-8',
-            'X-CSRF-Token': csrfToken,
-          },
-          method: 'post',
-        });
-        const data = await result.text();
-        if (result.status >= 400) {
-          showAlert(data);
-        } else {
-          showToast(data);
-        }
-      } catch (e) {
-        showAlert(`Could not send request to abort job: ${e}`);
-      }
+      await postAbort(url, csrfToken, "job");
     };
+    if (abortPull) {
+      const {org, repo, pull} = abortPull;
+      const abortPRButton = document.createElement('a');
+      abortPRButton.innerHTML = `<button class='mdl-button mdl-js-button mdl-button--raised mdl-button--colored' title='Abort all pending jobs of ${org}/${repo}#${pull.number} at ${pull.sha.substring(0, 7)}'>Abort all for PR</button>`;
+      buttonDiv.insertBefore(abortPRButton, cancelAbortButton);
+      abortPRButton.onclick = async () => {
+        gtag('event', 'abort_pr', {
+          event_category: 'engagement',
+          transport_type: 'beacon',
+        });
+        const params = new URLSearchParams({org, repo, pr: String(pull.number), sha: pull.sha});
+        await postAbort(`${location.protocol}//${location.host}/abort-pr?${params.toString()}`, csrfToken, "jobs");
+      };
+    }
     cancelAbortButton.onclick = closeModal;
   };
   return abortButton;
@@ -0,0 +1,81 @@
+/**
+ * isLabelPattern returns whether a label of a Tide query is a glob pattern.
+ * It mirrors isLabelPattern of the Tide config.
+ */
+export function isLabelPattern(label: string): boolean {
+  return /[*?[\\]/.test(label);
+}
+
+function escapeRegExp(s: string): string {
+  return s.replace(/[.*+?^${}()|[\]\\/-]/g, "\\$&");
+}
+
+/**
+ * globToRegExp converts a pattern with the syntax of Go's path.Match into a
+ * regular expression, or returns null if the pattern is malformed.
+ */
+function globToRegExp(pattern: string): RegExp | null {
+  let re = "";
+  for (let i = 0; i < pattern.length; i++) {
+    const c = pattern[i];
+    if (c === "*") {
+      re += "[^/]*";
+    } else if (c === "?") {
+      re += "[^/]";
+    } else if (c === "\\") {
+      i++;
+      if (i >= pattern.length) {
+        return null;
+      }
+      re += escapeRegExp(pattern[i]);
+    } else if (c === "[") {
+      let j = i + 1;
+      let negated = false;
+      if (pattern[j] === "^") {
+        negated = true;
+        j++;
+      }
+      let charClass = "";
+      for (; j < pattern.length && pattern[j] !== "]"; j++) {
+        if (pattern[j] === "\\") {
+          j++;
+          if (j >= pattern.length) {
+            return null;
+          }
+          charClass += escapeRegExp(pattern[j]);
+        } else if (pattern[j] === "-") {
+          charClass += "-";
+        } else {
+          charClass += escapeRegExp(pattern[j]);
+        }
+      }
+      if (j >= pattern.length || charClass === "") {
+        return null;
+      }
+      re += `[${negated ? "^" : ""}${charClass}]`;
+      i = j;
+    } else {
+      re += escapeRegExp(c);
+    }
+  }
+  try {
+    return new RegExp(`^${re}$`);
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * labelMatches returns whether the label is the missing label of a Tide query
+ * or matches it as a pattern, the same way as TideQuery.IsMissingLabel.
+ */
+export function labelMatches(missingLabel: string, label: string): boolean {
+  if (missingLabel === label) {
+    return true;
+  }
+  if (!isLabelPattern(missingLabel)) {
+    return false;
+  }
+  const re = globToRegExp(missingLabel);
+  return re !== null && re.test(label);
+}
@@ -0,0 +1,35 @@
+import "jasmine";
+import {isLabelPattern, labelMatches} from "./labels";
+
+describe('isLabelPattern', () => {
+  it('should detect glob patterns', () => {
+    expect(isLabelPattern("do-not-merge/*")).toBe(true);
+    expect(isLabelPattern("needs-rebase?")).toBe(true);
+    expect(isLabelPattern("[ab]")).toBe(true);
+    expect(isLabelPattern("do-not-merge/hold")).toBe(false);
+  });
+});
+
+describe('labelMatches', () => {
+  it('should match plain labels exactly', () => {
+    expect(labelMatches("do-not-merge/hold", "do-not-merge/hold")).toBe(true);
+    expect(labelMatches("do-not-merge/hold", "do-not-merge/hold-on")).toBe(false);
+  });
+  it('should match labels against patterns', () => {
+    expect(labelMatches("do-not-merge/*", "do-not-merge/hold")).toBe(true);
+    expect(labelMatches("do-not-merge/*", "do-not-merge/work-in-progress")).toBe(true);
+    expect(labelMatches("do-not-merge/*", "lgtm")).toBe(false);
+    expect(labelMatches("do-not-merge/*", "do-not-merge/a/b")).toBe(false);
+    expect(labelMatches("size/?", "size/L")).toBe(true);
+    expect(labelMatches("size/?", "size/XL")).toBe(false);
+    expect(labelMatches("size/[LM]", "size/M")).toBe(true);
+    expect(labelMatches("size/[^LM]", "size/M")).toBe(false);
+    expect(labelMatches("release-note-[a-z]*", "release-note-none")).toBe(true);
+    expect(labelMatches("a.b*", "axb")).toBe(false);
+  });
+  it('should not match malformed patterns', () => {
+    expect(labelMatches("do-not-merge/[", "do-not-merge/[")).toBe(true);
+    expect(labelMatches("do-not-merge/[*", "do-not-merge/x")).toBe(false);
+    expect(labelMatches("trailing\\", "trailing")).toBe(false);
+  });
+});
@@ -1,6 +1,38 @@
 import {copyToClipboard, icon, showAlert, showToast} from "./common";
 import {relativeURL} from "./urls";
 
+// loginPath is the page users log in at, set in base.html.
+declare const loginPath: string;
+
+// RerunOverrides mirrors the rerunOverrides struct defined in cmd/deck/rerun.go.
+export interface RerunOverrides {
+  env?: {[name: string]: string};
+  args?: string[];
+}
+
+// parseRerunOverrides reads the overrides from the text areas of the rerun
+// modal, which hold one NAME=value environment variable or one argument per
+// line. It returns undefined if no overrides were requested.
+export function parseRerunOverrides(env: string, args: string): RerunOverrides | undefined {
+  const overrides: RerunOverrides = {};
+  for (const line of env.split("\n").map((l) => l.trim()).filter((l) => l !== "")) {
+    const i = line.indexOf("=");
+    if (i <= 0) {
+      throw new Error(`Environment variable ${line} must be written as NAME=value.`);
+    }
+    overrides.env = overrides.env || {};
+    overrides.env[line.substring(0, i)] = line.substring(i + 1);
+  }
+  const argList = args.split("\n").map((l) => l.trim()).filter((l) => l !== "");
+  if (argList.length > 0) {
+    overrides.args = argList;
+  }
+  if (!overrides.env && !overrides.args) {
+    return undefined;
+  }
+  return overrides;
+}
+
 export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, prowjob: string, showRerunButton: boolean, csrfToken: string): HTMLElement {
   const LATEST_JOB = 'latest';
   const ORIGINAL_JOB = 'original';
@@ -125,6 +157,22 @@ export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, pr
     });
 
     if (showRerunButton) {
+      const overridesElement = document.createElement('div');
+      overridesElement.className = 'rerunModal-overrides';
+      overridesElement.innerHTML = `
+        <p class="rerunModal-overridesDescription">
+          Jobs that set <code>rerun_overrides</code> can be rerun with other environment variables and additional
+          arguments. Reruns with overrides require logging in and never report their status.
+        </p>
+        <label class="rerunModal-overridesLabel" for="rerunOverridesEnv">Environment variables, one NAME=value per line</label>
+        <textarea id="rerunOverridesEnv" class="rerunModal-overridesEnv" rows="3"></textarea>
+        <label class="rerunModal-overridesLabel" for="rerunOverridesArgs">Additional arguments, one per line</label>
+        <textarea id="rerunOverridesArgs" class="rerunModal-overridesArgs" rows="3"></textarea>
+      `;
+      parentEl.appendChild(overridesElement);
+      const envOverrides = overridesElement.querySelector<HTMLTextAreaElement>('.rerunModal-overridesEnv')!;
+      const argOverrides = overridesElement.querySelector<HTMLTextAreaElement>('.rerunModal-overridesArgs')!;
+
       const runButton = document.createElement('a');
       runButton.innerHTML = "<button class='mdl-button mdl-js-button mdl-button--raised mdl-button--colored'>Rerun</button>";
       runButton.onclick = async () => {
@@ -132,8 +180,22 @@ export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, pr
           event_category: "engagement",
           transport_type: "beacon",
         });
+        let overrides: RerunOverrides | undefined;
         try {
-          const result = await fetch(commandURL, {
+          overrides = parseRerunOverrides(envOverrides.value, argOverrides.value);
+        } catch (e) {
+          showAlert(e.message);
+          return;
+        }
+        try {
+          const result = await fetch(commandURL, overrides ? {
+            body: JSON.stringify(overrides),
+            headers: {
+              "Content-type": "application/json",
+              "X-CSRF-Token": csrfToken,
+            },
+            method: 'post',
+          } : {
             headers: {
               "Content-type": "application/x-www-form-urlencoded; charset=UTF-8",
               "X-CSRF-Token": csrfToken,
@@ -141,7 +203,7 @@ export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, pr
             method: 'post',
           });
           if (result.status === 401) {
-            window.location.href = `${window.location.origin  }/github-login?dest=${relativeURL({rerun: "gh_redirect"})}`;
+            window.location.href = `${window.location.origin  }${loginPath}?dest=${relativeURL({rerun: "gh_redirect"})}`;
           }
           const data = await result.text();
           if (result.status >= 400) {
@@ -5,6 +5,7 @@ import {Label, PullRequest, UserData} from '../api/pr';
 import {ProwJob, ProwJobList, ProwJobState} from '../api/prow';
 import {Blocker, TideData, TidePool, TideQuery as ITideQuery} from '../api/tide';
 import {getCookieByName, tidehistory} from '../common/common';
+import {labelMatches} from '../common/labels';
 import {parseQuery, relativeURL} from "../common/urls";
 
 declare const tideData: TideData;
@@ -24,6 +25,8 @@ interface UnifiedContext {
 interface ProcessedLabel {
   name: string;
   own: boolean;
+  // match is the label of the PR that matches a missing label pattern.
+  match?: string;
 }
 
 interface ProcessedQuery {
@@ -110,7 +113,8 @@ function submitQuery(input: HTMLInputElement | HTMLTextAreaElement) {
  */
 function createXMLHTTPRequest(fulfillFn: (request: XMLHttpRequest) => any, errorHandler: () => any): XMLHttpRequest {
   const request = new XMLHttpRequest();
-  const url = "/pr-data.js";
+  // Relative, so that the page of a tenant requests the PRs of the tenant.
+  const url = "pr-data.js";
   request.onreadystatechange = () => {
     if (request.readyState === 4 && request.status === 200) {
       fulfillFn(request);
@@ -748,7 +752,7 @@ function createLabelEl(label: string): HTMLElement {
 function createMergeLabelCell(labels: ProcessedLabel[], notMissingLabel = false): HTMLElement {
   const cell = document.createElement("td");
   labels.forEach((label) => {
-    const labelEl = createLabelEl(label.name);
+    const labelEl = createLabelEl(label.match !== undefined ? label.match : label.name);
     const toDisplay = label.own !== notMissingLabel;
     if (toDisplay) {
       cell.appendChild(labelEl);
@@ -1060,12 +1064,13 @@ function compareJobFn(a: UnifiedContext, b: UnifiedContext): number {
  * closestMatchingQueries returns a list of processed TideQueries that match the PR in descending order of likeliness.
  */
 function closestMatchingQueries(pr: PullRequest, queries: TideQuery[]): ProcessedQuery[] {
-  const prLabelsSet = new Set();
+  const prLabels: string[] = [];
   if (pr.Labels && pr.Labels.Nodes) {
     pr.Labels.Nodes.forEach((label) => {
-      prLabelsSet.add(label.Label.Name);
+      prLabels.push(label.Label.Name);
     });
   }
+  const prLabelsSet = new Set(prLabels);
   const processedQueries: ProcessedQuery[] = [];
   queries.forEach((query) => {
     let score = 0.0;
@@ -1088,7 +1093,9 @@ function closestMatchingQueries(pr: PullRequest, queries: TideQuery[]): Processe
       score += labels[labels.length - 1].own ? 1 : 0;
     });
     (query.missingLabels || []).forEach((label) => {
-      missingLabels.push({name: label, own: prLabelsSet.has(label)});
+      // Missing labels may be patterns.
+      const match = prLabels.find((prLabel) => labelMatches(label, prLabel));
+      missingLabels.push({name: label, own: match !== undefined, match});
       score += missingLabels[missingLabels.length - 1].own ? 0 : 1;
     });
     score = (labels.length + missingLabels.length > 0) ? score
@@ -1,6 +1,6 @@
 import moment from "moment";
 import {ProwJob, ProwJobList, ProwJobState, ProwJobType, Pull} from "../api/prow";
-import {createAbortProwJobIcon} from "../common/abort";
+import {AbortPull, createAbortProwJobIcon} from "../common/abort";
 import {cell, formatDuration, icon} from "../common/common";
 import {createRerunProwJobIcon} from "../common/rerun";
 import {getParameterByName} from "../common/urls";
@@ -32,6 +32,7 @@ interface RepoOptions {
   authors: {[key: string]: boolean};
   pulls: {[key: string]: boolean};
   states: {[key: string]: boolean};
+  reasons: {[key: string]: boolean};
   clusters: {[key: string]: boolean};
 }
 
@@ -41,6 +42,7 @@ function optionsForRepo(repository: string): RepoOptions {
     clusters: {},
     jobs: {},
     pulls: {},
+    reasons: {},
     repos: {},
     states: {},
     types: {},
@@ -58,12 +60,16 @@ function optionsForRepo(repository: string): RepoOptions {
       },
       status: {
         state = "",
+        failure_reason = "",
       },
     } = build;
 
     opts.types[type] = true;
     opts.clusters[cluster] = true;
     opts.states[state] = true;
+    if (failure_reason) {
+      opts.reasons[failure_reason] = true;
+    }
 
 
     const repoKey = `${org}/${repo}`;
@@ -107,6 +113,8 @@ function redrawOptions(fz: FuzzySearch, opts: RepoOptions) {
   }
   const ss = Object.keys(opts.states).sort();
   addOptions(ss, "state");
+  const fs = Object.keys(opts.reasons).sort();
+  addOptions(fs, "reason");
   const cs = Object.keys(opts.clusters).sort();
   addOptions(cs, "cluster");
 }
@@ -478,13 +486,14 @@ function redraw(fz: FuzzySearch, pushState = true): void {
   const authorSel = getSelection("author");
   const jobSel = getSelectionFuzzySearch("job", "job-input");
   const stateSel = getSelection("state");
+  const reasonSel = getSelection("reason");
   const clusterSel = getSelection("cluster");
 
   if (pushState && window.history && window.history.pushState !== undefined) {
     if (args.length > 0) {
-      history.pushState(null, "", `/?${  args.join('&')}`);
+      history.pushState(null, "", `${window.location.pathname}?${  args.join('&')}`);
     } else {
-      history.pushState(null, "", "/");
+      history.pushState(null, "", window.location.pathname);
     }
   }
   fz.setDict(Object.keys(opts.jobs));
@@ -513,7 +522,7 @@ function redraw(fz: FuzzySearch, pushState = true): void {
         refs: {repo_link = "", base_sha = "", base_link = "", pulls = [], base_ref = ""} = {},
         pod_spec,
       },
-      status: {startTime, completionTime = "", state = "", pod_name, build_id = "", url = ""},
+      status: {startTime, completionTime = "", state = "", failure_reason = "", pod_name, build_id = "", url = ""},
     } = build;
 
     let buildUrl = url;
@@ -540,6 +549,9 @@ function redraw(fz: FuzzySearch, pushState = true): void {
     if (!equalSelected(stateSel, state)) {
       continue;
     }
+    if (!equalSelected(reasonSel, failure_reason)) {
+      continue;
+    }
     if (!equalSelected(clusterSel, cluster)) {
       continue;
     }
@@ -609,13 +621,21 @@ function redraw(fz: FuzzySearch, pushState = true): void {
     displayedJob++;
     const r = document.createElement("tr");
     // State column
-    r.appendChild(cell.state(state));
+    const stateCell = cell.state(state);
+    if (build.status.retries) {
+      stateCell.title += ` (retry ${build.status.retries})`;
+    }
+    if (failure_reason === "preempted") {
+      stateCell.title += " (preempted by a job of a higher priority)";
+    }
+    r.appendChild(stateCell);
     // Log column
     r.appendChild(createLogCell(build, buildUrl));
     // Rerun column
     r.appendChild(createRerunCell(modal, modalContent, prowJobName));
     // Abort column
-    r.appendChild(createAbortCell(modal, modalContent, job, state, prowJobName));
+    const abortPull = type === "presubmit" && pulls.length ? {org, repo, pull: pulls[0]} : undefined;
+    r.appendChild(createAbortCell(modal, modalContent, job, state, prowJobName, abortPull));
     // Job Yaml column
     r.appendChild(createViewJobCell(prowJobName));
     // Repository column
@@ -667,11 +687,16 @@ function redraw(fz: FuzzySearch, pushState = true): void {
       r.appendChild(cell.text(''));
     }
     // Results column
-    if (buildUrl === "") {
-      r.appendChild(cell.text(job));
-    } else {
-      r.appendChild(cell.link(job, buildUrl));
-    }
+    const resultsCell = buildUrl === "" ? cell.text(job) : cell.link(job, buildUrl);
+    const runAfterSuccess = build.metadata.annotations ? build.metadata.annotations["prow.k8s.io/run-after-success"] : "";
+    if (runAfterSuccess) {
+      // Jobs triggered by the dependent-jobs controller show the jobs they ran after.
+      const dependencies = document.createElement("span");
+      dependencies.className = "run-after-success";
+      dependencies.textContent = ` (after ${runAfterSuccess.split(",").join(", ")})`;
+      resultsCell.appendChild(dependencies);
+    }
+    r.appendChild(resultsCell);
     // Started column
     r.appendChild(cell.time(i.toString(), moment.unix(started)));
     // Duration column
@@ -727,9 +752,9 @@ function redraw(fz: FuzzySearch, pushState = true): void {
   componentHandler.upgradeDom();
 }
 
-function createAbortCell(modal: HTMLElement, modalContent: Element, job: string, state: ProwJobState, prowjob: string): HTMLTableCellElement {
+function createAbortCell(modal: HTMLElement, modalContent: Element, job: string, state: ProwJobState, prowjob: string, abortPull?: AbortPull): HTMLTableCellElement {
   const c = document.createElement("td");
-  c.appendChild(createAbortProwJobIcon(modal, modalContent, job, state, prowjob, csrfToken));
+  c.appendChild(createAbortProwJobIcon(modal, modalContent, job, state, prowjob, csrfToken, abortPull));
   return c;
 }
 
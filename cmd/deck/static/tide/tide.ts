@@ -1,5 +1,6 @@
-import {PullRequest, TideData, TidePool} from '../api/tide';
+import {MergeTrain, MergeTrainCar, PullRequest, TideData, TidePool} from '../api/tide';
 import {tidehistory, tooltip} from '../common/common';
+import {isLabelPattern} from '../common/labels';
 
 declare const tideData: TideData;
 
@@ -26,6 +27,7 @@ function infoToggle(toToggle: HTMLElement): (event: Event) => void {
 function redraw(): void {
   redrawQueries();
   redrawPools();
+  redrawMergeTrains();
 }
 
 function createLink(href: string, text: string): HTMLAnchorElement {
@@ -74,6 +76,17 @@ function createLabelEl(label: string): HTMLElement {
   return el;
 }
 
+/**
+ * Creates a HTML element for a pattern that matches labels
+ */
+function createLabelPatternEl(pattern: string): HTMLElement {
+  const el = document.createElement("code");
+  el.title = "Any label matching this pattern";
+  el.textContent = pattern;
+
+  return el;
+}
+
 function createStrong(text: string): HTMLElement {
   const s = document.createElement("strong");
   s.appendChild(document.createTextNode(text));
@@ -182,7 +195,7 @@ function redrawQueries(): void {
     // required labels
     fillDetail(tideQuery.labels, "labels", "with ", li, (data) => createLabelEl(data));
     // required to be not present labels
-    fillDetail(tideQuery.missingLabels, "labels", "without ", li, (data) => createLabelEl(data));
+    fillDetail(tideQuery.missingLabels, "labels", "without ", li, (data) => isLabelPattern(data) ? createLabelPatternEl(data) : createLabelEl(data));
     // list milestone if existed
     fillDetail(tideQuery.milestone, "milestone", "with ", li, (data) => document.createTextNode(data));
     // list all excluded branches
@@ -228,6 +241,65 @@ function redrawPools(): void {
   }
 }
 
+function redrawMergeTrains(): void {
+  const trains = document.getElementById("merge-trains")!.getElementsByTagName("tbody")[0];
+  while (trains.firstChild) {
+    trains.removeChild(trains.firstChild);
+  }
+
+  if (!tideData.MergeTrains) {
+    return;
+  }
+  for (const train of tideData.MergeTrains) {
+    if (!train.Cars) {
+      continue;
+    }
+    for (const car of train.Cars) {
+      const r = document.createElement("tr");
+
+      r.appendChild(createRepoCell(train));
+      r.appendChild(createCarCell(train, car));
+      const batchTD = document.createElement("td");
+      batchTD.appendChild(document.createTextNode(String(car.Batch + 1)));
+      r.appendChild(batchTD);
+      const etaTD = document.createElement("td");
+      etaTD.appendChild(document.createTextNode(formatETA(car.ETA)));
+      r.appendChild(etaTD);
+
+      trains.appendChild(r);
+    }
+  }
+}
+
+function createCarCell(train: MergeTrain, car: MergeTrainCar): HTMLTableDataCellElement {
+  const td = document.createElement("td");
+  const a = createLink(`/github-link?dest=${train.Org}/${train.Repo}/pull/${car.Number}`, `#${car.Number}`);
+  a.id = `car-${train.Org}-${train.Repo}-${car.Number}-${nextID()}`;
+  if (car.Title) {
+    a.appendChild(tooltip.forElem(a.id, document.createTextNode(car.Title)));
+  }
+  td.appendChild(a);
+  if (car.Author) {
+    td.appendChild(document.createTextNode(` by ${car.Author}`));
+  }
+  return td;
+}
+
+// formatETA formats the predicted merge time relative to now.
+function formatETA(eta?: string): string {
+  if (!eta) {
+    return "unknown";
+  }
+  const minutes = Math.round((new Date(eta).getTime() - Date.now()) / 60000);
+  if (minutes <= 0) {
+    return "now";
+  }
+  if (minutes < 60) {
+    return `in ${minutes} min`;
+  }
+  return `in ${Math.floor(minutes / 60)} h ${minutes % 60} min`;
+}
+
 function createHistoryCell(pool: TidePool): HTMLTableDataCellElement {
   const td = document.createElement("td");
   td.classList.add("icon-cell");
@@ -235,8 +307,8 @@ function createHistoryCell(pool: TidePool): HTMLTableDataCellElement {
   return td;
 }
 
-function createRepoCell(pool: TidePool): HTMLTableDataCellElement {
-  const deckLink = `/?repo=${  encodeURIComponent(`${pool.Org}/${pool.Repo}`)}`;
+function createRepoCell(pool: TidePool | MergeTrain): HTMLTableDataCellElement {
+  const deckLink = `./?repo=${  encodeURIComponent(`${pool.Org}/${pool.Repo}`)}`;
   const branchLink = `/github-link?dest=${pool.Org}/${pool.Repo}/tree/${pool.Branch}`;
   const linksTD = document.createElement("td");
   linksTD.appendChild(createLink(deckLink, `${pool.Org}/${pool.Repo}`));
@@ -276,7 +348,7 @@ function createBatchCell(pool: TidePool): HTMLTableDataCellElement {
     const numbers = pool.BatchPending.map((p) => String(p.Number));
     const batchRef = `${pool.Branch},${numbers.join(',')}`;
     const encodedRepo = encodeURIComponent(`${pool.Org}/${pool.Repo}`);
-    const href = `/?repo=${encodedRepo}&type=batch&pull=${encodeURIComponent(batchRef)}`;
+    const href = `./?repo=${encodedRepo}&type=batch&pull=${encodeURIComponent(batchRef)}`;
     const link = document.createElement('a');
     link.href = href;
     for (let i = 0; i < pool.BatchPending.length; i++) {
@@ -0,0 +1,451 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/clusterhealth"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+const (
+	componentHealthy   = "healthy"
+	componentUnhealthy = "unhealthy"
+
+	// processStartTimeMetric is exposed by the process collector of every
+	// Prow component and used to determine their uptime.
+	processStartTimeMetric = "process_start_time_seconds"
+)
+
+// componentStatus is the status of a Prow component as observed by Deck.
+type componentStatus struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	// Errors lists why the state of the component could not be determined
+	// or why it is unhealthy.
+	Errors      []string  `json:"errors,omitempty"`
+	LastChecked time.Time `json:"last_checked"`
+	// StartTime is when the process of the component started.
+	StartTime *time.Time `json:"start_time,omitempty"`
+	// LastSync is when the sync metric of the component was last seen
+	// increasing. It is unset until Deck observes a sync.
+	LastSync *time.Time `json:"last_sync,omitempty"`
+	// ErrorsPerMinute is the rate at which the error metric of the component
+	// increased since it was previously checked.
+	ErrorsPerMinute *float64 `json:"errors_per_minute,omitempty"`
+	// BuildClusters is the health of the build clusters, as exposed by the
+	// cluster-health controller of prow-controller-manager.
+	BuildClusters []buildClusterStatus `json:"build_clusters,omitempty"`
+}
+
+// buildClusterStatus is the health of a build cluster as of its last probe.
+type buildClusterStatus struct {
+	Name          string   `json:"name"`
+	Schedulable   bool     `json:"schedulable"`
+	ReadyNodes    *int     `json:"ready_nodes,omitempty"`
+	FailingChecks []string `json:"failing_checks,omitempty"`
+}
+
+// metricSample holds the values of the sync and error metrics of a component
+// to compute their change on the next check.
+type metricSample struct {
+	at                  time.Time
+	syncs, errors       float64
+	hasSyncs, hasErrors bool
+}
+
+type statusAgent struct {
+	log          *logrus.Entry
+	client       *http.Client
+	components   func() []config.StatusComponent
+	updatePeriod func() time.Duration
+	now          func() time.Time
+
+	sync.Mutex
+	statuses map[string]componentStatus
+	samples  map[string]metricSample
+}
+
+func newStatusAgent(cfg config.Getter) *statusAgent {
+	return &statusAgent{
+		log:    logrus.WithField("agent", "status"),
+		client: &http.Client{Timeout: 10 * time.Second},
+		components: func() []config.StatusComponent {
+			return cfg().Deck.StatusComponents
+		},
+		updatePeriod: func() time.Duration {
+			if period := cfg().Deck.StatusUpdatePeriod; period != nil {
+				return period.Duration
+			}
+			return 30 * time.Second
+		},
+		now:      time.Now,
+		statuses: map[string]componentStatus{},
+		samples:  map[string]metricSample{},
+	}
+}
+
+func (sa *statusAgent) start() {
+	go func() {
+		for {
+			start := time.Now()
+			sa.update()
+			time.Sleep(time.Until(start.Add(sa.updatePeriod())))
+		}
+	}()
+}
+
+// update checks all configured components concurrently.
+func (sa *statusAgent) update() {
+	components := sa.components()
+	sa.Lock()
+	samples := make(map[string]metricSample, len(sa.samples))
+	for name, sample := range sa.samples {
+		samples[name] = sample
+	}
+	previous := make(map[string]componentStatus, len(sa.statuses))
+	for name, status := range sa.statuses {
+		previous[name] = status
+	}
+	sa.Unlock()
+
+	statuses := make([]componentStatus, len(components))
+	newSamples := make([]metricSample, len(components))
+	var wg sync.WaitGroup
+	for i := range components {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			component := components[i]
+			statuses[i], newSamples[i] = sa.check(component, previous[component.Name], samples[component.Name])
+		}(i)
+	}
+	wg.Wait()
+
+	sa.Lock()
+	defer sa.Unlock()
+	// Components removed from the config are dropped.
+	sa.statuses = make(map[string]componentStatus, len(components))
+	sa.samples = make(map[string]metricSample, len(components))
+	for i, component := range components {
+		sa.statuses[component.Name] = statuses[i]
+		sa.samples[component.Name] = newSamples[i]
+	}
+}
+
+// check determines the status of a single component, using its previous
+// status and metric sample to determine its last sync and error rate.
+func (sa *statusAgent) check(component config.StatusComponent, previous componentStatus, prevSample metricSample) (componentStatus, metricSample) {
+	now := sa.now()
+	status := componentStatus{
+		Name:        component.Name,
+		State:       componentHealthy,
+		LastChecked: now,
+		LastSync:    previous.LastSync,
+	}
+	sample := metricSample{at: now}
+
+	if component.HealthURL != "" {
+		if err := sa.checkHealth(component.HealthURL); err != nil {
+			status.State = componentUnhealthy
+			status.Errors = append(status.Errors, fmt.Sprintf("health check failed: %v", err))
+		}
+	}
+
+	if component.MetricsURL == "" {
+		return status, sample
+	}
+	families, err := sa.scrape(component.MetricsURL)
+	if err != nil {
+		status.State = componentUnhealthy
+		status.Errors = append(status.Errors, fmt.Sprintf("failed to scrape metrics: %v", err))
+		// Keep the previous sample so rates can be computed once the
+		// component can be scraped again.
+		return status, prevSample
+	}
+
+	status.BuildClusters = buildClusterStatuses(families)
+
+	if startTime, ok := metricValue(families, processStartTimeMetric); ok {
+		t := time.Unix(int64(startTime), 0)
+		status.StartTime = &t
+	}
+
+	if component.SyncMetric != "" {
+		syncs, ok := metricValue(families, component.SyncMetric)
+		if !ok {
+			status.Errors = append(status.Errors, fmt.Sprintf("sync metric %q not found", component.SyncMetric))
+		} else {
+			sample.syncs, sample.hasSyncs = syncs, true
+			// Any change counts as a sync, a lower value means that the
+			// component restarted and synced since.
+			if prevSample.hasSyncs && syncs != prevSample.syncs {
+				status.LastSync = &now
+			}
+		}
+	}
+
+	if component.ErrorMetric != "" {
+		errors, ok := metricValue(families, component.ErrorMetric)
+		if !ok {
+			status.Errors = append(status.Errors, fmt.Sprintf("error metric %q not found", component.ErrorMetric))
+		} else {
+			sample.errors, sample.hasErrors = errors, true
+			if elapsed := now.Sub(prevSample.at); prevSample.hasErrors && elapsed > 0 {
+				increase := errors - prevSample.errors
+				if increase < 0 {
+					// The counter was reset by a restart.
+					increase = errors
+				}
+				rate := increase / elapsed.Minutes()
+				status.ErrorsPerMinute = &rate
+			}
+		}
+	}
+
+	return status, sample
+}
+
+func (sa *statusAgent) checkHealth(url string) error {
+	resp, err := sa.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("response has status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (sa *statusAgent) scrape(url string) (map[string]*dto.MetricFamily, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := sa.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("response has status code %d", resp.StatusCode)
+	}
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+// metricValue sums up the values of all series of the named metric. The number
+// of observations is used for histograms and summaries.
+func metricValue(families map[string]*dto.MetricFamily, name string) (float64, bool) {
+	family, ok := families[name]
+	if !ok {
+		return 0, false
+	}
+	var sum float64
+	for _, metric := range family.GetMetric() {
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum += metric.GetCounter().GetValue()
+		case dto.MetricType_GAUGE:
+			sum += metric.GetGauge().GetValue()
+		case dto.MetricType_HISTOGRAM:
+			sum += float64(metric.GetHistogram().GetSampleCount())
+		case dto.MetricType_SUMMARY:
+			sum += float64(metric.GetSummary().GetSampleCount())
+		default:
+			sum += metric.GetUntyped().GetValue()
+		}
+	}
+	return sum, true
+}
+
+// buildClusterStatuses reads the health of build clusters from the metrics of
+// the cluster-health controller, sorted by cluster name.
+func buildClusterStatuses(families map[string]*dto.MetricFamily) []buildClusterStatus {
+	family, ok := families[clusterhealth.SchedulableMetric]
+	if !ok {
+		return nil
+	}
+	clusters := map[string]*buildClusterStatus{}
+	var names []string
+	for _, metric := range family.GetMetric() {
+		name := labelValue(metric, "cluster")
+		clusters[name] = &buildClusterStatus{Name: name, Schedulable: metric.GetGauge().GetValue() == 1}
+		names = append(names, name)
+	}
+	for _, metric := range families[clusterhealth.CheckFailingMetric].GetMetric() {
+		if cluster, ok := clusters[labelValue(metric, "cluster")]; ok && metric.GetGauge().GetValue() == 1 {
+			cluster.FailingChecks = append(cluster.FailingChecks, labelValue(metric, "check"))
+		}
+	}
+	for _, metric := range families[clusterhealth.ReadyNodesMetric].GetMetric() {
+		if cluster, ok := clusters[labelValue(metric, "cluster")]; ok {
+			nodes := int(metric.GetGauge().GetValue())
+			cluster.ReadyNodes = &nodes
+		}
+	}
+
+	sort.Strings(names)
+	statuses := make([]buildClusterStatus, 0, len(names))
+	for _, name := range names {
+		sort.Strings(clusters[name].FailingChecks)
+		statuses = append(statuses, *clusters[name])
+	}
+	return statuses
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// componentStatuses returns the status of all components in the order in which they are configured.
+func (sa *statusAgent) componentStatuses() []componentStatus {
+	sa.Lock()
+	defer sa.Unlock()
+	var statuses []componentStatus
+	for _, component := range sa.components() {
+		if status, ok := sa.statuses[component.Name]; ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// statusPageRow is a component status formatted for the status page.
+type statusPageRow struct {
+	Name        string
+	State       string
+	Uptime      string
+	LastSync    string
+	ErrorRate   string
+	LastChecked string
+	Errors      string
+}
+
+func statusPageRows(statuses []componentStatus, now time.Time) []statusPageRow {
+	rows := make([]statusPageRow, 0, len(statuses))
+	for _, status := range statuses {
+		row := statusPageRow{
+			Name:        status.Name,
+			State:       status.State,
+			Uptime:      "unknown",
+			LastSync:    "not observed",
+			ErrorRate:   "unknown",
+			LastChecked: formatAge(now.Sub(status.LastChecked)),
+			Errors:      strings.Join(status.Errors, "; "),
+		}
+		if status.StartTime != nil {
+			row.Uptime = now.Sub(*status.StartTime).Round(time.Second).String()
+		}
+		if status.LastSync != nil {
+			row.LastSync = formatAge(now.Sub(*status.LastSync))
+		}
+		if status.ErrorsPerMinute != nil {
+			row.ErrorRate = fmt.Sprintf("%.2f/min", *status.ErrorsPerMinute)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// buildClusterRow is a build cluster status formatted for the status page.
+type buildClusterRow struct {
+	Name          string
+	State         string
+	ReadyNodes    string
+	FailingChecks string
+	ReportedBy    string
+}
+
+func buildClusterRows(statuses []componentStatus) []buildClusterRow {
+	var rows []buildClusterRow
+	for _, status := range statuses {
+		for _, cluster := range status.BuildClusters {
+			row := buildClusterRow{
+				Name:          cluster.Name,
+				State:         componentHealthy,
+				ReadyNodes:    "unknown",
+				FailingChecks: strings.Join(cluster.FailingChecks, ", "),
+				ReportedBy:    status.Name,
+			}
+			if !cluster.Schedulable {
+				row.State = componentUnhealthy
+			}
+			if cluster.ReadyNodes != nil {
+				row.ReadyNodes = fmt.Sprintf("%d", *cluster.ReadyNodes)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// statusPage is the data rendered by the status page template.
+type statusPage struct {
+	Components    []statusPageRow
+	BuildClusters []buildClusterRow
+}
+
+func formatAge(age time.Duration) string {
+	return fmt.Sprintf("%s ago", age.Round(time.Second))
+}
+
+func handleComponentStatus(sa *statusAgent, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		statuses := sa.componentStatuses()
+		if statuses == nil {
+			statuses = []componentStatus{}
+		}
+		pd, err := json.Marshal(statuses)
+		if err != nil {
+			log.WithError(err).Error("Error marshaling component statuses.")
+			pd = []byte("[]")
+		}
+		writeJSONResponse(w, r, pd)
+	}
+}
+
+func handleStatusPage(o options, cfg config.Getter, sa *statusAgent) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		statuses := sa.componentStatuses()
+		page := statusPage{
+			Components:    statusPageRows(statuses, time.Now()),
+			BuildClusters: buildClusterRows(statuses),
+		}
+		handleSimpleTemplate(o, cfg, "status.html", page)(w, r)
+	}
+}
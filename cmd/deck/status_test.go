@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// fakeComponent serves the health and metrics endpoints of a Prow component.
+type fakeComponent struct {
+	healthy bool
+	syncs   int
+	errors  int
+}
+
+func (f *fakeComponent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/healthz":
+		if !f.healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	case "/metrics":
+		fmt.Fprintf(w, `# TYPE process_start_time_seconds gauge
+process_start_time_seconds 1.7670456e+09
+# TYPE syncs counter
+syncs{controller="a"} %d
+syncs{controller="b"} 1
+# TYPE errors counter
+errors %d
+`, f.syncs, f.errors)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestStatusAgentUpdate(t *testing.T) {
+	component := &fakeComponent{healthy: true, syncs: 1, errors: 3}
+	server := httptest.NewServer(component)
+	defer server.Close()
+
+	components := []config.StatusComponent{
+		{Name: "tide", HealthURL: server.URL + "/healthz", MetricsURL: server.URL + "/metrics", SyncMetric: "syncs", ErrorMetric: "errors"},
+		{Name: "hook", HealthURL: server.URL + "/missing"},
+		{Name: "crier", MetricsURL: server.URL + "/metrics", SyncMetric: "reports"},
+	}
+	now := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	sa := &statusAgent{
+		log:          logrus.WithField("agent", "status"),
+		client:       server.Client(),
+		components:   func() []config.StatusComponent { return components },
+		updatePeriod: func() time.Duration { return time.Minute },
+		now:          func() time.Time { return now },
+		statuses:     map[string]componentStatus{},
+		samples:      map[string]metricSample{},
+	}
+	startTime := time.Unix(1767045600, 0)
+
+	sa.update()
+	expected := []componentStatus{
+		{Name: "tide", State: componentHealthy, LastChecked: now, StartTime: &startTime},
+		{Name: "hook", State: componentUnhealthy, LastChecked: now, Errors: []string{"health check failed: response has status code 404"}},
+		{Name: "crier", State: componentHealthy, LastChecked: now, StartTime: &startTime, Errors: []string{`sync metric "reports" not found`}},
+	}
+	if diff := cmp.Diff(expected, sa.componentStatuses()); diff != "" {
+		t.Errorf("statuses after first update differ from expected (-want +got):\n%s", diff)
+	}
+
+	// The component synced and encountered six more errors in two minutes.
+	now = now.Add(2 * time.Minute)
+	component.syncs, component.errors = 2, 9
+	sa.update()
+	errorRate := 3.0
+	tide := sa.componentStatuses()[0]
+	if diff := cmp.Diff(componentStatus{Name: "tide", State: componentHealthy, LastChecked: now, StartTime: &startTime, LastSync: &now, ErrorsPerMinute: &errorRate}, tide); diff != "" {
+		t.Errorf("status after sync differs from expected (-want +got):\n%s", diff)
+	}
+
+	// The component is unhealthy and did not sync, the last sync is retained.
+	lastSync := now
+	now = now.Add(2 * time.Minute)
+	component.healthy = false
+	sa.update()
+	noErrors := 0.0
+	tide = sa.componentStatuses()[0]
+	if diff := cmp.Diff(componentStatus{Name: "tide", State: componentUnhealthy, LastChecked: now, StartTime: &startTime, LastSync: &lastSync, ErrorsPerMinute: &noErrors, Errors: []string{"health check failed: response has status code 503"}}, tide); diff != "" {
+		t.Errorf("status of unhealthy component differs from expected (-want +got):\n%s", diff)
+	}
+
+	// Components removed from the config are no longer reported.
+	components = components[:1]
+	sa.update()
+	if statuses := sa.componentStatuses(); len(statuses) != 1 || statuses[0].Name != "tide" {
+		t.Errorf("expected only the tide status to be reported, got %v", statuses)
+	}
+}
+
+func TestStatusPageRows(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	startTime := now.Add(-26 * time.Hour)
+	lastSync := now.Add(-90 * time.Second)
+	errorRate := 0.5
+	statuses := []componentStatus{
+		{Name: "tide", State: componentHealthy, LastChecked: now.Add(-10 * time.Second), StartTime: &startTime, LastSync: &lastSync, ErrorsPerMinute: &errorRate},
+		{Name: "hook", State: componentUnhealthy, LastChecked: now, Errors: []string{"health check failed", "failed to scrape metrics"}},
+	}
+	expected := []statusPageRow{
+		{Name: "tide", State: componentHealthy, Uptime: "26h0m0s", LastSync: "1m30s ago", ErrorRate: "0.50/min", LastChecked: "10s ago"},
+		{Name: "hook", State: componentUnhealthy, Uptime: "unknown", LastSync: "not observed", ErrorRate: "unknown", LastChecked: "0s ago", Errors: "health check failed; failed to scrape metrics"},
+	}
+	if diff := cmp.Diff(expected, statusPageRows(statuses, now)); diff != "" {
+		t.Errorf("rows differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleComponentStatus(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	sa := &statusAgent{
+		components: func() []config.StatusComponent {
+			return []config.StatusComponent{{Name: "sinker"}, {Name: "tide"}}
+		},
+		statuses: map[string]componentStatus{
+			"tide":   {Name: "tide", State: componentHealthy, LastChecked: now},
+			"sinker": {Name: "sinker", State: componentUnhealthy, LastChecked: now},
+		},
+	}
+	rr := httptest.NewRecorder()
+	handleComponentStatus(sa, logrus.WithField("handler", "/status.js")).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/status.js", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	var statuses []componentStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &statuses); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	expected := []componentStatus{
+		{Name: "sinker", State: componentUnhealthy, LastChecked: now},
+		{Name: "tide", State: componentHealthy, LastChecked: now},
+	}
+	if diff := cmp.Diff(expected, statuses); diff != "" {
+		t.Errorf("response differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildClusterStatuses(t *testing.T) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(`# TYPE build_cluster_schedulable gauge
+build_cluster_schedulable{cluster="default"} 1
+build_cluster_schedulable{cluster="broken"} 0
+# TYPE build_cluster_check_failing gauge
+build_cluster_check_failing{cluster="broken",check="api"} 1
+build_cluster_check_failing{cluster="broken",check="nodes"} 0
+build_cluster_check_failing{cluster="default",check="quota"} 1
+# TYPE build_cluster_ready_nodes gauge
+build_cluster_ready_nodes{cluster="default"} 3
+`))
+	if err != nil {
+		t.Fatalf("failed to parse metrics: %v", err)
+	}
+	readyNodes := 3
+	expected := []buildClusterStatus{
+		{Name: "broken", FailingChecks: []string{"api"}},
+		{Name: "default", Schedulable: true, ReadyNodes: &readyNodes, FailingChecks: []string{"quota"}},
+	}
+	statuses := buildClusterStatuses(families)
+	if diff := cmp.Diff(expected, statuses); diff != "" {
+		t.Errorf("statuses differ from expected (-want +got):\n%s", diff)
+	}
+
+	expectedRows := []buildClusterRow{
+		{Name: "broken", State: componentUnhealthy, ReadyNodes: "unknown", FailingChecks: "api", ReportedBy: "prow-controller-manager"},
+		{Name: "default", State: componentHealthy, ReadyNodes: "3", FailingChecks: "quota", ReportedBy: "prow-controller-manager"},
+	}
+	rows := buildClusterRows([]componentStatus{{Name: "prow-controller-manager", BuildClusters: statuses}, {Name: "tide"}})
+	if diff := cmp.Diff(expectedRows, rows); diff != "" {
+		t.Errorf("rows differ from expected (-want +got):\n%s", diff)
+	}
+
+	if statuses := buildClusterStatuses(nil); statuses != nil {
+		t.Errorf("expected no statuses without metrics, got %v", statuses)
+	}
+}
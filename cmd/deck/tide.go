@@ -37,6 +37,7 @@ type tidePools struct {
 	Queries     []string
 	TideQueries []config.TideQuery
 	Pools       []tide.PoolForDeck
+	MergeTrains []tide.MergeTrain
 }
 
 type tideHistory struct {
@@ -57,8 +58,9 @@ type tideAgent struct {
 	cfg       func() *config.Config
 
 	sync.Mutex
-	pools   []tide.Pool
-	history map[string][]history.Record
+	pools       []tide.Pool
+	mergeTrains []tide.MergeTrain
+	history     map[string][]history.Record
 }
 
 func (ta *tideAgent) start() {
@@ -66,6 +68,9 @@ func (ta *tideAgent) start() {
 	if err := ta.updatePools(); err != nil {
 		ta.log.WithError(err).Error("Updating pools the first time.")
 	}
+	if err := ta.updateMergeTrains(); err != nil {
+		ta.log.WithError(err).Error("Updating merge trains the first time.")
+	}
 	startTimeHistory := time.Now()
 	if err := ta.updateHistory(); err != nil {
 		ta.log.WithError(err).Error("Updating history the first time.")
@@ -78,6 +83,9 @@ func (ta *tideAgent) start() {
 			if err := ta.updatePools(); err != nil {
 				ta.log.WithError(err).Error("Updating pools.")
 			}
+			if err := ta.updateMergeTrains(); err != nil {
+				ta.log.WithError(err).Error("Updating merge trains.")
+			}
 		}
 	}()
 	go func() {
@@ -145,6 +153,20 @@ func (ta *tideAgent) updatePools() error {
 	return nil
 }
 
+func (ta *tideAgent) updateMergeTrains() error {
+	path := strings.TrimSuffix(ta.path, "/") + "/merge-trains"
+	var mergeTrains []tide.MergeTrain
+	if err := fetchTideData(ta.log, path, &mergeTrains); err != nil {
+		return err
+	}
+	mergeTrains = ta.filterMergeTrains(mergeTrains)
+
+	ta.Lock()
+	defer ta.Unlock()
+	ta.mergeTrains = mergeTrains
+	return nil
+}
+
 func (ta *tideAgent) updateHistory() error {
 	path := strings.TrimSuffix(ta.path, "/") + "/history"
 	var history map[string][]history.Record
@@ -178,6 +200,19 @@ func (ta *tideAgent) filterPools(pools []tide.Pool) []tide.Pool {
 	return filtered
 }
 
+func (ta *tideAgent) filterMergeTrains(mergeTrains []tide.MergeTrain) []tide.MergeTrain {
+	filtered := make([]tide.MergeTrain, 0, len(mergeTrains))
+	for _, train := range mergeTrains {
+		curIDs := sets.New[string](train.TenantIDs...)
+		orgRepoID := ta.cfg().GetProwJobDefault(train.Org+"/"+train.Repo, "*").TenantID
+		needsHide := matches(train.Org+"/"+train.Repo, ta.hiddenRepos())
+		if match := ta.filter(orgRepoID, curIDs, needsHide); match {
+			filtered = append(filtered, train)
+		}
+	}
+	return filtered
+}
+
 func noTenantIDOrDefaultTenantID(ids []string) bool {
 	for _, id := range ids {
 		if id != "" && id != config.DefaultTenantID {
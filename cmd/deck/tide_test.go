@@ -736,6 +736,17 @@ func TestFilter(t *testing.T) {
 		if !equality.Semantic.DeepEqual(gotPools, test.expectedPools) {
 			t.Errorf("expected pools:\n%v\ngot pools:\n%v\n", test.expectedPools, gotPools)
 		}
+		// merge trains are filtered like the pools they belong to
+		var mergeTrains, expectedMergeTrains []tide.MergeTrain
+		for _, pool := range test.pools {
+			mergeTrains = append(mergeTrains, tide.MergeTrain{Org: pool.Org, Repo: pool.Repo, Branch: pool.Branch, TenantIDs: pool.TenantIDs})
+		}
+		for _, pool := range test.expectedPools {
+			expectedMergeTrains = append(expectedMergeTrains, tide.MergeTrain{Org: pool.Org, Repo: pool.Repo, Branch: pool.Branch, TenantIDs: pool.TenantIDs})
+		}
+		if gotMergeTrains := ta.filterMergeTrains(mergeTrains); !equality.Semantic.DeepEqual(gotMergeTrains, expectedMergeTrains) {
+			t.Errorf("expected merge trains:\n%v\ngot merge trains:\n%v\n", expectedMergeTrains, gotMergeTrains)
+		}
 		// equality.Semantic.DeepEqual doesn't like the unexported fields in time.Time.
 		// We don't care about that for this test.
 		if !reflect.DeepEqual(gotHist, test.expectedHist) {
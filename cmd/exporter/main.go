@@ -18,7 +18,9 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -39,6 +41,13 @@ type options struct {
 	config                 configflagutil.ConfigOptions
 	kubernetes             prowflagutil.KubernetesOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
+
+	metricLabels   prowflagutil.Strings
+	maxLabelValues int
+}
+
+func (o *options) exporterOptions() prowjobs.ExporterOptions {
+	return prowjobs.ExporterOptions{Labels: o.metricLabels.Strings(), MaxLabelValues: o.maxLabelValues}
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
@@ -47,6 +56,9 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	o.config.AddFlags(fs)
 	o.kubernetes.AddFlags(fs)
 	o.instrumentationOptions.AddFlags(fs)
+	o.metricLabels = prowflagutil.NewStrings(prowjobs.DefaultLabels...)
+	fs.Var(&o.metricLabels, "prowjob-metric-label", fmt.Sprintf("Label of the ProwJob state, duration and retest metrics. Can be passed multiple times. One of %s, defaults to %s.", strings.Join(prowjobs.AllLabels, ", "), strings.Join(prowjobs.DefaultLabels, ", ")))
+	fs.IntVar(&o.maxLabelValues, "max-label-values", 0, fmt.Sprintf("Maximum number of distinct values of each label of the ProwJob state, duration and retest metrics. Further values are reported as %q. 0 means no limit.", prowjobs.OtherLabelValue))
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		logrus.WithError(err).Fatalf("cannot parse args: '%s'", os.Args[1:])
 	}
@@ -59,7 +71,7 @@ func (o *options) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return o.exporterOptions().Validate()
 }
 
 func mustRegister(component string, lister lister) *prometheus.Registry {
@@ -103,6 +115,11 @@ func main() {
 
 	registry := mustRegister("exporter", pjLister)
 	registry.MustRegister(prowjobs.NewProwJobLifecycleHistogramVec(informerFactory.Prow().V1().ProwJobs().Informer()))
+	exporter, err := prowjobs.NewExporter(informerFactory.Prow().V1().ProwJobs().Informer(), o.exporterOptions())
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to create ProwJob metrics exporter")
+	}
+	registry.MustRegister(exporter)
 
 	// Expose prometheus metrics
 	metrics.ExposeMetricsWithRegistry("exporter", cfg().PushGateway, o.instrumentationOptions.MetricsPort, registry, nil)
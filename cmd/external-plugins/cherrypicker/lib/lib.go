@@ -17,10 +17,27 @@ limitations under the License.
 package cherrypicker
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// backportRequestRe matches the details of a cherry-pick that a plugin
+// requested on behalf of a user.
+var backportRequestRe = regexp.MustCompile(`(?m)^<!-- cherrypick-request: (.+) -->$`)
+
+// BackportRequest holds the details of a cherry-pick that a plugin requests
+// on behalf of a user.
+type BackportRequest struct {
+	// Title is the title of the cherry-pick PR. Defaults to the title of
+	// the cherry-picked PR.
+	Title string `json:"title,omitempty"`
+	// Requestor is the user the cherry-pick is requested for, who is
+	// assigned to the cherry-pick PR.
+	Requestor string `json:"requestor"`
+}
+
 // CreateCherrypickBody creates the body of a cherrypick PR
 func CreateCherrypickBody(num int, requestor, note string, chainBranches []string) string {
 	cherryPickBody := fmt.Sprintf("This is an automated cherry-pick of #%d", num)
@@ -35,3 +52,28 @@ func CreateCherrypickBody(num int, requestor, note string, chainBranches []strin
 	}
 	return cherryPickBody
 }
+
+// CreateBackportRequest creates a comment requesting a cherry-pick of a PR to
+// the branch. The cherrypicker only honors the details of the request in
+// comments of its own bot user.
+func CreateBackportRequest(branch string, request BackportRequest) (string, error) {
+	details, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/cherrypick %s\n<!-- cherrypick-request: %s -->", branch, details), nil
+}
+
+// ParseBackportRequest returns the details of the cherry-pick requested in
+// the comment, or nil if the comment was not created by CreateBackportRequest.
+func ParseBackportRequest(comment string) (*BackportRequest, error) {
+	match := backportRequestRe.FindStringSubmatch(comment)
+	if match == nil {
+		return nil, nil
+	}
+	var request BackportRequest
+	if err := json.Unmarshal([]byte(match[1]), &request); err != nil {
+		return nil, fmt.Errorf("invalid cherry-pick request: %w", err)
+	}
+	return &request, nil
+}
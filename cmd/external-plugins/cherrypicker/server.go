@@ -196,15 +196,30 @@ func (s *Server) handleIssueComment(l *logrus.Entry, ic github.IssueCommentEvent
 		chainBranches = branches[1:]
 	}
 
+	// Other plugins request cherry-picks on behalf of users with comments of
+	// the bot, which may also set the title of the cherry-pick PR.
+	requestor := commentAuthor
+	var requestedTitle string
+	if s.botUser != nil && commentAuthor == s.botUser.Login {
+		request, err := cherrypicker.ParseBackportRequest(ic.Comment.Body)
+		if err != nil {
+			return err
+		}
+		if request != nil {
+			requestor = request.Requestor
+			requestedTitle = request.Title
+		}
+	}
+
 	if ic.Issue.State != "closed" {
 		if !s.allowAll {
 			// Only members should be able to do cherry-picks.
-			ok, err := s.ghc.IsMember(org, commentAuthor)
+			ok, err := s.ghc.IsMember(org, requestor)
 			if err != nil {
 				return err
 			}
 			if !ok {
-				resp := fmt.Sprintf(notOrgMemberMessageTemplate, org, org, org, commentAuthor)
+				resp := fmt.Sprintf(notOrgMemberMessageTemplate, org, org, org, requestor)
 				l.Info(resp)
 				return s.ghc.CreateComment(org, repo, num, plugins.FormatICResponse(ic.Comment, resp))
 			}
@@ -220,6 +235,9 @@ func (s *Server) handleIssueComment(l *logrus.Entry, ic github.IssueCommentEvent
 	}
 	baseBranch := pr.Base.Ref
 	title := pr.Title
+	if requestedTitle != "" {
+		title = requestedTitle
+	}
 	body := pr.Body
 
 	// Cherry-pick only merged PRs.
@@ -238,23 +256,23 @@ func (s *Server) handleIssueComment(l *logrus.Entry, ic github.IssueCommentEvent
 
 	if !s.allowAll {
 		// Only org members should be able to do cherry-picks.
-		ok, err := s.ghc.IsMember(org, commentAuthor)
+		ok, err := s.ghc.IsMember(org, requestor)
 		if err != nil {
 			return err
 		}
 		if !ok {
-			resp := fmt.Sprintf(notOrgMemberMessageTemplate, org, org, org, commentAuthor)
+			resp := fmt.Sprintf(notOrgMemberMessageTemplate, org, org, org, requestor)
 			l.Info(resp)
 			return s.ghc.CreateComment(org, repo, num, plugins.FormatICResponse(ic.Comment, resp))
 		}
 	}
 
 	*l = *l.WithFields(logrus.Fields{
-		"requestor":     ic.Comment.User.Login,
+		"requestor":     requestor,
 		"target_branch": targetBranch,
 	})
 	l.Debug("Cherrypick request.")
-	return s.handle(l, ic.Comment.User.Login, &ic.Comment, org, repo, targetBranch, baseBranch, chainBranches, title, body, num)
+	return s.handle(l, requestor, &ic.Comment, org, repo, targetBranch, baseBranch, chainBranches, title, body, num)
 }
 
 func (s *Server) handlePullRequest(l *logrus.Entry, pre github.PullRequestEvent) error {
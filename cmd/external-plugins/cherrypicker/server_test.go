@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 
+	cherrypicker "sigs.k8s.io/prow/cmd/external-plugins/cherrypicker/lib"
 	"sigs.k8s.io/prow/pkg/git/localgit"
 	v2 "sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
@@ -357,6 +358,85 @@ func testCherryPickIC(clients localgit.Clients, t *testing.T) {
 	}
 }
 
+func TestCherryPickICOnBehalfV2(t *testing.T) {
+	t.Parallel()
+	testCherryPickICOnBehalf(localgit.NewV2, t)
+}
+
+func testCherryPickICOnBehalf(clients localgit.Clients, t *testing.T) {
+	iNumber := fakePR.GetPRNumber()
+	lg, c := makeFakeRepoWithCommit(clients, t)
+	if err := lg.CheckoutNewBranch("foo", "bar", "stage"); err != nil {
+		t.Fatalf("Checking out pull branch: %v", err)
+	}
+
+	ghc := &fghc{
+		pr: &github.PullRequest{
+			Base: github.PullRequestBranch{
+				Ref: "master",
+			},
+			Merged: true,
+			Title:  "Bug 1: This is a fix for X",
+			Body:   body,
+		},
+		isMember: true,
+		patch:    patch,
+	}
+	request, err := cherrypicker.CreateBackportRequest("stage", cherrypicker.BackportRequest{Title: "Bug 2: This is a fix for X", Requestor: "wiseguy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ic := github.IssueCommentEvent{
+		Action: github.IssueCommentActionCreated,
+		Repo: github.Repo{
+			Owner: github.User{
+				Login: "foo",
+			},
+			Name:     "bar",
+			FullName: "foo/bar",
+		},
+		Issue: github.Issue{
+			Number:      iNumber,
+			State:       "closed",
+			PullRequest: &struct{}{},
+		},
+		Comment: github.IssueComment{
+			User: github.User{
+				Login: "ci-robot",
+			},
+			Body: request,
+		},
+	}
+
+	botUser := &github.UserData{Login: "ci-robot", Email: "ci-robot@users.noreply.github.com"}
+	expectedTitle := "[stage] Bug 2: This is a fix for X"
+	expectedBody := fmt.Sprintf("This is an automated cherry-pick of #%d\n\n/assign wiseguy\n\n```release-note\nUpdate the magic number from 42 to 49\n```", iNumber)
+	expectedBase := "stage"
+	expectedHead := fmt.Sprintf(botUser.Login+":"+cherryPickBranchFmt, iNumber, expectedBase)
+	expectedLabels := []string{}
+	expected := fmt.Sprintf(expectedFmt, expectedTitle, expectedBody, expectedHead, expectedBase, expectedLabels)
+
+	s := &Server{
+		botUser:        botUser,
+		gc:             c,
+		push:           func(forkName, newBranch string, force bool) error { return nil },
+		ghc:            ghc,
+		tokenGenerator: func() []byte { return []byte("sha=abcdefg") },
+		log:            logrus.StandardLogger().WithField("client", "cherrypicker"),
+		repos:          []github.Repo{{Fork: true, FullName: "ci-robot/bar"}},
+
+		prowAssignments: true,
+	}
+
+	if err := s.handleIssueComment(logrus.NewEntry(logrus.StandardLogger()), ic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := prToString(ghc.prs[0])
+	if got != expected {
+		t.Errorf("Expected (%d):\n%s\nGot (%d):\n%+v\n", len(expected), expected, len(got), got)
+	}
+}
+
 func TestCherryPickPRV2(t *testing.T) {
 	t.Parallel()
 	testCherryPickPR(localgit.NewV2, t)
@@ -33,6 +33,7 @@ import (
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/flagutil"
@@ -157,9 +158,24 @@ func main() {
 		}
 	}
 
+	// Pod and secret clients of the build clusters, to stream the censored
+	// logs of job executions.
+	buildClusterClients, err := o.client.BuildClusterCoreV1Clients(false)
+	if err != nil {
+		logrus.WithError(err).Fatal("unable to create build cluster clients")
+	}
+	podClients := map[string]corev1.PodInterface{}
+	secretClients := map[string]corev1.SecretInterface{}
+	for alias, client := range buildClusterClients {
+		podClients[alias] = client.Pods(configAgent.Config().PodNamespace)
+		secretClients[alias] = client.Secrets(configAgent.Config().PodNamespace)
+	}
+
 	gw := gangway.Gangway{
 		ConfigAgent:   configAgent,
 		ProwJobClient: prowjobClient,
+		PodClients:    podClients,
+		SecretClients: secretClients,
 	}
 
 	// InRepoConfig getter.
@@ -192,11 +208,12 @@ func main() {
 	}
 
 	// Create a new gRPC (empty) server, and wire it up to act as a "ProwServer"
-	// as defined in the auto-generated gangway_grpc.pb.go file. Also inject an
-	// interceptor for collecting Prometheus metrics for all unary gRPC
-	// requests.
+	// as defined in the auto-generated gangway_grpc.pb.go file. Also inject
+	// interceptors for collecting Prometheus metrics for all unary and streaming
+	// gRPC requests.
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
 	)
 	gangway.RegisterProwServer(grpcServer, &gw)
 	grpc_prometheus.Register(grpcServer)
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// github-token-minter mints installation tokens of a GitHub App for other
+// components, so that only the minter needs access to the app's private key.
+// Each client authenticates with its own secret and may only request tokens
+// for the orgs it is configured for, optionally restricted to a subset of the
+// app's permissions.
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config/secret"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+)
+
+type options struct {
+	port        int
+	clientsPath string
+	gracePeriod time.Duration
+
+	github                 prowflagutil.GitHubOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+}
+
+func (o *options) validate() error {
+	for _, group := range []prowflagutil.OptionGroup{&o.github, &o.instrumentationOptions} {
+		if err := group.Validate(false); err != nil {
+			return err
+		}
+	}
+	if o.github.AppID == "" {
+		return errors.New("--github-app-id and --github-app-private-key-path are required")
+	}
+	if o.clientsPath == "" {
+		return errors.New("--clients-path is required")
+	}
+	return nil
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	var o options
+	fs.IntVar(&o.port, "port", 8888, "Port to listen on.")
+	fs.StringVar(&o.clientsPath, "clients-path", "", "Path to the file configuring the clients allowed to request tokens.")
+	fs.DurationVar(&o.gracePeriod, "grace-period", 10*time.Second, "On shutdown, try to handle remaining requests for at most this duration.")
+	o.github.AddCustomizedFlags(fs, prowflagutil.DisableThrottlerOptions())
+	o.instrumentationOptions.AddFlags(fs)
+	fs.Parse(args)
+	return o
+}
+
+// clientsConfig configures the clients of the minter.
+type clientsConfig struct {
+	Clients []clientConfig `json:"clients"`
+}
+
+// clientConfig configures a client of the minter.
+type clientConfig struct {
+	// Name identifies the client in logs.
+	Name string `json:"name"`
+	// SecretPath is the path to the file containing the secret the client
+	// authenticates with.
+	SecretPath string `json:"secret_path"`
+	// Orgs lists the orgs the client may request tokens for. "*" allows all
+	// orgs the app is installed in.
+	Orgs []string `json:"orgs"`
+	// Permissions optionally restricts the tokens of the client to a subset
+	// of the app's permissions.
+	Permissions *github.InstallationPermissions `json:"permissions,omitempty"`
+
+	secret func() []byte
+}
+
+func loadClients(path string) ([]clientConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clients: %w", err)
+	}
+	var config clientsConfig
+	if err := yaml.Unmarshal(raw, &config, yaml.DisallowUnknownFields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal clients: %w", err)
+	}
+	var errs []error
+	names := sets.New[string]()
+	for i, client := range config.Clients {
+		if client.Name == "" {
+			errs = append(errs, fmt.Errorf("clients[%d]: name is required", i))
+		} else if names.Has(client.Name) {
+			errs = append(errs, fmt.Errorf("clients[%d]: duplicate name %q", i, client.Name))
+		}
+		names.Insert(client.Name)
+		if client.SecretPath == "" {
+			errs = append(errs, fmt.Errorf("clients[%d]: secret_path is required", i))
+		}
+		if len(client.Orgs) == 0 {
+			errs = append(errs, fmt.Errorf("clients[%d]: at least one org is required", i))
+		}
+	}
+	return config.Clients, utilerrors.NewAggregate(errs)
+}
+
+func (c *clientConfig) allowed(org string) bool {
+	for _, allowed := range c.Orgs {
+		if allowed == "*" || strings.EqualFold(allowed, org) {
+			return true
+		}
+	}
+	return false
+}
+
+type server struct {
+	minter  github.InstallationTokenMinter
+	clients []clientConfig
+
+	// tokens caches tokens with restricted permissions by client and org.
+	// Tokens with all permissions are cached by the minter itself.
+	lock   sync.Mutex
+	tokens map[string]*github.MintedToken
+}
+
+func (s *server) authenticate(r *http.Request) *clientConfig {
+	provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || provided == "" {
+		return nil
+	}
+	for i := range s.clients {
+		expected := strings.TrimSpace(string(s.clients[i].secret()))
+		if expected != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1 {
+			return &s.clients[i]
+		}
+	}
+	return nil
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	client := s.authenticate(r)
+	if client == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	l := logrus.WithField("client", client.Name)
+
+	var response interface{}
+	switch r.URL.Path {
+	case "/app":
+		app, err := s.minter.GetApp()
+		if err != nil {
+			l.WithError(err).Error("Failed to get app.")
+			http.Error(w, "failed to get app", http.StatusBadGateway)
+			return
+		}
+		response = app
+	case "/token":
+		org := r.URL.Query().Get("org")
+		if org == "" {
+			http.Error(w, "org is required", http.StatusBadRequest)
+			return
+		}
+		l = l.WithField("org", org)
+		if !client.allowed(org) {
+			l.Warn("Client requested a token for an org it is not allowed to access.")
+			http.Error(w, fmt.Sprintf("client %s may not request tokens for org %s", client.Name, org), http.StatusForbidden)
+			return
+		}
+		token, err := s.tokenFor(client, org)
+		if err != nil {
+			l.WithError(err).Error("Failed to mint token.")
+			http.Error(w, "failed to mint token", http.StatusBadGateway)
+			return
+		}
+		l.Debug("Served token.")
+		response = token
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	b, err := json.Marshal(response)
+	if err != nil {
+		l.WithError(err).Error("Failed to marshal response.")
+		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.Write(b)
+}
+
+func (s *server) tokenFor(client *clientConfig, org string) (*github.MintedToken, error) {
+	if client.Permissions == nil {
+		return s.minter.MintToken(org, nil)
+	}
+	key := client.Name + "/" + strings.ToLower(org)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if token, ok := s.tokens[key]; ok && token.ExpiresAt.Add(-5*time.Minute).After(time.Now()) {
+		return token, nil
+	}
+	token, err := s.minter.MintToken(org, client.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	s.tokens[key] = token
+	return token, nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+	pprof.Instrument(o.instrumentationOptions)
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
+
+	clients, err := loadClients(o.clientsPath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid clients")
+	}
+	for i := range clients {
+		if err := secret.Add(clients[i].SecretPath); err != nil {
+			logrus.WithError(err).WithField("client", clients[i].Name).Fatal("Error loading client secret.")
+		}
+		clients[i].secret = secret.GetTokenGenerator(clients[i].SecretPath)
+	}
+
+	minter, err := o.github.InstallationTokenMinter()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating token minter.")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", &server{minter: minter, clients: clients, tokens: map[string]*github.MintedToken{}})
+	httpServer := &http.Server{Addr: ":" + strconv.Itoa(o.port), Handler: mux}
+	health.ServeReady()
+	interrupts.ListenAndServe(httpServer, o.gracePeriod)
+}
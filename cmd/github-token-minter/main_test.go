@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+type fakeMinter struct {
+	minted []string
+}
+
+func (f *fakeMinter) MintToken(org string, permissions *github.InstallationPermissions) (*github.MintedToken, error) {
+	if org == "broken" {
+		return nil, errors.New("injected error")
+	}
+	token := "token-" + org
+	if permissions != nil {
+		token += "-scoped"
+	}
+	f.minted = append(f.minted, token)
+	return &github.MintedToken{Token: token, ExpiresAt: time.Now().Add(time.Hour), AppSlug: "app"}, nil
+}
+
+func (f *fakeMinter) GetApp() (*github.App, error) {
+	return &github.App{Slug: "app"}, nil
+}
+
+func TestServeHTTP(t *testing.T) {
+	clients := []clientConfig{
+		{
+			Name:   "tide",
+			Orgs:   []string{"*"},
+			secret: func() []byte { return []byte("tide-secret\n") },
+		},
+		{
+			Name:        "crier",
+			Orgs:        []string{"Org"},
+			Permissions: &github.InstallationPermissions{Statuses: "write"},
+			secret:      func() []byte { return []byte("crier-secret") },
+		},
+	}
+	testCases := []struct {
+		name             string
+		path             string
+		secret           string
+		expectedCode     int
+		expectedResponse interface{}
+	}{
+		{
+			name:         "missing secret is rejected",
+			path:         "/token?org=org",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "wrong secret is rejected",
+			path:         "/token?org=org",
+			secret:       "wrong",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "app is served",
+			path:             "/app",
+			secret:           "tide-secret",
+			expectedCode:     http.StatusOK,
+			expectedResponse: map[string]interface{}{"slug": "app"},
+		},
+		{
+			name:             "any org is allowed with a wildcard",
+			path:             "/token?org=other",
+			secret:           "tide-secret",
+			expectedCode:     http.StatusOK,
+			expectedResponse: "token-other",
+		},
+		{
+			name:             "scoped token for an allowed org",
+			path:             "/token?org=org",
+			secret:           "crier-secret",
+			expectedCode:     http.StatusOK,
+			expectedResponse: "token-org-scoped",
+		},
+		{
+			name:         "org that is not allowed is rejected",
+			path:         "/token?org=other",
+			secret:       "crier-secret",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "org is required",
+			path:         "/token",
+			secret:       "tide-secret",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "minting errors are surfaced",
+			path:         "/token?org=broken",
+			secret:       "tide-secret",
+			expectedCode: http.StatusBadGateway,
+		},
+		{
+			name:         "unknown path",
+			path:         "/unknown",
+			secret:       "tide-secret",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &server{minter: &fakeMinter{}, clients: clients, tokens: map[string]*github.MintedToken{}}
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.secret != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.secret)
+			}
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			if tc.expectedResponse == nil {
+				return
+			}
+			var response interface{}
+			if token, ok := tc.expectedResponse.(string); ok {
+				var minted github.MintedToken
+				if err := json.Unmarshal(rr.Body.Bytes(), &minted); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				tc.expectedResponse, response = token, minted.Token
+			} else {
+				var app map[string]interface{}
+				if err := json.Unmarshal(rr.Body.Bytes(), &app); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				response = map[string]interface{}{"slug": app["slug"]}
+			}
+			if diff := cmp.Diff(tc.expectedResponse, response); diff != "" {
+				t.Errorf("response differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScopedTokensAreCached(t *testing.T) {
+	minter := &fakeMinter{}
+	s := &server{minter: minter, tokens: map[string]*github.MintedToken{}}
+	client := &clientConfig{Name: "crier", Permissions: &github.InstallationPermissions{Statuses: "write"}}
+	for _, org := range []string{"org", "Org", "other"} {
+		if _, err := s.tokenFor(client, org); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"token-org-scoped", "token-other-scoped"}, minter.minted); diff != "" {
+		t.Errorf("minted tokens differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadClients(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expected    []clientConfig
+		expectedErr bool
+	}{
+		{
+			name: "valid clients",
+			content: `clients:
+- name: tide
+  secret_path: /etc/tide/secret
+  orgs: ["*"]
+- name: crier
+  secret_path: /etc/crier/secret
+  orgs: [org]
+  permissions:
+    statuses: write
+`,
+			expected: []clientConfig{
+				{Name: "tide", SecretPath: "/etc/tide/secret", Orgs: []string{"*"}},
+				{Name: "crier", SecretPath: "/etc/crier/secret", Orgs: []string{"org"}, Permissions: &github.InstallationPermissions{Statuses: "write"}},
+			},
+		},
+		{
+			name: "duplicate names",
+			content: `clients:
+- name: tide
+  secret_path: /etc/tide/secret
+  orgs: ["*"]
+- name: tide
+  secret_path: /etc/other/secret
+  orgs: ["*"]
+`,
+			expectedErr: true,
+		},
+		{
+			name: "missing secret and orgs",
+			content: `clients:
+- name: tide
+`,
+			expectedErr: true,
+		},
+		{
+			name:        "unknown fields",
+			content:     "clients:\n- name: tide\n  token: abc\n",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "clients.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("failed to write clients: %v", err)
+			}
+			clients, err := loadClients(path)
+			if err != nil != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, clients, cmp.AllowUnexported(clientConfig{})); diff != "" {
+				t.Errorf("clients differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -42,16 +43,47 @@ import (
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/ghhook"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
 )
 
+var (
+	tokenAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hmac_token_age_seconds",
+		Help: "Age of the most recent hmac token of an org or repo.",
+	}, []string{"org_repo"})
+	tokens = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hmac_tokens",
+		Help: "Number of hmac tokens hook accepts for an org or repo. More than one means a rotation is in its grace period.",
+	}, []string{"org_repo"})
+	rotations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hmac_rotations",
+		Help: "Number of hmac token rotations of an org or repo by result.",
+	}, []string{"org_repo", "result"})
+	lastSuccessfulRun = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hmac_last_successful_run_timestamp_seconds",
+		Help: "Time of the last run that reconciled all hmac tokens and webhooks.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(tokenAge)
+	prometheus.MustRegister(tokens)
+	prometheus.MustRegister(rotations)
+	prometheus.MustRegister(lastSuccessfulRun)
+}
+
 type options struct {
 	config configflagutil.ConfigOptions
 
-	dryRun        bool
-	github        prowflagutil.GitHubOptions
-	kubernetes    prowflagutil.KubernetesOptions
-	kubeconfigCtx string
+	dryRun                 bool
+	github                 prowflagutil.GitHubOptions
+	kubernetes             prowflagutil.KubernetesOptions
+	kubeconfigCtx          string
+	instrumentationOptions prowflagutil.InstrumentationOptions
+	runInterval            time.Duration
 
 	hookUrl                  string
 	hmacTokenSecretNamespace string
@@ -60,7 +92,7 @@ type options struct {
 }
 
 func (o *options) validate() error {
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.config} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.config, &o.instrumentationOptions} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
@@ -78,6 +110,9 @@ func (o *options) validate() error {
 	if o.hmacTokenKey == "" {
 		return errors.New("required flag --hmac-token-key was unset")
 	}
+	if o.runInterval < 0 {
+		return errors.New("--run-interval must not be negative")
+	}
 
 	return nil
 }
@@ -88,6 +123,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	o.config.AddFlags(fs)
 	o.github.AddFlags(fs)
 	o.kubernetes.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
 
 	fs.StringVar(&o.kubeconfigCtx, "kubeconfig-context", "", "Context of the Prow component cluster and namespace in the kubeconfig.")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
@@ -96,6 +132,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.StringVar(&o.hmacTokenSecretNamespace, "hmac-token-secret-namespace", "default", "Name of the namespace on the cluster where the hmac-token secret is in.")
 	fs.StringVar(&o.hmacTokenSecretName, "hmac-token-secret-name", "", "Name of the secret on the cluster containing the GitHub HMAC secret.")
 	fs.StringVar(&o.hmacTokenKey, "hmac-token-key", "", "Key of the hmac token in the secret.")
+	fs.DurationVar(&o.runInterval, "run-interval", 0, "If set, keep running and reconcile the hmac tokens and webhooks at this interval, which rotates tokens on the schedule of managed_webhooks.rotation_interval. Runs once if unset.")
 	fs.Parse(args)
 	return o
 }
@@ -130,16 +167,36 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Error starting config agent.")
 	}
-	newHMACConfig := configAgent.Config().ManagedWebhooks
 
 	gc, err := o.github.GitHubClient(o.dryRun)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error creating github client")
 	}
 
+	if o.runInterval == 0 {
+		if err := run(o, kc, gc, configAgent.Config().ManagedWebhooks); err != nil {
+			logrus.WithError(err).Fatal("Error reconciling hmac tokens.")
+		}
+		return
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
+	metrics.ExposeMetrics("hmac", configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
+	health.ServeReady()
+	interrupts.TickLiteral(func() {
+		if err := run(o, kc, gc, configAgent.Config().ManagedWebhooks); err != nil {
+			logrus.WithError(err).Error("Error reconciling hmac tokens.")
+		}
+	}, o.runInterval)
+}
+
+// run reconciles the hmac tokens, the secret and the webhooks with the
+// managed_webhooks config once.
+func run(o options, kc kubernetes.Interface, gc github.HookClient, newHMACConfig config.ManagedWebhooks) error {
 	currentHMACYaml, err := getCurrentHMACTokens(kc, o.hmacTokenSecretNamespace, o.hmacTokenSecretName, o.hmacTokenKey)
 	if err != nil {
-		logrus.WithError(err).Fatal("Error getting the current hmac yaml.")
+		return fmt.Errorf("error getting the current hmac yaml: %w", err)
 	}
 
 	currentHMACMap := map[string]github.HMACsForRepo{}
@@ -147,7 +204,7 @@ func main() {
 		// When the token is still a single global token, respect_legacy_global_token must be set to true before running this tool.
 		// This can prevent the global token from being deleted by mistake before users migrate all repos/orgs to use auto-generated private tokens.
 		if !newHMACConfig.RespectLegacyGlobalToken {
-			logrus.Fatal("respect_legacy_global_token must be set to true before the hmac tool is run for the first time.")
+			return errors.New("respect_legacy_global_token must be set to true before the hmac tool is run for the first time")
 		}
 
 		logrus.WithError(err).Error("Couldn't unmarshal the hmac secret as hierarchical file. Parsing as a single global token and writing it back to the secret.")
@@ -170,12 +227,16 @@ func main() {
 	}
 
 	if err := c.handleInvitation(); err != nil {
-		logrus.WithError(err).Fatal("Error accepting invitations.")
+		return fmt.Errorf("error accepting invitations: %w", err)
 	}
 
-	if err := c.handleConfigUpdate(); err != nil {
-		logrus.WithError(err).Fatal("Error handling hmac config update.")
+	err = c.handleConfigUpdate()
+	recordTokenMetrics(c.currentHMACMap, time.Now())
+	if err != nil {
+		return fmt.Errorf("error handling hmac config update: %w", err)
 	}
+	lastSuccessfulRun.SetToCurrentTime()
+	return nil
 }
 
 func (c *client) handleInvitation() error {
@@ -277,7 +338,9 @@ func (c *client) handleConfigUpdate() error {
 	}
 	// HACK: waiting for the hmac k8s secret update to propagate to the pods that are using the secret,
 	// so that components like hook can start respecting the new hmac values.
-	time.Sleep(20 * time.Second)
+	if len(c.hmacMapForBatchUpdate) != 0 {
+		time.Sleep(20 * time.Second)
+	}
 	errs := c.batchOnboardNewTokenForRepos()
 
 	// Do necessary cleanups after the token and webhook updates are done.
@@ -342,11 +405,20 @@ func (c *client) handleAddedRepo(added map[string]config.ManagedWebhookInfo) err
 
 func (c *client) handledRotatedRepo(rotated map[string]config.ManagedWebhookInfo) error {
 	// For each rotated repo, we only onboard a new token when none of the existing tokens is created after user specified time.
+	// With a rotation interval, tokens that are older than the interval are rotated as well.
+	createdAfter := func(hmacConfig config.ManagedWebhookInfo) time.Time {
+		if c.newHMACConfig.RotationInterval != nil {
+			if expiry := time.Now().Add(-c.newHMACConfig.RotationInterval.Duration); expiry.After(hmacConfig.TokenCreatedAfter) {
+				return expiry
+			}
+		}
+		return hmacConfig.TokenCreatedAfter
+	}
 	for repo, hmacConfig := range rotated {
 		needsRotation := true
 		for _, token := range c.currentHMACMap[repo] {
 			// If the existing token is created after the user specified time, we do not need to rotate it.
-			if token.CreatedAt.After(hmacConfig.TokenCreatedAfter) {
+			if token.CreatedAt.After(createdAfter(hmacConfig)) {
 				needsRotation = false
 				break
 			}
@@ -413,6 +485,7 @@ func (c *client) batchOnboardNewTokenForRepos() []error {
 	var errs []error
 	for repo, generatedToken := range c.hmacMapForBatchUpdate {
 		if err := c.onboardNewTokenForRepo(repo, generatedToken); err != nil {
+			rotations.WithLabelValues(repo, "error").Inc()
 			errs = append(errs, err)
 			logrus.WithError(err).Errorf("Error updating the webhook, will revert the hmacs for %q", repo)
 			if hmacs, exist := c.hmacMapForRecovery[repo]; exist {
@@ -420,7 +493,9 @@ func (c *client) batchOnboardNewTokenForRepos() []error {
 			} else {
 				delete(c.currentHMACMap, repo)
 			}
+			continue
 		}
+		rotations.WithLabelValues(repo, "success").Inc()
 	}
 	return errs
 }
@@ -459,7 +534,8 @@ func (c *client) updateHMACTokenSecret() error {
 	return nil
 }
 
-// pruneOldTokens removes all but most recent token from token config.
+// pruneOldTokens removes all but most recent token from token config, once
+// the most recent token is older than the rotation grace period.
 func (c *client) pruneOldTokens(repo string) {
 	tokens := c.currentHMACMap[repo]
 	if len(tokens) <= 1 {
@@ -467,13 +543,35 @@ func (c *client) pruneOldTokens(repo string) {
 		return
 	}
 
-	logrus.WithField("repo", repo).Debugf("Token size is %d, prune to 1", len(tokens))
 	sort.SliceStable(tokens, func(i, j int) bool {
 		return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
 	})
+	if gracePeriod := c.newHMACConfig.RotationGracePeriod; gracePeriod != nil && time.Since(tokens[0].CreatedAt) < gracePeriod.Duration {
+		logrus.WithField("repo", repo).Debugf("Token size is %d, keeping the previous tokens during the grace period", len(tokens))
+		return
+	}
+	logrus.WithField("repo", repo).Debugf("Token size is %d, prune to 1", len(tokens))
 	c.currentHMACMap[repo] = tokens[:1]
 }
 
+// recordTokenMetrics records the number and the age of the tokens of every org and repo.
+func recordTokenMetrics(hmacMap map[string]github.HMACsForRepo, now time.Time) {
+	tokenAge.Reset()
+	tokens.Reset()
+	for repo, hmacs := range hmacMap {
+		tokens.WithLabelValues(repo).Set(float64(len(hmacs)))
+		var newest time.Time
+		for _, token := range hmacs {
+			if token.CreatedAt.After(newest) {
+				newest = token.CreatedAt
+			}
+		}
+		if !newest.IsZero() {
+			tokenAge.WithLabelValues(repo).Set(now.Sub(newest).Seconds())
+		}
+	}
+}
+
 // generateNewHMACToken generates a hex encoded crypto random string of length 40.
 func generateNewHMACToken() (string, error) {
 	bytes := make([]byte, 20) // 20 bytes of entropy will result in a string of length 40 after hex encoding
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/prow/cmd/hmac/fakeghhook"
@@ -83,6 +84,7 @@ func TestGatherOptions(t *testing.T) {
 				hmacTokenSecretNamespace: "default",
 				hmacTokenSecretName:      "hmac-token",
 				hmacTokenKey:             "hmac",
+				instrumentationOptions:   flagutil.DefaultInstrumentationOptions(),
 			}
 			if tc.expected != nil {
 				tc.expected(expected)
@@ -127,12 +129,14 @@ func TestPruneOldTokens(t *testing.T) {
 	time1, _ := time.Parse(time.RFC3339, "2020-01-05T19:07:08+00:00")
 	time2, _ := time.Parse(time.RFC3339, "2020-02-05T19:07:08+00:00")
 	time3, _ := time.Parse(time.RFC3339, "2020-03-05T19:07:08+00:00")
+	recent := time.Now().Add(-time.Minute)
 
 	cases := []struct {
-		name     string
-		current  map[string]github.HMACsForRepo
-		repo     string
-		expected map[string]github.HMACsForRepo
+		name        string
+		current     map[string]github.HMACsForRepo
+		repo        string
+		gracePeriod *metav1.Duration
+		expected    map[string]github.HMACsForRepo
 	}{
 		{
 			name: "three hmacs, only the latest one is left after pruning",
@@ -186,6 +190,60 @@ func TestPruneOldTokens(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "two hmacs, the previous one is kept during the grace period",
+			current: map[string]github.HMACsForRepo{
+				"org1/repo1": []github.HMACToken{
+					{
+						Value:     "rand-val1",
+						CreatedAt: time1,
+					},
+					{
+						Value:     "rand-val2",
+						CreatedAt: recent,
+					},
+				},
+			},
+			repo:        "org1/repo1",
+			gracePeriod: &metav1.Duration{Duration: time.Hour},
+			expected: map[string]github.HMACsForRepo{
+				"org1/repo1": []github.HMACToken{
+					{
+						Value:     "rand-val2",
+						CreatedAt: recent,
+					},
+					{
+						Value:     "rand-val1",
+						CreatedAt: time1,
+					},
+				},
+			},
+		},
+		{
+			name: "two hmacs, only the latest one is left after the grace period",
+			current: map[string]github.HMACsForRepo{
+				"org1/repo1": []github.HMACToken{
+					{
+						Value:     "rand-val1",
+						CreatedAt: time1,
+					},
+					{
+						Value:     "rand-val2",
+						CreatedAt: time2,
+					},
+				},
+			},
+			repo:        "org1/repo1",
+			gracePeriod: &metav1.Duration{Duration: time.Hour},
+			expected: map[string]github.HMACsForRepo{
+				"org1/repo1": []github.HMACToken{
+					{
+						Value:     "rand-val2",
+						CreatedAt: time2,
+					},
+				},
+			},
+		},
 		{
 			name: "nothing will be changed if the repo is not in the map",
 			current: map[string]github.HMACsForRepo{
@@ -210,7 +268,7 @@ func TestPruneOldTokens(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			c := &client{currentHMACMap: tc.current}
+			c := &client{currentHMACMap: tc.current, newHMACConfig: config.ManagedWebhooks{RotationGracePeriod: tc.gracePeriod}}
 			c.pruneOldTokens(tc.repo)
 			if !reflect.DeepEqual(tc.expected, c.currentHMACMap) {
 				t.Errorf("%#v != expected %#v", c.currentHMACMap, tc.expected)
@@ -435,6 +493,7 @@ func TestHandleRotatedRepo(t *testing.T) {
 	cases := []struct {
 		name                         string
 		toRotate                     map[string]config.ManagedWebhookInfo
+		rotationInterval             *metav1.Duration
 		currentHMACs                 map[string]github.HMACsForRepo
 		currentHMACMapForBatchUpdate map[string]string
 		expectedHMACsSize            map[string]int
@@ -510,12 +569,46 @@ func TestHandleRotatedRepo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test a repo whose hmac is older than the rotation interval",
+			toRotate: map[string]config.ManagedWebhookInfo{
+				"repo1": {TokenCreatedAfter: pastTime},
+				"repo2": {TokenCreatedAfter: pastTime},
+			},
+			rotationInterval: &metav1.Duration{Duration: 24 * time.Hour},
+			currentHMACs: map[string]github.HMACsForRepo{
+				"repo1": []github.HMACToken{
+					{
+						Value:     "rand-val1",
+						CreatedAt: pastTime.Add(1 * time.Hour),
+					},
+				},
+				"repo2": []github.HMACToken{
+					{
+						Value:     "rand-val2",
+						CreatedAt: time.Now().Add(-1 * time.Hour),
+					},
+				},
+			},
+			currentHMACMapForBatchUpdate: map[string]string{},
+			expectedHMACsSize:            map[string]int{"repo1": 2, "repo2": 1},
+			expectedReposForBatchUpdate:  []string{"repo1"},
+			expectedHMACMapForRecovery: map[string]github.HMACsForRepo{
+				"repo1": []github.HMACToken{
+					{
+						Value:     "rand-val1",
+						CreatedAt: pastTime.Add(1 * time.Hour),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			c := &client{
 				currentHMACMap:        tc.currentHMACs,
+				newHMACConfig:         config.ManagedWebhooks{RotationInterval: tc.rotationInterval},
 				hmacMapForBatchUpdate: tc.currentHMACMapForBatchUpdate,
 				hmacMapForRecovery:    map[string]github.HMACsForRepo{},
 			}
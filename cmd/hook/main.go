@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"net/http"
 	"os"
@@ -25,6 +27,9 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 
 	"sigs.k8s.io/prow/pkg/bugzilla"
@@ -34,8 +39,13 @@ import (
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
+	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githubeventserver"
 	"sigs.k8s.io/prow/pkg/hook"
+	"sigs.k8s.io/prow/pkg/hook/eventqueue"
+	"sigs.k8s.io/prow/pkg/hook/firehose"
+	"sigs.k8s.io/prow/pkg/hook/mirror"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	jiraclient "sigs.k8s.io/prow/pkg/jira"
 	"sigs.k8s.io/prow/pkg/logrusutil"
@@ -46,7 +56,11 @@ import (
 	bzplugin "sigs.k8s.io/prow/pkg/plugins/bugzilla"
 	"sigs.k8s.io/prow/pkg/plugins/jira"
 	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/plugins/releasenote"
+	verifyowners "sigs.k8s.io/prow/pkg/plugins/verify-owners"
+	"sigs.k8s.io/prow/pkg/readiness"
 	"sigs.k8s.io/prow/pkg/repoowners"
+	"sigs.k8s.io/prow/pkg/scm"
 	"sigs.k8s.io/prow/pkg/slack"
 
 	_ "sigs.k8s.io/prow/pkg/version"
@@ -71,17 +85,34 @@ type options struct {
 	bugzilla               prowflagutil.BugzillaOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	jira                   prowflagutil.JiraOptions
-
-	webhookSecretFile string
-	slackTokenFile    string
+	gitlab                 prowflagutil.GitLabOptions
+	bitbucketServer        prowflagutil.BitbucketServerOptions
+	eventQueue             eventqueue.Options
+	mirror                 mirror.Options
+
+	webhookSecretFile                string
+	gitlabWebhookSecretFile          string
+	bitbucketServerWebhookSecretFile string
+	slackTokenFile                   string
+	firehoseTokenFile                string
+	deadLetterTokenFile              string
 }
 
 func (o *options) Validate() error {
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.jira, &o.gitlab, &o.bitbucketServer, &o.eventQueue, &o.mirror, &o.githubEnablement, &o.config, &o.pluginsConfig} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
 	}
+	if o.gitlab.Enabled() && o.gitlabWebhookSecretFile == "" {
+		return errors.New("--gitlab-webhook-secret-file is required with --gitlab-token-path")
+	}
+	if o.bitbucketServer.Enabled() && o.bitbucketServerWebhookSecretFile == "" {
+		return errors.New("--bitbucket-server-webhook-secret-file is required with --bitbucket-server-token-path")
+	}
+	if o.deadLetterTokenFile != "" && !o.eventQueue.Enabled() {
+		return errors.New("--event-queue is required with --dead-letter-token-file")
+	}
 
 	return nil
 }
@@ -94,12 +125,16 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
 	fs.DurationVar(&o.gracePeriod, "grace-period", 180*time.Second, "On shutdown, try to handle remaining events for the specified duration. ")
 	o.pluginsConfig.PluginConfigPathDefault = "/etc/plugins/plugins.yaml"
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.instrumentationOptions, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.instrumentationOptions, &o.jira, &o.gitlab, &o.bitbucketServer, &o.eventQueue, &o.mirror, &o.githubEnablement, &o.config, &o.pluginsConfig} {
 		group.AddFlags(fs)
 	}
 
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
+	fs.StringVar(&o.gitlabWebhookSecretFile, "gitlab-webhook-secret-file", "", "Path to the file containing the secret token of GitLab webhooks. GitLab webhooks are served on /hook/gitlab if --gitlab-token-path is set.")
+	fs.StringVar(&o.bitbucketServerWebhookSecretFile, "bitbucket-server-webhook-secret-file", "", "Path to the file containing the secret of Bitbucket Server webhooks. Bitbucket Server webhooks are served on /hook/bitbucket-server if --bitbucket-server-token-path is set.")
 	fs.StringVar(&o.slackTokenFile, "slack-token-file", "", "Path to the file containing the Slack token to use.")
+	fs.StringVar(&o.firehoseTokenFile, "firehose-token-file", "", "Path to the file containing the bearer token of firehose subscribers. The firehose event stream is served on /firehose if set.")
+	fs.StringVar(&o.deadLetterTokenFile, "dead-letter-token-file", "", "Path to the file containing the bearer token of admins of the event queue. Dead letters are listed, replayed and discarded on /dead-letters if set.")
 	fs.Parse(args)
 	return o
 }
@@ -127,6 +162,9 @@ func main() {
 	if o.github.AppPrivateKeyPath != "" {
 		tokens = append(tokens, o.github.AppPrivateKeyPath)
 	}
+	if o.github.TokenMinterSecretPath != "" {
+		tokens = append(tokens, o.github.TokenMinterSecretPath)
+	}
 	tokens = append(tokens, o.webhookSecretFile)
 
 	// This is necessary since slack token is optional.
@@ -138,6 +176,26 @@ func main() {
 		tokens = append(tokens, o.bugzilla.ApiKeyPath)
 	}
 
+	if o.mirror.Enabled() {
+		tokens = append(tokens, o.mirror.HMACSecretFile)
+	}
+
+	if o.firehoseTokenFile != "" {
+		tokens = append(tokens, o.firehoseTokenFile)
+	}
+
+	if o.deadLetterTokenFile != "" {
+		tokens = append(tokens, o.deadLetterTokenFile)
+	}
+
+	if o.gitlab.Enabled() {
+		tokens = append(tokens, o.gitlabWebhookSecretFile)
+	}
+
+	if o.bitbucketServer.Enabled() {
+		tokens = append(tokens, o.bitbucketServerWebhookSecretFile)
+	}
+
 	if err := secret.Add(tokens...); err != nil {
 		logrus.WithError(err).Fatal("Error starting secrets agent.")
 	}
@@ -151,6 +209,11 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting GitHub client.")
 	}
+	if policy := configAgent.Config().GitHubOptions.ThrottlePolicy("hook"); policy != nil {
+		if err := githubClient.ApplyThrottlePolicy(*policy); err != nil {
+			logrus.WithError(err).Fatal("Error throttling GitHub client.")
+		}
+	}
 	gitClient, err := o.github.GitClientFactory("", &o.config.InRepoConfigCacheDirBase, o.dryRun, false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting Git client.")
@@ -178,6 +241,22 @@ func main() {
 		jiraClient = client
 	}
 
+	scmProviders := map[string]scm.Provider{}
+	if o.gitlab.Enabled() {
+		provider, err := o.gitlab.Provider(o.dryRun, secret.GetTokenGenerator(o.gitlabWebhookSecretFile))
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting GitLab client.")
+		}
+		scmProviders[provider.Name()] = provider
+	}
+	if o.bitbucketServer.Enabled() {
+		provider, err := o.bitbucketServer.Provider(o.dryRun, secret.GetTokenGenerator(o.bitbucketServerWebhookSecretFile))
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting Bitbucket Server client.")
+		}
+		scmProviders[provider.Name()] = provider
+	}
+
 	infrastructureClient, err := o.kubernetes.InfrastructureClusterClient(o.dryRun)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting Kubernetes client for infrastructure cluster.")
@@ -193,6 +272,12 @@ func main() {
 		logrus.WithError(err).Fatal("Error getting ProwJob client for infrastructure cluster.")
 	}
 
+	var firehoseBroker *firehose.Broker
+	if o.firehoseTokenFile != "" {
+		firehoseBroker = firehose.NewBroker()
+		prowJobClient = firehose.NewProwJobClient(prowJobClient, firehoseBroker)
+	}
+
 	var slackClient *slack.Client
 	if !o.dryRun && string(secret.GetSecret(o.slackTokenFile)) != "" {
 		logrus.Info("Using real slack client.")
@@ -233,12 +318,20 @@ func main() {
 		OwnersClient:              ownersClient,
 		BugzillaClient:            bugzillaClient,
 		JiraClient:                jiraClient,
+		SCMProviders:              scmProviders,
 	}
 
 	promMetrics := githubeventserver.NewMetrics()
 
 	defer interrupts.WaitForGracefulShutdown()
 
+	// Periodically look for users who are no longer trusted in OWNERS files.
+	// Only one replica scans, so this needs leader election and is only set up
+	// if the scan is configured at startup.
+	if pluginAgent.Config().Owners.MembershipScan != nil {
+		startMembershipScan(o, configAgent.Config().ProwJobNamespace, githubClient, gitClient, pluginAgent.Config)
+	}
+
 	// Expose prometheus metrics
 	metrics.ExposeMetrics("hook", configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
 	pprof.Instrument(o.instrumentationOptions)
@@ -250,9 +343,32 @@ func main() {
 		Metrics:        promMetrics,
 		RepoEnabled:    o.githubEnablement.EnablementChecker(),
 		TokenGenerator: secret.GetTokenGenerator(o.webhookSecretFile),
+		Firehose:       firehoseBroker,
+		AcceptMirrored: o.dryRun,
+	}
+	if o.mirror.Enabled() {
+		server.Mirror = mirror.New(o.mirror.URL, o.mirror.Percent, secret.GetTokenGenerator(o.mirror.HMACSecretFile))
+	}
+	if o.eventQueue.Enabled() {
+		server.EventQueue, err = o.eventQueue.Store(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating event queue.")
+		}
+		if o.deadLetterTokenFile != "" {
+			server.DeadLetterTokenGenerator = secret.GetTokenGenerator(o.deadLetterTokenFile)
+		}
+		if err := server.ReplayPending(); err != nil {
+			logrus.WithError(err).Error("Error replaying pending events.")
+		}
 	}
 	interrupts.OnInterrupt(func() {
+		// Disconnect firehose subscribers, so that their streams do not
+		// block the shutdown of the http server.
+		if firehoseBroker != nil {
+			firehoseBroker.Close()
+		}
 		server.GracefulShutdown()
+		server.Mirror.Close()
 		if err := gitClient.Clean(); err != nil {
 			logrus.WithError(err).Error("Could not clean up git client cache.")
 		}
@@ -267,12 +383,72 @@ func main() {
 
 	// For /hook, handle a webhook normally.
 	hookMux.Handle(o.webhookPath, server)
+	// For /hook/<provider>, handle a webhook of the orgs configured to be on the provider.
+	for name, provider := range scmProviders {
+		hookMux.HandleFunc(o.webhookPath+"/"+name, server.ServeSCM(provider))
+	}
 	// Serve plugin help information from /plugin-help.
-	hookMux.Handle("/plugin-help", pluginhelp.NewHelpAgent(pluginAgent, githubClient))
+	helpAgent := pluginhelp.NewHelpAgent(pluginAgent, githubClient)
+	hookMux.Handle("/plugin-help", helpAgent)
+	// Serve the machine-readable commands of a repo from /plugin-help/commands?repo=org/repo.
+	hookMux.HandleFunc("/plugin-help/commands", helpAgent.ServeCommandRegistry)
+	// Serve the release notes of a milestone from /release-notes?repo=org/repo&milestone=v1.0.
+	hookMux.Handle("/release-notes", releasenote.NewNotesHandler(githubClient, pluginAgent.Config))
+	// Stream sanitized events to external consumers from /firehose.
+	if firehoseBroker != nil {
+		hookMux.Handle("/firehose", &firehose.Handler{
+			Broker:         firehoseBroker,
+			TokenGenerator: secret.GetTokenGenerator(o.firehoseTokenFile),
+		})
+	}
+	// List, replay and discard the events that plugins failed to handle from /dead-letters.
+	if server.DeadLetterTokenGenerator != nil {
+		hookMux.HandleFunc("/dead-letters", server.ServeDeadLetters)
+	}
 
 	httpServer := &http.Server{Addr: ":" + strconv.Itoa(o.port), Handler: hookMux}
 
+	// Only report ready once the configs are loaded and GitHub was reachable.
+	readiness.Register("config", readiness.Config(configAgent.Config))
+	readiness.Register("plugin-config", readiness.Config(pluginAgent.Config))
+	readiness.Register("github", readiness.GitHub(githubClient))
 	health.ServeReady()
 
 	interrupts.ListenAndServe(httpServer, o.gracePeriod)
 }
+
+// startMembershipScan runs the scan of OWNERS files for users who are no
+// longer trusted on the replica of hook that is the leader.
+func startMembershipScan(o options, namespace string, githubClient github.Client, gitClient git.ClientFactory, getConfig func() *plugins.Configuration) {
+	infrastructureClusterConfig, err := o.kubernetes.InfrastructureClusterConfig(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting config for infrastructure cluster")
+	}
+	mgr, err := manager.New(infrastructureClusterConfig, manager.Options{
+		Cache: cache.Options{
+			DefaultNamespaces: map[string]cache.Config{
+				namespace: {},
+			},
+		},
+		Metrics: metricsserver.Options{
+			BindAddress: "0",
+		},
+		LeaderElection:                true,
+		LeaderElectionNamespace:       namespace,
+		LeaderElectionID:              "prow-hook-membership-scan-leaderlock",
+		LeaderElectionReleaseOnCancel: true,
+	})
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating manager")
+	}
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return verifyowners.RunMembershipScan(ctx, githubClient, gitClient, getConfig)
+	})); err != nil {
+		logrus.WithError(err).Fatal("Failed to add the membership scan to the manager")
+	}
+	interrupts.Run(func(ctx context.Context) {
+		if err := mgr.Start(ctx); err != nil {
+			logrus.WithError(err).Fatal("Failed to start manager")
+		}
+	})
+}
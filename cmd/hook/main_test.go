@@ -77,6 +77,66 @@ func Test_gatherOptions(t *testing.T) {
 				o.webhookPath = "/random/hook"
 			},
 		},
+		{
+			name: "--gitlab-token-path requires --gitlab-webhook-secret-file",
+			args: map[string]string{
+				"--gitlab-token-path": "/etc/gitlab/token",
+			},
+			err: true,
+		},
+		{
+			name: "--bitbucket-server-token-path requires --bitbucket-server-webhook-secret-file",
+			args: map[string]string{
+				"--bitbucket-server-endpoint":   "https://bitbucket.example.com",
+				"--bitbucket-server-token-path": "/etc/bitbucket/token",
+			},
+			err: true,
+		},
+		{
+			name: "explicitly set --event-queue",
+			args: map[string]string{
+				"--event-queue":     "disk",
+				"--event-queue-dir": "/var/lib/hook",
+			},
+			expected: func(o *options) {
+				o.eventQueue.Backend = "disk"
+				o.eventQueue.Dir = "/var/lib/hook"
+			},
+		},
+		{
+			name: "--event-queue=disk requires --event-queue-dir",
+			args: map[string]string{
+				"--event-queue": "disk",
+			},
+			err: true,
+		},
+		{
+			name: "explicitly set --mirror-url",
+			args: map[string]string{
+				"--mirror-url":              "https://staging.example.com/hook",
+				"--mirror-percent":          "10",
+				"--mirror-hmac-secret-file": "/etc/staging/hmac",
+			},
+			expected: func(o *options) {
+				o.mirror.URL = "https://staging.example.com/hook"
+				o.mirror.Percent = 10
+				o.mirror.HMACSecretFile = "/etc/staging/hmac"
+			},
+		},
+		{
+			name: "--mirror-url requires --mirror-hmac-secret-file",
+			args: map[string]string{
+				"--mirror-url": "https://staging.example.com/hook",
+			},
+			err: true,
+		},
+		{
+			name: "--dead-letter-token-file requires --event-queue",
+			args: map[string]string{
+				"--dead-letter-token-file": "/etc/hook/admin-token",
+			},
+			err: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -102,6 +162,10 @@ func Test_gatherOptions(t *testing.T) {
 			}
 			expectedfs := flag.NewFlagSet("fake-flags", flag.PanicOnError)
 			expected.github.AddFlags(expectedfs)
+			expected.gitlab.AddFlags(expectedfs)
+			expected.bitbucketServer.AddFlags(expectedfs)
+			expected.eventQueue.AddFlags(expectedfs)
+			expected.mirror.AddFlags(expectedfs)
 			if tc.expected != nil {
 				tc.expected(expected)
 			}
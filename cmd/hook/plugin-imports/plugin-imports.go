@@ -20,6 +20,7 @@ package pluginimports
 // any hook binary.
 import (
 	_ "sigs.k8s.io/prow/pkg/plugins/approve" // Import all enabled plugins.
+	_ "sigs.k8s.io/prow/pkg/plugins/artifact-gate"
 	_ "sigs.k8s.io/prow/pkg/plugins/assign"
 	_ "sigs.k8s.io/prow/pkg/plugins/blockade"
 	_ "sigs.k8s.io/prow/pkg/plugins/blunderbuss"
@@ -42,7 +43,9 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/label"
 	_ "sigs.k8s.io/prow/pkg/plugins/lgtm"
 	_ "sigs.k8s.io/prow/pkg/plugins/lifecycle"
+	_ "sigs.k8s.io/prow/pkg/plugins/linked-issue"
 	_ "sigs.k8s.io/prow/pkg/plugins/merge-method-comment"
+	_ "sigs.k8s.io/prow/pkg/plugins/merge-when-green"
 	_ "sigs.k8s.io/prow/pkg/plugins/mergecommitblocker"
 	_ "sigs.k8s.io/prow/pkg/plugins/milestone"
 	_ "sigs.k8s.io/prow/pkg/plugins/milestoneapplier"
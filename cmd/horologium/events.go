@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+const (
+	webhookPathPrefix = "/webhook/"
+	// maxWebhookPayload limits the size of webhook request bodies.
+	maxWebhookPayload = 1 << 20
+	attributesPrefix  = "attributes."
+)
+
+// event is an external event that may trigger periodics.
+type event struct {
+	// source identifies the event source, it is recorded on the ProwJobs
+	// triggered by the event.
+	source     string
+	payload    []byte
+	attributes map[string]string
+}
+
+// eventTriggerer creates ProwJobs for periodics triggered by events.
+type eventTriggerer struct {
+	prowJobClient ctrlruntimeclient.Client
+	cfg           config.Getter
+}
+
+// trigger creates a ProwJob for every periodic with an event trigger that
+// matches. It returns the names of the created ProwJobs.
+func (t *eventTriggerer) trigger(ctx context.Context, e event, matches func(config.PeriodicEventTrigger) bool) ([]string, error) {
+	cfg := t.cfg()
+	var created []string
+	var errs []error
+	for _, p := range cfg.Periodics {
+		for _, et := range p.EventTriggers {
+			if !matches(et) {
+				continue
+			}
+			env, err := payloadEnv(et.Env, e.payload, e.attributes)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("periodic %s: %w", p.Name, err))
+				continue
+			}
+			annotations := map[string]string{kube.EventTriggerAnnotation: e.source}
+			for k, v := range p.Annotations {
+				annotations[k] = v
+			}
+			prowJob := pjutil.NewProwJob(pjutil.PeriodicSpec(p), p.Labels, annotations,
+				pjutil.RequireScheduling(cfg.Scheduler.Enabled))
+			prowJob.Namespace = cfg.ProwJobNamespace
+			if prowJob.Spec.PodSpec != nil && len(env) > 0 {
+				// The pod spec is shared with the config.
+				prowJob.Spec.PodSpec = prowJob.Spec.PodSpec.DeepCopy()
+				for i := range prowJob.Spec.PodSpec.Containers {
+					for _, name := range sets.List(sets.KeySet(env)) {
+						prowJob.Spec.PodSpec.Containers[i].Env = append(prowJob.Spec.PodSpec.Containers[i].Env, corev1.EnvVar{Name: name, Value: env[name]})
+					}
+				}
+			}
+			logrus.WithField("event-source", e.source).WithFields(pjutil.ProwJobFields(&prowJob)).Info("Triggering new run for event.")
+			if err := t.prowJobClient.Create(ctx, &prowJob); err != nil {
+				errs = append(errs, fmt.Errorf("periodic %s: %w", p.Name, err))
+				continue
+			}
+			created = append(created, prowJob.Name)
+			// A single event triggers a periodic at most once.
+			break
+		}
+	}
+	return created, utilerrors.NewAggregate(errs)
+}
+
+// payloadEnv resolves the environment variables of an event trigger from the
+// event payload and attributes. Variables referencing missing fields are not
+// set.
+func payloadEnv(mapping map[string]string, payload []byte, attributes map[string]string) (map[string]string, error) {
+	env := map[string]string{}
+	var parsed interface{}
+	parsedPayload := false
+	for name, path := range mapping {
+		if strings.HasPrefix(path, attributesPrefix) {
+			if value, ok := attributes[strings.TrimPrefix(path, attributesPrefix)]; ok {
+				env[name] = value
+			}
+			continue
+		}
+		if !parsedPayload {
+			parsedPayload = true
+			if len(bytes.TrimSpace(payload)) > 0 {
+				if err := json.Unmarshal(payload, &parsed); err != nil {
+					return nil, fmt.Errorf("failed to parse event payload as JSON: %w", err)
+				}
+			}
+		}
+		value, ok := lookup(parsed, strings.Split(path, "."))
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			env[name] = v
+		default:
+			raw, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize field %s: %w", path, err)
+			}
+			env[name] = string(raw)
+		}
+	}
+	return env, nil
+}
+
+// lookup returns the field at the given path of a parsed JSON value.
+func lookup(value interface{}, path []string) (interface{}, bool) {
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// webhookHandler triggers periodics with webhook event triggers. Requests
+// must be authenticated with the shared token as bearer token.
+type webhookHandler struct {
+	triggerer *eventTriggerer
+	token     func() []byte
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), bytes.TrimSpace(h.token())) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, webhookPathPrefix)
+	if name == "" || strings.Contains(name, "/") {
+		http.Error(w, "invalid webhook path", http.StatusNotFound)
+		return
+	}
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayload))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	configured := false
+	created, err := h.triggerer.trigger(r.Context(), event{source: "webhook/" + name, payload: payload}, func(et config.PeriodicEventTrigger) bool {
+		matches := et.Webhook != nil && et.Webhook.Name == name
+		configured = configured || matches
+		return matches
+	})
+	if !configured {
+		http.Error(w, fmt.Sprintf("no periodics are triggered by webhook %s", name), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("webhook", name).Error("Failed to trigger periodics for webhook.")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "Triggered %s\n", strings.Join(created, ", "))
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestPayloadEnv(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mapping     map[string]string
+		payload     string
+		attributes  map[string]string
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			name:     "nested fields",
+			mapping:  map[string]string{"IMAGE": "image.name", "TAG": "image.tag"},
+			payload:  `{"image": {"name": "gcr.io/project/app", "tag": "v1.2.3"}}`,
+			expected: map[string]string{"IMAGE": "gcr.io/project/app", "TAG": "v1.2.3"},
+		},
+		{
+			name:     "non-string fields are serialized as JSON",
+			mapping:  map[string]string{"BUILD": "build", "LABELS": "labels", "PUBLISHED": "published"},
+			payload:  `{"build": 42, "labels": ["a", "b"], "published": true}`,
+			expected: map[string]string{"BUILD": "42", "LABELS": `["a","b"]`, "PUBLISHED": "true"},
+		},
+		{
+			name:     "missing fields are not set",
+			mapping:  map[string]string{"TAG": "image.tag", "DIGEST": "digest.sha"},
+			payload:  `{"image": "gcr.io/project/app", "digest": null}`,
+			expected: map[string]string{},
+		},
+		{
+			name:       "attributes",
+			mapping:    map[string]string{"ACTION": "attributes.action", "MISSING": "attributes.missing"},
+			payload:    `not json`,
+			attributes: map[string]string{"action": "INSERT"},
+			expected:   map[string]string{"ACTION": "INSERT"},
+		},
+		{
+			name:     "empty payload",
+			mapping:  map[string]string{"TAG": "tag"},
+			expected: map[string]string{},
+		},
+		{
+			name:        "invalid payload",
+			mapping:     map[string]string{"TAG": "tag"},
+			payload:     `not json`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := payloadEnv(tc.mapping, []byte(tc.payload), tc.attributes)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); err == nil && diff != "" {
+				t.Errorf("unexpected env (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func eventTriggerConfig() *config.Config {
+	spec := &corev1.PodSpec{Containers: []corev1.Container{{Image: "image", Env: []corev1.EnvVar{{Name: "FOO", Value: "foo"}}}}}
+	return &config.Config{
+		ProwConfig: config.ProwConfig{ProwJobNamespace: "prowjobs"},
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{
+				{
+					JobBase: config.JobBase{Name: "nightly-or-published", Spec: spec, Annotations: map[string]string{"owner": "team"}},
+					Cron:    "0 0 * * *",
+					EventTriggers: []config.PeriodicEventTrigger{
+						{
+							PubSub: &config.PubSubEventSource{Project: "project", Subscription: "artifacts"},
+							Env:    map[string]string{"TAG": "tag", "ACTION": "attributes.action"},
+						},
+						{Webhook: &config.WebhookEventSource{Name: "upstream"}},
+					},
+				},
+				{
+					JobBase:       config.JobBase{Name: "on-webhook", Spec: spec},
+					EventTriggers: []config.PeriodicEventTrigger{{Webhook: &config.WebhookEventSource{Name: "upstream"}, Env: map[string]string{"TAG": "tag"}}},
+				},
+				{
+					JobBase:  config.JobBase{Name: "interval", Spec: spec},
+					Interval: "1h",
+				},
+			},
+		},
+	}
+}
+
+func createdJobs(t *testing.T, client *createTrackingClient) map[string][]corev1.EnvVar {
+	t.Helper()
+	jobs := map[string][]corev1.EnvVar{}
+	for _, obj := range client.created {
+		pj := obj.(*prowapi.ProwJob)
+		if pj.Namespace != "prowjobs" {
+			t.Errorf("expected job %s in namespace prowjobs, got %s", pj.Spec.Job, pj.Namespace)
+		}
+		if pj.Annotations[kube.EventTriggerAnnotation] == "" {
+			t.Errorf("expected job %s to have the %s annotation", pj.Spec.Job, kube.EventTriggerAnnotation)
+		}
+		jobs[pj.Spec.Job] = pj.Spec.PodSpec.Containers[0].Env
+	}
+	return jobs
+}
+
+func TestPubSubListenerHandle(t *testing.T) {
+	client := newCreateTrackingClient(nil)
+	cfg := eventTriggerConfig()
+	listener := &pubSubListener{triggerer: &eventTriggerer{prowJobClient: client, cfg: func() *config.Config { return cfg }}}
+
+	msg := &pubsub.Message{Data: []byte(`{"tag": "v1"}`), Attributes: map[string]string{"action": "INSERT"}}
+	listener.handle(context.Background(), config.PubSubEventSource{Project: "project", Subscription: "artifacts"}, msg)
+
+	expected := map[string][]corev1.EnvVar{
+		"nightly-or-published": {{Name: "FOO", Value: "foo"}, {Name: "ACTION", Value: "INSERT"}, {Name: "TAG", Value: "v1"}},
+	}
+	if diff := cmp.Diff(expected, createdJobs(t, client)); diff != "" {
+		t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+	}
+	if annotation := client.created[0].GetAnnotations()[kube.EventTriggerAnnotation]; annotation != "pubsub/project/artifacts" {
+		t.Errorf("expected event trigger annotation pubsub/project/artifacts, got %q", annotation)
+	}
+	if owner := client.created[0].GetAnnotations()["owner"]; owner != "team" {
+		t.Errorf("expected job annotations to be kept, got %v", client.created[0].GetAnnotations())
+	}
+
+	if env := cfg.Periodics[0].Spec.Containers[0].Env; len(env) != 1 {
+		t.Errorf("expected the config to not be modified, got env %v", env)
+	}
+
+	if sources := pubSubSources(cfg); !cmp.Equal(sources, []config.PubSubEventSource{{Project: "project", Subscription: "artifacts"}}) {
+		t.Errorf("unexpected Pub/Sub sources: %v", sources)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		token        string
+		body         string
+		expectedCode int
+		expectedJobs map[string][]corev1.EnvVar
+	}{
+		{
+			name:         "triggers all periodics of the webhook",
+			method:       http.MethodPost,
+			path:         "/webhook/upstream",
+			token:        "secret",
+			body:         `{"tag": "v2"}`,
+			expectedCode: http.StatusOK,
+			expectedJobs: map[string][]corev1.EnvVar{
+				"nightly-or-published": {{Name: "FOO", Value: "foo"}},
+				"on-webhook":           {{Name: "FOO", Value: "foo"}, {Name: "TAG", Value: "v2"}},
+			},
+		},
+		{
+			name:         "invalid token is rejected",
+			method:       http.MethodPost,
+			path:         "/webhook/upstream",
+			token:        "wrong",
+			expectedCode: http.StatusUnauthorized,
+			expectedJobs: map[string][]corev1.EnvVar{},
+		},
+		{
+			name:         "only POST is allowed",
+			method:       http.MethodGet,
+			path:         "/webhook/upstream",
+			token:        "secret",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedJobs: map[string][]corev1.EnvVar{},
+		},
+		{
+			name:         "unknown webhook",
+			method:       http.MethodPost,
+			path:         "/webhook/unknown",
+			token:        "secret",
+			expectedCode: http.StatusNotFound,
+			expectedJobs: map[string][]corev1.EnvVar{},
+		},
+		{
+			name:         "invalid payload",
+			method:       http.MethodPost,
+			path:         "/webhook/upstream",
+			token:        "secret",
+			body:         `not json`,
+			expectedCode: http.StatusInternalServerError,
+			expectedJobs: map[string][]corev1.EnvVar{
+				"nightly-or-published": {{Name: "FOO", Value: "foo"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newCreateTrackingClient(nil)
+			cfg := eventTriggerConfig()
+			handler := &webhookHandler{
+				triggerer: &eventTriggerer{prowJobClient: client, cfg: func() *config.Config { return cfg }},
+				token:     func() []byte { return []byte("secret\n") },
+			}
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			if diff := cmp.Diff(tc.expectedJobs, createdJobs(t, client)); diff != "" {
+				t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncSkipsEventOnlyPeriodics(t *testing.T) {
+	cfg := eventTriggerConfig()
+	cfg.Periodics[2].SetInterval(time.Hour)
+	client := newCreateTrackingClient(nil)
+	if err := sync(client, cfg, &fakeCron{}, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var triggered []string
+	for _, obj := range client.created {
+		triggered = append(triggered, obj.(*prowapi.ProwJob).Spec.Job)
+	}
+	if diff := cmp.Diff([]string{"nightly-or-published", "interval"}, triggered); diff != "" {
+		t.Errorf("unexpected triggered jobs (-want +got):\n%s", diff)
+	}
+}
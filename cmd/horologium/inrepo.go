@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/git/v2"
+)
+
+type refGetter interface {
+	GetRef(org, repo, ref string) (string, error)
+}
+
+// inRepoPeriodics loads the periodics defined in the in-repo config of the
+// repos in in_repo_config.periodics_branches. The periodics of a repo are
+// only reloaded when the head of its branch or the config changes.
+type inRepoPeriodics struct {
+	gc  git.ClientFactory
+	ghc refGetter
+
+	loaded map[string]loadedPeriodics
+}
+
+type loadedPeriodics struct {
+	cfg       *config.Config
+	sha       string
+	periodics []config.Periodic
+}
+
+func newInRepoPeriodics(gc git.ClientFactory, ghc refGetter) *inRepoPeriodics {
+	return &inRepoPeriodics{gc: gc, ghc: ghc, loaded: map[string]loadedPeriodics{}}
+}
+
+// withPeriodics returns a copy of the config that includes the in-repo
+// periodics. The periodics last loaded for a repo are kept if loading them
+// fails, so that they are not unscheduled by a transient error.
+func (r *inRepoPeriodics) withPeriodics(cfg *config.Config) *config.Config {
+	var repos []string
+	for repo := range cfg.InRepoConfig.PeriodicsBranches {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	loaded := map[string]loadedPeriodics{}
+	periodics := append([]config.Periodic(nil), cfg.Periodics...)
+	// The in-repo config of a repo cannot redefine a central periodic, but
+	// nothing prevents two repos from defining the same name. The repo that
+	// sorts first keeps it.
+	definedBy := map[string]string{}
+	for _, identifier := range repos {
+		log := logrus.WithField("repo", identifier)
+		previous, found := r.loaded[identifier]
+		current, err := r.load(cfg, identifier, previous)
+		if err != nil {
+			log.WithError(err).Error("Failed to load in-repo periodics.")
+			if !found {
+				continue
+			}
+			current = previous
+		}
+		loaded[identifier] = current
+		for _, periodic := range current.periodics {
+			if other, defined := definedBy[periodic.Name]; defined {
+				log.WithField("job", periodic.Name).Errorf("Periodic is already defined in the in-repo config of %s, ignoring it.", other)
+				continue
+			}
+			definedBy[periodic.Name] = identifier
+			periodics = append(periodics, periodic)
+		}
+	}
+	r.loaded = loaded
+
+	if len(periodics) == len(cfg.Periodics) {
+		return cfg
+	}
+	withPeriodics := *cfg
+	withPeriodics.Periodics = periodics
+	return &withPeriodics
+}
+
+func (r *inRepoPeriodics) load(cfg *config.Config, identifier string, previous loadedPeriodics) (loadedPeriodics, error) {
+	org, repo, err := config.SplitRepoName(identifier)
+	if err != nil {
+		return loadedPeriodics{}, err
+	}
+	sha, err := r.ghc.GetRef(org, repo, "heads/"+cfg.InRepoConfig.PeriodicsBranches[identifier])
+	if err != nil {
+		return loadedPeriodics{}, err
+	}
+	if previous.cfg == cfg && previous.sha == sha {
+		return previous, nil
+	}
+	periodics, err := cfg.GetInRepoPeriodics(r.gc, identifier, func() (string, error) { return sha, nil })
+	if err != nil {
+		return loadedPeriodics{}, err
+	}
+	return loadedPeriodics{cfg: cfg, sha: sha, periodics: periodics}, nil
+}
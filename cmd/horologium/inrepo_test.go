@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/git/v2"
+)
+
+type fakeRefGetter struct {
+	shas map[string]string
+	err  error
+}
+
+func (f *fakeRefGetter) GetRef(org, repo, ref string) (string, error) {
+	return f.shas[org+"/"+repo+"@"+ref], f.err
+}
+
+func TestInRepoPeriodics(t *testing.T) {
+	enabled := true
+	loads := map[string]int{}
+	var loadErr error
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "central"}}},
+			ProwYAMLGetterWithDefaults: func(_ *config.Config, _ git.ClientFactory, identifier, baseBranch, baseSHA string, _ ...string) (*config.ProwYAML, error) {
+				loads[identifier+"@"+baseBranch+":"+baseSHA]++
+				if loadErr != nil {
+					return nil, loadErr
+				}
+				return &config.ProwYAML{Periodics: []config.Periodic{{JobBase: config.JobBase{Name: identifier + "-" + baseSHA}}}}, nil
+			},
+		},
+		ProwConfig: config.ProwConfig{InRepoConfig: config.InRepoConfig{
+			Enabled:           map[string]*bool{"*": &enabled},
+			PeriodicsBranches: map[string]string{"org/a": "main", "org/b": "release"},
+		}},
+	}
+	ghc := &fakeRefGetter{shas: map[string]string{"org/a@heads/main": "1", "org/b@heads/release": "2"}}
+	r := newInRepoPeriodics(nil, ghc)
+
+	names := func(c *config.Config) []string {
+		var names []string
+		for _, p := range c.Periodics {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+	if diff := cmp.Diff([]string{"central", "org/a-1", "org/b-2"}, names(r.withPeriodics(cfg))); diff != "" {
+		t.Errorf("periodics differ from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"central"}, names(cfg)); diff != "" {
+		t.Errorf("expected the config not to be changed (-want +got):\n%s", diff)
+	}
+
+	// The periodics are only reloaded when the branch moves.
+	ghc.shas["org/a@heads/main"] = "3"
+	if diff := cmp.Diff([]string{"central", "org/a-3", "org/b-2"}, names(r.withPeriodics(cfg))); diff != "" {
+		t.Errorf("periodics differ from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int{"org/a@main:1": 1, "org/a@main:3": 1, "org/b@release:2": 1}, loads); diff != "" {
+		t.Errorf("loads differ from expected (-want +got):\n%s", diff)
+	}
+
+	// The periodics are kept if they cannot be reloaded.
+	ghc.shas["org/a@heads/main"] = "4"
+	loadErr = errors.New("injected error")
+	if diff := cmp.Diff([]string{"central", "org/a-3", "org/b-2"}, names(r.withPeriodics(cfg))); diff != "" {
+		t.Errorf("periodics differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestInRepoPeriodicsDefinedByTwoRepos(t *testing.T) {
+	enabled := true
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			ProwYAMLGetterWithDefaults: func(_ *config.Config, _ git.ClientFactory, identifier, _, _ string, _ ...string) (*config.ProwYAML, error) {
+				return &config.ProwYAML{Periodics: []config.Periodic{
+					{JobBase: config.JobBase{Name: "shared", Labels: map[string]string{"repo": identifier}}},
+					{JobBase: config.JobBase{Name: identifier}},
+				}}, nil
+			},
+		},
+		ProwConfig: config.ProwConfig{InRepoConfig: config.InRepoConfig{
+			Enabled:           map[string]*bool{"*": &enabled},
+			PeriodicsBranches: map[string]string{"org/b": "main", "org/a": "main"},
+		}},
+	}
+	ghc := &fakeRefGetter{shas: map[string]string{"org/a@heads/main": "1", "org/b@heads/main": "2"}}
+
+	var got []string
+	for _, p := range newInRepoPeriodics(nil, ghc).withPeriodics(cfg).Periodics {
+		got = append(got, p.Name+"@"+p.Labels["repo"])
+	}
+	if diff := cmp.Diff([]string{"shared@org/a", "org/a@", "org/b@"}, got); diff != "" {
+		t.Errorf("periodics differ from expected (-want +got):\n%s", diff)
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"hash/fnv"
+	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -31,11 +34,13 @@ import (
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/config/secret"
 	"sigs.k8s.io/prow/pkg/cron"
 	pkgflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
 	"sigs.k8s.io/prow/pkg/pjutil"
@@ -44,6 +49,9 @@ import (
 
 const (
 	defaultTickInterval = time.Minute
+	// maxMissedRuns is the maximum number of missed runs of a periodic with
+	// run_missed: all that are created at once.
+	maxMissedRuns = 50
 )
 
 type options struct {
@@ -53,6 +61,14 @@ type options struct {
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	controllerManager      prowflagutil.ControllerManagerOptions
 	dryRun                 bool
+
+	enablePubSubTriggers bool
+	webhookPort          int
+	webhookTokenFile     string
+
+	inRepoPeriodics bool
+	github          prowflagutil.GitHubOptions
+	cookiefilePath  string
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
@@ -64,6 +80,12 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	o.instrumentationOptions.AddFlags(fs)
 	o.controllerManager.TimeoutListingProwJobsDefault = 60 * time.Second
 	o.controllerManager.AddFlags(fs)
+	fs.BoolVar(&o.enablePubSubTriggers, "enable-pubsub-triggers", false, "Whether to trigger periodics on messages of the Pub/Sub subscriptions in their event_triggers. Requires GCP credentials.")
+	fs.IntVar(&o.webhookPort, "webhook-port", 8888, "Port to serve the webhooks of periodic event_triggers on.")
+	fs.StringVar(&o.webhookTokenFile, "webhook-token-file", "", "Path to the file containing the bearer token webhook requests must be authenticated with. Webhooks are disabled if unset.")
+	fs.BoolVar(&o.inRepoPeriodics, "in-repo-periodics", false, "Whether to run the periodics defined in the in-repo config of the repos in in_repo_config.periodics_branches. Requires GitHub credentials.")
+	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for github or anonymous")
+	o.github.AddFlags(fs)
 
 	fs.Parse(args)
 	return o
@@ -75,6 +97,11 @@ func (o *options) Validate() error {
 			return err
 		}
 	}
+	if o.inRepoPeriodics {
+		if err := o.github.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -132,17 +159,54 @@ func main() {
 
 	metrics.ExposeMetrics("horologium", configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
 
-	tickInterval := defaultTickInterval
-	if configAgent.Config().Horologium.TickInterval != nil {
-		tickInterval = configAgent.Config().Horologium.TickInterval.Duration
+	triggerer := &eventTriggerer{prowJobClient: cluster.GetClient(), cfg: configAgent.Config}
+	if o.enablePubSubTriggers {
+		listener := &pubSubListener{triggerer: triggerer, receiver: gcpPubSubReceiver{}}
+		interrupts.Run(func(ctx context.Context) {
+			listener.run(ctx, configAgent)
+		})
+	}
+	if o.webhookTokenFile != "" {
+		if err := secret.Add(o.webhookTokenFile); err != nil {
+			logrus.WithError(err).Fatal("Error starting secrets agent.")
+		}
+		mux := http.NewServeMux()
+		mux.Handle(webhookPathPrefix, &webhookHandler{triggerer: triggerer, token: secret.GetTokenGenerator(o.webhookTokenFile)})
+		server := &http.Server{Addr: ":" + strconv.Itoa(o.webhookPort), Handler: mux}
+		interrupts.ListenAndServe(server, 5*time.Second)
+	}
+
+	var inRepo *inRepoPeriodics
+	if o.inRepoPeriodics {
+		githubClient, err := o.github.GitHubClient(o.dryRun)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting GitHub client.")
+		}
+		gitClient, err := o.github.GitClientFactory(o.cookiefilePath, &o.config.InRepoConfigCacheDirBase, o.dryRun, false)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting Git client.")
+		}
+		inRepo = newInRepoPeriodics(gitClient, githubClient)
 	}
+
 	interrupts.TickLiteral(func() {
 		start := time.Now()
-		if err := sync(cluster.GetClient(), configAgent.Config(), cr, start); err != nil {
+		cfg := configAgent.Config()
+		if inRepo != nil {
+			cfg = inRepo.withPeriodics(cfg)
+		}
+		if err := sync(cluster.GetClient(), cfg, cr, start); err != nil {
 			logrus.WithError(err).Error("Error syncing periodic jobs.")
 		}
 		logrus.WithField("duration", time.Since(start)).Info("Synced periodic jobs")
-	}, tickInterval)
+	}, tickInterval(configAgent.Config()))
+}
+
+func tickInterval(cfg *config.Config) time.Duration {
+	if cfg.Horologium.TickInterval != nil {
+		return cfg.Horologium.TickInterval.Duration
+	}
+	return defaultTickInterval
 }
 
 type cronClient interface {
@@ -156,6 +220,7 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 		return fmt.Errorf("error listing prow jobs: %w", err)
 	}
 	latestJobs := pjutil.GetLatestProwJobs(jobs.Items, prowapi.PeriodicJob)
+	lastRuns := lastScheduledRuns(jobs.Items)
 
 	if err := cr.SyncConfig(cfg); err != nil {
 		logrus.WithError(err).Error("Error syncing cron jobs.")
@@ -168,6 +233,9 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 
 	var errs []error
 	for _, p := range cfg.Periodics {
+		if p.OnlyTriggeredByEvents() {
+			continue
+		}
 		j, previousFound := latestJobs[p.Name]
 		logger := logrus.WithFields(logrus.Fields{
 			"job":            p.Name,
@@ -175,6 +243,7 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 		})
 
 		var shouldTrigger = false
+		var runs []time.Time
 		switch {
 		case p.Cron == "": // no cron expression is set, we use interval to trigger
 			if j.Complete() {
@@ -186,6 +255,20 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 				}
 				shouldTrigger = now.Sub(intervalRef) > intervalDuration
 			}
+		case p.Scheduled():
+			if !previousFound {
+				break
+			}
+			lastRun, ok := lastRuns[p.Name]
+			if !ok {
+				lastRun = j.Status.StartTime.Time
+			}
+			var err error
+			if runs, err = dueRuns(p, lastRun, now, 2*tickInterval(cfg)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			shouldTrigger = len(runs) > 0 && j.Complete()
 		case cronTriggers.Has(p.Name):
 			shouldTrigger = j.Complete()
 		default:
@@ -207,17 +290,27 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 			}).Debug("Trigger time has not yet been reached.")
 		}
 		if !previousFound || shouldTrigger {
-			prowJob := pjutil.NewProwJob(pjutil.PeriodicSpec(p), p.Labels, p.Annotations,
-				pjutil.RequireScheduling(cfg.Scheduler.Enabled))
-			prowJob.Namespace = cfg.ProwJobNamespace
-			logger.WithFields(logrus.Fields{
-				"should-trigger": shouldTrigger,
-				"previous-found": previousFound,
-			}).WithFields(
-				pjutil.ProwJobFields(&prowJob),
-			).Info("Triggering new run.")
-			if err := prowJobClient.Create(context.TODO(), &prowJob); err != nil {
-				errs = append(errs, err)
+			if len(runs) == 0 {
+				runs = []time.Time{now}
+			}
+			for _, run := range runs {
+				annotations := map[string]string{kube.LastRunAnnotation: run.UTC().Format(time.RFC3339)}
+				for k, v := range p.Annotations {
+					annotations[k] = v
+				}
+				prowJob := pjutil.NewProwJob(pjutil.PeriodicSpec(p), p.Labels, annotations,
+					pjutil.RequireScheduling(cfg.Scheduler.Enabled))
+				prowJob.Namespace = cfg.ProwJobNamespace
+				logger.WithFields(logrus.Fields{
+					"should-trigger": shouldTrigger,
+					"previous-found": previousFound,
+					"scheduled-for":  run,
+				}).WithFields(
+					pjutil.ProwJobFields(&prowJob),
+				).Info("Triggering new run.")
+				if err := prowJobClient.Create(context.TODO(), &prowJob); err != nil {
+					errs = append(errs, err)
+				}
 			}
 		}
 	}
@@ -227,3 +320,74 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 	}
 	return nil
 }
+
+// lastScheduledRuns returns the time of the most recent run of each periodic
+// that was created on its schedule, as recorded in the last-run annotation.
+func lastScheduledRuns(jobs []prowapi.ProwJob) map[string]time.Time {
+	lastRuns := map[string]time.Time{}
+	for _, j := range jobs {
+		if j.Spec.Type != prowapi.PeriodicJob {
+			continue
+		}
+		value, ok := j.Annotations[kube.LastRunAnnotation]
+		if !ok {
+			continue
+		}
+		lastRun, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			logrus.WithError(err).WithField("prowjob", j.Name).Warn("Ignoring invalid last-run annotation.")
+			continue
+		}
+		if lastRun.After(lastRuns[j.Spec.Job]) {
+			lastRuns[j.Spec.Job] = lastRun
+		}
+	}
+	return lastRuns
+}
+
+// dueRuns returns the times of the runs of a scheduled periodic that are due
+// since its last run according to its cron, delayed by its jitter, and its
+// run_missed policy. Runs that are overdue by more than missedAfter count as
+// missed.
+func dueRuns(p config.Periodic, lastRun, now time.Time, missedAfter time.Duration) ([]time.Time, error) {
+	schedule, err := cron.ParseSchedule(p.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron of periodic %s: %w", p.Name, err)
+	}
+	offset := jitterOffset(p.Name, p.GetJitter())
+
+	var due []time.Time
+	for next := schedule.Next(lastRun); !next.IsZero() && !next.Add(offset).After(now); next = schedule.Next(next) {
+		due = append(due, next)
+		if len(due) > maxMissedRuns {
+			due = due[1:]
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	latest := due[len(due)-1]
+	switch p.RunMissed {
+	case config.RunMissedAll:
+		return due, nil
+	case config.RunMissedLatest:
+		return []time.Time{latest}, nil
+	}
+	if now.Sub(latest.Add(offset)) > missedAfter {
+		return nil, nil
+	}
+	return []time.Time{latest}, nil
+}
+
+// jitterOffset returns the delay of the runs of a periodic within its jitter.
+// It is derived from the name of the job so that it is the same for every run
+// and across restarts.
+func jitterOffset(name string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return time.Duration(h.Sum64() % uint64(jitter)).Truncate(time.Second)
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/kube"
 )
 
 type fakeCron struct {
@@ -356,6 +358,183 @@ func TestSyncCron(t *testing.T) {
 	}
 }
 
+func TestSyncScheduled(t *testing.T) {
+	hour := func(h, m int) time.Time {
+		return time.Date(2026, time.January, 7, h, m, 0, 0, time.UTC)
+	}
+	jitter := 30 * time.Minute
+	offset := jitterOffset("j", jitter)
+
+	var everyMinute []string
+	for i := maxMissedRuns; i > 0; i-- {
+		everyMinute = append(everyMinute, hour(12, 10).Add(time.Duration(1-i)*time.Minute).Format(time.RFC3339))
+	}
+
+	testcases := []struct {
+		name         string
+		cron         string
+		runMissed    config.RunMissedPolicy
+		jitter       string
+		previous     bool
+		lastRun      time.Time
+		startTime    time.Time
+		jobRunning   bool
+		now          time.Time
+		expectedRuns []string
+	}{
+		{
+			name:         "first run starts right away",
+			runMissed:    config.RunMissedLatest,
+			now:          hour(12, 10),
+			expectedRuns: []string{"2026-01-07T12:10:00Z"},
+		},
+		{
+			name:      "no run due",
+			runMissed: config.RunMissedLatest,
+			previous:  true,
+			lastRun:   hour(12, 0),
+			now:       hour(12, 10),
+		},
+		{
+			name:         "latest catches up on the most recent missed run",
+			runMissed:    config.RunMissedLatest,
+			previous:     true,
+			lastRun:      hour(9, 0),
+			now:          hour(12, 10),
+			expectedRuns: []string{"2026-01-07T12:00:00Z"},
+		},
+		{
+			name:         "all catches up on every missed run",
+			runMissed:    config.RunMissedAll,
+			previous:     true,
+			lastRun:      hour(9, 0),
+			now:          hour(12, 10),
+			expectedRuns: []string{"2026-01-07T10:00:00Z", "2026-01-07T11:00:00Z", "2026-01-07T12:00:00Z"},
+		},
+		{
+			name:         "all catches up on a limited number of runs",
+			cron:         "* * * * *",
+			runMissed:    config.RunMissedAll,
+			previous:     true,
+			lastRun:      hour(9, 0),
+			now:          hour(12, 10),
+			expectedRuns: everyMinute,
+		},
+		{
+			name:       "catching up waits for the previous run to complete",
+			runMissed:  config.RunMissedLatest,
+			previous:   true,
+			lastRun:    hour(9, 0),
+			jobRunning: true,
+			now:        hour(12, 10),
+		},
+		{
+			name:         "last run falls back to the start time of the previous run",
+			runMissed:    config.RunMissedAll,
+			previous:     true,
+			startTime:    hour(10, 30),
+			now:          hour(12, 10),
+			expectedRuns: []string{"2026-01-07T11:00:00Z", "2026-01-07T12:00:00Z"},
+		},
+		{
+			name:         "none runs the current run",
+			jitter:       "1s",
+			previous:     true,
+			lastRun:      hour(11, 0),
+			now:          hour(12, 1),
+			expectedRuns: []string{"2026-01-07T12:00:00Z"},
+		},
+		{
+			name:     "none skips missed runs",
+			jitter:   "1s",
+			previous: true,
+			lastRun:  hour(9, 0),
+			now:      hour(12, 10),
+		},
+		{
+			name:     "jitter delays the run",
+			jitter:   jitter.String(),
+			previous: true,
+			lastRun:  hour(11, 0),
+			now:      hour(12, 0).Add(offset - time.Second),
+		},
+		{
+			name:         "run starts after the jitter",
+			jitter:       jitter.String(),
+			previous:     true,
+			lastRun:      hour(11, 0),
+			now:          hour(12, 0).Add(offset),
+			expectedRuns: []string{"2026-01-07T12:00:00Z"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cron := tc.cron
+			if cron == "" {
+				cron = "0 * * * *"
+			}
+			periodic := config.Periodic{JobBase: config.JobBase{Name: "j"}, Cron: cron, RunMissed: tc.runMissed, Jitter: tc.jitter}
+			if tc.jitter != "" {
+				d, err := time.ParseDuration(tc.jitter)
+				if err != nil {
+					t.Fatalf("invalid jitter: %v", err)
+				}
+				periodic.SetJitter(d)
+			}
+			cfg := config.Config{
+				ProwConfig: config.ProwConfig{ProwJobNamespace: "prowjobs"},
+				JobConfig:  config.JobConfig{Periodics: []config.Periodic{periodic}},
+			}
+
+			var jobs []client.Object
+			if tc.previous {
+				job := &prowapi.ProwJob{
+					ObjectMeta: metav1.ObjectMeta{Name: "previous", Namespace: "prowjobs"},
+					Spec:       prowapi.ProwJobSpec{Type: prowapi.PeriodicJob, Job: "j"},
+					Status:     prowapi.ProwJobStatus{StartTime: metav1.NewTime(tc.startTime)},
+				}
+				if !tc.lastRun.IsZero() {
+					job.Annotations = map[string]string{kube.LastRunAnnotation: tc.lastRun.Format(time.RFC3339)}
+					job.Status.StartTime = metav1.NewTime(tc.lastRun)
+				}
+				if !tc.jobRunning {
+					job.Status.CompletionTime = &job.Status.StartTime
+				}
+				jobs = append(jobs, job)
+			}
+			fakeProwJobClient := newCreateTrackingClient(jobs)
+			// The cron agent triggers cron periodics, which must be ignored
+			// for scheduled periodics.
+			if err := sync(fakeProwJobClient, &cfg, &fakeCron{jobs: []string{"j"}}, tc.now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var runs []string
+			for _, obj := range fakeProwJobClient.created {
+				runs = append(runs, obj.GetAnnotations()[kube.LastRunAnnotation])
+			}
+			if diff := cmp.Diff(tc.expectedRuns, runs); diff != "" {
+				t.Errorf("created runs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJitterOffset(t *testing.T) {
+	if offset := jitterOffset("j", 0); offset != 0 {
+		t.Errorf("expected no offset without jitter, got %v", offset)
+	}
+	for _, name := range []string{"j", "periodic-ci-build", "periodic-ci-test"} {
+		offset := jitterOffset(name, time.Hour)
+		if offset < 0 || offset >= time.Hour {
+			t.Errorf("offset %v of %s is not within the jitter", offset, name)
+		}
+		if again := jitterOffset(name, time.Hour); again != offset {
+			t.Errorf("offset of %s changed from %v to %v", name, offset, again)
+		}
+	}
+}
+
 func TestFlags(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -404,6 +583,34 @@ func TestFlags(t *testing.T) {
 				o.controllerManager.TimeoutListingProwJobsDefault = 60 * time.Second
 			},
 		},
+		{
+			name: "explicitly set event trigger flags",
+			args: map[string]string{
+				"--enable-pubsub-triggers": "true",
+				"--webhook-port":           "8080",
+				"--webhook-token-file":     "/etc/webhook/token",
+			},
+			expected: func(o *options) {
+				o.enablePubSubTriggers = true
+				o.webhookPort = 8080
+				o.webhookTokenFile = "/etc/webhook/token"
+				o.controllerManager.TimeoutListingProwJobs = 60 * time.Second
+				o.controllerManager.TimeoutListingProwJobsDefault = 60 * time.Second
+			},
+		},
+		{
+			name: "in-repo periodics",
+			args: map[string]string{
+				"--in-repo-periodics": "true",
+				"--cookiefile":        "/etc/cookies",
+			},
+			expected: func(o *options) {
+				o.inRepoPeriodics = true
+				o.cookiefilePath = "/etc/cookies"
+				o.controllerManager.TimeoutListingProwJobs = 60 * time.Second
+				o.controllerManager.TimeoutListingProwJobsDefault = 60 * time.Second
+			},
+		},
 		{
 			name: "dry run defaults to true",
 			expected: func(o *options) {
@@ -426,6 +633,7 @@ func TestFlags(t *testing.T) {
 				},
 				dryRun:                 true,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
+				webhookPort:            8888,
 			}
 			if tc.expected != nil {
 				tc.expected(expected)
@@ -447,6 +655,8 @@ func TestFlags(t *testing.T) {
 			}
 			fs := flag.NewFlagSet("fake-flags", flag.PanicOnError)
 			actual := gatherOptions(fs, args...)
+			// The GitHub options are covered by their own tests.
+			expected.github = actual.github
 			switch err := actual.Validate(); {
 			case err != nil:
 				if !tc.err {
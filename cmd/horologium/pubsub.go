@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// pubSubRetryInterval is the time to wait before listening to a subscription
+// again after receiving from it failed.
+const pubSubRetryInterval = time.Minute
+
+// pubSubReceiver receives messages from a Pub/Sub subscription until the
+// context is cancelled.
+type pubSubReceiver interface {
+	receive(ctx context.Context, source config.PubSubEventSource, f func(context.Context, *pubsub.Message)) error
+}
+
+type gcpPubSubReceiver struct{}
+
+func (gcpPubSubReceiver) receive(ctx context.Context, source config.PubSubEventSource, f func(context.Context, *pubsub.Message)) error {
+	client, err := pubsub.NewClient(ctx, source.Project)
+	if err != nil {
+		return fmt.Errorf("failed to create Pub/Sub client: %w", err)
+	}
+	defer client.Close()
+	return client.Subscription(source.Subscription).Receive(ctx, f)
+}
+
+// pubSubListener triggers periodics with Pub/Sub event triggers for the
+// messages published to their subscriptions.
+type pubSubListener struct {
+	triggerer *eventTriggerer
+	receiver  pubSubReceiver
+}
+
+// pubSubSources returns all Pub/Sub subscriptions periodics are triggered by.
+func pubSubSources(cfg *config.Config) []config.PubSubEventSource {
+	seen := sets.New[config.PubSubEventSource]()
+	var sources []config.PubSubEventSource
+	for _, p := range cfg.Periodics {
+		for _, et := range p.EventTriggers {
+			if et.PubSub == nil || seen.Has(*et.PubSub) {
+				continue
+			}
+			seen.Insert(*et.PubSub)
+			sources = append(sources, *et.PubSub)
+		}
+	}
+	return sources
+}
+
+// handle triggers the periodics of a Pub/Sub message. Messages are only
+// acknowledged once all periodics were triggered, so that failures are
+// retried.
+func (l *pubSubListener) handle(ctx context.Context, source config.PubSubEventSource, msg *pubsub.Message) {
+	e := event{
+		source:     fmt.Sprintf("pubsub/%s/%s", source.Project, source.Subscription),
+		payload:    msg.Data,
+		attributes: msg.Attributes,
+	}
+	_, err := l.triggerer.trigger(ctx, e, func(et config.PeriodicEventTrigger) bool {
+		return et.PubSub != nil && *et.PubSub == source
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("event-source", e.source).WithField("message-id", msg.ID).Error("Failed to trigger periodics for Pub/Sub message.")
+		msg.Nack()
+		return
+	}
+	msg.Ack()
+}
+
+// listen receives messages of all subscriptions until the context is
+// cancelled.
+func (l *pubSubListener) listen(ctx context.Context, sources []config.PubSubEventSource) {
+	var g errgroup.Group
+	for _, source := range sources {
+		g.Go(func() error {
+			logger := logrus.WithFields(logrus.Fields{"project": source.Project, "subscription": source.Subscription})
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				logger.Info("Listening for Pub/Sub subscription.")
+				err := l.receiver.receive(ctx, source, func(ctx context.Context, msg *pubsub.Message) {
+					l.handle(ctx, source, msg)
+				})
+				if err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+					logger.WithError(err).Error("Failed to receive from Pub/Sub subscription.")
+				}
+			}, pubSubRetryInterval)
+			return nil
+		})
+	}
+	g.Wait()
+}
+
+// run listens to the subscriptions of the current config and restarts
+// listening whenever they change, until the context is cancelled.
+func (l *pubSubListener) run(ctx context.Context, configAgent *config.Agent) {
+	configEvents := make(chan config.Delta, 2)
+	configAgent.Subscribe(configEvents)
+
+	for {
+		sources := pubSubSources(configAgent.Config())
+		listenCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			l.listen(listenCtx, sources)
+		}()
+
+		changed := false
+		for !changed {
+			select {
+			case <-ctx.Done():
+				cancel()
+				<-done
+				return
+			case delta := <-configEvents:
+				changed = !reflect.DeepEqual(sources, pubSubSources(&delta.After))
+			}
+		}
+		logrus.Info("Pub/Sub event triggers changed, restarting listeners.")
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// lifecycle-manager marks inactive issues and pull requests as stale, then as
+// rotten and finally closes them, according to the lifecycle policies of the
+// Prow config. It is meant to run as a periodic job.
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+)
+
+const (
+	defaultTokens = 300
+	defaultBurst  = 100
+)
+
+type options struct {
+	config configflagutil.ConfigOptions
+	github flagutil.GitHubOptions
+
+	dryRun     bool
+	maxActions int
+	reportPath string
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{}
+
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate, only reports the transitions.")
+	fs.IntVar(&o.maxActions, "max-actions", 100, "Maximum number of transitions per run, to spread large backlogs over several runs. Unlimited if 0.")
+	fs.StringVar(&o.reportPath, "report-path", "", "If set, write a YAML report of the transitions of the run to this file.")
+
+	o.config.AddFlags(fs)
+	o.github.AddCustomizedFlags(fs, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
+	if err := fs.Parse(args); err != nil {
+		logrus.WithError(err).Fatal("could not parse input")
+	}
+	return o
+}
+
+func (o *options) Validate() error {
+	if err := o.config.Validate(o.dryRun); err != nil {
+		return err
+	}
+	if o.maxActions < 0 {
+		return errors.New("--max-actions must not be negative")
+	}
+	return o.github.Validate(o.dryRun)
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error loading config.")
+	}
+
+	gc, err := o.github.GitHubClient(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting GitHub client.")
+	}
+
+	m := &manager{
+		gc:         gc,
+		now:        time.Now,
+		dryRun:     o.dryRun,
+		maxActions: o.maxActions,
+	}
+	actions, err := m.run(configAgent.Config().LifecycleManager)
+	if o.reportPath != "" {
+		if reportErr := writeReport(o.reportPath, actions); reportErr != nil {
+			logrus.WithError(reportErr).Error("Error writing report.")
+		}
+	}
+	if err != nil {
+		logrus.WithError(err).Fatal("Errors occurred.")
+	}
+	logrus.WithField("transitions", len(actions)).Info("Done.")
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+)
+
+type githubClient interface {
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	CreateComment(org, repo string, number int, comment string) error
+	CloseIssueAsNotPlanned(org, repo string, number int) error
+	ClosePullRequest(org, repo string, number int) error
+}
+
+type transition string
+
+const (
+	transitionStale  transition = "stale"
+	transitionRotten transition = "rotten"
+	transitionClose  transition = "close"
+)
+
+// action is the transition of an issue or pull request through the lifecycle.
+type action struct {
+	Org         string     `json:"org"`
+	Repo        string     `json:"repo"`
+	Number      int        `json:"number"`
+	PullRequest bool       `json:"pull_request,omitempty"`
+	Transition  transition `json:"transition"`
+	Inactive    string     `json:"inactive"`
+	Comment     string     `json:"comment"`
+}
+
+// manager moves the issues and pull requests of the managed repos through
+// the lifecycle.
+type manager struct {
+	gc         githubClient
+	now        func() time.Time
+	dryRun     bool
+	maxActions int
+}
+
+// run finds the issues and pull requests that are due for a transition and
+// applies it, unless running in dry-run mode. It returns the transitions of
+// the run. The oldest issues and pull requests transition first, so that a
+// run that hits --max-actions leaves the newer ones for the next run.
+func (m *manager) run(cfg config.LifecycleManager) ([]action, error) {
+	now := m.now()
+	keys := make([]string, 0, len(cfg.Policies))
+	for key := range cfg.Policies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var actions []action
+	var errs []error
+	for _, key := range keys {
+		log := logrus.WithField("policy", key)
+		planned, err := m.plan(cfg, key, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find the issues of %s: %w", key, err))
+			continue
+		}
+		for _, a := range planned {
+			if m.maxActions > 0 && len(actions) >= m.maxActions {
+				log.WithField("max-actions", m.maxActions).Info("Reached the maximum number of transitions, leaving the rest for the next run.")
+				return actions, utilerrors.NewAggregate(errs)
+			}
+			actions = append(actions, a)
+			actionLog := log.WithFields(logrus.Fields{"org": a.Org, "repo": a.Repo, "number": a.Number, "transition": a.Transition, "inactive": a.Inactive})
+			if m.dryRun {
+				actionLog.Info("(dry-run) Transitioning.")
+				continue
+			}
+			actionLog.Info("Transitioning.")
+			if err := m.apply(a); err != nil {
+				errs = append(errs, fmt.Errorf("failed to transition %s/%s#%d to %s: %w", a.Org, a.Repo, a.Number, a.Transition, err))
+			}
+		}
+	}
+	return actions, utilerrors.NewAggregate(errs)
+}
+
+// plan returns the transitions of the issues and pull requests managed by the
+// policy configured under key. The search only narrows the candidates down, the
+// transition of every candidate is decided on its labels and last update.
+func (m *manager) plan(cfg config.LifecycleManager, key string, now time.Time) ([]action, error) {
+	policy := cfg.Policies[key]
+	org, scope := key, "org:"+key
+	if strings.Contains(key, "/") {
+		org, scope = strings.Split(key, "/")[0], "repo:"+key
+	}
+	minInactive := policy.StaleAfter.Duration
+	for _, d := range []time.Duration{policy.RottenAfter.Duration, policy.CloseAfter.Duration} {
+		if d < minInactive {
+			minInactive = d
+		}
+	}
+	query := []string{"is:open", scope, "updated:<" + now.Add(-minInactive).UTC().Format(time.RFC3339)}
+	for _, label := range policy.ExemptLabels {
+		query = append(query, fmt.Sprintf("-label:%q", label))
+	}
+
+	issues, err := m.gc.FindIssuesWithOrg(org, strings.Join(query, " "), "updated", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []action
+	for _, issue := range issues {
+		org, repo, err := issue.OrgRepo()
+		if err != nil {
+			return nil, err
+		}
+		orgRepo := config.OrgRepo{Org: org, Repo: repo}
+		// An org policy does not manage the repos with a policy of their own.
+		if policyKey, _ := cfg.PolicyFor(orgRepo.Org, orgRepo.Repo); policyKey != key {
+			continue
+		}
+		a, err := planIssue(policy, orgRepo, issue, now)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			actions = append(actions, *a)
+		}
+	}
+	return actions, nil
+}
+
+// planIssue returns the transition of an issue or pull request, or nil if it
+// is not due for one.
+func planIssue(policy *config.LifecyclePolicy, orgRepo config.OrgRepo, issue github.Issue, now time.Time) (*action, error) {
+	for _, label := range policy.ExemptLabels {
+		if issue.HasLabel(label) {
+			return nil, nil
+		}
+	}
+
+	inactive := now.Sub(issue.UpdatedAt)
+	var t transition
+	var tmpl string
+	var next time.Duration
+	switch {
+	case issue.HasLabel(labels.LifecycleRotten):
+		if inactive < policy.CloseAfter.Duration {
+			return nil, nil
+		}
+		t, tmpl = transitionClose, policy.CloseComment
+	case issue.HasLabel(labels.LifecycleStale):
+		if inactive < policy.RottenAfter.Duration {
+			return nil, nil
+		}
+		t, tmpl, next = transitionRotten, policy.RottenComment, policy.CloseAfter.Duration
+	default:
+		if inactive < policy.StaleAfter.Duration {
+			return nil, nil
+		}
+		t, tmpl, next = transitionStale, policy.StaleComment, policy.RottenAfter.Duration
+	}
+
+	data := config.LifecycleCommentData{
+		Org:      orgRepo.Org,
+		Repo:     orgRepo.Repo,
+		Number:   issue.Number,
+		Kind:     "issue",
+		Inactive: formatDuration(inactive),
+	}
+	if issue.IsPullRequest() {
+		data.Kind = "PR"
+	}
+	if next > 0 {
+		data.Next = formatDuration(next)
+	}
+	comment, err := config.LifecycleComment(tmpl, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the %s comment: %w", t, err)
+	}
+	return &action{
+		Org:         orgRepo.Org,
+		Repo:        orgRepo.Repo,
+		Number:      issue.Number,
+		PullRequest: issue.IsPullRequest(),
+		Transition:  t,
+		Inactive:    data.Inactive,
+		Comment:     comment,
+	}, nil
+}
+
+func (m *manager) apply(a action) error {
+	switch a.Transition {
+	case transitionStale:
+		if err := m.gc.AddLabel(a.Org, a.Repo, a.Number, labels.LifecycleStale); err != nil {
+			return err
+		}
+	case transitionRotten:
+		if err := m.gc.RemoveLabel(a.Org, a.Repo, a.Number, labels.LifecycleStale); err != nil {
+			return err
+		}
+		if err := m.gc.AddLabel(a.Org, a.Repo, a.Number, labels.LifecycleRotten); err != nil {
+			return err
+		}
+	}
+	if err := m.gc.CreateComment(a.Org, a.Repo, a.Number, a.Comment); err != nil {
+		return err
+	}
+	if a.Transition != transitionClose {
+		return nil
+	}
+	if a.PullRequest {
+		return m.gc.ClosePullRequest(a.Org, a.Repo, a.Number)
+	}
+	return m.gc.CloseIssueAsNotPlanned(a.Org, a.Repo, a.Number)
+}
+
+// formatDuration formats durations of a day and more in days, e.g. "90d".
+func formatDuration(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.Round(time.Minute).String()
+}
+
+func writeReport(path string, actions []action) error {
+	b, err := yaml.Marshal(actions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return os.WriteFile(path, b, 0644)
+}
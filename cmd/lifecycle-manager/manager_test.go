@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+)
+
+type fakeGitHub struct {
+	issues  map[string][]github.Issue
+	queries []string
+	calls   []string
+}
+
+func (f *fakeGitHub) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	f.queries = append(f.queries, query)
+	return f.issues[org], nil
+}
+
+func (f *fakeGitHub) AddLabel(org, repo string, number int, label string) error {
+	f.calls = append(f.calls, fmt.Sprintf("AddLabel %s/%s#%d %s", org, repo, number, label))
+	return nil
+}
+
+func (f *fakeGitHub) RemoveLabel(org, repo string, number int, label string) error {
+	f.calls = append(f.calls, fmt.Sprintf("RemoveLabel %s/%s#%d %s", org, repo, number, label))
+	return nil
+}
+
+func (f *fakeGitHub) CreateComment(org, repo string, number int, comment string) error {
+	f.calls = append(f.calls, fmt.Sprintf("CreateComment %s/%s#%d %s", org, repo, number, comment))
+	return nil
+}
+
+func (f *fakeGitHub) CloseIssueAsNotPlanned(org, repo string, number int) error {
+	f.calls = append(f.calls, fmt.Sprintf("CloseIssueAsNotPlanned %s/%s#%d", org, repo, number))
+	return nil
+}
+
+func (f *fakeGitHub) ClosePullRequest(org, repo string, number int) error {
+	f.calls = append(f.calls, fmt.Sprintf("ClosePullRequest %s/%s#%d", org, repo, number))
+	return nil
+}
+
+var now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func issue(repo string, number int, inactiveDays int, pr bool, issueLabels ...string) github.Issue {
+	i := github.Issue{
+		Number:    number,
+		HTMLURL:   fmt.Sprintf("https://github.com/acme/%s/issues/%d", repo, number),
+		UpdatedAt: now.Add(-time.Duration(inactiveDays) * 24 * time.Hour),
+	}
+	if pr {
+		i.PullRequest = &struct{}{}
+	}
+	for _, label := range issueLabels {
+		i.Labels = append(i.Labels, github.Label{Name: label})
+	}
+	return i
+}
+
+func lifecycleConfig(t *testing.T) config.LifecycleManager {
+	cfg := config.LifecycleManager{
+		Policies: map[string]*config.LifecyclePolicy{
+			"acme": {
+				StaleComment:  "stale {{.Kind}} after {{.Inactive}}, rotten in {{.Next}}",
+				RottenComment: "rotten {{.Kind}} after {{.Inactive}}, closed in {{.Next}}",
+				CloseComment:  "closed {{.Kind}} after {{.Inactive}}",
+			},
+			"acme/special": {
+				StaleAfter:   &metav1.Duration{Duration: 10 * 24 * time.Hour},
+				ExemptLabels: []string{"keep"},
+				StaleComment: "special {{.Org}}/{{.Repo}}#{{.Number}}",
+			},
+		},
+	}
+	if err := cfg.DefaultAndValidate(); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	return cfg
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		issues     []github.Issue
+		dryRun     bool
+		maxActions int
+
+		expectedActions []action
+		expectedCalls   []string
+	}{
+		{
+			name: "transitions through the lifecycle",
+			issues: []github.Issue{
+				issue("widget", 1, 100, false),
+				issue("widget", 2, 50, false),
+				issue("widget", 3, 40, true, labels.LifecycleStale),
+				issue("widget", 4, 10, false, labels.LifecycleStale),
+				issue("widget", 5, 31, true, labels.LifecycleRotten),
+				issue("widget", 6, 35, false, labels.LifecycleRotten),
+				issue("widget", 7, 400, false, labels.LifecycleFrozen),
+			},
+			expectedActions: []action{
+				{Org: "acme", Repo: "widget", Number: 1, Transition: transitionStale, Inactive: "100d", Comment: "stale issue after 100d, rotten in 30d"},
+				{Org: "acme", Repo: "widget", Number: 3, PullRequest: true, Transition: transitionRotten, Inactive: "40d", Comment: "rotten PR after 40d, closed in 30d"},
+				{Org: "acme", Repo: "widget", Number: 5, PullRequest: true, Transition: transitionClose, Inactive: "31d", Comment: "closed PR after 31d"},
+				{Org: "acme", Repo: "widget", Number: 6, Transition: transitionClose, Inactive: "35d", Comment: "closed issue after 35d"},
+			},
+			expectedCalls: []string{
+				"AddLabel acme/widget#1 lifecycle/stale",
+				"CreateComment acme/widget#1 stale issue after 100d, rotten in 30d",
+				"RemoveLabel acme/widget#3 lifecycle/stale",
+				"AddLabel acme/widget#3 lifecycle/rotten",
+				"CreateComment acme/widget#3 rotten PR after 40d, closed in 30d",
+				"CreateComment acme/widget#5 closed PR after 31d",
+				"ClosePullRequest acme/widget#5",
+				"CreateComment acme/widget#6 closed issue after 35d",
+				"CloseIssueAsNotPlanned acme/widget#6",
+			},
+		},
+		{
+			name: "repo policy takes precedence over the org policy",
+			issues: []github.Issue{
+				issue("special", 1, 11, false),
+				issue("special", 2, 100, false, "keep"),
+				issue("special", 3, 100, false, labels.LifecycleFrozen),
+			},
+			expectedActions: []action{
+				{Org: "acme", Repo: "special", Number: 1, Transition: transitionStale, Inactive: "11d", Comment: "special acme/special#1"},
+				{Org: "acme", Repo: "special", Number: 3, Transition: transitionStale, Inactive: "100d", Comment: "special acme/special#3"},
+			},
+			expectedCalls: []string{
+				"AddLabel acme/special#1 lifecycle/stale",
+				"CreateComment acme/special#1 special acme/special#1",
+				"AddLabel acme/special#3 lifecycle/stale",
+				"CreateComment acme/special#3 special acme/special#3",
+			},
+		},
+		{
+			name:   "dry run only reports",
+			issues: []github.Issue{issue("widget", 1, 100, false)},
+			dryRun: true,
+			expectedActions: []action{
+				{Org: "acme", Repo: "widget", Number: 1, Transition: transitionStale, Inactive: "100d", Comment: "stale issue after 100d, rotten in 30d"},
+			},
+		},
+		{
+			name: "maximum number of transitions",
+			issues: []github.Issue{
+				issue("widget", 1, 100, false),
+				issue("widget", 2, 95, false),
+			},
+			maxActions: 1,
+			expectedActions: []action{
+				{Org: "acme", Repo: "widget", Number: 1, Transition: transitionStale, Inactive: "100d", Comment: "stale issue after 100d, rotten in 30d"},
+			},
+			expectedCalls: []string{
+				"AddLabel acme/widget#1 lifecycle/stale",
+				"CreateComment acme/widget#1 stale issue after 100d, rotten in 30d",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := &fakeGitHub{issues: map[string][]github.Issue{"acme": tc.issues}}
+			m := &manager{gc: gc, now: func() time.Time { return now }, dryRun: tc.dryRun, maxActions: tc.maxActions}
+
+			actions, err := m.run(lifecycleConfig(t))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedActions, actions); diff != "" {
+				t.Errorf("actions differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedCalls, gc.calls); diff != "" {
+				t.Errorf("calls differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunQueries(t *testing.T) {
+	gc := &fakeGitHub{}
+	m := &manager{gc: gc, now: func() time.Time { return now }}
+	if _, err := m.run(lifecycleConfig(t)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		`is:open org:acme updated:<2026-05-02T00:00:00Z -label:"lifecycle/frozen"`,
+		`is:open repo:acme/special updated:<2026-05-22T00:00:00Z -label:"keep"`,
+	}
+	if diff := cmp.Diff(expected, gc.queries); diff != "" {
+		t.Errorf("queries differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.yaml")
+	actions := []action{{Org: "acme", Repo: "widget", Number: 1, Transition: transitionStale, Inactive: "100d", Comment: "stale"}}
+	if err := writeReport(path, actions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var actual []action
+	if err := yaml.Unmarshal(b, &actual); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	if diff := cmp.Diff(actions, actual); diff != "" {
+		t.Errorf("report differs from expected (-want +got):\n%s", diff)
+	}
+}
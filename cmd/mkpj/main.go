@@ -29,8 +29,8 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
-	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pjutil/factory"
 )
 
 type options struct {
@@ -45,135 +45,54 @@ type options struct {
 	pullSha     string
 	pullAuthor  string
 	pullHeadRef string
-	org         string
 	repo        string
+	fromPRURL   string
 
-	github       prowflagutil.GitHubOptions
-	githubClient githubClient
-	pullRequest  *github.PullRequest
+	github prowflagutil.GitHubOptions
 }
 
-func (o *options) genJobSpec(conf *config.Config) (config.JobBase, prowapi.ProwJobSpec) {
-	for fullRepoName, ps := range conf.PresubmitsStatic {
-		org, repo, err := config.SplitRepoName(fullRepoName)
+// request turns the flags into a factory.Request.
+func (o *options) request() (factory.Request, error) {
+	r := factory.Request{
+		JobName:     o.jobName,
+		BaseRef:     o.baseRef,
+		BaseSHA:     o.baseSha,
+		PullNumber:  o.pullNumber,
+		PullSHA:     o.pullSha,
+		PullAuthor:  o.pullAuthor,
+		PullHeadRef: o.pullHeadRef,
+	}
+	if o.repo != "" {
+		org, repo, err := config.SplitRepoName(o.repo)
 		if err != nil {
-			logrus.WithError(err).Warnf("Invalid repo name %s.", fullRepoName)
-			continue
-		}
-		for _, p := range ps {
-			if p.Name == o.jobName {
-				return p.JobBase, pjutil.PresubmitSpec(p, prowapi.Refs{
-					Org:     org,
-					Repo:    repo,
-					BaseRef: o.baseRef,
-					BaseSHA: o.baseSha,
-					Pulls: []prowapi.Pull{{
-						Author:  o.pullAuthor,
-						Number:  o.pullNumber,
-						SHA:     o.pullSha,
-						HeadRef: o.pullHeadRef,
-					}},
-				})
-			}
+			return r, fmt.Errorf("invalid --repo: %w", err)
 		}
+		r.Org, r.Repo = org, repo
 	}
-	for fullRepoName, ps := range conf.PostsubmitsStatic {
-		org, repo, err := config.SplitRepoName(fullRepoName)
+	if o.fromPRURL != "" {
+		org, repo, number, err := factory.ParsePullRequestURL(o.fromPRURL)
 		if err != nil {
-			logrus.WithError(err).Warnf("Invalid repo name %s.", fullRepoName)
-			continue
-		}
-		for _, p := range ps {
-			if p.Name == o.jobName {
-				return p.JobBase, pjutil.PostsubmitSpec(p, prowapi.Refs{
-					Org:     org,
-					Repo:    repo,
-					BaseRef: o.baseRef,
-					BaseSHA: o.baseSha,
-				})
-			}
+			return r, err
 		}
+		r.Org, r.Repo, r.PullNumber = org, repo, number
 	}
-	for _, p := range conf.Periodics {
-		if p.Name == o.jobName {
-			return p.JobBase, pjutil.PeriodicSpec(p)
-		}
-	}
-	return config.JobBase{}, prowapi.ProwJobSpec{}
-}
-
-func (o *options) getPullRequest() (*github.PullRequest, error) {
-	if o.pullRequest != nil {
-		return o.pullRequest, nil
-	}
-	pr, err := o.githubClient.GetPullRequest(o.org, o.repo, o.pullNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch PullRequest from GitHub: %w", err)
-	}
-	o.pullRequest = pr
-	return pr, nil
+	return r, nil
 }
 
-func (o *options) defaultPR(pjs *prowapi.ProwJobSpec) error {
-	if pjs.Refs.Pulls[0].Number == 0 {
-		fmt.Fprint(os.Stderr, "PR Number: ")
-		var pullNumber int
-		fmt.Scanln(&pullNumber)
-		pjs.Refs.Pulls[0].Number = pullNumber
-		o.pullNumber = pullNumber
-	}
-	if pjs.Refs.Pulls[0].Author == "" {
-		pr, err := o.getPullRequest()
-		if err != nil {
-			return err
-		}
-		pjs.Refs.Pulls[0].Author = pr.User.Login
-	}
-	if pjs.Refs.Pulls[0].SHA == "" {
-		pr, err := o.getPullRequest()
-		if err != nil {
-			return err
+// prompt asks for the refs that cannot be defaulted from GitHub.
+func prompt(job *factory.Job, r *factory.Request) {
+	switch job.Type {
+	case prowapi.PresubmitJob:
+		if r.PullNumber == 0 {
+			fmt.Fprint(os.Stderr, "PR Number: ")
+			fmt.Scanln(&r.PullNumber)
 		}
-		pjs.Refs.Pulls[0].SHA = pr.Head.SHA
-	}
-	return nil
-}
-
-func (o *options) defaultBaseRef(pjs *prowapi.ProwJobSpec) error {
-	if pjs.Refs.BaseRef == "" {
-		if o.pullNumber != 0 {
-			pr, err := o.getPullRequest()
-			if err != nil {
-				return err
-			}
-			pjs.Refs.BaseRef = pr.Base.Ref
-		} else {
+	case prowapi.PostsubmitJob:
+		if r.BaseRef == "" {
 			fmt.Fprint(os.Stderr, "Base ref (e.g. master): ")
-			fmt.Scanln(&pjs.Refs.BaseRef)
-		}
-	}
-	if pjs.Refs.BaseSHA == "" {
-		if o.pullNumber != 0 {
-			pr, err := o.getPullRequest()
-			if err != nil {
-				return err
-			}
-			pjs.Refs.BaseSHA = pr.Base.SHA
-		} else {
-			baseSHA, err := o.githubClient.GetRef(o.org, o.repo, fmt.Sprintf("heads/%s", pjs.Refs.BaseRef))
-			if err != nil {
-				logrus.Fatalf("failed to get base sha: %v", err)
-				return err
-			}
-			pjs.Refs.BaseSHA = baseSHA
+			fmt.Scanln(&r.BaseRef)
 		}
 	}
-	return nil
-}
-
-type githubClient interface {
-	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
-	GetRef(org, repo, ref string) (string, error)
 }
 
 func (o *options) Validate() error {
@@ -181,6 +100,10 @@ func (o *options) Validate() error {
 		return errors.New("required flag --job was unset")
 	}
 
+	if o.fromPRURL != "" && (o.pullNumber != 0 || o.repo != "") {
+		return errors.New("--from-pr-url is mutually exclusive with --pull-number and --repo")
+	}
+
 	if err := o.config.Validate(false); err != nil {
 		return err
 	}
@@ -208,6 +131,8 @@ func gatherOptions() options {
 	fs.StringVar(&o.pullSha, "pull-sha", "", "Git pull SHA under test")
 	fs.StringVar(&o.pullAuthor, "pull-author", "", "Git pull author under test")
 	fs.StringVar(&o.pullHeadRef, "pull-head-ref", "", "Git branch name of the proposed change")
+	fs.StringVar(&o.repo, "repo", "", "Repository (org/repo) of the job, required if jobs of several repositories share its name")
+	fs.StringVar(&o.fromPRURL, "from-pr-url", "", "URL of the pull request under test, e.g. https://github.com/org/repo/pull/123. Sets the repo and pull number and defaults the refs from the pull request")
 	fs.BoolVar(&o.triggerJob, "trigger-job", false, "Submit the job to Prow and wait for results")
 	fs.BoolVar(&o.failWithJob, "fail-with-job", false, "Exit with a non-zero exit code if the triggered job fails")
 	o.config.AddFlags(fs)
@@ -231,29 +156,26 @@ func main() {
 	}
 	conf := ca.Config()
 
-	o.githubClient, err = o.github.GitHubClient(false)
+	gc, err := o.github.GitHubClient(false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to get GitHub client")
 	}
-	job, pjs := o.genJobSpec(conf)
-	if job.Name == "" {
-		logrus.Fatalf("Job %s not found.", o.jobName)
+	r, err := o.request()
+	if err != nil {
+		logrus.WithError(err).Fatal("Bad flags")
 	}
-	if pjs.Refs != nil {
-		o.org = pjs.Refs.Org
-		o.repo = pjs.Refs.Repo
-		if len(pjs.Refs.Pulls) != 0 {
-			if err := o.defaultPR(&pjs); err != nil {
-				logrus.WithError(err).Fatal("Failed to default PR")
-			}
-		}
-		if err := o.defaultBaseRef(&pjs); err != nil {
-			logrus.WithError(err).Fatal("Failed to default base ref")
-		}
+	f := factory.New(conf, gc)
+	job, err := f.Job(r)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to find job")
+	}
+	prompt(job, &r)
+	pj, err := f.ProwJob(r)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to create ProwJob")
 	}
-	pj := pjutil.NewProwJob(pjs, job.Labels, job.Annotations, pjutil.RequireScheduling(conf.Scheduler.Enabled))
 	if !o.triggerJob {
-		b, err := yaml.Marshal(&pj)
+		b, err := yaml.Marshal(pj)
 		if err != nil {
 			logrus.WithError(err).Fatal("Error marshalling YAML.")
 		}
@@ -261,7 +183,7 @@ func main() {
 		return
 	}
 
-	if succeeded, err := pjutil.TriggerAndWatchProwJob(o.kubeOptions, &pj, conf, nil, false); err != nil {
+	if succeeded, err := pjutil.TriggerAndWatchProwJob(o.kubeOptions, pj, conf, nil, false); err != nil {
 		logrus.WithError(err).Fatalf("failed while submitting job or watching its result")
 	} else if !succeeded && o.failWithJob {
 		os.Exit(1)
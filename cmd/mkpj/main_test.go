@@ -19,10 +19,8 @@ package main
 import (
 	"testing"
 
-	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
-	"sigs.k8s.io/prow/pkg/github"
-	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/pjutil/factory"
 )
 
 func TestOptions_Validate(t *testing.T) {
@@ -46,6 +44,16 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "PR URL and pull number",
+			input: options{
+				jobName:    "job",
+				config:     configflagutil.ConfigOptions{ConfigPath: "somewhere"},
+				fromPRURL:  "https://github.com/org/repo/pull/1",
+				pullNumber: 1,
+			},
+			expectedErr: true,
+		},
 		{
 			name: "missing job",
 			input: options{
@@ -66,62 +74,43 @@ func TestOptions_Validate(t *testing.T) {
 	}
 }
 
-func TestDefaultPR(t *testing.T) {
-	author := "Bernardo Soares"
-	sha := "Esther Greenwood"
-	fakeGitHubClient := fakegithub.NewFakeClient()
-	fakeGitHubClient.PullRequests = map[int]*github.PullRequest{2: {
-		User: github.User{Login: author},
-		Head: github.PullRequestBranch{SHA: sha},
-	}}
-	o := &options{pullNumber: 2, githubClient: fakeGitHubClient}
-	pjs := &prowapi.ProwJobSpec{Refs: &prowapi.Refs{Pulls: []prowapi.Pull{{Number: 2}}}}
-	if err := o.defaultPR(pjs); err != nil {
-		t.Fatalf("Expected no err when defaulting PJ, but got %v", err)
-	}
-	if pjs.Refs.Pulls[0].Author != author {
-		t.Errorf("Expected author to get defaulted to %s but got %s", author, pjs.Refs.Pulls[0].Author)
-	}
-	if pjs.Refs.Pulls[0].SHA != sha {
-		t.Errorf("Expectged sha to get defaulted to %s but got %s", sha, pjs.Refs.Pulls[0].SHA)
-	}
-}
-
-func TestDefaultBaseRef(t *testing.T) {
+func TestRequest(t *testing.T) {
 	testCases := []struct {
-		name            string
-		baseRef         string
-		expectedBaseSha string
-		pullNumber      int
-		prBaseSha       string
+		name        string
+		input       options
+		expected    factory.Request
+		expectedErr bool
 	}{
 		{
-			name:            "Default for Presubmit",
-			expectedBaseSha: "Theodore Decker",
-			pullNumber:      2,
-			prBaseSha:       "Theodore Decker",
+			name:     "flags",
+			input:    options{jobName: "job", repo: "org/repo", baseRef: "main", pullNumber: 2, pullSha: "abc"},
+			expected: factory.Request{JobName: "job", Org: "org", Repo: "repo", BaseRef: "main", PullNumber: 2, PullSHA: "abc"},
+		},
+		{
+			name:     "from PR URL",
+			input:    options{jobName: "job", fromPRURL: "https://github.com/org/repo/pull/3/files"},
+			expected: factory.Request{JobName: "job", Org: "org", Repo: "repo", PullNumber: 3},
 		},
 		{
-			name:            "Default for Postsubmit",
-			baseRef:         "master",
-			expectedBaseSha: fakegithub.TestRef,
+			name:        "invalid PR URL",
+			input:       options{jobName: "job", fromPRURL: "https://github.com/org/repo/issues/3"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid repo",
+			input:       options{jobName: "job", repo: "repo"},
+			expectedErr: true,
 		},
 	}
 
-	for _, test := range testCases {
-		t.Run(test.name, func(t *testing.T) {
-			fakeGitHubClient := fakegithub.NewFakeClient()
-			fakeGitHubClient.PullRequests = map[int]*github.PullRequest{2: {Base: github.PullRequestBranch{
-				SHA: test.prBaseSha,
-			}}}
-			o := &options{pullNumber: test.pullNumber, githubClient: fakeGitHubClient}
-			pjs := &prowapi.ProwJobSpec{Refs: &prowapi.Refs{BaseRef: test.baseRef}}
-			if err := o.defaultBaseRef(pjs); err != nil {
-				t.Fatalf("Error when calling defaultBaseRef: %v", err)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.input.request()
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
 			}
-			if pjs.Refs.BaseSHA != test.expectedBaseSha {
-				t.Errorf("Expected BaseSHA to be %s after defaulting but was %s",
-					test.expectedBaseSha, pjs.Refs.BaseSHA)
+			if err == nil && actual != tc.expected {
+				t.Errorf("expected request %+v, got %+v", tc.expected, actual)
 			}
 		})
 	}
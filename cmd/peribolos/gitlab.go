@@ -0,0 +1,565 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config/org"
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// The GitLab backend maps the org config onto a top-level group: admins are
+// owners of the group, members are any other direct members, and teams are
+// subgroups whose maintainers and members are Maintainers and Developers.
+
+const (
+	platformGitHub = "github"
+	platformGitLab = "gitlab"
+
+	defaultGitLabEndpoint = "https://gitlab.com/api/v4"
+)
+
+// GitLab access levels, see https://docs.gitlab.com/ee/api/members.html#roles
+const (
+	gitlabDeveloper  = 30
+	gitlabMaintainer = 40
+	gitlabOwner      = 50
+)
+
+type gitlabOptions struct {
+	endpoint  string
+	tokenPath string
+}
+
+func (o *gitlabOptions) addFlags(flags *flag.FlagSet) {
+	flags.StringVar(&o.endpoint, "gitlab-endpoint", defaultGitLabEndpoint, "GitLab API endpoint, used with --platform=gitlab")
+	flags.StringVar(&o.tokenPath, "gitlab-token-path", "", "Path to the file containing the GitLab token, used with --platform=gitlab")
+}
+
+func (o *gitlabOptions) validate() error {
+	if o.tokenPath == "" {
+		return errors.New("--gitlab-token-path is required with --platform=gitlab")
+	}
+	if _, err := url.ParseRequestURI(o.endpoint); err != nil {
+		return fmt.Errorf("invalid --gitlab-endpoint URI: %q", o.endpoint)
+	}
+	return nil
+}
+
+func (o *gitlabOptions) client(dry bool) (*gitlabAPIClient, error) {
+	if err := secret.Add(o.tokenPath); err != nil {
+		return nil, fmt.Errorf("failed to load GitLab token: %w", err)
+	}
+	return newGitLabClient(o.endpoint, secret.GetTokenGenerator(o.tokenPath), dry), nil
+}
+
+type gitlabGroup struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	FullPath    string `json:"full_path,omitempty"`
+	Description string `json:"description"`
+	ParentID    int    `json:"parent_id,omitempty"`
+}
+
+type gitlabMember struct {
+	ID          int    `json:"id"`
+	Username    string `json:"username"`
+	AccessLevel int    `json:"access_level"`
+}
+
+type gitlabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+type gitlabClient interface {
+	CurrentUser() (*gitlabUser, error)
+	GetGroup(fullPath string) (*gitlabGroup, error)
+	ListSubgroups(groupID int) ([]gitlabGroup, error)
+	CreateGroup(group gitlabGroup) (*gitlabGroup, error)
+	UpdateGroup(group gitlabGroup) error
+	ListGroupMembers(groupID int) ([]gitlabMember, error)
+	AddGroupMember(groupID int, username string, accessLevel int) error
+	EditGroupMember(groupID, userID, accessLevel int) error
+	RemoveGroupMember(groupID, userID int) error
+}
+
+// gitlabAPIClient is a minimal client for the parts of the GitLab REST API
+// peribolos needs. In dry mode it only sends GET requests.
+type gitlabAPIClient struct {
+	endpoint string
+	token    func() []byte
+	client   *http.Client
+	dry      bool
+}
+
+func newGitLabClient(endpoint string, token func() []byte, dry bool) *gitlabAPIClient {
+	return &gitlabAPIClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		client:   &http.Client{Timeout: time.Minute},
+		dry:      dry,
+	}
+}
+
+// gitlabStatusError is returned for responses outside the 2XX range.
+type gitlabStatusError struct {
+	method, path string
+	code         int
+	body         string
+}
+
+func (e gitlabStatusError) Error() string {
+	return fmt.Sprintf("%s %s: status code %d: %s", e.method, e.path, e.code, e.body)
+}
+
+func (c *gitlabAPIClient) request(method, path string, query url.Values, body, out interface{}) (http.Header, error) {
+	logger := logrus.WithFields(logrus.Fields{"client": "gitlab", "method": method, "path": path})
+	if c.dry && method != http.MethodGet {
+		logger.Info("Not executing request in dry mode.")
+		return http.Header{}, nil
+	}
+	logger.Debug("Sending request.")
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", string(c.token()))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, gitlabStatusError{method: method, path: path, code: resp.StatusCode, body: string(data)}
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// listAll follows the X-Next-Page header to collect every page of a list.
+func listAll[T any](c *gitlabAPIClient, path string) ([]T, error) {
+	var all []T
+	page := "1"
+	for page != "" {
+		var items []T
+		header, err := c.request(http.MethodGet, path, url.Values{"per_page": []string{"100"}, "page": []string{page}}, nil, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		page = header.Get("X-Next-Page")
+	}
+	return all, nil
+}
+
+func (c *gitlabAPIClient) CurrentUser() (*gitlabUser, error) {
+	var user gitlabUser
+	if _, err := c.request(http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *gitlabAPIClient) GetGroup(fullPath string) (*gitlabGroup, error) {
+	var group gitlabGroup
+	if _, err := c.request(http.MethodGet, "/groups/"+url.PathEscape(fullPath), nil, nil, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (c *gitlabAPIClient) ListSubgroups(groupID int) ([]gitlabGroup, error) {
+	return listAll[gitlabGroup](c, fmt.Sprintf("/groups/%d/subgroups", groupID))
+}
+
+func (c *gitlabAPIClient) CreateGroup(group gitlabGroup) (*gitlabGroup, error) {
+	created := group
+	if _, err := c.request(http.MethodPost, "/groups", nil, group, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *gitlabAPIClient) UpdateGroup(group gitlabGroup) error {
+	_, err := c.request(http.MethodPut, fmt.Sprintf("/groups/%d", group.ID), nil, map[string]string{
+		"name":        group.Name,
+		"description": group.Description,
+	}, nil)
+	return err
+}
+
+func (c *gitlabAPIClient) ListGroupMembers(groupID int) ([]gitlabMember, error) {
+	return listAll[gitlabMember](c, fmt.Sprintf("/groups/%d/members", groupID))
+}
+
+func (c *gitlabAPIClient) AddGroupMember(groupID int, username string, accessLevel int) error {
+	var users []gitlabUser
+	if _, err := c.request(http.MethodGet, "/users", url.Values{"username": []string{username}}, nil, &users); err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return fmt.Errorf("user %s not found", username)
+	}
+	_, err := c.request(http.MethodPost, fmt.Sprintf("/groups/%d/members", groupID), nil, map[string]int{
+		"user_id":      users[0].ID,
+		"access_level": accessLevel,
+	}, nil)
+	return err
+}
+
+func (c *gitlabAPIClient) EditGroupMember(groupID, userID, accessLevel int) error {
+	_, err := c.request(http.MethodPut, fmt.Sprintf("/groups/%d/members/%d", groupID, userID), nil, map[string]int{
+		"access_level": accessLevel,
+	}, nil)
+	return err
+}
+
+func (c *gitlabAPIClient) RemoveGroupMember(groupID, userID int) error {
+	_, err := c.request(http.MethodDelete, fmt.Sprintf("/groups/%d/members/%d", groupID, userID), nil, nil, nil)
+	return err
+}
+
+// gitlabMemberships splits the direct members of a group into those with at
+// least the super access level and all others.
+func gitlabMemberships(current []gitlabMember, superLevel int) (memberships, map[string]gitlabMember) {
+	have := memberships{members: sets.Set[string]{}, super: sets.Set[string]{}}
+	byLogin := make(map[string]gitlabMember, len(current))
+	for _, m := range current {
+		login := github.NormLogin(m.Username)
+		byLogin[login] = m
+		if m.AccessLevel >= superLevel {
+			have.super.Insert(login)
+		} else {
+			have.members.Insert(login)
+		}
+	}
+	return have, byLogin
+}
+
+// configureGitLabMembers adds, updates and removes direct members of the
+// group. Existing members keep their access level as long as they stay on the
+// same side of superLevel.
+func configureGitLabMembers(client gitlabClient, group gitlabGroup, current []gitlabMember, want memberships, superLevel, memberLevel int) error {
+	have, byLogin := gitlabMemberships(current, superLevel)
+	adder := func(user string, super bool) error {
+		level := memberLevel
+		if super {
+			level = superLevel
+		}
+		m, exists := byLogin[user]
+		if !exists {
+			logrus.Infof("Adding %s to %s with access level %d", user, group.FullPath, level)
+			return client.AddGroupMember(group.ID, user, level)
+		}
+		logrus.Infof("Changing access level of %s in %s from %d to %d", user, group.FullPath, m.AccessLevel, level)
+		return client.EditGroupMember(group.ID, m.ID, level)
+	}
+	remover := func(user string) error {
+		logrus.Infof("Removing %s from %s", user, group.FullPath)
+		return client.RemoveGroupMember(group.ID, byLogin[user].ID)
+	}
+	return configureMembers(have, want, sets.Set[string]{}, adder, remover)
+}
+
+func configureGitLabGroupMembers(opt options, client gitlabClient, group gitlabGroup, orgConfig org.Config) error {
+	want := memberships{members: sets.New[string](orgConfig.Members...), super: sets.New[string](orgConfig.Admins...)}
+	want.normalize()
+
+	if n := len(want.super); n < opt.minAdmins {
+		return fmt.Errorf("%s must specify at least %d admins, only found %d", group.FullPath, opt.minAdmins, n)
+	}
+	var missing []string
+	for _, r := range opt.requiredAdmins.Strings() {
+		if !want.super.Has(github.NormLogin(r)) {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s must specify %v as admins, missing %v", group.FullPath, opt.requiredAdmins, missing)
+	}
+	if opt.requireSelf {
+		if me, err := client.CurrentUser(); err != nil {
+			return fmt.Errorf("cannot determine user making requests for %s: %w", opt.gitlab.tokenPath, err)
+		} else if !want.super.Has(github.NormLogin(me.Username)) {
+			return fmt.Errorf("authenticated user %s is not an admin of %s", me.Username, group.FullPath)
+		}
+	}
+
+	current, err := client.ListGroupMembers(group.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list %s members: %w", group.FullPath, err)
+	}
+	have, _ := gitlabMemberships(current, gitlabOwner)
+	remove := have.all().Difference(want.all())
+	if d := float64(len(remove)) / float64(len(have.all())); d > opt.maximumDelta {
+		return fmt.Errorf("cannot delete %d memberships or %.3f of %s (exceeds limit of %.3f)", len(remove), d, group.FullPath, opt.maximumDelta)
+	}
+
+	return configureGitLabMembers(client, group, current, want, gitlabOwner, gitlabDeveloper)
+}
+
+// configureGitLabGroupMeta updates the name and description of the group.
+// The other metadata fields only exist on GitHub.
+func configureGitLabGroupMeta(client gitlabClient, group gitlabGroup, want org.Metadata) error {
+	change := updateString(&group.Name, want.Name)
+	change = updateString(&group.Description, want.Description) || change
+	if !change {
+		return nil
+	}
+	if err := client.UpdateGroup(group); err != nil {
+		return fmt.Errorf("failed to edit %s metadata: %w", group.FullPath, err)
+	}
+	return nil
+}
+
+var gitlabPathRE = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// gitlabPath derives the path of a new subgroup from the team name.
+func gitlabPath(name string) string {
+	return strings.Trim(gitlabPathRE.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+}
+
+// configureGitLabSubgroups creates and updates a subgroup of the parent for
+// every team. Subgroups are matched by name, falling back to previous names.
+// Undeclared subgroups are never deleted, as that would delete their projects.
+func configureGitLabSubgroups(opt options, client gitlabClient, parent gitlabGroup, teams map[string]org.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	var subgroups []gitlabGroup
+	if parent.ID != 0 {
+		var err error
+		if subgroups, err = client.ListSubgroups(parent.ID); err != nil {
+			return fmt.Errorf("failed to list subgroups of %s: %w", parent.FullPath, err)
+		}
+	}
+	byName := make(map[string]gitlabGroup, len(subgroups))
+	for _, sg := range subgroups {
+		byName[sg.Name] = sg
+	}
+
+	var errs []error
+	used := sets.Set[string]{}
+	for _, name := range sets.List(sets.KeySet(teams)) {
+		team := teams[name]
+		if len(team.Repos) > 0 || team.Privacy != nil {
+			logrus.Warnf("Ignoring repos and privacy of team %s, they are not supported on GitLab", name)
+		}
+		var description string
+		if team.Description != nil {
+			description = *team.Description
+		}
+
+		var subgroup gitlabGroup
+		if sg, found := findSubgroup(byName, name, team.Previously...); found {
+			subgroup = sg
+			change := updateString(&subgroup.Name, &name)
+			change = updateString(&subgroup.Description, team.Description) || change
+			if change {
+				logrus.Infof("Updating subgroup %s", subgroup.FullPath)
+				if err := client.UpdateGroup(subgroup); err != nil {
+					errs = append(errs, fmt.Errorf("failed to update subgroup %s: %w", subgroup.FullPath, err))
+					continue
+				}
+			}
+		} else {
+			want := gitlabGroup{Name: name, Path: gitlabPath(name), Description: description, ParentID: parent.ID}
+			logrus.Infof("Creating subgroup %s in %s", want.Path, parent.FullPath)
+			created, err := client.CreateGroup(want)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to create subgroup %s in %s: %w", name, parent.FullPath, err))
+				continue
+			}
+			subgroup = *created
+			if subgroup.FullPath == "" {
+				subgroup.FullPath = parent.FullPath + "/" + want.Path
+			}
+		}
+		used.Insert(subgroup.Name)
+
+		if !opt.fixTeamMembers {
+			logrus.Infof("Skipping subgroup member configuration")
+		} else if err := configureGitLabSubgroupMembers(client, subgroup, team); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := configureGitLabSubgroups(opt, client, subgroup, team.Children); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, sg := range subgroups {
+		if !used.Has(sg.Name) {
+			logrus.Warnf("Subgroup %s is not configured, delete it manually if it is no longer needed", sg.FullPath)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func findSubgroup(subgroups map[string]gitlabGroup, name string, previousNames ...string) (gitlabGroup, bool) {
+	for _, n := range append([]string{name}, previousNames...) {
+		if sg, ok := subgroups[n]; ok {
+			return sg, true
+		}
+	}
+	return gitlabGroup{}, false
+}
+
+func configureGitLabSubgroupMembers(client gitlabClient, subgroup gitlabGroup, team org.Team) error {
+	// Subgroups created in dry mode do not exist, so they have no members.
+	var current []gitlabMember
+	if subgroup.ID != 0 {
+		var err error
+		if current, err = client.ListGroupMembers(subgroup.ID); err != nil {
+			return fmt.Errorf("failed to list %s members: %w", subgroup.FullPath, err)
+		}
+	}
+	want := memberships{members: sets.New[string](team.Members...), super: sets.New[string](team.Maintainers...)}
+	if err := configureGitLabMembers(client, subgroup, current, want, gitlabMaintainer, gitlabDeveloper); err != nil {
+		return fmt.Errorf("failed to configure %s members: %w", subgroup.FullPath, err)
+	}
+	return nil
+}
+
+func configureGitLabGroup(opt options, client gitlabClient, groupPath string, orgConfig org.Config) error {
+	if len(orgConfig.Repos) > 0 || len(orgConfig.Labels) > 0 {
+		logrus.Warnf("Ignoring repos and labels of %s, they are not supported on GitLab", groupPath)
+	}
+	group, err := client.GetGroup(groupPath)
+	if err != nil {
+		return fmt.Errorf("failed to get group %s: %w", groupPath, err)
+	}
+
+	if !opt.fixOrg {
+		logrus.Infof("Skipping group metadata configuration")
+	} else if err := configureGitLabGroupMeta(client, *group, orgConfig.Metadata); err != nil {
+		return err
+	}
+
+	if !opt.fixOrgMembers {
+		logrus.Infof("Skipping group member configuration")
+	} else if err := configureGitLabGroupMembers(opt, client, *group, orgConfig); err != nil {
+		return fmt.Errorf("failed to configure %s members: %w", groupPath, err)
+	}
+
+	if !opt.fixTeams {
+		logrus.Infof("Skipping subgroup and subgroup member configuration")
+		return nil
+	}
+	if err := validateTeamNames(orgConfig); err != nil {
+		return err
+	}
+	if err := configureGitLabSubgroups(opt, client, *group, orgConfig.Teams); err != nil {
+		return fmt.Errorf("failed to configure %s subgroups: %w", groupPath, err)
+	}
+	return nil
+}
+
+func dumpGitLabGroupConfig(client gitlabClient, groupPath string) (*org.Config, error) {
+	group, err := client.GetGroup(groupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	out := org.Config{}
+	out.Metadata.Name = &group.Name
+	out.Metadata.Description = &group.Description
+
+	members, err := client.ListGroupMembers(group.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+	have, _ := gitlabMemberships(members, gitlabOwner)
+	out.Admins = sets.List(have.super)
+	out.Members = sets.List(have.members)
+	logrus.Debugf("Found %d owners and %d other members", len(out.Admins), len(out.Members))
+
+	if out.Teams, err = dumpGitLabSubgroups(client, *group); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func dumpGitLabSubgroups(client gitlabClient, parent gitlabGroup) (map[string]org.Team, error) {
+	subgroups, err := client.ListSubgroups(parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subgroups of %s: %w", parent.FullPath, err)
+	}
+	logrus.Debugf("Found %d subgroups in %s", len(subgroups), parent.FullPath)
+	teams := make(map[string]org.Team, len(subgroups))
+	for _, sg := range subgroups {
+		members, err := client.ListGroupMembers(sg.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s members: %w", sg.FullPath, err)
+		}
+		have, _ := gitlabMemberships(members, gitlabMaintainer)
+		d := sg.Description
+		team := org.Team{
+			TeamMetadata: org.TeamMetadata{Description: &d},
+			Maintainers:  sets.List(have.super),
+			Members:      sets.List(have.members),
+		}
+		if team.Children, err = dumpGitLabSubgroups(client, sg); err != nil {
+			return nil, err
+		}
+		teams[sg.Name] = team
+	}
+	return teams, nil
+}
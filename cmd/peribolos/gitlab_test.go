@@ -0,0 +1,410 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config/org"
+)
+
+type fakeGitLabClient struct {
+	groups  map[int]gitlabGroup
+	members map[int][]gitlabMember
+	users   map[string]int
+}
+
+func newFakeGitLabClient(groups []gitlabGroup, members map[string]map[string]int) *fakeGitLabClient {
+	f := &fakeGitLabClient{
+		groups:  map[int]gitlabGroup{},
+		members: map[int][]gitlabMember{},
+		users:   map[string]int{},
+	}
+	for _, g := range groups {
+		f.groups[g.ID] = g
+	}
+	for path, levels := range members {
+		id := f.groupID(path)
+		for user, level := range levels {
+			f.members[id] = append(f.members[id], gitlabMember{ID: f.userID(user), Username: user, AccessLevel: level})
+		}
+	}
+	return f
+}
+
+func (f *fakeGitLabClient) groupID(path string) int {
+	for id, g := range f.groups {
+		if g.FullPath == path {
+			return id
+		}
+	}
+	return 0
+}
+
+func (f *fakeGitLabClient) userID(user string) int {
+	if _, ok := f.users[user]; !ok {
+		f.users[user] = len(f.users) + 1
+	}
+	return f.users[user]
+}
+
+// state returns the access levels of the members of every group by path.
+func (f *fakeGitLabClient) state() map[string]map[string]int {
+	out := map[string]map[string]int{}
+	for id, g := range f.groups {
+		out[g.FullPath] = map[string]int{}
+		for _, m := range f.members[id] {
+			out[g.FullPath][m.Username] = m.AccessLevel
+		}
+	}
+	return out
+}
+
+func (f *fakeGitLabClient) CurrentUser() (*gitlabUser, error) {
+	return &gitlabUser{ID: f.userID("me"), Username: "me"}, nil
+}
+
+func (f *fakeGitLabClient) GetGroup(fullPath string) (*gitlabGroup, error) {
+	id := f.groupID(fullPath)
+	if id == 0 {
+		return nil, fmt.Errorf("group %s not found", fullPath)
+	}
+	g := f.groups[id]
+	return &g, nil
+}
+
+func (f *fakeGitLabClient) ListSubgroups(groupID int) ([]gitlabGroup, error) {
+	var out []gitlabGroup
+	for _, g := range f.groups {
+		if g.ParentID == groupID {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeGitLabClient) CreateGroup(group gitlabGroup) (*gitlabGroup, error) {
+	if group.Name == "fail" {
+		return nil, fmt.Errorf("injected CreateGroup failure")
+	}
+	group.ID = len(f.groups) + 100
+	group.FullPath = f.groups[group.ParentID].FullPath + "/" + group.Path
+	f.groups[group.ID] = group
+	return &group, nil
+}
+
+func (f *fakeGitLabClient) UpdateGroup(group gitlabGroup) error {
+	f.groups[group.ID] = group
+	return nil
+}
+
+func (f *fakeGitLabClient) ListGroupMembers(groupID int) ([]gitlabMember, error) {
+	return f.members[groupID], nil
+}
+
+func (f *fakeGitLabClient) AddGroupMember(groupID int, username string, accessLevel int) error {
+	f.members[groupID] = append(f.members[groupID], gitlabMember{ID: f.userID(username), Username: username, AccessLevel: accessLevel})
+	return nil
+}
+
+func (f *fakeGitLabClient) EditGroupMember(groupID, userID, accessLevel int) error {
+	for i, m := range f.members[groupID] {
+		if m.ID == userID {
+			f.members[groupID][i].AccessLevel = accessLevel
+			return nil
+		}
+	}
+	return fmt.Errorf("member %d not found", userID)
+}
+
+func (f *fakeGitLabClient) RemoveGroupMember(groupID, userID int) error {
+	for i, m := range f.members[groupID] {
+		if m.ID == userID {
+			f.members[groupID] = append(f.members[groupID][:i], f.members[groupID][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("member %d not found", userID)
+}
+
+func TestConfigureGitLabGroup(t *testing.T) {
+	description := "The best group"
+	rootGroup := gitlabGroup{ID: 1, Name: "Root", Path: "root", FullPath: "root"}
+	opts := options{
+		minAdmins:      2,
+		requireSelf:    true,
+		maximumDelta:   0.5,
+		fixOrg:         true,
+		fixOrgMembers:  true,
+		fixTeams:       true,
+		fixTeamMembers: true,
+	}
+	testCases := []struct {
+		name        string
+		opts        options
+		config      org.Config
+		groups      []gitlabGroup
+		members     map[string]map[string]int
+		expectError bool
+
+		expectedMembers map[string]map[string]int
+		expectedGroups  []gitlabGroup
+	}{
+		{
+			name: "members are added, promoted, demoted and removed",
+			opts: opts,
+			config: org.Config{
+				Admins:  []string{"me", "anne"},
+				Members: []string{"bob", "carl", "dana"},
+			},
+			groups: []gitlabGroup{rootGroup},
+			members: map[string]map[string]int{
+				"root": {"me": gitlabOwner, "bob": gitlabOwner, "anne": gitlabDeveloper, "carl": 20, "eve": gitlabDeveloper},
+			},
+			expectedMembers: map[string]map[string]int{
+				"root": {"me": gitlabOwner, "anne": gitlabOwner, "bob": gitlabDeveloper, "carl": 20, "dana": gitlabDeveloper},
+			},
+			expectedGroups: []gitlabGroup{rootGroup},
+		},
+		{
+			name: "too many removals are rejected",
+			opts: opts,
+			config: org.Config{
+				Admins: []string{"me", "anne"},
+			},
+			groups: []gitlabGroup{rootGroup},
+			members: map[string]map[string]int{
+				"root": {"me": gitlabOwner, "anne": gitlabOwner, "bob": gitlabDeveloper, "carl": gitlabDeveloper, "dana": gitlabDeveloper},
+			},
+			expectError: true,
+			expectedMembers: map[string]map[string]int{
+				"root": {"me": gitlabOwner, "anne": gitlabOwner, "bob": gitlabDeveloper, "carl": gitlabDeveloper, "dana": gitlabDeveloper},
+			},
+			expectedGroups: []gitlabGroup{rootGroup},
+		},
+		{
+			name: "bot must be an admin",
+			opts: opts,
+			config: org.Config{
+				Admins: []string{"anne", "bob"},
+			},
+			groups: []gitlabGroup{rootGroup},
+			members: map[string]map[string]int{
+				"root": {"me": gitlabOwner, "anne": gitlabOwner, "bob": gitlabOwner},
+			},
+			expectError: true,
+			expectedMembers: map[string]map[string]int{
+				"root": {"me": gitlabOwner, "anne": gitlabOwner, "bob": gitlabOwner},
+			},
+			expectedGroups: []gitlabGroup{rootGroup},
+		},
+		{
+			name: "metadata is updated",
+			opts: options{fixOrg: true},
+			config: org.Config{
+				Metadata: org.Metadata{Description: &description},
+			},
+			groups: []gitlabGroup{rootGroup},
+			expectedMembers: map[string]map[string]int{
+				"root": {},
+			},
+			expectedGroups: []gitlabGroup{{ID: 1, Name: "Root", Path: "root", FullPath: "root", Description: description}},
+		},
+		{
+			name: "subgroups are created, renamed and their members configured",
+			opts: options{fixTeams: true, fixTeamMembers: true},
+			config: org.Config{
+				Teams: map[string]org.Team{
+					"Release Team": {
+						TeamMetadata: org.TeamMetadata{Description: &description},
+						Maintainers:  []string{"anne"},
+						Members:      []string{"bob"},
+						Children: map[string]org.Team{
+							"leads": {Maintainers: []string{"anne"}},
+						},
+					},
+					"docs": {
+						Previously: []string{"documentation"},
+						Members:    []string{"carl"},
+					},
+				},
+			},
+			groups: []gitlabGroup{
+				rootGroup,
+				{ID: 2, Name: "documentation", Path: "documentation", FullPath: "root/documentation", ParentID: 1},
+				{ID: 3, Name: "unmanaged", Path: "unmanaged", FullPath: "root/unmanaged", ParentID: 1},
+			},
+			members: map[string]map[string]int{
+				"root/documentation": {"carl": gitlabMaintainer, "dana": gitlabDeveloper},
+				"root/unmanaged":     {"eve": gitlabDeveloper},
+			},
+			expectedMembers: map[string]map[string]int{
+				"root":                    {},
+				"root/documentation":      {"carl": gitlabDeveloper},
+				"root/unmanaged":          {"eve": gitlabDeveloper},
+				"root/release-team":       {"anne": gitlabMaintainer, "bob": gitlabDeveloper},
+				"root/release-team/leads": {"anne": gitlabMaintainer},
+			},
+			expectedGroups: []gitlabGroup{
+				rootGroup,
+				{ID: 2, Name: "docs", Path: "documentation", FullPath: "root/documentation", ParentID: 1},
+				{ID: 3, Name: "unmanaged", Path: "unmanaged", FullPath: "root/unmanaged", ParentID: 1},
+				{ID: 103, Name: "Release Team", Path: "release-team", FullPath: "root/release-team", Description: description, ParentID: 1},
+				{ID: 104, Name: "leads", Path: "leads", FullPath: "root/release-team/leads", ParentID: 103},
+			},
+		},
+		{
+			name: "subgroup creation failures are returned",
+			opts: options{fixTeams: true},
+			config: org.Config{
+				Teams: map[string]org.Team{"fail": {}},
+			},
+			groups:          []gitlabGroup{rootGroup},
+			expectError:     true,
+			expectedMembers: map[string]map[string]int{"root": {}},
+			expectedGroups:  []gitlabGroup{rootGroup},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeGitLabClient(tc.groups, tc.members)
+			err := configureGitLabGroup(tc.opts, fc, "root", tc.config)
+			if err != nil != tc.expectError {
+				t.Errorf("expected error: %t, got: %v", tc.expectError, err)
+			}
+			if diff := cmp.Diff(tc.expectedMembers, fc.state()); diff != "" {
+				t.Errorf("members differ from expected (-want +got):\n%s", diff)
+			}
+			groups := map[int]gitlabGroup{}
+			for _, g := range tc.expectedGroups {
+				groups[g.ID] = g
+			}
+			if diff := cmp.Diff(groups, fc.groups); diff != "" {
+				t.Errorf("groups differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDumpGitLabGroupConfig(t *testing.T) {
+	fc := newFakeGitLabClient([]gitlabGroup{
+		{ID: 1, Name: "Root", Path: "root", FullPath: "root", Description: "root group"},
+		{ID: 2, Name: "team", Path: "team", FullPath: "root/team", Description: "a team", ParentID: 1},
+		{ID: 3, Name: "child", Path: "child", FullPath: "root/team/child", ParentID: 2},
+	}, map[string]map[string]int{
+		"root":            {"anne": gitlabOwner, "bob": gitlabMaintainer, "carl": gitlabDeveloper},
+		"root/team":       {"anne": gitlabOwner, "bob": gitlabDeveloper},
+		"root/team/child": {"carl": gitlabMaintainer},
+	})
+	name, description, teamDescription, childDescription := "Root", "root group", "a team", ""
+	expected := &org.Config{
+		Metadata: org.Metadata{Name: &name, Description: &description},
+		Admins:   []string{"anne"},
+		Members:  []string{"bob", "carl"},
+		Teams: map[string]org.Team{
+			"team": {
+				TeamMetadata: org.TeamMetadata{Description: &teamDescription},
+				Maintainers:  []string{"anne"},
+				Members:      []string{"bob"},
+				Children: map[string]org.Team{
+					"child": {
+						TeamMetadata: org.TeamMetadata{Description: &childDescription},
+						Maintainers:  []string{"carl"},
+						Members:      []string{},
+						Children:     map[string]org.Team{},
+					},
+				},
+			},
+		},
+	}
+	actual, err := dumpGitLabGroupConfig(fc, "root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("config differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitLabAPIClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v4/groups/root/team":
+			json.NewEncoder(w).Encode(gitlabGroup{ID: 2, Name: "team", Path: "team", FullPath: "root/team"})
+		case "/api/v4/groups/2/members":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				json.NewEncoder(w).Encode([]gitlabMember{{ID: 1, Username: "anne", AccessLevel: gitlabOwner}})
+				return
+			}
+			json.NewEncoder(w).Encode([]gitlabMember{{ID: 2, Username: "bob", AccessLevel: gitlabDeveloper}})
+		case "/api/v4/users":
+			json.NewEncoder(w).Encode([]gitlabUser{{ID: 3, Username: "carl"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dry := newGitLabClient(server.URL+"/api/v4/", func() []byte { return []byte("token") }, true)
+	group, err := dry.GetGroup("root/team")
+	if err != nil {
+		t.Fatalf("unexpected error getting group: %v", err)
+	}
+	if group.ID != 2 {
+		t.Errorf("expected group 2, got %d", group.ID)
+	}
+	members, err := dry.ListGroupMembers(group.ID)
+	if err != nil {
+		t.Fatalf("unexpected error listing members: %v", err)
+	}
+	if diff := cmp.Diff([]gitlabMember{{ID: 1, Username: "anne", AccessLevel: gitlabOwner}, {ID: 2, Username: "bob", AccessLevel: gitlabDeveloper}}, members); diff != "" {
+		t.Errorf("members differ from expected (-want +got):\n%s", diff)
+	}
+	if err := dry.AddGroupMember(group.ID, "carl", gitlabDeveloper); err != nil {
+		t.Fatalf("unexpected error adding member: %v", err)
+	}
+	if err := dry.RemoveGroupMember(group.ID, 2); err != nil {
+		t.Fatalf("unexpected error removing member: %v", err)
+	}
+	expected := []string{
+		"GET /api/v4/groups/root%2Fteam?",
+		"GET /api/v4/groups/2/members?page=1&per_page=100",
+		"GET /api/v4/groups/2/members?page=2&per_page=100",
+		"GET /api/v4/users?username=carl",
+	}
+	if diff := cmp.Diff(expected, requests); diff != "" {
+		t.Errorf("requests differ from expected (-want +got):\n%s", diff)
+	}
+
+	unauthorized := newGitLabClient(server.URL+"/api/v4", func() []byte { return []byte("wrong") }, false)
+	if _, err := unauthorized.GetGroup("root/team"); err == nil {
+		t.Error("expected an error for an unauthorized request")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -57,11 +58,14 @@ type options struct {
 	fixTeams          bool
 	fixTeamRepos      bool
 	fixRepos          bool
+	fixRepoLabels     bool
 	ignoreInvitees    bool
 	ignoreSecretTeams bool
 	allowRepoArchival bool
 	allowRepoPublish  bool
 	github            flagutil.GitHubOptions
+	platform          string
+	gitlab            gitlabOptions
 
 	logLevel string
 }
@@ -92,10 +96,13 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 	flags.BoolVar(&o.fixTeamMembers, "fix-team-members", false, "Add/remove team members if set")
 	flags.BoolVar(&o.fixTeamRepos, "fix-team-repos", false, "Add/remove team permissions on repos if set")
 	flags.BoolVar(&o.fixRepos, "fix-repos", false, "Create/update repositories if set")
+	flags.BoolVar(&o.fixRepoLabels, "fix-repo-labels", false, "Create/update the configured labels of repositories if set")
 	flags.BoolVar(&o.allowRepoArchival, "allow-repo-archival", false, "If set, archiving repos is allowed while updating repos")
 	flags.BoolVar(&o.allowRepoPublish, "allow-repo-publish", false, "If set, making private repos public is allowed while updating repos")
 	flags.StringVar(&o.logLevel, "log-level", logrus.InfoLevel.String(), fmt.Sprintf("Logging level, one of %v", logrus.AllLevels))
+	flags.StringVar(&o.platform, "platform", platformGitHub, fmt.Sprintf("Platform hosting the orgs, one of %q or %q", platformGitHub, platformGitLab))
 	o.github.AddCustomizedFlags(flags, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
+	o.gitlab.addFlags(flags)
 	if err := flags.Parse(args); err != nil {
 		return err
 	}
@@ -107,8 +114,20 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 	logrus.SetLevel(level)
 	logrus.SetReportCaller(level >= logrus.DebugLevel)
 
-	if err := o.github.Validate(!o.confirm); err != nil {
-		return err
+	switch o.platform {
+	case platformGitHub:
+		if err := o.github.Validate(!o.confirm); err != nil {
+			return err
+		}
+	case platformGitLab:
+		if err := o.gitlab.validate(); err != nil {
+			return err
+		}
+		if o.fixTeamRepos || o.fixRepos || o.fixRepoLabels {
+			return errors.New("--fix-team-repos, --fix-repos and --fix-repo-labels are not supported with --platform=gitlab")
+		}
+	default:
+		return fmt.Errorf("--platform=%s must be one of %q or %q", o.platform, platformGitHub, platformGitLab)
 	}
 
 	if o.minAdmins < 2 {
@@ -153,13 +172,35 @@ func main() {
 
 	o := parseOptions()
 
-	githubClient, err := o.github.GitHubClient(!o.confirm)
-	if err != nil {
-		logrus.WithError(err).Fatal("Error getting GitHub client.")
+	var dump func(orgName string) (*org.Config, error)
+	var configure func(orgName string, orgConfig org.Config) error
+	switch o.platform {
+	case platformGitLab:
+		gitlabClient, err := o.gitlab.client(!o.confirm)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting GitLab client.")
+		}
+		dump = func(groupPath string) (*org.Config, error) {
+			return dumpGitLabGroupConfig(gitlabClient, groupPath)
+		}
+		configure = func(groupPath string, orgConfig org.Config) error {
+			return configureGitLabGroup(o, gitlabClient, groupPath, orgConfig)
+		}
+	default:
+		githubClient, err := o.github.GitHubClient(!o.confirm)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting GitHub client.")
+		}
+		dump = func(orgName string) (*org.Config, error) {
+			return dumpOrgConfig(githubClient, orgName, o.ignoreSecretTeams, o.github.AppID)
+		}
+		configure = func(orgName string, orgConfig org.Config) error {
+			return configureOrg(o, githubClient, orgName, orgConfig)
+		}
 	}
 
 	if o.dump != "" {
-		ret, err := dumpOrgConfig(githubClient, o.dump, o.ignoreSecretTeams, o.github.AppID)
+		ret, err := dump(o.dump)
 		if err != nil {
 			logrus.WithError(err).Fatalf("Dump %s failed to collect current data.", o.dump)
 		}
@@ -191,7 +232,7 @@ func main() {
 	}
 
 	for name, orgcfg := range cfg.Orgs {
-		if err := configureOrg(o, githubClient, name, orgcfg); err != nil {
+		if err := configure(name, orgcfg); err != nil {
 			logrus.Fatalf("Configuration failed: %v", err)
 		}
 	}
@@ -369,6 +410,7 @@ func dumpOrgConfig(client dumpClient, orgName string, ignoreSecretTeams bool, ap
 			AllowRebaseMerge: &full.AllowRebaseMerge,
 			Archived:         &full.Archived,
 			DefaultBranch:    &full.DefaultBranch,
+			Topics:           full.Topics,
 		})
 	}
 
@@ -830,6 +872,12 @@ func configureOrg(opt options, client github.Client, orgName string, orgConfig o
 		return fmt.Errorf("failed to configure %s repos: %w", orgName, err)
 	}
 
+	if !opt.fixRepoLabels {
+		logrus.Info("Skipping repository labels configuration")
+	} else if err := configureRepoLabels(client, orgName, orgConfig); err != nil {
+		return fmt.Errorf("failed to configure %s repo labels: %w", orgName, err)
+	}
+
 	if !opt.fixTeams {
 		logrus.Infof("Skipping team and team member configuration")
 		return nil
@@ -863,8 +911,14 @@ type repoClient interface {
 	GetRepos(orgName string, isUser bool) ([]github.Repo, error)
 	CreateRepo(owner string, isUser bool, repo github.RepoCreateRequest) (*github.FullRepo, error)
 	UpdateRepo(owner, name string, repo github.RepoUpdateRequest) (*github.FullRepo, error)
+	ReplaceRepoTopics(org, repo string, topics []string) error
+	VulnerabilityAlertsEnabled(org, repo string) (bool, error)
+	SetVulnerabilityAlerts(org, repo string, enabled bool) error
 }
 
+// topicRE matches the topics GitHub accepts.
+var topicRE = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
 func newRepoCreateRequest(name string, definition org.Repo) github.RepoCreateRequest {
 	repoCreate := github.RepoCreateRequest{
 		RepoRequest: github.RepoRequest{
@@ -915,6 +969,14 @@ func validateRepos(repos map[string]org.Repo) error {
 		return fmt.Errorf("found duplicate repo names (GitHub repo names are case-insensitive): %s", strings.Join(dups, ", "))
 	}
 
+	for name, repo := range repos {
+		for _, topic := range repo.Topics {
+			if !topicRE.MatchString(topic) {
+				return fmt.Errorf("invalid topic %q of repo %s: topics must start with a lowercase letter or number and only consist of up to 50 lowercase letters, numbers and hyphens", topic, name)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1028,6 +1090,8 @@ func configureRepos(opt options, client repoClient, orgName string, orgConfig or
 				repoLogger.WithError(err).Error("failed to create repository")
 				allErrors = append(allErrors, err)
 			} else {
+				// The create request does not carry topics or vulnerability
+				// alerts, so the new repo is configured like an existing one.
 				existing = created
 			}
 		}
@@ -1040,6 +1104,9 @@ func configureRepos(opt options, client repoClient, orgName string, orgConfig or
 				}
 			}
 			repoLogger.Info("repo exists, considering an update")
+			// The settings are applied under the current name of the repo
+			// unless it is renamed successfully.
+			settingsName := existing.Name
 			delta := newRepoUpdateRequest(*existing, wantName, wantRepo)
 			if deltaErrors := sanitizeRepoDelta(opt, &delta); len(deltaErrors) > 0 {
 				for _, err := range deltaErrors {
@@ -1052,8 +1119,120 @@ func configureRepos(opt options, client repoClient, orgName string, orgConfig or
 				if _, err := client.UpdateRepo(orgName, existing.Name, delta); err != nil {
 					repoLogger.WithError(err).Error("failed to update repository")
 					allErrors = append(allErrors, err)
+				} else {
+					settingsName = wantName
 				}
 			}
+			for _, err := range configureRepoSettings(client, orgName, settingsName, *existing, wantRepo) {
+				repoLogger.WithError(err).Error("failed to update repository settings")
+				allErrors = append(allErrors, err)
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(allErrors)
+}
+
+// configureRepoSettings updates the settings of a repo that are not part of
+// github.RepoUpdateRequest.
+func configureRepoSettings(client repoClient, orgName, repoName string, current github.FullRepo, want org.Repo) []error {
+	var errs []error
+	if want.Topics != nil && !sets.New[string](want.Topics...).Equal(sets.New[string](current.Topics...)) {
+		logrus.WithField("repo", repoName).Infof("Replacing topics %q with %q", current.Topics, want.Topics)
+		if err := client.ReplaceRepoTopics(orgName, repoName, want.Topics); err != nil {
+			errs = append(errs, fmt.Errorf("failed to replace topics: %w", err))
+		}
+	}
+	if want.VulnerabilityAlerts != nil {
+		enabled, err := client.VulnerabilityAlertsEnabled(orgName, repoName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get vulnerability alerts: %w", err))
+		} else if enabled != *want.VulnerabilityAlerts {
+			logrus.WithField("repo", repoName).Infof("Setting vulnerability alerts to enabled=%t", *want.VulnerabilityAlerts)
+			if err := client.SetVulnerabilityAlerts(orgName, repoName, *want.VulnerabilityAlerts); err != nil {
+				errs = append(errs, fmt.Errorf("failed to set vulnerability alerts: %w", err))
+			}
+		}
+	}
+	return errs
+}
+
+// labelColorRE matches the hex codes of label colors.
+var labelColorRE = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+func validateLabels(labels []org.Label) error {
+	seen := sets.New[string]()
+	for _, label := range labels {
+		if label.Name == "" {
+			return errors.New("labels must have a name")
+		}
+		if !labelColorRE.MatchString(label.Color) {
+			return fmt.Errorf("label %q has invalid color %q: must be a hex code without the leading #", label.Name, label.Color)
+		}
+		if name := strings.ToLower(label.Name); seen.Has(name) {
+			return fmt.Errorf("found duplicate label %q (GitHub label names are case-insensitive)", label.Name)
+		} else {
+			seen.Insert(name)
+		}
+	}
+	return nil
+}
+
+type repoLabelClient interface {
+	GetRepoLabels(org, repo string) ([]github.Label, error)
+	AddRepoLabel(org, repo, label, description, color string) error
+	UpdateRepoLabel(org, repo, label, newName, description, color string) error
+}
+
+// configureRepoLabels creates the labels of the org and the repo in every
+// configured repo and updates their color and description. Labels that are
+// not configured are left alone.
+func configureRepoLabels(client repoLabelClient, orgName string, orgConfig org.Config) error {
+	if err := validateLabels(orgConfig.Labels); err != nil {
+		return err
+	}
+	for name, repo := range orgConfig.Repos {
+		if err := validateLabels(repo.Labels); err != nil {
+			return fmt.Errorf("repo %s: %w", name, err)
+		}
+	}
+
+	var allErrors []error
+	for repoName, repo := range orgConfig.Repos {
+		if repo.Archived != nil && *repo.Archived {
+			continue
+		}
+		want := orgConfig.RepoLabels(repo)
+		if len(want) == 0 {
+			continue
+		}
+		repoLogger := logrus.WithField("repo", repoName)
+		current, err := client.GetRepoLabels(orgName, repoName)
+		if err != nil {
+			repoLogger.WithError(err).Error("failed to list labels")
+			allErrors = append(allErrors, err)
+			continue
+		}
+		byName := make(map[string]github.Label, len(current))
+		for _, label := range current {
+			byName[strings.ToLower(label.Name)] = label
+		}
+		for _, label := range want {
+			have, exists := byName[strings.ToLower(label.Name)]
+			switch {
+			case !exists:
+				repoLogger.Infof("Creating label %q", label.Name)
+				err = client.AddRepoLabel(orgName, repoName, label.Name, label.Description, label.Color)
+			case have.Name != label.Name || !strings.EqualFold(have.Color, label.Color) || have.Description != label.Description:
+				repoLogger.Infof("Updating label %q", label.Name)
+				err = client.UpdateRepoLabel(orgName, repoName, have.Name, label.Name, label.Description, label.Color)
+			default:
+				continue
+			}
+			if err != nil {
+				repoLogger.WithError(err).Errorf("failed to configure label %q", label.Name)
+				allErrors = append(allErrors, err)
+			}
 		}
 	}
 
@@ -71,6 +71,8 @@ func TestOptions(t *testing.T) {
 				requireSelf:  true,
 				maximumDelta: 1,
 				logLevel:     "info",
+				platform:     platformGitHub,
+				gitlab:       gitlabOptions{endpoint: defaultGitLabEndpoint},
 			},
 		},
 		{
@@ -82,6 +84,8 @@ func TestOptions(t *testing.T) {
 				requireSelf:  true,
 				maximumDelta: 0,
 				logLevel:     "info",
+				platform:     platformGitHub,
+				gitlab:       gitlabOptions{endpoint: defaultGitLabEndpoint},
 			},
 		},
 		{
@@ -93,6 +97,8 @@ func TestOptions(t *testing.T) {
 				requireSelf:  true,
 				maximumDelta: defaultDelta,
 				logLevel:     "info",
+				platform:     platformGitHub,
+				gitlab:       gitlabOptions{endpoint: defaultGitLabEndpoint},
 			},
 		},
 		{
@@ -116,6 +122,8 @@ func TestOptions(t *testing.T) {
 				maximumDelta: defaultDelta,
 				dump:         "frogger",
 				logLevel:     "info",
+				platform:     platformGitHub,
+				gitlab:       gitlabOptions{endpoint: defaultGitLabEndpoint},
 			},
 		},
 		{
@@ -127,6 +135,33 @@ func TestOptions(t *testing.T) {
 				requireSelf:  true,
 				maximumDelta: defaultDelta,
 				logLevel:     "info",
+				platform:     platformGitHub,
+				gitlab:       gitlabOptions{endpoint: defaultGitLabEndpoint},
+			},
+		},
+		{
+			name: "reject unknown platform",
+			args: []string{"--config-path=foo", "--platform=bitbucket"},
+		},
+		{
+			name: "reject gitlab without token",
+			args: []string{"--config-path=foo", "--platform=gitlab"},
+		},
+		{
+			name: "reject gitlab with --fix-repos",
+			args: []string{"--config-path=foo", "--platform=gitlab", "--gitlab-token-path=token", "--fix-repos"},
+		},
+		{
+			name: "gitlab",
+			args: []string{"--config-path=foo", "--platform=gitlab", "--gitlab-token-path=token", "--gitlab-endpoint=https://gitlab.example.com/api/v4"},
+			expected: &options{
+				config:       "foo",
+				minAdmins:    defaultMinAdmins,
+				requireSelf:  true,
+				maximumDelta: defaultDelta,
+				logLevel:     "info",
+				platform:     platformGitLab,
+				gitlab:       gitlabOptions{endpoint: "https://gitlab.example.com/api/v4", tokenPath: "token"},
 			},
 		},
 		{
@@ -143,6 +178,8 @@ func TestOptions(t *testing.T) {
 				fixTeams:       true,
 				fixTeamMembers: true,
 				logLevel:       "debug",
+				platform:       platformGitHub,
+				gitlab:         gitlabOptions{endpoint: defaultGitLabEndpoint},
 			},
 		},
 	}
@@ -159,7 +196,7 @@ func TestOptions(t *testing.T) {
 			case err != nil && tc.expected != nil:
 				t.Errorf("%s: unexpected error: %v", tc.name, err)
 			case tc.expected != nil && !reflect.DeepEqual(*tc.expected, actual):
-				t.Errorf("%s: got incorrect options: %v", tc.name, cmp.Diff(actual, *tc.expected, cmp.AllowUnexported(options{}, flagutil.Strings{}, flagutil.GitHubOptions{})))
+				t.Errorf("%s: got incorrect options: %v", tc.name, cmp.Diff(actual, *tc.expected, cmp.AllowUnexported(options{}, flagutil.Strings{}, flagutil.GitHubOptions{}, gitlabOptions{})))
 			}
 		})
 	}
@@ -2531,8 +2568,9 @@ func TestConfigureTeamRepos(t *testing.T) {
 }
 
 type fakeRepoClient struct {
-	t     *testing.T
-	repos map[string]github.FullRepo
+	t      *testing.T
+	repos  map[string]github.FullRepo
+	alerts map[string]bool
 }
 
 func (f fakeRepoClient) GetRepo(owner, name string) (github.FullRepo, error) {
@@ -2627,10 +2665,34 @@ func (f fakeRepoClient) UpdateRepo(owner, name string, want github.RepoUpdateReq
 	return &have, nil
 }
 
+func (f fakeRepoClient) ReplaceRepoTopics(org, repo string, topics []string) error {
+	if repo == "fail" {
+		return fmt.Errorf("injected ReplaceRepoTopics failure")
+	}
+	have, exists := f.repos[repo]
+	if !exists {
+		f.t.Errorf("ReplaceRepoTopics() called on repo that does not exist")
+		return fmt.Errorf("ReplaceRepoTopics() called on repo that does not exist")
+	}
+	have.Topics = topics
+	f.repos[repo] = have
+	return nil
+}
+
+func (f fakeRepoClient) VulnerabilityAlertsEnabled(org, repo string) (bool, error) {
+	return f.alerts[repo], nil
+}
+
+func (f fakeRepoClient) SetVulnerabilityAlerts(org, repo string, enabled bool) error {
+	f.alerts[repo] = enabled
+	return nil
+}
+
 func makeFakeRepoClient(t *testing.T, repos ...github.FullRepo) fakeRepoClient {
 	fc := fakeRepoClient{
-		repos: make(map[string]github.FullRepo, len(repos)),
-		t:     t,
+		repos:  make(map[string]github.FullRepo, len(repos)),
+		alerts: map[string]bool{},
+		t:      t,
 	}
 	for _, repo := range repos {
 		fc.repos[repo.Name] = repo
@@ -2938,6 +3000,50 @@ func TestConfigureRepos(t *testing.T) {
 			repos:         []github.FullRepo{{Repo: github.Repo{Name: "CAMELCASE", Description: newDescription}}},
 			expectedRepos: []github.Repo{{Name: "CamelCase", Description: newDescription}},
 		},
+		{
+			description: "topics of existing repo are replaced",
+			orgConfig: org.Config{
+				Repos: map[string]org.Repo{
+					oldName: {Topics: []string{"prow", "ci"}},
+				},
+			},
+			repos: []github.FullRepo{{Repo: github.Repo{Name: oldName, Topics: []string{"old"}}}},
+
+			expectedRepos: []github.Repo{{Name: oldName, Topics: []string{"prow", "ci"}}},
+		},
+		{
+			description: "unmanaged topics are not touched",
+			orgConfig: org.Config{
+				Repos: map[string]org.Repo{
+					oldName: {Description: &updated},
+				},
+			},
+			repos: []github.FullRepo{{Repo: github.Repo{Name: oldName, Topics: []string{"old"}}}},
+
+			expectedRepos: []github.Repo{{Name: oldName, Description: updated, Topics: []string{"old"}}},
+		},
+		{
+			description: "empty topics remove all topics",
+			orgConfig: org.Config{
+				Repos: map[string]org.Repo{
+					oldName: {Topics: []string{}},
+				},
+			},
+			repos: []github.FullRepo{{Repo: github.Repo{Name: oldName, Topics: []string{"old"}}}},
+
+			expectedRepos: []github.Repo{{Name: oldName, Topics: []string{}}},
+		},
+		{
+			description: "topics of new repo are set",
+			orgConfig: org.Config{
+				Repos: map[string]org.Repo{
+					newName: {Topics: []string{"prow", "ci"}},
+				},
+			},
+			repos: []github.FullRepo{},
+
+			expectedRepos: []github.Repo{{Name: newName, Topics: []string{"prow", "ci"}}},
+		},
 		{
 			description: "avoid creating archived repo",
 			orgConfig: org.Config{
@@ -3027,6 +3133,19 @@ func TestValidateRepos(t *testing.T) {
 				"repo": {Previously: []string{"REPO"}},
 			},
 		},
+		{
+			description: "allows valid topics",
+			config: map[string]org.Repo{
+				"repo": {Topics: []string{"prow", "k8s-ci"}},
+			},
+		},
+		{
+			description: "finds invalid topic",
+			config: map[string]org.Repo{
+				"repo": {Topics: []string{"Prow CI"}},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3140,3 +3259,160 @@ func TestNewRepoUpdateRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureRepoSettingsVulnerabilityAlerts(t *testing.T) {
+	yes := true
+	no := false
+	testCases := []struct {
+		description string
+		current     bool
+		want        *bool
+		expected    bool
+	}{
+		{
+			description: "unmanaged alerts are not touched",
+			current:     true,
+			expected:    true,
+		},
+		{
+			description: "alerts are enabled",
+			want:        &yes,
+			expected:    true,
+		},
+		{
+			description: "alerts are disabled",
+			current:     true,
+			want:        &no,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fc := makeFakeRepoClient(t, github.FullRepo{Repo: github.Repo{Name: "repo"}})
+			fc.alerts["repo"] = tc.current
+			if errs := configureRepoSettings(fc, "org", "repo", fc.repos["repo"], org.Repo{VulnerabilityAlerts: tc.want}); len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if fc.alerts["repo"] != tc.expected {
+				t.Errorf("expected vulnerability alerts enabled=%t, got %t", tc.expected, fc.alerts["repo"])
+			}
+		})
+	}
+}
+
+type fakeLabelClient struct {
+	labels map[string][]github.Label
+}
+
+func (f *fakeLabelClient) GetRepoLabels(org, repo string) ([]github.Label, error) {
+	if repo == "fail" {
+		return nil, fmt.Errorf("injected GetRepoLabels failure")
+	}
+	return f.labels[repo], nil
+}
+
+func (f *fakeLabelClient) AddRepoLabel(org, repo, label, description, color string) error {
+	f.labels[repo] = append(f.labels[repo], github.Label{Name: label, Description: description, Color: color})
+	return nil
+}
+
+func (f *fakeLabelClient) UpdateRepoLabel(org, repo, label, newName, description, color string) error {
+	for i, l := range f.labels[repo] {
+		if l.Name == label {
+			f.labels[repo][i] = github.Label{Name: newName, Description: description, Color: color}
+			return nil
+		}
+	}
+	return fmt.Errorf("label %s not found", label)
+}
+
+func TestConfigureRepoLabels(t *testing.T) {
+	yes := true
+	testCases := []struct {
+		description string
+		config      org.Config
+		labels      map[string][]github.Label
+		expectError bool
+		expected    map[string][]github.Label
+	}{
+		{
+			description: "org labels are created in every repo",
+			config: org.Config{
+				Labels: []org.Label{{Name: "bug", Color: "ff0000", Description: "Something is broken"}},
+				Repos:  map[string]org.Repo{"a": {}, "b": {}},
+			},
+			labels: map[string][]github.Label{},
+			expected: map[string][]github.Label{
+				"a": {{Name: "bug", Color: "ff0000", Description: "Something is broken"}},
+				"b": {{Name: "bug", Color: "ff0000", Description: "Something is broken"}},
+			},
+		},
+		{
+			description: "repo labels override org labels and differing labels are updated",
+			config: org.Config{
+				Labels: []org.Label{{Name: "bug", Color: "ff0000"}},
+				Repos: map[string]org.Repo{"a": {Labels: []org.Label{
+					{Name: "Bug", Color: "00ff00"},
+					{Name: "docs", Color: "0000ff"},
+				}}},
+			},
+			labels: map[string][]github.Label{
+				"a": {{Name: "bug", Color: "ff0000"}, {Name: "docs", Color: "0000FF"}, {Name: "unmanaged", Color: "ffffff"}},
+			},
+			expected: map[string][]github.Label{
+				"a": {{Name: "Bug", Color: "00ff00"}, {Name: "docs", Color: "0000FF"}, {Name: "unmanaged", Color: "ffffff"}},
+			},
+		},
+		{
+			description: "archived repos are skipped",
+			config: org.Config{
+				Labels: []org.Label{{Name: "bug", Color: "ff0000"}},
+				Repos:  map[string]org.Repo{"a": {Archived: &yes}},
+			},
+			labels:   map[string][]github.Label{},
+			expected: map[string][]github.Label{},
+		},
+		{
+			description: "invalid color is rejected",
+			config: org.Config{
+				Labels: []org.Label{{Name: "bug", Color: "#ff0000"}},
+				Repos:  map[string]org.Repo{"a": {}},
+			},
+			labels:      map[string][]github.Label{},
+			expectError: true,
+			expected:    map[string][]github.Label{},
+		},
+		{
+			description: "duplicate labels are rejected",
+			config: org.Config{
+				Repos: map[string]org.Repo{"a": {Labels: []org.Label{{Name: "bug", Color: "ff0000"}, {Name: "BUG", Color: "ff0000"}}}},
+			},
+			labels:      map[string][]github.Label{},
+			expectError: true,
+			expected:    map[string][]github.Label{},
+		},
+		{
+			description: "failures are propagated",
+			config: org.Config{
+				Labels: []org.Label{{Name: "bug", Color: "ff0000"}},
+				Repos:  map[string]org.Repo{"a": {}, "fail": {}},
+			},
+			labels:      map[string][]github.Label{},
+			expectError: true,
+			expected: map[string][]github.Label{
+				"a": {{Name: "bug", Color: "ff0000"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fc := &fakeLabelClient{labels: tc.labels}
+			err := configureRepoLabels(fc, "org", tc.config)
+			if err != nil != tc.expectError {
+				t.Errorf("expected error: %t, got: %v", tc.expectError, err)
+			}
+			if diff := cmp.Diff(tc.expected, fc.labels); diff != "" {
+				t.Errorf("labels differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
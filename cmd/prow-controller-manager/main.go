@@ -33,6 +33,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/prow/pkg/clusterhealth"
+	"sigs.k8s.io/prow/pkg/dependentjobs"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 	"sigs.k8s.io/prow/pkg/scheduler"
@@ -42,6 +44,7 @@ import (
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/kubejob"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
 	"sigs.k8s.io/prow/pkg/plank"
@@ -49,7 +52,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/version"
 )
 
-var allControllers = sets.New(plank.ControllerName, scheduler.ControllerName)
+var allControllers = sets.New(plank.ControllerName, scheduler.ControllerName, dependentjobs.ControllerName, kubejob.ControllerName, clusterhealth.ControllerName)
 
 type options struct {
 	totURL string
@@ -57,6 +60,7 @@ type options struct {
 	config             configflagutil.ConfigOptions
 	selector           string
 	enabledControllers prowflagutil.Strings
+	kubeJobWorkers     int
 
 	dryRun                 bool
 	kubernetes             prowflagutil.KubernetesOptions
@@ -72,6 +76,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 
 	fs.StringVar(&o.selector, "label-selector", labels.Everything().String(), "Label selector to be applied in prowjobs. See https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors for constructing a label selector.")
 	fs.Var(&o.enabledControllers, "enable-controller", fmt.Sprintf("Controllers to enable. Can be passed multiple times. Defaults to controllers: %s", plank.ControllerName))
+	fs.IntVar(&o.kubeJobWorkers, "kubernetes-job-workers", 10, fmt.Sprintf("Number of ProwJobs the %s controller syncs concurrently.", kubejob.ControllerName))
 
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to GitHub.")
 	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.instrumentationOptions, &o.config, &o.storage} {
@@ -102,6 +107,10 @@ func (o *options) Validate() error {
 		errs = append(errs, errors.New("no controllers configured"))
 	}
 
+	if o.kubeJobWorkers < 1 {
+		errs = append(errs, fmt.Errorf("--kubernetes-job-workers must be at least 1, got %d", o.kubeJobWorkers))
+	}
+
 	if _, err := labels.Parse(o.selector); err != nil {
 		errs = append(errs, fmt.Errorf("parse label selector: %w", err))
 	}
@@ -210,12 +219,36 @@ func main() {
 		}
 	}
 
+	var clusterGate scheduler.ClusterGate
+	if enabledControllersSet.Has(clusterhealth.ControllerName) {
+		clusterHealth, err := clusterhealth.New(knownClusters, cfg)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to construct cluster-health controller")
+		}
+		if err := mgr.Add(clusterHealth); err != nil {
+			logrus.WithError(err).Fatal("Failed to add cluster-health controller to manager")
+		}
+		clusterGate = clusterHealth
+	}
+
 	if enabledControllersSet.Has(scheduler.ControllerName) {
-		if err := scheduler.Add(mgr, cfg, 1); err != nil {
+		if err := scheduler.Add(mgr, cfg, clusterGate, 1); err != nil {
 			logrus.WithError(err).Fatal("Failed to add scheduler to manager")
 		}
 	}
 
+	if enabledControllersSet.Has(dependentjobs.ControllerName) {
+		if err := dependentjobs.Add(mgr, cfg, 1); err != nil {
+			logrus.WithError(err).Fatal("Failed to add dependent-jobs controller to manager")
+		}
+	}
+
+	if enabledControllersSet.Has(kubejob.ControllerName) {
+		if err := kubejob.Add(mgr, buildClusters, cfg, o.totURL, o.kubeJobWorkers); err != nil {
+			logrus.WithError(err).Fatal("Failed to add kubernetes-job controller to manager")
+		}
+	}
+
 	// Expose prometheus metrics
 	metrics.ExposeMetrics("plank", cfg().PushGateway, o.instrumentationOptions.MetricsPort)
 	// Serve readiness endpoint
@@ -351,7 +351,7 @@ func (c *controller) clean() {
 	pjMap := map[string]*prowapi.ProwJob{}
 	isFinished := sets.New[string]()
 
-	maxProwJobAge := c.config().Sinker.MaxProwJobAge.Duration
+	sinkerConfig := c.config().Sinker
 	for i, prowJob := range prowJobs.Items {
 		pjMap[prowJob.ObjectMeta.Name] = &prowJobs.Items[i]
 		// Handle periodics separately.
@@ -362,7 +362,7 @@ func (c *controller) clean() {
 			continue
 		}
 		isFinished.Insert(prowJob.ObjectMeta.Name)
-		if time.Since(prowJob.Status.StartTime.Time) <= maxProwJobAge {
+		if time.Since(prowJob.Status.StartTime.Time) <= sinkerConfig.RetentionFor(&prowJob).MaxProwJobAge.Duration {
 			continue
 		}
 		if err := c.prowJobClient.Delete(c.ctx, &prowJob); err == nil {
@@ -398,7 +398,7 @@ func (c *controller) clean() {
 			// Ignore deleting this one.
 			continue
 		}
-		if time.Since(prowJob.Status.StartTime.Time) <= maxProwJobAge {
+		if time.Since(prowJob.Status.StartTime.Time) <= sinkerConfig.RetentionFor(&prowJob).MaxProwJobAge.Duration {
 			continue
 		}
 		if err := c.prowJobClient.Delete(c.ctx, &prowJob); err == nil {
@@ -431,8 +431,6 @@ func (c *controller) clean() {
 		}
 		log.WithField("pod-count", len(pods.Items)).Debug("Successfully listed pods.")
 		metrics.podsCreated += len(pods.Items)
-		maxPodAge := c.config().Sinker.MaxPodAge.Duration
-		terminatedPodTTL := c.config().Sinker.TerminatedPodTTL.Duration
 		for _, pod := range pods.Items {
 			reason := ""
 			clean := false
@@ -456,11 +454,13 @@ func (c *controller) clean() {
 				}
 			}
 
+			// Pods of unknown ProwJobs get the default retention.
+			retention := sinkerConfig.RetentionFor(pjMap[podJobName])
 			switch {
-			case !pod.Status.StartTime.IsZero() && time.Since(pod.Status.StartTime.Time) > maxPodAge:
+			case !pod.Status.StartTime.IsZero() && time.Since(pod.Status.StartTime.Time) > retention.MaxPodAge.Duration:
 				clean = true
 				reason = reasonPodAged
-			case !terminationTime.IsZero() && time.Since(terminationTime) > terminatedPodTTL:
+			case !terminationTime.IsZero() && time.Since(terminationTime) > retention.TerminatedPodTTL.Duration:
 				clean = true
 				reason = reasonPodTTLed
 			}
@@ -838,6 +838,97 @@ func TestNotClean(t *testing.T) {
 	assertSetsEqual(sets.Set[string]{}, podClientExcluded.deletedPods, t, "did not delete correct Pods")
 }
 
+func TestCleanRetentionPolicies(t *testing.T) {
+	completed := metav1.NewTime(time.Now().Add(-60 * time.Second))
+	prowJob := func(name string, refs *prowv1.Refs, labels map[string]string, age time.Duration) runtime.Object {
+		return &prowv1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    labels,
+			},
+			Spec: prowv1.ProwJobSpec{
+				Type: prowv1.PresubmitJob,
+				Job:  name,
+				Refs: refs,
+			},
+			Status: prowv1.ProwJobStatus{
+				StartTime:      metav1.NewTime(time.Now().Add(-age)),
+				CompletionTime: &completed,
+			},
+		}
+	}
+	pod := func(name string, age time.Duration) runtime.Object {
+		return &corev1api.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels: map[string]string{
+					kube.CreatedByProw:  "true",
+					kube.ProwJobIDLabel: name,
+				},
+			},
+			Status: corev1api.PodStatus{
+				Phase:     corev1api.PodSucceeded,
+				StartTime: startTime(time.Now().Add(-age)),
+			},
+		}
+	}
+	releaseBlocking := map[string]string{"release-blocking": "true"}
+	prowJobs := []runtime.Object{
+		prowJob("release-blocking", &prowv1.Refs{Org: "org", Repo: "repo"}, releaseBlocking, maxProwJobAge+time.Second),
+		prowJob("chaff", &prowv1.Refs{Org: "org", Repo: "repo"}, nil, 2*time.Hour),
+		prowJob("default-young", &prowv1.Refs{Org: "other", Repo: "repo"}, nil, 2*time.Hour),
+		prowJob("default-old", &prowv1.Refs{Org: "other", Repo: "repo"}, nil, maxProwJobAge+time.Second),
+	}
+	pods := []runtime.Object{
+		pod("release-blocking", maxPodAge+time.Second),
+		pod("default-young", maxPodAge+time.Second),
+	}
+	deletedProwJobs := sets.New[string]("chaff", "default-old")
+	deletedPods := sets.New[string]("default-young")
+
+	fpjc := &clientWrapper{
+		Client: fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(prowJobs...).Build(),
+	}
+	fkc := &podClientWrapper{
+		t: t, Client: fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pods...).Build(),
+	}
+	fakeSinkerConfig := newDefaultFakeSinkerConfig()
+	fakeSinkerConfig.RetentionPolicies = []config.SinkerRetentionPolicy{
+		{
+			Labels:        releaseBlocking,
+			MaxProwJobAge: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+			MaxPodAge:     &metav1.Duration{Duration: 90 * 24 * time.Hour},
+		},
+		{
+			OrgRepo:       "org/repo",
+			MaxProwJobAge: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	c := controller{
+		logger:        logrus.WithField("component", "sinker"),
+		prowJobClient: fpjc,
+		podClients:    map[string]ctrlruntimeclient.Client{"default": fkc},
+		config:        newFakeConfigAgent(fakeSinkerConfig).Config,
+	}
+	c.clean()
+	assertSetsEqual(deletedPods, fkc.deletedPods, t, "did not delete correct Pods")
+
+	remainingProwJobs := &prowv1.ProwJobList{}
+	if err := fpjc.List(context.Background(), remainingProwJobs); err != nil {
+		t.Fatalf("failed to get remaining prowjobs: %v", err)
+	}
+	actuallyDeletedProwJobs := sets.Set[string]{}
+	for _, initialProwJob := range prowJobs {
+		actuallyDeletedProwJobs.Insert(initialProwJob.(metav1.Object).GetName())
+	}
+	for _, remainingProwJob := range remainingProwJobs.Items {
+		actuallyDeletedProwJobs.Delete(remainingProwJob.Name)
+	}
+	assertSetsEqual(deletedProwJobs, actuallyDeletedProwJobs, t, "did not delete correct ProwJobs")
+}
+
 func assertSetsEqual(expected, actual sets.Set[string], t *testing.T, prefix string) {
 	if expected.Equal(actual) {
 		return
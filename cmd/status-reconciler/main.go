@@ -18,8 +18,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -40,6 +44,8 @@ const (
 	defaultBurst  = 100
 )
 
+var issueRe = regexp.MustCompile(`^([^/]+)/([^/#]+)#([1-9][0-9]*)$`)
+
 type options struct {
 	config        configflagutil.ConfigOptions
 	pluginsConfig pluginsflagutil.PluginOptions
@@ -59,6 +65,14 @@ type options struct {
 	// a) the gcs credentials can write to this bucket
 	// b) the default acls do not expose any private info
 	statusURI string
+
+	// reportURI makes Status-reconciler write a report of the actions it would
+	// take to this /local/path, gs://path/to/object or s3://path/to/object
+	// instead of taking them.
+	reportURI string
+	// reportIssue is the org/repo#number of an issue to comment a summary of the
+	// report on.
+	reportIssue string
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
@@ -66,6 +80,8 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 
 	fs.StringVar(&o.statusURI, "status-path", "", "The /local/path, gs://path/to/object or s3://path/to/object to store status controller state. GCS writes will use the default object ACL for the bucket.")
 
+	fs.StringVar(&o.reportURI, "report-path", "", "If set, do not trigger jobs or change contexts, but write a JSON report of the actions that would be taken for each PR to this /local/path, gs://path/to/object or s3://path/to/object.")
+	fs.StringVar(&o.reportIssue, "report-issue", "", "The org/repo#number of an issue to comment a summary of the report on. Requires --report-path and --dry-run=false.")
 	fs.BoolVar(&o.continueOnError, "continue-on-error", false, "Indicates that the migration should continue if context migration fails for an individual PR.")
 	fs.Var(&o.addedPresubmitDenylist, "denylist", "Org or org/repo to ignore new added presubmits for, set more than once to add more.")
 	fs.Var(&o.addedPresubmitDenylistAll, "denylist-all", "Org or org/repo to ignore reconciling, set more than once to add more.")
@@ -85,10 +101,31 @@ func (o *options) Validate() error {
 			return err
 		}
 	}
+	if o.reportIssue != "" {
+		if o.reportURI == "" {
+			return errors.New("--report-issue requires --report-path")
+		}
+		if !issueRe.MatchString(o.reportIssue) {
+			return fmt.Errorf("--report-issue %q is not of the form org/repo#number", o.reportIssue)
+		}
+	}
 
 	return nil
 }
 
+// reportOptions returns the options of the report mode, or nil if it is disabled.
+func (o *options) reportOptions() *statusreconciler.ReportOptions {
+	if o.reportURI == "" {
+		return nil
+	}
+	report := &statusreconciler.ReportOptions{URI: o.reportURI}
+	if match := issueRe.FindStringSubmatch(o.reportIssue); match != nil {
+		report.IssueOrg, report.IssueRepo = match[1], match[2]
+		report.IssueNumber, _ = strconv.Atoi(match[3])
+	}
+	return report
+}
+
 func (o *options) getDenyList() sets.Set[string] {
 	denyList := o.addedPresubmitDenylist.Strings()
 
@@ -139,7 +176,7 @@ func main() {
 		logrus.WithError(err).Fatal("Cannot create opener")
 	}
 
-	c := statusreconciler.NewController(o.continueOnError, o.getDenyList(), o.getDenyListAll(), opener, o.config, o.statusURI, prowJobClient, githubClient, pluginAgent)
+	c := statusreconciler.NewController(o.continueOnError, o.getDenyList(), o.getDenyListAll(), opener, o.config, o.statusURI, prowJobClient, githubClient, pluginAgent, o.reportOptions())
 	interrupts.Run(func(ctx context.Context) {
 		c.Run(ctx)
 	})
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"flag"
 	"reflect"
 	"testing"
@@ -26,6 +27,7 @@ import (
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
+	"sigs.k8s.io/prow/pkg/statusreconciler"
 )
 
 func newSetStringsFlagForTest(vals ...string) flagutil.Strings {
@@ -78,6 +80,39 @@ func TestGatherOptions(t *testing.T) {
 				o.addedPresubmitDenylist = newSetStringsFlagForTest("a", "b")
 			},
 		},
+		{
+			name: "report to an issue",
+			args: []string{
+				"-report-path=gs://bucket/report.json",
+				"-report-issue=org/repo#1",
+			},
+			expected: func(o *options) {
+				o.reportURI = "gs://bucket/report.json"
+				o.reportIssue = "org/repo#1"
+			},
+		},
+		{
+			name: "report-issue requires report-path",
+			args: []string{
+				"-report-issue=org/repo#1",
+			},
+			expected: func(o *options) {
+				o.reportIssue = "org/repo#1"
+			},
+			expectedErr: errors.New("--report-issue requires --report-path"),
+		},
+		{
+			name: "report-issue must be an issue",
+			args: []string{
+				"-report-path=gs://bucket/report.json",
+				"-report-issue=org/repo",
+			},
+			expected: func(o *options) {
+				o.reportURI = "gs://bucket/report.json"
+				o.reportIssue = "org/repo"
+			},
+			expectedErr: errors.New(`--report-issue "org/repo" is not of the form org/repo#number`),
+		},
 	}
 
 	for _, tc := range cases {
@@ -153,3 +188,33 @@ func TestGetDenyList(t *testing.T) {
 		})
 	}
 }
+
+func TestReportOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		o    options
+		want *statusreconciler.ReportOptions
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "report only",
+			o:    options{reportURI: "/tmp/report.json"},
+			want: &statusreconciler.ReportOptions{URI: "/tmp/report.json"},
+		},
+		{
+			name: "report and issue",
+			o:    options{reportURI: "/tmp/report.json", reportIssue: "org/repo#12"},
+			want: &statusreconciler.ReportOptions{URI: "/tmp/report.json", IssueOrg: "org", IssueRepo: "repo", IssueNumber: 12},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.o.reportOptions()); diff != "" {
+				t.Fatalf("Want(-), got(+):\n%s", diff)
+			}
+		})
+	}
+}
@@ -39,6 +39,8 @@ import (
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/readiness"
 	"sigs.k8s.io/prow/pkg/tide"
 )
 
@@ -114,8 +116,8 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.storage, &o.instrumentationOptions, &o.config, &o.gerrit} {
 		group.AddFlags(fs)
 	}
-	fs.IntVar(&o.syncThrottle, "sync-hourly-tokens", 800, "The maximum number of tokens per hour to be used by the sync controller.")
-	fs.IntVar(&o.statusThrottle, "status-hourly-tokens", 400, "The maximum number of tokens per hour to be used by the status controller.")
+	fs.IntVar(&o.syncThrottle, "sync-hourly-tokens", 800, "The maximum number of tokens per hour to be used by the sync controller. Overridden by the hourly_tokens of the github.throttle config policy for tide-sync.")
+	fs.IntVar(&o.statusThrottle, "status-hourly-tokens", 400, "The maximum number of tokens per hour to be used by the status controller. Overridden by the hourly_tokens of the github.throttle config policy for tide-status.")
 	fs.IntVar(&o.maxRecordsPerPool, "max-records-per-pool", 1000, "The maximum number of history records stored for an individual Tide pool.")
 	fs.StringVar(&o.historyURI, "history-uri", "", "The /local/path,gs://path/to/object or s3://path/to/object to store tide action history. GCS writes will use the default object ACL for the bucket")
 	fs.StringVar(&o.statusURI, "status-path", "", "The /local/path, gs://path/to/object or s3://path/to/object to store status controller state. GCS writes will use the default object ACL for the bucket.")
@@ -140,6 +142,7 @@ func main() {
 	}
 
 	pprof.Instrument(o.instrumentationOptions)
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
 
 	opener, err := o.storage.StorageClient(context.Background())
 	if err != nil {
@@ -193,6 +196,7 @@ func main() {
 		if err != nil {
 			logrus.WithError(err).Fatal("Error getting GitHub client for status.")
 		}
+		readiness.Register("github", readiness.GitHub(githubSync))
 
 		// The sync loop should be allowed more tokens than the status loop because
 		// it has to list all PRs in the pool every loop while the status loop only
@@ -200,8 +204,20 @@ func main() {
 		// The sync loop should have a much lower burst allowance than the status
 		// loop which may need to update many statuses upon restarting Tide after
 		// changing the context format or starting Tide on a new repo.
+		// Throttle policies from the config take precedence over the flags,
+		// which still apply to orgs the policies don't cover.
 		githubSync.Throttle(o.syncThrottle, 3*tokensPerIteration(o.syncThrottle, cfg().Tide.SyncPeriod.Duration))
+		if policy := cfg().GitHubOptions.ThrottlePolicy("tide-sync"); policy != nil {
+			if err := githubSync.ApplyThrottlePolicy(*policy); err != nil {
+				logrus.WithError(err).Fatal("Error throttling GitHub client for sync.")
+			}
+		}
 		githubStatus.Throttle(o.statusThrottle, o.statusThrottle/2)
+		if policy := cfg().GitHubOptions.ThrottlePolicy("tide-status"); policy != nil {
+			if err := githubStatus.ApplyThrottlePolicy(*policy); err != nil {
+				logrus.WithError(err).Fatal("Error throttling GitHub client for status.")
+			}
+		}
 
 		c, err = tide.NewController(
 			githubSync,
@@ -214,7 +230,7 @@ func main() {
 			o.historyURI,
 			o.statusURI,
 			nil,
-			o.github.AppPrivateKeyPath != "",
+			o.github.UsesAppsAuth(),
 		)
 		if err != nil {
 			logrus.WithError(err).Fatal("Error creating Tide controller.")
@@ -248,6 +264,21 @@ func main() {
 		logrus.Info("Mgr finished gracefully.")
 	})
 
+	// Only report ready once the config is loaded, the informers are synced
+	// and the dependencies were reachable.
+	readiness.Register("config", readiness.Config(cfg))
+	readiness.Register("informers", readiness.CacheSynced(mgr.GetCache()))
+	var storagePaths []string
+	for _, path := range []string{o.historyURI, o.statusURI} {
+		if path != "" {
+			storagePaths = append(storagePaths, path)
+		}
+	}
+	if len(storagePaths) > 0 {
+		readiness.Register("storage", readiness.Storage(opener, storagePaths...))
+	}
+	health.ServeReady()
+
 	mgrSyncCtx, mgrSyncCtxCancel := context.WithTimeout(context.Background(), o.controllerManager.TimeoutListingProwJobs)
 	defer mgrSyncCtxCancel()
 	if synced := mgr.GetCache().WaitForCacheSync(mgrSyncCtx); !synced {
@@ -265,6 +296,7 @@ func main() {
 	controllerMux := http.NewServeMux()
 	controllerMux.Handle("/", c)
 	controllerMux.Handle("/history", c.History())
+	controllerMux.Handle("/merge-trains", c.MergeTrains())
 	server := &http.Server{Addr: ":" + strconv.Itoa(o.port), Handler: controllerMux}
 
 	// Push metrics to the configured prometheus pushgateway endpoint or serve them
@@ -88,7 +88,7 @@ func validateProwJobClusterOnCreate(prowJob v1.ProwJob, statuses map[string]plan
 	if prowJob.Spec.Cluster != "" && prowJob.Spec.Cluster != kube.DefaultClusterAlias && agentsNotSupportingCluster.Has(string(prowJob.Spec.Agent)) {
 		return fmt.Errorf("%s: cannot set cluster field if agent is %s", prowJob.Name, prowJob.Spec.Agent)
 	}
-	if prowJob.Spec.Agent == v1.KubernetesAgent {
+	if prowJob.Spec.Agent == v1.KubernetesAgent || prowJob.Spec.Agent == v1.KubernetesJobAgent {
 		_, ok := statuses[prowJob.ClusterAlias()]
 		if !ok {
 			return fmt.Errorf("job configuration for %q specifies unknown 'cluster' value %q", prowJob.Name, prowJob.ClusterAlias())
@@ -166,7 +166,7 @@ gen-deepcopy() {
 
   "$deepcopygen" \
     --go-header-file hack/boilerplate/boilerplate.generated.go.txt \
-    --input-dirs sigs.k8s.io/prow/pkg/config \
+    --input-dirs sigs.k8s.io/prow/pkg/config,sigs.k8s.io/prow/pkg/config/matrix \
     --output-file-base zz_generated.deepcopy
   copyfiles "pkg/config" "zz_generated.deepcopy.go"
 
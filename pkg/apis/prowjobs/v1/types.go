@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"mime"
 	"net/url"
+	"path"
 	"strings"
 	"time"
 
@@ -80,6 +81,31 @@ func GetAllProwJobStates() []ProwJobState {
 		ErrorState}
 }
 
+// ProwJobFailureReason classifies why a job did not succeed, so that failures
+// of the infrastructure can be told apart from failures of the tests.
+type ProwJobFailureReason string
+
+// Various failure reasons.
+const (
+	// InfraErrorFailure means the job could not run because of a problem of
+	// the infrastructure, e.g. its pod could not be created or scheduled.
+	InfraErrorFailure ProwJobFailureReason = "infra-error"
+	// CloneFailure means the repositories of the job could not be cloned.
+	CloneFailure ProwJobFailureReason = "clone-failure"
+	// TimeoutFailure means the job did not finish in time.
+	TimeoutFailure ProwJobFailureReason = "timeout"
+	// TestFailure means the test process of the job failed.
+	TestFailure ProwJobFailureReason = "test-failure"
+	// PodEvictedFailure means the pod of the job was evicted.
+	PodEvictedFailure ProwJobFailureReason = "pod-evicted"
+	// PreemptedFailure means the job was aborted by plank to start a job
+	// of a higher priority class.
+	PreemptedFailure ProwJobFailureReason = "preempted"
+	// AbortedFailure means the job was aborted, e.g. by a user or because a
+	// newer run of the job superseded it.
+	AbortedFailure ProwJobFailureReason = "aborted"
+)
+
 // ProwJobAgent specifies the controller (such as plank or jenkins-agent) that runs the job.
 type ProwJobAgent string
 
@@ -90,6 +116,8 @@ const (
 	JenkinsAgent ProwJobAgent = "jenkins"
 	// TektonAgent means prow will schedule the job via a tekton PipelineRun CRD resource.
 	TektonAgent = "tekton-pipeline"
+	// KubernetesJobAgent means prow will create a Kubernetes Job to run this job.
+	KubernetesJobAgent ProwJobAgent = "kubernetes-job"
 )
 
 const (
@@ -182,10 +210,17 @@ type ProwJobSpec struct {
 	// If this field is unspecified or false, a new pod will be created to replace
 	// the evicted one.
 	ErrorOnEviction bool `json:"error_on_eviction,omitempty"`
+	// Retry configures plank to automatically re-run the job when it
+	// ends in one of the configured states, e.g. because of an
+	// infrastructure failure.
+	Retry *RetryConfig `json:"retry,omitempty"`
 
 	// PodSpec provides the basis for running the test under
 	// a Kubernetes agent
 	PodSpec *corev1.PodSpec `json:"pod_spec,omitempty"`
+	// PodTemplate is the name of one of the PodTemplates of Plank's config.
+	// It is merged into PodSpec when the pod of the job is created.
+	PodTemplate string `json:"pod_template,omitempty"`
 
 	// JenkinsSpec holds configuration specific to Jenkins jobs
 	JenkinsSpec *JenkinsSpec `json:"jenkins_spec,omitempty"`
@@ -214,6 +249,10 @@ type ProwJobSpec struct {
 	// RerunAuthConfig holds information about which users can rerun the job
 	RerunAuthConfig *RerunAuthConfig `json:"rerun_auth_config,omitempty"`
 
+	// RerunOverrides lists the changes users that may rerun the job can make
+	// to it when rerunning it from Deck.
+	RerunOverrides *RerunOverrides `json:"rerun_overrides,omitempty"`
+
 	// Hidden specifies if the Job is considered hidden.
 	// Hidden jobs are only shown by deck instances that have the
 	// `--hiddenOnly=true` or `--show-hidden=true` flag set.
@@ -233,6 +272,13 @@ type ProwJobSpec struct {
 	// This behaviour may be superseded by MaxConcurrency field, if it
 	// is set to a constraining value.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+
+	// PriorityClass is the name of one of the PriorityClasses of Plank's
+	// config. When MaxConcurrency of Plank or the capacity of the job queue
+	// is exhausted, jobs of a higher priority are started first and may
+	// preempt pending jobs of a lower priority. Jobs without a priority
+	// class have priority 0.
+	PriorityClass string `json:"priority_class,omitempty"`
 }
 
 func (pjs ProwJobSpec) HasPipelineRunSpec() bool {
@@ -259,11 +305,123 @@ func (pjs ProwJobSpec) GetPipelineRunSpec() (*pipelinev1.PipelineRunSpec, error)
 	return found, nil
 }
 
+// RetryConfig determines whether and how often a job is re-run by plank
+// after it ended in a retriable state.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of times the job is re-run, at most 10.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries int `json:"max_retries,omitempty"`
+	// Backoff is the time to wait before the first retry. It is doubled
+	// for each subsequent retry, up to one hour. Defaults to no wait.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+	// RetryOn is the list of final job states that cause a retry.
+	// Only `error` and `failure` are allowed. Defaults to `error`.
+	RetryOn []ProwJobState `json:"retry_on,omitempty"`
+}
+
+const (
+	// MaxRetries is the largest allowed RetryConfig.MaxRetries.
+	MaxRetries = 10
+	// MaxRetryBackoff is the longest time waited before a retry.
+	MaxRetryBackoff = time.Hour
+)
+
+// Validate validates the RetryConfig fields.
+func (rc *RetryConfig) Validate() error {
+	if rc == nil {
+		return nil
+	}
+	if rc.MaxRetries < 0 || rc.MaxRetries > MaxRetries {
+		return fmt.Errorf("max_retries: %d must be between 0 and %d", rc.MaxRetries, MaxRetries)
+	}
+	if rc.Backoff != nil && (rc.Backoff.Duration < 0 || rc.Backoff.Duration > MaxRetryBackoff) {
+		return fmt.Errorf("backoff: %s must be between 0 and %s", rc.Backoff.Duration, MaxRetryBackoff)
+	}
+	for _, state := range rc.RetryOn {
+		if state != ErrorState && state != FailureState {
+			return fmt.Errorf("retry_on: state %q is not retriable, only %q and %q are allowed", state, ErrorState, FailureState)
+		}
+	}
+	return nil
+}
+
+// ShouldRetry determines if a job that ended in the given state after the
+// given number of retries should be re-run.
+func (rc *RetryConfig) ShouldRetry(state ProwJobState, retries int) bool {
+	if rc == nil || retries >= rc.MaxRetries {
+		return false
+	}
+	if len(rc.RetryOn) == 0 {
+		return state == ErrorState
+	}
+	for _, s := range rc.RetryOn {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// BackoffFor returns how long to wait before starting the given retry,
+// counting from one. The backoff is capped at MaxRetryBackoff.
+func (rc *RetryConfig) BackoffFor(retry int) time.Duration {
+	if rc == nil || rc.Backoff == nil || rc.Backoff.Duration <= 0 || retry < 1 {
+		return 0
+	}
+	backoff := rc.Backoff.Duration
+	for i := 1; i < retry && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > MaxRetryBackoff {
+		return MaxRetryBackoff
+	}
+	return backoff
+}
+
 type GitHubTeamSlug struct {
 	Slug string `json:"slug"`
 	Org  string `json:"org"`
 }
 
+// RerunOverrides lists the changes users may make to a job when rerunning it
+// from Deck. Reruns with changes never report to GitHub.
+type RerunOverrides struct {
+	// Env are the names of the environment variables that may be set in the
+	// containers of the job.
+	Env []string `json:"env,omitempty"`
+	// Args are the arguments that may be appended to the arguments of the
+	// first container of the job. An argument is allowed if it is one of them
+	// or starts with one of them followed by `=`.
+	Args []string `json:"args,omitempty"`
+}
+
+// EnvAllowed returns true if the environment variable may be overridden.
+func (ro *RerunOverrides) EnvAllowed(name string) bool {
+	if ro == nil {
+		return false
+	}
+	for _, allowed := range ro.Env {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ArgAllowed returns true if the argument may be appended.
+func (ro *RerunOverrides) ArgAllowed(arg string) bool {
+	if ro == nil {
+		return false
+	}
+	for _, allowed := range ro.Args {
+		if arg == allowed || strings.HasPrefix(arg, allowed+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 type RerunAuthConfig struct {
 	// If AllowAnyone is set to true, any user can rerun the job
 	AllowAnyone bool `json:"allow_anyone,omitempty"`
@@ -280,6 +438,9 @@ type RerunAuthConfig struct {
 	GitHubUsers []string `json:"github_users,omitempty"`
 	// GitHubOrgs contains names of GitHub organizations whose members can rerun the job
 	GitHubOrgs []string `json:"github_orgs,omitempty"`
+	// OIDCGroups contains names of groups of users logged in with OpenID
+	// Connect who can rerun the job
+	OIDCGroups []string `json:"oidc_groups,omitempty"`
 }
 
 // IsSpecifiedUser returns true if AllowAnyone is set to true or if the given user is
@@ -330,13 +491,32 @@ func (rac *RerunAuthConfig) IsAuthorized(org, user string, cli prowgithub.RerunC
 	return false, nil
 }
 
+// IsAuthorizedByGroups returns true if AllowAnyone is set to true or if one of
+// the given OpenID Connect groups is specified as a permitted OIDCGroup
+func (rac *RerunAuthConfig) IsAuthorizedByGroups(groups []string) bool {
+	if rac == nil {
+		return false
+	}
+	if rac.AllowAnyone {
+		return true
+	}
+	for _, allowed := range rac.OIDCGroups {
+		for _, group := range groups {
+			if allowed == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Validate validates the RerunAuthConfig fields.
 func (rac *RerunAuthConfig) Validate() error {
 	if rac == nil {
 		return nil
 	}
 
-	hasAllowList := len(rac.GitHubUsers) > 0 || len(rac.GitHubTeamIDs) > 0 || len(rac.GitHubTeamSlugs) > 0 || len(rac.GitHubOrgs) > 0
+	hasAllowList := len(rac.GitHubUsers) > 0 || len(rac.GitHubTeamIDs) > 0 || len(rac.GitHubTeamSlugs) > 0 || len(rac.GitHubOrgs) > 0 || len(rac.OIDCGroups) > 0
 
 	// If an allowlist is specified, the user probably does not intend for anyone to be able to rerun any job.
 	if rac.AllowAnyone && hasAllowList {
@@ -473,6 +653,20 @@ type DecorationConfig struct {
 	// after sending SIGINT to send SIGKILL when aborting
 	// a job. Only applicable if decorating the PodSpec.
 	GracePeriod *Duration `json:"grace_period,omitempty"`
+	// Steps are run one after another in place of the command of
+	// the test container, each with its own timeout. The first
+	// failing step ends the test. Timeout still limits the time
+	// taken by all steps. Requires a single test container that
+	// specifies neither command nor args.
+	Steps []EntrypointStep `json:"steps,omitempty"`
+	// SignalEscalation is the sequence of signals sent to the test
+	// process when it times out or is aborted, each followed by its
+	// grace period. SIGKILL is sent if the process outlives the last
+	// one. Defaults to SIGINT followed by GracePeriod.
+	SignalEscalation []SignalEscalationStep `json:"signal_escalation,omitempty"`
+	// CaptureProcessTree writes the tree of the processes of the test
+	// to the artifacts when the test times out, before signalling it.
+	CaptureProcessTree *bool `json:"capture_process_tree,omitempty"`
 
 	// UtilityImages holds pull specs for utility container
 	// images used to decorate a PodSpec.
@@ -489,6 +683,15 @@ type DecorationConfig struct {
 	// S3CredentialsSecret is the name of the Kubernetes secret
 	// that holds blob storage push credentials.
 	S3CredentialsSecret *string `json:"s3_credentials_secret,omitempty"`
+	// AzureCredentialsSecret is the name of the Kubernetes secret that
+	// holds Azure Blob Storage push credentials. Its keys are exposed
+	// as environment variables, e.g. AZURE_STORAGE_ACCOUNT and
+	// AZURE_STORAGE_KEY, to the containers uploading artifacts.
+	AzureCredentialsSecret *string `json:"azure_credentials_secret,omitempty"`
+	// Vault configures fetching short-lived secrets from HashiCorp Vault
+	// when the test container starts, instead of mounting Kubernetes
+	// secrets into it.
+	Vault *VaultConfig `json:"vault,omitempty"`
 	// DefaultServiceAccountName is the name of the Kubernetes service account
 	// that should be used by the pod if one is not specified in the podspec.
 	DefaultServiceAccountName *string `json:"default_service_account_name,omitempty"`
@@ -502,6 +705,14 @@ type DecorationConfig struct {
 	// BloblessFetch tells Prow to avoid fetching objects when cloning using
 	// the --filter=blob:none flag.
 	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// SparseCheckout lists the directories to check out from the cloned
+	// repositories using a cone mode sparse checkout. All files are checked
+	// out if unset.
+	SparseCheckout []string `json:"sparse_checkout,omitempty"`
+	// ShallowSince tells Prow to only fetch the history after the given date
+	// when cloning using the --shallow-since flag. Takes precedence over the
+	// clone depth of the repositories.
+	ShallowSince *string `json:"shallow_since,omitempty"`
 	// SkipCloning determines if we should clone source code in the
 	// initcontainers for jobs that specify refs
 	SkipCloning *bool `json:"skip_cloning,omitempty"`
@@ -519,6 +730,9 @@ type DecorationConfig struct {
 	// GitHubAppPrivateKeySecret is a Kubernetes secret that contains the GitHub App private key,
 	// which is going to be used for fetching a private repository.
 	GitHubAppPrivateKeySecret *GitHubAppPrivateKeySecret `json:"github_app_private_key_secret,omitempty"`
+	// SubmoduleCredentialSecrets are Kubernetes secrets that contain HTTPS
+	// credentials for the hosts of submodules and Git LFS objects.
+	SubmoduleCredentialSecrets []SubmoduleCredentialSecret `json:"submodule_credential_secrets,omitempty"`
 
 	// CensorSecrets enables censoring output logs and artifacts.
 	CensorSecrets *bool `json:"censor_secrets,omitempty"`
@@ -530,6 +744,18 @@ type DecorationConfig struct {
 	// hope that the test process exits cleanly before starting an upload.
 	UploadIgnoresInterrupts *bool `json:"upload_ignores_interrupts,omitempty"`
 
+	// LogStreamInterval makes sidecar upload the build log in chunks at this
+	// interval while the test runs, so that it can be tailed in Spyglass.
+	// Unset disables streaming; the build log is then only uploaded when the
+	// test finishes.
+	LogStreamInterval *Duration `json:"log_stream_interval,omitempty"`
+
+	// ResourceMetricsInterval makes sidecar record the CPU and memory usage of
+	// the containers of the pod from the metrics.k8s.io API at this interval while the
+	// test runs, and upload it as resource-usage.json. The service account of
+	// the pod must be allowed to get PodMetrics. Unset disables recording.
+	ResourceMetricsInterval *Duration `json:"resource_metrics_interval,omitempty"`
+
 	// SetLimitEqualsMemoryRequest sets memory limit equal to request.
 	SetLimitEqualsMemoryRequest *bool `json:"set_limit_equals_memory_request,omitempty"`
 	// DefaultMemoryRequest is the default requested memory on a test container.
@@ -709,6 +935,147 @@ type OauthTokenSecret struct {
 	Key string `json:"key,omitempty"`
 }
 
+// EntrypointStep is a command run by the entrypoint of the test container.
+type EntrypointStep struct {
+	// Name identifies the step in the build log.
+	Name string `json:"name"`
+	// Command is the command of the step and its arguments.
+	Command []string `json:"command"`
+	// Timeout is how long the step may run before it is aborted
+	// like a timed out test. Defaults to the remaining time of the test.
+	Timeout *Duration `json:"timeout,omitempty"`
+}
+
+// SignalEscalationStep is a signal sent to a test process that
+// timed out or is aborted.
+type SignalEscalationStep struct {
+	// Signal is the name of the signal, one of SIGINT, SIGTERM,
+	// SIGQUIT, SIGHUP, SIGUSR1 and SIGUSR2. Go programs dump the stacks
+	// of all goroutines on SIGQUIT.
+	Signal string `json:"signal"`
+	// GracePeriod is how long to wait for the process to exit before
+	// sending the next signal. Defaults to DecorationConfig.GracePeriod.
+	GracePeriod *Duration `json:"grace_period,omitempty"`
+}
+
+// escalationSignals are the signals allowed in a SignalEscalationStep.
+var escalationSignals = map[string]bool{
+	"SIGINT":  true,
+	"SIGTERM": true,
+	"SIGQUIT": true,
+	"SIGHUP":  true,
+	"SIGUSR1": true,
+	"SIGUSR2": true,
+}
+
+// IsEscalationSignal returns whether signal may be used in a SignalEscalationStep.
+func IsEscalationSignal(signal string) bool {
+	return escalationSignals[signal]
+}
+
+const (
+	// VaultAuthKubernetes logs in to Vault with the service account
+	// token of the pod.
+	VaultAuthKubernetes = "kubernetes"
+	// VaultAuthAppRole logs in to Vault with a role and secret ID.
+	VaultAuthAppRole = "approle"
+)
+
+// VaultConfig configures fetching secrets from HashiCorp Vault.
+type VaultConfig struct {
+	// Address is the address of the Vault server,
+	// e.g. https://vault.example.com:8200.
+	Address string `json:"address"`
+	// AuthMethod is how to log in to Vault, either kubernetes to use
+	// the service account token of the pod or approle.
+	AuthMethod string `json:"auth_method"`
+	// AuthMount is the path the auth method is enabled at in Vault.
+	// Defaults to the name of the auth method.
+	AuthMount string `json:"auth_mount,omitempty"`
+	// Role is the Vault role to log in as with the kubernetes auth method.
+	Role string `json:"role,omitempty"`
+	// AppRoleSecret is the name of the Kubernetes secret holding the
+	// role_id and secret_id keys to log in with the approle auth method.
+	AppRoleSecret string `json:"approle_secret,omitempty"`
+	// Secrets are the values to fetch from Vault.
+	Secrets []VaultSecret `json:"secrets"`
+}
+
+// VaultSecret is a value of a secret in Vault exposed to the test container.
+type VaultSecret struct {
+	// Path is the path of the secret in Vault, e.g. secret/data/ci/github
+	// for a KV version 2 secrets engine enabled at secret/.
+	Path string `json:"path"`
+	// Key is the key of the value in the data of the secret.
+	Key string `json:"key"`
+	// File is the name of the file in /etc/vault-secrets the value is
+	// written to. Defaults to Key.
+	File string `json:"file,omitempty"`
+	// Env is the name of an environment variable to also expose the
+	// value in.
+	Env string `json:"env,omitempty"`
+}
+
+// FileName returns the name of the file the value is written to.
+func (s VaultSecret) FileName() string {
+	if s.File != "" {
+		return s.File
+	}
+	return s.Key
+}
+
+// Validate ensures all the fields needed to fetch the secrets are set.
+func (v *VaultConfig) Validate() error {
+	if v.Address == "" {
+		return errors.New("address is required")
+	}
+	if u, err := url.Parse(v.Address); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("address %q is not a valid URL", v.Address)
+	}
+	switch v.AuthMethod {
+	case VaultAuthKubernetes:
+		if v.Role == "" {
+			return errors.New("role is required for the kubernetes auth method")
+		}
+	case VaultAuthAppRole:
+		if v.AppRoleSecret == "" {
+			return errors.New("approle_secret is required for the approle auth method")
+		}
+	default:
+		return fmt.Errorf("auth method %q is not one of %s and %s", v.AuthMethod, VaultAuthKubernetes, VaultAuthAppRole)
+	}
+	if len(v.Secrets) == 0 {
+		return errors.New("no secrets configured")
+	}
+	files := map[string]bool{}
+	for _, secret := range v.Secrets {
+		if secret.Path == "" || secret.Key == "" {
+			return errors.New("secrets require a path and a key")
+		}
+		file := secret.FileName()
+		if strings.Contains(file, "/") || file == "." || file == ".." {
+			return fmt.Errorf("file %q of secret %s must be a plain file name", file, secret.Path)
+		}
+		if files[file] {
+			return fmt.Errorf("file %q is used by more than one secret", file)
+		}
+		files[file] = true
+	}
+	return nil
+}
+
+// SubmoduleCredentialSecret holds the information of the secret containing
+// the HTTPS credentials for a git host.
+type SubmoduleCredentialSecret struct {
+	// Host is the git host the credentials are used for, e.g. gitlab.example.com.
+	Host string `json:"host"`
+	// Name is the name of a kubernetes secret.
+	Name string `json:"name"`
+	// Key is the key of the corresponding kubernetes secret that holds
+	// the credentials, either a token or `username:token`.
+	Key string `json:"key"`
+}
+
 // GitHubAppPrivateKeySecret holds the information of the GitHub App private key's secret name and key.
 type GitHubAppPrivateKeySecret struct {
 	// Name is the name of a kubernetes secret.
@@ -768,12 +1135,27 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.GracePeriod == nil {
 		merged.GracePeriod = def.GracePeriod
 	}
+	if merged.Steps == nil {
+		merged.Steps = def.Steps
+	}
+	if merged.SignalEscalation == nil {
+		merged.SignalEscalation = def.SignalEscalation
+	}
+	if merged.CaptureProcessTree == nil {
+		merged.CaptureProcessTree = def.CaptureProcessTree
+	}
 	if merged.GCSCredentialsSecret == nil {
 		merged.GCSCredentialsSecret = def.GCSCredentialsSecret
 	}
 	if merged.S3CredentialsSecret == nil {
 		merged.S3CredentialsSecret = def.S3CredentialsSecret
 	}
+	if merged.AzureCredentialsSecret == nil {
+		merged.AzureCredentialsSecret = def.AzureCredentialsSecret
+	}
+	if merged.Vault == nil {
+		merged.Vault = def.Vault
+	}
 	if merged.DefaultServiceAccountName == nil {
 		merged.DefaultServiceAccountName = def.DefaultServiceAccountName
 	}
@@ -792,6 +1174,9 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.OauthTokenSecret == nil {
 		merged.OauthTokenSecret = def.OauthTokenSecret
 	}
+	if len(merged.SubmoduleCredentialSecrets) == 0 {
+		merged.SubmoduleCredentialSecrets = def.SubmoduleCredentialSecrets
+	}
 	if len(merged.GitHubAPIEndpoints) == 0 {
 		merged.GitHubAPIEndpoints = def.GitHubAPIEndpoints
 	}
@@ -809,6 +1194,14 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 		merged.UploadIgnoresInterrupts = def.UploadIgnoresInterrupts
 	}
 
+	if merged.LogStreamInterval == nil {
+		merged.LogStreamInterval = def.LogStreamInterval
+	}
+
+	if merged.ResourceMetricsInterval == nil {
+		merged.ResourceMetricsInterval = def.ResourceMetricsInterval
+	}
+
 	if merged.SetLimitEqualsMemoryRequest == nil {
 		merged.SetLimitEqualsMemoryRequest = def.SetLimitEqualsMemoryRequest
 	}
@@ -844,6 +1237,12 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.BloblessFetch == nil {
 		merged.BloblessFetch = def.BloblessFetch
 	}
+	if merged.SparseCheckout == nil {
+		merged.SparseCheckout = def.SparseCheckout
+	}
+	if merged.ShallowSince == nil {
+		merged.ShallowSince = def.ShallowSince
+	}
 	if merged.SchedulingOptions == nil {
 		merged.SchedulingOptions = def.SchedulingOptions
 	}
@@ -875,8 +1274,8 @@ func (d *DecorationConfig) Validate() error {
 	if d.GCSConfiguration == nil {
 		return errors.New("GCS upload configuration is not specified")
 	}
-	// Intentionally allow d.GCSCredentialsSecret and d.S3CredentialsSecret to
-	// be unset in which case we assume GCS permissions are provided by GKE
+	// Intentionally allow d.GCSCredentialsSecret, d.S3CredentialsSecret and
+	// d.AzureCredentialsSecret to be unset in which case we assume GCS permissions are provided by GKE
 	// Workload Identity: https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity
 
 	if err := d.GCSConfiguration.Validate(); err != nil {
@@ -885,6 +1284,51 @@ func (d *DecorationConfig) Validate() error {
 	if d.OauthTokenSecret != nil && len(d.SSHKeySecrets) > 0 {
 		return errors.New("both OAuth token and SSH key secrets are specified")
 	}
+	hosts := map[string]bool{}
+	for _, s := range d.SubmoduleCredentialSecrets {
+		if s.Host == "" || s.Name == "" || s.Key == "" {
+			return errors.New("submodule credential secrets require host, name and key")
+		}
+		if hosts[s.Host] {
+			return fmt.Errorf("multiple submodule credential secrets are specified for host %s", s.Host)
+		}
+		hosts[s.Host] = true
+	}
+	if d.LogStreamInterval.Get() < 0 {
+		return errors.New("log stream interval must not be negative")
+	}
+	if d.ResourceMetricsInterval.Get() < 0 {
+		return errors.New("resource metrics interval must not be negative")
+	}
+	for _, p := range d.SparseCheckout {
+		if p == "" || path.IsAbs(p) || strings.HasPrefix(path.Clean(p), "..") {
+			return fmt.Errorf("sparse checkout path %q must be a directory within the repository", p)
+		}
+	}
+	if d.ShallowSince != nil && *d.ShallowSince == "" {
+		return errors.New("shallow since must not be empty")
+	}
+	for i, step := range d.Steps {
+		if step.Name == "" || len(step.Command) == 0 || step.Command[0] == "" {
+			return fmt.Errorf("step %d requires a name and a command", i)
+		}
+		if step.Timeout.Get() < 0 {
+			return fmt.Errorf("timeout of step %s must not be negative", step.Name)
+		}
+	}
+	for _, step := range d.SignalEscalation {
+		if !escalationSignals[step.Signal] {
+			return fmt.Errorf("signal escalation does not support signal %q", step.Signal)
+		}
+		if step.GracePeriod.Get() < 0 {
+			return fmt.Errorf("grace period after %s must not be negative", step.Signal)
+		}
+	}
+	if d.Vault != nil {
+		if err := d.Vault.Validate(); err != nil {
+			return fmt.Errorf("vault configuration is invalid: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -947,6 +1391,7 @@ type GCSConfiguration struct {
 	// Bucket is the bucket to upload to, it can be:
 	// * a GCS bucket: with gs:// prefix
 	// * a S3 bucket: with s3:// prefix
+	// * an Azure Blob Storage container: with azblob:// prefix
 	// * a GCS bucket: without a prefix (deprecated, it's discouraged to use Bucket without prefix please add the gs:// prefix)
 	Bucket string `json:"bucket,omitempty"`
 	// PathPrefix is an optional path that follows the
@@ -1126,6 +1571,30 @@ type ProwJobStatus struct {
 	// PrevReportStates stores the previous reported prowjob state per reporter
 	// So crier won't make duplicated report attempt
 	PrevReportStates map[string]ProwJobState `json:"prev_report_states,omitempty"`
+
+	// Retries is the number of times plank re-ran this job because
+	// it ended in a state configured in Spec.Retry.
+	Retries int `json:"retries,omitempty"`
+	// NextRetryTime is the earliest time at which plank starts the
+	// next retry of this job.
+	NextRetryTime *metav1.Time `json:"next_retry_time,omitempty"`
+
+	// FailureReason classifies why the job did not succeed. It is set by
+	// the controller running the job when the job ends in the failure, error
+	// or aborted state, or by whoever aborts the job.
+	// +kubebuilder:validation:Enum=infra-error;clone-failure;timeout;test-failure;pod-evicted;preempted;aborted
+	FailureReason ProwJobFailureReason `json:"failure_reason,omitempty"`
+	// FailureMessage explains the failure reason.
+	FailureMessage string `json:"failure_message,omitempty"`
+}
+
+// DescriptionWithFailureReason returns the description of the status of the
+// job, followed by the reason why it did not succeed if one is known.
+func (j *ProwJob) DescriptionWithFailureReason() string {
+	if j.Status.FailureReason == "" {
+		return j.Status.Description
+	}
+	return fmt.Sprintf("%s [%s]", j.Status.Description, j.Status.FailureReason)
 }
 
 // Complete returns true if the prow job has finished
@@ -1219,6 +1688,18 @@ type Refs struct {
 	// using the --filter=blob:none flag. If unspecified, defaults to
 	// DecorationConfig.BloblessFetch.
 	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// SparseCheckout lists the directories to check out using a cone
+	// mode sparse checkout. If unspecified, defaults to
+	// DecorationConfig.SparseCheckout.
+	SparseCheckout []string `json:"sparse_checkout,omitempty"`
+	// ShallowSince tells prow to only fetch the history after the given
+	// date using the --shallow-since flag, and takes precedence over
+	// CloneDepth. If unspecified, defaults to DecorationConfig.ShallowSince.
+	ShallowSince string `json:"shallow_since,omitempty"`
+	// FetchLFS determines if Git LFS objects are fetched for the
+	// repository and its submodules. Requires git-lfs in the
+	// clonerefs image.
+	FetchLFS bool `json:"fetch_lfs,omitempty"`
 }
 
 func (r Refs) String() string {
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	fuzz "github.com/google/gofuzz"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func pStr(str string) *string {
@@ -359,6 +360,38 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 				return def
 			},
 		},
+		{
+			name: "log stream interval provided",
+			provided: &DecorationConfig{
+				LogStreamInterval: &Duration{Duration: 30 * time.Second},
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.LogStreamInterval = orig.LogStreamInterval
+				return def
+			},
+		},
+		{
+			name: "resource metrics interval provided",
+			provided: &DecorationConfig{
+				ResourceMetricsInterval: &Duration{Duration: 15 * time.Second},
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.ResourceMetricsInterval = orig.ResourceMetricsInterval
+				return def
+			},
+		},
+		{
+			name: "sparse checkout and shallow since provided",
+			provided: &DecorationConfig{
+				SparseCheckout: []string{"pkg"},
+				ShallowSince:   pStr("2026-01-01"),
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.SparseCheckout = orig.SparseCheckout
+				def.ShallowSince = orig.ShallowSince
+				return def
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -397,6 +430,257 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 	}
 }
 
+func TestDecorationConfigValidateClone(t *testing.T) {
+	testCases := []struct {
+		name           string
+		sparseCheckout []string
+		shallowSince   *string
+		expectedErr    bool
+	}{
+		{
+			name:           "valid sparse checkout and shallow since",
+			sparseCheckout: []string{"pkg", "cmd/foo/"},
+			shallowSince:   pStr("2 weeks ago"),
+		},
+		{
+			name:           "absolute sparse checkout path",
+			sparseCheckout: []string{"/pkg"},
+			expectedErr:    true,
+		},
+		{
+			name:           "sparse checkout path outside of the repository",
+			sparseCheckout: []string{"pkg/../../other"},
+			expectedErr:    true,
+		},
+		{
+			name:           "empty sparse checkout path",
+			sparseCheckout: []string{""},
+			expectedErr:    true,
+		},
+		{
+			name:         "empty shallow since",
+			shallowSince: pStr(""),
+			expectedErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := &DecorationConfig{
+				UtilityImages: &UtilityImages{
+					CloneRefs:  "clonerefs",
+					InitUpload: "initupload",
+					Entrypoint: "entrypoint",
+					Sidecar:    "sidecar",
+				},
+				GCSConfiguration: &GCSConfiguration{
+					Bucket:       "bucket",
+					PathStrategy: PathStrategyExplicit,
+				},
+				SparseCheckout: tc.sparseCheckout,
+				ShallowSince:   tc.shallowSince,
+			}
+			if err := dc.Validate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDecorationConfigValidateEntrypoint(t *testing.T) {
+	testCases := []struct {
+		name        string
+		steps       []EntrypointStep
+		escalation  []SignalEscalationStep
+		expectedErr bool
+	}{
+		{
+			name: "valid steps and signal escalation",
+			steps: []EntrypointStep{
+				{Name: "build", Command: []string{"make"}, Timeout: &Duration{Duration: time.Minute}},
+				{Name: "test", Command: []string{"make", "test"}},
+			},
+			escalation: []SignalEscalationStep{
+				{Signal: "SIGTERM", GracePeriod: &Duration{Duration: time.Second}},
+				{Signal: "SIGQUIT"},
+			},
+		},
+		{
+			name:        "step without name",
+			steps:       []EntrypointStep{{Command: []string{"make"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "step without command",
+			steps:       []EntrypointStep{{Name: "build"}},
+			expectedErr: true,
+		},
+		{
+			name:        "negative step timeout",
+			steps:       []EntrypointStep{{Name: "build", Command: []string{"make"}, Timeout: &Duration{Duration: -time.Minute}}},
+			expectedErr: true,
+		},
+		{
+			name:        "unsupported signal",
+			escalation:  []SignalEscalationStep{{Signal: "SIGKILL"}},
+			expectedErr: true,
+		},
+		{
+			name:        "negative grace period",
+			escalation:  []SignalEscalationStep{{Signal: "SIGTERM", GracePeriod: &Duration{Duration: -time.Second}}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := &DecorationConfig{
+				UtilityImages: &UtilityImages{
+					CloneRefs:  "clonerefs",
+					InitUpload: "initupload",
+					Entrypoint: "entrypoint",
+					Sidecar:    "sidecar",
+				},
+				GCSConfiguration: &GCSConfiguration{
+					Bucket:       "bucket",
+					PathStrategy: PathStrategyExplicit,
+				},
+				Steps:            tc.steps,
+				SignalEscalation: tc.escalation,
+			}
+			if err := dc.Validate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDecorationConfigValidateVault(t *testing.T) {
+	testCases := []struct {
+		name        string
+		vault       *VaultConfig
+		expectedErr bool
+	}{
+		{
+			name: "valid kubernetes auth",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthKubernetes,
+				Role:       "ci",
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github", Key: "token", Env: "GITHUB_TOKEN"}},
+			},
+		},
+		{
+			name: "valid approle auth",
+			vault: &VaultConfig{
+				Address:       "https://vault.example.com",
+				AuthMethod:    VaultAuthAppRole,
+				AppRoleSecret: "vault-approle",
+				Secrets:       []VaultSecret{{Path: "secret/data/ci/github", Key: "token", File: "github-token"}},
+			},
+		},
+		{
+			name: "missing address",
+			vault: &VaultConfig{
+				AuthMethod: VaultAuthKubernetes,
+				Role:       "ci",
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github", Key: "token"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "unknown auth method",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: "token",
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github", Key: "token"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "kubernetes auth without role",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthKubernetes,
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github", Key: "token"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "approle auth without secret",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthAppRole,
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github", Key: "token"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "no secrets",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthKubernetes,
+				Role:       "ci",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "secret without key",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthKubernetes,
+				Role:       "ci",
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "file outside of the secret directory",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthKubernetes,
+				Role:       "ci",
+				Secrets:    []VaultSecret{{Path: "secret/data/ci/github", Key: "token", File: "../token"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "duplicate files",
+			vault: &VaultConfig{
+				Address:    "https://vault.example.com",
+				AuthMethod: VaultAuthKubernetes,
+				Role:       "ci",
+				Secrets: []VaultSecret{
+					{Path: "secret/data/ci/github", Key: "token"},
+					{Path: "secret/data/ci/gitlab", Key: "token"},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := &DecorationConfig{
+				UtilityImages: &UtilityImages{
+					CloneRefs:  "clonerefs",
+					InitUpload: "initupload",
+					Entrypoint: "entrypoint",
+					Sidecar:    "sidecar",
+				},
+				GCSConfiguration: &GCSConfiguration{
+					Bucket:       "bucket",
+					PathStrategy: PathStrategyExplicit,
+				},
+				Vault: tc.vault,
+			}
+			if err := dc.Validate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
 func TestApplyDefaultsAppliesDefaultsForAllFields(t *testing.T) {
 	t.Parallel()
 	seed := time.Now().UnixNano()
@@ -576,6 +860,134 @@ func TestRerunAuthConfigValidate(t *testing.T) {
 	}
 }
 
+func TestRetryConfigValidate(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		config      *RetryConfig
+		errExpected bool
+	}{
+		{
+			name: "nil config",
+		},
+		{
+			name:   "retry on error by default",
+			config: &RetryConfig{MaxRetries: 3, Backoff: &metav1.Duration{Duration: time.Minute}},
+		},
+		{
+			name:   "retry on error and failure",
+			config: &RetryConfig{MaxRetries: 1, RetryOn: []ProwJobState{ErrorState, FailureState}},
+		},
+		{
+			name:        "negative max retries",
+			config:      &RetryConfig{MaxRetries: -1},
+			errExpected: true,
+		},
+		{
+			name:        "negative backoff",
+			config:      &RetryConfig{MaxRetries: 1, Backoff: &metav1.Duration{Duration: -time.Minute}},
+			errExpected: true,
+		},
+		{
+			name:   "maximum retries and backoff",
+			config: &RetryConfig{MaxRetries: MaxRetries, Backoff: &metav1.Duration{Duration: MaxRetryBackoff}},
+		},
+		{
+			name:        "too many retries",
+			config:      &RetryConfig{MaxRetries: MaxRetries + 1},
+			errExpected: true,
+		},
+		{
+			name:        "too long backoff",
+			config:      &RetryConfig{MaxRetries: 1, Backoff: &metav1.Duration{Duration: MaxRetryBackoff + time.Second}},
+			errExpected: true,
+		},
+		{
+			name:        "retry on aborted",
+			config:      &RetryConfig{MaxRetries: 1, RetryOn: []ProwJobState{AbortedState}},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.config.Validate(); (err != nil) != tc.errExpected {
+				t.Errorf("Expected error %v, got %v", tc.errExpected, err)
+			}
+		})
+	}
+}
+
+func TestRetryConfigShouldRetry(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		config   *RetryConfig
+		state    ProwJobState
+		retries  int
+		expected bool
+	}{
+		{
+			name:  "nil config",
+			state: ErrorState,
+		},
+		{
+			name:     "error is retried by default",
+			config:   &RetryConfig{MaxRetries: 1},
+			state:    ErrorState,
+			expected: true,
+		},
+		{
+			name:   "failure is not retried by default",
+			config: &RetryConfig{MaxRetries: 1},
+			state:  FailureState,
+		},
+		{
+			name:     "failure is retried when configured",
+			config:   &RetryConfig{MaxRetries: 1, RetryOn: []ProwJobState{FailureState}},
+			state:    FailureState,
+			expected: true,
+		},
+		{
+			name:    "retries are exhausted",
+			config:  &RetryConfig{MaxRetries: 2},
+			state:   ErrorState,
+			retries: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.config.ShouldRetry(tc.state, tc.retries); actual != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryConfigBackoffFor(t *testing.T) {
+	config := &RetryConfig{MaxRetries: 3, Backoff: &metav1.Duration{Duration: time.Minute}}
+	for retry, expected := range map[int]time.Duration{0: 0, 1: time.Minute, 2: 2 * time.Minute, 3: 4 * time.Minute, 6: 32 * time.Minute, 7: MaxRetryBackoff} {
+		if actual := config.BackoffFor(retry); actual != expected {
+			t.Errorf("retry %d: expected backoff %v, got %v", retry, expected, actual)
+		}
+	}
+	// Shifting the backoff by these retries overflows time.Duration, the
+	// backoff must be capped instead of wrapping around.
+	for _, tc := range []struct {
+		backoff time.Duration
+		retry   int
+	}{
+		{backoff: time.Nanosecond, retry: 64},
+		{backoff: time.Minute, retry: 28},
+		{backoff: time.Minute, retry: 1 << 20},
+		{backoff: 1 << 62, retry: 2},
+	} {
+		config := &RetryConfig{Backoff: &metav1.Duration{Duration: tc.backoff}}
+		if actual := config.BackoffFor(tc.retry); actual != MaxRetryBackoff {
+			t.Errorf("backoff %d, retry %d: expected backoff %v, got %v", tc.backoff, tc.retry, MaxRetryBackoff, actual)
+		}
+	}
+}
+
 func TestRerunAuthConfigIsAuthorized(t *testing.T) {
 	var testCases = []struct {
 		name       string
@@ -619,6 +1031,47 @@ func TestRerunAuthConfigIsAuthorized(t *testing.T) {
 	}
 }
 
+func TestRerunAuthConfigIsAuthorizedByGroups(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		groups     []string
+		config     *RerunAuthConfig
+		authorized bool
+	}{
+		{
+			name:       "authorized - AllowAnyone is true",
+			config:     &RerunAuthConfig{AllowAnyone: true},
+			authorized: true,
+		},
+		{
+			name:       "authorized - group in OIDCGroups",
+			groups:     []string{"developers", "ci-admins"},
+			config:     &RerunAuthConfig{OIDCGroups: []string{"ci-admins"}},
+			authorized: true,
+		},
+		{
+			name:       "unauthorized - group not in OIDCGroups",
+			groups:     []string{"developers"},
+			config:     &RerunAuthConfig{OIDCGroups: []string{"ci-admins"}, GitHubUsers: []string{"developers"}},
+			authorized: false,
+		},
+		{
+			name:       "unauthorized - RerunAuthConfig is nil",
+			groups:     []string{"ci-admins"},
+			config:     nil,
+			authorized: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.config.IsAuthorizedByGroups(tc.groups); actual != tc.authorized {
+				t.Errorf("Expected %v, got %v", tc.authorized, actual)
+			}
+		})
+	}
+}
+
 func TestRerunAuthConfigIsAllowAnyone(t *testing.T) {
 	var testCases = []struct {
 		name     string
@@ -907,3 +1360,29 @@ func TestProwJobSpec_GetPipelineRunSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestDescriptionWithFailureReason(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status ProwJobStatus
+		want   string
+	}{
+		{
+			name:   "no failure reason",
+			status: ProwJobStatus{Description: "Job succeeded."},
+			want:   "Job succeeded.",
+		},
+		{
+			name:   "failure reason",
+			status: ProwJobStatus{Description: "Job failed.", FailureReason: TestFailure},
+			want:   "Job failed. [test-failure]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &ProwJob{Status: tc.status}
+			if got := pj.DescriptionWithFailureReason(); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -79,6 +79,25 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(Duration)
 		**out = **in
 	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]EntrypointStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SignalEscalation != nil {
+		in, out := &in.SignalEscalation, &out.SignalEscalation
+		*out = make([]SignalEscalationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CaptureProcessTree != nil {
+		in, out := &in.CaptureProcessTree, &out.CaptureProcessTree
+		*out = new(bool)
+		**out = **in
+	}
 	if in.UtilityImages != nil {
 		in, out := &in.UtilityImages, &out.UtilityImages
 		*out = new(UtilityImages)
@@ -104,6 +123,16 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AzureCredentialsSecret != nil {
+		in, out := &in.AzureCredentialsSecret, &out.AzureCredentialsSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DefaultServiceAccountName != nil {
 		in, out := &in.DefaultServiceAccountName, &out.DefaultServiceAccountName
 		*out = new(string)
@@ -124,6 +153,16 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShallowSince != nil {
+		in, out := &in.ShallowSince, &out.ShallowSince
+		*out = new(string)
+		**out = **in
+	}
 	if in.SkipCloning != nil {
 		in, out := &in.SkipCloning, &out.SkipCloning
 		*out = new(bool)
@@ -149,6 +188,11 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(GitHubAppPrivateKeySecret)
 		**out = **in
 	}
+	if in.SubmoduleCredentialSecrets != nil {
+		in, out := &in.SubmoduleCredentialSecrets, &out.SubmoduleCredentialSecrets
+		*out = make([]SubmoduleCredentialSecret, len(*in))
+		copy(*out, *in)
+	}
 	if in.CensorSecrets != nil {
 		in, out := &in.CensorSecrets, &out.CensorSecrets
 		*out = new(bool)
@@ -164,6 +208,16 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LogStreamInterval != nil {
+		in, out := &in.LogStreamInterval, &out.LogStreamInterval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.ResourceMetricsInterval != nil {
+		in, out := &in.ResourceMetricsInterval, &out.ResourceMetricsInterval
+		*out = new(Duration)
+		**out = **in
+	}
 	if in.SetLimitEqualsMemoryRequest != nil {
 		in, out := &in.SetLimitEqualsMemoryRequest, &out.SetLimitEqualsMemoryRequest
 		*out = new(bool)
@@ -238,6 +292,32 @@ func (in *Duration) DeepCopy() *Duration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntrypointStep) DeepCopyInto(out *EntrypointStep) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntrypointStep.
+func (in *EntrypointStep) DeepCopy() *EntrypointStep {
+	if in == nil {
+		return nil
+	}
+	out := new(EntrypointStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCSConfiguration) DeepCopyInto(out *GCSConfiguration) {
 	*out = *in
@@ -427,6 +507,11 @@ func (in *ProwJobSpec) DeepCopyInto(out *ProwJobSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodSpec != nil {
 		in, out := &in.PodSpec, &out.PodSpec
 		*out = new(corev1.PodSpec)
@@ -462,6 +547,11 @@ func (in *ProwJobSpec) DeepCopyInto(out *ProwJobSpec) {
 		*out = new(RerunAuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RerunOverrides != nil {
+		in, out := &in.RerunOverrides, &out.RerunOverrides
+		*out = new(RerunOverrides)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProwJobDefault != nil {
 		in, out := &in.ProwJobDefault, &out.ProwJobDefault
 		*out = new(ProwJobDefault)
@@ -499,6 +589,10 @@ func (in *ProwJobStatus) DeepCopyInto(out *ProwJobStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -562,6 +656,11 @@ func (in *Refs) DeepCopyInto(out *Refs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -619,6 +718,11 @@ func (in *RerunAuthConfig) DeepCopyInto(out *RerunAuthConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OIDCGroups != nil {
+		in, out := &in.OIDCGroups, &out.OIDCGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -632,6 +736,32 @@ func (in *RerunAuthConfig) DeepCopy() *RerunAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerunOverrides) DeepCopyInto(out *RerunOverrides) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerunOverrides.
+func (in *RerunOverrides) DeepCopy() *RerunOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(RerunOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
@@ -684,6 +814,32 @@ func (in *ResultStoreConfig) DeepCopy() *ResultStoreConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryConfig) DeepCopyInto(out *RetryConfig) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]ProwJobState, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryConfig.
+func (in *RetryConfig) DeepCopy() *RetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingOptions) DeepCopyInto(out *SchedulingOptions) {
 	*out = *in
@@ -712,6 +868,27 @@ func (in *SchedulingOptions) DeepCopy() *SchedulingOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignalEscalationStep) DeepCopyInto(out *SignalEscalationStep) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignalEscalationStep.
+func (in *SignalEscalationStep) DeepCopy() *SignalEscalationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(SignalEscalationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlackReporterConfig) DeepCopyInto(out *SlackReporterConfig) {
 	*out = *in
@@ -738,6 +915,22 @@ func (in *SlackReporterConfig) DeepCopy() *SlackReporterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubmoduleCredentialSecret) DeepCopyInto(out *SubmoduleCredentialSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubmoduleCredentialSecret.
+func (in *SubmoduleCredentialSecret) DeepCopy() *SubmoduleCredentialSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(SubmoduleCredentialSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TektonPipelineRunSpec) DeepCopyInto(out *TektonPipelineRunSpec) {
 	*out = *in
@@ -774,3 +967,40 @@ func (in *UtilityImages) DeepCopy() *UtilityImages {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConfig) DeepCopyInto(out *VaultConfig) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]VaultSecret, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConfig.
+func (in *VaultConfig) DeepCopy() *VaultConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecret) DeepCopyInto(out *VaultSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecret.
+func (in *VaultSecret) DeepCopy() *VaultSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecret)
+	in.DeepCopyInto(out)
+	return out
+}
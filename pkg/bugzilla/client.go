@@ -19,6 +19,7 @@ package bugzilla
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -39,6 +40,8 @@ import (
 
 const (
 	methodField = "method"
+
+	defaultSearchPageSize = 500
 )
 
 type Client interface {
@@ -68,6 +71,9 @@ type Client interface {
 	// UpdateBug updates the fields of a bug on the server
 	// https://bugzilla.readthedocs.io/en/latest/api/core/v1/bug.html#update-bug
 	UpdateBug(id int, update BugUpdate) error
+	// UpdateFlags sets the flags of a bug by name. The status X removes a flag.
+	// https://bugzilla.readthedocs.io/en/latest/api/core/v1/bug.html#update-bug
+	UpdateFlags(id int, flags []Flag) error
 	// AddPullRequestAsExternalBug attempts to add a PR to the external tracker list.
 	// External bugs are assumed to fall under the type identified by their hostname,
 	// so we will provide https://github.com/ here for the URL identifier. We return
@@ -97,8 +103,12 @@ type Client interface {
 	WithFields(fields logrus.Fields) Client
 	Used() bool
 
-	// SearchBugs returns all bugs that meet the given criteria
-	SearchBugs(filters map[string]string) ([]*Bug, error)
+	// SearchBugs returns all bugs that match the query, requesting them page by page
+	SearchBugs(query SearchQuery) ([]*Bug, error)
+	// CreateAttachment attaches a file to bugs and returns the IDs of the new attachments
+	CreateAttachment(attachment *AttachmentCreate) ([]int, error)
+	// GetAttachments gets the attachments of a bug, including their content
+	GetAttachments(id int) ([]Attachment, error)
 }
 
 // NewClient returns a bugzilla client.
@@ -524,6 +534,27 @@ func (c *client) UpdateBug(id int, update BugUpdate) error {
 	return err
 }
 
+// UpdateFlags sets the flags of a bug by name. The status X removes a flag.
+// https://bugzilla.readthedocs.io/en/latest/api/core/v1/bug.html#update-bug
+func (c *client) UpdateFlags(id int, flags []Flag) error {
+	logger := c.logger.WithFields(logrus.Fields{methodField: "UpdateFlags", "id": id, "flags": flags})
+	update := struct {
+		Flags []Flag `json:"flags"`
+	}{Flags: flags}
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal update payload: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/rest/bug/%d", c.endpoint, id), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = c.request(req, logger)
+	return err
+}
+
 // CreateBug creates a new bug on the server.
 // https://bugzilla.readthedocs.io/en/latest/api/core/v1/bug.html#create-bug
 func (c *client) CreateBug(bug *BugCreate) (int, error) {
@@ -699,6 +730,60 @@ func (c *client) GetComments(bugID int) ([]Comment, error) {
 	return parsedResponse.Bugs[bugID].Comments, nil
 }
 
+// CreateAttachment attaches a file to bugs and returns the IDs of the new attachments.
+// https://bugzilla.readthedocs.io/en/latest/api/core/v1/attachment.html#create-attachment
+func (c *client) CreateAttachment(attachment *AttachmentCreate) ([]int, error) {
+	if len(attachment.IDs) == 0 {
+		return nil, errors.New("no bug to attach to")
+	}
+	logger := c.logger.WithFields(logrus.Fields{methodField: "CreateAttachment", "bugs": attachment.IDs, "file_name": attachment.FileName})
+	body, err := json.Marshal(attachment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal create payload: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rest/bug/%d/attachment", c.endpoint, attachment.IDs[0]), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.request(req, logger)
+	if err != nil {
+		return nil, err
+	}
+	var idsStruct struct {
+		IDs []int `json:"ids,omitempty"`
+	}
+	if err := json.Unmarshal(resp, &idsStruct); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server response: %w", err)
+	}
+	return idsStruct.IDs, nil
+}
+
+// GetAttachments gets the attachments of a bug, including their content.
+// https://bugzilla.readthedocs.io/en/latest/api/core/v1/attachment.html#get-attachment
+func (c *client) GetAttachments(id int) ([]Attachment, error) {
+	logger := c.logger.WithFields(logrus.Fields{methodField: "GetAttachments", "id": id})
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rest/bug/%d/attachment", c.endpoint, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := c.request(req, logger)
+	if err != nil {
+		return nil, err
+	}
+	var parsedResponse struct {
+		Bugs map[int][]Attachment `json:"bugs,omitempty"`
+	}
+	if err := json.Unmarshal(raw, &parsedResponse); err != nil {
+		return nil, fmt.Errorf("could not unmarshal response body: %w", err)
+	}
+	attachments, ok := parsedResponse.Bugs[id]
+	if !ok {
+		return nil, fmt.Errorf("did not get attachments for bug %d: %v", id, parsedResponse)
+	}
+	return attachments, nil
+}
+
 func (c *client) request(req *http.Request, logger *logrus.Entry) ([]byte, error) {
 	c.used = true
 	if apiKey := c.getAPIKey(); len(apiKey) > 0 {
@@ -1010,27 +1095,58 @@ func IsIdentifierNotForPullErr(err error) bool {
 	return ok
 }
 
-func (c *client) SearchBugs(filters map[string]string) ([]*Bug, error) {
-	logger := c.logger.WithFields(logrus.Fields{methodField: "SearchBugs", "filters": filters})
+func (c *client) SearchBugs(query SearchQuery) ([]*Bug, error) {
+	logger := c.logger.WithFields(logrus.Fields{methodField: "SearchBugs", "filters": query.Filters, "quicksearch": query.Quicksearch})
 
 	params := url.Values{}
-	for param, value := range filters {
-		params.Add(param, value)
+	for param, values := range query.Filters {
+		for _, value := range values {
+			params.Add(param, value)
+		}
 	}
-
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rest/bug?%s", c.endpoint, params.Encode()), nil)
-	if err != nil {
-		return nil, err
+	if query.Quicksearch != "" {
+		params.Set("quicksearch", query.Quicksearch)
 	}
-	raw, err := c.request(req, logger)
-	if err != nil {
-		return nil, err
+	if len(query.IncludeFields) > 0 {
+		for _, field := range query.IncludeFields {
+			params.Add("include_fields", field)
+		}
+	} else {
+		params.Add("include_fields", "_default")
+		params.Add("include_fields", "flags")
 	}
-	var parsedResponse struct {
-		Bugs []*Bug `json:"bugs,omitempty"`
+	// a stable order is required for the offsets to not skip or repeat bugs
+	params.Set("order", "bug_id")
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
 	}
-	if err := json.Unmarshal(raw, &parsedResponse); err != nil {
-		return nil, fmt.Errorf("could not unmarshal response body: %v", err)
+
+	var bugs []*Bug
+	for {
+		limit := pageSize
+		if query.MaxResults > 0 && query.MaxResults-len(bugs) < limit {
+			limit = query.MaxResults - len(bugs)
+		}
+		params.Set("limit", strconv.Itoa(limit))
+		params.Set("offset", strconv.Itoa(len(bugs)))
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rest/bug?%s", c.endpoint, params.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := c.request(req, logger)
+		if err != nil {
+			return nil, err
+		}
+		var parsedResponse struct {
+			Bugs []*Bug `json:"bugs,omitempty"`
+		}
+		if err := json.Unmarshal(raw, &parsedResponse); err != nil {
+			return nil, fmt.Errorf("could not unmarshal response body: %w", err)
+		}
+		bugs = append(bugs, parsedResponse.Bugs...)
+		if len(parsedResponse.Bugs) < limit || (query.MaxResults > 0 && len(bugs) >= query.MaxResults) {
+			return bugs, nil
+		}
 	}
-	return parsedResponse.Bugs, nil
 }
@@ -565,6 +565,42 @@ func TestUpdateBug(t *testing.T) {
 	}
 }
 
+func TestUpdateFlags(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-BUGZILLA-API-KEY") != "api-key" {
+			t.Error("did not get api-key passed in X-BUGZILLA-API-KEY header")
+			http.Error(w, "403 Forbidden", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodPut {
+			t.Errorf("incorrect method to update flags: %s", r.Method)
+			http.Error(w, "400 Bad Request", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/rest/bug/1705243" {
+			http.Error(w, "404 Not Found", http.StatusNotFound)
+			return
+		}
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read update body: %v", err)
+		}
+		if actual, expected := string(raw), `{"flags":[{"name":"qe_test_coverage","status":"+"},{"name":"blocker","status":"X"}]}`; actual != expected {
+			t.Errorf("got incorrect update: expected %v, got %v", expected, actual)
+		}
+	}))
+	defer testServer.Close()
+	client := clientForUrl(testServer.URL)
+
+	flags := []Flag{{Name: "qe_test_coverage", Status: "+"}, {Name: "blocker", Status: "X"}}
+	if err := client.UpdateFlags(1705243, flags); err != nil {
+		t.Errorf("expected no error, but got one: %v", err)
+	}
+	if err := client.UpdateFlags(1, flags); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
 func TestAddPullRequestAsExternalBug(t *testing.T) {
 	var testCases = []struct {
 		name            string
@@ -1279,3 +1315,194 @@ func TestClone(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchBugs(t *testing.T) {
+	var allBugs []Bug
+	for id := 1; id <= 7; id++ {
+		allBugs = append(allBugs, Bug{ID: id, Status: "POST"})
+	}
+	var queries []string
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/bug" {
+			t.Errorf("incorrect path to search bugs: %s", r.URL.Path)
+			http.Error(w, "400 Bad Request", http.StatusBadRequest)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("order") != "bug_id" {
+			t.Errorf("expected the bugs to be ordered by id, got %q", query.Get("order"))
+		}
+		queries = append(queries, fmt.Sprintf("limit=%s offset=%s", query.Get("limit"), query.Get("offset")))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		end := offset + limit
+		if end > len(allBugs) {
+			end = len(allBugs)
+		}
+		raw, err := json.Marshal(struct {
+			Bugs []Bug `json:"bugs"`
+		}{Bugs: allBugs[offset:end]})
+		if err != nil {
+			t.Fatalf("failed to marshal bugs: %v", err)
+		}
+		w.Write(raw)
+	}))
+	defer testServer.Close()
+
+	testCases := []struct {
+		name            string
+		query           SearchQuery
+		expectedBugs    int
+		expectedQueries []string
+	}{
+		{
+			name:            "all bugs in pages",
+			query:           SearchQuery{PageSize: 3},
+			expectedBugs:    7,
+			expectedQueries: []string{"limit=3 offset=0", "limit=3 offset=3", "limit=3 offset=6"},
+		},
+		{
+			name:            "page size matches number of bugs",
+			query:           SearchQuery{PageSize: 7},
+			expectedBugs:    7,
+			expectedQueries: []string{"limit=7 offset=0", "limit=7 offset=7"},
+		},
+		{
+			name:            "limited results",
+			query:           SearchQuery{PageSize: 3, MaxResults: 5},
+			expectedBugs:    5,
+			expectedQueries: []string{"limit=3 offset=0", "limit=2 offset=3"},
+		},
+		{
+			name:            "default page size",
+			query:           SearchQuery{},
+			expectedBugs:    7,
+			expectedQueries: []string{"limit=500 offset=0"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queries = nil
+			bugs, err := clientForUrl(testServer.URL).SearchBugs(tc.query)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(bugs) != tc.expectedBugs {
+				t.Errorf("expected %d bugs, got %d", tc.expectedBugs, len(bugs))
+			}
+			if diff := cmp.Diff(tc.expectedQueries, queries); diff != "" {
+				t.Errorf("queries differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchBugsParameters(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if diff := cmp.Diff([]string{"POST", "MODIFIED"}, query["status"]); diff != "" {
+			t.Errorf("status differs from expected (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"4.6.0"}, query["target_release"]); diff != "" {
+			t.Errorf("target_release differs from expected (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"id", "status"}, query["include_fields"]); diff != "" {
+			t.Errorf("include_fields differs from expected (-want +got):\n%s", diff)
+		}
+		if actual := query.Get("quicksearch"); actual != "product:OpenShift" {
+			t.Errorf("expected quicksearch product:OpenShift, got %q", actual)
+		}
+		w.Write([]byte(`{"bugs":[{"id":1,"status":"POST"}]}`))
+	}))
+	defer testServer.Close()
+
+	bugs, err := clientForUrl(testServer.URL).SearchBugs(SearchQuery{
+		Filters:       map[string][]string{"status": {"POST", "MODIFIED"}, "target_release": {"4.6.0"}},
+		Quicksearch:   "product:OpenShift",
+		IncludeFields: []string{"id", "status"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if diff := cmp.Diff([]*Bug{{ID: 1, Status: "POST"}}, bugs); diff != "" {
+		t.Errorf("bugs differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateAttachment(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("incorrect method to create an attachment: %s", r.Method)
+			http.Error(w, "400 Bad Request", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/rest/bug/1/attachment" {
+			t.Errorf("incorrect path to create an attachment: %s", r.URL.Path)
+			http.Error(w, "400 Bad Request", http.StatusBadRequest)
+			return
+		}
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		expected := `{"ids":[1,2],"data":"ZGlmZg==","file_name":"pr.diff","summary":"the diff","is_patch":true}`
+		if string(raw) != expected {
+			t.Errorf("expected body %s, got %s", expected, string(raw))
+		}
+		w.Write([]byte(`{"ids":[10,11]}`))
+	}))
+	defer testServer.Close()
+
+	ids, err := clientForUrl(testServer.URL).CreateAttachment(&AttachmentCreate{
+		IDs:      []int{1, 2},
+		Data:     []byte("diff"),
+		FileName: "pr.diff",
+		Summary:  "the diff",
+		IsPatch:  true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if diff := cmp.Diff([]int{10, 11}, ids); diff != "" {
+		t.Errorf("ids differ from expected (-want +got):\n%s", diff)
+	}
+
+	if _, err := clientForUrl(testServer.URL).CreateAttachment(&AttachmentCreate{Data: []byte("diff")}); err == nil {
+		t.Error("expected an error for an attachment without bugs")
+	}
+}
+
+func TestGetAttachments(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/bug/1/attachment" {
+			http.Error(w, "404 Not Found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"bugs":{"1":[{"id":10,"bug_id":1,"data":"ZGlmZg==","size":4,"file_name":"pr.diff","summary":"the diff","content_type":"text/plain","is_patch":true,"creator":"bot","creation_time":"2026-01-02T03:04:05Z"}]},"attachments":{}}`))
+	}))
+	defer testServer.Close()
+
+	attachments, err := clientForUrl(testServer.URL).GetAttachments(1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []Attachment{{
+		ID:           10,
+		BugID:        1,
+		Data:         []byte("diff"),
+		Size:         4,
+		FileName:     "pr.diff",
+		Summary:      "the diff",
+		ContentType:  "text/plain",
+		IsPatch:      true,
+		Creator:      "bot",
+		CreationTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+	if diff := cmp.Diff(expected, attachments); diff != "" {
+		t.Errorf("attachments differ from expected (-want +got):\n%s", diff)
+	}
+
+	if _, err := clientForUrl(testServer.URL).GetAttachments(2); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
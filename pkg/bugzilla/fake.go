@@ -17,12 +17,14 @@ limitations under the License.
 package bugzilla
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
 )
 
 // Fake is a fake Bugzilla client with injectable fields
@@ -36,6 +38,13 @@ type Fake struct {
 	ExternalBugs     map[int][]ExternalBug
 	SubComponents    map[int]map[string][]string
 	SearchedBugs     []*Bug
+	Attachments      map[int][]Attachment
+	// PrivateBugs respond with an error that matches IsAccessDenied
+	PrivateBugs sets.Set[int]
+	// Transitions change bugs once Clock passed their time
+	Transitions []Transition
+	// Clock defaults to the real clock
+	Clock clock.PassiveClock
 }
 
 // Endpoint returns the endpoint for this fake
@@ -50,12 +59,16 @@ func (c *Fake) bugErrorMsg(bug int, def string) error {
 	return errors.New(def)
 }
 
-// GetBug retrieves the bug, if registered, or an error, if set,
-// or responds with an error that matches IsNotFound
+// GetBug retrieves the bug, if registered, or an error, if set or the
+// bug is private, or responds with an error that matches IsNotFound
 func (c *Fake) GetBug(id int) (*Bug, error) {
 	if c.BugErrors.Has(id) {
 		return nil, c.bugErrorMsg(id, "injected error getting bug")
 	}
+	if c.PrivateBugs.Has(id) {
+		return nil, &requestError{statusCode: http.StatusOK, bugzillaCode: 102, message: fmt.Sprintf("You are not authorized to access bug #%d.", id)}
+	}
+	c.applyTransitions()
 	if bug, exists := c.Bugs[id]; exists {
 		return &bug, nil
 	}
@@ -69,6 +82,7 @@ func (c *Fake) GetExternalBugPRsOnBug(id int) ([]ExternalBug, error) {
 	if c.BugErrors.Has(id) {
 		return nil, c.bugErrorMsg(id, "injected error adding external bug to bug")
 	}
+	c.applyTransitions()
 	if _, exists := c.Bugs[id]; exists {
 		return c.ExternalBugs[id], nil
 	}
@@ -81,6 +95,11 @@ func (c *Fake) UpdateBug(id int, update BugUpdate) error {
 	if c.BugErrors.Has(id) {
 		return c.bugErrorMsg(id, "injected error updating bug")
 	}
+	c.applyTransitions()
+	return c.updateBug(id, update)
+}
+
+func (c *Fake) updateBug(id int, update BugUpdate) error {
 	bug, exists := c.Bugs[id]
 	if !exists {
 		return &requestError{statusCode: http.StatusNotFound, message: "bug not registered in the fake"}
@@ -121,6 +140,40 @@ func (c *Fake) UpdateBug(id int, update BugUpdate) error {
 	return nil
 }
 
+// UpdateFlags sets the flags of the bug, if registered, or an error, if set,
+// or responds with an error that matches IsNotFound
+func (c *Fake) UpdateFlags(id int, flags []Flag) error {
+	if c.BugErrors.Has(id) {
+		return c.bugErrorMsg(id, "injected error updating flags")
+	}
+	c.applyTransitions()
+	bug, exists := c.Bugs[id]
+	if !exists {
+		return &requestError{statusCode: http.StatusNotFound, message: "bug not registered in the fake"}
+	}
+	for _, flag := range flags {
+		var updated []Flag
+		found := false
+		for _, existing := range bug.Flags {
+			if existing.Name == flag.Name {
+				found = true
+				if flag.Status == "X" {
+					continue
+				}
+				existing.Status = flag.Status
+				existing.Requestee = flag.Requestee
+			}
+			updated = append(updated, existing)
+		}
+		if !found && flag.Status != "X" {
+			updated = append(updated, Flag{Name: flag.Name, Status: flag.Status, Requestee: flag.Requestee})
+		}
+		bug.Flags = updated
+	}
+	c.Bugs[id] = bug
+	return nil
+}
+
 // AddPullRequestAsExternalBug adds an external bug to the Bugzilla bug,
 // if registered, or an error, if set, or responds with an error that
 // matches IsNotFound
@@ -257,6 +310,7 @@ func (c *Fake) GetComments(id int) ([]Comment, error) {
 	if c.BugErrors.Has(id) {
 		return nil, c.bugErrorMsg(id, "injected error getting bug comments")
 	}
+	c.applyTransitions()
 	if comments, exists := c.BugComments[id]; exists {
 		return comments, nil
 	}
@@ -299,8 +353,127 @@ func (c *Fake) GetRootForClone(bug *Bug) (*Bug, error) {
 	return getRootForClone(c, bug)
 }
 
-func (c *Fake) SearchBugs(filters map[string]string) ([]*Bug, error) {
-	return c.SearchedBugs, nil
+// SearchBugs returns SearchedBugs, if set, or the registered bugs that match
+// the filters of the query in the order of their IDs. Filters are matched
+// against the JSON fields of the bugs. Quicksearch is not supported and the
+// fields of the bugs are not limited.
+func (c *Fake) SearchBugs(query SearchQuery) ([]*Bug, error) {
+	if c.SearchedBugs != nil {
+		return c.SearchedBugs, nil
+	}
+	if query.Quicksearch != "" {
+		return nil, errors.New("quicksearch is not supported by the fake")
+	}
+	c.applyTransitions()
+	var bugs []*Bug
+	for _, id := range sets.List(sets.KeySet(c.Bugs)) {
+		bug := c.Bugs[id]
+		matches, err := bugMatches(bug, query.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		bugs = append(bugs, &bug)
+		if query.MaxResults > 0 && len(bugs) == query.MaxResults {
+			break
+		}
+	}
+	return bugs, nil
+}
+
+func bugMatches(bug Bug, filters map[string][]string) (bool, error) {
+	if len(filters) == 0 {
+		return true, nil
+	}
+	raw, err := json.Marshal(bug)
+	if err != nil {
+		return false, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return false, err
+	}
+	for param, values := range filters {
+		var actual []string
+		switch value := fields[param].(type) {
+		case nil:
+		case []interface{}:
+			for _, v := range value {
+				actual = append(actual, fmt.Sprint(v))
+			}
+		default:
+			actual = []string{fmt.Sprint(value)}
+		}
+		if !sets.New[string](actual...).HasAny(values...) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// CreateAttachment attaches the file to the bugs, if registered, or an error,
+// if set, or responds with an error that matches IsNotFound
+func (c *Fake) CreateAttachment(attachment *AttachmentCreate) ([]int, error) {
+	for _, id := range attachment.IDs {
+		if c.BugErrors.Has(id) {
+			return nil, c.bugErrorMsg(id, "injected error creating attachment")
+		}
+		if _, exists := c.Bugs[id]; !exists {
+			return nil, &requestError{statusCode: http.StatusNotFound, message: "bug not registered in the fake"}
+		}
+	}
+	if c.Attachments == nil {
+		c.Attachments = map[int][]Attachment{}
+	}
+	// add new attachments one ID newer than highest existing AttachmentID
+	newID := 1
+	for _, attachments := range c.Attachments {
+		for _, existing := range attachments {
+			if existing.ID >= newID {
+				newID = existing.ID + 1
+			}
+		}
+	}
+	var ids []int
+	for _, id := range attachment.IDs {
+		contentType := attachment.ContentType
+		if attachment.IsPatch {
+			contentType = "text/plain"
+		}
+		c.Attachments[id] = append(c.Attachments[id], Attachment{
+			ID:          newID,
+			BugID:       id,
+			Data:        attachment.Data,
+			Size:        len(attachment.Data),
+			FileName:    attachment.FileName,
+			Summary:     attachment.Summary,
+			ContentType: contentType,
+			IsPrivate:   attachment.IsPrivate,
+			IsPatch:     attachment.IsPatch,
+		})
+		if attachment.Comment != "" {
+			if _, err := c.CreateComment(&CommentCreate{ID: id, Comment: attachment.Comment, IsPrivate: attachment.IsPrivate}); err != nil {
+				return nil, err
+			}
+		}
+		ids = append(ids, newID)
+		newID++
+	}
+	return ids, nil
+}
+
+// GetAttachments retrieves the attachments of the bug, if registered, or an
+// error, if set, or responds with an error that matches IsNotFound
+func (c *Fake) GetAttachments(id int) ([]Attachment, error) {
+	if c.BugErrors.Has(id) {
+		return nil, c.bugErrorMsg(id, "injected error getting attachments")
+	}
+	if _, exists := c.Bugs[id]; !exists {
+		return nil, &requestError{statusCode: http.StatusNotFound, message: "bug not registered in the fake"}
+	}
+	return c.Attachments[id], nil
 }
 
 // SetRoundTripper sets the Transport in http.Client to a custom RoundTripper
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bugzilla
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/yaml"
+)
+
+// Fixture is a scenario of bugs for the Fake, usually loaded from a YAML file:
+//
+//	bugs:
+//	- bug:
+//	    id: 1
+//	    status: NEW
+//	    target_release: ["4.6.0"]
+//	  comments:
+//	  - text: the description
+//	  subcomponents:
+//	    Networking: ["DNS"]
+//	  external_bugs:
+//	  - ext_bz_bug_id: org/repo/pull/1
+//	transitions:
+//	- bug_id: 1
+//	  after: 1h
+//	  update:
+//	    status: VERIFIED
+//	  comment: verified by QA
+type Fixture struct {
+	Bugs        []FixtureBug        `json:"bugs,omitempty"`
+	Transitions []FixtureTransition `json:"transitions,omitempty"`
+}
+
+// FixtureBug is a bug with its comments, subcomponents, external bugs and
+// attachments. The IDs of the bug and its count are filled in for the comments,
+// the IDs of the bug and the pull requests are filled in for the external bugs
+// and the ID of the bug and the size are filled in for the attachments.
+type FixtureBug struct {
+	Bug           Bug                 `json:"bug"`
+	Comments      []Comment           `json:"comments,omitempty"`
+	SubComponents map[string][]string `json:"subcomponents,omitempty"`
+	ExternalBugs  []ExternalBug       `json:"external_bugs,omitempty"`
+	Attachments   []Attachment        `json:"attachments,omitempty"`
+}
+
+// FixtureTransition updates a bug once the given time passed after the
+// fixture was loaded.
+type FixtureTransition struct {
+	BugID   int             `json:"bug_id"`
+	After   metav1.Duration `json:"after"`
+	Update  BugUpdate       `json:"update"`
+	Comment string          `json:"comment,omitempty"`
+}
+
+// Transition updates a bug like UpdateBug once the clock of the Fake passed At
+// and adds the comment, if set.
+type Transition struct {
+	BugID   int
+	At      time.Time
+	Update  BugUpdate
+	Comment string
+}
+
+// NewFakeFromFixture returns a Fake serving the fixture file. The transitions
+// of the fixture are timed with the clock, which defaults to the real clock.
+func NewFakeFromFixture(path string, clk clock.PassiveClock) (*Fake, error) {
+	c := &Fake{Clock: clk}
+	if err := c.LoadFixture(path); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadFixture adds the bugs and transitions of the fixture file to the Fake.
+func (c *Fake) LoadFixture(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var fixture Fixture
+	if err := yaml.UnmarshalStrict(raw, &fixture); err != nil {
+		return fmt.Errorf("failed to unmarshal fixture %s: %w", path, err)
+	}
+	return c.AddFixture(fixture)
+}
+
+// AddFixture adds the bugs and transitions of the fixture to the Fake.
+func (c *Fake) AddFixture(fixture Fixture) error {
+	if c.Bugs == nil {
+		c.Bugs = map[int]Bug{}
+	}
+	if c.BugComments == nil {
+		c.BugComments = map[int][]Comment{}
+	}
+	if c.SubComponents == nil {
+		c.SubComponents = map[int]map[string][]string{}
+	}
+	if c.ExternalBugs == nil {
+		c.ExternalBugs = map[int][]ExternalBug{}
+	}
+	if c.Attachments == nil {
+		c.Attachments = map[int][]Attachment{}
+	}
+	if c.BugErrors == nil {
+		c.BugErrors = sets.New[int]()
+	}
+	if c.BugCreateErrors == nil {
+		c.BugCreateErrors = sets.New[string]()
+	}
+
+	nextCommentID := 0
+	for _, comments := range c.BugComments {
+		for _, comment := range comments {
+			if comment.ID >= nextCommentID {
+				nextCommentID = comment.ID + 1
+			}
+		}
+	}
+	for _, fixtureBug := range fixture.Bugs {
+		id := fixtureBug.Bug.ID
+		if id == 0 {
+			return fmt.Errorf("bug %q has no id", fixtureBug.Bug.Summary)
+		}
+		if _, exists := c.Bugs[id]; exists {
+			return fmt.Errorf("bug %d is already registered in the fake", id)
+		}
+		c.Bugs[id] = fixtureBug.Bug
+		for _, comment := range fixtureBug.Comments {
+			if comment.ID == 0 {
+				comment.ID = nextCommentID
+				nextCommentID++
+			}
+			comment.BugID = id
+			comment.Count = len(c.BugComments[id])
+			c.BugComments[id] = append(c.BugComments[id], comment)
+		}
+		if fixtureBug.SubComponents != nil {
+			c.SubComponents[id] = fixtureBug.SubComponents
+		}
+		for _, externalBug := range fixtureBug.ExternalBugs {
+			externalBug.BugzillaBugID = id
+			org, repo, num, err := PullFromIdentifier(externalBug.ExternalBugID)
+			if err == nil {
+				externalBug.Org, externalBug.Repo, externalBug.Num = org, repo, num
+			}
+			c.ExternalBugs[id] = append(c.ExternalBugs[id], externalBug)
+		}
+		for _, attachment := range fixtureBug.Attachments {
+			attachment.BugID = id
+			attachment.Size = len(attachment.Data)
+			c.Attachments[id] = append(c.Attachments[id], attachment)
+		}
+	}
+
+	now := c.clock().Now()
+	for _, transition := range fixture.Transitions {
+		if _, exists := c.Bugs[transition.BugID]; !exists {
+			return fmt.Errorf("transition for bug %d, which is not registered in the fake", transition.BugID)
+		}
+		c.Transitions = append(c.Transitions, Transition{
+			BugID:   transition.BugID,
+			At:      now.Add(transition.After.Duration),
+			Update:  transition.Update,
+			Comment: transition.Comment,
+		})
+	}
+	return nil
+}
+
+func (c *Fake) clock() clock.PassiveClock {
+	if c.Clock == nil {
+		return clock.RealClock{}
+	}
+	return c.Clock
+}
+
+// applyTransitions applies the due transitions in the order of their time.
+func (c *Fake) applyTransitions() {
+	if len(c.Transitions) == 0 {
+		return
+	}
+	sort.SliceStable(c.Transitions, func(i, j int) bool { return c.Transitions[i].At.Before(c.Transitions[j].At) })
+	now := c.clock().Now()
+	applied := 0
+	for _, transition := range c.Transitions {
+		if transition.At.After(now) {
+			break
+		}
+		applied++
+		if err := c.updateBug(transition.BugID, transition.Update); err != nil {
+			continue
+		}
+		if transition.Comment != "" {
+			_, _ = c.CreateComment(&CommentCreate{ID: transition.BugID, Comment: transition.Comment})
+		}
+	}
+	c.Transitions = c.Transitions[applied:]
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bugzilla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestNewFakeFromFixture(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	c, err := NewFakeFromFixture("testdata/fixture.yaml", fakeClock)
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	bug, err := c.GetBug(1)
+	if err != nil {
+		t.Fatalf("failed to get bug: %v", err)
+	}
+	if bug.Status != "POST" || bug.Summary != "DNS lookups time out" {
+		t.Errorf("unexpected bug: %+v", bug)
+	}
+	comments, err := c.GetComments(1)
+	if err != nil {
+		t.Fatalf("failed to get comments: %v", err)
+	}
+	expectedComments := []Comment{
+		{ID: 0, BugID: 1, Count: 0, Text: "DNS lookups time out after an upgrade."},
+		{ID: 1, BugID: 1, Count: 1, Text: "A fix is up for review.", Creator: "dev@example.com"},
+	}
+	if diff := cmp.Diff(expectedComments, comments); diff != "" {
+		t.Errorf("comments differ from expected (-want +got):\n%s", diff)
+	}
+	subComponents, err := c.GetSubComponentsOnBug(1)
+	if err != nil {
+		t.Fatalf("failed to get subcomponents: %v", err)
+	}
+	if diff := cmp.Diff(map[string][]string{"Networking": {"DNS"}}, subComponents); diff != "" {
+		t.Errorf("subcomponents differ from expected (-want +got):\n%s", diff)
+	}
+	externalBugs, err := c.GetExternalBugPRsOnBug(1)
+	if err != nil {
+		t.Fatalf("failed to get external bugs: %v", err)
+	}
+	expectedExternalBugs := []ExternalBug{{
+		BugzillaBugID: 1,
+		ExternalBugID: "openshift/cluster-dns-operator/pull/42",
+		Org:           "openshift",
+		Repo:          "cluster-dns-operator",
+		Num:           42,
+		Status:        "open",
+	}}
+	if diff := cmp.Diff(expectedExternalBugs, externalBugs); diff != "" {
+		t.Errorf("external bugs differ from expected (-want +got):\n%s", diff)
+	}
+	clones, err := c.GetClones(bug)
+	if err != nil {
+		t.Fatalf("failed to get clones: %v", err)
+	}
+	if len(clones) != 1 || clones[0].ID != 2 {
+		t.Errorf("expected bug 2 to be a clone of bug 1, got %v", clones)
+	}
+
+	fakeClock.Step(90 * time.Minute)
+	if bug, _ := c.GetBug(1); bug.Status != "MODIFIED" {
+		t.Errorf("expected bug to be MODIFIED after 90m, got %s", bug.Status)
+	}
+	fakeClock.Step(time.Hour)
+	if bug, _ := c.GetBug(1); bug.Status != "VERIFIED" {
+		t.Errorf("expected bug to be VERIFIED after 150m, got %s", bug.Status)
+	}
+	comments, _ = c.GetComments(1)
+	if last := comments[len(comments)-1]; last.Text != "Verified by QA." || last.Count != 2 {
+		t.Errorf("expected the transition to add a comment, got %+v", last)
+	}
+	if len(c.Transitions) != 0 {
+		t.Errorf("expected all transitions to be applied, got %d pending", len(c.Transitions))
+	}
+}
+
+func TestAddFixtureRejectsInvalidFixtures(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fixture Fixture
+	}{
+		{
+			name:    "bug without id",
+			fixture: Fixture{Bugs: []FixtureBug{{Bug: Bug{Summary: "no id"}}}},
+		},
+		{
+			name:    "duplicate bug",
+			fixture: Fixture{Bugs: []FixtureBug{{Bug: Bug{ID: 1}}, {Bug: Bug{ID: 1}}}},
+		},
+		{
+			name:    "transition for unknown bug",
+			fixture: Fixture{Transitions: []FixtureTransition{{BugID: 1}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := (&Fake{}).AddFixture(tc.fixture); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFakeSearchBugs(t *testing.T) {
+	c, err := NewFakeFromFixture("testdata/fixture.yaml", nil)
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+	testCases := []struct {
+		name     string
+		query    SearchQuery
+		expected []int
+	}{
+		{
+			name:     "all bugs",
+			expected: []int{1, 2},
+		},
+		{
+			name:     "by status",
+			query:    SearchQuery{Filters: map[string][]string{"status": {"NEW", "MODIFIED"}}},
+			expected: []int{2},
+		},
+		{
+			name:     "by target release and component",
+			query:    SearchQuery{Filters: map[string][]string{"target_release": {"4.6.0"}, "component": {"Networking"}}},
+			expected: []int{1},
+		},
+		{
+			name:     "by id",
+			query:    SearchQuery{Filters: map[string][]string{"id": {"2"}}},
+			expected: []int{2},
+		},
+		{
+			name:     "limited results",
+			query:    SearchQuery{MaxResults: 1},
+			expected: []int{1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bugs, err := c.SearchBugs(tc.query)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var ids []int
+			for _, bug := range bugs {
+				ids = append(ids, bug.ID)
+			}
+			if diff := cmp.Diff(tc.expected, ids); diff != "" {
+				t.Errorf("bugs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
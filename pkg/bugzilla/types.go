@@ -204,6 +204,55 @@ type CommentCreate struct {
 	IsPrivate bool `json:"is_private,omitempty"`
 }
 
+// Attachment holds information about an attachment of a bug. See API documentation at:
+// https://bugzilla.readthedocs.io/en/latest/api/core/v1/attachment.html#get-attachment
+type Attachment struct {
+	// ID is the unique ID of the attachment.
+	ID int `json:"id,omitempty"`
+	// BugID is the ID of the bug that the attachment is on.
+	BugID int `json:"bug_id,omitempty"`
+	// Data is the content of the attachment.
+	Data []byte `json:"data,omitempty"`
+	// Size is the length in bytes of the content of the attachment.
+	Size int `json:"size,omitempty"`
+	// FileName is the file name of the attachment.
+	FileName string `json:"file_name,omitempty"`
+	// Summary is a short string describing the attachment.
+	Summary string `json:"summary,omitempty"`
+	// ContentType is the MIME type of the attachment.
+	ContentType string `json:"content_type,omitempty"`
+	// IsPrivate is true if the attachment is private (only visible to a certain group called the "insidergroup"), false otherwise.
+	IsPrivate bool `json:"is_private,omitempty"`
+	// IsObsolete is true if the attachment is obsolete, false otherwise.
+	IsObsolete bool `json:"is_obsolete,omitempty"`
+	// IsPatch is true if the attachment is a patch, false otherwise.
+	IsPatch bool `json:"is_patch,omitempty"`
+	// Creator is the login name of the user that created the attachment.
+	Creator string `json:"creator,omitempty"`
+	// CreationTime is the time the attachment was created.
+	CreationTime time.Time `json:"creation_time,omitempty"`
+}
+
+// AttachmentCreate holds the info needed to attach a file to a bug
+type AttachmentCreate struct {
+	// IDs are the IDs of the bugs the file is attached to.
+	IDs []int `json:"ids"`
+	// Data is the content of the attachment.
+	Data []byte `json:"data"`
+	// FileName is the file name of the attachment.
+	FileName string `json:"file_name"`
+	// Summary is a short string describing the attachment.
+	Summary string `json:"summary"`
+	// ContentType is the MIME type of the attachment. Ignored for patches.
+	ContentType string `json:"content_type,omitempty"`
+	// Comment is added to the bugs along with the attachment.
+	Comment string `json:"comment,omitempty"`
+	// IsPatch is true if the attachment is a patch, false otherwise.
+	IsPatch bool `json:"is_patch,omitempty"`
+	// IsPrivate is true if the attachment is private (only visible to a certain group called the "insidergroup"), false otherwise.
+	IsPrivate bool `json:"is_private,omitempty"`
+}
+
 // User holds information about a user
 type User struct {
 	// The user ID for this user.
@@ -319,3 +368,21 @@ type RemoveExternalBugParameters struct {
 	// The inline identifier for which external bug to remove
 	ExternalBugIdentifier
 }
+
+// SearchQuery selects the bugs returned by SearchBugs. See the API documentation at:
+// https://bugzilla.readthedocs.io/en/latest/api/core/v1/bug.html#search-bugs
+type SearchQuery struct {
+	// Filters are search parameters such as status or target_release. Bugs
+	// match if they match any of the values of every parameter.
+	Filters map[string][]string
+	// Quicksearch is a search in the quicksearch syntax, e.g. "ALL product:Foo".
+	Quicksearch string
+	// IncludeFields limits the fields of the returned bugs. Defaults to the
+	// default fields and the flags.
+	IncludeFields []string
+	// PageSize is the number of bugs requested at a time. Defaults to 500.
+	PageSize int
+	// MaxResults limits the number of returned bugs. If zero, all matching
+	// bugs are returned.
+	MaxResults int
+}
@@ -73,6 +73,12 @@ type Options struct {
 	GitHubAppID             string   `json:"github_app_id,omitempty"`
 	GitHubAppPrivateKeyFile string   `json:"github_app_private_key_file,omitempty"`
 
+	// SubmoduleCredentialFiles maps git hosts to files containing the
+	// credentials used for them, either a token or `user:token`. They are
+	// used for submodules and LFS objects hosted outside of the repository
+	// host.
+	SubmoduleCredentialFiles map[string]string `json:"submodule_credential_files,omitempty"`
+
 	// used to hold flag values
 	refs      gitRefs
 	clonePath orgRepoFormat
@@ -124,6 +130,15 @@ func (o *Options) Validate() error {
 		return errors.New("no GitHub App ID specified")
 	}
 
+	for host, file := range o.SubmoduleCredentialFiles {
+		if host == "" || strings.ContainsAny(host, "/@") {
+			return fmt.Errorf("invalid submodule credentials host %q", host)
+		}
+		if file == "" {
+			return fmt.Errorf("no submodule credentials file specified for host %s", host)
+		}
+	}
+
 	return nil
 }
 
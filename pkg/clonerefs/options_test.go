@@ -211,6 +211,51 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "specify submodule credentials",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				SubmoduleCredentialFiles: map[string]string{"gitlab.example.com": "/secrets/submodule-credentials/gitlab/token"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "submodule credentials host with scheme",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				SubmoduleCredentialFiles: map[string]string{"https://gitlab.example.com": "/secrets/submodule-credentials/gitlab/token"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "submodule credentials without file",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				SubmoduleCredentialFiles: map[string]string{"gitlab.example.com": ""},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -21,9 +21,11 @@ import (
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -122,6 +124,16 @@ func (o *Options) createRecords() []clone.Record {
 		}
 	}
 
+	if len(o.SubmoduleCredentialFiles) > 0 {
+		cmds, err := configureCredentialStore(o.SubmoduleCredentialFiles, filepath.Join(os.TempDir(), "git-credentials"))
+		rec.Commands = append(rec.Commands, cmds...)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to configure submodule credentials")
+			rec.Failed = true
+			return []clone.Record{rec}
+		}
+	}
+
 	var numWorkers int
 	if o.MaxParallelWorkers != 0 {
 		numWorkers = o.MaxParallelWorkers
@@ -212,6 +224,66 @@ func configureGlobalCookiefile(cookiePath string) (clone.Command, error) {
 	return cmd, err
 }
 
+// configureCredentialStore writes the credentials of the given hosts to a git
+// credential store and configures git to use it, so that submodules and LFS
+// objects from these hosts can be fetched.
+//
+// Since this is a global setting, we do it once and before running parallel clones.
+func configureCredentialStore(files map[string]string, storePath string) ([]clone.Command, error) {
+	var cmds []clone.Command
+	for _, file := range files {
+		if err := secret.Add(file); err != nil {
+			return cmds, fmt.Errorf("read credentials file %s: %w", file, err)
+		}
+	}
+
+	cmd := clone.Command{
+		Command: fmt.Sprintf("golang: write %q", storePath),
+	}
+	if err := os.WriteFile(storePath, []byte(credentialStore(files, secret.GetSecret)), 0600); err != nil {
+		cmd.Error = err.Error()
+		cmds = append(cmds, cmd)
+		return cmds, fmt.Errorf("write credential store %s: %w", storePath, err)
+	}
+	cmds = append(cmds, cmd)
+
+	helper := fmt.Sprintf("store --file=%s", storePath)
+	out, err := exec.Command("git", "config", "--global", "credential.helper", helper).CombinedOutput()
+	cmd = clone.Command{
+		Command: fmt.Sprintf("git config --global credential.helper %q", helper),
+		Output:  string(out),
+	}
+	if err != nil {
+		cmd.Error = err.Error()
+	}
+	cmds = append(cmds, cmd)
+	return cmds, err
+}
+
+// credentialStore renders the git credential store entries for the hosts.
+// Credentials are either `user:token` or a bare token, which is used as the
+// username as GitHub expects for personal access tokens.
+func credentialStore(files map[string]string, getSecret func(string) []byte) string {
+	hosts := make([]string, 0, len(files))
+	for host := range files {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var lines []string
+	for _, host := range hosts {
+		credentials := strings.TrimSpace(string(getSecret(files[host])))
+		u := url.URL{Scheme: "https", Host: host}
+		if user, token, ok := strings.Cut(credentials, ":"); ok {
+			u.User = url.UserPassword(user, token)
+		} else {
+			u.User = url.UserPassword(credentials, "x-oauth-basic")
+		}
+		lines = append(lines, u.String())
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func addHostFingerprints(fingerprints []string) (string, []clone.Command, error) {
 	// let's try to create the tmp dir if it doesn't exist
 	var cmds []clone.Command
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterhealth probes the build clusters Prow knows about and tells
+// the scheduler which of them can take new ProwJobs.
+package clusterhealth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+const ControllerName = "cluster-health"
+
+// Check is one of the checks a build cluster is probed with.
+type Check string
+
+const (
+	// CheckAPI fails when the API server of the cluster cannot be reached.
+	CheckAPI Check = "api"
+	// CheckNodes fails when the cluster has fewer ready nodes than configured.
+	CheckNodes Check = "nodes"
+	// CheckQuota fails when a resource quota in the pod namespace has less
+	// headroom than configured.
+	CheckQuota Check = "quota"
+)
+
+// Names of the metrics exposed for every build cluster. Deck reads them to
+// show the health of the build clusters on its status page.
+const (
+	SchedulableMetric  = "build_cluster_schedulable"
+	CheckFailingMetric = "build_cluster_check_failing"
+	ReadyNodesMetric   = "build_cluster_ready_nodes"
+)
+
+// probeTimeout bounds every request made while probing a cluster, so that a
+// cluster that does not respond is not waited on for long.
+const probeTimeout = 30 * time.Second
+
+var (
+	schedulable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: SchedulableMetric,
+		Help: "Whether ProwJobs are scheduled to the build cluster.",
+	}, []string{"cluster"})
+	checkFailing = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: CheckFailingMetric,
+		Help: "Whether a check failed in the last probe of the build cluster.",
+	}, []string{"cluster", "check"})
+	readyNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: ReadyNodesMetric,
+		Help: "Number of ready and schedulable nodes of the build cluster.",
+	}, []string{"cluster"})
+)
+
+func init() {
+	prometheus.MustRegister(schedulable)
+	prometheus.MustRegister(checkFailing)
+	prometheus.MustRegister(readyNodes)
+}
+
+// status is the health of a build cluster as of its last probe.
+type status struct {
+	schedulable         bool
+	consecutiveFailures int
+}
+
+// Controller periodically probes all build clusters found in the kubeconfigs
+// and marks the ones that keep failing their probes unschedulable.
+type Controller struct {
+	clients map[string]kubernetes.Interface
+	cfg     config.Getter
+	log     *logrus.Entry
+	clock   clock.Clock
+
+	lock     sync.RWMutex
+	statuses map[string]status
+}
+
+// New returns a controller probing the given clusters. Clusters are
+// discovered from the kubeconfigs, so also clusters that could not be
+// connected to on startup are probed.
+func New(knownClusters map[string]rest.Config, cfg config.Getter) (*Controller, error) {
+	clients := make(map[string]kubernetes.Interface, len(knownClusters))
+	for name, restConfig := range knownClusters {
+		restConfig := restConfig
+		restConfig.Timeout = probeTimeout
+		client, err := kubernetes.NewForConfig(&restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct client for cluster %q: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return newController(clients, cfg), nil
+}
+
+func newController(clients map[string]kubernetes.Interface, cfg config.Getter) *Controller {
+	return &Controller{
+		clients:  clients,
+		cfg:      cfg,
+		log:      logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
+		clock:    clock.RealClock{},
+		statuses: map[string]status{},
+	}
+}
+
+// Start probes the clusters until the context is cancelled. Nothing is
+// probed while scheduler.cluster_health is not configured.
+func (c *Controller) Start(ctx context.Context) error {
+	for {
+		interval := time.Minute
+		if healthConfig := c.cfg().Scheduler.ClusterHealth; healthConfig != nil {
+			c.probeAll(ctx, *healthConfig)
+			interval = healthConfig.ProbeInterval.Duration
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.clock.After(interval):
+		}
+	}
+}
+
+// Schedulable returns whether ProwJobs can be scheduled to the cluster.
+// Clusters that were not probed yet are schedulable.
+func (c *Controller) Schedulable(cluster string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	s, ok := c.statuses[cluster]
+	return !ok || s.schedulable
+}
+
+func (c *Controller) probeAll(ctx context.Context, healthConfig config.ClusterHealthGating) {
+	namespace := c.cfg().PodNamespace
+	var wg sync.WaitGroup
+	for name, client := range c.clients {
+		wg.Add(1)
+		go func(name string, client kubernetes.Interface) {
+			defer wg.Done()
+			failures, nodes := probe(ctx, client, namespace, healthConfig)
+			c.record(name, failures, nodes, healthConfig.FailureThreshold)
+		}(name, client)
+	}
+	wg.Wait()
+}
+
+// record updates the status and the metrics of a cluster with the result of
+// a probe. A negative number of nodes means that they could not be counted.
+func (c *Controller) record(cluster string, failures map[Check]string, nodes int, failureThreshold int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	s, probed := c.statuses[cluster]
+	wasSchedulable := !probed || s.schedulable
+	if len(failures) == 0 {
+		s.consecutiveFailures = 0
+	} else {
+		s.consecutiveFailures++
+	}
+	s.schedulable = s.consecutiveFailures < failureThreshold
+	c.statuses[cluster] = s
+
+	log := c.log.WithField("cluster", cluster)
+	for _, check := range []Check{CheckAPI, CheckNodes, CheckQuota} {
+		reason, failed := failures[check]
+		if failed {
+			log.WithField("check", check).Warnf("Build cluster failed its health check: %s", reason)
+		}
+		checkFailing.WithLabelValues(cluster, string(check)).Set(boolToFloat(failed))
+	}
+	if nodes >= 0 {
+		readyNodes.WithLabelValues(cluster).Set(float64(nodes))
+	}
+	schedulable.WithLabelValues(cluster).Set(boolToFloat(s.schedulable))
+	if wasSchedulable && !s.schedulable {
+		log.WithField("failures", s.consecutiveFailures).Error("Build cluster is unhealthy, no longer scheduling ProwJobs to it.")
+	} else if !wasSchedulable && s.schedulable {
+		log.Info("Build cluster is healthy again, scheduling ProwJobs to it.")
+	}
+}
+
+// probe runs all checks against a cluster. It returns the reasons for the
+// failed checks and the number of ready nodes, or -1 if the nodes could not
+// be counted.
+func probe(ctx context.Context, client kubernetes.Interface, namespace string, healthConfig config.ClusterHealthGating) (map[Check]string, int) {
+	failures := map[Check]string{}
+	if _, err := client.Discovery().ServerVersion(); err != nil {
+		failures[CheckAPI] = fmt.Sprintf("API server is unreachable: %v", err)
+		return failures, -1
+	}
+
+	nodes := -1
+	nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	switch {
+	case kerrors.IsForbidden(err):
+		// Listing nodes is not required to run jobs, so clusters where
+		// Prow may not do so are not checked for ready nodes.
+	case err != nil:
+		failures[CheckNodes] = fmt.Sprintf("failed to list nodes: %v", err)
+	default:
+		nodes = countReadyNodes(nodeList.Items)
+		if nodes < healthConfig.MinReadyNodes {
+			failures[CheckNodes] = fmt.Sprintf("%d of %d nodes are ready, at least %d are required", nodes, len(nodeList.Items), healthConfig.MinReadyNodes)
+		}
+	}
+
+	if healthConfig.MinQuotaHeadroom > 0 {
+		quotas, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+		switch {
+		case kerrors.IsForbidden(err):
+		case err != nil:
+			failures[CheckQuota] = fmt.Sprintf("failed to list resource quotas: %v", err)
+		default:
+			if reasons := exhaustedQuotas(quotas.Items, healthConfig.MinQuotaHeadroom); len(reasons) > 0 {
+				failures[CheckQuota] = fmt.Sprintf("not enough quota left: %v", reasons)
+			}
+		}
+	}
+
+	return failures, nodes
+}
+
+func countReadyNodes(nodes []corev1.Node) int {
+	var ready int
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+	}
+	return ready
+}
+
+// exhaustedQuotas describes every resource of the quotas with less than
+// minHeadroom percent of its hard limit left.
+func exhaustedQuotas(quotas []corev1.ResourceQuota, minHeadroom int) []string {
+	var exhausted []string
+	for _, quota := range quotas {
+		for resource, hard := range quota.Status.Hard {
+			if hard.IsZero() {
+				continue
+			}
+			used := quota.Status.Used[resource]
+			headroom := 100 * (1 - float64(used.MilliValue())/float64(hard.MilliValue()))
+			if headroom < float64(minHeadroom) {
+				exhausted = append(exhausted, fmt.Sprintf("%s/%s: %s of %s used", quota.Name, resource, used.String(), hard.String()))
+			}
+		}
+	}
+	sort.Strings(exhausted)
+	return exhausted
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
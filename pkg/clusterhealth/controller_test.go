@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhealth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func node(name string, ready, unschedulable bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+	}
+}
+
+func quota(cpuUsed, cpuHard string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "test-pods"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuHard)},
+			Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuUsed)},
+		},
+	}
+}
+
+func TestProbe(t *testing.T) {
+	healthConfig := config.ClusterHealthGating{MinReadyNodes: 2, MinQuotaHeadroom: 10}
+	testCases := []struct {
+		name      string
+		objects   []runtime.Object
+		reactions map[string]error
+
+		expectedFailures []Check
+		expectedNodes    int
+	}{
+		{
+			name:          "healthy cluster",
+			objects:       []runtime.Object{node("a", true, false), node("b", true, false), quota("8", "10")},
+			expectedNodes: 2,
+		},
+		{
+			name:             "unreachable API server",
+			reactions:        map[string]error{"get/version": errors.New("connection refused")},
+			expectedFailures: []Check{CheckAPI},
+			expectedNodes:    -1,
+		},
+		{
+			name:             "not enough ready nodes",
+			objects:          []runtime.Object{node("a", true, false), node("b", false, false), node("c", true, true)},
+			expectedFailures: []Check{CheckNodes},
+			expectedNodes:    1,
+		},
+		{
+			name:          "nodes may not be listed",
+			objects:       []runtime.Object{quota("1", "10")},
+			reactions:     map[string]error{"list/nodes": kerrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", errors.New("forbidden"))},
+			expectedNodes: -1,
+		},
+		{
+			name:             "quota exhausted",
+			objects:          []runtime.Object{node("a", true, false), node("b", true, false), quota("9500m", "10")},
+			expectedFailures: []Check{CheckQuota},
+			expectedNodes:    2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(tc.objects...)
+			for verbAndResource, err := range tc.reactions {
+				err := err
+				verb, resource, _ := strings.Cut(verbAndResource, "/")
+				client.PrependReactor(verb, resource, func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, err
+				})
+			}
+
+			failures, nodes := probe(context.Background(), client, "test-pods", healthConfig)
+			var failedChecks []Check
+			for _, check := range []Check{CheckAPI, CheckNodes, CheckQuota} {
+				if _, failed := failures[check]; failed {
+					failedChecks = append(failedChecks, check)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedFailures, failedChecks); diff != "" {
+				t.Errorf("failed checks differ from expected (-want +got):\n%s\nfailures: %v", diff, failures)
+			}
+			if nodes != tc.expectedNodes {
+				t.Errorf("expected %d ready nodes, got %d", tc.expectedNodes, nodes)
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	c := newController(nil, func() *config.Config { return &config.Config{} })
+	failed := map[Check]string{CheckAPI: "API server is unreachable"}
+
+	expected := []bool{true, true, false, false, true}
+	for i, failures := range []map[Check]string{failed, failed, failed, failed, {}} {
+		c.record("build", failures, -1, 3)
+		if got := c.Schedulable("build"); got != expected[i] {
+			t.Errorf("probe %d: expected schedulable to be %t, got %t", i, expected[i], got)
+		}
+	}
+	if !c.Schedulable("unknown") {
+		t.Error("expected a cluster that was not probed to be schedulable")
+	}
+}
@@ -51,6 +51,11 @@ type Policy struct {
 	AllowForcePushes *bool `json:"allow_force_pushes,omitempty"`
 	// AllowDeletions allows deletion of the protected branch by anyone with write access to the repository.
 	AllowDeletions *bool `json:"allow_deletions,omitempty"`
+	// LockBranch makes the branch read-only, users cannot push to it.
+	LockBranch *bool `json:"lock_branch,omitempty"`
+	// RequiredDeployments appends environments that a commit must be successfully deployed to
+	// before it can be merged into the branch.
+	RequiredDeployments []string `json:"required_deployments,omitempty"`
 	// Exclude specifies a set of regular expressions which identify branches
 	// that should be excluded from the protection policy, mutually exclusive with Include
 	Exclude []string `json:"exclude,omitempty"`
@@ -66,7 +71,7 @@ func (p Policy) Managed() bool {
 
 func (p Policy) defined() bool {
 	return p.Protect != nil || p.RequiredStatusChecks != nil || p.Admins != nil || p.Restrictions != nil || p.RequireManuallyTriggeredJobs != nil ||
-		p.RequiredPullRequestReviews != nil || p.RequiredLinearHistory != nil || p.AllowForcePushes != nil || p.AllowDeletions != nil
+		p.RequiredPullRequestReviews != nil || p.RequiredLinearHistory != nil || p.AllowForcePushes != nil || p.AllowDeletions != nil || p.LockBranch != nil || p.RequiredDeployments != nil
 }
 
 // ContextPolicy configures required github contexts.
@@ -77,6 +82,19 @@ type ContextPolicy struct {
 	Contexts []string `json:"contexts,omitempty"`
 	// Strict overrides whether new commits in the base branch require updating the PR if set
 	Strict *bool `json:"strict,omitempty"`
+	// Checks appends required contexts that must be provided by a specific GitHub App,
+	// e.g. check runs. A check overrides the check of the parent with the same context.
+	Checks []CheckPolicy `json:"checks,omitempty"`
+}
+
+// CheckPolicy configures a required context and the GitHub App that must provide it.
+type CheckPolicy struct {
+	// Context is the name of the required context, e.g. the name of a check run.
+	Context string `json:"context"`
+	// AppID is the ID of the GitHub App that must provide the context.
+	// If unset, GitHub selects the app that most recently provided the context.
+	// Use -1 to accept the context from any source.
+	AppID *int `json:"app_id,omitempty"`
 }
 
 // ReviewPolicy specifies github approval/review criteria.
@@ -156,7 +174,31 @@ func mergeContextPolicy(parent, child *ContextPolicy) *ContextPolicy {
 	return &ContextPolicy{
 		Contexts: unionStrings(parent.Contexts, child.Contexts),
 		Strict:   selectBool(parent.Strict, child.Strict),
+		Checks:   mergeChecks(parent.Checks, child.Checks),
+	}
+}
+
+// mergeChecks merges the parent and child checks together, sorted by context.
+// A child check replaces the parent check with the same context.
+func mergeChecks(parent, child []CheckPolicy) []CheckPolicy {
+	if child == nil {
+		return parent
+	}
+	if parent == nil {
+		return child
+	}
+	byContext := map[string]CheckPolicy{}
+	for _, check := range parent {
+		byContext[check.Context] = check
+	}
+	for _, check := range child {
+		byContext[check.Context] = check
+	}
+	merged := make([]CheckPolicy, 0, len(byContext))
+	for _, context := range sets.List(sets.KeySet(byContext)) {
+		merged = append(merged, byContext[context])
 	}
+	return merged
 }
 
 func mergeReviewPolicy(parent, child *ReviewPolicy) *ReviewPolicy {
@@ -225,7 +267,9 @@ func (p Policy) Apply(child Policy) Policy {
 		RequiredLinearHistory:        selectBool(p.RequiredLinearHistory, child.RequiredLinearHistory),
 		AllowForcePushes:             selectBool(p.AllowForcePushes, child.AllowForcePushes),
 		AllowDeletions:               selectBool(p.AllowDeletions, child.AllowDeletions),
+		LockBranch:                   selectBool(p.LockBranch, child.LockBranch),
 		RequireManuallyTriggeredJobs: selectBool(p.RequireManuallyTriggeredJobs, child.RequireManuallyTriggeredJobs),
+		RequiredDeployments:          unionStrings(p.RequiredDeployments, child.RequiredDeployments),
 		Restrictions:                 mergeRestrictions(p.Restrictions, child.Restrictions),
 		RequiredPullRequestReviews:   mergeReviewPolicy(p.RequiredPullRequestReviews, child.RequiredPullRequestReviews),
 		Exclude:                      unionStrings(p.Exclude, child.Exclude),
@@ -321,6 +321,40 @@ func TestApply(test *testing.T) {
 	}
 }
 
+func TestMergeChecks(t *testing.T) {
+	parentApp, childApp := 1, 2
+	cases := []struct {
+		name     string
+		parent   []CheckPolicy
+		child    []CheckPolicy
+		expected []CheckPolicy
+	}{
+		{
+			name:     "nil child returns parent",
+			parent:   []CheckPolicy{{Context: "a"}},
+			expected: []CheckPolicy{{Context: "a"}},
+		},
+		{
+			name:     "nil parent returns child",
+			child:    []CheckPolicy{{Context: "a"}},
+			expected: []CheckPolicy{{Context: "a"}},
+		},
+		{
+			name:     "checks are merged and child overrides parent",
+			parent:   []CheckPolicy{{Context: "c"}, {Context: "a", AppID: &parentApp}},
+			child:    []CheckPolicy{{Context: "a", AppID: &childApp}, {Context: "b"}},
+			expected: []CheckPolicy{{Context: "a", AppID: &childApp}, {Context: "b"}, {Context: "c"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, mergeChecks(tc.parent, tc.child)); diff != "" {
+				t.Errorf("merged checks differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestBranchRequirements(t *testing.T) {
 	cases := []struct {
 		name                            string
@@ -166,6 +166,19 @@ type ProwConfig struct {
 	// It has to be explicitly enabled.
 	Scheduler Scheduler `json:"scheduler,omitempty"`
 
+	// Extensions configures job and plugin config fragments that orgs
+	// maintain in their own repositories.
+	Extensions Extensions `json:"extensions,omitempty"`
+
+	// JobPolicies are rules that jobs have to follow. Checkconfig checks all
+	// jobs against them, while in-repo configs and config extensions that
+	// violate them are rejected when they are loaded.
+	JobPolicies []JobPolicy `json:"job_policies,omitempty"`
+
+	// LifecycleManager configures the lifecycle-manager, which marks inactive
+	// issues and pull requests as stale and rotten and eventually closes them.
+	LifecycleManager LifecycleManager `json:"lifecycle_manager,omitempty"`
+
 	// TODO: Move this out of the main config.
 	JenkinsOperators []JenkinsOperator `json:"jenkins_operators,omitempty"`
 
@@ -204,9 +217,26 @@ type ProwConfig struct {
 	// can be used to restrict build cluster on a topic.
 	PubSubTriggers PubSubTriggers `json:"pubsub_triggers,omitempty"`
 
+	// NATSTriggers defines NATS subjects that sub listens to for ProwJob
+	// events, using the same payload as Pub/Sub messages.
+	NATSTriggers []NATSTrigger `json:"nats_triggers,omitempty"`
+
+	// KafkaTriggers defines Kafka topics that sub listens to for ProwJob
+	// events, using the same payload as Pub/Sub messages.
+	KafkaTriggers []KafkaTrigger `json:"kafka_triggers,omitempty"`
+
 	// GitHubOptions allows users to control how prow applications display GitHub website links.
 	GitHubOptions GitHubOptions `json:"github,omitempty"`
 
+	// GitLab configures the orgs whose repos are hosted on GitLab rather
+	// than GitHub.
+	GitLab *GitLab `json:"gitlab,omitempty"`
+
+	// SCM configures the orgs whose repos are hosted on other source code
+	// management providers, keyed by the name of the provider. The only
+	// supported provider is bitbucket-server.
+	SCM map[string]SCMProvider `json:"scm,omitempty"`
+
 	// StatusErrorLink is the url that will be used for jenkins prowJobs that can't be
 	// found, or have another generic issue. The default that will be used if this is not set
 	// is: https://github.com/kubernetes/test-infra/issues.
@@ -241,6 +271,24 @@ type InRepoConfig struct {
 	// a given repo. All clusters that are allowed for the specific repo, its org or
 	// globally can be used.
 	AllowedClusters map[string][]string `json:"allowed_clusters,omitempty"`
+	// AllowedSecrets restricts the secrets that in-repo jobs and presets of a given
+	// repo may mount, reference in their environment, pull images with or use in
+	// their decoration config. Vault secrets are restricted by their path.
+	// All secrets that are allowed for the specific repo, its org or globally can
+	// be used. If no secrets are configured for the repo, its org or globally, any
+	// secret can be used.
+	// Secrets added by presets and pod templates of the central config are not
+	// restricted.
+	AllowedSecrets map[string][]string `json:"allowed_secrets,omitempty"`
+	// AllowedLabels restricts the label keys that in-repo jobs of a given repo may
+	// set, which also restricts the presets of the central config they can select.
+	// It follows the same rules as AllowedSecrets.
+	AllowedLabels map[string][]string `json:"allowed_labels,omitempty"`
+	// PeriodicsBranches maps the repos ('org/repo') whose in-repo config may define
+	// periodics to the branch the periodics are read from. In-repo periodics clone
+	// their repo at that branch, may not define event_triggers and are only run
+	// by a horologium started with --in-repo-periodics.
+	PeriodicsBranches map[string]string `json:"periodics_branches,omitempty"`
 }
 
 func SplitRepoName(fullRepoName string) (string, string, error) {
@@ -280,6 +328,48 @@ func (c *Config) InRepoConfigAllowsCluster(clusterName, identifier string) bool
 	return false
 }
 
+// InRepoConfigAllowsSecret determines if in-repo jobs of a given repository
+// may use a given secret.
+func (c *Config) InRepoConfigAllowsSecret(secretName, identifier string) bool {
+	return inRepoConfigAllows(c.InRepoConfig.AllowedSecrets, secretName, identifier)
+}
+
+// InRepoConfigAllowsLabel determines if in-repo jobs of a given repository
+// may set a given label.
+func (c *Config) InRepoConfigAllowsLabel(label, identifier string) bool {
+	return inRepoConfigAllows(c.InRepoConfig.AllowedLabels, label, identifier)
+}
+
+// inRepoConfigAllows returns whether the value is allowed for the repository,
+// its org or globally, or if nothing is configured for any of them.
+func inRepoConfigAllows(allowed map[string][]string, value, identifier string) bool {
+	configured := false
+	for _, key := range keysForIdentifier(identifier) {
+		values, ok := allowed[key]
+		if !ok {
+			continue
+		}
+		configured = true
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return !configured
+}
+
+// InRepoConfigPeriodicsBranch returns the branch the in-repo periodics of a
+// given repository are read from, if it may define periodics.
+func (c *Config) InRepoConfigPeriodicsBranch(identifier string) (string, bool) {
+	for _, key := range []string{identifier, gerritsource.TrimHTTPSPrefix(identifier)} {
+		if branch, ok := c.InRepoConfig.PeriodicsBranches[key]; ok {
+			return branch, true
+		}
+	}
+	return "", false
+}
+
 // keysForIdentifier returns all possible identifiers for given keys. In
 // consideration of Gerrit identifiers that contain `https://` prefix, it
 // returns keys contain both `https://foo/bar` and `foo/bar` for identifier
@@ -545,6 +635,22 @@ func (c *Config) GetPostsubmitsStatic(identifier string) []Postsubmit {
 	return res
 }
 
+// GetInRepoPeriodics will return the periodics that are versioned inside the given
+// repo on its branch in in_repo_config.periodics_branches. It returns no periodics
+// if the repo may not define any.
+func (c *Config) GetInRepoPeriodics(gc git.ClientFactory, identifier string, baseSHAGetter RefGetter) ([]Periodic, error) {
+	branch, ok := c.InRepoConfigPeriodicsBranch(identifier)
+	if !ok {
+		return nil, nil
+	}
+	prowYAML, err := c.getProwYAMLWithDefaults(gc, identifier, branch, baseSHAGetter)
+	if err != nil {
+		return nil, err
+	}
+
+	return prowYAML.Periodics, nil
+}
+
 // OwnersDirDenylist is used to configure regular expressions matching directories
 // to ignore when searching for OWNERS{,_ALIAS} files in a repo.
 type OwnersDirDenylist struct {
@@ -708,6 +814,40 @@ type Plank struct {
 	// limit. An example use case would be easier scheduling of jobs using boskos resources.
 	// This mechanism is separate from ProwJob's MaxConcurrency setting.
 	JobQueueCapacities map[string]int `json:"job_queue_capacities,omitempty"`
+
+	// PodTemplates maps names to pod settings that jobs can reference with
+	// pod_template instead of repeating them in every pod spec. The template
+	// of a job is expanded into its pod spec when the pod is created.
+	PodTemplates map[string]PodTemplate `json:"pod_templates,omitempty"`
+
+	// SkipProwJobMetrics disables the prowjobs and prowjob_state_transitions
+	// metrics plank periodically gathers from all ProwJobs. Set it when the
+	// ProwJob metrics are served by the exporter instead.
+	SkipProwJobMetrics bool `json:"skip_prowjob_metrics,omitempty"`
+
+	// PriorityClasses maps the names of priority classes jobs can set with
+	// priority_class to their priority. When max_concurrency or the capacity
+	// of a job queue is exhausted, triggered jobs of a higher priority are
+	// started before jobs of a lower priority, which delays the latter. Jobs
+	// without a priority class have priority 0.
+	PriorityClasses map[string]PriorityClass `json:"priority_classes,omitempty"`
+}
+
+// PriorityClass defines the priority of the jobs that belong to it.
+type PriorityClass struct {
+	// Priority of the jobs, higher values are more important.
+	Priority int `json:"priority"`
+	// Preempt allows the jobs to abort pending jobs of a lower priority when
+	// max_concurrency or the capacity of their job queue is exhausted, instead
+	// of waiting for them to finish. The aborted jobs have the failure reason
+	// "preempted".
+	Preempt bool `json:"preempt,omitempty"`
+}
+
+// GetPriorityClass returns the priority class of the given name. Unknown names
+// and the empty name return a priority class with priority 0.
+func (p Plank) GetPriorityClass(name string) PriorityClass {
+	return p.PriorityClasses[name]
 }
 
 type ProwJobDefaultEntry struct {
@@ -971,6 +1111,19 @@ func (g *Gerrit) DefaultAndValidate() error {
 		return fmt.Errorf("failed to compile regex for allowed presubmit triggers: %s", err.Error())
 	}
 	g.AllowedPresubmitTriggerRe = &CopyableRegexp{re}
+
+	if g.OrgReposConfig != nil {
+		for _, orgConfig := range *g.OrgReposConfig {
+			if orgConfig.Checks == nil {
+				continue
+			}
+			if orgConfig.Checks.Scheme == "" {
+				orgConfig.Checks.Scheme = DefaultGerritChecksScheme
+			} else if !gerritChecksSchemeRe.MatchString(orgConfig.Checks.Scheme) {
+				return fmt.Errorf("invalid checks scheme %q for %s: must only contain alphanumeric characters, '.', '_' and '-'", orgConfig.Checks.Scheme, orgConfig.Org)
+			}
+		}
+	}
 	return nil
 }
 
@@ -996,6 +1149,53 @@ type GerritOrgRepoConfig struct {
 	// Filters are used for limiting the scope of querying the Gerrit server.
 	// Currently supports branches and excluded branches.
 	Filters *GerritQueryFilter `json:"filters,omitempty"`
+	// Checks configures reporting the results of presubmit jobs as checks
+	// through the Gerrit Checks plugin, in addition to review messages.
+	Checks *GerritChecks `json:"checks,omitempty"`
+	// OverrideAllowedUsers are the Gerrit usernames or emails of the users
+	// allowed to force presubmits of changes to pass by commenting
+	// `/override job-name`. The command is disabled if empty.
+	OverrideAllowedUsers []string `json:"override_allowed_users,omitempty"`
+	// AggregateReports makes Crier report the results of all presubmit jobs
+	// of a patchset in a single review message once all of them finished,
+	// instead of one message per job or per voting label. Each label is voted
+	// on by the jobs that report to it, jobs that don't vote on a label are
+	// optional and only listed in the message.
+	AggregateReports bool `json:"aggregate_reports,omitempty"`
+}
+
+// DefaultGerritChecksScheme is the default scheme of the checkers jobs report
+// to.
+const DefaultGerritChecksScheme = "prow"
+
+var gerritChecksSchemeRe = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// GerritChecks configures reporting job results through the Gerrit Checks
+// plugin. Every presubmit job reports to its own checker, identified by the
+// UUID "<scheme>:<job name>". Checks that are re-run from the Gerrit UI
+// trigger the job again.
+type GerritChecks struct {
+	// Scheme is the scheme of the checkers of the jobs. Defaults to "prow".
+	Scheme string `json:"scheme,omitempty"`
+	// CreateCheckers makes Prow create the checkers of jobs that don't
+	// have one yet. This requires the "Administrate Checkers" capability,
+	// otherwise the checkers need to be created by a Gerrit admin.
+	CreateCheckers bool `json:"create_checkers,omitempty"`
+	// Blocking makes checkers created by Prow block submission of changes
+	// while a check is not successful. Only applies when creating checkers.
+	Blocking bool `json:"blocking,omitempty"`
+}
+
+// CheckerUUID returns the UUID of the checker of the job.
+func (gc *GerritChecks) CheckerUUID(job string) string {
+	return gc.Scheme + ":" + job
+}
+
+// JobForCheckerUUID returns the job of the checker, or false if the checker
+// doesn't belong to the scheme.
+func (gc *GerritChecks) JobForCheckerUUID(uuid string) (string, bool) {
+	job, ok := strings.CutPrefix(uuid, gc.Scheme+":")
+	return job, ok && job != ""
 }
 
 type GerritQueryFilter struct {
@@ -1022,6 +1222,65 @@ func (goc *GerritOrgRepoConfigs) AllRepos() map[string]map[string]*GerritQueryFi
 	return res
 }
 
+// ChecksFor returns the checks configuration of a repo on a Gerrit instance,
+// or nil if checks are not enabled for it.
+func (goc *GerritOrgRepoConfigs) ChecksFor(instance, repo string) *GerritChecks {
+	if goc == nil {
+		return nil
+	}
+	for _, orgConfig := range goc.configsFor(instance, repo) {
+		if orgConfig.Checks != nil {
+			return orgConfig.Checks
+		}
+	}
+	return nil
+}
+
+// AggregateReportsFor returns whether the results of the presubmit jobs of a
+// repo on a Gerrit instance are reported in one message per patchset.
+func (goc *GerritOrgRepoConfigs) AggregateReportsFor(instance, repo string) bool {
+	if goc == nil {
+		return false
+	}
+	for _, orgConfig := range goc.configsFor(instance, repo) {
+		if orgConfig.AggregateReports {
+			return true
+		}
+	}
+	return false
+}
+
+// OverrideAllowedUsers returns the users allowed to use `/override` on
+// changes of a repo on a Gerrit instance.
+func (goc *GerritOrgRepoConfigs) OverrideAllowedUsers(instance, repo string) sets.Set[string] {
+	users := sets.New[string]()
+	if goc == nil {
+		return users
+	}
+	for _, orgConfig := range goc.configsFor(instance, repo) {
+		users.Insert(orgConfig.OverrideAllowedUsers...)
+	}
+	return users
+}
+
+// configsFor returns the configs that include the repo of the Gerrit instance.
+func (goc *GerritOrgRepoConfigs) configsFor(instance, repo string) []GerritOrgRepoConfig {
+	var res []GerritOrgRepoConfig
+	instance = gerritsource.NormalizeOrg(instance)
+	for _, orgConfig := range *goc {
+		if gerritsource.NormalizeOrg(orgConfig.Org) != instance {
+			continue
+		}
+		for _, r := range orgConfig.Repos {
+			if r == repo {
+				res = append(res, orgConfig)
+				break
+			}
+		}
+	}
+	return res
+}
+
 func (goc *GerritOrgRepoConfigs) OptOutHelpRepos() map[string]sets.Set[string] {
 	var res map[string]sets.Set[string]
 	for _, orgConfig := range *goc {
@@ -1092,6 +1351,87 @@ type Sinker struct {
 	TerminatedPodTTL *metav1.Duration `json:"terminated_pod_ttl,omitempty"`
 	// ExcludeClusters are build clusters that don't want to be managed by sinker.
 	ExcludeClusters []string `json:"exclude_clusters,omitempty"`
+	// RetentionPolicies override the ages above for the ProwJobs they match,
+	// and for the pods of these ProwJobs. The first matching policy is used,
+	// fields it leaves unset fall back to the ages above.
+	RetentionPolicies []SinkerRetentionPolicy `json:"retention_policies,omitempty"`
+}
+
+// SinkerRetentionPolicy configures how long the ProwJobs it matches and their
+// pods are kept. All filters must match for a policy to match.
+type SinkerRetentionPolicy struct {
+	// OrgRepo matches against the "org" or "org/repo" of the ProwJob. If the
+	// job is a periodic, extra_refs[0] is used. If this field is omitted all
+	// jobs will match.
+	OrgRepo string `json:"repo,omitempty"`
+	// Job is a regular expression matched against the full job name.
+	// If this field is omitted all jobs will match.
+	Job string `json:"job,omitempty"`
+	// Labels must all be present with the same values on the ProwJob.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// MaxProwJobAge is how old a matching ProwJob can be before it is
+	// garbage-collected.
+	MaxProwJobAge *metav1.Duration `json:"max_prowjob_age,omitempty"`
+	// MaxPodAge is how old a Pod of a matching ProwJob can be before it is
+	// garbage-collected.
+	MaxPodAge *metav1.Duration `json:"max_pod_age,omitempty"`
+	// TerminatedPodTTL is how long a Pod of a matching ProwJob can live after
+	// termination before it is garbage collected.
+	TerminatedPodTTL *metav1.Duration `json:"terminated_pod_ttl,omitempty"`
+
+	jobRe *regexp.Regexp
+}
+
+// matches returns true iff all the filters of the policy match the ProwJob.
+func (p *SinkerRetentionPolicy) matches(pj *prowapi.ProwJob) bool {
+	var orgRepo string
+	if pj.Spec.Refs != nil {
+		orgRepo = pj.Spec.Refs.OrgRepoString()
+	} else if len(pj.Spec.ExtraRefs) > 0 {
+		orgRepo = pj.Spec.ExtraRefs[0].OrgRepoString()
+	}
+	if !matches(p.OrgRepo, "", orgRepo, pj.Spec.Cluster) {
+		return false
+	}
+	if p.jobRe != nil && !p.jobRe.MatchString(pj.Spec.Job) {
+		return false
+	}
+	for k, v := range p.Labels {
+		if pj.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// RetentionFor returns the retention of the given ProwJob. All ages of the
+// returned policy are set. A nil ProwJob gets the default retention.
+func (s *Sinker) RetentionFor(pj *prowapi.ProwJob) SinkerRetentionPolicy {
+	retention := SinkerRetentionPolicy{
+		MaxProwJobAge:    s.MaxProwJobAge,
+		MaxPodAge:        s.MaxPodAge,
+		TerminatedPodTTL: s.TerminatedPodTTL,
+	}
+	if pj == nil {
+		return retention
+	}
+	for _, policy := range s.RetentionPolicies {
+		if !policy.matches(pj) {
+			continue
+		}
+		if policy.MaxProwJobAge != nil {
+			retention.MaxProwJobAge = policy.MaxProwJobAge
+		}
+		if policy.MaxPodAge != nil {
+			retention.MaxPodAge = policy.MaxPodAge
+		}
+		if policy.TerminatedPodTTL != nil {
+			retention.TerminatedPodTTL = policy.TerminatedPodTTL
+		}
+		break
+	}
+	return retention
 }
 
 // LensConfig names a specific lens, and optionally provides some configuration for it.
@@ -1265,6 +1605,122 @@ type Deck struct {
 	// AllKnownStorageBuckets contains all storage buckets configured in all of the
 	// job configs.
 	AllKnownStorageBuckets sets.Set[string] `json:"-"`
+	// StatusComponents lists the Prow components whose health and metrics
+	// endpoints are polled by Deck to render the component status page.
+	StatusComponents []StatusComponent `json:"status_components,omitempty"`
+	// StatusUpdatePeriod specifies how often Deck will poll the status components.
+	// Defaults to 30s if any status components are configured.
+	StatusUpdatePeriod *metav1.Duration `json:"status_update_period,omitempty"`
+	// Tenants are scoped views of Deck served at /t/<name>/, so that one Prow
+	// can serve many teams that are only interested in their own jobs.
+	Tenants []DeckTenant `json:"tenants,omitempty"`
+}
+
+// DeckTenant is a scoped view of Deck that only shows matching ProwJobs.
+type DeckTenant struct {
+	// Name of the tenant, used in the path of its view.
+	Name string `json:"name"`
+	// Repos limits the view to the jobs of these orgs and repos (`org` or `org/repo`).
+	Repos []string `json:"repos,omitempty"`
+	// LabelSelector limits the view to the ProwJobs whose labels match, e.g. `team=a`.
+	LabelSelector string `json:"label_selector,omitempty"`
+	// RerunAuthConfig specifies who can rerun and abort the jobs of the tenant.
+	// It takes precedence over the default rerun auth configs.
+	RerunAuthConfig *prowapi.RerunAuthConfig `json:"rerun_auth_config,omitempty"`
+
+	selector labels.Selector
+}
+
+// Matches returns whether the ProwJob is part of the view of the tenant.
+func (t *DeckTenant) Matches(pj *prowapi.ProwJob) bool {
+	if len(t.Repos) > 0 {
+		var refs *prowapi.Refs
+		if pj.Spec.Refs != nil {
+			refs = pj.Spec.Refs
+		} else if len(pj.Spec.ExtraRefs) > 0 {
+			refs = &pj.Spec.ExtraRefs[0]
+		}
+		if refs == nil || !t.MatchesRepo(refs.Org, refs.Repo) {
+			return false
+		}
+	}
+	if t.LabelSelector == "" {
+		return true
+	}
+	selector := t.selector
+	if selector == nil {
+		var err error
+		if selector, err = labels.Parse(t.LabelSelector); err != nil {
+			return false
+		}
+	}
+	return selector.Matches(labels.Set(pj.Labels))
+}
+
+// MatchesRepo returns whether the repo is part of the view of the tenant.
+// Tenants without repos include all repos.
+func (t *DeckTenant) MatchesRepo(org, repo string) bool {
+	if len(t.Repos) == 0 {
+		return true
+	}
+	repos := sets.New[string](t.Repos...)
+	return repos.Has(org) || repos.Has(org+"/"+repo)
+}
+
+// MatchesOrg returns whether any repo of the org is part of the view of the
+// tenant.
+func (t *DeckTenant) MatchesOrg(org string) bool {
+	if len(t.Repos) == 0 {
+		return true
+	}
+	for _, repo := range t.Repos {
+		if repo == org || strings.HasPrefix(repo, org+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTenant returns the tenant with the given name or nil if there is none.
+func (d *Deck) GetTenant(name string) *DeckTenant {
+	for i := range d.Tenants {
+		if d.Tenants[i].Name == name {
+			return &d.Tenants[i]
+		}
+	}
+	return nil
+}
+
+// GetTenantRerunAuthConfig returns the rerun auth config of the first tenant
+// that has one and whose view includes the ProwJob, or nil if there is none.
+func (d *Deck) GetTenantRerunAuthConfig(pj *prowapi.ProwJob) *prowapi.RerunAuthConfig {
+	for i := range d.Tenants {
+		if d.Tenants[i].RerunAuthConfig != nil && d.Tenants[i].Matches(pj) {
+			return d.Tenants[i].RerunAuthConfig
+		}
+	}
+	return nil
+}
+
+// StatusComponent describes how Deck gathers the status of a Prow component.
+type StatusComponent struct {
+	// Name is the name of the component on the status page, e.g. "tide".
+	Name string `json:"name"`
+	// HealthURL is polled to determine whether the component is healthy, e.g.
+	// "http://tide:8081/healthz/ready". Any 2xx response is considered healthy.
+	HealthURL string `json:"health_url,omitempty"`
+	// MetricsURL is the Prometheus metrics endpoint of the component, e.g.
+	// "http://tide:9090/metrics". It is used to determine the uptime of the
+	// component as well as its last sync time and error rate.
+	MetricsURL string `json:"metrics_url,omitempty"`
+	// SyncMetric is the name of a metric that increases every time the component
+	// completes a sync, e.g. "tidesyncheartbeat". For histograms and summaries the
+	// number of observations is used.
+	SyncMetric string `json:"sync_metric,omitempty"`
+	// ErrorMetric is the name of a counter of errors encountered by the component,
+	// e.g. "sinker_pod_removal_errors". For histograms and summaries the number of
+	// observations is used.
+	ErrorMetric string `json:"error_metric,omitempty"`
 }
 
 // Validate performs validation and sanitization on the Deck object.
@@ -1279,6 +1735,50 @@ func (d *Deck) Validate() error {
 		}
 	}
 
+	names := sets.New[string]()
+	for i, component := range d.StatusComponents {
+		if component.Name == "" {
+			return fmt.Errorf("status_components[%d]: name must be set", i)
+		}
+		if names.Has(component.Name) {
+			return fmt.Errorf("status_components[%d]: name %q is used by another component", i, component.Name)
+		}
+		names.Insert(component.Name)
+		if component.HealthURL == "" && component.MetricsURL == "" {
+			return fmt.Errorf("status_components[%d]: at least one of health_url and metrics_url must be set", i)
+		}
+		if component.MetricsURL == "" && (component.SyncMetric != "" || component.ErrorMetric != "") {
+			return fmt.Errorf("status_components[%d]: sync_metric and error_metric require metrics_url to be set", i)
+		}
+	}
+
+	tenants := sets.New[string]()
+	for i := range d.Tenants {
+		tenant := &d.Tenants[i]
+		if errs := validation.IsDNS1123Label(tenant.Name); len(errs) > 0 {
+			return fmt.Errorf("tenants[%d]: name %q is invalid: %s", i, tenant.Name, strings.Join(errs, ", "))
+		}
+		if tenants.Has(tenant.Name) {
+			return fmt.Errorf("tenants[%d]: name %q is used by another tenant", i, tenant.Name)
+		}
+		tenants.Insert(tenant.Name)
+		if len(tenant.Repos) == 0 && tenant.LabelSelector == "" {
+			return fmt.Errorf("tenants[%d]: at least one of repos and label_selector must be set", i)
+		}
+		if tenant.LabelSelector != "" {
+			selector, err := labels.Parse(tenant.LabelSelector)
+			if err != nil {
+				return fmt.Errorf("tenants[%d]: invalid label_selector: %w", i, err)
+			}
+			tenant.selector = selector
+		}
+		if tenant.RerunAuthConfig != nil {
+			if err := tenant.RerunAuthConfig.Validate(); err != nil {
+				return fmt.Errorf("tenants[%d]: %w", i, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1518,6 +2018,9 @@ func (d *Deck) FinalizeDefaultRerunAuthConfigs() error {
 
 const (
 	defaultMaxOutstandingMessages = 10
+	// defaultMessageBusConsumerGroup is the default NATS queue group and
+	// Kafka consumer group of sub.
+	defaultMessageBusConsumerGroup = "prow-sub"
 )
 
 // PubsubSubscriptions maps GCP project IDs to a list of subscription IDs.
@@ -1533,6 +2036,43 @@ type PubSubTrigger struct {
 	AllowedClusters []string `json:"allowed_clusters"`
 	// MaxOutstandingMessages is the max number of messaged being processed, default is 10.
 	MaxOutstandingMessages int `json:"max_outstanding_messages"`
+	// ReplyTopic is a topic in Project that the completion of ProwJobs
+	// triggered by messages of the subscriptions is published to, together
+	// with the message and correlation IDs of the triggering message.
+	// Messages that set their own prow.k8s.io/pubsub.project and
+	// prow.k8s.io/pubsub.topic annotations keep reporting there instead.
+	ReplyTopic string `json:"reply_topic,omitempty"`
+	// ReplySchemaVersion is the schema version of the messages published to
+	// the ReplyTopic. Version 2 adds the labels, annotations and artifacts
+	// manifest of the job. Defaults to 1.
+	ReplySchemaVersion int `json:"reply_schema_version,omitempty"`
+}
+
+// NATSTrigger contains the configuration for listening to NATS subjects.
+// The event type is read from the `prow.k8s.io/pubsub.EventType` message
+// header.
+type NATSTrigger struct {
+	// URL is the URL of the NATS server, e.g. nats://nats.nats:4222.
+	URL      string   `json:"url"`
+	Subjects []string `json:"subjects"`
+	// QueueGroup is the queue group the subjects are subscribed with, so
+	// that every message is only handled by one replica. Defaults to
+	// "prow-sub".
+	QueueGroup      string   `json:"queue_group,omitempty"`
+	AllowedClusters []string `json:"allowed_clusters"`
+}
+
+// KafkaTrigger contains the configuration for listening to Kafka topics.
+// The event type is read from the `prow.k8s.io/pubsub.EventType` message
+// header.
+type KafkaTrigger struct {
+	// Brokers are the addresses of the Kafka brokers, e.g. kafka:9092.
+	Brokers []string `json:"brokers"`
+	Topics  []string `json:"topics"`
+	// GroupID is the consumer group the topics are consumed with, so that
+	// every message is only handled by one replica. Defaults to "prow-sub".
+	GroupID         string   `json:"group_id,omitempty"`
+	AllowedClusters []string `json:"allowed_clusters"`
 }
 
 // GitHubOptions allows users to control how prow applications display GitHub website links.
@@ -1545,6 +2085,117 @@ type GitHubOptions struct {
 	// LinkURL is the url representation of LinkURLFromConfig. This variable should be used
 	// in all places internally.
 	LinkURL *url.URL `json:"-"`
+
+	// Throttle configures client-side throttling of GitHub API requests for
+	// components that support it. Components read it on startup and fall
+	// back to their throttling flags if it has no policy for them, or for
+	// orgs if their policy only throttles individual orgs.
+	Throttle *GitHubThrottle `json:"throttle,omitempty"`
+}
+
+// GitHubThrottle configures client-side throttling of GitHub API requests
+// per component.
+type GitHubThrottle struct {
+	// Default is the policy for components without an entry in Components.
+	Default *github.ThrottlePolicy `json:"default,omitempty"`
+	// Components maps component names to their throttle policy. Supported
+	// components are hook, crier, tide-sync and tide-status.
+	Components map[string]github.ThrottlePolicy `json:"components,omitempty"`
+}
+
+// ThrottlePolicy returns the throttle policy for the component, or nil if
+// none is configured.
+func (o *GitHubOptions) ThrottlePolicy(component string) *github.ThrottlePolicy {
+	if o.Throttle == nil {
+		return nil
+	}
+	if policy, ok := o.Throttle.Components[component]; ok {
+		return &policy
+	}
+	return o.Throttle.Default
+}
+
+func (t *GitHubThrottle) validate() error {
+	if t == nil {
+		return nil
+	}
+	var errs []error
+	if t.Default != nil {
+		if err := t.Default.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("github.throttle.default: %w", err))
+		}
+	}
+	for component, policy := range t.Components {
+		if err := policy.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("github.throttle.components[%s]: %w", component, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// GitLab configures the orgs that hook serves from GitLab webhooks. Plugins
+// operate on the merge requests of these orgs through the GitLab API.
+type GitLab struct {
+	// Orgs lists the full paths of the GitLab groups that are served from
+	// GitLab. All other orgs are served from GitHub.
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+// IsGitLabOrg returns whether the org is served from GitLab.
+func (g *GitLab) IsGitLabOrg(org string) bool {
+	if g == nil {
+		return false
+	}
+	for _, o := range g.Orgs {
+		if strings.EqualFold(o, org) {
+			return true
+		}
+	}
+	return false
+}
+
+// SCMProvider configures the orgs that hook serves from the webhooks of a
+// source code management provider. Plugins and crier operate on the pull
+// requests of these orgs through the API of the provider.
+type SCMProvider struct {
+	// Orgs lists the orgs that are served from the provider. For Bitbucket
+	// Server these are the keys of projects.
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+// SCMProviderOf returns the name of the provider that serves the org, or the
+// empty string if the org is served from GitHub. The orgs of the gitlab
+// section are served from the "gitlab" provider.
+func (c *ProwConfig) SCMProviderOf(org string) string {
+	if c.GitLab.IsGitLabOrg(org) {
+		return "gitlab"
+	}
+	for name, provider := range c.SCM {
+		for _, o := range provider.Orgs {
+			if strings.EqualFold(o, org) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func (c *ProwConfig) validateSCM() error {
+	served := map[string]string{}
+	if c.GitLab != nil {
+		for _, org := range c.GitLab.Orgs {
+			served[strings.ToLower(org)] = "gitlab"
+		}
+	}
+	for _, name := range sets.List(sets.KeySet(c.SCM)) {
+		for _, org := range c.SCM[name].Orgs {
+			if other, ok := served[strings.ToLower(org)]; ok {
+				return fmt.Errorf("org %q is served from both %s and %s", org, other, name)
+			}
+			served[strings.ToLower(org)] = name
+		}
+	}
+	return nil
 }
 
 // ManagedWebhookInfo contains metadata about the repo/org which is onboarded.
@@ -1561,6 +2212,16 @@ type ManagedWebhooks struct {
 	// will be left pending.
 	AutoAcceptInvitation bool                          `json:"auto_accept_invitation"`
 	OrgRepoConfig        map[string]ManagedWebhookInfo `json:"org_repo_config,omitempty"`
+	// RotationInterval is the maximum age of the token of an org or repo. When
+	// the hmac tool runs continuously, it rotates older tokens in addition to
+	// the rotations requested with token_created_after. Tokens are not rotated
+	// on a schedule if unset.
+	RotationInterval *metav1.Duration `json:"rotation_interval,omitempty"`
+	// RotationGracePeriod is how long the previous token of an org or repo
+	// stays valid after a rotation, so that hook accepts webhooks signed with
+	// either token while GitHub switches over to the new one. Previous tokens
+	// are removed right after the webhooks are updated if unset.
+	RotationGracePeriod *metav1.Duration `json:"rotation_grace_period,omitempty"`
 }
 
 // SlackReporter represents the config for the Slack reporter. The channel can be overridden
@@ -1615,7 +2276,7 @@ func (cfg SlackReporterConfigs) HasGlobalConfig() bool {
 func (cfg *SlackReporter) DefaultAndValidate() error {
 	// Default ReportTemplate.
 	if cfg.ReportTemplate == "" {
-		cfg.ReportTemplate = `Job {{.Spec.Job}} of type {{.Spec.Type}} ended with state {{.Status.State}}. <{{.Status.URL}}|View logs>`
+		cfg.ReportTemplate = `Job {{.Spec.Job}} of type {{.Spec.Type}} ended with state {{.Status.State}}{{with .Status.FailureReason}} ({{.}}){{end}}. <{{.Status.URL}}|View logs>`
 	}
 
 	if cfg.Channel == "" {
@@ -1883,18 +2544,32 @@ func loadConfig(prowConfig, jobConfig string, additionalProwConfigDirs []string,
 			nc.PubSubTriggers[i].MaxOutstandingMessages = defaultMaxOutstandingMessages
 		}
 	}
+	for i, trigger := range nc.NATSTriggers {
+		if trigger.QueueGroup == "" {
+			nc.NATSTriggers[i].QueueGroup = defaultMessageBusConsumerGroup
+		}
+	}
+	for i, trigger := range nc.KafkaTriggers {
+		if trigger.GroupID == "" {
+			nc.KafkaTriggers[i].GroupID = defaultMessageBusConsumerGroup
+		}
+	}
 
 	// TODO(krzyzacy): temporary allow empty jobconfig
 	//                 also temporary allow job config in prow config.
-	if jobConfig == "" {
-		return &nc, nil
+	if jobConfig != "" {
+		jc, err := ReadJobConfig(jobConfig, yamlOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if err := nc.mergeJobConfig(jc); err != nil {
+			return nil, err
+		}
 	}
 
-	jc, err := ReadJobConfig(jobConfig, yamlOpts...)
-	if err != nil {
-		return nil, err
-	}
-	if err := nc.mergeJobConfig(jc); err != nil {
+	// The extensions are loaded last, so that they are checked against all
+	// other jobs.
+	if err := nc.loadExtensions(yamlOpts...); err != nil {
 		return nil, err
 	}
 
@@ -1944,6 +2619,9 @@ func yamlToConfig(path string, nc interface{}, opts ...yaml.JSONOpt) error {
 	for i := range jc.Periodics {
 		fix(&jc.Periodics[i])
 	}
+	if err := jc.expandMatrices(); err != nil {
+		return fmt.Errorf("error expanding matrix jobs in %s: %w", path, err)
+	}
 	return nil
 }
 
@@ -2090,6 +2768,9 @@ func defaultPresubmits(presubmits []Presubmit, additionalPresets []Preset, c *Co
 		if err := resolvePresets(ps.Name, ps.Labels, ps.Spec, append(c.Presets, additionalPresets...)); err != nil {
 			errs = append(errs, err)
 		}
+		if err := validatePodTemplate(ps.Name, ps.PodTemplate, ps.Spec, c.Plank.PodTemplates); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	if err := SetPresubmitRegexes(presubmits); err != nil {
 		errs = append(errs, fmt.Errorf("could not set regex: %w", err))
@@ -2108,6 +2789,9 @@ func defaultPostsubmits(postsubmits []Postsubmit, additionalPresets []Preset, c
 		if err := resolvePresets(ps.Name, ps.Labels, ps.Spec, append(c.Presets, additionalPresets...)); err != nil {
 			errs = append(errs, err)
 		}
+		if err := validatePodTemplate(ps.Name, ps.PodTemplate, ps.Spec, c.Plank.PodTemplates); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	if err := SetPostsubmitRegexes(postsubmits); err != nil {
 		errs = append(errs, fmt.Errorf("could not set regex: %w", err))
@@ -2117,10 +2801,17 @@ func defaultPostsubmits(postsubmits []Postsubmit, additionalPresets []Preset, c
 
 // DefaultPeriodic defaults (mutates) a single Periodic.
 func (c *Config) DefaultPeriodic(periodic *Periodic) error {
+	return c.defaultPeriodic(periodic, nil)
+}
+
+func (c *Config) defaultPeriodic(periodic *Periodic, additionalPresets []Preset) error {
 	c.defaultPeriodicFields(periodic)
 	setPeriodicDecorationDefaults(c, periodic)
 	setPeriodicProwJobDefaults(c, periodic)
-	return resolvePresets(periodic.Name, periodic.Labels, periodic.Spec, c.Presets)
+	if err := resolvePresets(periodic.Name, periodic.Labels, periodic.Spec, append(c.Presets, additionalPresets...)); err != nil {
+		return err
+	}
+	return validatePodTemplate(periodic.Name, periodic.PodTemplate, periodic.Spec, c.Plank.PodTemplates)
 }
 
 // defaultPeriodics defaults c.Periodics.
@@ -2159,6 +2850,38 @@ func (c *Config) finalizeJobConfig() error {
 	return nil
 }
 
+// validateMessageBusTriggers validates the Pub/Sub, NATS and Kafka triggers of sub.
+func (c *Config) validateMessageBusTriggers() error {
+	var errs []error
+	for i, trigger := range c.PubSubTriggers {
+		if trigger.ReplySchemaVersion < 0 || trigger.ReplySchemaVersion > 2 {
+			errs = append(errs, fmt.Errorf("pubsub_triggers[%d]: reply_schema_version must be 1 or 2, got %d", i, trigger.ReplySchemaVersion))
+		}
+		if trigger.ReplySchemaVersion != 0 && trigger.ReplyTopic == "" {
+			errs = append(errs, fmt.Errorf("pubsub_triggers[%d]: reply_schema_version requires reply_topic", i))
+		}
+	}
+	for i, trigger := range c.NATSTriggers {
+		if trigger.URL == "" {
+			errs = append(errs, fmt.Errorf("nats_triggers[%d]: url must be set", i))
+		} else if _, err := url.Parse(trigger.URL); err != nil {
+			errs = append(errs, fmt.Errorf("nats_triggers[%d]: invalid url: %w", i, err))
+		}
+		if len(trigger.Subjects) == 0 {
+			errs = append(errs, fmt.Errorf("nats_triggers[%d]: at least one subject must be set", i))
+		}
+	}
+	for i, trigger := range c.KafkaTriggers {
+		if len(trigger.Brokers) == 0 {
+			errs = append(errs, fmt.Errorf("kafka_triggers[%d]: at least one broker must be set", i))
+		}
+		if len(trigger.Topics) == 0 {
+			errs = append(errs, fmt.Errorf("kafka_triggers[%d]: at least one topic must be set", i))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // validateComponentConfig validates the various infrastructure components' configurations.
 func (c *Config) validateComponentConfig() error {
 	for k, v := range c.Plank.JobURLPrefixConfig {
@@ -2172,6 +2895,14 @@ func (c *Config) validateComponentConfig() error {
 		}
 	}
 
+	if err := c.GitHubOptions.Throttle.validate(); err != nil {
+		return err
+	}
+
+	if err := c.validateSCM(); err != nil {
+		return err
+	}
+
 	var validationErrs []error
 	if c.ManagedWebhooks.OrgRepoConfig != nil {
 		for repoName, repoValue := range c.ManagedWebhooks.OrgRepoConfig {
@@ -2183,6 +2914,19 @@ func (c *Config) validateComponentConfig() error {
 			return utilerrors.NewAggregate(validationErrs)
 		}
 	}
+	if c.ManagedWebhooks.RotationInterval != nil && c.ManagedWebhooks.RotationInterval.Duration <= 0 {
+		return errors.New("managed_webhooks.rotation_interval must be positive")
+	}
+	if c.ManagedWebhooks.RotationGracePeriod != nil && c.ManagedWebhooks.RotationGracePeriod.Duration < 0 {
+		return errors.New("managed_webhooks.rotation_grace_period must not be negative")
+	}
+	if c.ManagedWebhooks.RotationInterval != nil && c.ManagedWebhooks.RotationGracePeriod != nil && c.ManagedWebhooks.RotationGracePeriod.Duration >= c.ManagedWebhooks.RotationInterval.Duration {
+		return errors.New("managed_webhooks.rotation_grace_period must be shorter than managed_webhooks.rotation_interval")
+	}
+
+	if err := c.validateMessageBusTriggers(); err != nil {
+		return err
+	}
 
 	if c.SlackReporterConfigs != nil {
 		for k, config := range c.SlackReporterConfigs {
@@ -2215,6 +2959,7 @@ func (c *Config) validateComponentConfig() error {
 var (
 	jobNameRegex        = regexp.MustCompile(`^[A-Za-z0-9-._]+$`)
 	jobNameRegexJenkins = regexp.MustCompile(`^[A-Za-z0-9-._]([A-Za-z0-9-._/]*[A-Za-z0-9-_])?$`)
+	webhookNameRegex    = regexp.MustCompile(`^[A-Za-z0-9-._]+$`)
 )
 
 func validateJobName(v JobBase) error {
@@ -2242,6 +2987,9 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 	if err := validateAgent(v, c.PodNamespace); err != nil {
 		return err
 	}
+	if err := v.Retry.Validate(); err != nil {
+		return fmt.Errorf("invalid retry config: %w", err)
+	}
 	if err := validatePodSpec(jobType, v.Spec, v.DecorationConfig); err != nil {
 		return err
 	}
@@ -2264,6 +3012,9 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 	if err := validateJobQueueName(v.JobQueueName, validJobQueueNames); err != nil {
 		return err
 	}
+	if _, ok := c.Plank.PriorityClasses[v.PriorityClass]; v.PriorityClass != "" && !ok {
+		return fmt.Errorf("invalid priority class %s", v.PriorityClass)
+	}
 	if v.Spec == nil || len(v.Spec.Containers) == 0 {
 		return nil // jenkins jobs have no spec.
 	}
@@ -2273,6 +3024,9 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 	if err := v.UtilityConfig.Validate(); err != nil {
 		return err
 	}
+	if v.DecorationConfig != nil && len(v.DecorationConfig.Steps) > 0 && len(v.Spec.Containers) > 1 {
+		return errors.New("decoration steps are not supported for jobs with multiple containers")
+	}
 	for i := range v.Spec.Containers {
 		if err := validateDecoration(v.Spec.Containers[i], v.DecorationConfig); err != nil {
 			return err
@@ -2285,6 +3039,7 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 func (c Config) validatePresubmits(presubmits []Presubmit) error {
 	validPresubmits := map[string][]Presubmit{}
 	duplicatePresubmits := sets.New[string]()
+	dependencies := map[string][]string{}
 	var errs []error
 	for _, ps := range presubmits {
 		// Checking that no duplicate job in prow config exists on the same branch.
@@ -2311,11 +3066,18 @@ func (c Config) validatePresubmits(presubmits []Presubmit) error {
 		if err := validateReporting(ps.JobBase, ps.Reporter); err != nil {
 			errs = append(errs, fmt.Errorf("invalid presubmit job %s: %w", ps.Name, err))
 		}
+		if len(ps.RunAfterSuccess) > 0 && ps.RegexpChangeMatcher.CouldRun() {
+			errs = append(errs, fmt.Errorf("invalid presubmit job %s: run_after_success can not be combined with run_if_changed or skip_if_only_changed", ps.Name))
+		}
 		validPresubmits[ps.Name] = append(validPresubmits[ps.Name], ps)
+		dependencies[ps.Name] = append(dependencies[ps.Name], ps.RunAfterSuccess...)
 	}
 	if duplicatePresubmits.Len() > 0 {
 		errs = append(errs, fmt.Errorf("duplicated presubmit jobs (consider both inrepo and central config): %v", sortStringSlice(duplicatePresubmits.UnsortedList())))
 	}
+	if err := validateRunAfterSuccess(prowapi.PresubmitJob, dependencies); err != nil {
+		errs = append(errs, err)
+	}
 
 	return utilerrors.NewAggregate(errs)
 }
@@ -2347,6 +3109,7 @@ func ValidateRefs(repo string, jobBase JobBase) error {
 func (c Config) validatePostsubmits(postsubmits []Postsubmit) error {
 	validPostsubmits := map[string][]Postsubmit{}
 	duplicatePostsubmits := sets.New[string]()
+	dependencies := map[string][]string{}
 
 	var errs []error
 	for _, ps := range postsubmits {
@@ -2374,11 +3137,18 @@ func (c Config) validatePostsubmits(postsubmits []Postsubmit) error {
 		if err := validateReporting(ps.JobBase, ps.Reporter); err != nil {
 			errs = append(errs, fmt.Errorf("invalid postsubmit job %s: %w", ps.Name, err))
 		}
+		if len(ps.RunAfterSuccess) > 0 && ps.RegexpChangeMatcher.CouldRun() {
+			errs = append(errs, fmt.Errorf("invalid postsubmit job %s: run_after_success can not be combined with run_if_changed or skip_if_only_changed", ps.Name))
+		}
 		validPostsubmits[ps.Name] = append(validPostsubmits[ps.Name], ps)
+		dependencies[ps.Name] = append(dependencies[ps.Name], ps.RunAfterSuccess...)
 	}
 	if duplicatePostsubmits.Len() > 0 {
 		errs = append(errs, fmt.Errorf("duplicated postsubmit jobs (consider both inrepo and central config): %v", sortStringSlice(duplicatePostsubmits.UnsortedList())))
 	}
+	if err := validateRunAfterSuccess(prowapi.PostsubmitJob, dependencies); err != nil {
+		errs = append(errs, err)
+	}
 
 	return utilerrors.NewAggregate(errs)
 }
@@ -2414,8 +3184,32 @@ func (c Config) validatePeriodics(periodics []Periodic) error {
 			errs = append(errs, fmt.Errorf("cron, interval, and minimum_interval are mutually exclusive in periodic %s", p.Name))
 			continue
 		}
+		if err := validatePeriodicEventTriggers(p.EventTriggers); err != nil {
+			errs = append(errs, fmt.Errorf("invalid event_triggers in periodic %s: %w", p.Name, err))
+		}
+		if p.RunMissed != "" || p.Jitter != "" {
+			if p.Cron == "" {
+				errs = append(errs, fmt.Errorf("run_missed and jitter require cron to be set in periodic %s", p.Name))
+			}
+			switch p.RunMissed {
+			case "", RunMissedNone, RunMissedLatest, RunMissedAll:
+			default:
+				errs = append(errs, fmt.Errorf("invalid run_missed %q in periodic %s, must be one of %s, %s or %s", p.RunMissed, p.Name, RunMissedNone, RunMissedLatest, RunMissedAll))
+			}
+		}
+		if p.Jitter != "" {
+			d, err := time.ParseDuration(p.Jitter)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cannot parse jitter for %s: %w", p.Name, err))
+			} else if d < 0 {
+				errs = append(errs, fmt.Errorf("jitter of %s must not be negative", p.Name))
+			}
+			periodics[j].jitter = d
+		}
 		if seen == 0 {
-			errs = append(errs, fmt.Errorf("at least one of cron, interval, or minimum_interval must be set in periodic %s", p.Name))
+			if len(p.EventTriggers) == 0 {
+				errs = append(errs, fmt.Errorf("at least one of cron, interval, minimum_interval, or event_triggers must be set in periodic %s", p.Name))
+			}
 			continue
 		}
 
@@ -2499,6 +3293,18 @@ func parseProwConfig(c *Config) error {
 		return fmt.Errorf("validating gerrit config: %w", err)
 	}
 
+	if err := c.Extensions.defaultAndValidate(); err != nil {
+		return fmt.Errorf("validating extensions config: %w", err)
+	}
+
+	if err := ValidateJobPolicies("job_policies", c.JobPolicies); err != nil {
+		return fmt.Errorf("validating job_policies config: %w", err)
+	}
+
+	if err := c.LifecycleManager.DefaultAndValidate(); err != nil {
+		return fmt.Errorf("validating lifecycle_manager config: %w", err)
+	}
+
 	if c.Tide.Gerrit != nil {
 		if c.Tide.Gerrit.RateLimit == 0 {
 			c.Tide.Gerrit.RateLimit = 5
@@ -2562,10 +3368,38 @@ func parseProwConfig(c *Config) error {
 		c.Deck.ExternalAgentLogs[i].Selector = s
 	}
 
+	if ch := c.Scheduler.ClusterHealth; ch != nil {
+		if !c.Scheduler.Enabled {
+			logrus.Warn("scheduler.cluster_health has no effect unless scheduler.enabled is true")
+		}
+		if ch.ProbeInterval == nil {
+			ch.ProbeInterval = &metav1.Duration{Duration: time.Minute}
+		} else if ch.ProbeInterval.Duration <= 0 {
+			return errors.New("scheduler.cluster_health.probe_interval must be positive")
+		}
+		if ch.MinReadyNodes == 0 {
+			ch.MinReadyNodes = 1
+		} else if ch.MinReadyNodes < 0 {
+			return errors.New("scheduler.cluster_health.min_ready_nodes must not be negative")
+		}
+		if ch.MinQuotaHeadroom < 0 || ch.MinQuotaHeadroom > 100 {
+			return errors.New("scheduler.cluster_health.min_quota_headroom must be a percentage between 0 and 100")
+		}
+		if ch.FailureThreshold == 0 {
+			ch.FailureThreshold = 3
+		} else if ch.FailureThreshold < 0 {
+			return errors.New("scheduler.cluster_health.failure_threshold must not be negative")
+		}
+	}
+
 	if c.Deck.TideUpdatePeriod == nil {
 		c.Deck.TideUpdatePeriod = &metav1.Duration{Duration: time.Second * 10}
 	}
 
+	if len(c.Deck.StatusComponents) > 0 && c.Deck.StatusUpdatePeriod == nil {
+		c.Deck.StatusUpdatePeriod = &metav1.Duration{Duration: time.Second * 30}
+	}
+
 	if c.Deck.Spyglass.SizeLimit == 0 {
 		c.Deck.Spyglass.SizeLimit = 100e6
 	} else if c.Deck.Spyglass.SizeLimit <= 0 {
@@ -2669,6 +3503,17 @@ func parseProwConfig(c *Config) error {
 		c.Sinker.TerminatedPodTTL = &metav1.Duration{Duration: c.Sinker.MaxPodAge.Duration}
 	}
 
+	for i, policy := range c.Sinker.RetentionPolicies {
+		if policy.Job == "" {
+			continue
+		}
+		re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", policy.Job))
+		if err != nil {
+			return fmt.Errorf("invalid job regexp %q in sinker.retention_policies[%d]: %w", policy.Job, i, err)
+		}
+		c.Sinker.RetentionPolicies[i].jobRe = re
+	}
+
 	if c.Tide.SyncPeriod == nil {
 		c.Tide.SyncPeriod = &metav1.Duration{Duration: time.Minute}
 	}
@@ -2748,6 +3593,10 @@ func parseProwConfig(c *Config) error {
 		}
 	}
 
+	if err := c.Tide.MergeWhenGreen.Validate(); err != nil {
+		return fmt.Errorf("tide merge_when_green is invalid: %w", err)
+	}
+
 	if c.ProwJobNamespace == "" {
 		c.ProwJobNamespace = "default"
 	}
@@ -2873,27 +3722,32 @@ func validateJobQueueName(name string, validNames sets.Set[string]) error {
 
 func validateAgent(v JobBase, podNamespace string) error {
 	k := string(prowapi.KubernetesAgent)
+	kj := string(prowapi.KubernetesJobAgent)
 	j := string(prowapi.JenkinsAgent)
 	p := string(prowapi.TektonAgent)
-	agents := sets.New[string](k, j, p)
+	agents := sets.New[string](k, kj, j, p)
+	// Both plank and the kubernetes-job controller run the pod spec of the job.
+	podAgents := sets.New[string](k, kj)
 	agent := v.Agent
 	switch {
 	case !agents.Has(agent):
 		logrus.Warningf("agent %s is unknown and cannot be validated: use at your own risk", agent)
 		return nil
-	case v.Spec != nil && agent != k:
-		return fmt.Errorf("job specs require agent: %s (found %q)", k, agent)
-	case agent == k && v.Spec == nil:
+	case v.Spec != nil && !podAgents.Has(agent):
+		return fmt.Errorf("job specs require agent: %s or %s (found %q)", k, kj, agent)
+	case podAgents.Has(agent) && v.Spec == nil:
 		return errors.New("kubernetes jobs require a spec")
 	case v.HasPipelineRunSpec() && agent != p:
 		return fmt.Errorf("job pipeline_run_spec require agent: %s (found %q)", p, agent)
 	case agent == p && !v.HasPipelineRunSpec():
 		return fmt.Errorf("agent: %s jobs require a pipeline_run_spec", p)
-	case v.DecorationConfig != nil && agent != k:
+	case v.DecorationConfig != nil && !podAgents.Has(agent):
 		// TODO(fejta): only source decoration supported...
-		return fmt.Errorf("decoration requires agent: %s (found %q)", k, agent)
+		return fmt.Errorf("decoration requires agent: %s or %s (found %q)", k, kj, agent)
 	case v.ErrorOnEviction && agent != k:
 		return fmt.Errorf("error_on_eviction only applies to agent: %s (found %q)", k, agent)
+	case v.Retry != nil && !podAgents.Has(agent):
+		return fmt.Errorf("retry only applies to agents: %s and %s (found %q)", k, kj, agent)
 	case v.Namespace == nil || *v.Namespace == "":
 		return fmt.Errorf("failed to default namespace")
 	case *v.Namespace != podNamespace && agent != p:
@@ -2913,6 +3767,12 @@ func validateDecoration(container v1.Container, config *prowapi.DecorationConfig
 	}
 	var args []string
 	args = append(append(args, container.Command...), container.Args...)
+	if len(config.Steps) > 0 {
+		if len(args) > 0 {
+			return errors.New("decorated job containers must not specify command or args when steps are configured")
+		}
+		return nil
+	}
 	if len(args) == 0 || args[0] == "" {
 		return errors.New("decorated job containers must specify command and/or args")
 	}
@@ -2931,6 +3791,37 @@ func resolvePresets(name string, labels map[string]string, spec *v1.PodSpec, pre
 	return nil
 }
 
+func validatePodTemplate(name, template string, spec *v1.PodSpec, templates map[string]PodTemplate) error {
+	if template == "" {
+		return nil
+	}
+	if _, ok := templates[template]; !ok {
+		return fmt.Errorf("job %s references unknown pod template %q", name, template)
+	}
+	if spec == nil {
+		return fmt.Errorf("job %s references pod template %q but has no pod spec", name, template)
+	}
+	return nil
+}
+
+// ExpandPodTemplate returns a copy of the ProwJob whose pod spec has the
+// pod template referenced by the job merged into it.
+func (p Plank) ExpandPodTemplate(pj prowapi.ProwJob) (prowapi.ProwJob, error) {
+	if pj.Spec.PodTemplate == "" {
+		return pj, nil
+	}
+	template, ok := p.PodTemplates[pj.Spec.PodTemplate]
+	if !ok {
+		return pj, fmt.Errorf("unknown pod template %q", pj.Spec.PodTemplate)
+	}
+	if pj.Spec.PodSpec == nil {
+		return pj, fmt.Errorf("pod template %q is referenced by a job without a pod spec", pj.Spec.PodTemplate)
+	}
+	pj.Spec.PodSpec = pj.Spec.PodSpec.DeepCopy()
+	mergePodTemplate(template, pj.Spec.PodSpec)
+	return pj, nil
+}
+
 var ReProwExtraRef = regexp.MustCompile(`PROW_EXTRA_GIT_REF_(\d+)`)
 
 func ValidatePipelineRunSpec(jobType prowapi.ProwJobType, extraRefs []prowapi.Refs, spec *pipelinev1.PipelineRunSpec) error {
@@ -3073,6 +3964,9 @@ func validateAlwaysRun(job Postsubmit) error {
 	if job.RunIfChanged != "" && job.SkipIfOnlyChanged != "" {
 		return fmt.Errorf("job %s declares run_if_changed and skip_if_only_changed, which are mutually exclusive", job.Name)
 	}
+	if job.RunIfChangedContent != "" {
+		return fmt.Errorf("job %s declares run_if_changed_content, which is only supported for presubmits", job.Name)
+	}
 	return nil
 }
 
@@ -3088,6 +3982,9 @@ func validateTriggering(job Presubmit) error {
 	if job.RunIfChanged != "" && job.SkipIfOnlyChanged != "" {
 		return fmt.Errorf("job %s declares run_if_changed and skip_if_only_changed, which are mutually exclusive", job.Name)
 	}
+	if job.RunIfChangedContent != "" && job.RunIfChanged == "" {
+		return fmt.Errorf("job %s declares run_if_changed_content without run_if_changed", job.Name)
+	}
 
 	if (job.Trigger != "" && job.RerunCommand == "") || (job.Trigger == "" && job.RerunCommand != "") {
 		return fmt.Errorf("either both of job.Trigger and job.RerunCommand must be set, wasnt the case for job %q", job.Name)
@@ -3096,6 +3993,86 @@ func validateTriggering(job Presubmit) error {
 	return nil
 }
 
+// validatePeriodicEventTriggers validates the event triggers of a periodic.
+func validatePeriodicEventTriggers(triggers []PeriodicEventTrigger) error {
+	var errs []error
+	for i, trigger := range triggers {
+		switch {
+		case trigger.PubSub != nil && trigger.Webhook != nil:
+			errs = append(errs, fmt.Errorf("trigger %d: pubsub and webhook are mutually exclusive", i))
+		case trigger.PubSub != nil:
+			if trigger.PubSub.Project == "" || trigger.PubSub.Subscription == "" {
+				errs = append(errs, fmt.Errorf("trigger %d: pubsub requires project and subscription", i))
+			}
+		case trigger.Webhook != nil:
+			if !webhookNameRegex.MatchString(trigger.Webhook.Name) {
+				errs = append(errs, fmt.Errorf("trigger %d: webhook name %q must match regex %q", i, trigger.Webhook.Name, webhookNameRegex.String()))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("trigger %d: one of pubsub or webhook must be set", i))
+		}
+		for _, name := range sets.List(sets.KeySet(trigger.Env)) {
+			if msgs := validation.IsEnvVarName(name); len(msgs) > 0 {
+				errs = append(errs, fmt.Errorf("trigger %d: invalid env var name %q: %s", i, name, strings.Join(msgs, ", ")))
+			}
+			if trigger.Env[name] == "" {
+				errs = append(errs, fmt.Errorf("trigger %d: env var %s does not reference a payload field", i, name))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateRunAfterSuccess validates the run_after_success dependencies of the
+// jobs of one type in one repo, given as a map of job names to the jobs they
+// run after. All dependencies have to exist and must not form a cycle.
+func validateRunAfterSuccess(jobType prowapi.ProwJobType, dependencies map[string][]string) error {
+	var errs []error
+	names := sets.List(sets.KeySet(dependencies))
+	for _, name := range names {
+		for _, dependency := range dependencies[name] {
+			if dependency == name {
+				errs = append(errs, fmt.Errorf("%s job %s can not run after its own success", jobType, name))
+			} else if _, exists := dependencies[dependency]; !exists {
+				errs = append(errs, fmt.Errorf("%s job %s runs after unknown job %s", jobType, name, dependency))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("%s jobs have a run_after_success cycle: %s", jobType, strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dependency := range dependencies[name] {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateReporting(j JobBase, r Reporter) error {
 	if !r.SkipReport && r.Context == "" {
 		return errors.New("job is set to report but has no context configured")
@@ -3281,6 +4258,13 @@ func setChangeRegexes(cm RegexpChangeMatcher) (RegexpChangeMatcher, error) {
 		}
 		cm.reChanges = &CopyableRegexp{re}
 	}
+	if cm.RunIfChangedContent != "" {
+		re, err := regexp.Compile(cm.RunIfChangedContent)
+		if err != nil {
+			return cm, fmt.Errorf("could not compile run_if_changed_content regex: %w", err)
+		}
+		cm.reContent = &CopyableRegexp{re}
+	}
 	return cm, nil
 }
 
@@ -3504,6 +4488,7 @@ func (tm tideQueryMap) queries() (TideQueries, error) {
 			MissingLabels:          queryConfig.MissingLabels,
 			Milestone:              queryConfig.Milestone,
 			ReviewApprovedRequired: queryConfig.ReviewApprovedRequired,
+			StrictRebase:           queryConfig.StrictRebase,
 		})
 
 	}
@@ -3544,6 +4529,7 @@ func (c *Config) deduplicateTideQueries(queries TideQueries) (TideQueries, error
 			MissingLabels:          sortStringSlice(query.MissingLabels),
 			Milestone:              query.Milestone,
 			ReviewApprovedRequired: query.ReviewApprovedRequired,
+			StrictRebase:           query.StrictRebase,
 			TenantIDs:              query.TenantIDs(*c),
 		}
 		keyRaw, err := json.Marshal(key)
@@ -1353,6 +1353,7 @@ func TestDisabledClustersRawYaml(t *testing.T) {
 func TestValidateAgent(t *testing.T) {
 	jenk := string(prowapi.JenkinsAgent)
 	k := string(prowapi.KubernetesAgent)
+	kj := string(prowapi.KubernetesJobAgent)
 	ns := "default"
 	base := JobBase{
 		Agent:     k,
@@ -1428,6 +1429,28 @@ func TestValidateAgent(t *testing.T) {
 			},
 			pass: true,
 		},
+		{
+			name: "accept kubernetes-job agent",
+			base: func(j *JobBase) {
+				j.Agent = kj
+				j.Retry = &prowapi.RetryConfig{MaxRetries: 2}
+			},
+			pass: true,
+		},
+		{
+			name: "kubernetes-job agent requires spec",
+			base: func(j *JobBase) {
+				j.Agent = kj
+				j.Spec = nil
+			},
+		},
+		{
+			name: "error_on_eviction rejected for kubernetes-job agent",
+			base: func(j *JobBase) {
+				j.Agent = kj
+				j.ErrorOnEviction = true
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1845,6 +1868,26 @@ func TestValidateDecoration(t *testing.T) {
 			name:   "reject container that has no cmd, no args",
 			config: &defCfg,
 		},
+		{
+			name: "happy case with steps",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{{Name: "test", Command: []string{"make", "test"}}}
+				return cfg
+			}(),
+			pass: true,
+		},
+		{
+			name: "reject container with cmd when steps are configured",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{{Name: "test", Command: []string{"make", "test"}}}
+				return cfg
+			}(),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2039,7 +2082,10 @@ func TestValidateJobBase(t *testing.T) {
 	}
 	cfg := Config{
 		ProwConfig: ProwConfig{
-			Plank:        Plank{JobQueueCapacities: map[string]int{"queue": 0}},
+			Plank: Plank{
+				JobQueueCapacities: map[string]int{"queue": 0},
+				PriorityClasses:    map[string]PriorityClass{"release-blocking": {Priority: 100}},
+			},
 			PodNamespace: "target-namespace",
 		},
 	}
@@ -2085,6 +2131,36 @@ func TestValidateJobBase(t *testing.T) {
 			},
 			pass: false,
 		},
+		{
+			name: "valid retry config",
+			base: JobBase{
+				Name:      "name",
+				Agent:     ka,
+				Spec:      &goodSpec,
+				Namespace: &cfg.PodNamespace,
+				Retry:     &prowapi.RetryConfig{MaxRetries: 2, RetryOn: []prowapi.ProwJobState{prowapi.ErrorState, prowapi.FailureState}},
+			},
+			pass: true,
+		},
+		{
+			name: "invalid retry state",
+			base: JobBase{
+				Name:      "name",
+				Agent:     ka,
+				Spec:      &goodSpec,
+				Namespace: &cfg.PodNamespace,
+				Retry:     &prowapi.RetryConfig{MaxRetries: 2, RetryOn: []prowapi.ProwJobState{prowapi.SuccessState}},
+			},
+		},
+		{
+			name: "retry not allowed for jenkins job",
+			base: JobBase{
+				Name:      "name",
+				Agent:     ja,
+				Namespace: &cfg.PodNamespace,
+				Retry:     &prowapi.RetryConfig{MaxRetries: 1},
+			},
+		},
 		{
 			name: "invalid concurrency",
 			base: JobBase{
@@ -2174,6 +2250,22 @@ func TestValidateJobBase(t *testing.T) {
 			},
 			pass: false,
 		},
+		{
+			name: "valid priority class",
+			base: JobBase{
+				Name:          "name",
+				PriorityClass: "release-blocking",
+			},
+			pass: true,
+		},
+		{
+			name: "invalid priority class",
+			base: JobBase{
+				Name:          "name",
+				PriorityClass: "unknown",
+			},
+			pass: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -2216,6 +2308,68 @@ func TestValidateDeck(t *testing.T) {
 			deck:        Deck{SkipStoragePathValidation: &boolTrue, AdditionalAllowedBuckets: []string{"hello", "world"}},
 			expectedErr: "skip_storage_path_validation is enabled",
 		},
+		{
+			name: "valid status components => no errors",
+			deck: Deck{StatusComponents: []StatusComponent{
+				{Name: "hook", HealthURL: "http://hook:8081/healthz"},
+				{Name: "tide", MetricsURL: "http://tide:9090/metrics", SyncMetric: "tidesyncheartbeat"},
+			}},
+			expectedErr: "",
+		},
+		{
+			name:        "status component without name => error",
+			deck:        Deck{StatusComponents: []StatusComponent{{HealthURL: "http://hook:8081/healthz"}}},
+			expectedErr: "name must be set",
+		},
+		{
+			name: "status components with the same name => error",
+			deck: Deck{StatusComponents: []StatusComponent{
+				{Name: "hook", HealthURL: "http://hook:8081/healthz"},
+				{Name: "hook", HealthURL: "http://hook-2:8081/healthz"},
+			}},
+			expectedErr: `name "hook" is used by another component`,
+		},
+		{
+			name:        "status component without endpoints => error",
+			deck:        Deck{StatusComponents: []StatusComponent{{Name: "hook"}}},
+			expectedErr: "at least one of health_url and metrics_url must be set",
+		},
+		{
+			name:        "status component with sync metric but without metrics url => error",
+			deck:        Deck{StatusComponents: []StatusComponent{{Name: "tide", HealthURL: "http://tide:8081/healthz", SyncMetric: "tidesyncheartbeat"}}},
+			expectedErr: "sync_metric and error_metric require metrics_url to be set",
+		},
+		{
+			name: "valid tenants => no errors",
+			deck: Deck{Tenants: []DeckTenant{
+				{Name: "team-a", LabelSelector: "team=a"},
+				{Name: "team-b", Repos: []string{"acme/widget"}, RerunAuthConfig: &prowapi.RerunAuthConfig{GitHubTeamSlugs: []prowapi.GitHubTeamSlug{{Org: "acme", Slug: "b"}}}},
+			}},
+			expectedErr: "",
+		},
+		{
+			name:        "tenant with invalid name => error",
+			deck:        Deck{Tenants: []DeckTenant{{Name: "Team A", LabelSelector: "team=a"}}},
+			expectedErr: `name "Team A" is invalid`,
+		},
+		{
+			name: "tenants with the same name => error",
+			deck: Deck{Tenants: []DeckTenant{
+				{Name: "team-a", LabelSelector: "team=a"},
+				{Name: "team-a", Repos: []string{"acme"}},
+			}},
+			expectedErr: `name "team-a" is used by another tenant`,
+		},
+		{
+			name:        "tenant without scope => error",
+			deck:        Deck{Tenants: []DeckTenant{{Name: "team-a"}}},
+			expectedErr: "at least one of repos and label_selector must be set",
+		},
+		{
+			name:        "tenant with invalid label selector => error",
+			deck:        Deck{Tenants: []DeckTenant{{Name: "team-a", LabelSelector: "team in (a"}}},
+			expectedErr: "invalid label_selector",
+		},
 	}
 
 	for _, tc := range cases {
@@ -2235,6 +2389,77 @@ func TestValidateDeck(t *testing.T) {
 	}
 }
 
+func TestDeckTenants(t *testing.T) {
+	deck := Deck{Tenants: []DeckTenant{
+		{Name: "team-a", LabelSelector: "team=a", RerunAuthConfig: &prowapi.RerunAuthConfig{GitHubUsers: []string{"alice"}}},
+		{Name: "widget", Repos: []string{"acme/widget"}},
+		{Name: "acme", Repos: []string{"acme"}, RerunAuthConfig: &prowapi.RerunAuthConfig{GitHubUsers: []string{"bob"}}},
+	}}
+	if err := deck.Validate(); err != nil {
+		t.Fatalf("invalid deck config: %v", err)
+	}
+	pj := func(team, org, repo string) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": team}},
+			Spec:       prowapi.ProwJobSpec{Refs: &prowapi.Refs{Org: org, Repo: repo}},
+		}
+	}
+	periodic := &prowapi.ProwJob{Spec: prowapi.ProwJobSpec{ExtraRefs: []prowapi.Refs{{Org: "acme", Repo: "widget"}}}}
+
+	cases := []struct {
+		name            string
+		pj              *prowapi.ProwJob
+		expectedTenants []string
+		expectedUsers   []string
+	}{
+		{
+			name:            "matches by label and repo",
+			pj:              pj("a", "acme", "widget"),
+			expectedTenants: []string{"team-a", "widget", "acme"},
+			expectedUsers:   []string{"alice"},
+		},
+		{
+			name:            "matches by org",
+			pj:              pj("b", "acme", "gadget"),
+			expectedTenants: []string{"acme"},
+			expectedUsers:   []string{"bob"},
+		},
+		{
+			name:            "periodic matches by extra refs",
+			pj:              periodic,
+			expectedTenants: []string{"widget", "acme"},
+			expectedUsers:   []string{"bob"},
+		},
+		{
+			name: "matches no tenant",
+			pj:   pj("b", "other", "repo"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tenants []string
+			for _, tenant := range deck.Tenants {
+				if tenant.Matches(tc.pj) {
+					tenants = append(tenants, tenant.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedTenants, tenants); diff != "" {
+				t.Errorf("tenants differ from expected (-want +got):\n%s", diff)
+			}
+			var users []string
+			if authConfig := deck.GetTenantRerunAuthConfig(tc.pj); authConfig != nil {
+				users = authConfig.GitHubUsers
+			}
+			if diff := cmp.Diff(tc.expectedUsers, users); diff != "" {
+				t.Errorf("rerun auth config differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if deck.GetTenant("widget") == nil || deck.GetTenant("missing") != nil {
+		t.Error("GetTenant did not find the tenants by name")
+	}
+}
+
 func TestValidateRefs(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -2433,6 +2658,366 @@ func TestGerritAllRepos(t *testing.T) {
 	}
 }
 
+func TestGerritChecksFor(t *testing.T) {
+	checks := &GerritChecks{Scheme: "prow"}
+	in := &GerritOrgRepoConfigs{
+		{
+			Org:    "https://org-1",
+			Repos:  []string{"repo-1"},
+			Checks: checks,
+		},
+		{
+			Org:   "org-1",
+			Repos: []string{"repo-2"},
+		},
+	}
+	tests := []struct {
+		name     string
+		in       *GerritOrgRepoConfigs
+		instance string
+		repo     string
+		want     *GerritChecks
+	}{
+		{
+			name:     "enabled",
+			in:       in,
+			instance: "https://org-1",
+			repo:     "repo-1",
+			want:     checks,
+		},
+		{
+			name:     "instance without https prefix",
+			in:       in,
+			instance: "org-1",
+			repo:     "repo-1",
+			want:     checks,
+		},
+		{
+			name:     "not enabled for repo",
+			in:       in,
+			instance: "https://org-1",
+			repo:     "repo-2",
+		},
+		{
+			name:     "unknown repo",
+			in:       in,
+			instance: "https://org-2",
+			repo:     "repo-1",
+		},
+		{
+			name:     "nil",
+			instance: "https://org-1",
+			repo:     "repo-1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.ChecksFor(tc.instance, tc.repo); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGerritAggregateReportsFor(t *testing.T) {
+	in := &GerritOrgRepoConfigs{
+		{
+			Org:              "https://org-1",
+			Repos:            []string{"repo-1"},
+			AggregateReports: true,
+		},
+		{
+			Org:   "org-1",
+			Repos: []string{"repo-1", "repo-2"},
+		},
+	}
+	tests := []struct {
+		name     string
+		in       *GerritOrgRepoConfigs
+		instance string
+		repo     string
+		want     bool
+	}{
+		{
+			name:     "enabled",
+			in:       in,
+			instance: "https://org-1",
+			repo:     "repo-1",
+			want:     true,
+		},
+		{
+			name:     "instance without https prefix",
+			in:       in,
+			instance: "org-1",
+			repo:     "repo-1",
+			want:     true,
+		},
+		{
+			name:     "not enabled for repo",
+			in:       in,
+			instance: "https://org-1",
+			repo:     "repo-2",
+		},
+		{
+			name:     "nil",
+			instance: "https://org-1",
+			repo:     "repo-1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.AggregateReportsFor(tc.instance, tc.repo); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSinkerRetentionFor(t *testing.T) {
+	const prowConfig = `
+sinker:
+  max_prowjob_age: 48h
+  max_pod_age: 12h
+  retention_policies:
+  - labels:
+      release-blocking: "true"
+    max_prowjob_age: 2160h
+  - repo: org/repo
+    job: pull-.*
+    max_prowjob_age: 1h
+    terminated_pod_ttl: 5m
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(prowConfig), 0666); err != nil {
+		t.Fatalf("fail to write config: %v", err)
+	}
+	cfg, err := Load(path, "", nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		pj   *prowapi.ProwJob
+		want [3]time.Duration
+	}{
+		{
+			name: "nil ProwJob gets the default retention",
+			want: [3]time.Duration{48 * time.Hour, 12 * time.Hour, 12 * time.Hour},
+		},
+		{
+			name: "labels match",
+			pj: &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"release-blocking": "true"}},
+				Spec:       prowapi.ProwJobSpec{Job: "pull-foo", Refs: &prowapi.Refs{Org: "org", Repo: "repo"}},
+			},
+			want: [3]time.Duration{2160 * time.Hour, 12 * time.Hour, 12 * time.Hour},
+		},
+		{
+			name: "repo and job match",
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{Job: "pull-foo", Refs: &prowapi.Refs{Org: "org", Repo: "repo"}},
+			},
+			want: [3]time.Duration{time.Hour, 12 * time.Hour, 5 * time.Minute},
+		},
+		{
+			name: "periodic uses the first extra ref",
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{Job: "pull-foo", ExtraRefs: []prowapi.Refs{{Org: "org", Repo: "repo"}}},
+			},
+			want: [3]time.Duration{time.Hour, 12 * time.Hour, 5 * time.Minute},
+		},
+		{
+			name: "job regexp must match the full name",
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{Job: "ci-pull-foo", Refs: &prowapi.Refs{Org: "org", Repo: "repo"}},
+			},
+			want: [3]time.Duration{48 * time.Hour, 12 * time.Hour, 12 * time.Hour},
+		},
+		{
+			name: "other repo",
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{Job: "pull-foo", Refs: &prowapi.Refs{Org: "org", Repo: "other"}},
+			},
+			want: [3]time.Duration{48 * time.Hour, 12 * time.Hour, 12 * time.Hour},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			retention := cfg.Sinker.RetentionFor(tc.pj)
+			got := [3]time.Duration{retention.MaxProwJobAge.Duration, retention.MaxPodAge.Duration, retention.TerminatedPodTTL.Duration}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("output mismatch. got(+), want(-):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSinkerRetentionPoliciesInvalidJob(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("sinker:\n  retention_policies:\n  - job: '('\n"), 0666); err != nil {
+		t.Fatalf("fail to write config: %v", err)
+	}
+	if _, err := Load(path, "", nil, ""); err == nil {
+		t.Error("expected an error for an invalid job regexp")
+	}
+}
+
+func TestGerritOverrideAllowedUsers(t *testing.T) {
+	in := &GerritOrgRepoConfigs{
+		{
+			Org:                  "https://org-1",
+			Repos:                []string{"repo-1", "repo-2"},
+			OverrideAllowedUsers: []string{"alice"},
+		},
+		{
+			Org:                  "org-1",
+			Repos:                []string{"repo-1"},
+			OverrideAllowedUsers: []string{"bob@example.com"},
+		},
+	}
+	tests := []struct {
+		name string
+		in   *GerritOrgRepoConfigs
+		repo string
+		want sets.Set[string]
+	}{
+		{
+			name: "union of configs",
+			in:   in,
+			repo: "repo-1",
+			want: sets.New[string]("alice", "bob@example.com"),
+		},
+		{
+			name: "single config",
+			in:   in,
+			repo: "repo-2",
+			want: sets.New[string]("alice"),
+		},
+		{
+			name: "unknown repo",
+			in:   in,
+			repo: "repo-3",
+			want: sets.New[string](),
+		},
+		{
+			name: "nil",
+			repo: "repo-1",
+			want: sets.New[string](),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.in.OverrideAllowedUsers("https://org-1", tc.repo)); diff != "" {
+				t.Errorf("output mismatch. got(+), want(-):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGerritChecksDefaultAndValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     *GerritChecks
+		wantScheme string
+		wantErr    bool
+	}{
+		{
+			name:       "defaults scheme",
+			checks:     &GerritChecks{},
+			wantScheme: DefaultGerritChecksScheme,
+		},
+		{
+			name:       "custom scheme",
+			checks:     &GerritChecks{Scheme: "my-prow"},
+			wantScheme: "my-prow",
+		},
+		{
+			name:    "invalid scheme",
+			checks:  &GerritChecks{Scheme: "my:prow"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &Gerrit{OrgReposConfig: &GerritOrgRepoConfigs{{Org: "org", Repos: []string{"repo"}, Checks: tc.checks}}}
+			err := g.DefaultAndValidate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %t, got: %v", tc.wantErr, err)
+			}
+			if err == nil && tc.checks.Scheme != tc.wantScheme {
+				t.Errorf("expected scheme %q, got %q", tc.wantScheme, tc.checks.Scheme)
+			}
+			if uuid := tc.checks.CheckerUUID("job"); !tc.wantErr {
+				if job, ok := tc.checks.JobForCheckerUUID(uuid); !ok || job != "job" {
+					t.Errorf("expected checker %q to map back to job, got %q", uuid, job)
+				}
+			}
+		})
+	}
+}
+
+func TestClusterHealthDefaulting(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      ClusterHealthGating
+		want    ClusterHealthGating
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: ClusterHealthGating{ProbeInterval: &metav1.Duration{Duration: time.Minute}, MinReadyNodes: 1, FailureThreshold: 3},
+		},
+		{
+			name: "explicit values",
+			in:   ClusterHealthGating{ProbeInterval: &metav1.Duration{Duration: time.Second}, MinReadyNodes: 5, MinQuotaHeadroom: 20, FailureThreshold: 1},
+			want: ClusterHealthGating{ProbeInterval: &metav1.Duration{Duration: time.Second}, MinReadyNodes: 5, MinQuotaHeadroom: 20, FailureThreshold: 1},
+		},
+		{
+			name:    "headroom above 100 percent",
+			in:      ClusterHealthGating{MinQuotaHeadroom: 101},
+			wantErr: true,
+		},
+		{
+			name:    "negative failure threshold",
+			in:      ClusterHealthGating{FailureThreshold: -1},
+			wantErr: true,
+		},
+		{
+			name:    "zero probe interval",
+			in:      ClusterHealthGating{ProbeInterval: &metav1.Duration{}},
+			wantErr: true,
+		},
+		{
+			name:    "negative probe interval",
+			in:      ClusterHealthGating{ProbeInterval: &metav1.Duration{Duration: -time.Minute}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Config{ProwConfig: ProwConfig{Scheduler: Scheduler{ClusterHealth: &tc.in}}}
+			err := parseProwConfig(c)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %t, got: %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, *c.Scheduler.ClusterHealth); diff != "" {
+				t.Errorf("cluster health config differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGerritOptOutHelpRepos(t *testing.T) {
 	tests := []struct {
 		name string
@@ -3190,6 +3775,34 @@ pubsub_subscriptions:
 				return nil
 			},
 		},
+		{
+			name: "NATS and Kafka triggers default their consumer groups",
+			prowConfig: `
+nats_triggers:
+- url: nats://nats:4222
+  subjects: [prow.jobs]
+- url: nats://nats:4222
+  subjects: [other.jobs]
+  queue_group: other
+kafka_triggers:
+- brokers: [kafka:9092]
+  topics: [prow-jobs]
+`,
+			verify: func(c *Config) error {
+				if diff := cmp.Diff([]NATSTrigger{
+					{URL: "nats://nats:4222", Subjects: []string{"prow.jobs"}, QueueGroup: "prow-sub"},
+					{URL: "nats://nats:4222", Subjects: []string{"other.jobs"}, QueueGroup: "other"},
+				}, c.NATSTriggers); diff != "" {
+					return fmt.Errorf("unexpected NATS triggers (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff([]KafkaTrigger{
+					{Brokers: []string{"kafka:9092"}, Topics: []string{"prow-jobs"}, GroupID: "prow-sub"},
+				}, c.KafkaTriggers); diff != "" {
+					return fmt.Errorf("unexpected Kafka triggers (-want +got):\n%s", diff)
+				}
+				return nil
+			},
+		},
 		{
 			name:               "Version file sets the version",
 			versionFileContent: "some-git-sha",
@@ -4722,6 +5335,87 @@ func TestValidateComponentConfig(t *testing.T) {
 			}}},
 			errExpected: false,
 		},
+		{
+			name: "NATS and Kafka triggers, no err",
+			config: &Config{ProwConfig: ProwConfig{
+				NATSTriggers:  []NATSTrigger{{URL: "nats://nats:4222", Subjects: []string{"prow.jobs"}}},
+				KafkaTriggers: []KafkaTrigger{{Brokers: []string{"kafka:9092"}, Topics: []string{"prow-jobs"}}},
+			}},
+			errExpected: false,
+		},
+		{
+			name: "Pub/Sub trigger replying with schema version 2, no err",
+			config: &Config{ProwConfig: ProwConfig{
+				PubSubTriggers: []PubSubTrigger{{Project: "project", Topics: []string{"jobs"}, ReplyTopic: "results", ReplySchemaVersion: 2}},
+			}},
+			errExpected: false,
+		},
+		{
+			name: "Pub/Sub trigger with unknown reply schema version, err",
+			config: &Config{ProwConfig: ProwConfig{
+				PubSubTriggers: []PubSubTrigger{{Project: "project", Topics: []string{"jobs"}, ReplyTopic: "results", ReplySchemaVersion: 3}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "Pub/Sub trigger with reply schema version but no reply topic, err",
+			config: &Config{ProwConfig: ProwConfig{
+				PubSubTriggers: []PubSubTrigger{{Project: "project", Topics: []string{"jobs"}, ReplySchemaVersion: 2}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "NATS trigger without subjects, err",
+			config: &Config{ProwConfig: ProwConfig{
+				NATSTriggers: []NATSTrigger{{URL: "nats://nats:4222"}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "NATS trigger without url, err",
+			config: &Config{ProwConfig: ProwConfig{
+				NATSTriggers: []NATSTrigger{{Subjects: []string{"prow.jobs"}}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "Kafka trigger without brokers, err",
+			config: &Config{ProwConfig: ProwConfig{
+				KafkaTriggers: []KafkaTrigger{{Topics: []string{"prow-jobs"}}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "Kafka trigger without topics, err",
+			config: &Config{ProwConfig: ProwConfig{
+				KafkaTriggers: []KafkaTrigger{{Brokers: []string{"kafka:9092"}}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "GitHub throttle policies, no err",
+			config: &Config{ProwConfig: ProwConfig{GitHubOptions: GitHubOptions{Throttle: &GitHubThrottle{
+				Default: &github.ThrottlePolicy{HourlyTokens: 3000, Burst: 100},
+				Components: map[string]github.ThrottlePolicy{
+					"tide-sync": {HourlyTokens: 800, Burst: 50, Orgs: map[string]github.OrgThrottlePolicy{"my-org": {HourlyTokens: 100, Burst: 10}}},
+				},
+			}}}},
+			errExpected: false,
+		},
+		{
+			name: "GitHub throttle policy with burst larger than hourly tokens, err",
+			config: &Config{ProwConfig: ProwConfig{GitHubOptions: GitHubOptions{Throttle: &GitHubThrottle{
+				Components: map[string]github.ThrottlePolicy{"hook": {HourlyTokens: 10, Burst: 100}},
+			}}}},
+			errExpected: true,
+		},
+		{
+			name: "GitHub throttle policy with invalid org, err",
+			config: &Config{ProwConfig: ProwConfig{GitHubOptions: GitHubOptions{Throttle: &GitHubThrottle{
+				Default: &github.ThrottlePolicy{Orgs: map[string]github.OrgThrottlePolicy{"my-org": {HourlyTokens: 100}}},
+			}}}},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4733,6 +5427,46 @@ func TestValidateComponentConfig(t *testing.T) {
 	}
 }
 
+func TestGitHubThrottlePolicy(t *testing.T) {
+	defaultPolicy := &github.ThrottlePolicy{HourlyTokens: 3000, Burst: 100}
+	testCases := []struct {
+		name      string
+		throttle  *GitHubThrottle
+		component string
+		expected  *github.ThrottlePolicy
+	}{
+		{
+			name:      "no throttle config",
+			component: "hook",
+		},
+		{
+			name:      "component policy",
+			throttle:  &GitHubThrottle{Default: defaultPolicy, Components: map[string]github.ThrottlePolicy{"hook": {HourlyTokens: 1000, Burst: 10}}},
+			component: "hook",
+			expected:  &github.ThrottlePolicy{HourlyTokens: 1000, Burst: 10},
+		},
+		{
+			name:      "default policy",
+			throttle:  &GitHubThrottle{Default: defaultPolicy, Components: map[string]github.ThrottlePolicy{"hook": {HourlyTokens: 1000, Burst: 10}}},
+			component: "crier",
+			expected:  defaultPolicy,
+		},
+		{
+			name:      "no policy for component",
+			throttle:  &GitHubThrottle{Components: map[string]github.ThrottlePolicy{"hook": {HourlyTokens: 1000, Burst: 10}}},
+			component: "crier",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := GitHubOptions{Throttle: tc.throttle}
+			if diff := cmp.Diff(tc.expected, o.ThrottlePolicy(tc.component)); diff != "" {
+				t.Errorf("throttle policy differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSlackReporterValidation(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -4915,6 +5649,35 @@ func TestManagedHmacEntityValidation(t *testing.T) {
 			}},
 			shouldFail: true,
 		},
+		{
+			name: "Config with a rotation interval and grace period",
+			prowConfig: Config{ProwConfig: ProwConfig{
+				ManagedWebhooks: ManagedWebhooks{
+					RotationInterval:    &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					RotationGracePeriod: &metav1.Duration{Duration: time.Hour},
+				},
+			}},
+			shouldFail: false,
+		},
+		{
+			name: "Config with a non-positive rotation interval",
+			prowConfig: Config{ProwConfig: ProwConfig{
+				ManagedWebhooks: ManagedWebhooks{
+					RotationInterval: &metav1.Duration{},
+				},
+			}},
+			shouldFail: true,
+		},
+		{
+			name: "Config with a grace period longer than the rotation interval",
+			prowConfig: Config{ProwConfig: ProwConfig{
+				ManagedWebhooks: ManagedWebhooks{
+					RotationInterval:    &metav1.Duration{Duration: time.Hour},
+					RotationGracePeriod: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			}},
+			shouldFail: true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -4964,6 +5727,25 @@ func TestValidateTriggering(t *testing.T) {
 			},
 			errExpected: false,
 		},
+		{
+			name: "run_if_changed_content without run_if_changed, err",
+			presubmit: Presubmit{
+				RegexpChangeMatcher: RegexpChangeMatcher{
+					RunIfChangedContent: `^type .* struct`,
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "run_if_changed_content with run_if_changed, no err",
+			presubmit: Presubmit{
+				RegexpChangeMatcher: RegexpChangeMatcher{
+					RunIfChanged:        `^pkg/apis/`,
+					RunIfChangedContent: `^type .* struct`,
+				},
+			},
+			errExpected: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5013,6 +5795,16 @@ func TestValidateAlwaysRunPostsubmit(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "run_if_changed_content set, err",
+			postsubmit: Postsubmit{
+				RegexpChangeMatcher: RegexpChangeMatcher{
+					RunIfChanged:        `foo`,
+					RunIfChangedContent: `bar`,
+				},
+			},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8147,6 +8939,26 @@ func TestValidatePostsubmits(t *testing.T) {
 			}},
 			expectedError: "job a declares run_if_changed and skip_if_only_changed, which are mutually exclusive",
 		},
+		{
+			name: "Mutually exclusive settings: run_after_success and run_if_changed",
+			postsubmits: []Postsubmit{
+				{JobBase: JobBase{Name: "a"}, Reporter: Reporter{Context: "a"}},
+				{
+					JobBase:             JobBase{Name: "b"},
+					Reporter:            Reporter{Context: "b"},
+					RunAfterSuccess:     []string{"a"},
+					RegexpChangeMatcher: RegexpChangeMatcher{RunIfChanged: `\.go$`},
+				},
+			},
+			expectedError: "invalid postsubmit job b: run_after_success can not be combined with run_if_changed or skip_if_only_changed",
+		},
+		{
+			name: "run_after_success of an unknown job causes error",
+			postsubmits: []Postsubmit{
+				{JobBase: JobBase{Name: "a"}, Reporter: Reporter{Context: "a"}, RunAfterSuccess: []string{"missing"}},
+			},
+			expectedError: "postsubmit job a runs after unknown job missing",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8161,6 +8973,56 @@ func TestValidatePostsubmits(t *testing.T) {
 	}
 }
 
+func TestValidateRunAfterSuccess(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		dependencies  map[string][]string
+		expectedError string
+	}{
+		{
+			name: "valid dependencies",
+			dependencies: map[string][]string{
+				"unit":  nil,
+				"lint":  nil,
+				"build": {"unit"},
+				"e2e":   {"build", "lint"},
+			},
+		},
+		{
+			name:          "job depending on itself causes error",
+			dependencies:  map[string][]string{"a": {"a"}},
+			expectedError: "presubmit job a can not run after its own success",
+		},
+		{
+			name:          "unknown dependency causes error",
+			dependencies:  map[string][]string{"a": {"b"}},
+			expectedError: "presubmit job a runs after unknown job b",
+		},
+		{
+			name: "cycle causes error",
+			dependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"a"},
+			},
+			expectedError: "presubmit jobs have a run_after_success cycle: a -> b -> c -> a",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateRunAfterSuccess(prowapi.PresubmitJob, tc.dependencies); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedError {
+				t.Errorf("expected error '%s', got error '%s'", tc.expectedError, errMsg)
+			}
+		})
+	}
+}
+
 func TestValidatePeriodics(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -8189,7 +9051,50 @@ func TestValidatePeriodics(t *testing.T) {
 			periodics: []Periodic{
 				{JobBase: JobBase{Name: "a"}},
 			},
-			expectedError: "at least one of cron, interval, or minimum_interval must be set in periodic a",
+			expectedError: "at least one of cron, interval, minimum_interval, or event_triggers must be set in periodic a",
+		},
+		{
+			name: "Event triggers without cron or interval",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, EventTriggers: []PeriodicEventTrigger{{Webhook: &WebhookEventSource{Name: "upstream"}}}},
+			},
+			expected: []Periodic{
+				{JobBase: JobBase{Name: "a"}, EventTriggers: []PeriodicEventTrigger{{Webhook: &WebhookEventSource{Name: "upstream"}}}},
+			},
+		},
+		{
+			name: "Event trigger without source",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@daily", EventTriggers: []PeriodicEventTrigger{{}}},
+			},
+			expectedError: "invalid event_triggers in periodic a: trigger 0: one of pubsub or webhook must be set",
+		},
+		{
+			name: "Event trigger with pubsub and webhook",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, EventTriggers: []PeriodicEventTrigger{{
+					PubSub:  &PubSubEventSource{Project: "project", Subscription: "sub"},
+					Webhook: &WebhookEventSource{Name: "upstream"},
+				}}},
+			},
+			expectedError: "invalid event_triggers in periodic a: trigger 0: pubsub and webhook are mutually exclusive",
+		},
+		{
+			name: "Event trigger with incomplete pubsub source",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, EventTriggers: []PeriodicEventTrigger{{PubSub: &PubSubEventSource{Project: "project"}}}},
+			},
+			expectedError: "invalid event_triggers in periodic a: trigger 0: pubsub requires project and subscription",
+		},
+		{
+			name: "Event trigger with invalid env var",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, EventTriggers: []PeriodicEventTrigger{{
+					Webhook: &WebhookEventSource{Name: "upstream"},
+					Env:     map[string]string{"IMAGE_TAG": ""},
+				}}},
+			},
+			expectedError: "invalid event_triggers in periodic a: trigger 0: env var IMAGE_TAG does not reference a payload field",
 		},
 		{
 			name: "Invalid cron string",
@@ -8212,6 +9117,43 @@ func TestValidatePeriodics(t *testing.T) {
 			},
 			expectedError: "cannot parse duration for a: time: invalid duration \"hello\"",
 		},
+		{
+			name: "run_missed without cron",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Interval: "1h", RunMissed: RunMissedLatest},
+			},
+			expectedError: "run_missed and jitter require cron to be set in periodic a",
+		},
+		{
+			name: "Invalid run_missed",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@hourly", RunMissed: "some"},
+			},
+			expectedError: "invalid run_missed \"some\" in periodic a, must be one of none, latest or all",
+		},
+		{
+			name: "Invalid jitter",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@hourly", Jitter: "hello"},
+			},
+			expectedError: "cannot parse jitter for a: time: invalid duration \"hello\"",
+		},
+		{
+			name: "Negative jitter",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@hourly", Jitter: "-5m"},
+			},
+			expectedError: "jitter of a must not be negative",
+		},
+		{
+			name: "Sets jitter",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@hourly", RunMissed: RunMissedAll, Jitter: "5m"},
+			},
+			expected: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@hourly", RunMissed: RunMissedAll, Jitter: "5m", jitter: 5 * time.Minute},
+			},
+		},
 		{
 			name: "Sets interval",
 			periodics: []Periodic{
@@ -8387,6 +9329,7 @@ deck:
     size_limit: 100000000
   tide_update_period: 10s
 default_job_timeout: 24h0m0s
+extensions: {}
 gangway: {}
 gerrit:
   ratelimit: 5
@@ -8402,6 +9345,7 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+lifecycle_manager: {}
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8471,6 +9415,7 @@ deck:
     size_limit: 100000000
   tide_update_period: 10s
 default_job_timeout: 24h0m0s
+extensions: {}
 gangway: {}
 gerrit:
   ratelimit: 5
@@ -8486,6 +9431,7 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+lifecycle_manager: {}
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8548,6 +9494,7 @@ deck:
     size_limit: 100000000
   tide_update_period: 10s
 default_job_timeout: 24h0m0s
+extensions: {}
 gangway: {}
 gerrit:
   ratelimit: 5
@@ -8563,6 +9510,7 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+lifecycle_manager: {}
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8630,6 +9578,7 @@ deck:
     size_limit: 100000000
   tide_update_period: 10s
 default_job_timeout: 24h0m0s
+extensions: {}
 gangway: {}
 gerrit:
   ratelimit: 5
@@ -8645,6 +9594,7 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+lifecycle_manager: {}
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -9567,3 +10517,46 @@ func TestSplitRepoName(t *testing.T) {
 		})
 	}
 }
+
+func TestGitLabIsGitLabOrg(t *testing.T) {
+	gitlab := &GitLab{Orgs: []string{"group", "other/subgroup"}}
+	for org, expected := range map[string]bool{
+		"group":          true,
+		"Group":          true,
+		"other/subgroup": true,
+		"other":          false,
+		"kubernetes":     false,
+	} {
+		if got := gitlab.IsGitLabOrg(org); got != expected {
+			t.Errorf("expected IsGitLabOrg(%q) to be %t, got %t", org, expected, got)
+		}
+	}
+	var unset *GitLab
+	if unset.IsGitLabOrg("group") {
+		t.Error("expected no GitLab orgs without GitLab config")
+	}
+}
+
+func TestSCMProviderOf(t *testing.T) {
+	c := &ProwConfig{
+		SCM:    map[string]SCMProvider{"bitbucket-server": {Orgs: []string{"PRJ"}}},
+		GitLab: &GitLab{Orgs: []string{"group"}},
+	}
+	for org, expected := range map[string]string{
+		"PRJ":        "bitbucket-server",
+		"prj":        "bitbucket-server",
+		"group":      "gitlab",
+		"kubernetes": "",
+	} {
+		if got := c.SCMProviderOf(org); got != expected {
+			t.Errorf("expected SCMProviderOf(%q) to be %q, got %q", org, expected, got)
+		}
+	}
+	if err := c.validateSCM(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	c.GitLab = &GitLab{Orgs: []string{"prj"}}
+	if err := c.validateSCM(); err == nil {
+		t.Error("expected an org served from both GitLab and Bitbucket Server to be invalid")
+	}
+}
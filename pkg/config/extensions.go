@@ -0,0 +1,372 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+const (
+	// ExtensionJobsDir is the directory below the path of an extension
+	// source that holds the job config of the org.
+	ExtensionJobsDir = "jobs"
+	// ExtensionPluginsFile is the file below the path of an extension
+	// source that holds the plugin config of the org.
+	ExtensionPluginsFile = "plugins.yaml"
+
+	defaultExtensionBaseRef = "main"
+	defaultExtensionPath    = "prow"
+)
+
+// Extensions configures the job and plugin config fragments that orgs
+// maintain in their own repositories, so that teams can manage their jobs
+// without write access to the central config repository. The config-loader
+// fetches the fragments, validates them against the rest of the config and
+// syncs them to a config map that all components mount at Dir.
+type Extensions struct {
+	// Dir is the directory the synced fragments are mounted at. The job
+	// config of the extensions is only loaded if it is set.
+	Dir string `json:"dir,omitempty"`
+	// Sources are the repositories holding the fragments, at most one per org.
+	Sources []ExtensionSource `json:"sources,omitempty"`
+}
+
+// ExtensionSource is the repository that holds the config fragments of an org.
+type ExtensionSource struct {
+	// Org is the org the fragments configure. They may only configure jobs
+	// and plugins for the repositories of this org.
+	Org string `json:"org"`
+	// Repo is the repository of the org that holds the fragments.
+	Repo string `json:"repo"`
+	// BaseRef is the branch the fragments are loaded from. Defaults to "main".
+	BaseRef string `json:"base_ref,omitempty"`
+	// Path is the directory of the repository that holds the fragments: the
+	// job config in its jobs/ directory and the plugin config in its
+	// plugins.yaml file. Defaults to "prow".
+	Path string `json:"path,omitempty"`
+	// AllowedClusters are the build clusters the jobs of the org may run on.
+	// Defaults to the default cluster.
+	AllowedClusters []string `json:"allowed_clusters,omitempty"`
+}
+
+// Source returns the extension source of the given org, if any.
+func (e *Extensions) Source(org string) *ExtensionSource {
+	for i := range e.Sources {
+		if e.Sources[i].Org == org {
+			return &e.Sources[i]
+		}
+	}
+	return nil
+}
+
+// ExtensionJobConfigFile is the name of the file the job config of an org's
+// extension is synced to.
+func ExtensionJobConfigFile(org string) string {
+	return org + ".yaml"
+}
+
+// ReadExtensionJobConfig reads the job config of the config extension at dir,
+// the path of an extension source in a checkout of its repository. It returns
+// nil if the extension does not configure any jobs.
+func ReadExtensionJobConfig(dir string) (*JobConfig, error) {
+	jobsDir := filepath.Join(dir, ExtensionJobsDir)
+	if _, err := os.Stat(jobsDir); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	jc, err := ReadJobConfig(jobsDir, yaml.DisallowUnknownFields)
+	if err != nil {
+		return nil, err
+	}
+	return &jc, nil
+}
+
+func (e *Extensions) defaultAndValidate() error {
+	orgs := sets.New[string]()
+	var errs []error
+	for i := range e.Sources {
+		source := &e.Sources[i]
+		if source.Org == "" || source.Repo == "" {
+			errs = append(errs, fmt.Errorf("extensions.sources[%d]: org and repo must be set", i))
+			continue
+		}
+		if orgs.Has(source.Org) {
+			errs = append(errs, fmt.Errorf("extensions.sources[%d]: org %s has more than one source", i, source.Org))
+		}
+		orgs.Insert(source.Org)
+		if source.BaseRef == "" {
+			source.BaseRef = defaultExtensionBaseRef
+		}
+		if source.Path == "" {
+			source.Path = defaultExtensionPath
+		}
+		if filepath.IsAbs(source.Path) || strings.HasPrefix(filepath.Clean(source.Path), "..") {
+			errs = append(errs, fmt.Errorf("extensions.sources[%d]: path %q must be relative to the repository", i, source.Path))
+		}
+		if len(source.AllowedClusters) == 0 {
+			source.AllowedClusters = []string{kube.DefaultClusterAlias}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// loadExtensions merges the job config of the extensions synced to
+// Extensions.Dir into the rest of the job config. An extension that configures
+// jobs outside of its org or jobs with the names of jobs that are already
+// loaded is skipped, so that a single org cannot break the config of everyone
+// else.
+func (c *Config) loadExtensions(yamlOpts ...yaml.JSONOpt) error {
+	if c.Extensions.Dir == "" {
+		return nil
+	}
+	for _, source := range c.Extensions.Sources {
+		log := logrus.WithField("org", source.Org)
+		path := filepath.Join(c.Extensions.Dir, ExtensionJobConfigFile(source.Org))
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to stat config extension of org %s: %w", source.Org, err)
+		}
+		var jc JobConfig
+		if err := yamlToConfig(path, &jc, yamlOpts...); err != nil {
+			log.WithError(err).Error("Skipping invalid config extension.")
+			continue
+		}
+		if err := validateExtensionScope(source, jc); err != nil {
+			log.WithError(err).Error("Skipping config extension that configures jobs outside of its org.")
+			continue
+		}
+		if err := c.validateExtensionJobNames(jc); err != nil {
+			log.WithError(err).Error("Skipping config extension that configures jobs that already exist.")
+			continue
+		}
+		if err := c.checkExtensionJobPolicies(jc); err != nil {
+			log.WithError(err).Error("Skipping config extension that violates job policies.")
+			continue
+		}
+		if err := c.mergeJobConfig(jc); err != nil {
+			return fmt.Errorf("failed to merge config extension of org %s: %w", source.Org, err)
+		}
+	}
+	return nil
+}
+
+// checkExtensionJobPolicies checks the jobs of an extension against the job
+// policies. The jobs are only defaulted once the whole job config is loaded,
+// so copies of them are defaulted for the check.
+func (c *Config) checkExtensionJobPolicies(jc JobConfig) error {
+	if len(c.JobPolicies) == 0 {
+		return nil
+	}
+	defaulted := JobConfig{PresubmitsStatic: map[string][]Presubmit{}, PostsubmitsStatic: map[string][]Postsubmit{}}
+	var errs []error
+	for repo, jobs := range jc.PresubmitsStatic {
+		for _, job := range jobs {
+			defaulted.PresubmitsStatic[repo] = append(defaulted.PresubmitsStatic[repo], *job.DeepCopy())
+		}
+		if err := defaultPresubmits(defaulted.PresubmitsStatic[repo], nil, c, repo); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for repo, jobs := range jc.PostsubmitsStatic {
+		for _, job := range jobs {
+			defaulted.PostsubmitsStatic[repo] = append(defaulted.PostsubmitsStatic[repo], *job.DeepCopy())
+		}
+		if err := defaultPostsubmits(defaulted.PostsubmitsStatic[repo], nil, c, repo); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, job := range jc.Periodics {
+		job.JobBase = *job.JobBase.DeepCopy()
+		if err := c.DefaultPeriodic(&job); err != nil {
+			errs = append(errs, err)
+		}
+		defaulted.Periodics = append(defaulted.Periodics, job)
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	return CheckJobPolicies(c.JobPolicies, defaulted)
+}
+
+// validateExtensionJobNames ensures that the jobs of an extension don't have
+// the names of jobs that are already loaded for the same repository, or of
+// periodics that are already loaded.
+func (c *Config) validateExtensionJobNames(jc JobConfig) error {
+	var errs []error
+	for repo, jobs := range jc.PresubmitsStatic {
+		existing := sets.New[string]()
+		for _, job := range c.PresubmitsStatic[repo] {
+			existing.Insert(job.Name)
+		}
+		for _, job := range jobs {
+			if existing.Has(job.Name) {
+				errs = append(errs, fmt.Errorf("presubmit %s of repository %s is already defined", job.Name, repo))
+			}
+		}
+	}
+	for repo, jobs := range jc.PostsubmitsStatic {
+		existing := sets.New[string]()
+		for _, job := range c.PostsubmitsStatic[repo] {
+			existing.Insert(job.Name)
+		}
+		for _, job := range jobs {
+			if existing.Has(job.Name) {
+				errs = append(errs, fmt.Errorf("postsubmit %s of repository %s is already defined", job.Name, repo))
+			}
+		}
+	}
+	existing := sets.New[string]()
+	for _, job := range c.Periodics {
+		existing.Insert(job.Name)
+	}
+	for _, job := range jc.Periodics {
+		if existing.Has(job.Name) {
+			errs = append(errs, fmt.Errorf("periodic %s is already defined", job.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateExtensionScope ensures that the job config of an extension only
+// configures jobs for the repositories of its org that run on the clusters
+// allowed for the org.
+func validateExtensionScope(source ExtensionSource, jc JobConfig) error {
+	var errs []error
+	if len(jc.Presets) > 0 {
+		errs = append(errs, errors.New("presets cannot be set in config extensions"))
+	}
+	if jc.DecorateAllJobs {
+		errs = append(errs, errors.New("decorate_all_jobs cannot be set in config extensions"))
+	}
+	allowedClusters := sets.New[string](source.AllowedClusters...)
+	validateJob := func(job JobBase) {
+		cluster := job.Cluster
+		if cluster == "" {
+			cluster = kube.DefaultClusterAlias
+		}
+		if !allowedClusters.Has(cluster) {
+			errs = append(errs, fmt.Errorf("job %s: cluster %q is not allowed for org %s", job.Name, cluster, source.Org))
+		}
+		for _, ref := range job.ExtraRefs {
+			if ref.Org != source.Org {
+				errs = append(errs, fmt.Errorf("job %s: extra_refs of org %s are not allowed for org %s", job.Name, ref.Org, source.Org))
+			}
+		}
+	}
+	validateRepo := func(repo string) {
+		if org, _, err := SplitRepoName(repo); err != nil || org != source.Org {
+			errs = append(errs, fmt.Errorf("jobs of repository %s are not allowed for org %s", repo, source.Org))
+		}
+	}
+	for repo, jobs := range jc.PresubmitsStatic {
+		validateRepo(repo)
+		for _, job := range jobs {
+			validateJob(job.JobBase)
+		}
+	}
+	for repo, jobs := range jc.PostsubmitsStatic {
+		validateRepo(repo)
+		for _, job := range jobs {
+			validateJob(job.JobBase)
+		}
+	}
+	for _, job := range jc.Periodics {
+		validateJob(job.JobBase)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ValidateExtension validates the job config of the extension of an org
+// against the rest of the config, ignoring the version of the extension that
+// is currently loaded. The jobs in jc are defaulted in the process.
+func (c *Config) ValidateExtension(org string, jc *JobConfig) error {
+	source := c.Extensions.Source(org)
+	if source == nil {
+		return fmt.Errorf("no config extension is configured for org %s", org)
+	}
+	if err := validateExtensionScope(*source, *jc); err != nil {
+		return err
+	}
+
+	current := filepath.Join(c.Extensions.Dir, ExtensionJobConfigFile(org))
+	var errs []error
+	for repo, jobs := range jc.PresubmitsStatic {
+		if err := defaultPresubmits(jobs, nil, c, repo); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, job := range c.GetPresubmitsStatic(repo) {
+			if job.SourcePath != current {
+				jobs = append(jobs, job)
+			}
+		}
+		if err := c.validatePresubmits(jobs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for repo, jobs := range jc.PostsubmitsStatic {
+		if err := defaultPostsubmits(jobs, nil, c, repo); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, job := range c.GetPostsubmitsStatic(repo) {
+			if job.SourcePath != current {
+				jobs = append(jobs, job)
+			}
+		}
+		if err := c.validatePostsubmits(jobs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(jc.Periodics) > 0 {
+		periodics := jc.Periodics
+		for i := range jc.Periodics {
+			if err := c.DefaultPeriodic(&jc.Periodics[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, job := range c.Periodics {
+			if job.SourcePath != current {
+				periodics = append(periodics, job)
+			}
+		}
+		if err := c.validatePeriodics(periodics); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		if err := CheckJobPolicies(c.JobPolicies, *jc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
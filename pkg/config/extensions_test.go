@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+)
+
+const extensionsProwConfig = `pod_namespace: test-pods
+extensions:
+  dir: %s
+  sources:
+  - org: acme
+    repo: prow-config
+  - org: beta
+    repo: prow-config
+    allowed_clusters: [build]
+`
+
+const centralJobConfig = `presubmits:
+  acme/widget:
+  - name: central-unit
+    always_run: true
+    spec:
+      containers:
+      - image: alpine
+periodics:
+- name: central-periodic
+  interval: 1h
+  spec:
+    containers:
+    - image: alpine
+`
+
+func writeExtensionsConfig(t *testing.T, extensions map[string]string) (string, string) {
+	dir := t.TempDir()
+	extensionsDir := filepath.Join(dir, "extensions")
+	if err := os.Mkdir(extensionsDir, 0755); err != nil {
+		t.Fatalf("failed to create extensions dir: %v", err)
+	}
+	files := map[string]string{
+		"config.yaml": fmt.Sprintf(extensionsProwConfig, extensionsDir),
+		"jobs.yaml":   centralJobConfig,
+	}
+	for org, content := range extensions {
+		files[filepath.Join("extensions", ExtensionJobConfigFile(org))] = content
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return filepath.Join(dir, "config.yaml"), filepath.Join(dir, "jobs.yaml")
+}
+
+func TestLoadExtensions(t *testing.T) {
+	prowConfig, jobConfig := writeExtensionsConfig(t, map[string]string{
+		"acme": "presubmits:\n  acme/widget:\n  - name: acme-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+		// Configures jobs of another org, so it is skipped.
+		"beta": "presubmits:\n  acme/widget:\n  - name: beta-unit\n    cluster: build\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+		// Not a configured source, so it is ignored.
+		"gamma": "presubmits:\n  gamma/repo:\n  - name: gamma-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+	})
+	cfg, err := Load(prowConfig, jobConfig, nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	jobs := map[string][]string{}
+	for repo, presubmits := range cfg.PresubmitsStatic {
+		for _, job := range presubmits {
+			jobs[repo] = append(jobs[repo], job.Name)
+		}
+		sort.Strings(jobs[repo])
+	}
+	if diff := cmp.Diff(map[string][]string{"acme/widget": {"acme-unit", "central-unit"}}, jobs); diff != "" {
+		t.Errorf("loaded presubmits differ from expected (-want +got):\n%s", diff)
+	}
+
+	expectedSources := []ExtensionSource{
+		{Org: "acme", Repo: "prow-config", BaseRef: "main", Path: "prow", AllowedClusters: []string{"default"}},
+		{Org: "beta", Repo: "prow-config", BaseRef: "main", Path: "prow", AllowedClusters: []string{"build"}},
+	}
+	if diff := cmp.Diff(expectedSources, cfg.Extensions.Sources); diff != "" {
+		t.Errorf("defaulted sources differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadExtensionsWithDuplicateJobs(t *testing.T) {
+	prowConfig, jobConfig := writeExtensionsConfig(t, map[string]string{
+		// Redefines a central presubmit, so it is skipped.
+		"acme": "presubmits:\n  acme/widget:\n  - name: acme-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n" +
+			"  - name: central-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+		// Redefines a central periodic, so it is skipped.
+		"beta": "presubmits:\n  beta/repo:\n  - name: beta-unit\n    cluster: build\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n" +
+			"periodics:\n- name: central-periodic\n  cluster: build\n  interval: 1h\n  spec:\n    containers:\n    - image: golang\n",
+	})
+	cfg, err := Load(prowConfig, jobConfig, nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	var jobs []string
+	for _, presubmits := range cfg.PresubmitsStatic {
+		for _, job := range presubmits {
+			jobs = append(jobs, job.Name)
+		}
+	}
+	for _, periodic := range cfg.Periodics {
+		jobs = append(jobs, periodic.Name)
+	}
+	sort.Strings(jobs)
+	if diff := cmp.Diff([]string{"central-periodic", "central-unit"}, jobs); diff != "" {
+		t.Errorf("loaded jobs differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadExtensionsWithJobPolicies(t *testing.T) {
+	prowConfig, jobConfig := writeExtensionsConfig(t, map[string]string{
+		"acme": "presubmits:\n  acme/widget:\n  - name: acme-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+		// Does not request cpu, so it is skipped.
+		"beta": "presubmits:\n  beta/repo:\n  - name: beta-unit\n    cluster: build\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+	})
+	policies := "job_policies:\n- name: resources\n  orgs: [beta]\n  rules:\n    required_resource_requests: [cpu]\n"
+	raw, err := os.ReadFile(prowConfig)
+	if err != nil {
+		t.Fatalf("failed to read prow config: %v", err)
+	}
+	if err := os.WriteFile(prowConfig, append(raw, policies...), 0644); err != nil {
+		t.Fatalf("failed to write prow config: %v", err)
+	}
+	cfg, err := Load(prowConfig, jobConfig, nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	var jobs []string
+	for _, presubmits := range cfg.PresubmitsStatic {
+		for _, job := range presubmits {
+			jobs = append(jobs, job.Name)
+		}
+	}
+	sort.Strings(jobs)
+	if diff := cmp.Diff([]string{"acme-unit", "central-unit"}, jobs); diff != "" {
+		t.Errorf("loaded jobs differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtensionsDefaultAndValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sources     []ExtensionSource
+		expectedErr bool
+	}{
+		{
+			name:    "valid sources",
+			sources: []ExtensionSource{{Org: "acme", Repo: "prow-config"}, {Org: "beta", Repo: "jobs", Path: "ci/prow"}},
+		},
+		{
+			name:        "missing repo",
+			sources:     []ExtensionSource{{Org: "acme"}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate org",
+			sources:     []ExtensionSource{{Org: "acme", Repo: "prow-config"}, {Org: "acme", Repo: "jobs"}},
+			expectedErr: true,
+		},
+		{
+			name:        "path outside of the repository",
+			sources:     []ExtensionSource{{Org: "acme", Repo: "prow-config", Path: "../jobs"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := Extensions{Sources: tc.sources}
+			if err := e.defaultAndValidate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateExtension(t *testing.T) {
+	prowConfig, jobConfig := writeExtensionsConfig(t, map[string]string{
+		"acme": "presubmits:\n  acme/widget:\n  - name: acme-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+	})
+	cfg, err := Load(prowConfig, jobConfig, nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	testCases := []struct {
+		name        string
+		org         string
+		jobs        string
+		expectedErr bool
+	}{
+		{
+			name: "update of the loaded extension",
+			org:  "acme",
+			jobs: "presubmits:\n  acme/widget:\n  - name: acme-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang:1.22\n",
+		},
+		{
+			name: "periodic with extra refs of the org",
+			org:  "acme",
+			jobs: "periodics:\n- name: acme-nightly\n  interval: 24h\n  extra_refs:\n  - org: acme\n    repo: widget\n    base_ref: main\n  spec:\n    containers:\n    - image: golang\n",
+		},
+		{
+			name:        "org without extension",
+			org:         "gamma",
+			jobs:        "presubmits:\n  gamma/repo:\n  - name: gamma-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+			expectedErr: true,
+		},
+		{
+			name:        "job of another org",
+			org:         "acme",
+			jobs:        "presubmits:\n  beta/repo:\n  - name: acme-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+			expectedErr: true,
+		},
+		{
+			name:        "extra refs of another org",
+			org:         "acme",
+			jobs:        "periodics:\n- name: acme-nightly\n  interval: 24h\n  extra_refs:\n  - org: beta\n    repo: repo\n    base_ref: main\n  spec:\n    containers:\n    - image: golang\n",
+			expectedErr: true,
+		},
+		{
+			name:        "cluster not allowed for the org",
+			org:         "acme",
+			jobs:        "presubmits:\n  acme/widget:\n  - name: acme-unit\n    cluster: trusted\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+			expectedErr: true,
+		},
+		{
+			name:        "presets",
+			org:         "acme",
+			jobs:        "presets:\n- labels:\n    preset-acme: \"true\"\n  env:\n  - name: TOKEN\n    value: secret\n",
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate of a central presubmit",
+			org:         "acme",
+			jobs:        "presubmits:\n  acme/widget:\n  - name: central-unit\n    always_run: true\n    spec:\n      containers:\n      - image: golang\n",
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate of a central periodic",
+			org:         "acme",
+			jobs:        "periodics:\n- name: central-periodic\n  interval: 1h\n  spec:\n    containers:\n    - image: golang\n",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var jc JobConfig
+			if err := yaml.Unmarshal([]byte(tc.jobs), &jc); err != nil {
+				t.Fatalf("failed to unmarshal job config: %v", err)
+			}
+			if err := cfg.ValidateExtension(tc.org, &jc); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
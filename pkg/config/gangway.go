@@ -41,6 +41,39 @@ type AllowedApiClient struct {
 	// AllowedJobsFilters contains information about what kinds of Prow jobs this
 	// API client is authorized to trigger.
 	AllowedJobsFilters []AllowedJobsFilter `json:"allowed_jobs_filters,omitempty"`
+
+	// AllowedOverrides restricts the environment variables, labels and
+	// annotations this API client may set in the pod_spec_options of a job
+	// execution. If it is not set, all overrides are allowed.
+	AllowedOverrides *AllowedOverrides `json:"allowed_overrides,omitempty"`
+}
+
+// AllowedOverrides lists the keys an API client may override when it creates
+// a job execution.
+type AllowedOverrides struct {
+	// Envs are the names of the environment variables the client may set.
+	Envs []string `json:"envs,omitempty"`
+	// Labels are the keys of the labels the client may set.
+	Labels []string `json:"labels,omitempty"`
+	// Annotations are the keys of the annotations the client may set.
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+func (ao *AllowedOverrides) Validate() error {
+	if ao == nil {
+		return nil
+	}
+	for _, keys := range []struct {
+		kind string
+		keys []string
+	}{{"envs", ao.Envs}, {"labels", ao.Labels}, {"annotations", ao.Annotations}} {
+		for _, key := range keys.keys {
+			if len(key) == 0 {
+				return fmt.Errorf("allowed_overrides.%s contains an empty key", keys.kind)
+			}
+		}
+	}
+	return nil
 }
 
 // ApiClientGcp encodes GCP Cloud Endpoints-specific HTTP metadata header
@@ -226,6 +259,10 @@ func (g *Gangway) Validate() error {
 				return err
 			}
 		}
+
+		if err := allowedApiClient.AllowedOverrides.Validate(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -95,6 +95,40 @@ gangway:
       endpoint_api_consumer_type: "PROJECT"
     allowed_jobs_filters:
     - tenant_id: "well-behaved-tenant-for-gangway"
+`,
+			expectError: true,
+		},
+		{
+			name: "allowed overrides",
+			gangwayConfig: `
+gangway:
+  allowed_api_clients:
+  - gcp:
+      endpoint_api_consumer_type: "PROJECT"
+      endpoint_api_consumer_number: "123"
+    allowed_jobs_filters:
+    - tenant_id: "well-behaved-tenant-for-gangway"
+    allowed_overrides:
+      envs:
+      - "FOCUS"
+      labels:
+      - "team"
+`,
+			expectError: false,
+		},
+		{
+			name: "allowed overrides with an empty key",
+			gangwayConfig: `
+gangway:
+  allowed_api_clients:
+  - gcp:
+      endpoint_api_consumer_type: "PROJECT"
+      endpoint_api_consumer_number: "123"
+    allowed_jobs_filters:
+    - tenant_id: "well-behaved-tenant-for-gangway"
+    allowed_overrides:
+      annotations:
+      - ""
 `,
 			expectError: true,
 		},
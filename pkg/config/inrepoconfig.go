@@ -29,10 +29,12 @@ import (
 	gitignore "github.com/denormal/go-gitignore"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	gerritsource "sigs.k8s.io/prow/pkg/gerrit/source"
 
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/git/types"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/yaml"
@@ -88,6 +90,9 @@ type ProwYAML struct {
 	Presets     []Preset     `json:"presets"`
 	Presubmits  []Presubmit  `json:"presubmits"`
 	Postsubmits []Postsubmit `json:"postsubmits"`
+	// Periodics may only be defined by the repos listed in
+	// in_repo_config.periodics_branches of the central config.
+	Periodics []Periodic `json:"periodics"`
 
 	// ProwIgnored is a well known, unparsed field where non-Prow fields can
 	// be defined without conflicting with unknown field validation.
@@ -207,6 +212,7 @@ func ReadProwYAML(log *logrus.Entry, dir string, strict bool) (*ProwYAML, error)
 			c.Presets = append(a.Presets, b.Presets...)
 			c.Presubmits = append(a.Presubmits, b.Presubmits...)
 			c.Postsubmits = append(a.Postsubmits, b.Postsubmits...)
+			c.Periodics = append(a.Periodics, b.Periodics...)
 
 			return c
 		}
@@ -287,6 +293,24 @@ func prowYAMLGetterWithDefaults(
 }
 
 func DefaultAndValidateProwYAML(c *Config, p *ProwYAML, identifier string) error {
+	var err error
+	if p.Presubmits, err = expandMatrixJobs(p.Presubmits, presubmitMatrixFields); err != nil {
+		return err
+	}
+	if p.Postsubmits, err = expandMatrixJobs(p.Postsubmits, postsubmitMatrixFields); err != nil {
+		return err
+	}
+	if p.Periodics, err = expandMatrixJobs(p.Periodics, periodicMatrixFields); err != nil {
+		return err
+	}
+	// Secrets are checked before the presets and pod templates of the central
+	// config are applied, as these may use any secret.
+	if err := validateInRepoConfigPolicy(c, p, identifier); err != nil {
+		return err
+	}
+	if err := defaultInRepoPeriodics(c, p, identifier); err != nil {
+		return err
+	}
 	if err := defaultPresubmits(p.Presubmits, p.Presets, c, identifier); err != nil {
 		return err
 	}
@@ -299,6 +323,16 @@ func DefaultAndValidateProwYAML(c *Config, p *ProwYAML, identifier string) error
 	if err := c.validatePostsubmits(append(p.Postsubmits, c.GetPostsubmitsStatic(identifier)...)); err != nil {
 		return err
 	}
+	if err := c.validatePeriodics(p.Periodics); err != nil {
+		return err
+	}
+	if err := CheckJobPolicies(c.JobPolicies, JobConfig{
+		PresubmitsStatic:  map[string][]Presubmit{identifier: p.Presubmits},
+		PostsubmitsStatic: map[string][]Postsubmit{identifier: p.Postsubmits},
+		Periodics:         p.Periodics,
+	}); err != nil {
+		return err
+	}
 
 	var errs []error
 	for _, pre := range p.Presubmits {
@@ -311,15 +345,179 @@ func DefaultAndValidateProwYAML(c *Config, p *ProwYAML, identifier string) error
 			errs = append(errs, fmt.Errorf("cluster %q is not allowed for repository %q", post.Cluster, identifier))
 		}
 	}
+	centralPeriodics := sets.New[string]()
+	for _, periodic := range c.Periodics {
+		centralPeriodics.Insert(periodic.Name)
+	}
+	for _, periodic := range p.Periodics {
+		if !c.InRepoConfigAllowsCluster(periodic.Cluster, identifier) {
+			errs = append(errs, fmt.Errorf("cluster %q is not allowed for repository %q", periodic.Cluster, identifier))
+		}
+		if centralPeriodics.Has(periodic.Name) {
+			errs = append(errs, fmt.Errorf("periodic %s is already defined in the central config", periodic.Name))
+		}
+		if len(periodic.EventTriggers) > 0 {
+			errs = append(errs, fmt.Errorf("periodic %s may not define event_triggers in the in-repo config", periodic.Name))
+		}
+	}
 
 	if len(errs) == 0 {
 		log := logrus.WithField("repo", identifier)
-		log.Debugf("Successfully got %d presubmits and %d postsubmits.", len(p.Presubmits), len(p.Postsubmits))
+		log.Debugf("Successfully got %d presubmits, %d postsubmits and %d periodics.", len(p.Presubmits), len(p.Postsubmits), len(p.Periodics))
 	}
 
 	return utilerrors.NewAggregate(errs)
 }
 
+// validateInRepoConfigPolicy checks that the in-repo jobs and presets only use
+// the secrets and labels that the central config allows for the repository, and
+// that only repositories that may define periodics do so.
+func validateInRepoConfigPolicy(c *Config, p *ProwYAML, identifier string) error {
+	var errs []error
+	checkSecrets := func(what string, secrets []string) {
+		for _, secret := range secrets {
+			if !c.InRepoConfigAllowsSecret(secret, identifier) {
+				errs = append(errs, fmt.Errorf("%s: secret %q is not allowed for repository %q", what, secret, identifier))
+			}
+		}
+	}
+	checkJob := func(job JobBase) {
+		checkSecrets("job "+job.Name, append(podSpecSecrets(job.Spec), decorationSecrets(job.DecorationConfig)...))
+		for _, label := range sets.List(sets.KeySet(job.Labels)) {
+			if !c.InRepoConfigAllowsLabel(label, identifier) {
+				errs = append(errs, fmt.Errorf("job %s: label %q is not allowed for repository %q", job.Name, label, identifier))
+			}
+		}
+	}
+	for i, preset := range p.Presets {
+		secrets := append(volumeSecrets(preset.Volumes), envSecrets(preset.Env)...)
+		checkSecrets(fmt.Sprintf("preset %d", i), secrets)
+	}
+	for _, job := range p.Presubmits {
+		checkJob(job.JobBase)
+	}
+	for _, job := range p.Postsubmits {
+		checkJob(job.JobBase)
+	}
+	for _, job := range p.Periodics {
+		checkJob(job.JobBase)
+	}
+	if _, ok := c.InRepoConfigPeriodicsBranch(identifier); !ok && len(p.Periodics) > 0 {
+		errs = append(errs, fmt.Errorf("periodics are not allowed for repository %q", identifier))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// defaultInRepoPeriodics makes the in-repo periodics clone their repository at
+// the branch they are read from and applies the defaults of the central config.
+func defaultInRepoPeriodics(c *Config, p *ProwYAML, identifier string) error {
+	branch, ok := c.InRepoConfigPeriodicsBranch(identifier)
+	if !ok {
+		return nil
+	}
+	org, repo, err := SplitRepoName(identifier)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i := range p.Periodics {
+		periodic := &p.Periodics[i]
+		if refs := periodic.ExtraRefs; len(refs) == 0 || refs[0].Org != org || refs[0].Repo != repo {
+			periodic.ExtraRefs = append([]prowapi.Refs{{Org: org, Repo: repo, BaseRef: branch}}, refs...)
+		} else if refs[0].BaseRef == "" {
+			refs[0].BaseRef = branch
+		}
+		if err := c.defaultPeriodic(periodic, p.Presets); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// podSpecSecrets returns the secrets a pod spec mounts or references in the
+// environment of its containers.
+func podSpecSecrets(spec *v1.PodSpec) []string {
+	if spec == nil {
+		return nil
+	}
+	secrets := volumeSecrets(spec.Volumes)
+	for _, ref := range spec.ImagePullSecrets {
+		secrets = append(secrets, ref.Name)
+	}
+	for _, container := range append(spec.InitContainers, spec.Containers...) {
+		secrets = append(secrets, envSecrets(container.Env)...)
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				secrets = append(secrets, envFrom.SecretRef.Name)
+			}
+		}
+	}
+	return secrets
+}
+
+// decorationSecrets returns the secrets the decoration config makes the pod
+// utilities use and the Vault secrets it fetches.
+func decorationSecrets(dc *prowapi.DecorationConfig) []string {
+	if dc == nil {
+		return nil
+	}
+	var secrets []string
+	for _, secret := range []*string{dc.GCSCredentialsSecret, dc.S3CredentialsSecret, dc.AzureCredentialsSecret, dc.CookiefileSecret} {
+		if secret != nil && *secret != "" {
+			secrets = append(secrets, *secret)
+		}
+	}
+	secrets = append(secrets, dc.SSHKeySecrets...)
+	if dc.OauthTokenSecret != nil {
+		secrets = append(secrets, dc.OauthTokenSecret.Name)
+	}
+	if dc.GitHubAppPrivateKeySecret != nil {
+		secrets = append(secrets, dc.GitHubAppPrivateKeySecret.Name)
+	}
+	for _, secret := range dc.SubmoduleCredentialSecrets {
+		secrets = append(secrets, secret.Name)
+	}
+	if dc.Vault != nil {
+		if dc.Vault.AppRoleSecret != "" {
+			secrets = append(secrets, dc.Vault.AppRoleSecret)
+		}
+		for _, secret := range dc.Vault.Secrets {
+			secrets = append(secrets, secret.Path)
+		}
+	}
+	return secrets
+}
+
+func volumeSecrets(volumes []v1.Volume) []string {
+	var secrets []string
+	for _, volume := range volumes {
+		if volume.Secret != nil {
+			secrets = append(secrets, volume.Secret.SecretName)
+		}
+		if volume.CSI != nil && volume.CSI.NodePublishSecretRef != nil {
+			secrets = append(secrets, volume.CSI.NodePublishSecretRef.Name)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					secrets = append(secrets, source.Secret.Name)
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+func envSecrets(env []v1.EnvVar) []string {
+	var secrets []string
+	for _, e := range env {
+		if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+			secrets = append(secrets, e.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	return secrets
+}
+
 // ContainsInRepoConfigPath indicates whether the specified list of changed
 // files (repo relative paths) includes a file that might be an inrepo config file.
 //
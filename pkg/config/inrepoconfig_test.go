@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/git/localgit"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/yaml"
 )
 
 var defaultBranch = localgit.DefaultBranch("")
@@ -743,3 +746,133 @@ func TestInRepoConfigClean(t *testing.T) {
 		t.Fatalf("%s should have been deleted", f)
 	}
 }
+
+func TestDefaultAndValidateProwYAMLPolicy(t *testing.T) {
+	const identifier = "org/repo"
+	job := `{"name": "job", "interval": "1h", "spec": {"containers": [{"env": [{"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "token", "key": "token"}}}]}]}}`
+	testCases := []struct {
+		name        string
+		prowYAML    string
+		inRepo      InRepoConfig
+		jobPolicies []JobPolicy
+		expectedErr string
+		validate    func(*ProwYAML) error
+	}{
+		{
+			name:     "secrets are not restricted by default",
+			prowYAML: `{"presubmits": [` + job + `]}`,
+		},
+		{
+			name:     "allowed secret",
+			prowYAML: `{"postsubmits": [` + job + `]}`,
+			inRepo:   InRepoConfig{AllowedSecrets: map[string][]string{"org": {"token"}}},
+		},
+		{
+			name:        "secret is not allowed",
+			prowYAML:    `{"presubmits": [` + job + `]}`,
+			inRepo:      InRepoConfig{AllowedSecrets: map[string][]string{"*": {"other"}}},
+			expectedErr: `job job: secret "token" is not allowed for repository "org/repo"`,
+		},
+		{
+			name:        "secret of a preset is not allowed",
+			prowYAML:    `{"presets": [{"volumes": [{"name": "creds", "secret": {"secretName": "creds"}}]}]}`,
+			inRepo:      InRepoConfig{AllowedSecrets: map[string][]string{"org/repo": {"token"}}},
+			expectedErr: `preset 0: secret "creds" is not allowed for repository "org/repo"`,
+		},
+		{
+			name:        "decoration secret is not allowed",
+			prowYAML:    `{"presubmits": [{"name": "job", "decorate": true, "decoration_config": {"oauth_token_secret": {"name": "admin-token", "key": "token"}}, "spec": {"containers": [{}]}}]}`,
+			inRepo:      InRepoConfig{AllowedSecrets: map[string][]string{"*": {"token"}}},
+			expectedErr: `job job: secret "admin-token" is not allowed for repository "org/repo"`,
+		},
+		{
+			name:        "image pull secret is not allowed",
+			prowYAML:    `{"presubmits": [{"name": "job", "spec": {"imagePullSecrets": [{"name": "registry"}], "containers": [{}]}}]}`,
+			inRepo:      InRepoConfig{AllowedSecrets: map[string][]string{"*": {"token"}}},
+			expectedErr: `job job: secret "registry" is not allowed for repository "org/repo"`,
+		},
+		{
+			name:        "label is not allowed",
+			prowYAML:    `{"presubmits": [{"name": "job", "labels": {"preset-admin": "true"}, "spec": {"containers": [{}]}}]}`,
+			inRepo:      InRepoConfig{AllowedLabels: map[string][]string{"*": {"preset-go"}}},
+			expectedErr: `job job: label "preset-admin" is not allowed for repository "org/repo"`,
+		},
+		{
+			name:        "periodics are not allowed",
+			prowYAML:    `{"periodics": [` + job + `]}`,
+			expectedErr: `periodics are not allowed for repository "org/repo"`,
+		},
+		{
+			name:     "periodics clone their repository",
+			prowYAML: `{"periodics": [` + job + `]}`,
+			inRepo:   InRepoConfig{PeriodicsBranches: map[string]string{"org/repo": "main"}},
+			validate: func(p *ProwYAML) error {
+				if n := len(p.Periodics); n != 1 {
+					return fmt.Errorf("expected one periodic, got %d", n)
+				}
+				expected := []prowapi.Refs{{Org: "org", Repo: "repo", BaseRef: "main"}}
+				if diff := cmp.Diff(expected, p.Periodics[0].ExtraRefs); diff != "" {
+					return fmt.Errorf("extra refs differ from expected (-want +got):\n%s", diff)
+				}
+				return nil
+			},
+		},
+		{
+			name:        "periodics may not define event triggers",
+			prowYAML:    `{"periodics": [{"name": "job", "event_triggers": [{"webhook": {"name": "build"}}], "spec": {"containers": [{}]}}]}`,
+			inRepo:      InRepoConfig{PeriodicsBranches: map[string]string{"org/repo": "main"}},
+			expectedErr: "periodic job may not define event_triggers in the in-repo config",
+		},
+		{
+			name:        "periodics may not redefine central periodics",
+			prowYAML:    `{"periodics": [{"name": "central", "interval": "1h", "spec": {"containers": [{}]}}]}`,
+			inRepo:      InRepoConfig{PeriodicsBranches: map[string]string{"org/repo": "main"}},
+			expectedErr: "periodic central is already defined in the central config",
+		},
+		{
+			name:        "job policy is violated",
+			prowYAML:    `{"presubmits": [` + job + `]}`,
+			jobPolicies: []JobPolicy{{Name: "prod", Orgs: []string{"org"}, Rules: JobPolicyRules{ForbiddenSecrets: []string{"token"}}}},
+			expectedErr: `presubmit "job" of org/repo must not use secret "token" as required by policy "prod"`,
+		},
+		{
+			name:        "job policy applies to secrets of presets",
+			prowYAML:    `{"presets": [{"labels": {"preset-creds": "true"}, "env": [{"name": "CREDS", "valueFrom": {"secretKeyRef": {"name": "creds", "key": "creds"}}}]}], "presubmits": [{"name": "job", "labels": {"preset-creds": "true"}, "spec": {"containers": [{}]}}]}`,
+			jobPolicies: []JobPolicy{{Name: "prod", Rules: JobPolicyRules{ForbiddenSecrets: []string{"creds"}}}},
+			expectedErr: `presubmit "job" of org/repo must not use secret "creds" as required by policy "prod"`,
+		},
+		{
+			name:        "job policy of another org",
+			prowYAML:    `{"presubmits": [` + job + `]}`,
+			jobPolicies: []JobPolicy{{Name: "prod", Orgs: []string{"other"}, Rules: JobPolicyRules{ForbiddenSecrets: []string{"token"}}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.inRepo.AllowedClusters = map[string][]string{"*": {kube.DefaultClusterAlias}}
+			c := &Config{
+				JobConfig:  JobConfig{Periodics: []Periodic{{JobBase: JobBase{Name: "central"}}}},
+				ProwConfig: ProwConfig{InRepoConfig: tc.inRepo, PodNamespace: "my-ns", JobPolicies: tc.jobPolicies},
+			}
+			p := &ProwYAML{}
+			if err := yaml.Unmarshal([]byte(tc.prowYAML), p); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			err := DefaultAndValidateProwYAML(c, p, identifier)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.validate != nil {
+				if err := tc.validate(p); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// JobPolicy is a set of rules that all jobs in its scope have to follow.
+// Checkconfig checks the central job config against the policies. In-repo
+// configs and config extensions that violate them are rejected when they
+// are loaded.
+type JobPolicy struct {
+	// Name identifies the policy in violations.
+	Name string `json:"name"`
+	// Description explains why the policy exists or how to follow it. It is
+	// included in violations.
+	Description string `json:"description,omitempty"`
+	// Orgs and Repos limit the policy to the jobs of these orgs and repos.
+	// Periodics belong to the orgs and repos of their extra_refs. The policy
+	// applies to all jobs if both are empty.
+	Orgs  []string `json:"orgs,omitempty"`
+	Repos []string `json:"repos,omitempty"`
+	// JobTypes limits the policy to jobs of these types.
+	JobTypes []prowapi.ProwJobType `json:"job_types,omitempty"`
+	// Rules are the rules of the policy.
+	Rules JobPolicyRules `json:"rules"`
+}
+
+// JobPolicyRules are the requirements a policy places on jobs.
+type JobPolicyRules struct {
+	// RequireDecoration requires jobs to be decorated.
+	RequireDecoration bool `json:"require_decoration,omitempty"`
+	// ForbiddenSecrets are secrets jobs may neither mount nor reference in
+	// environment variables.
+	ForbiddenSecrets []string `json:"forbidden_secrets,omitempty"`
+	// RequiredResourceRequests are resources, like cpu and memory, that all
+	// containers of the jobs have to request.
+	RequiredResourceRequests []coreapi.ResourceName `json:"required_resource_requests,omitempty"`
+	// RequiredResourceLimits are resources that all containers of the jobs
+	// have to set limits for.
+	RequiredResourceLimits []coreapi.ResourceName `json:"required_resource_limits,omitempty"`
+	// AllowedClusters are the only build clusters jobs may run in.
+	AllowedClusters []string `json:"allowed_clusters,omitempty"`
+}
+
+func (r JobPolicyRules) empty() bool {
+	return !r.RequireDecoration && len(r.ForbiddenSecrets) == 0 && len(r.RequiredResourceRequests) == 0 &&
+		len(r.RequiredResourceLimits) == 0 && len(r.AllowedClusters) == 0
+}
+
+// ValidateJobPolicies validates the policies, which are listed in the given
+// field of the config.
+func ValidateJobPolicies(field string, policies []JobPolicy) error {
+	names := sets.New[string]()
+	for i, p := range policies {
+		if p.Name == "" {
+			return fmt.Errorf("%s[%d] has no name", field, i)
+		}
+		if names.Has(p.Name) {
+			return fmt.Errorf("policy %q is defined more than once", p.Name)
+		}
+		names.Insert(p.Name)
+		for _, jobType := range p.JobTypes {
+			if jobType != prowapi.PresubmitJob && jobType != prowapi.PostsubmitJob && jobType != prowapi.PeriodicJob {
+				return fmt.Errorf("policy %q: invalid job type %q, must be one of %s, %s or %s", p.Name, jobType, prowapi.PresubmitJob, prowapi.PostsubmitJob, prowapi.PeriodicJob)
+			}
+		}
+		for _, repo := range p.Repos {
+			if len(strings.Split(repo, "/")) != 2 {
+				return fmt.Errorf("policy %q: repo %q is not in org/repo format", p.Name, repo)
+			}
+		}
+		if p.Rules.empty() {
+			return fmt.Errorf("policy %q has no rules", p.Name)
+		}
+	}
+	return nil
+}
+
+// policyJob is a job in the form the policies are checked against.
+type policyJob struct {
+	JobBase
+	jobType prowapi.ProwJobType
+	// repos are the org/repos the job belongs to.
+	repos []string
+}
+
+func (j policyJob) String() string {
+	s := fmt.Sprintf("%s %q", j.jobType, j.Name)
+	if j.jobType != prowapi.PeriodicJob {
+		s += fmt.Sprintf(" of %s", j.repos[0])
+	}
+	if j.SourcePath != "" {
+		s += fmt.Sprintf(" (%s)", j.SourcePath)
+	}
+	return s
+}
+
+func policyJobs(c JobConfig) []policyJob {
+	var jobs []policyJob
+	for _, repo := range sets.List(sets.KeySet(c.PresubmitsStatic)) {
+		for _, job := range c.PresubmitsStatic[repo] {
+			jobs = append(jobs, policyJob{JobBase: job.JobBase, jobType: prowapi.PresubmitJob, repos: []string{repo}})
+		}
+	}
+	for _, repo := range sets.List(sets.KeySet(c.PostsubmitsStatic)) {
+		for _, job := range c.PostsubmitsStatic[repo] {
+			jobs = append(jobs, policyJob{JobBase: job.JobBase, jobType: prowapi.PostsubmitJob, repos: []string{repo}})
+		}
+	}
+	for _, job := range c.Periodics {
+		var repos []string
+		for _, ref := range job.ExtraRefs {
+			repos = append(repos, ref.Org+"/"+ref.Repo)
+		}
+		jobs = append(jobs, policyJob{JobBase: job.JobBase, jobType: prowapi.PeriodicJob, repos: repos})
+	}
+	return jobs
+}
+
+// appliesTo determines whether the job is in the scope of the policy.
+func (p JobPolicy) appliesTo(job policyJob) bool {
+	if len(p.JobTypes) > 0 && !sets.New(p.JobTypes...).Has(job.jobType) {
+		return false
+	}
+	if len(p.Orgs) == 0 && len(p.Repos) == 0 {
+		return true
+	}
+	orgs, repos := sets.New(p.Orgs...), sets.New(p.Repos...)
+	for _, repo := range job.repos {
+		if repos.Has(repo) || orgs.Has(strings.Split(repo, "/")[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// violations returns the ways in which the defaulted job violates the policy.
+func (p JobPolicy) violations(job policyJob) []string {
+	var violations []string
+	if p.Rules.RequireDecoration && (job.Decorate == nil || !*job.Decorate) {
+		violations = append(violations, "must set decorate: true")
+	}
+	if len(p.Rules.AllowedClusters) > 0 {
+		cluster := job.Cluster
+		if cluster == "" {
+			cluster = kube.DefaultClusterAlias
+		}
+		if !sets.New(p.Rules.AllowedClusters...).Has(cluster) {
+			violations = append(violations, fmt.Sprintf("must run in one of the clusters %s instead of %q", strings.Join(p.Rules.AllowedClusters, ", "), cluster))
+		}
+	}
+	if job.Spec == nil {
+		return violations
+	}
+	forbidden := sets.New(p.Rules.ForbiddenSecrets...)
+	for _, secret := range sets.List(referencedSecrets(job.Spec).Intersection(forbidden)) {
+		violations = append(violations, fmt.Sprintf("must not use secret %q", secret))
+	}
+	for i, container := range job.Spec.Containers {
+		for _, resource := range p.Rules.RequiredResourceRequests {
+			if _, ok := container.Resources.Requests[resource]; !ok {
+				violations = append(violations, fmt.Sprintf("container %s must request %s", containerName(i, container), resource))
+			}
+		}
+		for _, resource := range p.Rules.RequiredResourceLimits {
+			if _, ok := container.Resources.Limits[resource]; !ok {
+				violations = append(violations, fmt.Sprintf("container %s must set a limit for %s", containerName(i, container), resource))
+			}
+		}
+	}
+	return violations
+}
+
+func containerName(index int, container coreapi.Container) string {
+	if container.Name != "" {
+		return fmt.Sprintf("%q", container.Name)
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+// referencedSecrets returns the secrets mounted or referenced in environment
+// variables by the pod.
+func referencedSecrets(spec *coreapi.PodSpec) sets.Set[string] {
+	secrets := sets.New[string]()
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			secrets.Insert(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					secrets.Insert(source.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, container := range append(append([]coreapi.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				secrets.Insert(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				secrets.Insert(envFrom.SecretRef.Name)
+			}
+		}
+	}
+	return secrets
+}
+
+// CheckJobPolicies checks the defaulted jobs against the policies and returns
+// an error listing every violation.
+func CheckJobPolicies(policies []JobPolicy, c JobConfig) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	var violations []string
+	for _, job := range policyJobs(c) {
+		for _, p := range policies {
+			if !p.appliesTo(job) {
+				continue
+			}
+			for _, violation := range p.violations(job) {
+				message := fmt.Sprintf("%s %s as required by policy %q", job, violation, p.Name)
+				if p.Description != "" {
+					message += ": " + p.Description
+				}
+				violations = append(violations, message)
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("found %d policy violations:\n  %s", len(violations), strings.Join(violations, "\n  "))
+}
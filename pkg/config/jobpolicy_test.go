@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestCheckJobPolicies(t *testing.T) {
+	jobBase := func(name string, modify func(*JobBase)) JobBase {
+		jb := JobBase{
+			Name:       name,
+			SourcePath: "jobs/" + name + ".yaml",
+			Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{
+				Resources: coreapi.ResourceRequirements{
+					Requests: coreapi.ResourceList{coreapi.ResourceCPU: resource.MustParse("1")},
+				},
+			}}},
+			UtilityConfig: UtilityConfig{Decorate: ptr.To(true)},
+		}
+		if modify != nil {
+			modify(&jb)
+		}
+		return jb
+	}
+	undecorated := func(jb *JobBase) { jb.Decorate = nil }
+
+	testCases := []struct {
+		name        string
+		policies    []JobPolicy
+		jobs        JobConfig
+		expectedErr string
+	}{
+		{
+			name:     "compliant jobs",
+			policies: []JobPolicy{{Name: "decorated", Rules: JobPolicyRules{RequireDecoration: true}}},
+			jobs: JobConfig{
+				PresubmitsStatic: map[string][]Presubmit{"acme/widget": {{JobBase: jobBase("unit", nil)}}},
+				Periodics:        []Periodic{{JobBase: jobBase("cleanup", nil)}},
+			},
+		},
+		{
+			name:     "undecorated jobs",
+			policies: []JobPolicy{{Name: "decorated", Description: "Undecorated jobs are deprecated.", Rules: JobPolicyRules{RequireDecoration: true}}},
+			jobs: JobConfig{
+				PresubmitsStatic:  map[string][]Presubmit{"acme/widget": {{JobBase: jobBase("unit", undecorated)}}},
+				PostsubmitsStatic: map[string][]Postsubmit{"acme/widget": {{JobBase: jobBase("push", nil)}}},
+				Periodics:         []Periodic{{JobBase: jobBase("cleanup", undecorated)}},
+			},
+			expectedErr: `found 2 policy violations:
+  presubmit "unit" of acme/widget (jobs/unit.yaml) must set decorate: true as required by policy "decorated": Undecorated jobs are deprecated.
+  periodic "cleanup" (jobs/cleanup.yaml) must set decorate: true as required by policy "decorated": Undecorated jobs are deprecated.`,
+		},
+		{
+			name: "policies only apply to their scope",
+			policies: []JobPolicy{
+				{Name: "acme", Orgs: []string{"acme"}, Rules: JobPolicyRules{RequireDecoration: true}},
+				{Name: "gadget", Repos: []string{"other/gadget"}, Rules: JobPolicyRules{RequireDecoration: true}},
+				{Name: "postsubmits", JobTypes: []prowapi.ProwJobType{prowapi.PostsubmitJob}, Rules: JobPolicyRules{RequireDecoration: true}},
+			},
+			jobs: JobConfig{
+				PresubmitsStatic: map[string][]Presubmit{
+					"acme/widget":  {{JobBase: jobBase("unit", undecorated)}},
+					"other/gadget": {{JobBase: jobBase("lint", undecorated)}},
+					"other/gizmo":  {{JobBase: jobBase("e2e", undecorated)}},
+				},
+				Periodics: []Periodic{
+					{JobBase: jobBase("acme-cleanup", func(jb *JobBase) {
+						undecorated(jb)
+						jb.ExtraRefs = []prowapi.Refs{{Org: "acme", Repo: "infra"}}
+					})},
+					{JobBase: jobBase("cleanup", undecorated)},
+				},
+			},
+			expectedErr: `found 3 policy violations:
+  presubmit "unit" of acme/widget (jobs/unit.yaml) must set decorate: true as required by policy "acme"
+  presubmit "lint" of other/gadget (jobs/lint.yaml) must set decorate: true as required by policy "gadget"
+  periodic "acme-cleanup" (jobs/acme-cleanup.yaml) must set decorate: true as required by policy "acme"`,
+		},
+		{
+			name:     "forbidden secrets",
+			policies: []JobPolicy{{Name: "secrets", Rules: JobPolicyRules{ForbiddenSecrets: []string{"prod-creds", "signing-key"}}}},
+			jobs: JobConfig{
+				PresubmitsStatic: map[string][]Presubmit{"acme/widget": {
+					{JobBase: jobBase("volume", func(jb *JobBase) {
+						jb.Spec.Volumes = []coreapi.Volume{{Name: "creds", VolumeSource: coreapi.VolumeSource{Secret: &coreapi.SecretVolumeSource{SecretName: "prod-creds"}}}}
+					})},
+					{JobBase: jobBase("env", func(jb *JobBase) {
+						jb.Spec.Containers[0].Env = []coreapi.EnvVar{{Name: "KEY", ValueFrom: &coreapi.EnvVarSource{
+							SecretKeyRef: &coreapi.SecretKeySelector{LocalObjectReference: coreapi.LocalObjectReference{Name: "signing-key"}},
+						}}}
+						jb.Spec.Containers[0].EnvFrom = []coreapi.EnvFromSource{{SecretRef: &coreapi.SecretEnvSource{LocalObjectReference: coreapi.LocalObjectReference{Name: "test-creds"}}}}
+					})},
+				}},
+			},
+			expectedErr: `found 2 policy violations:
+  presubmit "volume" of acme/widget (jobs/volume.yaml) must not use secret "prod-creds" as required by policy "secrets"
+  presubmit "env" of acme/widget (jobs/env.yaml) must not use secret "signing-key" as required by policy "secrets"`,
+		},
+		{
+			name: "resources and clusters",
+			policies: []JobPolicy{{Name: "resources", Rules: JobPolicyRules{
+				RequiredResourceRequests: []coreapi.ResourceName{coreapi.ResourceCPU, coreapi.ResourceMemory},
+				RequiredResourceLimits:   []coreapi.ResourceName{coreapi.ResourceMemory},
+				AllowedClusters:          []string{"build"},
+			}}},
+			jobs: JobConfig{
+				PresubmitsStatic: map[string][]Presubmit{"acme/widget": {
+					{JobBase: jobBase("unit", func(jb *JobBase) {
+						jb.Cluster = "build"
+						jb.Spec.Containers[0].Name = "test"
+						jb.Spec.Containers[0].Resources.Requests[coreapi.ResourceMemory] = resource.MustParse("1Gi")
+						jb.Spec.Containers[0].Resources.Limits = coreapi.ResourceList{coreapi.ResourceMemory: resource.MustParse("1Gi")}
+					})},
+					{JobBase: jobBase("lint", nil)},
+				}},
+			},
+			expectedErr: `found 3 policy violations:
+  presubmit "lint" of acme/widget (jobs/lint.yaml) must run in one of the clusters build instead of "default" as required by policy "resources"
+  presubmit "lint" of acme/widget (jobs/lint.yaml) container 0 must request memory as required by policy "resources"
+  presubmit "lint" of acme/widget (jobs/lint.yaml) container 0 must set a limit for memory as required by policy "resources"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckJobPolicies(tc.policies, tc.jobs)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if diff := cmp.Diff(tc.expectedErr, errMsg); diff != "" {
+				t.Errorf("error differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
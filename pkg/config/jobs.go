@@ -29,6 +29,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config/matrix"
 	"sigs.k8s.io/prow/pkg/github"
 )
 
@@ -84,6 +85,72 @@ func mergePreset(preset Preset, labels map[string]string, containers []v1.Contai
 	return nil
 }
 
+// PodTemplate contains pod settings that can be shared by many jobs. Settings
+// of the pod spec of a job take precedence over the ones of its template.
+type PodTemplate struct {
+	// NodeSelector is merged into the node selector of the pod.
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	// Tolerations are added to the tolerations of the pod.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// Affinity is used if the pod does not set an affinity.
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName is used if the pod does not set a priority class.
+	PriorityClassName string `json:"priority_class_name,omitempty"`
+	// Resources are the default resource requests and limits of every
+	// container of the pod. They are used for each resource that a container
+	// does not request or limit itself.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+func mergePodTemplate(template PodTemplate, spec *v1.PodSpec) {
+	for key, value := range template.NodeSelector {
+		if _, ok := spec.NodeSelector[key]; ok {
+			continue
+		}
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		spec.NodeSelector[key] = value
+	}
+	for _, t1 := range template.Tolerations {
+		duplicate := false
+		for _, t2 := range spec.Tolerations {
+			if t1.MatchToleration(&t2) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			spec.Tolerations = append(spec.Tolerations, t1)
+		}
+	}
+	if spec.Affinity == nil && template.Affinity != nil {
+		spec.Affinity = template.Affinity.DeepCopy()
+	}
+	if spec.PriorityClassName == "" {
+		spec.PriorityClassName = template.PriorityClassName
+	}
+	for i := range spec.Containers {
+		resources := &spec.Containers[i].Resources
+		resources.Requests = mergeResourceList(resources.Requests, template.Resources.Requests)
+		resources.Limits = mergeResourceList(resources.Limits, template.Resources.Limits)
+	}
+}
+
+// mergeResourceList adds the defaults for all resources missing from list.
+func mergeResourceList(list, defaults v1.ResourceList) v1.ResourceList {
+	for name, quantity := range defaults {
+		if _, ok := list[name]; ok {
+			continue
+		}
+		if list == nil {
+			list = v1.ResourceList{}
+		}
+		list[name] = quantity.DeepCopy()
+	}
+	return list
+}
+
 // +k8s:deepcopy-gen=true
 
 // JobBase contains attributes common to all job types
@@ -109,10 +176,16 @@ type JobBase struct {
 	// If this field is unspecified or false, a new pod will be created to replace
 	// the evicted one.
 	ErrorOnEviction bool `json:"error_on_eviction,omitempty"`
+	// Retry configures automatic re-runs of the job by plank when it ends
+	// in a retriable state, e.g. because its pod was evicted.
+	Retry *prowapi.RetryConfig `json:"retry,omitempty"`
 	// SourcePath contains the path where this job is defined
 	SourcePath string `json:"-"`
 	// Spec is the Kubernetes pod spec used if Agent is kubernetes.
 	Spec *v1.PodSpec `json:"spec,omitempty"`
+	// PodTemplate is the name of a pod template of the plank config that is
+	// merged into Spec when the pod is created.
+	PodTemplate string `json:"pod_template,omitempty"`
 	// PipelineRunSpec is the tekton pipeline spec used if Agent is tekton-pipeline.
 	PipelineRunSpec *pipelinev1.PipelineRunSpec `json:"pipeline_run_spec,omitempty"`
 	// TektonPipelineRunSpec is the versioned tekton pipeline spec used if Agent is tekton-pipeline.
@@ -123,6 +196,9 @@ type JobBase struct {
 	ReporterConfig *prowapi.ReporterConfig `json:"reporter_config,omitempty"`
 	// RerunAuthConfig specifies who can rerun the job
 	RerunAuthConfig *prowapi.RerunAuthConfig `json:"rerun_auth_config,omitempty"`
+	// RerunOverrides lists the changes users that may rerun the job can make
+	// to it when rerunning it from Deck.
+	RerunOverrides *prowapi.RerunOverrides `json:"rerun_overrides,omitempty"`
 	// Hidden defines if the job is hidden. If set to `true`, only Deck instances
 	// that have the flag `--hiddenOnly=true or `--show-hidden=true` set will show it.
 	// Presubmits and Postsubmits can also be set to hidden by
@@ -135,6 +211,14 @@ type JobBase struct {
 	// Works in parallel with MaxConcurrency and the limit is selected from the
 	// minimal setting of those two fields.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+	// PriorityClass is the name of one of plank's priority_classes. Jobs of a
+	// higher priority start first and may preempt jobs of a lower priority when
+	// max_concurrency or the capacity of their job queue is exhausted.
+	PriorityClass string `json:"priority_class,omitempty"`
+	// Matrix expands the job into one job per combination of the values of
+	// its axes when the config is loaded. The values are referred to with
+	// ${matrix.<axis>} and appended to the name of the expanded jobs.
+	Matrix *matrix.Matrix `json:"matrix,omitempty"`
 
 	UtilityConfig
 }
@@ -204,6 +288,12 @@ type Presubmit struct {
 	// every single push from all PRs.
 	RunBeforeMerge bool `json:"run_before_merge,omitempty"`
 
+	// RunAfterSuccess lists presubmits of the same repository that have to
+	// succeed on the same commit before this job is triggered automatically.
+	// The job is started by the dependent-jobs controller once all of them
+	// succeeded. It can still be triggered explicitly at any time.
+	RunAfterSuccess []string `json:"run_after_success,omitempty"`
+
 	Brancher
 
 	RegexpChangeMatcher
@@ -256,6 +346,11 @@ type Postsubmit struct {
 	// if this field is not provided, which is the opposite of what we want.
 	AlwaysRun *bool `json:"always_run,omitempty"`
 
+	// RunAfterSuccess lists postsubmits of the same repository that have to
+	// succeed on the same commit before this job is triggered. The job is
+	// started by the dependent-jobs controller once all of them succeeded.
+	RunAfterSuccess []string `json:"run_after_success,omitempty"`
+
 	RegexpChangeMatcher
 
 	Brancher
@@ -281,9 +376,65 @@ type Periodic struct {
 	Cron string `json:"cron,omitempty"`
 	// Tags for config entries
 	Tags []string `json:"tags,omitempty"`
+	// EventTriggers trigger the job on external events in addition to its
+	// cron or interval. Periodics with event triggers may omit cron and
+	// interval to only run on events.
+	EventTriggers []PeriodicEventTrigger `json:"event_triggers,omitempty"`
+	// RunMissed determines which of the runs of a cron periodic that
+	// horologium missed, e.g. because it was down, are created once it
+	// notices. One of `none` (default), `latest` or `all`.
+	RunMissed RunMissedPolicy `json:"run_missed,omitempty"`
+	// Jitter delays every run of a cron periodic by up to this duration to
+	// spread out periodics scheduled at the same time. The delay is derived
+	// from the name of the job, so it is the same for every run.
+	Jitter string `json:"jitter,omitempty"`
 
 	interval         time.Duration
 	minimum_interval time.Duration
+	jitter           time.Duration
+}
+
+// RunMissedPolicy determines which missed runs of a cron periodic are created.
+type RunMissedPolicy string
+
+const (
+	// RunMissedNone skips missed runs.
+	RunMissedNone RunMissedPolicy = "none"
+	// RunMissedLatest creates a single run for the most recent missed run.
+	RunMissedLatest RunMissedPolicy = "latest"
+	// RunMissedAll creates a run for every missed run.
+	RunMissedAll RunMissedPolicy = "all"
+)
+
+// PeriodicEventTrigger triggers a periodic on external events. Exactly one
+// event source must be set.
+type PeriodicEventTrigger struct {
+	// PubSub triggers the job for every message published to a Pub/Sub
+	// subscription.
+	PubSub *PubSubEventSource `json:"pubsub,omitempty"`
+	// Webhook triggers the job for every authenticated POST request to
+	// horologium's /webhook/<name> endpoint.
+	Webhook *WebhookEventSource `json:"webhook,omitempty"`
+	// Env maps environment variables set in the containers of the job to
+	// fields of the event payload. The payload is parsed as JSON and fields
+	// are referenced by their dot separated path, e.g. `image.tag`. Pub/Sub
+	// message attributes are referenced as `attributes.<name>`. Variables
+	// of fields missing in the payload are not set.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// PubSubEventSource is a Pub/Sub subscription that triggers periodics.
+type PubSubEventSource struct {
+	// Project is the GCP project of the subscription.
+	Project string `json:"project"`
+	// Subscription is the ID of the subscription.
+	Subscription string `json:"subscription"`
+}
+
+// WebhookEventSource is a horologium webhook that triggers periodics.
+type WebhookEventSource struct {
+	// Name is the name of the webhook, used in its URL path.
+	Name string `json:"name"`
 }
 
 // JenkinsSpec holds optional Jenkins job config
@@ -293,6 +444,12 @@ type JenkinsSpec struct {
 	GitHubBranchSourceJob bool `json:"github_branch_source_job,omitempty"`
 }
 
+// OnlyTriggeredByEvents returns true if the periodic has event triggers but
+// neither a cron nor an interval.
+func (p *Periodic) OnlyTriggeredByEvents() bool {
+	return len(p.EventTriggers) > 0 && p.Cron == "" && p.Interval == "" && p.MinimumInterval == ""
+}
+
 // SetInterval updates interval, the frequency duration it runs.
 func (p *Periodic) SetInterval(d time.Duration) {
 	p.interval = d
@@ -313,6 +470,22 @@ func (p *Periodic) GetMinimumInterval() time.Duration {
 	return p.minimum_interval
 }
 
+// SetJitter updates jitter, the maximum delay of its runs.
+func (p *Periodic) SetJitter(d time.Duration) {
+	p.jitter = d
+}
+
+// GetJitter returns jitter, the maximum delay of its runs.
+func (p *Periodic) GetJitter() time.Duration {
+	return p.jitter
+}
+
+// Scheduled returns true if horologium tracks the cron schedule of the
+// periodic itself to catch up on missed runs or delay runs by a jitter.
+func (p *Periodic) Scheduled() bool {
+	return p.Cron != "" && ((p.RunMissed != "" && p.RunMissed != RunMissedNone) || p.Jitter != "")
+}
+
 // +k8s:deepcopy-gen=true
 
 // Brancher is for shared code between jobs that only run against certain
@@ -340,8 +513,15 @@ type RegexpChangeMatcher struct {
 	// If all files in the changeset match this regex, the job will be skipped.
 	// In other words, this is the negation of RunIfChanged.
 	// Additionally AlwaysRun is mutually exclusive with SkipIfOnlyChanged.
-	SkipIfOnlyChanged string          `json:"skip_if_only_changed,omitempty"`
-	reChanges         *CopyableRegexp // from RunIfChanged xor SkipIfOnlyChanged
+	SkipIfOnlyChanged string `json:"skip_if_only_changed,omitempty"`
+	// RunIfChangedContent defines a regex matched against the lines added or removed
+	// in the files that match RunIfChanged. If set, the job is only triggered
+	// automatically if any of these lines matches, e.g. `^type .* struct`.
+	// Files for which no diff is available, like binary files, are considered to match.
+	// Requires RunIfChanged and is only evaluated for presubmits by the trigger plugin.
+	RunIfChangedContent string          `json:"run_if_changed_content,omitempty"`
+	reChanges           *CopyableRegexp // from RunIfChanged xor SkipIfOnlyChanged
+	reContent           *CopyableRegexp // from RunIfChangedContent
 }
 
 type Reporter struct {
@@ -435,6 +615,33 @@ func (cm RegexpChangeMatcher) RunsAgainstChanges(changes []string) bool {
 	return false
 }
 
+// RunsAgainstContent returns true if run_if_changed_content is unset or if any line
+// added or removed in a file matching the run_if_changed regex matches it.
+func (cm RegexpChangeMatcher) RunsAgainstContent(changes []github.PullRequestChange) bool {
+	if cm.RunIfChangedContent == "" {
+		return true
+	}
+	for _, change := range changes {
+		if !cm.reChanges.MatchString(change.Filename) {
+			continue
+		}
+		// GitHub omits the patch of binary files and of very large diffs,
+		// we cannot rule out that they match.
+		if change.Patch == "" {
+			return true
+		}
+		for _, line := range strings.Split(change.Patch, "\n") {
+			if !strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "-") {
+				continue
+			}
+			if cm.reContent.MatchString(line[1:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // CouldRun determines if the postsubmit could run against a specific
 // base ref
 func (ps Postsubmit) CouldRun(baseRef string) bool {
@@ -503,6 +710,18 @@ func (ps Presubmit) NeedsExplicitTrigger() bool {
 	return !ps.AlwaysRun && !ps.RegexpChangeMatcher.CouldRun()
 }
 
+// RunsAfterSuccess determines if the postsubmit is only triggered after other
+// postsubmits succeeded.
+func (ps Postsubmit) RunsAfterSuccess() bool {
+	return len(ps.RunAfterSuccess) > 0
+}
+
+// RunsAfterSuccess determines if the presubmit is only triggered automatically
+// after other presubmits succeeded.
+func (ps Presubmit) RunsAfterSuccess() bool {
+	return len(ps.RunAfterSuccess) > 0
+}
+
 // TriggerMatches returns true if the comment body should trigger this presubmit.
 //
 // This is usually a /test foo string.
@@ -522,6 +741,9 @@ type githubClient interface {
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
 }
 
+// ChangedContentProvider returns the changes of a pull request including their patches.
+type ChangedContentProvider func() ([]github.PullRequestChange, error)
+
 // NewGitHubDeferredChangedFilesProvider uses a closure to lazily retrieve the file changes only if they are needed.
 // We only have to fetch the changes if there is at least one RunIfChanged/SkipIfOnlyChanged job that is not being
 // force run (due to a `/retest` after a failure or because it is explicitly triggered with `/test foo`).
@@ -567,6 +789,10 @@ type UtilityConfig struct {
 	// SkipFetchHead tells prow to avoid a git fetch <remote> call.
 	// The git fetch <remote> <BaseRef> call occurs regardless.
 	SkipFetchHead bool `json:"skip_fetch_head,omitempty"`
+	// FetchLFS determines if Git LFS objects are fetched for the
+	// repository and its submodules. Requires git-lfs in the
+	// clonerefs image.
+	FetchLFS bool `json:"fetch_lfs,omitempty"`
 
 	// ExtraRefs are auxiliary repositories that
 	// need to be cloned, determined from config
@@ -707,5 +933,6 @@ func ClearCompiledRegexes(presubmits []Presubmit) {
 		presubmits[i].Brancher.re = nil
 		presubmits[i].Brancher.reSkip = nil
 		presubmits[i].RegexpChangeMatcher.reChanges = nil
+		presubmits[i].RegexpChangeMatcher.reContent = nil
 	}
 }
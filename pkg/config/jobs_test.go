@@ -21,9 +21,12 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/github"
 )
 
 func TestRunIfChangedPresubmits(t *testing.T) {
@@ -58,6 +61,76 @@ func TestRunIfChangedPresubmits(t *testing.T) {
 	}
 }
 
+func TestRunIfChangedContentPresubmits(t *testing.T) {
+	PresubmitsStatic := []Presubmit{
+		{
+			JobBase: JobBase{
+				Name: "api compatibility",
+			},
+			RegexpChangeMatcher: RegexpChangeMatcher{
+				RunIfChanged:        `^pkg/apis/`,
+				RunIfChangedContent: `^type .* struct`,
+			},
+		},
+	}
+	if err := SetPresubmitRegexes(PresubmitsStatic); err != nil {
+		t.Fatalf("failed to set presubmit regexes: %v", err)
+	}
+	ps := PresubmitsStatic[0]
+	var testcases = []struct {
+		name     string
+		changes  []github.PullRequestChange
+		expected bool
+	}{
+		{
+			name:     "no changes",
+			expected: false,
+		},
+		{
+			name: "added line matches",
+			changes: []github.PullRequestChange{
+				{Filename: "pkg/apis/types.go", Patch: "@@ -1,2 +1,3 @@\n package apis\n+type Foo struct {\n }"},
+			},
+			expected: true,
+		},
+		{
+			name: "removed line matches",
+			changes: []github.PullRequestChange{
+				{Filename: "pkg/apis/types.go", Patch: "@@ -1,3 +1,2 @@\n package apis\n-type Foo struct {\n }"},
+			},
+			expected: true,
+		},
+		{
+			name: "only context line matches",
+			changes: []github.PullRequestChange{
+				{Filename: "pkg/apis/types.go", Patch: "@@ -1,3 +1,4 @@\n type Foo struct {\n+\tBar string\n }"},
+			},
+			expected: false,
+		},
+		{
+			name: "matching line in file not matching run_if_changed",
+			changes: []github.PullRequestChange{
+				{Filename: "pkg/util/types.go", Patch: "@@ -1,2 +1,3 @@\n package util\n+type Foo struct {\n }"},
+			},
+			expected: false,
+		},
+		{
+			name: "patch of matching file is not available",
+			changes: []github.PullRequestChange{
+				{Filename: "pkg/apis/testdata/types.bin"},
+			},
+			expected: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := ps.RunsAgainstContent(tc.changes); actual != tc.expected {
+				t.Errorf("wrong RunsAgainstContent result. Got %v, expected %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
 // TestRunIfChangedPostsubmits is identical to TestRunIfChangedPresubmits.
 func TestRunIfChangedPostsubmits(t *testing.T) {
 	PostsubmitsStatic := []Postsubmit{
@@ -394,6 +467,149 @@ func TestRunAgainstBranch(t *testing.T) {
 	}
 }
 
+func TestExpandPodTemplate(t *testing.T) {
+	templates := map[string]PodTemplate{
+		"gpu-large": {
+			NodeSelector: map[string]string{"pool": "gpu", "zone": "a"},
+			Tolerations:  []coreapi.Toleration{{Key: "gpu", Operator: coreapi.TolerationOpExists, Effect: coreapi.TaintEffectNoSchedule}},
+			Affinity:     &coreapi.Affinity{NodeAffinity: &coreapi.NodeAffinity{}},
+			Resources: coreapi.ResourceRequirements{
+				Requests: coreapi.ResourceList{coreapi.ResourceCPU: resource.MustParse("8"), coreapi.ResourceMemory: resource.MustParse("32Gi")},
+				Limits:   coreapi.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			},
+			PriorityClassName: "ci",
+		},
+	}
+	tcs := []struct {
+		name     string
+		template string
+		pod      *coreapi.PodSpec
+
+		expectedErr string
+		expected    *coreapi.PodSpec
+	}{
+		{
+			name:     "no template",
+			pod:      &coreapi.PodSpec{Containers: []coreapi.Container{{}}},
+			expected: &coreapi.PodSpec{Containers: []coreapi.Container{{}}},
+		},
+		{
+			name:     "template is expanded",
+			template: "gpu-large",
+			pod:      &coreapi.PodSpec{Containers: []coreapi.Container{{}, {}}},
+			expected: &coreapi.PodSpec{
+				Containers: []coreapi.Container{
+					{Resources: templates["gpu-large"].Resources},
+					{Resources: templates["gpu-large"].Resources},
+				},
+				NodeSelector:      map[string]string{"pool": "gpu", "zone": "a"},
+				Tolerations:       templates["gpu-large"].Tolerations,
+				Affinity:          &coreapi.Affinity{NodeAffinity: &coreapi.NodeAffinity{}},
+				PriorityClassName: "ci",
+			},
+		},
+		{
+			name:     "pod settings take precedence",
+			template: "gpu-large",
+			pod: &coreapi.PodSpec{
+				Containers: []coreapi.Container{{Resources: coreapi.ResourceRequirements{
+					Requests: coreapi.ResourceList{coreapi.ResourceCPU: resource.MustParse("2")},
+				}}},
+				NodeSelector:      map[string]string{"zone": "b"},
+				Tolerations:       templates["gpu-large"].Tolerations,
+				Affinity:          &coreapi.Affinity{PodAffinity: &coreapi.PodAffinity{}},
+				PriorityClassName: "urgent",
+			},
+			expected: &coreapi.PodSpec{
+				Containers: []coreapi.Container{{Resources: coreapi.ResourceRequirements{
+					Requests: coreapi.ResourceList{coreapi.ResourceCPU: resource.MustParse("2"), coreapi.ResourceMemory: resource.MustParse("32Gi")},
+					Limits:   coreapi.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+				}}},
+				NodeSelector:      map[string]string{"pool": "gpu", "zone": "b"},
+				Tolerations:       templates["gpu-large"].Tolerations,
+				Affinity:          &coreapi.Affinity{PodAffinity: &coreapi.PodAffinity{}},
+				PriorityClassName: "urgent",
+			},
+		},
+		{
+			name:        "unknown template",
+			template:    "tiny",
+			pod:         &coreapi.PodSpec{},
+			expectedErr: `unknown pod template "tiny"`,
+		},
+		{
+			name:        "no pod spec",
+			template:    "gpu-large",
+			expectedErr: `pod template "gpu-large" is referenced by a job without a pod spec`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{Spec: prowapi.ProwJobSpec{PodTemplate: tc.template, PodSpec: tc.pod}}
+			original := pj.DeepCopy()
+			expanded, err := Plank{PodTemplates: templates}.ExpandPodTemplate(pj)
+			var actualErr string
+			if err != nil {
+				actualErr = err.Error()
+			}
+			if actualErr != tc.expectedErr {
+				t.Fatalf("expected error %q, got %q", tc.expectedErr, actualErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, expanded.Spec.PodSpec); diff != "" {
+				t.Errorf("pod spec differs from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, &pj); diff != "" {
+				t.Errorf("expanding the template modified the ProwJob (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePodTemplate(t *testing.T) {
+	templates := map[string]PodTemplate{"gpu-large": {}}
+	tcs := []struct {
+		name     string
+		template string
+		pod      *coreapi.PodSpec
+
+		expectedErr string
+	}{
+		{
+			name: "no template",
+		},
+		{
+			name:     "known template",
+			template: "gpu-large",
+			pod:      &coreapi.PodSpec{},
+		},
+		{
+			name:        "unknown template",
+			template:    "tiny",
+			pod:         &coreapi.PodSpec{},
+			expectedErr: `job foo references unknown pod template "tiny"`,
+		},
+		{
+			name:        "no pod spec",
+			template:    "gpu-large",
+			expectedErr: `job foo references pod template "gpu-large" but has no pod spec`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var actualErr string
+			if err := validatePodTemplate("foo", tc.template, tc.pod, templates); err != nil {
+				actualErr = err.Error()
+			}
+			if actualErr != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, actualErr)
+			}
+		})
+	}
+}
+
 func TestMergePreset(t *testing.T) {
 	tcs := []struct {
 		name      string
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/labels"
+)
+
+const (
+	defaultLifecycleStaleAfter  = 90 * 24 * time.Hour
+	defaultLifecycleRottenAfter = 30 * 24 * time.Hour
+	defaultLifecycleCloseAfter  = 30 * 24 * time.Hour
+
+	// DefaultLifecycleStaleComment is the default comment posted when an
+	// issue or pull request is marked as stale.
+	DefaultLifecycleStaleComment = `This {{.Kind}} has not been updated in {{.Inactive}} and is now marked as stale.
+It will be marked as rotten after {{.Next}} of inactivity.
+
+- Comment ` + "`/remove-lifecycle stale`" + ` to mark it as fresh
+- Comment ` + "`/lifecycle frozen`" + ` to exempt it from the lifecycle`
+	// DefaultLifecycleRottenComment is the default comment posted when a
+	// stale issue or pull request is marked as rotten.
+	DefaultLifecycleRottenComment = `This {{.Kind}} has not been updated in {{.Inactive}} since it was marked as stale and is now marked as rotten.
+It will be closed after {{.Next}} of inactivity.
+
+- Comment ` + "`/remove-lifecycle rotten`" + ` to mark it as fresh
+- Comment ` + "`/lifecycle frozen`" + ` to exempt it from the lifecycle`
+	// DefaultLifecycleCloseComment is the default comment posted when a
+	// rotten issue or pull request is closed.
+	DefaultLifecycleCloseComment = `This {{.Kind}} has not been updated in {{.Inactive}} since it was marked as rotten and is now closed.
+
+- Comment ` + "`/reopen`" + ` to reopen it`
+)
+
+// LifecycleManager configures the lifecycle-manager, which marks inactive
+// issues and pull requests as stale, then as rotten and finally closes them.
+type LifecycleManager struct {
+	// Policies maps an org or a repo in org/repo notation to the lifecycle
+	// policy of its open issues and pull requests. The policy of a repo takes
+	// precedence over the policy of its org. Only the issues and pull
+	// requests of the configured orgs and repos are managed.
+	Policies map[string]*LifecyclePolicy `json:"policies,omitempty"`
+}
+
+// LifecyclePolicy configures when issues and pull requests transition through
+// the lifecycle and what is commented on each transition.
+type LifecyclePolicy struct {
+	// StaleAfter is how long an issue or pull request may be inactive before
+	// it is marked as stale. Defaults to 90 days.
+	StaleAfter *metav1.Duration `json:"stale_after,omitempty"`
+	// RottenAfter is how long a stale issue or pull request may be inactive
+	// before it is marked as rotten. Defaults to 30 days.
+	RottenAfter *metav1.Duration `json:"rotten_after,omitempty"`
+	// CloseAfter is how long a rotten issue or pull request may be inactive
+	// before it is closed. Defaults to 30 days.
+	CloseAfter *metav1.Duration `json:"close_after,omitempty"`
+	// ExemptLabels are the labels that exempt issues and pull requests from
+	// the lifecycle. Defaults to "lifecycle/frozen".
+	ExemptLabels []string `json:"exempt_labels,omitempty"`
+	// StaleComment, RottenComment and CloseComment are the templates of the
+	// comments posted on each transition. They are executed on a
+	// LifecycleCommentData and default to a generic explanation of the
+	// transition.
+	StaleComment  string `json:"stale_comment,omitempty"`
+	RottenComment string `json:"rotten_comment,omitempty"`
+	CloseComment  string `json:"close_comment,omitempty"`
+}
+
+// LifecycleCommentData is the data the comment templates of a lifecycle
+// policy are executed on.
+type LifecycleCommentData struct {
+	Org    string
+	Repo   string
+	Number int
+	// Kind is either "issue" or "PR".
+	Kind string
+	// Inactive is how long the issue or pull request has been inactive, e.g. "90d".
+	Inactive string
+	// Next is how long until the next transition, empty when closing.
+	Next string
+}
+
+// PolicyFor returns the lifecycle policy of a repo and the key it is
+// configured under, or nil if the repo is not managed.
+func (l *LifecycleManager) PolicyFor(org, repo string) (string, *LifecyclePolicy) {
+	for _, key := range []string{org + "/" + repo, org} {
+		if policy := l.Policies[key]; policy != nil {
+			return key, policy
+		}
+	}
+	return "", nil
+}
+
+// LifecycleComment executes the comment template of a transition.
+func LifecycleComment(tmpl string, data LifecycleCommentData) (string, error) {
+	t, err := template.New("comment").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DefaultAndValidate defaults the lifecycle policies and validates them.
+func (l *LifecycleManager) DefaultAndValidate() error {
+	var errs []error
+	for key, policy := range l.Policies {
+		if policy == nil {
+			policy = &LifecyclePolicy{}
+			l.Policies[key] = policy
+		}
+		if key == "" || strings.Count(key, "/") > 1 {
+			errs = append(errs, fmt.Errorf("lifecycle_manager.policies: %q is neither an org nor a repo in org/repo notation", key))
+			continue
+		}
+		for _, d := range []struct {
+			name     string
+			duration *metav1.Duration
+		}{
+			{name: "stale_after", duration: policy.StaleAfter},
+			{name: "rotten_after", duration: policy.RottenAfter},
+			{name: "close_after", duration: policy.CloseAfter},
+		} {
+			if d.duration != nil && d.duration.Duration <= 0 {
+				errs = append(errs, fmt.Errorf("lifecycle_manager.policies[%s].%s must be positive", key, d.name))
+			}
+		}
+		if policy.StaleAfter == nil {
+			policy.StaleAfter = &metav1.Duration{Duration: defaultLifecycleStaleAfter}
+		}
+		if policy.RottenAfter == nil {
+			policy.RottenAfter = &metav1.Duration{Duration: defaultLifecycleRottenAfter}
+		}
+		if policy.CloseAfter == nil {
+			policy.CloseAfter = &metav1.Duration{Duration: defaultLifecycleCloseAfter}
+		}
+		if len(policy.ExemptLabels) == 0 {
+			policy.ExemptLabels = []string{labels.LifecycleFrozen}
+		}
+		if policy.StaleComment == "" {
+			policy.StaleComment = DefaultLifecycleStaleComment
+		}
+		if policy.RottenComment == "" {
+			policy.RottenComment = DefaultLifecycleRottenComment
+		}
+		if policy.CloseComment == "" {
+			policy.CloseComment = DefaultLifecycleCloseComment
+		}
+		for _, c := range []struct {
+			name     string
+			template string
+		}{
+			{name: "stale_comment", template: policy.StaleComment},
+			{name: "rotten_comment", template: policy.RottenComment},
+			{name: "close_comment", template: policy.CloseComment},
+		} {
+			if _, err := LifecycleComment(c.template, LifecycleCommentData{}); err != nil {
+				errs = append(errs, fmt.Errorf("lifecycle_manager.policies[%s].%s is not a valid template: %w", key, c.name, err))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLifecycleManagerDefaultAndValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		policies    map[string]*LifecyclePolicy
+		expectedErr bool
+	}{
+		{
+			name:     "valid policies",
+			policies: map[string]*LifecyclePolicy{"acme": nil, "acme/widget": {StaleAfter: &metav1.Duration{Duration: time.Hour}, StaleComment: "Stale {{.Kind}}"}},
+		},
+		{
+			name:        "invalid key",
+			policies:    map[string]*LifecyclePolicy{"acme/widget/extra": {}},
+			expectedErr: true,
+		},
+		{
+			name:        "negative duration",
+			policies:    map[string]*LifecyclePolicy{"acme": {CloseAfter: &metav1.Duration{Duration: -time.Hour}}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid template",
+			policies:    map[string]*LifecyclePolicy{"acme": {RottenComment: "{{.Kind"}},
+			expectedErr: true,
+		},
+		{
+			name:        "template with unknown field",
+			policies:    map[string]*LifecyclePolicy{"acme": {CloseComment: "{{.Author}}"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := LifecycleManager{Policies: tc.policies}
+			if err := l.DefaultAndValidate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLifecycleManagerPolicyFor(t *testing.T) {
+	l := LifecycleManager{Policies: map[string]*LifecyclePolicy{"acme": nil, "acme/widget": {StaleAfter: &metav1.Duration{Duration: time.Hour}}}}
+	if err := l.DefaultAndValidate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if key, policy := l.PolicyFor("acme", "widget"); key != "acme/widget" || policy.StaleAfter.Duration != time.Hour {
+		t.Errorf("expected the repo policy, got %q: %+v", key, policy)
+	}
+	if key, policy := l.PolicyFor("acme", "gadget"); key != "acme" || policy.StaleAfter.Duration != defaultLifecycleStaleAfter || policy.StaleComment != DefaultLifecycleStaleComment {
+		t.Errorf("expected the defaulted org policy, got %q: %+v", key, policy)
+	}
+	if key, policy := l.PolicyFor("other", "widget"); key != "" || policy != nil {
+		t.Errorf("expected no policy, got %q: %+v", key, policy)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config/matrix"
+)
+
+// expandMatrices replaces the jobs that have a matrix with the jobs it
+// expands to.
+func (jc *JobConfig) expandMatrices() error {
+	var errs []error
+	for repo, jobs := range jc.PresubmitsStatic {
+		expanded, err := expandMatrixJobs(jobs, presubmitMatrixFields)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		jc.PresubmitsStatic[repo] = expanded
+	}
+	for repo, jobs := range jc.PostsubmitsStatic {
+		expanded, err := expandMatrixJobs(jobs, postsubmitMatrixFields)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		jc.PostsubmitsStatic[repo] = expanded
+	}
+	expanded, err := expandMatrixJobs(jc.Periodics, periodicMatrixFields)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	jc.Periodics = expanded
+	return utilerrors.NewAggregate(errs)
+}
+
+func presubmitMatrixFields(p *Presubmit) (*JobBase, *string) {
+	return &p.JobBase, &p.Context
+}
+
+func postsubmitMatrixFields(p *Postsubmit) (*JobBase, *string) {
+	return &p.JobBase, &p.Context
+}
+
+func periodicMatrixFields(p *Periodic) (*JobBase, *string) {
+	return &p.JobBase, nil
+}
+
+// expandMatrixJobs expands the jobs that have a matrix and keeps the others.
+// fields returns the base of a job and its context, if it has one.
+func expandMatrixJobs[T any](jobs []T, fields func(*T) (*JobBase, *string)) ([]T, error) {
+	if jobs == nil {
+		return nil, nil
+	}
+	var errs []error
+	expanded := make([]T, 0, len(jobs))
+	for _, job := range jobs {
+		if base, _ := fields(&job); base.Matrix == nil {
+			expanded = append(expanded, job)
+			continue
+		}
+		jobs, err := expandMatrixJob(job, fields)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		expanded = append(expanded, jobs...)
+	}
+	return expanded, utilerrors.NewAggregate(errs)
+}
+
+// expandMatrixJob returns one job per combination of the matrix of the job,
+// with the references to the axes replaced by their values. The values are
+// appended to the name and context unless these refer to the matrix, and
+// annotations link the jobs to the definition they were expanded from.
+func expandMatrixJob[T any](job T, fields func(*T) (*JobBase, *string)) ([]T, error) {
+	base, context := fields(&job)
+	m, name, sourcePath := base.Matrix, base.Name, base.SourcePath
+	var originalContext string
+	if context != nil {
+		originalContext = *context
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("job %s has an invalid matrix: %w", name, err)
+	}
+	base.Matrix = nil
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job %s: %w", name, err)
+	}
+	if unknown := m.UnknownReferences(string(raw)); len(unknown) > 0 {
+		return nil, fmt.Errorf("job %s refers to unknown matrix axes: %s", name, strings.Join(unknown, ", "))
+	}
+
+	var jobs []T
+	for _, combination := range m.Combinations() {
+		var expanded T
+		if err := json.Unmarshal([]byte(combination.Expand(string(raw))), &expanded); err != nil {
+			return nil, fmt.Errorf("failed to expand job %s for %s: %w", name, combination, err)
+		}
+		base, context := fields(&expanded)
+		base.SourcePath = sourcePath
+		if base.Name == name {
+			base.Name += "-" + combination.Suffix()
+		}
+		if context != nil && *context != "" && *context == originalContext {
+			*context += "-" + combination.Suffix()
+		}
+		if base.Annotations == nil {
+			base.Annotations = map[string]string{}
+		}
+		base.Annotations[matrix.SourceAnnotation] = name
+		base.Annotations[matrix.CombinationAnnotation] = combination.String()
+		jobs = append(jobs, expanded)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// SourceAnnotation is set on expanded jobs to the name of the job
+	// definition they were expanded from.
+	SourceAnnotation = "prow.k8s.io/matrix-source"
+	// CombinationAnnotation is set on expanded jobs to the combination of
+	// values they were expanded for, e.g. "go=1.22,platform=linux".
+	CombinationAnnotation = "prow.k8s.io/matrix-combination"
+
+	// MaxCombinations bounds the number of jobs a single definition expands to.
+	MaxCombinations = 256
+)
+
+var (
+	axisNameRe = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	// Values become part of job names, so they are restricted to the
+	// characters allowed in those.
+	valueRe = regexp.MustCompile(`^[A-Za-z0-9-._]+$`)
+	// referenceRe matches the references to values in a job definition.
+	referenceRe = regexp.MustCompile(`\$\{matrix\.([^}]*)\}`)
+)
+
+// Matrix is a set of axes along which a job definition is expanded.
+type Matrix struct {
+	// Axes lists the axes in the order their values appear in the names of
+	// the expanded jobs.
+	Axes []Axis `json:"axes"`
+	// Exclude lists combinations that are not expanded. A combination is
+	// excluded if it has all values of an entry, which maps axes to values.
+	Exclude []map[string]string `json:"exclude,omitempty"`
+}
+
+// Axis is a dimension of a matrix, e.g. the Go version.
+type Axis struct {
+	// Name is used to refer to the value of the axis with ${matrix.<name>}
+	// in the job definition.
+	Name string `json:"name"`
+	// Values of the axis. They are appended to the name of the expanded jobs.
+	Values []string `json:"values"`
+}
+
+// AxisValue is the value of an axis in a combination.
+type AxisValue struct {
+	Axis  string
+	Value string
+}
+
+// Combination holds a value of every axis of a matrix, in the order of the
+// axes.
+type Combination []AxisValue
+
+// Validate returns an error if the matrix cannot be expanded.
+func (m *Matrix) Validate() error {
+	if len(m.Axes) == 0 {
+		return errors.New("a matrix needs at least one axis")
+	}
+	var errs []error
+	values := map[string]sets.Set[string]{}
+	for _, axis := range m.Axes {
+		if !axisNameRe.MatchString(axis.Name) {
+			errs = append(errs, fmt.Errorf("axis name %q must match %s", axis.Name, axisNameRe))
+		}
+		if _, seen := values[axis.Name]; seen {
+			errs = append(errs, fmt.Errorf("axis %q is defined more than once", axis.Name))
+		}
+		if len(axis.Values) == 0 {
+			errs = append(errs, fmt.Errorf("axis %q has no values", axis.Name))
+		}
+		values[axis.Name] = sets.New[string]()
+		for _, value := range axis.Values {
+			if !valueRe.MatchString(value) {
+				errs = append(errs, fmt.Errorf("value %q of axis %q must match %s", value, axis.Name, valueRe))
+			}
+			if values[axis.Name].Has(value) {
+				errs = append(errs, fmt.Errorf("value %q of axis %q is listed more than once", value, axis.Name))
+			}
+			values[axis.Name].Insert(value)
+		}
+	}
+	for _, exclude := range m.Exclude {
+		for axis, value := range exclude {
+			if _, ok := values[axis]; !ok {
+				errs = append(errs, fmt.Errorf("exclude refers to unknown axis %q", axis))
+			} else if !values[axis].Has(value) {
+				errs = append(errs, fmt.Errorf("exclude refers to unknown value %q of axis %q", value, axis))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		if n := len(m.Combinations()); n == 0 {
+			errs = append(errs, errors.New("all combinations are excluded"))
+		} else if n > MaxCombinations {
+			errs = append(errs, fmt.Errorf("the matrix expands to %d combinations, more than the maximum of %d", n, MaxCombinations))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Combinations returns the combinations of the values of the axes that are
+// not excluded. The values of the first axis vary slowest.
+func (m *Matrix) Combinations() []Combination {
+	combinations := []Combination{{}}
+	for _, axis := range m.Axes {
+		var next []Combination
+		for _, combination := range combinations {
+			for _, value := range axis.Values {
+				c := make(Combination, len(combination), len(combination)+1)
+				copy(c, combination)
+				next = append(next, append(c, AxisValue{Axis: axis.Name, Value: value}))
+			}
+		}
+		combinations = next
+	}
+	var included []Combination
+	for _, combination := range combinations {
+		if !m.excludes(combination) {
+			included = append(included, combination)
+		}
+	}
+	return included
+}
+
+func (m *Matrix) excludes(combination Combination) bool {
+	for _, exclude := range m.Exclude {
+		if len(exclude) == 0 {
+			continue
+		}
+		matches := true
+		for axis, value := range exclude {
+			if combination.value(axis) != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Combination) value(axis string) string {
+	for _, v := range c {
+		if v.Axis == axis {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// Suffix returns the values of the combination joined by dashes, which is
+// appended to the names of the expanded jobs.
+func (c Combination) Suffix() string {
+	values := make([]string, 0, len(c))
+	for _, v := range c {
+		values = append(values, v.Value)
+	}
+	return strings.Join(values, "-")
+}
+
+// String returns the combination in the form "axis=value,axis=value".
+func (c Combination) String() string {
+	pairs := make([]string, 0, len(c))
+	for _, v := range c {
+		pairs = append(pairs, v.Axis+"="+v.Value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// UnknownReferences returns the axes referred to with ${matrix.<name>} in s
+// that are not axes of the matrix.
+func (m *Matrix) UnknownReferences(s string) []string {
+	axes := sets.New[string]()
+	for _, axis := range m.Axes {
+		axes.Insert(axis.Name)
+	}
+	unknown := sets.New[string]()
+	for _, match := range referenceRe.FindAllStringSubmatch(s, -1) {
+		if !axes.Has(match[1]) {
+			unknown.Insert(match[1])
+		}
+	}
+	return sets.List(unknown)
+}
+
+// Expand replaces the references to the axes in s with their values in the
+// combination.
+func (c Combination) Expand(s string) string {
+	return referenceRe.ReplaceAllStringFunc(s, func(reference string) string {
+		axis := referenceRe.FindStringSubmatch(reference)[1]
+		for _, v := range c {
+			if v.Axis == axis {
+				return v.Value
+			}
+		}
+		return reference
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCombinations(t *testing.T) {
+	m := &Matrix{
+		Axes: []Axis{
+			{Name: "go", Values: []string{"1.21", "1.22"}},
+			{Name: "platform", Values: []string{"linux", "windows"}},
+			{Name: "kube", Values: []string{"1.30"}},
+		},
+		Exclude: []map[string]string{{"go": "1.21", "platform": "windows"}},
+	}
+	var got []string
+	for _, c := range m.Combinations() {
+		got = append(got, c.String()+" "+c.Suffix())
+	}
+	expected := []string{
+		"go=1.21,platform=linux,kube=1.30 1.21-linux-1.30",
+		"go=1.22,platform=linux,kube=1.30 1.22-linux-1.30",
+		"go=1.22,platform=windows,kube=1.30 1.22-windows-1.30",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("combinations differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		matrix      Matrix
+		expectedErr bool
+	}{
+		{
+			name:   "valid",
+			matrix: Matrix{Axes: []Axis{{Name: "go", Values: []string{"1.21", "1.22"}}}},
+		},
+		{
+			name:        "no axes",
+			expectedErr: true,
+		},
+		{
+			name:        "axis without values",
+			matrix:      Matrix{Axes: []Axis{{Name: "go"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid axis name",
+			matrix:      Matrix{Axes: []Axis{{Name: "go.version", Values: []string{"1.22"}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate axis",
+			matrix:      Matrix{Axes: []Axis{{Name: "go", Values: []string{"1.21"}}, {Name: "go", Values: []string{"1.22"}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "value not allowed in job names",
+			matrix:      Matrix{Axes: []Axis{{Name: "platform", Values: []string{"linux/amd64"}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate value",
+			matrix:      Matrix{Axes: []Axis{{Name: "go", Values: []string{"1.22", "1.22"}}}},
+			expectedErr: true,
+		},
+		{
+			name: "exclude of unknown value",
+			matrix: Matrix{
+				Axes:    []Axis{{Name: "go", Values: []string{"1.22"}}},
+				Exclude: []map[string]string{{"go": "1.20"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "everything excluded",
+			matrix: Matrix{
+				Axes:    []Axis{{Name: "go", Values: []string{"1.22"}}},
+				Exclude: []map[string]string{{"go": "1.22"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "too many combinations",
+			matrix: Matrix{Axes: []Axis{
+				{Name: "a", Values: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}},
+				{Name: "b", Values: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}},
+				{Name: "c", Values: []string{"0", "1", "2"}},
+			}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.matrix.Validate()
+			if tc.expectedErr != (err != nil) {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	m := &Matrix{Axes: []Axis{{Name: "go", Values: []string{"1.22"}}, {Name: "os", Values: []string{"linux"}}}}
+	c := m.Combinations()[0]
+	if got, expected := c.Expand("golang:${matrix.go}-${matrix.os} ${matrix.go} $(GO)"), "golang:1.22-linux 1.22 $(GO)"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if diff := cmp.Diff([]string{"arch"}, m.UnknownReferences("${matrix.go} ${matrix.arch} ${matrix.arch}")); diff != "" {
+		t.Errorf("unknown references differ from expected (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadJobConfigMatrix(t *testing.T) {
+	type job struct {
+		Name, Context, Image, SourcePath string
+		Annotations                      map[string]string
+	}
+	testCases := []struct {
+		name               string
+		config             string
+		expectedPresubmits []job
+		expectedPeriodics  []job
+		expectedErr        string
+	}{
+		{
+			name: "presubmits and periodics are expanded",
+			config: `presubmits:
+  org/repo:
+  - name: pull-unit
+    matrix:
+      axes:
+      - name: go
+        values: ["1.21", "1.22"]
+      - name: platform
+        values: [linux, windows]
+      exclude:
+      - go: "1.21"
+        platform: windows
+    annotations:
+      owner: team
+    spec:
+      containers:
+      - image: golang:${matrix.go}-${matrix.platform}
+  - name: pull-lint
+    spec:
+      containers:
+      - image: golangci-lint
+periodics:
+- name: ci-e2e-${matrix.kube}
+  interval: 1h
+  matrix:
+    axes:
+    - name: kube
+      values: ["1.30"]
+  spec:
+    containers:
+    - image: e2e:${matrix.kube}
+`,
+			expectedPresubmits: []job{
+				{
+					Name:        "pull-unit-1.21-linux",
+					Image:       "golang:1.21-linux",
+					Annotations: map[string]string{"owner": "team", "prow.k8s.io/matrix-source": "pull-unit", "prow.k8s.io/matrix-combination": "go=1.21,platform=linux"},
+				},
+				{
+					Name:        "pull-unit-1.22-linux",
+					Image:       "golang:1.22-linux",
+					Annotations: map[string]string{"owner": "team", "prow.k8s.io/matrix-source": "pull-unit", "prow.k8s.io/matrix-combination": "go=1.22,platform=linux"},
+				},
+				{
+					Name:        "pull-unit-1.22-windows",
+					Image:       "golang:1.22-windows",
+					Annotations: map[string]string{"owner": "team", "prow.k8s.io/matrix-source": "pull-unit", "prow.k8s.io/matrix-combination": "go=1.22,platform=windows"},
+				},
+				{
+					Name:  "pull-lint",
+					Image: "golangci-lint",
+				},
+			},
+			expectedPeriodics: []job{
+				{
+					Name:        "ci-e2e-1.30",
+					Image:       "e2e:1.30",
+					Annotations: map[string]string{"prow.k8s.io/matrix-source": "ci-e2e-${matrix.kube}", "prow.k8s.io/matrix-combination": "kube=1.30"},
+				},
+			},
+		},
+		{
+			name: "contexts that don't refer to the matrix get the values",
+			config: `presubmits:
+  org/repo:
+  - name: pull-unit
+    context: ci/unit
+    matrix:
+      axes:
+      - name: go
+        values: ["1.22"]
+    spec:
+      containers:
+      - image: golang
+  - name: pull-e2e
+    context: ci/e2e (${matrix.kube})
+    matrix:
+      axes:
+      - name: kube
+        values: ["1.30"]
+    spec:
+      containers:
+      - image: e2e
+`,
+			expectedPresubmits: []job{
+				{
+					Name:        "pull-unit-1.22",
+					Context:     "ci/unit-1.22",
+					Image:       "golang",
+					Annotations: map[string]string{"prow.k8s.io/matrix-source": "pull-unit", "prow.k8s.io/matrix-combination": "go=1.22"},
+				},
+				{
+					Name:        "pull-e2e-1.30",
+					Context:     "ci/e2e (1.30)",
+					Image:       "e2e",
+					Annotations: map[string]string{"prow.k8s.io/matrix-source": "pull-e2e", "prow.k8s.io/matrix-combination": "kube=1.30"},
+				},
+			},
+		},
+		{
+			name: "unknown axis",
+			config: `presubmits:
+  org/repo:
+  - name: pull-unit
+    matrix:
+      axes:
+      - name: go
+        values: ["1.22"]
+    spec:
+      containers:
+      - image: golang:${matrix.golang}
+`,
+			expectedErr: "job pull-unit refers to unknown matrix axes: golang",
+		},
+		{
+			name: "invalid matrix",
+			config: `periodics:
+- name: ci-unit
+  interval: 1h
+  matrix:
+    axes:
+    - name: go
+  spec:
+    containers:
+    - image: golang
+`,
+			expectedErr: `job ci-unit has an invalid matrix: axis "go" has no values`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jobs.yaml")
+			if err := os.WriteFile(path, []byte(tc.config), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			jc, err := ReadJobConfig(path)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			toJob := func(base JobBase, context string) job {
+				if base.SourcePath != path {
+					t.Errorf("expected job %s to be defined in %s, got %q", base.Name, path, base.SourcePath)
+				}
+				if base.Matrix != nil {
+					t.Errorf("expected the matrix of job %s to be removed", base.Name)
+				}
+				return job{Name: base.Name, Context: context, Image: base.Spec.Containers[0].Image, Annotations: base.Annotations}
+			}
+			var presubmits, periodics []job
+			for _, p := range jc.PresubmitsStatic["org/repo"] {
+				presubmits = append(presubmits, toJob(p.JobBase, p.Context))
+			}
+			for _, p := range jc.Periodics {
+				periodics = append(periodics, toJob(p.JobBase, ""))
+			}
+			if diff := cmp.Diff(tc.expectedPresubmits, presubmits); diff != "" {
+				t.Errorf("presubmits differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedPeriodics, periodics); diff != "" {
+				t.Errorf("periodics differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
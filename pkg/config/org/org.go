@@ -18,6 +18,8 @@ package org
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"sigs.k8s.io/prow/pkg/github"
 )
@@ -71,6 +73,14 @@ type Repo struct {
 	DefaultBranch *string `json:"default_branch,omitempty"`
 	Archived      *bool   `json:"archived,omitempty"`
 
+	// Topics replace the topics of the repo if set. An empty list removes all topics.
+	Topics []string `json:"topics,omitempty"`
+	// VulnerabilityAlerts enables or disables Dependabot alerts if set.
+	VulnerabilityAlerts *bool `json:"vulnerability_alerts,omitempty"`
+	// Labels are added to the labels of the org to create in the repo.
+	// A label with the same name as a label of the org replaces it.
+	Labels []Label `json:"labels,omitempty"`
+
 	Previously []string `json:"previously,omitempty"`
 
 	OnCreate *RepoCreateOptions `json:"on_create,omitempty"`
@@ -83,6 +93,39 @@ type Config struct {
 	Members []string        `json:"members,omitempty"`
 	Admins  []string        `json:"admins,omitempty"`
 	Repos   map[string]Repo `json:"repos,omitempty"`
+	// Labels are created or updated in every repo configured in Repos.
+	// Labels that are not configured are left alone.
+	Labels []Label `json:"labels,omitempty"`
+}
+
+// Label declares a label of a repo.
+//
+// See https://docs.github.com/en/rest/issues/labels#create-a-label
+type Label struct {
+	Name string `json:"name"`
+	// Color is the hex code of the color without the leading #.
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// RepoLabels returns the labels of the org merged with the labels of the repo,
+// sorted by name.
+func (c Config) RepoLabels(repo Repo) []Label {
+	byName := map[string]Label{}
+	for _, label := range c.Labels {
+		byName[strings.ToLower(label.Name)] = label
+	}
+	for _, label := range repo.Labels {
+		byName[strings.ToLower(label.Name)] = label
+	}
+	labels := make([]Label, 0, len(byName))
+	for _, label := range byName {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return strings.ToLower(labels[i].Name) < strings.ToLower(labels[j].Name)
+	})
+	return labels
 }
 
 // TeamMetadata declares metadata about the github team.
@@ -131,3 +131,26 @@ func TestPruneRepoDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestRepoLabels(t *testing.T) {
+	config := Config{
+		Labels: []Label{
+			{Name: "bug", Color: "ff0000"},
+			{Name: "docs", Color: "0000ff"},
+		},
+	}
+	repo := Repo{
+		Labels: []Label{
+			{Name: "Bug", Color: "00ff00", Description: "Something is broken"},
+			{Name: "api", Color: "ffffff"},
+		},
+	}
+	expected := []Label{
+		{Name: "api", Color: "ffffff"},
+		{Name: "Bug", Color: "00ff00", Description: "Something is broken"},
+		{Name: "docs", Color: "0000ff"},
+	}
+	if actual := config.RepoLabels(repo); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("result differs from expected:\n%s", diff.ObjectReflectDiff(expected, actual))
+	}
+}
@@ -24,6 +24,30 @@ type Scheduler struct {
 	// Scheduling strategies
 	Failover *FailoverScheduling `json:"failover,omitempty"`
 	External *ExternalScheduling `json:"external,omitempty"`
+
+	// ClusterHealth enables probing the build clusters and keeps ProwJobs
+	// from being scheduled to clusters found unhealthy. Requires Enabled and
+	// the cluster-health controller of prow-controller-manager to be enabled,
+	// as ProwJobs are only held back by the scheduler.
+	ClusterHealth *ClusterHealthGating `json:"cluster_health,omitempty"`
+}
+
+// ClusterHealthGating configures how build clusters are probed and when they
+// are considered unschedulable.
+type ClusterHealthGating struct {
+	// ProbeInterval is how often every build cluster is probed. Defaults to 1m.
+	ProbeInterval *metav1.Duration `json:"probe_interval,omitempty"`
+	// MinReadyNodes is the number of ready and schedulable nodes a cluster
+	// must have to be healthy. Defaults to 1.
+	MinReadyNodes int `json:"min_ready_nodes,omitempty"`
+	// MinQuotaHeadroom is the percentage of every resource quota in the pod
+	// namespace that must still be available for a cluster to be healthy.
+	// Zero, the default, disables the quota check.
+	MinQuotaHeadroom int `json:"min_quota_headroom,omitempty"`
+	// FailureThreshold is the number of consecutive failed probes after
+	// which a cluster becomes unschedulable. A single successful probe makes
+	// it schedulable again. Defaults to 3.
+	FailureThreshold int `json:"failure_threshold,omitempty"`
 }
 
 // FailoverScheduling is a configuration for the Failover scheduling strategy
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"regexp"
 	"sort"
 	"strings"
@@ -303,6 +304,62 @@ type TideGitHubConfig struct {
 	// creates. The default is to only mention the one to which we are closest (Calculated
 	// by total number of requirements - fulfilled number of requirements).
 	DisplayAllQueriesInStatus bool `json:"display_all_tide_queries_in_status,omitempty"`
+
+	// MergeWhenGreen configures the orgs and repos in which Tide merges approved
+	// PRs labeled by the merge-when-green plugin once their required contexts
+	// pass, even though they are not part of any Tide query.
+	MergeWhenGreen *TideMergeWhenGreen `json:"merge_when_green,omitempty"`
+
+	// CrossRepoDependencies enables linking PRs of different repos with
+	// "Depends-On: org/repo#123" lines in their descriptions. Linked PRs are
+	// never batched and only merge once all of them are ready to merge. Serial
+	// runs of decorated presubmits of a linked PR check out the other linked
+	// PRs as extra refs, so that the PRs are tested together.
+	CrossRepoDependencies bool `json:"cross_repo_dependencies,omitempty"`
+}
+
+// TideMergeWhenGreen configures where PRs labeled with /merge-when-green are
+// merged. Repos that are part of a Tide query are always left to Tide.
+type TideMergeWhenGreen struct {
+	Orgs  []string `json:"orgs,omitempty"`
+	Repos []string `json:"repos,omitempty"`
+}
+
+// Enabled returns whether merge-when-green is configured for any org or repo.
+func (mwg *TideMergeWhenGreen) Enabled() bool {
+	return mwg != nil && (len(mwg.Orgs) > 0 || len(mwg.Repos) > 0)
+}
+
+// ForRepo returns whether merge-when-green is configured for the repo.
+func (mwg *TideMergeWhenGreen) ForRepo(repo OrgRepo) bool {
+	if mwg == nil {
+		return false
+	}
+	for _, org := range mwg.Orgs {
+		if org == repo.Org {
+			return true
+		}
+	}
+	for _, r := range mwg.Repos {
+		if r == repo.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if any of the configured repos is not of the
+// form org/repo.
+func (mwg *TideMergeWhenGreen) Validate() error {
+	if mwg == nil {
+		return nil
+	}
+	for _, repo := range mwg.Repos {
+		if parts := strings.Split(repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("repo %q is not of the form org/repo", repo)
+		}
+	}
+	return nil
 }
 
 // TideGerritConfig contains all Gerrit related configurations for tide.
@@ -496,7 +553,11 @@ func (t *Tide) GetTargetURL(repo OrgRepo) string {
 type TideQuery struct {
 	Author string `json:"author,omitempty"`
 
-	Labels        []string `json:"labels,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	// MissingLabels are the labels a PR must not have. Entries may be glob
+	// patterns as accepted by path.Match, e.g. "do-not-merge/*" or "needs-*".
+	// Patterns cannot be part of the GitHub search, PRs with matching labels
+	// are filtered out of the search results instead.
 	MissingLabels []string `json:"missingLabels,omitempty"`
 
 	ExcludedBranches []string `json:"excludedBranches,omitempty"`
@@ -509,6 +570,14 @@ type TideQuery struct {
 	Orgs          []string `json:"orgs,omitempty"`
 	Repos         []string `json:"repos,omitempty"`
 	ExcludedRepos []string `json:"excludedRepos,omitempty"`
+
+	// StrictRebase requires PRs to be up to date with the base branch before
+	// they merge, like the "Require branches to be up to date" branch
+	// protection. Tide updates the branch of a PR that does not contain the
+	// base HEAD before it retests and merges it, so that the tested commit is
+	// the merged one. Batches still merge, as batch jobs test the PRs on the
+	// base HEAD. Applies to all PRs of the branches of the query.
+	StrictRebase bool `json:"strictRebase,omitempty"`
 }
 
 func (q TideQuery) TenantIDs(cfg Config) []string {
@@ -533,6 +602,7 @@ type tideQueryConfig struct {
 	MissingLabels          []string
 	Milestone              string
 	ReviewApprovedRequired bool
+	StrictRebase           bool
 	TenantIDs              []string
 }
 
@@ -581,6 +651,9 @@ func (tq *TideQuery) constructQuery() (map[string][]string, string) {
 		queryString = append(queryString, fmt.Sprintf("label:%s", strings.Join(orOperands, ",")))
 	}
 	for _, l := range tq.MissingLabels {
+		if isLabelPattern(l) {
+			continue
+		}
 		queryString = append(queryString, fmt.Sprintf("-label:\"%s\"", l))
 	}
 	if tq.Milestone != "" {
@@ -593,6 +666,27 @@ func (tq *TideQuery) constructQuery() (map[string][]string, string) {
 	return orgScopedIdentifiers, strings.Join(queryString, " ")
 }
 
+// isLabelPattern determines whether the label is a glob pattern.
+func isLabelPattern(label string) bool {
+	return strings.ContainsAny(label, "*?[\\")
+}
+
+// IsMissingLabel determines whether the label is one of the MissingLabels of
+// the query or matches one of their patterns.
+func (tq *TideQuery) IsMissingLabel(label string) bool {
+	for _, missing := range tq.MissingLabels {
+		if missing == label {
+			return true
+		}
+		if isLabelPattern(missing) {
+			if matches, err := path.Match(missing, label); err == nil && matches {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func splitOrgRepoString(orgRepo string) (string, string, bool) {
 	split := strings.Split(orgRepo, "/")
 	if len(split) != 2 {
@@ -624,6 +718,35 @@ func (tq *TideQuery) Query() string {
 	return strings.Join(toks, " ")
 }
 
+// ForBranch indicates if the tide query applies to the specified branch.
+func (tq TideQuery) ForBranch(branch string) bool {
+	for _, excludedBranch := range tq.ExcludedBranches {
+		if excludedBranch == branch {
+			return false
+		}
+	}
+	if len(tq.IncludedBranches) == 0 {
+		return true
+	}
+	for _, includedBranch := range tq.IncludedBranches {
+		if includedBranch == branch {
+			return true
+		}
+	}
+	return false
+}
+
+// StrictRebase indicates if any of the tide queries for the specified repo
+// and branch requires PRs to be up to date with the branch.
+func (tqs TideQueries) StrictRebase(repo OrgRepo, branch string) bool {
+	for _, tq := range tqs {
+		if tq.StrictRebase && tq.ForRepo(repo) && tq.ForBranch(branch) {
+			return true
+		}
+	}
+	return false
+}
+
 // ForRepo indicates if the tide query applies to the specified repo.
 func (tq TideQuery) ForRepo(repo OrgRepo) bool {
 	for _, queryOrg := range tq.Orgs {
@@ -796,7 +919,18 @@ func (tq *TideQuery) Validate() error {
 		return err
 	}
 
-	if invalids := sets.New[string](tq.Labels...).Intersection(sets.New[string](tq.MissingLabels...)); len(invalids) > 0 {
+	for _, l := range tq.MissingLabels {
+		if _, err := path.Match(l, ""); err != nil {
+			return fmt.Errorf("missingLabels: %q is not a valid pattern: %w", l, err)
+		}
+	}
+	invalids := sets.New[string]()
+	for _, l := range tq.Labels {
+		if tq.IsMissingLabel(l) {
+			invalids.Insert(l)
+		}
+	}
+	if len(invalids) > 0 {
 		return fmt.Errorf("the labels: %q are both required and forbidden", sets.List(invalids))
 	}
 	if err := duplicates("labels", tq.Labels); err != nil {
@@ -479,6 +479,29 @@ func TestTideQuery(t *testing.T) {
 	}
 }
 
+func TestTideQueryMissingLabelPatterns(t *testing.T) {
+	query := TideQuery{Orgs: []string{"org"}, MissingLabels: []string{"do-not-merge", "do-not-merge/*", "needs-*"}}
+	q := " " + query.Query() + " "
+	if !strings.Contains(q, ` -label:"do-not-merge" `) {
+		t.Errorf("expected the query to exclude the do-not-merge label, got %q", q)
+	}
+	if strings.Contains(q, "*") {
+		t.Errorf("expected the query not to contain patterns, got %q", q)
+	}
+
+	for label, expected := range map[string]bool{
+		"do-not-merge":       true,
+		"do-not-merge/hold":  true,
+		"needs-rebase":       true,
+		"do-not-merge-later": false,
+		"lgtm":               false,
+	} {
+		if actual := query.IsMissingLabel(label); actual != expected {
+			t.Errorf("expected IsMissingLabel(%q) to be %t, got %t", label, expected, actual)
+		}
+	}
+}
+
 func checkTok(t *testing.T, q string) func(tok string) {
 	return func(tok string) {
 		t.Run("Query string contains "+tok, func(t *testing.T) {
@@ -1824,6 +1847,32 @@ func TestTideQuery_Validate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "missing label pattern is valid",
+			query: TideQuery{
+				Orgs:          []string{"kuber"},
+				Labels:        []string{labels.LGTM},
+				MissingLabels: []string{"do-not-merge/*", "needs-*"},
+			},
+			expectError: false,
+		},
+		{
+			name: "malformed missing label pattern is invalid",
+			query: TideQuery{
+				Orgs:          []string{"kuber"},
+				MissingLabels: []string{"do-not-merge/["},
+			},
+			expectError: true,
+		},
+		{
+			name: "required label matching a missing label pattern is invalid",
+			query: TideQuery{
+				Orgs:          []string{"kuber"},
+				Labels:        []string{"needs-review"},
+				MissingLabels: []string{"needs-*"},
+			},
+			expectError: true,
+		},
 		{
 			name: "simple excluded branches query is valid",
 			query: TideQuery{
@@ -1855,6 +1904,58 @@ func TestTideQuery_Validate(t *testing.T) {
 	}
 }
 
+func TestTideMergeWhenGreen(t *testing.T) {
+	mwg := TideMergeWhenGreen{Orgs: []string{"org"}, Repos: []string{"other/repo"}}
+	for _, tc := range []struct {
+		repo     OrgRepo
+		expected bool
+	}{
+		{repo: OrgRepo{Org: "org", Repo: "any"}, expected: true},
+		{repo: OrgRepo{Org: "other", Repo: "repo"}, expected: true},
+		{repo: OrgRepo{Org: "other", Repo: "different"}, expected: false},
+	} {
+		if actual := mwg.ForRepo(tc.repo); actual != tc.expected {
+			t.Errorf("expected ForRepo(%s) to be %t, got %t", tc.repo.String(), tc.expected, actual)
+		}
+	}
+	if err := mwg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (&TideMergeWhenGreen{Repos: []string{"repo"}}).Validate(); err == nil {
+		t.Error("expected an error for a repo without org")
+	}
+	if !mwg.Enabled() || !(&TideMergeWhenGreen{Repos: []string{"other/repo"}}).Enabled() {
+		t.Error("expected merge-when-green to be enabled")
+	}
+	var unset *TideMergeWhenGreen
+	if unset.Enabled() || unset.ForRepo(OrgRepo{Org: "org", Repo: "any"}) {
+		t.Error("expected an unset merge-when-green to be disabled")
+	}
+}
+
+func TestTideQueriesStrictRebase(t *testing.T) {
+	queries := TideQueries{
+		{Orgs: []string{"org"}, ExcludedBranches: []string{"dev"}, StrictRebase: true},
+		{Repos: []string{"other/repo"}, IncludedBranches: []string{"main"}, StrictRebase: true},
+		{Repos: []string{"other/lax"}},
+	}
+	for _, tc := range []struct {
+		repo     OrgRepo
+		branch   string
+		expected bool
+	}{
+		{repo: OrgRepo{Org: "org", Repo: "any"}, branch: "main", expected: true},
+		{repo: OrgRepo{Org: "org", Repo: "any"}, branch: "dev", expected: false},
+		{repo: OrgRepo{Org: "other", Repo: "repo"}, branch: "main", expected: true},
+		{repo: OrgRepo{Org: "other", Repo: "repo"}, branch: "release", expected: false},
+		{repo: OrgRepo{Org: "other", Repo: "lax"}, branch: "main", expected: false},
+	} {
+		if actual := queries.StrictRebase(tc.repo, tc.branch); actual != tc.expected {
+			t.Errorf("expected StrictRebase(%s, %s) to be %t, got %t", tc.repo.String(), tc.branch, tc.expected, actual)
+		}
+	}
+}
+
 func TestTideContextPolicy_Validate(t *testing.T) {
 	testCases := []struct {
 		name   string
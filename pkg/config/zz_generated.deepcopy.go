@@ -27,6 +27,7 @@ import (
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	v1 "k8s.io/api/core/v1"
 	prowjobsv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	matrix "sigs.k8s.io/prow/pkg/config/matrix"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -88,6 +89,11 @@ func (in *JobBase) DeepCopyInto(out *JobBase) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(prowjobsv1.RetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
 		*out = new(v1.PodSpec)
@@ -120,11 +126,21 @@ func (in *JobBase) DeepCopyInto(out *JobBase) {
 		*out = new(prowjobsv1.RerunAuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RerunOverrides != nil {
+		in, out := &in.RerunOverrides, &out.RerunOverrides
+		*out = new(prowjobsv1.RerunOverrides)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProwJobDefault != nil {
 		in, out := &in.ProwJobDefault, &out.ProwJobDefault
 		*out = new(prowjobsv1.ProwJobDefault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(matrix.Matrix)
+		(*in).DeepCopyInto(*out)
+	}
 	in.UtilityConfig.DeepCopyInto(&out.UtilityConfig)
 	return
 }
@@ -148,6 +164,11 @@ func (in *Postsubmit) DeepCopyInto(out *Postsubmit) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RunAfterSuccess != nil {
+		in, out := &in.RunAfterSuccess, &out.RunAfterSuccess
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.RegexpChangeMatcher.DeepCopyInto(&out.RegexpChangeMatcher)
 	in.Brancher.DeepCopyInto(&out.Brancher)
 	out.Reporter = in.Reporter
@@ -217,6 +238,11 @@ func (in *Preset) DeepCopy() *Preset {
 func (in *Presubmit) DeepCopyInto(out *Presubmit) {
 	*out = *in
 	in.JobBase.DeepCopyInto(&out.JobBase)
+	if in.RunAfterSuccess != nil {
+		in, out := &in.RunAfterSuccess, &out.RunAfterSuccess
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Brancher.DeepCopyInto(&out.Brancher)
 	in.RegexpChangeMatcher.DeepCopyInto(&out.RegexpChangeMatcher)
 	out.Reporter = in.Reporter
@@ -295,6 +321,10 @@ func (in *RegexpChangeMatcher) DeepCopyInto(out *RegexpChangeMatcher) {
 		in, out := &in.reChanges, &out.reChanges
 		*out = (*in).DeepCopy()
 	}
+	if in.reContent != nil {
+		in, out := &in.reContent, &out.reContent
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
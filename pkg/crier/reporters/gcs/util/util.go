@@ -98,5 +98,8 @@ func MarshalFinishedJSON(pj *prowv1.ProwJob) ([]byte, error) {
 		Metadata:  metadata.Metadata{"uploader": "crier"},
 		Result:    string(pj.Status.State),
 	}
+	if pj.Status.FailureReason != "" {
+		f.Metadata["failure_reason"] = string(pj.Status.FailureReason)
+	}
 	return json.MarshalIndent(f, "", "\t")
 }
@@ -105,13 +105,16 @@ type gerritClient interface {
 	SetReview(instance, id, revision, message string, labels map[string]string) error
 	GetChange(instance, id string, additionalFields ...string) (*gerrit.ChangeInfo, error)
 	ChangeExist(instance, id string) (bool, error)
+	EnsureChecker(instance string, input client.CheckerInput) error
+	UpdateCheck(instance, id, revision string, input client.CheckInput) error
 }
 
 // Client is a gerrit reporter client
 type Client struct {
-	gc          gerritClient
-	pjclientset ctrlruntimeclient.Client
-	prLocks     *criercommonlib.ShardedLock
+	gc                  gerritClient
+	pjclientset         ctrlruntimeclient.Client
+	prLocks             *criercommonlib.ShardedLock
+	orgRepoConfigGetter func() *config.GerritOrgRepoConfigs
 }
 
 // Job is the view of a prowjob scoped for a report
@@ -152,9 +155,10 @@ func NewReporter(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, cookie
 	gc.Authenticate(cookiefilePath, "")
 
 	c := &Client{
-		gc:          gc,
-		pjclientset: pjclientset,
-		prLocks:     criercommonlib.NewShardedLock(),
+		gc:                  gc,
+		pjclientset:         pjclientset,
+		prLocks:             criercommonlib.NewShardedLock(),
+		orgRepoConfigGetter: orgRepoConfigGetter,
 	}
 
 	c.prLocks.RunCleanup()
@@ -172,6 +176,40 @@ func (c *Client) ShouldReport(ctx context.Context, log *logrus.Entry, pj *v1.Pro
 		return false
 	}
 
+	// Every state change of jobs reporting to checks is reported, whether
+	// the review needs to be reported as well is decided in Report.
+	if c.checksFor(pj) != nil {
+		return true
+	}
+
+	return c.shouldReportReview(ctx, log, pj)
+}
+
+// checksFor returns the checks configuration of the repo of a presubmit job,
+// or nil if the job doesn't report to checks.
+func (c *Client) checksFor(pj *v1.ProwJob) *config.GerritChecks {
+	if c.orgRepoConfigGetter == nil || pj.Spec.Type != v1.PresubmitJob || pj.Spec.Refs == nil {
+		return nil
+	}
+	if pj.ObjectMeta.Annotations[kube.GerritID] == "" ||
+		pj.ObjectMeta.Annotations[kube.GerritInstance] == "" ||
+		pj.ObjectMeta.Labels[kube.GerritRevision] == "" {
+		return nil
+	}
+	return c.orgRepoConfigGetter().ChecksFor(pj.ObjectMeta.Annotations[kube.GerritInstance], pj.Spec.Refs.Repo)
+}
+
+// aggregatesReports returns whether the job is a presubmit of a repo that
+// reports the results of all presubmits of a patchset in one review.
+func (c *Client) aggregatesReports(pj *v1.ProwJob) bool {
+	if c.orgRepoConfigGetter == nil || pj.Spec.Type != v1.PresubmitJob || pj.Spec.Refs == nil {
+		return false
+	}
+	return c.orgRepoConfigGetter().AggregateReportsFor(pj.ObjectMeta.Annotations[kube.GerritInstance], pj.Spec.Refs.Repo)
+}
+
+// shouldReportReview returns if the prowjob should be reported as a review.
+func (c *Client) shouldReportReview(ctx context.Context, log *logrus.Entry, pj *v1.ProwJob) bool {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -195,8 +233,13 @@ func (c *Client) ShouldReport(ctx context.Context, log *logrus.Entry, pj *v1.Pro
 		return false
 	}
 
-	// Don't wait for report aggregation if not voting on any label
-	if pj.ObjectMeta.Labels[kube.GerritReportLabel] == "" {
+	// Jobs on the revision that are reported together with this one.
+	aggregatedLabels := []string{kube.GerritRevision, kube.ProwJobTypeLabel, kube.GerritReportLabel}
+	if c.aggregatesReports(pj) {
+		// Wait for all jobs of the patchset, voting or not.
+		aggregatedLabels = []string{kube.GerritRevision, kube.ProwJobTypeLabel}
+	} else if pj.ObjectMeta.Labels[kube.GerritReportLabel] == "" {
+		// Don't wait for report aggregation if not voting on any label
 		return true
 	}
 
@@ -250,10 +293,10 @@ func (c *Client) ShouldReport(ctx context.Context, log *logrus.Entry, pj *v1.Pro
 	patchsetNum := patchsetNumFromPJ(pj)
 
 	// Check all other prowjobs to see whether they agree or not
-	return allPJsAgreeToReport([]string{kube.GerritRevision, kube.ProwJobTypeLabel, kube.GerritReportLabel}, func(otherPj *v1.ProwJob) bool {
+	return allPJsAgreeToReport(aggregatedLabels, func(otherPj *v1.ProwJob) bool {
 		if otherPj.Status.State == v1.TriggeredState || otherPj.Status.State == v1.PendingState {
 			// other jobs with same label are still running on this revision, skip report
-			log.Info("Other jobs with same label are still running on this revision")
+			log.Info("Other jobs reported together are still running on this revision")
 			return false
 		}
 		return true
@@ -271,6 +314,16 @@ func (c *Client) ShouldReport(ctx context.Context, log *logrus.Entry, pj *v1.Pro
 func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJob) ([]*v1.ProwJob, *reconcile.Result, error) {
 	logger = logger.WithFields(logrus.Fields{"job": pj.Spec.Job, "name": pj.Name})
 
+	if checks := c.checksFor(pj); checks != nil {
+		if err := c.reportCheck(checks, pj); err != nil {
+			return nil, nil, err
+		}
+		logger.WithField("state", pj.Status.State).Debug("Reported check.")
+		if !c.shouldReportReview(ctx, logger, pj) {
+			return nil, nil, nil
+		}
+	}
+
 	// Gerrit reporter hasn't learned how to deduplicate itself from report yet,
 	// will need to block here. Unfortunately need to check after this section
 	// to ensure that the job was not already marked reported by other threads
@@ -323,10 +376,11 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	pjTypeLabel := kube.ProwJobTypeLabel
 	gerritReportLabel := kube.GerritReportLabel
 
+	aggregate := c.aggregatesReports(pj)
 	var pjsOnRevisionWithSameLabel v1.ProwJobList
 	var pjsToUpdateState []v1.ProwJob
 	var toReportJobs []*v1.ProwJob
-	if pj.ObjectMeta.Labels[gerritReportLabel] == "" && pj.Status.State != v1.AbortedState {
+	if !aggregate && pj.ObjectMeta.Labels[gerritReportLabel] == "" && pj.Status.State != v1.AbortedState {
 		toReportJobs = append(toReportJobs, pj)
 		pjsToUpdateState = []v1.ProwJob{*pj}
 	} else { // generate an aggregated report
@@ -335,7 +389,10 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 		selector := map[string]string{
 			clientGerritRevision: pj.ObjectMeta.Labels[clientGerritRevision],
 			pjTypeLabel:          pj.ObjectMeta.Labels[pjTypeLabel],
-			gerritReportLabel:    pj.ObjectMeta.Labels[gerritReportLabel],
+		}
+		if !aggregate {
+			// and voting on the same label
+			selector[gerritReportLabel] = pj.ObjectMeta.Labels[gerritReportLabel]
 		}
 
 		if err := c.pjclientset.List(newCtx, &pjsOnRevisionWithSameLabel, ctrlruntimeclient.MatchingLabels(selector)); err != nil {
@@ -354,6 +411,10 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 		for _, pjOnRevisionWithSameLabel := range mostRecentJob {
 			toReportJobs = append(toReportJobs, pjOnRevisionWithSameLabel)
 		}
+		// Keep the report stable across reports of the revision.
+		sort.Slice(toReportJobs, func(i, j int) bool {
+			return toReportJobs[i].Spec.Job < toReportJobs[j].Spec.Job
+		})
 	}
 	report := GenerateReport(toReportJobs, 0)
 	message := report.Header + report.Message
@@ -365,12 +426,28 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 		"instance": gerritInstance,
 		"id":       gerritID,
 	})
-	var reportLabel string
-	if val, ok := pj.ObjectMeta.Labels[kube.GerritReportLabel]; ok {
-		reportLabel = val
-	} else {
-		reportLabel = codeReview
+	// labelsPassed maps the labels to vote on to whether all jobs voting on
+	// them passed.
+	labelsPassed := map[string]bool{}
+	if aggregate {
+		// Jobs not voting on any label are optional and don't affect votes.
+		for _, job := range toReportJobs {
+			label := reportLabelOf(job)
+			if label == "" {
+				continue
+			}
+			passed, seen := labelsPassed[label]
+			labelsPassed[label] = (passed || !seen) && job.Status.State == v1.SuccessState
+		}
+	} else if label := reportLabelOf(pj); label != "" {
+		labelsPassed[label] = report.Success == report.Total
+	}
+	var labels []string
+	for label := range labelsPassed {
+		labels = append(labels, label)
 	}
+	sort.Strings(labels)
+	reportLabel := strings.Join(labels, ", ")
 
 	if report.Total <= 0 {
 		// Shouldn't happen but return if does
@@ -380,35 +457,41 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	var reviewLabels map[string]string
 	var change *gerrit.ChangeInfo
 	var err error
-	if reportLabel != "" {
+	for _, label := range labels {
+		if reviewLabels == nil {
+			reviewLabels = map[string]string{}
+		}
 		var vote string
 		// Can only vote below zero before merge
 		// TODO(fejta): cannot vote below previous vote after merge
 		switch {
-		case report.Success == report.Total:
+		case labelsPassed[label]:
 			vote = lgtm
 		case pj.Spec.Type == v1.PresubmitJob:
 			//https://gerrit-documentation.storage.googleapis.com/Documentation/3.1.4/config-labels.html#label_allowPostSubmit
 			// If presubmit and failure vote -1...
 			vote = lbtm
 
-			change, err = c.gc.GetChange(gerritInstance, gerritID)
-			if err != nil {
-				exist, existErr := c.gc.ChangeExist(gerritInstance, gerritID)
-				if existErr == nil && !exist {
-					// PR was deleted, no reason to report or retry
-					logger.WithError(err).Info("Change doesn't exist any more, skip reporting.")
-					return nil, nil, nil
+			if change == nil {
+				change, err = c.gc.GetChange(gerritInstance, gerritID)
+				if err != nil {
+					exist, existErr := c.gc.ChangeExist(gerritInstance, gerritID)
+					if existErr == nil && !exist {
+						// PR was deleted, no reason to report or retry
+						logger.WithError(err).Info("Change doesn't exist any more, skip reporting.")
+						return nil, nil, nil
+					}
+					logger.WithError(err).Warn("Unable to get change")
 				}
-				logger.WithError(err).Warn("Unable to get change")
-			} else if change.Status == client.Merged {
+			}
+			if change != nil && change.Status == client.Merged {
 				// Unless change is already merged. Merged changes should not be voted <0
 				vote = lztm
 			}
 		default:
 			vote = lztm
 		}
-		reviewLabels = map[string]string{reportLabel: vote}
+		reviewLabels[label] = vote
 	}
 
 	logger.Infof("Reporting to instance %s on id %s with message %s", gerritInstance, gerritID, message)
@@ -491,6 +574,52 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	return nil, nil, err
 }
 
+// reportCheck reports the state of the job to its checker.
+func (c *Client) reportCheck(checks *config.GerritChecks, pj *v1.ProwJob) error {
+	instance := pj.ObjectMeta.Annotations[kube.GerritInstance]
+	if checks.CreateCheckers {
+		if err := c.gc.EnsureChecker(instance, client.CheckerForJob(checks, pj.Spec.Refs.Repo, pj.Spec.Job)); err != nil {
+			return err
+		}
+	}
+	input := client.CheckInput{
+		CheckerUUID: checks.CheckerUUID(pj.Spec.Job),
+		State:       checkState(pj.Status.State),
+		Message:     pj.DescriptionWithFailureReason(),
+		URL:         pj.Status.URL,
+	}
+	if !pj.Status.StartTime.IsZero() {
+		input.Started = &gerrit.Timestamp{Time: pj.Status.StartTime.UTC()}
+	}
+	if pj.Status.CompletionTime != nil {
+		input.Finished = &gerrit.Timestamp{Time: pj.Status.CompletionTime.UTC()}
+	}
+	return c.gc.UpdateCheck(instance, pj.ObjectMeta.Annotations[kube.GerritID], pj.ObjectMeta.Labels[kube.GerritRevision], input)
+}
+
+// checkState maps the state of a job to the state of its check.
+func checkState(state v1.ProwJobState) string {
+	switch state {
+	case v1.TriggeredState:
+		return client.CheckScheduled
+	case v1.PendingState:
+		return client.CheckRunning
+	case v1.SuccessState:
+		return client.CheckSuccessful
+	default:
+		return client.CheckFailed
+	}
+}
+
+// reportLabelOf returns the label the job votes on, which is empty if the
+// job doesn't vote.
+func reportLabelOf(pj *v1.ProwJob) string {
+	if val, ok := pj.ObjectMeta.Labels[kube.GerritReportLabel]; ok {
+		return val
+	}
+	return codeReview
+}
+
 func jobNames(jobs []*v1.ProwJob) []string {
 	names := make([]string, len(jobs))
 	for i, job := range jobs {
@@ -28,15 +28,19 @@ import (
 	"time"
 
 	"github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/diff"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
 	"sigs.k8s.io/prow/pkg/kube"
 )
 
@@ -53,6 +57,21 @@ type fgc struct {
 	instance      string
 	changes       map[string][]*gerrit.ChangeInfo
 	count         int
+	checks        []client.CheckInput
+	checkers      []string
+}
+
+func (f *fgc) EnsureChecker(instance string, input client.CheckerInput) error {
+	f.checkers = append(f.checkers, input.UUID)
+	return nil
+}
+
+func (f *fgc) UpdateCheck(instance, id, revision string, input client.CheckInput) error {
+	if instance != f.instance {
+		return fmt.Errorf("wrong instance: %s", instance)
+	}
+	f.checks = append(f.checks, input)
+	return nil
 }
 
 func (f *fgc) SetReview(instance, id, revision, message string, labels map[string]string) error {
@@ -2064,6 +2083,307 @@ func TestReport(t *testing.T) {
 	}
 }
 
+func TestReportChecks(t *testing.T) {
+	changes := map[string][]*gerrit.ChangeInfo{
+		"gerrit": {
+			{ID: "123-abc", Status: "NEW", Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
+		},
+	}
+	started := metav1.NewTime(timeNow)
+	completed := metav1.NewTime(timeNow.Add(time.Minute))
+	pj := func(job string, state v1.ProwJobState) *v1.ProwJob {
+		pj := &v1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      job,
+				Namespace: "default",
+				Labels: map[string]string{
+					kube.GerritRevision:    "abc",
+					kube.ProwJobTypeLabel:  presubmit,
+					kube.GerritReportLabel: "Code-Review",
+					kube.OrgLabel:          "gerrit",
+					kube.RepoLabel:         "repo",
+					kube.PullLabel:         "123",
+				},
+				Annotations: map[string]string{
+					kube.GerritID:       "123-abc",
+					kube.GerritInstance: "gerrit",
+				},
+			},
+			Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Job:    job,
+				Report: true,
+				Refs:   &v1.Refs{Org: "gerrit", Repo: "repo", Pulls: []v1.Pull{{Number: 123}}},
+			},
+			Status: v1.ProwJobStatus{
+				State:       state,
+				Description: "Job " + string(state),
+				URL:         "https://prow/view/" + job,
+				StartTime:   started,
+			},
+		}
+		if state != v1.TriggeredState && state != v1.PendingState {
+			pj.Status.CompletionTime = &completed
+		}
+		return pj
+	}
+	var testcases = []struct {
+		name           string
+		pj             *v1.ProwJob
+		existingPJs    []*v1.ProwJob
+		createCheckers bool
+		expectChecks   []client.CheckInput
+		expectCheckers []string
+		expectReview   bool
+	}{
+		{
+			name: "pending job reports a running check but no review",
+			pj:   pj("ci-foo", v1.PendingState),
+			expectChecks: []client.CheckInput{{
+				CheckerUUID: "prow:ci-foo",
+				State:       client.CheckRunning,
+				Message:     "Job pending",
+				URL:         "https://prow/view/ci-foo",
+				Started:     &gerrit.Timestamp{Time: timeNow},
+			}},
+		},
+		{
+			name: "finished job reports a check and a review",
+			pj:   pj("ci-foo", v1.SuccessState),
+			expectChecks: []client.CheckInput{{
+				CheckerUUID: "prow:ci-foo",
+				State:       client.CheckSuccessful,
+				Message:     "Job success",
+				URL:         "https://prow/view/ci-foo",
+				Started:     &gerrit.Timestamp{Time: timeNow},
+				Finished:    &gerrit.Timestamp{Time: timeNow.Add(time.Minute)},
+			}},
+			expectReview: true,
+		},
+		{
+			name:        "finished job reports a check while other jobs are running",
+			pj:          pj("ci-foo", v1.FailureState),
+			existingPJs: []*v1.ProwJob{pj("ci-bar", v1.PendingState)},
+			expectChecks: []client.CheckInput{{
+				CheckerUUID: "prow:ci-foo",
+				State:       client.CheckFailed,
+				Message:     "Job failure",
+				URL:         "https://prow/view/ci-foo",
+				Started:     &gerrit.Timestamp{Time: timeNow},
+				Finished:    &gerrit.Timestamp{Time: timeNow.Add(time.Minute)},
+			}},
+		},
+		{
+			name:           "checkers are created when configured",
+			pj:             pj("ci-foo", v1.TriggeredState),
+			createCheckers: true,
+			expectChecks: []client.CheckInput{{
+				CheckerUUID: "prow:ci-foo",
+				State:       client.CheckScheduled,
+				Message:     "Job triggered",
+				URL:         "https://prow/view/ci-foo",
+				Started:     &gerrit.Timestamp{Time: timeNow},
+			}},
+			expectCheckers: []string{"prow:ci-foo"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fgc := &fgc{instance: "gerrit", changes: changes}
+			builder := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(tc.pj)
+			for _, pj := range tc.existingPJs {
+				builder.WithRuntimeObjects(pj)
+			}
+			orgRepoConfigs := &config.GerritOrgRepoConfigs{{
+				Org:    "gerrit",
+				Repos:  []string{"repo"},
+				Checks: &config.GerritChecks{Scheme: "prow", CreateCheckers: tc.createCheckers},
+			}}
+			reporter := &Client{
+				gc:                  fgc,
+				pjclientset:         builder.Build(),
+				prLocks:             criercommonlib.NewShardedLock(),
+				orgRepoConfigGetter: func() *config.GerritOrgRepoConfigs { return orgRepoConfigs },
+			}
+
+			log := logrus.NewEntry(logrus.StandardLogger())
+			if !reporter.ShouldReport(context.Background(), log, tc.pj) {
+				t.Fatal("expected job reporting to checks to be reported")
+			}
+			if _, _, err := reporter.Report(context.Background(), log, tc.pj); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectChecks, fgc.checks); diff != "" {
+				t.Errorf("checks differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectCheckers, fgc.checkers); diff != "" {
+				t.Errorf("checkers differ from expected (-want +got):\n%s", diff)
+			}
+			if reviewed := fgc.count > 0; reviewed != tc.expectReview {
+				t.Errorf("expected review: %t, got: %t", tc.expectReview, reviewed)
+			}
+		})
+	}
+}
+
+func TestReportAggregated(t *testing.T) {
+	changes := map[string][]*gerrit.ChangeInfo{
+		"gerrit": {
+			{ID: "123-abc", Status: "NEW", Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
+		},
+	}
+	created := metav1.NewTime(timeNow)
+	pj := func(name, job, label string, state v1.ProwJobState) *v1.ProwJob {
+		return &v1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: created,
+				Labels: map[string]string{
+					kube.GerritRevision:    "abc",
+					kube.GerritPatchset:    "1",
+					kube.ProwJobTypeLabel:  presubmit,
+					kube.GerritReportLabel: label,
+					kube.OrgLabel:          "gerrit",
+					kube.RepoLabel:         "repo",
+					kube.PullLabel:         "123",
+				},
+				Annotations: map[string]string{
+					kube.GerritID:       "123-abc",
+					kube.GerritInstance: "gerrit",
+				},
+			},
+			Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Job:    job,
+				Report: true,
+				Refs:   &v1.Refs{Org: "gerrit", Repo: "repo", Pulls: []v1.Pull{{Number: 123}}},
+			},
+			Status: v1.ProwJobStatus{
+				State: state,
+				URL:   "https://prow/view/" + name,
+			},
+		}
+	}
+	newer := func(pj *v1.ProwJob) *v1.ProwJob {
+		pj.CreationTimestamp = metav1.NewTime(timeNow.Add(time.Minute))
+		return pj
+	}
+	var testcases = []struct {
+		name          string
+		pj            *v1.ProwJob
+		existingPJs   []*v1.ProwJob
+		expectReport  bool
+		expectLabels  map[string]string
+		expectMessage string
+	}{
+		{
+			name:        "optional job waits for required jobs",
+			pj:          pj("ci-optional", "ci-optional", "", v1.FailureState),
+			existingPJs: []*v1.ProwJob{pj("ci-required", "ci-required", "Code-Review", v1.PendingState)},
+		},
+		{
+			name:        "required job waits for optional jobs",
+			pj:          pj("ci-required", "ci-required", "Code-Review", v1.SuccessState),
+			existingPJs: []*v1.ProwJob{pj("ci-optional", "ci-optional", "", v1.PendingState)},
+		},
+		{
+			name:         "failed optional job does not affect the vote",
+			pj:           pj("ci-required", "ci-required", "Code-Review", v1.SuccessState),
+			existingPJs:  []*v1.ProwJob{pj("ci-optional", "ci-optional", "", v1.FailureState)},
+			expectReport: true,
+			expectLabels: map[string]string{"Code-Review": lgtm},
+			expectMessage: "Prow Status: 1 out of 2 pjs passed! 👉 Comment `/retest` to rerun only failed tests (if any), or `/test all` to rerun all tests.\n" +
+				"❌ [ci-optional](https://prow/view/ci-optional) FAILURE\n" +
+				"✔️ [ci-required](https://prow/view/ci-required) SUCCESS\n",
+		},
+		{
+			name: "each label is voted on by its jobs",
+			pj:   pj("ci-verify", "ci-verify", "Verified", v1.FailureState),
+			existingPJs: []*v1.ProwJob{
+				pj("ci-review", "ci-review", "Code-Review", v1.SuccessState),
+				pj("ci-optional", "ci-optional", "", v1.SuccessState),
+			},
+			expectReport: true,
+			expectLabels: map[string]string{"Code-Review": lgtm, "Verified": lbtm},
+			expectMessage: "Prow Status: 2 out of 3 pjs passed! 👉 Comment `/retest` to rerun only failed tests (if any), or `/test all` to rerun all tests.\n" +
+				"❌ [ci-verify](https://prow/view/ci-verify) FAILURE\n" +
+				"✔️ [ci-review](https://prow/view/ci-review) SUCCESS\n" +
+				"✔️ [ci-optional](https://prow/view/ci-optional) SUCCESS\n",
+		},
+		{
+			name:         "only the most recent run of a job is reported",
+			pj:           newer(pj("ci-required-2", "ci-required", "Code-Review", v1.SuccessState)),
+			existingPJs:  []*v1.ProwJob{pj("ci-required-1", "ci-required", "Code-Review", v1.FailureState)},
+			expectReport: true,
+			expectLabels: map[string]string{"Code-Review": lgtm},
+			expectMessage: "Prow Status: 1 out of 1 pjs passed! 👉 Comment `/retest` to rerun only failed tests (if any), or `/test all` to rerun all tests.\n" +
+				"✔️ [ci-required](https://prow/view/ci-required-2) SUCCESS\n",
+		},
+		{
+			name:         "only optional jobs don't vote",
+			pj:           pj("ci-optional", "ci-optional", "", v1.FailureState),
+			existingPJs:  []*v1.ProwJob{pj("ci-other", "ci-other", "", v1.SuccessState)},
+			expectReport: true,
+			expectMessage: "Prow Status: 1 out of 2 pjs passed! 👉 Comment `/retest` to rerun only failed tests (if any), or `/test all` to rerun all tests.\n" +
+				"❌ [ci-optional](https://prow/view/ci-optional) FAILURE\n" +
+				"✔️ [ci-other](https://prow/view/ci-other) SUCCESS\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fgc := &fgc{instance: "gerrit", changes: changes}
+			builder := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(tc.pj)
+			for _, pj := range tc.existingPJs {
+				builder.WithRuntimeObjects(pj)
+			}
+			pjclient := builder.Build()
+			orgRepoConfigs := &config.GerritOrgRepoConfigs{{
+				Org:              "gerrit",
+				Repos:            []string{"repo"},
+				AggregateReports: true,
+			}}
+			reporter := &Client{
+				gc:                  fgc,
+				pjclientset:         pjclient,
+				prLocks:             criercommonlib.NewShardedLock(),
+				orgRepoConfigGetter: func() *config.GerritOrgRepoConfigs { return orgRepoConfigs },
+			}
+
+			log := logrus.NewEntry(logrus.StandardLogger())
+			if shouldReport := reporter.ShouldReport(context.Background(), log, tc.pj); shouldReport != tc.expectReport {
+				t.Fatalf("expected should report: %t, got: %t", tc.expectReport, shouldReport)
+			}
+			if !tc.expectReport {
+				return
+			}
+			if _, _, err := reporter.Report(context.Background(), log, tc.pj); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fgc.count != 1 {
+				t.Fatalf("expected one review, got %d", fgc.count)
+			}
+			if diff := cmp.Diff(tc.expectMessage, fgc.reportMessage); diff != "" {
+				t.Errorf("message differs from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectLabels, fgc.reportLabel); diff != "" {
+				t.Errorf("labels differ from expected (-want +got):\n%s", diff)
+			}
+			for _, existing := range tc.existingPJs {
+				var pj v1.ProwJob
+				if err := pjclient.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(existing), &pj); err != nil {
+					t.Fatalf("failed to get prowjob: %v", err)
+				}
+				if pj.Status.PrevReportStates[reporter.GetName()] != pj.Status.State {
+					t.Errorf("expected prowjob %s to be marked as reported", pj.Name)
+				}
+			}
+		})
+	}
+}
+
 func TestMultipleWorks(t *testing.T) {
 	samplePJ := v1.ProwJob{
 		ObjectMeta: metav1.ObjectMeta{
@@ -48,6 +48,9 @@ type Client struct {
 	reportAgent v1.ProwJobAgent
 	prLocks     *criercommonlib.ShardedLock
 	lister      ctrlruntimeclient.Reader
+	// provider is the name of the SCM provider whose orgs are reported, or
+	// empty for GitHub.
+	provider string
 }
 
 // NewReporter returns a reporter client
@@ -63,8 +66,19 @@ func NewReporter(gc report.GitHubClient, cfg config.Getter, reportAgent v1.ProwJ
 	return c
 }
 
+// NewSCMReporter returns a reporter client for the orgs that are configured
+// to be on the SCM provider. gc operates on the pull requests of the provider.
+func NewSCMReporter(provider string, gc report.GitHubClient, cfg config.Getter, reportAgent v1.ProwJobAgent, lister ctrlruntimeclient.Reader) *Client {
+	c := NewReporter(gc, cfg, reportAgent, lister)
+	c.provider = provider
+	return c
+}
+
 // GetName returns the name of the reporter
 func (c *Client) GetName() string {
+	if c.provider != "" {
+		return c.provider + "-reporter"
+	}
 	return GitHubReporterName
 }
 
@@ -81,6 +95,8 @@ func (c *Client) ShouldReport(_ context.Context, _ *logrus.Entry, pj *v1.ProwJob
 		return false // Report presubmit and postsubmit github jobs for github reporter
 	case c.reportAgent != "" && pj.Spec.Agent != c.reportAgent:
 		return false // Only report for specified agent
+	case pj.Spec.Refs != nil && c.config().SCMProviderOf(pj.Spec.Refs.Org) != c.provider:
+		return false // Only report the orgs of the provider
 	}
 
 	return true
@@ -42,6 +42,7 @@ func TestShouldReport(t *testing.T) {
 		pj          v1.ProwJob
 		report      bool
 		reportAgent v1.ProwJobAgent
+		provider    string
 	}{
 		{
 			name: "should not report periodic job",
@@ -97,11 +98,50 @@ func TestShouldReport(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "github should not report jobs of bitbucket server orgs",
+			pj: v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Type:   v1.PresubmitJob,
+					Report: true,
+					Refs:   &v1.Refs{Org: "PRJ", Repo: "repo"},
+				},
+			},
+		},
+		{
+			name: "bitbucket server should report jobs of its orgs",
+			pj: v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Type:   v1.PresubmitJob,
+					Report: true,
+					Refs:   &v1.Refs{Org: "PRJ", Repo: "repo"},
+				},
+			},
+			provider: "bitbucket-server",
+			report:   true,
+		},
+		{
+			name: "bitbucket server should not report jobs of github orgs",
+			pj: v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Type:   v1.PresubmitJob,
+					Report: true,
+					Refs:   &v1.Refs{Org: "org", Repo: "repo"},
+				},
+			},
+			provider: "bitbucket-server",
+		},
+	}
+
+	cfg := func() *config.Config {
+		return &config.Config{ProwConfig: config.ProwConfig{
+			SCM: map[string]config.SCMProvider{"bitbucket-server": {Orgs: []string{"PRJ"}}},
+		}}
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			c := NewReporter(nil, nil, tc.reportAgent, nil)
+			c := NewSCMReporter(tc.provider, nil, cfg, tc.reportAgent, nil)
 			if r := c.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), &tc.pj); r == tc.report {
 				return
 			}
@@ -22,6 +22,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	stdio "io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,7 +34,9 @@ import (
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
+	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/io/providers"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
 )
 
@@ -43,6 +47,19 @@ const (
 	PubSubTopicLabel = "prow.k8s.io/pubsub.topic"
 	// PubSubRunIDLabel annotation
 	PubSubRunIDLabel = "prow.k8s.io/pubsub.runID"
+	// PubSubCorrelationIDLabel annotation carries the correlation ID of the
+	// Pub/Sub message that triggered the job.
+	PubSubCorrelationIDLabel = "prow.k8s.io/pubsub.correlationID"
+	// PubSubCompletionOnlyLabel annotation limits the reports to the
+	// completion of the job when set to "true".
+	PubSubCompletionOnlyLabel = "prow.k8s.io/pubsub.completionOnly"
+	// PubSubSchemaVersionLabel annotation selects the schema version of the
+	// reported messages, defaults to 1.
+	PubSubSchemaVersionLabel = "prow.k8s.io/pubsub.schemaVersion"
+
+	// SchemaVersion2 adds the labels, annotations and artifacts manifest of
+	// the job to the reported messages.
+	SchemaVersion2 = 2
 )
 
 // ReportMessage is a message structure used to pass a prowjob status to Pub/Sub topic.s
@@ -57,17 +74,56 @@ type ReportMessage struct {
 	JobType prowapi.ProwJobType  `json:"job_type"`
 	JobName string               `json:"job_name"`
 	Message string               `json:"message,omitempty"`
+	// FailureReason classifies why the job did not succeed.
+	FailureReason prowapi.ProwJobFailureReason `json:"failure_reason,omitempty"`
+	// MessageID is the ID of the Pub/Sub message that triggered the job.
+	MessageID string `json:"message_id,omitempty"`
+	// CorrelationID is the correlation ID of the Pub/Sub message that
+	// triggered the job.
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// The fields below are only reported from schema version 2 on.
+	SchemaVersion int                `json:"schema_version,omitempty"`
+	Labels        map[string]string  `json:"labels,omitempty"`
+	Annotations   map[string]string  `json:"annotations,omitempty"`
+	Artifacts     *ArtifactsManifest `json:"artifacts,omitempty"`
+}
+
+// ArtifactsManifest holds the paths of the key files a job uploads to its
+// storage path, so consumers don't have to list the storage bucket.
+type ArtifactsManifest struct {
+	// Listed is true if the paths were listed from the storage bucket, they
+	// are the default locations of the files otherwise.
+	Listed    bool     `json:"listed"`
+	BuildLogs []string `json:"build_logs,omitempty"`
+	Started   string   `json:"started,omitempty"`
+	Finished  string   `json:"finished,omitempty"`
+	ProwJob   string   `json:"prowjob,omitempty"`
+	// Artifacts is the directory holding the artifacts of the job.
+	Artifacts string   `json:"artifacts,omitempty"`
+	Metadata  string   `json:"metadata,omitempty"`
+	JUnit     []string `json:"junit,omitempty"`
 }
 
 // Client is a reporter client fed to crier controller
 type Client struct {
 	config config.Getter
+	opener io.Opener
 }
 
 // NewReporter creates a new Pub/Sub reporter
 func NewReporter(cfg config.Getter) *Client {
+	return NewReporterWithOpener(cfg, nil)
+}
+
+// NewReporterWithOpener creates a new Pub/Sub reporter that lists the
+// storage path of completed jobs for the artifacts manifest of schema
+// version 2. Without an opener the manifest holds the default locations of
+// the files.
+func NewReporterWithOpener(cfg config.Getter, opener io.Opener) *Client {
 	return &Client{
 		config: cfg,
+		opener: opener,
 	}
 }
 
@@ -91,8 +147,17 @@ func findLabels(pj *prowapi.ProwJob, labels ...string) map[string]string {
 
 // ShouldReport tells if a prowjob should be reported by this reporter
 func (c *Client) ShouldReport(_ context.Context, _ *logrus.Entry, pj *prowapi.ProwJob) bool {
-	pubSubMap := findLabels(pj, PubSubProjectLabel, PubSubTopicLabel)
-	return pubSubMap[PubSubProjectLabel] != "" && pubSubMap[PubSubTopicLabel] != ""
+	pubSubMap := findLabels(pj, PubSubProjectLabel, PubSubTopicLabel, PubSubCompletionOnlyLabel)
+	if pubSubMap[PubSubProjectLabel] == "" || pubSubMap[PubSubTopicLabel] == "" {
+		return false
+	}
+	if pubSubMap[PubSubCompletionOnlyLabel] == "true" {
+		switch pj.Status.State {
+		case prowapi.SchedulingState, prowapi.TriggeredState, prowapi.PendingState:
+			return false
+		}
+	}
+	return true
 }
 
 // Report takes a prowjob, and generate a pubsub ReportMessage and publish to specific Pub/Sub topic
@@ -102,6 +167,9 @@ func (c *Client) Report(ctx context.Context, l *logrus.Entry, pj *prowapi.ProwJo
 	defer cancel()
 
 	message := c.generateMessageFromPJ(pj)
+	if message.SchemaVersion >= SchemaVersion2 {
+		message.Artifacts = c.artifactsManifest(ctx, l, pj, message.GCSPath)
+	}
 	// TODO: Consider caching the pubsub client.
 	client, err := pubsub.NewClient(ctx, message.Project)
 	if err != nil {
@@ -122,8 +190,16 @@ func (c *Client) Report(ctx context.Context, l *logrus.Entry, pj *prowapi.ProwJo
 		return nil, nil, fmt.Errorf("could not marshal pubsub report: %w", err)
 	}
 
+	attributes := map[string]string{}
+	if message.RunID != "" {
+		attributes[PubSubRunIDLabel] = message.RunID
+	}
+	if message.CorrelationID != "" {
+		attributes[PubSubCorrelationIDLabel] = message.CorrelationID
+	}
 	res := topic.Publish(ctx, &pubsub.Message{
-		Data: d,
+		Data:       d,
+		Attributes: attributes,
 	})
 
 	_, err = res.Get(ctx)
@@ -148,7 +224,7 @@ func (c *Client) Report(ctx context.Context, l *logrus.Entry, pj *prowapi.ProwJo
 }
 
 func (c *Client) generateMessageFromPJ(pj *prowapi.ProwJob) *ReportMessage {
-	pubSubMap := findLabels(pj, PubSubProjectLabel, PubSubTopicLabel, PubSubRunIDLabel)
+	pubSubMap := findLabels(pj, PubSubProjectLabel, PubSubTopicLabel, PubSubRunIDLabel, PubSubCorrelationIDLabel, PubSubSchemaVersionLabel)
 	var refs []prowapi.Refs
 	if pj.Spec.Refs != nil {
 		refs = append(refs, *pj.Spec.Refs)
@@ -182,16 +258,103 @@ func (c *Client) generateMessageFromPJ(pj *prowapi.ProwJob) *ReportMessage {
 
 	}
 
-	return &ReportMessage{
-		Project: pubSubMap[PubSubProjectLabel],
-		Topic:   pubSubMap[PubSubTopicLabel],
-		RunID:   pubSubMap[PubSubRunIDLabel],
-		Status:  pj.Status.State,
-		URL:     pj.Status.URL,
-		GCSPath: storagePath,
-		Refs:    refs,
-		JobType: pj.Spec.Type,
-		JobName: pj.Spec.Job,
-		Message: pj.Status.Description,
+	message := &ReportMessage{
+		Project:       pubSubMap[PubSubProjectLabel],
+		Topic:         pubSubMap[PubSubTopicLabel],
+		RunID:         pubSubMap[PubSubRunIDLabel],
+		Status:        pj.Status.State,
+		URL:           pj.Status.URL,
+		GCSPath:       storagePath,
+		Refs:          refs,
+		JobType:       pj.Spec.Type,
+		JobName:       pj.Spec.Job,
+		Message:       pj.Status.Description,
+		FailureReason: pj.Status.FailureReason,
+		MessageID:     pj.Annotations[kube.PubSubMessageIDAnnotation],
+		CorrelationID: pubSubMap[PubSubCorrelationIDLabel],
+	}
+	if version, err := strconv.Atoi(pubSubMap[PubSubSchemaVersionLabel]); err == nil && version >= SchemaVersion2 {
+		message.SchemaVersion = SchemaVersion2
+		message.Labels = pj.Labels
+		message.Annotations = pj.Annotations
+	}
+	return message
+}
+
+// artifactsManifest lists the key files of the job below its storage path.
+// The default locations of the files are reported for jobs that are not
+// complete yet or if listing fails.
+func (c *Client) artifactsManifest(ctx context.Context, l *logrus.Entry, pj *prowapi.ProwJob, storagePath string) *ArtifactsManifest {
+	if storagePath == "" {
+		return nil
+	}
+	storagePath = strings.TrimSuffix(storagePath, "/") + "/"
+	if c.opener != nil && pj.Complete() {
+		manifest, err := c.listArtifacts(ctx, storagePath)
+		if err == nil {
+			return manifest
+		}
+		l.WithError(err).Warn("Failed listing artifacts, reporting their default locations.")
+	}
+	manifest := &ArtifactsManifest{
+		BuildLogs: []string{storagePath + buildLogFile},
+		Started:   storagePath + prowapi.StartedStatusFile,
+		Artifacts: storagePath + artifactsDir,
+	}
+	if pj.Complete() {
+		manifest.Finished = storagePath + prowapi.FinishedStatusFile
+		manifest.ProwJob = storagePath + prowapi.ProwJobFile
+	}
+	return manifest
+}
+
+const (
+	buildLogFile = "build-log.txt"
+	artifactsDir = "artifacts/"
+	metadataFile = "metadata.json"
+)
+
+func (c *Client) listArtifacts(ctx context.Context, storagePath string) (*ArtifactsManifest, error) {
+	storageProvider, bucket, _, err := providers.ParseStoragePath(storagePath)
+	if err != nil {
+		return nil, err
+	}
+	it, err := c.opener.Iterator(ctx, storagePath, "")
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ArtifactsManifest{Listed: true}
+	for {
+		attrs, err := it.Next(ctx)
+		if err == stdio.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if attrs.IsDir {
+			continue
+		}
+		path := fmt.Sprintf("%s://%s/%s", storageProvider, bucket, attrs.Name)
+		name := strings.TrimPrefix(path, storagePath)
+		base := name[strings.LastIndex(name, "/")+1:]
+		switch {
+		case name == prowapi.StartedStatusFile:
+			manifest.Started = path
+		case name == prowapi.FinishedStatusFile:
+			manifest.Finished = path
+		case name == prowapi.ProwJobFile:
+			manifest.ProwJob = path
+		case !strings.Contains(name, "/") && strings.HasSuffix(name, buildLogFile):
+			manifest.BuildLogs = append(manifest.BuildLogs, path)
+		case strings.HasPrefix(name, artifactsDir):
+			manifest.Artifacts = storagePath + artifactsDir
+			if name == artifactsDir+metadataFile {
+				manifest.Metadata = path
+			} else if strings.HasPrefix(base, "junit") && strings.HasSuffix(base, ".xml") {
+				manifest.JUnit = append(manifest.JUnit, path)
+			}
+		}
 	}
+	return manifest, nil
 }
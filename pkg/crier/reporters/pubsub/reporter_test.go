@@ -18,15 +18,20 @@ package pubsub
 
 import (
 	"context"
+	"errors"
+	stdio "io"
 	"reflect"
 	"sync"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/kube"
 )
 
 const (
@@ -323,6 +328,59 @@ func TestGenerateMessageFromPJ(t *testing.T) {
 				Message: "this job went great",
 			},
 		},
+		{
+			name: "Prowjob triggered by a Pub/Sub message reports the message and correlation IDs",
+			pj: &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+					Annotations: map[string]string{
+						PubSubProjectLabel:             testPubSubProjectName,
+						PubSubTopicLabel:               testPubSubTopicName,
+						PubSubCorrelationIDLabel:       "correlation-1",
+						kube.PubSubMessageIDAnnotation: "1234",
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.FailureState,
+				},
+			},
+			expectedMessage: &ReportMessage{
+				Project:       testPubSubProjectName,
+				Topic:         testPubSubTopicName,
+				Status:        prowapi.FailureState,
+				MessageID:     "1234",
+				CorrelationID: "correlation-1",
+			},
+		},
+		{
+			name: "Prowjob reporting schema version 2 adds labels and annotations",
+			pj: &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test1",
+					Labels: map[string]string{"created-by-prow": "true"},
+					Annotations: map[string]string{
+						PubSubProjectLabel:       testPubSubProjectName,
+						PubSubTopicLabel:         testPubSubTopicName,
+						PubSubSchemaVersionLabel: "2",
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.SuccessState,
+				},
+			},
+			expectedMessage: &ReportMessage{
+				Project:       testPubSubProjectName,
+				Topic:         testPubSubTopicName,
+				Status:        prowapi.SuccessState,
+				SchemaVersion: SchemaVersion2,
+				Labels:        map[string]string{"created-by-prow": "true"},
+				Annotations: map[string]string{
+					PubSubProjectLabel:       testPubSubProjectName,
+					PubSubTopicLabel:         testPubSubTopicName,
+					PubSubSchemaVersionLabel: "2",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -453,6 +511,40 @@ func TestShouldReport(t *testing.T) {
 			},
 			expectedResult: false,
 		},
+		{
+			name: "Pending prowjob reporting only its completion should not report",
+			pj: &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-completion-only",
+					Annotations: map[string]string{
+						PubSubProjectLabel:        testPubSubProjectName,
+						PubSubTopicLabel:          testPubSubTopicName,
+						PubSubCompletionOnlyLabel: "true",
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.PendingState,
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "Completed prowjob reporting only its completion should report",
+			pj: &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-completion-only",
+					Annotations: map[string]string{
+						PubSubProjectLabel:        testPubSubProjectName,
+						PubSubTopicLabel:          testPubSubTopicName,
+						PubSubCompletionOnlyLabel: "true",
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.ErrorState,
+				},
+			},
+			expectedResult: true,
+		},
 	}
 
 	var fakeConfigAgent fca
@@ -467,3 +559,109 @@ func TestShouldReport(t *testing.T) {
 		}
 	}
 }
+
+type fakeIterator struct {
+	names []string
+}
+
+func (it *fakeIterator) Next(_ context.Context) (io.ObjectAttributes, error) {
+	if len(it.names) == 0 {
+		return io.ObjectAttributes{}, stdio.EOF
+	}
+	name := it.names[0]
+	it.names = it.names[1:]
+	return io.ObjectAttributes{Name: name}, nil
+}
+
+type fakeOpener struct {
+	io.Opener
+	names []string
+	err   error
+}
+
+func (o *fakeOpener) Iterator(_ context.Context, prefix, _ string) (io.ObjectIterator, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	return &fakeIterator{names: o.names}, nil
+}
+
+func TestArtifactsManifest(t *testing.T) {
+	completed := metav1.Now()
+	for _, tc := range []struct {
+		name     string
+		opener   io.Opener
+		complete bool
+		expected *ArtifactsManifest
+	}{
+		{
+			name:   "running job reports default locations",
+			opener: &fakeOpener{},
+			expected: &ArtifactsManifest{
+				BuildLogs: []string{"gs://bucket/logs/job/1/build-log.txt"},
+				Started:   "gs://bucket/logs/job/1/started.json",
+				Artifacts: "gs://bucket/logs/job/1/artifacts/",
+			},
+		},
+		{
+			name:     "completed job without opener reports default locations",
+			complete: true,
+			expected: &ArtifactsManifest{
+				BuildLogs: []string{"gs://bucket/logs/job/1/build-log.txt"},
+				Started:   "gs://bucket/logs/job/1/started.json",
+				Finished:  "gs://bucket/logs/job/1/finished.json",
+				ProwJob:   "gs://bucket/logs/job/1/prowjob.json",
+				Artifacts: "gs://bucket/logs/job/1/artifacts/",
+			},
+		},
+		{
+			name:     "completed job lists artifacts",
+			complete: true,
+			opener: &fakeOpener{names: []string{
+				"logs/job/1/build-log.txt",
+				"logs/job/1/sidecar-build-log.txt",
+				"logs/job/1/started.json",
+				"logs/job/1/finished.json",
+				"logs/job/1/prowjob.json",
+				"logs/job/1/artifacts/metadata.json",
+				"logs/job/1/artifacts/junit_01.xml",
+				"logs/job/1/artifacts/e2e/junit_runner.xml",
+				"logs/job/1/artifacts/e2e/build-log.txt",
+			}},
+			expected: &ArtifactsManifest{
+				Listed:    true,
+				BuildLogs: []string{"gs://bucket/logs/job/1/build-log.txt", "gs://bucket/logs/job/1/sidecar-build-log.txt"},
+				Started:   "gs://bucket/logs/job/1/started.json",
+				Finished:  "gs://bucket/logs/job/1/finished.json",
+				ProwJob:   "gs://bucket/logs/job/1/prowjob.json",
+				Artifacts: "gs://bucket/logs/job/1/artifacts/",
+				Metadata:  "gs://bucket/logs/job/1/artifacts/metadata.json",
+				JUnit:     []string{"gs://bucket/logs/job/1/artifacts/junit_01.xml", "gs://bucket/logs/job/1/artifacts/e2e/junit_runner.xml"},
+			},
+		},
+		{
+			name:     "completed job falls back to default locations if listing fails",
+			complete: true,
+			opener:   &fakeOpener{err: errors.New("injected error")},
+			expected: &ArtifactsManifest{
+				BuildLogs: []string{"gs://bucket/logs/job/1/build-log.txt"},
+				Started:   "gs://bucket/logs/job/1/started.json",
+				Finished:  "gs://bucket/logs/job/1/finished.json",
+				ProwJob:   "gs://bucket/logs/job/1/prowjob.json",
+				Artifacts: "gs://bucket/logs/job/1/artifacts/",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{}
+			if tc.complete {
+				pj.Status.CompletionTime = &completed
+			}
+			c := NewReporterWithOpener(nil, tc.opener)
+			manifest := c.artifactsManifest(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj, "gs://bucket/logs/job/1")
+			if diff := cmp.Diff(tc.expected, manifest); diff != "" {
+				t.Errorf("unexpected manifest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -116,6 +116,12 @@ func (c *Cron) SyncConfig(cfg *config.Config) error {
 	return utilerrors.NewAggregate(removalErrors)
 }
 
+// ParseSchedule parses a cron expression of a periodic into the schedule it is
+// triggered on.
+func ParseSchedule(cronStr string) (cron.Schedule, error) {
+	return cron.Parse("TZ=UTC " + cronStr)
+}
+
 // HasJob returns if a job has been scheduled in cronAgent or not
 func (c *Cron) HasJob(name string) bool {
 	c.lock.Lock()
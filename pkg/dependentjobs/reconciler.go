@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dependentjobs implements a controller that triggers presubmits and
+// postsubmits configured with run_after_success once all the jobs they depend
+// on succeeded on the same commit.
+package dependentjobs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+const ControllerName = "dependent-jobs"
+
+// inheritedMetadata are labels and annotations that are copied from the job
+// that triggered a dependent job, so that it is reported the same way.
+var inheritedMetadata = []string{
+	github.EventGUID,
+	kube.GerritID,
+	kube.GerritInstance,
+	kube.GerritRevision,
+	kube.GerritPatchset,
+}
+
+func Add(mgr controllerruntime.Manager, cfg config.Getter, numWorkers int) error {
+	predicates := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		pj, isPJ := object.(*prowv1.ProwJob)
+		return isPJ && triggersDependents(pj)
+	})
+
+	reconciler := NewReconciler(mgr.GetClient(), cfg)
+	if err := controllerruntime.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&prowv1.ProwJob{}).
+		WithEventFilter(predicates).
+		WithOptions(controller.Options{MaxConcurrentReconciles: numWorkers}).
+		Complete(reconciler); err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	return nil
+}
+
+// triggersDependents determines if the ProwJob can trigger dependent jobs.
+func triggersDependents(pj *prowv1.ProwJob) bool {
+	if pj.Status.State != prowv1.SuccessState || pj.Spec.Refs == nil {
+		return false
+	}
+	switch pj.Spec.Type {
+	case prowv1.PresubmitJob:
+		return len(pj.Spec.Refs.Pulls) > 0
+	case prowv1.PostsubmitJob:
+		return true
+	}
+	return false
+}
+
+// revision returns the commit a ProwJob tested.
+func revision(pj *prowv1.ProwJob) string {
+	if pj.Spec.Type == prowv1.PresubmitJob && len(pj.Spec.Refs.Pulls) > 0 {
+		return pj.Spec.Refs.Pulls[0].SHA
+	}
+	return pj.Spec.Refs.BaseSHA
+}
+
+type Reconciler struct {
+	pjClient client.Client
+	log      *logrus.Entry
+	cfg      config.Getter
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithField("request", request)
+
+	pj := &prowv1.ProwJob{}
+	if err := r.pjClient.Get(ctx, request.NamespacedName, pj); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("get prowjob %s: %w", request.Name, err)
+		}
+		return reconcile.Result{}, nil
+	}
+	if !triggersDependents(pj) {
+		return reconcile.Result{}, nil
+	}
+
+	log = log.WithFields(pjutil.ProwJobFields(pj))
+	if err := r.triggerDependents(ctx, log, pj); err != nil {
+		return reconcile.Result{}, fmt.Errorf("trigger dependents of prowjob %s: %w", request.Name, err)
+	}
+	return reconcile.Result{}, nil
+}
+
+// dependent is a job that runs after the success of other jobs.
+type dependent struct {
+	name            string
+	runAfterSuccess []string
+	newProwJob      func() prowv1.ProwJob
+}
+
+// dependentsOf returns the jobs configured to run after the success of the
+// given ProwJob that should run against its base ref.
+func (r *Reconciler) dependentsOf(pj *prowv1.ProwJob) []dependent {
+	cfg := r.cfg()
+	orgRepo := pj.Spec.Refs.OrgRepoString()
+	labels, annotations := inheritedLabelsAndAnnotations(pj)
+	refs := prowv1.Refs{
+		Org:      pj.Spec.Refs.Org,
+		Repo:     pj.Spec.Refs.Repo,
+		RepoLink: pj.Spec.Refs.RepoLink,
+		BaseRef:  pj.Spec.Refs.BaseRef,
+		BaseSHA:  pj.Spec.Refs.BaseSHA,
+		BaseLink: pj.Spec.Refs.BaseLink,
+		Pulls:    pj.Spec.Refs.Pulls,
+	}
+	modifier := pjutil.RequireScheduling(cfg.Scheduler.Enabled)
+
+	var dependents []dependent
+	switch pj.Spec.Type {
+	case prowv1.PresubmitJob:
+		for _, ps := range cfg.GetPresubmitsStatic(orgRepo) {
+			if !sets.New(ps.RunAfterSuccess...).Has(pj.Spec.Job) || !ps.AlwaysRun || !ps.CouldRun(refs.BaseRef) {
+				continue
+			}
+			dependents = append(dependents, dependent{
+				name:            ps.Name,
+				runAfterSuccess: ps.RunAfterSuccess,
+				newProwJob: func() prowv1.ProwJob {
+					l := mergeMaps(ps.Labels, labels)
+					l[kube.IsOptionalLabel] = strconv.FormatBool(ps.Optional)
+					a := mergeMaps(ps.Annotations, annotations)
+					a[kube.RunAfterSuccessAnnotation] = strings.Join(ps.RunAfterSuccess, ",")
+					return pjutil.NewProwJob(pjutil.PresubmitSpec(ps, refs), l, a, modifier)
+				},
+			})
+		}
+	case prowv1.PostsubmitJob:
+		for _, ps := range cfg.GetPostsubmitsStatic(orgRepo) {
+			if !sets.New(ps.RunAfterSuccess...).Has(pj.Spec.Job) || !ps.CouldRun(refs.BaseRef) {
+				continue
+			}
+			if ps.AlwaysRun != nil && !*ps.AlwaysRun {
+				continue
+			}
+			dependents = append(dependents, dependent{
+				name:            ps.Name,
+				runAfterSuccess: ps.RunAfterSuccess,
+				newProwJob: func() prowv1.ProwJob {
+					a := mergeMaps(ps.Annotations, annotations)
+					a[kube.RunAfterSuccessAnnotation] = strings.Join(ps.RunAfterSuccess, ",")
+					return pjutil.NewProwJob(pjutil.PostsubmitSpec(ps, refs), mergeMaps(ps.Labels, labels), a, modifier)
+				},
+			})
+		}
+	}
+	return dependents
+}
+
+// triggerDependents creates ProwJobs for all dependents of the given ProwJob
+// whose dependencies all succeeded on the same commit and which have not been
+// triggered for it yet.
+func (r *Reconciler) triggerDependents(ctx context.Context, log *logrus.Entry, pj *prowv1.ProwJob) error {
+	dependents := r.dependentsOf(pj)
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	selector := client.MatchingLabels{}
+	for _, label := range []string{kube.ProwJobTypeLabel, kube.OrgLabel, kube.RepoLabel, kube.PullLabel} {
+		if value, ok := pj.Labels[label]; ok {
+			selector[label] = value
+		}
+	}
+	pjs := &prowv1.ProwJobList{}
+	if err := r.pjClient.List(ctx, pjs, client.InNamespace(pj.Namespace), selector); err != nil {
+		return fmt.Errorf("list prowjobs: %w", err)
+	}
+	succeeded := sets.New[string]()
+	triggered := sets.New[string]()
+	for i := range pjs.Items {
+		other := &pjs.Items[i]
+		if other.Spec.Type != pj.Spec.Type || other.Spec.Refs == nil || revision(other) != revision(pj) {
+			continue
+		}
+		triggered.Insert(other.Spec.Job)
+		if other.Status.State == prowv1.SuccessState {
+			succeeded.Insert(other.Spec.Job)
+		}
+	}
+
+	var errs []error
+	for _, d := range dependents {
+		if triggered.Has(d.name) {
+			continue
+		}
+		if missing := sets.New(d.runAfterSuccess...).Difference(succeeded); missing.Len() > 0 {
+			log.WithField("dependent", d.name).Debugf("Waiting for %s to succeed.", strings.Join(sets.List(missing), ", "))
+			continue
+		}
+		newPJ := d.newProwJob()
+		newPJ.Namespace = pj.Namespace
+		// The name is derived from the job and commit so that concurrent
+		// reconciles of several dependencies can not trigger the job twice.
+		newPJ.Name = uuid.NewSHA1(uuid.NameSpaceURL, []byte(strings.Join([]string{string(pj.Spec.Type), pj.Spec.Refs.OrgRepoString(), d.name, revision(pj)}, "/"))).String()
+		log.WithFields(pjutil.ProwJobFields(&newPJ)).Info("Creating a new prowjob for a dependent job.")
+		if err := r.pjClient.Create(ctx, &newPJ); err != nil && !kerrors.IsAlreadyExists(err) {
+			errs = append(errs, fmt.Errorf("create prowjob for %s: %w", d.name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// inheritedLabelsAndAnnotations returns the labels and annotations of the
+// ProwJob that dependent jobs inherit.
+func inheritedLabelsAndAnnotations(pj *prowv1.ProwJob) (map[string]string, map[string]string) {
+	labels, annotations := map[string]string{}, map[string]string{}
+	for _, key := range inheritedMetadata {
+		if value, ok := pj.Labels[key]; ok {
+			labels[key] = value
+		}
+		if value, ok := pj.Annotations[key]; ok {
+			annotations[key] = value
+		}
+	}
+	return labels, annotations
+}
+
+func mergeMaps(maps ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func NewReconciler(pjClient client.Client, cfg config.Getter) *Reconciler {
+	return &Reconciler{
+		pjClient: pjClient,
+		log:      logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
+		cfg:      cfg,
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependentjobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func presubmitJob(name, job, sha string, state prowv1.ProwJobState) *prowv1.ProwJob {
+	return &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "prowjobs",
+			Labels: map[string]string{
+				kube.ProwJobTypeLabel: string(prowv1.PresubmitJob),
+				kube.OrgLabel:         "org",
+				kube.RepoLabel:        "repo",
+				kube.PullLabel:        "1",
+				github.EventGUID:      "guid",
+			},
+		},
+		Spec: prowv1.ProwJobSpec{
+			Type: prowv1.PresubmitJob,
+			Job:  job,
+			Refs: &prowv1.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "main",
+				BaseSHA: "base",
+				Pulls:   []prowv1.Pull{{Number: 1, SHA: sha}},
+			},
+		},
+		Status: prowv1.ProwJobStatus{State: state},
+	}
+}
+
+func postsubmitJob(name, job, sha string, state prowv1.ProwJobState) *prowv1.ProwJob {
+	return &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "prowjobs",
+			Labels: map[string]string{
+				kube.ProwJobTypeLabel: string(prowv1.PostsubmitJob),
+				kube.OrgLabel:         "org",
+				kube.RepoLabel:        "repo",
+			},
+		},
+		Spec: prowv1.ProwJobSpec{
+			Type: prowv1.PostsubmitJob,
+			Job:  job,
+			Refs: &prowv1.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: sha},
+		},
+		Status: prowv1.ProwJobStatus{State: state},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	never := false
+	cfg := &config.Config{}
+	if err := cfg.SetPresubmits(map[string][]config.Presubmit{
+		"org/repo": {
+			{JobBase: config.JobBase{Name: "unit"}, AlwaysRun: true},
+			{JobBase: config.JobBase{Name: "lint"}, AlwaysRun: true},
+			{JobBase: config.JobBase{Name: "build"}, AlwaysRun: true, RunAfterSuccess: []string{"unit"}},
+			{JobBase: config.JobBase{Name: "e2e"}, AlwaysRun: true, RunAfterSuccess: []string{"unit", "lint"}},
+			{JobBase: config.JobBase{Name: "manual"}, RunAfterSuccess: []string{"unit"}},
+			{JobBase: config.JobBase{Name: "release-only"}, AlwaysRun: true, RunAfterSuccess: []string{"unit"}, Brancher: config.Brancher{Branches: []string{"release"}}},
+		},
+	}); err != nil {
+		t.Fatalf("failed to set presubmits: %v", err)
+	}
+	if err := cfg.SetPostsubmits(map[string][]config.Postsubmit{
+		"org/repo": {
+			{JobBase: config.JobBase{Name: "push-image"}},
+			{JobBase: config.JobBase{Name: "deploy"}, RunAfterSuccess: []string{"push-image"}},
+			{JobBase: config.JobBase{Name: "deploy-manually"}, AlwaysRun: &never, RunAfterSuccess: []string{"push-image"}},
+		},
+	}); err != nil {
+		t.Fatalf("failed to set postsubmits: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		existing []client.Object
+		request  string
+		expected sets.Set[string]
+	}{
+		{
+			name:     "dependent job with a single dependency is triggered",
+			existing: []client.Object{presubmitJob("unit-1", "unit", "head", prowv1.SuccessState)},
+			request:  "unit-1",
+			expected: sets.New("build"),
+		},
+		{
+			name: "dependent job is triggered once all dependencies succeeded",
+			existing: []client.Object{
+				presubmitJob("unit-1", "unit", "head", prowv1.SuccessState),
+				presubmitJob("lint-1", "lint", "head", prowv1.SuccessState),
+			},
+			request:  "lint-1",
+			expected: sets.New("e2e"),
+		},
+		{
+			name: "success of a dependency on another commit does not count",
+			existing: []client.Object{
+				presubmitJob("unit-1", "unit", "old", prowv1.SuccessState),
+				presubmitJob("lint-1", "lint", "head", prowv1.SuccessState),
+			},
+			request:  "lint-1",
+			expected: sets.New[string](),
+		},
+		{
+			name: "dependent job that already ran is not triggered again",
+			existing: []client.Object{
+				presubmitJob("unit-1", "unit", "head", prowv1.SuccessState),
+				presubmitJob("build-1", "build", "head", prowv1.FailureState),
+			},
+			request:  "unit-1",
+			expected: sets.New[string](),
+		},
+		{
+			name:     "failed job does not trigger dependents",
+			existing: []client.Object{presubmitJob("unit-1", "unit", "head", prowv1.FailureState)},
+			request:  "unit-1",
+			expected: sets.New[string](),
+		},
+		{
+			name:     "postsubmit dependent job is triggered",
+			existing: []client.Object{postsubmitJob("push-1", "push-image", "sha", prowv1.SuccessState)},
+			request:  "push-1",
+			expected: sets.New("deploy"),
+		},
+		{
+			name:     "missing prowjob is ignored",
+			request:  "missing",
+			expected: sets.New[string](),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pjClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.existing...).Build()
+			r := NewReconciler(pjClient, func() *config.Config { return cfg })
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "prowjobs", Name: tc.request}}
+			if _, err := r.Reconcile(context.Background(), request); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// Reconciling twice must not trigger jobs twice.
+			if _, err := r.Reconcile(context.Background(), request); err != nil {
+				t.Fatalf("unexpected error on second reconcile: %v", err)
+			}
+
+			pjs := &prowv1.ProwJobList{}
+			if err := pjClient.List(context.Background(), pjs); err != nil {
+				t.Fatalf("failed to list prowjobs: %v", err)
+			}
+			created := sets.New[string]()
+			for _, pj := range pjs.Items {
+				if _, ok := pj.Annotations[kube.RunAfterSuccessAnnotation]; !ok {
+					continue
+				}
+				if created.Has(pj.Spec.Job) {
+					t.Errorf("job %s was triggered more than once", pj.Spec.Job)
+				}
+				created.Insert(pj.Spec.Job)
+				if pj.Status.State != prowv1.TriggeredState {
+					t.Errorf("expected job %s to be triggered, got state %s", pj.Spec.Job, pj.Status.State)
+				}
+				if pj.Spec.Type == prowv1.PresubmitJob && pj.Labels[github.EventGUID] != "guid" {
+					t.Errorf("expected job %s to inherit the event GUID, got labels %v", pj.Spec.Job, pj.Labels)
+				}
+			}
+			if diff := cmp.Diff(sets.List(tc.expected), sets.List(created)); diff != "" {
+				t.Errorf("unexpected triggered jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"time"
 
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 )
 
@@ -45,6 +47,20 @@ type Options struct {
 	// sending SIGINT before the entrypoint sends
 	// SIGKILL.
 	GracePeriod time.Duration `json:"grace_period"`
+	// Steps are run one after another instead of Args,
+	// each with its own timeout. Timeout still applies
+	// to all steps together.
+	Steps []Step `json:"steps,omitempty"`
+	// SignalEscalation replaces sending SIGINT and waiting
+	// for GracePeriod when the process times out or is
+	// aborted. SIGKILL is sent after the last step.
+	SignalEscalation []EscalationStep `json:"signal_escalation,omitempty"`
+	// CaptureProcessTree writes the process tree to the
+	// ArtifactDir when the process times out.
+	CaptureProcessTree bool `json:"capture_process_tree,omitempty"`
+	// Vault configures secrets to fetch from HashiCorp Vault
+	// before the process is started.
+	Vault *VaultOptions `json:"vault,omitempty"`
 	// ArtifactDir is a directory where test processes can dump artifacts
 	// for upload to persistent storage (courtesy of sidecar).
 	// If specified, it is created by entrypoint before starting the test process.
@@ -72,12 +88,51 @@ type Options struct {
 	*wrapper.Options
 }
 
+// Step is a command run by the entrypoint.
+type Step struct {
+	// Name identifies the step in the process log.
+	Name string `json:"name"`
+	// Args are the command of the step and its arguments.
+	Args []string `json:"args"`
+	// Timeout determines how long the step may run. The
+	// remaining time of the overall timeout is used if unset.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// EscalationStep is a signal sent to a timed out or aborted
+// process, followed by waiting for it to exit.
+type EscalationStep struct {
+	// Signal is the name of the signal, e.g. SIGQUIT.
+	Signal string `json:"signal"`
+	// GracePeriod determines how long to wait before the next
+	// signal is sent. Defaults to the GracePeriod of the options.
+	GracePeriod time.Duration `json:"grace_period,omitempty"`
+}
+
 // Validate ensures that the set of options are
 // self-consistent and valid
 func (o *Options) Validate() error {
-	if len(o.Args) == 0 {
+	if len(o.Args) == 0 && len(o.Steps) == 0 {
 		return errors.New("no process to wrap specified")
 	}
+	if len(o.Args) > 0 && len(o.Steps) > 0 {
+		return errors.New("cannot wrap a process and run steps")
+	}
+	for _, step := range o.Steps {
+		if len(step.Args) == 0 {
+			return fmt.Errorf("no process specified for step %s", step.Name)
+		}
+	}
+	for _, step := range o.SignalEscalation {
+		if !prowapi.IsEscalationSignal(step.Signal) {
+			return fmt.Errorf("unsupported signal %q in signal escalation", step.Signal)
+		}
+	}
+	if o.Vault != nil {
+		if err := o.Vault.Validate(); err != nil {
+			return fmt.Errorf("invalid vault options: %w", err)
+		}
+	}
 	if o.PropagateErrorCode && o.AlwaysZero {
 		return errors.New("cannot propagate error code and always exit zero")
 	}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const procDir = "/proc"
+
+// captureProcessTree writes the tree of processes rooted at the
+// wrapped process into the artifact directory so that hanging
+// children can be debugged after a timeout.
+func (o Options) captureProcessTree(pid int) {
+	if o.ArtifactDir == "" {
+		logrus.Warn("Not capturing process tree as no artifact directory is configured")
+		return
+	}
+	tree, err := processTree(procDir, pid)
+	if err != nil {
+		logrus.WithError(err).Error("Could not capture process tree")
+		return
+	}
+	name := "process-tree.txt"
+	if o.ContainerName != "" {
+		name = o.ContainerName + "-" + name
+	}
+	if err := os.WriteFile(filepath.Join(o.ArtifactDir, name), []byte(tree), 0644); err != nil {
+		logrus.WithError(err).Error("Could not write process tree")
+	}
+}
+
+type process struct {
+	pid     int
+	ppid    int
+	command string
+}
+
+// processTree renders the process with the given pid and all of its
+// descendants, as found in the proc filesystem mounted at dir.
+func processTree(dir string, root int) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("could not list processes: %w", err)
+	}
+	processes := map[int]process{}
+	children := map[int][]int{}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		proc, err := readProcess(filepath.Join(dir, entry.Name()), pid)
+		if err != nil {
+			// processes may exit while we are walking the tree
+			continue
+		}
+		processes[pid] = proc
+		children[proc.ppid] = append(children[proc.ppid], pid)
+	}
+	if _, ok := processes[root]; !ok {
+		return "", fmt.Errorf("process %d not found", root)
+	}
+
+	var out strings.Builder
+	var walk func(pid, depth int)
+	walk = func(pid, depth int) {
+		fmt.Fprintf(&out, "%s%d %s\n", strings.Repeat("  ", depth), pid, processes[pid].command)
+		sort.Ints(children[pid])
+		for _, child := range children[pid] {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+	return out.String(), nil
+}
+
+// readProcess reads the parent and command line of a process from its
+// proc directory.
+func readProcess(dir string, pid int) (process, error) {
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return process{}, err
+	}
+	// the command name in the second field may contain spaces and
+	// parentheses, so the remaining fields start after the last ')'
+	closing := bytes.LastIndexByte(stat, ')')
+	if closing < 0 {
+		return process{}, fmt.Errorf("malformed stat for process %d", pid)
+	}
+	fields := strings.Fields(string(stat[closing+1:]))
+	if len(fields) < 2 {
+		return process{}, fmt.Errorf("malformed stat for process %d", pid)
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return process{}, fmt.Errorf("malformed parent for process %d: %w", pid, err)
+	}
+	command := string(stat[bytes.IndexByte(stat, '(')+1 : closing])
+	if cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil && len(cmdline) > 0 {
+		command = strings.Join(strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00"), " ")
+	}
+	return process{pid: pid, ppid: ppid, command: command}, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcessTree(t *testing.T) {
+	dir := t.TempDir()
+	for pid, proc := range map[string]struct{ stat, cmdline string }{
+		"1":   {stat: "1 (init) S 0 1 1", cmdline: "/sbin/init\x00"},
+		"10":  {stat: "10 (sh) S 1 10 10", cmdline: "sh\x00-c\x00make test\x00"},
+		"12":  {stat: "12 (make) S 10 10 10", cmdline: "make\x00test\x00"},
+		"11":  {stat: "11 (go test) S 10 10 10", cmdline: ""},
+		"13":  {stat: "13 (weird) name)) S 12 10 10", cmdline: ""},
+		"20":  {stat: "20 (other) S 1 20 20", cmdline: "other\x00"},
+		"bad": {stat: "not a process"},
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, pid), 0755); err != nil {
+			t.Fatalf("failed to create process dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, pid, "stat"), []byte(proc.stat), 0644); err != nil {
+			t.Fatalf("failed to write stat: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, pid, "cmdline"), []byte(proc.cmdline), 0644); err != nil {
+			t.Fatalf("failed to write cmdline: %v", err)
+		}
+	}
+
+	tree, err := processTree(dir, 10)
+	if err != nil {
+		t.Fatalf("failed to get process tree: %v", err)
+	}
+	expected := `10 sh -c make test
+  11 go test
+  12 make test
+    13 weird) name)
+`
+	if diff := cmp.Diff(expected, tree); diff != "" {
+		t.Errorf("process tree differs from expected (-want +got):\n%s", diff)
+	}
+
+	if _, err := processTree(dir, 42); err == nil {
+		t.Error("expected an error for a missing process")
+	}
+}
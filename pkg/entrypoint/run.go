@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -30,6 +31,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
@@ -63,9 +65,11 @@ const (
 )
 
 var (
-	// errTimedOut is used as the command's error when the command
-	// is terminated after the timeout is reached
-	errTimedOut = errors.New("process timed out")
+	// ErrTimedOut is used as the command's error when the command
+	// is terminated after the timeout is reached. It is logged, so
+	// plank finds it in the termination message of a timed out
+	// test container.
+	ErrTimedOut = errors.New("process timed out")
 	// errAborted is used as the command's error when the command
 	// is shut down by an external signal
 	errAborted = errors.New("process aborted")
@@ -138,14 +142,55 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 		}
 	}
 
-	executable := o.Args[0]
+	var env []string
+	if o.Vault != nil {
+		if env, err = o.Vault.fetch(context.Background(), http.DefaultClient); err != nil {
+			logrus.WithError(err).Error("Could not fetch secrets from vault")
+			return InternalErrorCode, fmt.Errorf("fetch secrets from vault: %w", err)
+		}
+	}
+
+	steps := o.Steps
+	if len(steps) == 0 {
+		steps = []Step{{Args: o.Args}}
+	}
+	timeout := optionOrDefault(o.Timeout, DefaultTimeout)
+	remaining := timeout
+	for _, step := range steps {
+		if remaining <= 0 {
+			logrus.Errorf("Process did not finish before %s timeout", timeout)
+			return InternalErrorCode, ErrTimedOut
+		}
+		if step.Name != "" {
+			logrus.Infof("Running step %s", step.Name)
+		}
+		stepTimeout := remaining
+		if step.Timeout > 0 && step.Timeout < remaining {
+			stepTimeout = step.Timeout
+		}
+		start := time.Now()
+		if code, err := o.executeCommand(step.Args, env, stepTimeout, output, processLogFile, interrupt); code != 0 || err != nil {
+			return code, err
+		}
+		remaining -= time.Since(start)
+	}
+	return 0, nil
+}
+
+// executeCommand runs a single command until it exits, times out or is
+// interrupted, and returns the code to exit with.
+func (o Options) executeCommand(args, env []string, timeout time.Duration, output io.Writer, processLogFile *os.File, interrupt chan os.Signal) (int, error) {
+	executable := args[0]
 	var arguments []string
-	if len(o.Args) > 1 {
-		arguments = o.Args[1:]
+	if len(args) > 1 {
+		arguments = args[1:]
 	}
 	command := exec.Command(executable, arguments...)
 	command.Stderr = output
 	command.Stdout = output
+	if len(env) > 0 {
+		command.Env = append(os.Environ(), env...)
+	}
 	if err := command.Start(); err != nil {
 		errs := []error{fmt.Errorf("could not start the process: %w", err)}
 		if _, err := processLogFile.Write([]byte(errs[0].Error())); err != nil {
@@ -154,8 +199,7 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 		return InternalErrorCode, utilerrors.NewAggregate(errs)
 	}
 
-	timeout := optionOrDefault(o.Timeout, DefaultTimeout)
-	gracePeriod := optionOrDefault(o.GracePeriod, DefaultGracePeriod)
+	escalation := o.escalation()
 	var commandErr error
 	cancelled, aborted := false, false
 	done := make(chan error)
@@ -168,12 +212,15 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 	case <-time.After(timeout):
 		logrus.Errorf("Process did not finish before %s timeout", timeout)
 		cancelled = true
-		gracefullyTerminate(command, done, gracePeriod, nil)
+		if o.CaptureProcessTree {
+			o.captureProcessTree(command.Process.Pid)
+		}
+		gracefullyTerminate(command, done, escalation, nil)
 	case s := <-interrupt:
 		logrus.Errorf("Entrypoint received interrupt: %v", s)
 		cancelled = true
 		aborted = true
-		gracefullyTerminate(command, done, gracePeriod, &s)
+		gracefullyTerminate(command, done, escalation, &s)
 	}
 
 	var returnCode int
@@ -186,7 +233,7 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 				returnCode = AbortedErrorCode
 			}
 		} else {
-			commandErr = errTimedOut
+			commandErr = ErrTimedOut
 			if o.PropagateErrorCode {
 				returnCode = command.ProcessState.ExitCode()
 			} else {
@@ -250,23 +297,44 @@ func optionOrDefault(option, defaultValue time.Duration) time.Duration {
 	return option
 }
 
-func gracefullyTerminate(command *exec.Cmd, done <-chan error, gracePeriod time.Duration, signal *os.Signal) {
-	if err := command.Process.Signal(os.Interrupt); err != nil {
-		logrus.WithError(err).Error("Could not interrupt process after timeout")
+// escalation returns the signals to send to a timed out or aborted process,
+// defaulting to SIGINT followed by the grace period.
+func (o Options) escalation() []EscalationStep {
+	gracePeriod := optionOrDefault(o.GracePeriod, DefaultGracePeriod)
+	if len(o.SignalEscalation) == 0 {
+		return []EscalationStep{{Signal: "SIGINT", GracePeriod: gracePeriod}}
 	}
-	if signal != nil {
-		if err := command.Process.Signal(*signal); err != nil {
-			logrus.WithError(err).Errorf("Could not send signal %v to process after timeout", signal)
-		}
+	var escalation []EscalationStep
+	for _, step := range o.SignalEscalation {
+		step.GracePeriod = optionOrDefault(step.GracePeriod, gracePeriod)
+		escalation = append(escalation, step)
 	}
-	select {
-	case <-done:
-		logrus.Errorf("Process gracefully exited before %s grace period", gracePeriod)
-		// but we ignore the output error as we will want errTimedOut
-	case <-time.After(gracePeriod):
-		logrus.Errorf("Process did not exit before %s grace period", gracePeriod)
-		if err := command.Process.Kill(); err != nil {
-			logrus.WithError(err).Error("Could not kill process after grace period")
+	return escalation
+}
+
+func gracefullyTerminate(command *exec.Cmd, done <-chan error, escalation []EscalationStep, signal *os.Signal) {
+	for i, step := range escalation {
+		if i > 0 {
+			logrus.Errorf("Sending %s to process", step.Signal)
+		}
+		if err := command.Process.Signal(unix.SignalNum(step.Signal)); err != nil {
+			logrus.WithError(err).Errorf("Could not send %s to process", step.Signal)
+		}
+		if i == 0 && signal != nil {
+			if err := command.Process.Signal(*signal); err != nil {
+				logrus.WithError(err).Errorf("Could not send signal %v to process after timeout", signal)
+			}
+		}
+		select {
+		case <-done:
+			logrus.Errorf("Process gracefully exited before %s grace period", step.GracePeriod)
+			// but we ignore the output error as we will want ErrTimedOut
+			return
+		case <-time.After(step.GracePeriod):
+			logrus.Errorf("Process did not exit before %s grace period", step.GracePeriod)
 		}
 	}
+	if err := command.Process.Kill(); err != nil {
+		logrus.WithError(err).Error("Could not kill process after grace period")
+	}
 }
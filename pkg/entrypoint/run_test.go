@@ -34,6 +34,8 @@ func TestOptions_Run(t *testing.T) {
 	var testCases = []struct {
 		name           string
 		args           []string
+		steps          []Step
+		escalation     []EscalationStep
 		alwaysZero     bool
 		interrupt      bool
 		propagate      bool
@@ -186,6 +188,51 @@ wait`},
 			expectedMarker: "127",
 			expectedCode:   InternalErrorCode,
 		},
+		{
+			name: "steps run in order",
+			steps: []Step{
+				{Name: "first", Args: []string{"echo", "one"}},
+				{Name: "second", Args: []string{"echo", "two"}},
+			},
+			expectedLog:    "level=info msg=\"Running step first\"\none\nlevel=info msg=\"Running step second\"\ntwo\n",
+			expectedMarker: "0",
+			expectedCode:   0,
+		},
+		{
+			name: "failing step skips remaining steps",
+			steps: []Step{
+				{Name: "first", Args: []string{"sh", "-c", "exit 3"}},
+				{Name: "second", Args: []string{"echo", "two"}},
+			},
+			expectedLog:    "level=info msg=\"Running step first\"\n",
+			expectedMarker: "3",
+			expectedCode:   3,
+		},
+		{
+			name: "step times out before overall timeout",
+			steps: []Step{
+				{Name: "slow", Args: []string{"sleep", "10"}, Timeout: 1 * time.Second},
+				{Name: "second", Args: []string{"echo", "two"}},
+			},
+			timeout:        time.Minute,
+			gracePeriod:    1 * time.Second,
+			expectedLog:    "level=info msg=\"Running step slow\"\nlevel=error msg=\"Process did not finish before 1s timeout\"\nlevel=error msg=\"Process gracefully exited before 1s grace period\"\n",
+			expectedMarker: strconv.Itoa(InternalErrorCode),
+			expectedCode:   InternalErrorCode,
+		},
+		{
+			name:        "signals are escalated until the process exits",
+			args:        []string{"sh", "-c", "trap '' INT; while :; do :; done"},
+			timeout:     1 * time.Second,
+			gracePeriod: 1 * time.Second,
+			escalation: []EscalationStep{
+				{Signal: "SIGINT"},
+				{Signal: "SIGTERM"},
+			},
+			expectedLog:    "level=error msg=\"Process did not finish before 1s timeout\"\nlevel=error msg=\"Process did not exit before 1s grace period\"\nlevel=error msg=\"Sending SIGTERM to process\"\nlevel=error msg=\"Process gracefully exited before 1s grace period\"\n",
+			expectedMarker: strconv.Itoa(InternalErrorCode),
+			expectedCode:   InternalErrorCode,
+		},
 	}
 
 	// we write logs to the process log if wrapping fails
@@ -202,6 +249,8 @@ wait`},
 				PropagateErrorCode: testCase.propagate,
 				Timeout:            testCase.timeout,
 				GracePeriod:        testCase.gracePeriod,
+				Steps:              testCase.steps,
+				SignalEscalation:   testCase.escalation,
 				Options: &wrapper.Options{
 					Args:       testCase.args,
 					ProcessLog: path.Join(tmpDir, "process-log.txt"),
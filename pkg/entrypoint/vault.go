@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// VaultAuthKubernetes logs in with the service account token of the pod.
+	VaultAuthKubernetes = "kubernetes"
+	// VaultAuthAppRole logs in with a role and secret ID.
+	VaultAuthAppRole = "approle"
+
+	vaultTimeout = time.Minute
+)
+
+// VaultOptions configures fetching secrets from HashiCorp Vault.
+type VaultOptions struct {
+	// Address is the address of the Vault server.
+	Address string `json:"address"`
+	// AuthMethod is either kubernetes or approle.
+	AuthMethod string `json:"auth_method"`
+	// AuthMount is the path the auth method is enabled at.
+	// Defaults to the name of the auth method.
+	AuthMount string `json:"auth_mount,omitempty"`
+	// Role is the role to log in as with the kubernetes auth method.
+	Role string `json:"role,omitempty"`
+	// TokenFile holds the service account token to log in
+	// with the kubernetes auth method.
+	TokenFile string `json:"token_file,omitempty"`
+	// AppRoleDir holds the role_id and secret_id files to
+	// log in with the approle auth method.
+	AppRoleDir string `json:"approle_dir,omitempty"`
+	// SecretDir is the directory the secrets are written to.
+	SecretDir string `json:"secret_dir"`
+	// Secrets are the values to fetch.
+	Secrets []VaultSecret `json:"secrets"`
+}
+
+// VaultSecret is a value of a secret in Vault.
+type VaultSecret struct {
+	// Path is the path of the secret in Vault.
+	Path string `json:"path"`
+	// Key is the key of the value in the data of the secret.
+	Key string `json:"key"`
+	// File is the name of the file in SecretDir to write the value to.
+	File string `json:"file"`
+	// Env is the name of an environment variable to expose the value in.
+	Env string `json:"env,omitempty"`
+}
+
+// Validate ensures that the options are complete.
+func (o *VaultOptions) Validate() error {
+	if o.Address == "" {
+		return errors.New("no address specified")
+	}
+	switch o.AuthMethod {
+	case VaultAuthKubernetes:
+		if o.Role == "" || o.TokenFile == "" {
+			return errors.New("the kubernetes auth method requires a role and a token file")
+		}
+	case VaultAuthAppRole:
+		if o.AppRoleDir == "" {
+			return errors.New("the approle auth method requires an approle directory")
+		}
+	default:
+		return fmt.Errorf("unsupported auth method %q", o.AuthMethod)
+	}
+	if o.SecretDir == "" {
+		return errors.New("no secret directory specified")
+	}
+	for _, secret := range o.Secrets {
+		if secret.Path == "" || secret.Key == "" || secret.File == "" {
+			return fmt.Errorf("secret %s requires a path, a key and a file", secret.Path)
+		}
+	}
+	return nil
+}
+
+// fetch logs in to Vault, writes the secrets to the secret directory
+// and returns the environment variables to expose them in. The token
+// is revoked once all secrets are fetched.
+func (o *VaultOptions) fetch(ctx context.Context, client *http.Client) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, vaultTimeout)
+	defer cancel()
+
+	token, err := o.login(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("log in to vault: %w", err)
+	}
+	defer func() {
+		if err := o.request(ctx, client, http.MethodPost, "auth/token/revoke-self", token, nil, nil); err != nil {
+			logrus.WithError(err).Warn("Could not revoke vault token")
+		}
+	}()
+
+	if err := os.MkdirAll(o.SecretDir, 0700); err != nil {
+		return nil, fmt.Errorf("create secret directory: %w", err)
+	}
+	data := map[string]map[string]interface{}{}
+	var env []string
+	for _, secret := range o.Secrets {
+		if _, ok := data[secret.Path]; !ok {
+			if data[secret.Path], err = o.read(ctx, client, token, secret.Path); err != nil {
+				return nil, fmt.Errorf("read secret %s: %w", secret.Path, err)
+			}
+		}
+		raw, ok := data[secret.Path][secret.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no key %s", secret.Path, secret.Key)
+		}
+		value, ok := raw.(string)
+		if !ok {
+			encoded, err := json.Marshal(raw)
+			if err != nil {
+				return nil, fmt.Errorf("encode key %s of secret %s: %w", secret.Key, secret.Path, err)
+			}
+			value = string(encoded)
+		}
+		if err := os.WriteFile(filepath.Join(o.SecretDir, secret.File), []byte(value), 0400); err != nil {
+			return nil, fmt.Errorf("write secret %s: %w", secret.File, err)
+		}
+		if secret.Env != "" {
+			env = append(env, secret.Env+"="+value)
+		}
+	}
+	return env, nil
+}
+
+func (o *VaultOptions) login(ctx context.Context, client *http.Client) (string, error) {
+	mount := o.AuthMount
+	if mount == "" {
+		mount = o.AuthMethod
+	}
+	body := map[string]string{}
+	switch o.AuthMethod {
+	case VaultAuthKubernetes:
+		jwt, err := os.ReadFile(o.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("read service account token: %w", err)
+		}
+		body["role"] = o.Role
+		body["jwt"] = strings.TrimSpace(string(jwt))
+	case VaultAuthAppRole:
+		for _, key := range []string{"role_id", "secret_id"} {
+			value, err := os.ReadFile(filepath.Join(o.AppRoleDir, key))
+			if err != nil {
+				return "", fmt.Errorf("read %s: %w", key, err)
+			}
+			body[key] = strings.TrimSpace(string(value))
+		}
+	}
+	var response struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := o.request(ctx, client, http.MethodPost, "auth/"+strings.Trim(mount, "/")+"/login", "", body, &response); err != nil {
+		return "", err
+	}
+	if response.Auth.ClientToken == "" {
+		return "", errors.New("no token in login response")
+	}
+	return response.Auth.ClientToken, nil
+}
+
+// read returns the data of a secret, unwrapping the data of
+// secrets in a KV version 2 secrets engine.
+func (o *VaultOptions) read(ctx context.Context, client *http.Client, token, path string) (map[string]interface{}, error) {
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := o.request(ctx, client, http.MethodGet, strings.Trim(path, "/"), token, nil, &response); err != nil {
+		return nil, err
+	}
+	if nested, ok := response.Data["data"].(map[string]interface{}); ok {
+		if _, ok := response.Data["metadata"]; ok {
+			return nested, nil
+		}
+	}
+	return response.Data, nil
+}
+
+func (o *VaultOptions) request(ctx context.Context, client *http.Client, method, path, token string, body, into interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(o.Address, "/")+"/v1/"+path, reader)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// vault error responses never contain secrets
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if into == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
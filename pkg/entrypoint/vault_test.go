@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVaultFetch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authMethod    string
+		secrets       []VaultSecret
+		expectedEnv   []string
+		expectedFiles map[string]string
+		expectedErr   bool
+	}{
+		{
+			name:       "kubernetes auth with kv version 2 and version 1 secrets",
+			authMethod: VaultAuthKubernetes,
+			secrets: []VaultSecret{
+				{Path: "secret/data/ci/github", Key: "token", File: "github-token", Env: "GITHUB_TOKEN"},
+				{Path: "secret/data/ci/github", Key: "user", File: "github-user"},
+				{Path: "kv/ci/registry", Key: "port", File: "registry-port", Env: "REGISTRY_PORT"},
+			},
+			expectedEnv: []string{"GITHUB_TOKEN=abc", "REGISTRY_PORT=5000"},
+			expectedFiles: map[string]string{
+				"github-token":  "abc",
+				"github-user":   "bot",
+				"registry-port": "5000",
+			},
+		},
+		{
+			name:          "approle auth",
+			authMethod:    VaultAuthAppRole,
+			secrets:       []VaultSecret{{Path: "secret/data/ci/github", Key: "token", File: "token"}},
+			expectedFiles: map[string]string{"token": "abc"},
+		},
+		{
+			name:        "missing key",
+			authMethod:  VaultAuthKubernetes,
+			secrets:     []VaultSecret{{Path: "secret/data/ci/github", Key: "password", File: "password"}},
+			expectedErr: true,
+		},
+		{
+			name:        "missing secret",
+			authMethod:  VaultAuthKubernetes,
+			secrets:     []VaultSecret{{Path: "secret/data/ci/other", Key: "token", File: "token"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var revoked bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				if strings.HasSuffix(r.URL.Path, "/login") {
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("failed to decode request: %v", err)
+					}
+				}
+				switch r.URL.Path {
+				case "/v1/auth/kubernetes/login":
+					if diff := cmp.Diff(map[string]string{"role": "ci", "jwt": "sa-token"}, body); diff != "" {
+						t.Errorf("unexpected login (-want +got):\n%s", diff)
+					}
+					w.Write([]byte(`{"auth":{"client_token":"vault-token"}}`))
+					return
+				case "/v1/auth/approle/login":
+					if diff := cmp.Diff(map[string]string{"role_id": "role", "secret_id": "secret"}, body); diff != "" {
+						t.Errorf("unexpected login (-want +got):\n%s", diff)
+					}
+					w.Write([]byte(`{"auth":{"client_token":"vault-token"}}`))
+					return
+				}
+				if r.Header.Get("X-Vault-Token") != "vault-token" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				switch r.URL.Path {
+				case "/v1/auth/token/revoke-self":
+					revoked = true
+				case "/v1/secret/data/ci/github":
+					w.Write([]byte(`{"data":{"data":{"token":"abc","user":"bot"},"metadata":{"version":3}}}`))
+				case "/v1/kv/ci/registry":
+					w.Write([]byte(`{"data":{"port":5000}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"errors":[]}`))
+				}
+			}))
+			defer server.Close()
+
+			dir := t.TempDir()
+			tokenFile := filepath.Join(dir, "token")
+			if err := os.WriteFile(tokenFile, []byte("sa-token\n"), 0600); err != nil {
+				t.Fatalf("failed to write token: %v", err)
+			}
+			appRoleDir := filepath.Join(dir, "approle")
+			if err := os.Mkdir(appRoleDir, 0700); err != nil {
+				t.Fatalf("failed to create approle dir: %v", err)
+			}
+			for name, value := range map[string]string{"role_id": "role", "secret_id": "secret"} {
+				if err := os.WriteFile(filepath.Join(appRoleDir, name), []byte(value), 0600); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			options := &VaultOptions{
+				Address:    server.URL,
+				AuthMethod: tc.authMethod,
+				Role:       "ci",
+				TokenFile:  tokenFile,
+				AppRoleDir: appRoleDir,
+				SecretDir:  filepath.Join(dir, "secrets"),
+				Secrets:    tc.secrets,
+			}
+			env, err := options.fetch(context.Background(), server.Client())
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if !revoked {
+				t.Error("expected the vault token to be revoked")
+			}
+			if tc.expectedErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expectedEnv, env); diff != "" {
+				t.Errorf("environment differs from expected (-want +got):\n%s", diff)
+			}
+			files := map[string]string{}
+			for name := range tc.expectedFiles {
+				content, err := os.ReadFile(filepath.Join(options.SecretDir, name))
+				if err != nil {
+					t.Fatalf("failed to read secret file: %v", err)
+				}
+				files[name] = string(content)
+			}
+			if diff := cmp.Diff(tc.expectedFiles, files); diff != "" {
+				t.Errorf("secret files differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externalplugin is an SDK for external plugins. It validates and
+// decodes the events hook sends, dispatches them to the handlers of a plugin,
+// serves the plugin help and shuts down gracefully. Besides webhooks, hook
+// can dispatch typed events to plugins over gRPC.
+package externalplugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp/externalplugins"
+)
+
+// Event is a decoded event. Events of the types with handlers in Plugin set
+// the field of their type, other events keep their raw payload.
+type Event struct {
+	Type string `json:"type"`
+	GUID string `json:"guid"`
+	// Repo and Org are those of the event. Events dispatched over gRPC are
+	// signed with the HMAC token of the repo or org.
+	Repo github.Repo         `json:"repository"`
+	Org  github.Organization `json:"organization"`
+
+	Issue         *github.IssueEvent         `json:"issue_event,omitempty"`
+	IssueComment  *github.IssueCommentEvent  `json:"issue_comment_event,omitempty"`
+	PullRequest   *github.PullRequestEvent   `json:"pull_request_event,omitempty"`
+	Review        *github.ReviewEvent        `json:"review_event,omitempty"`
+	ReviewComment *github.ReviewCommentEvent `json:"review_comment_event,omitempty"`
+	Push          *github.PushEvent          `json:"push_event,omitempty"`
+	Status        *github.StatusEvent        `json:"status_event,omitempty"`
+
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// DecodeEvent decodes the payload of a webhook.
+func DecodeEvent(eventType, eventGUID string, payload []byte) (*Event, error) {
+	var ge github.GenericEvent
+	if err := json.Unmarshal(payload, &ge); err != nil {
+		return nil, err
+	}
+	e := &Event{Type: eventType, GUID: eventGUID, Repo: ge.Repo, Org: ge.Org}
+	var err error
+	switch eventType {
+	case "issues":
+		e.Issue = &github.IssueEvent{}
+		err = json.Unmarshal(payload, e.Issue)
+		e.Issue.GUID = eventGUID
+	case "issue_comment":
+		e.IssueComment = &github.IssueCommentEvent{}
+		err = json.Unmarshal(payload, e.IssueComment)
+		e.IssueComment.GUID = eventGUID
+	case "pull_request":
+		e.PullRequest = &github.PullRequestEvent{}
+		err = json.Unmarshal(payload, e.PullRequest)
+		e.PullRequest.GUID = eventGUID
+	case "pull_request_review":
+		e.Review = &github.ReviewEvent{}
+		err = json.Unmarshal(payload, e.Review)
+		e.Review.GUID = eventGUID
+	case "pull_request_review_comment":
+		e.ReviewComment = &github.ReviewCommentEvent{}
+		err = json.Unmarshal(payload, e.ReviewComment)
+		e.ReviewComment.GUID = eventGUID
+	case "push":
+		e.Push = &github.PushEvent{}
+		err = json.Unmarshal(payload, e.Push)
+		e.Push.GUID = eventGUID
+	case "status":
+		e.Status = &github.StatusEvent{}
+		err = json.Unmarshal(payload, e.Status)
+		e.Status.GUID = eventGUID
+	default:
+		e.Payload = payload
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// orgRepo returns the org/repo, or only the org of org-level events, the
+// event is signed for.
+func (e *Event) orgRepo() string {
+	if e.Repo.FullName != "" {
+		return e.Repo.FullName
+	}
+	return e.Org.Login
+}
+
+// Plugin is an external plugin. Handlers may be nil for events the plugin
+// does not handle.
+type Plugin struct {
+	// Name of the plugin, used in logs.
+	Name         string
+	HelpProvider externalplugins.ExternalPluginHelpProvider
+
+	IssueHandler         func(*logrus.Entry, github.IssueEvent) error
+	IssueCommentHandler  func(*logrus.Entry, github.IssueCommentEvent) error
+	PullRequestHandler   func(*logrus.Entry, github.PullRequestEvent) error
+	ReviewHandler        func(*logrus.Entry, github.ReviewEvent) error
+	ReviewCommentHandler func(*logrus.Entry, github.ReviewCommentEvent) error
+	PushHandler          func(*logrus.Entry, github.PushEvent) error
+	StatusHandler        func(*logrus.Entry, github.StatusEvent) error
+	// GenericHandler handles the events of other types.
+	GenericHandler func(*logrus.Entry, Event) error
+}
+
+// Handle dispatches the event to the handler of its type. Panics of the
+// handler are returned as errors.
+func (p *Plugin) Handle(l *logrus.Entry, e *Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic caught: %v. stack is: %s", r, debug.Stack())
+		}
+	}()
+	switch {
+	case e.Issue != nil:
+		if p.IssueHandler != nil {
+			return p.IssueHandler(l, *e.Issue)
+		}
+	case e.IssueComment != nil:
+		if p.IssueCommentHandler != nil {
+			return p.IssueCommentHandler(l, *e.IssueComment)
+		}
+	case e.PullRequest != nil:
+		if p.PullRequestHandler != nil {
+			return p.PullRequestHandler(l, *e.PullRequest)
+		}
+	case e.Review != nil:
+		if p.ReviewHandler != nil {
+			return p.ReviewHandler(l, *e.Review)
+		}
+	case e.ReviewComment != nil:
+		if p.ReviewCommentHandler != nil {
+			return p.ReviewCommentHandler(l, *e.ReviewComment)
+		}
+	case e.Push != nil:
+		if p.PushHandler != nil {
+			return p.PushHandler(l, *e.Push)
+		}
+	case e.Status != nil:
+		if p.StatusHandler != nil {
+			return p.StatusHandler(l, *e.Status)
+		}
+	default:
+		if p.GenericHandler != nil {
+			return p.GenericHandler(l, *e)
+		}
+	}
+	l.Debug("Ignoring event without a handler.")
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalplugin
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	testCases := []struct {
+		name      string
+		eventType string
+		payload   string
+		expected  *Event
+	}{
+		{
+			name:      "issue comment",
+			eventType: "issue_comment",
+			payload:   `{"action": "created", "repository": {"full_name": "org/repo"}, "comment": {"body": "/hold"}}`,
+			expected: &Event{
+				Type: "issue_comment",
+				GUID: "guid",
+				Repo: github.Repo{FullName: "org/repo"},
+				IssueComment: &github.IssueCommentEvent{
+					Action:  github.IssueCommentActionCreated,
+					Repo:    github.Repo{FullName: "org/repo"},
+					Comment: github.IssueComment{Body: "/hold"},
+					GUID:    "guid",
+				},
+			},
+		},
+		{
+			name:      "push",
+			eventType: "push",
+			payload:   `{"ref": "refs/heads/main", "repository": {"full_name": "org/repo"}}`,
+			expected: &Event{
+				Type: "push",
+				GUID: "guid",
+				Repo: github.Repo{FullName: "org/repo"},
+				Push: &github.PushEvent{
+					Ref:  "refs/heads/main",
+					Repo: github.Repo{FullName: "org/repo"},
+					GUID: "guid",
+				},
+			},
+		},
+		{
+			name:      "other events keep their payload",
+			eventType: "membership",
+			payload:   `{"organization": {"login": "org"}}`,
+			expected: &Event{
+				Type:    "membership",
+				GUID:    "guid",
+				Org:     github.Organization{Login: "org"},
+				Payload: json.RawMessage(`{"organization": {"login": "org"}}`),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := DecodeEvent(tc.eventType, "guid", []byte(tc.payload))
+			if err != nil {
+				t.Fatalf("failed to decode event: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, e); diff != "" {
+				t.Errorf("event differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	var handled []string
+	p := &Plugin{
+		IssueCommentHandler: func(_ *logrus.Entry, ic github.IssueCommentEvent) error {
+			handled = append(handled, ic.Comment.Body)
+			return nil
+		},
+		PushHandler: func(*logrus.Entry, github.PushEvent) error {
+			return errors.New("push failed")
+		},
+		StatusHandler: func(*logrus.Entry, github.StatusEvent) error {
+			panic("boom")
+		},
+	}
+	l := logrus.WithField("plugin", "test")
+
+	if err := p.Handle(l, &Event{IssueComment: &github.IssueCommentEvent{Comment: github.IssueComment{Body: "/hold"}}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"/hold"}, handled); diff != "" {
+		t.Errorf("handled comments differ from expected (-want +got):\n%s", diff)
+	}
+	if err := p.Handle(l, &Event{Review: &github.ReviewEvent{}}); err != nil {
+		t.Errorf("expected events without a handler to be ignored, got %v", err)
+	}
+	if err := p.Handle(l, &Event{Push: &github.PushEvent{}}); err == nil || err.Error() != "push failed" {
+		t.Errorf("expected the error of the handler, got %v", err)
+	}
+	if err := p.Handle(l, &Event{Status: &github.StatusEvent{}}); err == nil || !strings.Contains(err.Error(), "panic caught: boom") {
+		t.Errorf("expected the panic of the handler as error, got %v", err)
+	}
+}
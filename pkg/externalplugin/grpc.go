@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+const (
+	serviceName    = "prow.externalplugin.ExternalPlugin"
+	dispatchMethod = "/" + serviceName + "/Dispatch"
+	// signatureKey is the metadata key of the HMAC signature of events.
+	signatureKey = "x-hub-signature"
+)
+
+// The Dispatch method takes a google.protobuf.BytesValue holding the JSON
+// encoded event, as the GitHub events have no protobuf definitions, and
+// returns a google.protobuf.Empty. The event is passed on as it is, so that
+// its signature can be verified before it is decoded.
+
+// dispatcher is the gRPC service of external plugins.
+type dispatcher interface {
+	dispatch(ctx context.Context, event []byte) error
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*dispatcher)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Dispatch",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := &wrapperspb.BytesValue{}
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &emptypb.Empty{}, srv.(dispatcher).dispatch(ctx, req.(*wrapperspb.BytesValue).GetValue())
+			}
+			if interceptor == nil {
+				return handler(ctx, req)
+			}
+			return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: dispatchMethod}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{},
+}
+
+// NewGRPCServer returns a gRPC server that dispatches the events of hook to
+// the server.
+func NewGRPCServer(s *Server, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	server.RegisterService(&serviceDesc, s)
+	return server
+}
+
+// dispatch verifies the signature of an event and handles it. Unlike
+// webhooks, the event is handled before the response, so that hook learns
+// about errors.
+func (s *Server) dispatch(ctx context.Context, event []byte) error {
+	var sig string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(signatureKey); len(values) > 0 {
+			sig = values[0]
+		}
+	}
+	if !github.ValidatePayload(event, sig, s.tokenGenerator) {
+		return status.Error(codes.Unauthenticated, "invalid signature")
+	}
+	var e Event
+	if err := json.Unmarshal(event, &e); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to decode event: %v", err)
+	}
+	s.wg.Add(1)
+	defer s.wg.Done()
+	if err := s.handle(&e); err != nil {
+		return status.Error(codes.Unknown, err.Error())
+	}
+	return nil
+}
+
+// Client dispatches events to an external plugin over gRPC.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// NewClient returns a client of the gRPC server at the address. The
+// connection is established on the first dispatch.
+func NewClient(address string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	opts = append(opts, grpc.WithStatsHandler(sentHandler{}))
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client of %s: %w", address, err)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Dispatch sends the event, signed with the HMAC token of its repo or org,
+// and returns once the plugin handled it. If sent is not nil, it is called
+// as soon as the event was sent.
+func (c *Client) Dispatch(ctx context.Context, e *Event, tokenGenerator func() []byte, sent func()) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	sig, err := github.SignPayload(b, e.orgRepo(), tokenGenerator)
+	if err != nil {
+		return fmt.Errorf("failed to sign event: %w", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, signatureKey, sig)
+	if sent != nil {
+		ctx = context.WithValue(ctx, sentKey{}, sent)
+	}
+	return c.conn.Invoke(ctx, dispatchMethod, wrapperspb.Bytes(b), &emptypb.Empty{})
+}
+
+type sentKey struct{}
+
+// sentHandler calls the sent callback of a dispatch once the event was
+// written to the connection.
+type sentHandler struct{}
+
+func (sentHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (sentHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if _, ok := s.(*stats.OutPayload); !ok {
+		return
+	}
+	if sent, ok := ctx.Value(sentKey{}).(func()); ok {
+		sent()
+	}
+}
+
+func (sentHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (sentHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
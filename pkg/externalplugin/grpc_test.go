@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestGRPCDispatch(t *testing.T) {
+	var lock sync.Mutex
+	var handled []int
+	p := &Plugin{
+		PullRequestHandler: func(_ *logrus.Entry, pr github.PullRequestEvent) error {
+			lock.Lock()
+			defer lock.Unlock()
+			handled = append(handled, pr.Number)
+			if pr.Number == 2 {
+				return errors.New("cannot handle #2")
+			}
+			return nil
+		},
+	}
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewGRPCServer(NewServer(p, func() []byte { return []byte("secret") }))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	client, err := NewClient("passthrough:///bufconn", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	event := func(number int) *Event {
+		e, err := DecodeEvent("pull_request", "guid", []byte(fmt.Sprintf(`{"number": %d, "repository": {"full_name": "org/repo"}}`, number)))
+		if err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		return e
+	}
+
+	testCases := []struct {
+		name     string
+		event    *Event
+		token    string
+		expected codes.Code
+	}{
+		{
+			name:     "event is handled",
+			event:    event(1),
+			token:    "secret",
+			expected: codes.OK,
+		},
+		{
+			name:     "errors of handlers are returned",
+			event:    event(2),
+			token:    "secret",
+			expected: codes.Unknown,
+		},
+		{
+			name:     "events with invalid signatures are rejected",
+			event:    event(3),
+			token:    "wrong",
+			expected: codes.Unauthenticated,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent int
+			err := client.Dispatch(context.Background(), tc.event, func() []byte { return []byte(tc.token) }, func() { sent++ })
+			if code := status.Code(err); code != tc.expected {
+				t.Errorf("expected code %s, got %v", tc.expected, err)
+			}
+			if sent != 1 {
+				t.Errorf("expected the sent callback to be called once, got %d", sent)
+			}
+		})
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if len(handled) != 2 || handled[0] != 1 || handled[1] != 2 {
+		t.Errorf("expected pull requests 1 and 2 to be handled, got %v", handled)
+	}
+}
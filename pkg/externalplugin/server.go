@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalplugin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pluginhelp/externalplugins"
+)
+
+// Server validates the events of hook and dispatches them to a plugin.
+type Server struct {
+	plugin         *Plugin
+	tokenGenerator func() []byte
+	log            *logrus.Entry
+	// Tracks running handlers for graceful shutdown
+	wg sync.WaitGroup
+}
+
+// NewServer returns a server of the plugin. Events must be signed with the
+// HMAC tokens of the token generator.
+func NewServer(p *Plugin, tokenGenerator func() []byte) *Server {
+	return &Server{
+		plugin:         p,
+		tokenGenerator: tokenGenerator,
+		log:            logrus.WithField("plugin", p.Name),
+	}
+}
+
+// ServeHTTP validates an incoming webhook and handles it in the background.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	eventType, eventGUID, payload, ok, _ := github.ValidateWebhook(w, r, s.tokenGenerator)
+	if !ok {
+		return
+	}
+	fmt.Fprint(w, "Event received. Have a nice day.")
+
+	e, err := DecodeEvent(eventType, eventGUID, payload)
+	if err != nil {
+		s.log.WithError(err).Error("Error parsing event.")
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.handle(e); err != nil {
+			s.log.WithError(err).Error("Error handling event.")
+		}
+	}()
+}
+
+func (s *Server) handle(e *Event) error {
+	l := s.log.WithFields(logrus.Fields{
+		"event-type":     e.Type,
+		github.EventGUID: e.GUID,
+	})
+	if e.Repo.FullName != "" {
+		l = l.WithFields(logrus.Fields{
+			github.OrgLogField:  e.Repo.Owner.Login,
+			github.RepoLogField: e.Repo.Name,
+		})
+	}
+	return s.plugin.Handle(l, e)
+}
+
+// GracefulShutdown waits for the running handlers.
+func (s *Server) GracefulShutdown() {
+	s.wg.Wait()
+}
+
+// Options are the flags of an external plugin server.
+type Options struct {
+	Port              int
+	GRPCPort          int
+	WebhookSecretFile string
+	GracePeriod       time.Duration
+
+	Instrumentation flagutil.InstrumentationOptions
+}
+
+// AddFlags injects the server options into the given FlagSet.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.IntVar(&o.Port, "port", 8888, "Port to serve webhooks and plugin help on.")
+	fs.IntVar(&o.GRPCPort, "grpc-port", 0, "Port to serve gRPC dispatch from hook on. gRPC is not served if 0.")
+	fs.StringVar(&o.WebhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
+	fs.DurationVar(&o.GracePeriod, "grace-period", 180*time.Second, "On shutdown, try to handle remaining events for the specified duration.")
+	o.Instrumentation.AddFlags(fs)
+}
+
+// Validate validates the server options.
+func (o *Options) Validate(dryRun bool) error {
+	if o.GRPCPort != 0 && o.GRPCPort == o.Port {
+		return errors.New("--grpc-port must differ from --port")
+	}
+	return o.Instrumentation.Validate(dryRun)
+}
+
+// Run serves the plugin until hook is interrupted and all running handlers
+// finished.
+func Run(p *Plugin, o Options) error {
+	if err := secret.Add(o.WebhookSecretFile); err != nil {
+		return fmt.Errorf("failed to start secrets agent: %w", err)
+	}
+	server := NewServer(p, secret.GetTokenGenerator(o.WebhookSecretFile))
+
+	health := pjutil.NewHealthOnPort(o.Instrumentation.HealthPort)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", server)
+	externalplugins.ServeExternalPluginHelp(mux, server.log, p.HelpProvider)
+	interrupts.ListenAndServe(&http.Server{Addr: ":" + strconv.Itoa(o.Port), Handler: mux}, o.GracePeriod)
+
+	if o.GRPCPort != 0 {
+		listener, err := net.Listen("tcp", ":"+strconv.Itoa(o.GRPCPort))
+		if err != nil {
+			return fmt.Errorf("failed to listen on gRPC port: %w", err)
+		}
+		grpcServer := NewGRPCServer(server)
+		interrupts.Run(func(ctx context.Context) {
+			go func() {
+				<-ctx.Done()
+				grpcServer.GracefulStop()
+			}()
+			if err := grpcServer.Serve(listener); err != nil {
+				server.log.WithError(err).Error("gRPC server exited.")
+			}
+		})
+	}
+	interrupts.OnInterrupt(server.GracefulShutdown)
+
+	health.ServeReady()
+	interrupts.WaitForGracefulShutdown()
+	return nil
+}
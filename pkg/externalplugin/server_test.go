@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalplugin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestServeHTTP(t *testing.T) {
+	const payload = `{"action": "created", "repository": {"full_name": "org/repo"}, "comment": {"body": "/hold"}}`
+	testCases := []struct {
+		name         string
+		sig          string
+		expectedCode int
+		expected     string
+	}{
+		{
+			name:         "valid webhook is handled",
+			sig:          github.PayloadSignature([]byte(payload), []byte("secret")),
+			expectedCode: http.StatusOK,
+			expected:     "/hold",
+		},
+		{
+			name:         "invalid signature is rejected",
+			sig:          github.PayloadSignature([]byte(payload), []byte("wrong")),
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var handled string
+			s := NewServer(&Plugin{
+				Name: "test",
+				IssueCommentHandler: func(_ *logrus.Entry, ic github.IssueCommentEvent) error {
+					handled = ic.Comment.Body
+					return nil
+				},
+			}, func() []byte { return []byte("secret") })
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+			r.Header.Set("X-GitHub-Event", "issue_comment")
+			r.Header.Set("X-GitHub-Delivery", "guid")
+			r.Header.Set("X-Hub-Signature", tc.sig)
+			r.Header.Set("content-type", "application/json")
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			s.GracefulShutdown()
+			if w.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, w.Code)
+			}
+			if handled != tc.expected {
+				t.Errorf("expected comment %q to be handled, got %q", tc.expected, handled)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/scm"
+	"sigs.k8s.io/prow/pkg/scm/bitbucket"
+)
+
+// BitbucketServerOptions holds options for interacting with Bitbucket Server.
+type BitbucketServerOptions struct {
+	endpoint  string
+	tokenPath string
+}
+
+// AddFlags injects Bitbucket Server options into the given FlagSet.
+func (o *BitbucketServerOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.endpoint, "bitbucket-server-endpoint", "", "Base URL of the Bitbucket Server, without any API path.")
+	fs.StringVar(&o.tokenPath, "bitbucket-server-token-path", "", "Path to the file containing the Bitbucket Server HTTP access token. Bitbucket Server is disabled if unset.")
+}
+
+// Validate validates Bitbucket Server options.
+func (o *BitbucketServerOptions) Validate(_ bool) error {
+	if o.tokenPath == "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(o.endpoint); err != nil {
+		return fmt.Errorf("--bitbucket-server-endpoint %q is invalid: %w", o.endpoint, err)
+	}
+	return nil
+}
+
+// Enabled returns whether a Bitbucket Server token was configured.
+func (o *BitbucketServerOptions) Enabled() bool {
+	return o.tokenPath != ""
+}
+
+// Provider returns the Bitbucket Server provider. webhookSecret is only
+// needed to validate webhooks and may be nil otherwise.
+func (o *BitbucketServerOptions) Provider(dryRun bool, webhookSecret func() []byte) (scm.Provider, error) {
+	if !o.Enabled() {
+		return nil, errors.New("empty --bitbucket-server-token-path, can not create a client")
+	}
+	if err := secret.Add(o.tokenPath); err != nil {
+		return nil, fmt.Errorf("failed to get --bitbucket-server-token-path: %w", err)
+	}
+	return bitbucket.NewProvider(o.endpoint, secret.GetTokenGenerator(o.tokenPath), webhookSecret, dryRun), nil
+}
@@ -49,6 +49,9 @@ type GitHubOptions struct {
 	AppID             string
 	AppPrivateKeyPath string
 
+	TokenMinterURL        string
+	TokenMinterSecretPath string
+
 	ThrottleHourlyTokens int
 	ThrottleAllowBurst   int
 
@@ -65,6 +68,11 @@ type GitHubOptions struct {
 	max404Retries  int
 	initialDelay   time.Duration
 	maxSleepTime   time.Duration
+
+	// the following options determine whether responses are cached
+	cacheSize     int
+	cacheDir      string
+	responseCache github.ResponseCache
 }
 
 type throttlerSettings struct {
@@ -135,6 +143,8 @@ func (o *GitHubOptions) addFlags(fs *flag.FlagSet, paramFuncs ...FlagParameter)
 	fs.StringVar(&o.TokenPath, "github-token-path", defaults.TokenPath, "Path to the file containing the GitHub OAuth secret.")
 	fs.StringVar(&o.AppID, "github-app-id", defaults.AppID, "ID of the GitHub app. If set, requires --github-app-private-key-path to be set and --github-token-path to be unset.")
 	fs.StringVar(&o.AppPrivateKeyPath, "github-app-private-key-path", defaults.AppPrivateKeyPath, "Path to the private key of the github app. If set, requires --github-app-id to bet set and --github-token-path to be unset")
+	fs.StringVar(&o.TokenMinterURL, "github-token-minter-url", defaults.TokenMinterURL, "URL of a github-token-minter to obtain GitHub App installation tokens from instead of holding the app's private key. If set, requires --github-token-minter-secret-path to be set and --github-token-path and --github-app-id to be unset.")
+	fs.StringVar(&o.TokenMinterSecretPath, "github-token-minter-secret-path", defaults.TokenMinterSecretPath, "Path to the file containing the secret to authenticate with the github-token-minter.")
 
 	if !params.disableThrottlerOptions {
 		fs.IntVar(&o.ThrottleHourlyTokens, "github-hourly-tokens", defaults.ThrottleHourlyTokens, "If set to a value larger than zero, enable client-side throttling to limit hourly token consumption. If set, --github-allowed-burst must be positive too.")
@@ -147,6 +157,8 @@ func (o *GitHubOptions) addFlags(fs *flag.FlagSet, paramFuncs ...FlagParameter)
 	fs.IntVar(&o.max404Retries, "github-client.max-404-retries", github.DefaultMax404Retries, "Maximum number of retries that will be used for a 404-ing request to the GitHub API.")
 	fs.DurationVar(&o.maxSleepTime, "github-client.backoff-timeout", github.DefaultMaxSleepTime, "Largest allowable Retry-After time for requests to the GitHub API.")
 	fs.DurationVar(&o.initialDelay, "github-client.initial-delay", github.DefaultInitialDelay, "Initial delay before retries begin for requests to the GitHub API.")
+	fs.IntVar(&o.cacheSize, "github-client.cache-size", 0, "Number of responses to cache and revalidate with conditional requests, which don't count against the rate limit if the response did not change. Useful when not using ghproxy. Disabled if zero.")
+	fs.StringVar(&o.cacheDir, "github-client.cache-dir", "", "Directory to persist the cached responses in, so that they survive restarts. Requires --github-client.cache-size.")
 }
 
 func (o *GitHubOptions) parseOrgThrottlers() error {
@@ -217,6 +229,24 @@ func (o *GitHubOptions) Validate(bool) error {
 	if o.AppID == "" != (o.AppPrivateKeyPath == "") {
 		return errors.New("--app-id and --app-private-key-path must be set together")
 	}
+	if o.TokenMinterURL != "" && (o.TokenPath != "" || o.AppID != "") {
+		return errors.New("--github-token-minter-url is mutually exclusive with --github-token-path and --github-app-id")
+	}
+	if o.TokenMinterURL == "" != (o.TokenMinterSecretPath == "") {
+		return errors.New("--github-token-minter-url and --github-token-minter-secret-path must be set together")
+	}
+	if o.TokenMinterURL != "" {
+		if _, err := url.ParseRequestURI(o.TokenMinterURL); err != nil {
+			return fmt.Errorf("invalid --github-token-minter-url URI: %q", o.TokenMinterURL)
+		}
+	}
+
+	if o.cacheSize < 0 {
+		return errors.New("--github-client.cache-size must not be negative")
+	}
+	if o.cacheDir != "" && o.cacheSize == 0 {
+		return errors.New("--github-client.cache-dir requires --github-client.cache-size")
+	}
 
 	if o.TokenPath != "" && len(endpoints) == 1 && endpoints[0] == github.DefaultAPIEndpoint && !o.AllowDirectAccess {
 		logrus.Warn("It doesn't look like you are using ghproxy to cache API calls to GitHub! This has become a required component of Prow and other components will soon be allowed to add features that may rapidly consume API ratelimit without caching. Starting May 1, 2020 use Prow components without ghproxy at your own risk! https://docs.prow.k8s.io/docs/ghproxy/")
@@ -243,6 +273,12 @@ func (o *GitHubOptions) Validate(bool) error {
 	return o.parseOrgThrottlers()
 }
 
+// UsesAppsAuth returns whether the client authenticates as a GitHub App,
+// either with the app's private key or through a token minter.
+func (o *GitHubOptions) UsesAppsAuth() bool {
+	return o.AppPrivateKeyPath != "" || o.TokenMinterURL != ""
+}
+
 // GitHubClientWithLogFields returns a GitHub client with extra logging fields
 func (o *GitHubOptions) GitHubClientWithLogFields(dryRun bool, fields logrus.Fields) (github.Client, error) {
 	client, err := o.githubClient(dryRun)
@@ -257,7 +293,19 @@ func (o *GitHubOptions) githubClient(dryRun bool) (github.Client, error) {
 	options := o.baseClientOptions()
 	options.DryRun = dryRun
 
-	if o.TokenPath == "" && o.AppPrivateKeyPath == "" {
+	if o.cacheSize > 0 {
+		// Clients created from the same options share their cache.
+		if o.responseCache == nil {
+			cache, err := github.NewLRUResponseCache(o.cacheSize, o.cacheDir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create GitHub response cache: %w", err)
+			}
+			o.responseCache = cache
+		}
+		options.ResponseCache = o.responseCache
+	}
+
+	if o.TokenPath == "" && o.AppPrivateKeyPath == "" && o.TokenMinterURL == "" {
 		logrus.Warn("empty -github-token-path, will use anonymous github client")
 	}
 
@@ -280,6 +328,14 @@ func (o *GitHubOptions) githubClient(dryRun bool) (github.Client, error) {
 		options.AppPrivateKey = apk
 	}
 
+	if o.TokenMinterURL != "" {
+		if err := secret.Add(o.TokenMinterSecretPath); err != nil {
+			return nil, fmt.Errorf("failed to add token minter secret to secret agent: %w", err)
+		}
+		options.TokenMinterURL = o.TokenMinterURL
+		options.TokenMinterSecret = secret.GetTokenGenerator(o.TokenMinterSecretPath)
+	}
+
 	optionallyThrottled := func(c github.Client) (github.Client, error) {
 		// Throttle handles zeros as "disable throttling" so we do not need to call it conditionally
 		if err := c.Throttle(o.ThrottleHourlyTokens, o.ThrottleAllowBurst); err != nil {
@@ -317,6 +373,21 @@ func (o *GitHubOptions) baseClientOptions() github.ClientOptions {
 	}
 }
 
+// InstallationTokenMinter returns a minter for installation tokens of the
+// GitHub App configured with --github-app-id and --github-app-private-key-path.
+func (o *GitHubOptions) InstallationTokenMinter() (github.InstallationTokenMinter, error) {
+	if o.AppID == "" {
+		return nil, errors.New("minting installation tokens requires --github-app-id and --github-app-private-key-path")
+	}
+	options := o.baseClientOptions()
+	apk, err := o.appPrivateKeyGenerator()
+	if err != nil {
+		return nil, err
+	}
+	options.AppPrivateKey = apk
+	return github.NewInstallationTokenMinter(logrus.Fields{}, options)
+}
+
 // GitHubClient returns a GitHub client.
 func (o *GitHubOptions) GitHubClient(dryRun bool) (github.Client, error) {
 	return o.GitHubClientWithLogFields(dryRun, logrus.Fields{})
@@ -346,7 +417,7 @@ func (o *GitHubOptions) GitClientFactory(cookieFilePath string, cacheDir *string
 		opts.CacheDirBase = cacheDir
 	}
 
-	if cookieFilePath == "" && (o.TokenPath != "" || o.AppPrivateKeyPath != "") {
+	if cookieFilePath == "" && (o.TokenPath != "" || o.UsesAppsAuth()) {
 		// Make a client with auth suitable for GitHub
 		user, generator, err := o.getGitHubAuthentication(dryRun)
 		if err != nil {
@@ -98,6 +98,62 @@ func TestGitHubOptions_Validate(t *testing.T) {
 			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
 			expectedErr:             false,
 		},
+		{
+			name: "token minter with secret: no error",
+			in: &GitHubOptions{
+				TokenMinterURL:        "http://github-token-minter",
+				TokenMinterSecretPath: "/etc/github-token-minter/secret",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+		},
+		{
+			name: "token minter without secret: error",
+			in: &GitHubOptions{
+				TokenMinterURL: "http://github-token-minter",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "token minter and token path: error",
+			in: &GitHubOptions{
+				TokenPath:             "/etc/github/oauth",
+				TokenMinterURL:        "http://github-token-minter",
+				TokenMinterSecretPath: "/etc/github-token-minter/secret",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "token minter and app id: error",
+			in: &GitHubOptions{
+				AppID:                 "10",
+				AppPrivateKeyPath:     "/etc/github/app-key",
+				TokenMinterURL:        "http://github-token-minter",
+				TokenMinterSecretPath: "/etc/github-token-minter/secret",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "cache size and dir: no error",
+			in: &GitHubOptions{
+				cacheSize: 1000,
+				cacheDir:  "/var/cache/github",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+		},
+		{
+			name: "cache dir without size: error",
+			in: &GitHubOptions{
+				cacheDir: "/var/cache/github",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "negative cache size: error",
+			in: &GitHubOptions{
+				cacheSize: -1,
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/gitlab"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// GitLabOptions holds options for interacting with GitLab.
+type GitLabOptions struct {
+	endpoint  string
+	tokenPath string
+}
+
+// AddFlags injects GitLab options into the given FlagSet.
+func (o *GitLabOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.endpoint, "gitlab-endpoint", gitlab.DefaultEndpoint, "GitLab API endpoint.")
+	fs.StringVar(&o.tokenPath, "gitlab-token-path", "", "Path to the file containing the GitLab access token. GitLab is disabled if unset.")
+}
+
+// Validate validates GitLab options.
+func (o *GitLabOptions) Validate(_ bool) error {
+	if o.tokenPath == "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(o.endpoint); err != nil {
+		return fmt.Errorf("--gitlab-endpoint %q is invalid: %w", o.endpoint, err)
+	}
+	return nil
+}
+
+// Enabled returns whether a GitLab token was configured.
+func (o *GitLabOptions) Enabled() bool {
+	return o.tokenPath != ""
+}
+
+// Provider returns the GitLab provider. webhookSecret is only needed to
+// validate webhooks.
+func (o *GitLabOptions) Provider(dryRun bool, webhookSecret func() []byte) (scm.Provider, error) {
+	if !o.Enabled() {
+		return nil, errors.New("empty --gitlab-token-path, can not create a client")
+	}
+	if err := secret.Add(o.tokenPath); err != nil {
+		return nil, fmt.Errorf("failed to get --gitlab-token-path: %w", err)
+	}
+	return gitlab.NewProvider(gitlab.NewClient(o.endpoint, secret.GetTokenGenerator(o.tokenPath), dryRun), webhookSecret), nil
+}
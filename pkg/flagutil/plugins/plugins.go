@@ -30,6 +30,7 @@ type PluginOptions struct {
 	SupplementalPluginsConfigDirs            flagutil.Strings
 	SupplementalPluginsConfigsFileNameSuffix string
 	CheckUnknownPlugins                      bool
+	CheckUnknownFields                       bool
 	SkipResolveConfigUpdater                 bool
 }
 
@@ -37,6 +38,7 @@ func (o *PluginOptions) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&o.PluginConfigPath, "plugin-config", o.PluginConfigPathDefault, "Path to plugin config file.")
 	fs.Var(&o.SupplementalPluginsConfigDirs, "supplemental-plugin-config-dir", "An additional directory from which to load plugin configs. Can be used for config sharding but only supports a subset of the config. The flag can be passed multiple times.")
 	fs.StringVar(&o.SupplementalPluginsConfigsFileNameSuffix, "supplemental-plugin-configs-filename-suffix", "_pluginconfig.yaml", "Suffix for additional plugin configs. Only files with this name will be considered")
+	fs.BoolVar(&o.CheckUnknownFields, "strict-plugin-config", false, "If set, unknown fields in the plugin config make loading it fail.")
 }
 
 func (o *PluginOptions) Validate(_ bool) error {
@@ -45,7 +47,7 @@ func (o *PluginOptions) Validate(_ bool) error {
 
 func (o *PluginOptions) PluginAgent() (*plugins.ConfigAgent, error) {
 	pluginAgent := &plugins.ConfigAgent{}
-	if err := pluginAgent.Start(o.PluginConfigPath, o.SupplementalPluginsConfigDirs.Strings(), o.SupplementalPluginsConfigsFileNameSuffix, o.CheckUnknownPlugins, o.SkipResolveConfigUpdater); err != nil {
+	if err := pluginAgent.Start(o.PluginConfigPath, o.SupplementalPluginsConfigDirs.Strings(), o.SupplementalPluginsConfigsFileNameSuffix, o.CheckUnknownPlugins, o.CheckUnknownFields, o.SkipResolveConfigUpdater); err != nil {
 		return nil, fmt.Errorf("failed to start plugins agent: %w", err)
 	}
 
@@ -20,6 +20,7 @@ import (
 	context "context"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 	"time"
@@ -30,13 +31,17 @@ import (
 	status "google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/secretutil"
 	"sigs.k8s.io/prow/pkg/version"
 )
 
@@ -45,6 +50,7 @@ const (
 	HEADER_API_CONSUMER_ID   = "x-endpoint-api-consumer-number"
 	CONTEXT_TIMEOUT          = 10 * time.Minute
 	LIST_TIMEOUT             = 60
+	LOG_CHUNK_SIZE           = 32 * 1024
 )
 
 type Gangway struct {
@@ -52,6 +58,12 @@ type Gangway struct {
 	ConfigAgent        *config.Agent
 	ProwJobClient      ProwJobClient
 	InRepoConfigGetter config.InRepoConfigGetter
+	// PodClients are the pod clients of the build clusters, by cluster alias.
+	// They are used to stream the logs of job executions.
+	PodClients map[string]corev1.PodInterface
+	// SecretClients are the secret clients of the build clusters, by cluster
+	// alias. The secrets of a test pod are censored in its streamed logs.
+	SecretClients map[string]corev1.SecretInterface
 }
 
 // ProwJobClient describes a Kubernetes client for the Prow Job CR. Unlike a
@@ -130,6 +142,166 @@ func (gw *Gangway) GetJobExecution(ctx context.Context, gjer *GetJobExecutionReq
 	return jobExec, nil
 }
 
+// StreamJobExecutionLogs streams the log of a container of the test pod of a
+// Prow job execution until the container terminates. Only clients that are
+// authorized to run the job may read its log, and the secrets of the pod are
+// censored in it.
+func (gw *Gangway) StreamJobExecutionLogs(request *StreamJobExecutionLogsRequest, stream Prow_StreamJobExecutionLogsServer) error {
+	ctx := stream.Context()
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(request.GetId()) == 0 {
+		return status.Error(codes.InvalidArgument, "id field cannot be empty")
+	}
+
+	mainConfig := gw.ConfigAgent.Config()
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	prowJobCR, err := gw.ProwJobClient.Get(ctx, request.GetId(), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return status.Errorf(codes.NotFound, "job execution %s not found", request.GetId())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !ClientAuthorized(allowedApiClient, *prowJobCR) {
+		return status.Error(codes.PermissionDenied, "client is not authorized to read the log of the given job")
+	}
+
+	if prowJobCR.Status.PodName == "" {
+		return status.Errorf(codes.FailedPrecondition, "job execution %s has no pod yet", prowJobCR.Name)
+	}
+	podClient, ok := gw.PodClients[prowJobCR.ClusterAlias()]
+	if !ok {
+		return status.Errorf(codes.Unavailable, "no client for build cluster %q", prowJobCR.ClusterAlias())
+	}
+	censorer, err := gw.podCensorer(ctx, podClient, prowJobCR)
+	if err != nil {
+		return err
+	}
+	// The last bytes read are held back until more of the log is read, as
+	// they may be the beginning of a secret.
+	holdback := censorer.LargestSecret() - 1
+	if holdback < 0 {
+		holdback = 0
+	}
+	container := request.GetContainer()
+	if container == "" {
+		container = kube.TestContainerName
+	}
+
+	reader, err := podClient.GetLogs(prowJobCR.Status.PodName, &v1.PodLogOptions{Container: container, Follow: true}).Stream(ctx)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return status.Errorf(codes.NotFound, "pod of job execution %s is gone, its log is available at %s", prowJobCR.Name, prowJobCR.Status.URL)
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer reader.Close()
+
+	buf := make([]byte, LOG_CHUNK_SIZE)
+	var pending []byte
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			pending = append(pending, buf[:n]...)
+			censorer.Censor(&pending)
+			if cut := len(pending) - holdback; cut > 0 {
+				if err := stream.Send(&LogChunk{Data: pending[:cut]}); err != nil {
+					return err
+				}
+				pending = append([]byte(nil), pending[cut:]...)
+			}
+		}
+		if err == io.EOF {
+			if len(pending) > 0 {
+				return stream.Send(&LogChunk{Data: pending})
+			}
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+// podCensorer returns a censorer for the values of the Kubernetes secrets
+// used by the pod of the job execution. Secrets fetched from Vault when the
+// test starts are unknown to gangway, so the logs of such jobs are not
+// streamed.
+func (gw *Gangway) podCensorer(ctx context.Context, podClient corev1.PodInterface, pj *prowcrd.ProwJob) (*secretutil.ReloadingCensorer, error) {
+	if pj.Spec.DecorationConfig != nil && pj.Spec.DecorationConfig.Vault != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "job execution %s fetches secrets from Vault that cannot be censored while streaming, its log is available at %s", pj.Name, pj.Status.URL)
+	}
+	pod, err := podClient.Get(ctx, pj.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "pod of job execution %s is gone, its log is available at %s", pj.Name, pj.Status.URL)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	secretClient, ok := gw.SecretClients[pj.ClusterAlias()]
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "no client for build cluster %q", pj.ClusterAlias())
+	}
+	var values []string
+	for _, name := range sets.List(podSecretNames(pod.Spec)) {
+		secret, err := secretClient.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				// The pod can not read a missing secret either.
+				continue
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get secret %s: %v", name, err)
+		}
+		for _, value := range secret.Data {
+			values = append(values, string(value))
+		}
+	}
+	censorer := secretutil.NewCensorer()
+	censorer.Refresh(values...)
+	return censorer, nil
+}
+
+// podSecretNames returns the names of the secrets that are mounted into the
+// pod or exposed to its containers as environment variables.
+func podSecretNames(spec v1.PodSpec) sets.Set[string] {
+	names := sets.New[string]()
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			names.Insert(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					names.Insert(source.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, container := range append(spec.InitContainers, spec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names.Insert(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				names.Insert(envFrom.SecretRef.Name)
+			}
+		}
+	}
+	return names
+}
+
 // Translate ProwJobStatus.State in the Prow Job CR into a JobExecutionStatus.
 func TranslateProwJobStatus(prowJobStatus *prowcrd.ProwJobStatus) JobExecutionStatus {
 	var jobStatus JobExecutionStatus
@@ -294,6 +466,9 @@ func isMatchingCondition(pj prowcrd.ProwJob, request *BulkJobStatusChangeRequest
 // allowlist (allowed_api_clients) allows it.
 func ClientAuthorized(allowedApiClient *config.AllowedApiClient, prowJobCR prowcrd.ProwJob) bool {
 	pjd := prowJobCR.Spec.ProwJobDefault
+	if pjd == nil {
+		return false
+	}
 	for _, allowedJobsFilter := range allowedApiClient.AllowedJobsFilters {
 		if allowedJobsFilter.TenantID == pjd.TenantID {
 			return true
@@ -722,6 +897,11 @@ func HandleProwJob(l *logrus.Entry,
 			logrus.Error("client is not authorized to execute the given job")
 			return nil, status.Error(codes.PermissionDenied, "client is not authorized to execute the given job")
 		}
+
+		if err := checkOverrides(allowedApiClient.AllowedOverrides, cjer.GetPodSpecOptions()); err != nil {
+			l.WithError(err).Info("client is not allowed to override the given pod spec options")
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 	}
 
 	if _, err := pjc.Create(context.TODO(), &prowJobCR, metav1.CreateOptions{}); err != nil {
@@ -759,6 +939,36 @@ func HandleProwJob(l *logrus.Entry,
 	return jobExec, nil
 }
 
+// checkOverrides returns an error naming the environment variables, labels and
+// annotations of the pod spec options that are not in the allowed overrides.
+// All overrides are allowed if allowedOverrides is nil.
+func checkOverrides(allowedOverrides *config.AllowedOverrides, pso *PodSpecOptions) error {
+	if allowedOverrides == nil {
+		return nil
+	}
+	var disallowed []string
+	for _, overrides := range []struct {
+		kind    string
+		keys    map[string]string
+		allowed []string
+	}{
+		{"environment variable", pso.GetEnvs(), allowedOverrides.Envs},
+		{"label", pso.GetLabels(), allowedOverrides.Labels},
+		{"annotation", pso.GetAnnotations(), allowedOverrides.Annotations},
+	} {
+		allowed := sets.New(overrides.allowed...)
+		for _, key := range sets.List(sets.KeySet(overrides.keys)) {
+			if !allowed.Has(key) {
+				disallowed = append(disallowed, fmt.Sprintf("%s %q", overrides.kind, key))
+			}
+		}
+	}
+	if len(disallowed) > 0 {
+		return fmt.Errorf("client is not allowed to override %s", strings.Join(disallowed, ", "))
+	}
+	return nil
+}
+
 // jobHandler handles job type specific logic
 type jobHandler interface {
 	getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error)
@@ -20,10 +20,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Prow_CreateJobExecution_FullMethodName  = "/Prow/CreateJobExecution"
-	Prow_GetJobExecution_FullMethodName     = "/Prow/GetJobExecution"
-	Prow_ListJobExecutions_FullMethodName   = "/Prow/ListJobExecutions"
-	Prow_BulkJobStatusChange_FullMethodName = "/Prow/BulkJobStatusChange"
+	Prow_CreateJobExecution_FullMethodName     = "/Prow/CreateJobExecution"
+	Prow_GetJobExecution_FullMethodName        = "/Prow/GetJobExecution"
+	Prow_ListJobExecutions_FullMethodName      = "/Prow/ListJobExecutions"
+	Prow_BulkJobStatusChange_FullMethodName    = "/Prow/BulkJobStatusChange"
+	Prow_StreamJobExecutionLogs_FullMethodName = "/Prow/StreamJobExecutionLogs"
 )
 
 // ProwClient is the client API for Prow service.
@@ -39,6 +40,9 @@ type ProwClient interface {
 	GetJobExecution(ctx context.Context, in *GetJobExecutionRequest, opts ...grpc.CallOption) (*JobExecution, error)
 	ListJobExecutions(ctx context.Context, in *ListJobExecutionsRequest, opts ...grpc.CallOption) (*JobExecutions, error)
 	BulkJobStatusChange(ctx context.Context, in *BulkJobStatusChangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Stream the log of a container of a Prow Job execution, until the container
+	// terminates.
+	StreamJobExecutionLogs(ctx context.Context, in *StreamJobExecutionLogsRequest, opts ...grpc.CallOption) (Prow_StreamJobExecutionLogsClient, error)
 }
 
 type prowClient struct {
@@ -85,6 +89,38 @@ func (c *prowClient) BulkJobStatusChange(ctx context.Context, in *BulkJobStatusC
 	return out, nil
 }
 
+func (c *prowClient) StreamJobExecutionLogs(ctx context.Context, in *StreamJobExecutionLogsRequest, opts ...grpc.CallOption) (Prow_StreamJobExecutionLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Prow_ServiceDesc.Streams[0], Prow_StreamJobExecutionLogs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &prowStreamJobExecutionLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Prow_StreamJobExecutionLogsClient interface {
+	Recv() (*LogChunk, error)
+	grpc.ClientStream
+}
+
+type prowStreamJobExecutionLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *prowStreamJobExecutionLogsClient) Recv() (*LogChunk, error) {
+	m := new(LogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ProwServer is the server API for Prow service.
 // All implementations must embed UnimplementedProwServer
 // for forward compatibility
@@ -98,6 +134,9 @@ type ProwServer interface {
 	GetJobExecution(context.Context, *GetJobExecutionRequest) (*JobExecution, error)
 	ListJobExecutions(context.Context, *ListJobExecutionsRequest) (*JobExecutions, error)
 	BulkJobStatusChange(context.Context, *BulkJobStatusChangeRequest) (*emptypb.Empty, error)
+	// Stream the log of a container of a Prow Job execution, until the container
+	// terminates.
+	StreamJobExecutionLogs(*StreamJobExecutionLogsRequest, Prow_StreamJobExecutionLogsServer) error
 	mustEmbedUnimplementedProwServer()
 }
 
@@ -117,6 +156,9 @@ func (UnimplementedProwServer) ListJobExecutions(context.Context, *ListJobExecut
 func (UnimplementedProwServer) BulkJobStatusChange(context.Context, *BulkJobStatusChangeRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BulkJobStatusChange not implemented")
 }
+func (UnimplementedProwServer) StreamJobExecutionLogs(*StreamJobExecutionLogsRequest, Prow_StreamJobExecutionLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamJobExecutionLogs not implemented")
+}
 func (UnimplementedProwServer) mustEmbedUnimplementedProwServer() {}
 
 // UnsafeProwServer may be embedded to opt out of forward compatibility for this service.
@@ -202,6 +244,27 @@ func _Prow_BulkJobStatusChange_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Prow_StreamJobExecutionLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamJobExecutionLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProwServer).StreamJobExecutionLogs(m, &prowStreamJobExecutionLogsServer{stream})
+}
+
+type Prow_StreamJobExecutionLogsServer interface {
+	Send(*LogChunk) error
+	grpc.ServerStream
+}
+
+type prowStreamJobExecutionLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *prowStreamJobExecutionLogsServer) Send(m *LogChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Prow_ServiceDesc is the grpc.ServiceDesc for Prow service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -226,6 +289,12 @@ var Prow_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Prow_BulkJobStatusChange_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamJobExecutionLogs",
+			Handler:       _Prow_StreamJobExecutionLogs_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "gangway.proto",
 }
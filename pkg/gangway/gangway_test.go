@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	status "google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestCheckOverrides(t *testing.T) {
+	pso := &PodSpecOptions{
+		Envs:        map[string]string{"FOCUS": "e2e"},
+		Labels:      map[string]string{"team": "infra"},
+		Annotations: map[string]string{"owner": "alice"},
+	}
+	testCases := []struct {
+		name             string
+		allowedOverrides *config.AllowedOverrides
+		pso              *PodSpecOptions
+		expectedErr      string
+	}{
+		{
+			name: "all overrides are allowed without an allowlist",
+			pso:  pso,
+		},
+		{
+			name:             "no overrides",
+			allowedOverrides: &config.AllowedOverrides{},
+		},
+		{
+			name: "allowed overrides",
+			allowedOverrides: &config.AllowedOverrides{
+				Envs:        []string{"FOCUS"},
+				Labels:      []string{"team"},
+				Annotations: []string{"owner"},
+			},
+			pso: pso,
+		},
+		{
+			name:             "disallowed overrides",
+			allowedOverrides: &config.AllowedOverrides{Envs: []string{"FOCUS"}},
+			pso:              pso,
+			expectedErr:      `client is not allowed to override label "team", annotation "owner"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkOverrides(tc.allowedOverrides, tc.pso)
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+				t.Fatalf("expected error %q, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+type fakeLogStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []string
+}
+
+func (f *fakeLogStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeLogStream) Send(chunk *LogChunk) error {
+	f.chunks = append(f.chunks, string(chunk.Data))
+	return nil
+}
+
+func TestStreamJobExecutionLogs(t *testing.T) {
+	cfg := &config.Config{ProwConfig: config.ProwConfig{
+		ProwJobNamespace: "prowjobs",
+		Gangway: config.Gangway{AllowedApiClients: []config.AllowedApiClient{{
+			GCP:                &config.ApiClientGcp{EndpointApiConsumerType: "PROJECT", EndpointApiConsumerNumber: "123"},
+			AllowedJobsFilters: []config.AllowedJobsFilter{{TenantID: "tenant"}},
+		}}},
+	}}
+	ca := &config.Agent{}
+	ca.Set(cfg)
+
+	prowJob := func(name, tenantID, podName string) *prowcrd.ProwJob {
+		return &prowcrd.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prowjobs"},
+			Spec:       prowcrd.ProwJobSpec{Cluster: "build", ProwJobDefault: &prowcrd.ProwJobDefault{TenantID: tenantID}},
+			Status:     prowcrd.ProwJobStatus{PodName: podName},
+		}
+	}
+	vaultJob := prowJob("vault", "tenant", "vault-pod")
+	vaultJob.Spec.DecorationConfig = &prowcrd.DecorationConfig{Vault: &prowcrd.VaultConfig{}}
+	pod := func(name string, spec v1.PodSpec) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-pods"}, Spec: spec}
+	}
+	buildCluster := k8sfake.NewSimpleClientset(
+		pod("running-pod", v1.PodSpec{}),
+		pod("secret-pod", v1.PodSpec{Containers: []v1.Container{{
+			Env: []v1.EnvVar{{Name: "TOKEN", ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "token"}, Key: "token"},
+			}}},
+		}}}),
+		pod("vault-pod", v1.PodSpec{}),
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "test-pods"}, Data: map[string][]byte{"token": []byte("logs")}},
+	)
+	gw := &Gangway{
+		ConfigAgent: ca,
+		ProwJobClient: fake.NewSimpleClientset(
+			prowJob("running", "tenant", "running-pod"),
+			prowJob("secret", "tenant", "secret-pod"),
+			prowJob("other-tenant", "other", "other-pod"),
+			prowJob("scheduling", "tenant", ""),
+			prowJob("gone", "tenant", "gone-pod"),
+			vaultJob,
+		).ProwV1().ProwJobs("prowjobs"),
+		PodClients:    map[string]corev1.PodInterface{"build": buildCluster.CoreV1().Pods("test-pods")},
+		SecretClients: map[string]corev1.SecretInterface{"build": buildCluster.CoreV1().Secrets("test-pods")},
+	}
+
+	testCases := []struct {
+		name         string
+		id           string
+		md           metadata.MD
+		expectedCode codes.Code
+		expectedLog  string
+	}{
+		{
+			name:         "streams the log of the test container",
+			id:           "running",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.OK,
+			expectedLog:  "fake logs",
+		},
+		{
+			name:         "secrets of the pod are censored",
+			id:           "secret",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.OK,
+			expectedLog:  "fake XXXX",
+		},
+		{
+			name:         "job with secrets from Vault",
+			id:           "vault",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "pod is gone",
+			id:           "gone",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "unknown client",
+			id:           "running",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "456"),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "job of another tenant",
+			id:           "other-tenant",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "unknown job",
+			id:           "missing",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "job without a pod",
+			id:           "scheduling",
+			md:           metadata.Pairs(HEADER_API_CONSUMER_TYPE, "PROJECT", HEADER_API_CONSUMER_ID, "123"),
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &fakeLogStream{ctx: metadata.NewIncomingContext(context.Background(), tc.md)}
+			err := gw.StreamJobExecutionLogs(&StreamJobExecutionLogsRequest{Id: tc.id}, stream)
+			if code := status.Code(err); code != tc.expectedCode {
+				t.Fatalf("expected code %s, got: %v", tc.expectedCode, err)
+			}
+			if log := strings.Join(stream.chunks, ""); log != tc.expectedLog {
+				t.Errorf("expected log %q, got %q", tc.expectedLog, log)
+			}
+		})
+	}
+}
@@ -64,6 +64,21 @@ func (o Options) Run(ctx context.Context, spec *downwardapi.JobSpec, extra map[s
 	return err
 }
 
+// UploadExtra uploads only the extra targets, relative to the path of the job
+// in blob storage. Unlike Run, it neither uploads the configured items nor
+// the alias and latest build markers of the job.
+func (o Options) UploadExtra(ctx context.Context, spec *downwardapi.JobSpec, extra map[string]gcs.UploadFunc) error {
+	_, blobStoragePath, _ := PathsForJob(o.GCSConfiguration, spec, o.SubDir)
+	if o.LocalOutputDir != "" {
+		blobStoragePath = ""
+	}
+	uploadTargets := make(map[string]gcs.UploadFunc, len(extra))
+	for destination, upload := range extra {
+		uploadTargets[path.Join(blobStoragePath, destination)] = upload
+	}
+	return completeUpload(ctx, o, uploadTargets)
+}
+
 func completeUpload(ctx context.Context, o Options, uploadTargets map[string]gcs.UploadFunc) error {
 	if o.DryRun {
 		for destination := range uploadTargets {
@@ -26,6 +26,7 @@ import (
 )
 
 // PopulateStruct will recursively populate a struct via reflection for consumption by genyaml by:
+// * Skipping fields that are not serialized (`json:"-"`)
 // * Filling all pointer fields
 // * Filling all slices with a one-element slice and filling that one element
 // * Filling all maps with a one-element map and filling that one element
@@ -46,6 +47,11 @@ func PopulateStruct(in interface{}) interface{} {
 		if !valueOf.Elem().Field(i).CanSet() {
 			continue
 		}
+		// Not serialized, which also stops the recursion into fields that
+		// refer to their own type
+		if typeOf.Elem().Field(i).Tag.Get("json") == "-" {
+			continue
+		}
 
 		if typeOf.Elem().Field(i).Anonymous {
 			// We can only warn about this, because the go stdlib and some kube types do this :/
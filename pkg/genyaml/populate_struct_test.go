@@ -179,3 +179,20 @@ func TestPopulateStructHandlesUnexportedFields(_ *testing.T) {
 	PopulateStruct(&s)
 	// This test indicates success by not panicking, so nothing further to check
 }
+
+type selfReferencing struct {
+	Field    *int
+	Resolved map[string]*selfReferencing `json:"-"`
+}
+
+func TestPopulateStructSkipsFieldsThatAreNotSerialized(t *testing.T) {
+	s := selfReferencing{}
+
+	PopulateStruct(&s)
+	if s.Field == nil {
+		t.Fatalf("Pointer field in struct didn't get set, struct: %+v", s)
+	}
+	if s.Resolved != nil {
+		t.Fatalf("Field that is not serialized got set, struct: %+v", s)
+	}
+}
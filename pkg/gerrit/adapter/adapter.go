@@ -158,6 +158,11 @@ type gerritClient interface {
 	SetReview(instance, id, revision, message string, labels map[string]string) error
 	Account(instance string) (*gerrit.AccountInfo, error)
 	HasRelatedChanges(instance, id, revision string) (bool, error)
+	GetChange(instance, id string, additionalFields ...string) (*gerrit.ChangeInfo, error)
+	EnsureChecker(instance string, input client.CheckerInput) error
+	GetCheck(instance, id, revision, checkerUUID string) (*client.CheckInfo, error)
+	UpdateCheck(instance, id, revision string, input client.CheckInput) error
+	PendingChecks(instance, scheme string) ([]client.PendingChecksInfo, error)
 }
 
 // Controller manages gerrit changes.
@@ -407,6 +412,7 @@ func (c *Controller) Sync() {
 			staggerPosition++
 		}
 	}
+	c.processPendingChecks()
 }
 
 // CreateRefs creates refs for a presubmit job from given changes.
@@ -567,7 +573,9 @@ func (c *Controller) shouldTriggerJobs(change client.ChangeInfo, lastProjectSync
 
 func (c *Controller) messageContainsJobTriggeringCommand(message gerrit.ChangeMessageInfo) bool {
 	return pjutil.RetestRe.MatchString(message.Message) ||
-		pjutil.TestAllRe.MatchString(message.Message) ||
+		pjutil.RetestRequiredRe.MatchString(message.Message) ||
+		testRe.MatchString(message.Message) ||
+		overrideRe.MatchString(message.Message) ||
 		c.configAgent.Config().Gerrit.IsAllowedPresubmitTrigger(message.Message)
 }
 
@@ -663,6 +671,10 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 		}
 
 		for _, postsubmit := range postsubmits {
+			if postsubmit.RunsAfterSuccess() {
+				// Triggered by the dependent-jobs controller instead.
+				continue
+			}
 			if shouldRun, err := postsubmit.ShouldRun(change.Branch, client.ChangedFilesProvider(&change)); err != nil {
 				return fmt.Errorf("failed to determine if postsubmit %q should run: %w", postsubmit.Name, err)
 			} else if shouldRun {
@@ -726,13 +738,17 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 		failedJobs := failedJobs(account.AccountID, revision.Number, change.Messages...)
 		failed, all := presubmitContexts(failedJobs, presubmits, logger)
 		messages := currentMessages(change, lastUpdate)
+		if err := c.handleOverrides(logger, instance, change, presubmits, messages, refs); err != nil {
+			logger.WithError(err).Warn("Failed to handle /override commands.")
+		}
 		logger.WithField("failed", len(failed)).Debug("Failed jobs parsed from previous comments.")
 		filters := []pjutil.Filter{
 			messageFilter(messages, failed, all, triggerTimes, logger),
 		}
 		// Automatically trigger the Prow jobs if the revision is new and the
 		// change is not in WorkInProgress.
-		if revision.Created.Time.After(lastUpdate) && !change.WorkInProgress {
+		autoTrigger := revision.Created.Time.After(lastUpdate) && !change.WorkInProgress
+		if autoTrigger {
 			filters = append(filters, &timeAnnotationFilter{
 				Filter:       pjutil.NewTestAllFilter(),
 				eventTime:    revision.Created.Time,
@@ -744,6 +760,9 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 			return fmt.Errorf("filter presubmits: %w", err)
 		}
 		// At this point triggerTimes should be properly populated as a side effect of FilterPresubmits.
+		if checks := c.config().Gerrit.OrgReposConfig.ChecksFor(instance, change.Project); checks != nil && autoTrigger {
+			c.reportSkippedChecks(logger, instance, checks, change, presubmits, toTrigger)
+		}
 
 		// Reply with help information to run the presubmit Prow jobs if requested.
 		for _, msg := range messages {
@@ -71,6 +71,43 @@ func (f *fca) Config() *config.Config {
 type fgc struct {
 	reviews     int
 	instanceMap map[string]*gerrit.AccountInfo
+
+	changes       map[string]*gerrit.ChangeInfo
+	checks        map[string]*client.CheckInfo
+	pendingChecks []client.PendingChecksInfo
+	pendingLists  int
+	checkUpdates  []client.CheckInput
+	checkers      []string
+}
+
+func (f *fgc) GetChange(instance, id string, additionalFields ...string) (*gerrit.ChangeInfo, error) {
+	change, ok := f.changes[id]
+	if !ok {
+		return nil, errors.New("change not found")
+	}
+	return change, nil
+}
+
+func (f *fgc) EnsureChecker(instance string, input client.CheckerInput) error {
+	f.checkers = append(f.checkers, input.UUID)
+	return nil
+}
+
+func (f *fgc) GetCheck(instance, id, revision, checkerUUID string) (*client.CheckInfo, error) {
+	if check, ok := f.checks[checkerUUID]; ok {
+		return check, nil
+	}
+	return &client.CheckInfo{CheckerUUID: checkerUUID, State: client.CheckNotStarted}, nil
+}
+
+func (f *fgc) UpdateCheck(instance, id, revision string, input client.CheckInput) error {
+	f.checkUpdates = append(f.checkUpdates, input)
+	return nil
+}
+
+func (f *fgc) PendingChecks(instance, scheme string) ([]client.PendingChecksInfo, error) {
+	f.pendingLists++
+	return f.pendingChecks, nil
 }
 
 func (f *fgc) HasRelatedChanges(instance, id, revision string) (bool, error) {
@@ -229,6 +266,38 @@ func TestShouldTriggerJobs(t *testing.T) {
 			latest: lastUpdateTime,
 			result: true,
 		},
+		{
+			name:     "trigger jobs when comment contains /retest-required",
+			instance: instance,
+			change: gerrit.ChangeInfo{ID: "1", CurrentRevision: "10", Project: project,
+				Revisions: map[string]gerrit.RevisionInfo{
+					"10": {Number: 10, Created: makeStamp(now.Add(-2 * time.Hour))},
+				}, Messages: []gerrit.ChangeMessageInfo{
+					{
+						Date:           makeStamp(now),
+						Message:        "Patch Set 10:\n\n/retest-required",
+						RevisionNumber: 10,
+					},
+				}},
+			latest: lastUpdateTime,
+			result: true,
+		},
+		{
+			name:     "trigger jobs when comment contains /override",
+			instance: instance,
+			change: gerrit.ChangeInfo{ID: "1", CurrentRevision: "10", Project: project,
+				Revisions: map[string]gerrit.RevisionInfo{
+					"10": {Number: 10, Created: makeStamp(now.Add(-2 * time.Hour))},
+				}, Messages: []gerrit.ChangeMessageInfo{
+					{
+						Date:           makeStamp(now),
+						Message:        "Patch Set 10:\n\n/override integration",
+						RevisionNumber: 10,
+					},
+				}},
+			latest: lastUpdateTime,
+			result: true,
+		},
 		{
 			name:     "do not trigger when command does not conform to requirements",
 			instance: instance,
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
+	"sigs.k8s.io/prow/pkg/gerrit/source"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+const (
+	checkNotRunMessage     = "Not run automatically. Run the check to trigger the job."
+	checkUnknownJobMessage = "There is no such job for this change."
+	checkRerunMessage      = "Re-run requested."
+)
+
+// updateCheck sets the state of the check of a job on the current revision of
+// a change, creating the checker first if configured to.
+func (c *Controller) updateCheck(instance string, checks *config.GerritChecks, change client.ChangeInfo, job, state, message string) error {
+	if checks.CreateCheckers {
+		if err := c.gc.EnsureChecker(instance, client.CheckerForJob(checks, change.Project, job)); err != nil {
+			return err
+		}
+	}
+	return c.gc.UpdateCheck(instance, change.ID, change.CurrentRevision, client.CheckInput{
+		CheckerUUID: checks.CheckerUUID(job),
+		State:       state,
+		Message:     message,
+	})
+}
+
+// reportSkippedChecks marks the checks of presubmits that were not triggered
+// for a new revision as not relevant, so they can be run from the Gerrit UI
+// on demand.
+func (c *Controller) reportSkippedChecks(logger logrus.FieldLogger, instance string, checks *config.GerritChecks, change client.ChangeInfo, presubmits, triggered []config.Presubmit) {
+	triggeredNames := sets.New[string]()
+	for _, presubmit := range triggered {
+		triggeredNames.Insert(presubmit.Name)
+	}
+	for _, presubmit := range presubmits {
+		if triggeredNames.Has(presubmit.Name) || presubmit.SkipReport {
+			continue
+		}
+		if err := c.updateCheck(instance, checks, change, presubmit.Name, client.CheckNotRelevant, checkNotRunMessage); err != nil {
+			logger.WithError(err).WithField("job", presubmit.Name).Warn("Failed to mark check as not relevant.")
+		}
+	}
+}
+
+// processPendingChecks triggers the jobs of checks that were re-run from the
+// Gerrit UI. The pending checks of a scheme are listed once for all projects
+// of an instance.
+func (c *Controller) processPendingChecks() {
+	orgReposConfig := c.config().Gerrit.OrgReposConfig
+	for instance, projects := range orgReposConfig.AllRepos() {
+		schemes := sets.New[string]()
+		for project := range projects {
+			if checks := orgReposConfig.ChecksFor(instance, project); checks != nil {
+				schemes.Insert(checks.Scheme)
+			}
+		}
+		for _, scheme := range sets.List(schemes) {
+			c.processPendingChecksOfScheme(instance, scheme)
+		}
+	}
+}
+
+// processPendingChecksOfScheme triggers the jobs of the pending checks of a
+// scheme on an instance.
+func (c *Controller) processPendingChecksOfScheme(instance, scheme string) {
+	log := logrus.WithFields(logrus.Fields{"host": instance, "scheme": scheme})
+	pending, err := c.gc.PendingChecks(instance, scheme)
+	if err != nil {
+		log.WithError(err).Warn("Failed to list pending checks.")
+		return
+	}
+	for _, patchSet := range pending {
+		checks := c.config().Gerrit.OrgReposConfig.ChecksFor(instance, patchSet.PatchSet.Repository)
+		if checks == nil || checks.Scheme != scheme {
+			continue
+		}
+		jobs := sets.New[string]()
+		for uuid := range patchSet.PendingChecks {
+			if job, ok := checks.JobForCheckerUUID(uuid); ok {
+				jobs.Insert(job)
+			}
+		}
+		if jobs.Len() == 0 {
+			continue
+		}
+		log := log.WithFields(logrus.Fields{"repo": patchSet.PatchSet.Repository, "change": patchSet.PatchSet.ChangeNumber, "patchset": patchSet.PatchSet.PatchSetID})
+		if err := c.rerunChecks(log, instance, checks, patchSet.PatchSet, jobs); err != nil {
+			log.WithError(err).Warn("Failed to re-run checks.")
+		}
+	}
+}
+
+// rerunChecks triggers the jobs of pending checks on a patch set. Gerrit also
+// lists checks that were never reported as pending, so only checks that Prow
+// reported before, and that were then reset by a re-run, trigger their job.
+func (c *Controller) rerunChecks(log logrus.FieldLogger, instance string, checks *config.GerritChecks, patchSet client.CheckablePatchSetInfo, jobs sets.Set[string]) error {
+	change, err := c.gc.GetChange(instance, strconv.Itoa(patchSet.ChangeNumber), "CURRENT_REVISION", "CURRENT_COMMIT", "CURRENT_FILES")
+	if err != nil {
+		return err
+	}
+	// Checks of merged and abandoned changes or of outdated patch sets are
+	// not worth running.
+	if change.Status != client.New || change.Revisions[change.CurrentRevision].Number != patchSet.PatchSetID {
+		return nil
+	}
+
+	rerun := sets.New[string]()
+	for _, job := range sets.List(jobs) {
+		check, err := c.gc.GetCheck(instance, change.ID, change.CurrentRevision, checks.CheckerUUID(job))
+		if err != nil {
+			log.WithError(err).WithField("job", job).Warn("Failed to get check.")
+			continue
+		}
+		if check.Created != nil && !check.Created.IsZero() {
+			rerun.Insert(job)
+		}
+	}
+	if rerun.Len() == 0 {
+		return nil
+	}
+
+	cloneURI := source.CloneURIFromOrgRepo(instance, change.Project)
+	baseSHA, err := c.gc.GetBranchRevision(instance, change.Project, change.Branch)
+	if err != nil {
+		return fmt.Errorf("GetBranchRevision: %w", err)
+	}
+	presubmits, err := c.inRepoConfigGetter.GetPresubmits(cloneURI, change.Branch, func() (string, error) { return baseSHA, nil }, func() (string, error) { return change.CurrentRevision, nil })
+	if err != nil {
+		return fmt.Errorf("failed to get presubmits: %w", err)
+	}
+	refs, err := CreateRefs(instance, change.Project, change.Branch, baseSHA, *change)
+	if err != nil {
+		return fmt.Errorf("createRefs from %s at %s: %w", cloneURI, baseSHA, err)
+	}
+
+	schedulerEnabled := c.config().Scheduler.Enabled
+	for _, presubmit := range presubmits {
+		if !rerun.Has(presubmit.Name) {
+			continue
+		}
+		rerun.Delete(presubmit.Name)
+		labels, annotations := LabelsAndAnnotations(instance, presubmit.Labels, presubmit.Annotations, *change)
+		pj := pjutil.NewProwJob(pjutil.PresubmitSpec(presubmit, refs), labels, annotations, pjutil.RequireScheduling(schedulerEnabled))
+		logger := log.WithFields(logrus.Fields{"job": presubmit.Name, "prowjob": pj.Name})
+		if _, err := c.prowJobClient.Create(context.TODO(), &pj, metav1.CreateOptions{}); err != nil {
+			logger.WithError(err).Error("Failed to create ProwJob")
+			continue
+		}
+		logger.Info("Triggered job for re-run check")
+		// Move the check out of the pending state right away, so that the
+		// job isn't triggered again before crier reports it.
+		if err := c.updateCheck(instance, checks, *change, presubmit.Name, client.CheckScheduled, checkRerunMessage); err != nil {
+			logger.WithError(err).Warn("Failed to mark check as scheduled.")
+		}
+	}
+	for _, job := range sets.List(rerun) {
+		if err := c.updateCheck(instance, checks, *change, job, client.CheckNotRelevant, checkUnknownJobMessage); err != nil {
+			log.WithError(err).WithField("job", job).Warn("Failed to mark check as not relevant.")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"testing"
+
+	"github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	clienttesting "k8s.io/client-go/testing"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowfake "sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
+)
+
+type fakeInRepoConfigGetter struct {
+	presubmits []config.Presubmit
+}
+
+func (f *fakeInRepoConfigGetter) GetInRepoConfig(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) (*config.ProwYAML, error) {
+	return &config.ProwYAML{Presubmits: f.presubmits}, nil
+}
+
+func (f *fakeInRepoConfigGetter) GetPresubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Presubmit, error) {
+	return f.presubmits, nil
+}
+
+func (f *fakeInRepoConfigGetter) GetPostsubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Postsubmit, error) {
+	return nil, nil
+}
+
+func TestProcessPendingChecks(t *testing.T) {
+	instance := "https://gerrit"
+	presubmits := []config.Presubmit{
+		{JobBase: config.JobBase{Name: "unit"}, AlwaysRun: true},
+		{JobBase: config.JobBase{Name: "e2e"}},
+	}
+	openChange := &gerrit.ChangeInfo{
+		ID:              "repo~master~I1",
+		Number:          1,
+		Project:         "repo",
+		Branch:          "master",
+		Status:          client.New,
+		CurrentRevision: "abc",
+		Revisions:       map[string]gerrit.RevisionInfo{"abc": {Number: 2, Ref: "refs/changes/01/1/2"}},
+	}
+	reported := &gerrit.Timestamp{Time: timeNow}
+	pending := func(patchSet int, uuids ...string) []client.PendingChecksInfo {
+		checks := map[string]client.PendingCheckInfo{}
+		for _, uuid := range uuids {
+			checks[uuid] = client.PendingCheckInfo{State: client.CheckNotStarted}
+		}
+		return []client.PendingChecksInfo{{
+			PatchSet:      client.CheckablePatchSetInfo{Repository: "repo", ChangeNumber: 1, PatchSetID: patchSet},
+			PendingChecks: checks,
+		}}
+	}
+
+	testCases := []struct {
+		name          string
+		pending       []client.PendingChecksInfo
+		checks        map[string]*client.CheckInfo
+		expectedJobs  []string
+		expectedCheck []client.CheckInput
+	}{
+		{
+			name:    "re-run checks trigger their jobs",
+			pending: pending(2, "prow:unit", "prow:e2e"),
+			checks: map[string]*client.CheckInfo{
+				"prow:unit": {State: client.CheckNotStarted, Created: reported},
+				"prow:e2e":  {State: client.CheckNotStarted, Created: reported},
+			},
+			expectedJobs: []string{"unit", "e2e"},
+			expectedCheck: []client.CheckInput{
+				{CheckerUUID: "prow:unit", State: client.CheckScheduled, Message: checkRerunMessage},
+				{CheckerUUID: "prow:e2e", State: client.CheckScheduled, Message: checkRerunMessage},
+			},
+		},
+		{
+			name:    "checks that were never reported are ignored",
+			pending: pending(2, "prow:unit", "prow:e2e"),
+			checks: map[string]*client.CheckInfo{
+				"prow:unit": {State: client.CheckNotStarted, Created: reported},
+			},
+			expectedJobs: []string{"unit"},
+			expectedCheck: []client.CheckInput{
+				{CheckerUUID: "prow:unit", State: client.CheckScheduled, Message: checkRerunMessage},
+			},
+		},
+		{
+			name:    "checks of other schemes are ignored",
+			pending: pending(2, "other:unit"),
+			checks: map[string]*client.CheckInfo{
+				"other:unit": {State: client.CheckNotStarted, Created: reported},
+			},
+		},
+		{
+			name:    "checks of outdated patch sets are ignored",
+			pending: pending(1, "prow:unit"),
+			checks: map[string]*client.CheckInfo{
+				"prow:unit": {State: client.CheckNotStarted, Created: reported},
+			},
+		},
+		{
+			name:    "checks of unknown jobs are marked as not relevant",
+			pending: pending(2, "prow:removed"),
+			checks: map[string]*client.CheckInfo{
+				"prow:removed": {State: client.CheckNotStarted, Created: reported},
+			},
+			expectedCheck: []client.CheckInput{
+				{CheckerUUID: "prow:removed", State: client.CheckNotRelevant, Message: checkUnknownJobMessage},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeProwJobClient := prowfake.NewSimpleClientset()
+			gc := &fgc{
+				changes:       map[string]*gerrit.ChangeInfo{"1": openChange},
+				checks:        tc.checks,
+				pendingChecks: tc.pending,
+			}
+			cfg := &config.Config{ProwConfig: config.ProwConfig{
+				PodNamespace: "default",
+				Gerrit: config.Gerrit{OrgReposConfig: &config.GerritOrgRepoConfigs{{
+					Org:    instance,
+					Repos:  []string{"repo", "other-repo"},
+					Checks: &config.GerritChecks{Scheme: "prow"},
+				}}},
+			}}
+			c := &Controller{
+				config:             func() *config.Config { return cfg },
+				prowJobClient:      fakeProwJobClient.ProwV1().ProwJobs("default"),
+				gc:                 gc,
+				inRepoConfigGetter: &fakeInRepoConfigGetter{presubmits: presubmits},
+			}
+
+			c.processPendingChecks()
+			if gc.pendingLists != 1 {
+				t.Errorf("expected the pending checks to be listed once, got %d", gc.pendingLists)
+			}
+
+			var jobs []string
+			for _, action := range fakeProwJobClient.Fake.Actions() {
+				if create, ok := action.(clienttesting.CreateActionImpl); ok {
+					pj := create.Object.(*prowapi.ProwJob)
+					jobs = append(jobs, pj.Spec.Job)
+					if pj.Spec.Type != prowapi.PresubmitJob || pj.Spec.Refs.Pulls[0].SHA != "abc" {
+						t.Errorf("expected a presubmit for the current revision, got: %+v", pj.Spec)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.expectedJobs, jobs); diff != "" {
+				t.Errorf("triggered jobs differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedCheck, gc.checkUpdates); diff != "" {
+				t.Errorf("check updates differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportSkippedChecks(t *testing.T) {
+	presubmits := []config.Presubmit{
+		{JobBase: config.JobBase{Name: "unit"}},
+		{JobBase: config.JobBase{Name: "e2e"}},
+		{JobBase: config.JobBase{Name: "silent"}, Reporter: config.Reporter{SkipReport: true}},
+	}
+	gc := &fgc{}
+	c := &Controller{gc: gc}
+	checks := &config.GerritChecks{Scheme: "prow", CreateCheckers: true}
+	c.reportSkippedChecks(logrus.WithField("test", t.Name()), "https://gerrit", checks, client.ChangeInfo{Project: "repo"}, presubmits, presubmits[:1])
+
+	expected := []client.CheckInput{{CheckerUUID: "prow:e2e", State: client.CheckNotRelevant, Message: checkNotRunMessage}}
+	if diff := cmp.Diff(expected, gc.checkUpdates); diff != "" {
+		t.Errorf("check updates differ from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"prow:e2e"}, gc.checkers); diff != "" {
+		t.Errorf("ensured checkers differ from expected (-want +got):\n%s", diff)
+	}
+}
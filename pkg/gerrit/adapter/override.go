@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andygrunwald/go-gerrit"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+var (
+	// overrideRe matches `/override job-name` commands, like the override
+	// plugin does on GitHub.
+	overrideRe = regexp.MustCompile(`(?mi)^/override( ([^\r\n]+))?[\r\n]?$`)
+	// testRe matches `/test` commands naming jobs.
+	testRe = regexp.MustCompile(`(?m)^/test\s+\S`)
+)
+
+// handleOverrides forces the presubmits named in `/override` commands of
+// authorized users to pass, by creating successful ProwJobs for them that
+// crier reports like any other job.
+func (c *Controller) handleOverrides(logger logrus.FieldLogger, instance string, change client.ChangeInfo, presubmits []config.Presubmit, messages []gerrit.ChangeMessageInfo, refs prowapi.Refs) error {
+	allowed := c.config().Gerrit.OrgReposConfig.OverrideAllowedUsers(instance, change.Project)
+	jobs := map[string]config.Presubmit{}
+	for _, presubmit := range presubmits {
+		jobs[presubmit.Name] = presubmit
+	}
+
+	var errs []error
+	reply := func(message string) {
+		if err := c.gc.SetReview(instance, change.ID, change.CurrentRevision, message, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, message := range messages {
+		matches := overrideRe.FindAllStringSubmatch(message.Message, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		user := message.Author.Username
+		if user == "" {
+			user = message.Author.Email
+		}
+		log := logger.WithField("user", user)
+		if allowed.Len() == 0 {
+			log.Debug("Ignoring /override, it is not enabled for the repo.")
+			continue
+		}
+		if !allowed.Has(message.Author.Username) && !allowed.Has(message.Author.Email) {
+			log.Info("Unauthorized /override.")
+			reply(fmt.Sprintf("%s is not allowed to override jobs, only the following users are: %s", user, strings.Join(sets.List(allowed), ", ")))
+			continue
+		}
+
+		overrides := sets.New[string]()
+		for _, match := range matches {
+			overrides.Insert(strings.Fields(match[2])...)
+		}
+		if overrides.Len() == 0 {
+			reply("Overriding jobs requires the names of the jobs to override, but none were given.")
+			continue
+		}
+		if unknown := overrides.Difference(sets.KeySet(jobs)); unknown.Len() > 0 {
+			reply(fmt.Sprintf("Cannot override unknown jobs: %s\n\nThe following jobs can be overridden: %s", strings.Join(sets.List(unknown), ", "), strings.Join(sets.List(sets.KeySet(jobs)), ", ")))
+			continue
+		}
+
+		var overridden []string
+		for _, name := range sets.List(overrides) {
+			presubmit := jobs[name]
+			labels, annotations := LabelsAndAnnotations(instance, presubmit.Labels, presubmit.Annotations, change)
+			pj := pjutil.NewProwJob(pjutil.PresubmitSpec(presubmit, refs), labels, annotations)
+			now := metav1.Now()
+			pj.Status = prowapi.ProwJobStatus{
+				StartTime:      now,
+				CompletionTime: &now,
+				State:          prowapi.SuccessState,
+				Description:    fmt.Sprintf("Overridden by %s", user),
+			}
+			if len(refs.Pulls) == 1 {
+				pj.Status.URL = refs.Pulls[0].Link
+			}
+			if _, err := c.prowJobClient.Create(context.TODO(), &pj, metav1.CreateOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create override job for %s: %w", name, err))
+				continue
+			}
+			log.WithField("job", name).Info("Overrode job.")
+			overridden = append(overridden, name)
+		}
+		if len(overridden) > 0 {
+			reply(fmt.Sprintf("Overrode jobs on behalf of %s: %s", user, strings.Join(overridden, ", ")))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"testing"
+
+	"github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	clienttesting "k8s.io/client-go/testing"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowfake "sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestHandleOverrides(t *testing.T) {
+	instance := "https://gerrit"
+	presubmits := []config.Presubmit{
+		{JobBase: config.JobBase{Name: "unit"}},
+		{JobBase: config.JobBase{Name: "e2e"}},
+	}
+	change := client.ChangeInfo{
+		ID:              "repo~master~I1",
+		Number:          1,
+		Project:         "repo",
+		CurrentRevision: "abc",
+		Revisions:       map[string]gerrit.RevisionInfo{"abc": {Number: 1}},
+	}
+	refs := prowapi.Refs{Org: instance, Repo: "repo", Pulls: []prowapi.Pull{{Number: 1, SHA: "abc", Link: "https://gerrit/c/repo/+/1"}}}
+	message := func(username, text string) gerrit.ChangeMessageInfo {
+		return gerrit.ChangeMessageInfo{Author: gerrit.AccountInfo{Username: username}, Message: "Patch Set 1:\n\n" + text, RevisionNumber: 1}
+	}
+
+	testCases := []struct {
+		name            string
+		allowedUsers    []string
+		messages        []gerrit.ChangeMessageInfo
+		expectedJobs    []string
+		expectedReviews int
+	}{
+		{
+			name:            "authorized user overrides jobs",
+			allowedUsers:    []string{"alice"},
+			messages:        []gerrit.ChangeMessageInfo{message("alice", "/override unit e2e")},
+			expectedJobs:    []string{"e2e", "unit"},
+			expectedReviews: 1,
+		},
+		{
+			name:         "authorized by email",
+			allowedUsers: []string{"alice@example.com"},
+			messages: []gerrit.ChangeMessageInfo{{
+				Author:  gerrit.AccountInfo{Email: "alice@example.com"},
+				Message: "/override unit",
+			}},
+			expectedJobs:    []string{"unit"},
+			expectedReviews: 1,
+		},
+		{
+			name:            "unauthorized user",
+			allowedUsers:    []string{"alice"},
+			messages:        []gerrit.ChangeMessageInfo{message("bob", "/override unit")},
+			expectedReviews: 1,
+		},
+		{
+			name:     "disabled without allowed users",
+			messages: []gerrit.ChangeMessageInfo{message("alice", "/override unit")},
+		},
+		{
+			name:            "unknown jobs",
+			allowedUsers:    []string{"alice"},
+			messages:        []gerrit.ChangeMessageInfo{message("alice", "/override unit lint")},
+			expectedReviews: 1,
+		},
+		{
+			name:            "missing jobs",
+			allowedUsers:    []string{"alice"},
+			messages:        []gerrit.ChangeMessageInfo{message("alice", "/override")},
+			expectedReviews: 1,
+		},
+		{
+			name:         "other commands",
+			allowedUsers: []string{"alice"},
+			messages:     []gerrit.ChangeMessageInfo{message("alice", "/test unit")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeProwJobClient := prowfake.NewSimpleClientset()
+			gc := &fgc{}
+			cfg := &config.Config{ProwConfig: config.ProwConfig{
+				PodNamespace: "default",
+				Gerrit: config.Gerrit{OrgReposConfig: &config.GerritOrgRepoConfigs{{
+					Org:                  instance,
+					Repos:                []string{"repo"},
+					OverrideAllowedUsers: tc.allowedUsers,
+				}}},
+			}}
+			c := &Controller{
+				config:        func() *config.Config { return cfg },
+				prowJobClient: fakeProwJobClient.ProwV1().ProwJobs("default"),
+				gc:            gc,
+			}
+
+			if err := c.handleOverrides(logrus.WithField("test", t.Name()), instance, change, presubmits, tc.messages, refs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var jobs []string
+			for _, action := range fakeProwJobClient.Fake.Actions() {
+				if create, ok := action.(clienttesting.CreateActionImpl); ok {
+					pj := create.Object.(*prowapi.ProwJob)
+					jobs = append(jobs, pj.Spec.Job)
+					if pj.Status.State != prowapi.SuccessState || pj.Status.CompletionTime == nil {
+						t.Errorf("expected override job %s to be successful, got: %+v", pj.Spec.Job, pj.Status)
+					}
+					if pj.Labels[kube.GerritRevision] != "abc" || pj.Annotations[kube.GerritID] != change.ID {
+						t.Errorf("expected override job %s to be reported to the change, got labels %v and annotations %v", pj.Spec.Job, pj.Labels, pj.Annotations)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.expectedJobs, jobs); diff != "" {
+				t.Errorf("overridden jobs differ from expected (-want +got):\n%s", diff)
+			}
+			if gc.reviews != tc.expectedReviews {
+				t.Errorf("expected %d reviews, got %d", tc.expectedReviews, gc.reviews)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	gerrit "github.com/andygrunwald/go-gerrit"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// States of checks of the Gerrit Checks plugin, see
+// https://gerrit.googlesource.com/plugins/checks/+/master/resources/Documentation/rest-api-checks.md#check-info
+const (
+	CheckNotStarted  = "NOT_STARTED"
+	CheckScheduled   = "SCHEDULED"
+	CheckRunning     = "RUNNING"
+	CheckSuccessful  = "SUCCESSFUL"
+	CheckFailed      = "FAILED"
+	CheckNotRelevant = "NOT_RELEVANT"
+)
+
+// CheckInfo is a check on a revision of a change.
+type CheckInfo struct {
+	Repository   string            `json:"repository"`
+	ChangeNumber int               `json:"change_number"`
+	PatchSetID   int               `json:"patch_set_id"`
+	CheckerUUID  string            `json:"checker_uuid"`
+	State        string            `json:"state"`
+	Message      string            `json:"message,omitempty"`
+	URL          string            `json:"url,omitempty"`
+	Started      *gerrit.Timestamp `json:"started,omitempty"`
+	Finished     *gerrit.Timestamp `json:"finished,omitempty"`
+	// Created is unset for checks that were never posted, which Gerrit
+	// backfills with the NOT_STARTED state for all checkers of a repo.
+	Created *gerrit.Timestamp `json:"created,omitempty"`
+	Updated *gerrit.Timestamp `json:"updated,omitempty"`
+}
+
+// CheckInput creates or updates a check on a revision of a change.
+type CheckInput struct {
+	CheckerUUID string            `json:"checker_uuid"`
+	State       string            `json:"state,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Started     *gerrit.Timestamp `json:"started,omitempty"`
+	Finished    *gerrit.Timestamp `json:"finished,omitempty"`
+}
+
+// CheckerInput creates a checker.
+type CheckerInput struct {
+	UUID        string   `json:"uuid"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Repository  string   `json:"repository"`
+	Blocking    []string `json:"blocking,omitempty"`
+}
+
+// PendingChecksInfo lists the pending checks of a patch set.
+type PendingChecksInfo struct {
+	PatchSet      CheckablePatchSetInfo       `json:"patch_set"`
+	PendingChecks map[string]PendingCheckInfo `json:"pending_checks"`
+}
+
+// CheckablePatchSetInfo identifies a patch set with pending checks.
+type CheckablePatchSetInfo struct {
+	Repository   string `json:"repository"`
+	ChangeNumber int    `json:"change_number"`
+	PatchSetID   int    `json:"patch_set_id"`
+}
+
+// PendingCheckInfo is the state of a pending check.
+type PendingCheckInfo struct {
+	State string `json:"state"`
+}
+
+// CheckerForJob returns the checker the job reports to.
+func CheckerForJob(checks *config.GerritChecks, repo, job string) CheckerInput {
+	checker := CheckerInput{
+		UUID:        checks.CheckerUUID(job),
+		Name:        job,
+		Description: fmt.Sprintf("Prow job %s", job),
+		Repository:  repo,
+	}
+	if checks.Blocking {
+		checker.Blocking = []string{"STATE_NOT_PASSING"}
+	}
+	return checker
+}
+
+type gerritChecks interface {
+	GetChecker(uuid string) (*gerrit.Response, error)
+	CreateChecker(input *CheckerInput) (*gerrit.Response, error)
+	GetCheck(changeID, revisionID, checkerUUID string) (*CheckInfo, *gerrit.Response, error)
+	UpdateCheck(changeID, revisionID string, input *CheckInput) (*gerrit.Response, error)
+	ListPendingChecks(scheme string, states ...string) ([]PendingChecksInfo, *gerrit.Response, error)
+}
+
+// checksService calls the REST API of the Gerrit Checks plugin, which the
+// go-gerrit client doesn't cover.
+type checksService struct {
+	client *gerrit.Client
+}
+
+func (s *checksService) GetChecker(uuid string) (*gerrit.Response, error) {
+	return s.client.Call(http.MethodGet, "plugins/checks/checkers/"+url.PathEscape(uuid), nil, nil)
+}
+
+func (s *checksService) CreateChecker(input *CheckerInput) (*gerrit.Response, error) {
+	return s.client.Call(http.MethodPost, "plugins/checks/checkers/", input, nil)
+}
+
+func (s *checksService) GetCheck(changeID, revisionID, checkerUUID string) (*CheckInfo, *gerrit.Response, error) {
+	var check CheckInfo
+	resp, err := s.client.Call(http.MethodGet, fmt.Sprintf("changes/%s/revisions/%s/checks/%s", url.PathEscape(changeID), url.PathEscape(revisionID), url.PathEscape(checkerUUID)), nil, &check)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &check, resp, nil
+}
+
+func (s *checksService) UpdateCheck(changeID, revisionID string, input *CheckInput) (*gerrit.Response, error) {
+	return s.client.Call(http.MethodPost, fmt.Sprintf("changes/%s/revisions/%s/checks/", url.PathEscape(changeID), url.PathEscape(revisionID)), input, nil)
+}
+
+func (s *checksService) ListPendingChecks(scheme string, states ...string) ([]PendingChecksInfo, *gerrit.Response, error) {
+	query := url.Values{"scheme": []string{scheme}}
+	for _, state := range states {
+		query.Add("state", state)
+	}
+	var pending []PendingChecksInfo
+	resp, err := s.client.Call(http.MethodGet, "plugins/checks/checks.pending/?"+query.Encode(), nil, &pending)
+	if err != nil {
+		return nil, resp, err
+	}
+	return pending, resp, nil
+}
+
+// EnsureChecker creates the checker unless it exists already.
+func (c *Client) EnsureChecker(instance string, input CheckerInput) error {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	h.checkersLock.Lock()
+	defer h.checkersLock.Unlock()
+	if h.checkers.Has(input.UUID) {
+		return nil
+	}
+	resp, err := h.checksService.GetChecker(input.UUID)
+	switch {
+	case err == nil:
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		if resp, err := h.checksService.CreateChecker(&input); err != nil {
+			return fmt.Errorf("error creating checker %s: %w", input.UUID, responseBodyError(err, resp))
+		}
+	default:
+		return fmt.Errorf("error getting checker %s: %w", input.UUID, responseBodyError(err, resp))
+	}
+	h.checkers.Insert(input.UUID)
+	return nil
+}
+
+// GetCheck returns the check of the checker on a revision. Gerrit returns a
+// NOT_STARTED check without a creation time for checkers that were never
+// reported to.
+func (c *Client) GetCheck(instance, id, revision, checkerUUID string) (*CheckInfo, error) {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	check, resp, err := h.checksService.GetCheck(id, revision, checkerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting check %s: %w", checkerUUID, responseBodyError(err, resp))
+	}
+	return check, nil
+}
+
+// UpdateCheck creates or updates a check on a revision.
+func (c *Client) UpdateCheck(instance, id, revision string, input CheckInput) error {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	if resp, err := h.checksService.UpdateCheck(id, revision, &input); err != nil {
+		return fmt.Errorf("error updating check %s: %w", input.CheckerUUID, responseBodyError(err, resp))
+	}
+	return nil
+}
+
+// PendingChecks lists the checks of all checkers of the scheme that wait to
+// be run, that is checks in the NOT_STARTED state.
+func (c *Client) PendingChecks(instance, scheme string) ([]PendingChecksInfo, error) {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	pending, resp, err := h.checksService.ListPendingChecks(scheme, CheckNotStarted)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pending checks: %w", responseBodyError(err, resp))
+	}
+	return pending, nil
+}
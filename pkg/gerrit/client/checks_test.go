@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	gerrit "github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type fakeChecks struct {
+	checkers sets.Set[string]
+	calls    []string
+}
+
+func (f *fakeChecks) GetChecker(uuid string) (*gerrit.Response, error) {
+	f.calls = append(f.calls, "get "+uuid)
+	if f.checkers.Has(uuid) {
+		return &gerrit.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+	}
+	return &gerrit.Response{Response: &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}}, errors.New("not found")
+}
+
+func (f *fakeChecks) CreateChecker(input *CheckerInput) (*gerrit.Response, error) {
+	f.calls = append(f.calls, "create "+input.UUID)
+	f.checkers.Insert(input.UUID)
+	return nil, nil
+}
+
+func (f *fakeChecks) GetCheck(changeID, revisionID, checkerUUID string) (*CheckInfo, *gerrit.Response, error) {
+	return nil, nil, nil
+}
+
+func (f *fakeChecks) UpdateCheck(changeID, revisionID string, input *CheckInput) (*gerrit.Response, error) {
+	return nil, nil
+}
+
+func (f *fakeChecks) ListPendingChecks(scheme string, states ...string) ([]PendingChecksInfo, *gerrit.Response, error) {
+	return nil, nil, nil
+}
+
+func TestEnsureChecker(t *testing.T) {
+	checks := &fakeChecks{checkers: sets.New[string]("prow:existing")}
+	c := &Client{handlers: map[string]*gerritInstanceHandler{
+		"gerrit": {checksService: checks, checkers: sets.New[string]()},
+	}}
+	for _, uuid := range []string{"prow:existing", "prow:new", "prow:existing", "prow:new"} {
+		if err := c.EnsureChecker("gerrit", CheckerInput{UUID: uuid, Repository: "repo"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	expected := []string{"get prow:existing", "get prow:new", "create prow:new"}
+	if diff := cmp.Diff(expected, checks.calls); diff != "" {
+		t.Errorf("calls differ from expected (-want +got):\n%s", diff)
+	}
+	if err := c.EnsureChecker("unknown", CheckerInput{UUID: "prow:new"}); err == nil {
+		t.Error("expected an error for an unknown instance")
+	}
+}
@@ -114,6 +114,11 @@ type gerritInstanceHandler struct {
 	changeService   gerritChange
 	projectService  gerritProjects
 	revisionService gerritRevision
+	checksService   gerritChecks
+
+	// checkers caches the UUIDs of checkers known to exist.
+	checkers     sets.Set[string]
+	checkersLock sync.Mutex
 
 	log logrus.FieldLogger
 }
@@ -328,6 +333,8 @@ func (c *Client) newInstanceHandler(instance string, projects map[string]*config
 		accountService: gc.Accounts,
 		changeService:  gc.Changes,
 		projectService: gc.Projects,
+		checksService:  &checksService{client: gc},
+		checkers:       sets.New[string](),
 		log:            logrus.WithField("host", instance),
 	}, nil
 }
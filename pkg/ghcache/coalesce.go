@@ -19,6 +19,7 @@ package ghcache
 import (
 	"bufio"
 	"bytes"
+	"io"
 	"net/http"
 	"net/http/httputil"
 	"strconv"
@@ -51,6 +52,10 @@ type requestCoalescer struct {
 	requestExecutor http.RoundTripper
 
 	hasher ghmetrics.Hasher
+
+	// graphQLCache holds responses to GraphQL queries. It is nil if GraphQL
+	// responses should not be cached.
+	graphQLCache *graphQLCache
 }
 
 // firstRequest is where we store the coalesced requests's actual response. It
@@ -73,14 +78,17 @@ type firstRequest struct {
 
 // RoundTrip coalesces concurrent GET requests for the same URI by blocking
 // the later requests until the first request returns and then sharing the
-// response between all requests.
+// response between all requests. GraphQL queries are coalesced the same way
+// when their query and variables match, and are served from the GraphQL
+// cache while a previous response is still fresh.
 //
 // Notes: Deadlock shouldn't be possible because the map lock is always
 // acquired before firstRequest lock if both locks are to be held and we
 // never hold multiple firstRequest locks.
 func (coalescer *requestCoalescer) RoundTrip(req *http.Request) (*http.Response, error) {
-	// Only coalesce GET requests
-	if req.Method != http.MethodGet {
+	// Only coalesce GET requests and GraphQL queries
+	key, gql := coalescingKey(req)
+	if key == "" {
 		resp, err := coalescer.requestExecutor.RoundTrip(req)
 		var tokenBudgetName string
 		if val := req.Header.Get(TokenBudgetIdentifierHeader); val != "" {
@@ -94,7 +102,21 @@ func (coalescer *requestCoalescer) RoundTrip(req *http.Request) (*http.Response,
 
 	var cacheMode = ModeError
 	resp, err := func() (*http.Response, error) {
-		key := req.URL.String()
+		if gql != nil && coalescer.graphQLCache != nil {
+			if cached, ok := coalescer.graphQLCache.get(key); ok {
+				// As for coalesced requests below, the request is never sent
+				// so we must close its body ourselves.
+				req.Body.Close()
+				resp, err := http.ReadResponse(bufio.NewReader(bytes.NewBuffer(cached)), nil)
+				if err != nil {
+					logrus.WithField("cache-key", key).WithError(err).Error("Error loading cached GraphQL response.")
+					return nil, err
+				}
+				cacheMode = ModeFresh
+				return resp, nil
+			}
+		}
+
 		coalescer.Lock()
 		firstReq, ok := coalescer.cache[key]
 		// Note that we cannot immediately Unlock() coalescer here just after
@@ -240,6 +262,9 @@ func (coalescer *requestCoalescer) RoundTrip(req *http.Request) (*http.Response,
 		// is what cacheResponseMode() does, unless there are other modes we can
 		// glean from the response header, find it with cacheResponseMode.
 		cacheMode = cacheResponseMode(resp.Header)
+		if gql != nil && coalescer.graphQLCache != nil && coalescer.cacheGraphQLResponse(key, resp) {
+			cacheMode = ModeMiss
+		}
 
 		return resp, nil
 	}()
@@ -255,6 +280,53 @@ func (coalescer *requestCoalescer) RoundTrip(req *http.Request) (*http.Response,
 	return resp, err
 }
 
+// coalescingKey determines the key under which the request is coalesced. GET
+// requests are identified by their URL and GraphQL queries by their query and
+// variables. An empty key means the request must not be coalesced.
+func coalescingKey(req *http.Request) (string, *graphQLRequest) {
+	switch {
+	case req.Method == http.MethodGet:
+		return req.URL.String(), nil
+	case req.Method == http.MethodPost && isGraphQLPath(req.URL.Path):
+		gql, err := parseGraphQLRequest(req)
+		if err != nil {
+			logrus.WithField("path", req.URL.Path).WithError(err).Debug("Not coalescing unparseable GraphQL request.")
+			return "", nil
+		}
+		if !gql.isQuery() {
+			return "", nil
+		}
+		return gql.key(), gql
+	}
+	return "", nil
+}
+
+// cacheGraphQLResponse stores a successful GraphQL response in the GraphQL
+// cache and reports whether it did so. Responses with errors are not cached
+// as they may be caused by transient problems.
+func (coalescer *requestCoalescer) cacheGraphQLResponse(key string, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		logrus.WithField("cache-key", key).WithError(err).Warn("Error reading GraphQL response.")
+		return false
+	}
+	if _, hasErrors := ghmetrics.GraphQLCost(body); hasErrors {
+		return false
+	}
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		logrus.WithField("cache-key", key).WithError(err).Warn("Error storing GraphQL response.")
+		return false
+	}
+	coalescer.graphQLCache.set(key, dump)
+	return true
+}
+
 func collectMetrics(cacheMode CacheResponseMode, req *http.Request, resp *http.Response, tokenBudgetName string) {
 	ghmetrics.CollectCacheRequestMetrics(string(cacheMode), req.URL.Path, req.Header.Get("User-Agent"), tokenBudgetName)
 	if resp != nil {
@@ -22,13 +22,17 @@ limitations under the License.
 // because conditional requests for unchanged resources don't cost any API
 // tokens!!! See: https://developer.github.com/v3/#conditional-requests
 //
-// It also provides request coalescing and prometheus instrumentation.
+// It also provides request coalescing and prometheus instrumentation, and
+// understands enough GraphQL to coalesce identical queries and account for
+// their rate limit cost.
 package ghcache
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path"
@@ -67,6 +71,7 @@ const (
 	// free (no API tokens used).
 	ModeCoalesced   CacheResponseMode = "COALESCED"   // coalesced request, this is a copied response
 	ModeRevalidated CacheResponseMode = "REVALIDATED" // cached value revalidated and returned
+	ModeFresh       CacheResponseMode = "FRESH"       // cached GraphQL response returned within its TTL
 
 	// cacheEntryCreationDateHeader contains the creation date of the cache entry
 	cacheEntryCreationDateHeader = "X-PROW-REQUEST-DATE"
@@ -126,6 +131,8 @@ func CacheModeIsFree(mode CacheResponseMode) bool {
 		return true
 	case ModeRevalidated:
 		return true
+	case ModeFresh:
+		return true
 	case ModeError:
 		// In this case we did not successfully communicate with the GH API, so no
 		// token is used, but we also don't return a response, so ModeError won't
@@ -292,7 +299,7 @@ func (c *throttlingTransport) holdRequest(req *http.Request) {
 	tokenBudgetName := c.getTokenBudgetName(req)
 	getReq := req.Method == http.MethodGet
 	var duration time.Duration
-	if strings.HasPrefix(req.URL.Path, "graphql") || strings.HasPrefix(req.URL.Path, "/graphql") {
+	if isGraphQLPath(req.URL.Path) {
 		duration = c.registryApiV4.getRequestWaitDuration(tokenBudgetName, getReq)
 		ghmetrics.CollectGitHubRequestWaitDurationMetrics(tokenBudgetName, req.Method, apiV4, duration)
 	} else {
@@ -342,6 +349,13 @@ type upstreamTransport struct {
 
 func (u upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	etag := req.Header.Get("if-none-match")
+	var gql *graphQLRequest
+	if req.Method == http.MethodPost && isGraphQLPath(req.URL.Path) {
+		var err error
+		if gql, err = parseGraphQLRequest(req); err != nil {
+			logrus.WithField("path", req.URL.Path).WithError(err).Debug("Not accounting for cost of unparseable GraphQL request.")
+		}
+	}
 	var tokenBudgetName string
 	if val := req.Header.Get(TokenBudgetIdentifierHeader); val != "" {
 		tokenBudgetName = val
@@ -375,9 +389,12 @@ func (u upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	}
 
 	apiVersion := apiV3
-	if strings.HasPrefix(req.URL.Path, "graphql") || strings.HasPrefix(req.URL.Path, "/graphql") {
+	if isGraphQLPath(req.URL.Path) {
 		resp.Header.Set("Cache-Control", "no-store")
 		apiVersion = apiV4
+		if gql != nil {
+			collectGraphQLCostMetrics(gql, resp, tokenBudgetName, req.Header.Get("User-Agent"))
+		}
 	}
 
 	ghmetrics.CollectGitHubTokenMetrics(tokenBudgetName, apiVersion, resp.Header, reqStartTime, responseTime)
@@ -386,12 +403,28 @@ func (u upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	return resp, nil
 }
 
+// collectGraphQLCostMetrics records the rate limit cost of a GraphQL request
+// that was sent to GitHub.
+func collectGraphQLCostMetrics(gql *graphQLRequest, resp *http.Response, tokenBudgetName, userAgent string) {
+	cost := 1
+	if resp.StatusCode == http.StatusOK && resp.Header.Get("Content-Encoding") == "" {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			logrus.WithField("operation", gql.operation()).WithError(err).Warn("Error reading GraphQL response.")
+		}
+		cost, _ = ghmetrics.GraphQLCost(body)
+	}
+	ghmetrics.CollectGraphQLCostMetrics(tokenBudgetName, userAgent, gql.operation(), cost)
+}
+
 const LogMessageWithDiskPartitionFields = "Not using a partitioned cache because legacyDisablePartitioningByAuthHeader is true"
 
 // NewDiskCache creates a GitHub cache RoundTripper that is backed by a disk
 // cache.
 // It supports a partitioned cache.
-func NewDiskCache(roundTripper http.RoundTripper, cacheDir string, cacheSizeGB, maxConcurrency int, legacyDisablePartitioningByAuthHeader bool, cachePruneInterval time.Duration, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+func NewDiskCache(roundTripper http.RoundTripper, cacheDir string, cacheSizeGB, maxConcurrency int, legacyDisablePartitioningByAuthHeader bool, cachePruneInterval time.Duration, throttlingTimes RequestThrottlingTimes, graphQLCacheTTL time.Duration) http.RoundTripper {
 	if legacyDisablePartitioningByAuthHeader {
 		diskCache := diskcache.NewWithDiskv(
 			diskv.New(diskv.Options{
@@ -408,6 +441,7 @@ func NewDiskCache(roundTripper http.RoundTripper, cacheDir string, cacheSizeGB,
 			},
 			maxConcurrency,
 			throttlingTimes,
+			graphQLCacheTTL,
 		)
 	}
 
@@ -432,6 +466,7 @@ func NewDiskCache(roundTripper http.RoundTripper, cacheDir string, cacheSizeGB,
 		},
 		maxConcurrency,
 		throttlingTimes,
+		graphQLCacheTTL,
 	)
 }
 
@@ -507,11 +542,12 @@ type cachePartitionMetadata struct {
 // NewMemCache creates a GitHub cache RoundTripper that is backed by a memory
 // cache.
 // It supports a partitioned cache.
-func NewMemCache(roundTripper http.RoundTripper, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+func NewMemCache(roundTripper http.RoundTripper, maxConcurrency int, throttlingTimes RequestThrottlingTimes, graphQLCacheTTL time.Duration) http.RoundTripper {
 	return NewFromCache(roundTripper,
 		func(_ string, _ *time.Time) httpcache.Cache { return httpcache.NewMemoryCache() },
 		maxConcurrency,
-		throttlingTimes)
+		throttlingTimes,
+		graphQLCacheTTL)
 }
 
 // CachePartitionCreator creates a new cache partition using the given key
@@ -519,7 +555,9 @@ type CachePartitionCreator func(partitionKey string, expiresAt *time.Time) httpc
 
 // NewFromCache creates a GitHub cache RoundTripper that is backed by the
 // specified httpcache.Cache implementation.
-func NewFromCache(roundTripper http.RoundTripper, cache CachePartitionCreator, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+// Responses to GraphQL queries are kept in memory for graphQLCacheTTL, which
+// disables caching them if zero.
+func NewFromCache(roundTripper http.RoundTripper, cache CachePartitionCreator, maxConcurrency int, throttlingTimes RequestThrottlingTimes, graphQLCacheTTL time.Duration) http.RoundTripper {
 	hasher := ghmetrics.NewCachingHasher()
 	return newPartitioningRoundTripper(func(partitionKey string, expiresAt *time.Time) http.RoundTripper {
 		cacheTransport := httpcache.NewTransport(cache(partitionKey, expiresAt))
@@ -528,6 +566,7 @@ func NewFromCache(roundTripper http.RoundTripper, cache CachePartitionCreator, m
 			cache:           make(map[string]*firstRequest),
 			requestExecutor: cacheTransport,
 			hasher:          hasher,
+			graphQLCache:    newGraphQLCache(graphQLCacheTTL),
 		}
 	})
 }
@@ -537,7 +576,7 @@ func NewFromCache(roundTripper http.RoundTripper, cache CachePartitionCreator, m
 // Important note: The redis implementation does not support partitioning the cache
 // which means that requests to the same path from different tokens will invalidate
 // each other.
-func NewRedisCache(roundTripper http.RoundTripper, redisAddress string, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+func NewRedisCache(roundTripper http.RoundTripper, redisAddress string, maxConcurrency int, throttlingTimes RequestThrottlingTimes, graphQLCacheTTL time.Duration) http.RoundTripper {
 	conn, err := redis.Dial("tcp", redisAddress)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error connecting to Redis")
@@ -546,5 +585,6 @@ func NewRedisCache(roundTripper http.RoundTripper, redisAddress string, maxConcu
 	return NewFromCache(roundTripper,
 		func(_ string, _ *time.Time) httpcache.Cache { return redisCache },
 		maxConcurrency,
-		throttlingTimes)
+		throttlingTimes,
+		graphQLCacheTTL)
 }
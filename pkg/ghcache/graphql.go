@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// graphQLRequest is the body of a request to the GitHub GraphQL API.
+type graphQLRequest struct {
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	OperationName string          `json:"operationName,omitempty"`
+}
+
+var (
+	graphQLComment       = regexp.MustCompile(`#[^\n]*`)
+	graphQLOperationName = regexp.MustCompile(`^(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+	graphQLUnsafe        = regexp.MustCompile(`\b(?:mutation|subscription)\b`)
+)
+
+func isGraphQLPath(path string) bool {
+	return strings.HasPrefix(path, "graphql") || strings.HasPrefix(path, "/graphql")
+}
+
+// parseGraphQLRequest reads the GraphQL request from the body of req. The
+// body is restored so that req can still be sent upstream.
+func parseGraphQLRequest(req *http.Request) (*graphQLRequest, error) {
+	if req.Body == nil {
+		return nil, fmt.Errorf("request has no body")
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	var gql graphQLRequest
+	if err := json.Unmarshal(body, &gql); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GraphQL request: %w", err)
+	}
+	return &gql, nil
+}
+
+// document returns the query without comments or surrounding whitespace.
+func (g *graphQLRequest) document() string {
+	return strings.TrimSpace(graphQLComment.ReplaceAllString(g.Query, ""))
+}
+
+// isQuery determines whether the request only reads data and can therefore
+// be shared between callers. This errs on the side of caution: documents
+// that mention mutations or subscriptions anywhere are never considered safe.
+func (g *graphQLRequest) isQuery() bool {
+	doc := g.document()
+	if !strings.HasPrefix(doc, "{") && !strings.HasPrefix(doc, "query") {
+		return false
+	}
+	return !graphQLUnsafe.MatchString(doc)
+}
+
+// key identifies the request by its query and variables. Variables are
+// normalized so that clients serializing them in a different order share a
+// key.
+func (g *graphQLRequest) key() string {
+	variables := []byte("null")
+	if len(g.Variables) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(g.Variables, &v); err == nil {
+			variables, _ = json.Marshal(v)
+		} else {
+			variables = g.Variables
+		}
+	}
+	hash := sha256.New()
+	hash.Write([]byte(g.document()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(g.OperationName))
+	hash.Write([]byte{0})
+	hash.Write(variables)
+	return fmt.Sprintf("graphql:%x", hash.Sum(nil))
+}
+
+// operation names the request for metrics. Anonymous queries are identified
+// by a short hash of the query so that the label cardinality is bounded by
+// the number of distinct queries clients send rather than their variables.
+func (g *graphQLRequest) operation() string {
+	if g.OperationName != "" {
+		return g.OperationName
+	}
+	doc := g.document()
+	if match := graphQLOperationName.FindStringSubmatch(doc); match != nil {
+		return match[1]
+	}
+	return fmt.Sprintf("anonymous-%x", sha256.Sum256([]byte(doc)))[:len("anonymous-")+12]
+}
+
+// graphQLCache holds GraphQL responses for a fixed amount of time. Unlike
+// REST responses, GraphQL responses can't be revalidated with conditional
+// requests, so entries are served without contacting GitHub until they
+// expire.
+type graphQLCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]graphQLCacheEntry
+}
+
+type graphQLCacheEntry struct {
+	resp      []byte
+	expiresAt time.Time
+}
+
+func newGraphQLCache(ttl time.Duration) *graphQLCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &graphQLCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]graphQLCacheEntry{},
+	}
+}
+
+func (c *graphQLCache) get(key string) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.resp, true
+}
+
+func (c *graphQLCache) set(key string, resp []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = graphQLCacheEntry{resp: resp, expiresAt: now.Add(c.ttl)}
+}
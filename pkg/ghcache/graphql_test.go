@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github/ghmetrics"
+)
+
+func TestGraphQLRequest(t *testing.T) {
+	testCases := []struct {
+		name              string
+		query             string
+		operationName     string
+		expectedQuery     bool
+		expectedOperation string
+	}{
+		{
+			name:              "anonymous query",
+			query:             `query($org:String!){organization(login:$org){id}}`,
+			expectedQuery:     true,
+			expectedOperation: "anonymous-",
+		},
+		{
+			name:              "shorthand query",
+			query:             `{viewer{login}}`,
+			expectedQuery:     true,
+			expectedOperation: "anonymous-",
+		},
+		{
+			name:              "named query with comments",
+			query:             "# find the org\nquery FindOrg { organization(login: \"org\") { id } }",
+			expectedQuery:     true,
+			expectedOperation: "FindOrg",
+		},
+		{
+			name:              "operation name takes precedence",
+			query:             `query FindOrg { organization(login: "org") { id } }`,
+			operationName:     "Explicit",
+			expectedQuery:     true,
+			expectedOperation: "Explicit",
+		},
+		{
+			name:              "mutation",
+			query:             `mutation AddLabel($input: AddLabelsToLabelableInput!) { addLabelsToLabelable(input: $input) { clientMutationId } }`,
+			expectedOperation: "AddLabel",
+		},
+		{
+			name:              "document with a query and a mutation",
+			query:             `query Q { viewer { login } } mutation M { addStar(input: {}) { clientMutationId } }`,
+			expectedOperation: "Q",
+		},
+		{
+			name:              "subscription",
+			query:             `subscription { events { id } }`,
+			expectedOperation: "anonymous-",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gql := &graphQLRequest{Query: tc.query, OperationName: tc.operationName}
+			if actual := gql.isQuery(); actual != tc.expectedQuery {
+				t.Errorf("expected isQuery to be %t, got %t", tc.expectedQuery, actual)
+			}
+			if actual := gql.operation(); !strings.HasPrefix(actual, tc.expectedOperation) || (tc.expectedOperation == "anonymous-" && len(actual) != len("anonymous-")+12) {
+				t.Errorf("expected operation %q, got %q", tc.expectedOperation, actual)
+			}
+		})
+	}
+}
+
+func TestGraphQLRequestKey(t *testing.T) {
+	query := `query($org:String!,$first:Int!){organization(login:$org){repositories(first:$first){nodes{name}}}}`
+	base := &graphQLRequest{Query: query, Variables: json.RawMessage(`{"org":"kubernetes","first":10}`)}
+	testCases := []struct {
+		name     string
+		request  *graphQLRequest
+		expected bool
+	}{
+		{
+			name:     "variables in a different order",
+			request:  &graphQLRequest{Query: query, Variables: json.RawMessage(`{ "first": 10, "org": "kubernetes" }`)},
+			expected: true,
+		},
+		{
+			name:     "comments are ignored",
+			request:  &graphQLRequest{Query: "# list repos\n" + query, Variables: json.RawMessage(`{"org":"kubernetes","first":10}`)},
+			expected: true,
+		},
+		{
+			name:    "different variables",
+			request: &graphQLRequest{Query: query, Variables: json.RawMessage(`{"org":"kubernetes-sigs","first":10}`)},
+		},
+		{
+			name:    "different query",
+			request: &graphQLRequest{Query: strings.Replace(query, "name", "id", 1), Variables: json.RawMessage(`{"org":"kubernetes","first":10}`)},
+		},
+		{
+			name:    "no variables",
+			request: &graphQLRequest{Query: query},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := base.key() == tc.request.key(); actual != tc.expected {
+				t.Errorf("expected keys to match: %t, got: %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGraphQLCache(t *testing.T) {
+	if c := newGraphQLCache(0); c != nil {
+		t.Fatalf("expected a zero TTL to disable the cache")
+	}
+	now := time.Now()
+	c := newGraphQLCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.set("a", []byte("first"))
+	now = now.Add(30 * time.Second)
+	c.set("b", []byte("second"))
+	if resp, ok := c.get("a"); !ok || string(resp) != "first" {
+		t.Errorf("expected fresh entry, got %q, %t", resp, ok)
+	}
+	now = now.Add(30 * time.Second)
+	if _, ok := c.get("a"); ok {
+		t.Error("expected expired entry to be missing")
+	}
+	c.set("c", []byte("third"))
+	var keys []string
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	if len(keys) != 2 {
+		t.Errorf("expected expired entries to be pruned, got %v", keys)
+	}
+}
+
+// fakeGraphQLExecutor is a fake upstream that counts GraphQL requests by
+// their query and responds immediately.
+type fakeGraphQLExecutor struct {
+	hits map[string]int
+	body string
+}
+
+func (f *fakeGraphQLExecutor) RoundTrip(req *http.Request) (*http.Response, error) {
+	gql, err := parseGraphQLRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	f.hits[gql.Query]++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(f.body)),
+		Header:     http.Header{"Cache-Control": []string{"no-store"}},
+	}, nil
+}
+
+func TestGraphQLRoundTrip(t *testing.T) {
+	query := `query { viewer { login } }`
+	mutation := `mutation { addStar(input: {starrableId: "1"}) { clientMutationId } }`
+	testCases := []struct {
+		name          string
+		ttl           time.Duration
+		body          string
+		queries       []string
+		expectedModes []CacheResponseMode
+		expectedHits  map[string]int
+	}{
+		{
+			name:          "queries are served from the cache",
+			ttl:           time.Minute,
+			body:          `{"data":{"viewer":{"login":"bot"}}}`,
+			queries:       []string{query, query},
+			expectedModes: []CacheResponseMode{ModeMiss, ModeFresh},
+			expectedHits:  map[string]int{query: 1},
+		},
+		{
+			name:          "queries are not cached without a TTL",
+			body:          `{"data":{"viewer":{"login":"bot"}}}`,
+			queries:       []string{query, query},
+			expectedModes: []CacheResponseMode{ModeNoStore, ModeNoStore},
+			expectedHits:  map[string]int{query: 2},
+		},
+		{
+			name:          "responses with errors are not cached",
+			ttl:           time.Minute,
+			body:          `{"errors":[{"message":"timeout"}]}`,
+			queries:       []string{query, query},
+			expectedModes: []CacheResponseMode{ModeNoStore, ModeNoStore},
+			expectedHits:  map[string]int{query: 2},
+		},
+		{
+			name:          "mutations skip the cache",
+			ttl:           time.Minute,
+			body:          `{"data":{"addStar":{"clientMutationId":null}}}`,
+			queries:       []string{mutation, mutation},
+			expectedModes: []CacheResponseMode{ModeSkip, ModeSkip},
+			expectedHits:  map[string]int{mutation: 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream := &fakeGraphQLExecutor{hits: map[string]int{}, body: tc.body}
+			coalescer := &requestCoalescer{
+				cache:           make(map[string]*firstRequest),
+				requestExecutor: upstream,
+				hasher:          ghmetrics.NewCachingHasher(),
+				graphQLCache:    newGraphQLCache(tc.ttl),
+			}
+			var modes []CacheResponseMode
+			for _, q := range tc.queries {
+				body, err := json.Marshal(graphQLRequest{Query: q})
+				if err != nil {
+					t.Fatalf("failed to marshal request: %v", err)
+				}
+				req, err := http.NewRequest(http.MethodPost, "http://api.github.com/graphql", bytes.NewReader(body))
+				if err != nil {
+					t.Fatalf("failed to create request: %v", err)
+				}
+				resp, err := coalescer.RoundTrip(req)
+				if err != nil {
+					t.Fatalf("failed to run request: %v", err)
+				}
+				respBody, err := io.ReadAll(resp.Body)
+				if err != nil {
+					t.Fatalf("failed to read response: %v", err)
+				}
+				if string(respBody) != tc.body {
+					t.Errorf("expected response body %q, got %q", tc.body, respBody)
+				}
+				modes = append(modes, CacheResponseMode(resp.Header.Get(CacheModeHeader)))
+			}
+			if diff := cmp.Diff(tc.expectedModes, modes); diff != "" {
+				t.Errorf("cache modes differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedHits, upstream.hits); diff != "" {
+				t.Errorf("upstream hits differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -74,6 +74,8 @@ type Interactor interface {
 	Diff(head, sha string) (changes []string, err error)
 	// MergeCommitsExistBetween determines if merge commits exist between target and HEAD
 	MergeCommitsExistBetween(target, head string) (bool, error)
+	// IsAncestor determines if ancestor is reachable from descendant
+	IsAncestor(ancestor, descendant string) (bool, error)
 	// ShowRef returns the commit for a commitlike. Unlike rev-parse it does not require a checkout.
 	ShowRef(commitlike string) (string, error)
 }
@@ -588,6 +590,17 @@ func (i *interactor) MergeCommitsExistBetween(target, head string) (bool, error)
 	return len(out) != 0, nil
 }
 
+// IsAncestor runs 'git rev-list --count <descendant>..<ancestor>' to verify
+// that no commits of "ancestor" are missing from "descendant".
+func (i *interactor) IsAncestor(ancestor, descendant string) (bool, error) {
+	i.logger.Infof("Determining if %q is an ancestor of %q", ancestor, descendant)
+	out, err := i.executor.Run("rev-list", "--count", fmt.Sprintf("%s..%s", descendant, ancestor))
+	if err != nil {
+		return false, fmt.Errorf("error verifying if %q is an ancestor of %q: %v %s", ancestor, descendant, err, string(out))
+	}
+	return strings.TrimSpace(string(out)) == "0", nil
+}
+
 func (i *interactor) ShowRef(commitlike string) (string, error) {
 	i.logger.Infof("Getting the commit sha for commitlike %s", commitlike)
 	out, err := i.executor.Run("show-ref", "-s", commitlike)
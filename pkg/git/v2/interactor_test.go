@@ -1912,6 +1912,79 @@ func TestInteractor_MergeCommitsExistBetween(t *testing.T) {
 	}
 }
 
+func TestInteractor_IsAncestor(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		responses     map[string]execResponse
+		expectedCalls [][]string
+		expectedOut   bool
+		expectedErr   bool
+	}{
+		{
+			name: "happy case and ancestor",
+			responses: map[string]execResponse{
+				"rev-list --count head..base": {
+					out: []byte("0\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-list", "--count", "head..base"},
+			},
+			expectedOut: true,
+		},
+		{
+			name: "happy case and not an ancestor",
+			responses: map[string]execResponse{
+				"rev-list --count head..base": {
+					out: []byte("3\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-list", "--count", "head..base"},
+			},
+			expectedOut: false,
+		},
+		{
+			name: "rev-list fails",
+			responses: map[string]execResponse{
+				"rev-list --count head..base": {
+					err: errors.New("oops"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-list", "--count", "head..base"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			i := interactor{
+				executor: &e,
+				logger:   logrus.WithField("test", testCase.name),
+			}
+			actualOut, actualErr := i.IsAncestor("base", "head")
+			if testCase.expectedOut != actualOut {
+				t.Errorf("%s: got incorrect output: expected %v, got %v", testCase.name, testCase.expectedOut, actualOut)
+			}
+			if testCase.expectedErr && actualErr == nil {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if !testCase.expectedErr && actualErr != nil {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
+
 func TestInteractor_ShowRef(t *testing.T) {
 	const target = "some-branch"
 	var testCases = []struct {
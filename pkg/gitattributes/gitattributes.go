@@ -38,12 +38,14 @@ type Pattern interface {
 // inclusion in the group using the Match method.
 type Group struct {
 	LinguistGeneratedPatterns []Pattern
+	LinguistVendoredPatterns  []Pattern
 }
 
 // NewGroup reads the .gitattributes file in the root of the repository only.
 func NewGroup(gitAttributesContent func() ([]byte, error)) (*Group, error) {
 	g := &Group{
 		LinguistGeneratedPatterns: []Pattern{},
+		LinguistVendoredPatterns:  []Pattern{},
 	}
 
 	bs, err := gitAttributesContent()
@@ -85,14 +87,23 @@ func (g *Group) load(r io.Reader) error {
 		}
 
 		// When the pattern matches the path in question, the attributes listed on the line are given to the path.
+		// An attribute is set either by its name alone or with "=true".
 		attributes := sets.New[string](fs[1:]...)
-		if attributes.Has("linguist-generated=true") {
-			p, err := parsePattern(fs[0])
-			if err != nil {
-				return fmt.Errorf("error parsing pattern: %w", err)
-			}
+		generated := attributes.HasAny("linguist-generated", "linguist-generated=true")
+		vendored := attributes.HasAny("linguist-vendored", "linguist-vendored=true")
+		if !generated && !vendored {
+			continue
+		}
+		p, err := parsePattern(fs[0])
+		if err != nil {
+			return fmt.Errorf("error parsing pattern: %w", err)
+		}
+		if generated {
 			g.LinguistGeneratedPatterns = append(g.LinguistGeneratedPatterns, p)
 		}
+		if vendored {
+			g.LinguistVendoredPatterns = append(g.LinguistVendoredPatterns, p)
+		}
 	}
 
 	if err := s.Err(); err != nil {
@@ -115,3 +126,17 @@ func (g *Group) IsLinguistGenerated(path string) bool {
 	}
 	return false
 }
+
+// IsLinguistVendored determines whether a file, given here by its full path
+// is included in the .gitattributes linguist-vendored group.
+// These files are excluded from language stats.
+// https://github.com/github/linguist/#vendored-code
+// Unmarked paths (linguist-vendored=false) are not supported.
+func (g *Group) IsLinguistVendored(path string) bool {
+	for _, p := range g.LinguistVendoredPatterns {
+		if p.Match(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -26,6 +26,7 @@ func TestLoad(t *testing.T) {
 		name                        string
 		src                         string
 		nbLinguistGeneratedPatterns int
+		nbLinguistVendoredPatterns  int
 		expectError                 bool
 	}{
 		{
@@ -70,6 +71,15 @@ api/openapi-spec/*.json linguist-generated=true`,
 *.json linguist-generated=true`,
 			nbLinguistGeneratedPatterns: 1,
 		},
+		{
+			name: "attributes set without value",
+			src: `third_party/** linguist-vendored
+*.pb.go linguist-generated
+vendor/** linguist-vendored=true linguist-generated=true
+docs/** linguist-vendored=false`,
+			nbLinguistGeneratedPatterns: 2,
+			nbLinguistVendoredPatterns:  2,
+		},
 		{
 			name:        "wrong pattern",
 			src:         `abc/ linguist-generated=true`,
@@ -80,6 +90,7 @@ api/openapi-spec/*.json linguist-generated=true`,
 		t.Run(c.name, func(t *testing.T) {
 			g := &Group{
 				LinguistGeneratedPatterns: []Pattern{},
+				LinguistVendoredPatterns:  []Pattern{},
 			}
 			if err := g.load(bytes.NewBufferString(c.src)); err != nil && !c.expectError {
 				t.Fatalf("load error: %v", err)
@@ -87,6 +98,9 @@ api/openapi-spec/*.json linguist-generated=true`,
 			if got := len(g.LinguistGeneratedPatterns); got != c.nbLinguistGeneratedPatterns {
 				t.Fatalf("len(g.LinguistGeneratedPatterns) mismatch: got %d, want %d", got, c.nbLinguistGeneratedPatterns)
 			}
+			if got := len(g.LinguistVendoredPatterns); got != c.nbLinguistVendoredPatterns {
+				t.Fatalf("len(g.LinguistVendoredPatterns) mismatch: got %d, want %d", got, c.nbLinguistVendoredPatterns)
+			}
 		})
 	}
 }
@@ -147,3 +161,49 @@ api/openapi-spec/*.json linguist-generated=true`
 		})
 	}
 }
+
+func TestIsLinguistVendored(t *testing.T) {
+	var src = `vendor/** linguist-vendored
+third_party/**/*.go linguist-vendored=true
+*.pb.go linguist-generated=true`
+	var cases = []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "vendored dependency",
+			path: "vendor/github.com/a/b/c.go",
+			want: true,
+		},
+		{
+			name: "third party code",
+			path: "third_party/forked/d/e.go",
+			want: true,
+		},
+		{
+			name: "generated file",
+			path: "pkg/api/types.pb.go",
+			want: false,
+		},
+		{
+			name: "regular file",
+			path: "pkg/main.go",
+			want: false,
+		},
+	}
+	g := &Group{
+		LinguistGeneratedPatterns: []Pattern{},
+		LinguistVendoredPatterns:  []Pattern{},
+	}
+	if err := g.load(bytes.NewBufferString(src)); err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := g.IsLinguistVendored(c.path); got != c.want {
+				t.Fatalf("IsLinguistVendored mismatch: got %t, want %t", got, c.want)
+			}
+		})
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/prow/pkg/ghcache"
+	"sigs.k8s.io/prow/pkg/github/ghmetrics"
 	"sigs.k8s.io/prow/pkg/throttle"
 	"sigs.k8s.io/prow/pkg/version"
 )
@@ -49,6 +50,7 @@ import (
 type timeClient interface {
 	Sleep(time.Duration)
 	Until(time.Time) time.Duration
+	Now() time.Time
 }
 
 type standardTime struct{}
@@ -59,6 +61,9 @@ func (s *standardTime) Sleep(d time.Duration) {
 func (s *standardTime) Until(t time.Time) time.Duration {
 	return time.Until(t)
 }
+func (s *standardTime) Now() time.Time {
+	return time.Now()
+}
 
 // OrganizationClient interface for organisation related API actions
 type OrganizationClient interface {
@@ -114,6 +119,7 @@ type IssueClient interface {
 	UnassignIssue(org, repo string, number int, logins []string) error
 	CloseIssue(org, repo string, number int) error
 	CloseIssueAsNotPlanned(org, repo string, number int) error
+	LockIssue(org, repo string, number int, reason string) error
 	ReopenIssue(org, repo string, number int) error
 	FindIssues(query, sort string, asc bool) ([]Issue, error)
 	FindIssuesWithOrg(org, query, sort string, asc bool) ([]Issue, error)
@@ -189,6 +195,9 @@ type RepositoryClient interface {
 	ListRepoTeams(org, repo string) ([]Team, error)
 	CreateRepo(owner string, isUser bool, repo RepoCreateRequest) (*FullRepo, error)
 	UpdateRepo(owner, name string, repo RepoUpdateRequest) (*FullRepo, error)
+	ReplaceRepoTopics(org, repo string, topics []string) error
+	VulnerabilityAlertsEnabled(org, repo string) (bool, error)
+	SetVulnerabilityAlerts(org, repo string, enabled bool) error
 }
 
 // TeamClient interface for team related API actions
@@ -272,6 +281,7 @@ type Client interface {
 	GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]WorkflowRun, error)
 
 	Throttle(hourlyTokens, burst int, org ...string) error
+	ApplyThrottlePolicy(policy ThrottlePolicy) error
 	QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error
 	MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error
 
@@ -317,6 +327,8 @@ type delegate struct {
 	getToken     func() []byte
 	censor       func([]byte) []byte
 
+	secondaryRateLimits secondaryRateLimiter
+
 	mut      sync.Mutex // protects botName and email
 	userData *UserData
 }
@@ -498,6 +510,11 @@ type ClientOptions struct {
 	GetToken      func() []byte
 	AppID         string
 	AppPrivateKey func() *rsa.PrivateKey
+	// TokenMinterURL is the address of a token minter that mints GitHub App
+	// installation tokens on behalf of the client, which then never holds
+	// the app's private key. TokenMinterSecret authenticates the client.
+	TokenMinterURL    string
+	TokenMinterSecret func() []byte
 
 	// the following fields determine which server we talk to
 	GraphqlEndpoint string
@@ -508,6 +525,10 @@ type ClientOptions struct {
 	MaxRetries, Max404Retries                  int
 
 	DryRun bool
+	// ResponseCache, if set, stores responses to GET requests and revalidates
+	// them with conditional requests. It is meant for clients that don't send
+	// their requests through ghproxy.
+	ResponseCache ResponseCache
 	// BaseRoundTripper is the last RoundTripper to be called. Used for testing, gets defaulted to http.DefaultTransport
 	BaseRoundTripper http.RoundTripper
 }
@@ -577,6 +598,16 @@ func (c *client) wrapThrottler() {
 
 // NewClientFromOptions creates a new client from the options we expose. This method should be used over the more-specific ones.
 func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGenerator, UserGenerator, Client, error) {
+	tokenGenerator, userGenerator, c, _, err := newClientFromOptions(fields, options)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tokenGenerator, userGenerator, c, nil
+}
+
+// newClientFromOptions creates a client and additionally returns the apps
+// auth transport, which is nil unless options.AppID is set.
+func newClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGenerator, UserGenerator, *client, *appsRoundTripper, error) {
 	options = options.Default()
 
 	// Will be nil if github app authentication is used
@@ -586,6 +617,10 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 	if options.BaseRoundTripper == nil {
 		options.BaseRoundTripper = http.DefaultTransport
 	}
+	if options.ResponseCache != nil {
+		options.BaseRoundTripper = &conditionalRequestTransport{upstream: options.BaseRoundTripper, cache: options.ResponseCache}
+	}
+	options.BaseRoundTripper = &consumptionTransport{upstream: options.BaseRoundTripper}
 
 	httpClient := &http.Client{
 		Transport: options.BaseRoundTripper,
@@ -611,7 +646,7 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 			getToken:      options.GetToken,
 			censor:        options.Censor,
 			dry:           options.DryRun,
-			usesAppsAuth:  options.AppID != "",
+			usesAppsAuth:  options.AppID != "" || options.TokenMinterURL != "",
 			maxRetries:    options.MaxRetries,
 			max404Retries: options.Max404Retries,
 			initialDelay:  options.InitialDelay,
@@ -625,10 +660,12 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 
 	var tokenGenerator func(_ string) (string, error)
 	var userGenerator func() (string, error)
+	var appsTransport *appsRoundTripper
 	if options.AppID != "" {
-		appsTransport, err := newAppsRoundTripper(options.AppID, options.AppPrivateKey, options.BaseRoundTripper, c, options.Bases)
+		var err error
+		appsTransport, err = newAppsRoundTripper(options.AppID, options.AppPrivateKey, options.BaseRoundTripper, c, options.Bases)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to construct apps auth roundtripper: %w", err)
+			return nil, nil, nil, nil, fmt.Errorf("failed to construct apps auth roundtripper: %w", err)
 		}
 		httpClient.Transport = appsTransport
 		graphQLTransport.upstream = appsTransport
@@ -642,6 +679,24 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 		userGenerator = func() (string, error) {
 			return "x-access-token", nil
 		}
+	} else if options.TokenMinterURL != "" {
+		minterTransport, err := newTokenMinterRoundTripper(options.TokenMinterURL, options.TokenMinterSecret, options.BaseRoundTripper, options.Bases)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to construct token minter roundtripper: %w", err)
+		}
+		httpClient.Transport = minterTransport
+		graphQLTransport.upstream = minterTransport
+
+		tokenGenerator = func(org string) (string, error) {
+			token, err := minterTransport.tokenFor(org)
+			if err != nil {
+				return "", err
+			}
+			return token.Token, nil
+		}
+		userGenerator = func() (string, error) {
+			return "x-access-token", nil
+		}
 	} else {
 		// Use Personal Access token auth for git actions
 		tokenGenerator = func(_ string) (string, error) {
@@ -656,7 +711,7 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 		}
 	}
 
-	return tokenGenerator, userGenerator, c, nil
+	return tokenGenerator, userGenerator, c, appsTransport, nil
 }
 
 type graphQLGitHubAppsAuthClientWrapper struct {
@@ -719,6 +774,34 @@ func (s *addHeaderTransport) RoundTrip(r *http.Request) (*http.Response, error)
 	return s.upstream.RoundTrip(r)
 }
 
+// consumptionTransport attributes the requests sent to GitHub, and the rate
+// limit points they spend, to the component and consumer that sent them.
+type consumptionTransport struct {
+	upstream http.RoundTripper
+}
+
+func (t *consumptionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.upstream.RoundTrip(r)
+	if err != nil {
+		return resp, err
+	}
+	cost := 1
+	if ghcache.CacheModeIsFree(ghcache.CacheResponseMode(resp.Header.Get(ghcache.CacheModeHeader))) {
+		cost = 0
+	} else if strings.HasSuffix(r.URL.Path, "/graphql") && resp.StatusCode == http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GraphQL response: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		cost, _ = ghmetrics.GraphQLCost(body)
+	}
+	org, _ := r.Context().Value(githubOrgContextKey).(string)
+	ghmetrics.CollectConsumptionMetrics(r.Header.Get("User-Agent"), org, r.URL.Path, cost)
+	return resp, nil
+}
+
 // NewClient creates a new fully operational GitHub client.
 func NewClient(getToken func() []byte, censor func([]byte) []byte, graphqlEndpoint string, bases ...string) (Client, error) {
 	return NewClientWithFields(logrus.Fields{}, getToken, censor, graphqlEndpoint, bases...)
@@ -941,6 +1024,13 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 		if retries > 0 && resp != nil {
 			resp.Body.Close()
 		}
+		if delay := c.secondaryRateLimits.delay(c.time, c.secondaryRateLimitKey(org)); delay > 0 {
+			if delay >= c.maxSleepTime {
+				return nil, fmt.Errorf("waiting for secondary rate limit exceeds max sleep time (%v > %v)", delay, c.maxSleepTime)
+			}
+			c.logger.WithField("backoff", delay.String()).WithField("path", path).Debug("Waiting for secondary ratelimit")
+			c.time.Sleep(delay)
+		}
 		resp, err = c.doRequest(ctx, method, c.bases[hostIndex]+path, accept, org, body)
 		if err == nil {
 			if resp.StatusCode == 404 && retries < c.max404Retries {
@@ -953,7 +1043,7 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 				c.logger.WithField("backoff", backoff.String()).Debug("Retrying 404")
 				c.time.Sleep(backoff)
 				backoff *= 2
-			} else if resp.StatusCode == 403 {
+			} else if resp.StatusCode == 403 || resp.StatusCode == 429 {
 				if resp.Header.Get("X-RateLimit-Remaining") == "0" {
 					// If we are out of API tokens, sleep first. The X-RateLimit-Reset
 					// header tells us the time at which we can request again.
@@ -975,24 +1065,20 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 						resp.Body.Close()
 						break
 					}
-				} else if rawTime := resp.Header.Get("Retry-After"); rawTime != "" && rawTime != "0" {
-					// If we are getting abuse rate limited, we need to wait or
-					// else we risk continuing to make the situation worse
-					var t int
-					if t, err = strconv.Atoi(rawTime); err == nil {
-						// Sleep an extra second plus how long GitHub wants us to
-						// sleep. If it's going to take too long, then break.
-						sleepTime := time.Duration(t+1) * time.Second
-						if sleepTime < c.maxSleepTime {
-							c.logger.WithField("backoff", sleepTime.String()).WithField("path", path).Debug("Retrying after abuse ratelimit reset")
-							c.time.Sleep(sleepTime)
-						} else {
-							err = fmt.Errorf("sleep time for abuse rate limit exceeds max sleep time (%v > %v)", sleepTime, c.maxSleepTime)
-							resp.Body.Close()
-							break
-						}
+				} else if limited, retryAfter, parseErr := secondaryRateLimit(resp); parseErr != nil {
+					err = parseErr
+					resp.Body.Close()
+					break
+				} else if limited {
+					// If we are getting secondary rate limited, we need to wait or
+					// else we risk continuing to make the situation worse. All
+					// requests using the same token wait, not just this one.
+					sleepTime := c.secondaryRateLimits.penalize(c.time, c.secondaryRateLimitKey(org), retryAfter)
+					if sleepTime < c.maxSleepTime {
+						c.logger.WithField("backoff", sleepTime.String()).WithField("path", path).Warn("Retrying after secondary ratelimit")
+						c.time.Sleep(sleepTime)
 					} else {
-						err = fmt.Errorf("failed to parse abuse rate limit wait time %q: %w", rawTime, err)
+						err = fmt.Errorf("sleep time for secondary rate limit exceeds max sleep time (%v > %v)", sleepTime, c.maxSleepTime)
 						resp.Body.Close()
 						break
 					}
@@ -1022,6 +1108,7 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 				}
 			} else if resp.StatusCode < 500 {
 				// Normal, happy case.
+				c.secondaryRateLimits.reset(c.secondaryRateLimitKey(org))
 				break
 			} else {
 				// Retry 500 after a break.
@@ -1051,6 +1138,16 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 	return resp, err
 }
 
+// secondaryRateLimitKey identifies the token whose requests are held back
+// after a secondary rate limit. GitHub Apps use a token per installation, and
+// thus per org.
+func (c *client) secondaryRateLimitKey(org string) string {
+	if c.usesAppsAuth {
+		return org
+	}
+	return ""
+}
+
 func (c *client) doRequest(ctx context.Context, method, path, accept, org string, body interface{}) (*http.Response, error) {
 	var buf io.Reader
 	if body != nil {
@@ -2522,6 +2619,66 @@ func (c *client) UpdateRepo(owner, name string, repo RepoUpdateRequest) (*FullRe
 	return &retRepo, err
 }
 
+// ReplaceRepoTopics replaces all topics of a repo.
+//
+// See https://docs.github.com/en/rest/repos/repos#replace-all-repository-topics
+func (c *client) ReplaceRepoTopics(org, repo string, topics []string) error {
+	durationLogger := c.log("ReplaceRepoTopics", org, repo, topics)
+	defer durationLogger()
+
+	if topics == nil {
+		topics = []string{}
+	}
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/repos/%s/%s/topics", org, repo),
+		org:         org,
+		requestBody: map[string][]string{"names": topics},
+		exitCodes:   []int{200},
+	}, nil)
+	return err
+}
+
+// VulnerabilityAlertsEnabled returns whether Dependabot alerts are enabled for a repo.
+//
+// See https://docs.github.com/en/rest/repos/repos#check-if-vulnerability-alerts-are-enabled-for-a-repository
+func (c *client) VulnerabilityAlertsEnabled(org, repo string) (bool, error) {
+	durationLogger := c.log("VulnerabilityAlertsEnabled", org, repo)
+	defer durationLogger()
+
+	code, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", org, repo),
+		org:       org,
+		exitCodes: []int{204, 404},
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	return code == 204, nil
+}
+
+// SetVulnerabilityAlerts enables or disables Dependabot alerts for a repo.
+//
+// See https://docs.github.com/en/rest/repos/repos#enable-vulnerability-alerts
+// and https://docs.github.com/en/rest/repos/repos#disable-vulnerability-alerts
+func (c *client) SetVulnerabilityAlerts(org, repo string, enabled bool) error {
+	durationLogger := c.log("SetVulnerabilityAlerts", org, repo, enabled)
+	defer durationLogger()
+
+	method := http.MethodDelete
+	if enabled {
+		method = http.MethodPut
+	}
+	_, err := c.request(&request{
+		method:    method,
+		path:      fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", org, repo),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
 // GetRepos returns all repos in an org.
 //
 // This call uses multiple API tokens when results are paginated.
@@ -3221,6 +3378,28 @@ func (c *client) closeIssue(org, repo string, number int, reason string) error {
 	return err
 }
 
+// LockIssue locks the conversation of an issue or pull request. The reason is
+// optional and must be one of "off-topic", "too heated", "resolved" or "spam".
+//
+// See https://docs.github.com/en/rest/issues/issues#lock-an-issue
+func (c *client) LockIssue(org, repo string, number int, reason string) error {
+	durationLogger := c.log("LockIssue", org, repo, number, reason)
+	defer durationLogger()
+
+	var body interface{}
+	if reason != "" {
+		body = map[string]string{"lock_reason": reason}
+	}
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/repos/%s/%s/issues/%d/lock", org, repo, number),
+		org:         org,
+		requestBody: body,
+		exitCodes:   []int{204},
+	}, nil)
+	return err
+}
+
 // StateCannotBeChanged represents the "custom" GitHub API
 // error that occurs when a resource cannot be changed
 type StateCannotBeChanged struct {
@@ -4774,11 +4953,25 @@ func (c *client) getAppInstallationToken(installationId int64) (*AppInstallation
 		return nil, fmt.Errorf("not requesting GitHub App access_token in dry-run mode")
 	}
 
+	return c.createAppInstallationToken(installationId, nil)
+}
+
+// createAppInstallationToken creates an installation token. If permissions
+// are given, the token is restricted to them instead of all permissions of
+// the app.
+//
+// See https://docs.github.com/en/rest/apps/apps#create-an-installation-access-token-for-an-app
+func (c *client) createAppInstallationToken(installationId int64, permissions *InstallationPermissions) (*AppInstallationToken, error) {
+	var body interface{}
+	if permissions != nil {
+		body = map[string]*InstallationPermissions{"permissions": permissions}
+	}
 	var token AppInstallationToken
 	if _, err := c.request(&request{
-		method:    http.MethodPost,
-		path:      fmt.Sprintf("/app/installations/%d/access_tokens", installationId),
-		exitCodes: []int{201},
+		method:      http.MethodPost,
+		path:        fmt.Sprintf("/app/installations/%d/access_tokens", installationId),
+		requestBody: body,
+		exitCodes:   []int{201},
 	}, &token); err != nil {
 		return nil, err
 	}
@@ -41,6 +41,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/diff"
 
+	"sigs.k8s.io/prow/pkg/ghcache"
+	"sigs.k8s.io/prow/pkg/github/ghmetrics"
 	"sigs.k8s.io/prow/pkg/throttle"
 	"sigs.k8s.io/prow/pkg/version"
 )
@@ -56,6 +58,9 @@ func (tt *testTime) Sleep(d time.Duration) {
 func (tt *testTime) Until(t time.Time) time.Duration {
 	return t.Sub(tt.now)
 }
+func (tt *testTime) Now() time.Time {
+	return tt.now
+}
 
 func getClient(url string) *client {
 	getToken := func() []byte {
@@ -132,6 +137,167 @@ func TestAbuseRateLimit(t *testing.T) {
 	}
 }
 
+func TestSecondaryRateLimit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		status        int
+		header        http.Header
+		body          string
+		expectedSleep time.Duration
+	}{
+		{
+			name:          "429 without Retry-After",
+			status:        http.StatusTooManyRequests,
+			expectedSleep: secondaryRateLimitInitialBackoff,
+		},
+		{
+			name:          "403 with secondary rate limit message",
+			status:        http.StatusForbidden,
+			body:          `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`,
+			expectedSleep: secondaryRateLimitInitialBackoff,
+		},
+		{
+			name:          "429 with Retry-After",
+			status:        http.StatusTooManyRequests,
+			header:        http.Header{"Retry-After": []string{"30"}},
+			expectedSleep: 31 * time.Second,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := &testTime{now: time.Now()}
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.slept == 0 {
+					for k, v := range tc.header {
+						w.Header()[k] = v
+					}
+					http.Error(w, tc.body, tc.status)
+				}
+			}))
+			defer ts.Close()
+			c := getClient(ts.URL)
+			c.time = tt
+			resp, err := c.requestRetry(http.MethodGet, "/", "", "", nil)
+			if err != nil {
+				t.Fatalf("Error from request: %v", err)
+			}
+			if resp.StatusCode != 200 {
+				t.Errorf("Expected status code 200, got %d", resp.StatusCode)
+			}
+			if tt.slept != tc.expectedSleep {
+				t.Errorf("Expected to sleep for %v, got %v", tc.expectedSleep, tt.slept)
+			}
+			if delay := c.secondaryRateLimits.delay(tt, ""); delay != 0 {
+				t.Errorf("Expected successful request to reset the backoff, got %v", delay)
+			}
+		})
+	}
+}
+
+func TestSecondaryRateLimiter(t *testing.T) {
+	tt := &testTime{now: time.Now()}
+	var s secondaryRateLimiter
+
+	var backoffs []time.Duration
+	for i := 0; i < 6; i++ {
+		backoffs = append(backoffs, s.penalize(tt, "org", 0))
+	}
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 15 * time.Minute, 15 * time.Minute}
+	if diff := cmp.Diff(expected, backoffs); diff != "" {
+		t.Errorf("Backoffs differ from expected (-want +got):\n%s", diff)
+	}
+	if delay := s.delay(tt, "org"); delay != 15*time.Minute {
+		t.Errorf("Expected requests for org to be held back for 15m, got %v", delay)
+	}
+	if delay := s.delay(tt, "other-org"); delay != 0 {
+		t.Errorf("Expected requests for other-org not to be held back, got %v", delay)
+	}
+	s.reset("org")
+	if backoff := s.penalize(tt, "org", 0); backoff != time.Minute {
+		t.Errorf("Expected backoff to restart at 1m after reset, got %v", backoff)
+	}
+}
+
+func TestSecondaryRateLimitWaitExceedsMaxSleepTime(t *testing.T) {
+	tt := &testTime{now: time.Now()}
+	var requests int
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	c.time = tt
+	c.usesAppsAuth = true
+	c.secondaryRateLimits.penalize(tt, "org", 10*time.Minute)
+
+	if _, err := c.requestRetry(http.MethodGet, "/", "", "org", nil); err == nil {
+		t.Error("Expected an error while org is held back longer than the max sleep time")
+	}
+	if _, err := c.requestRetry(http.MethodGet, "/", "", "other-org", nil); err != nil {
+		t.Errorf("Unexpected error for other-org: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request to reach GitHub, got %d", requests)
+	}
+}
+
+func TestApplyThrottlePolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       ThrottlePolicy
+		usesAppsAuth bool
+		expectedErr  bool
+	}{
+		{
+			name:   "global policy",
+			policy: ThrottlePolicy{HourlyTokens: 100, Burst: 10},
+		},
+		{
+			name:         "org policies with apps auth",
+			policy:       ThrottlePolicy{HourlyTokens: 100, Burst: 10, Orgs: map[string]OrgThrottlePolicy{"org": {HourlyTokens: 10, Burst: 1}}},
+			usesAppsAuth: true,
+		},
+		{
+			name:        "org policies without apps auth",
+			policy:      ThrottlePolicy{Orgs: map[string]OrgThrottlePolicy{"org": {HourlyTokens: 10, Burst: 1}}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid policy",
+			policy:      ThrottlePolicy{HourlyTokens: 100},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := getClient("")
+			c.usesAppsAuth = tc.usesAppsAuth
+			if err := c.ApplyThrottlePolicy(tc.policy); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestApplyThrottlePolicyKeepsGlobalThrottle(t *testing.T) {
+	c := getClient("")
+	c.usesAppsAuth = true
+	if err := c.Throttle(1, 1); err != nil {
+		t.Fatalf("failed to throttle: %v", err)
+	}
+	if err := c.ApplyThrottlePolicy(ThrottlePolicy{Orgs: map[string]OrgThrottlePolicy{"org": {HourlyTokens: 10, Burst: 1}}}); err != nil {
+		t.Fatalf("failed to apply throttle policy: %v", err)
+	}
+	if err := c.throttle.Wait(context.Background(), "other"); err != nil {
+		t.Fatalf("expected the first request to pass, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.throttle.Wait(ctx, "other"); err == nil {
+		t.Error("expected orgs without a policy to still be throttled globally")
+	}
+}
+
 func TestRetry404(t *testing.T) {
 	tc := &testTime{now: time.Now()}
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1562,6 +1728,33 @@ func TestCloseIssueAsNotPlanned(t *testing.T) {
 	}
 }
 
+func TestLockIssue(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/k8s/kuber/issues/5/lock" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		var ps map[string]string
+		if err := json.Unmarshal(b, &ps); err != nil {
+			t.Errorf("Could not unmarshal request: %v", err)
+		} else if ps["lock_reason"] != "resolved" {
+			t.Errorf("Wrong lock_reason: %s", ps["lock_reason"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.LockIssue("k8s", "kuber", 5, "resolved"); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
 func TestReopenIssue(t *testing.T) {
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPatch {
@@ -2841,6 +3034,71 @@ func TestUpdateRepo(t *testing.T) {
 	}
 }
 
+func TestReplaceRepoTopics(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Bad method: %s (expected %s)", r.Method, http.MethodPut)
+		}
+		if r.URL.Path != "/repos/org/repo/topics" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		if expected := `{"names":[]}`; string(b) != expected {
+			t.Errorf("Bad request body: %s (expected %s)", string(b), expected)
+		}
+		fmt.Fprint(w, `{"names":[]}`)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.ReplaceRepoTopics("org", "repo", nil); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
+func TestVulnerabilityAlerts(t *testing.T) {
+	enabled := map[string]bool{"enabled": true}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo := strings.Split(r.URL.Path, "/")[3]
+		if r.URL.Path != fmt.Sprintf("/repos/org/%s/vulnerability-alerts", repo) {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if !enabled[repo] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		case http.MethodPut:
+			enabled[repo] = true
+		case http.MethodDelete:
+			delete(enabled, repo)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+
+	for repo, expected := range map[string]bool{"enabled": true, "disabled": false} {
+		if actual, err := c.VulnerabilityAlertsEnabled("org", repo); err != nil {
+			t.Errorf("Didn't expect error: %v", err)
+		} else if actual != expected {
+			t.Errorf("Expected alerts of %s to be enabled=%t, got %t", repo, expected, actual)
+		}
+	}
+	if err := c.SetVulnerabilityAlerts("org", "disabled", true); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+	if err := c.SetVulnerabilityAlerts("org", "enabled", false); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+	if expected := map[string]bool{"disabled": true}; !reflect.DeepEqual(enabled, expected) {
+		t.Errorf("Expected enabled alerts %v, got %v", expected, enabled)
+	}
+}
+
 type fakeHttpClient struct {
 	received []*http.Request
 }
@@ -3013,6 +3271,44 @@ func (rt testRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
 	return rt.rt(r)
 }
 
+func TestConsumptionTransport(t *testing.T) {
+	transport := &consumptionTransport{upstream: testRoundTripper{rt: func(r *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"data":{"rateLimit":{"cost":3}}}`))}
+		if r.URL.Path == "/repos/org/repo/issues/1" {
+			resp.Header.Set(ghcache.CacheModeHeader, string(ghcache.ModeRevalidated))
+		}
+		return resp, nil
+	}}}
+
+	for _, path := range []string{"/repos/org/repo/issues/1", "/repos/org/repo/pulls/1", "/graphql"} {
+		req := httptest.NewRequest(http.MethodPost, "https://api.github.com"+path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), githubOrgContextKey, "org"))
+		req.Header.Set("User-Agent", "consumption-test.plugin/v1")
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if body, _ := io.ReadAll(resp.Body); len(body) == 0 {
+			t.Errorf("expected the body of %s to be preserved", path)
+		}
+	}
+
+	var got []ghmetrics.Consumption
+	for _, c := range ghmetrics.ConsumptionReport() {
+		if c.Component == "consumption-test" {
+			got = append(got, c)
+		}
+	}
+	expected := []ghmetrics.Consumption{
+		{Component: "consumption-test", Consumer: "plugin", Org: "org", Category: ghmetrics.CategoryGraphQL, Requests: 1, Cost: 3},
+		{Component: "consumption-test", Consumer: "plugin", Org: "org", Category: "pulls", Requests: 1, Cost: 1},
+		{Component: "consumption-test", Consumer: "plugin", Org: "org", Category: "issues", Requests: 1, Cost: 0},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("consumption differs from expected (-want +got):\n%s", diff)
+	}
+}
+
 // TestAllMethodsThatDoRequestSetOrgHeader uses reflect to find all methods of the Client and
 // their arguments and calls them with an empty argument, then verifies via a RoundTripper that
 // all requests made had an org header set.
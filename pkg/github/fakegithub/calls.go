@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakegithub
+
+import (
+	"time"
+)
+
+// Call is a call of a method of the FakeClient. Methods with a context are
+// recorded under the name of the method without it, e.g. CreateComment for
+// CreateCommentWithContext.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Failure configures a method of the FakeClient to fail or to be slow.
+type Failure struct {
+	// Calls are the numbers of the calls of the method that fail, starting
+	// at 1. If empty, all calls fail.
+	Calls []int
+	// Err is returned by the failing calls. If nil, the calls only have
+	// the latency.
+	Err error
+	// Latency delays the failing calls.
+	Latency time.Duration
+}
+
+func (fl Failure) appliesTo(call int) bool {
+	if len(fl.Calls) == 0 {
+		return true
+	}
+	for _, c := range fl.Calls {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+// InjectFailure makes the calls of the method fail as configured. Failures
+// injected for the same method are combined.
+func (f *FakeClient) InjectFailure(method string, failure Failure) {
+	f.callLock.Lock()
+	defer f.callLock.Unlock()
+	if f.failures == nil {
+		f.failures = map[string][]Failure{}
+	}
+	f.failures[method] = append(f.failures[method], failure)
+}
+
+// CallLog returns all calls of methods of the FakeClient in order.
+func (f *FakeClient) CallLog() []Call {
+	f.callLock.Lock()
+	defer f.callLock.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls of the method in order.
+func (f *FakeClient) CallsTo(method string) []Call {
+	f.callLock.Lock()
+	defer f.callLock.Unlock()
+	var calls []Call
+	for _, c := range f.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// call records a call of the method and applies the failures injected for
+// it. Callers must not hold f.lock, as the latency would block all other
+// methods.
+func (f *FakeClient) call(method string, args ...interface{}) error {
+	f.callLock.Lock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+	if f.callCounts == nil {
+		f.callCounts = map[string]int{}
+	}
+	f.callCounts[method]++
+	count := f.callCounts[method]
+	var latency time.Duration
+	var err error
+	for _, failure := range f.failures[method] {
+		if !failure.appliesTo(count) {
+			continue
+		}
+		latency += failure.Latency
+		if err == nil {
+			err = failure.Err
+		}
+	}
+	f.callLock.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	return err
+}
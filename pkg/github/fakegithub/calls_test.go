@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakegithub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInjectFailure(t *testing.T) {
+	f := NewFakeClient()
+	errBoom := errors.New("boom")
+	f.InjectFailure("CreateComment", Failure{Calls: []int{2, 3}, Err: errBoom})
+
+	var errs []error
+	for i := 0; i < 4; i++ {
+		errs = append(errs, f.CreateComment("org", "repo", 1, "hello"))
+	}
+	for i, expected := range []error{nil, errBoom, errBoom, nil} {
+		if !errors.Is(errs[i], expected) {
+			t.Errorf("call %d: expected error %v, got %v", i+1, expected, errs[i])
+		}
+	}
+	if len(f.IssueComments[1]) != 2 {
+		t.Errorf("expected the failing calls not to create comments, got %d comments", len(f.IssueComments[1]))
+	}
+
+	if err := f.CreateCommentWithContext(context.Background(), "org", "repo", 1, "hello"); err != nil {
+		t.Errorf("expected the fifth call to succeed, got %v", err)
+	}
+}
+
+func TestInjectLatency(t *testing.T) {
+	f := NewFakeClient()
+	f.InjectFailure("GetPullRequest", Failure{Latency: 50 * time.Millisecond})
+	f.PullRequests = nil
+
+	start := time.Now()
+	_, _ = f.GetPullRequest("org", "repo", 1)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the call to take at least 50ms, took %v", elapsed)
+	}
+}
+
+func TestCallLog(t *testing.T) {
+	f := NewFakeClient()
+	_ = f.AddLabel("org", "repo", 1, "lgtm")
+	_ = f.CreateCommentWithContext(context.Background(), "org", "repo", 1, "hello")
+	_ = f.AddLabels("org", "repo", 1, "approved", "lgtm")
+
+	expected := []Call{
+		{Method: "AddLabel", Args: []interface{}{"org", "repo", 1, "lgtm"}},
+		{Method: "CreateComment", Args: []interface{}{"org", "repo", 1, "hello"}},
+		{Method: "AddLabels", Args: []interface{}{"org", "repo", 1, []string{"approved", "lgtm"}}},
+	}
+	if diff := cmp.Diff(expected, f.CallLog()); diff != "" {
+		t.Errorf("call log differs from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expected[1:2], f.CallsTo("CreateComment")); diff != "" {
+		t.Errorf("calls to CreateComment differ from expected (-want +got):\n%s", diff)
+	}
+	if !f.Used() {
+		t.Error("expected the client to be used")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"sync"
 
 	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -75,6 +76,9 @@ type FakeClient struct {
 	// org/repo#issuecommentid
 	IssueCommentsDeleted []string
 
+	// org/repo#number:reason
+	IssuesLocked []string
+
 	// org/repo#number:body
 	PullRequestReviewCommentsAdded []string
 
@@ -147,11 +151,55 @@ type FakeClient struct {
 	// lock to be thread safe
 	lock sync.RWMutex
 
+	// callLock guards the call log and the injected failures
+	callLock   sync.Mutex
+	calls      []Call
+	callCounts map[string]int
+	failures   map[string][]Failure
+
 	// Team is a map org->teamSlug->TeamWithMembers
 	Teams map[string]map[string]TeamWithMembers
 
 	// Reviewers Requested
 	ReviewersRequested []string
+
+	// org/repo#number:assignee
+	AssigneesRemoved []string
+	// org/repo#number
+	PullRequestsMerged []string
+	// org/repo#number
+	PullRequestBranchesUpdated []string
+
+	// Maps PR number to its diff and patch
+	PullRequestDiffs   map[int][]byte
+	PullRequestPatches map[int][]byte
+
+	// Maps SHA to the check runs of the commit
+	CheckRuns map[string][]github.CheckRun
+	// Maps file path to the commits that changed it
+	FileCommits map[string][]github.RepositoryCommit
+
+	// Maps org/repo to its branches
+	Branches map[string][]github.Branch
+	// Maps org/repo=branch to its protection
+	BranchProtections map[string]*github.BranchProtection
+	// Maps org/repo=branch to the last protection requested for it
+	BranchProtectionRequests map[string]github.BranchProtectionRequest
+
+	// Maps org/repo to its tags, topics and whether vulnerability alerts are enabled
+	Tags                map[string][]github.GitHubTag
+	RepoTopics          map[string][]string
+	VulnerabilityAlerts map[string]bool
+
+	// Maps user to their permission in every repo
+	UserPermissions map[string]string
+	// Maps org name to the organization
+	Organizations map[string]github.Organization
+	// Maps org/teamSlug to the repos of the team
+	TeamRepos map[string][]github.Repo
+
+	// HookID is the ID of the last created hook
+	HookID int
 }
 
 type TeamWithMembers struct {
@@ -160,6 +208,9 @@ type TeamWithMembers struct {
 }
 
 func (f *FakeClient) BotUser() (*github.UserData, error) {
+	if err := f.call("BotUser"); err != nil {
+		return nil, err
+	}
 	return &github.UserData{Login: botName}, nil
 }
 
@@ -168,6 +219,9 @@ func (f *FakeClient) BotUserCheckerWithContext(_ context.Context) (func(candidat
 }
 
 func (f *FakeClient) BotUserChecker() (func(candidate string) bool, error) {
+	if err := f.call("BotUserChecker"); err != nil {
+		return nil, err
+	}
 	return func(candidate string) bool {
 		candidate = strings.TrimSuffix(candidate, "[bot]")
 		return candidate == botName
@@ -207,6 +261,9 @@ func NewFakeClient() *FakeClient {
 
 // IsMember returns true if user is in org.
 func (f *FakeClient) IsMember(org, user string) (bool, error) {
+	if err := f.call("IsMember", org, user); err != nil {
+		return false, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	for _, m := range f.OrgMembers[org] {
@@ -218,6 +275,9 @@ func (f *FakeClient) IsMember(org, user string) (bool, error) {
 }
 
 func (f *FakeClient) WasLabelAddedByHuman(_, _ string, _ int, _ string) (bool, error) {
+	if err := f.call("WasLabelAddedByHuman"); err != nil {
+		return false, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return f.WasLabelAddedByHumanVal, nil
@@ -226,6 +286,9 @@ func (f *FakeClient) WasLabelAddedByHuman(_, _ string, _ int, _ string) (bool, e
 // ListOpenIssues returns f.issues
 // To mock a mix of issues and pull requests, see github.Issue.PullRequest
 func (f *FakeClient) ListOpenIssues(org, repo string) ([]github.Issue, error) {
+	if err := f.call("ListOpenIssues", org, repo); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	var issues []github.Issue
@@ -241,6 +304,9 @@ func (f *FakeClient) ListIssueComments(owner, repo string, number int) ([]github
 }
 
 func (f *FakeClient) ListIssueCommentsWithContext(ctx context.Context, owner, repo string, number int) ([]github.IssueComment, error) {
+	if err := f.call("ListIssueComments", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	if f.ListIssueCommentsWithContextError != nil {
@@ -251,6 +317,9 @@ func (f *FakeClient) ListIssueCommentsWithContext(ctx context.Context, owner, re
 
 // ListPullRequestComments returns review comments.
 func (f *FakeClient) ListPullRequestComments(owner, repo string, number int) ([]github.ReviewComment, error) {
+	if err := f.call("ListPullRequestComments", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return append([]github.ReviewComment{}, f.PullRequestComments[number]...), nil
@@ -258,6 +327,9 @@ func (f *FakeClient) ListPullRequestComments(owner, repo string, number int) ([]
 
 // ListReviews returns reviews.
 func (f *FakeClient) ListReviews(owner, repo string, number int) ([]github.Review, error) {
+	if err := f.call("ListReviews", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return append([]github.Review{}, f.Reviews[number]...), nil
@@ -265,6 +337,9 @@ func (f *FakeClient) ListReviews(owner, repo string, number int) ([]github.Revie
 
 // ListIssueEvents returns issue events
 func (f *FakeClient) ListIssueEvents(owner, repo string, number int) ([]github.ListedIssueEvent, error) {
+	if err := f.call("ListIssueEvents", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return append([]github.ListedIssueEvent{}, f.IssueEvents[number]...), nil
@@ -276,6 +351,9 @@ func (f *FakeClient) CreateComment(owner, repo string, number int, comment strin
 }
 
 func (f *FakeClient) CreateCommentWithContext(_ context.Context, owner, repo string, number int, comment string) error {
+	if err := f.call("CreateComment", owner, repo, number, comment); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.IssueCommentID++
@@ -294,6 +372,9 @@ func (f *FakeClient) EditComment(org, repo string, ID int, comment string) error
 }
 
 func (f *FakeClient) EditCommentWithContext(_ context.Context, org, repo string, ID int, comment string) error {
+	if err := f.call("EditComment", org, repo, ID, comment); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.IssueCommentsEdited = append(f.IssueCommentsEdited, fmt.Sprintf("%s/%s#%d:%s", org, repo, ID, comment))
@@ -309,6 +390,9 @@ func (f *FakeClient) EditCommentWithContext(_ context.Context, org, repo string,
 
 // CreateReview adds a review to a PR
 func (f *FakeClient) CreateReview(org, repo string, number int, r github.DraftReview) error {
+	if err := f.call("CreateReview", org, repo, number, r); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.ReviewID++
@@ -322,6 +406,9 @@ func (f *FakeClient) CreateReview(org, repo string, number int, r github.DraftRe
 
 // CreateCommentReaction adds emoji to a comment.
 func (f *FakeClient) CreateCommentReaction(org, repo string, ID int, reaction string) error {
+	if err := f.call("CreateCommentReaction", org, repo, ID, reaction); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.CommentReactionsAdded = append(f.CommentReactionsAdded, fmt.Sprintf("%s/%s#%d:%s", org, repo, ID, reaction))
@@ -330,6 +417,9 @@ func (f *FakeClient) CreateCommentReaction(org, repo string, ID int, reaction st
 
 // CreateIssueReaction adds an emoji to an issue.
 func (f *FakeClient) CreateIssueReaction(org, repo string, ID int, reaction string) error {
+	if err := f.call("CreateIssueReaction", org, repo, ID, reaction); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.IssueReactionsAdded = append(f.IssueReactionsAdded, fmt.Sprintf("%s/%s#%d:%s", org, repo, ID, reaction))
@@ -342,6 +432,9 @@ func (f *FakeClient) DeleteComment(owner, repo string, ID int) error {
 }
 
 func (f *FakeClient) DeleteCommentWithContext(_ context.Context, owner, repo string, ID int) error {
+	if err := f.call("DeleteComment", owner, repo, ID); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.IssueCommentsDeleted = append(f.IssueCommentsDeleted, fmt.Sprintf("%s/%s#%d", owner, repo, ID))
@@ -378,6 +471,9 @@ func (f *FakeClient) DeleteStaleCommentsWithContext(ctx context.Context, org, re
 
 // GetPullRequest returns details about the PR.
 func (f *FakeClient) GetPullRequest(owner, repo string, number int) (*github.PullRequest, error) {
+	if err := f.call("GetPullRequest", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	val, exists := f.PullRequests[number]
@@ -389,6 +485,9 @@ func (f *FakeClient) GetPullRequest(owner, repo string, number int) (*github.Pul
 
 // EditPullRequest edits the pull request.
 func (f *FakeClient) EditPullRequest(org, repo string, number int, issue *github.PullRequest) (*github.PullRequest, error) {
+	if err := f.call("EditPullRequest", org, repo, number, issue); err != nil {
+		return nil, err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	if _, exists := f.PullRequests[number]; !exists {
@@ -400,6 +499,9 @@ func (f *FakeClient) EditPullRequest(org, repo string, number int, issue *github
 
 // GetIssue returns the issue.
 func (f *FakeClient) GetIssue(owner, repo string, number int) (*github.Issue, error) {
+	if err := f.call("GetIssue", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	val, exists := f.Issues[number]
@@ -411,6 +513,9 @@ func (f *FakeClient) GetIssue(owner, repo string, number int) (*github.Issue, er
 
 // EditIssue edits the issue.
 func (f *FakeClient) EditIssue(org, repo string, number int, issue *github.Issue) (*github.Issue, error) {
+	if err := f.call("EditIssue", org, repo, number, issue); err != nil {
+		return nil, err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	if _, exists := f.Issues[number]; !exists {
@@ -422,6 +527,9 @@ func (f *FakeClient) EditIssue(org, repo string, number int, issue *github.Issue
 
 // CreateIssue creates the issue.
 func (f *FakeClient) CreateIssue(org, repo, title, body string, milestone int, labels, assignees []string) (int, error) {
+	if err := f.call("CreateIssue", org, repo, title, body, milestone, labels, assignees); err != nil {
+		return 0, err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.IssueID++
@@ -450,6 +558,9 @@ func (f *FakeClient) CreateIssue(org, repo, title, body string, milestone int, l
 }
 
 func (f *FakeClient) CloseIssue(org, repo string, number int) error {
+	if err := f.call("CloseIssue", org, repo, number); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
@@ -464,6 +575,9 @@ func (f *FakeClient) CloseIssue(org, repo string, number int) error {
 }
 
 func (f *FakeClient) CloseIssueAsNotPlanned(org, repo string, number int) error {
+	if err := f.call("CloseIssueAsNotPlanned", org, repo, number); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
@@ -477,8 +591,22 @@ func (f *FakeClient) CloseIssueAsNotPlanned(org, repo string, number int) error
 	return nil
 }
 
+// LockIssue records the locked issue.
+func (f *FakeClient) LockIssue(org, repo string, number int, reason string) error {
+	if err := f.call("LockIssue", org, repo, number, reason); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.IssuesLocked = append(f.IssuesLocked, fmt.Sprintf("%s/%s#%d:%s", org, repo, number, reason))
+	return nil
+}
+
 // GetPullRequestChanges returns the file modifications in a PR.
 func (f *FakeClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	if err := f.call("GetPullRequestChanges", org, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return f.PullRequestChanges[number], nil
@@ -486,11 +614,17 @@ func (f *FakeClient) GetPullRequestChanges(org, repo string, number int) ([]gith
 
 // GetRef returns the hash of a ref.
 func (f *FakeClient) GetRef(owner, repo, ref string) (string, error) {
+	if err := f.call("GetRef", owner, repo, ref); err != nil {
+		return "", err
+	}
 	return TestRef, nil
 }
 
 // DeleteRef returns an error indicating if deletion of the given ref was successful
 func (f *FakeClient) DeleteRef(owner, repo, ref string) error {
+	if err := f.call("DeleteRef", owner, repo, ref); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.RefsDeleted = append(f.RefsDeleted, struct{ Org, Repo, Ref string }{Org: owner, Repo: repo, Ref: ref})
@@ -499,6 +633,9 @@ func (f *FakeClient) DeleteRef(owner, repo, ref string) error {
 
 // GetSingleCommit returns a single commit.
 func (f *FakeClient) GetSingleCommit(org, repo, SHA string) (github.RepositoryCommit, error) {
+	if err := f.call("GetSingleCommit", org, repo, SHA); err != nil {
+		return github.RepositoryCommit{}, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return f.Commits[SHA], nil
@@ -509,6 +646,9 @@ func (f *FakeClient) CreateStatus(owner, repo, SHA string, s github.Status) erro
 	return f.CreateStatusWithContext(context.Background(), owner, repo, SHA, s)
 }
 func (f *FakeClient) CreateStatusWithContext(_ context.Context, owner, repo, SHA string, s github.Status) error {
+	if err := f.call("CreateStatus", owner, repo, SHA, s); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	if f.Error != nil {
@@ -538,6 +678,9 @@ func (f *FakeClient) CreateStatusWithContext(_ context.Context, owner, repo, SHA
 
 // ListStatuses returns individual status contexts on a commit.
 func (f *FakeClient) ListStatuses(org, repo, ref string) ([]github.Status, error) {
+	if err := f.call("ListStatuses", org, repo, ref); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return f.CreatedStatuses[ref], nil
@@ -545,6 +688,9 @@ func (f *FakeClient) ListStatuses(org, repo, ref string) ([]github.Status, error
 
 // GetCombinedStatus returns the overall status for a commit.
 func (f *FakeClient) GetCombinedStatus(owner, repo, ref string) (*github.CombinedStatus, error) {
+	if err := f.call("GetCombinedStatus", owner, repo, ref); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return f.CombinedStatuses[ref], nil
@@ -552,6 +698,9 @@ func (f *FakeClient) GetCombinedStatus(owner, repo, ref string) (*github.Combine
 
 // GetRepoLabels gets labels in a repo.
 func (f *FakeClient) GetRepoLabels(owner, repo string) ([]github.Label, error) {
+	if err := f.call("GetRepoLabels", owner, repo); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	la := []github.Label{}
@@ -563,6 +712,9 @@ func (f *FakeClient) GetRepoLabels(owner, repo string) ([]github.Label, error) {
 
 // AddRepoLabel adds a defined label given org/repo
 func (f *FakeClient) AddRepoLabel(org, repo, label, description, color string) error {
+	if err := f.call("AddRepoLabel", org, repo, label, description, color); err != nil {
+		return err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 
@@ -572,6 +724,9 @@ func (f *FakeClient) AddRepoLabel(org, repo, label, description, color string) e
 
 // GetIssueLabels gets labels on an issue
 func (f *FakeClient) GetIssueLabels(owner, repo string, number int) ([]github.Label, error) {
+	if err := f.call("GetIssueLabels", owner, repo, number); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	re := regexp.MustCompile(fmt.Sprintf(`^%s/%s#%d:(.*)$`, owner, repo, number))
@@ -590,12 +745,15 @@ func (f *FakeClient) GetIssueLabels(owner, repo string, number int) ([]github.La
 
 // AddLabel adds a label
 func (f *FakeClient) AddLabel(owner, repo string, number int, label string) error {
-	return f.AddLabelsWithContext(context.Background(), owner, repo, number, label)
+	return f.AddLabelWithContext(context.Background(), owner, repo, number, label)
 }
 
 // AddLabelWithContext adds a label with a provided context
 func (f *FakeClient) AddLabelWithContext(ctx context.Context, owner, repo string, number int, label string) error {
-	return f.AddLabelsWithContext(context.Background(), owner, repo, number, label)
+	if err := f.call("AddLabel", owner, repo, number, label); err != nil {
+		return err
+	}
+	return f.addLabels(owner, repo, number, label)
 }
 
 // AddLabels adds a list of labels
@@ -605,6 +763,13 @@ func (f *FakeClient) AddLabels(owner, repo string, number int, labels ...string)
 
 // AddLabelsWithContext adds a list of labels with a provided context
 func (f *FakeClient) AddLabelsWithContext(ctx context.Context, owner, repo string, number int, labels ...string) error {
+	if err := f.call("AddLabels", owner, repo, number, labels); err != nil {
+		return err
+	}
+	return f.addLabels(owner, repo, number, labels...)
+}
+
+func (f *FakeClient) addLabels(owner, repo string, number int, labels ...string) error {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	for _, label := range labels {
@@ -639,6 +804,9 @@ func (f *FakeClient) RemoveLabel(owner, repo string, number int, label string) e
 
 // RemoveLabelWithContext removes a label with a provided context
 func (f *FakeClient) RemoveLabelWithContext(ctx context.Context, owner, repo string, number int, label string) error {
+	if err := f.call("RemoveLabel", owner, repo, number, label); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	labelString := fmt.Sprintf("%s/%s#%d:%s", owner, repo, number, label)
@@ -656,6 +824,9 @@ func (f *FakeClient) FindIssues(query, sort string, asc bool) ([]github.Issue, e
 
 // FindIssuesWithOrg returns f.Issues
 func (f *FakeClient) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	if err := f.call("FindIssuesWithOrg", org, query, sort, asc); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	var issues []github.Issue
@@ -673,6 +844,9 @@ func (f *FakeClient) FindIssuesWithOrg(org, query, sort string, asc bool) ([]git
 
 // AssignIssue adds assignees.
 func (f *FakeClient) AssignIssue(owner, repo string, number int, assignees []string) error {
+	if err := f.call("AssignIssue", owner, repo, number, assignees); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	var m github.MissingUsers
@@ -691,6 +865,9 @@ func (f *FakeClient) AssignIssue(owner, repo string, number int, assignees []str
 
 // GetFile returns the bytes of the file.
 func (f *FakeClient) GetFile(org, repo, file, commit string) ([]byte, error) {
+	if err := f.call("GetFile", org, repo, file, commit); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	contents, ok := f.RemoteFiles[file]
@@ -714,6 +891,9 @@ func (f *FakeClient) GetFile(org, repo, file, commit string) ([]byte, error) {
 
 // ListTeams return a list of fake teams that correspond to the fake team members returned by ListTeamMembers
 func (f *FakeClient) ListTeams(org string) ([]github.Team, error) {
+	if err := f.call("ListTeams", org); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return []github.Team{
@@ -732,6 +912,9 @@ func (f *FakeClient) ListTeams(org string) ([]github.Team, error) {
 
 // ListTeamMembers return a fake team with a single "sig-lead" GitHub teammember
 func (f *FakeClient) ListTeamMembers(org string, teamID int, role string) ([]github.TeamMember, error) {
+	if err := f.call("ListTeamMembers", org, teamID, role); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	if role != github.RoleAll {
@@ -750,6 +933,9 @@ func (f *FakeClient) ListTeamMembers(org string, teamID int, role string) ([]git
 
 // ListTeamMembers return a fake team with a single "sig-lead" GitHub teammember
 func (f *FakeClient) ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error) {
+	if err := f.call("ListTeamMembersBySlug", org, teamSlug, role); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	if role != github.RoleAll {
@@ -767,6 +953,9 @@ func (f *FakeClient) ListTeamMembersBySlug(org, teamSlug, role string) ([]github
 }
 
 func (f *FakeClient) TeamBySlugHasMember(org string, teamSlug string, memberLogin string) (bool, error) {
+	if err := f.call("TeamBySlugHasMember", org, teamSlug, memberLogin); err != nil {
+		return false, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	if f.Teams[org] != nil {
@@ -777,6 +966,9 @@ func (f *FakeClient) TeamBySlugHasMember(org string, teamSlug string, memberLogi
 
 // IsCollaborator returns true if the user is a collaborator of the repo.
 func (f *FakeClient) IsCollaborator(org, repo, login string) (bool, error) {
+	if err := f.call("IsCollaborator", org, repo, login); err != nil {
+		return false, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	normed := github.NormLogin(login)
@@ -790,6 +982,9 @@ func (f *FakeClient) IsCollaborator(org, repo, login string) (bool, error) {
 
 // ListCollaborators lists the collaborators.
 func (f *FakeClient) ListCollaborators(org, repo string) ([]github.User, error) {
+	if err := f.call("ListCollaborators", org, repo); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	result := make([]github.User, 0, len(f.Collaborators))
@@ -801,12 +996,18 @@ func (f *FakeClient) ListCollaborators(org, repo string) ([]github.User, error)
 
 // ClearMilestone removes the milestone
 func (f *FakeClient) ClearMilestone(org, repo string, issueNum int) error {
+	if err := f.call("ClearMilestone", org, repo, issueNum); err != nil {
+		return err
+	}
 	f.Milestone = 0
 	return nil
 }
 
 // SetMilestone sets the milestone.
 func (f *FakeClient) SetMilestone(org, repo string, issueNum, milestoneNum int) error {
+	if err := f.call("SetMilestone", org, repo, issueNum, milestoneNum); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	if milestoneNum < 0 {
@@ -818,6 +1019,9 @@ func (f *FakeClient) SetMilestone(org, repo string, issueNum, milestoneNum int)
 
 // ListMilestones lists milestones.
 func (f *FakeClient) ListMilestones(org, repo string) ([]github.Milestone, error) {
+	if err := f.call("ListMilestones", org, repo); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	milestones := []github.Milestone{}
@@ -829,6 +1033,9 @@ func (f *FakeClient) ListMilestones(org, repo string) ([]github.Milestone, error
 
 // ListPullRequestCommits lists commits for a given PR.
 func (f *FakeClient) ListPullRequestCommits(org, repo string, prNumber int) ([]github.RepositoryCommit, error) {
+	if err := f.call("ListPullRequestCommits", org, repo, prNumber); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	k := fmt.Sprintf("%s/%s#%d", org, repo, prNumber)
@@ -837,6 +1044,9 @@ func (f *FakeClient) ListPullRequestCommits(org, repo string, prNumber int) ([]g
 
 // GetRepoProjects returns the list of projects under a repo.
 func (f *FakeClient) GetRepoProjects(owner, repo string) ([]github.Project, error) {
+	if err := f.call("GetRepoProjects", owner, repo); err != nil {
+		return nil, err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	return f.RepoProjects[fmt.Sprintf("%s/%s", owner, repo)], nil
@@ -844,6 +1054,9 @@ func (f *FakeClient) GetRepoProjects(owner, repo string) ([]github.Project, erro
 
 // GetOrgProjects returns the list of projects under an org
 func (f *FakeClient) GetOrgProjects(org string) ([]github.Project, error) {
+	if err := f.call("GetOrgProjects", org); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	return f.RepoProjects[fmt.Sprintf("%s/*", org)], nil
@@ -851,6 +1064,9 @@ func (f *FakeClient) GetOrgProjects(org string) ([]github.Project, error) {
 
 // GetProjectColumns returns the list of columns for a given project.
 func (f *FakeClient) GetProjectColumns(org string, projectID int) ([]github.ProjectColumn, error) {
+	if err := f.call("GetProjectColumns", org, projectID); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	defer f.lock.RUnlock()
 	// Get project name
@@ -866,6 +1082,9 @@ func (f *FakeClient) GetProjectColumns(org string, projectID int) ([]github.Proj
 
 // CreateProjectCard creates a project card under a given column.
 func (f *FakeClient) CreateProjectCard(org string, columnID int, projectCard github.ProjectCard) (*github.ProjectCard, error) {
+	if err := f.call("CreateProjectCard", org, columnID, projectCard); err != nil {
+		return nil, err
+	}
 	cards, err := f.GetColumnProjectCards(org, columnID)
 	if err != nil {
 		return nil, err
@@ -901,6 +1120,9 @@ func (f *FakeClient) CreateProjectCard(org string, columnID int, projectCard git
 
 // DeleteProjectCard deletes the project card of a specific issue or PR
 func (f *FakeClient) DeleteProjectCard(org string, projectCardID int) error {
+	if err := f.call("DeleteProjectCard", org, projectCardID); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	if f.ColumnCardsMap == nil {
@@ -935,6 +1157,9 @@ func (f *FakeClient) DeleteProjectCard(org string, projectCardID int) error {
 
 // GetColumnProjectCards fetches project cards  under given column
 func (f *FakeClient) GetColumnProjectCards(org string, columnID int) ([]github.ProjectCard, error) {
+	if err := f.call("GetColumnProjectCards", org, columnID); err != nil {
+		return nil, err
+	}
 	f.lock.RLock()
 	if f.ColumnCardsMap == nil {
 		f.ColumnCardsMap = make(map[int][]github.ProjectCard)
@@ -946,6 +1171,9 @@ func (f *FakeClient) GetColumnProjectCards(org string, columnID int) ([]github.P
 
 // GetColumnProjectCard fetches project card if the content_url in the card matched the issue/pr
 func (f *FakeClient) GetColumnProjectCard(org string, columnID int, contentURL string) (*github.ProjectCard, error) {
+	if err := f.call("GetColumnProjectCard", org, columnID, contentURL); err != nil {
+		return nil, err
+	}
 	cards, err := f.GetColumnProjectCards(org, columnID)
 	if err != nil {
 		return nil, err
@@ -959,6 +1187,9 @@ func (f *FakeClient) GetColumnProjectCard(org string, columnID int, contentURL s
 }
 
 func (f *FakeClient) GetRepos(org string, isUser bool) ([]github.Repo, error) {
+	if err := f.call("GetRepos", org, isUser); err != nil {
+		return nil, err
+	}
 	return []github.Repo{
 		{
 			Owner: github.User{
@@ -976,6 +1207,9 @@ func (f *FakeClient) GetRepos(org string, isUser bool) ([]github.Repo, error) {
 }
 
 func (f *FakeClient) GetRepo(owner, name string) (github.FullRepo, error) {
+	if err := f.call("GetRepo", owner, name); err != nil {
+		return github.FullRepo{}, err
+	}
 	if f.GetRepoError != nil {
 		return github.FullRepo{}, f.GetRepoError
 	}
@@ -993,6 +1227,9 @@ func (f *FakeClient) GetRepo(owner, name string) (github.FullRepo, error) {
 
 // MoveProjectCard moves a specific project card to a specified column in the same project
 func (f *FakeClient) MoveProjectCard(org string, projectCardID int, newColumnID int) error {
+	if err := f.call("MoveProjectCard", org, projectCardID, newColumnID); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	// Remove project card from old column
@@ -1038,6 +1275,9 @@ func (f *FakeClient) MoveProjectCard(org string, projectCardID int, newColumnID
 
 // TeamHasMember checks if a user belongs to a team
 func (f *FakeClient) TeamHasMember(org string, teamID int, memberLogin string) (bool, error) {
+	if err := f.call("TeamHasMember", org, teamID, memberLogin); err != nil {
+		return false, err
+	}
 	teamMembers, _ := f.ListTeamMembers(org, teamID, github.RoleAll)
 	for _, member := range teamMembers {
 		if member.Login == memberLogin {
@@ -1048,6 +1288,9 @@ func (f *FakeClient) TeamHasMember(org string, teamID int, memberLogin string) (
 }
 
 func (f *FakeClient) GetTeamBySlug(slug string, org string) (*github.Team, error) {
+	if err := f.call("GetTeamBySlug", slug, org); err != nil {
+		return nil, err
+	}
 	teams, _ := f.ListTeams(org)
 	for _, team := range teams {
 		if team.Name == slug {
@@ -1058,6 +1301,9 @@ func (f *FakeClient) GetTeamBySlug(slug string, org string) (*github.Team, error
 }
 
 func (f *FakeClient) CreatePullRequest(org, repo, title, body, head, base string, canModify bool) (int, error) {
+	if err := f.call("CreatePullRequest", org, repo, title, body, head, base, canModify); err != nil {
+		return 0, err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	if f.PullRequests == nil {
@@ -1085,6 +1331,9 @@ func (f *FakeClient) CreatePullRequest(org, repo, title, body, head, base string
 }
 
 func (f *FakeClient) UpdatePullRequest(org, repo string, number int, title, body *string, open *bool, branch *string, canModify *bool) error {
+	if err := f.call("UpdatePullRequest", org, repo, number, title, body, open, branch, canModify); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	pr, found := f.PullRequests[number]
@@ -1103,11 +1352,17 @@ func (f *FakeClient) UpdatePullRequest(org, repo string, number int, title, body
 // Query simply exists to allow the fake client to match the interface for packages that need it.
 // It does not modify the passed interface at all.
 func (f *FakeClient) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
+	if err := f.call("Query", q, vars); err != nil {
+		return err
+	}
 	return nil
 }
 
 // GetDirectory returns the contents of the file.
 func (f *FakeClient) GetDirectory(org, repo, dir, commit string) ([]github.DirectoryContent, error) {
+	if err := f.call("GetDirectory", org, repo, dir, commit); err != nil {
+		return nil, err
+	}
 	contents, ok := f.RemoteDirectories[dir]
 	if !ok {
 		return nil, fmt.Errorf("could not find dir %s", dir)
@@ -1129,6 +1384,9 @@ func (f *FakeClient) GetDirectory(org, repo, dir, commit string) ([]github.Direc
 
 // CreatePullRequestReviewComment adds a comment on a PR.
 func (f *FakeClient) CreatePullRequestReviewComment(owner, repo string, number int, rc github.ReviewComment) error {
+	if err := f.call("CreatePullRequestReviewComment", owner, repo, number, rc); err != nil {
+		return err
+	}
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	f.PullRequestReviewCommentID++
@@ -1138,6 +1396,9 @@ func (f *FakeClient) CreatePullRequestReviewComment(owner, repo string, number i
 }
 
 func (f *FakeClient) ListCurrentUserRepoInvitations() ([]github.UserRepoInvitation, error) {
+	if err := f.call("ListCurrentUserRepoInvitations"); err != nil {
+		return nil, err
+	}
 	var ret []github.UserRepoInvitation
 	for _, inv := range f.UserRepoInvitations {
 		ret = append(ret, inv)
@@ -1151,6 +1412,9 @@ func (f *FakeClient) ListCurrentUserRepoInvitations() ([]github.UserRepoInvitati
 }
 
 func (f *FakeClient) AcceptUserRepoInvitation(invitationID int) error {
+	if err := f.call("AcceptUserRepoInvitation", invitationID); err != nil {
+		return err
+	}
 	if _, ok := f.UserRepoInvitations[invitationID]; !ok {
 		return fmt.Errorf("couldn't find invitation id: %d", invitationID)
 	}
@@ -1160,6 +1424,9 @@ func (f *FakeClient) AcceptUserRepoInvitation(invitationID int) error {
 }
 
 func (f *FakeClient) AcceptUserOrgInvitation(org string) error {
+	if err := f.call("AcceptUserOrgInvitation", org); err != nil {
+		return err
+	}
 	if _, ok := f.UserOrgInvitations[org]; !ok {
 		return fmt.Errorf("couldn't find invitation for org: %s", org)
 	}
@@ -1169,6 +1436,9 @@ func (f *FakeClient) AcceptUserOrgInvitation(org string) error {
 }
 
 func (f *FakeClient) ListCurrentUserOrgInvitations() ([]github.UserOrgInvitation, error) {
+	if err := f.call("ListCurrentUserOrgInvitations"); err != nil {
+		return nil, err
+	}
 	var ret []github.UserOrgInvitation
 	for _, inv := range f.UserOrgInvitations {
 		ret = append(ret, inv)
@@ -1182,22 +1452,929 @@ func (f *FakeClient) ListCurrentUserOrgInvitations() ([]github.UserOrgInvitation
 }
 
 func (f *FakeClient) MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error {
+	if err := f.call("MutateWithGitHubAppsSupport", m, input, vars, org); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (f *FakeClient) GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]github.WorkflowRun, error) {
+	if err := f.call("GetFailedActionRunsByHeadBranch", org, repo, branchName, headSHA); err != nil {
+		return nil, err
+	}
 	return []github.WorkflowRun{}, nil
 }
 
 func (f *FakeClient) TriggerGitHubWorkflow(org, repo string, id int) error {
+	if err := f.call("TriggerGitHubWorkflow", org, repo, id); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (f *FakeClient) TriggerFailedGitHubWorkflow(org, repo string, id int) error {
+	if err := f.call("TriggerFailedGitHubWorkflow", org, repo, id); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (f *FakeClient) RequestReview(org, repo string, number int, logins []string) error {
+	if err := f.call("RequestReview", org, repo, number, logins); err != nil {
+		return err
+	}
 	f.ReviewersRequested = logins
 	return nil
 }
+
+// UnrequestReview removes the logins from the requested reviewers.
+func (f *FakeClient) UnrequestReview(org, repo string, number int, logins []string) error {
+	if err := f.call("UnrequestReview", org, repo, number, logins); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	unrequested := sets.New[string](logins...)
+	var requested []string
+	for _, login := range f.ReviewersRequested {
+		if !unrequested.Has(login) {
+			requested = append(requested, login)
+		}
+	}
+	f.ReviewersRequested = requested
+	return nil
+}
+
+// UnassignIssue removes assignees.
+func (f *FakeClient) UnassignIssue(owner, repo string, number int, assignees []string) error {
+	if err := f.call("UnassignIssue", owner, repo, number, assignees); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, a := range assignees {
+		f.AssigneesRemoved = append(f.AssigneesRemoved, fmt.Sprintf("%s/%s#%d:%s", owner, repo, number, a))
+	}
+	if issue, ok := f.Issues[number]; ok {
+		unassigned := sets.New[string](assignees...)
+		var remaining []github.User
+		for _, user := range issue.Assignees {
+			if !unassigned.Has(user.Login) {
+				remaining = append(remaining, user)
+			}
+		}
+		issue.Assignees = remaining
+	}
+	return nil
+}
+
+// ReopenIssue reopens the issue.
+func (f *FakeClient) ReopenIssue(org, repo string, number int) error {
+	if err := f.call("ReopenIssue", org, repo, number); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	issue, ok := f.Issues[number]
+	if !ok {
+		return fmt.Errorf("issue number %d does not exist", number)
+	}
+	issue.State = "open"
+	issue.StateReason = "reopened"
+	return nil
+}
+
+// ClosePullRequest closes the pull request.
+func (f *FakeClient) ClosePullRequest(org, repo string, number int) error {
+	return f.setPullRequestState("ClosePullRequest", org, repo, number, "closed")
+}
+
+// ReopenPullRequest reopens the pull request.
+func (f *FakeClient) ReopenPullRequest(org, repo string, number int) error {
+	return f.setPullRequestState("ReopenPullRequest", org, repo, number, "open")
+}
+
+func (f *FakeClient) setPullRequestState(method, org, repo string, number int, state string) error {
+	if err := f.call(method, org, repo, number); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	pr, ok := f.PullRequests[number]
+	if !ok {
+		return fmt.Errorf("pull request number %d does not exist", number)
+	}
+	pr.State = state
+	return nil
+}
+
+// GetPullRequests returns the open pull requests.
+func (f *FakeClient) GetPullRequests(org, repo string) ([]github.PullRequest, error) {
+	if err := f.call("GetPullRequests", org, repo); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var prs []github.PullRequest
+	for _, pr := range f.PullRequests {
+		if pr.State == "" || pr.State == "open" {
+			prs = append(prs, *pr)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+	return prs, nil
+}
+
+// GetPullRequestDiff returns the diff of the pull request.
+func (f *FakeClient) GetPullRequestDiff(org, repo string, number int) ([]byte, error) {
+	if err := f.call("GetPullRequestDiff", org, repo, number); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.PullRequestDiffs[number], nil
+}
+
+// GetPullRequestPatch returns the patch of the pull request.
+func (f *FakeClient) GetPullRequestPatch(org, repo string, number int) ([]byte, error) {
+	if err := f.call("GetPullRequestPatch", org, repo, number); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.PullRequestPatches[number], nil
+}
+
+// UpdatePullRequestBranch records that the branch of the pull request was updated.
+func (f *FakeClient) UpdatePullRequestBranch(org, repo string, number int, expectedHeadSha *string) error {
+	if err := f.call("UpdatePullRequestBranch", org, repo, number, expectedHeadSha); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	pr, ok := f.PullRequests[number]
+	if !ok {
+		return fmt.Errorf("pull request number %d does not exist", number)
+	}
+	if expectedHeadSha != nil && *expectedHeadSha != pr.Head.SHA {
+		return fmt.Errorf("expected head SHA %s, got %s", *expectedHeadSha, pr.Head.SHA)
+	}
+	f.PullRequestBranchesUpdated = append(f.PullRequestBranchesUpdated, fmt.Sprintf("%s/%s#%d", org, repo, number))
+	return nil
+}
+
+// IsMergeable returns whether the pull request is mergeable at the SHA.
+func (f *FakeClient) IsMergeable(org, repo string, number int, SHA string) (bool, error) {
+	if err := f.call("IsMergeable", org, repo, number, SHA); err != nil {
+		return false, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	pr, ok := f.PullRequests[number]
+	if !ok {
+		return false, fmt.Errorf("pull request number %d does not exist", number)
+	}
+	if pr.Head.SHA != SHA {
+		return false, fmt.Errorf("pull request head changed while checking mergeability (%s -> %s)", SHA, pr.Head.SHA)
+	}
+	if pr.Merged {
+		return false, errors.New("pull request was merged while checking mergeability")
+	}
+	return pr.Mergable == nil || *pr.Mergable, nil
+}
+
+// Merge merges the pull request.
+func (f *FakeClient) Merge(org, repo string, number int, details github.MergeDetails) error {
+	if err := f.call("Merge", org, repo, number, details); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	pr, ok := f.PullRequests[number]
+	if !ok {
+		return fmt.Errorf("pull request number %d does not exist", number)
+	}
+	if details.SHA != "" && details.SHA != pr.Head.SHA {
+		return github.ModifiedHeadError("head SHA changed")
+	}
+	if pr.Mergable != nil && !*pr.Mergable {
+		return github.UnmergablePRError("pull request is not mergeable")
+	}
+	pr.Merged = true
+	pr.State = "closed"
+	f.PullRequestsMerged = append(f.PullRequestsMerged, fmt.Sprintf("%s/%s#%d", org, repo, number))
+	return nil
+}
+
+// CreateCheckRun adds a check run to a commit.
+func (f *FakeClient) CreateCheckRun(org, repo string, checkRun github.CheckRun) error {
+	if err := f.call("CreateCheckRun", org, repo, checkRun); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.CheckRuns == nil {
+		f.CheckRuns = map[string][]github.CheckRun{}
+	}
+	f.CheckRuns[checkRun.HeadSHA] = append(f.CheckRuns[checkRun.HeadSHA], checkRun)
+	return nil
+}
+
+// ListCheckRuns lists the check runs of a ref.
+func (f *FakeClient) ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error) {
+	if err := f.call("ListCheckRuns", org, repo, ref); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	checkRuns := append([]github.CheckRun{}, f.CheckRuns[ref]...)
+	return &github.CheckRunList{Total: len(checkRuns), CheckRuns: checkRuns}, nil
+}
+
+// ListFileCommits returns the commits that changed the file.
+func (f *FakeClient) ListFileCommits(org, repo, path string) ([]github.RepositoryCommit, error) {
+	if err := f.call("ListFileCommits", org, repo, path); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]github.RepositoryCommit{}, f.FileCommits[path]...), nil
+}
+
+// GetBranches returns the branches of the repo.
+func (f *FakeClient) GetBranches(org, repo string, onlyProtected bool) ([]github.Branch, error) {
+	if err := f.call("GetBranches", org, repo, onlyProtected); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var branches []github.Branch
+	for _, branch := range f.Branches[org+"/"+repo] {
+		if !onlyProtected || branch.Protected {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
+
+// GetBranchProtection returns the protection of the branch or nil if it is not protected.
+func (f *FakeClient) GetBranchProtection(org, repo, branch string) (*github.BranchProtection, error) {
+	if err := f.call("GetBranchProtection", org, repo, branch); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.BranchProtections[org+"/"+repo+"="+branch], nil
+}
+
+// UpdateBranchProtection records the requested protection of the branch.
+func (f *FakeClient) UpdateBranchProtection(org, repo, branch string, config github.BranchProtectionRequest) error {
+	if err := f.call("UpdateBranchProtection", org, repo, branch, config); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.BranchProtectionRequests == nil {
+		f.BranchProtectionRequests = map[string]github.BranchProtectionRequest{}
+	}
+	f.BranchProtectionRequests[org+"/"+repo+"="+branch] = config
+	return nil
+}
+
+// RemoveBranchProtection removes the protection of the branch.
+func (f *FakeClient) RemoveBranchProtection(org, repo, branch string) error {
+	if err := f.call("RemoveBranchProtection", org, repo, branch); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := org + "/" + repo + "=" + branch
+	delete(f.BranchProtections, key)
+	delete(f.BranchProtectionRequests, key)
+	return nil
+}
+
+// ListTags returns the tags of the repo.
+func (f *FakeClient) ListTags(org, repo string) ([]github.GitHubTag, error) {
+	if err := f.call("ListTags", org, repo); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]github.GitHubTag{}, f.Tags[org+"/"+repo]...), nil
+}
+
+// CreateRepo creates the repo from the request.
+func (f *FakeClient) CreateRepo(owner string, isUser bool, repo github.RepoCreateRequest) (*github.FullRepo, error) {
+	if err := f.call("CreateRepo", owner, isUser, repo); err != nil {
+		return nil, err
+	}
+	created := repo.ToRepo()
+	created.Owner = github.User{Login: owner}
+	return created, nil
+}
+
+// UpdateRepo updates the repo from the request.
+func (f *FakeClient) UpdateRepo(owner, name string, repo github.RepoUpdateRequest) (*github.FullRepo, error) {
+	if err := f.call("UpdateRepo", owner, name, repo); err != nil {
+		return nil, err
+	}
+	updated := repo.ToRepo()
+	updated.Owner = github.User{Login: owner}
+	if updated.Name == "" {
+		updated.Name = name
+	}
+	return updated, nil
+}
+
+// CreateFork returns the name of the fork of the repo.
+func (f *FakeClient) CreateFork(owner, repo string) (string, error) {
+	if err := f.call("CreateFork", owner, repo); err != nil {
+		return "", err
+	}
+	return repo, nil
+}
+
+// EnsureFork returns the name of the fork of the repo.
+func (f *FakeClient) EnsureFork(forkingUser, org, repo string) (string, error) {
+	if err := f.call("EnsureFork", forkingUser, org, repo); err != nil {
+		return "", err
+	}
+	return repo, nil
+}
+
+// ReplaceRepoTopics replaces the topics of the repo.
+func (f *FakeClient) ReplaceRepoTopics(org, repo string, topics []string) error {
+	if err := f.call("ReplaceRepoTopics", org, repo, topics); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.RepoTopics == nil {
+		f.RepoTopics = map[string][]string{}
+	}
+	f.RepoTopics[org+"/"+repo] = topics
+	return nil
+}
+
+// SetVulnerabilityAlerts enables or disables vulnerability alerts of the repo.
+func (f *FakeClient) SetVulnerabilityAlerts(org, repo string, enabled bool) error {
+	if err := f.call("SetVulnerabilityAlerts", org, repo, enabled); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.VulnerabilityAlerts == nil {
+		f.VulnerabilityAlerts = map[string]bool{}
+	}
+	f.VulnerabilityAlerts[org+"/"+repo] = enabled
+	return nil
+}
+
+// VulnerabilityAlertsEnabled returns whether vulnerability alerts of the repo are enabled.
+func (f *FakeClient) VulnerabilityAlertsEnabled(org, repo string) (bool, error) {
+	if err := f.call("VulnerabilityAlertsEnabled", org, repo); err != nil {
+		return false, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.VulnerabilityAlerts[org+"/"+repo], nil
+}
+
+// DeleteRepoLabel deletes a label of the repo.
+func (f *FakeClient) DeleteRepoLabel(org, repo, label string) error {
+	if err := f.call("DeleteRepoLabel", org, repo, label); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, l := range f.RepoLabelsExisting {
+		if l == label {
+			f.RepoLabelsExisting = append(f.RepoLabelsExisting[:i], f.RepoLabelsExisting[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("label %s does not exist", label)
+}
+
+// UpdateRepoLabel renames a label of the repo.
+func (f *FakeClient) UpdateRepoLabel(org, repo, label, newName, description, color string) error {
+	if err := f.call("UpdateRepoLabel", org, repo, label, newName, description, color); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, l := range f.RepoLabelsExisting {
+		if l == label {
+			f.RepoLabelsExisting[i] = newName
+			return nil
+		}
+	}
+	return fmt.Errorf("label %s does not exist", label)
+}
+
+// GetUserPermission returns the permission of the user in the repo. Users
+// without a permission in UserPermissions have write permission if they are
+// collaborators and read permission otherwise.
+func (f *FakeClient) GetUserPermission(org, repo, user string) (string, error) {
+	if err := f.call("GetUserPermission", org, repo, user); err != nil {
+		return "", err
+	}
+	return f.userPermission(user), nil
+}
+
+// HasPermission returns true if the user has one of the roles in the repo.
+func (f *FakeClient) HasPermission(org, repo, user string, roles ...string) (bool, error) {
+	if err := f.call("HasPermission", org, repo, user, roles); err != nil {
+		return false, err
+	}
+	permission := f.userPermission(user)
+	for _, role := range roles {
+		if role == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakeClient) userPermission(user string) string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	if permission, ok := f.UserPermissions[user]; ok {
+		return permission
+	}
+	for _, collaborator := range f.Collaborators {
+		if github.NormLogin(collaborator) == github.NormLogin(user) {
+			return string(github.Write)
+		}
+	}
+	return string(github.Read)
+}
+
+// GetOrg returns the organization.
+func (f *FakeClient) GetOrg(name string) (*github.Organization, error) {
+	if err := f.call("GetOrg", name); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	if org, ok := f.Organizations[name]; ok {
+		return &org, nil
+	}
+	return &github.Organization{Login: name}, nil
+}
+
+// EditOrg replaces the organization.
+func (f *FakeClient) EditOrg(name string, config github.Organization) (*github.Organization, error) {
+	if err := f.call("EditOrg", name, config); err != nil {
+		return nil, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.Organizations == nil {
+		f.Organizations = map[string]github.Organization{}
+	}
+	f.Organizations[name] = config
+	return &config, nil
+}
+
+// ListOrgMembers returns the members of the org.
+func (f *FakeClient) ListOrgMembers(org, role string) ([]github.TeamMember, error) {
+	if err := f.call("ListOrgMembers", org, role); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var members []github.TeamMember
+	for _, login := range f.OrgMembers[org] {
+		members = append(members, github.TeamMember{Login: login})
+	}
+	return members, nil
+}
+
+// ListOrgInvitations returns no invitations.
+func (f *FakeClient) ListOrgInvitations(org string) ([]github.OrgInvitation, error) {
+	if err := f.call("ListOrgInvitations", org); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// UpdateOrgMembership adds the user to the org.
+func (f *FakeClient) UpdateOrgMembership(org, user string, admin bool) (*github.OrgMembership, error) {
+	if err := f.call("UpdateOrgMembership", org, user, admin); err != nil {
+		return nil, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !sets.New[string](f.OrgMembers[org]...).Has(user) {
+		f.OrgMembers[org] = append(f.OrgMembers[org], user)
+	}
+	role := github.RoleMember
+	if admin {
+		role = github.RoleAdmin
+	}
+	return &github.OrgMembership{Membership: github.Membership{Role: role, State: github.StateActive}}, nil
+}
+
+// RemoveOrgMembership removes the user from the org.
+func (f *FakeClient) RemoveOrgMembership(org, user string) error {
+	if err := f.call("RemoveOrgMembership", org, user); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var members []string
+	for _, member := range f.OrgMembers[org] {
+		if member != user {
+			members = append(members, member)
+		}
+	}
+	f.OrgMembers[org] = members
+	return nil
+}
+
+// CreateTeam creates the team in Teams.
+func (f *FakeClient) CreateTeam(org string, team github.Team) (*github.Team, error) {
+	if err := f.call("CreateTeam", org, team); err != nil {
+		return nil, err
+	}
+	if team.Name == "" {
+		return nil, errors.New("team.Name must be non-empty")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if team.Slug == "" {
+		team.Slug = strings.ToLower(strings.ReplaceAll(team.Name, " ", "-"))
+	}
+	if f.Teams == nil {
+		f.Teams = map[string]map[string]TeamWithMembers{}
+	}
+	if f.Teams[org] == nil {
+		f.Teams[org] = map[string]TeamWithMembers{}
+	}
+	if _, exists := f.Teams[org][team.Slug]; exists {
+		return nil, fmt.Errorf("team %s already exists", team.Slug)
+	}
+	f.Teams[org][team.Slug] = TeamWithMembers{Team: team, Members: sets.New[string]()}
+	return &team, nil
+}
+
+// EditTeam replaces the team in Teams.
+func (f *FakeClient) EditTeam(org string, team github.Team) (*github.Team, error) {
+	if err := f.call("EditTeam", org, team); err != nil {
+		return nil, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	existing, ok := f.Teams[org][team.Slug]
+	if !ok {
+		return nil, fmt.Errorf("team %s does not exist", team.Slug)
+	}
+	existing.Team = team
+	f.Teams[org][team.Slug] = existing
+	return &team, nil
+}
+
+// DeleteTeamBySlug deletes the team from Teams.
+func (f *FakeClient) DeleteTeamBySlug(org, teamSlug string) error {
+	if err := f.call("DeleteTeamBySlug", org, teamSlug); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.Teams[org][teamSlug]; !ok {
+		return fmt.Errorf("team %s does not exist", teamSlug)
+	}
+	delete(f.Teams[org], teamSlug)
+	return nil
+}
+
+// UpdateTeamMembershipBySlug adds the user to the team in Teams.
+func (f *FakeClient) UpdateTeamMembershipBySlug(org, teamSlug, user string, maintainer bool) (*github.TeamMembership, error) {
+	if err := f.call("UpdateTeamMembershipBySlug", org, teamSlug, user, maintainer); err != nil {
+		return nil, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	team, ok := f.Teams[org][teamSlug]
+	if !ok {
+		return nil, fmt.Errorf("team %s does not exist", teamSlug)
+	}
+	if team.Members == nil {
+		team.Members = sets.New[string]()
+		f.Teams[org][teamSlug] = team
+	}
+	team.Members.Insert(user)
+	role := github.RoleMember
+	if maintainer {
+		role = github.RoleMaintainer
+	}
+	return &github.TeamMembership{Membership: github.Membership{Role: role, State: github.StateActive}}, nil
+}
+
+// RemoveTeamMembershipBySlug removes the user from the team in Teams.
+func (f *FakeClient) RemoveTeamMembershipBySlug(org, teamSlug, user string) error {
+	if err := f.call("RemoveTeamMembershipBySlug", org, teamSlug, user); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	team, ok := f.Teams[org][teamSlug]
+	if !ok {
+		return fmt.Errorf("team %s does not exist", teamSlug)
+	}
+	team.Members.Delete(user)
+	return nil
+}
+
+// ListTeamInvitationsBySlug returns no invitations.
+func (f *FakeClient) ListTeamInvitationsBySlug(org, teamSlug string) ([]github.OrgInvitation, error) {
+	if err := f.call("ListTeamInvitationsBySlug", org, teamSlug); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ListTeamReposBySlug returns the repos of the team.
+func (f *FakeClient) ListTeamReposBySlug(org, teamSlug string) ([]github.Repo, error) {
+	if err := f.call("ListTeamReposBySlug", org, teamSlug); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]github.Repo{}, f.TeamRepos[org+"/"+teamSlug]...), nil
+}
+
+// UpdateTeamRepoBySlug gives the team the permission in the repo.
+func (f *FakeClient) UpdateTeamRepoBySlug(org, teamSlug, repo string, permission github.TeamPermission) error {
+	if err := f.call("UpdateTeamRepoBySlug", org, teamSlug, repo, permission); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.TeamRepos == nil {
+		f.TeamRepos = map[string][]github.Repo{}
+	}
+	key := org + "/" + teamSlug
+	updated := github.Repo{Owner: github.User{Login: org}, Name: repo, Permissions: github.PermissionsFromTeamPermission(permission)}
+	for i, r := range f.TeamRepos[key] {
+		if r.Name == repo {
+			f.TeamRepos[key][i] = updated
+			return nil
+		}
+	}
+	f.TeamRepos[key] = append(f.TeamRepos[key], updated)
+	return nil
+}
+
+// RemoveTeamRepoBySlug removes the repo from the team.
+func (f *FakeClient) RemoveTeamRepoBySlug(org, teamSlug, repo string) error {
+	if err := f.call("RemoveTeamRepoBySlug", org, teamSlug, repo); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := org + "/" + teamSlug
+	for i, r := range f.TeamRepos[key] {
+		if r.Name == repo {
+			f.TeamRepos[key] = append(f.TeamRepos[key][:i], f.TeamRepos[key][i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// ListRepoTeams returns the teams that have access to the repo.
+func (f *FakeClient) ListRepoTeams(org, repo string) ([]github.Team, error) {
+	if err := f.call("ListRepoTeams", org, repo); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var teams []github.Team
+	for slug, team := range f.Teams[org] {
+		for _, r := range f.TeamRepos[org+"/"+slug] {
+			if r.Name == repo {
+				teams = append(teams, team.Team)
+			}
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Slug < teams[j].Slug })
+	return teams, nil
+}
+
+// ListOrgHooks returns the hooks of the org.
+func (f *FakeClient) ListOrgHooks(org string) ([]github.Hook, error) {
+	if err := f.call("ListOrgHooks", org); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]github.Hook{}, f.OrgHooks[org]...), nil
+}
+
+// ListRepoHooks returns the hooks of the repo.
+func (f *FakeClient) ListRepoHooks(org, repo string) ([]github.Hook, error) {
+	if err := f.call("ListRepoHooks", org, repo); err != nil {
+		return nil, err
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]github.Hook{}, f.RepoHooks[org+"/"+repo]...), nil
+}
+
+// CreateOrgHook creates a hook for the org.
+func (f *FakeClient) CreateOrgHook(org string, req github.HookRequest) (int, error) {
+	if err := f.call("CreateOrgHook", org, req); err != nil {
+		return 0, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.createHook(f.OrgHooks, org, req), nil
+}
+
+// CreateRepoHook creates a hook for the repo.
+func (f *FakeClient) CreateRepoHook(org, repo string, req github.HookRequest) (int, error) {
+	if err := f.call("CreateRepoHook", org, repo, req); err != nil {
+		return 0, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.createHook(f.RepoHooks, org+"/"+repo, req), nil
+}
+
+func (f *FakeClient) createHook(hooks map[string][]github.Hook, key string, req github.HookRequest) int {
+	f.HookID++
+	hook := github.Hook{ID: f.HookID, Name: "web", Events: req.Events, Active: true}
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+	if req.Config != nil {
+		hook.Config = *req.Config
+	}
+	hooks[key] = append(hooks[key], hook)
+	return hook.ID
+}
+
+// EditOrgHook edits a hook of the org.
+func (f *FakeClient) EditOrgHook(org string, id int, req github.HookRequest) error {
+	if err := f.call("EditOrgHook", org, id, req); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return editHook(f.OrgHooks, org, id, req)
+}
+
+// EditRepoHook edits a hook of the repo.
+func (f *FakeClient) EditRepoHook(org, repo string, id int, req github.HookRequest) error {
+	if err := f.call("EditRepoHook", org, repo, id, req); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return editHook(f.RepoHooks, org+"/"+repo, id, req)
+}
+
+func editHook(hooks map[string][]github.Hook, key string, id int, req github.HookRequest) error {
+	for i := range hooks[key] {
+		hook := &hooks[key][i]
+		if hook.ID != id {
+			continue
+		}
+		if req.Active != nil {
+			hook.Active = *req.Active
+		}
+		if req.Config != nil {
+			hook.Config = *req.Config
+		}
+		if req.Events != nil {
+			hook.Events = req.Events
+		}
+		return nil
+	}
+	return fmt.Errorf("hook %d of %s does not exist", id, key)
+}
+
+// DeleteOrgHook deletes a hook of the org.
+func (f *FakeClient) DeleteOrgHook(org string, id int, req github.HookRequest) error {
+	if err := f.call("DeleteOrgHook", org, id, req); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return deleteHook(f.OrgHooks, org, id)
+}
+
+// DeleteRepoHook deletes a hook of the repo.
+func (f *FakeClient) DeleteRepoHook(org, repo string, id int, req github.HookRequest) error {
+	if err := f.call("DeleteRepoHook", org, repo, id, req); err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return deleteHook(f.RepoHooks, org+"/"+repo, id)
+}
+
+func deleteHook(hooks map[string][]github.Hook, key string, id int) error {
+	for i, hook := range hooks[key] {
+		if hook.ID == id {
+			hooks[key] = append(hooks[key][:i], hooks[key][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("hook %d of %s does not exist", id, key)
+}
+
+// Email returns the email of the bot.
+func (f *FakeClient) Email() (string, error) {
+	if err := f.call("Email"); err != nil {
+		return "", err
+	}
+	return botName + "@users.noreply.github.com", nil
+}
+
+// GetApp returns a fake app.
+func (f *FakeClient) GetApp() (*github.App, error) {
+	return f.GetAppWithContext(context.Background())
+}
+
+// GetAppWithContext returns a fake app.
+func (f *FakeClient) GetAppWithContext(_ context.Context) (*github.App, error) {
+	if err := f.call("GetApp"); err != nil {
+		return nil, err
+	}
+	return &github.App{Slug: botName, Name: botName}, nil
+}
+
+// ListAppInstallations returns no installations.
+func (f *FakeClient) ListAppInstallations() ([]github.AppInstallation, error) {
+	if err := f.call("ListAppInstallations"); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ListAppInstallationsForOrg returns no installations.
+func (f *FakeClient) ListAppInstallationsForOrg(org string) ([]github.AppInstallation, error) {
+	if err := f.call("ListAppInstallationsForOrg", org); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// IsAppInstalled returns false as the FakeClient does not use app auth.
+func (f *FakeClient) IsAppInstalled(org, repo string) (bool, error) {
+	if err := f.call("IsAppInstalled", org, repo); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// UsesAppAuth returns false.
+func (f *FakeClient) UsesAppAuth() bool {
+	return false
+}
+
+// QueryWithGitHubAppsSupport runs Query.
+func (f *FakeClient) QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error {
+	return f.Query(ctx, q, vars)
+}
+
+// Throttle does nothing.
+func (f *FakeClient) Throttle(hourlyTokens, burst int, org ...string) error {
+	return f.call("Throttle", hourlyTokens, burst, org)
+}
+
+// ApplyThrottlePolicy does nothing.
+func (f *FakeClient) ApplyThrottlePolicy(policy github.ThrottlePolicy) error {
+	return f.call("ApplyThrottlePolicy", policy)
+}
+
+// SetMax404Retries does nothing.
+func (f *FakeClient) SetMax404Retries(int) {}
+
+// WithFields returns the FakeClient.
+func (f *FakeClient) WithFields(fields logrus.Fields) github.Client {
+	return f
+}
+
+// ForPlugin returns the FakeClient.
+func (f *FakeClient) ForPlugin(plugin string) github.Client {
+	return f
+}
+
+// ForSubcomponent returns the FakeClient.
+func (f *FakeClient) ForSubcomponent(subcomponent string) github.Client {
+	return f
+}
+
+// Used returns true if any method was called.
+func (f *FakeClient) Used() bool {
+	f.callLock.Lock()
+	defer f.callLock.Unlock()
+	return len(f.calls) > 0
+}
+
+var _ github.Client = &FakeClient{}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghmetrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// CategoryGraphQL is the category of requests to the GraphQL API.
+const CategoryGraphQL = "graphql"
+
+var consumptionLabels = []string{"component", "consumer", "org", "category"}
+
+// consumerRequestsCounter provides the 'github_consumer_requests' counter
+// that attributes GitHub API requests to the component and consumer that
+// made them.
+var consumerRequestsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "github_consumer_requests",
+		Help: "GitHub API requests by component, consumer, org and call category.",
+	},
+	consumptionLabels,
+)
+
+// consumerCostCounter provides the 'github_consumer_cost' counter that
+// attributes the rate limit points spent to the component and consumer that
+// spent them.
+var consumerCostCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "github_consumer_cost",
+		Help: "GitHub rate limit points spent by component, consumer, org and call category.",
+	},
+	consumptionLabels,
+)
+
+func init() {
+	prometheus.MustRegister(consumerRequestsCounter)
+	prometheus.MustRegister(consumerCostCounter)
+}
+
+// Consumption is the API usage attributed to a consumer of a component in an
+// org for a category of calls.
+type Consumption struct {
+	Component string `json:"component"`
+	Consumer  string `json:"consumer,omitempty"`
+	Org       string `json:"org,omitempty"`
+	Category  string `json:"category"`
+	Requests  int    `json:"requests"`
+	Cost      int    `json:"cost"`
+}
+
+type consumptionKey struct {
+	component, consumer, org, category string
+}
+
+var consumption = struct {
+	sync.Mutex
+	totals map[consumptionKey]*Consumption
+}{totals: map[consumptionKey]*Consumption{}}
+
+// CollectConsumptionMetrics attributes a request to the component and consumer
+// of its user agent, e.g. "hook.trigger/v20260101-abcdef" for the trigger
+// plugin of hook. The org is parsed from the path if it is not given. The cost
+// is the number of rate limit points the request spent: 0 for responses
+// served by ghproxy without contacting GitHub, 1 for other REST requests and
+// the reported cost for GraphQL requests.
+func CollectConsumptionMetrics(userAgent, org, path string, cost int) {
+	component, consumer := parseUserAgent(userAgent)
+	pathOrg, category := categorize(path)
+	if org == "" {
+		org = pathOrg
+	}
+	labels := prometheus.Labels{"component": component, "consumer": consumer, "org": org, "category": category}
+	consumerRequestsCounter.With(labels).Inc()
+	consumerCostCounter.With(labels).Add(float64(cost))
+
+	key := consumptionKey{component: component, consumer: consumer, org: org, category: category}
+	consumption.Lock()
+	defer consumption.Unlock()
+	total, ok := consumption.totals[key]
+	if !ok {
+		total = &Consumption{Component: component, Consumer: consumer, Org: org, Category: category}
+		consumption.totals[key] = total
+	}
+	total.Requests++
+	total.Cost += cost
+}
+
+// ConsumptionReport returns the API usage collected by this process, most
+// expensive first.
+func ConsumptionReport() []Consumption {
+	consumption.Lock()
+	report := make([]Consumption, 0, len(consumption.totals))
+	for _, total := range consumption.totals {
+		report = append(report, *total)
+	}
+	consumption.Unlock()
+
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return strings.Join([]string{a.Component, a.Consumer, a.Org, a.Category}, "/") <
+			strings.Join([]string{b.Component, b.Consumer, b.Org, b.Category}, "/")
+	})
+	return report
+}
+
+// ConsumptionHandler serves the ConsumptionReport as JSON to find out which
+// component or plugin spends the rate limit.
+func ConsumptionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(ConsumptionReport()); err != nil {
+			logrus.WithError(err).Warn("Failed to write GitHub API consumption report.")
+		}
+	})
+}
+
+// parseUserAgent returns the component and consumer of a user agent created
+// by version.UserAgentWithIdentifier.
+func parseUserAgent(userAgent string) (component, consumer string) {
+	component, consumer, _ = strings.Cut(userAgentWithoutVersion(userAgent), ".")
+	if component == "" {
+		component = "unknown"
+	}
+	return component, consumer
+}
+
+// categorize returns the org and the call category of an API path, e.g. org
+// "kubernetes" and category "issues" for /repos/kubernetes/test-infra/issues/1.
+func categorize(path string) (org, category string) {
+	path = strings.TrimPrefix(path, "/api/v3")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case parts[len(parts)-1] == "graphql":
+		return "", CategoryGraphQL
+	case parts[0] == "repos" && len(parts) > 3:
+		return parts[1], parts[3]
+	case parts[0] == "orgs" && len(parts) > 2:
+		return parts[1], parts[2]
+	case (parts[0] == "repos" || parts[0] == "orgs") && len(parts) > 1:
+		return parts[1], parts[0]
+	case parts[0] == "":
+		return "", "unknown"
+	}
+	return "", parts[0]
+}
+
+type graphQLResponse struct {
+	Data struct {
+		RateLimit *struct {
+			Cost int `json:"cost"`
+		} `json:"rateLimit"`
+	} `json:"data"`
+	Errors []json.RawMessage `json:"errors"`
+}
+
+// GraphQLCost returns the rate limit cost GitHub reported for a GraphQL
+// response and whether the response contains errors. GitHub only reports the
+// cost if the query requests the rateLimit object; every query costs at least
+// one point, so that is assumed otherwise.
+func GraphQLCost(body []byte) (int, bool) {
+	var resp graphQLResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 1, true
+	}
+	cost := 1
+	if resp.Data.RateLimit != nil && resp.Data.RateLimit.Cost > 0 {
+		cost = resp.Data.RateLimit.Cost
+	}
+	return cost, len(resp.Errors) > 0
+}
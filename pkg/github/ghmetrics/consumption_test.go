@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghmetrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCategorize(t *testing.T) {
+	testCases := []struct {
+		path             string
+		expectedOrg      string
+		expectedCategory string
+	}{
+		{path: "/repos/kubernetes/test-infra/issues/1/comments", expectedOrg: "kubernetes", expectedCategory: "issues"},
+		{path: "/api/v3/repos/kubernetes/test-infra/pulls/2", expectedOrg: "kubernetes", expectedCategory: "pulls"},
+		{path: "/repos/kubernetes/test-infra", expectedOrg: "kubernetes", expectedCategory: "repos"},
+		{path: "/orgs/kubernetes/members", expectedOrg: "kubernetes", expectedCategory: "members"},
+		{path: "/orgs/kubernetes", expectedOrg: "kubernetes", expectedCategory: "orgs"},
+		{path: "/search/issues", expectedCategory: "search"},
+		{path: "/graphql", expectedCategory: CategoryGraphQL},
+		{path: "/api/graphql", expectedCategory: CategoryGraphQL},
+		{path: "/", expectedCategory: "unknown"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			org, category := categorize(tc.path)
+			if org != tc.expectedOrg || category != tc.expectedCategory {
+				t.Errorf("expected org %q and category %q, got %q and %q", tc.expectedOrg, tc.expectedCategory, org, category)
+			}
+		})
+	}
+}
+
+func TestParseUserAgent(t *testing.T) {
+	testCases := []struct {
+		userAgent         string
+		expectedComponent string
+		expectedConsumer  string
+	}{
+		{userAgent: "hook.trigger/v20260314-12f848798", expectedComponent: "hook", expectedConsumer: "trigger"},
+		{userAgent: "tide/v20260314-12f848798", expectedComponent: "tide"},
+		{userAgent: "", expectedComponent: "unknown"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.userAgent, func(t *testing.T) {
+			component, consumer := parseUserAgent(tc.userAgent)
+			if component != tc.expectedComponent || consumer != tc.expectedConsumer {
+				t.Errorf("expected component %q and consumer %q, got %q and %q", tc.expectedComponent, tc.expectedConsumer, component, consumer)
+			}
+		})
+	}
+}
+
+func TestConsumptionHandler(t *testing.T) {
+	consumption.Lock()
+	consumption.totals = map[consumptionKey]*Consumption{}
+	consumption.Unlock()
+
+	CollectConsumptionMetrics("hook.trigger/v1", "", "/repos/kubernetes/test-infra/issues/1/comments", 1)
+	CollectConsumptionMetrics("hook.trigger/v1", "", "/repos/kubernetes/test-infra/issues/2/comments", 0)
+	CollectConsumptionMetrics("hook.lgtm/v1", "kubernetes", "/graphql", 5)
+	CollectConsumptionMetrics("tide/v1", "", "/search/issues", 1)
+
+	rr := httptest.NewRecorder()
+	ConsumptionHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/github-consumption", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	var report []Consumption
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	expected := []Consumption{
+		{Component: "hook", Consumer: "lgtm", Org: "kubernetes", Category: CategoryGraphQL, Requests: 1, Cost: 5},
+		{Component: "hook", Consumer: "trigger", Org: "kubernetes", Category: "issues", Requests: 2, Cost: 1},
+		{Component: "tide", Category: "search", Requests: 1, Cost: 1},
+	}
+	if diff := cmp.Diff(expected, report); diff != "" {
+		t.Errorf("report differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestGraphQLCost(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedCost   int
+		expectedErrors bool
+	}{
+		{
+			name:         "reported cost",
+			body:         `{"data":{"rateLimit":{"cost":7,"remaining":4993},"viewer":{"login":"bot"}}}`,
+			expectedCost: 7,
+		},
+		{
+			name:         "unreported cost",
+			body:         `{"data":{"viewer":{"login":"bot"}}}`,
+			expectedCost: 1,
+		},
+		{
+			name:           "errors",
+			body:           `{"data":null,"errors":[{"message":"Something went wrong"}]}`,
+			expectedCost:   1,
+			expectedErrors: true,
+		},
+		{
+			name:           "invalid response",
+			body:           `<html>`,
+			expectedCost:   1,
+			expectedErrors: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cost, hasErrors := GraphQLCost([]byte(tc.body))
+			if cost != tc.expectedCost {
+				t.Errorf("expected cost %d, got %d", tc.expectedCost, cost)
+			}
+			if hasErrors != tc.expectedErrors {
+				t.Errorf("expected errors: %t, got: %t", tc.expectedErrors, hasErrors)
+			}
+		})
+	}
+}
@@ -101,6 +101,27 @@ var cacheEntryAge = prometheus.NewHistogramVec(
 	[]string{"token_hash", "path", "user_agent"},
 )
 
+// graphQLCostCounter provides the 'github_graphql_cost' counter that keeps
+// track of the GraphQL rate limit points spent by each consumer.
+var graphQLCostCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "github_graphql_cost",
+		Help: "GraphQL rate limit points spent by token and user agent.",
+	},
+	[]string{"token_hash", "user_agent"},
+)
+
+// graphQLQueryCostHistVec provides the 'github_graphql_query_cost' histogram
+// that keeps track of the rate limit cost of individual GraphQL queries.
+var graphQLQueryCostHistVec = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "github_graphql_query_cost",
+		Help:    "GraphQL rate limit cost of a single request by operation.",
+		Buckets: []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	},
+	[]string{"token_hash", "user_agent", "operation"},
+)
+
 var muxTokenUsage sync.Mutex
 var lastGitHubResponse time.Time
 
@@ -112,6 +133,8 @@ func init() {
 	prometheus.MustRegister(cacheCounter)
 	prometheus.MustRegister(timeoutDuration)
 	prometheus.MustRegister(cacheEntryAge)
+	prometheus.MustRegister(graphQLCostCounter)
+	prometheus.MustRegister(graphQLQueryCostHistVec)
 }
 
 // CollectGitHubTokenMetrics publishes the rate limits of the github api to
@@ -194,3 +217,11 @@ func CollectRequestTimeoutMetrics(tokenHash, path, userAgent string, reqStartTim
 func CollectGitHubRequestWaitDurationMetrics(tokenHash, requestType, api string, duration time.Duration) {
 	ghRequestWaitDurationHistVec.With(prometheus.Labels{"token_hash": tokenHash, "request_type": requestType, "api": api}).Observe(duration.Seconds())
 }
+
+// CollectGraphQLCostMetrics publishes the rate limit cost of a GraphQL request
+// to 'github_graphql_cost' and 'github_graphql_query_cost' on prometheus.
+func CollectGraphQLCostMetrics(tokenHash, userAgent, operation string, cost int) {
+	userAgent = userAgentWithoutVersion(userAgent)
+	graphQLCostCounter.With(prometheus.Labels{"token_hash": tokenHash, "user_agent": userAgent}).Add(float64(cost))
+	graphQLQueryCostHistVec.With(prometheus.Labels{"token_hash": tokenHash, "user_agent": userAgent, "operation": operation}).Observe(float64(cost))
+}
@@ -86,6 +86,19 @@ func PayloadSignature(payload []byte, key []byte) string {
 	return "sha1=" + hex.EncodeToString(sum)
 }
 
+// SignPayload returns the signature of the payload with an HMAC token of the
+// org or repo, which ValidatePayload accepts for the same payload.
+func SignPayload(payload []byte, orgRepo string, tokenGenerator func() []byte) (string, error) {
+	hmacs, err := extractHMACs(orgRepo, tokenGenerator)
+	if err != nil {
+		return "", err
+	}
+	if len(hmacs) == 0 {
+		return "", fmt.Errorf("no hmac is configured for the org/repo %q", orgRepo)
+	}
+	return PayloadSignature(payload, hmacs[0]), nil
+}
+
 // extractHMACs returns all *valid* HMAC tokens for given repository/organization.
 // It considers only the tokens at the most specific level configured for the given repo.
 // For example : if a token for repo is present and it doesn't match the repo, we will
@@ -101,3 +101,38 @@ func TestValidatePayload(t *testing.T) {
 		}
 	}
 }
+
+func TestSignPayload(t *testing.T) {
+	var testcases = []struct {
+		name    string
+		payload string
+		orgRepo string
+	}{
+		{
+			name:    "repo token",
+			payload: `{"repository": {"full_name": "org2/repo"}}`,
+			orgRepo: "org2/repo",
+		},
+		{
+			name:    "org token",
+			payload: `{"organization": {"login": "org1"}}`,
+			orgRepo: "org1",
+		},
+		{
+			name:    "global token",
+			payload: `{"repository": {"full_name": "org3/repo"}}`,
+			orgRepo: "org3/repo",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sig, err := SignPayload([]byte(tc.payload), tc.orgRepo, defaultTokenGenerator)
+			if err != nil {
+				t.Fatalf("failed to sign payload: %v", err)
+			}
+			if !ValidatePayload([]byte(tc.payload), sig, defaultTokenGenerator) {
+				t.Errorf("expected signature %q to be valid", sig)
+			}
+		})
+	}
+}
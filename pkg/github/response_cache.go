@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/ghcache"
+)
+
+// ResponseCache stores responses of the GitHub API so that they can be
+// revalidated with conditional requests, which don't count against the rate
+// limit if the response did not change. It is used by clients that don't
+// send their requests through ghproxy.
+type ResponseCache interface {
+	// Get returns the response stored for the key, if any.
+	Get(key string) (*CachedResponse, bool)
+	// Set stores the response for the key.
+	Set(key string, response *CachedResponse)
+}
+
+// CachedResponse is a response stored in a ResponseCache.
+type CachedResponse struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// lruResponseCache keeps the most recently used responses in memory and
+// optionally persists them in a directory, one file per key. Every file in the
+// directory has an entry in the cache, so evicting it removes the file and the
+// directory never holds more responses than the cache. Entries of responses
+// that were persisted before a restart are nil until they are read again.
+type lruResponseCache struct {
+	cache *lru.Cache
+	dir   string
+}
+
+// NewLRUResponseCache returns a ResponseCache holding up to size responses.
+// If dir is not empty, the responses are also persisted in it so that they
+// are still available after a restart.
+func NewLRUResponseCache(size int, dir string) (ResponseCache, error) {
+	if size <= 0 {
+		return nil, errors.New("the size of the response cache must be positive")
+	}
+	c := &lruResponseCache{dir: dir}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create response cache directory: %w", err)
+		}
+	}
+	cache, err := lru.NewWithEvict(size, func(key, _ interface{}) {
+		if c.dir == "" {
+			return
+		}
+		if err := os.Remove(c.path(key.(string))); err != nil && !os.IsNotExist(err) {
+			logrus.WithError(err).Warn("Failed to remove evicted response from the cache directory.")
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.cache = cache
+	if dir != "" {
+		if err := c.index(); err != nil {
+			return nil, fmt.Errorf("failed to index response cache directory: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// tmpPrefix is the prefix of the files responses are written to before they
+// are renamed into place.
+const tmpPrefix = ".tmp-"
+
+// index adds the responses persisted in the directory to the cache, oldest
+// first, so that the ones exceeding its size are evicted and removed.
+func (c *lruResponseCache) index() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	type file struct {
+		name    string
+		modTime time.Time
+	}
+	var files []file
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), tmpPrefix) {
+			// Left behind by a write that was interrupted.
+			if err := os.Remove(c.path(entry.Name())); err != nil && !os.IsNotExist(err) {
+				logrus.WithError(err).Warn("Failed to remove temporary file from the cache directory.")
+			}
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		files = append(files, file{name: entry.Name(), modTime: info.ModTime()})
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, f := range files {
+		c.cache.Add(f.name, (*CachedResponse)(nil))
+	}
+	return nil
+}
+
+func (c *lruResponseCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+func (c *lruResponseCache) Get(key string) (*CachedResponse, bool) {
+	value, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if response := value.(*CachedResponse); response != nil {
+		return response, true
+	}
+	// The response was persisted before a restart.
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to read response from the cache directory.")
+		c.cache.Remove(key)
+		return nil, false
+	}
+	var response CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		logrus.WithError(err).Warn("Failed to unmarshal response from the cache directory.")
+		c.cache.Remove(key)
+		return nil, false
+	}
+	c.cache.Add(key, &response)
+	return &response, true
+}
+
+func (c *lruResponseCache) Set(key string, response *CachedResponse) {
+	c.cache.Add(key, response)
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to marshal response for the cache directory.")
+		return
+	}
+	// Write to a temporary file first so that a crash never leaves a
+	// truncated response behind.
+	tmp, err := os.CreateTemp(c.dir, tmpPrefix)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to persist response in the cache directory.")
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		os.Remove(tmp.Name())
+		logrus.WithError(err).Warn("Failed to persist response in the cache directory.")
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		logrus.WithError(err).Warn("Failed to persist response in the cache directory.")
+	}
+}
+
+// responseCacheKey identifies the response to a request. It includes the
+// credentials, as different users may see different responses, but hashes
+// them so that they are never stored.
+func responseCacheKey(r *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s", r.Header.Get("Authorization"), r.Header.Get("Accept"), r.URL.String())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// conditionalRequestTransport revalidates cached responses to GET requests
+// with If-None-Match and If-Modified-Since and serves them when GitHub
+// replies that they did not change.
+type conditionalRequestTransport struct {
+	upstream http.RoundTripper
+	cache    ResponseCache
+}
+
+func (t *conditionalRequestTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet || r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+		// The caller handles conditional requests itself.
+		return t.upstream.RoundTrip(r)
+	}
+	key := responseCacheKey(r)
+	cached, ok := t.cache.Get(key)
+	if ok {
+		r = r.Clone(r.Context())
+		if cached.ETag != "" {
+			r.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			r.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	resp, err := t.upstream.RoundTrip(r)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		header := cached.Header.Clone()
+		// The revalidation carries the current rate limit.
+		for name, values := range resp.Header {
+			if name == "Content-Length" {
+				continue
+			}
+			header[name] = values
+		}
+		header.Set(ghcache.CacheModeHeader, string(ghcache.ModeRevalidated))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+			StatusCode:    cached.StatusCode,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       r,
+		}, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.Set(key, &CachedResponse{
+		ETag:         etag,
+		LastModified: lastModified,
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	})
+	return resp, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/ghcache"
+)
+
+func TestConditionalRequestTransport(t *testing.T) {
+	var requests, conditional int
+	body := "first"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		etag := fmt.Sprintf("%q", r.Header.Get("Authorization")+body)
+		if r.Header.Get("If-None-Match") == etag {
+			conditional++
+			w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(5000-requests))
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(5000-requests))
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	cache, err := NewLRUResponseCache(10, "")
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	client := &http.Client{Transport: &conditionalRequestTransport{upstream: http.DefaultTransport, cache: cache}}
+	get := func(method, token string) (string, *http.Response) {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+"/repos/org/repo", nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read response: %v", err)
+		}
+		return string(data), resp
+	}
+
+	if got, resp := get(http.MethodGet, "token a"); got != "first" || resp.Header.Get(ghcache.CacheModeHeader) != "" {
+		t.Errorf("expected an uncached first response, got %q with mode %q", got, resp.Header.Get(ghcache.CacheModeHeader))
+	}
+	got, resp := get(http.MethodGet, "token a")
+	if got != "first" || resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the cached response, got %d %q", resp.StatusCode, got)
+	}
+	if mode := resp.Header.Get(ghcache.CacheModeHeader); mode != string(ghcache.ModeRevalidated) {
+		t.Errorf("expected the response to be revalidated, got mode %q", mode)
+	}
+	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "4998" {
+		t.Errorf("expected the rate limit of the revalidation, got %q", remaining)
+	}
+	if conditional != 1 {
+		t.Errorf("expected one conditional request, got %d", conditional)
+	}
+
+	// Responses are not shared between credentials.
+	if get(http.MethodGet, "token b"); conditional != 1 {
+		t.Errorf("expected the response of another token not to be revalidated")
+	}
+	// Requests other than GET are not cached.
+	if get(http.MethodHead, "token a"); conditional != 1 {
+		t.Errorf("expected HEAD requests not to be revalidated")
+	}
+
+	body = "second"
+	if got, _ := get(http.MethodGet, "token a"); got != "second" {
+		t.Errorf("expected the changed response, got %q", got)
+	}
+	if got, _ := get(http.MethodGet, "token a"); got != "second" || conditional != 2 {
+		t.Errorf("expected the changed response to be cached, got %q after %d conditional requests", got, conditional)
+	}
+}
+
+func TestLRUResponseCachePersistence(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewLRUResponseCache(1, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	cache.Set("a", &CachedResponse{ETag: `"a"`, StatusCode: http.StatusOK, Body: []byte("a")})
+
+	restarted, err := NewLRUResponseCache(1, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	response, ok := restarted.Get("a")
+	if !ok || response.ETag != `"a"` || string(response.Body) != "a" {
+		t.Fatalf("expected the persisted response, got %v", response)
+	}
+
+	// Evicting a response removes it from the directory.
+	restarted.Set("b", &CachedResponse{ETag: `"b"`, StatusCode: http.StatusOK})
+	if _, err := os.Stat(dir + "/a"); !os.IsNotExist(err) {
+		t.Errorf("expected the evicted response to be removed, got %v", err)
+	}
+	if _, ok := restarted.Get("a"); ok {
+		t.Error("expected the evicted response to be gone")
+	}
+
+	if _, err := NewLRUResponseCache(0, ""); err == nil {
+		t.Error("expected an error for an empty cache")
+	}
+}
+
+func TestLRUResponseCacheIndexesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"oldest", "older", "newest"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(fmt.Sprintf(`{"etag":%q,"status_code":200}`, name)), 0644); err != nil {
+			t.Fatalf("failed to write response: %v", err)
+		}
+		modTime := now.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".tmp-123"), []byte("{"), 0644); err != nil {
+		t.Fatalf("failed to write temporary file: %v", err)
+	}
+
+	cache, err := NewLRUResponseCache(2, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	for _, name := range []string{"oldest", ".tmp-123"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed on startup, got %v", name, err)
+		}
+	}
+	if _, ok := cache.Get("oldest"); ok {
+		t.Error("expected the oldest response to be evicted")
+	}
+	if response, ok := cache.Get("newest"); !ok || response.ETag != "newest" {
+		t.Errorf("expected the newest response, got %v", response)
+	}
+
+	// Responses loaded from the directory are evicted like any other.
+	cache.Set("new", &CachedResponse{StatusCode: http.StatusOK})
+	if _, err := os.Stat(filepath.Join(dir, "older")); !os.IsNotExist(err) {
+		t.Errorf("expected the least recently used response to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// secondaryRateLimitInitialBackoff is how long requests are held back
+	// after GitHub reported a secondary rate limit without telling us how long
+	// to wait. GitHub asks clients to wait at least a minute in that case.
+	secondaryRateLimitInitialBackoff = time.Minute
+	// secondaryRateLimitMaxBackoff caps the backoff for repeated secondary
+	// rate limits.
+	secondaryRateLimitMaxBackoff = 15 * time.Minute
+)
+
+// secondaryRateLimiter holds back all requests made with a token after GitHub
+// reported that the token exceeded a secondary rate limit. Unlike the primary
+// rate limit, GitHub doesn't expose how close a token is to its secondary
+// limits, so the only way to avoid them is to slow down once they are hit.
+// Repeated hits without a successful request in between double the backoff.
+type secondaryRateLimiter struct {
+	lock      sync.Mutex
+	penalties map[string]secondaryRateLimitPenalty
+}
+
+type secondaryRateLimitPenalty struct {
+	until   time.Time
+	backoff time.Duration
+}
+
+// delay returns how long requests for the key still need to be held back.
+func (s *secondaryRateLimiter) delay(tc timeClient, key string) time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	penalty, ok := s.penalties[key]
+	if !ok {
+		return 0
+	}
+	return tc.Until(penalty.until)
+}
+
+// penalize records a secondary rate limit for the key and returns how long
+// requests for the key are held back. retryAfter is the wait time GitHub asked
+// for, if any.
+func (s *secondaryRateLimiter) penalize(tc timeClient, key string, retryAfter time.Duration) time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.penalties == nil {
+		s.penalties = map[string]secondaryRateLimitPenalty{}
+	}
+	backoff := secondaryRateLimitInitialBackoff
+	if previous, ok := s.penalties[key]; ok {
+		backoff = min(2*previous.backoff, secondaryRateLimitMaxBackoff)
+	}
+	wait := backoff
+	if retryAfter > 0 {
+		// Sleep an extra second plus how long GitHub wants us to sleep.
+		wait = retryAfter + time.Second
+	}
+	s.penalties[key] = secondaryRateLimitPenalty{until: tc.Now().Add(wait), backoff: backoff}
+	return wait
+}
+
+// reset forgets about previous secondary rate limits for the key once a
+// request succeeded.
+func (s *secondaryRateLimiter) reset(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.penalties, key)
+}
+
+// secondaryRateLimit determines whether GitHub rejected the request because
+// of a secondary rate limit and how long GitHub asked us to wait. The response
+// body is restored so that it can still be read by the caller.
+func secondaryRateLimit(resp *http.Response) (bool, time.Duration, error) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false, 0, nil
+	}
+	if rawTime := resp.Header.Get("Retry-After"); rawTime != "" && rawTime != "0" {
+		seconds, err := strconv.Atoi(rawTime)
+		if err != nil {
+			return false, 0, fmt.Errorf("failed to parse secondary rate limit wait time %q: %w", rawTime, err)
+		}
+		return true, time.Duration(seconds) * time.Second, nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, 0, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+	message := strings.ToLower(string(body))
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection"), 0, nil
+}
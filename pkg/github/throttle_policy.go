@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"errors"
+	"fmt"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ThrottlePolicy configures client-side throttling of GitHub API requests. It
+// allows components to share their throttling settings through configuration
+// instead of each component exposing its own flags.
+type ThrottlePolicy struct {
+	// HourlyTokens is the maximum number of tokens the client uses per hour.
+	// Zero keeps the throttling the client was created with, e.g. through
+	// --github-hourly-tokens, which also applies to orgs without a policy in
+	// Orgs.
+	HourlyTokens int `json:"hourly_tokens,omitempty"`
+	// Burst is the number of tokens the client may use at once. It must be
+	// positive if HourlyTokens is and may not exceed it.
+	Burst int `json:"burst,omitempty"`
+	// Orgs overrides the throttling for requests to individual orgs. This is
+	// only valid when using GitHub App auth, which uses a separate token per
+	// org.
+	Orgs map[string]OrgThrottlePolicy `json:"orgs,omitempty"`
+}
+
+// OrgThrottlePolicy configures client-side throttling of GitHub API requests
+// to a single org.
+type OrgThrottlePolicy struct {
+	// HourlyTokens is the maximum number of tokens the client uses per hour
+	// for the org.
+	HourlyTokens int `json:"hourly_tokens"`
+	// Burst is the number of tokens the client may use at once for the org.
+	Burst int `json:"burst"`
+}
+
+// Validate validates the throttle policy.
+func (p ThrottlePolicy) Validate() error {
+	var errs []error
+	if (p.HourlyTokens > 0) != (p.Burst > 0) {
+		errs = append(errs, errors.New("hourly_tokens and burst must be either both higher than zero or both equal to zero"))
+	}
+	if p.Burst > p.HourlyTokens {
+		errs = append(errs, errors.New("burst must not be larger than hourly_tokens"))
+	}
+	for org, policy := range p.Orgs {
+		if policy.HourlyTokens <= 0 || policy.Burst <= 0 {
+			errs = append(errs, fmt.Errorf("orgs[%s]: hourly_tokens and burst must be higher than zero", org))
+		}
+		if policy.Burst > policy.HourlyTokens {
+			errs = append(errs, fmt.Errorf("orgs[%s]: burst must not be larger than hourly_tokens", org))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ApplyThrottlePolicy throttles the client according to the policy, replacing
+// any throttling previously configured for the same orgs. The global
+// throttling is only replaced if the policy sets HourlyTokens.
+func (c *client) ApplyThrottlePolicy(policy ThrottlePolicy) error {
+	c.log("ApplyThrottlePolicy", policy)
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid throttle policy: %w", err)
+	}
+	if policy.HourlyTokens > 0 {
+		if err := c.Throttle(policy.HourlyTokens, policy.Burst); err != nil {
+			return err
+		}
+	}
+	for org, orgPolicy := range policy.Orgs {
+		if err := c.Throttle(orgPolicy.HourlyTokens, orgPolicy.Burst, org); err != nil {
+			return fmt.Errorf("failed to throttle org %s: %w", org, err)
+		}
+	}
+	return nil
+}
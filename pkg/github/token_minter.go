@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/ghcache"
+)
+
+// MintedToken is an installation token minted by a token minter.
+type MintedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// AppSlug identifies the app the token belongs to.
+	AppSlug string `json:"app_slug"`
+}
+
+// InstallationTokenMinter mints installation tokens of a GitHub App.
+type InstallationTokenMinter interface {
+	// MintToken returns an installation token for the org. If permissions
+	// are given, the token is restricted to them.
+	MintToken(org string, permissions *InstallationPermissions) (*MintedToken, error)
+	// GetApp returns the app the tokens are minted for.
+	GetApp() (*App, error)
+}
+
+// NewInstallationTokenMinter creates an InstallationTokenMinter for the app
+// configured in the options.
+func NewInstallationTokenMinter(fields logrus.Fields, options ClientOptions) (InstallationTokenMinter, error) {
+	if options.AppID == "" {
+		return nil, fmt.Errorf("minting installation tokens requires GitHub App auth")
+	}
+	_, _, c, appsTransport, err := newClientFromOptions(fields, options)
+	if err != nil {
+		return nil, err
+	}
+	return &appTokenMinter{client: c, transport: appsTransport}, nil
+}
+
+type appTokenMinter struct {
+	client    *client
+	transport *appsRoundTripper
+}
+
+func (m *appTokenMinter) MintToken(org string, permissions *InstallationPermissions) (*MintedToken, error) {
+	slug, err := m.transport.getSlug()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app slug: %w", err)
+	}
+	if permissions == nil {
+		token, expiresAt, err := m.transport.installationTokenFor(org)
+		if err != nil {
+			return nil, err
+		}
+		return &MintedToken{Token: token, ExpiresAt: expiresAt, AppSlug: slug}, nil
+	}
+	installationID, err := m.transport.installationIDFor(org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation id for org %s: %w", org, err)
+	}
+	token, err := m.client.createAppInstallationToken(installationID, permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an installation token for org %s: %w", org, err)
+	}
+	return &MintedToken{Token: token.Token, ExpiresAt: token.ExpiresAt, AppSlug: slug}, nil
+}
+
+func (m *appTokenMinter) GetApp() (*App, error) {
+	return m.client.GetApp()
+}
+
+// tokenMinterRoundTripper authenticates requests with installation tokens
+// obtained from a token minter. Requests that need to be authenticated as the
+// app itself can't be made, except for getting the app, which the token
+// minter serves.
+type tokenMinterRoundTripper struct {
+	minterURL         *url.URL
+	secret            func() []byte
+	minter            *http.Client
+	upstream          http.RoundTripper
+	hostPrefixMapping map[string]string
+
+	lock   sync.Mutex
+	tokens map[string]*MintedToken
+}
+
+func newTokenMinterRoundTripper(minterURL string, secret func() []byte, upstream http.RoundTripper, v3BaseURLs []string) (*tokenMinterRoundTripper, error) {
+	parsed, err := url.Parse(minterURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token minter URL %s: %w", minterURL, err)
+	}
+	roundTripper := &tokenMinterRoundTripper{
+		minterURL:         parsed,
+		secret:            secret,
+		minter:            &http.Client{Transport: upstream, Timeout: time.Minute},
+		upstream:          upstream,
+		hostPrefixMapping: make(map[string]string, len(v3BaseURLs)),
+		tokens:            map[string]*MintedToken{},
+	}
+	for _, baseURL := range v3BaseURLs {
+		url, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse github-endpoint %s as URL: %w", baseURL, err)
+		}
+		roundTripper.hostPrefixMapping[url.Host] = url.Path
+	}
+	return roundTripper, nil
+}
+
+func (t *tokenMinterRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(r.URL.Path, t.hostPrefixMapping[r.URL.Host])
+	if path == "/app" {
+		req, err := t.minterRequest(r.Method, "/app", nil)
+		if err != nil {
+			return nil, &appsAuthError{err}
+		}
+		return t.upstream.RoundTrip(req.WithContext(r.Context()))
+	}
+	if requiresAppAuth(path) {
+		return nil, &appsAuthError{fmt.Errorf("%s requires app authentication, which is not available when using a token minter", path)}
+	}
+
+	org := extractOrgFromContext(r.Context())
+	if org == "" {
+		return nil, &appsAuthError{fmt.Errorf("token minter auth requested but empty org")}
+	}
+	token, err := t.tokenFor(org)
+	if err != nil {
+		return nil, &appsAuthError{err}
+	}
+	r.Header.Set("Authorization", "Bearer "+token.Token)
+	r.Header.Set(ghcache.TokenExpiryAtHeader, token.ExpiresAt.Format(time.RFC3339))
+	// Token budgets are set on organization level, so include it in the identifier
+	// to not mess up metrics.
+	r.Header.Set(ghcache.TokenBudgetIdentifierHeader, token.AppSlug+" - "+org)
+	return t.upstream.RoundTrip(r)
+}
+
+// requiresAppAuth returns whether the endpoint must be called with the JWT of
+// the app rather than an installation token. Endpoints under /apps, e.g. to
+// get a public app, work with installation tokens.
+func requiresAppAuth(path string) bool {
+	return path == "/app" || strings.HasPrefix(path, "/app/") || installationPath.MatchString(path)
+}
+
+func (t *tokenMinterRoundTripper) minterRequest(method, path string, query url.Values) (*http.Request, error) {
+	u := *t.minterURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token minter request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(t.secret())))
+	return req, nil
+}
+
+// tokenFor returns an installation token for the org, minting a new one if
+// there is none or it is about to expire.
+func (t *tokenMinterRoundTripper) tokenFor(org string) (*MintedToken, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if token, ok := t.tokens[org]; ok && token.ExpiresAt.Add(-time.Minute).After(time.Now()) {
+		return token, nil
+	}
+
+	req, err := t.minterRequest(http.MethodGet, "/token", url.Values{"org": []string{org}})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.minter.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token for org %s from token minter: %w", org, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token minter response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token minter responded with status %d for org %s: %s", resp.StatusCode, org, strings.TrimSpace(string(body)))
+	}
+	var token MintedToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token minter response: %w", err)
+	}
+	t.tokens[org] = &token
+	return &token, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/ghcache"
+)
+
+func TestTokenMinterRoundTripper(t *testing.T) {
+	var minted []string
+	minter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/minter/app":
+			json.NewEncoder(w).Encode(App{Slug: "app"})
+		case "/minter/token":
+			org := r.URL.Query().Get("org")
+			minted = append(minted, org)
+			json.NewEncoder(w).Encode(MintedToken{Token: "token-" + org, ExpiresAt: time.Now().Add(time.Hour), AppSlug: "app"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer minter.Close()
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if expected := "Bearer token-org"; r.Header.Get("Authorization") != expected {
+			t.Errorf("expected Authorization header %q, got %q", expected, r.Header.Get("Authorization"))
+		}
+		if expected := "app - org"; r.Header.Get(ghcache.TokenBudgetIdentifierHeader) != expected {
+			t.Errorf("expected budget identifier %q, got %q", expected, r.Header.Get(ghcache.TokenBudgetIdentifierHeader))
+		}
+		json.NewEncoder(w).Encode(Organization{Login: "org"})
+	}))
+	defer upstream.Close()
+
+	_, _, c, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{
+		Censor:            func(b []byte) []byte { return b },
+		TokenMinterURL:    minter.URL + "/minter/",
+		TokenMinterSecret: func() []byte { return []byte("secret\n") },
+		GraphqlEndpoint:   upstream.URL + "/graphql",
+		Bases:             []string{upstream.URL},
+		MaxRetries:        1,
+	})
+	if err != nil {
+		t.Fatalf("failed to construct client: %v", err)
+	}
+
+	app, err := c.GetApp()
+	if err != nil {
+		t.Fatalf("failed to get app: %v", err)
+	}
+	if app.Slug != "app" {
+		t.Errorf("expected app slug %q, got %q", "app", app.Slug)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetOrg("org"); err != nil {
+			t.Fatalf("failed to get org: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"org"}, minted); diff != "" {
+		t.Errorf("minted tokens differ from expected (-want +got):\n%s", diff)
+	}
+	if _, err := c.ListAppInstallations(); err == nil {
+		t.Error("expected listing app installations to fail without app authentication")
+	}
+}
+
+func TestRequiresAppAuth(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"/app":                          true,
+		"/app/installations":            true,
+		"/repos/org/repo/installation":  true,
+		"/apps/some-app":                false,
+		"/application":                  false,
+		"/repos/org/repo/installations": false,
+		"/orgs/org":                     false,
+	} {
+		if actual := requiresAppAuth(path); actual != expected {
+			t.Errorf("%s: expected %t, got %t", path, expected, actual)
+		}
+	}
+}
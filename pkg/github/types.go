@@ -275,6 +275,9 @@ type PullRequest struct {
 	Milestone         *Milestone `json:"milestone,omitempty"`
 	Commits           int        `json:"commits"`
 	AuthorAssociation string     `json:"author_association,omitempty"`
+	// CloneRef is only set for pull requests that are not hosted on GitHub.
+	// Jobs fetch these pull requests from it instead of from pull/N/head.
+	CloneRef string `json:"clone_ref,omitempty"`
 }
 
 // PullRequestBranch contains information about a particular branch in a PR.
@@ -324,26 +327,30 @@ type PullRequestChange struct {
 // "Get" method. Use FullRepo struct for "Get" method.
 // See also https://developer.github.com/v3/repos/#list-organization-repositories
 type Repo struct {
-	Owner         User   `json:"owner"`
-	Name          string `json:"name"`
-	FullName      string `json:"full_name"`
-	HTMLURL       string `json:"html_url"`
-	Fork          bool   `json:"fork"`
-	DefaultBranch string `json:"default_branch"`
-	Archived      bool   `json:"archived"`
-	Private       bool   `json:"private"`
-	Description   string `json:"description"`
-	Homepage      string `json:"homepage"`
-	HasIssues     bool   `json:"has_issues"`
-	HasProjects   bool   `json:"has_projects"`
-	HasWiki       bool   `json:"has_wiki"`
-	NodeID        string `json:"node_id"`
+	Owner         User     `json:"owner"`
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	HTMLURL       string   `json:"html_url"`
+	Fork          bool     `json:"fork"`
+	DefaultBranch string   `json:"default_branch"`
+	Archived      bool     `json:"archived"`
+	Private       bool     `json:"private"`
+	Description   string   `json:"description"`
+	Homepage      string   `json:"homepage"`
+	HasIssues     bool     `json:"has_issues"`
+	HasProjects   bool     `json:"has_projects"`
+	HasWiki       bool     `json:"has_wiki"`
+	NodeID        string   `json:"node_id"`
+	Topics        []string `json:"topics,omitempty"`
 	// Permissions reflect the permission level for the requester, so
 	// on a repository GET call this will be for the user whose token
 	// is being used, if listing a team's repos this will be for the
 	// team's privilege level in the repo
 	Permissions RepoPermissions `json:"permissions"`
 	Parent      ParentRepo      `json:"parent"`
+	// CloneURI is only set for repos that are not hosted on GitHub. Jobs
+	// clone these repos from it instead of from GitHub.
+	CloneURI string `json:"clone_uri,omitempty"`
 }
 
 // ParentRepo contains a small subsection of general repository information: it
@@ -557,6 +564,12 @@ type BranchProtection struct {
 	AllowForcePushes           AllowForcePushes            `json:"allow_force_pushes"`
 	RequiredLinearHistory      RequiredLinearHistory       `json:"required_linear_history"`
 	AllowDeletions             AllowDeletions              `json:"allow_deletions"`
+	LockBranch                 LockBranch                  `json:"lock_branch"`
+}
+
+// LockBranch specifies whether the branch is read-only.
+type LockBranch struct {
+	Enabled bool `json:"enabled"`
 }
 
 // AllowDeletions specifies whether to permit users with push access to delete matching branches.
@@ -619,6 +632,7 @@ type BranchProtectionRequest struct {
 	RequiredLinearHistory      bool                               `json:"required_linear_history"`
 	AllowForcePushes           bool                               `json:"allow_force_pushes"`
 	AllowDeletions             bool                               `json:"allow_deletions"`
+	LockBranch                 bool                               `json:"lock_branch"`
 }
 
 func (r BranchProtectionRequest) String() string {
@@ -633,6 +647,17 @@ func (r BranchProtectionRequest) String() string {
 type RequiredStatusChecks struct {
 	Strict   bool     `json:"strict"` // PR must be up to date (include latest base branch commit).
 	Contexts []string `json:"contexts"`
+	// Checks lists the required contexts with the app that must provide them.
+	Checks []RequiredStatusCheck `json:"checks,omitempty"`
+}
+
+// RequiredStatusCheck is a context that must pass to merge.
+type RequiredStatusCheck struct {
+	Context string `json:"context"`
+	// AppID is the ID of the GitHub App that must provide the check. If unset,
+	// GitHub selects the app that most recently provided the check, -1 accepts
+	// the check from any source.
+	AppID *int `json:"app_id,omitempty"`
 }
 
 // RequiredPullRequestReviewsRequest controls a request for review rights.
@@ -877,6 +902,17 @@ func (i Issue) IsPullRequest() bool {
 	return i.PullRequest != nil
 }
 
+// OrgRepo parses the org and repo of an issue from its HTML URL, e.g.
+// https://github.com/org/repo/issues/1, as issues returned by the search API
+// do not include their repo.
+func (i Issue) OrgRepo() (string, string, error) {
+	parts := strings.Split(i.HTMLURL, "/")
+	if len(parts) < 4 {
+		return "", "", fmt.Errorf("failed to parse repo from URL %q of issue %d", i.HTMLURL, i.Number)
+	}
+	return parts[len(parts)-4], parts[len(parts)-3], nil
+}
+
 // HasLabel checks if an issue has a given label.
 func (i Issue) HasLabel(labelToFind string) bool {
 	for _, label := range i.Labels {
@@ -53,6 +53,45 @@ func TestIssueCapsLogin(t *testing.T) {
 	}
 }
 
+func TestIssueOrgRepo(t *testing.T) {
+	testCases := []struct {
+		name         string
+		url          string
+		expectedOrg  string
+		expectedRepo string
+		expectErr    bool
+	}{
+		{
+			name:         "issue",
+			url:          "https://github.com/org/repo/issues/1",
+			expectedOrg:  "org",
+			expectedRepo: "repo",
+		},
+		{
+			name:         "pull request on GitHub Enterprise",
+			url:          "https://github.example.com/org/repo/pull/2",
+			expectedOrg:  "org",
+			expectedRepo: "repo",
+		},
+		{
+			name:      "invalid URL",
+			url:       "repo/issues",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			org, repo, err := Issue{HTMLURL: tc.url}.OrgRepo()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if org != tc.expectedOrg || repo != tc.expectedRepo {
+				t.Errorf("expected %s/%s, got %s/%s", tc.expectedOrg, tc.expectedRepo, org, repo)
+			}
+		})
+	}
+}
+
 func TestUnmarshalClientError(t *testing.T) {
 	var testcases = []struct {
 		name string
@@ -41,6 +41,19 @@ var (
 		Name: "prow_plugin_handle_errors",
 		Help: "Prow errors handling an event by plugin, event type and action.",
 	}, []string{"event_type", "action", "plugin", "took_action"})
+	pluginQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prow_plugin_queue_depth",
+		Help: "Number of events waiting for a plugin to reach less than its max_concurrency handlers, by plugin.",
+	}, []string{"plugin"})
+	pluginQueueDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "prow_plugin_queue_duration_seconds",
+		Help:    "How long events waited for a handler of a plugin with max_concurrency, by plugin.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 40, 80, 160, 320, 640},
+	}, []string{"plugin"})
+	pluginRunningHandlers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prow_plugin_running_handlers",
+		Help: "Number of events a plugin is handling, by plugin.",
+	}, []string{"plugin"})
 )
 
 func init() {
@@ -48,6 +61,9 @@ func init() {
 	prometheus.MustRegister(responseCounter)
 	prometheus.MustRegister(pluginHandleDuration)
 	prometheus.MustRegister(pluginHandleErrors)
+	prometheus.MustRegister(pluginQueueDepth)
+	prometheus.MustRegister(pluginQueueDuration)
+	prometheus.MustRegister(pluginRunningHandlers)
 }
 
 // Metrics is a set of metrics gathered by hook.
@@ -56,6 +72,11 @@ type Metrics struct {
 	ResponseCounter      *prometheus.CounterVec
 	PluginHandleDuration *prometheus.HistogramVec
 	PluginHandleErrors   *prometheus.CounterVec
+	// PluginQueueDepth, PluginQueueDuration and PluginRunningHandlers
+	// are reported by the dispatcher of hook.
+	PluginQueueDepth      *prometheus.GaugeVec
+	PluginQueueDuration   *prometheus.HistogramVec
+	PluginRunningHandlers *prometheus.GaugeVec
 	*plugins.Metrics
 }
 
@@ -69,10 +90,13 @@ type PluginMetrics struct {
 // NewMetrics creates a new set of metrics for the hook server.
 func NewMetrics() *Metrics {
 	return &Metrics{
-		WebhookCounter:       webhookCounter,
-		ResponseCounter:      responseCounter,
-		PluginHandleDuration: pluginHandleDuration,
-		PluginHandleErrors:   pluginHandleErrors,
-		Metrics:              plugins.NewMetrics(),
+		WebhookCounter:        webhookCounter,
+		ResponseCounter:       responseCounter,
+		PluginHandleDuration:  pluginHandleDuration,
+		PluginHandleErrors:    pluginHandleErrors,
+		PluginQueueDepth:      pluginQueueDepth,
+		PluginQueueDuration:   pluginQueueDuration,
+		PluginRunningHandlers: pluginRunningHandlers,
+		Metrics:               plugins.NewMetrics(),
 	}
 }
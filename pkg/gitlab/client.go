@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitlab provides a client for the parts of the GitLab REST API that
+// Prow uses, the parsing of GitLab webhooks and their conversion into the
+// GitHub events that plugins handle.
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultEndpoint is the API endpoint of gitlab.com.
+const DefaultEndpoint = "https://gitlab.com/api/v4"
+
+// Client interacts with the GitLab API on behalf of Prow.
+type Client interface {
+	CurrentUser() (*User, error)
+	GetUser(username string) (*User, error)
+	GetProject(org, repo string) (*Project, error)
+	GetBranch(org, repo, branch string) (*Branch, error)
+	ListLabels(org, repo string) ([]Label, error)
+	GetProjectMember(org, repo string, userID int) (*Member, error)
+	GetGroupMember(group string, userID int) (*Member, error)
+	ListGroupMembers(group string) ([]Member, error)
+	ListSubgroups(group string) ([]Group, error)
+
+	GetMergeRequest(org, repo string, iid int) (*MergeRequest, error)
+	UpdateMergeRequest(org, repo string, iid int, update MergeRequestUpdate) error
+	ListMergeRequestDiffs(org, repo string, iid int) ([]Diff, error)
+	ListNotes(org, repo string, iid int) ([]Note, error)
+	CreateNote(org, repo string, iid int, body string) (*Note, error)
+	EditNote(org, repo string, iid, id int, body string) error
+	DeleteNote(org, repo string, iid, id int) error
+
+	ListCommitStatuses(org, repo, sha string) ([]CommitStatus, error)
+	SetCommitStatus(org, repo, sha string, status CommitStatus) error
+}
+
+// NewClient returns a client for the GitLab API at endpoint, authenticating
+// with the personal, group or project access token from token. In dry run
+// mode only GET requests are sent.
+func NewClient(endpoint string, token func() []byte, dryRun bool) Client {
+	return &client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		client:   &http.Client{Timeout: time.Minute},
+		dryRun:   dryRun,
+		logger:   logrus.WithField("client", "gitlab"),
+	}
+}
+
+type client struct {
+	endpoint string
+	token    func() []byte
+	client   *http.Client
+	dryRun   bool
+	logger   *logrus.Entry
+}
+
+// StatusError is returned for responses outside the 2XX range.
+type StatusError struct {
+	Method, Path string
+	Code         int
+	Body         string
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("%s %s: status code %d: %s", e.Method, e.Path, e.Code, e.Body)
+}
+
+// IsNotFound returns whether err is a 404 response from GitLab.
+func IsNotFound(err error) bool {
+	var statusErr StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound
+}
+
+func (c *client) request(method, path string, query url.Values, body, out interface{}) (http.Header, error) {
+	logger := c.logger.WithFields(logrus.Fields{"method": method, "path": path})
+	if c.dryRun && method != http.MethodGet {
+		logger.Info("Not executing request in dry run mode.")
+		return http.Header{}, nil
+	}
+	logger.Debug("Sending request.")
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", string(c.token()))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, StatusError{Method: method, Path: path, Code: resp.StatusCode, Body: string(data)}
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// listAll follows the X-Next-Page header to collect every page of a list.
+func listAll[T any](c *client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", "100")
+	var all []T
+	page := "1"
+	for page != "" {
+		query.Set("page", page)
+		var items []T
+		header, err := c.request(http.MethodGet, path, query, nil, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		page = header.Get("X-Next-Page")
+	}
+	return all, nil
+}
+
+// projectPath returns the API path of the project org/repo, which GitLab
+// accepts in place of the numeric project ID.
+func projectPath(org, repo string) string {
+	return "/projects/" + url.PathEscape(org+"/"+repo)
+}
+
+func mergeRequestPath(org, repo string, iid int) string {
+	return fmt.Sprintf("%s/merge_requests/%d", projectPath(org, repo), iid)
+}
+
+func (c *client) CurrentUser() (*User, error) {
+	var user User
+	if _, err := c.request(http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *client) GetUser(username string) (*User, error) {
+	var users []User
+	if _, err := c.request(http.MethodGet, "/users", url.Values{"username": []string{username}}, nil, &users); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, StatusError{Method: http.MethodGet, Path: "/users", Code: http.StatusNotFound, Body: fmt.Sprintf("user %s not found", username)}
+	}
+	return &users[0], nil
+}
+
+func (c *client) GetProject(org, repo string) (*Project, error) {
+	var project Project
+	if _, err := c.request(http.MethodGet, projectPath(org, repo), nil, nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (c *client) GetBranch(org, repo, branch string) (*Branch, error) {
+	var b Branch
+	if _, err := c.request(http.MethodGet, projectPath(org, repo)+"/repository/branches/"+url.PathEscape(branch), nil, nil, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *client) ListLabels(org, repo string) ([]Label, error) {
+	return listAll[Label](c, projectPath(org, repo)+"/labels", nil)
+}
+
+// GetProjectMember returns the member of the project including inherited
+// members of its groups.
+func (c *client) GetProjectMember(org, repo string, userID int) (*Member, error) {
+	var member Member
+	if _, err := c.request(http.MethodGet, fmt.Sprintf("%s/members/all/%d", projectPath(org, repo), userID), nil, nil, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetGroupMember returns the member of the group including inherited members
+// of its parent groups.
+func (c *client) GetGroupMember(group string, userID int) (*Member, error) {
+	var member Member
+	if _, err := c.request(http.MethodGet, fmt.Sprintf("/groups/%s/members/all/%d", url.PathEscape(group), userID), nil, nil, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (c *client) ListGroupMembers(group string) ([]Member, error) {
+	return listAll[Member](c, "/groups/"+url.PathEscape(group)+"/members/all", nil)
+}
+
+func (c *client) ListSubgroups(group string) ([]Group, error) {
+	return listAll[Group](c, "/groups/"+url.PathEscape(group)+"/subgroups", nil)
+}
+
+func (c *client) GetMergeRequest(org, repo string, iid int) (*MergeRequest, error) {
+	var mr MergeRequest
+	if _, err := c.request(http.MethodGet, mergeRequestPath(org, repo, iid), nil, nil, &mr); err != nil {
+		return nil, err
+	}
+	return &mr, nil
+}
+
+func (c *client) UpdateMergeRequest(org, repo string, iid int, update MergeRequestUpdate) error {
+	_, err := c.request(http.MethodPut, mergeRequestPath(org, repo, iid), nil, update, nil)
+	return err
+}
+
+func (c *client) ListMergeRequestDiffs(org, repo string, iid int) ([]Diff, error) {
+	return listAll[Diff](c, mergeRequestPath(org, repo, iid)+"/diffs", nil)
+}
+
+// ListNotes returns the notes of the merge request, oldest first.
+func (c *client) ListNotes(org, repo string, iid int) ([]Note, error) {
+	return listAll[Note](c, mergeRequestPath(org, repo, iid)+"/notes", url.Values{"sort": []string{"asc"}, "order_by": []string{"created_at"}})
+}
+
+func (c *client) CreateNote(org, repo string, iid int, body string) (*Note, error) {
+	var note Note
+	if _, err := c.request(http.MethodPost, mergeRequestPath(org, repo, iid)+"/notes", nil, map[string]string{"body": body}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (c *client) EditNote(org, repo string, iid, id int, body string) error {
+	_, err := c.request(http.MethodPut, fmt.Sprintf("%s/notes/%d", mergeRequestPath(org, repo, iid), id), nil, map[string]string{"body": body}, nil)
+	return err
+}
+
+func (c *client) DeleteNote(org, repo string, iid, id int) error {
+	_, err := c.request(http.MethodDelete, fmt.Sprintf("%s/notes/%d", mergeRequestPath(org, repo, iid), id), nil, nil, nil)
+	return err
+}
+
+func (c *client) ListCommitStatuses(org, repo, sha string) ([]CommitStatus, error) {
+	return listAll[CommitStatus](c, projectPath(org, repo)+"/repository/commits/"+sha+"/statuses", nil)
+}
+
+func (c *client) SetCommitStatus(org, repo, sha string, status CommitStatus) error {
+	body := map[string]string{
+		"state":       status.Status,
+		"name":        status.Name,
+		"target_url":  status.TargetURL,
+		"description": status.Description,
+	}
+	_, err := c.request(http.MethodPost, projectPath(org, repo)+"/statuses/"+sha, nil, body, nil)
+	return err
+}
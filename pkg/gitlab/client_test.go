@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClientRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("PRIVATE-TOKEN"); token != "token" {
+			t.Errorf("expected token to be sent, got %q", token)
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Frepo/merge_requests/3/notes":
+			if r.Method == http.MethodPost {
+				json.NewEncoder(w).Encode(Note{ID: 3, Body: "created"})
+				return
+			}
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				json.NewEncoder(w).Encode([]Note{{ID: 1}})
+				return
+			}
+			json.NewEncoder(w).Encode([]Note{{ID: 2}})
+		default:
+			http.Error(w, `{"message":"404 Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL+"/api/v4/", func() []byte { return []byte("token") }, false)
+	notes, err := c.ListNotes("group", "repo", 3)
+	if err != nil {
+		t.Fatalf("failed to list notes: %v", err)
+	}
+	if diff := cmp.Diff([]Note{{ID: 1}, {ID: 2}}, notes); diff != "" {
+		t.Errorf("notes differ from expected (-want +got):\n%s", diff)
+	}
+	if note, err := c.CreateNote("group", "repo", 3, "created"); err != nil || note.ID != 3 {
+		t.Errorf("expected note 3 to be created, got %v and %v", note, err)
+	}
+	if _, err := c.GetMergeRequest("group", "repo", 4); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	dry := NewClient(server.URL+"/api/v4", func() []byte { return []byte("token") }, true)
+	if err := dry.DeleteNote("group", "repo", 3, 1); err != nil {
+		t.Errorf("expected no error in dry run mode, got %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v4/projects/group%2Frepo/merge_requests/3/notes?order_by=created_at&page=1&per_page=100&sort=asc",
+		"GET /api/v4/projects/group%2Frepo/merge_requests/3/notes?order_by=created_at&page=2&per_page=100&sort=asc",
+		"POST /api/v4/projects/group%2Frepo/merge_requests/3/notes",
+		"GET /api/v4/projects/group%2Frepo/merge_requests/4",
+	}
+	if diff := cmp.Diff(expected, requests); diff != "" {
+		t.Errorf("requests differ from expected (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// GitLab does not have the GitHub concept of issues attached to pull
+// requests, so the events below only cover merge requests. Their IID is used
+// as the number of the pull request.
+
+// zeroSHA is the before or after commit of pushes creating or deleting a ref.
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// GitHubUser converts a GitLab user into a GitHub user.
+func GitHubUser(u User) github.User {
+	return github.User{
+		Login:   u.Username,
+		Name:    u.Name,
+		ID:      u.ID,
+		HTMLURL: u.WebURL,
+		Type:    github.UserTypeUser,
+	}
+}
+
+func gitHubUsers(users []User) []github.User {
+	var converted []github.User
+	for _, u := range users {
+		converted = append(converted, GitHubUser(u))
+	}
+	return converted
+}
+
+// GitHubRepo converts a GitLab project into a GitHub repo. Jobs clone the
+// repo from the project's clone URL.
+func GitHubRepo(p Project) github.Repo {
+	org, repo := p.OrgRepo()
+	return github.Repo{
+		Owner:         github.User{Login: org, Name: org},
+		Name:          repo,
+		FullName:      p.PathWithNamespace,
+		HTMLURL:       p.WebURL,
+		DefaultBranch: p.DefaultBranch,
+		Archived:      p.Archived,
+		CloneURI:      p.CloneURL(),
+	}
+}
+
+// GitHubPullRequest converts a merge request of the project into a GitHub
+// pull request.
+func GitHubPullRequest(p Project, mr MergeRequest) github.PullRequest {
+	repo := GitHubRepo(p)
+	pr := github.PullRequest{
+		ID:        mr.ID,
+		Number:    mr.IID,
+		HTMLURL:   mr.WebURL,
+		User:      GitHubUser(mr.Author),
+		Base:      github.PullRequestBranch{Ref: mr.TargetBranch, SHA: mr.DiffRefs.BaseSHA, Repo: repo},
+		Head:      github.PullRequestBranch{Ref: mr.SourceBranch, SHA: mr.SHA, Repo: repo},
+		Title:     mr.Title,
+		Body:      mr.Description,
+		Assignees: gitHubUsers(mr.Assignees),
+		// GitLab reviewers are requested, not submitted reviews.
+		RequestedReviewers: gitHubUsers(mr.Reviewers),
+		State:              github.PullRequestStateOpen,
+		Draft:              mr.Draft,
+		MergeSHA:           mr.MergeCommitSHA,
+		CreatedAt:          mr.CreatedAt,
+		UpdatedAt:          mr.UpdatedAt,
+		CloneRef:           fmt.Sprintf("refs/merge-requests/%d/head", mr.IID),
+	}
+	for _, label := range mr.Labels {
+		pr.Labels = append(pr.Labels, github.Label{Name: label})
+	}
+	switch mr.State {
+	case MergeRequestStateMerged:
+		pr.State = github.PullRequestStateClosed
+		pr.Merged = true
+	case MergeRequestStateClosed, MergeRequestStateLocked:
+		pr.State = github.PullRequestStateClosed
+	}
+	return pr
+}
+
+// PullRequestEvents converts a merge request webhook into the pull request
+// events GitHub would have sent for the same change. mr is the current state
+// of the merge request from the API, as webhooks only identify the author
+// and assignees by ID. Approvals are converted by ReviewEvent instead.
+func PullRequestEvents(e MergeRequestEvent, mr MergeRequest, guid string) []github.PullRequestEvent {
+	pr := GitHubPullRequest(e.Project, mr)
+	event := func(action github.PullRequestEventAction) github.PullRequestEvent {
+		return github.PullRequestEvent{
+			Action:      action,
+			Number:      pr.Number,
+			PullRequest: pr,
+			Repo:        pr.Base.Repo,
+			Sender:      GitHubUser(e.User),
+			GUID:        guid,
+		}
+	}
+
+	switch e.ObjectAttributes.Action {
+	case MergeRequestActionOpen:
+		return []github.PullRequestEvent{event(github.PullRequestActionOpened)}
+	case MergeRequestActionReopen:
+		return []github.PullRequestEvent{event(github.PullRequestActionReopened)}
+	case MergeRequestActionClose, MergeRequestActionMerge:
+		return []github.PullRequestEvent{event(github.PullRequestActionClosed)}
+	case MergeRequestActionUpdate:
+	default:
+		return nil
+	}
+
+	// A single update can carry several changes.
+	var events []github.PullRequestEvent
+	if e.ObjectAttributes.OldRev != "" {
+		events = append(events, event(github.PullRequestActionSynchronize))
+	}
+	if changes := e.Changes.Labels; changes != nil {
+		previous, current := map[string]bool{}, map[string]bool{}
+		for _, l := range changes.Previous {
+			previous[l.Title] = true
+		}
+		for _, l := range changes.Current {
+			current[l.Title] = true
+			if !previous[l.Title] {
+				labeled := event(github.PullRequestActionLabeled)
+				labeled.Label = github.Label{Name: l.Title}
+				events = append(events, labeled)
+			}
+		}
+		for _, l := range changes.Previous {
+			if !current[l.Title] {
+				unlabeled := event(github.PullRequestActionUnlabeled)
+				unlabeled.Label = github.Label{Name: l.Title}
+				events = append(events, unlabeled)
+			}
+		}
+	}
+	if e.Changes.Title != nil || e.Changes.Description != nil || e.Changes.TargetBranch != nil {
+		edited := event(github.PullRequestActionEdited)
+		if e.Changes.TargetBranch != nil {
+			// Trigger retests pull requests whose base changed.
+			var changes struct {
+				Base struct {
+					Ref struct {
+						From string `json:"from"`
+					} `json:"ref"`
+				} `json:"base"`
+			}
+			changes.Base.Ref.From = e.Changes.TargetBranch.Previous
+			edited.Changes, _ = json.Marshal(changes)
+		}
+		events = append(events, edited)
+	}
+	if draft := e.Changes.Draft; draft != nil {
+		if draft.Current {
+			events = append(events, event(github.PullRequestActionConvertedToDraft))
+		} else {
+			events = append(events, event(github.PullRequestActionReadyForReview))
+		}
+	}
+	return events
+}
+
+// ReviewEvent converts the approval of a merge request into an approving
+// review. It returns false for other merge request webhooks.
+func ReviewEvent(e MergeRequestEvent, mr MergeRequest, guid string) (github.ReviewEvent, bool) {
+	if e.ObjectAttributes.Action != MergeRequestActionApproved {
+		return github.ReviewEvent{}, false
+	}
+	pr := GitHubPullRequest(e.Project, mr)
+	return github.ReviewEvent{
+		Action:      github.ReviewActionSubmitted,
+		PullRequest: pr,
+		Repo:        pr.Base.Repo,
+		Review: github.Review{
+			User:    GitHubUser(e.User),
+			State:   github.ReviewStateApproved,
+			HTMLURL: e.ObjectAttributes.URL,
+		},
+		GUID: guid,
+	}, true
+}
+
+// IssueCommentEvent converts a comment on a merge request into the
+// equivalent comment on a pull request. It returns false for comments on
+// anything else, such as issues, commits or snippets.
+func IssueCommentEvent(e NoteEvent, mr MergeRequest, guid string) (github.IssueCommentEvent, bool) {
+	if e.ObjectAttributes.NoteableType != NoteableTypeMergeRequest {
+		return github.IssueCommentEvent{}, false
+	}
+	action := github.IssueCommentActionCreated
+	if e.ObjectAttributes.Action == "update" {
+		action = github.IssueCommentActionEdited
+	}
+	pr := GitHubPullRequest(e.Project, mr)
+	return github.IssueCommentEvent{
+		Action: action,
+		Issue: github.Issue{
+			ID:          pr.ID,
+			User:        pr.User,
+			Number:      pr.Number,
+			Title:       pr.Title,
+			State:       pr.State,
+			HTMLURL:     pr.HTMLURL,
+			Labels:      pr.Labels,
+			Assignees:   pr.Assignees,
+			Body:        pr.Body,
+			CreatedAt:   pr.CreatedAt,
+			UpdatedAt:   pr.UpdatedAt,
+			PullRequest: &struct{}{},
+		},
+		Comment: github.IssueComment{
+			ID:      e.ObjectAttributes.ID,
+			Body:    e.ObjectAttributes.Note,
+			User:    GitHubUser(e.User),
+			HTMLURL: e.ObjectAttributes.URL,
+		},
+		Repo: pr.Base.Repo,
+		GUID: guid,
+	}, true
+}
+
+// GitHubPushEvent converts a push webhook into a GitHub push event.
+func GitHubPushEvent(e PushEvent, guid string) github.PushEvent {
+	pusher := github.User{Login: e.UserUsername, Name: e.UserName, ID: e.UserID, Type: github.UserTypeUser}
+	pe := github.PushEvent{
+		Ref:     e.Ref,
+		Before:  e.Before,
+		After:   e.After,
+		Created: e.Before == zeroSHA,
+		Deleted: e.After == zeroSHA,
+		Pusher:  pusher,
+		Sender:  pusher,
+		Repo:    GitHubRepo(e.Project),
+		GUID:    guid,
+	}
+	if e.Project.WebURL != "" && !pe.Created && !pe.Deleted {
+		pe.Compare = e.Project.WebURL + "/-/compare/" + e.Before + "..." + e.After
+	}
+	for _, c := range e.Commits {
+		pe.Commits = append(pe.Commits, github.Commit{
+			ID:       c.ID,
+			Message:  strings.TrimSpace(c.Message),
+			Added:    c.Added,
+			Removed:  c.Removed,
+			Modified: c.Modified,
+		})
+	}
+	return pe
+}
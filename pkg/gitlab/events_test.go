@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+var testProject = Project{
+	ID:                1,
+	Name:              "repo",
+	PathWithNamespace: "group/sub/repo",
+	WebURL:            "https://gitlab.example.com/group/sub/repo",
+	DefaultBranch:     "main",
+	GitHTTPURL:        "https://gitlab.example.com/group/sub/repo.git",
+}
+
+func TestProjectOrgRepo(t *testing.T) {
+	org, repo := testProject.OrgRepo()
+	if org != "group/sub" || repo != "repo" {
+		t.Errorf("expected group/sub and repo, got %s and %s", org, repo)
+	}
+}
+
+func TestPullRequestEvents(t *testing.T) {
+	mr := MergeRequest{IID: 3, State: MergeRequestStateOpened, TargetBranch: "main", SourceBranch: "fix", SHA: "head"}
+	event := func(payload string) MergeRequestEvent {
+		var e MergeRequestEvent
+		if err := json.Unmarshal([]byte(payload), &e); err != nil {
+			t.Fatalf("failed to unmarshal event: %v", err)
+		}
+		e.Project = testProject
+		return e
+	}
+
+	testcases := []struct {
+		name     string
+		event    MergeRequestEvent
+		mr       MergeRequest
+		expected []string
+	}{
+		{
+			name:     "opened",
+			event:    event(`{"object_attributes": {"iid": 3, "action": "open"}}`),
+			mr:       mr,
+			expected: []string{"opened"},
+		},
+		{
+			name:     "merged",
+			event:    event(`{"object_attributes": {"iid": 3, "action": "merge"}}`),
+			mr:       MergeRequest{IID: 3, State: MergeRequestStateMerged},
+			expected: []string{"closed"},
+		},
+		{
+			name:     "pushed",
+			event:    event(`{"object_attributes": {"iid": 3, "action": "update", "oldrev": "abc"}}`),
+			mr:       mr,
+			expected: []string{"synchronize"},
+		},
+		{
+			name:     "labels changed",
+			event:    event(`{"object_attributes": {"iid": 3, "action": "update"}, "changes": {"labels": {"previous": [{"title": "a"}, {"title": "b"}], "current": [{"title": "b"}, {"title": "c"}]}}}`),
+			mr:       mr,
+			expected: []string{"labeled c", "unlabeled a"},
+		},
+		{
+			name:     "target branch and draft changed",
+			event:    event(`{"object_attributes": {"iid": 3, "action": "update"}, "changes": {"target_branch": {"previous": "old", "current": "main"}, "draft": {"previous": true, "current": false}}}`),
+			mr:       mr,
+			expected: []string{"edited", "ready_for_review"},
+		},
+		{
+			name:  "approval is not a pull request event",
+			event: event(`{"object_attributes": {"iid": 3, "action": "approved"}}`),
+			mr:    mr,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actions []string
+			for _, e := range PullRequestEvents(tc.event, tc.mr, "guid") {
+				action := string(e.Action)
+				if e.Label.Name != "" {
+					action += " " + e.Label.Name
+				}
+				actions = append(actions, action)
+				if e.GUID != "guid" || e.Number != 3 || e.Repo.Owner.Login != "group/sub" || e.Repo.Name != "repo" {
+					t.Errorf("unexpected event %+v", e)
+				}
+			}
+			if diff := cmp.Diff(tc.expected, actions); diff != "" {
+				t.Errorf("actions differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitHubPullRequest(t *testing.T) {
+	mergeSHA := "merge"
+	mr := MergeRequest{
+		ID:             10,
+		IID:            3,
+		Title:          "Fix",
+		Description:    "Fixes things",
+		State:          MergeRequestStateMerged,
+		TargetBranch:   "main",
+		SourceBranch:   "fix",
+		Author:         User{ID: 1, Username: "alice"},
+		Assignees:      []User{{ID: 2, Username: "bob"}},
+		Labels:         []string{"lgtm"},
+		SHA:            "head",
+		MergeCommitSHA: &mergeSHA,
+		WebURL:         "https://gitlab.example.com/group/sub/repo/-/merge_requests/3",
+		DiffRefs:       DiffRefs{BaseSHA: "base"},
+	}
+	repo := github.Repo{Owner: github.User{Login: "group/sub", Name: "group/sub"}, Name: "repo", FullName: "group/sub/repo", HTMLURL: testProject.WebURL, DefaultBranch: "main", CloneURI: testProject.GitHTTPURL}
+	expected := github.PullRequest{
+		ID:        10,
+		Number:    3,
+		HTMLURL:   mr.WebURL,
+		User:      github.User{Login: "alice", ID: 1, Type: github.UserTypeUser},
+		Labels:    []github.Label{{Name: "lgtm"}},
+		Base:      github.PullRequestBranch{Ref: "main", SHA: "base", Repo: repo},
+		Head:      github.PullRequestBranch{Ref: "fix", SHA: "head", Repo: repo},
+		Title:     "Fix",
+		Body:      "Fixes things",
+		Assignees: []github.User{{Login: "bob", ID: 2, Type: github.UserTypeUser}},
+		State:     github.PullRequestStateClosed,
+		Merged:    true,
+		MergeSHA:  &mergeSHA,
+		CloneRef:  "refs/merge-requests/3/head",
+	}
+	if diff := cmp.Diff(expected, GitHubPullRequest(testProject, mr)); diff != "" {
+		t.Errorf("pull request differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestIssueCommentEvent(t *testing.T) {
+	var e NoteEvent
+	if err := json.Unmarshal([]byte(`{"user": {"username": "bob"}, "object_attributes": {"id": 5, "note": "/retest", "noteable_type": "MergeRequest", "action": "update"}, "merge_request": {"iid": 3}}`), &e); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	e.Project = testProject
+	ic, ok := IssueCommentEvent(e, MergeRequest{IID: 3, State: MergeRequestStateOpened, Author: User{Username: "alice"}}, "guid")
+	if !ok {
+		t.Fatal("expected comment on merge request to be converted")
+	}
+	if ic.Action != github.IssueCommentActionEdited || !ic.Issue.IsPullRequest() || ic.Issue.Number != 3 || ic.Issue.User.Login != "alice" || ic.Comment.ID != 5 || ic.Comment.Body != "/retest" || ic.Comment.User.Login != "bob" {
+		t.Errorf("unexpected event %+v", ic)
+	}
+
+	e.ObjectAttributes.NoteableType = "Issue"
+	if _, ok := IssueCommentEvent(e, MergeRequest{}, "guid"); ok {
+		t.Error("expected comment on issue not to be converted")
+	}
+}
+
+func TestGitHubPushEvent(t *testing.T) {
+	var e PushEvent
+	if err := json.Unmarshal([]byte(`{"before": "0000000000000000000000000000000000000000", "after": "abc", "ref": "refs/heads/main", "user_username": "alice", "commits": [{"id": "abc", "message": "Fix\n", "modified": ["a.go"]}]}`), &e); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	e.Project = testProject
+	pe := GitHubPushEvent(e, "guid")
+	expected := github.PushEvent{
+		Ref:     "refs/heads/main",
+		Before:  zeroSHA,
+		After:   "abc",
+		Created: true,
+		Commits: []github.Commit{{ID: "abc", Message: "Fix", Modified: []string{"a.go"}}},
+		Pusher:  github.User{Login: "alice", Type: github.UserTypeUser},
+		Sender:  github.User{Login: "alice", Type: github.UserTypeUser},
+		Repo:    github.Repo{Owner: github.User{Login: "group/sub", Name: "group/sub"}, Name: "repo", FullName: "group/sub/repo", HTMLURL: testProject.WebURL, DefaultBranch: "main", CloneURI: testProject.GitHTTPURL},
+		GUID:    "guid",
+	}
+	if diff := cmp.Diff(expected, pe); diff != "" {
+		t.Errorf("push event differs from expected (-want +got):\n%s", diff)
+	}
+}
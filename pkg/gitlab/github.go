@@ -0,0 +1,438 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// gitHubClient serves the GitHub API calls of plugins from GitLab. Only the
+// calls used by the core plugins (trigger, lgtm and label) are translated,
+// all others are passed through to the embedded GitHub client and fail for
+// repos that are not on GitHub.
+type gitHubClient struct {
+	github.Client
+	gl Client
+
+	lock sync.Mutex
+	used bool
+	// notes maps the IDs of notes, which GitLab only addresses through
+	// their merge request, to the IID of the merge request.
+	notes map[int]int
+	// isBot is populated lazily by BotUserChecker.
+	isBot func(string) bool
+}
+
+// NewGitHubClient returns a GitHub client that operates on the merge
+// requests of GitLab projects, addressing them as org/repo#IID where org is
+// the full path of the namespace of the project.
+func NewGitHubClient(gl Client, gh github.Client) github.Client {
+	return &gitHubClient{Client: gh, gl: gl, notes: map[int]int{}}
+}
+
+func (c *gitHubClient) mutated() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.used = true
+}
+
+// Used reports whether the client mutated anything on GitLab or GitHub.
+func (c *gitHubClient) Used() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.used || (c.Client != nil && c.Client.Used())
+}
+
+func (c *gitHubClient) rememberNote(id, iid int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.notes[id] = iid
+}
+
+func (c *gitHubClient) noteIID(id int) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	iid, ok := c.notes[id]
+	if !ok {
+		return 0, fmt.Errorf("unknown merge request of note %d, notes must be listed before they are changed", id)
+	}
+	return iid, nil
+}
+
+func (c *gitHubClient) BotUser() (*github.UserData, error) {
+	user, err := c.gl.CurrentUser()
+	if err != nil {
+		return nil, err
+	}
+	return &github.UserData{Login: user.Username, Name: user.Name}, nil
+}
+
+func (c *gitHubClient) BotUserChecker() (func(candidate string) bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.isBot == nil {
+		user, err := c.gl.CurrentUser()
+		if err != nil {
+			return nil, err
+		}
+		login := github.NormLogin(user.Username)
+		c.isBot = func(candidate string) bool {
+			return github.NormLogin(candidate) == login
+		}
+	}
+	return c.isBot, nil
+}
+
+func (c *gitHubClient) userID(login string) (int, error) {
+	user, err := c.gl.GetUser(login)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// IsCollaborator returns whether the user has at least developer access to
+// the project, which allows pushing to non-protected branches.
+func (c *gitHubClient) IsCollaborator(org, repo, user string) (bool, error) {
+	id, err := c.userID(user)
+	if IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	member, err := c.gl.GetProjectMember(org, repo, id)
+	if IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return member.AccessLevel >= DeveloperAccess, nil
+}
+
+// IsMember returns whether the user is a direct or inherited member of the
+// group.
+func (c *gitHubClient) IsMember(org, user string) (bool, error) {
+	id, err := c.userID(user)
+	if IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if _, err := c.gl.GetGroupMember(org, id); IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListTeams returns the subgroups of the group as teams, their path is the
+// team slug.
+func (c *gitHubClient) ListTeams(org string) ([]github.Team, error) {
+	groups, err := c.gl.ListSubgroups(org)
+	if err != nil {
+		return nil, err
+	}
+	var teams []github.Team
+	for _, g := range groups {
+		teams = append(teams, github.Team{ID: g.ID, Name: g.Name, Slug: g.Path})
+	}
+	return teams, nil
+}
+
+// ListTeamMembersBySlug returns the members of the subgroup, maintainers are
+// members with at least maintainer access.
+func (c *gitHubClient) ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error) {
+	members, err := c.gl.ListGroupMembers(org + "/" + teamSlug)
+	if err != nil {
+		return nil, err
+	}
+	var teamMembers []github.TeamMember
+	for _, m := range members {
+		maintainer := m.AccessLevel >= MaintainerAccess
+		if (role == github.RoleMaintainer && !maintainer) || (role == github.RoleMember && maintainer) {
+			continue
+		}
+		teamMembers = append(teamMembers, github.TeamMember{Login: m.Username})
+	}
+	return teamMembers, nil
+}
+
+func (c *gitHubClient) TeamBySlugHasMember(org, teamSlug, memberLogin string) (bool, error) {
+	return c.IsMember(org+"/"+teamSlug, memberLogin)
+}
+
+func (c *gitHubClient) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	project, err := c.gl.GetProject(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	mr, err := c.gl.GetMergeRequest(org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	pr := GitHubPullRequest(*project, *mr)
+	return &pr, nil
+}
+
+func (c *gitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	diffs, err := c.gl.ListMergeRequestDiffs(org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	var changes []github.PullRequestChange
+	for _, d := range diffs {
+		change := github.PullRequestChange{Filename: d.NewPath, Status: string(github.PullRequestFileModified), Patch: d.Diff}
+		switch {
+		case d.NewFile:
+			change.Status = github.PullRequestFileAdded
+		case d.DeletedFile:
+			change.Status = github.PullRequestFileRemoved
+		case d.RenamedFile:
+			change.Status = github.PullRequestFileRenamed
+			change.PreviousFilename = d.OldPath
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (c *gitHubClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
+	mr, err := c.gl.GetMergeRequest(org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	var labels []github.Label
+	for _, l := range mr.Labels {
+		labels = append(labels, github.Label{Name: l})
+	}
+	return labels, nil
+}
+
+func (c *gitHubClient) GetRepoLabels(org, repo string) ([]github.Label, error) {
+	projectLabels, err := c.gl.ListLabels(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	var labels []github.Label
+	for _, l := range projectLabels {
+		labels = append(labels, github.Label{Name: l.Name, Color: strings.TrimPrefix(l.Color, "#"), Description: l.Description})
+	}
+	return labels, nil
+}
+
+func (c *gitHubClient) AddLabel(org, repo string, number int, label string) error {
+	c.mutated()
+	return c.gl.UpdateMergeRequest(org, repo, number, MergeRequestUpdate{AddLabels: label})
+}
+
+func (c *gitHubClient) RemoveLabel(org, repo string, number int, label string) error {
+	c.mutated()
+	return c.gl.UpdateMergeRequest(org, repo, number, MergeRequestUpdate{RemoveLabels: label})
+}
+
+// userIDs resolves the logins and adds them to the users, it returns false if
+// all logins are already among the users.
+func (c *gitHubClient) userIDs(users []User, logins []string) ([]int, bool, error) {
+	ids := []int{}
+	have := map[string]bool{}
+	for _, u := range users {
+		ids = append(ids, u.ID)
+		have[github.NormLogin(u.Username)] = true
+	}
+	changed := false
+	for _, login := range logins {
+		if have[github.NormLogin(login)] {
+			continue
+		}
+		id, err := c.userID(login)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to look up user %s: %w", login, err)
+		}
+		ids = append(ids, id)
+		have[github.NormLogin(login)] = true
+		changed = true
+	}
+	return ids, changed, nil
+}
+
+func (c *gitHubClient) AssignIssue(org, repo string, number int, logins []string) error {
+	mr, err := c.gl.GetMergeRequest(org, repo, number)
+	if err != nil {
+		return err
+	}
+	ids, changed, err := c.userIDs(mr.Assignees, logins)
+	if err != nil || !changed {
+		return err
+	}
+	c.mutated()
+	return c.gl.UpdateMergeRequest(org, repo, number, MergeRequestUpdate{AssigneeIDs: &ids})
+}
+
+func (c *gitHubClient) RequestReview(org, repo string, number int, logins []string) error {
+	mr, err := c.gl.GetMergeRequest(org, repo, number)
+	if err != nil {
+		return err
+	}
+	ids, changed, err := c.userIDs(mr.Reviewers, logins)
+	if err != nil || !changed {
+		return err
+	}
+	c.mutated()
+	return c.gl.UpdateMergeRequest(org, repo, number, MergeRequestUpdate{ReviewerIDs: &ids})
+}
+
+// ListIssueComments returns the notes of the merge request, leaving out the
+// system notes GitLab adds for changes such as labels or pushes.
+func (c *gitHubClient) ListIssueComments(org, repo string, number int) ([]github.IssueComment, error) {
+	notes, err := c.gl.ListNotes(org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	var comments []github.IssueComment
+	for _, n := range notes {
+		c.rememberNote(n.ID, number)
+		if n.System {
+			continue
+		}
+		comments = append(comments, github.IssueComment{
+			ID:        n.ID,
+			Body:      n.Body,
+			User:      GitHubUser(n.Author),
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+		})
+	}
+	return comments, nil
+}
+
+func (c *gitHubClient) CreateComment(org, repo string, number int, comment string) error {
+	c.mutated()
+	note, err := c.gl.CreateNote(org, repo, number, comment)
+	if err != nil {
+		return err
+	}
+	c.rememberNote(note.ID, number)
+	return nil
+}
+
+func (c *gitHubClient) EditComment(org, repo string, id int, comment string) error {
+	iid, err := c.noteIID(id)
+	if err != nil {
+		return err
+	}
+	c.mutated()
+	return c.gl.EditNote(org, repo, iid, id, comment)
+}
+
+func (c *gitHubClient) DeleteComment(org, repo string, id int) error {
+	iid, err := c.noteIID(id)
+	if err != nil {
+		return err
+	}
+	c.mutated()
+	return c.gl.DeleteNote(org, repo, iid, id)
+}
+
+func (c *gitHubClient) DeleteStaleComments(org, repo string, number int, comments []github.IssueComment, isStale func(github.IssueComment) bool) error {
+	// Listing the comments also records their merge request.
+	listed, err := c.ListIssueComments(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to list comments while deleting stale comments. err: %w", err)
+	}
+	if comments == nil {
+		comments = listed
+	}
+	for _, comment := range comments {
+		if isStale(comment) {
+			if err := c.DeleteComment(org, repo, comment.ID); err != nil {
+				return fmt.Errorf("failed to delete stale comment with ID '%d'", comment.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// GetRef resolves branches, given as heads/<branch>, to their commit.
+func (c *gitHubClient) GetRef(org, repo, ref string) (string, error) {
+	branch, ok := strings.CutPrefix(ref, "heads/")
+	if !ok {
+		return "", fmt.Errorf("unsupported ref %q, only branches are supported on GitLab", ref)
+	}
+	b, err := c.gl.GetBranch(org, repo, branch)
+	if err != nil {
+		return "", err
+	}
+	return b.Commit.ID, nil
+}
+
+func (c *gitHubClient) CreateStatus(org, repo, sha string, status github.Status) error {
+	state := StatusPending
+	switch status.State {
+	case github.StatusSuccess:
+		state = StatusSuccess
+	case github.StatusError, github.StatusFailure:
+		state = StatusFailed
+	}
+	c.mutated()
+	return c.gl.SetCommitStatus(org, repo, sha, CommitStatus{
+		Name:        status.Context,
+		Status:      state,
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+	})
+}
+
+// GetCombinedStatus combines the commit statuses the way GitHub does: it is
+// failed if any status failed, pending if any is still pending and
+// successful otherwise.
+func (c *gitHubClient) GetCombinedStatus(org, repo, sha string) (*github.CombinedStatus, error) {
+	statuses, err := c.gl.ListCommitStatuses(org, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	combined := &github.CombinedStatus{SHA: sha, State: github.StatusSuccess}
+	for _, s := range statuses {
+		state := github.StatusPending
+		switch s.Status {
+		case StatusSuccess:
+			state = github.StatusSuccess
+		case StatusFailed, StatusCanceled:
+			state = github.StatusFailure
+		}
+		combined.Statuses = append(combined.Statuses, github.Status{
+			State:       state,
+			TargetURL:   s.TargetURL,
+			Description: s.Description,
+			Context:     s.Name,
+		})
+		if state == github.StatusFailure || (state == github.StatusPending && combined.State == github.StatusSuccess) {
+			combined.State = state
+		}
+	}
+	return combined, nil
+}
+
+// GetFailedActionRunsByHeadBranch returns no runs, GitHub Actions do not
+// exist on GitLab.
+func (c *gitHubClient) GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]github.WorkflowRun, error) {
+	return nil, nil
+}
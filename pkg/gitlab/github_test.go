@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+type fakeClient struct {
+	Client
+	users    map[string]User
+	members  map[string]map[int]int
+	mr       MergeRequest
+	notes    []Note
+	updates  []MergeRequestUpdate
+	deleted  []int
+	statuses []CommitStatus
+}
+
+var notFound = StatusError{Code: http.StatusNotFound}
+
+func (f *fakeClient) CurrentUser() (*User, error) {
+	return &User{ID: 99, Username: "bot"}, nil
+}
+
+func (f *fakeClient) GetUser(username string) (*User, error) {
+	u, ok := f.users[username]
+	if !ok {
+		return nil, notFound
+	}
+	return &u, nil
+}
+
+func (f *fakeClient) member(path string, userID int) (*Member, error) {
+	level, ok := f.members[path][userID]
+	if !ok {
+		return nil, notFound
+	}
+	return &Member{User: User{ID: userID}, AccessLevel: level}, nil
+}
+
+func (f *fakeClient) GetProjectMember(org, repo string, userID int) (*Member, error) {
+	return f.member(org+"/"+repo, userID)
+}
+
+func (f *fakeClient) GetGroupMember(group string, userID int) (*Member, error) {
+	return f.member(group, userID)
+}
+
+func (f *fakeClient) GetMergeRequest(org, repo string, iid int) (*MergeRequest, error) {
+	mr := f.mr
+	return &mr, nil
+}
+
+func (f *fakeClient) UpdateMergeRequest(org, repo string, iid int, update MergeRequestUpdate) error {
+	f.updates = append(f.updates, update)
+	return nil
+}
+
+func (f *fakeClient) ListNotes(org, repo string, iid int) ([]Note, error) {
+	return f.notes, nil
+}
+
+func (f *fakeClient) DeleteNote(org, repo string, iid, id int) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeClient) ListCommitStatuses(org, repo, sha string) ([]CommitStatus, error) {
+	return f.statuses, nil
+}
+
+func TestGitHubClientMembership(t *testing.T) {
+	gl := &fakeClient{
+		users: map[string]User{"dev": {ID: 1}, "reporter": {ID: 2}, "outsider": {ID: 3}},
+		members: map[string]map[int]int{
+			"group/repo": {1: DeveloperAccess, 2: 20},
+			"group":      {1: DeveloperAccess, 2: 20},
+			"group/team": {1: MaintainerAccess},
+		},
+	}
+	c := NewGitHubClient(gl, github.NewFakeClient())
+	for _, tc := range []struct {
+		user         string
+		collaborator bool
+		member       bool
+		teamMember   bool
+	}{
+		{user: "dev", collaborator: true, member: true, teamMember: true},
+		{user: "reporter", member: true},
+		{user: "outsider"},
+		{user: "unknown"},
+	} {
+		if got, err := c.IsCollaborator("group", "repo", tc.user); err != nil || got != tc.collaborator {
+			t.Errorf("expected IsCollaborator of %s to be %t, got %t, %v", tc.user, tc.collaborator, got, err)
+		}
+		if got, err := c.IsMember("group", tc.user); err != nil || got != tc.member {
+			t.Errorf("expected IsMember of %s to be %t, got %t, %v", tc.user, tc.member, got, err)
+		}
+		if got, err := c.TeamBySlugHasMember("group", "team", tc.user); err != nil || got != tc.teamMember {
+			t.Errorf("expected TeamBySlugHasMember of %s to be %t, got %t, %v", tc.user, tc.teamMember, got, err)
+		}
+	}
+}
+
+func TestGitHubClientComments(t *testing.T) {
+	gl := &fakeClient{notes: []Note{
+		{ID: 1, Body: "added label", System: true},
+		{ID: 2, Body: "stale", Author: User{Username: "bot"}},
+		{ID: 3, Body: "/lgtm", Author: User{Username: "alice"}},
+	}}
+	c := NewGitHubClient(gl, github.NewFakeClient())
+
+	if err := c.DeleteComment("group", "repo", 2); err == nil {
+		t.Error("expected error deleting a comment that was not listed")
+	}
+	comments, err := c.ListIssueComments("group", "repo", 3)
+	if err != nil {
+		t.Fatalf("failed to list comments: %v", err)
+	}
+	if len(comments) != 2 || comments[0].ID != 2 || comments[1].User.Login != "alice" {
+		t.Errorf("expected system notes to be left out, got %+v", comments)
+	}
+	if err := c.DeleteStaleComments("group", "repo", 3, nil, func(c github.IssueComment) bool { return c.Body == "stale" }); err != nil {
+		t.Fatalf("failed to delete stale comments: %v", err)
+	}
+	if diff := cmp.Diff([]int{2}, gl.deleted); diff != "" {
+		t.Errorf("deleted notes differ from expected (-want +got):\n%s", diff)
+	}
+	if !c.Used() {
+		t.Error("expected client to be used after deleting a comment")
+	}
+}
+
+func TestGitHubClientAssignIssue(t *testing.T) {
+	gl := &fakeClient{
+		users: map[string]User{"alice": {ID: 1, Username: "alice"}, "bob": {ID: 2, Username: "bob"}},
+		mr:    MergeRequest{Assignees: []User{{ID: 1, Username: "alice"}}},
+	}
+	c := NewGitHubClient(gl, github.NewFakeClient())
+	if err := c.AssignIssue("group", "repo", 3, []string{"Alice"}); err != nil {
+		t.Fatalf("failed to assign: %v", err)
+	}
+	if len(gl.updates) != 0 {
+		t.Errorf("expected no update when already assigned, got %+v", gl.updates)
+	}
+	if err := c.AssignIssue("group", "repo", 3, []string{"bob"}); err != nil {
+		t.Fatalf("failed to assign: %v", err)
+	}
+	expected := []MergeRequestUpdate{{AssigneeIDs: &[]int{1, 2}}}
+	if diff := cmp.Diff(expected, gl.updates); diff != "" {
+		t.Errorf("updates differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitHubClientGetCombinedStatus(t *testing.T) {
+	testcases := []struct {
+		name     string
+		statuses []CommitStatus
+		expected string
+	}{
+		{
+			name:     "no statuses",
+			expected: github.StatusSuccess,
+		},
+		{
+			name:     "running",
+			statuses: []CommitStatus{{Name: "a", Status: StatusSuccess}, {Name: "b", Status: StatusRunning}},
+			expected: github.StatusPending,
+		},
+		{
+			name:     "failed",
+			statuses: []CommitStatus{{Name: "a", Status: StatusFailed}, {Name: "b", Status: StatusPending}},
+			expected: github.StatusFailure,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewGitHubClient(&fakeClient{statuses: tc.statuses}, github.NewFakeClient())
+			combined, err := c.GetCombinedStatus("group", "repo", "sha")
+			if err != nil {
+				t.Fatalf("failed to get combined status: %v", err)
+			}
+			if combined.State != tc.expected || len(combined.Statuses) != len(tc.statuses) {
+				t.Errorf("expected state %s, got %+v", tc.expected, combined)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// Name is the name of the provider, which is also the source of its events
+// in the event queue.
+const Name = "gitlab"
+
+// NewProvider returns the provider for the GitLab instance that gl talks to.
+// Webhooks are validated against the secret token from webhookSecret.
+func NewProvider(gl Client, webhookSecret func() []byte) scm.Provider {
+	return &provider{
+		gl:            gl,
+		gh:            &gitHubClient{gl: gl, notes: map[int]int{}},
+		webhookSecret: webhookSecret,
+	}
+}
+
+// provider implements the calls of the core CI flow with the translations of
+// gitHubClient, merge requests are addressed as org/repo#IID where org is the
+// full path of the namespace of the project.
+type provider struct {
+	gl            Client
+	gh            *gitHubClient
+	webhookSecret func() []byte
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+// GitHubClient returns a GitHub client that translates the calls of the
+// labeling and review plugins as well, see NewGitHubClient.
+func (p *provider) GitHubClient(gh github.Client) github.Client {
+	return NewGitHubClient(p.gl, gh)
+}
+
+func (p *provider) BotLogin() (string, error) {
+	user, err := p.gl.CurrentUser()
+	if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+func (p *provider) IsCollaborator(org, repo, user string) (bool, error) {
+	return p.gh.IsCollaborator(org, repo, user)
+}
+
+func (p *provider) IsMember(org, user string) (bool, error) {
+	return p.gh.IsMember(org, user)
+}
+
+func (p *provider) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	return p.gh.GetPullRequest(org, repo, number)
+}
+
+func (p *provider) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	return p.gh.GetPullRequestChanges(org, repo, number)
+}
+
+func (p *provider) GetRef(org, repo, ref string) (string, error) {
+	return p.gh.GetRef(org, repo, ref)
+}
+
+func (p *provider) ListComments(org, repo string, number int) ([]github.IssueComment, error) {
+	return p.gh.ListIssueComments(org, repo, number)
+}
+
+func (p *provider) CreateComment(org, repo string, number int, body string) (*github.IssueComment, error) {
+	note, err := p.gl.CreateNote(org, repo, number, body)
+	if err != nil {
+		return nil, err
+	}
+	return &github.IssueComment{
+		ID:        note.ID,
+		Body:      note.Body,
+		User:      GitHubUser(note.Author),
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}, nil
+}
+
+func (p *provider) EditComment(org, repo string, number, id int, body string) error {
+	return p.gl.EditNote(org, repo, number, id, body)
+}
+
+func (p *provider) DeleteComment(org, repo string, number, id int) error {
+	return p.gl.DeleteNote(org, repo, number, id)
+}
+
+func (p *provider) ListStatuses(org, repo, sha string) ([]github.Status, error) {
+	combined, err := p.gh.GetCombinedStatus(org, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	return combined.Statuses, nil
+}
+
+func (p *provider) CreateStatus(org, repo, sha string, status github.Status) error {
+	return p.gh.CreateStatus(org, repo, sha, status)
+}
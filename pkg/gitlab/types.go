@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"strings"
+	"time"
+)
+
+// Merge request states, see https://docs.gitlab.com/ee/api/merge_requests.html
+const (
+	MergeRequestStateOpened = "opened"
+	MergeRequestStateClosed = "closed"
+	MergeRequestStateLocked = "locked"
+	MergeRequestStateMerged = "merged"
+)
+
+// Access levels of project and group members, see
+// https://docs.gitlab.com/ee/api/members.html#roles
+const (
+	DeveloperAccess  = 30
+	MaintainerAccess = 40
+	OwnerAccess      = 50
+)
+
+// User is a GitLab user as embedded in API responses and webhooks.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	WebURL   string `json:"web_url,omitempty"`
+}
+
+// Member is a user with access to a project or group.
+type Member struct {
+	User
+	AccessLevel int `json:"access_level"`
+}
+
+// Group is a GitLab group. Prow treats top-level groups as orgs and their
+// subgroups as teams.
+type Group struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+// Project is a GitLab project, which Prow treats as a repo.
+type Project struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	DefaultBranch     string `json:"default_branch"`
+	HTTPURLToRepo     string `json:"http_url_to_repo,omitempty"`
+	// GitHTTPURL is the name of the clone URL in webhook payloads.
+	GitHTTPURL string `json:"git_http_url,omitempty"`
+	Archived   bool   `json:"archived,omitempty"`
+}
+
+// OrgRepo splits the full path of the project into its namespace, which Prow
+// treats as the org, and the path of the project within it.
+func (p Project) OrgRepo() (string, string) {
+	i := strings.LastIndex(p.PathWithNamespace, "/")
+	if i < 0 {
+		return "", p.PathWithNamespace
+	}
+	return p.PathWithNamespace[:i], p.PathWithNamespace[i+1:]
+}
+
+// CloneURL returns the HTTP clone URL of the project, which API responses and
+// webhook payloads name differently.
+func (p Project) CloneURL() string {
+	if p.HTTPURLToRepo != "" {
+		return p.HTTPURLToRepo
+	}
+	return p.GitHTTPURL
+}
+
+// DiffRefs holds the commits a merge request diff is computed between.
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// MergeRequest is a merge request as returned by the GitLab API.
+type MergeRequest struct {
+	ID             int       `json:"id"`
+	IID            int       `json:"iid"`
+	ProjectID      int       `json:"project_id"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	State          string    `json:"state"`
+	Draft          bool      `json:"draft"`
+	TargetBranch   string    `json:"target_branch"`
+	SourceBranch   string    `json:"source_branch"`
+	Author         User      `json:"author"`
+	Assignees      []User    `json:"assignees"`
+	Reviewers      []User    `json:"reviewers"`
+	Labels         []string  `json:"labels"`
+	SHA            string    `json:"sha"`
+	MergeCommitSHA *string   `json:"merge_commit_sha"`
+	WebURL         string    `json:"web_url"`
+	DiffRefs       DiffRefs  `json:"diff_refs"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Note is a comment on a merge request.
+type Note struct {
+	ID        int       `json:"id"`
+	Body      string    `json:"body"`
+	Author    User      `json:"author"`
+	System    bool      `json:"system"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Label is a project label.
+type Label struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// Diff is the change of a single file in a merge request.
+type Diff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// Branch is a repository branch.
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// Commit status states, see https://docs.gitlab.com/ee/api/commits.html#set-the-pipeline-status-of-a-commit
+const (
+	StatusPending  = "pending"
+	StatusRunning  = "running"
+	StatusSuccess  = "success"
+	StatusFailed   = "failed"
+	StatusCanceled = "canceled"
+)
+
+// CommitStatus is an external status of a commit, the equivalent of a
+// GitHub status context.
+type CommitStatus struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// MergeRequestUpdate holds the fields of a merge request to update, unset
+// fields are left unchanged.
+type MergeRequestUpdate struct {
+	AddLabels    string `json:"add_labels,omitempty"`
+	RemoveLabels string `json:"remove_labels,omitempty"`
+	AssigneeIDs  *[]int `json:"assignee_ids,omitempty"`
+	ReviewerIDs  *[]int `json:"reviewer_ids,omitempty"`
+}
+
+// Merge request actions sent in merge request webhooks.
+const (
+	MergeRequestActionOpen     = "open"
+	MergeRequestActionClose    = "close"
+	MergeRequestActionReopen   = "reopen"
+	MergeRequestActionUpdate   = "update"
+	MergeRequestActionMerge    = "merge"
+	MergeRequestActionApproved = "approved"
+)
+
+// MergeRequestEvent is the payload of a merge request webhook, see
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#merge-request-events
+type MergeRequestEvent struct {
+	ObjectKind       string  `json:"object_kind"`
+	User             User    `json:"user"`
+	Project          Project `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Action string `json:"action"`
+		// OldRev is set when new commits were pushed to the merge request.
+		OldRev string `json:"oldrev"`
+		URL    string `json:"url"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Labels *struct {
+			Previous []EventLabel `json:"previous"`
+			Current  []EventLabel `json:"current"`
+		} `json:"labels"`
+		Title        *struct{} `json:"title"`
+		Description  *struct{} `json:"description"`
+		TargetBranch *struct {
+			Previous string `json:"previous"`
+		} `json:"target_branch"`
+		Draft *struct {
+			Current bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// EventLabel is a label as embedded in webhooks.
+type EventLabel struct {
+	Title string `json:"title"`
+}
+
+// Noteable types of notes.
+const (
+	NoteableTypeMergeRequest = "MergeRequest"
+)
+
+// NoteEvent is the payload of a comment webhook, see
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#comment-events
+type NoteEvent struct {
+	ObjectKind       string  `json:"object_kind"`
+	User             User    `json:"user"`
+	Project          Project `json:"project"`
+	ObjectAttributes struct {
+		ID           int    `json:"id"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		// Action is "create" or "update", it is unset on older GitLab versions.
+		Action string `json:"action"`
+		URL    string `json:"url"`
+	} `json:"object_attributes"`
+	MergeRequest *struct {
+		IID int `json:"iid"`
+	} `json:"merge_request"`
+}
+
+// PushEvent is the payload of a push webhook, see
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#push-events
+type PushEvent struct {
+	ObjectKind   string  `json:"object_kind"`
+	Before       string  `json:"before"`
+	After        string  `json:"after"`
+	Ref          string  `json:"ref"`
+	UserID       int     `json:"user_id"`
+	UserName     string  `json:"user_name"`
+	UserUsername string  `json:"user_username"`
+	Project      Project `json:"project"`
+	Commits      []struct {
+		ID       string   `json:"id"`
+		Message  string   `json:"message"`
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
+	} `json:"commits"`
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// Event types sent in the X-Gitlab-Event header.
+const (
+	MergeRequestHook = "Merge Request Hook"
+	NoteHook         = "Note Hook"
+	PushHook         = "Push Hook"
+)
+
+// ValidateWebhook ensures that the provided request conforms to the
+// format of a GitLab webhook and that its secret token matches the webhook
+// secret. The returned values mirror github.ValidateWebhook: the event type,
+// the event GUID, the payload, whether the webhook is valid and the HTTP
+// status code that was responded with.
+func (p *provider) ValidateWebhook(w http.ResponseWriter, r *http.Request) (string, string, []byte, bool, int) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPost {
+		responseHTTPError(w, http.StatusMethodNotAllowed, "405 Method not allowed")
+		return "", "", nil, false, http.StatusMethodNotAllowed
+	}
+	eventType := r.Header.Get("X-Gitlab-Event")
+	if eventType == "" {
+		responseHTTPError(w, http.StatusBadRequest, "400 Bad Request: Missing X-Gitlab-Event Header")
+		return "", "", nil, false, http.StatusBadRequest
+	}
+	// Older GitLab versions do not send a delivery UUID, so it is optional.
+	eventGUID := r.Header.Get("X-Gitlab-Event-UUID")
+	token := r.Header.Get("X-Gitlab-Token")
+	if token == "" {
+		responseHTTPError(w, http.StatusForbidden, "403 Forbidden: Missing X-Gitlab-Token")
+		return "", "", nil, false, http.StatusForbidden
+	}
+	if contentType := r.Header.Get("content-type"); contentType != "application/json" {
+		responseHTTPError(w, http.StatusBadRequest, "400 Bad Request: Hook only accepts content-type: application/json")
+		return "", "", nil, false, http.StatusBadRequest
+	}
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		responseHTTPError(w, http.StatusInternalServerError, "500 Internal Server Error: Failed to read request body")
+		return "", "", nil, false, http.StatusInternalServerError
+	}
+	if subtle.ConstantTimeCompare([]byte(token), p.webhookSecret()) != 1 {
+		responseHTTPError(w, http.StatusForbidden, "403 Forbidden: Invalid X-Gitlab-Token")
+		return "", "", nil, false, http.StatusForbidden
+	}
+
+	return eventType, eventGUID, payload, true, http.StatusOK
+}
+
+// ParseWebhook converts a webhook into the GitHub events it is equivalent
+// to. Merge request webhooks lack some details of the merge request, so it is
+// looked up through the API.
+func (p *provider) ParseWebhook(eventType, eventGUID string, payload []byte) (*scm.Events, error) {
+	events := &scm.Events{}
+	switch eventType {
+	case MergeRequestHook:
+		var e MergeRequestEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		org, repo := e.Project.OrgRepo()
+		mr, err := p.gl.GetMergeRequest(org, repo, e.ObjectAttributes.IID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get merge request %s!%d: %w", e.Project.PathWithNamespace, e.ObjectAttributes.IID, err)
+		}
+		if re, ok := ReviewEvent(e, *mr, eventGUID); ok {
+			events.Reviews = append(events.Reviews, re)
+		}
+		events.PullRequests = append(events.PullRequests, PullRequestEvents(e, *mr, eventGUID)...)
+	case NoteHook:
+		var e NoteEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		// Comments on issues, commits and snippets are not handled.
+		if e.MergeRequest == nil {
+			return events, nil
+		}
+		org, repo := e.Project.OrgRepo()
+		mr, err := p.gl.GetMergeRequest(org, repo, e.MergeRequest.IID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get merge request %s!%d: %w", e.Project.PathWithNamespace, e.MergeRequest.IID, err)
+		}
+		if ic, ok := IssueCommentEvent(e, *mr, eventGUID); ok {
+			events.IssueComments = append(events.IssueComments, ic)
+		}
+	case PushHook:
+		var e PushEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		events.Pushes = append(events.Pushes, GitHubPushEvent(e, eventGUID))
+	}
+	return events, nil
+}
+
+func responseHTTPError(w http.ResponseWriter, statusCode int, response string) {
+	logrus.WithFields(logrus.Fields{
+		"response":    response,
+		"status-code": statusCode,
+	}).Debug(response)
+	http.Error(w, response, statusCode)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	testcases := []struct {
+		name   string
+		method string
+		header map[string]string
+		code   int
+	}{
+		{
+			name:   "valid",
+			method: http.MethodPost,
+			header: map[string]string{"X-Gitlab-Event": NoteHook, "X-Gitlab-Token": "secret", "content-type": "application/json"},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "get",
+			method: http.MethodGet,
+			header: map[string]string{"X-Gitlab-Event": NoteHook, "X-Gitlab-Token": "secret", "content-type": "application/json"},
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "no event",
+			method: http.MethodPost,
+			header: map[string]string{"X-Gitlab-Token": "secret", "content-type": "application/json"},
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "no token",
+			method: http.MethodPost,
+			header: map[string]string{"X-Gitlab-Event": NoteHook, "content-type": "application/json"},
+			code:   http.StatusForbidden,
+		},
+		{
+			name:   "wrong token",
+			method: http.MethodPost,
+			header: map[string]string{"X-Gitlab-Event": NoteHook, "X-Gitlab-Token": "guess", "content-type": "application/json"},
+			code:   http.StatusForbidden,
+		},
+		{
+			name:   "form content type",
+			method: http.MethodPost,
+			header: map[string]string{"X-Gitlab-Event": NoteHook, "X-Gitlab-Token": "secret", "content-type": "application/x-www-form-urlencoded"},
+			code:   http.StatusBadRequest,
+		},
+	}
+	p := NewProvider(nil, func() []byte { return []byte("secret") })
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/hook/gitlab", strings.NewReader("{}"))
+			for k, v := range tc.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			eventType, _, payload, ok, code := p.ValidateWebhook(w, r)
+			if code != tc.code || w.Code != tc.code {
+				t.Fatalf("expected status code %d, got %d and %d", tc.code, code, w.Code)
+			}
+			if ok != (tc.code == http.StatusOK) {
+				t.Fatalf("expected valid to be %t, got %t", tc.code == http.StatusOK, ok)
+			}
+			if ok && (eventType != NoteHook || string(payload) != "{}") {
+				t.Errorf("unexpected event type %q or payload %q", eventType, payload)
+			}
+		})
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	p := NewProvider(&fakeClient{mr: MergeRequest{IID: 3, State: MergeRequestStateOpened}}, nil)
+	project := `"project": {"id": 1, "name": "repo", "path_with_namespace": "group/repo"}`
+	testcases := []struct {
+		name          string
+		eventType     string
+		payload       string
+		pullRequests  int
+		reviews       int
+		issueComments int
+		pushes        int
+	}{
+		{
+			name:         "opened merge request",
+			eventType:    MergeRequestHook,
+			payload:      `{` + project + `, "object_attributes": {"iid": 3, "action": "open"}}`,
+			pullRequests: 1,
+		},
+		{
+			name:      "approved merge request",
+			eventType: MergeRequestHook,
+			payload:   `{` + project + `, "object_attributes": {"iid": 3, "action": "approved"}}`,
+			reviews:   1,
+		},
+		{
+			name:          "comment on a merge request",
+			eventType:     NoteHook,
+			payload:       `{` + project + `, "object_attributes": {"id": 100, "note": "/lgtm", "noteable_type": "MergeRequest"}, "merge_request": {"iid": 3}}`,
+			issueComments: 1,
+		},
+		{
+			name:      "comment on an issue",
+			eventType: NoteHook,
+			payload:   `{` + project + `, "object_attributes": {"id": 100, "note": "/lgtm", "noteable_type": "Issue"}}`,
+		},
+		{
+			name:      "push",
+			eventType: PushHook,
+			payload:   `{` + project + `, "ref": "refs/heads/main", "before": "a", "after": "b"}`,
+			pushes:    1,
+		},
+		{
+			name:      "unhandled event",
+			eventType: "Pipeline Hook",
+			payload:   `{}`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := p.ParseWebhook(tc.eventType, "guid", []byte(tc.payload))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events.PullRequests) != tc.pullRequests || len(events.Reviews) != tc.reviews || len(events.IssueComments) != tc.issueComments || len(events.Pushes) != tc.pushes {
+				t.Errorf("expected %d pull request, %d review, %d comment and %d push events, got %+v", tc.pullRequests, tc.reviews, tc.issueComments, tc.pushes, events)
+			}
+		})
+	}
+}
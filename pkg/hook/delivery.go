@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/hook/eventqueue"
+)
+
+// delivery tracks the handlers of an event, so that the event can be
+// acknowledged or dead-lettered once all of them are done.
+type delivery struct {
+	s     *Server
+	event eventqueue.Event
+	// only restricts the handlers to the plugins that failed a previous
+	// attempt, it is nil if all plugins handle the event.
+	only sets.Set[string]
+
+	wg     sync.WaitGroup
+	lock   sync.Mutex
+	failed sets.Set[string]
+	// all is set if the event failed as a whole, e.g. because it could not
+	// be parsed, so that a replay dispatches it to the same plugins again.
+	all  bool
+	errs []string
+}
+
+func (s *Server) newDelivery(e eventqueue.Event) *delivery {
+	d := &delivery{s: s, event: e, failed: sets.New[string]()}
+	if len(e.FailedPlugins) > 0 {
+		d.only = sets.New(e.FailedPlugins...)
+	}
+	return d
+}
+
+// add tracks a handler, both for the delivery and the graceful shutdown of
+// the server.
+func (d *delivery) add() {
+	d.s.wg.Add(1)
+	d.wg.Add(1)
+}
+
+func (d *delivery) done() {
+	d.wg.Done()
+	d.s.wg.Done()
+}
+
+// wants returns whether the plugin should handle the event.
+func (d *delivery) wants(plugin string) bool {
+	return d.only == nil || d.only.Has(plugin)
+}
+
+// fail records that the plugin failed to handle the event. An empty plugin
+// means that the event failed as a whole.
+func (d *delivery) fail(plugin string, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if plugin == "" {
+		d.all = true
+		d.errs = append(d.errs, err.Error())
+		return
+	}
+	d.failed.Insert(plugin)
+	d.errs = append(d.errs, fmt.Sprintf("%s: %v", plugin, err))
+}
+
+// finish acknowledges the event once all handlers are done, or moves it to
+// the dead letters if any of them failed.
+func (d *delivery) finish() {
+	if d.s.EventQueue == nil {
+		return
+	}
+	d.s.wg.Add(1)
+	go func() {
+		defer d.s.wg.Done()
+		d.wg.Wait()
+		l := logrus.WithField(github.EventGUID, d.event.ID)
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		if len(d.errs) == 0 {
+			if err := d.s.EventQueue.Ack(d.event.ID); err != nil {
+				l.WithError(err).Error("Failed to acknowledge event.")
+			}
+			return
+		}
+		e := d.event
+		e.Attempts++
+		e.Errors = d.errs
+		sort.Strings(e.Errors)
+		if !d.all {
+			e.FailedPlugins = sets.List(d.failed)
+		}
+		if err := d.s.EventQueue.DeadLetter(e); err != nil {
+			l.WithError(err).Error("Failed to dead-letter event.")
+			return
+		}
+		l.WithField("failed-plugins", e.FailedPlugins).Warn("Dead-lettered event that plugins failed to handle.")
+	}()
+}
+
+// persist builds the event of a webhook and puts it into the event queue, if
+// one is configured. Events are dispatched even if they cannot be persisted.
+func (s *Server) persist(source, eventType, eventGUID string, payload []byte, h http.Header) eventqueue.Event {
+	e := eventqueue.Event{
+		ID:         eventGUID,
+		Source:     source,
+		Type:       eventType,
+		Header:     h.Clone(),
+		Payload:    payload,
+		ReceivedAt: time.Now(),
+	}
+	if s.EventQueue == nil {
+		return e
+	}
+	if e.ID == "" {
+		e.ID = uuid.NewString()
+	}
+	if err := s.EventQueue.Put(e); err != nil {
+		logrus.WithError(err).WithField(github.EventGUID, e.ID).Error("Failed to persist event.")
+	}
+	return e
+}
+
+// dispatchEvent dispatches a persisted event to the plugins.
+func (s *Server) dispatchEvent(e eventqueue.Event) {
+	d := s.newDelivery(e)
+	var err error
+	switch e.Source {
+	case "":
+		h := e.Header.Clone()
+		if h == nil {
+			h = http.Header{}
+		}
+		err = s.demuxEvent(d, e.Type, e.ID, e.Payload, h)
+	default:
+		if provider, ok := s.ClientAgent.SCMProviders[e.Source]; ok {
+			err = s.demuxSCMEvent(d, provider, e.Type, e.ID, e.Payload)
+		} else {
+			err = fmt.Errorf("received an event from %s, but no client is configured for it", e.Source)
+		}
+	}
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			eventTypeField:   e.Type,
+			github.EventGUID: e.ID,
+		}).Error("Error parsing event.")
+		d.fail("", err)
+	}
+	d.finish()
+}
+
+// ReplayPending dispatches the events that were still pending when hook
+// stopped. It must be called before webhooks are served.
+func (s *Server) ReplayPending() error {
+	if s.EventQueue == nil {
+		return nil
+	}
+	events, err := s.EventQueue.Pending()
+	if err != nil {
+		return fmt.Errorf("failed to list pending events: %w", err)
+	}
+	for _, e := range events {
+		logrus.WithFields(logrus.Fields{
+			eventTypeField:   e.Type,
+			github.EventGUID: e.ID,
+		}).Info("Replaying pending event.")
+		s.dispatchEvent(e)
+	}
+	return nil
+}
+
+// ServeDeadLetters lists the dead letters on GET, replays the dead letter of
+// the `id` query parameter on POST and discards it on DELETE. Requests must
+// be authenticated with the admin token as bearer token.
+func (s *Server) ServeDeadLetters(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	expected := bytes.TrimSpace(s.DeadLetterTokenGenerator())
+	if len(expected) == 0 || subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	if s.EventQueue == nil {
+		http.Error(w, "no event queue is configured", http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		events, err := s.EventQueue.DeadLetters()
+		if err != nil {
+			logrus.WithError(err).Error("Failed to list dead letters.")
+			http.Error(w, "failed to list dead letters", http.StatusInternalServerError)
+			return
+		}
+		// Payloads and headers may be large and contain secrets.
+		for i := range events {
+			events[i].Header = nil
+			events[i].Payload = nil
+		}
+		if events == nil {
+			events = []eventqueue.Event{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(events); err != nil {
+			logrus.WithError(err).Error("Failed to write dead letters.")
+		}
+		return
+	}
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "only GET, POST and DELETE requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "the id query parameter is required", http.StatusBadRequest)
+		return
+	}
+	l := logrus.WithField(github.EventGUID, id)
+	e, err := s.EventQueue.Take(id)
+	if errors.Is(err, eventqueue.ErrNotFound) {
+		http.Error(w, fmt.Sprintf("dead letter %q not found", id), http.StatusNotFound)
+		return
+	} else if err != nil {
+		l.WithError(err).Error("Failed to take dead letter.")
+		http.Error(w, "failed to take dead letter", http.StatusInternalServerError)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		l.Info("Discarded dead letter.")
+		fmt.Fprintf(w, "Discarded dead letter %s.", id)
+		return
+	}
+
+	// Persist the event as pending again, so that it is not lost if hook
+	// restarts during the replay.
+	if err := s.EventQueue.Put(*e); err != nil {
+		l.WithError(err).Error("Failed to persist replayed dead letter.")
+	}
+	l.WithField("failed-plugins", e.FailedPlugins).Info("Replaying dead letter.")
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.dispatchEvent(*e)
+	}()
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprintf(w, "Replaying dead letter %s.", id)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/hook/eventqueue"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestDeadLetters(t *testing.T) {
+	store, err := eventqueue.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	pa := &plugins.ConfigAgent{}
+	pa.Set(&plugins.Configuration{
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"kubernetes": {
+				{Name: "coffee", Endpoint: "/coffee"},
+				{Name: "water", Endpoint: "/water"},
+			},
+		},
+	})
+
+	var called []string
+	var lock sync.Mutex
+	broken := true
+	client := newTestClient(func(req *http.Request) *http.Response {
+		lock.Lock()
+		defer lock.Unlock()
+		called = append(called, req.URL.String())
+		status := http.StatusOK
+		if req.URL.String() == "/water" && broken {
+			status = http.StatusInternalServerError
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewBufferString(`OK`)),
+			Header:     make(http.Header),
+		}
+	})
+	s := &Server{
+		Metrics:                  githubeventserver.NewMetrics(),
+		Plugins:                  pa,
+		RepoEnabled:              func(org, repo string) bool { return true },
+		EventQueue:               store,
+		DeadLetterTokenGenerator: func() []byte { return []byte("secret\n") },
+		c:                        *client,
+	}
+	calls := func() []string {
+		s.wg.Wait()
+		lock.Lock()
+		defer lock.Unlock()
+		sort.Strings(called)
+		got := called
+		called = nil
+		return got
+	}
+	request := func(method, target, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		s.ServeDeadLetters(w, r)
+		return w
+	}
+	payload := []byte(`{"repository": {"full_name": "kubernetes/test-infra"}}`)
+
+	s.dispatchEvent(s.persist("", "repository", "guid", payload, http.Header{}))
+	if diff := cmp.Diff([]string{"/coffee", "/water"}, calls()); diff != "" {
+		t.Errorf("dispatched plugins differ from expected (-want +got):\n%s", diff)
+	}
+	if pending, err := store.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending events, got %v, %v", pending, err)
+	}
+
+	if w := request(http.MethodGet, "/dead-letters", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a wrong token to be rejected, got %d", w.Code)
+	}
+	w := request(http.MethodGet, "/dead-letters", "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to list dead letters: %d %s", w.Code, w.Body.String())
+	}
+	var deadLetters []eventqueue.Event
+	if err := json.Unmarshal(w.Body.Bytes(), &deadLetters); err != nil {
+		t.Fatalf("failed to unmarshal dead letters: %v", err)
+	}
+	for i := range deadLetters {
+		deadLetters[i].ReceivedAt = time.Time{}
+	}
+	expected := []eventqueue.Event{{
+		ID:            "guid",
+		Type:          "repository",
+		Attempts:      1,
+		FailedPlugins: []string{"water"},
+		Errors:        []string{`water: response has status "" and body "OK"`},
+	}}
+	if diff := cmp.Diff(expected, deadLetters); diff != "" {
+		t.Errorf("dead letters differ from expected (-want +got):\n%s", diff)
+	}
+
+	// Replays only dispatch the event to the plugins that failed.
+	broken = false
+	if w := request(http.MethodPost, "/dead-letters?id=unknown", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("expected replaying an unknown dead letter to fail with 404, got %d", w.Code)
+	}
+	if w := request(http.MethodPost, "/dead-letters?id=guid", "secret"); w.Code != http.StatusAccepted {
+		t.Fatalf("failed to replay dead letter: %d %s", w.Code, w.Body.String())
+	}
+	if diff := cmp.Diff([]string{"/water"}, calls()); diff != "" {
+		t.Errorf("replayed plugins differ from expected (-want +got):\n%s", diff)
+	}
+	if dead, err := store.DeadLetters(); err != nil || len(dead) != 0 {
+		t.Errorf("expected no dead letters, got %v, %v", dead, err)
+	}
+	if pending, err := store.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending events, got %v, %v", pending, err)
+	}
+
+	// Events interrupted by a restart are replayed to all plugins.
+	if err := store.Put(eventqueue.Event{ID: "interrupted", Type: "repository", Payload: payload}); err != nil {
+		t.Fatalf("failed to put event: %v", err)
+	}
+	if err := s.ReplayPending(); err != nil {
+		t.Fatalf("failed to replay pending events: %v", err)
+	}
+	if diff := cmp.Diff([]string{"/coffee", "/water"}, calls()); diff != "" {
+		t.Errorf("replayed plugins differ from expected (-want +got):\n%s", diff)
+	}
+	if pending, err := store.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending events, got %v, %v", pending, err)
+	}
+
+	// Events that cannot be parsed are dead-lettered, and can be discarded.
+	s.dispatchEvent(s.persist("", "repository", "invalid", []byte("{"), nil))
+	calls()
+	if dead, err := store.DeadLetters(); err != nil || len(dead) != 1 || len(dead[0].FailedPlugins) != 0 {
+		t.Errorf("expected the invalid event to be dead-lettered for all plugins, got %v, %v", dead, err)
+	}
+	if w := request(http.MethodDelete, "/dead-letters?id=invalid", "secret"); w.Code != http.StatusOK {
+		t.Errorf("failed to discard dead letter: %d %s", w.Code, w.Body.String())
+	}
+	if dead, err := store.DeadLetters(); err != nil || len(dead) != 0 {
+		t.Errorf("expected no dead letters, got %v, %v", dead, err)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"sync"
+	"time"
+)
+
+// pluginLimiter bounds the number of concurrent handlers of each plugin by
+// its max_concurrency.
+type pluginLimiter struct {
+	lock  sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// slotsFor returns the slots of the plugin, replacing them if the limit
+// changed. Handlers that hold slots of a previous limit release them there.
+func (pl *pluginLimiter) slotsFor(plugin string, limit int) chan struct{} {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	if pl.slots == nil {
+		pl.slots = map[string]chan struct{}{}
+	}
+	slots, ok := pl.slots[plugin]
+	if !ok || cap(slots) != limit {
+		slots = make(chan struct{}, limit)
+		pl.slots[plugin] = slots
+	}
+	return slots
+}
+
+// dispatches returns whether hook dispatches events of the type to the
+// plugin.
+func (s *Server) dispatches(plugin, eventType string) bool {
+	return s.Plugins.Config().PluginDispatch[plugin].Dispatches(eventType)
+}
+
+// acquire blocks until the plugin may run another handler and returns the
+// function that releases the handler once it finished.
+func (s *Server) acquire(plugin string) func() {
+	running := s.Metrics.PluginRunningHandlers.WithLabelValues(plugin)
+	limit := s.Plugins.Config().PluginDispatch[plugin].MaxConcurrency
+	if limit <= 0 {
+		running.Inc()
+		return running.Dec
+	}
+	slots := s.limiter.slotsFor(plugin, limit)
+	queued := s.Metrics.PluginQueueDepth.WithLabelValues(plugin)
+	queued.Inc()
+	start := time.Now()
+	slots <- struct{}{}
+	queued.Dec()
+	s.Metrics.PluginQueueDuration.WithLabelValues(plugin).Observe(time.Since(start).Seconds())
+	running.Inc()
+	return func() {
+		running.Dec()
+		<-slots
+	}
+}
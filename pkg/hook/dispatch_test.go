@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestAcquire(t *testing.T) {
+	pa := &plugins.ConfigAgent{}
+	pa.Set(&plugins.Configuration{
+		PluginDispatch: map[string]plugins.PluginDispatch{
+			"bugzilla": {MaxConcurrency: 2},
+		},
+	})
+	s := &Server{Plugins: pa, Metrics: githubeventserver.NewMetrics()}
+
+	testCases := []struct {
+		name     string
+		plugin   string
+		expected int
+	}{
+		{
+			name:     "limited plugin",
+			plugin:   "bugzilla",
+			expected: 2,
+		},
+		{
+			name:     "unlimited plugin",
+			plugin:   "trigger",
+			expected: 5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var running, maxRunning int
+			var wg sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer s.acquire(tc.plugin)()
+					lock.Lock()
+					running++
+					if running > maxRunning {
+						maxRunning = running
+					}
+					lock.Unlock()
+					time.Sleep(100 * time.Millisecond)
+					lock.Lock()
+					running--
+					lock.Unlock()
+				}()
+			}
+			wg.Wait()
+			if maxRunning != tc.expected {
+				t.Errorf("expected at most %d concurrent handlers, got %d", tc.expected, maxRunning)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	pendingDir = "pending"
+	deadDir    = "dead"
+)
+
+// diskStore keeps every event as a JSON file in the pending or dead
+// subdirectory of its directory. Files are replaced atomically, so that a
+// crash never leaves a partially written event behind.
+type diskStore struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// NewDiskStore returns a store that keeps events in dir, which is created if
+// it does not exist.
+func NewDiskStore(dir string) (Store, error) {
+	for _, sub := range []string{pendingDir, deadDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create event queue directory: %w", err)
+		}
+	}
+	return &diskStore{dir: dir}, nil
+}
+
+func (s *diskStore) path(sub, id string) string {
+	return filepath.Join(s.dir, sub, url.PathEscape(id)+".json")
+}
+
+func (s *diskStore) write(sub string, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, sub), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(sub, e.ID))
+}
+
+func (s *diskStore) remove(sub, id string) error {
+	if err := os.Remove(s.path(sub, id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *diskStore) list(sub string) ([]Event, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, sub))
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(s.dir, sub, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var e Event
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", entry.Name(), err)
+		}
+		events = append(events, e)
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+func (s *diskStore) Put(e Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.write(pendingDir, e)
+}
+
+func (s *diskStore) Ack(id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.remove(pendingDir, id)
+}
+
+func (s *diskStore) DeadLetter(e Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.write(deadDir, e); err != nil {
+		return err
+	}
+	return s.remove(pendingDir, e.ID)
+}
+
+func (s *diskStore) Pending() ([]Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.list(pendingDir)
+}
+
+func (s *diskStore) DeadLetters() ([]Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.list(deadDir)
+}
+
+func (s *diskStore) Take(id string) (*Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	b, err := os.ReadFile(s.path(deadDir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var e Event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+	if err := s.remove(deadDir, id); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventqueue persists the webhook events received by hook until all
+// plugins handled them, so that events interrupted by a restart can be
+// replayed and events that plugins failed to handle are kept as dead letters
+// for an admin to replay once the cause is fixed.
+package eventqueue
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ErrNotFound is returned by Take for unknown dead letters.
+var ErrNotFound = errors.New("event not found")
+
+// Event is a webhook event as received by hook.
+type Event struct {
+	// ID is the GUID of the delivery.
+	ID     string      `json:"id"`
+	Source string      `json:"source,omitempty"`
+	Type   string      `json:"type"`
+	Header http.Header `json:"header,omitempty"`
+	// Payload is the raw body of the webhook.
+	Payload    []byte    `json:"payload,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+	// Attempts counts the failed dispatches of the event.
+	Attempts int `json:"attempts,omitempty"`
+	// FailedPlugins are the plugins that failed to handle the event. Replays
+	// of dead letters only dispatch the event to them.
+	FailedPlugins []string `json:"failed_plugins,omitempty"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+// Store persists events. Events are put before they are dispatched to
+// plugins and are either acknowledged or moved to the dead letters once all
+// plugins are done. Events that are still pending when hook starts were
+// interrupted and are replayed.
+type Store interface {
+	// Put persists a pending event, replacing any event with the same ID.
+	Put(e Event) error
+	// Ack removes a pending event.
+	Ack(id string) error
+	// DeadLetter replaces a pending event with a dead letter.
+	DeadLetter(e Event) error
+	// Pending lists the pending events, oldest first.
+	Pending() ([]Event, error)
+	// DeadLetters lists the dead letters, oldest first.
+	DeadLetters() ([]Event, error)
+	// Take removes and returns a dead letter.
+	Take(id string) (*Event, error)
+}
+
+func sortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].ReceivedAt.Equal(events[j].ReceivedAt) {
+			return events[i].ReceivedAt.Before(events[j].ReceivedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+}
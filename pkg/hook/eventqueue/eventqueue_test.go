@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func testEvent(id string, minute int) Event {
+	return Event{
+		ID:         id,
+		Type:       "issue_comment",
+		Header:     http.Header{"X-Github-Event": []string{"issue_comment"}},
+		Payload:    []byte(`{"action":"created"}`),
+		ReceivedAt: time.Date(2026, time.January, 1, 0, minute, 0, 0, time.UTC),
+	}
+}
+
+// testStore exercises the contract of Store. Stores must be empty.
+func testStore(t *testing.T, s Store) {
+	t.Helper()
+	ids := func(events []Event, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("failed to list events: %v", err)
+		}
+		var ids []string
+		for _, e := range events {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+
+	for _, e := range []Event{testEvent("b", 2), testEvent("a", 1), testEvent("c", 3)} {
+		if err := s.Put(e); err != nil {
+			t.Fatalf("failed to put event: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c"}, ids(s.Pending())); diff != "" {
+		t.Errorf("pending events differ from expected (-want +got):\n%s", diff)
+	}
+
+	if err := s.Ack("a"); err != nil {
+		t.Fatalf("failed to ack event: %v", err)
+	}
+	failed := testEvent("b", 2)
+	failed.Attempts = 1
+	failed.FailedPlugins = []string{"trigger"}
+	failed.Errors = []string{"trigger: boom"}
+	if err := s.DeadLetter(failed); err != nil {
+		t.Fatalf("failed to dead-letter event: %v", err)
+	}
+	if diff := cmp.Diff([]string{"c"}, ids(s.Pending())); diff != "" {
+		t.Errorf("pending events differ from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"b"}, ids(s.DeadLetters())); diff != "" {
+		t.Errorf("dead letters differ from expected (-want +got):\n%s", diff)
+	}
+
+	taken, err := s.Take("b")
+	if err != nil {
+		t.Fatalf("failed to take dead letter: %v", err)
+	}
+	if diff := cmp.Diff(&failed, taken); diff != "" {
+		t.Errorf("taken event differs from expected (-want +got):\n%s", diff)
+	}
+	if _, err := s.Take("b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected taking a dead letter twice to fail with ErrNotFound, got %v", err)
+	}
+	if got := ids(s.DeadLetters()); len(got) != 0 {
+		t.Errorf("expected no dead letters, got %v", got)
+	}
+}
+
+func TestDiskStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewDiskStore(dir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	testStore(t, s)
+
+	// Events survive restarts.
+	restarted, err := NewDiskStore(dir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	if pending, err := restarted.Pending(); err != nil || len(pending) != 1 || pending[0].ID != "c" {
+		t.Errorf("expected event c to be pending after restart, got %v, %v", pending, err)
+	}
+}
+
+// fakeRedisConn implements the commands used by the Redis store. Leases
+// don't expire by themselves, tests delete them instead.
+type fakeRedisConn struct {
+	redis.Conn
+	lock    *sync.Mutex
+	hashes  map[string]map[string][]byte
+	strings map[string]string
+	sets    map[string]map[string]bool
+}
+
+func newFakeRedisConn() *fakeRedisConn {
+	return &fakeRedisConn{
+		lock:    &sync.Mutex{},
+		hashes:  map[string]map[string][]byte{},
+		strings: map[string]string{},
+		sets:    map[string]map[string]bool{},
+	}
+}
+
+func (c *fakeRedisConn) Close() error { return nil }
+
+func (c *fakeRedisConn) Err() error { return nil }
+
+func (c *fakeRedisConn) Do(command string, args ...interface{}) (interface{}, error) {
+	if command == "" {
+		// The pool flushes connections with an empty command on release.
+		return nil, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := args[0].(string)
+	if c.hashes[key] == nil {
+		c.hashes[key] = map[string][]byte{}
+	}
+	if c.sets[key] == nil {
+		c.sets[key] = map[string]bool{}
+	}
+	hash, set := c.hashes[key], c.sets[key]
+	switch command {
+	case "HSET":
+		hash[args[1].(string)] = args[2].([]byte)
+		return int64(1), nil
+	case "HGET":
+		v, ok := hash[args[1].(string)]
+		if !ok {
+			return nil, nil
+		}
+		return v, nil
+	case "HDEL":
+		_, ok := hash[args[1].(string)]
+		delete(hash, args[1].(string))
+		if ok {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case "HVALS":
+		var values []interface{}
+		for _, v := range hash {
+			values = append(values, v)
+		}
+		return values, nil
+	case "HGETALL":
+		var values []interface{}
+		for k, v := range hash {
+			values = append(values, []byte(k), v)
+		}
+		return values, nil
+	case "RENAME":
+		if len(hash) == 0 {
+			return nil, redis.Error("ERR no such key")
+		}
+		c.hashes[args[1].(string)] = hash
+		delete(c.hashes, key)
+		return "OK", nil
+	case "DEL":
+		delete(c.hashes, key)
+		return int64(1), nil
+	case "SET":
+		c.strings[key] = args[1].(string)
+		return "OK", nil
+	case "EXISTS":
+		if _, ok := c.strings[key]; ok {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case "SADD":
+		set[args[1].(string)] = true
+		return int64(1), nil
+	case "SREM":
+		delete(set, args[1].(string))
+		return int64(1), nil
+	case "SMEMBERS":
+		var members []interface{}
+		for member := range set {
+			members = append(members, []byte(member))
+		}
+		return members, nil
+	}
+	return nil, fmt.Errorf("unsupported command %s", command)
+}
+
+func TestRedisStore(t *testing.T) {
+	conn := newFakeRedisConn()
+	dial := func() (redis.Conn, error) { return conn, nil }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := NewRedisStore(ctx, dial, "prefix", "hook-a")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	testStore(t, s)
+	if _, ok := conn.hashes["prefix:hook-a:pending"]["c"]; !ok {
+		t.Errorf("expected event c under the key of the replica, got %v", conn.hashes)
+	}
+
+	// Replicas only list their own pending events while the others run.
+	other, err := NewRedisStore(ctx, dial, "prefix", "hook-b")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	if pending, err := other.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending events of another replica, got %v, %v", pending, err)
+	}
+
+	// The pending events of a replica whose lease expired are adopted once.
+	delete(conn.strings, "prefix:hook-a:lease")
+	if pending, err := other.Pending(); err != nil || len(pending) != 1 || pending[0].ID != "c" {
+		t.Errorf("expected event c to be adopted, got %v, %v", pending, err)
+	}
+	if err := other.Ack("c"); err != nil {
+		t.Fatalf("failed to ack event: %v", err)
+	}
+	if pending, err := other.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending events after the ack, got %v, %v", pending, err)
+	}
+}
+
+func TestPubSubStore(t *testing.T) {
+	ctx := context.Background()
+	server := pstest.NewServer()
+	defer server.Close()
+	conn, err := grpc.NewClient(server.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect to fake Pub/Sub: %v", err)
+	}
+	defer conn.Close()
+	client, err := pubsub.NewClient(ctx, "project", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("failed to create Pub/Sub client: %v", err)
+	}
+	defer client.Close()
+	topic, err := client.CreateTopic(ctx, "events")
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	sub, err := client.CreateSubscription(ctx, "hook", pubsub.SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	s, err := newPubSubStore(ctx, topic, sub, "hook-a", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	testStore(t, s)
+	if err := s.DeadLetter(testEvent("d", 4)); err != nil {
+		t.Fatalf("failed to dead-letter event: %v", err)
+	}
+
+	// Other replicas don't restore the events.
+	other, err := newPubSubStore(ctx, topic, client.Subscription("hook"), "hook-b", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to restore store: %v", err)
+	}
+	if pending, err := other.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending events of another replica, got %v, %v", pending, err)
+	}
+
+	// Events are restored from the log, which is compacted.
+	restarted, err := newPubSubStore(ctx, topic, client.Subscription("hook"), "hook-a", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to restore store: %v", err)
+	}
+	if pending, err := restarted.Pending(); err != nil || len(pending) != 1 || pending[0].ID != "c" {
+		t.Errorf("expected event c to be pending after restart, got %v, %v", pending, err)
+	}
+	if dead, err := restarted.DeadLetters(); err != nil || len(dead) != 1 || dead[0].ID != "d" {
+		t.Errorf("expected event d to be a dead letter after restart, got %v, %v", dead, err)
+	}
+	var published int
+	for _, msg := range server.Messages() {
+		if msg.Acks == 0 {
+			published++
+		}
+	}
+	if published != 2 {
+		t.Errorf("expected the log to be compacted to 2 messages, got %d", published)
+	}
+}
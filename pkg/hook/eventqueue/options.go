@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventqueue
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/gomodule/redigo/redis"
+)
+
+// Backends of the event queue.
+const (
+	BackendDisk   = "disk"
+	BackendRedis  = "redis"
+	BackendPubSub = "pubsub"
+)
+
+// Options configure the event queue of hook.
+type Options struct {
+	Backend string
+	Replica string
+
+	Dir string
+
+	RedisAddress   string
+	RedisKeyPrefix string
+
+	PubSubProject      string
+	PubSubTopic        string
+	PubSubSubscription string
+}
+
+// AddFlags injects the event queue options into the given FlagSet.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Backend, "event-queue", "", "Backend of the queue that webhook events are persisted to until plugins handled them: disk, redis or pubsub. Events are not persisted if unset.")
+	fs.StringVar(&o.Replica, "event-queue-replica", "", "Name of the hook replica that owns the events it persists to redis or pubsub. Defaults to the hostname, i.e. the name of the pod.")
+	fs.StringVar(&o.Dir, "event-queue-dir", "", "Directory that events are persisted to, used with --event-queue=disk.")
+	fs.StringVar(&o.RedisAddress, "event-queue-redis-address", "", "Address of the Redis server that events are persisted to, used with --event-queue=redis.")
+	fs.StringVar(&o.RedisKeyPrefix, "event-queue-redis-key-prefix", "prow-hook-events", "Prefix of the Redis keys of the events, used with --event-queue=redis.")
+	fs.StringVar(&o.PubSubProject, "event-queue-pubsub-project", "", "GCP project of the Pub/Sub topic and subscription, used with --event-queue=pubsub.")
+	fs.StringVar(&o.PubSubTopic, "event-queue-pubsub-topic", "", "Pub/Sub topic that events are persisted to, used with --event-queue=pubsub.")
+	fs.StringVar(&o.PubSubSubscription, "event-queue-pubsub-subscription", "", "Pub/Sub subscription of the topic that events are restored from, used with --event-queue=pubsub.")
+}
+
+// Validate validates the event queue options.
+func (o *Options) Validate(_ bool) error {
+	switch o.Backend {
+	case "":
+	case BackendDisk:
+		if o.Dir == "" {
+			return errors.New("--event-queue-dir is required with --event-queue=disk")
+		}
+	case BackendRedis:
+		if o.RedisAddress == "" {
+			return errors.New("--event-queue-redis-address is required with --event-queue=redis")
+		}
+	case BackendPubSub:
+		if o.PubSubProject == "" || o.PubSubTopic == "" || o.PubSubSubscription == "" {
+			return errors.New("--event-queue-pubsub-project, --event-queue-pubsub-topic and --event-queue-pubsub-subscription are required with --event-queue=pubsub")
+		}
+	default:
+		return fmt.Errorf("invalid --event-queue %q, must be one of %s, %s or %s", o.Backend, BackendDisk, BackendRedis, BackendPubSub)
+	}
+	return nil
+}
+
+// Enabled returns whether an event queue is configured.
+func (o *Options) Enabled() bool {
+	return o.Backend != ""
+}
+
+// Store returns the store of the configured backend.
+func (o *Options) Store(ctx context.Context) (Store, error) {
+	replica := o.Replica
+	if replica == "" && o.Backend != BackendDisk {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the hostname to name the replica: %w", err)
+		}
+		replica = hostname
+	}
+	switch o.Backend {
+	case BackendDisk:
+		return NewDiskStore(o.Dir)
+	case BackendRedis:
+		return NewRedisStore(ctx, func() (redis.Conn, error) {
+			return redis.Dial("tcp", o.RedisAddress)
+		}, o.RedisKeyPrefix, replica)
+	case BackendPubSub:
+		client, err := pubsub.NewClient(ctx, o.PubSubProject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Pub/Sub client: %w", err)
+		}
+		return NewPubSubStore(ctx, client, o.PubSubTopic, o.PubSubSubscription, replica)
+	}
+	return nil, errors.New("no event queue is configured")
+}
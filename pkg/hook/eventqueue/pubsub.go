@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/sirupsen/logrus"
+)
+
+// Operations of the Pub/Sub log, sent in the op attribute of messages.
+const (
+	opPut        = "put"
+	opAck        = "ack"
+	opDeadLetter = "dead"
+	opTake       = "take"
+)
+
+// defaultDrainIdle is how long the log is read without receiving any
+// message before it is considered fully read.
+const defaultDrainIdle = 5 * time.Second
+
+// pubSubStore keeps the events in memory and appends every change to a
+// Pub/Sub topic. When hook starts, the store reads the log from the
+// subscription to restore the events, republishes the events that remain
+// and acknowledges the log, so that the log only grows while hook runs.
+// Messages that exceed the retention of the subscription are lost. Messages
+// carry the replica that published them, replicas only restore their own.
+type pubSubStore struct {
+	topic   *pubsub.Topic
+	replica string
+
+	lock    sync.Mutex
+	pending map[string]Event
+	dead    map[string]Event
+	seq     int
+}
+
+// NewPubSubStore returns a store that logs events to the topic and restores
+// them from the subscription of the topic. Replicas may share the
+// subscription, but the events of a replica are only restored by a replica
+// with the same name, so the names must be stable across restarts.
+func NewPubSubStore(ctx context.Context, client *pubsub.Client, topic, subscription, replica string) (Store, error) {
+	return newPubSubStore(ctx, client.Topic(topic), client.Subscription(subscription), replica, defaultDrainIdle)
+}
+
+func newPubSubStore(ctx context.Context, topic *pubsub.Topic, sub *pubsub.Subscription, replica string, drainIdle time.Duration) (*pubSubStore, error) {
+	s := &pubSubStore{topic: topic, replica: replica, pending: map[string]Event{}, dead: map[string]Event{}}
+	if err := s.restore(ctx, sub, drainIdle); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// restore reads the whole log from the subscription. The messages are only
+// acknowledged once the restored events have been republished, so that a
+// crash during the restore does not lose any event.
+func (s *pubSubStore) restore(ctx context.Context, sub *pubsub.Subscription, drainIdle time.Duration) error {
+	sub.ReceiveSettings.MaxOutstandingMessages = -1
+	sub.ReceiveSettings.MaxOutstandingBytes = -1
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var lock sync.Mutex
+	var messages []*pubsub.Message
+	// restored holds the IDs of the messages to acknowledge once released.
+	restored := map[string]bool{}
+	received := make(chan struct{}, 1)
+	released := make(chan struct{})
+	receiveErr := make(chan error, 1)
+	go func() {
+		receiveErr <- sub.Receive(ctx, func(_ context.Context, msg *pubsub.Message) {
+			// The log of other replicas is left to them. Messages without
+			// a replica were published before replicas were told apart.
+			if replica := msg.Attributes["replica"]; replica != "" && replica != s.replica {
+				msg.Nack()
+				return
+			}
+			lock.Lock()
+			messages = append(messages, msg)
+			lock.Unlock()
+			select {
+			case received <- struct{}{}:
+			default:
+			}
+			<-released
+			lock.Lock()
+			ack := restored[msg.ID]
+			lock.Unlock()
+			if ack {
+				msg.Ack()
+			} else {
+				msg.Nack()
+			}
+		})
+	}()
+
+	timer := time.NewTimer(drainIdle)
+	defer timer.Stop()
+	for drained := false; !drained; {
+		select {
+		case <-received:
+			timer.Reset(drainIdle)
+		case <-timer.C:
+			drained = true
+		case err := <-receiveErr:
+			close(released)
+			return fmt.Errorf("failed to read the event queue log: %w", err)
+		}
+	}
+
+	lock.Lock()
+	log := append([]*pubsub.Message(nil), messages...)
+	lock.Unlock()
+	s.replay(log)
+	err := s.republish(ctx)
+	if err == nil {
+		// Messages that arrived after the log was read stay in the log for
+		// the next restore.
+		lock.Lock()
+		for _, msg := range log {
+			restored[msg.ID] = true
+		}
+		lock.Unlock()
+	}
+	close(released)
+	cancel()
+	if receiveErr := <-receiveErr; err == nil && receiveErr != nil {
+		return fmt.Errorf("failed to read the event queue log: %w", receiveErr)
+	}
+	return err
+}
+
+// replay applies the operations of the log in the order they were published.
+func (s *pubSubStore) replay(log []*pubsub.Message) {
+	seq := func(msg *pubsub.Message) int {
+		n, _ := strconv.Atoi(msg.Attributes["seq"])
+		return n
+	}
+	sort.SliceStable(log, func(i, j int) bool {
+		if !log[i].PublishTime.Equal(log[j].PublishTime) {
+			return log[i].PublishTime.Before(log[j].PublishTime)
+		}
+		return seq(log[i]) < seq(log[j])
+	})
+	for _, msg := range log {
+		id := msg.Attributes["id"]
+		var e Event
+		if op := msg.Attributes["op"]; op == opPut || op == opDeadLetter {
+			if err := json.Unmarshal(msg.Data, &e); err != nil {
+				logrus.WithError(err).WithField("id", id).Warn("Dropping event with invalid payload from the event queue log.")
+				continue
+			}
+		}
+		switch msg.Attributes["op"] {
+		case opPut:
+			s.pending[id] = e
+		case opAck:
+			delete(s.pending, id)
+		case opDeadLetter:
+			delete(s.pending, id)
+			s.dead[id] = e
+		case opTake:
+			delete(s.dead, id)
+		}
+	}
+}
+
+func (s *pubSubStore) republish(ctx context.Context) error {
+	for _, e := range values(s.pending) {
+		if err := s.publish(ctx, opPut, e.ID, &e); err != nil {
+			return err
+		}
+	}
+	for _, e := range values(s.dead) {
+		if err := s.publish(ctx, opDeadLetter, e.ID, &e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publish appends an operation to the log and waits until it was stored.
+func (s *pubSubStore) publish(ctx context.Context, op, id string, e *Event) error {
+	var data []byte
+	if e != nil {
+		var err error
+		if data, err = json.Marshal(e); err != nil {
+			return err
+		}
+	}
+	s.seq++
+	msg := &pubsub.Message{
+		Data:       data,
+		Attributes: map[string]string{"op": op, "id": id, "seq": strconv.Itoa(s.seq), "replica": s.replica},
+	}
+	if _, err := s.topic.Publish(ctx, msg).Get(ctx); err != nil {
+		return fmt.Errorf("failed to publish %s of event %s: %w", op, id, err)
+	}
+	return nil
+}
+
+func values(events map[string]Event) []Event {
+	var list []Event
+	for _, e := range events {
+		list = append(list, e)
+	}
+	sortEvents(list)
+	return list
+}
+
+func (s *pubSubStore) Put(e Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.publish(context.Background(), opPut, e.ID, &e); err != nil {
+		return err
+	}
+	s.pending[e.ID] = e
+	return nil
+}
+
+func (s *pubSubStore) Ack(id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.publish(context.Background(), opAck, id, nil); err != nil {
+		return err
+	}
+	delete(s.pending, id)
+	return nil
+}
+
+func (s *pubSubStore) DeadLetter(e Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.publish(context.Background(), opDeadLetter, e.ID, &e); err != nil {
+		return err
+	}
+	delete(s.pending, e.ID)
+	s.dead[e.ID] = e
+	return nil
+}
+
+func (s *pubSubStore) Pending() ([]Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return values(s.pending), nil
+}
+
+func (s *pubSubStore) DeadLetters() ([]Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return values(s.dead), nil
+}
+
+func (s *pubSubStore) Take(id string) (*Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e, ok := s.dead[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := s.publish(context.Background(), opTake, id, nil); err != nil {
+		return nil, err
+	}
+	delete(s.dead, id)
+	return &e, nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultLeaseTTL is how long the pending events of a replica are left to it
+// after it last renewed its lease.
+const defaultLeaseTTL = time.Minute
+
+// redisStore keeps the pending events of every replica and the dead letters
+// of all replicas in Redis hashes that map event IDs to the JSON of the event.
+// Replicas hold a lease on their pending events while they run. A replica
+// that starts adopts the pending events of the replicas whose lease expired,
+// so that the events of replicas that were replaced are replayed once.
+type redisStore struct {
+	pool          *redis.Pool
+	prefix        string
+	replica       string
+	pendingKey    string
+	deadLetterKey string
+	leaseTTL      time.Duration
+}
+
+// NewRedisStore returns a store that keeps events in Redis under keys with
+// the prefix. The pending events are kept per replica, the lease of the
+// replica is renewed until the context is done.
+func NewRedisStore(ctx context.Context, dial func() (redis.Conn, error), prefix, replica string) (Store, error) {
+	return newRedisStore(ctx, dial, prefix, replica, defaultLeaseTTL)
+}
+
+func newRedisStore(ctx context.Context, dial func() (redis.Conn, error), prefix, replica string, leaseTTL time.Duration) (*redisStore, error) {
+	s := &redisStore{
+		pool:          &redis.Pool{Dial: dial, MaxIdle: 3},
+		prefix:        prefix,
+		replica:       replica,
+		pendingKey:    pendingKey(prefix, replica),
+		deadLetterKey: prefix + ":dead",
+		leaseTTL:      leaseTTL,
+	}
+	if err := s.renewLease(); err != nil {
+		return nil, fmt.Errorf("failed to acquire the lease of replica %s: %w", replica, err)
+	}
+	if _, err := s.do("SADD", s.replicasKey(), replica); err != nil {
+		return nil, fmt.Errorf("failed to register replica %s: %w", replica, err)
+	}
+	go func() {
+		ticker := time.NewTicker(leaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.renewLease(); err != nil {
+					logrus.WithError(err).WithField("replica", replica).Warn("Failed to renew the lease of the event queue.")
+				}
+			}
+		}
+	}()
+	return s, nil
+}
+
+func pendingKey(prefix, replica string) string {
+	return prefix + ":" + replica + ":pending"
+}
+
+func leaseKey(prefix, replica string) string {
+	return prefix + ":" + replica + ":lease"
+}
+
+func (s *redisStore) replicasKey() string {
+	return s.prefix + ":replicas"
+}
+
+func (s *redisStore) renewLease() error {
+	_, err := s.do("SET", leaseKey(s.prefix, s.replica), "1", "EX", int(s.leaseTTL.Seconds()))
+	return err
+}
+
+// adopt moves the pending events of the replicas whose lease expired to the
+// pending events of this replica. Renaming the pending events of a replica
+// claims them, so that only one replica adopts them.
+func (s *redisStore) adopt() error {
+	replicas, err := redis.Strings(s.do("SMEMBERS", s.replicasKey()))
+	if err != nil {
+		return err
+	}
+	for _, replica := range replicas {
+		if replica == s.replica {
+			continue
+		}
+		if alive, err := redis.Bool(s.do("EXISTS", leaseKey(s.prefix, replica))); err != nil {
+			return err
+		} else if alive {
+			continue
+		}
+		claimed := s.pendingKey + ":adopted:" + replica
+		if _, err := s.do("RENAME", pendingKey(s.prefix, replica), claimed); err != nil && !strings.Contains(err.Error(), "no such key") {
+			return err
+		}
+		values, err := redis.StringMap(s.do("HGETALL", claimed))
+		if err != nil {
+			return err
+		}
+		for id, value := range values {
+			if _, err := s.do("HSET", s.pendingKey, id, []byte(value)); err != nil {
+				return err
+			}
+		}
+		if _, err := s.do("DEL", claimed); err != nil {
+			return err
+		}
+		if _, err := s.do("SREM", s.replicasKey(), replica); err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			logrus.WithField("replica", replica).Infof("Adopted %d pending events of a replica that is gone.", len(values))
+		}
+	}
+	return nil
+}
+
+func (s *redisStore) do(command string, args ...interface{}) (interface{}, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+	return conn.Do(command, args...)
+}
+
+func (s *redisStore) set(key string, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.do("HSET", key, e.ID, b)
+	return err
+}
+
+func (s *redisStore) list(key string) ([]Event, error) {
+	values, err := redis.ByteSlices(s.do("HVALS", key))
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, b := range values {
+		var e Event
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		events = append(events, e)
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+func (s *redisStore) Put(e Event) error {
+	return s.set(s.pendingKey, e)
+}
+
+func (s *redisStore) Ack(id string) error {
+	_, err := s.do("HDEL", s.pendingKey, id)
+	return err
+}
+
+func (s *redisStore) DeadLetter(e Event) error {
+	if err := s.set(s.deadLetterKey, e); err != nil {
+		return err
+	}
+	return s.Ack(e.ID)
+}
+
+// Pending lists the pending events of this replica, including the ones it
+// adopted from replicas that are gone.
+func (s *redisStore) Pending() ([]Event, error) {
+	if err := s.adopt(); err != nil {
+		return nil, fmt.Errorf("failed to adopt the pending events of other replicas: %w", err)
+	}
+	return s.list(s.pendingKey)
+}
+
+func (s *redisStore) DeadLetters() ([]Event, error) {
+	return s.list(s.deadLetterKey)
+}
+
+func (s *redisStore) Take(id string) (*Event, error) {
+	b, err := redis.Bytes(s.do("HGET", s.deadLetterKey, id))
+	if err == redis.ErrNil {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var e Event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	// Another replay may have taken the event in the meantime.
+	if deleted, err := redis.Int(s.do("HDEL", s.deadLetterKey, id)); err != nil {
+		return nil, err
+	} else if deleted == 0 {
+		return nil, ErrNotFound
+	}
+	return &e, nil
+}
@@ -69,8 +69,8 @@ var (
 	}
 )
 
-func (s *Server) handleReviewEvent(l *logrus.Entry, re github.ReviewEvent) {
-	defer s.wg.Done()
+func (s *Server) handleReviewEvent(l *logrus.Entry, d *delivery, re github.ReviewEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  re.Repo.Owner.Login,
 		github.RepoLogField: re.Repo.Name,
@@ -81,10 +81,14 @@ func (s *Server) handleReviewEvent(l *logrus.Entry, re github.ReviewEvent) {
 	})
 	l.Infof("Review %s.", re.Action)
 	for p, h := range s.Plugins.ReviewEventHandlers(re.PullRequest.Base.Repo.Owner.Login, re.PullRequest.Base.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.ReviewEventHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, re.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, re.Repo.Owner.Login, re.Repo.Name, s.Metrics.Metrics, l, p)
 			agent.InitializeCommentPruner(
 				re.Repo.Owner.Login,
 				re.Repo.Name,
@@ -96,6 +100,7 @@ func (s *Server) handleReviewEvent(l *logrus.Entry, re github.ReviewEvent) {
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling ReviewEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
@@ -112,11 +117,11 @@ func (s *Server) handleReviewEvent(l *logrus.Entry, re github.ReviewEvent) {
 		return
 	}
 
-	s.handleGenericComment(l, gce)
+	s.handleGenericComment(l, d, gce)
 }
 
-func (s *Server) handleReviewCommentEvent(l *logrus.Entry, rce github.ReviewCommentEvent) {
-	defer s.wg.Done()
+func (s *Server) handleReviewCommentEvent(l *logrus.Entry, d *delivery, rce github.ReviewCommentEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  rce.Repo.Owner.Login,
 		github.RepoLogField: rce.Repo.Name,
@@ -127,10 +132,14 @@ func (s *Server) handleReviewCommentEvent(l *logrus.Entry, rce github.ReviewComm
 	})
 	l.Infof("Review comment %s.", rce.Action)
 	for p, h := range s.Plugins.ReviewCommentEventHandlers(rce.PullRequest.Base.Repo.Owner.Login, rce.PullRequest.Base.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.ReviewCommentEventHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, rce.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, rce.Repo.Owner.Login, rce.Repo.Name, s.Metrics.Metrics, l, p)
 			agent.InitializeCommentPruner(
 				rce.Repo.Owner.Login,
 				rce.Repo.Name,
@@ -142,6 +151,7 @@ func (s *Server) handleReviewCommentEvent(l *logrus.Entry, rce github.ReviewComm
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling ReviewCommentEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
@@ -158,11 +168,11 @@ func (s *Server) handleReviewCommentEvent(l *logrus.Entry, rce github.ReviewComm
 		return
 	}
 
-	s.handleGenericComment(l, gce)
+	s.handleGenericComment(l, d, gce)
 }
 
-func (s *Server) handlePullRequestEvent(l *logrus.Entry, pr github.PullRequestEvent) {
-	defer s.wg.Done()
+func (s *Server) handlePullRequestEvent(l *logrus.Entry, d *delivery, pr github.PullRequestEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  pr.Repo.Owner.Login,
 		github.RepoLogField: pr.Repo.Name,
@@ -172,10 +182,14 @@ func (s *Server) handlePullRequestEvent(l *logrus.Entry, pr github.PullRequestEv
 	})
 	l.Infof("Pull request %s.", pr.Action)
 	for p, h := range s.Plugins.PullRequestHandlers(pr.PullRequest.Base.Repo.Owner.Login, pr.PullRequest.Base.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.PullRequestHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, pr.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, pr.Repo.Owner.Login, pr.Repo.Name, s.Metrics.Metrics, l, p)
 			agent.InitializeCommentPruner(
 				pr.Repo.Owner.Login,
 				pr.Repo.Name,
@@ -187,6 +201,7 @@ func (s *Server) handlePullRequestEvent(l *logrus.Entry, pr github.PullRequestEv
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling PullRequestEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
@@ -205,11 +220,11 @@ func (s *Server) handlePullRequestEvent(l *logrus.Entry, pr github.PullRequestEv
 		return
 	}
 
-	s.handleGenericComment(l, gce)
+	s.handleGenericComment(l, d, gce)
 }
 
-func (s *Server) handlePushEvent(l *logrus.Entry, pe github.PushEvent) {
-	defer s.wg.Done()
+func (s *Server) handlePushEvent(l *logrus.Entry, d *delivery, pe github.PushEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  pe.Repo.Owner.Name,
 		github.RepoLogField: pe.Repo.Name,
@@ -218,24 +233,29 @@ func (s *Server) handlePushEvent(l *logrus.Entry, pe github.PushEvent) {
 	})
 	l.Info("Push event.")
 	for p, h := range s.Plugins.PushEventHandlers(pe.Repo.Owner.Name, pe.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.PushEventHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, pe.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, pe.Repo.Owner.Login, pe.Repo.Name, s.Metrics.Metrics, l, p)
 			start := time.Now()
 			err := errorOnPanic(func() error { return h(agent, pe) })
 			labels := prometheus.Labels{"event_type": l.Data[eventTypeField].(string), "action": "none", "plugin": p, "took_action": strconv.FormatBool(agent.TookAction())}
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling PushEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
 	}
 }
 
-func (s *Server) handleIssueEvent(l *logrus.Entry, i github.IssueEvent) {
-	defer s.wg.Done()
+func (s *Server) handleIssueEvent(l *logrus.Entry, d *delivery, i github.IssueEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  i.Repo.Owner.Login,
 		github.RepoLogField: i.Repo.Name,
@@ -245,10 +265,14 @@ func (s *Server) handleIssueEvent(l *logrus.Entry, i github.IssueEvent) {
 	})
 	l.Infof("Issue %s.", i.Action)
 	for p, h := range s.Plugins.IssueHandlers(i.Repo.Owner.Login, i.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.IssueHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, i.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, i.Repo.Owner.Login, i.Repo.Name, s.Metrics.Metrics, l, p)
 			agent.InitializeCommentPruner(
 				i.Repo.Owner.Login,
 				i.Repo.Name,
@@ -260,6 +284,7 @@ func (s *Server) handleIssueEvent(l *logrus.Entry, i github.IssueEvent) {
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling IssueEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
@@ -278,11 +303,11 @@ func (s *Server) handleIssueEvent(l *logrus.Entry, i github.IssueEvent) {
 		return
 	}
 
-	s.handleGenericComment(l, gce)
+	s.handleGenericComment(l, d, gce)
 }
 
-func (s *Server) handleIssueCommentEvent(l *logrus.Entry, ic github.IssueCommentEvent) {
-	defer s.wg.Done()
+func (s *Server) handleIssueCommentEvent(l *logrus.Entry, d *delivery, ic github.IssueCommentEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  ic.Repo.Owner.Login,
 		github.RepoLogField: ic.Repo.Name,
@@ -292,10 +317,14 @@ func (s *Server) handleIssueCommentEvent(l *logrus.Entry, ic github.IssueComment
 	})
 	l.Infof("Issue comment %s.", ic.Action)
 	for p, h := range s.Plugins.IssueCommentHandlers(ic.Repo.Owner.Login, ic.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.IssueCommentHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, ic.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, ic.Repo.Owner.Login, ic.Repo.Name, s.Metrics.Metrics, l, p)
 			agent.InitializeCommentPruner(
 				ic.Repo.Owner.Login,
 				ic.Repo.Name,
@@ -307,6 +336,7 @@ func (s *Server) handleIssueCommentEvent(l *logrus.Entry, ic github.IssueComment
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling IssueCommentEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
@@ -323,11 +353,11 @@ func (s *Server) handleIssueCommentEvent(l *logrus.Entry, ic github.IssueComment
 		return
 	}
 
-	s.handleGenericComment(l, gce)
+	s.handleGenericComment(l, d, gce)
 }
 
-func (s *Server) handleStatusEvent(l *logrus.Entry, se github.StatusEvent) {
-	defer s.wg.Done()
+func (s *Server) handleStatusEvent(l *logrus.Entry, d *delivery, se github.StatusEvent) {
+	defer d.done()
 	l = l.WithFields(logrus.Fields{
 		github.OrgLogField:  se.Repo.Owner.Login,
 		github.RepoLogField: se.Repo.Name,
@@ -338,28 +368,37 @@ func (s *Server) handleStatusEvent(l *logrus.Entry, se github.StatusEvent) {
 	})
 	l.Infof("Status description %s.", se.Description)
 	for p, h := range s.Plugins.StatusEventHandlers(se.Repo.Owner.Login, se.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.StatusEventHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, se.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, se.Repo.Owner.Login, se.Repo.Name, s.Metrics.Metrics, l, p)
 			start := time.Now()
 			err := errorOnPanic(func() error { return h(agent, se) })
 			labels := prometheus.Labels{"event_type": l.Data[eventTypeField].(string), "action": "none", "plugin": p, "took_action": strconv.FormatBool(agent.TookAction())}
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling StatusEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
 	}
 }
 
-func (s *Server) handleGenericComment(l *logrus.Entry, ce *github.GenericCommentEvent) {
+func (s *Server) handleGenericComment(l *logrus.Entry, d *delivery, ce *github.GenericCommentEvent) {
 	for p, h := range s.Plugins.GenericCommentHandlers(ce.Repo.Owner.Login, ce.Repo.Name) {
-		s.wg.Add(1)
+		if !d.wants(p) || !s.dispatches(p, l.Data[eventTypeField].(string)) {
+			continue
+		}
+		d.add()
 		go func(p string, h plugins.GenericCommentHandler) {
-			defer s.wg.Done()
-			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, ce.Repo.Owner.Login, s.Metrics.Metrics, l, p)
+			defer d.done()
+			defer s.acquire(p)()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, ce.Repo.Owner.Login, ce.Repo.Name, s.Metrics.Metrics, l, p)
 			agent.InitializeCommentPruner(
 				ce.Repo.Owner.Login,
 				ce.Repo.Name,
@@ -371,6 +410,7 @@ func (s *Server) handleGenericComment(l *logrus.Entry, ce *github.GenericComment
 			if err != nil {
 				agent.Logger.WithError(err).Error("Error handling GenericCommentEvent.")
 				s.Metrics.PluginHandleErrors.With(labels).Inc()
+				d.fail(p, err)
 			}
 			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
 		}(p, h)
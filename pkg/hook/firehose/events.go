@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firehose
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// ProwJobEventType is the type of events for ProwJobs triggered by plugins.
+const ProwJobEventType = "prowjob"
+
+// IssueEvent sanitizes an issues event.
+func IssueEvent(i github.IssueEvent) Event {
+	return Event{
+		Type:   "issues",
+		Action: string(i.Action),
+		GUID:   i.GUID,
+		Org:    i.Repo.Owner.Login,
+		Repo:   i.Repo.Name,
+		Number: i.Issue.Number,
+		Actor:  i.Sender.Login,
+		URL:    i.Issue.HTMLURL,
+		Label:  i.Label.Name,
+	}
+}
+
+// IssueCommentEvent sanitizes an issue_comment event.
+func IssueCommentEvent(ic github.IssueCommentEvent) Event {
+	return Event{
+		Type:   "issue_comment",
+		Action: string(ic.Action),
+		GUID:   ic.GUID,
+		Org:    ic.Repo.Owner.Login,
+		Repo:   ic.Repo.Name,
+		Number: ic.Issue.Number,
+		Actor:  ic.Comment.User.Login,
+		URL:    ic.Comment.HTMLURL,
+	}
+}
+
+// PullRequestEvent sanitizes a pull_request event.
+func PullRequestEvent(pr github.PullRequestEvent) Event {
+	return Event{
+		Type:   "pull_request",
+		Action: string(pr.Action),
+		GUID:   pr.GUID,
+		Org:    pr.Repo.Owner.Login,
+		Repo:   pr.Repo.Name,
+		Number: pr.Number,
+		Actor:  pr.Sender.Login,
+		URL:    pr.PullRequest.HTMLURL,
+		Label:  pr.Label.Name,
+		Ref:    pr.PullRequest.Base.Ref,
+		SHA:    pr.PullRequest.Head.SHA,
+	}
+}
+
+// ReviewEvent sanitizes a pull_request_review event.
+func ReviewEvent(re github.ReviewEvent) Event {
+	return Event{
+		Type:   "pull_request_review",
+		Action: string(re.Action),
+		GUID:   re.GUID,
+		Org:    re.Repo.Owner.Login,
+		Repo:   re.Repo.Name,
+		Number: re.PullRequest.Number,
+		Actor:  re.Review.User.Login,
+		URL:    re.Review.HTMLURL,
+		State:  string(re.Review.State),
+	}
+}
+
+// PushEvent sanitizes a push event.
+func PushEvent(pe github.PushEvent) Event {
+	return Event{
+		Type:  "push",
+		GUID:  pe.GUID,
+		Org:   pe.Repo.Owner.Name,
+		Repo:  pe.Repo.Name,
+		Actor: pe.Sender.Login,
+		URL:   pe.Compare,
+		Ref:   pe.Ref,
+		SHA:   pe.After,
+	}
+}
+
+// StatusEvent sanitizes a status event.
+func StatusEvent(se github.StatusEvent) Event {
+	return Event{
+		Type:    "status",
+		GUID:    se.GUID,
+		Org:     se.Repo.Owner.Login,
+		Repo:    se.Repo.Name,
+		Actor:   se.Sender.Login,
+		URL:     se.TargetURL,
+		SHA:     se.SHA,
+		Context: se.Context,
+		State:   se.State,
+	}
+}
+
+// ProwJobEvent describes a ProwJob triggered by a plugin.
+func ProwJobEvent(pj *prowapi.ProwJob) Event {
+	e := Event{
+		Type:    ProwJobEventType,
+		Action:  "triggered",
+		Context: pj.Spec.Context,
+		Job:     pj.Spec.Job,
+		JobType: string(pj.Spec.Type),
+		ProwJob: pj.Name,
+		URL:     pj.Status.URL,
+	}
+	if refs := pj.Spec.Refs; refs != nil {
+		e.Org = refs.Org
+		e.Repo = refs.Repo
+		e.Ref = refs.BaseRef
+		e.SHA = refs.BaseSHA
+		if len(refs.Pulls) > 0 {
+			e.Number = refs.Pulls[0].Number
+			e.Actor = refs.Pulls[0].Author
+			e.SHA = refs.Pulls[0].SHA
+		}
+	}
+	return e
+}
+
+// prowJobClient publishes an event for every ProwJob it creates.
+type prowJobClient struct {
+	prowv1.ProwJobInterface
+	broker *Broker
+}
+
+// NewProwJobClient wraps a ProwJob client to publish the ProwJobs created
+// through it to the broker.
+func NewProwJobClient(client prowv1.ProwJobInterface, broker *Broker) prowv1.ProwJobInterface {
+	return &prowJobClient{ProwJobInterface: client, broker: broker}
+}
+
+func (c *prowJobClient) Create(ctx context.Context, pj *prowapi.ProwJob, opts metav1.CreateOptions) (*prowapi.ProwJob, error) {
+	created, err := c.ProwJobInterface.Create(ctx, pj, opts)
+	if err == nil {
+		c.broker.Publish(ProwJobEvent(created))
+	}
+	return created, err
+}
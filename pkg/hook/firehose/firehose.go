@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package firehose streams sanitized repository events received by hook to
+// external consumers, so that they can react to repository activity without
+// registering their own GitHub webhooks.
+package firehose
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// subscriberBuffer is the number of events buffered for every subscriber.
+// Events for subscribers that fall further behind are dropped.
+const subscriberBuffer = 100
+
+// Event is a sanitized repository event. It only carries metadata about the
+// activity, never the content of issues, pull requests or comments.
+type Event struct {
+	// ID is assigned by the broker and increases monotonically.
+	ID   uint64    `json:"id"`
+	Time time.Time `json:"time"`
+	// Type is the GitHub webhook event type, or "prowjob" for triggered jobs.
+	Type   string `json:"type"`
+	Action string `json:"action,omitempty"`
+	GUID   string `json:"guid,omitempty"`
+
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number,omitempty"`
+	Actor  string `json:"actor,omitempty"`
+	URL    string `json:"url,omitempty"`
+
+	Label   string `json:"label,omitempty"`
+	Ref     string `json:"ref,omitempty"`
+	SHA     string `json:"sha,omitempty"`
+	Context string `json:"context,omitempty"`
+	State   string `json:"state,omitempty"`
+
+	Job     string `json:"job,omitempty"`
+	JobType string `json:"job_type,omitempty"`
+	ProwJob string `json:"prowjob,omitempty"`
+}
+
+// Filter selects the events a subscriber receives. Empty fields match all
+// events.
+type Filter struct {
+	// Repos holds orgs or org/repo names.
+	Repos   sets.Set[string]
+	Types   sets.Set[string]
+	Actions sets.Set[string]
+}
+
+// ParseFilter parses a filter from the `repo`, `type` and `action` query
+// parameters, which may be repeated or hold comma-separated values.
+func ParseFilter(query url.Values) Filter {
+	values := func(key string) sets.Set[string] {
+		s := sets.New[string]()
+		for _, value := range query[key] {
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					s.Insert(v)
+				}
+			}
+		}
+		return s
+	}
+	return Filter{Repos: values("repo"), Types: values("type"), Actions: values("action")}
+}
+
+// Matches determines if the event passes the filter.
+func (f Filter) Matches(e Event) bool {
+	if f.Repos.Len() > 0 && !f.Repos.Has(e.Org) && !f.Repos.Has(e.Org+"/"+e.Repo) {
+		return false
+	}
+	if f.Types.Len() > 0 && !f.Types.Has(e.Type) {
+		return false
+	}
+	if f.Actions.Len() > 0 && !f.Actions.Has(e.Action) {
+		return false
+	}
+	return true
+}
+
+type subscriber struct {
+	filter Filter
+	events chan Event
+}
+
+// Broker fans published events out to all subscribers with a matching
+// filter. A nil *Broker discards all events.
+type Broker struct {
+	lock        sync.Mutex
+	subscribers map[*subscriber]struct{}
+	lastID      uint64
+	closed      bool
+	now         func() time.Time
+}
+
+// NewBroker creates a broker without subscribers.
+func NewBroker() *Broker {
+	return &Broker{subscribers: map[*subscriber]struct{}{}, now: time.Now}
+}
+
+// Subscribe registers a subscriber for the events matching the filter. The
+// returned function unsubscribes and must be called once the subscriber is
+// done. The channel is closed when unsubscribing or closing the broker.
+func (b *Broker) Subscribe(filter Filter) (<-chan Event, func()) {
+	s := &subscriber{filter: filter, events: make(chan Event, subscriberBuffer)}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		close(s.events)
+		return s.events, func() {}
+	}
+	b.subscribers[s] = struct{}{}
+	return s.events, func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		if _, ok := b.subscribers[s]; ok {
+			delete(b.subscribers, s)
+			close(s.events)
+		}
+	}
+}
+
+// Publish sends the event to all matching subscribers without blocking.
+func (b *Broker) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return
+	}
+	b.lastID++
+	e.ID = b.lastID
+	if e.Time.IsZero() {
+		e.Time = b.now()
+	}
+	for s := range b.subscribers {
+		if !s.filter.Matches(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			logrus.WithField("event-type", e.Type).Warn("Firehose subscriber is too slow, dropping event.")
+		}
+	}
+}
+
+// Close disconnects all subscribers and discards future events.
+func (b *Broker) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for s := range b.subscribers {
+		delete(b.subscribers, s)
+		close(s.events)
+	}
+}
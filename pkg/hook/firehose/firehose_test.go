@@ -0,0 +1,296 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firehose
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestParseFilter(t *testing.T) {
+	query, err := url.ParseQuery("repo=kubernetes,org/repo&repo=other&type=pull_request&action=labeled,+opened")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Filter{
+		Repos:   sets.New[string]("kubernetes", "org/repo", "other"),
+		Types:   sets.New[string]("pull_request"),
+		Actions: sets.New[string]("labeled", "opened"),
+	}
+	if diff := cmp.Diff(expected, ParseFilter(query)); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	event := Event{Type: "pull_request", Action: "labeled", Org: "org", Repo: "repo"}
+	testCases := []struct {
+		name     string
+		filter   string
+		expected bool
+	}{
+		{
+			name:     "empty filter matches everything",
+			expected: true,
+		},
+		{
+			name:     "org matches",
+			filter:   "repo=org",
+			expected: true,
+		},
+		{
+			name:     "repo matches",
+			filter:   "repo=other/repo,org/repo",
+			expected: true,
+		},
+		{
+			name:   "other repo",
+			filter: "repo=org/other",
+		},
+		{
+			name:     "type and action match",
+			filter:   "type=issues,pull_request&action=labeled",
+			expected: true,
+		},
+		{
+			name:   "other type",
+			filter: "type=issues",
+		},
+		{
+			name:   "other action",
+			filter: "repo=org&action=opened",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tc.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := ParseFilter(query).Matches(event); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBroker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBroker()
+	b.now = func() time.Time { return now }
+
+	all, unsubscribeAll := b.Subscribe(Filter{})
+	labels, unsubscribeLabels := b.Subscribe(Filter{Actions: sets.New[string]("labeled")})
+
+	b.Publish(Event{Type: "pull_request", Action: "opened"})
+	b.Publish(Event{Type: "pull_request", Action: "labeled"})
+	unsubscribeLabels()
+	unsubscribeLabels()
+	b.Publish(Event{Type: "issues", Action: "labeled"})
+
+	receive := func(events <-chan Event) []Event {
+		var received []Event
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					return received
+				}
+				received = append(received, e)
+			default:
+				return received
+			}
+		}
+	}
+	expectedAll := []Event{
+		{ID: 1, Time: now, Type: "pull_request", Action: "opened"},
+		{ID: 2, Time: now, Type: "pull_request", Action: "labeled"},
+		{ID: 3, Time: now, Type: "issues", Action: "labeled"},
+	}
+	if diff := cmp.Diff(expectedAll, receive(all)); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]Event{{ID: 2, Time: now, Type: "pull_request", Action: "labeled"}}, receive(labels)); diff != "" {
+		t.Errorf("unexpected events for filtered subscriber (-want +got):\n%s", diff)
+	}
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		b.Publish(Event{Type: "push"})
+	}
+	if received := receive(all); len(received) != subscriberBuffer {
+		t.Errorf("expected events of slow subscribers to be dropped after %d events, got %d", subscriberBuffer, len(received))
+	}
+
+	b.Close()
+	if _, ok := <-all; ok {
+		t.Error("expected subscriptions to be closed with the broker")
+	}
+	unsubscribeAll()
+	if _, ok := <-func() <-chan Event { c, _ := b.Subscribe(Filter{}); return c }(); ok {
+		t.Error("expected subscriptions to a closed broker to be closed")
+	}
+
+	var nilBroker *Broker
+	nilBroker.Publish(Event{Type: "push"})
+}
+
+func TestEvents(t *testing.T) {
+	repo := github.Repo{Owner: github.User{Login: "org", Name: "org"}, Name: "repo"}
+	testCases := []struct {
+		name     string
+		actual   Event
+		expected Event
+	}{
+		{
+			name: "labeled issue",
+			actual: IssueEvent(github.IssueEvent{
+				Action: github.IssueActionLabeled,
+				Issue:  github.Issue{Number: 1, Title: "secret title", Body: "secret body", HTMLURL: "https://github.com/org/repo/issues/1"},
+				Repo:   repo,
+				Label:  github.Label{Name: "kind/bug"},
+				Sender: github.User{Login: "alice"},
+				GUID:   "guid",
+			}),
+			expected: Event{Type: "issues", Action: "labeled", GUID: "guid", Org: "org", Repo: "repo", Number: 1, Actor: "alice", URL: "https://github.com/org/repo/issues/1", Label: "kind/bug"},
+		},
+		{
+			name: "issue comment",
+			actual: IssueCommentEvent(github.IssueCommentEvent{
+				Action:  github.IssueCommentActionCreated,
+				Issue:   github.Issue{Number: 2},
+				Comment: github.IssueComment{Body: "/lgtm", User: github.User{Login: "bob"}, HTMLURL: "https://github.com/org/repo/pull/2#issuecomment-1"},
+				Repo:    repo,
+			}),
+			expected: Event{Type: "issue_comment", Action: "created", Org: "org", Repo: "repo", Number: 2, Actor: "bob", URL: "https://github.com/org/repo/pull/2#issuecomment-1"},
+		},
+		{
+			name: "opened pull request",
+			actual: PullRequestEvent(github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				Number: 3,
+				PullRequest: github.PullRequest{
+					Title:   "secret title",
+					HTMLURL: "https://github.com/org/repo/pull/3",
+					Base:    github.PullRequestBranch{Ref: "main"},
+					Head:    github.PullRequestBranch{SHA: "abcdef"},
+				},
+				Repo:   repo,
+				Sender: github.User{Login: "alice"},
+			}),
+			expected: Event{Type: "pull_request", Action: "opened", Org: "org", Repo: "repo", Number: 3, Actor: "alice", URL: "https://github.com/org/repo/pull/3", Ref: "main", SHA: "abcdef"},
+		},
+		{
+			name: "review",
+			actual: ReviewEvent(github.ReviewEvent{
+				Action:      github.ReviewActionSubmitted,
+				PullRequest: github.PullRequest{Number: 3},
+				Repo:        repo,
+				Review:      github.Review{User: github.User{Login: "bob"}, Body: "secret body", State: github.ReviewStateApproved},
+			}),
+			expected: Event{Type: "pull_request_review", Action: "submitted", Org: "org", Repo: "repo", Number: 3, Actor: "bob", State: "APPROVED"},
+		},
+		{
+			name: "push",
+			actual: PushEvent(github.PushEvent{
+				Ref:     "refs/heads/main",
+				After:   "abcdef",
+				Compare: "https://github.com/org/repo/compare/a...b",
+				Commits: []github.Commit{{Message: "secret message"}},
+				Sender:  github.User{Login: "alice"},
+				Repo:    repo,
+			}),
+			expected: Event{Type: "push", Org: "org", Repo: "repo", Actor: "alice", URL: "https://github.com/org/repo/compare/a...b", Ref: "refs/heads/main", SHA: "abcdef"},
+		},
+		{
+			name: "status",
+			actual: StatusEvent(github.StatusEvent{
+				SHA:         "abcdef",
+				State:       "success",
+				Description: "Job succeeded.",
+				TargetURL:   "https://prow.example.com/view/1",
+				Context:     "pull-unit",
+				Repo:        repo,
+			}),
+			expected: Event{Type: "status", Org: "org", Repo: "repo", URL: "https://prow.example.com/view/1", SHA: "abcdef", Context: "pull-unit", State: "success"},
+		},
+		{
+			name: "presubmit ProwJob",
+			actual: ProwJobEvent(&prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "pj"},
+				Spec: prowapi.ProwJobSpec{
+					Type:    prowapi.PresubmitJob,
+					Job:     "pull-unit",
+					Context: "pull-unit",
+					Refs: &prowapi.Refs{
+						Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "base",
+						Pulls: []prowapi.Pull{{Number: 3, Author: "alice", SHA: "head"}},
+					},
+				},
+			}),
+			expected: Event{Type: "prowjob", Action: "triggered", Org: "org", Repo: "repo", Number: 3, Actor: "alice", Ref: "main", SHA: "head", Context: "pull-unit", Job: "pull-unit", JobType: "presubmit", ProwJob: "pj"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, tc.actual); diff != "" {
+				t.Errorf("unexpected event (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProwJobClient(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(Filter{Types: sets.New[string](ProwJobEventType)})
+	defer unsubscribe()
+
+	client := NewProwJobClient(fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"), b)
+	pj := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "pj", Namespace: "prowjobs"},
+		Spec:       prowapi.ProwJobSpec{Type: prowapi.PeriodicJob, Job: "periodic"},
+	}
+	if _, err := client.Create(context.Background(), pj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Create(context.Background(), pj, metav1.CreateOptions{}); err == nil {
+		t.Fatal("expected creating a duplicate ProwJob to fail")
+	}
+
+	select {
+	case e := <-events:
+		if e.ProwJob != "pj" || e.Job != "periodic" {
+			t.Errorf("unexpected event %+v", e)
+		}
+	default:
+		t.Fatal("expected an event for the created ProwJob")
+	}
+	select {
+	case e := <-events:
+		t.Errorf("expected no event for the failed creation, got %+v", e)
+	default:
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firehose
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerAuthentication(t *testing.T) {
+	testCases := []struct {
+		name         string
+		method       string
+		token        string
+		configured   string
+		expectedCode int
+	}{
+		{
+			name:         "invalid token",
+			method:       http.MethodGet,
+			token:        "wrong",
+			configured:   "secret",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "no token configured",
+			method:       http.MethodGet,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "only GET is allowed",
+			method:       http.MethodPost,
+			token:        "secret",
+			configured:   "secret",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Handler{Broker: NewBroker(), TokenGenerator: func() []byte { return []byte(tc.configured) }}
+			req := httptest.NewRequest(tc.method, "/firehose", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+			if rr.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, rr.Code)
+			}
+		})
+	}
+}
+
+func TestHandlerStreamsEvents(t *testing.T) {
+	b := NewBroker()
+	b.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	server := httptest.NewServer(&Handler{Broker: b, TokenGenerator: func() []byte { return []byte("secret\n") }, KeepAlive: time.Hour})
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/firehose?repo=org/repo&type=pull_request", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("expected content type text/event-stream, got %s", contentType)
+	}
+
+	// The subscription is registered before the headers are flushed.
+	b.Publish(Event{Type: "pull_request", Action: "opened", Org: "org", Repo: "other"})
+	b.Publish(Event{Type: "issues", Action: "opened", Org: "org", Repo: "repo"})
+	b.Publish(Event{Type: "pull_request", Action: "opened", Org: "org", Repo: "repo", Number: 1})
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 4 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	expected := []string{
+		"id: 3",
+		"event: pull_request",
+		`data: {"id":3,"time":"2026-01-01T00:00:00Z","type":"pull_request","action":"opened","org":"org","repo":"repo","number":1}`,
+		"",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected event stream:\n%s\nexpected:\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+
+	b.Close()
+	if _, err := reader.ReadString('\n'); err == nil {
+		t.Error("expected the stream to end when the broker is closed")
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/bugzilla"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/gitlab"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/repoowners"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+type fakeGitLabClient struct {
+	gitlab.Client
+	mrs map[int]gitlab.MergeRequest
+}
+
+func (f *fakeGitLabClient) GetMergeRequest(org, repo string, iid int) (*gitlab.MergeRequest, error) {
+	mr, ok := f.mrs[iid]
+	if !ok {
+		return nil, gitlab.StatusError{Code: http.StatusNotFound}
+	}
+	return &mr, nil
+}
+
+const gitLabNoteEvent = `{
+  "object_kind": "note",
+  "user": {"id": 2, "name": "Bob", "username": "bob"},
+  "project": {"id": 1, "name": "repo", "path_with_namespace": "ORG/repo", "web_url": "https://gitlab.example.com/ORG/repo", "default_branch": "main"},
+  "object_attributes": {"id": 100, "note": "/lgtm", "noteable_type": "MergeRequest", "url": "https://gitlab.example.com/ORG/repo/-/merge_requests/3#note_100"},
+  "merge_request": {"iid": 3}
+}`
+
+// TestServeSCMGitLab sends a GitLab comment webhook to a hook.Server and
+// ensures that a fake plugin is called with the converted comment.
+func TestServeSCMGitLab(t *testing.T) {
+	called := make(chan github.GenericCommentEvent, 1)
+	plugins.RegisterGenericCommentHandler(
+		"gitlab-comment",
+		func(pc plugins.Agent, e github.GenericCommentEvent) error {
+			called <- e
+			return nil
+		},
+		nil,
+	)
+	pa := &plugins.ConfigAgent{}
+	pa.Set(&plugins.Configuration{Plugins: plugins.Plugins{
+		"group":   {Plugins: []string{"gitlab-comment"}},
+		"foo/bar": {Plugins: []string{"gitlab-comment"}},
+	}})
+	ca := &config.Agent{}
+	ca.Set(&config.Config{ProwConfig: config.ProwConfig{GitLab: &config.GitLab{Orgs: []string{"group"}}}})
+	clientAgent := &plugins.ClientAgent{
+		GitHubClient:   github.NewFakeClient(),
+		OwnersClient:   repoowners.NewClient(nil, nil, func(org, repo string) bool { return false }, func(org, repo string) bool { return false }, func() *config.OwnersDirDenylist { return &config.OwnersDirDenylist{} }, ownersconfig.FakeResolver),
+		JiraClient:     &fakejira.FakeClient{},
+		BugzillaClient: &bugzilla.Fake{},
+	}
+	provider := gitlab.NewProvider(&fakeGitLabClient{mrs: map[int]gitlab.MergeRequest{
+		3: {IID: 3, Title: "Fix it", State: gitlab.MergeRequestStateOpened, Author: gitlab.User{Username: "alice"}},
+	}}, func() []byte { return []byte("secret") })
+	clientAgent.SCMProviders = map[string]scm.Provider{provider.Name(): provider}
+
+	testcases := []struct {
+		name     string
+		org      string
+		token    string
+		code     int
+		expected *github.GenericCommentEvent
+	}{
+		{
+			name:  "comment of a GitLab org is handled",
+			org:   "group",
+			token: "secret",
+			code:  http.StatusOK,
+			expected: &github.GenericCommentEvent{
+				CommentID:   func() *int { id := 100; return &id }(),
+				IsPR:        true,
+				Action:      github.GenericCommentActionCreated,
+				Body:        "/lgtm",
+				HTMLURL:     "https://gitlab.example.com/group/repo/-/merge_requests/3#note_100",
+				Number:      3,
+				Repo:        github.Repo{Owner: github.User{Login: "group", Name: "group"}, Name: "repo", FullName: "group/repo", HTMLURL: "https://gitlab.example.com/group/repo", DefaultBranch: "main"},
+				User:        github.User{Login: "bob", Name: "Bob", ID: 2, Type: github.UserTypeUser},
+				IssueAuthor: github.User{Login: "alice", Type: github.UserTypeUser},
+				IssueState:  github.PullRequestStateOpen,
+				IssueTitle:  "Fix it",
+				GUID:        "uuid",
+			},
+		},
+		{
+			name:  "comment of a GitHub org is ignored",
+			org:   "foo",
+			token: "secret",
+			code:  http.StatusOK,
+		},
+		{
+			name:  "invalid token is rejected",
+			org:   "group",
+			token: "wrong",
+			code:  http.StatusForbidden,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &Server{
+				ClientAgent: clientAgent,
+				Plugins:     pa,
+				ConfigAgent: ca,
+				Metrics:     githubeventserver.NewMetrics(),
+				RepoEnabled: func(org, repo string) bool { return true },
+			}
+			body := strings.ReplaceAll(gitLabNoteEvent, "ORG", tc.org)
+			req := httptest.NewRequest(http.MethodPost, "/hook/gitlab", strings.NewReader(body))
+			req.Header.Set("X-Gitlab-Event", gitlab.NoteHook)
+			req.Header.Set("X-Gitlab-Event-UUID", "uuid")
+			req.Header.Set("X-Gitlab-Token", tc.token)
+			req.Header.Set("content-type", "application/json")
+			w := httptest.NewRecorder()
+			server.ServeSCM(provider)(w, req)
+			if w.Code != tc.code {
+				t.Fatalf("expected status code %d, got %d", tc.code, w.Code)
+			}
+			server.GracefulShutdown()
+
+			select {
+			case e := <-called:
+				if tc.expected == nil {
+					t.Fatalf("expected no event, got %+v", e)
+				}
+				if diff := cmp.Diff(*tc.expected, e); diff != "" {
+					t.Errorf("event differs from expected (-want +got):\n%s", diff)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tc.expected != nil {
+					t.Error("plugin not called")
+				}
+			}
+		})
+	}
+}
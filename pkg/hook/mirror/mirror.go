@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mirror sends a sample of the webhooks received by hook to a staging
+// hook, so that changes can be tested against live traffic before they are
+// rolled out.
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// Header marks the webhooks sent by a Mirror. Hook does not mirror these
+// webhooks again, and only handles them in dry-run.
+const Header = "X-Prow-Mirrored"
+
+const (
+	// queueSize is the number of webhooks waiting to be mirrored. Further
+	// webhooks are dropped, so that a slow staging hook never holds up hook.
+	queueSize = 1000
+	// workers is the number of webhooks mirrored at the same time.
+	workers = 4
+	// requestTimeout bounds how long the staging hook may take to respond.
+	requestTimeout = 30 * time.Second
+)
+
+// Results of mirrored webhooks.
+const (
+	resultSent    = "sent"
+	resultFailed  = "failed"
+	resultDropped = "dropped"
+)
+
+var mirroredWebhooks = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "prow_webhook_mirrored",
+	Help: "A counter of the webhooks mirrored to a staging hook, by result: sent, failed or dropped.",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(mirroredWebhooks)
+}
+
+type webhook struct {
+	eventType string
+	eventGUID string
+	payload   []byte
+}
+
+// Mirror sends webhooks to a staging hook in the background. A nil *Mirror
+// discards all webhooks.
+type Mirror struct {
+	url            string
+	percent        int
+	tokenGenerator func() []byte
+	client         *http.Client
+
+	webhooks chan webhook
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// New creates a mirror that sends the given percentage of webhooks to the
+// url, signed with the HMAC tokens of the staging hook.
+func New(url string, percent int, tokenGenerator func() []byte) *Mirror {
+	return newMirror(url, percent, tokenGenerator, workers)
+}
+
+func newMirror(url string, percent int, tokenGenerator func() []byte, workers int) *Mirror {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Mirror{
+		url:            url,
+		percent:        percent,
+		tokenGenerator: tokenGenerator,
+		client:         &http.Client{Timeout: requestTimeout},
+		webhooks:       make(chan webhook, queueSize),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	m.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go m.work()
+	}
+	return m
+}
+
+// sampled determines if the webhook of the delivery is mirrored. Webhooks
+// are sampled by their delivery GUID, so that redeliveries of a webhook are
+// mirrored alike.
+func (m *Mirror) sampled(eventGUID string) bool {
+	if m.percent >= 100 {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(eventGUID))
+	return int(h.Sum32()%100) < m.percent
+}
+
+// Send queues the webhook to be mirrored if it is sampled, without blocking.
+// The webhook is dropped if the queue is full.
+func (m *Mirror) Send(eventType, eventGUID string, payload []byte) {
+	if m == nil || m.ctx.Err() != nil || !m.sampled(eventGUID) {
+		return
+	}
+	select {
+	case m.webhooks <- webhook{eventType: eventType, eventGUID: eventGUID, payload: payload}:
+	default:
+		mirroredWebhooks.WithLabelValues(resultDropped).Inc()
+		logrus.WithField(github.EventGUID, eventGUID).Warn("Staging hook is too slow, dropping mirrored webhook.")
+	}
+}
+
+func (m *Mirror) work() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case w := <-m.webhooks:
+			if err := m.send(w); err != nil {
+				mirroredWebhooks.WithLabelValues(resultFailed).Inc()
+				logrus.WithError(err).WithField(github.EventGUID, w.eventGUID).Warn("Failed to mirror webhook.")
+				continue
+			}
+			mirroredWebhooks.WithLabelValues(resultSent).Inc()
+		}
+	}
+}
+
+// send posts the webhook to the staging hook, signed with the HMAC token of
+// its org or repo.
+func (m *Mirror) send(w webhook) error {
+	var event github.GenericEvent
+	if err := json.Unmarshal(w.payload, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+	orgRepo := event.Repo.FullName
+	if orgRepo == "" {
+		orgRepo = event.Org.Login
+	}
+	sig, err := github.SignPayload(w.payload, orgRepo, m.tokenGenerator)
+	if err != nil {
+		return fmt.Errorf("failed to sign payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(m.ctx, http.MethodPost, m.url, bytes.NewReader(w.payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("X-GitHub-Event", w.eventType)
+	req.Header.Set("X-GitHub-Delivery", w.eventGUID)
+	req.Header.Set("X-Hub-Signature", sig)
+	req.Header.Set(Header, "true")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("staging hook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// Close stops mirroring. Webhooks that were not sent yet are dropped.
+func (m *Mirror) Close() {
+	if m == nil {
+		return
+	}
+	m.cancel()
+	m.wg.Wait()
+}
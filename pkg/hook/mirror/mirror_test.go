@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestSend(t *testing.T) {
+	tokenGenerator := func() []byte {
+		return []byte(`
+'*':
+  - value: abc
+    created_at: 2019-10-02T15:00:00Z
+org/repo:
+  - value: staging
+    created_at: 2019-10-02T15:00:00Z
+`)
+	}
+	payload := []byte(`{"repository":{"full_name":"org/repo"}}`)
+
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	m := New(server.URL, 100, tokenGenerator)
+	defer m.Close()
+	m.Send("pull_request", "guid", payload)
+
+	var r *http.Request
+	select {
+	case r = <-requests:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Webhook was not mirrored.")
+	}
+	body := <-bodies
+	if string(body) != string(payload) {
+		t.Errorf("Expected payload %s, got %s", payload, body)
+	}
+	for header, expected := range map[string]string{
+		"X-GitHub-Event":    "pull_request",
+		"X-GitHub-Delivery": "guid",
+		"content-type":      "application/json",
+		Header:              "true",
+	} {
+		if actual := r.Header.Get(header); actual != expected {
+			t.Errorf("Expected header %s to be %q, got %q", header, expected, actual)
+		}
+	}
+	if sig := r.Header.Get("X-Hub-Signature"); sig != github.PayloadSignature(payload, []byte("staging")) {
+		t.Errorf("Expected payload to be signed with the token of the repo, got signature %q", sig)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	testCases := []struct {
+		percent  int
+		min, max int
+	}{
+		{percent: 0, min: 0, max: 0},
+		{percent: 100, min: 1000, max: 1000},
+		{percent: 10, min: 50, max: 150},
+		{percent: 50, min: 400, max: 600},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d percent", tc.percent), func(t *testing.T) {
+			m := &Mirror{percent: tc.percent}
+			var sampled int
+			for i := 0; i < 1000; i++ {
+				guid := fmt.Sprintf("guid-%d", i)
+				if m.sampled(guid) {
+					sampled++
+				}
+				if m.sampled(guid) != m.sampled(guid) {
+					t.Errorf("Expected delivery %s to be sampled alike", guid)
+				}
+			}
+			if sampled < tc.min || sampled > tc.max {
+				t.Errorf("Expected between %d and %d sampled deliveries, got %d", tc.min, tc.max, sampled)
+			}
+		})
+	}
+}
+
+func TestSendDropsWhenQueueIsFull(t *testing.T) {
+	m := newMirror("http://staging", 100, func() []byte { return nil }, 0)
+	for i := 0; i < queueSize+10; i++ {
+		m.Send("push", fmt.Sprintf("guid-%d", i), []byte(`{}`))
+	}
+	if len(m.webhooks) != queueSize {
+		t.Errorf("Expected %d queued webhooks, got %d", queueSize, len(m.webhooks))
+	}
+	m.Close()
+	m.Send("push", "after-close", []byte(`{}`))
+	if len(m.webhooks) != queueSize {
+		t.Errorf("Expected no webhooks to be queued after closing, got %d", len(m.webhooks)-queueSize)
+	}
+
+	var nilMirror *Mirror
+	nilMirror.Send("push", "guid", []byte(`{}`))
+	nilMirror.Close()
+}
+
+func TestOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options Options
+		err     bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:    "valid",
+			options: Options{URL: "https://staging.example.com/hook", Percent: 10, HMACSecretFile: "/etc/staging/hmac"},
+		},
+		{
+			name:    "missing secret",
+			options: Options{URL: "https://staging.example.com/hook", Percent: 10},
+			err:     true,
+		},
+		{
+			name:    "invalid url",
+			options: Options{URL: "staging.example.com/hook", Percent: 10, HMACSecretFile: "/etc/staging/hmac"},
+			err:     true,
+		},
+		{
+			name:    "invalid percent",
+			options: Options{URL: "https://staging.example.com/hook", Percent: 101, HMACSecretFile: "/etc/staging/hmac"},
+			err:     true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.options.Validate(false); (err != nil) != tc.err {
+				t.Errorf("Expected error %t, got %v", tc.err, err)
+			}
+		})
+	}
+}
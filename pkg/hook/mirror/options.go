@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+)
+
+// Options configure the mirroring of webhooks to a staging hook.
+type Options struct {
+	URL            string
+	Percent        int
+	HMACSecretFile string
+}
+
+// AddFlags injects the mirror options into the given FlagSet.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.URL, "mirror-url", "", "URL of the webhook path of a staging hook that incoming webhooks are mirrored to. Webhooks are not mirrored if unset.")
+	fs.IntVar(&o.Percent, "mirror-percent", 100, "Percentage of the webhooks that are mirrored to --mirror-url, sampled by their delivery GUID.")
+	fs.StringVar(&o.HMACSecretFile, "mirror-hmac-secret-file", "", "Path to the file containing the HMAC secret of the staging hook that mirrored webhooks are signed with, used with --mirror-url.")
+}
+
+// Validate validates the mirror options.
+func (o *Options) Validate(_ bool) error {
+	if o.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(o.URL)
+	if err != nil {
+		return fmt.Errorf("invalid --mirror-url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid --mirror-url %q, must be an http or https URL", o.URL)
+	}
+	if o.Percent < 0 || o.Percent > 100 {
+		return fmt.Errorf("invalid --mirror-percent %d, must be between 0 and 100", o.Percent)
+	}
+	if o.HMACSecretFile == "" {
+		return errors.New("--mirror-hmac-secret-file is required with --mirror-url")
+	}
+	return nil
+}
+
+// Enabled returns whether webhooks are mirrored.
+func (o *Options) Enabled() bool {
+	return o.URL != ""
+}
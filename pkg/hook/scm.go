@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/hook/firehose"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// ServeSCM returns a handler that validates incoming webhooks of the provider
+// and dispatches the GitHub events they correspond to. Only orgs configured
+// to be on the provider are served.
+func (s *Server) ServeSCM(provider scm.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventType, eventGUID, payload, ok, resp := provider.ValidateWebhook(w, r)
+		if counter, err := s.Metrics.ResponseCounter.GetMetricWithLabelValues(strconv.Itoa(resp)); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"status-code": resp,
+			}).WithError(err).Error("Failed to get metric for reporting webhook status code")
+		} else {
+			counter.Inc()
+		}
+
+		if !ok {
+			return
+		}
+		fmt.Fprint(w, "Event received. Have a nice day.")
+
+		e := s.persist(provider.Name(), eventType, eventGUID, payload, nil)
+		// Converting events may take requests to the provider, which must
+		// not delay the response to the webhook.
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.dispatchEvent(e)
+		}()
+	}
+}
+
+func (s *Server) demuxSCMEvent(d *delivery, provider scm.Provider, eventType, eventGUID string, payload []byte) error {
+	l := logrus.WithFields(
+		logrus.Fields{
+			eventTypeField:   eventType,
+			github.EventGUID: eventGUID,
+			"provider":       provider.Name(),
+		},
+	)
+	// We don't want to fail the webhook due to a metrics error.
+	if counter, err := s.Metrics.WebhookCounter.GetMetricWithLabelValues(eventType); err != nil {
+		l.WithError(err).Warn("Failed to get metric for eventType " + eventType)
+	} else {
+		counter.Inc()
+	}
+	events, err := provider.ParseWebhook(eventType, eventGUID, payload)
+	if err != nil {
+		return err
+	}
+	for _, pr := range events.PullRequests {
+		if !s.scmRepoEnabled(l, provider, pr.Repo.Owner.Login, pr.Repo.Name) {
+			continue
+		}
+		s.Firehose.Publish(firehose.PullRequestEvent(pr))
+		d.add()
+		go s.handlePullRequestEvent(l.WithField(eventTypeField, "pull_request"), d, pr)
+	}
+	for _, re := range events.Reviews {
+		if !s.scmRepoEnabled(l, provider, re.Repo.Owner.Login, re.Repo.Name) {
+			continue
+		}
+		s.Firehose.Publish(firehose.ReviewEvent(re))
+		d.add()
+		go s.handleReviewEvent(l.WithField(eventTypeField, "pull_request_review"), d, re)
+	}
+	for _, ic := range events.IssueComments {
+		if !s.scmRepoEnabled(l, provider, ic.Repo.Owner.Login, ic.Repo.Name) {
+			continue
+		}
+		s.Firehose.Publish(firehose.IssueCommentEvent(ic))
+		d.add()
+		go s.handleIssueCommentEvent(l.WithField(eventTypeField, "issue_comment"), d, ic)
+	}
+	for _, pe := range events.Pushes {
+		if !s.scmRepoEnabled(l, provider, pe.Repo.Owner.Login, pe.Repo.Name) {
+			continue
+		}
+		s.Firehose.Publish(firehose.PushEvent(pe))
+		d.add()
+		go s.handlePushEvent(l.WithField(eventTypeField, "push"), d, pe)
+	}
+	return nil
+}
+
+// scmRepoEnabled returns whether events of the repo should be handled. The
+// org must be configured to be on the provider, as plugins would otherwise
+// talk to GitHub about it.
+func (s *Server) scmRepoEnabled(l *logrus.Entry, provider scm.Provider, org, repo string) bool {
+	if s.ConfigAgent.Config().SCMProviderOf(org) != provider.Name() {
+		l.WithField(github.OrgLogField, org).Warn("Ignoring event of an org that is not configured to be on the provider.")
+		return false
+	}
+	return s.RepoEnabled(org, repo)
+}
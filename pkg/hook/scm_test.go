@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/bugzilla"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/repoowners"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// fakeProvider accepts webhooks with the token "secret" and converts their
+// payload, the org, into a comment on a pull request of the org.
+type fakeProvider struct {
+	scm.Client
+}
+
+func (f *fakeProvider) Name() string {
+	return "fake-scm"
+}
+
+func (f *fakeProvider) ValidateWebhook(w http.ResponseWriter, r *http.Request) (string, string, []byte, bool, int) {
+	if r.Header.Get("X-Token") != "secret" {
+		http.Error(w, "403 Forbidden", http.StatusForbidden)
+		return "", "", nil, false, http.StatusForbidden
+	}
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+		return "", "", nil, false, http.StatusInternalServerError
+	}
+	return "comment", "guid", payload, true, http.StatusOK
+}
+
+func (f *fakeProvider) ParseWebhook(eventType, eventGUID string, payload []byte) (*scm.Events, error) {
+	repo := github.Repo{Owner: github.User{Login: string(payload)}, Name: "repo", FullName: string(payload) + "/repo"}
+	return &scm.Events{IssueComments: []github.IssueCommentEvent{{
+		Action:  github.IssueCommentActionCreated,
+		Issue:   github.Issue{Number: 3, State: github.PullRequestStateOpen, PullRequest: &struct{}{}},
+		Comment: github.IssueComment{ID: 100, Body: "/lgtm"},
+		Repo:    repo,
+		GUID:    eventGUID,
+	}}}, nil
+}
+
+// TestServeSCM sends a webhook of an SCM provider to a hook.Server and
+// ensures that a fake plugin is called with the converted comment.
+func TestServeSCM(t *testing.T) {
+	called := make(chan github.GenericCommentEvent, 1)
+	plugins.RegisterGenericCommentHandler(
+		"scm-comment",
+		func(pc plugins.Agent, e github.GenericCommentEvent) error {
+			called <- e
+			return nil
+		},
+		nil,
+	)
+	pa := &plugins.ConfigAgent{}
+	pa.Set(&plugins.Configuration{Plugins: plugins.Plugins{
+		"PRJ": {Plugins: []string{"scm-comment"}},
+		"foo": {Plugins: []string{"scm-comment"}},
+	}})
+	ca := &config.Agent{}
+	ca.Set(&config.Config{ProwConfig: config.ProwConfig{SCM: map[string]config.SCMProvider{"fake-scm": {Orgs: []string{"PRJ"}}}}})
+	provider := &fakeProvider{}
+	clientAgent := &plugins.ClientAgent{
+		GitHubClient:   github.NewFakeClient(),
+		OwnersClient:   repoowners.NewClient(nil, nil, func(org, repo string) bool { return false }, func(org, repo string) bool { return false }, func() *config.OwnersDirDenylist { return &config.OwnersDirDenylist{} }, ownersconfig.FakeResolver),
+		JiraClient:     &fakejira.FakeClient{},
+		BugzillaClient: &bugzilla.Fake{},
+		SCMProviders:   map[string]scm.Provider{provider.Name(): provider},
+	}
+
+	testcases := []struct {
+		name    string
+		org     string
+		token   string
+		code    int
+		handled bool
+	}{
+		{
+			name:    "comment of an org of the provider is handled",
+			org:     "PRJ",
+			token:   "secret",
+			code:    http.StatusOK,
+			handled: true,
+		},
+		{
+			name:  "comment of a GitHub org is ignored",
+			org:   "foo",
+			token: "secret",
+			code:  http.StatusOK,
+		},
+		{
+			name:  "invalid token is rejected",
+			org:   "PRJ",
+			token: "wrong",
+			code:  http.StatusForbidden,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &Server{
+				ClientAgent: clientAgent,
+				Plugins:     pa,
+				ConfigAgent: ca,
+				Metrics:     githubeventserver.NewMetrics(),
+				RepoEnabled: func(org, repo string) bool { return true },
+			}
+			req := httptest.NewRequest(http.MethodPost, "/hook/fake-scm", strings.NewReader(tc.org))
+			req.Header.Set("X-Token", tc.token)
+			w := httptest.NewRecorder()
+			server.ServeSCM(provider)(w, req)
+			if w.Code != tc.code {
+				t.Fatalf("expected status code %d, got %d", tc.code, w.Code)
+			}
+			server.GracefulShutdown()
+
+			select {
+			case e := <-called:
+				if !tc.handled {
+					t.Fatalf("expected no event, got %+v", e)
+				}
+				if e.Repo.Owner.Login != tc.org || e.Body != "/lgtm" || e.GUID != "guid" {
+					t.Errorf("unexpected event %+v", e)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tc.handled {
+					t.Error("plugin not called")
+				}
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,12 +31,20 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/externalplugin"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/hook/eventqueue"
+	"sigs.k8s.io/prow/pkg/hook/firehose"
+	"sigs.k8s.io/prow/pkg/hook/mirror"
 	_ "sigs.k8s.io/prow/pkg/hook/plugin-imports"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
+// grpcDispatchTimeout bounds how long hook waits for an external plugin to
+// handle an event dispatched over gRPC.
+const grpcDispatchTimeout = 5 * time.Minute
+
 // Server implements http.Handler. It validates incoming GitHub webhooks and
 // then dispatches them to the appropriate plugins.
 type Server struct {
@@ -45,36 +54,67 @@ type Server struct {
 	TokenGenerator func() []byte
 	Metrics        *githubeventserver.Metrics
 	RepoEnabled    func(org, repo string) bool
+	// Firehose receives sanitized events of enabled repos for external
+	// consumers, it may be nil.
+	Firehose *firehose.Broker
+	// EventQueue persists events until plugins handled them, it may be nil.
+	EventQueue eventqueue.Store
+	// DeadLetterTokenGenerator returns the bearer token of admins, it is only
+	// needed to serve ServeDeadLetters.
+	DeadLetterTokenGenerator func() []byte
+	// Mirror sends a sample of the webhooks to a staging hook, it may be nil.
+	Mirror *mirror.Mirror
+	// AcceptMirrored allows handling webhooks mirrored by another hook. It
+	// should only be set in dry-run, so that events are not acted on twice.
+	AcceptMirrored bool
 
 	// c is an http client used for dispatching events
 	// to external plugin services.
 	c http.Client
 	// Tracks running handlers for graceful shutdown
 	wg sync.WaitGroup
+	// limiter bounds the concurrent handlers of plugins.
+	limiter pluginLimiter
+	// grpcClients are the clients of external plugins dispatched to over
+	// gRPC, by their endpoint.
+	grpcClients     map[string]*externalplugin.Client
+	grpcClientsLock sync.Mutex
 }
 
 // ServeHTTP validates an incoming webhook and puts it into the event channel.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	eventType, eventGUID, payload, ok, resp := github.ValidateWebhook(w, r, s.TokenGenerator)
-	if counter, err := s.Metrics.ResponseCounter.GetMetricWithLabelValues(strconv.Itoa(resp)); err != nil {
-		logrus.WithFields(logrus.Fields{
-			"status-code": resp,
-		}).WithError(err).Error("Failed to get metric for reporting webhook status code")
-	} else {
-		counter.Inc()
+	mirrored := r.Header.Get(mirror.Header) != ""
+	if mirrored && !s.AcceptMirrored {
+		s.countResponse(http.StatusForbidden)
+		http.Error(w, "403 Forbidden: Mirrored webhooks are only accepted in dry-run", http.StatusForbidden)
+		return
 	}
+	eventType, eventGUID, payload, ok, resp := github.ValidateWebhook(w, r, s.TokenGenerator)
+	s.countResponse(resp)
 
 	if !ok {
 		return
 	}
 	fmt.Fprint(w, "Event received. Have a nice day.")
 
-	if err := s.demuxEvent(eventType, eventGUID, payload, r.Header); err != nil {
-		logrus.WithError(err).Error("Error parsing event.")
+	if !mirrored {
+		s.Mirror.Send(eventType, eventGUID, payload)
 	}
+	s.dispatchEvent(s.persist("", eventType, eventGUID, payload, r.Header))
 }
 
-func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.Header) error {
+// countResponse reports the status code hook responded to a webhook with.
+func (s *Server) countResponse(resp int) {
+	if counter, err := s.Metrics.ResponseCounter.GetMetricWithLabelValues(strconv.Itoa(resp)); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"status-code": resp,
+		}).WithError(err).Error("Failed to get metric for reporting webhook status code")
+	} else {
+		counter.Inc()
+	}
+}
+
+func (s *Server) demuxEvent(d *delivery, eventType, eventGUID string, payload []byte, h http.Header) error {
 	l := logrus.WithFields(
 		logrus.Fields{
 			eventTypeField:   eventType,
@@ -97,8 +137,9 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		i.GUID = eventGUID
 		srcRepo = i.Repo.FullName
 		if s.RepoEnabled(i.Repo.Owner.Login, i.Repo.Name) {
-			s.wg.Add(1)
-			go s.handleIssueEvent(l, i)
+			s.Firehose.Publish(firehose.IssueEvent(i))
+			d.add()
+			go s.handleIssueEvent(l, d, i)
 		}
 	case "issue_comment":
 		var ic github.IssueCommentEvent
@@ -108,8 +149,9 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		ic.GUID = eventGUID
 		srcRepo = ic.Repo.FullName
 		if s.RepoEnabled(ic.Repo.Owner.Login, ic.Repo.Name) {
-			s.wg.Add(1)
-			go s.handleIssueCommentEvent(l, ic)
+			s.Firehose.Publish(firehose.IssueCommentEvent(ic))
+			d.add()
+			go s.handleIssueCommentEvent(l, d, ic)
 		}
 	case "pull_request":
 		var pr github.PullRequestEvent
@@ -119,8 +161,9 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		pr.GUID = eventGUID
 		srcRepo = pr.Repo.FullName
 		if s.RepoEnabled(pr.Repo.Owner.Login, pr.Repo.Name) {
-			s.wg.Add(1)
-			go s.handlePullRequestEvent(l, pr)
+			s.Firehose.Publish(firehose.PullRequestEvent(pr))
+			d.add()
+			go s.handlePullRequestEvent(l, d, pr)
 		}
 	case "pull_request_review":
 		var re github.ReviewEvent
@@ -130,8 +173,9 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		re.GUID = eventGUID
 		srcRepo = re.Repo.FullName
 		if s.RepoEnabled(re.Repo.Owner.Login, re.Repo.Name) {
-			s.wg.Add(1)
-			go s.handleReviewEvent(l, re)
+			s.Firehose.Publish(firehose.ReviewEvent(re))
+			d.add()
+			go s.handleReviewEvent(l, d, re)
 		}
 	case "pull_request_review_comment":
 		var rce github.ReviewCommentEvent
@@ -141,8 +185,8 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		rce.GUID = eventGUID
 		srcRepo = rce.Repo.FullName
 		if s.RepoEnabled(rce.Repo.Owner.Login, rce.Repo.Name) {
-			s.wg.Add(1)
-			go s.handleReviewCommentEvent(l, rce)
+			d.add()
+			go s.handleReviewCommentEvent(l, d, rce)
 		}
 	case "push":
 		var pe github.PushEvent
@@ -152,8 +196,9 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		pe.GUID = eventGUID
 		srcRepo = pe.Repo.FullName
 		if s.RepoEnabled(pe.Repo.Owner.Login, pe.Repo.Name) {
-			s.wg.Add(1)
-			go s.handlePushEvent(l, pe)
+			s.Firehose.Publish(firehose.PushEvent(pe))
+			d.add()
+			go s.handlePushEvent(l, d, pe)
 		}
 	case "status":
 		var se github.StatusEvent
@@ -163,8 +208,9 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 		se.GUID = eventGUID
 		srcRepo = se.Repo.FullName
 		if s.RepoEnabled(se.Repo.Owner.Login, se.Repo.Name) {
-			s.wg.Add(1)
-			go s.handleStatusEvent(l, se)
+			s.Firehose.Publish(firehose.StatusEvent(se))
+			d.add()
+			go s.handleStatusEvent(l, d, se)
 		}
 	default:
 		var ge github.GenericEvent
@@ -176,8 +222,8 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 	}
 	// Demux events only to external plugins that require this event.
 	if external := s.needDemux(eventType, srcRepo); len(external) > 0 {
-		s.wg.Add(1)
-		go s.demuxExternal(l, external, payload, h)
+		d.add()
+		go s.demuxExternal(l, d, external, eventType, eventGUID, payload, h)
 	}
 	return nil
 }
@@ -204,6 +250,9 @@ func (s *Server) needDemux(eventType, orgRepo string) []plugins.ExternalPlugin {
 
 		// Make sure the events match
 		for _, p := range plugins {
+			if !s.dispatches(p.Name, eventType) {
+				continue
+			}
 			if len(p.Events) == 0 {
 				matching = append(matching, p)
 			} else {
@@ -221,15 +270,37 @@ func (s *Server) needDemux(eventType, orgRepo string) []plugins.ExternalPlugin {
 }
 
 // demuxExternal dispatches the provided payload to the external plugins.
-func (s *Server) demuxExternal(l *logrus.Entry, externalPlugins []plugins.ExternalPlugin, payload []byte, h http.Header) {
-	defer s.wg.Done()
+func (s *Server) demuxExternal(l *logrus.Entry, d *delivery, externalPlugins []plugins.ExternalPlugin, eventType, eventGUID string, payload []byte, h http.Header) {
+	defer d.done()
 	h.Set("User-Agent", "ProwHook")
+	// The event is decoded once for all plugins that are dispatched to over gRPC.
+	decode := sync.OnceValues(func() (*externalplugin.Event, error) {
+		return externalplugin.DecodeEvent(eventType, eventGUID, payload)
+	})
 	for _, p := range externalPlugins {
-		s.wg.Add(1)
+		if !d.wants(p.Name) {
+			continue
+		}
+		d.add()
 		go func(p plugins.ExternalPlugin) {
-			defer s.wg.Done()
-			if err := s.dispatch(p.Endpoint, payload, h); err != nil {
+			defer d.done()
+			release := sync.OnceFunc(s.acquire(p.Name))
+			defer release()
+			dispatch := func() error { return s.dispatch(p.Endpoint, payload, h) }
+			if p.GRPCEndpoint != "" {
+				dispatch = func() error {
+					e, err := decode()
+					if err != nil {
+						return err
+					}
+					// Plugins handle events before responding, so the slot
+					// is released once the event was sent, like for webhooks.
+					return s.dispatchGRPC(p.GRPCEndpoint, e, release)
+				}
+			}
+			if err := dispatch(); err != nil {
 				l.WithError(err).WithField("external-plugin", p.Name).Error("Error dispatching event to external plugin.")
+				d.fail(p.Name, err)
 			} else {
 				l.WithField("external-plugin", p.Name).Info("Dispatched event to external plugin")
 			}
@@ -260,10 +331,41 @@ func (s *Server) dispatch(endpoint string, payload []byte, h http.Header) error
 	return nil
 }
 
+// dispatchGRPC dispatches the event to the gRPC server of an external plugin
+// and waits until the plugin handled it. sent is called once the event was
+// sent.
+func (s *Server) dispatchGRPC(endpoint string, e *externalplugin.Event, sent func()) error {
+	s.grpcClientsLock.Lock()
+	if s.grpcClients == nil {
+		s.grpcClients = map[string]*externalplugin.Client{}
+	}
+	client, ok := s.grpcClients[endpoint]
+	if !ok {
+		var err error
+		if client, err = externalplugin.NewClient(endpoint); err != nil {
+			s.grpcClientsLock.Unlock()
+			return err
+		}
+		s.grpcClients[endpoint] = client
+	}
+	s.grpcClientsLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcDispatchTimeout)
+	defer cancel()
+	return client.Dispatch(ctx, e, s.TokenGenerator, sent)
+}
+
 // GracefulShutdown implements a graceful shutdown protocol. It handles all requests sent before
 // receiving the shutdown signal.
 func (s *Server) GracefulShutdown() {
 	s.wg.Wait() // Handle remaining requests
+	s.grpcClientsLock.Lock()
+	defer s.grpcClientsLock.Unlock()
+	for endpoint, client := range s.grpcClients {
+		if err := client.Close(); err != nil {
+			logrus.WithError(err).WithField("endpoint", endpoint).Warn("Failed to close gRPC client of external plugin.")
+		}
+	}
 }
 
 func (s *Server) do(req *http.Request) (*http.Response, error) {
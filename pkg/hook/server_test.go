@@ -19,6 +19,7 @@ package hook
 import (
 	"bytes"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -28,8 +29,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
 
+	"sigs.k8s.io/prow/pkg/externalplugin"
+	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/hook/eventqueue"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
@@ -159,6 +164,20 @@ foo/bar:
 			Body: body,
 			Code: http.StatusOK,
 		},
+		{
+			name: "Mirrored outside of dry-run",
+
+			Method: http.MethodPost,
+			Header: map[string]string{
+				"X-GitHub-Event":    "ping",
+				"X-GitHub-Delivery": "I am unique",
+				"X-Hub-Signature":   hmac,
+				"X-Prow-Mirrored":   "true",
+				"content-type":      "application/json",
+			},
+			Body: body,
+			Code: http.StatusForbidden,
+		},
 		{
 			name: "Good, again",
 
@@ -197,6 +216,7 @@ func TestNeedDemux(t *testing.T) {
 		srcRepo     string
 		repoEnabled func(org, repo string) bool
 		plugins     map[string][]plugins.ExternalPlugin
+		dispatch    map[string]plugins.PluginDispatch
 
 		expected []plugins.ExternalPlugin
 	}{
@@ -209,6 +229,32 @@ func TestNeedDemux(t *testing.T) {
 
 			expected: nil,
 		},
+		{
+			name: "plugin dispatch filters events",
+
+			eventType: "issue_comment",
+			srcRepo:   "kubernetes/test-infra",
+			plugins: map[string][]plugins.ExternalPlugin{
+				"kubernetes": {
+					{
+						Name: "coffee",
+					},
+					{
+						Name: "water",
+					},
+				},
+			},
+			dispatch: map[string]plugins.PluginDispatch{
+				"coffee": {Events: []string{"pull_request"}},
+				"water":  {Events: []string{"issue_comment"}},
+			},
+
+			expected: []plugins.ExternalPlugin{
+				{
+					Name: "water",
+				},
+			},
+		},
 		{
 			name: "we have variety",
 
@@ -359,6 +405,7 @@ func TestNeedDemux(t *testing.T) {
 			pa := &plugins.ConfigAgent{}
 			pa.Set(&plugins.Configuration{
 				ExternalPlugins: test.plugins,
+				PluginDispatch:  test.dispatch,
 			})
 
 			if test.repoEnabled == nil {
@@ -578,3 +625,47 @@ foo/bar:
 		})
 	}
 }
+
+func TestDemuxExternalGRPC(t *testing.T) {
+	var lock sync.Mutex
+	var handled []string
+	p := &externalplugin.Plugin{
+		IssueCommentHandler: func(_ *logrus.Entry, ic github.IssueCommentEvent) error {
+			lock.Lock()
+			defer lock.Unlock()
+			handled = append(handled, ic.Comment.Body)
+			return nil
+		},
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := externalplugin.NewGRPCServer(externalplugin.NewServer(p, func() []byte { return []byte("abc") }))
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	pa := &plugins.ConfigAgent{}
+	pa.Set(&plugins.Configuration{
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"kubernetes": {{Name: "hold", GRPCEndpoint: listener.Addr().String()}},
+		},
+	})
+	s := &Server{
+		Metrics:        githubeventserver.NewMetrics(),
+		Plugins:        pa,
+		TokenGenerator: func() []byte { return []byte("abc") },
+		RepoEnabled:    func(org, repo string) bool { return true },
+	}
+	payload := []byte(`{"action": "created", "repository": {"full_name": "kubernetes/test-infra"}, "comment": {"body": "/hold"}}`)
+	if err := s.demuxEvent(s.newDelivery(eventqueue.Event{}), "issue_comment", "guid", payload, http.Header{}); err != nil {
+		t.Fatalf("failed to demux event: %v", err)
+	}
+	s.GracefulShutdown()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if diff := cmp.Diff([]string{"/hold"}, handled); diff != "" {
+		t.Errorf("handled comments differ from expected (-want +got):\n%s", diff)
+	}
+}
@@ -33,6 +33,10 @@ import (
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
 )
 
+// ErrCloneFailed is returned when any of the refs could not be cloned. It is
+// logged, so plank finds it in the termination message of the container.
+var ErrCloneFailed = errors.New("cloning the appropriate refs failed")
+
 // Run will start the initupload job to upload the artifacts, logs and clone status.
 func (o Options) Run() error {
 	spec, err := downwardapi.ResolveSpecFromEnv()
@@ -65,7 +69,7 @@ func (o Options) Run() error {
 	}
 
 	if failed {
-		return errors.New("cloning the appropriate refs failed")
+		return ErrCloneFailed
 	}
 
 	return nil
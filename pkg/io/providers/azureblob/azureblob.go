@@ -0,0 +1,475 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package azureblob provides a gocloud blob driver for Azure Blob Storage,
+// registered for azblob:// bucket URLs.
+//
+// Access is configured through the environment:
+//   - AZURE_STORAGE_ACCOUNT is the name of the storage account.
+//   - AZURE_STORAGE_KEY is the shared key of the account. It is required
+//     to sign URLs and to copy objects.
+//   - AZURE_STORAGE_SAS_TOKEN is a shared access signature to use instead
+//     of the shared key.
+//   - AZURE_STORAGE_ENDPOINT optionally replaces the default endpoint of
+//     https://<account>.blob.core.windows.net, e.g. for Azurite.
+//
+// Without a key or SAS token, containers are accessed anonymously.
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	azblob "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/gcerrors"
+)
+
+// Scheme is the URL scheme azureblob registers its URLOpener under.
+const Scheme = "azblob"
+
+const (
+	accountEnv  = "AZURE_STORAGE_ACCOUNT"
+	keyEnv      = "AZURE_STORAGE_KEY"
+	sasTokenEnv = "AZURE_STORAGE_SAS_TOKEN"
+	endpointEnv = "AZURE_STORAGE_ENDPOINT"
+
+	defaultPageSize  = 1000
+	copyPollInterval = time.Second
+)
+
+func init() {
+	blob.DefaultURLMux().RegisterBucket(Scheme, URLOpener{})
+}
+
+// URLOpener opens azblob://<container> URLs.
+type URLOpener struct{}
+
+// OpenBucketURL opens the container named by the URL's host.
+func (URLOpener) OpenBucketURL(_ context.Context, u *url.URL) (*blob.Bucket, error) {
+	b, err := openBucket(u.Host, os.Getenv)
+	if err != nil {
+		return nil, fmt.Errorf("open bucket %v: %w", u, err)
+	}
+	return blob.NewBucket(b), nil
+}
+
+type bucket struct {
+	container *container.Client
+}
+
+func openBucket(containerName string, getenv func(string) string) (*bucket, error) {
+	if containerName == "" {
+		return nil, errors.New("no container name given")
+	}
+	account := getenv(accountEnv)
+	endpoint := getenv(endpointEnv)
+	if endpoint == "" {
+		if account == "" {
+			return nil, fmt.Errorf("%s or %s must be set", accountEnv, endpointEnv)
+		}
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+	containerURL, err := url.JoinPath(endpoint, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	var client *container.Client
+	switch key, token := getenv(keyEnv), getenv(sasTokenEnv); {
+	case key != "":
+		if account == "" {
+			return nil, fmt.Errorf("%s must be set to use %s", accountEnv, keyEnv)
+		}
+		cred, err := container.NewSharedKeyCredential(account, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shared key: %w", err)
+		}
+		client, err = container.NewClientWithSharedKeyCredential(containerURL, cred, nil)
+		if err != nil {
+			return nil, err
+		}
+	case token != "":
+		client, err = container.NewClientWithNoCredential(containerURL+"?"+strings.TrimPrefix(token, "?"), nil)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		client, err = container.NewClientWithNoCredential(containerURL, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &bucket{container: client}, nil
+}
+
+func (b *bucket) ErrorCode(err error) gcerrors.ErrorCode {
+	switch {
+	case bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound, bloberror.ResourceNotFound):
+		return gcerrors.NotFound
+	case bloberror.HasCode(err, bloberror.AuthenticationFailed, bloberror.AuthorizationFailure):
+		return gcerrors.PermissionDenied
+	}
+	// responses to HEAD requests carry no error code
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.StatusCode {
+		case http.StatusNotFound:
+			return gcerrors.NotFound
+		case http.StatusForbidden:
+			return gcerrors.PermissionDenied
+		}
+	}
+	return gcerrors.Unknown
+}
+
+func (b *bucket) As(i interface{}) bool {
+	p, ok := i.(**container.Client)
+	if ok {
+		*p = b.container
+	}
+	return ok
+}
+
+func (b *bucket) ErrorAs(err error, i interface{}) bool {
+	if p, ok := i.(**azcore.ResponseError); ok {
+		return errors.As(err, p)
+	}
+	return false
+}
+
+func (b *bucket) Attributes(ctx context.Context, key string) (*driver.Attributes, error) {
+	props, err := b.container.NewBlobClient(key).GetProperties(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &driver.Attributes{
+		CacheControl:       deref(props.CacheControl),
+		ContentDisposition: deref(props.ContentDisposition),
+		ContentEncoding:    deref(props.ContentEncoding),
+		ContentLanguage:    deref(props.ContentLanguage),
+		ContentType:        deref(props.ContentType),
+		Metadata:           fromMetadata(props.Metadata),
+		ModTime:            deref(props.LastModified),
+		Size:               deref(props.ContentLength),
+		MD5:                props.ContentMD5,
+		AsFunc: func(i interface{}) bool {
+			p, ok := i.(*azblob.GetPropertiesResponse)
+			if ok {
+				*p = props
+			}
+			return ok
+		},
+	}, nil
+}
+
+func (b *bucket) ListPaged(ctx context.Context, opts *driver.ListOptions) (*driver.ListPage, error) {
+	pageSize := int32(defaultPageSize)
+	if opts.PageSize > 0 {
+		pageSize = int32(opts.PageSize)
+	}
+	var prefix, marker *string
+	if opts.Prefix != "" {
+		prefix = &opts.Prefix
+	}
+	if len(opts.PageToken) > 0 {
+		token := string(opts.PageToken)
+		marker = &token
+	}
+
+	page := &driver.ListPage{}
+	var items []*container.BlobItem
+	var nextMarker *string
+	if opts.Delimiter == "" {
+		resp, err := b.container.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+			Prefix:     prefix,
+			Marker:     marker,
+			MaxResults: &pageSize,
+		}).NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items, nextMarker = resp.Segment.BlobItems, resp.NextMarker
+	} else {
+		resp, err := b.container.NewListBlobsHierarchyPager(opts.Delimiter, &container.ListBlobsHierarchyOptions{
+			Prefix:     prefix,
+			Marker:     marker,
+			MaxResults: &pageSize,
+		}).NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items, nextMarker = resp.Segment.BlobItems, resp.NextMarker
+		for _, p := range resp.Segment.BlobPrefixes {
+			page.Objects = append(page.Objects, &driver.ListObject{Key: deref(p.Name), IsDir: true})
+		}
+	}
+	for _, item := range items {
+		object := &driver.ListObject{Key: deref(item.Name)}
+		if item.Properties != nil {
+			object.ModTime = deref(item.Properties.LastModified)
+			object.Size = deref(item.Properties.ContentLength)
+			object.MD5 = item.Properties.ContentMD5
+		}
+		page.Objects = append(page.Objects, object)
+	}
+	sort.Slice(page.Objects, func(i, j int) bool {
+		return page.Objects[i].Key < page.Objects[j].Key
+	})
+	if nextMarker != nil && *nextMarker != "" {
+		page.NextPageToken = []byte(*nextMarker)
+	}
+	return page, nil
+}
+
+type reader struct {
+	body  io.ReadCloser
+	attrs driver.ReaderAttributes
+	raw   *azblob.DownloadStreamResponse
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	return r.body.Read(p)
+}
+
+func (r *reader) Close() error {
+	return r.body.Close()
+}
+
+func (r *reader) Attributes() *driver.ReaderAttributes {
+	return &r.attrs
+}
+
+func (r *reader) As(i interface{}) bool {
+	p, ok := i.(*azblob.DownloadStreamResponse)
+	if ok && r.raw != nil {
+		*p = *r.raw
+	}
+	return ok && r.raw != nil
+}
+
+func (b *bucket) NewRangeReader(ctx context.Context, key string, offset, length int64, _ *driver.ReaderOptions) (driver.Reader, error) {
+	client := b.container.NewBlobClient(key)
+	if length == 0 {
+		// a zero count downloads the whole blob, so only fetch its attributes
+		props, err := client.GetProperties(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &reader{
+			body: io.NopCloser(strings.NewReader("")),
+			attrs: driver.ReaderAttributes{
+				ContentType: deref(props.ContentType),
+				ModTime:     deref(props.LastModified),
+				Size:        deref(props.ContentLength),
+			},
+		}, nil
+	}
+
+	count := length
+	if count < 0 {
+		count = 0
+	}
+	resp, err := client.DownloadStream(ctx, &azblob.DownloadStreamOptions{
+		Range: azblob.HTTPRange{Offset: offset, Count: count},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reader{
+		body: resp.Body,
+		attrs: driver.ReaderAttributes{
+			ContentType: deref(resp.ContentType),
+			ModTime:     deref(resp.LastModified),
+			Size:        objectSize(resp.ContentRange, resp.ContentLength),
+		},
+		raw: &resp,
+	}, nil
+}
+
+// objectSize returns the size of the whole object from the Content-Range
+// of a ranged download, falling back to the Content-Length.
+func objectSize(contentRange *string, contentLength *int64) int64 {
+	if contentRange != nil {
+		if i := strings.LastIndex(*contentRange, "/"); i >= 0 {
+			if size, err := strconv.ParseInt((*contentRange)[i+1:], 10, 64); err == nil {
+				return size
+			}
+		}
+	}
+	return deref(contentLength)
+}
+
+type writer struct {
+	pw    *io.PipeWriter
+	donec chan struct{}
+	err   error
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+func (w *writer) Close() error {
+	if err := w.pw.Close(); err != nil {
+		return err
+	}
+	<-w.donec
+	return w.err
+}
+
+func (b *bucket) NewTypedWriter(ctx context.Context, key, contentType string, opts *driver.WriterOptions) (driver.Writer, error) {
+	uploadOpts := &blockblob.UploadStreamOptions{
+		HTTPHeaders: &azblob.HTTPHeaders{
+			BlobCacheControl:       nonEmpty(opts.CacheControl),
+			BlobContentDisposition: nonEmpty(opts.ContentDisposition),
+			BlobContentEncoding:    nonEmpty(opts.ContentEncoding),
+			BlobContentLanguage:    nonEmpty(opts.ContentLanguage),
+			BlobContentMD5:         opts.ContentMD5,
+			BlobContentType:        nonEmpty(contentType),
+		},
+		Metadata: toMetadata(opts.Metadata),
+	}
+	if opts.BufferSize > 0 {
+		uploadOpts.BlockSize = int64(opts.BufferSize)
+	}
+	if opts.BeforeWrite != nil {
+		asFunc := func(i interface{}) bool {
+			p, ok := i.(**blockblob.UploadStreamOptions)
+			if ok {
+				*p = uploadOpts
+			}
+			return ok
+		}
+		if err := opts.BeforeWrite(asFunc); err != nil {
+			return nil, err
+		}
+	}
+
+	pr, pw := io.Pipe()
+	w := &writer{pw: pw, donec: make(chan struct{})}
+	go func() {
+		defer close(w.donec)
+		_, w.err = b.container.NewBlockBlobClient(key).UploadStream(ctx, pr, uploadOpts)
+		pr.CloseWithError(w.err)
+	}()
+	return w, nil
+}
+
+func (b *bucket) Copy(ctx context.Context, dstKey, srcKey string, _ *driver.CopyOptions) error {
+	dst := b.container.NewBlobClient(dstKey)
+	resp, err := dst.StartCopyFromURL(ctx, b.container.NewBlobClient(srcKey).URL(), nil)
+	if err != nil {
+		return err
+	}
+	status, description := resp.CopyStatus, (*string)(nil)
+	for status != nil && *status == azblob.CopyStatusTypePending {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(copyPollInterval):
+		}
+		props, err := dst.GetProperties(ctx, nil)
+		if err != nil {
+			return err
+		}
+		status, description = props.CopyStatus, props.CopyStatusDescription
+	}
+	if status != nil && *status != azblob.CopyStatusTypeSuccess {
+		return fmt.Errorf("copy of %s to %s did not succeed: %s: %s", srcKey, dstKey, *status, deref(description))
+	}
+	return nil
+}
+
+func (b *bucket) Delete(ctx context.Context, key string) error {
+	_, err := b.container.NewBlobClient(key).Delete(ctx, nil)
+	return err
+}
+
+func (b *bucket) SignedURL(_ context.Context, key string, opts *driver.SignedURLOptions) (string, error) {
+	var permissions sas.BlobPermissions
+	switch opts.Method {
+	case http.MethodGet:
+		permissions.Read = true
+	case http.MethodPut:
+		permissions.Create = true
+		permissions.Write = true
+	case http.MethodDelete:
+		permissions.Delete = true
+	default:
+		return "", fmt.Errorf("unsupported method %q for signed URL", opts.Method)
+	}
+	return b.container.NewBlobClient(key).GetSASURL(permissions, time.Now().Add(opts.Expiry), nil)
+}
+
+func (b *bucket) Close() error {
+	return nil
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func toMetadata(metadata map[string]string) map[string]*string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	out := make(map[string]*string, len(metadata))
+	for k, v := range metadata {
+		v := v
+		out[k] = &v
+	}
+	return out
+}
+
+// fromMetadata lowercases the keys as they are returned canonicalized
+// as HTTP header names.
+func fromMetadata(metadata map[string]*string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		out[strings.ToLower(k)] = deref(v)
+	}
+	return out
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureblob
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gocloud.dev/gcerrors"
+	"k8s.io/utils/ptr"
+)
+
+func TestOpenBucket(t *testing.T) {
+	testCases := []struct {
+		name        string
+		container   string
+		env         map[string]string
+		expectedURL string
+		expectedErr bool
+	}{
+		{
+			name:        "shared key",
+			container:   "artifacts",
+			env:         map[string]string{accountEnv: "prow", keyEnv: "a2V5"},
+			expectedURL: "https://prow.blob.core.windows.net/artifacts",
+		},
+		{
+			name:        "sas token",
+			container:   "artifacts",
+			env:         map[string]string{accountEnv: "prow", sasTokenEnv: "?sv=2022-11-02&sig=abc"},
+			expectedURL: "https://prow.blob.core.windows.net/artifacts?sv=2022-11-02&sig=abc",
+		},
+		{
+			name:        "custom endpoint",
+			container:   "artifacts",
+			env:         map[string]string{accountEnv: "devstoreaccount1", keyEnv: "a2V5", endpointEnv: "http://azurite:10000/devstoreaccount1"},
+			expectedURL: "http://azurite:10000/devstoreaccount1/artifacts",
+		},
+		{
+			name:        "anonymous",
+			container:   "artifacts",
+			env:         map[string]string{accountEnv: "prow"},
+			expectedURL: "https://prow.blob.core.windows.net/artifacts",
+		},
+		{
+			name:        "no account",
+			container:   "artifacts",
+			env:         map[string]string{keyEnv: "a2V5"},
+			expectedErr: true,
+		},
+		{
+			name:        "shared key without account",
+			container:   "artifacts",
+			env:         map[string]string{keyEnv: "a2V5", endpointEnv: "http://azurite:10000/devstoreaccount1"},
+			expectedErr: true,
+		},
+		{
+			name:        "no container",
+			env:         map[string]string{accountEnv: "prow"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := openBucket(tc.container, func(key string) string { return tc.env[key] })
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expectedURL, b.container.URL()); diff != "" {
+				t.Errorf("container URL differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectSize(t *testing.T) {
+	testCases := []struct {
+		name          string
+		contentRange  *string
+		contentLength *int64
+		expected      int64
+	}{
+		{
+			name:          "ranged download",
+			contentRange:  ptr.To("bytes 10-19/100"),
+			contentLength: ptr.To(int64(10)),
+			expected:      100,
+		},
+		{
+			name:          "full download",
+			contentLength: ptr.To(int64(100)),
+			expected:      100,
+		},
+		{
+			name:          "unparsable range",
+			contentRange:  ptr.To("bytes 10-19/*"),
+			contentLength: ptr.To(int64(10)),
+			expected:      10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := objectSize(tc.contentRange, tc.contentLength); got != tc.expected {
+				t.Errorf("expected size %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifacts/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/artifacts/private":
+			w.Header().Set("x-ms-error-code", "AuthorizationFailure")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	b, err := openBucket("artifacts", func(key string) string {
+		return map[string]string{endpointEnv: server.URL}[key]
+	})
+	if err != nil {
+		t.Fatalf("failed to open bucket: %v", err)
+	}
+	for key, expected := range map[string]gcerrors.ErrorCode{
+		"missing": gcerrors.NotFound,
+		"private": gcerrors.PermissionDenied,
+	} {
+		_, err := b.Attributes(context.Background(), key)
+		if err == nil {
+			t.Fatalf("expected an error for %s", key)
+		}
+		if code := b.ErrorCode(err); code != expected {
+			t.Errorf("expected %s for %s, got %s: %v", expected, key, code, err)
+		}
+	}
+}
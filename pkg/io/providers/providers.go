@@ -31,11 +31,13 @@ import (
 	"gocloud.dev/blob/s3blob"
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io/providers/azureblob"
 )
 
 const (
-	S3 = "s3"
-	GS = "gs"
+	S3    = "s3"
+	GS    = "gs"
+	Azure = azureblob.Scheme
 	// TODO(danilo-gemoli): complete the implementation since at this time only opener.Writer()
 	// is supported
 	File = "file"
@@ -48,6 +50,8 @@ func DisplayName(provider string) string {
 		return "GCS"
 	case S3:
 		return "S3"
+	case Azure:
+		return "Azure Blob Storage"
 	case File:
 		return "File"
 	}
@@ -78,6 +82,9 @@ func DisplayName(provider string) string {
 //     "access_key": "access_key",
 //     "secret_key": "secret_key"
 //     }
+//
+// Azure Blob Storage (azblob://) is configured through environment variables,
+// see prow/io/providers/azureblob.
 func GetBucket(ctx context.Context, s3Credentials []byte, path string) (*blob.Bucket, error) {
 	storageProvider, bucket, _, err := ParseStoragePath(path)
 	if err != nil {
@@ -150,12 +157,12 @@ func getS3Bucket(ctx context.Context, creds []byte, bucketName string) (*blob.Bu
 // * gs/kubernetes-jenkins returns true
 // * kubernetes-jenkins returns false
 func HasStorageProviderPrefix(path string) bool {
-	return strings.HasPrefix(path, GS+"/") || strings.HasPrefix(path, S3+"/")
+	return strings.HasPrefix(path, GS+"/") || strings.HasPrefix(path, S3+"/") || strings.HasPrefix(path, Azure+"/")
 }
 
 // ParseStoragePath parses storagePath and returns the storageProvider, bucket and relativePath
 // For example gs://prow-artifacts/test.log results in (gs, prow-artifacts, test.log)
-// Currently detected storageProviders are GS, S3 and file.
+// Currently detected storageProviders are GS, S3, Azure and file.
 // Paths with a leading / instead of a storageProvider prefix are treated as file paths for backwards
 // compatibility reasons.
 // File paths are split into a directory and a file. Directory is returned as bucket, file is returned.
@@ -38,6 +38,11 @@ func TestHasStorageProviderPrefix(t *testing.T) {
 			path: "gs/kubernetes-jenkins",
 			want: true,
 		},
+		{
+			name: "azblob prefix",
+			path: "azblob/kubernetes-jenkins",
+			want: true,
+		},
 		{
 			name: "no prefix",
 			path: "kubernetes-jenkins",
@@ -167,6 +172,12 @@ func TestStoragePath(t *testing.T) {
 			path:   "b",
 			want:   "s3://a/b",
 		},
+		{
+			name:   "azure",
+			bucket: "azblob://a",
+			path:   "b",
+			want:   "azblob://a/b",
+		},
 	}
 
 	for _, tc := range tests {
@@ -121,6 +121,15 @@ func getJobIdentifier(pj prowapi.ProwJob) jobIdentifier {
 // previousStates records the prowJobs we were called with previously
 var previousStates map[jobIdentifier]prowapi.ProwJobState
 
+// ResetProwJobMetrics drops all previously gathered prowjob metrics, e.g.
+// once another component took over serving them.
+// Not threadsafe, ensure this is called serially with GatherProwJobMetrics.
+func ResetProwJobMetrics() {
+	prowJobs.Reset()
+	prowJobTransitions.Reset()
+	previousStates = nil
+}
+
 // GatherProwJobMetrics gathers prometheus metrics for prowjobs.
 // Not threadsafe, ensure this is called serially.
 func GatherProwJobMetrics(l *logrus.Entry, current []prowapi.ProwJob) {
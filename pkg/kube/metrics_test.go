@@ -20,6 +20,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/diff"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
@@ -138,3 +140,27 @@ func TestGetJobLabelMap(t *testing.T) {
 		t.Errorf("Unexpected mis-match: %s", diff.ObjectReflectDiff(expected, jobLabelMap))
 	}
 }
+
+func TestResetProwJobMetrics(t *testing.T) {
+	pjs := []prowapi.ProwJob{
+		{
+			Spec:   prowapi.ProwJobSpec{Job: "test-job", Type: prowapi.PeriodicJob},
+			Status: prowapi.ProwJobStatus{State: prowapi.PendingState},
+		},
+	}
+	GatherProwJobMetrics(logrus.NewEntry(logrus.StandardLogger()), pjs)
+	if n := testutil.CollectAndCount(prowJobs); n != 1 {
+		t.Fatalf("expected 1 prowjobs series after gathering, got %d", n)
+	}
+
+	ResetProwJobMetrics()
+	if n := testutil.CollectAndCount(prowJobs); n != 0 {
+		t.Errorf("expected no prowjobs series after reset, got %d", n)
+	}
+	if n := testutil.CollectAndCount(prowJobTransitions); n != 0 {
+		t.Errorf("expected no prowjob_state_transitions series after reset, got %d", n)
+	}
+	if previousStates != nil {
+		t.Errorf("expected previous states to be dropped, got %v", previousStates)
+	}
+}
@@ -69,6 +69,38 @@ const (
 	// IsOptionalLabel is added in resources created by prow and
 	// carries the Optional from a Presubmit job.
 	IsOptionalLabel = "prow.k8s.io/is-optional"
+	// RunAfterSuccessAnnotation is added to ProwJobs created by the
+	// dependent-jobs controller and carries the comma-separated names
+	// of the jobs whose success triggered the job.
+	RunAfterSuccessAnnotation = "prow.k8s.io/run-after-success"
+	// EventTriggerAnnotation is added to periodic ProwJobs created by
+	// horologium for an event and identifies the event source.
+	EventTriggerAnnotation = "prow.k8s.io/event-trigger"
+	// ModifiedRerunLabel is added to ProwJobs rerun from Deck with
+	// overrides. These jobs never report to GitHub.
+	ModifiedRerunLabel = "prow.k8s.io/modified-rerun"
+	// RerunOverridesAnnotation is added to ProwJobs rerun from Deck with
+	// overrides and carries the overrides as JSON.
+	RerunOverridesAnnotation = "prow.k8s.io/rerun-overrides"
+	// PreemptedByAnnotation is added to ProwJobs plank aborted to start a
+	// job of a higher priority class and carries the name of that job.
+	PreemptedByAnnotation = "prow.k8s.io/preempted-by"
+	// LastRunAnnotation is added to periodic ProwJobs created by horologium
+	// on their schedule and carries the time the run was scheduled for in
+	// RFC 3339 format.
+	LastRunAnnotation = "prow.k8s.io/last-run"
+	// TriggeredByAnnotation is added to ProwJobs triggered by a comment or
+	// rerun from Deck and carries the login of the user who triggered them.
+	TriggeredByAnnotation = "prow.k8s.io/triggered-by"
+	// TriggerCommentAnnotation is added to ProwJobs triggered by a comment
+	// and carries the URL of the comment.
+	TriggerCommentAnnotation = "prow.k8s.io/trigger-comment"
+	// RerunOfAnnotation is added to ProwJobs rerun from Deck and carries
+	// the name of the ProwJob that was rerun.
+	RerunOfAnnotation = "prow.k8s.io/rerun-of"
+	// PubSubMessageIDAnnotation is added to ProwJobs created for a Pub/Sub
+	// message and carries the ID of the message.
+	PubSubMessageIDAnnotation = "prow.k8s.io/pubsub-message-id"
 
 	// Gerrit related labels that are used by Prow
 
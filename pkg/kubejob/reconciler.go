@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubejob implements a controller that runs ProwJobs with the
+// kubernetes-job agent as Kubernetes Jobs in the build clusters. Unlike plank,
+// which manages the test pods itself, it leaves retrying failed pods to the
+// Job controller and maps the status of the Job back into the ProwJob.
+package kubejob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+	"sigs.k8s.io/prow/pkg/version"
+)
+
+const ControllerName = "kubernetes-job"
+
+func Add(mgr controllerruntime.Manager, buildClusters map[string]cluster.Cluster, cfg config.Getter, totURL string, numWorkers int) error {
+	predicates := predicate.NewPredicateFuncs(func(o ctrlruntimeclient.Object) bool {
+		pj, ok := o.(*prowv1.ProwJob)
+		if !ok {
+			// We ignore Jobs that were not created by prow
+			return o.GetLabels()[kube.CreatedByProw] == "true"
+		}
+		return reconcilable(pj)
+	})
+
+	r := NewReconciler(mgr.GetClient(), cfg, totURL)
+	blder := controllerruntime.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&prowv1.ProwJob{}).
+		WithEventFilter(predicates).
+		WithOptions(controller.Options{MaxConcurrentReconciles: numWorkers})
+	for buildClusterName, buildCluster := range buildClusters {
+		blder = blder.WatchesRawSource(
+			source.Kind(buildCluster.GetCache(), &batchv1.Job{}),
+			jobEventRequestMapper(cfg().ProwJobNamespace))
+		r.buildClients[buildClusterName] = buildCluster.GetClient()
+	}
+	if err := blder.Complete(r); err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+	return nil
+}
+
+// reconcilable determines if the ProwJob is run by this controller and still
+// needs to be synced.
+func reconcilable(pj *prowv1.ProwJob) bool {
+	return pj.Spec.Agent == prowv1.KubernetesJobAgent && !pj.Complete() && pj.Status.State != prowv1.SchedulingState
+}
+
+// jobEventRequestMapper maps Jobs to the ProwJobs of the same name.
+func jobEventRequestMapper(prowJobNamespace string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o ctrlruntimeclient.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: ctrlruntimeclient.ObjectKey{
+			Namespace: prowJobNamespace,
+			Name:      o.GetName(),
+		}}}
+	})
+}
+
+type Reconciler struct {
+	pjClient     ctrlruntimeclient.Client
+	buildClients map[string]ctrlruntimeclient.Client
+	log          *logrus.Entry
+	cfg          config.Getter
+	totURL       string
+	clock        clock.Clock
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	pj := &prowv1.ProwJob{}
+	if err := r.pjClient.Get(ctx, request.NamespacedName, pj); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("get prowjob %s: %w", request.Name, err)
+		}
+		return reconcile.Result{}, nil
+	}
+	if !reconcilable(pj) {
+		return reconcile.Result{}, nil
+	}
+
+	log := r.log.WithFields(pjutil.ProwJobFields(pj))
+	prevPJ := pj.DeepCopy()
+	if client, ok := r.buildClients[pj.ClusterAlias()]; !ok {
+		pj.SetComplete()
+		pj.Status.State = prowv1.ErrorState
+		pj.Status.Description = fmt.Sprintf("No build client found for cluster %q.", pj.ClusterAlias())
+		pj.Status.FailureReason = prowv1.InfraErrorFailure
+		pj.Status.FailureMessage = pj.Status.Description
+	} else {
+		var err error
+		switch pj.Status.State {
+		case prowv1.TriggeredState:
+			err = r.syncTriggeredJob(ctx, client, pj)
+		case prowv1.PendingState:
+			err = r.syncPendingJob(ctx, client, pj)
+		case prowv1.AbortedState:
+			err = r.syncAbortedJob(ctx, client, pj)
+		}
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("sync prowjob %s: %w", request.Name, err)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(prevPJ.Status, pj.Status) {
+		return reconcile.Result{}, nil
+	}
+	if prevPJ.Status.State != pj.Status.State {
+		log.WithField("from", prevPJ.Status.State).WithField("to", pj.Status.State).Info("Transitioning states.")
+	}
+	if err := r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("patch prowjob %s: %w", request.Name, err)
+	}
+	return reconcile.Result{}, nil
+}
+
+// syncTriggeredJob creates the Job of the ProwJob if it does not exist yet and
+// marks the ProwJob as pending.
+func (r *Reconciler) syncTriggeredJob(ctx context.Context, client ctrlruntimeclient.Client, pj *prowv1.ProwJob) error {
+	job, err := r.job(ctx, client, pj)
+	if err != nil {
+		return err
+	}
+	// The Job may exist already if we failed to update the ProwJob after
+	// creating it in a previous sync.
+	if job != nil {
+		pj.Status.BuildID = job.Labels[kube.ProwBuildIDLabel]
+	} else {
+		buildID, err := pjutil.GetBuildID(pj.Spec.Job, r.totURL)
+		if err != nil {
+			return fmt.Errorf("get build ID: %w", err)
+		}
+		pj.Status.BuildID = buildID
+		job, err = newJob(*pj, r.cfg())
+		if err != nil {
+			pj.SetComplete()
+			pj.Status.State = prowv1.ErrorState
+			pj.Status.Description = fmt.Sprintf("Job can not be created: %v", err)
+			pj.Status.FailureReason = prowv1.InfraErrorFailure
+			pj.Status.FailureMessage = err.Error()
+			return nil
+		}
+		if err := client.Create(ctx, job); err != nil && !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("create job %s/%s in cluster %s: %w", job.Namespace, job.Name, pj.ClusterAlias(), err)
+		}
+	}
+
+	now := metav1.NewTime(r.clock.Now())
+	pj.Status.PendingTime = &now
+	pj.Status.State = prowv1.PendingState
+	pj.Status.Description = "Job triggered."
+	pj.Status.URL, err = pjutil.JobURL(r.cfg().Plank, *pj, r.log)
+	if err != nil {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warn("failed to get jobURL")
+	}
+	return nil
+}
+
+// syncPendingJob maps the status of the Job into the ProwJob.
+func (r *Reconciler) syncPendingJob(ctx context.Context, client ctrlruntimeclient.Client, pj *prowv1.ProwJob) error {
+	job, err := r.job(ctx, client, pj)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		pj.SetComplete()
+		pj.Status.State = prowv1.ErrorState
+		pj.Status.Description = "Job has disappeared from the cluster."
+		pj.Status.FailureReason = prowv1.InfraErrorFailure
+		pj.Status.FailureMessage = "Job was deleted before it completed."
+		return nil
+	}
+
+	podName, err := latestPod(ctx, client, job)
+	if err != nil {
+		return err
+	}
+	if podName != "" {
+		pj.Status.PodName = podName
+	}
+	// The Job controller retries failed pods until the Job failed.
+	retries := int(job.Status.Failed)
+	if jobCondition(job, batchv1.JobFailed) != nil && retries > 0 {
+		retries--
+	}
+	pj.Status.Retries = retries
+
+	if condition := jobCondition(job, batchv1.JobComplete); condition != nil {
+		pj.SetComplete()
+		pj.Status.State = prowv1.SuccessState
+		pj.Status.Description = "Job succeeded."
+	} else if condition := jobCondition(job, batchv1.JobFailed); condition != nil {
+		pj.SetComplete()
+		pj.Status.State = prowv1.FailureState
+		pj.Status.Description = "Job failed."
+		if condition.Message != "" {
+			pj.Status.Description = fmt.Sprintf("Job failed: %s", condition.Message)
+		}
+		pj.Status.FailureReason = prowv1.TestFailure
+		if condition.Reason == batchv1.JobReasonDeadlineExceeded {
+			pj.Status.FailureReason = prowv1.TimeoutFailure
+		}
+		pj.Status.FailureMessage = condition.Message
+	}
+	return nil
+}
+
+// syncAbortedJob deletes the Job of the aborted ProwJob together with its pods.
+func (r *Reconciler) syncAbortedJob(ctx context.Context, client ctrlruntimeclient.Client, pj *prowv1.ProwJob) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:      pj.Name,
+		Namespace: r.cfg().PodNamespace,
+	}}
+	if err := ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, job, ctrlruntimeclient.PropagationPolicy(metav1.DeletePropagationBackground))); err != nil {
+		return fmt.Errorf("delete job %s/%s in cluster %s: %w", job.Namespace, job.Name, pj.ClusterAlias(), err)
+	}
+	pj.SetComplete()
+	if pj.Status.FailureReason == "" {
+		pj.Status.FailureReason = prowv1.AbortedFailure
+		pj.Status.FailureMessage = pj.Status.Description
+	}
+	return nil
+}
+
+// job gets the Job of the ProwJob, or nil if it does not exist.
+func (r *Reconciler) job(ctx context.Context, client ctrlruntimeclient.Client, pj *prowv1.ProwJob) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	name := types.NamespacedName{Namespace: r.cfg().PodNamespace, Name: pj.Name}
+	if err := client.Get(ctx, name, job); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get job %s in cluster %s: %w", name, pj.ClusterAlias(), err)
+	}
+	return job, nil
+}
+
+// newJob creates a Job that runs the pod plank would create for the ProwJob.
+// The retries of the ProwJob become the backoff limit of the Job, which may
+// run for as long as the decoration timeout allows every attempt.
+func newJob(pj prowv1.ProwJob, cfg *config.Config) (*batchv1.Job, error) {
+	pj, err := cfg.Plank.ExpandPodTemplate(pj)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := decorate.ProwJobToPod(pj)
+	if err != nil {
+		return nil, err
+	}
+	pod.Labels[kube.PlankVersionLabel] = version.Version
+
+	// Sinker deletes pods created by prow whose name does not match a ProwJob,
+	// so the pods of the Job are cleaned up together with the Job instead.
+	podLabels := map[string]string{}
+	for k, v := range pod.Labels {
+		if k != kube.CreatedByProw {
+			podLabels[k] = v
+		}
+	}
+	var backoffLimit int32
+	if pj.Spec.Retry != nil {
+		backoffLimit = int32(pj.Spec.Retry.MaxRetries)
+	}
+	var ttl *int32
+	if cfg.Sinker.TerminatedPodTTL != nil {
+		seconds := int32(cfg.Sinker.TerminatedPodTTL.Seconds())
+		ttl = &seconds
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pj.Name,
+			Namespace:   cfg.PodNamespace,
+			Labels:      pod.Labels,
+			Annotations: pod.Annotations,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			ActiveDeadlineSeconds:   activeDeadlineSeconds(pj, backoffLimit+1),
+			TTLSecondsAfterFinished: ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: pod.Annotations,
+				},
+				Spec: pod.Spec,
+			},
+		},
+	}, nil
+}
+
+// activeDeadlineSeconds returns the time the given number of attempts of the
+// ProwJob may take, or nil if it is not decorated with a timeout. Every
+// attempt may run for the timeout and the grace period after it.
+func activeDeadlineSeconds(pj prowv1.ProwJob, attempts int32) *int64 {
+	dc := pj.Spec.DecorationConfig
+	if dc == nil || dc.Timeout == nil {
+		return nil
+	}
+	attempt := dc.Timeout.Duration
+	if dc.GracePeriod != nil {
+		attempt += dc.GracePeriod.Duration
+	}
+	seconds := int64(attempt.Seconds()) * int64(attempts)
+	return &seconds
+}
+
+// latestPod returns the name of the most recently created pod of the Job.
+func latestPod(ctx context.Context, client ctrlruntimeclient.Client, job *batchv1.Job) (string, error) {
+	if job.Spec.Selector == nil {
+		return "", nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("parse selector of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(job.Namespace), ctrlruntimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("list pods of job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	var latest *corev1.Pod
+	for i := range pods.Items {
+		if latest == nil || latest.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			latest = &pods.Items[i]
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Name, nil
+}
+
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		if condition := &job.Status.Conditions[i]; condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+func NewReconciler(pjClient ctrlruntimeclient.Client, cfg config.Getter, totURL string) *Reconciler {
+	return &Reconciler{
+		pjClient:     pjClient,
+		buildClients: map[string]ctrlruntimeclient.Client{},
+		log:          logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
+		cfg:          cfg,
+		totURL:       totURL,
+		clock:        clock.RealClock{},
+	}
+}
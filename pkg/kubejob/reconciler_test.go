@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubejob
+
+import (
+	"context"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func prowJob(state prowv1.ProwJobState, cluster string) *prowv1.ProwJob {
+	return &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "pj", Namespace: "prowjobs"},
+		Spec: prowv1.ProwJobSpec{
+			Type:    prowv1.PeriodicJob,
+			Job:     "periodic",
+			Agent:   prowv1.KubernetesJobAgent,
+			Cluster: cluster,
+			PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "image"}}},
+			Retry:   &prowv1.RetryConfig{MaxRetries: 2},
+		},
+		Status: prowv1.ProwJobStatus{State: state},
+	}
+}
+
+func job(failed int32, conditions ...batchv1.JobCondition) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pj",
+			Namespace: "pods",
+			Labels:    map[string]string{kube.ProwBuildIDLabel: "42"},
+		},
+		Status: batchv1.JobStatus{Failed: failed, Conditions: conditions},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &config.Config{ProwConfig: config.ProwConfig{
+		ProwJobNamespace: "prowjobs",
+		PodNamespace:     "pods",
+		Plank: config.Plank{Controller: config.Controller{
+			JobURLTemplate: template.Must(template.New("test").Parse("{{.ObjectMeta.Name}}/{{.Status.State}}")),
+		}},
+		Sinker: config.Sinker{TerminatedPodTTL: &metav1.Duration{Duration: time.Hour}},
+	}}
+	testCases := []struct {
+		name    string
+		pj      *prowv1.ProwJob
+		job     *batchv1.Job
+		cluster string
+
+		expectedState       prowv1.ProwJobState
+		expectedDescription string
+		expectedReason      prowv1.ProwJobFailureReason
+		expectedRetries     int
+		expectJob           bool
+	}{
+		{
+			name:                "triggered job creates a Job",
+			pj:                  prowJob(prowv1.TriggeredState, "default"),
+			expectedState:       prowv1.PendingState,
+			expectedDescription: "Job triggered.",
+			expectJob:           true,
+		},
+		{
+			name:                "triggered job with an existing Job",
+			pj:                  prowJob(prowv1.TriggeredState, "default"),
+			job:                 job(0),
+			expectedState:       prowv1.PendingState,
+			expectedDescription: "Job triggered.",
+			expectJob:           true,
+		},
+		{
+			name:            "running Job retried a failed pod",
+			pj:              prowJob(prowv1.PendingState, "default"),
+			job:             job(1),
+			expectedState:   prowv1.PendingState,
+			expectedRetries: 1,
+			expectJob:       true,
+		},
+		{
+			name:                "completed Job",
+			pj:                  prowJob(prowv1.PendingState, "default"),
+			job:                 job(0, batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+			expectedState:       prowv1.SuccessState,
+			expectedDescription: "Job succeeded.",
+			expectJob:           true,
+		},
+		{
+			name:                "failed Job",
+			pj:                  prowJob(prowv1.PendingState, "default"),
+			job:                 job(3, batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}),
+			expectedState:       prowv1.FailureState,
+			expectedDescription: "Job failed: Job has reached the specified backoff limit",
+			expectedReason:      prowv1.TestFailure,
+			expectedRetries:     2,
+			expectJob:           true,
+		},
+		{
+			name:                "Job exceeded its deadline",
+			pj:                  prowJob(prowv1.PendingState, "default"),
+			job:                 job(1, batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: batchv1.JobReasonDeadlineExceeded, Message: "Job was active longer than specified deadline"}),
+			expectedState:       prowv1.FailureState,
+			expectedDescription: "Job failed: Job was active longer than specified deadline",
+			expectedReason:      prowv1.TimeoutFailure,
+			expectJob:           true,
+		},
+		{
+			name:                "missing Job",
+			pj:                  prowJob(prowv1.PendingState, "default"),
+			expectedState:       prowv1.ErrorState,
+			expectedDescription: "Job has disappeared from the cluster.",
+			expectedReason:      prowv1.InfraErrorFailure,
+		},
+		{
+			name:           "aborted job deletes the Job",
+			pj:             prowJob(prowv1.AbortedState, "default"),
+			job:            job(0),
+			expectedState:  prowv1.AbortedState,
+			expectedReason: prowv1.AbortedFailure,
+		},
+		{
+			name:                "unknown cluster",
+			pj:                  prowJob(prowv1.TriggeredState, "other"),
+			expectedState:       prowv1.ErrorState,
+			expectedDescription: `No build client found for cluster "other".`,
+			expectedReason:      prowv1.InfraErrorFailure,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			pjClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.pj).Build()
+			var buildObjects []ctrlruntimeclient.Object
+			if tc.job != nil {
+				buildObjects = append(buildObjects, tc.job)
+			}
+			buildClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(buildObjects...).Build()
+			r := NewReconciler(pjClient, func() *config.Config { return cfg }, "")
+			r.buildClients["default"] = buildClient
+			r.clock = clocktesting.NewFakeClock(now)
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "prowjobs", Name: "pj"}}); err != nil {
+				t.Fatalf("reconcile failed: %v", err)
+			}
+
+			pj := &prowv1.ProwJob{}
+			if err := pjClient.Get(ctx, types.NamespacedName{Namespace: "prowjobs", Name: "pj"}, pj); err != nil {
+				t.Fatalf("failed to get prowjob: %v", err)
+			}
+			if pj.Status.State != tc.expectedState {
+				t.Errorf("expected state %q, got %q", tc.expectedState, pj.Status.State)
+			}
+			if tc.expectedDescription != "" && pj.Status.Description != tc.expectedDescription {
+				t.Errorf("expected description %q, got %q", tc.expectedDescription, pj.Status.Description)
+			}
+			if pj.Status.FailureReason != tc.expectedReason {
+				t.Errorf("expected failure reason %q, got %q", tc.expectedReason, pj.Status.FailureReason)
+			}
+			if pj.Status.Retries != tc.expectedRetries {
+				t.Errorf("expected %d retries, got %d", tc.expectedRetries, pj.Status.Retries)
+			}
+			if complete := tc.expectedState != prowv1.PendingState; complete != pj.Complete() {
+				t.Errorf("expected complete to be %t, got %t", complete, pj.Complete())
+			}
+			if tc.pj.Status.State == prowv1.TriggeredState && tc.expectedState == prowv1.PendingState {
+				if pj.Status.PendingTime == nil || pj.Status.BuildID == "" {
+					t.Errorf("expected pending time and build ID to be set, got %v and %q", pj.Status.PendingTime, pj.Status.BuildID)
+				}
+				if pj.Status.URL != "pj/pending" {
+					t.Errorf("expected URL pj/pending, got %q", pj.Status.URL)
+				}
+			}
+
+			actual := &batchv1.Job{}
+			err := buildClient.Get(ctx, types.NamespacedName{Namespace: "pods", Name: "pj"}, actual)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatalf("failed to get job: %v", err)
+			}
+			if exists := err == nil; exists != tc.expectJob {
+				t.Fatalf("expected job to exist to be %t, got %t", tc.expectJob, exists)
+			}
+			if tc.job == nil && tc.expectJob {
+				if actual.Spec.BackoffLimit == nil || *actual.Spec.BackoffLimit != 2 {
+					t.Errorf("expected backoff limit 2, got %v", actual.Spec.BackoffLimit)
+				}
+				if actual.Spec.TTLSecondsAfterFinished == nil || *actual.Spec.TTLSecondsAfterFinished != 3600 {
+					t.Errorf("expected TTL of 3600 seconds, got %v", actual.Spec.TTLSecondsAfterFinished)
+				}
+				if actual.Labels[kube.CreatedByProw] != "true" {
+					t.Errorf("expected the job to be labeled as created by prow, got labels %v", actual.Labels)
+				}
+				if _, ok := actual.Spec.Template.Labels[kube.CreatedByProw]; ok {
+					t.Errorf("expected the pods not to be labeled as created by prow, got labels %v", actual.Spec.Template.Labels)
+				}
+				if actual.Labels[kube.ProwBuildIDLabel] != pj.Status.BuildID {
+					t.Errorf("expected build ID label %q, got %q", pj.Status.BuildID, actual.Labels[kube.ProwBuildIDLabel])
+				}
+			}
+		})
+	}
+}
+
+func TestLatestPod(t *testing.T) {
+	pod := func(name string, created time.Time) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "pods",
+			Labels:            map[string]string{"controller-uid": "uid"},
+			CreationTimestamp: metav1.NewTime(created),
+		}}
+	}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := fakectrlruntimeclient.NewClientBuilder().WithObjects(
+		pod("pj-first", start),
+		pod("pj-retry", start.Add(time.Minute)),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "pods", CreationTimestamp: metav1.NewTime(start.Add(time.Hour))}},
+	).Build()
+	j := job(1)
+	j.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "uid"}}
+
+	name, err := latestPod(context.Background(), client, j)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "pj-retry" {
+		t.Errorf("expected pod pj-retry, got %q", name)
+	}
+}
+
+func TestActiveDeadlineSeconds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dc       *prowv1.DecorationConfig
+		attempts int32
+		expected *int64
+	}{
+		{
+			name:     "undecorated job has no deadline",
+			attempts: 1,
+		},
+		{
+			name:     "decorated job without a timeout has no deadline",
+			dc:       &prowv1.DecorationConfig{},
+			attempts: 1,
+		},
+		{
+			name:     "timeout and grace period of a single attempt",
+			dc:       &prowv1.DecorationConfig{Timeout: &prowv1.Duration{Duration: time.Hour}, GracePeriod: &prowv1.Duration{Duration: time.Minute}},
+			attempts: 1,
+			expected: ptr.To[int64](3660),
+		},
+		{
+			name:     "every attempt gets the timeout",
+			dc:       &prowv1.DecorationConfig{Timeout: &prowv1.Duration{Duration: time.Hour}},
+			attempts: 3,
+			expected: ptr.To[int64](3 * 3600),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowv1.ProwJob{Spec: prowv1.ProwJobSpec{DecorationConfig: tc.dc}}
+			if diff := cmp.Diff(tc.expected, activeDeadlineSeconds(pj, tc.attempts)); diff != "" {
+				t.Errorf("active deadline differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
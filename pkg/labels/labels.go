@@ -42,6 +42,8 @@ const (
 	LifecycleRotten             = "lifecycle/rotten"
 	LifecycleStale              = "lifecycle/stale"
 	MergeCommits                = "do-not-merge/contains-merge-commits"
+	MergeWhenGreen              = "merge-when-green"
+	NeedsIssue                  = "do-not-merge/needs-issue"
 	NeedsOkToTest               = "needs-ok-to-test"
 	NeedsRebase                 = "needs-rebase"
 	OkToTest                    = "ok-to-test"
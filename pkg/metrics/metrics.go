@@ -28,6 +28,7 @@ import (
 	ctrlruntimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github/ghmetrics"
 	"sigs.k8s.io/prow/pkg/interrupts"
 )
 
@@ -64,6 +65,9 @@ func ExposeMetricsWithRegistry(component string, pushGateway config.PushGateway,
 	)
 	metricsMux := http.NewServeMux()
 	metricsMux.Handle("/metrics", handler)
+	// The API usage of the GitHub client of this component, to find out which
+	// consumer spends the rate limit.
+	metricsMux.Handle("/github-consumption", ghmetrics.ConsumptionHandler())
 	var server interrupts.ListenAndServer
 	if createServer == nil {
 		server = &http.Server{Addr: ":" + strconv.Itoa(port), Handler: metricsMux}
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// Labels that the metrics of the Exporter can have in addition to the states
+// of jobs.
+const (
+	LabelJobNamespace = "job_namespace"
+	LabelJobName      = "job_name"
+	LabelType         = "type"
+	LabelOrg          = "org"
+	LabelRepo         = "repo"
+	LabelBaseRef      = "base_ref"
+	LabelCluster      = "cluster"
+)
+
+// OtherLabelValue replaces the values of labels beyond MaxLabelValues.
+const OtherLabelValue = "other"
+
+var (
+	// AllLabels are all labels the metrics of the Exporter can have.
+	AllLabels = []string{LabelJobNamespace, LabelJobName, LabelType, LabelOrg, LabelRepo, LabelBaseRef, LabelCluster}
+	// DefaultLabels are the labels the metrics of the Exporter have by default.
+	DefaultLabels = []string{LabelJobName, LabelType, LabelOrg, LabelRepo, LabelCluster}
+)
+
+// ExporterOptions control the cardinality of the metrics of the Exporter.
+type ExporterOptions struct {
+	// Labels are the labels of the metrics, out of AllLabels.
+	Labels []string
+	// MaxLabelValues limits the distinct values of each label. Further values
+	// are reported as OtherLabelValue. Zero means no limit.
+	MaxLabelValues int
+}
+
+// Validate validates the options.
+func (o ExporterOptions) Validate() error {
+	if unknown := sets.New(o.Labels...).Difference(sets.New(AllLabels...)); unknown.Len() > 0 {
+		return fmt.Errorf("unknown labels %v, valid labels are %v", sets.List(unknown), AllLabels)
+	}
+	if o.MaxLabelValues < 0 {
+		return fmt.Errorf("the maximum number of label values must not be negative, got %d", o.MaxLabelValues)
+	}
+	return nil
+}
+
+// Exporter exports metrics of ProwJobs from an informer, so that components
+// reconciling ProwJobs do not have to. Counters and histograms are only
+// updated for changes observed after the initial list of the informer, so
+// restarts of the exporter neither count jobs twice nor lose the history of
+// jobs that were already deleted by sinker.
+type Exporter struct {
+	labels         []string
+	maxLabelValues int
+	store          cache.Store
+
+	lock sync.Mutex
+	// seen are the values of each label that are reported as is.
+	seen map[string]sets.Set[string]
+
+	jobs        *prometheus.Desc
+	transitions *prometheus.CounterVec
+	queued      *prometheus.HistogramVec
+	durations   *prometheus.HistogramVec
+	retests     *prometheus.CounterVec
+}
+
+var durationBuckets = []float64{
+	(30 * time.Second).Seconds(),
+	time.Minute.Seconds(),
+	(2 * time.Minute).Seconds(),
+	(5 * time.Minute).Seconds(),
+	(10 * time.Minute).Seconds(),
+	(30 * time.Minute).Seconds(),
+	time.Hour.Seconds(),
+	(2 * time.Hour).Seconds(),
+	(4 * time.Hour).Seconds(),
+	(8 * time.Hour).Seconds(),
+	(12 * time.Hour).Seconds(),
+	(24 * time.Hour).Seconds(),
+}
+
+// NewExporter returns an exporter of the ProwJobs of the informer, which has
+// to be registered with a prometheus registry.
+func NewExporter(informer cache.SharedIndexInformer, opts ExporterOptions) (*Exporter, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	// Keep the order of the labels stable regardless of the options.
+	var labels []string
+	for _, label := range AllLabels {
+		if sets.New(opts.Labels...).Has(label) {
+			labels = append(labels, label)
+		}
+	}
+	e := &Exporter{
+		labels:         labels,
+		maxLabelValues: opts.MaxLabelValues,
+		store:          informer.GetStore(),
+		seen:           map[string]sets.Set[string]{},
+		jobs: prometheus.NewDesc(
+			"prow_job_count",
+			"Number of ProwJobs in the cluster by state.",
+			append(append([]string{}, labels...), "state"), nil,
+		),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_state_transitions_total",
+			Help: "Number of ProwJobs transitioning states. Created jobs transition from the \"none\" state.",
+		}, append(append([]string{}, labels...), "from", "to")),
+		queued: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "prow_job_queued_seconds",
+			Help:    "Time ProwJobs were triggered before they became pending.",
+			Buckets: durationBuckets,
+		}, labels),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "prow_job_duration_seconds",
+			Help:    "Time from the start to the completion of ProwJobs by their final state.",
+			Buckets: durationBuckets,
+		}, append(append([]string{}, labels...), "state")),
+		retests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_retests_total",
+			Help: "Number of ProwJobs created to retest a pull request.",
+		}, labels),
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if pj, ok := obj.(*prowapi.ProwJob); ok && !isInInitialList {
+				e.add(pj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldJob, oldOK := oldObj.(*prowapi.ProwJob)
+			newJob, newOK := newObj.(*prowapi.ProwJob)
+			if oldOK && newOK {
+				e.update(oldJob, newJob)
+			}
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add event handler: %w", err)
+	}
+	return e, nil
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.jobs
+	e.transitions.Describe(ch)
+	e.queued.Describe(ch)
+	e.durations.Describe(ch)
+	e.retests.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	counts := map[string]float64{}
+	values := map[string][]string{}
+	for _, obj := range e.store.List() {
+		pj, ok := obj.(*prowapi.ProwJob)
+		if !ok {
+			continue
+		}
+		labelValues := append(e.labelValues(pj), string(pj.Status.State))
+		key := fmt.Sprint(labelValues)
+		counts[key]++
+		values[key] = labelValues
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(e.jobs, prometheus.GaugeValue, count, values[key]...)
+	}
+	e.transitions.Collect(ch)
+	e.queued.Collect(ch)
+	e.durations.Collect(ch)
+	e.retests.Collect(ch)
+}
+
+// labelValues returns the values of the labels of the job, replacing values
+// beyond the limit with OtherLabelValue.
+func (e *Exporter) labelValues(pj *prowapi.ProwJob) []string {
+	refs := pj.Spec.Refs
+	if refs == nil && len(pj.Spec.ExtraRefs) > 0 {
+		refs = &pj.Spec.ExtraRefs[0]
+	}
+	all := map[string]string{
+		LabelJobNamespace: pj.Namespace,
+		LabelJobName:      pj.Spec.Job,
+		LabelType:         string(pj.Spec.Type),
+		LabelCluster:      pj.Spec.Cluster,
+	}
+	if refs != nil {
+		all[LabelOrg] = refs.Org
+		all[LabelRepo] = refs.Repo
+		all[LabelBaseRef] = refs.BaseRef
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	values := make([]string, 0, len(e.labels)+2)
+	for _, label := range e.labels {
+		value := all[label]
+		if e.maxLabelValues > 0 {
+			seen, ok := e.seen[label]
+			if !ok {
+				seen = sets.New[string]()
+				e.seen[label] = seen
+			}
+			if !seen.Has(value) {
+				if seen.Len() >= e.maxLabelValues {
+					value = OtherLabelValue
+				} else {
+					seen.Insert(value)
+				}
+			}
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func (e *Exporter) add(pj *prowapi.ProwJob) {
+	labelValues := e.labelValues(pj)
+	state := string(pj.Status.State)
+	if state == "" {
+		state = string(prowapi.TriggeredState)
+	}
+	e.transitions.WithLabelValues(append(labelValues, "none", state)...).Inc()
+	if pj.Labels[kube.RetestLabel] == "true" {
+		e.retests.WithLabelValues(labelValues...).Inc()
+	}
+}
+
+func (e *Exporter) update(oldJob, newJob *prowapi.ProwJob) {
+	if oldJob.Status.State == newJob.Status.State || newJob.Status.State == "" {
+		return
+	}
+	labelValues := e.labelValues(newJob)
+	from := string(oldJob.Status.State)
+	if from == "" {
+		from = string(prowapi.TriggeredState)
+	}
+	e.transitions.WithLabelValues(append(labelValues, from, string(newJob.Status.State))...).Inc()
+
+	switch {
+	case newJob.Status.State == prowapi.PendingState && newJob.Status.PendingTime != nil:
+		e.queued.WithLabelValues(labelValues...).Observe(newJob.Status.PendingTime.Sub(newJob.CreationTimestamp.Time).Seconds())
+	case newJob.Complete() && newJob.Status.CompletionTime != nil:
+		duration := newJob.Status.CompletionTime.Sub(newJob.Status.StartTime.Time)
+		if duration < 0 {
+			logrus.WithField("prowjob", newJob.Name).Debug("Ignoring ProwJob that completed before it started.")
+			return
+		}
+		e.durations.WithLabelValues(append(labelValues, string(newJob.Status.State))...).Observe(duration.Seconds())
+	}
+}
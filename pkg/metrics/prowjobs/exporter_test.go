@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func newTestExporter(t *testing.T, opts ExporterOptions) *Exporter {
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+			return &prowapi.ProwJobList{}, nil
+		},
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}, &prowapi.ProwJob{}, 0, cache.Indexers{})
+	e, err := NewExporter(informer, opts)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+	return e
+}
+
+func testJob(name, job, repo string, state prowapi.ProwJobState) *prowapi.ProwJob {
+	created := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	return &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prow", CreationTimestamp: metav1.NewTime(created)},
+		Spec: prowapi.ProwJobSpec{
+			Type:    prowapi.PresubmitJob,
+			Job:     job,
+			Cluster: "default",
+			Refs:    &prowapi.Refs{Org: "org", Repo: repo, BaseRef: "main"},
+		},
+		Status: prowapi.ProwJobStatus{State: state, StartTime: metav1.NewTime(created)},
+	}
+}
+
+func TestExporterTransitions(t *testing.T) {
+	e := newTestExporter(t, ExporterOptions{Labels: []string{LabelJobName, LabelRepo}})
+
+	triggered := testJob("a", "unit", "repo", prowapi.TriggeredState)
+	triggered.Labels = map[string]string{kube.RetestLabel: "true"}
+	e.add(triggered)
+
+	pending := triggered.DeepCopy()
+	pending.Status.State = prowapi.PendingState
+	pendingTime := metav1.NewTime(triggered.CreationTimestamp.Add(time.Minute))
+	pending.Status.PendingTime = &pendingTime
+	e.update(triggered, pending)
+	// Updates without a state change are not transitions.
+	e.update(pending, pending)
+
+	succeeded := pending.DeepCopy()
+	succeeded.Status.State = prowapi.SuccessState
+	completionTime := metav1.NewTime(triggered.CreationTimestamp.Add(20 * time.Minute))
+	succeeded.Status.CompletionTime = &completionTime
+	e.update(pending, succeeded)
+	if err := e.store.Add(succeeded); err != nil {
+		t.Fatalf("failed to add job to store: %v", err)
+	}
+
+	expected := `
+# HELP prow_job_count Number of ProwJobs in the cluster by state.
+# TYPE prow_job_count gauge
+prow_job_count{job_name="unit",repo="repo",state="success"} 1
+# HELP prow_job_retests_total Number of ProwJobs created to retest a pull request.
+# TYPE prow_job_retests_total counter
+prow_job_retests_total{job_name="unit",repo="repo"} 1
+# HELP prow_job_state_transitions_total Number of ProwJobs transitioning states. Created jobs transition from the "none" state.
+# TYPE prow_job_state_transitions_total counter
+prow_job_state_transitions_total{from="none",job_name="unit",repo="repo",to="triggered"} 1
+prow_job_state_transitions_total{from="pending",job_name="unit",repo="repo",to="success"} 1
+prow_job_state_transitions_total{from="triggered",job_name="unit",repo="repo",to="pending"} 1
+`
+	if err := testutil.CollectAndCompare(e, strings.NewReader(expected), "prow_job_count", "prow_job_retests_total", "prow_job_state_transitions_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(e, "prow_job_queued_seconds"); n != 1 {
+		t.Errorf("expected one queued histogram, got %d", n)
+	}
+	if n := testutil.CollectAndCount(e, "prow_job_duration_seconds"); n != 1 {
+		t.Errorf("expected one duration histogram, got %d", n)
+	}
+}
+
+func TestExporterMaxLabelValues(t *testing.T) {
+	e := newTestExporter(t, ExporterOptions{Labels: []string{LabelRepo}, MaxLabelValues: 2})
+	for _, repo := range []string{"a", "b", "c", "d", "a"} {
+		e.add(testJob(repo, "unit", repo, prowapi.TriggeredState))
+	}
+	expected := `
+# HELP prow_job_state_transitions_total Number of ProwJobs transitioning states. Created jobs transition from the "none" state.
+# TYPE prow_job_state_transitions_total counter
+prow_job_state_transitions_total{from="none",repo="a",to="triggered"} 2
+prow_job_state_transitions_total{from="none",repo="b",to="triggered"} 1
+prow_job_state_transitions_total{from="none",repo="other",to="triggered"} 2
+`
+	if err := testutil.CollectAndCompare(e, strings.NewReader(expected), "prow_job_state_transitions_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExporterOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    ExporterOptions
+		invalid bool
+	}{
+		{name: "default labels", opts: ExporterOptions{Labels: DefaultLabels}},
+		{name: "unknown label", opts: ExporterOptions{Labels: []string{LabelRepo, "pull"}}, invalid: true},
+		{name: "negative maximum", opts: ExporterOptions{MaxLabelValues: -1}, invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.opts.Validate(); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid %t, got error %v", tc.invalid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oidcauth logs users into Deck with an OpenID Connect provider
+// such as Okta or Keycloak.
+package oidcauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/xsrftoken"
+	"golang.org/x/oauth2"
+)
+
+const (
+	identitySession   = "oidc-identity-session"
+	loginKey          = "login"
+	groupsKey         = "groups"
+	oidcSessionCookie = "oidc-session"
+	stateKey          = "state"
+	destKey           = "dest"
+	// loginCookie holds the login of the user so that the frontend can show
+	// who is logged in. It is not used for authentication.
+	loginCookie = "oidc_login"
+
+	// maxGroupsSize caps the total length of the groups remembered in the
+	// identity cookie, as browsers drop cookies larger than 4KB.
+	maxGroupsSize = 2048
+
+	defaultUsernameClaim = "preferred_username"
+	defaultGroupsClaim   = "groups"
+)
+
+// Config is the config for logging users in with an OpenID Connect provider.
+type Config struct {
+	// Issuer is the URL of the provider. Its discovery document is served
+	// under <issuer>/.well-known/openid-configuration.
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	// RedirectURL is the URL of the /oidc-login/redirect endpoint of Deck.
+	RedirectURL string `json:"redirect_url"`
+	// Scopes are requested in addition to the openid scope.
+	Scopes []string `json:"scopes,omitempty"`
+	// UsernameClaim is the claim that holds the login of the user.
+	// Defaults to preferred_username.
+	UsernameClaim string `json:"username_claim,omitempty"`
+	// GroupsClaim is the claim that holds the groups of the user.
+	// Defaults to groups.
+	GroupsClaim string `json:"groups_claim,omitempty"`
+
+	CookieStore *sessions.CookieStore `json:"-"`
+}
+
+// Validate checks that the required fields are set.
+func (c *Config) Validate() error {
+	if c.Issuer == "" || c.ClientID == "" || c.ClientSecret == "" || c.RedirectURL == "" {
+		return errors.New("issuer, client_id, client_secret and redirect_url are required")
+	}
+	return nil
+}
+
+// Identity is an authenticated user.
+type Identity struct {
+	Login  string
+	Groups []string
+}
+
+// providerMetadata is the subset of the discovery document that is used.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// Agent handles the login of users with an OpenID Connect provider and
+// remembers their identity in a cookie.
+type Agent struct {
+	config      *Config
+	oauth       *oauth2.Config
+	userInfoURL string
+	client      *http.Client
+	logger      *logrus.Entry
+}
+
+// NewAgent discovers the endpoints of the provider and returns an agent for it.
+func NewAgent(config *Config, client *http.Client, logger *logrus.Entry) (*Agent, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	discoveryURL := strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+	resp, err := client.Get(discoveryURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch discovery document: %s", resp.Status)
+	}
+	var metadata providerMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != strings.TrimSuffix(config.Issuer, "/") {
+		return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", metadata.Issuer, config.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.UserInfoEndpoint == "" {
+		return nil, errors.New("discovery document lacks the authorization, token or userinfo endpoint")
+	}
+	return &Agent{
+		config: config,
+		oauth: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       append([]string{"openid"}, config.Scopes...),
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  metadata.AuthorizationEndpoint,
+				TokenURL: metadata.TokenEndpoint,
+			},
+		},
+		userInfoURL: metadata.UserInfoEndpoint,
+		client:      client,
+		logger:      logger,
+	}, nil
+}
+
+// HandleLogin starts a new login session and redirects the user to the provider.
+func (a *Agent) HandleLogin(secure bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state := hex.EncodeToString([]byte(xsrftoken.Generate(a.config.ClientSecret, "", "")))
+		session, err := a.config.CookieStore.New(r, oidcSessionCookie)
+		if err != nil {
+			a.serverError(w, "Creating new OIDC session", err)
+			return
+		}
+		session.Options.Secure = secure
+		session.Options.HttpOnly = true
+		session.Options.MaxAge = 10 * 60
+		session.Values[stateKey] = state
+		session.Values[destKey] = r.URL.Query().Get("dest")
+		if err := session.Save(r, w); err != nil {
+			a.serverError(w, "Save OIDC session", err)
+			return
+		}
+		http.Redirect(w, r, a.oauth.AuthCodeURL(state), http.StatusFound)
+	}
+}
+
+// HandleRedirect handles the redirection from the provider. It exchanges the
+// code for a token, looks up the identity of the user and remembers it.
+func (a *Agent) HandleRedirect(secure bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if providerError := r.FormValue("error"); providerError != "" {
+			a.logger.WithField("error_description", r.FormValue("error_description")).Debugf("OIDC provider returned error %s.", providerError)
+			http.Error(w, fmt.Sprintf("Login failed: %s", providerError), http.StatusUnauthorized)
+			return
+		}
+		session, err := a.config.CookieStore.Get(r, oidcSessionCookie)
+		if err != nil {
+			a.serverError(w, "Get cookie", err)
+			return
+		}
+		secretState, ok := session.Values[stateKey].(string)
+		if !ok {
+			a.serverError(w, "Get secret state", errors.New("no login in progress"))
+			return
+		}
+		state := r.FormValue("state")
+		if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(secretState)) != 1 {
+			a.serverError(w, "Validate state", errors.New("invalid state"))
+			return
+		}
+		stateToken, err := hex.DecodeString(state)
+		if err != nil || !xsrftoken.Valid(string(stateToken), a.config.ClientSecret, "", "") {
+			a.serverError(w, "Validate state", errors.New("state token has expired"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), oauth2.HTTPClient, a.client)
+		token, err := a.oauth.Exchange(ctx, r.FormValue("code"))
+		if err != nil {
+			a.serverError(w, "Exchange code for token", err)
+			return
+		}
+		identity, err := a.userInfo(ctx, token)
+		if err != nil {
+			a.serverError(w, "Get user info", err)
+			return
+		}
+
+		idSession, err := a.config.CookieStore.New(r, identitySession)
+		if err != nil {
+			a.serverError(w, "Create new session", err)
+			return
+		}
+		idSession.Options.Secure = secure
+		idSession.Options.HttpOnly = true
+		groups, dropped := capGroups(identity.Groups)
+		if dropped > 0 {
+			a.logger.WithField("user", identity.Login).Warnf("Dropped %d of %d groups that don't fit in the session cookie. Configure the provider to only send the groups relevant to Prow.", dropped, len(identity.Groups))
+		}
+		idSession.Values[loginKey] = identity.Login
+		idSession.Values[groupsKey] = groups
+		if err := idSession.Save(r, w); err != nil {
+			a.serverError(w, "Save session", err)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:    loginCookie,
+			Value:   identity.Login,
+			Path:    "/",
+			Expires: time.Now().Add(time.Hour * 24 * 30),
+			Secure:  secure,
+		})
+		session.Options.MaxAge = -1
+		if err := session.Save(r, w); err != nil {
+			a.serverError(w, "Save invalidated OIDC session", err)
+			return
+		}
+
+		scheme := "http"
+		if secure {
+			scheme = "https"
+		}
+		dest, _ := session.Values[destKey].(string)
+		http.Redirect(w, r, scheme+"://"+r.Host+"/"+strings.TrimPrefix(dest, "/"), http.StatusFound)
+	}
+}
+
+// HandleLogout forgets the identity of the user and redirects to the front page.
+func (a *Agent) HandleLogout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, err := a.config.CookieStore.Get(r, identitySession)
+		if err != nil {
+			a.serverError(w, "Get cookie", err)
+			return
+		}
+		session.Options.MaxAge = -1
+		if err := session.Save(r, w); err != nil {
+			a.serverError(w, "Save invalidated session on log out", err)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:    loginCookie,
+			Path:    "/",
+			MaxAge:  -1,
+			Expires: time.Now().Add(-time.Hour * 24),
+		})
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
+
+// GetIdentity returns the identity of the already authenticated user.
+func (a *Agent) GetIdentity(r *http.Request) (*Identity, error) {
+	session, err := a.config.CookieStore.Get(r, identitySession)
+	if err != nil {
+		return nil, err
+	}
+	login, ok := session.Values[loginKey].(string)
+	if !ok || login == "" {
+		return nil, errors.New("could not find OIDC identity")
+	}
+	groups, _ := session.Values[groupsKey].([]string)
+	return &Identity{Login: login, Groups: groups}, nil
+}
+
+// userInfo fetches the claims of the user from the userinfo endpoint.
+func (a *Agent) userInfo(ctx context.Context, token *oauth2.Token) (*Identity, error) {
+	resp, err := a.oauth.Client(ctx, token).Get(a.userInfoURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo endpoint returned %s", resp.Status)
+	}
+	var claims map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("failed to decode user info: %w", err)
+	}
+	return identityFromClaims(claims, a.config.UsernameClaim, a.config.GroupsClaim)
+}
+
+func identityFromClaims(claims map[string]interface{}, usernameClaim, groupsClaim string) (*Identity, error) {
+	if usernameClaim == "" {
+		usernameClaim = defaultUsernameClaim
+	}
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
+	login, _ := claims[usernameClaim].(string)
+	if login == "" {
+		return nil, fmt.Errorf("user info lacks the %s claim", usernameClaim)
+	}
+	identity := &Identity{Login: login}
+	switch groups := claims[groupsClaim].(type) {
+	case nil:
+	case string:
+		identity.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if s, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, s)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("the %s claim is neither a string nor a list of strings", groupsClaim)
+	}
+	return identity, nil
+}
+
+// capGroups returns the groups that fit into maxGroupsSize and the number of
+// groups that were dropped.
+func capGroups(groups []string) ([]string, int) {
+	var size int
+	for i, group := range groups {
+		size += len(group)
+		if size > maxGroupsSize {
+			return groups[:i], len(groups) - i
+		}
+	}
+	return groups, 0
+}
+
+func (a *Agent) serverError(w http.ResponseWriter, action string, err error) {
+	a.logger.WithError(err).Errorf("Error %s.", action)
+	http.Error(w, fmt.Sprintf("500 Internal server error %s: %v", action, err), http.StatusInternalServerError)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/sessions"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeProvider serves the endpoints of an OpenID Connect provider that
+// knows a single user.
+func fakeProvider(t *testing.T, userInfo map[string]interface{}) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/authorize",
+			TokenEndpoint:         server.URL + "/token",
+			UserInfoEndpoint:      server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if code := r.FormValue("code"); code != "the-code" {
+			t.Errorf("expected code the-code, got %q", code)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "the-token", "token_type": "Bearer"}`))
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer the-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(userInfo)
+	})
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestLogin(t *testing.T) {
+	provider := fakeProvider(t, map[string]interface{}{
+		"preferred_username": "alice",
+		"groups":             []string{"ci-admins", "developers"},
+	})
+	defer provider.Close()
+
+	config := &Config{
+		Issuer:       provider.URL,
+		ClientID:     "deck",
+		ClientSecret: "secret",
+		RedirectURL:  "https://prow.example.com/oidc-login/redirect",
+		CookieStore:  sessions.NewCookieStore([]byte("cookie-secret")),
+	}
+	agent, err := NewAgent(config, provider.Client(), logrus.WithField("client", "oidcauth"))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+
+	login := httptest.NewRecorder()
+	agent.HandleLogin(false).ServeHTTP(login, httptest.NewRequest(http.MethodGet, "/oidc-login?dest=view/gs/job", nil))
+	if login.Code != http.StatusFound {
+		t.Fatalf("expected a redirect, got %d: %s", login.Code, login.Body.String())
+	}
+	location, err := url.Parse(login.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("failed to parse redirect: %v", err)
+	}
+	if location.Path != "/authorize" || location.Query().Get("scope") != "openid" {
+		t.Errorf("unexpected redirect to %s", location)
+	}
+
+	redirect := httptest.NewRequest(http.MethodGet, "/oidc-login/redirect?code=the-code&state="+location.Query().Get("state"), nil)
+	for _, cookie := range login.Result().Cookies() {
+		redirect.AddCookie(cookie)
+	}
+	redirect.Host = "prow.example.com"
+	redirected := httptest.NewRecorder()
+	agent.HandleRedirect(false).ServeHTTP(redirected, redirect)
+	if redirected.Code != http.StatusFound {
+		t.Fatalf("expected a redirect, got %d: %s", redirected.Code, redirected.Body.String())
+	}
+	if expected, actual := "http://prow.example.com/view/gs/job", redirected.Header().Get("Location"); expected != actual {
+		t.Errorf("expected redirect to %s, got %s", expected, actual)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/rerun", nil)
+	var loggedIn string
+	for _, cookie := range redirected.Result().Cookies() {
+		request.AddCookie(cookie)
+		if cookie.Name == loginCookie {
+			loggedIn = cookie.Value
+		}
+	}
+	if loggedIn != "alice" {
+		t.Errorf("expected the %s cookie to hold alice, got %q", loginCookie, loggedIn)
+	}
+	identity, err := agent.GetIdentity(request)
+	if err != nil {
+		t.Fatalf("failed to get identity: %v", err)
+	}
+	if diff := cmp.Diff(&Identity{Login: "alice", Groups: []string{"ci-admins", "developers"}}, identity); diff != "" {
+		t.Errorf("identity differs from expected (-want +got):\n%s", diff)
+	}
+
+	if _, err := agent.GetIdentity(httptest.NewRequest(http.MethodPost, "/rerun", nil)); err == nil {
+		t.Error("expected an error for a request without identity")
+	}
+}
+
+func TestHandleRedirectRejectsInvalidState(t *testing.T) {
+	provider := fakeProvider(t, map[string]interface{}{"preferred_username": "alice"})
+	defer provider.Close()
+
+	config := &Config{
+		Issuer:       provider.URL,
+		ClientID:     "deck",
+		ClientSecret: "secret",
+		RedirectURL:  "https://prow.example.com/oidc-login/redirect",
+		CookieStore:  sessions.NewCookieStore([]byte("cookie-secret")),
+	}
+	agent, err := NewAgent(config, provider.Client(), logrus.WithField("client", "oidcauth"))
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	login := httptest.NewRecorder()
+	agent.HandleLogin(false).ServeHTTP(login, httptest.NewRequest(http.MethodGet, "/oidc-login", nil))
+
+	redirect := httptest.NewRequest(http.MethodGet, "/oidc-login/redirect?code=the-code&state=forged", nil)
+	for _, cookie := range login.Result().Cookies() {
+		redirect.AddCookie(cookie)
+	}
+	redirected := httptest.NewRecorder()
+	agent.HandleRedirect(false).ServeHTTP(redirected, redirect)
+	if redirected.Code != http.StatusInternalServerError {
+		t.Errorf("expected the redirect to fail, got %d", redirected.Code)
+	}
+}
+
+func TestIdentityFromClaims(t *testing.T) {
+	testCases := []struct {
+		name          string
+		claims        map[string]interface{}
+		usernameClaim string
+		groupsClaim   string
+		expected      *Identity
+		expectedErr   bool
+	}{
+		{
+			name:     "default claims",
+			claims:   map[string]interface{}{"preferred_username": "alice", "groups": []interface{}{"admins", 1}},
+			expected: &Identity{Login: "alice", Groups: []string{"admins"}},
+		},
+		{
+			name:          "custom claims",
+			claims:        map[string]interface{}{"email": "alice@example.com", "roles": "admins"},
+			usernameClaim: "email",
+			groupsClaim:   "roles",
+			expected:      &Identity{Login: "alice@example.com", Groups: []string{"admins"}},
+		},
+		{
+			name:     "no groups",
+			claims:   map[string]interface{}{"preferred_username": "alice"},
+			expected: &Identity{Login: "alice"},
+		},
+		{
+			name:        "no username",
+			claims:      map[string]interface{}{"email": "alice@example.com"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid groups",
+			claims:      map[string]interface{}{"preferred_username": "alice", "groups": 1},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := identityFromClaims(tc.claims, tc.usernameClaim, tc.groupsClaim)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expected, identity); diff != "" {
+				t.Errorf("identity differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCapGroups(t *testing.T) {
+	group := strings.Repeat("g", 1200)
+	testCases := []struct {
+		name            string
+		groups          []string
+		expected        []string
+		expectedDropped int
+	}{
+		{
+			name:     "groups fit",
+			groups:   []string{"admins", "developers"},
+			expected: []string{"admins", "developers"},
+		},
+		{
+			name:            "groups exceeding the cap are dropped",
+			groups:          []string{"admins", group, group, "developers"},
+			expected:        []string{"admins", group},
+			expectedDropped: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			groups, dropped := capGroups(tc.groups)
+			if diff := cmp.Diff(tc.expected, groups); diff != "" {
+				t.Errorf("groups differ from expected (-want +got):\n%s", diff)
+			}
+			if dropped != tc.expectedDropped {
+				t.Errorf("expected %d dropped groups, got %d", tc.expectedDropped, dropped)
+			}
+		})
+	}
+}
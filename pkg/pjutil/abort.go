@@ -101,6 +101,8 @@ func TerminateOlderJobs(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJo
 		prevPJ := toCancel.DeepCopy()
 
 		toCancel.Status.State = prowapi.AbortedState
+		toCancel.Status.FailureReason = prowapi.AbortedFailure
+		toCancel.Status.FailureMessage = "Superseded by a newer run of the job."
 		if toCancel.Status.PrevReportStates == nil {
 			toCancel.Status.PrevReportStates = map[string]prowapi.ProwJobState{}
 		}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package factory creates ProwJobs from the job configuration the way mkpj
+// does, for tooling that needs to create them programmatically.
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+// GitHubClient is used to default the refs of a request.
+type GitHubClient interface {
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetRef(org, repo, ref string) (string, error)
+}
+
+// Request describes the ProwJob to create. Refs that are not set are defaulted
+// from GitHub: the pull request determines the refs of a presubmit and the
+// base ref determines the base SHA of a postsubmit.
+type Request struct {
+	// JobName is the name of the presubmit, postsubmit or periodic.
+	JobName string
+	// Org and Repo restrict the search for presubmits and postsubmits. They
+	// are required if jobs of several repositories share the name.
+	Org  string
+	Repo string
+
+	BaseRef     string
+	BaseSHA     string
+	PullNumber  int
+	PullSHA     string
+	PullAuthor  string
+	PullHeadRef string
+}
+
+// Job is a job found in the configuration.
+type Job struct {
+	config.JobBase
+	Type prowapi.ProwJobType
+	// Org and Repo are empty for periodics.
+	Org  string
+	Repo string
+
+	presubmit  *config.Presubmit
+	postsubmit *config.Postsubmit
+	periodic   *config.Periodic
+}
+
+// Factory creates ProwJobs from the job configuration.
+type Factory struct {
+	config *config.Config
+	gc     GitHubClient
+}
+
+// New returns a Factory for the jobs of the configuration. The GitHub client
+// is only used to default refs and may be nil if requests are complete.
+func New(cfg *config.Config, gc GitHubClient) *Factory {
+	return &Factory{config: cfg, gc: gc}
+}
+
+// Job finds the job of the request in the configuration.
+func (f *Factory) Job(r Request) (*Job, error) {
+	if r.JobName == "" {
+		return nil, errors.New("a job name is required")
+	}
+	var matches []*Job
+	for _, orgRepo := range sortedKeys(f.config.PresubmitsStatic) {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil || !r.matches(org, repo) {
+			continue
+		}
+		for _, p := range f.config.PresubmitsStatic[orgRepo] {
+			if p.Name == r.JobName {
+				p := p
+				matches = append(matches, &Job{JobBase: p.JobBase, Type: prowapi.PresubmitJob, Org: org, Repo: repo, presubmit: &p})
+			}
+		}
+	}
+	for _, orgRepo := range sortedKeys(f.config.PostsubmitsStatic) {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil || !r.matches(org, repo) {
+			continue
+		}
+		for _, p := range f.config.PostsubmitsStatic[orgRepo] {
+			if p.Name == r.JobName {
+				p := p
+				matches = append(matches, &Job{JobBase: p.JobBase, Type: prowapi.PostsubmitJob, Org: org, Repo: repo, postsubmit: &p})
+			}
+		}
+	}
+	if r.Org == "" && r.Repo == "" {
+		for _, p := range f.config.Periodics {
+			if p.Name == r.JobName {
+				p := p
+				matches = append(matches, &Job{JobBase: p.JobBase, Type: prowapi.PeriodicJob, periodic: &p})
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("job %s not found", r.JobName)
+	case 1:
+		return matches[0], nil
+	}
+	var candidates []string
+	for _, m := range matches {
+		if m.Type == prowapi.PeriodicJob {
+			candidates = append(candidates, string(m.Type))
+			continue
+		}
+		candidates = append(candidates, fmt.Sprintf("%s of %s/%s", m.Type, m.Org, m.Repo))
+	}
+	return nil, fmt.Errorf("job %s is ambiguous, set the org and repo to choose one of: %s", r.JobName, strings.Join(candidates, ", "))
+}
+
+func (r Request) matches(org, repo string) bool {
+	return (r.Org == "" || r.Org == org) && (r.Repo == "" || r.Repo == repo)
+}
+
+// ProwJob creates the ProwJob of the request. The configured defaults and
+// decoration are applied, refs are defaulted from GitHub and the result is
+// validated.
+func (f *Factory) ProwJob(r Request) (*prowapi.ProwJob, error) {
+	job, err := f.Job(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec prowapi.ProwJobSpec
+	switch job.Type {
+	case prowapi.PresubmitJob:
+		refs, err := f.presubmitRefs(job, r)
+		if err != nil {
+			return nil, err
+		}
+		if !job.presubmit.CouldRun(refs.BaseRef) {
+			return nil, fmt.Errorf("presubmit %s does not run against branch %s", job.Name, refs.BaseRef)
+		}
+		spec = pjutil.PresubmitSpec(*job.presubmit, *refs)
+	case prowapi.PostsubmitJob:
+		refs, err := f.postsubmitRefs(job, r)
+		if err != nil {
+			return nil, err
+		}
+		if !job.postsubmit.CouldRun(refs.BaseRef) {
+			return nil, fmt.Errorf("postsubmit %s does not run against branch %s", job.Name, refs.BaseRef)
+		}
+		spec = pjutil.PostsubmitSpec(*job.postsubmit, *refs)
+	case prowapi.PeriodicJob:
+		spec = pjutil.PeriodicSpec(*job.periodic)
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return nil, fmt.Errorf("invalid ProwJob for %s %s: %w", job.Type, job.Name, err)
+	}
+	pj := pjutil.NewProwJob(spec, job.Labels, job.Annotations, pjutil.RequireScheduling(f.config.Scheduler.Enabled))
+	return &pj, nil
+}
+
+func (f *Factory) presubmitRefs(job *Job, r Request) (*prowapi.Refs, error) {
+	if r.PullNumber == 0 {
+		return nil, fmt.Errorf("a pull request number is required for presubmit %s", job.Name)
+	}
+	refs := &prowapi.Refs{
+		Org:     job.Org,
+		Repo:    job.Repo,
+		BaseRef: r.BaseRef,
+		BaseSHA: r.BaseSHA,
+		Pulls: []prowapi.Pull{{
+			Number:  r.PullNumber,
+			Author:  r.PullAuthor,
+			SHA:     r.PullSHA,
+			HeadRef: r.PullHeadRef,
+		}},
+	}
+	pull := &refs.Pulls[0]
+	if refs.BaseRef != "" && refs.BaseSHA != "" && pull.Author != "" && pull.SHA != "" && pull.HeadRef != "" {
+		return refs, nil
+	}
+	if f.gc == nil {
+		return nil, errors.New("refs are incomplete and no GitHub client was provided to default them")
+	}
+	pr, err := f.gc.GetPullRequest(job.Org, job.Repo, r.PullNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pull request %s/%s#%d: %w", job.Org, job.Repo, r.PullNumber, err)
+	}
+	setDefault(&refs.BaseRef, pr.Base.Ref)
+	setDefault(&refs.BaseSHA, pr.Base.SHA)
+	setDefault(&pull.Author, pr.User.Login)
+	setDefault(&pull.SHA, pr.Head.SHA)
+	setDefault(&pull.HeadRef, pr.Head.Ref)
+	return refs, nil
+}
+
+func (f *Factory) postsubmitRefs(job *Job, r Request) (*prowapi.Refs, error) {
+	if r.BaseRef == "" {
+		return nil, fmt.Errorf("a base ref is required for postsubmit %s", job.Name)
+	}
+	refs := &prowapi.Refs{Org: job.Org, Repo: job.Repo, BaseRef: r.BaseRef, BaseSHA: r.BaseSHA}
+	if refs.BaseSHA != "" {
+		return refs, nil
+	}
+	if f.gc == nil {
+		return nil, errors.New("refs are incomplete and no GitHub client was provided to default them")
+	}
+	sha, err := f.gc.GetRef(job.Org, job.Repo, "heads/"+r.BaseRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the SHA of %s in %s/%s: %w", r.BaseRef, job.Org, job.Repo, err)
+	}
+	refs.BaseSHA = sha
+	return refs, nil
+}
+
+func validateSpec(spec prowapi.ProwJobSpec) error {
+	if spec.Type != prowapi.PeriodicJob {
+		if spec.Refs == nil {
+			return errors.New("refs must be set")
+		}
+		if spec.Refs.BaseRef == "" || spec.Refs.BaseSHA == "" {
+			return errors.New("base ref and base SHA must be set")
+		}
+	}
+	if spec.Type == prowapi.PresubmitJob {
+		for _, pull := range spec.Refs.Pulls {
+			if pull.Number == 0 || pull.SHA == "" {
+				return errors.New("number and SHA of the pull request must be set")
+			}
+		}
+	}
+	if spec.DecorationConfig != nil {
+		if err := spec.DecorationConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid decoration config: %w", err)
+		}
+	}
+	return nil
+}
+
+// ParsePullRequestURL parses the org, repo and number of a pull request from
+// its URL, e.g. https://github.com/org/repo/pull/123.
+func ParsePullRequestURL(raw string) (org, repo string, number int, err error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid pull request URL %q: %w", raw, err)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return "", "", 0, fmt.Errorf("invalid pull request URL %q, expected a path like /org/repo/pull/123", raw)
+	}
+	number, err = strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return "", "", 0, fmt.Errorf("invalid pull request number %q in URL %q", parts[3], raw)
+	}
+	return parts[0], parts[1], number, nil
+}
+
+func setDefault(s *string, value string) {
+	if *s == "" {
+		*s = value
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	decoration := &prowapi.DecorationConfig{
+		UtilityImages: &prowapi.UtilityImages{
+			CloneRefs:  "clonerefs",
+			InitUpload: "initupload",
+			Entrypoint: "entrypoint",
+			Sidecar:    "sidecar",
+		},
+		GCSConfiguration: &prowapi.GCSConfiguration{
+			Bucket:       "bucket",
+			PathStrategy: prowapi.PathStrategyExplicit,
+		},
+	}
+	cfg := &config.Config{}
+	if err := cfg.SetPresubmits(map[string][]config.Presubmit{
+		"org/repo": {
+			{JobBase: config.JobBase{Name: "unit", UtilityConfig: config.UtilityConfig{DecorationConfig: decoration, PathAlias: "example.com/repo"}}},
+			{JobBase: config.JobBase{Name: "release-only"}, Brancher: config.Brancher{Branches: []string{"release"}}},
+			{JobBase: config.JobBase{Name: "shared"}},
+			{JobBase: config.JobBase{Name: "broken", UtilityConfig: config.UtilityConfig{DecorationConfig: &prowapi.DecorationConfig{}}}},
+		},
+		"org/other": {
+			{JobBase: config.JobBase{Name: "shared"}},
+		},
+	}); err != nil {
+		t.Fatalf("failed to set presubmits: %v", err)
+	}
+	if err := cfg.SetPostsubmits(map[string][]config.Postsubmit{
+		"org/repo": {{JobBase: config.JobBase{Name: "push"}}},
+	}); err != nil {
+		t.Fatalf("failed to set postsubmits: %v", err)
+	}
+	cfg.Periodics = []config.Periodic{{JobBase: config.JobBase{Name: "nightly", Labels: map[string]string{"team": "infra"}}}}
+	return cfg
+}
+
+func fakeClient() *fakegithub.FakeClient {
+	gc := fakegithub.NewFakeClient()
+	gc.PullRequests = map[int]*github.PullRequest{
+		2: {
+			User: github.User{Login: "alice"},
+			Base: github.PullRequestBranch{Ref: "main", SHA: "base"},
+			Head: github.PullRequestBranch{Ref: "feature", SHA: "head"},
+		},
+	}
+	return gc
+}
+
+func TestProwJob(t *testing.T) {
+	testCases := []struct {
+		name         string
+		request      Request
+		expectedType prowapi.ProwJobType
+		expectedRefs *prowapi.Refs
+		expectedErr  bool
+	}{
+		{
+			name:         "presubmit refs are defaulted from the pull request",
+			request:      Request{JobName: "unit", PullNumber: 2},
+			expectedType: prowapi.PresubmitJob,
+			expectedRefs: &prowapi.Refs{
+				Org:       "org",
+				Repo:      "repo",
+				BaseRef:   "main",
+				BaseSHA:   "base",
+				PathAlias: "example.com/repo",
+				Pulls:     []prowapi.Pull{{Number: 2, Author: "alice", SHA: "head", HeadRef: "feature"}},
+			},
+		},
+		{
+			name:         "refs of the request take precedence",
+			request:      Request{JobName: "unit", PullNumber: 2, PullSHA: "older"},
+			expectedType: prowapi.PresubmitJob,
+			expectedRefs: &prowapi.Refs{
+				Org:       "org",
+				Repo:      "repo",
+				BaseRef:   "main",
+				BaseSHA:   "base",
+				PathAlias: "example.com/repo",
+				Pulls:     []prowapi.Pull{{Number: 2, Author: "alice", SHA: "older", HeadRef: "feature"}},
+			},
+		},
+		{
+			name:        "presubmit requires a pull request",
+			request:     Request{JobName: "unit"},
+			expectedErr: true,
+		},
+		{
+			name:        "presubmit does not run against the branch",
+			request:     Request{JobName: "release-only", PullNumber: 2},
+			expectedErr: true,
+		},
+		{
+			name:        "ambiguous job",
+			request:     Request{JobName: "shared", PullNumber: 2},
+			expectedErr: true,
+		},
+		{
+			name:         "ambiguous job with repo",
+			request:      Request{JobName: "shared", Org: "org", Repo: "other", PullNumber: 2},
+			expectedType: prowapi.PresubmitJob,
+			expectedRefs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "other",
+				BaseRef: "main",
+				BaseSHA: "base",
+				Pulls:   []prowapi.Pull{{Number: 2, Author: "alice", SHA: "head", HeadRef: "feature"}},
+			},
+		},
+		{
+			name:        "invalid decoration config",
+			request:     Request{JobName: "broken", PullNumber: 2},
+			expectedErr: true,
+		},
+		{
+			name:         "postsubmit base SHA is defaulted from the base ref",
+			request:      Request{JobName: "push", BaseRef: "main"},
+			expectedType: prowapi.PostsubmitJob,
+			expectedRefs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: fakegithub.TestRef},
+		},
+		{
+			name:        "postsubmit requires a base ref",
+			request:     Request{JobName: "push"},
+			expectedErr: true,
+		},
+		{
+			name:         "periodic",
+			request:      Request{JobName: "nightly"},
+			expectedType: prowapi.PeriodicJob,
+		},
+		{
+			name:        "unknown job",
+			request:     Request{JobName: "missing"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj, err := New(testConfig(t), fakeClient()).ProwJob(tc.request)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if pj.Spec.Type != tc.expectedType {
+				t.Errorf("expected type %s, got %s", tc.expectedType, pj.Spec.Type)
+			}
+			if diff := cmp.Diff(tc.expectedRefs, pj.Spec.Refs); diff != "" {
+				t.Errorf("refs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProwJobWithoutGitHub(t *testing.T) {
+	f := New(testConfig(t), nil)
+	if _, err := f.ProwJob(Request{JobName: "unit", PullNumber: 2}); err == nil {
+		t.Error("expected an error for incomplete refs without a GitHub client")
+	}
+	pj, err := f.ProwJob(Request{JobName: "nightly"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pj.Labels["team"] != "infra" {
+		t.Errorf("expected the labels of the job, got %v", pj.Labels)
+	}
+}
+
+func TestParsePullRequestURL(t *testing.T) {
+	testCases := []struct {
+		url            string
+		expectedOrg    string
+		expectedRepo   string
+		expectedNumber int
+		expectedErr    bool
+	}{
+		{url: "https://github.com/org/repo/pull/123", expectedOrg: "org", expectedRepo: "repo", expectedNumber: 123},
+		{url: "https://github.com/org/repo/pull/123/files", expectedOrg: "org", expectedRepo: "repo", expectedNumber: 123},
+		{url: "https://github.com/org/repo/issues/123", expectedErr: true},
+		{url: "https://github.com/org/repo/pull/abc", expectedErr: true},
+		{url: "https://github.com/org/repo", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			org, repo, number, err := ParsePullRequestURL(tc.url)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if org != tc.expectedOrg || repo != tc.expectedRepo || number != tc.expectedNumber {
+				t.Errorf("expected %s/%s#%d, got %s/%s#%d", tc.expectedOrg, tc.expectedRepo, tc.expectedNumber, org, repo, number)
+			}
+		})
+	}
+}
@@ -29,6 +29,9 @@ import (
 
 var TestAllRe = regexp.MustCompile(`(?m)^/test all,?($|\s.*)`)
 
+// TestRequiredRe provides the regex for `/test required`
+var TestRequiredRe = regexp.MustCompile(`(?m)^/test required\s*$`)
+
 // RetestRe provides the regex for `/retest`
 var RetestRe = regexp.MustCompile(`(?m)^/retest\s*$`)
 
@@ -76,6 +79,67 @@ func AvailablePresubmits(changes config.ChangedFilesProvider, branch string,
 	return runWithTestAllNames, optionalJobTriggerCommands, requiredJobsTriggerCommands, nil
 }
 
+// PresubmitGroups holds the trigger commands of the presubmits available for
+// a change, grouped by how they are triggered.
+type PresubmitGroups struct {
+	// AlwaysRun are required jobs that run for every change.
+	AlwaysRun sets.Set[string]
+	// RunIfChanged are required jobs that run because the changed files match
+	// their run_if_changed or skip_if_only_changed configuration.
+	RunIfChanged sets.Set[string]
+	// RunIfChangedNotMatching are required jobs that do not run because the
+	// changed files do not match their configuration.
+	RunIfChangedNotMatching sets.Set[string]
+	// NotTriggeredAutomatically are required jobs that are neither started by
+	// `/test all` nor `/test required`.
+	NotTriggeredAutomatically sets.Set[string]
+	// Optional are jobs that are not required for merge.
+	Optional sets.Set[string]
+}
+
+// AvailablePresubmitGroups returns the trigger commands of all presubmits
+// that can be triggered for a change, grouped by how they are triggered.
+func AvailablePresubmitGroups(changes config.ChangedFilesProvider, branch string, presubmits []config.Presubmit, logger *logrus.Entry) (PresubmitGroups, error) {
+	groups := PresubmitGroups{
+		AlwaysRun:                 sets.New[string](),
+		RunIfChanged:              sets.New[string](),
+		RunIfChangedNotMatching:   sets.New[string](),
+		NotTriggeredAutomatically: sets.New[string](),
+		Optional:                  sets.New[string](),
+	}
+
+	var triggerFilters []Filter
+	for _, ps := range presubmits {
+		triggerFilters = append(triggerFilters, NewCommandFilter(ps.RerunCommand))
+	}
+	runWithTrigger, err := FilterPresubmits(NewAggregateFilter(triggerFilters), changes, branch, presubmits, logger)
+	if err != nil {
+		return groups, err
+	}
+
+	for _, ps := range runWithTrigger {
+		switch {
+		case ps.Optional:
+			groups.Optional.Insert(ps.RerunCommand)
+		case ps.NeedsExplicitTrigger() || ps.RunsAfterSuccess():
+			groups.NotTriggeredAutomatically.Insert(ps.RerunCommand)
+		case ps.AlwaysRun:
+			groups.AlwaysRun.Insert(ps.RerunCommand)
+		default:
+			determined, shouldRun, err := ps.RegexpChangeMatcher.ShouldRun(changes)
+			if err != nil {
+				return groups, fmt.Errorf("%s: should run: %w", ps.Name, err)
+			}
+			if determined && shouldRun {
+				groups.RunIfChanged.Insert(ps.RerunCommand)
+			} else {
+				groups.RunIfChangedNotMatching.Insert(ps.RerunCommand)
+			}
+		}
+	}
+	return groups, nil
+}
+
 // Filter digests a presubmit config to determine if:
 //   - the presubmit matched the filter
 //   - we know that the presubmit is forced to run
@@ -134,7 +198,8 @@ func (cf *CommandFilter) Name() string {
 
 // TestAllFilter builds a filter for the automatic behavior of `/test all`.
 // Jobs that explicitly match `/test all` in their trigger regex will be
-// handled by a commandFilter for the comment in question.
+// handled by a commandFilter for the comment in question. Jobs that run
+// after the success of other jobs are not matched.
 type TestAllFilter struct{}
 
 func NewTestAllFilter() *TestAllFilter {
@@ -142,13 +207,34 @@ func NewTestAllFilter() *TestAllFilter {
 }
 
 func (tf *TestAllFilter) ShouldRun(p config.Presubmit) (bool, bool, bool) {
-	return !p.NeedsExplicitTrigger(), false, false
+	// Jobs that run after the success of other jobs are triggered by the
+	// dependent-jobs controller instead.
+	return !p.NeedsExplicitTrigger() && !p.RunsAfterSuccess(), false, false
 }
 
 func (tf *TestAllFilter) Name() string {
 	return "test-all-filter"
 }
 
+// TestRequiredFilter builds a filter for `/test required`. It matches the jobs
+// that `/test all` matches, except for optional ones.
+type TestRequiredFilter struct{}
+
+func NewTestRequiredFilter() *TestRequiredFilter {
+	return &TestRequiredFilter{}
+}
+
+func (tf *TestRequiredFilter) ShouldRun(p config.Presubmit) (bool, bool, bool) {
+	if p.Optional {
+		return false, false, false
+	}
+	return NewTestAllFilter().ShouldRun(p)
+}
+
+func (tf *TestRequiredFilter) Name() string {
+	return "test-required-filter"
+}
+
 // AggregateFilter builds a filter that evaluates the child filters in order
 // and returns the first match
 type AggregateFilter struct {
@@ -217,6 +303,33 @@ func FilterPresubmits(filter Filter, changes config.ChangedFilesProvider, branch
 	return toTrigger, nil
 }
 
+// FilterPresubmitsByContent removes the presubmits that the filter does not force
+// to run and whose run_if_changed_content does not match the changed lines. These
+// are returned separately. The changes are only fetched if any presubmit needs them.
+func FilterPresubmitsByContent(filter Filter, changes config.ChangedContentProvider, presubmits []config.Presubmit) ([]config.Presubmit, []config.Presubmit, error) {
+	var toTrigger, skipped []config.Presubmit
+	for _, presubmit := range presubmits {
+		if presubmit.RunIfChangedContent == "" {
+			toTrigger = append(toTrigger, presubmit)
+			continue
+		}
+		if _, forced, defaults := filter.ShouldRun(presubmit); forced || defaults {
+			toTrigger = append(toTrigger, presubmit)
+			continue
+		}
+		changeList, err := changes()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: should run: %w", presubmit.Name, err)
+		}
+		if presubmit.RunsAgainstContent(changeList) {
+			toTrigger = append(toTrigger, presubmit)
+		} else {
+			skipped = append(skipped, presubmit)
+		}
+	}
+	return toTrigger, skipped, nil
+}
+
 // RetestFilter builds a filter for `/retest`
 type RetestFilter struct {
 	failedContexts, allContexts sets.Set[string]
@@ -231,7 +344,7 @@ func NewRetestFilter(failedContexts, allContexts sets.Set[string]) *RetestFilter
 
 func (rf *RetestFilter) ShouldRun(p config.Presubmit) (bool, bool, bool) {
 	failed := rf.failedContexts.Has(p.Context)
-	return failed || (!p.NeedsExplicitTrigger() && !rf.allContexts.Has(p.Context)), false, failed
+	return failed || (!p.NeedsExplicitTrigger() && !p.RunsAfterSuccess() && !rf.allContexts.Has(p.Context)), false, failed
 }
 
 func (rf *RetestFilter) Name() string {
@@ -292,5 +405,9 @@ func PresubmitFilter(honorOkToTest bool, contextGetter contextGetter, body strin
 		logger.Debug("Using test-all filter.")
 		filters = append(filters, NewTestAllFilter())
 	}
+	if TestRequiredRe.MatchString(body) {
+		logger.Debug("Using test-required filter.")
+		filters = append(filters, NewTestRequiredFilter())
+	}
 	return NewAggregateFilter(filters), nil
 }
@@ -85,8 +85,15 @@ func TestTestAllFilter(t *testing.T) {
 					Trigger:      `(?m)^/test (?:.*? )?all(?: .*?)?$`,
 					RerunCommand: "/test all",
 				},
+				{
+					JobBase: config.JobBase{
+						Name: "runs-after-success",
+					},
+					AlwaysRun:       true,
+					RunAfterSuccess: []string{"always-runs"},
+				},
 			},
-			expected: [][]bool{{true, false, false}, {true, false, false}, {true, false, false}, {false, false, false}, {false, false, false}},
+			expected: [][]bool{{true, false, false}, {true, false, false}, {true, false, false}, {false, false, false}, {false, false, false}, {false, false, false}},
 		},
 	}
 
@@ -116,6 +123,41 @@ func TestTestAllFilter(t *testing.T) {
 	}
 }
 
+func TestTestRequiredFilter(t *testing.T) {
+	presubmits := []config.Presubmit{
+		{
+			JobBase:   config.JobBase{Name: "always-runs"},
+			AlwaysRun: true,
+		},
+		{
+			JobBase:   config.JobBase{Name: "optional"},
+			AlwaysRun: true,
+			Optional:  true,
+		},
+		{
+			JobBase:             config.JobBase{Name: "runs-if-changed"},
+			RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "sometimes"},
+		},
+		{
+			JobBase:      config.JobBase{Name: "runs-if-triggered"},
+			Reporter:     config.Reporter{Context: "runs-if-triggered"},
+			Trigger:      `(?m)^/test (?:.*? )?trigger(?: .*?)?$`,
+			RerunCommand: "/test trigger",
+		},
+	}
+	expected := [][]bool{{true, false, false}, {false, false, false}, {true, false, false}, {false, false, false}}
+	if err := config.SetPresubmitRegexes(presubmits); err != nil {
+		t.Fatalf("could not set presubmit regexes: %v", err)
+	}
+	filter := NewTestRequiredFilter()
+	for i, presubmit := range presubmits {
+		actualFiltered, actualForced, actualDefault := filter.ShouldRun(presubmit)
+		if actual := []bool{actualFiltered, actualForced, actualDefault}; !reflect.DeepEqual(actual, expected[i]) {
+			t.Errorf("expected %v for %s, got %v", expected[i], presubmit.Name, actual)
+		}
+	}
+}
+
 func TestCommandFilter(t *testing.T) {
 	var testCases = []struct {
 		name         string
@@ -417,6 +459,85 @@ func TestFilterPresubmits(t *testing.T) {
 	}
 }
 
+func TestFilterPresubmitsByContent(t *testing.T) {
+	presubmits := []config.Presubmit{
+		{
+			JobBase: config.JobBase{Name: "unit"},
+		},
+		{
+			JobBase: config.JobBase{Name: "api-compat"},
+			RegexpChangeMatcher: config.RegexpChangeMatcher{
+				RunIfChanged:        `^pkg/apis/`,
+				RunIfChangedContent: `^type .* struct`,
+			},
+		},
+	}
+	if err := config.SetPresubmitRegexes(presubmits); err != nil {
+		t.Fatalf("failed to set presubmit regexes: %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		filter          Filter
+		changes         []github.PullRequestChange
+		changesErr      error
+		expectedTrigger []string
+		expectedSkipped []string
+		expectErr       bool
+	}{
+		{
+			name:            "content matches",
+			filter:          NewTestAllFilter(),
+			changes:         []github.PullRequestChange{{Filename: "pkg/apis/types.go", Patch: "@@ -1 +1,2 @@\n package apis\n+type Foo struct{}"}},
+			expectedTrigger: []string{"unit", "api-compat"},
+		},
+		{
+			name:            "content does not match",
+			filter:          NewTestAllFilter(),
+			changes:         []github.PullRequestChange{{Filename: "pkg/apis/types.go", Patch: "@@ -1 +1,2 @@\n package apis\n+// Foo is a comment."}},
+			expectedTrigger: []string{"unit"},
+			expectedSkipped: []string{"api-compat"},
+		},
+		{
+			name:            "forced job is not filtered and changes are not fetched",
+			filter:          NewCommandFilter("/test api-compat"),
+			changesErr:      errors.New("injected error"),
+			expectedTrigger: []string{"unit", "api-compat"},
+		},
+		{
+			name:       "error fetching changes",
+			filter:     NewTestAllFilter(),
+			changesErr: errors.New("injected error"),
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes := func() ([]github.PullRequestChange, error) {
+				return tc.changes, tc.changesErr
+			}
+			toTrigger, skipped, err := FilterPresubmitsByContent(tc.filter, changes, presubmits)
+			if err != nil != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			var triggerNames, skippedNames []string
+			for _, ps := range toTrigger {
+				triggerNames = append(triggerNames, ps.Name)
+			}
+			for _, ps := range skipped {
+				skippedNames = append(skippedNames, ps.Name)
+			}
+			if diff := cmp.Diff(tc.expectedTrigger, triggerNames); diff != "" {
+				t.Errorf("presubmits to trigger differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSkipped, skippedNames); diff != "" {
+				t.Errorf("skipped presubmits differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 type orgRepoRef struct {
 	org, repo, ref string
 }
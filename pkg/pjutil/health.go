@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/readiness"
 )
 
 const healthPort = 8081
@@ -53,9 +54,16 @@ func NewHealthOnPort(port int) *Health {
 
 type ReadinessCheck func() bool
 
-// ServeReady starts serving the readiness endpoint
+// ServeReady starts serving the readiness endpoint. The component is only
+// reported ready once the gates registered with the readiness package passed
+// and all readinessChecks pass.
 func (h *Health) ServeReady(readinessChecks ...ReadinessCheck) {
 	h.healthMux.HandleFunc("/healthz/ready", func(w http.ResponseWriter, r *http.Request) {
+		if err := readiness.Ready(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, err.Error())
+			return
+		}
 		for _, readinessCheck := range readinessChecks {
 			if !readinessCheck() {
 				w.WriteHeader(http.StatusServiceUnavailable)
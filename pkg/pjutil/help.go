@@ -30,10 +30,11 @@ var (
 	RetestWithTargetRe  = regexp.MustCompile(`(?m)^/retest[ \t]+\S+`)
 	TestWithAnyTargetRe = regexp.MustCompile(`(?m)^/test[ \t]+\S+`)
 
-	TestWithoutTargetNote     = "The `/test` command needs one or more targets.\n"
-	RetestWithTargetNote      = "The `/retest` command does not accept any targets.\n"
-	TargetNotFoundNote        = "The specified target(s) for `/test` were not found.\n"
-	ThereAreNoTestAllJobsNote = "No jobs can be run with `/test all`.\n"
+	TestWithoutTargetNote      = "The `/test` command needs one or more targets.\n"
+	RetestWithTargetNote       = "The `/retest` command does not accept any targets.\n"
+	TargetNotFoundNote         = "The specified target(s) for `/test` were not found.\n"
+	ThereAreNoTestAllJobsNote  = "No jobs can be run with `/test all`.\n"
+	ThereAreNoRequiredJobsNote = "No required jobs can be run with `/test required`.\n"
 )
 
 func MayNeedHelpComment(body string) bool {
@@ -53,6 +54,8 @@ func ShouldRespondWithHelp(body string, toRunOrSkip int) (bool, string) {
 		return true, RetestWithTargetNote
 	case toRunOrSkip == 0 && TestAllRe.MatchString(body):
 		return true, ThereAreNoTestAllJobsNote
+	case toRunOrSkip == 0 && TestRequiredRe.MatchString(body):
+		return true, ThereAreNoRequiredJobsNote
 	case toRunOrSkip == 0 && TestWithAnyTargetRe.MatchString(body):
 		return true, TargetNotFoundNote
 	default:
@@ -95,3 +98,39 @@ func HelpMessage(org, repo, branch, note string, testAllNames, optionalTestComma
 
 	return resp
 }
+
+// PresubmitGroupsMessage returns the response to `/test ?`, which lists the
+// commands of all available presubmits grouped by how they are triggered.
+func PresubmitGroupsMessage(org, repo, branch string, groups PresubmitGroups) string {
+	sections := []struct {
+		title    string
+		commands sets.Set[string]
+	}{
+		{title: "Required jobs that always run", commands: groups.AlwaysRun},
+		{title: "Required jobs that run because of the changed files", commands: groups.RunIfChanged},
+		{title: "Required jobs that are not run for the changed files", commands: groups.RunIfChangedNotMatching},
+		{title: "Required jobs that are not triggered by `/test all`", commands: groups.NotTriggeredAutomatically},
+		{title: "Optional jobs", commands: groups.Optional},
+	}
+
+	// Commands shared by several jobs are only listed in the first section.
+	listed := sets.New[string]()
+	var resp strings.Builder
+	for _, section := range sections {
+		commands := section.commands.Difference(listed)
+		if commands.Len() == 0 {
+			continue
+		}
+		listed = listed.Union(commands)
+		resp.WriteString(section.title + ":")
+		for _, command := range sets.List(commands) {
+			resp.WriteString(fmt.Sprintf("\n* `%s`", command))
+		}
+		resp.WriteString("\n\n")
+	}
+	if resp.Len() == 0 {
+		return fmt.Sprintf("No presubmit jobs available for %s/%s@%s", org, repo, branch)
+	}
+	resp.WriteString("Use `/test all` to run all jobs that are triggered automatically, or `/test required` to only run the required ones.\n")
+	return resp.String()
+}
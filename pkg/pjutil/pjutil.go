@@ -132,11 +132,13 @@ func createRefs(pr github.PullRequest, baseSHA string) prowapi.Refs {
 		BaseRef:  pr.Base.Ref,
 		BaseSHA:  baseSHA,
 		BaseLink: fmt.Sprintf("%s/commit/%s", repoLink, baseSHA),
+		CloneURI: pr.Base.Repo.CloneURI,
 		Pulls: []prowapi.Pull{
 			{
 				Number:     number,
 				Author:     pr.User.Login,
 				SHA:        pr.Head.SHA,
+				Ref:        pr.CloneRef,
 				HeadRef:    pr.Head.Ref,
 				Title:      pr.Title,
 				Link:       pr.HTMLURL,
@@ -234,19 +236,23 @@ func specFromJobBase(jb config.JobBase) prowapi.ProwJobSpec {
 		Namespace:       namespace,
 		MaxConcurrency:  jb.MaxConcurrency,
 		ErrorOnEviction: jb.ErrorOnEviction,
+		Retry:           jb.Retry,
 
 		ExtraRefs:        DecorateExtraRefs(jb.ExtraRefs, jb),
 		DecorationConfig: jb.DecorationConfig,
 
 		PodSpec:               jb.Spec,
+		PodTemplate:           jb.PodTemplate,
 		PipelineRunSpec:       jb.PipelineRunSpec,
 		TektonPipelineRunSpec: jb.TektonPipelineRunSpec,
 
 		ReporterConfig:  jb.ReporterConfig,
 		RerunAuthConfig: jb.RerunAuthConfig,
+		RerunOverrides:  jb.RerunOverrides,
 		Hidden:          jb.Hidden,
 		ProwJobDefault:  jb.ProwJobDefault,
 		JobQueueName:    jb.JobQueueName,
+		PriorityClass:   jb.PriorityClass,
 	}
 }
 
@@ -269,6 +275,12 @@ func DecorateRefs(refs prowapi.Refs, jb config.JobBase) *prowapi.Refs {
 	if refs.BloblessFetch == nil {
 		refs.BloblessFetch = dc.BloblessFetch
 	}
+	if refs.SparseCheckout == nil {
+		refs.SparseCheckout = dc.SparseCheckout
+	}
+	if refs.ShallowSince == "" && dc.ShallowSince != nil {
+		refs.ShallowSince = *dc.ShallowSince
+	}
 	return &refs
 }
 
@@ -288,6 +300,9 @@ func CompletePrimaryRefs(refs prowapi.Refs, jb config.JobBase) *prowapi.Refs {
 	if jb.SkipFetchHead {
 		refs.SkipFetchHead = jb.SkipFetchHead
 	}
+	if jb.FetchLFS {
+		refs.FetchLFS = jb.FetchLFS
+	}
 	return DecorateRefs(refs, jb)
 }
 
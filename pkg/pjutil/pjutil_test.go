@@ -32,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/diff"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
@@ -315,6 +316,7 @@ func TestCompletePrimaryRefs(t *testing.T) {
 					SkipSubmodules: true,
 					CloneDepth:     2,
 					SkipFetchHead:  true,
+					FetchLFS:       true,
 					DecorationConfig: &prowapi.DecorationConfig{
 						BloblessFetch: boolPtr(true),
 					},
@@ -327,6 +329,7 @@ func TestCompletePrimaryRefs(t *testing.T) {
 				CloneDepth:     2,
 				SkipFetchHead:  true,
 				BloblessFetch:  boolPtr(true),
+				FetchLFS:       true,
 			},
 		},
 		{
@@ -1426,6 +1429,14 @@ func TestCreateRefs(t *testing.T) {
 	if actual := createRefs(pr, "abcdef"); !reflect.DeepEqual(expected, actual) {
 		t.Errorf("diff between expected and actual refs:%s", diff.ObjectReflectDiff(expected, actual))
 	}
+
+	pr.Base.Repo.CloneURI = "https://gitlab.example.com/kubernetes/Hello-World.git"
+	pr.CloneRef = "refs/merge-requests/42/head"
+	expected.CloneURI = pr.Base.Repo.CloneURI
+	expected.Pulls[0].Ref = pr.CloneRef
+	if actual := createRefs(pr, "abcdef"); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("diff between expected and actual refs of pull request not on GitHub:%s", diff.ObjectReflectDiff(expected, actual))
+	}
 }
 
 func TestSpecFromJobBase(t *testing.T) {
@@ -1567,6 +1578,39 @@ func TestSpecFromJobBase(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "Verify DecorateExtraRefs defaults sparse checkout and shallow since",
+			jobBase: config.JobBase{
+				UtilityConfig: config.UtilityConfig{
+					DecorationConfig: &prowapi.DecorationConfig{
+						SparseCheckout: []string{"default"},
+						ShallowSince:   ptr.To("2026-01-01"),
+					},
+					ExtraRefs: []prowapi.Refs{
+						{
+							Org:            "set-org",
+							SparseCheckout: []string{"set"},
+							ShallowSince:   "2026-06-01",
+						},
+						{
+							Org: "default-org",
+						},
+					},
+				},
+			},
+			verify: func(pj prowapi.ProwJobSpec) error {
+				for _, r := range pj.ExtraRefs {
+					want := prowapi.Refs{Org: r.Org, SparseCheckout: []string{"default"}, ShallowSince: "2026-01-01"}
+					if r.Org == "set-org" {
+						want.SparseCheckout, want.ShallowSince = []string{"set"}, "2026-06-01"
+					}
+					if diff := cmp.Diff(want, r); diff != "" {
+						return fmt.Errorf("ExtraRefs for %s differ (-want +got)\n%s", r.Org, diff)
+					}
+				}
+				return nil
+			},
+		},
 		{
 			name: "Verify DecorateExtraRefs no decoration preserves existing",
 			jobBase: config.JobBase{
@@ -702,6 +702,52 @@ func TestSyncTriggeredJobs(t *testing.T) {
 			ExpectedBuildID:     "0987654321",
 			ExpectedPodHasName:  true,
 		},
+		{
+			Name: "don't start retry before backoff passed",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+					Retry:   &prowapi.RetryConfig{MaxRetries: 1, Backoff: &metav1.Duration{Duration: time.Minute}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:         prowapi.TriggeredState,
+					Retries:       1,
+					NextRetryTime: startTime(fakeClock.Now().Add(time.Minute)),
+				},
+			},
+			ExpectedState:   prowapi.TriggeredState,
+			ExpectedNumPods: map[string]int{"default": 0},
+		},
+		{
+			Name: "start retry once backoff passed",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+					Retry:   &prowapi.RetryConfig{MaxRetries: 1, Backoff: &metav1.Duration{Duration: time.Minute}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:         prowapi.TriggeredState,
+					Retries:       1,
+					NextRetryTime: startTime(fakeClock.Now().Add(-time.Second)),
+				},
+			},
+			ExpectedState:       prowapi.PendingState,
+			ExpectedNumPods:     map[string]int{"default": 1},
+			ExpectedPendingTime: &pendingTime,
+			ExpectedPodHasName:  true,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -859,6 +905,7 @@ func TestSyncPendingJob(t *testing.T) {
 		ExpectedPodRunningTimeout     *metav1.Duration
 		ExpectedPodPendingTimeout     *metav1.Duration
 		ExpectedPodUnscheduledTimeout *metav1.Duration
+		ExpectedRetries               int
 	}
 	testcases := []testCase{
 		{
@@ -1180,6 +1227,108 @@ func TestSyncPendingJob(t *testing.T) {
 			ExpectedNumPods:  1,
 			ExpectedURL:      "boop-42/error",
 		},
+		{
+			Name: "retry job w/ error_on_eviction when pod got evicted",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					ErrorOnEviction: true,
+					Retry:           &prowapi.RetryConfig{MaxRetries: 2},
+					PodSpec:         &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "boop-42",
+						Namespace:  "pods",
+						Finalizers: []string{"prow.x-k8s.io/gcsk8sreporter"},
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.TriggeredState,
+			ExpectedNumPods:  0,
+			ExpectedRetries:  1,
+		},
+		{
+			Name: "retry failed job when failure is retriable",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Retry:   &prowapi.RetryConfig{MaxRetries: 2, RetryOn: []prowapi.ProwJobState{prowapi.FailureState}},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+					Retries: 1,
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+					},
+				},
+			},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.TriggeredState,
+			ExpectedNumPods:  0,
+			ExpectedRetries:  2,
+		},
+		{
+			Name: "don't retry failed job when retries are exhausted",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Retry:   &prowapi.RetryConfig{MaxRetries: 2, RetryOn: []prowapi.ProwJobState{prowapi.FailureState}},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+					Retries: 2,
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+					},
+				},
+			},
+			ExpectedComplete: true,
+			ExpectedState:    prowapi.FailureState,
+			ExpectedNumPods:  1,
+			ExpectedURL:      "boop-42/failure",
+			ExpectedRetries:  2,
+		},
 		{
 			Name: "running pod",
 			PJ: prowapi.ProwJob{
@@ -1746,6 +1895,9 @@ func TestSyncPendingJob(t *testing.T) {
 					t.Errorf("pod %s was deleted but still had finalizers: %v", pod.Name, pod.Finalizers)
 				}
 			}
+			if actual.Status.Retries != tc.ExpectedRetries {
+				t.Errorf("expected %d retries, got %d", tc.ExpectedRetries, actual.Status.Retries)
+			}
 			if actual := actual.Complete(); actual != tc.ExpectedComplete {
 				t.Errorf("expected complete: %t, got complete: %t", tc.ExpectedComplete, actual)
 			}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/entrypoint"
+	"sigs.k8s.io/prow/pkg/initupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+)
+
+// setFailureReason records why the job did not succeed.
+func setFailureReason(pj *prowv1.ProwJob, reason prowv1.ProwJobFailureReason, message string) {
+	pj.Status.FailureReason = reason
+	pj.Status.FailureMessage = message
+}
+
+// podFailureReason classifies why a pod failed based on the states of its
+// containers. The pod utilities log why they failed, so their termination
+// messages, which fall back to the tail of their logs, tell clone failures
+// and timeouts apart from failing tests.
+func podFailureReason(pod *corev1.Pod) (prowv1.ProwJobFailureReason, string) {
+	for _, status := range pod.Status.InitContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+		if status.Name == decorate.CloneRefsContainerName || strings.Contains(terminated.Message, initupload.ErrCloneFailed.Error()) {
+			return prowv1.CloneFailure, "Cloning the refs of the job failed."
+		}
+		return prowv1.InfraErrorFailure, fmt.Sprintf("Init container %s failed with exit code %d.", status.Name, terminated.ExitCode)
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 || decorate.PodUtilsContainerNames().Has(status.Name) {
+			continue
+		}
+		if strings.Contains(terminated.Message, entrypoint.ErrTimedOut.Error()) {
+			return prowv1.TimeoutFailure, fmt.Sprintf("Container %s did not finish before its timeout.", status.Name)
+		}
+		return prowv1.TestFailure, fmt.Sprintf("Container %s failed with exit code %d.", status.Name, terminated.ExitCode)
+	}
+
+	return prowv1.TestFailure, "Job failed."
+}
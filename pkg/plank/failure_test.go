@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func terminated(name string, exitCode int32, message string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  name,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Message: message}},
+	}
+}
+
+func TestPodFailureReason(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initContainers []corev1.ContainerStatus
+		containers     []corev1.ContainerStatus
+		expected       prowv1.ProwJobFailureReason
+	}{
+		{
+			name:           "clonerefs failed",
+			initContainers: []corev1.ContainerStatus{terminated("clonerefs", 1, "")},
+			expected:       prowv1.CloneFailure,
+		},
+		{
+			name:           "initupload reported a clone failure",
+			initContainers: []corev1.ContainerStatus{terminated("clonerefs", 0, ""), terminated("initupload", 1, `{"level":"fatal","msg":"Failed to initialize job","error":"cloning the appropriate refs failed"}`)},
+			expected:       prowv1.CloneFailure,
+		},
+		{
+			name:           "initupload failed to upload",
+			initContainers: []corev1.ContainerStatus{terminated("initupload", 1, `{"level":"fatal","msg":"Failed to initialize job","error":"failed to upload to GCS"}`)},
+			expected:       prowv1.InfraErrorFailure,
+		},
+		{
+			name:       "test timed out",
+			containers: []corev1.ContainerStatus{terminated("sidecar", 1, ""), terminated("test", 1, `{"error":"process timed out","level":"error","msg":"Error executing test process"}`)},
+			expected:   prowv1.TimeoutFailure,
+		},
+		{
+			name:       "test failed",
+			containers: []corev1.ContainerStatus{terminated("sidecar", 1, ""), terminated("test", 2, "FAIL")},
+			expected:   prowv1.TestFailure,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{
+				Phase:                 corev1.PodFailed,
+				InitContainerStatuses: tc.initContainers,
+				ContainerStatuses:     tc.containers,
+			}}
+			if reason, message := podFailureReason(pod); reason != tc.expected {
+				t.Errorf("expected failure reason %q, got %q (%s)", tc.expected, reason, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+var preemptedProwJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "plank_preempted_prowjobs",
+	Help: "Number of ProwJobs plank aborted to start a job of a higher priority class.",
+}, []string{
+	// the cluster the preempted job ran on
+	"cluster",
+	// the priority class of the preempted job
+	"priority_class",
+	// the priority class of the job that preempted it
+	"preempting_priority_class",
+})
+
+func init() {
+	prometheus.MustRegister(preemptedProwJobs)
+}
+
+// priority returns the priority of the priority class of the job.
+func (r *reconciler) priority(pj *prowv1.ProwJob) int {
+	return r.config().Plank.GetPriorityClass(pj.Spec.PriorityClass).Priority
+}
+
+// isAhead determines if the triggered job other gets started before pj when
+// they compete for the same capacity: jobs of a higher priority first, jobs
+// of the same priority in creation order.
+func isAhead(other, pj *prowv1.ProwJob, priority func(*prowv1.ProwJob) int) bool {
+	if otherPriority, pjPriority := priority(other), priority(pj); otherPriority != pjPriority {
+		return otherPriority > pjPriority
+	}
+	return other.CreationTimestamp.Before(&pj.CreationTimestamp)
+}
+
+// countHigherPriorityTriggered counts the triggered jobs with a higher
+// priority than pj that could start right away, which the remaining capacity
+// of max_concurrency is left to. Jobs that are held back by their own
+// max_concurrency or the capacity of their job queue don't reserve capacity,
+// as they would otherwise keep pj from starting without starting themselves.
+func (r *reconciler) countHigherPriorityTriggered(ctx context.Context, pj *prowv1.ProwJob, pending []prowv1.ProwJob) (int, error) {
+	if len(r.config().Plank.PriorityClasses) == 0 {
+		return 0, nil
+	}
+	pjs := &prowv1.ProwJobList{}
+	if err := r.pjClient.List(ctx, pjs, optTriggeredProwJobs()); err != nil {
+		return 0, fmt.Errorf("failed to list triggered prowjobs: %w", err)
+	}
+	pendingOrTriggered := append(append([]prowv1.ProwJob(nil), pending...), pjs.Items...)
+	priority := r.priority(pj)
+	var higher int
+	for i := range pjs.Items {
+		candidate := &pjs.Items[i]
+		if candidate.UID != pj.UID && r.priority(candidate) > priority && r.couldStart(candidate, pendingOrTriggered) {
+			higher++
+		}
+	}
+	return higher, nil
+}
+
+// couldStart determines if the triggered job pj is within its max_concurrency
+// and the capacity of its job queue, given the pending and triggered jobs.
+func (r *reconciler) couldStart(pj *prowv1.ProwJob, pendingOrTriggered []prowv1.ProwJob) bool {
+	var sameJob, sameQueue []prowv1.ProwJob
+	for _, other := range pendingOrTriggered {
+		if other.Spec.Job == pj.Spec.Job {
+			sameJob = append(sameJob, other)
+		}
+		if pj.Spec.JobQueueName != "" && other.Spec.JobQueueName == pj.Spec.JobQueueName {
+			sameQueue = append(sameQueue, other)
+		}
+	}
+	if pj.Spec.MaxConcurrency > 0 && countPendingOrOlderTriggeredMatchingPJs(*pj, sameJob, r.priority) >= pj.Spec.MaxConcurrency {
+		return false
+	}
+	if pj.Spec.JobQueueName == "" {
+		return true
+	}
+	capacity, defined := r.config().Plank.JobQueueCapacities[pj.Spec.JobQueueName]
+	switch {
+	case !defined || capacity == 0:
+		return false
+	case capacity < 0:
+		return true
+	}
+	return countPendingOrOlderTriggeredMatchingPJs(*pj, sameQueue, r.priority) < capacity
+}
+
+// preempt aborts the pending job of the lowest priority below the one of pj
+// if the priority class of pj allows preemption, so that pj can start once
+// the abort is observed. Of the jobs of the lowest priority, the one started
+// last is aborted to waste as little work as possible.
+func (r *reconciler) preempt(ctx context.Context, pj *prowv1.ProwJob, pjs []prowv1.ProwJob) error {
+	class := r.config().Plank.GetPriorityClass(pj.Spec.PriorityClass)
+	if !class.Preempt {
+		return nil
+	}
+	var victim *prowv1.ProwJob
+	for i := range pjs {
+		candidate := &pjs[i]
+		if candidate.Status.State != prowv1.PendingState || r.priority(candidate) >= class.Priority {
+			continue
+		}
+		if victim == nil || r.priority(candidate) < r.priority(victim) ||
+			(r.priority(candidate) == r.priority(victim) && victim.Status.StartTime.Before(&candidate.Status.StartTime)) {
+			victim = candidate
+		}
+	}
+	if victim == nil {
+		return nil
+	}
+
+	prevPJ := victim.DeepCopy()
+	victim.Status.State = prowv1.AbortedState
+	victim.Status.Description = fmt.Sprintf("Preempted by %s of a higher priority.", pj.Spec.Job)
+	setFailureReason(victim, prowv1.PreemptedFailure, fmt.Sprintf("Aborted to start %s of priority class %q.", pj.Name, pj.Spec.PriorityClass))
+	if victim.Annotations == nil {
+		victim.Annotations = map[string]string{}
+	}
+	victim.Annotations[kube.PreemptedByAnnotation] = pj.Name
+	if err := r.pjClient.Patch(ctx, victim.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
+		return fmt.Errorf("failed to preempt prowjob %s: %w", victim.Name, err)
+	}
+	r.log.WithFields(pjutil.ProwJobFields(victim)).WithField("preempted-by", pj.Name).Info("Preempted job of a lower priority.")
+	preemptedProwJobs.WithLabelValues(victim.ClusterAlias(), victim.Spec.PriorityClass, pj.Spec.PriorityClass).Inc()
+
+	// Block until the abort is observed in the cache, otherwise the next
+	// reconciliation of pj might preempt another job.
+	nn := types.NamespacedName{Namespace: victim.Namespace, Name: victim.Name}
+	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		if err := r.pjClient.Get(ctx, nn, victim); err != nil {
+			return false, fmt.Errorf("failed to get prowjob: %w", err)
+		}
+		return victim.Status.State != prowv1.PendingState, nil
+	}); err != nil {
+		return fmt.Errorf("failed to wait for preempted prowjob %s to get aborted in the cache: %w", nn.String(), err)
+	}
+	return nil
+}
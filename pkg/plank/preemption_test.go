@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/testutil"
+)
+
+func TestPriorityClasses(t *testing.T) {
+	now := time.Now()
+	pj := func(name, priorityClass string, state prowapi.ProwJobState, age time.Duration, queue string) prowapi.ProwJob {
+		return prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "prowjobs",
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: prowapi.ProwJobSpec{
+				Agent:         prowapi.KubernetesAgent,
+				Job:           name,
+				JobQueueName:  queue,
+				PriorityClass: priorityClass,
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     state,
+				StartTime: metav1.NewTime(now.Add(-age)),
+			},
+		}
+	}
+	withMaxConcurrency := func(pj prowapi.ProwJob, job string, maxConcurrency int) prowapi.ProwJob {
+		pj.Spec.Job = job
+		pj.Spec.MaxConcurrency = maxConcurrency
+		return pj
+	}
+	priorityClasses := map[string]config.PriorityClass{
+		"optional":         {Priority: -10},
+		"important":        {Priority: 10},
+		"release-blocking": {Priority: 100, Preempt: true},
+	}
+
+	testCases := []struct {
+		name               string
+		maxConcurrency     int
+		jobQueueCapacities map[string]int
+		prowJob            prowapi.ProwJob
+		existingProwJobs   []prowapi.ProwJob
+
+		expectedResult    bool
+		expectedPreempted []string
+	}{
+		{
+			name:           "triggered job of a higher priority delays the job",
+			maxConcurrency: 2,
+			prowJob:        pj("under-test", "", prowapi.TriggeredState, time.Hour, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("running", "", prowapi.PendingState, time.Hour, ""),
+				pj("waiting", "important", prowapi.TriggeredState, time.Minute, ""),
+			},
+			expectedResult: false,
+		},
+		{
+			name:               "triggered job of a higher priority held back by its queue does not delay the job",
+			maxConcurrency:     2,
+			jobQueueCapacities: map[string]int{"queue": 1},
+			prowJob:            pj("under-test", "", prowapi.TriggeredState, time.Hour, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("running", "", prowapi.PendingState, time.Hour, "queue"),
+				pj("waiting", "important", prowapi.TriggeredState, time.Minute, "queue"),
+			},
+			expectedResult: true,
+		},
+		{
+			name:           "triggered job of a higher priority held back by its max_concurrency does not delay the job",
+			maxConcurrency: 2,
+			prowJob:        pj("under-test", "", prowapi.TriggeredState, time.Hour, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				withMaxConcurrency(pj("limited-running", "important", prowapi.PendingState, time.Hour, ""), "limited", 1),
+				withMaxConcurrency(pj("limited-waiting", "important", prowapi.TriggeredState, time.Minute, ""), "limited", 1),
+			},
+			expectedResult: true,
+		},
+		{
+			name:           "triggered job of a lower priority does not delay the job",
+			maxConcurrency: 2,
+			prowJob:        pj("under-test", "", prowapi.TriggeredState, time.Minute, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("running", "", prowapi.PendingState, time.Hour, ""),
+				pj("waiting", "optional", prowapi.TriggeredState, time.Hour, ""),
+			},
+			expectedResult: true,
+		},
+		{
+			name:           "max concurrency exhausted, the lower priority job started last is preempted",
+			maxConcurrency: 3,
+			prowJob:        pj("under-test", "release-blocking", prowapi.TriggeredState, time.Minute, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("important", "important", prowapi.PendingState, 2*time.Minute, ""),
+				pj("optional-old", "optional", prowapi.PendingState, time.Hour, ""),
+				pj("optional-new", "optional", prowapi.PendingState, 10*time.Minute, ""),
+			},
+			expectedResult:    false,
+			expectedPreempted: []string{"optional-new"},
+		},
+		{
+			name:           "max concurrency exhausted, priority class without preemption waits",
+			maxConcurrency: 1,
+			prowJob:        pj("under-test", "important", prowapi.TriggeredState, time.Minute, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("optional", "optional", prowapi.PendingState, time.Hour, ""),
+			},
+			expectedResult: false,
+		},
+		{
+			name:           "max concurrency exhausted, jobs of the same priority are not preempted",
+			maxConcurrency: 1,
+			prowJob:        pj("under-test", "release-blocking", prowapi.TriggeredState, time.Minute, ""),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("release", "release-blocking", prowapi.PendingState, time.Hour, ""),
+			},
+			expectedResult: false,
+		},
+		{
+			name:               "job queue exhausted, job of a lower priority in the queue is preempted",
+			jobQueueCapacities: map[string]int{"queue": 1, "other-queue": 1},
+			prowJob:            pj("under-test", "release-blocking", prowapi.TriggeredState, time.Minute, "queue"),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("other-queue", "", prowapi.PendingState, time.Minute, "other-queue"),
+				pj("queue", "", prowapi.PendingState, time.Hour, "queue"),
+			},
+			expectedResult:    false,
+			expectedPreempted: []string{"queue"},
+		},
+		{
+			name:               "job queue exhausted, triggered job of a higher priority preempts first",
+			jobQueueCapacities: map[string]int{"queue": 1},
+			prowJob:            pj("under-test", "release-blocking", prowapi.TriggeredState, time.Minute, "queue"),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("queue", "", prowapi.PendingState, time.Hour, "queue"),
+				pj("waiting", "release-blocking", prowapi.TriggeredState, time.Hour, "queue"),
+			},
+			expectedResult: false,
+		},
+		{
+			name:               "job of a higher priority starts before older jobs in the queue",
+			jobQueueCapacities: map[string]int{"queue": 2},
+			prowJob:            pj("under-test", "important", prowapi.TriggeredState, time.Minute, "queue"),
+			existingProwJobs: []prowapi.ProwJob{
+				pj("queue", "", prowapi.PendingState, time.Hour, "queue"),
+				pj("waiting", "", prowapi.TriggeredState, time.Hour, "queue"),
+			},
+			expectedResult: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := func() *config.Config {
+				return &config.Config{ProwConfig: config.ProwConfig{
+					ProwJobNamespace: "prowjobs",
+					Plank: config.Plank{
+						Controller:         config.Controller{MaxConcurrency: tc.maxConcurrency},
+						JobQueueCapacities: tc.jobQueueCapacities,
+						PriorityClasses:    priorityClasses,
+					},
+				}}
+			}
+			var prowJobs []runtime.Object
+			for i := range tc.existingProwJobs {
+				prowJobs = append(prowJobs, &tc.existingProwJobs[i])
+			}
+			fakeMgr, err := testutil.NewFakeManager(
+				ctx,
+				prowJobs,
+				func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+					return setupIndexes(ctx, indexer, cfg)
+				},
+			)
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			r := &reconciler{
+				pjClient: fakeMgr.GetClient(),
+				log:      logrus.NewEntry(logrus.StandardLogger()),
+				config:   cfg,
+				clock:    clock.RealClock{},
+			}
+
+			result, err := r.canExecuteConcurrently(ctx, &tc.prowJob)
+			if err != nil {
+				t.Fatalf("canExecuteConcurrently: %v", err)
+			}
+			if result != tc.expectedResult {
+				t.Errorf("expected the job to be allowed to start: %t, was %t", tc.expectedResult, result)
+			}
+
+			pjs := &prowapi.ProwJobList{}
+			if err := r.pjClient.List(ctx, pjs); err != nil {
+				t.Fatalf("failed to list prowjobs: %v", err)
+			}
+			var preempted []string
+			for _, pj := range pjs.Items {
+				if pj.Status.State != prowapi.AbortedState {
+					continue
+				}
+				preempted = append(preempted, pj.Name)
+				if pj.Status.FailureReason != prowapi.PreemptedFailure {
+					t.Errorf("expected failure reason %q of %s, got %q", prowapi.PreemptedFailure, pj.Name, pj.Status.FailureReason)
+				}
+				if by := pj.Annotations[kube.PreemptedByAnnotation]; by != tc.prowJob.Name {
+					t.Errorf("expected %s to be preempted by %s, got %q", pj.Name, tc.prowJob.Name, by)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedPreempted, preempted); diff != "" {
+				t.Errorf("preempted jobs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
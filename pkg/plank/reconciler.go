@@ -255,6 +255,10 @@ func (r *reconciler) syncMetrics(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
+			if r.config().Plank.SkipProwJobMetrics {
+				kube.ResetProwJobMetrics()
+				continue
+			}
 			pjs := &prowv1.ProwJobList{}
 			if err := r.pjClient.List(ctx, pjs, optAllProwJobs()); err != nil {
 				r.log.WithError(err).Error("failed to list prowjobs for metrics")
@@ -364,6 +368,7 @@ func (r *reconciler) defaultReconcile(ctx context.Context, request reconcile.Req
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = fmt.Sprintf("Terminal error: %v.", err)
+			setFailureReason(pj, prowv1.InfraErrorFailure, err.Error())
 			if err := r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(originalPJ)); err != nil {
 				// If we fail to complete and mark the job as errorer we will try again on the next sync loop.
 				log.Errorf("Error marking job with terminal failure as errored: %v.", err)
@@ -455,6 +460,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
 			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+			setFailureReason(pj, prowv1.InfraErrorFailure, err.Error())
 			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warning("Unprocessable pod.")
 		} else {
 			pj.Status.BuildID = id
@@ -470,6 +476,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = "Job pod was evicted by the cluster."
+			setFailureReason(pj, prowv1.PodEvictedFailure, pod.Status.Message)
 		} else {
 			// ErrorOnEviction is disabled. Delete the pod now and recreate it in
 			// the next resync.
@@ -542,6 +549,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			} else {
 				pj.Status.State = prowv1.ErrorState
 				pj.Status.Description = "Pod was in succeeded phase but some containers didn't finish"
+				setFailureReason(pj, prowv1.InfraErrorFailure, "Some containers of the pod did not finish.")
 			}
 
 		case corev1.PodFailed:
@@ -549,6 +557,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.FailureState
 			pj.Status.Description = "Job failed."
+			pj.Status.FailureReason, pj.Status.FailureMessage = podFailureReason(pod)
 
 		case corev1.PodPending:
 			var requeueAfter time.Duration
@@ -567,6 +576,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 					pj.SetComplete()
 					pj.Status.State = prowv1.ErrorState
 					pj.Status.Description = "Pod scheduling timeout."
+					setFailureReason(pj, prowv1.InfraErrorFailure, fmt.Sprintf("Pod was not scheduled within %s.", maxPodUnscheduled))
 					r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job for stale unscheduled pod as errored.")
 					if err := r.deletePod(ctx, pj); err != nil {
 						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
@@ -584,6 +594,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 					pj.SetComplete()
 					pj.Status.State = prowv1.ErrorState
 					pj.Status.Description = "Pod pending timeout."
+					setFailureReason(pj, prowv1.InfraErrorFailure, fmt.Sprintf("Pod did not start running within %s.", maxPodPending))
 					r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job for stale pending pod as errored.")
 					if err := r.deletePod(ctx, pj); err != nil {
 						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
@@ -618,6 +629,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.AbortedState
 			pj.Status.Description = "Pod running timeout."
+			setFailureReason(pj, prowv1.TimeoutFailure, fmt.Sprintf("Pod did not finish within %s.", maxPodRunning))
 			if err := r.deletePod(ctx, pj); err != nil {
 				return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
 			}
@@ -635,6 +647,13 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 		pj.SetComplete()
 		pj.Status.State = prowv1.ErrorState
 		pj.Status.Description = "Pod got deleted unexpectedly"
+		setFailureReason(pj, prowv1.InfraErrorFailure, "Pod was deleted before the job completed.")
+	}
+
+	if pj.Complete() && pj.Spec.Retry.ShouldRetry(pj.Status.State, pj.Status.Retries) {
+		if err := r.retryJob(ctx, pj, pod); err != nil {
+			return nil, err
+		}
 	}
 
 	pj.Status.URL, err = pjutil.JobURL(r.config().Plank, *pj, r.log)
@@ -672,6 +691,40 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 	return nil, nil
 }
 
+// retryJob resets a completed job back to the triggered state so that a new
+// pod is started for it once the configured backoff has passed. The pod of
+// the failed attempt is deleted.
+func (r *reconciler) retryJob(ctx context.Context, pj *prowv1.ProwJob, pod *corev1.Pod) error {
+	if pod != nil {
+		client, ok := r.buildClients[pj.ClusterAlias()]
+		if !ok {
+			return TerminalError(fmt.Errorf("retry pod %s: unknown cluster alias %q", pod.Name, pj.ClusterAlias()))
+		}
+		if finalizers := sets.New[string](pod.Finalizers...); finalizers.Has(kubernetesreporterapi.FinalizerName) {
+			// The failed attempt is not reported, so remove the finalizer, otherwise the pod hangs
+			oldPod := pod.DeepCopy()
+			pod.Finalizers = finalizers.Delete(kubernetesreporterapi.FinalizerName).UnsortedList()
+			if err := client.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod)); err != nil {
+				return fmt.Errorf("failed to patch pod trying to remove %s finalizer: %w", kubernetesreporterapi.FinalizerName, err)
+			}
+		}
+		if err := ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, pod)); err != nil {
+			return fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
+		}
+	}
+
+	retry := pj.Status.Retries + 1
+	r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("state", pj.Status.State).WithField("retry", retry).Info("Retrying job.")
+	next := metav1.NewTime(r.clock.Now().Add(pj.Spec.Retry.BackoffFor(retry)))
+	pj.Status.Retries = retry
+	pj.Status.NextRetryTime = &next
+	pj.Status.CompletionTime = nil
+	pj.Status.State = prowv1.TriggeredState
+	pj.Status.Description = fmt.Sprintf("%s Retrying (%d/%d).", pj.Status.Description, retry, pj.Spec.Retry.MaxRetries)
+	setFailureReason(pj, "", "")
+	return nil
+}
+
 // syncTriggeredJob syncs jobs that do not yet have an associated test workload running
 func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (*reconcile.Result, error) {
 	prevPJ := pj.DeepCopy()
@@ -686,6 +739,16 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 	// updated to pending if we successfully create a new pod in a previous
 	// sync but the prowjob update fails. Simply ignore creating a new pod
 	// and rerun the prowjob update.
+	if podExists && pod.DeletionTimestamp != nil && pj.Status.Retries > 0 {
+		// The pod of the previous attempt is still being deleted, wait for
+		// it to go away before starting the retry.
+		return &reconcile.Result{RequeueAfter: time.Second}, nil
+	}
+	if !podExists && pj.Status.NextRetryTime != nil {
+		if wait := pj.Status.NextRetryTime.Sub(r.clock.Now()); wait > 0 {
+			return &reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
 	if podExists {
 		id = getPodBuildID(pod)
 		pn = pod.ObjectMeta.Name
@@ -707,6 +770,7 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
 			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+			setFailureReason(pj, prowv1.InfraErrorFailure, err.Error())
 			logrus.WithField("job", pj.Spec.Job).WithError(err).Warning("Unprocessable pod.")
 		}
 	}
@@ -774,6 +838,9 @@ func (r *reconciler) syncAbortedJob(ctx context.Context, pj *prowv1.ProwJob) err
 
 	originalPJ := pj.DeepCopy()
 	pj.SetComplete()
+	if pj.Status.FailureReason == "" {
+		setFailureReason(pj, prowv1.AbortedFailure, pj.Status.Description)
+	}
 	return r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(originalPJ))
 }
 
@@ -828,7 +895,11 @@ func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string,
 	}
 
 	pj.Status.BuildID = buildID
-	pod, err := decorate.ProwJobToPod(*pj)
+	expanded, err := r.config().Plank.ExpandPodTemplate(*pj)
+	if err != nil {
+		return "", "", TerminalError(err)
+	}
+	pod, err := decorate.ProwJobToPod(expanded)
 	if err != nil {
 		return "", "", err
 	}
@@ -869,18 +940,27 @@ func (r *reconciler) getBuildID(name string) (string, error) {
 }
 
 // canExecuteConcurrently determines if the cocurrency settings allow our job
-// to be started. We start jobs with a limited concurrency in order, oldest
-// first. This allows us to get away without any global locking by just looking
-// at the jobs in the cluster.
+// to be started. We start jobs with a limited concurrency in order, highest
+// priority and then oldest first. This allows us to get away without any global
+// locking by just looking at the jobs in the cluster. When the concurrency is
+// exhausted, jobs of a priority class that allows preemption abort a pending
+// job of a lower priority to be started once the abort is done.
 func (r *reconciler) canExecuteConcurrently(ctx context.Context, pj *prowv1.ProwJob) (bool, error) {
 	if max := r.config().Plank.MaxConcurrency; max > 0 {
 		pjs := &prowv1.ProwJobList{}
 		if err := r.pjClient.List(ctx, pjs, optPendingProwJobs()); err != nil {
 			return false, fmt.Errorf("failed to list prowjobs: %w", err)
 		}
+		higherPriority, err := r.countHigherPriorityTriggered(ctx, pj, pjs.Items)
+		if err != nil {
+			return false, err
+		}
 
-		if running := len(pjs.Items); running >= max {
-			r.log.WithFields(pjutil.ProwJobFields(pj)).Infof("Not starting another job, already %d running.", running)
+		if running := len(pjs.Items); running+higherPriority >= max {
+			r.log.WithFields(pjutil.ProwJobFields(pj)).Infof("Not starting another job, already %d running and %d of a higher priority waiting.", running, higherPriority)
+			if running >= max && higherPriority == 0 {
+				return false, r.preempt(ctx, pj, pjs.Items)
+			}
 			return false, nil
 		}
 	}
@@ -903,7 +983,7 @@ func (r *reconciler) canExecuteConcurrentlyPerJob(ctx context.Context, pj *prowv
 	}
 	r.log.Infof("got %d not completed with same name", len(pjs.Items))
 
-	pendingOrOlderMatchingPJs := countPendingOrOlderTriggeredMatchingPJs(*pj, pjs.Items)
+	pendingOrOlderMatchingPJs := countPendingOrOlderTriggeredMatchingPJs(*pj, pjs.Items, r.priority)
 	if pendingOrOlderMatchingPJs >= pj.Spec.MaxConcurrency {
 		r.log.WithFields(pjutil.ProwJobFields(pj)).
 			Debugf("Not starting another instance of %s, have %d instances that are pending or older, %d is the limit",
@@ -937,11 +1017,16 @@ func (r *reconciler) canExecuteConcurrentlyPerQueue(ctx context.Context, pj *pro
 	}
 	r.log.Infof("got %d not completed within queue %s", len(pjs.Items), queueName)
 
-	pendingOrOlderMatchingPJs := countPendingOrOlderTriggeredMatchingPJs(*pj, pjs.Items)
+	pendingOrOlderMatchingPJs := countPendingOrOlderTriggeredMatchingPJs(*pj, pjs.Items, r.priority)
 	if pendingOrOlderMatchingPJs >= queueConcurrency {
 		r.log.WithFields(pjutil.ProwJobFields(pj)).
 			Debugf("Not starting another instance of %s, have %d instances in queue %s that are pending or older, %d is the limit",
 				pj.Spec.Job, pendingOrOlderMatchingPJs, queueName, queueConcurrency)
+		// Only preempt when no triggered job is ahead of us, they get to
+		// preempt first.
+		if countTriggeredAhead(*pj, pjs.Items, r.priority) == 0 {
+			return false, r.preempt(ctx, pj, pjs.Items)
+		}
 		return false, nil
 	}
 
@@ -1000,6 +1085,9 @@ const (
 	// that are currently pending AKA a corresponding pod
 	// exists but didn't yet finish
 	prowJobIndexKeyPending = "pending"
+	// prowJobIndexKeyTriggered is the indexKey for prowjobs
+	// that are waiting for their pod to be created
+	prowJobIndexKeyTriggered = "triggered"
 )
 
 func pendingTriggeredIndexKeyByName(jobName string) string {
@@ -1022,6 +1110,9 @@ func prowJobIndexer(prowJobNamespace string) ctrlruntimeclient.IndexerFunc {
 		if pj.Status.State == prowv1.PendingState {
 			indexes = append(indexes, prowJobIndexKeyPending)
 		}
+		if pj.Status.State == prowv1.TriggeredState {
+			indexes = append(indexes, prowJobIndexKeyTriggered)
+		}
 
 		if pj.Status.State == prowv1.PendingState || pj.Status.State == prowv1.TriggeredState {
 			indexes = append(indexes, pendingTriggeredIndexKeyByName(pj.Spec.Job))
@@ -1043,6 +1134,10 @@ func optPendingProwJobs() ctrlruntimeclient.ListOption {
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: prowJobIndexKeyPending}
 }
 
+func optTriggeredProwJobs() ctrlruntimeclient.ListOption {
+	return ctrlruntimeclient.MatchingFields{prowJobIndexName: prowJobIndexKeyTriggered}
+}
+
 func optPendingTriggeredJobsNamed(name string) ctrlruntimeclient.ListOption {
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: pendingTriggeredIndexKeyByName(name)}
 }
@@ -1090,7 +1185,7 @@ func isRequestError(err error) bool {
 	return 400 <= code && code < 500
 }
 
-func countPendingOrOlderTriggeredMatchingPJs(pj prowv1.ProwJob, pjs []prowv1.ProwJob) int {
+func countPendingOrOlderTriggeredMatchingPJs(pj prowv1.ProwJob, pjs []prowv1.ProwJob, priority func(*prowv1.ProwJob) int) int {
 	var pendingOrOlderTriggeredMatchingPJs int
 
 	for _, foundPJ := range pjs {
@@ -1103,13 +1198,24 @@ func countPendingOrOlderTriggeredMatchingPJs(pj prowv1.ProwJob, pjs []prowv1.Pro
 			continue
 		}
 
-		// At this point if foundPJ is older than our prowJobs it gets
-		// priorized to make sure we execute jobs in creation order.
-		if foundPJ.Status.State == prowv1.TriggeredState &&
-			foundPJ.CreationTimestamp.Before(&pj.CreationTimestamp) {
+		// At this point if foundPJ has a higher priority or the same
+		// priority and is older than our prowJobs it gets priorized to make
+		// sure we execute jobs in priority and creation order.
+		if foundPJ.Status.State == prowv1.TriggeredState && isAhead(&foundPJ, &pj, priority) {
 			pendingOrOlderTriggeredMatchingPJs++
 		}
 	}
 
 	return pendingOrOlderTriggeredMatchingPJs
 }
+
+// countTriggeredAhead counts the triggered jobs that get started before pj.
+func countTriggeredAhead(pj prowv1.ProwJob, pjs []prowv1.ProwJob, priority func(*prowv1.ProwJob) int) int {
+	var ahead int
+	for i := range pjs {
+		if pjs[i].UID != pj.UID && pjs[i].Status.State == prowv1.TriggeredState && isAhead(&pjs[i], &pj, priority) {
+			ahead++
+		}
+	}
+	return ahead
+}
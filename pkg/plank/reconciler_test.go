@@ -334,6 +334,7 @@ func TestProwJobIndexer(t *testing.T) {
 			modify: func(pj *prowv1.ProwJob) { pj.Status.State = prowv1.TriggeredState },
 			expected: []string{
 				prowJobIndexKeyAll,
+				prowJobIndexKeyTriggered,
 				pendingTriggeredIndexKeyByName(pjName),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
 			},
@@ -572,6 +573,52 @@ func TestStartPodBlocksUntilItHasThePodInCache(t *testing.T) {
 	}
 }
 
+func TestStartPodExpandsPodTemplate(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{ProwConfig: config.ProwConfig{Plank: config.Plank{
+		PodTemplates: map[string]config.PodTemplate{
+			"gpu-large": {NodeSelector: map[string]string{"pool": "gpu"}},
+		},
+	}}}
+	r := &reconciler{
+		log: logrus.NewEntry(logrus.New()),
+		buildClients: map[string]buildClient{
+			"default": {
+				Client: fakectrlruntimeclient.NewClientBuilder().Build(),
+			},
+		},
+		config: func() *config.Config { return cfg },
+	}
+	pj := &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		Spec: prowv1.ProwJobSpec{
+			PodSpec:     &corev1.PodSpec{Containers: []corev1.Container{{}}},
+			PodTemplate: "gpu-large",
+			Refs:        &prowv1.Refs{},
+			Type:        prowv1.PeriodicJob,
+		},
+	}
+	if _, _, err := r.startPod(context.Background(), pj); err != nil {
+		t.Fatalf("startPod: %v", err)
+	}
+	pod := &corev1.Pod{}
+	if err := r.buildClients["default"].Get(context.Background(), types.NamespacedName{Name: "name"}, pod); err != nil {
+		t.Fatalf("couldn't get pod: %v", err)
+	}
+	if pod.Spec.NodeSelector["pool"] != "gpu" {
+		t.Errorf("expected the pod template to be expanded into the pod, got node selector %v", pod.Spec.NodeSelector)
+	}
+	if pj.Spec.PodSpec.NodeSelector != nil {
+		t.Errorf("expected the pod spec of the ProwJob to be unchanged, got node selector %v", pj.Spec.PodSpec.NodeSelector)
+	}
+
+	pj.Spec.PodTemplate = "tiny"
+	if _, _, err := r.startPod(context.Background(), pj); !IsTerminalError(err) {
+		t.Errorf("expected a terminal error for an unknown pod template, got %v", err)
+	}
+}
+
 type fakeOpener struct {
 	io.Opener
 	strings.Builder
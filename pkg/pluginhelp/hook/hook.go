@@ -178,6 +178,59 @@ func (ha *HelpAgent) GeneratePluginHelp() *pluginhelp.Help {
 	}
 }
 
+// GenerateCommandRegistry compiles the commands of all plugins enabled for the
+// org/repo. The help of every plugin is generated for that repo alone.
+func (ha *HelpAgent) GenerateCommandRegistry(orgRepo string) (*pluginhelp.CommandRegistry, error) {
+	repo := prowconfig.NewOrgRepo(orgRepo)
+	if repo.Repo == "" {
+		return nil, fmt.Errorf("%q is not an org/repo string", orgRepo)
+	}
+	config := ha.pa.Config().ForRepo(repo.Org, repo.Repo)
+	enabled := []prowconfig.OrgRepo{*repo}
+	registry := &pluginhelp.CommandRegistry{Repo: repo.String()}
+
+	helpProviders := plugins.HelpProviders()
+	for _, name := range enabledPlugins(config, repo.Org, repo.Repo) {
+		provider := helpProviders[name]
+		if provider == nil {
+			continue
+		}
+		help, err := provider(config, enabled)
+		if err != nil {
+			ha.log.WithError(err).Errorf("Generating help from normal plugin %q.", name)
+			continue
+		}
+		for _, command := range help.Commands {
+			registry.Commands = append(registry.Commands, pluginhelp.RegisteredCommand{Command: command, Plugin: name})
+		}
+	}
+
+	external := &plugins.Configuration{ExternalPlugins: map[string][]plugins.ExternalPlugin{}}
+	revMap := map[string][]prowconfig.OrgRepo{}
+	for _, key := range []string{repo.Org, repo.String()} {
+		for _, ext := range config.ExternalPlugins[key] {
+			external.ExternalPlugins[repo.String()] = append(external.ExternalPlugins[repo.String()], ext)
+			revMap[ext.Name] = enabled
+		}
+	}
+	_, externalHelp := ha.generateExternalPluginHelp(external, revMap)
+	for _, name := range sets.List(sets.KeySet(externalHelp)) {
+		for _, command := range externalHelp[name].Commands {
+			registry.Commands = append(registry.Commands, pluginhelp.RegisteredCommand{Command: command, Plugin: name, External: true})
+		}
+	}
+	return registry, nil
+}
+
+// enabledPlugins returns the sorted names of the normal plugins enabled for the repo.
+func enabledPlugins(config *plugins.Configuration, org, repo string) []string {
+	enabled := sets.New[string](config.Plugins[prowconfig.OrgRepo{Org: org, Repo: repo}.String()].Plugins...)
+	if !sets.New[string](config.Plugins[org].ExcludedRepos...).Has(repo) {
+		enabled.Insert(config.Plugins[org].Plugins...)
+	}
+	return sets.List(enabled)
+}
+
 func allRepos(config *plugins.Configuration, orgToRepos map[string]sets.Set[string]) []string {
 	all := sets.New[string]()
 	for repo := range config.Plugins {
@@ -400,3 +453,28 @@ func (ha *HelpAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	fmt.Fprint(w, string(b))
 }
+
+// ServeCommandRegistry serves the command registry for the repo given by the
+// "repo" query parameter as JSON.
+func (ha *HelpAgent) ServeCommandRegistry(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if r.Method != http.MethodGet {
+		ha.log.Errorf("Invalid request method: %v.", r.Method)
+		http.Error(w, "405 Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	registry, err := ha.GenerateCommandRegistry(r.URL.Query().Get("repo"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("400 Bad request: %v", err), http.StatusBadRequest)
+		return
+	}
+	b, err := json.Marshal(registry)
+	if err != nil {
+		ha.log.WithError(err).Error("Error marshaling command registry.")
+		http.Error(w, fmt.Sprintf("500 Internal server error marshaling command registry: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, string(b))
+}
@@ -207,3 +207,70 @@ func registerNormalPlugins(t *testing.T, pluginsToEvents map[string][]string, pl
 		}
 	}
 }
+
+func TestGenerateCommandRegistry(t *testing.T) {
+	command := func(name string) pluginhelp.Command {
+		return pluginhelp.Command{Name: name, Usage: "/" + name}
+	}
+	for _, plugin := range []string{"registry-org-plugin", "registry-repo-plugin", "registry-other-plugin"} {
+		plugin := plugin
+		plugins.RegisterGenericCommentHandler(plugin, nil, func(_ *plugins.Configuration, enabledRepos []prowconfig.OrgRepo) (*pluginhelp.PluginHelp, error) {
+			if expected := []prowconfig.OrgRepo{{Org: "org", Repo: "repo"}}; !reflect.DeepEqual(enabledRepos, expected) {
+				t.Errorf("Plugin '%s' expected help for repos %q, but got %q.", plugin, expected, enabledRepos)
+			}
+			help := &pluginhelp.PluginHelp{}
+			help.AddCommand(command(plugin))
+			return help, nil
+		})
+	}
+	mux := http.NewServeMux()
+	externalplugins.ServeExternalPluginHelp(
+		mux,
+		logrus.WithField("plugin", "registry-external"),
+		func(enabledRepos []prowconfig.OrgRepo) (*pluginhelp.PluginHelp, error) {
+			help := &pluginhelp.PluginHelp{}
+			help.AddCommand(command("registry-external"))
+			return help, nil
+		},
+	)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := plugins.Configuration{
+		Plugins: plugins.Plugins{
+			"org":       {Plugins: []string{"registry-org-plugin"}},
+			"org/repo":  {Plugins: []string{"registry-repo-plugin"}},
+			"org/other": {Plugins: []string{"registry-other-plugin"}},
+		},
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"org": {{Name: "registry-external", Endpoint: server.URL}},
+		},
+	}
+	ha := NewHelpAgent(fakePluginAgent(config), fakeGitHubClient{})
+
+	registry, err := ha.GenerateCommandRegistry("org/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &pluginhelp.CommandRegistry{
+		Repo: "org/repo",
+		Commands: []pluginhelp.RegisteredCommand{
+			{Command: command("registry-org-plugin"), Plugin: "registry-org-plugin"},
+			{Command: command("registry-repo-plugin"), Plugin: "registry-repo-plugin"},
+			{Command: command("registry-external"), Plugin: "registry-external", External: true},
+		},
+	}
+	if !reflect.DeepEqual(registry, expected) {
+		t.Errorf("Expected command registry %+v, but got %+v.", expected, registry)
+	}
+
+	if _, err := ha.GenerateCommandRegistry("org"); err == nil {
+		t.Error("Expected an error for a registry of an org.")
+	}
+
+	recorder := httptest.NewRecorder()
+	ha.ServeCommandRegistry(recorder, httptest.NewRequest(http.MethodGet, "/plugin-help/commands", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a request without a repo, but got %d.", http.StatusBadRequest, recorder.Code)
+	}
+}
@@ -18,6 +18,49 @@ limitations under the License.
 // These structs are used by sub-packages 'hook' and 'externalplugins'.
 package pluginhelp
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ArgumentType is the type of the value of a command argument.
+type ArgumentType string
+
+const (
+	// ArgumentString is any word.
+	ArgumentString ArgumentType = "string"
+	// ArgumentInt is an integer.
+	ArgumentInt ArgumentType = "int"
+	// ArgumentEnum is one of the Values of the argument.
+	ArgumentEnum ArgumentType = "enum"
+	// ArgumentUser is a GitHub login, optionally prefixed with @.
+	ArgumentUser ArgumentType = "user"
+	// ArgumentContext is a status context, which must be quoted if it contains spaces.
+	ArgumentContext ArgumentType = "context"
+	// ArgumentText is free text that extends to the end of the line.
+	ArgumentText ArgumentType = "text"
+)
+
+// Argument is a serializable description of a single command argument.
+type Argument struct {
+	// Name is a short name of the argument, e.g. "reason".
+	Name string
+	// Type is the type of the value of the argument.
+	Type ArgumentType
+	// Description is a short description of the argument.
+	Description string `json:",omitempty"`
+	// Optional is set for arguments that may be omitted.
+	Optional bool `json:",omitempty"`
+	// Repeated is set for arguments that may be given multiple times. Only the
+	// last argument of a command may be repeated.
+	Repeated bool `json:",omitempty"`
+	// Values are the allowed values of an ArgumentEnum.
+	Values []string `json:",omitempty"`
+}
+
 // Command is a serializable representation of the command information for a single command.
 type Command struct {
 	// Usage is a usage string for the command.
@@ -33,6 +76,62 @@ type Command struct {
 	// OWNERS file alias, etc.
 	// This field may include HTML.
 	WhoCanUse string
+	// Name is the name of the command without the leading slash, including any
+	// subcommand, e.g. "bugzilla refresh". Optional, but required to validate
+	// the arguments of the command.
+	Name string `json:",omitempty"`
+	// Arguments describes the arguments the command accepts, in order.
+	Arguments []Argument `json:",omitempty"`
+}
+
+// ValidateArgs checks the arguments given to the command against its
+// Arguments. Text arguments consume all remaining arguments.
+func (c Command) ValidateArgs(args []string) error {
+	for i, arg := range c.Arguments {
+		if i >= len(args) {
+			if !arg.Optional {
+				return fmt.Errorf("/%s: missing argument %q", c.Name, arg.Name)
+			}
+			return nil
+		}
+		if arg.Type == ArgumentText {
+			return nil
+		}
+		values := args[i : i+1]
+		if arg.Repeated {
+			values = args[i:]
+		}
+		for _, value := range values {
+			if err := arg.validate(value); err != nil {
+				return fmt.Errorf("/%s: invalid argument %q: %w", c.Name, arg.Name, err)
+			}
+		}
+		if arg.Repeated {
+			return nil
+		}
+	}
+	if len(args) > len(c.Arguments) {
+		return fmt.Errorf("/%s: unexpected arguments %q", c.Name, strings.Join(args[len(c.Arguments):], " "))
+	}
+	return nil
+}
+
+func (a Argument) validate(value string) error {
+	switch a.Type {
+	case ArgumentInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	case ArgumentEnum:
+		if !sets.New[string](a.Values...).Has(value) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(a.Values, ", "))
+		}
+	case ArgumentUser:
+		if strings.TrimPrefix(value, "@") == "" {
+			return fmt.Errorf("%q is not a user", value)
+		}
+	}
+	return nil
 }
 
 // PluginHelp is a serializable representation of the help information for a single plugin.
@@ -69,6 +168,26 @@ type Help struct {
 	ExternalPluginHelp map[string]PluginHelp
 }
 
+// RegisteredCommand is a command of a plugin enabled for a repo, as listed by
+// the command registry.
+type RegisteredCommand struct {
+	Command
+	// Plugin is the name of the plugin that provides the command.
+	Plugin string
+	// External is set for commands of external plugins.
+	External bool `json:",omitempty"`
+}
+
+// CommandRegistry is a serializable, machine-readable listing of all commands
+// available in a repo. The help of every plugin is generated for the repo alone,
+// so WhoCanUse is specific to the repo.
+type CommandRegistry struct {
+	// Repo is the org/repo string the commands are available in.
+	Repo string
+	// Commands lists the commands sorted by plugin.
+	Commands []RegisteredCommand
+}
+
 // AddCommand registers new help text for a bot command.
 func (pluginHelp *PluginHelp) AddCommand(command Command) {
 	pluginHelp.Commands = append(pluginHelp.Commands, command)
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluginhelp
+
+import "testing"
+
+func TestValidateArgs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		arguments   []Argument
+		args        []string
+		expectedErr bool
+	}{
+		{
+			name: "no arguments",
+		},
+		{
+			name:        "unexpected argument",
+			args:        []string{"foo"},
+			expectedErr: true,
+		},
+		{
+			name:      "optional enum omitted",
+			arguments: []Argument{{Name: "cancel", Type: ArgumentEnum, Values: []string{"cancel"}, Optional: true}},
+		},
+		{
+			name:      "enum value",
+			arguments: []Argument{{Name: "cancel", Type: ArgumentEnum, Values: []string{"cancel"}, Optional: true}},
+			args:      []string{"cancel"},
+		},
+		{
+			name:        "unknown enum value",
+			arguments:   []Argument{{Name: "cancel", Type: ArgumentEnum, Values: []string{"cancel"}, Optional: true}},
+			args:        []string{"please"},
+			expectedErr: true,
+		},
+		{
+			name:        "missing required argument",
+			arguments:   []Argument{{Name: "reason", Type: ArgumentText}},
+			expectedErr: true,
+		},
+		{
+			name:      "text consumes all arguments",
+			arguments: []Argument{{Name: "reason", Type: ArgumentText}},
+			args:      []string{"emergency", "fix"},
+		},
+		{
+			name:      "repeated argument",
+			arguments: []Argument{{Name: "context", Type: ArgumentContext, Repeated: true}},
+			args:      []string{"unit", "e2e"},
+		},
+		{
+			name:      "int argument",
+			arguments: []Argument{{Name: "count", Type: ArgumentInt}},
+			args:      []string{"3"},
+		},
+		{
+			name:        "invalid int argument",
+			arguments:   []Argument{{Name: "count", Type: ArgumentInt}},
+			args:        []string{"three"},
+			expectedErr: true,
+		},
+		{
+			name:      "user argument",
+			arguments: []Argument{{Name: "user", Type: ArgumentUser}, {Name: "count", Type: ArgumentInt, Optional: true}},
+			args:      []string{"@alice"},
+		},
+		{
+			name:        "empty user argument",
+			arguments:   []Argument{{Name: "user", Type: ArgumentUser}},
+			args:        []string{"@"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			command := Command{Name: "test", Arguments: tc.arguments}
+			err := command.ValidateArgs(tc.args)
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
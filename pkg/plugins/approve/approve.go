@@ -65,6 +65,7 @@ type githubClient interface {
 	ListPullRequestComments(org, repo string, number int) ([]github.ReviewComment, error)
 	DeleteComment(org, repo string, ID int) error
 	CreateComment(org, repo string, number int, comment string) error
+	EditComment(org, repo string, ID int, comment string) error
 	BotUserChecker() (func(candidate string) bool, error)
 	AddLabel(org, repo string, number int, label string) error
 	RemoveLabel(org, repo string, number int, label string) error
@@ -110,7 +111,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 	approveConfig := map[string]string{}
 	for _, repo := range enabledRepos {
 		opts := config.ApproveFor(repo.Org, repo.Repo)
-		approveConfig[repo.String()] = fmt.Sprintf("Pull requests %s require an associated issue.<br>Pull request authors %s implicitly approve their own PRs.<br>The /lgtm [cancel] command(s) %s act as approval.<br>A GitHub approved or changes requested review %s act as approval or cancel respectively.", doNot(opts.IssueRequired), doNot(opts.HasSelfApproval()), willNot(opts.LgtmActsAsApprove), willNot(opts.ConsiderReviewState()))
+		approveConfig[repo.String()] = fmt.Sprintf("Pull requests %s require an associated issue.<br>Pull request authors %s implicitly approve their own PRs.<br>The /lgtm [cancel] command(s) %s act as approval.<br>A GitHub approved or changes requested review %s act as approval or cancel respectively.<br>The notification comment %sshow the approval progress of each top-level directory and be edited in place.", doNot(opts.IssueRequired), doNot(opts.HasSelfApproval()), willNot(opts.LgtmActsAsApprove), willNot(opts.ConsiderReviewState()), willNot(opts.ProgressComment))
 	}
 
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
@@ -434,6 +435,7 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 		log.WithError(err).Errorf("Failed to find associated issue from PR body: %v", err)
 	}
 	approversHandler.RequireIssue = opts.IssueRequired
+	approversHandler.ShowScopes = opts.ProgressComment
 	approversHandler.ManuallyApproved = humanAddedApproved(ghc, log, pr.org, pr.repo, pr.number, hasApprovedLabel)
 
 	// Author implicitly approves their own PR if config allows it
@@ -467,12 +469,21 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	log.WithField("duration", time.Since(start).String()).Debug("Completed getting notifications in handle")
 	start = time.Now()
 	if newMessage != nil {
+		// The progress comment is kept in place, so only older duplicates are removed.
+		editInPlace := opts.ProgressComment && latestNotification != nil
 		for _, notif := range notifications {
+			if editInPlace && notif.ID == latestNotification.ID {
+				continue
+			}
 			if err := ghc.DeleteComment(pr.org, pr.repo, notif.ID); err != nil {
 				log.WithError(err).Errorf("Failed to delete comment from %s/%s#%d, ID: %d.", pr.org, pr.repo, pr.number, notif.ID)
 			}
 		}
-		if err := ghc.CreateComment(pr.org, pr.repo, pr.number, *newMessage); err != nil {
+		if editInPlace {
+			if err := ghc.EditComment(pr.org, pr.repo, latestNotification.ID, *newMessage); err != nil {
+				log.WithError(err).Errorf("Failed to edit comment %d on %s/%s#%d: %q.", latestNotification.ID, pr.org, pr.repo, pr.number, *newMessage)
+			}
+		} else if err := ghc.CreateComment(pr.org, pr.repo, pr.number, *newMessage); err != nil {
 			log.WithError(err).Errorf("Failed to create comment on %s/%s#%d: %q.", pr.org, pr.repo, pr.number, *newMessage)
 		}
 	}
@@ -190,9 +190,11 @@ func TestHandle(t *testing.T) {
 		lgtmActsAsApprove   bool
 		reviewActsAsApprove bool
 		githubLinkURL       *url.URL
+		progressComment     bool
 
 		expectDelete    bool
 		expectComment   bool
+		expectEditID    int
 		expectedComment string
 		expectToggle    bool
 	}{
@@ -1213,6 +1215,89 @@ Needs approval from an approver in each of these files:
 - ~~[a/OWNERS](https://github.com/org/repo/blob/master/a/OWNERS)~~ [Alice]
 - **[c/OWNERS](https://github.com/org/repo/blob/master/c/OWNERS)**
 
+Approvers can indicate their approval by writing ` + "`/approve`" + ` in a comment
+Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a comment
+</details>
+<!-- META={"approvers":["cjwagner"]} -->`,
+		},
+		{
+			name:                "progress comment, initial notification",
+			hasLabel:            false,
+			files:               []string{"a/a.go", "c/c.go"},
+			comments:            []github.IssueComment{newTestComment("Alice", "/approve")},
+			reviews:             []github.Review{},
+			selfApprove:         false,
+			needsIssue:          false,
+			lgtmActsAsApprove:   false,
+			reviewActsAsApprove: false,
+			githubLinkURL:       &url.URL{Scheme: "https", Host: "github.com"},
+			progressComment:     true,
+
+			expectDelete:  false,
+			expectToggle:  false,
+			expectComment: true,
+			expectedComment: `[APPROVALNOTIFIER] This PR is **NOT APPROVED**
+
+This pull-request has been approved by: *<a href="" title="Approved">Alice</a>*
+**Once this PR has been reviewed and has the lgtm label**, please ask for approval from [cjwagner](https://github.com/cjwagner). For more information see [the Kubernetes Code Review Process](https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process).
+
+The full list of commands accepted by this bot can be found [here](https://go.k8s.io/bot-commands?repo=org%2Frepo).
+
+Approval progress by directory:
+
+- [x] ` + "`a/`" + ` approved by Alice
+- [ ] ` + "`c/`" + ` suggested approvers: [cjwagner](https://github.com/cjwagner)
+
+<details open>
+Needs approval from an approver in each of these files:
+
+- ~~[a/OWNERS](https://github.com/org/repo/blob/master/a/OWNERS)~~ [Alice]
+- **[c/OWNERS](https://github.com/org/repo/blob/master/c/OWNERS)**
+
+Approvers can indicate their approval by writing ` + "`/approve`" + ` in a comment
+Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a comment
+</details>
+<!-- META={"approvers":["cjwagner"]} -->`,
+		},
+		{
+			name:     "progress comment, edited in place",
+			hasLabel: false,
+			files:    []string{"a/a.go", "c/c.go"},
+			comments: []github.IssueComment{
+				{ID: 1, User: github.User{Login: "k8s-ci-robot"}, Body: "[APPROVALNOTIFIER] This PR is **NOT APPROVED**\n\nblah"},
+				{ID: 2, User: github.User{Login: "k8s-ci-robot"}, Body: "[APPROVALNOTIFIER] This PR is **NOT APPROVED**\n\nblah"},
+				{ID: 3, User: github.User{Login: "Alice"}, Body: "/approve"},
+			},
+			reviews:             []github.Review{},
+			selfApprove:         false,
+			needsIssue:          false,
+			lgtmActsAsApprove:   false,
+			reviewActsAsApprove: false,
+			githubLinkURL:       &url.URL{Scheme: "https", Host: "github.com"},
+			progressComment:     true,
+
+			expectDelete:  true,
+			expectToggle:  false,
+			expectComment: false,
+			expectEditID:  2,
+			expectedComment: `[APPROVALNOTIFIER] This PR is **NOT APPROVED**
+
+This pull-request has been approved by: *<a href="" title="Approved">Alice</a>*
+**Once this PR has been reviewed and has the lgtm label**, please ask for approval from [cjwagner](https://github.com/cjwagner). For more information see [the Kubernetes Code Review Process](https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process).
+
+The full list of commands accepted by this bot can be found [here](https://go.k8s.io/bot-commands?repo=org%2Frepo).
+
+Approval progress by directory:
+
+- [x] ` + "`a/`" + ` approved by Alice
+- [ ] ` + "`c/`" + ` suggested approvers: [cjwagner](https://github.com/cjwagner)
+
+<details open>
+Needs approval from an approver in each of these files:
+
+- ~~[a/OWNERS](https://github.com/org/repo/blob/master/a/OWNERS)~~ [Alice]
+- **[c/OWNERS](https://github.com/org/repo/blob/master/c/OWNERS)**
+
 Approvers can indicate their approval by writing ` + "`/approve`" + ` in a comment
 Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a comment
 </details>
@@ -1268,6 +1353,7 @@ Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a commen
 					IgnoreReviewState:   &irs,
 					CommandHelpLink:     "https://go.k8s.io/bot-commands",
 					PrProcessLink:       "https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process",
+					ProgressComment:     test.progressComment,
 				},
 				&state{
 					org:       "org",
@@ -1318,6 +1404,15 @@ Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a commen
 					)
 				}
 			}
+			if test.expectEditID != 0 {
+				if len(fghc.IssueCommentsEdited) != 1 {
+					t.Errorf("Expected 1 notification to be edited but %d notifications were edited.", len(fghc.IssueCommentsEdited))
+				} else if expect, got := fmt.Sprintf("org/repo#%d:", test.expectEditID)+test.expectedComment, fghc.IssueCommentsEdited[0]; got != expect {
+					t.Errorf("expected edited notification differs from actual: %s", cmp.Diff(expect, got))
+				}
+			} else if len(fghc.IssueCommentsEdited) != 0 {
+				t.Errorf("Expected 0 notifications to be edited but %d notifications were edited.", len(fghc.IssueCommentsEdited))
+			}
 
 			labelAdded := false
 			for _, l := range fghc.IssueLabelsAdded {
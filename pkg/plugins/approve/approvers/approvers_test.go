@@ -235,6 +235,64 @@ func TestGetFiles(t *testing.T) {
 	}
 }
 
+func TestGetScopes(t *testing.T) {
+	FakeRepoMap := map[string]sets.Set[string]{
+		"":    sets.New[string]("Alice"),
+		"a":   sets.New[string]("Anne"),
+		"a/d": sets.New[string]("Dan"),
+		"b":   sets.New[string]("Bill"),
+	}
+	tests := []struct {
+		testName          string
+		filenames         []string
+		currentlyApproved sets.Set[string]
+		expectedScopes    []Scope
+	}{
+		{
+			testName:          "Empty PR",
+			filenames:         []string{},
+			currentlyApproved: sets.New[string](),
+			expectedScopes:    nil,
+		},
+		{
+			testName:          "Root file approved",
+			filenames:         []string{"kubernetes.go"},
+			currentlyApproved: sets.New[string]("Alice"),
+			expectedScopes:    []Scope{{Name: "", Approved: true, Approvers: []string{"Alice"}}},
+		},
+		{
+			testName:          "Nested directory covered by unapproved parent",
+			filenames:         []string{"a/test.go", "a/d/test.go", "b/test.go"},
+			currentlyApproved: sets.New[string]("Dan"),
+			expectedScopes: []Scope{
+				{Name: "a", Suggested: []string{"anne"}},
+				{Name: "b", Suggested: []string{"bill"}},
+			},
+		},
+		{
+			testName:          "All scopes approved",
+			filenames:         []string{"a/d/test.go", "b/test.go"},
+			currentlyApproved: sets.New[string]("Dan", "Bill"),
+			expectedScopes: []Scope{
+				{Name: "a", Approved: true, Approvers: []string{"Dan"}},
+				{Name: "b", Approved: true, Approvers: []string{"Bill"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			testApprovers := NewApprovers(Owners{filenames: test.filenames, repo: createFakeRepo(FakeRepoMap), seed: TestSeed, log: logrus.WithField("plugin", "some_plugin")})
+			for approver := range test.currentlyApproved {
+				testApprovers.AddApprover(approver, "REFERENCE", false)
+			}
+			if diff := cmp.Diff(test.expectedScopes, testApprovers.GetScopes(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("expected scopes differ from actual: %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetCCs(t *testing.T) {
 	rootApprovers := sets.New[string]("Alice", "Bob")
 	aApprovers := sets.New[string]("Art", "Anne")
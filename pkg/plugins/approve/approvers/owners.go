@@ -292,6 +292,10 @@ type Approvers struct {
 	assignees       sets.Set[string]
 	AssociatedIssue int
 	RequireIssue    bool
+	// ShowScopes adds a checklist of the top-level directories touched by the
+	// PR to the notification, with suggested approvers for those still
+	// needing approval.
+	ShowScopes bool
 
 	ManuallyApproved func() bool
 }
@@ -511,6 +515,66 @@ func (ap Approvers) GetFiles(baseURL *url.URL, branch string) []File {
 	return allOwnersFiles
 }
 
+// maxScopeSuggestions is the number of approvers suggested for a scope when
+// none of the approvers suggested for the whole PR can approve it.
+const maxScopeSuggestions = 3
+
+// GetScopes groups the OWNERS files of the PR by top-level directory and
+// returns their approval progress, sorted by directory.
+func (ap Approvers) GetScopes() []Scope {
+	filesApprovers := ap.GetFilesApprovers()
+	unapprovedFiles := ap.UnapprovedFiles()
+	scopeFiles := map[string][]string{}
+	for _, file := range sets.List(ap.owners.GetOwnersSet()) {
+		name := topLevelDir(file)
+		scopeFiles[name] = append(scopeFiles[name], file)
+	}
+
+	ccs := sets.New[string](ap.GetCCs()...)
+	potentialApprovers := ap.owners.GetApprovers()
+	leafApprovers := ap.owners.GetLeafApprovers()
+	var scopes []Scope
+	for _, name := range sets.List(sets.KeySet(scopeFiles)) {
+		scope := Scope{Name: name, Approved: true}
+		approvers := sets.New[string]()
+		candidates := sets.New[string]()
+		leafCandidates := sets.New[string]()
+		for _, file := range scopeFiles[name] {
+			approvers.Insert(sets.List(filesApprovers[file])...)
+			if unapprovedFiles.Has(file) {
+				scope.Approved = false
+				candidates.Insert(sets.List(potentialApprovers[file])...)
+				leafCandidates.Insert(sets.List(leafApprovers[file])...)
+			}
+		}
+		scope.Approvers = sets.List(approvers)
+		if !scope.Approved {
+			// Prefer the approvers already suggested for the PR so that the
+			// checklist and the CC list agree, otherwise fall back to the
+			// approvers closest to the changes.
+			scope.Suggested = sets.List(ccs.Intersection(candidates))
+			if len(scope.Suggested) == 0 {
+				scope.Suggested = sets.List(leafCandidates.Difference(ap.GetCurrentApproversSet()))
+				if len(scope.Suggested) > maxScopeSuggestions {
+					scope.Suggested = scope.Suggested[:maxScopeSuggestions]
+				}
+			}
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// topLevelDir returns the top-level directory of an OWNERS file path, or the
+// empty string for the root of the repository.
+func topLevelDir(ownersFile string) string {
+	dir, _, found := strings.Cut(ownersFile, "/")
+	if !found && strings.HasSuffix(ownersFile, ".md") {
+		return ""
+	}
+	return dir
+}
+
 // GetCCs gets the list of suggested approvers for a pull-request.  It
 // now considers current assignees as potential approvers. Here is how
 // it works:
@@ -652,6 +716,39 @@ func (ua UnapprovedFile) String() string {
 	return fmt.Sprintf("- **[%s](%s)**\n", fullOwnersPath, link)
 }
 
+// Scope is the approval progress of the OWNERS files under a top-level
+// directory of the repository.
+type Scope struct {
+	// Name is the top-level directory, empty for the root of the repository.
+	Name     string
+	Approved bool
+	// Approvers is the set of users that approved changes in the scope.
+	Approvers []string
+	// Suggested is the list of users that could approve the rest of the scope.
+	Suggested []string
+}
+
+func (s Scope) String() string {
+	name := "`/`"
+	if s.Name != "" {
+		name = "`" + s.Name + "/`"
+	}
+	if s.Approved && len(s.Approvers) == 0 {
+		return fmt.Sprintf("- [x] %s\n", name)
+	}
+	if s.Approved {
+		return fmt.Sprintf("- [x] %s approved by %s\n", name, strings.Join(s.Approvers, ", "))
+	}
+	var suggested []string
+	for _, login := range s.Suggested {
+		suggested = append(suggested, fmt.Sprintf("[%s](https://github.com/%s)", login, login))
+	}
+	if len(suggested) == 0 {
+		return fmt.Sprintf("- [ ] %s\n", name)
+	}
+	return fmt.Sprintf("- [ ] %s suggested approvers: %s\n", name, strings.Join(suggested, ", "))
+}
+
 // GenerateTemplate takes a template, name and data, and generates
 // the corresponding string.
 func GenerateTemplate(templ, name string, data interface{}) (string, error) {
@@ -714,6 +811,11 @@ The full list of commands accepted by this bot can be found [here]({{ .commandHe
 The pull request process is described [here]({{ .prProcessLink }})
 
 {{ end -}}
+{{if .ap.ShowScopes -}}
+Approval progress by directory:
+
+{{range .ap.GetScopes}}{{.}}{{end}}
+{{end -}}
 <details {{if (and (not .ap.AreFilesApproved) (not (call .ap.ManuallyApproved))) }}open{{end}}>
 Needs approval from an approver in each of these files:
 
@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package artifactgate implements the `artifact-gate` plugin. It keeps a
+// status context (`artifacts-verified` by default) on PRs that only turns
+// green once a policy service confirms that every artifact built by the
+// configured presubmits for the PR head was scanned and signed.
+package artifactgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "artifact-gate"
+
+var (
+	handlePRActions = map[github.PullRequestEventAction]bool{
+		github.PullRequestActionOpened:      true,
+		github.PullRequestActionReopened:    true,
+		github.PullRequestActionSynchronize: true,
+	}
+
+	// policyClient is used to query the policy service.
+	policyClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+	plugins.RegisterStatusEventHandler(PluginName, handleStatusEvent, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		cfg := config.ArtifactGateFor(repo.Org, repo.Repo)
+		if cfg == nil {
+			continue
+		}
+		configInfo[repo.String()] = fmt.Sprintf("The %q context is set once the artifacts built by %s are verified by %s.", cfg.Context, strings.Join(cfg.Jobs, ", "), cfg.PolicyURL)
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		ArtifactGate: map[string]*plugins.ArtifactGate{
+			"org/repo": {
+				Jobs:      []string{"pull-repo-build-images"},
+				PolicyURL: "https://policy.example.com/verify",
+				Context:   "artifacts-verified",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: "The artifact-gate plugin sets a status context on PRs that only succeeds once a policy service confirms that all artifacts built by the configured presubmits for the PR head were scanned and signed. Make the context required in branch protection to block merging unverified artifacts.",
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	CreateStatus(org, repo, ref string, s github.Status) error
+	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
+}
+
+type prowJobClient interface {
+	List(context.Context, metav1.ListOptions) (*prowapi.ProwJobList, error)
+}
+
+// verifyRequest is sent to the policy service for every configured job.
+type verifyRequest struct {
+	Org     string `json:"org"`
+	Repo    string `json:"repo"`
+	SHA     string `json:"sha"`
+	Job     string `json:"job"`
+	BuildID string `json:"build_id"`
+}
+
+// verifyResponse lists the artifacts the policy service knows for a build.
+type verifyResponse struct {
+	Artifacts []artifact `json:"artifacts"`
+}
+
+type artifact struct {
+	Name    string `json:"name"`
+	Digest  string `json:"digest"`
+	Scanned bool   `json:"scanned"`
+	Signed  bool   `json:"signed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
+	return handlePR(pc.GitHubClient, pc.PluginConfig, pre)
+}
+
+func handlePR(ghc githubClient, pluginConfig *plugins.Configuration, pre github.PullRequestEvent) error {
+	if !handlePRActions[pre.Action] {
+		return nil
+	}
+	org, repo := pre.Repo.Owner.Login, pre.Repo.Name
+	cfg := pluginConfig.ArtifactGateFor(org, repo)
+	if cfg == nil {
+		return nil
+	}
+	return ghc.CreateStatus(org, repo, pre.PullRequest.Head.SHA, github.Status{
+		State:       github.StatusPending,
+		Context:     cfg.Context,
+		Description: fmt.Sprintf("Waiting for %s to build artifacts.", strings.Join(cfg.Jobs, ", ")),
+	})
+}
+
+func handleStatusEvent(pc plugins.Agent, se github.StatusEvent) error {
+	org, repo := se.Repo.Owner.Login, se.Repo.Name
+	cfg := pc.PluginConfig.ArtifactGateFor(org, repo)
+	if cfg == nil {
+		return nil
+	}
+	contexts := jobContexts(pc.Config.GetPresubmitsStatic(org+"/"+repo), cfg.Jobs)
+	return handle(pc.GitHubClient, pc.ProwJobClient, policyClient, pc.Logger, cfg, contexts, se)
+}
+
+// jobContexts maps the status contexts of the given jobs to the job names.
+// Jobs that are not configured as presubmits are assumed to report under
+// their own name.
+func jobContexts(presubmits []config.Presubmit, jobs []string) map[string]string {
+	contexts := map[string]string{}
+	for _, job := range jobs {
+		name := job
+		for _, ps := range presubmits {
+			if ps.Name == job && ps.Context != "" {
+				name = ps.Context
+				break
+			}
+		}
+		contexts[name] = job
+	}
+	return contexts
+}
+
+func handle(ghc githubClient, pjc prowJobClient, hc *http.Client, log *logrus.Entry, cfg *plugins.ArtifactGate, contexts map[string]string, se github.StatusEvent) error {
+	job, ok := contexts[se.Context]
+	if !ok {
+		return nil
+	}
+	org, repo := se.Repo.Owner.Login, se.Repo.Name
+	setStatus := func(state, desc string) error {
+		return ghc.CreateStatus(org, repo, se.SHA, github.Status{
+			State:       state,
+			Context:     cfg.Context,
+			Description: truncate(desc),
+		})
+	}
+	// A rerun of a job invalidates any earlier verification of its artifacts.
+	if se.State == github.StatusPending {
+		return setStatus(github.StatusPending, fmt.Sprintf("Waiting for %s to build artifacts.", job))
+	}
+	if se.State != github.StatusSuccess {
+		return setStatus(github.StatusFailure, fmt.Sprintf("%s failed, artifacts cannot be verified.", job))
+	}
+
+	combined, err := ghc.GetCombinedStatus(org, repo, se.SHA)
+	if err != nil {
+		return fmt.Errorf("failed to get combined status of %s: %w", se.SHA, err)
+	}
+	states := map[string]string{}
+	for _, status := range combined.Statuses {
+		states[status.Context] = status.State
+	}
+	// The event that triggered us may not be part of the combined status yet.
+	states[se.Context] = se.State
+	for name, job := range contexts {
+		if states[name] != github.StatusSuccess {
+			log.WithField("job", job).Debug("Waiting for job to succeed before verifying artifacts.")
+			if states[cfg.Context] == github.StatusPending {
+				return nil
+			}
+			return setStatus(github.StatusPending, fmt.Sprintf("Waiting for %s to build artifacts.", job))
+		}
+	}
+
+	for _, job := range cfg.Jobs {
+		buildID, err := latestBuildID(pjc, org, repo, job, se.SHA)
+		if err != nil {
+			return err
+		}
+		if buildID == "" {
+			return setStatus(github.StatusFailure, fmt.Sprintf("No successful run of %s found for %s.", job, se.SHA))
+		}
+		artifacts, err := verify(hc, cfg.PolicyURL, verifyRequest{Org: org, Repo: repo, SHA: se.SHA, Job: job, BuildID: buildID})
+		if err != nil {
+			log.WithError(err).WithField("job", job).Warn("Failed to query the policy service.")
+			return setStatus(github.StatusError, fmt.Sprintf("Failed to verify the artifacts of %s.", job))
+		}
+		if len(artifacts) == 0 {
+			return setStatus(github.StatusFailure, fmt.Sprintf("No artifacts of %s are known to the policy service.", job))
+		}
+		for _, a := range artifacts {
+			if a.Scanned && a.Signed {
+				continue
+			}
+			desc := fmt.Sprintf("%s@%s is not verified", a.Name, a.Digest)
+			if a.Reason != "" {
+				desc += ": " + a.Reason
+			}
+			return setStatus(github.StatusFailure, desc+".")
+		}
+	}
+	return setStatus(github.StatusSuccess, "All artifacts are scanned and signed.")
+}
+
+// latestBuildID returns the build ID of the most recent successful run of the
+// presubmit for the given head SHA, or an empty string if there is none.
+func latestBuildID(pjc prowJobClient, org, repo, job, sha string) (string, error) {
+	// The job label holds the job name truncated the same way as for any prowjob.
+	jobLabels, _ := decorate.LabelsAndAnnotationsForSpec(prowapi.ProwJobSpec{Type: prowapi.PresubmitJob, Job: job}, nil, nil)
+	selector := labels.SelectorFromSet(labels.Set{
+		kube.OrgLabel:          org,
+		kube.RepoLabel:         repo,
+		kube.ProwJobTypeLabel:  string(prowapi.PresubmitJob),
+		kube.ProwJobAnnotation: jobLabels[kube.ProwJobAnnotation],
+	})
+	pjs, err := pjc.List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list prowjobs: %w", err)
+	}
+	var latest *prowapi.ProwJob
+	for i := range pjs.Items {
+		pj := &pjs.Items[i]
+		if pj.Spec.Job != job || pj.Status.State != prowapi.SuccessState || pj.Spec.Refs == nil || len(pj.Spec.Refs.Pulls) == 0 {
+			continue
+		}
+		if pj.Spec.Refs.Pulls[0].SHA != sha || pj.Status.CompletionTime == nil {
+			continue
+		}
+		if latest == nil || pj.Status.CompletionTime.After(latest.Status.CompletionTime.Time) {
+			latest = pj
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Status.BuildID, nil
+}
+
+func verify(hc *http.Client, url string, req verifyRequest) ([]artifact, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hc.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("policy service responded with %d: %s", resp.StatusCode, string(data))
+	}
+	var vr verifyResponse
+	if err := json.Unmarshal(data, &vr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return vr.Artifacts, nil
+}
+
+// truncate shortens status descriptions to the maximum length GitHub accepts.
+func truncate(desc string) string {
+	const maxLen = 140
+	if len(desc) <= maxLen {
+		return desc
+	}
+	return desc[:maxLen-3] + "..."
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactgate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	org  = "org"
+	repo = "repo"
+	sha  = "abcdef"
+	job  = "build-images"
+)
+
+func prowJob(name, job, sha string, state prowapi.ProwJobState, buildID string, completed time.Time) runtime.Object {
+	return &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "prowjobs",
+			Labels: map[string]string{
+				kube.OrgLabel:          org,
+				kube.RepoLabel:         repo,
+				kube.ProwJobTypeLabel:  string(prowapi.PresubmitJob),
+				kube.ProwJobAnnotation: job,
+			},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Type: prowapi.PresubmitJob,
+			Job:  job,
+			Refs: &prowapi.Refs{Org: org, Repo: repo, Pulls: []prowapi.Pull{{Number: 1, SHA: sha}}},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:          state,
+			BuildID:        buildID,
+			CompletionTime: &metav1.Time{Time: completed},
+		},
+	}
+}
+
+func TestHandlePR(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		action   github.PullRequestEventAction
+		repo     string
+		expected []github.Status
+	}{
+		{
+			name:   "opened PR gets a pending context",
+			action: github.PullRequestActionOpened,
+			repo:   repo,
+			expected: []github.Status{{
+				State:       github.StatusPending,
+				Context:     "artifacts-verified",
+				Description: "Waiting for build-images to build artifacts.",
+			}},
+		},
+		{
+			name:   "pushed PR gets a pending context",
+			action: github.PullRequestActionSynchronize,
+			repo:   repo,
+			expected: []github.Status{{
+				State:       github.StatusPending,
+				Context:     "artifacts-verified",
+				Description: "Waiting for build-images to build artifacts.",
+			}},
+		},
+		{
+			name:   "edited PR is ignored",
+			action: github.PullRequestActionEdited,
+			repo:   repo,
+		},
+		{
+			name:   "unconfigured repo is ignored",
+			action: github.PullRequestActionOpened,
+			repo:   "other",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ghc := fakegithub.NewFakeClient()
+			pluginConfig := &plugins.Configuration{
+				ArtifactGate: map[string]*plugins.ArtifactGate{
+					"org/repo": {Jobs: []string{job}, PolicyURL: "https://policy", Context: "artifacts-verified"},
+				},
+			}
+			pre := github.PullRequestEvent{
+				Action: tc.action,
+				Repo:   github.Repo{Owner: github.User{Login: org}, Name: tc.repo},
+				PullRequest: github.PullRequest{
+					Head: github.PullRequestBranch{SHA: sha},
+				},
+			}
+			if err := handlePR(ghc, pluginConfig, pre); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, ghc.CreatedStatuses[sha]); diff != "" {
+				t.Errorf("statuses differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	now := time.Now()
+	verified := []artifact{{Name: "image", Digest: "sha256:1", Scanned: true, Signed: true}}
+	for _, tc := range []struct {
+		name      string
+		jobs      []string
+		event     github.StatusEvent
+		existing  []github.Status
+		prowJobs  []runtime.Object
+		artifacts map[string][]artifact
+		policyErr bool
+
+		expectedRequests []verifyRequest
+		expectedStatus   *github.Status
+	}{
+		{
+			name:  "unrelated context is ignored",
+			jobs:  []string{job},
+			event: github.StatusEvent{Context: "unit", State: github.StatusSuccess},
+		},
+		{
+			name:     "rerun build resets the gate to pending",
+			jobs:     []string{job},
+			event:    github.StatusEvent{Context: job, State: github.StatusPending},
+			existing: []github.Status{{Context: "artifacts-verified", State: github.StatusSuccess}},
+			expectedStatus: &github.Status{
+				State:       github.StatusPending,
+				Context:     "artifacts-verified",
+				Description: "Waiting for build-images to build artifacts.",
+			},
+		},
+		{
+			name:  "failed build fails the gate",
+			jobs:  []string{job},
+			event: github.StatusEvent{Context: job, State: github.StatusFailure},
+			expectedStatus: &github.Status{
+				State:       github.StatusFailure,
+				Context:     "artifacts-verified",
+				Description: "build-images failed, artifacts cannot be verified.",
+			},
+		},
+		{
+			name:  "other builds still running keeps the pending gate",
+			jobs:  []string{job, "build-binaries"},
+			event: github.StatusEvent{Context: job, State: github.StatusSuccess},
+			existing: []github.Status{
+				{Context: "build-binaries", State: github.StatusPending},
+				{Context: "artifacts-verified", State: github.StatusPending},
+			},
+			expectedStatus: &github.Status{State: github.StatusPending, Context: "artifacts-verified"},
+		},
+		{
+			name:  "other builds still running after an earlier verification resets the gate to pending",
+			jobs:  []string{job, "build-binaries"},
+			event: github.StatusEvent{Context: job, State: github.StatusSuccess},
+			existing: []github.Status{
+				{Context: "build-binaries", State: github.StatusPending},
+				{Context: "artifacts-verified", State: github.StatusSuccess},
+			},
+			expectedStatus: &github.Status{
+				State:       github.StatusPending,
+				Context:     "artifacts-verified",
+				Description: "Waiting for build-binaries to build artifacts.",
+			},
+		},
+		{
+			name:      "verified artifacts pass the gate",
+			jobs:      []string{job},
+			event:     github.StatusEvent{Context: job, State: github.StatusSuccess},
+			prowJobs:  []runtime.Object{prowJob("a", job, sha, prowapi.SuccessState, "1", now)},
+			artifacts: map[string][]artifact{"1": verified},
+			expectedRequests: []verifyRequest{
+				{Org: org, Repo: repo, SHA: sha, Job: job, BuildID: "1"},
+			},
+			expectedStatus: &github.Status{
+				State:       github.StatusSuccess,
+				Context:     "artifacts-verified",
+				Description: "All artifacts are scanned and signed.",
+			},
+		},
+		{
+			name:  "latest successful run for the SHA is verified",
+			jobs:  []string{job},
+			event: github.StatusEvent{Context: job, State: github.StatusSuccess},
+			prowJobs: []runtime.Object{
+				prowJob("a", job, sha, prowapi.SuccessState, "1", now.Add(-time.Hour)),
+				prowJob("b", job, sha, prowapi.SuccessState, "2", now),
+				prowJob("c", job, sha, prowapi.FailureState, "3", now.Add(time.Hour)),
+				prowJob("d", job, "other", prowapi.SuccessState, "4", now.Add(time.Hour)),
+				prowJob("e", "unit", sha, prowapi.SuccessState, "5", now.Add(time.Hour)),
+			},
+			artifacts: map[string][]artifact{"2": verified},
+			expectedRequests: []verifyRequest{
+				{Org: org, Repo: repo, SHA: sha, Job: job, BuildID: "2"},
+			},
+			expectedStatus: &github.Status{
+				State:       github.StatusSuccess,
+				Context:     "artifacts-verified",
+				Description: "All artifacts are scanned and signed.",
+			},
+		},
+		{
+			name:     "unsigned artifact fails the gate",
+			jobs:     []string{job},
+			event:    github.StatusEvent{Context: job, State: github.StatusSuccess},
+			prowJobs: []runtime.Object{prowJob("a", job, sha, prowapi.SuccessState, "1", now)},
+			artifacts: map[string][]artifact{"1": {
+				{Name: "image", Digest: "sha256:1", Scanned: true, Signed: true},
+				{Name: "debug", Digest: "sha256:2", Scanned: true, Reason: "no signature found"},
+			}},
+			expectedRequests: []verifyRequest{
+				{Org: org, Repo: repo, SHA: sha, Job: job, BuildID: "1"},
+			},
+			expectedStatus: &github.Status{
+				State:       github.StatusFailure,
+				Context:     "artifacts-verified",
+				Description: "debug@sha256:2 is not verified: no signature found.",
+			},
+		},
+		{
+			name:     "no artifacts fails the gate",
+			jobs:     []string{job},
+			event:    github.StatusEvent{Context: job, State: github.StatusSuccess},
+			prowJobs: []runtime.Object{prowJob("a", job, sha, prowapi.SuccessState, "1", now)},
+			expectedRequests: []verifyRequest{
+				{Org: org, Repo: repo, SHA: sha, Job: job, BuildID: "1"},
+			},
+			expectedStatus: &github.Status{
+				State:       github.StatusFailure,
+				Context:     "artifacts-verified",
+				Description: "No artifacts of build-images are known to the policy service.",
+			},
+		},
+		{
+			name:  "missing prowjob fails the gate",
+			jobs:  []string{job},
+			event: github.StatusEvent{Context: job, State: github.StatusSuccess},
+			expectedStatus: &github.Status{
+				State:       github.StatusFailure,
+				Context:     "artifacts-verified",
+				Description: "No successful run of build-images found for abcdef.",
+			},
+		},
+		{
+			name:      "policy service errors set the gate to error",
+			jobs:      []string{job},
+			event:     github.StatusEvent{Context: job, State: github.StatusSuccess},
+			prowJobs:  []runtime.Object{prowJob("a", job, sha, prowapi.SuccessState, "1", now)},
+			policyErr: true,
+			expectedRequests: []verifyRequest{
+				{Org: org, Repo: repo, SHA: sha, Job: job, BuildID: "1"},
+			},
+			expectedStatus: &github.Status{
+				State:       github.StatusError,
+				Context:     "artifacts-verified",
+				Description: "Failed to verify the artifacts of build-images.",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []verifyRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req verifyRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				requests = append(requests, req)
+				if tc.policyErr {
+					http.Error(w, "boom", http.StatusInternalServerError)
+					return
+				}
+				json.NewEncoder(w).Encode(verifyResponse{Artifacts: tc.artifacts[req.BuildID]})
+			}))
+			defer server.Close()
+
+			ghc := fakegithub.NewFakeClient()
+			existing := append([]github.Status{{Context: tc.event.Context, State: tc.event.State}}, tc.existing...)
+			for _, status := range existing {
+				if err := ghc.CreateStatus(org, repo, sha, status); err != nil {
+					t.Fatalf("failed to create status: %v", err)
+				}
+			}
+			pjc := fake.NewSimpleClientset(tc.prowJobs...).ProwV1().ProwJobs("prowjobs")
+			cfg := &plugins.ArtifactGate{Jobs: tc.jobs, PolicyURL: server.URL, Context: "artifacts-verified"}
+			event := tc.event
+			event.SHA = sha
+			event.Repo = github.Repo{Owner: github.User{Login: org}, Name: repo}
+
+			if err := handle(ghc, pjc, server.Client(), logrus.WithField("plugin", PluginName), cfg, jobContexts(nil, tc.jobs), event); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedRequests, requests); diff != "" {
+				t.Errorf("policy requests differ from expected (-want +got):\n%s", diff)
+			}
+			var actual *github.Status
+			for _, status := range ghc.CreatedStatuses[sha] {
+				if status.Context == cfg.Context {
+					actual = &status
+				}
+			}
+			if diff := cmp.Diff(tc.expectedStatus, actual); diff != "" {
+				t.Errorf("gate status differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobContexts(t *testing.T) {
+	presubmits := []config.Presubmit{
+		{JobBase: config.JobBase{Name: "build-images"}, Reporter: config.Reporter{Context: "ci/build-images"}},
+		{JobBase: config.JobBase{Name: "unit"}, Reporter: config.Reporter{Context: "ci/unit"}},
+	}
+	expected := map[string]string{
+		"ci/build-images": "build-images",
+		"build-binaries":  "build-binaries",
+	}
+	if diff := cmp.Diff(expected, jobContexts(presubmits, []string{"build-images", "build-binaries"})); diff != "" {
+		t.Errorf("contexts differ from expected (-want +got):\n%s", diff)
+	}
+}
@@ -48,12 +48,22 @@ func init() {
 	plugins.RegisterGenericCommentHandler(PluginName, handleGenericCommentEvent, helpProvider)
 }
 
-func configString(reviewCount int) string {
+func configString(reviewCount int, config plugins.Blunderbuss) string {
 	var pluralSuffix string
 	if reviewCount > 1 {
 		pluralSuffix = "s"
 	}
-	return fmt.Sprintf("Blunderbuss is currently configured to request reviews from %d reviewer%s.", reviewCount, pluralSuffix)
+	str := fmt.Sprintf("Blunderbuss is currently configured to request reviews from %d reviewer%s.", reviewCount, pluralSuffix)
+	if config.UseReviewLoad {
+		str += " Reviewers with fewer open review requests are preferred."
+		if config.MaxReviewLoad > 0 {
+			str += fmt.Sprintf(" Reviewers with %d or more open review requests are only requested with /auto-cc.", config.MaxReviewLoad)
+		}
+	}
+	if config.TimeZonesFile != "" {
+		str += " Reviewers within their working hours are preferred."
+	}
+	return str
 }
 
 func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
@@ -77,14 +87,14 @@ func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhel
 	pluginHelp := &pluginhelp.PluginHelp{
 		Description: "The blunderbuss plugin automatically requests reviews from reviewers when a new PR is created. The reviewers are selected based on the reviewers specified in the OWNERS files that apply to the files modified by the PR.",
 		Config: map[string]string{
-			"": configString(reviewCount),
+			"": configString(reviewCount, config.Blunderbuss),
 		},
 		Snippet: yamlSnippet,
 	}
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/auto-cc",
 		Featured:    false,
-		Description: "Manually request reviews from reviewers for a PR. Useful if OWNERS file were updated since the PR was opened.",
+		Description: "Manually request reviews from reviewers for a PR. Useful if OWNERS file were updated since the PR was opened. Reviewers that would be skipped for having too many open review requests are requested too.",
 		Examples:    []string{"/auto-cc"},
 		WhoCanUse:   "Anyone",
 	})
@@ -166,6 +176,7 @@ func handlePullRequest(ghc githubClient, roc repoownersClient, log *logrus.Entry
 		config.MaxReviewerCount,
 		config.ExcludeApprovers,
 		config.UseStatusAvailability,
+		newReviewLoad(ghc, log, config, repo.Owner.Login, match.MatchString(pr.Body)),
 		repo,
 		pr,
 	)
@@ -208,12 +219,13 @@ func handleGenericComment(ghc githubClient, roc repoownersClient, log *logrus.En
 		config.MaxReviewerCount,
 		config.ExcludeApprovers,
 		config.UseStatusAvailability,
+		newReviewLoad(ghc, log, config, repo.Owner.Login, true),
 		repo,
 		pr,
 	)
 }
 
-func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerCount *int, maxReviewers int, excludeApprovers bool, useStatusAvailability bool, repo *github.Repo, pr *github.PullRequest) error {
+func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerCount *int, maxReviewers int, excludeApprovers bool, useStatusAvailability bool, rl *reviewLoad, repo *github.Repo, pr *github.PullRequest) error {
 	oc, err := roc.LoadRepoOwners(repo.Owner.Login, repo.Name, pr.Base.Ref)
 	if err != nil {
 		return fmt.Errorf("error loading RepoOwners: %w", err)
@@ -227,7 +239,7 @@ func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerC
 	var reviewers []string
 	var requiredReviewers []string
 	if reviewerCount != nil {
-		reviewers, requiredReviewers, err = getReviewers(oc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability)
+		reviewers, requiredReviewers, err = getReviewers(oc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability, rl)
 		if err != nil {
 			return err
 		}
@@ -238,7 +250,7 @@ func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerC
 				// and approvers and the search might stop too early if it finds
 				// duplicates.
 				frc := fallbackReviewersClient{ownersClient: oc}
-				approvers, _, err := getReviewers(frc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability)
+				approvers, _, err := getReviewers(frc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability, rl)
 				if err != nil {
 					return err
 				}
@@ -274,7 +286,7 @@ func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerC
 	return nil
 }
 
-func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, author string, files []github.PullRequestChange, minReviewers int, useStatusAvailability bool) ([]string, []string, error) {
+func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, author string, files []github.PullRequestChange, minReviewers int, useStatusAvailability bool, rl *reviewLoad) ([]string, []string, error) {
 	authorSet := sets.New[string](github.NormLogin(author))
 	reviewers := layeredsets.NewString()
 	requiredReviewers := sets.New[string]()
@@ -300,14 +312,14 @@ func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, autho
 			continue
 		}
 		leafReviewers = leafReviewers.Union(fileUnusedLeaves)
-		if r := findReviewer(ghc, log, useStatusAvailability, &busyReviewers, &fileUnusedLeaves); r != "" {
+		if r := findReviewer(ghc, log, useStatusAvailability, rl, &busyReviewers, &fileUnusedLeaves); r != "" {
 			reviewers.Insert(0, r)
 		}
 	}
 	// now ensure that we request review from at least minReviewers reviewers. Favor leaf reviewers.
 	unusedLeaves := leafReviewers.Difference(reviewers.Set())
 	for reviewers.Len() < minReviewers && unusedLeaves.Len() > 0 {
-		if r := findReviewer(ghc, log, useStatusAvailability, &busyReviewers, &unusedLeaves); r != "" {
+		if r := findReviewer(ghc, log, useStatusAvailability, rl, &busyReviewers, &unusedLeaves); r != "" {
 			reviewers.Insert(1, r)
 		}
 	}
@@ -317,7 +329,7 @@ func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, autho
 		}
 		fileReviewers := rc.Reviewers(file.Filename).Difference(authorSet)
 		for reviewers.Len() < minReviewers && fileReviewers.Len() > 0 {
-			if r := findReviewer(ghc, log, useStatusAvailability, &busyReviewers, &fileReviewers); r != "" {
+			if r := findReviewer(ghc, log, useStatusAvailability, rl, &busyReviewers, &fileReviewers); r != "" {
 				reviewers.Insert(2, r)
 			}
 		}
@@ -326,11 +338,15 @@ func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, autho
 }
 
 // findReviewer finds a reviewer from a set, potentially using status
-// availability.
-func findReviewer(ghc githubClient, log *logrus.Entry, useStatusAvailability bool, busyReviewers *sets.Set[string], targetSet *layeredsets.String) string {
+// availability and review load.
+func findReviewer(ghc githubClient, log *logrus.Entry, useStatusAvailability bool, rl *reviewLoad, busyReviewers *sets.Set[string], targetSet *layeredsets.String) string {
+	pop := targetSet.PopRandom
+	if rl != nil {
+		pop = func() string { return rl.pop(targetSet) }
+	}
 	// if we don't care about status availability, just pop a target from the set
 	if !useStatusAvailability {
-		return targetSet.PopRandom()
+		return pop()
 	}
 
 	// if we do care, start looping through the candidates
@@ -339,7 +355,11 @@ func findReviewer(ghc githubClient, log *logrus.Entry, useStatusAvailability boo
 			// if there are no candidates left, then break
 			break
 		}
-		candidate := targetSet.PopRandom()
+		candidate := pop()
+		if candidate == "" {
+			// all remaining candidates were overloaded
+			break
+		}
 		if busyReviewers.Has(candidate) {
 			// we've already verified this reviewer is busy
 			continue
@@ -44,6 +44,8 @@ type fakeGitHubClient struct {
 	pr        *github.PullRequest
 	changes   []github.PullRequestChange
 	requested []string
+	// loads is the number of open review requests per user
+	loads map[string]int
 }
 
 func newFakeGitHubClient(pr *github.PullRequest, filesChanged []string) *fakeGitHubClient {
@@ -86,14 +88,19 @@ func (c *fakeGitHubClient) GetPullRequest(org, repo string, num int) (*github.Pu
 }
 
 func (c *fakeGitHubClient) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
-	sq, ok := q.(*githubAvailabilityQuery)
-	if !ok {
+	switch sq := q.(type) {
+	case *githubAvailabilityQuery:
+		sq.User.Login = vars["user"].(githubql.String)
+		if sq.User.Login == githubql.String("busy-user") {
+			sq.User.Status.IndicatesLimitedAvailability = githubql.Boolean(true)
+		}
+	case *reviewLoadQuery:
+		query := string(vars["query"].(githubql.String))
+		user := query[strings.LastIndex(query, "review-requested:")+len("review-requested:"):]
+		sq.Search.IssueCount = githubql.Int(c.loads[user])
+	default:
 		return errors.New("unexpected query type")
 	}
-	sq.User.Login = vars["user"].(githubql.String)
-	if sq.User.Login == githubql.String("busy-user") {
-		sq.User.Status.IndicatesLimitedAvailability = githubql.Boolean(true)
-	}
 	return nil
 }
 
@@ -355,7 +362,7 @@ func TestHandleWithExcludeApproversOnlyReviewers(t *testing.T) {
 
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, true, false, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, true, false, nil, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
@@ -397,7 +404,7 @@ func TestHandleWithoutExcludeApproversNoReviewers(t *testing.T) {
 
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, false, false, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, false, false, nil, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
@@ -542,7 +549,7 @@ func TestHandleWithoutExcludeApproversMixed(t *testing.T) {
 		fghc := newFakeGitHubClient(&pr, tc.filesChanged)
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, false, false, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, false, false, nil, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
@@ -585,6 +592,8 @@ func TestHandlePullRequest(t *testing.T) {
 		draft             bool
 		ignoreDrafts      bool
 		ignoreAuthors     []string
+		loads             map[string]int
+		maxReviewLoad     int
 	}{
 		{
 			name:              "PR opened",
@@ -636,6 +645,32 @@ func TestHandlePullRequest(t *testing.T) {
 			action:        github.PullRequestActionOpened,
 			filesChanged:  []string{"a.go"},
 			ignoreAuthors: []string{"author"},
+		}, {
+			name:          "PR opened, only reviewer is overloaded, do not assign review to PR",
+			action:        github.PullRequestActionOpened,
+			filesChanged:  []string{"a.go"},
+			reviewerCount: 1,
+			loads:         map[string]int{"al": 3},
+			maxReviewLoad: 3,
+		},
+		{
+			name:              "PR opened with /auto-cc, only reviewer is overloaded, assign review to PR",
+			action:            github.PullRequestActionOpened,
+			body:              "/auto-cc",
+			filesChanged:      []string{"a.go"},
+			reviewerCount:     1,
+			loads:             map[string]int{"al": 3},
+			maxReviewLoad:     3,
+			expectedRequested: []string{"al"},
+		},
+		{
+			name:              "PR opened, reviewer below the maximum load, assign review to PR",
+			action:            github.PullRequestActionOpened,
+			filesChanged:      []string{"a.go"},
+			reviewerCount:     1,
+			loads:             map[string]int{"al": 2},
+			maxReviewLoad:     3,
+			expectedRequested: []string{"al"},
 		},
 	}
 	for _, tc := range testcases {
@@ -643,12 +678,15 @@ func TestHandlePullRequest(t *testing.T) {
 			pr := github.PullRequest{Number: 5, User: github.User{Login: "author"}, Body: tc.body, Draft: tc.draft}
 			repo := github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}
 			fghc := newFakeGitHubClient(&pr, tc.filesChanged)
+			fghc.loads = tc.loads
 			c := plugins.Blunderbuss{
 				ReviewerCount:    &tc.reviewerCount,
 				MaxReviewerCount: 0,
 				ExcludeApprovers: false,
 				IgnoreDrafts:     tc.ignoreDrafts,
 				IgnoreAuthors:    tc.ignoreAuthors,
+				UseReviewLoad:    tc.maxReviewLoad > 0,
+				MaxReviewLoad:    tc.maxReviewLoad,
 			}
 
 			if err := handlePullRequest(
@@ -794,7 +832,7 @@ func TestHelpProvider(t *testing.T) {
 			name:               "Empty config",
 			config:             &plugins.Configuration{},
 			enabledRepos:       enabledRepos,
-			configInfoIncludes: []string{configString(0)},
+			configInfoIncludes: []string{configString(0, plugins.Blunderbuss{})},
 		},
 		{
 			name: "ReviewerCount specified",
@@ -804,7 +842,18 @@ func TestHelpProvider(t *testing.T) {
 				},
 			},
 			enabledRepos:       enabledRepos,
-			configInfoIncludes: []string{configString(2)},
+			configInfoIncludes: []string{configString(2, plugins.Blunderbuss{})},
+		},
+		{
+			name: "Review load specified",
+			config: &plugins.Configuration{
+				Blunderbuss: plugins.Blunderbuss{
+					UseReviewLoad: true,
+					MaxReviewLoad: 5,
+				},
+			},
+			enabledRepos:       enabledRepos,
+			configInfoIncludes: []string{"Reviewers with 5 or more open review requests are only requested with /auto-cc."},
 		},
 	}
 	for _, c := range cases {
@@ -877,7 +926,7 @@ func TestPopActiveReviewer(t *testing.T) {
 		fghc := newFakeGitHubClient(&pr, tc.filesChanged)
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, false, true, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, false, true, nil, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
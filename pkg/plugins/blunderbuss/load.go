@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blunderbuss
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/layeredsets"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	workdayStartHour = 9
+	workdayEndHour   = 17
+)
+
+// reviewLoad ranks candidate reviewers by the number of open pull requests
+// requesting their review and by whether it is currently within their working
+// hours.
+type reviewLoad struct {
+	ghc           githubClient
+	log           *logrus.Entry
+	org           string
+	useReviewLoad bool
+	maxLoad       int
+	// override is set when reviews were explicitly requested with /auto-cc,
+	// in which case overloaded candidates are still requested.
+	override  bool
+	timeZones map[string]*time.Location
+	now       func() time.Time
+
+	loads map[string]int
+}
+
+// newReviewLoad returns nil if the config does not enable load-aware
+// selection, in which case candidates are picked at random.
+func newReviewLoad(ghc githubClient, log *logrus.Entry, config plugins.Blunderbuss, org string, override bool) *reviewLoad {
+	if !config.UseReviewLoad && len(config.TimeZones) == 0 {
+		return nil
+	}
+	rl := &reviewLoad{
+		ghc:           ghc,
+		log:           log,
+		org:           org,
+		useReviewLoad: config.UseReviewLoad,
+		maxLoad:       config.MaxReviewLoad,
+		override:      override,
+		timeZones:     config.TimeZones,
+		now:           time.Now,
+		loads:         map[string]int{},
+	}
+	return rl
+}
+
+type reviewLoadQuery struct {
+	Search struct {
+		IssueCount githubql.Int
+	} `graphql:"search(query: $query, type: ISSUE)"`
+}
+
+// load returns the number of open pull requests in the org that request a
+// review from the user. Errors are logged and count as no load.
+func (rl *reviewLoad) load(user string) int {
+	if !rl.useReviewLoad {
+		return 0
+	}
+	if load, ok := rl.loads[user]; ok {
+		return load
+	}
+	var query reviewLoadQuery
+	vars := map[string]interface{}{
+		"query": githubql.String(fmt.Sprintf("org:%s is:pr is:open archived:false review-requested:%s", rl.org, user)),
+	}
+	if err := rl.ghc.Query(context.Background(), &query, vars); err != nil {
+		rl.log.WithField("user", user).WithError(err).Error("Error checking user review load")
+	}
+	rl.loads[user] = int(query.Search.IssueCount)
+	return rl.loads[user]
+}
+
+// workingHours returns whether it is currently a weekday between
+// workdayStartHour and workdayEndHour in the user's time zone.
+func (rl *reviewLoad) workingHours(user string) bool {
+	loc, ok := rl.timeZones[github.NormLogin(user)]
+	if !ok {
+		return true
+	}
+	now := rl.now().In(loc)
+	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return false
+	}
+	return now.Hour() >= workdayStartHour && now.Hour() < workdayEndHour
+}
+
+// pop removes and returns the preferred candidate of the highest priority
+// layer that has one: candidates within their working hours come first, then
+// those with the fewest open review requests. Ties are broken at random.
+// Overloaded candidates are removed from the set unless overridden.
+func (rl *reviewLoad) pop(targetSet *layeredsets.String) string {
+	for _, layer := range *targetSet {
+		candidates := layer.UnsortedList()
+		sort.Strings(candidates)
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		var eligible []string
+		for _, candidate := range candidates {
+			if rl.maxLoad > 0 && !rl.override && rl.load(candidate) >= rl.maxLoad {
+				rl.log.WithField("user", candidate).Debug("User has too many open review requests")
+				targetSet.Delete(candidate)
+				continue
+			}
+			eligible = append(eligible, candidate)
+		}
+		if len(eligible) == 0 {
+			continue
+		}
+		sort.SliceStable(eligible, func(i, j int) bool {
+			iWorking, jWorking := rl.workingHours(eligible[i]), rl.workingHours(eligible[j])
+			if iWorking != jWorking {
+				return iWorking
+			}
+			return rl.load(eligible[i]) < rl.load(eligible[j])
+		})
+		targetSet.Delete(eligible[0])
+		return eligible[0]
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blunderbuss
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/layeredsets"
+)
+
+func TestReviewLoadPop(t *testing.T) {
+	// Wednesday 12:00 UTC
+	now := time.Date(2026, time.January, 7, 12, 0, 0, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+
+	testcases := []struct {
+		name          string
+		candidates    layeredsets.String
+		loads         map[string]int
+		useReviewLoad bool
+		maxLoad       int
+		override      bool
+		timeZones     map[string]*time.Location
+		expected      []string
+	}{
+		{
+			name:          "least loaded candidates first",
+			candidates:    layeredsets.NewString("alice", "bob", "carol"),
+			loads:         map[string]int{"alice": 4, "bob": 1, "carol": 2},
+			useReviewLoad: true,
+			expected:      []string{"bob", "carol", "alice"},
+		},
+		{
+			name:          "overloaded candidates are skipped",
+			candidates:    layeredsets.NewString("alice", "bob", "carol"),
+			loads:         map[string]int{"alice": 4, "bob": 1, "carol": 2},
+			useReviewLoad: true,
+			maxLoad:       2,
+			expected:      []string{"bob"},
+		},
+		{
+			name:          "override requests overloaded candidates",
+			candidates:    layeredsets.NewString("alice", "bob", "carol"),
+			loads:         map[string]int{"alice": 4, "bob": 1, "carol": 2},
+			useReviewLoad: true,
+			maxLoad:       2,
+			override:      true,
+			expected:      []string{"bob", "carol", "alice"},
+		},
+		{
+			name:       "candidates within working hours first",
+			candidates: layeredsets.NewString("alice", "bob"),
+			timeZones:  map[string]*time.Location{"alice": tokyo, "bob": berlin},
+			expected:   []string{"bob", "alice"},
+		},
+		{
+			name:          "working hours take precedence over load",
+			candidates:    layeredsets.NewString("alice", "bob", "carol"),
+			loads:         map[string]int{"alice": 0, "bob": 3, "carol": 1},
+			useReviewLoad: true,
+			timeZones:     map[string]*time.Location{"alice": tokyo, "bob": berlin},
+			expected:      []string{"carol", "bob", "alice"},
+		},
+		{
+			name:          "layers are respected",
+			candidates:    layeredsets.NewStringFromSlices([]string{"alice"}, []string{"bob"}),
+			loads:         map[string]int{"alice": 4, "bob": 1},
+			useReviewLoad: true,
+			expected:      []string{"alice", "bob"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rl := &reviewLoad{
+				ghc:           &fakeGitHubClient{loads: tc.loads},
+				log:           logrus.WithField("plugin", PluginName),
+				org:           "org",
+				useReviewLoad: tc.useReviewLoad,
+				maxLoad:       tc.maxLoad,
+				override:      tc.override,
+				timeZones:     tc.timeZones,
+				now:           func() time.Time { return now },
+				loads:         map[string]int{},
+			}
+			var popped []string
+			for tc.candidates.Len() > 0 {
+				if candidate := rl.pop(&tc.candidates); candidate != "" {
+					popped = append(popped, candidate)
+				}
+			}
+			if diff := cmp.Diff(tc.expected, popped); diff != "" {
+				t.Errorf("popped candidates differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	cherrypicker "sigs.k8s.io/prow/cmd/external-plugins/cherrypicker/lib"
 	"sigs.k8s.io/prow/pkg/bugzilla"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
@@ -43,6 +44,8 @@ var (
 	refreshCommandMatch  = regexp.MustCompile(`(?mi)^/bugzilla refresh\s*$`)
 	qaAssignCommandMatch = regexp.MustCompile(`(?mi)^/bugzilla assign-qa\s*$`)
 	qaReviewCommandMatch = regexp.MustCompile(`(?mi)^/bugzilla cc-qa\s*$`)
+	skipCommandMatch     = regexp.MustCompile(`(?mi)^/bugzilla skip(?:[ \t]+(.*?))?\s*$`)
+	backportCommandMatch = regexp.MustCompile(`(?mi)^/bugzilla backport(?:[ \t]+(.*?))?\s*$`)
 	cherrypickPRMatch    = regexp.MustCompile(`This is an automated cherry-pick of #([0-9]+)`)
 )
 
@@ -54,6 +57,8 @@ const (
 	medSeverity         = "medium"
 	lowSeverity         = "low"
 	unspecifiedSeverity = "unspecified"
+	// maxAttachmentSize is the default maximum size of attachments in Bugzilla
+	maxAttachmentSize = 1000 * 1024
 )
 
 func init() {
@@ -109,6 +114,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			if opts[branch].DependentBugTargetReleases != nil {
 				conditions = append(conditions, fmt.Sprintf("have all dependent bugs in one of the following target releases: %s", strings.Join(*opts[branch].DependentBugTargetReleases, ", ")))
 			}
+			if opts[branch].RequireQAContact != nil && *opts[branch].RequireQAContact {
+				conditions = append(conditions, "have a QA contact whose public email belongs to a GitHub user")
+			}
 			switch len(conditions) {
 			case 0:
 				message += "exist"
@@ -124,12 +132,24 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			if opts[branch].StateAfterValidation != nil {
 				updates = append(updates, fmt.Sprintf("moved to the %s state", opts[branch].StateAfterValidation))
 			}
+			if opts[branch].FlagsAfterValidation != nil {
+				updates = append(updates, fmt.Sprintf("given the following flags: %s", prettyBugzillaFlags(*opts[branch].FlagsAfterValidation)))
+			}
 			if opts[branch].AddExternalLink != nil && *opts[branch].AddExternalLink {
 				updates = append(updates, "updated to refer to the pull request using the external bug tracker")
 			}
+			if opts[branch].AddExternalLink != nil && *opts[branch].AddExternalLink && opts[branch].CommentOnLink != nil && *opts[branch].CommentOnLink {
+				updates = append(updates, "given comments summarizing the pull request when it is linked and when it merges")
+			}
 			if opts[branch].StateAfterMerge != nil {
 				updates = append(updates, fmt.Sprintf("moved to the %s state when all linked pull requests are merged", opts[branch].StateAfterMerge))
 			}
+			if opts[branch].FlagsAfterMerge != nil {
+				updates = append(updates, fmt.Sprintf("given the following flags when all linked pull requests are merged: %s", prettyBugzillaFlags(*opts[branch].FlagsAfterMerge)))
+			}
+			if opts[branch].AttachDiffOnMerge != nil && *opts[branch].AttachDiffOnMerge {
+				updates = append(updates, "given the diff of the pull request as an attachment when it merges")
+			}
 
 			if len(updates) > 0 {
 				message += ". After being linked to a pull request, bugs will be "
@@ -144,6 +164,26 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 				updates[len(updates)-1] = fmt.Sprintf("and %s", updates[len(updates)-1])
 				message += strings.Join(updates, ", ")
 			}
+			if opts[branch].BugLabels != nil && len(*opts[branch].BugLabels) > 0 {
+				var mappings []string
+				for match, label := range *opts[branch].BugLabels {
+					mappings = append(mappings, fmt.Sprintf("%s for %s", label, match))
+				}
+				sort.Strings(mappings)
+				message += fmt.Sprintf(". Pull requests will be labeled according to the keywords and flags of the bug: %s", strings.Join(mappings, ", "))
+			}
+			if opts[branch].AllowPrivateBugs != nil && *opts[branch].AllowPrivateBugs {
+				message += ". Private bugs, which the bot is not permitted to access, are considered valid"
+			}
+			if opts[branch].PrivateBugLabel != nil {
+				message += fmt.Sprintf(". Pull requests referencing private bugs are labeled %q", *opts[branch].PrivateBugLabel)
+			}
+			if opts[branch].BackportChainComment != nil && *opts[branch].BackportChainComment {
+				message += ". The pull requests of backported bugs will show the backport chain in a comment"
+			}
+			if opts[branch].ExemptionLabel != nil {
+				message += fmt.Sprintf(". Collaborators may exempt pull requests from this requirement with <code>/bugzilla skip &lt;reason&gt;</code>, which adds the %q label", *opts[branch].ExemptionLabel)
+			}
 			configInfoStrings = append(configInfoStrings, "<li>"+message+".</li>")
 		}
 		configInfoStrings = append(configInfoStrings, "</ul>")
@@ -191,7 +231,17 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 						Status:     "RESET",
 						Resolution: "FIXED",
 					},
-					AllowedGroups: []string{"group1", "groups2"},
+					AllowedGroups:        []string{"group1", "groups2"},
+					ExemptionLabel:       str("bugzilla/no-bug-needed"),
+					AttachDiffOnMerge:    &yes,
+					FlagsAfterValidation: &[]plugins.BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}},
+					FlagsAfterMerge:      &[]plugins.BugzillaFlag{{Name: "requires_doc_text", Status: "?"}},
+					CommentOnLink:        &yes,
+					BackportChainComment: &yes,
+					AllowPrivateBugs:     &no,
+					PrivateBugLabel:      str("bugzilla/private-bug"),
+					DeckURL:              str("https://prow.k8s.io"),
+					BugLabels:            &map[string]string{"TestBlocker": "kind/release-blocker", "blocker+": "kind/release-blocker"},
 				},
 			},
 			Orgs: map[string]plugins.BugzillaOrgOptions{
@@ -233,7 +283,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 								Status:     "RESET",
 								Resolution: "FIXED",
 							},
-							AllowedGroups: []string{"group1", "groups2"},
+							AllowedGroups:     []string{"group1", "groups2"},
+							ExemptionLabel:    str("bugzilla/no-bug-needed"),
+							AttachDiffOnMerge: &yes,
 						},
 					},
 					Repos: map[string]plugins.BugzillaRepoOptions{
@@ -275,7 +327,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 										Status:     "RESET",
 										Resolution: "FIXED",
 									},
-									AllowedGroups: []string{"group1", "groups2"},
+									AllowedGroups:     []string{"group1", "groups2"},
+									ExemptionLabel:    str("bugzilla/no-bug-needed"),
+									AttachDiffOnMerge: &yes,
 								},
 							},
 						},
@@ -298,6 +352,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		Featured:    false,
 		WhoCanUse:   "Anyone",
 		Examples:    []string{"/bugzilla refresh"},
+		Name:        "bugzilla refresh",
 	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/bugzilla assign-qa",
@@ -305,6 +360,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		Featured:    false,
 		WhoCanUse:   "Anyone",
 		Examples:    []string{"/bugzilla assign-qa"},
+		Name:        "bugzilla assign-qa",
 	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/bugzilla cc-qa",
@@ -312,6 +368,29 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		Featured:    false,
 		WhoCanUse:   "Anyone",
 		Examples:    []string{"/bugzilla cc-qa"},
+		Name:        "bugzilla cc-qa",
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/bugzilla skip <reason>",
+		Description: "Exempt the PR from referencing a valid Bugzilla bug by adding the configured exemption label, recording the reason",
+		Featured:    false,
+		WhoCanUse:   "Collaborators of the repository, if an exemption label is configured for the target branch",
+		Examples:    []string{"/bugzilla skip emergency fix for a broken release build"},
+		Name:        "bugzilla skip",
+		Arguments: []pluginhelp.Argument{
+			{Name: "reason", Type: pluginhelp.ArgumentText, Description: "Why the PR does not need a bug."},
+		},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/bugzilla backport <branch>[,<branch>...]",
+		Description: "Clone the bug of a merged PR for the target release of each branch and request cherry-picks of the PR to the branches, which open referencing the cloned bugs",
+		Featured:    false,
+		WhoCanUse:   "Collaborators of the repository, for branches that have backporting enabled",
+		Examples:    []string{"/bugzilla backport release-4.5,release-4.4"},
+		Name:        "bugzilla backport",
+		Arguments: []pluginhelp.Argument{
+			{Name: "branches", Type: pluginhelp.ArgumentString, Description: "The comma separated branches to backport the PR to."},
+		},
 	})
 	return pluginHelp, nil
 }
@@ -323,7 +402,14 @@ type githubClient interface {
 	AddLabel(owner, repo string, number int, label string) error
 	RemoveLabel(owner, repo string, number int, label string) error
 	WasLabelAddedByHuman(org, repo string, num int, label string) (bool, error)
+	IsCollaborator(org, repo, user string) (bool, error)
+	GetPullRequestDiff(org, repo string, number int) ([]byte, error)
+	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
+	ListPullRequestCommits(org, repo string, number int) ([]github.RepositoryCommit, error)
 	Query(ctx context.Context, q interface{}, vars map[string]interface{}) error
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	EditComment(org, repo string, id int, comment string) error
+	BotUserChecker() (func(candidate string) bool, error)
 }
 
 func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) (err error) {
@@ -337,8 +423,11 @@ func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) (err e
 		return err
 	}
 	if event != nil {
+		if event.backport {
+			return handleBackport(*event, pc.GitHubClient, pc.BugzillaClient, pc.PluginConfig.Bugzilla, pc.Logger)
+		}
 		options := pc.PluginConfig.Bugzilla.OptionsForBranch(event.org, event.repo, event.baseRef)
-		return handle(*event, pc.GitHubClient, pc.BugzillaClient, options, pc.Logger, pc.Config.AllRepos)
+		return handle(*event, pc.GitHubClient, pc.BugzillaClient, options, pc.Logger, pc.Config.AllRepos, pc.Config.GitHubOptions.LinkURL.String())
 	}
 	return nil
 }
@@ -355,7 +444,7 @@ func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) (err error
 		return err
 	}
 	if event != nil {
-		return handle(*event, pc.GitHubClient, pc.BugzillaClient, options, pc.Logger, pc.Config.AllRepos)
+		return handle(*event, pc.GitHubClient, pc.BugzillaClient, options, pc.Logger, pc.Config.AllRepos, pc.Config.GitHubOptions.LinkURL.String())
 	}
 	return nil
 }
@@ -470,7 +559,9 @@ func digestComment(gc githubClient, log *logrus.Entry, gce github.GenericComment
 		return nil, nil
 	}
 	// Make sure they are requesting a valid command
-	var assign, cc bool
+	var assign, cc, skip, backport bool
+	var skipReason string
+	var backportBranches []string
 	switch {
 	case refreshCommandMatch.MatchString(gce.Body):
 		// continue without updating bool values
@@ -478,6 +569,14 @@ func digestComment(gc githubClient, log *logrus.Entry, gce github.GenericComment
 		assign = true
 	case qaReviewCommandMatch.MatchString(gce.Body):
 		cc = true
+	case skipCommandMatch.MatchString(gce.Body):
+		skip = true
+		skipReason = skipCommandMatch.FindStringSubmatch(gce.Body)[1]
+	case backportCommandMatch.MatchString(gce.Body):
+		backport = true
+		backportBranches = strings.FieldsFunc(backportCommandMatch.FindStringSubmatch(gce.Body)[1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
 	default:
 		return nil, nil
 	}
@@ -499,7 +598,7 @@ func digestComment(gc githubClient, log *logrus.Entry, gce github.GenericComment
 		return nil, err
 	}
 
-	e := &event{org: org, repo: repo, baseRef: pr.Base.Ref, number: number, merged: pr.Merged, state: pr.State, body: gce.Body, htmlUrl: gce.HTMLURL, login: gce.User.Login, assign: assign, cc: cc}
+	e := &event{org: org, repo: repo, baseRef: pr.Base.Ref, number: number, merged: pr.Merged, state: pr.State, body: gce.Body, htmlUrl: gce.HTMLURL, login: gce.User.Login, assign: assign, cc: cc, skip: skip, skipReason: skipReason, backport: backport, backportBranches: backportBranches}
 	e.bugId, e.missing, err = bugIDFromTitle(pr.Title)
 	if err != nil {
 		// should be impossible based on the regex
@@ -517,9 +616,13 @@ type event struct {
 	state                           string
 	body, htmlUrl, login            string
 	assign, cc                      bool
+	skip                            bool
+	skipReason                      string
 	cherrypick                      bool
 	cherrypickFromPRNum             int
 	cherrypickTo                    string
+	backport                        bool
+	backportBranches                []string
 }
 
 func (e *event) comment(gc githubClient) func(body string) error {
@@ -579,15 +682,66 @@ func processQuery(query *emailToLoginQuery, email string, log *logrus.Entry) str
 	}
 }
 
-func handle(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions, log *logrus.Entry, allRepos sets.Set[string]) error {
+// queryLoginsForEmail searches GitHub for the users with the public email.
+func queryLoginsForEmail(gc githubClient, email string) (*emailToLoginQuery, error) {
+	query := &emailToLoginQuery{}
+	queryVars := map[string]interface{}{
+		"email": githubql.String(email),
+	}
+	if err := gc.Query(context.Background(), query, queryVars); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// qaContactEmail returns the email of the QA contact of the bug, if known.
+func qaContactEmail(bug bugzilla.Bug) string {
+	if bug.QAContactDetail == nil {
+		return ""
+	}
+	return bug.QAContactDetail.Email
+}
+
+// validateQAContact checks that the QA contact of the bug resolves to exactly
+// one GitHub user. It returns the validation or the reason for the bug being
+// invalid and the result of the query, if one was run.
+func validateQAContact(gc githubClient, bug bugzilla.Bug) (bool, string, string, *emailToLoginQuery, error) {
+	if bug.QAContact == "" && bug.QAContactDetail == nil {
+		return false, "", "expected the bug to have a QA contact, but none is set", nil, nil
+	}
+	email := qaContactEmail(bug)
+	if email == "" {
+		return false, "", "expected the QA contact of the bug to have a listed email, but none is listed", nil, nil
+	}
+	query, err := queryLoginsForEmail(gc, email)
+	if err != nil {
+		return false, "", "", nil, err
+	}
+	switch len(query.Search.Edges) {
+	case 0:
+		return false, "", fmt.Sprintf("expected the QA contact of the bug (%s) to be a GitHub user, but no GitHub user has that public email", email), query, nil
+	case 1:
+		return true, fmt.Sprintf("bug has the QA contact %s, who is the GitHub user @%s", email, query.Search.Edges[0].Node.User.Login), "", query, nil
+	default:
+		var logins []string
+		for _, edge := range query.Search.Edges {
+			logins = append(logins, string(edge.Node.User.Login))
+		}
+		return false, "", fmt.Sprintf("expected the QA contact of the bug (%s) to be a single GitHub user, but multiple GitHub users have that public email: %s", email, strings.Join(logins, ", ")), query, nil
+	}
+}
+
+func handle(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions, log *logrus.Entry, allRepos sets.Set[string], githubURL string) error {
 	comment := e.comment(gc)
-	// check if bug is part of a restricted group
+	// check if bug is private or part of a restricted group
+	var private bool
 	if !e.missing {
-		bug, err := getBug(bc, e.bugId, log, comment)
-		if err != nil || bug == nil {
+		bug, err := bc.GetBug(e.bugId)
+		if private = bugzilla.IsAccessDenied(err); private {
+			log.WithError(err).Debug("Bug is private.")
+		} else if bug, err = checkBug(bc, e.bugId, bug, err, log, comment); err != nil || bug == nil {
 			return err
-		}
-		if !isBugAllowed(bug, options.AllowedGroups) {
+		} else if !isBugAllowed(bug, options.AllowedGroups) {
 			// ignore bugs that are in non-allowed groups for this repo
 			if e.opened || refreshCommandMatch.MatchString(e.body) {
 				response := fmt.Sprintf(bugLink+" is in a bug group that is not in the allowed groups for this repo.", e.bugId, bc.Endpoint(), e.bugId)
@@ -604,9 +758,29 @@ func handle(e event, gc githubClient, bc bugzilla.Client, options plugins.Bugzil
 			return nil
 		}
 	}
+	// private bugs cannot be updated, so only their validity is reported
+	if private && (e.merged || e.closed || e.cherrypick) {
+		log.Debug("Not updating the private bug.")
+		return nil
+	}
 	// merges follow a different pattern from the normal validation
 	if e.merged {
-		return handleMerge(e, gc, bc, options, log, allRepos)
+		if e.closed && !e.missing && options.AttachDiffOnMerge != nil && *options.AttachDiffOnMerge {
+			if err := attachOnMerge(e, gc, bc); err != nil {
+				log.WithError(err).Warn("Failed to attach the merged pull request to the Bugzilla bug.")
+			}
+		}
+		if e.closed && !e.missing && options.AddExternalLink != nil && *options.AddExternalLink && options.CommentOnLink != nil && *options.CommentOnLink {
+			if err := commentOnMerge(e, gc, bc); err != nil {
+				log.WithError(err).Warn("Failed to comment on the Bugzilla bug about the merged pull request.")
+			}
+		}
+		if !e.missing && options.BackportChainComment != nil && *options.BackportChainComment {
+			if err := updateBackportChain(gc, bc, e.bugId, options, allRepos, githubURL, false, log); err != nil {
+				log.WithError(err).Warn("Failed to update the backport chain.")
+			}
+		}
+		return handleMerge(e, gc, bc, options, log, allRepos, githubURL)
 	}
 	// close events follow a different pattern from the normal validation
 	if e.closed && !e.merged {
@@ -614,21 +788,74 @@ func handle(e event, gc githubClient, bc bugzilla.Client, options plugins.Bugzil
 	}
 	// cherrypicks follow a different pattern than normal validation
 	if e.cherrypick {
-		if *options.EnableBackporting {
-			return handleCherrypick(e, gc, bc, options, log)
+		if options.EnableBackporting != nil && *options.EnableBackporting {
+			return handleCherrypick(e, gc, bc, options, log, allRepos, githubURL)
 		} else {
 			return nil
 		}
 	}
 
+	if e.skip {
+		if options.ExemptionLabel == nil {
+			return comment("Skipping Bugzilla bug validation is not enabled for this branch.")
+		}
+		if e.skipReason == "" {
+			return comment("A reason is required to skip Bugzilla bug validation, comment <code>/bugzilla skip &lt;reason&gt;</code> to record it.")
+		}
+		isCollaborator, err := gc.IsCollaborator(e.org, e.repo, e.login)
+		if err != nil {
+			return fmt.Errorf("failed to check if %s is a collaborator of %s/%s: %w", e.login, e.org, e.repo, err)
+		}
+		if !isCollaborator {
+			return comment("Only collaborators of this repository may skip Bugzilla bug validation.")
+		}
+	}
+
+	currentLabels, err := gc.GetIssueLabels(e.org, e.repo, e.number)
+	if err != nil {
+		log.WithError(err).Warn("Could not list labels on PR")
+	}
+	exempt := e.skip
+	if options.ExemptionLabel != nil && !exempt {
+		for _, l := range currentLabels {
+			if l.Name == *options.ExemptionLabel {
+				exempt = true
+				break
+			}
+		}
+	}
+
 	var needsValidLabel, needsInvalidLabel bool
 	var response, severityLabel string
-	if e.missing {
+	bugLabels := sets.New[string]()
+	if exempt {
+		log.Debug("Pull request is exempt from bug validation.")
+		needsValidLabel, needsInvalidLabel = true, false
+		if e.skip {
+			if err := gc.AddLabel(e.org, e.repo, e.number, *options.ExemptionLabel); err != nil {
+				return fmt.Errorf("failed to add the %s label: %w", *options.ExemptionLabel, err)
+			}
+			response = fmt.Sprintf("Bugzilla bug validation has been skipped for this pull request for the following reason:\n\n> %s\n\nRemove the %s label to require a valid bug again.", e.skipReason, *options.ExemptionLabel)
+		} else {
+			response = fmt.Sprintf("This pull request has the %s label and is exempt from referencing a valid Bugzilla bug.", *options.ExemptionLabel)
+		}
+	} else if e.missing {
 		log.WithField("bugMissing", true)
 		log.Debug("No bug referenced.")
 		needsValidLabel, needsInvalidLabel = false, false
 		response = `No Bugzilla bug is referenced in the title of this pull request.
 To reference a bug, add 'Bug XXX:' to the title of this pull request and request another bug refresh with <code>/bugzilla refresh</code>.`
+	} else if private {
+		log.Debug("Private bug referenced.")
+		valid := options.AllowPrivateBugs != nil && *options.AllowPrivateBugs
+		needsValidLabel, needsInvalidLabel = valid, !valid
+		response = fmt.Sprintf(`This pull request references `+bugLink+`, which is private. The bot is not permitted to access the bug, so it cannot be validated and its details are not shown.`, e.bugId, bc.Endpoint(), e.bugId)
+		if valid {
+			response += " Private bugs are considered valid for this branch."
+		} else {
+			response += `
+Private bugs are not considered valid for this branch. Edit the title of this pull request to link to a bug the bot may access, or request a bug refresh with <code>/bugzilla refresh</code> once the bot was given access to the bug.`
+		}
 	} else {
 		log = log.WithField("bugId", e.bugId)
 
@@ -637,6 +864,9 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 			return err
 		}
 		severityLabel = getSeverityLabel(bug.Severity)
+		if options.BugLabels != nil {
+			bugLabels = getBugLabels(bug, *options.BugLabels)
+		}
 
 		var dependents []bugzilla.Bug
 		if options.DependentBugStates != nil || options.DependentBugTargetReleases != nil {
@@ -650,6 +880,22 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 		}
 
 		valid, validationsRun, why := validateBug(*bug, dependents, options, bc.Endpoint())
+		var qaQuery *emailToLoginQuery
+		if options.RequireQAContact != nil && *options.RequireQAContact {
+			var qaValid bool
+			var qaValidation, qaWhy string
+			qaValid, qaValidation, qaWhy, qaQuery, err = validateQAContact(gc, *bug)
+			if err != nil {
+				log.WithError(err).Error("Failed to run graphql github query")
+				return comment(formatError(fmt.Sprintf("querying GitHub for users with public email (%s)", qaContactEmail(*bug)), bc.Endpoint(), e.bugId, err))
+			}
+			valid = valid && qaValid
+			if qaValid {
+				validationsRun = append(validationsRun, qaValidation)
+			} else {
+				why = append(why, qaWhy)
+			}
+		}
 		needsValidLabel, needsInvalidLabel = valid, !valid
 		if valid {
 			log.Debug("Valid bug found.")
@@ -662,6 +908,13 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 				}
 				response += fmt.Sprintf(" The bug has been moved to the %s state.", options.StateAfterValidation)
 			}
+			if flags := flagUpdates(options.FlagsAfterValidation, bug); len(flags) > 0 {
+				if err := bc.UpdateFlags(e.bugId, flags); err != nil {
+					log.WithError(err).Warn("Unexpected error updating Bugzilla bug flags.")
+					return comment(formatError(fmt.Sprintf("setting the %s flags", prettyFlags(flags)), bc.Endpoint(), e.bugId, err))
+				}
+				response += fmt.Sprintf(" The %s flags have been set on the bug.", prettyFlags(flags))
+			}
 			if options.AddExternalLink != nil && *options.AddExternalLink {
 				changed, err := bc.AddPullRequestAsExternalBug(e.bugId, e.org, e.repo, e.number)
 				if err != nil {
@@ -670,6 +923,16 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 				}
 				if changed {
 					response += " The bug has been updated to refer to the pull request using the external bug tracker."
+					if options.CommentOnLink != nil && *options.CommentOnLink {
+						if err := commentOnLink(e, gc, bc, options); err != nil {
+							log.WithError(err).Warn("Failed to comment on the Bugzilla bug about the linked pull request.")
+						}
+					}
+					if options.BackportChainComment != nil && *options.BackportChainComment {
+						if err := updateBackportChain(gc, bc, e.bugId, options, allRepos, githubURL, false, log); err != nil {
+							log.WithError(err).Warn("Failed to update the backport chain.")
+						}
+					}
 				}
 			}
 
@@ -679,8 +942,13 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 			} else {
 				response += fmt.Sprintf("<summary>%d validation(s) were run on this bug</summary>\n", len(validationsRun))
 			}
-			for _, validation := range validationsRun {
-				response += fmt.Sprint("\n* ", validation)
+			if len(bug.Groups) > 0 && len(validationsRun) > 0 {
+				// the validations reveal details of the bug
+				response += "\nThe validations are not shown as the bug is restricted to bug groups."
+			} else {
+				for _, validation := range validationsRun {
+					response += fmt.Sprint("\n* ", validation)
+				}
 			}
 			response += "</details>"
 
@@ -695,15 +963,14 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 					response += fmt.Sprintf("QA contact for "+bugLink+" does not have a listed email, skipping assignment", e.bugId, bc.Endpoint(), e.bugId)
 				}
 			} else {
-				query := &emailToLoginQuery{}
+				query := qaQuery
 				email := bug.QAContactDetail.Email
-				queryVars := map[string]interface{}{
-					"email": githubql.String(email),
-				}
-				err := gc.Query(context.Background(), query, queryVars)
-				if err != nil {
-					log.WithError(err).Error("Failed to run graphql github query")
-					return comment(formatError(fmt.Sprintf("querying GitHub for users with public email (%s)", email), bc.Endpoint(), e.bugId, err))
+				if query == nil {
+					query, err = queryLoginsForEmail(gc, email)
+					if err != nil {
+						log.WithError(err).Error("Failed to run graphql github query")
+						return comment(formatError(fmt.Sprintf("querying GitHub for users with public email (%s)", email), bc.Endpoint(), e.bugId, err))
+					}
 				}
 				response += fmt.Sprint("\n\n", processQuery(query, email, log))
 				if e.assign {
@@ -713,8 +980,13 @@ To reference a bug, add 'Bug XXX:' to the title of this pull request and request
 		} else {
 			log.Debug("Invalid bug found.")
 			var formattedReasons string
-			for _, reason := range why {
-				formattedReasons += fmt.Sprintf(" - %s\n", reason)
+			if len(bug.Groups) > 0 {
+				// the reasons reveal details of the bug
+				formattedReasons = fmt.Sprintf(" - %d validation(s) failed, which are not shown as the bug is restricted to bug groups\n", len(why))
+			} else {
+				for _, reason := range why {
+					formattedReasons += fmt.Sprintf(" - %s\n", reason)
+				}
 			}
 			response = fmt.Sprintf(`This pull request references `+bugLink+`, which is invalid:
 %s
@@ -725,10 +997,6 @@ Comment <code>/bugzilla refresh</code> to re-evaluate validity if changes to the
 	// ensure label state is correct. Do not propagate errors
 	// as it is more important to report to the user than to
 	// fail early on a label check.
-	currentLabels, err := gc.GetIssueLabels(e.org, e.repo, e.number)
-	if err != nil {
-		log.WithError(err).Warn("Could not list labels on PR")
-	}
 	var hasValidLabel, hasInvalidLabel bool
 	var severityLabelToRemove string
 	for _, l := range currentLabels {
@@ -758,6 +1026,46 @@ Comment <code>/bugzilla refresh</code> to re-evaluate validity if changes to the
 		}
 	}
 
+	if options.BugLabels != nil {
+		currentLabelSet, mappedLabels := sets.New[string](), sets.New[string]()
+		for _, l := range currentLabels {
+			currentLabelSet.Insert(l.Name)
+		}
+		for _, label := range *options.BugLabels {
+			mappedLabels.Insert(label)
+		}
+		for _, label := range sets.List(mappedLabels) {
+			if bugLabels.Has(label) && !currentLabelSet.Has(label) {
+				if err := gc.AddLabel(e.org, e.repo, e.number, label); err != nil {
+					log.WithError(err).Errorf("Failed to add the %s label.", label)
+				}
+			} else if !bugLabels.Has(label) && currentLabelSet.Has(label) {
+				if err := gc.RemoveLabel(e.org, e.repo, e.number, label); err != nil {
+					log.WithError(err).Errorf("Failed to remove the %s label.", label)
+				}
+			}
+		}
+	}
+
+	if options.PrivateBugLabel != nil {
+		var hasPrivateLabel bool
+		for _, l := range currentLabels {
+			if l.Name == *options.PrivateBugLabel {
+				hasPrivateLabel = true
+				break
+			}
+		}
+		if private && !hasPrivateLabel {
+			if err := gc.AddLabel(e.org, e.repo, e.number, *options.PrivateBugLabel); err != nil {
+				log.WithError(err).Errorf("Failed to add the %s label.", *options.PrivateBugLabel)
+			}
+		} else if !private && hasPrivateLabel {
+			if err := gc.RemoveLabel(e.org, e.repo, e.number, *options.PrivateBugLabel); err != nil {
+				log.WithError(err).Errorf("Failed to remove the %s label.", *options.PrivateBugLabel)
+			}
+		}
+	}
+
 	if hasValidLabel && !needsValidLabel {
 		humanLabelled, err := gc.WasLabelAddedByHuman(e.org, e.repo, e.number, labels.ValidBug)
 		if err != nil {
@@ -814,6 +1122,21 @@ func getSeverityLabel(severity string) string {
 	return ""
 }
 
+// getBugLabels returns the labels mapped to the keywords and flags of the bug.
+func getBugLabels(bug *bugzilla.Bug, mapping map[string]string) sets.Set[string] {
+	matches := sets.New[string](bug.Keywords...)
+	for _, flag := range bug.Flags {
+		matches.Insert(flag.Name + flag.Status)
+	}
+	bugLabels := sets.New[string]()
+	for match, label := range mapping {
+		if matches.Has(match) {
+			bugLabels.Insert(label)
+		}
+	}
+	return bugLabels
+}
+
 func bugMatchesStates(bug *bugzilla.Bug, states []plugins.BugzillaBugState) bool {
 	for _, state := range states {
 		if (&state).Matches(bug) {
@@ -940,22 +1263,169 @@ func validateBug(bug bugzilla.Bug, dependents []bugzilla.Bug, options plugins.Bu
 	return valid, validations, errors
 }
 
-func handleMerge(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions, log *logrus.Entry, allRepos sets.Set[string]) error {
+// attachOnMerge attaches the diff of the merged pull request to the bug or,
+// if the diff is too large for Bugzilla, a summary of the test results of the
+// merged commit.
+func attachOnMerge(e event, gc githubClient, bc bugzilla.Client) error {
+	prLink := fmt.Sprintf("https://github.com/%s/%s/pull/%d", e.org, e.repo, e.number)
+	fileName := fmt.Sprintf("%s-%s-pr-%d", e.org, e.repo, e.number)
+	diff, err := gc.GetPullRequestDiff(e.org, e.repo, e.number)
+	if err != nil {
+		return fmt.Errorf("failed to get the diff: %w", err)
+	}
+	attachment := &bugzilla.AttachmentCreate{
+		IDs:      []int{e.bugId},
+		Data:     diff,
+		FileName: fileName + ".diff",
+		Summary:  fmt.Sprintf("Diff of %s", prLink),
+		IsPatch:  true,
+	}
+	if len(diff) > maxAttachmentSize {
+		pr, err := gc.GetPullRequest(e.org, e.repo, e.number)
+		if err != nil {
+			return fmt.Errorf("failed to get the pull request: %w", err)
+		}
+		status, err := gc.GetCombinedStatus(e.org, e.repo, pr.Head.SHA)
+		if err != nil {
+			return fmt.Errorf("failed to get the test results: %w", err)
+		}
+		attachment = &bugzilla.AttachmentCreate{
+			IDs:         []int{e.bugId},
+			Data:        []byte(testResultSummary(prLink, pr.Head.SHA, len(diff), status)),
+			FileName:    fileName + "-test-results.txt",
+			Summary:     fmt.Sprintf("Test results of %s", prLink),
+			ContentType: "text/plain",
+		}
+	}
+	if _, err := bc.CreateAttachment(attachment); err != nil {
+		return fmt.Errorf("failed to create the attachment: %w", err)
+	}
+	return nil
+}
+
+// commentOnLink summarizes the pull request in a comment on the bug, so that
+// readers of the bug do not need to follow the external bug to get context.
+func commentOnLink(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions) error {
+	pr, err := gc.GetPullRequest(e.org, e.repo, e.number)
+	if err != nil {
+		return fmt.Errorf("failed to get the pull request: %w", err)
+	}
+	lines := []string{
+		fmt.Sprintf("Pull request https://github.com/%s/%s/pull/%d was linked to this bug.", e.org, e.repo, e.number),
+		fmt.Sprintf("Title: %s", pr.Title),
+		fmt.Sprintf("Author: %s", pr.User.Login),
+		fmt.Sprintf("Target branch: %s", pr.Base.Ref),
+	}
+	if options.DeckURL != nil && *options.DeckURL != "" {
+		lines = append(lines, fmt.Sprintf("History: %s/pr-history?org=%s&repo=%s&pr=%d", strings.TrimSuffix(*options.DeckURL, "/"), e.org, e.repo, e.number))
+	}
+	if _, err := bc.CreateComment(&bugzilla.CommentCreate{ID: e.bugId, Comment: strings.Join(lines, "\n")}); err != nil {
+		return fmt.Errorf("failed to create the comment: %w", err)
+	}
+	return nil
+}
+
+// commentOnMerge lists the commits and the CI status of the merged pull request
+// in a comment on the bug.
+func commentOnMerge(e event, gc githubClient, bc bugzilla.Client) error {
+	pr, err := gc.GetPullRequest(e.org, e.repo, e.number)
+	if err != nil {
+		return fmt.Errorf("failed to get the pull request: %w", err)
+	}
+	commits, err := gc.ListPullRequestCommits(e.org, e.repo, e.number)
+	if err != nil {
+		return fmt.Errorf("failed to list the commits of the pull request: %w", err)
+	}
+	lines := []string{fmt.Sprintf("Pull request https://github.com/%s/%s/pull/%d was merged into %s.", e.org, e.repo, e.number, pr.Base.Ref)}
+	if pr.MergeSHA != nil && *pr.MergeSHA != "" {
+		lines = append(lines, fmt.Sprintf("Merge commit: %s", *pr.MergeSHA))
+	}
+	status, err := gc.GetCombinedStatus(e.org, e.repo, pr.Head.SHA)
+	if err != nil {
+		return fmt.Errorf("failed to get the test results: %w", err)
+	}
+	lines = append(lines, fmt.Sprintf("CI status of %s: %s", pr.Head.SHA, status.State))
+	if len(commits) > 0 {
+		lines = append(lines, "Commits:")
+		for _, commit := range commits {
+			lines = append(lines, fmt.Sprintf("* %s %s", commit.SHA, strings.SplitN(commit.Commit.Message, "\n", 2)[0]))
+		}
+	}
+	if _, err := bc.CreateComment(&bugzilla.CommentCreate{ID: e.bugId, Comment: strings.Join(lines, "\n")}); err != nil {
+		return fmt.Errorf("failed to create the comment: %w", err)
+	}
+	return nil
+}
+
+// flagUpdates returns the flags that are not yet set on the bug.
+func flagUpdates(flags *[]plugins.BugzillaFlag, bug *bugzilla.Bug) []bugzilla.Flag {
+	if flags == nil {
+		return nil
+	}
+	current := map[string]string{}
+	for _, flag := range bug.Flags {
+		current[flag.Name] = flag.Status
+	}
+	var updates []bugzilla.Flag
+	for _, flag := range *flags {
+		if status, ok := current[flag.Name]; ok && status == flag.Status {
+			continue
+		}
+		updates = append(updates, bugzilla.Flag{Name: flag.Name, Status: flag.Status})
+	}
+	return updates
+}
+
+func prettyBugzillaFlags(flags []plugins.BugzillaFlag) string {
+	var pretty []string
+	for _, flag := range flags {
+		pretty = append(pretty, flag.String())
+	}
+	return strings.Join(pretty, ", ")
+}
+
+func prettyFlags(flags []bugzilla.Flag) string {
+	var pretty []string
+	for _, flag := range flags {
+		pretty = append(pretty, flag.Name+flag.Status)
+	}
+	return strings.Join(pretty, ", ")
+}
+
+func testResultSummary(prLink, sha string, diffSize int, status *github.CombinedStatus) string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "The diff of %s is too large to attach (%d bytes).\n", prLink, diffSize)
+	fmt.Fprintf(&summary, "Test results of the merged commit %s: %s\n\n", sha, status.State)
+	statuses := append([]github.Status{}, status.Statuses...)
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Context < statuses[j].Context })
+	for _, s := range statuses {
+		fmt.Fprintf(&summary, "%s: %s", s.Context, s.State)
+		if s.TargetURL != "" {
+			fmt.Fprintf(&summary, " %s", s.TargetURL)
+		}
+		summary.WriteString("\n")
+	}
+	return summary.String()
+}
+
+func handleMerge(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions, log *logrus.Entry, allRepos sets.Set[string], githubURL string) error {
 	comment := e.comment(gc)
 
-	if options.StateAfterMerge == nil {
+	if options.StateAfterMerge == nil && options.FlagsAfterMerge == nil {
 		return nil
 	}
 	if e.missing {
 		return nil
 	}
-	if options.ValidStates != nil || options.StateAfterValidation != nil {
+	var bug *bugzilla.Bug
+	if options.StateAfterMerge != nil && (options.ValidStates != nil || options.StateAfterValidation != nil) {
 		// we should only migrate if we can be fairly certain that the bug
 		// is not in a state that required human intervention to get to.
 		// For instance, if a bug is closed after a PR merges it should not
 		// be possible for /bugzilla refresh to move it back to the post-merge
 		// state.
-		bug, err := getBug(bc, e.bugId, log, comment)
+		var err error
+		bug, err = getBug(bc, e.bugId, log, comment)
 		if err != nil || bug == nil {
 			return err
 		}
@@ -1042,27 +1512,55 @@ These pull request must merge or be unlinked from the Bugzilla bug in order for
 
 `, strings.Join(statements, "\n"))
 
-	outcomeMessage := func(action string) string {
-		return fmt.Sprintf(bugLink+" has %sbeen moved to the %s state.", e.bugId, bc.Endpoint(), e.bugId, action, options.StateAfterMerge)
+	var flags []bugzilla.Flag
+	if options.FlagsAfterMerge != nil {
+		if bug == nil {
+			var err error
+			bug, err = getBug(bc, e.bugId, log, comment)
+			if err != nil || bug == nil {
+				return err
+			}
+		}
+		flags = flagUpdates(options.FlagsAfterMerge, bug)
 	}
 
-	update := options.StateAfterMerge.AsBugUpdate(nil)
-	if update == nil {
-		// should never happen
-		return nil
+	outcomeMessage := func(action string) string {
+		var outcomes []string
+		if options.StateAfterMerge != nil {
+			outcomes = append(outcomes, fmt.Sprintf(bugLink+" has %sbeen moved to the %s state.", e.bugId, bc.Endpoint(), e.bugId, action, options.StateAfterMerge))
+		}
+		if len(flags) > 0 {
+			outcomes = append(outcomes, fmt.Sprintf("The %s flags have %sbeen set on "+bugLink+".", prettyFlags(flags), action, e.bugId, bc.Endpoint(), e.bugId))
+		}
+		return strings.Join(outcomes, " ")
 	}
 
 	if shouldMigrate {
-		if err := bc.UpdateBug(e.bugId, *update); err != nil {
-			log.WithError(err).Warn("Unexpected error updating Bugzilla bug.")
-			return comment(formatError(fmt.Sprintf("updating to the %s state", options.StateAfterMerge), bc.Endpoint(), e.bugId, err))
+		if update := options.StateAfterMerge.AsBugUpdate(nil); update != nil {
+			if err := bc.UpdateBug(e.bugId, *update); err != nil {
+				log.WithError(err).Warn("Unexpected error updating Bugzilla bug.")
+				return comment(formatError(fmt.Sprintf("updating to the %s state", options.StateAfterMerge), bc.Endpoint(), e.bugId, err))
+			}
+		}
+		if len(flags) > 0 {
+			if err := bc.UpdateFlags(e.bugId, flags); err != nil {
+				log.WithError(err).Warn("Unexpected error updating Bugzilla bug flags.")
+				return comment(formatError(fmt.Sprintf("setting the %s flags", prettyFlags(flags)), bc.Endpoint(), e.bugId, err))
+			}
+		}
+		if options.StateAfterMerge == nil && len(flags) == 0 {
+			// the flags are already set
+			return nil
 		}
 		return comment(fmt.Sprintf("%s%s", mergedMessage("All"), outcomeMessage("")))
 	}
+	if options.StateAfterMerge == nil && len(flags) == 0 {
+		return nil
+	}
 	return comment(fmt.Sprintf("%s%s%s", mergedMessage("Some"), unmergedMessage, outcomeMessage("not ")))
 }
 
-func handleCherrypick(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions, log *logrus.Entry) error {
+func handleCherrypick(e event, gc githubClient, bc bugzilla.Client, options plugins.BugzillaBranchOptions, log *logrus.Entry, allRepos sets.Set[string], githubURL string) error {
 	comment := e.comment(gc)
 	// get the info for the PR being cherrypicked from
 	pr, err := gc.GetPullRequest(e.org, e.repo, e.cherrypickFromPRNum)
@@ -1104,6 +1602,12 @@ func handleCherrypick(e event, gc githubClient, bc bugzilla.Client, options plug
 	targetRelease := *options.TargetRelease
 	for _, clone := range clones {
 		if len(clone.TargetRelease) == 1 && clone.TargetRelease[0] == targetRelease {
+			if e.bugId == clone.ID {
+				// cherry-picks requested by /bugzilla backport already refer to
+				// the clone, so they are validated like any other pull request
+				e.cherrypick = false
+				return handle(e, gc, bc, options, log, allRepos, githubURL)
+			}
 			newTitle := strings.Replace(e.body, fmt.Sprintf("Bug %d", bugID), fmt.Sprintf("Bug %d", clone.ID), 1)
 			return comment(fmt.Sprintf("Detected clone of %s with correct target release. Retitling PR to link to clone:\n/retitle %s", oldLink, newTitle))
 		}
@@ -1130,7 +1634,122 @@ func handleCherrypick(e event, gc githubClient, bc bugzilla.Client, options plug
 		return comment(formatError(fmt.Sprintf("updating GitHub PR title: Created cherrypick %s, but failed to update GitHub PR title name to match", cloneLink), bc.Endpoint(), cloneID, err))
 	}
 	response := fmt.Sprintf("%s has been cloned as %s. Retitling PR to link against new bug.\n/retitle %s", oldLink, cloneLink, newTitle)
-	return comment(response)
+	if err := comment(response); err != nil {
+		return err
+	}
+	if options.BackportChainComment != nil && *options.BackportChainComment {
+		if err := updateBackportChain(gc, bc, cloneID, options, allRepos, githubURL, true, log); err != nil {
+			log.WithError(err).Warn("Failed to update the backport chain.")
+		}
+	}
+	return nil
+}
+
+// handleBackport clones the bug of a merged pull request for the target release
+// of each requested branch and requests cherry-picks of the pull request to the
+// branches from the cherrypicker plugin, on behalf of the user who asked for
+// the backports. The cherry-picks are titled to refer to the clones right away.
+func handleBackport(e event, gc githubClient, bc bugzilla.Client, config plugins.Bugzilla, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	if !e.merged {
+		return comment("Only merged pull requests can be backported.")
+	}
+	if e.missing {
+		return comment("No Bugzilla bug is referenced in the title of this pull request, so there is no bug to backport.")
+	}
+	if len(e.backportBranches) == 0 {
+		return comment("The branches to backport to are required, comment <code>/bugzilla backport &lt;branch&gt;[,&lt;branch&gt;...]</code> to request backports.")
+	}
+	isCollaborator, err := gc.IsCollaborator(e.org, e.repo, e.login)
+	if err != nil {
+		return fmt.Errorf("failed to check if %s is a collaborator of %s/%s: %w", e.login, e.org, e.repo, err)
+	}
+	if !isCollaborator {
+		return comment("Only collaborators of this repository may request backports.")
+	}
+	bug, err := getBug(bc, e.bugId, log, comment)
+	if err != nil || bug == nil {
+		return err
+	}
+	if !isBugAllowed(bug, config.OptionsForBranch(e.org, e.repo, e.baseRef).AllowedGroups) {
+		// ignore bugs that are in non-allowed groups for this repo
+		return nil
+	}
+	clones, err := bc.GetClones(bug)
+	if err != nil {
+		return comment(formatError("backporting: could not get list of clones", bc.Endpoint(), bug.ID, err))
+	}
+
+	var results []string
+	var requests []string
+	seen := sets.New[string]()
+	for _, branch := range e.backportBranches {
+		if seen.Has(branch) {
+			continue
+		}
+		seen.Insert(branch)
+		if branch == e.baseRef {
+			results = append(results, fmt.Sprintf("* %s: this pull request already targets the branch.", branch))
+			continue
+		}
+		options := config.OptionsForBranch(e.org, e.repo, branch)
+		if options.EnableBackporting == nil || !*options.EnableBackporting {
+			results = append(results, fmt.Sprintf("* %s: backporting is not enabled for the branch.", branch))
+			continue
+		}
+		if options.TargetRelease == nil {
+			results = append(results, fmt.Sprintf("* %s: the target_release is not set for the branch in the bugzilla plugin config.", branch))
+			continue
+		}
+		targetRelease := *options.TargetRelease
+		var cloneID int
+		for _, clone := range clones {
+			if len(clone.TargetRelease) == 1 && clone.TargetRelease[0] == targetRelease {
+				cloneID = clone.ID
+				break
+			}
+		}
+		if cloneID != 0 {
+			results = append(results, fmt.Sprintf("* %s: "+bugLink+" already is the clone for the %s target release.", branch, cloneID, bc.Endpoint(), cloneID, targetRelease))
+		} else {
+			if cloneID, err = bc.CloneBug(bug); err != nil {
+				log.WithError(err).Debugf("Failed to clone bug %d", bug.ID)
+				results = append(results, fmt.Sprintf("* %s: failed to clone the bug: %v", branch, err))
+				continue
+			}
+			if err := bc.UpdateBug(cloneID, bugzilla.BugUpdate{TargetRelease: []string{targetRelease}}); err != nil {
+				log.WithError(err).Debugf("Unable to update target release of bug %d", cloneID)
+				results = append(results, fmt.Sprintf("* %s: cloned the bug as "+bugLink+", but failed to set its target release: %v", branch, cloneID, bc.Endpoint(), cloneID, err))
+				continue
+			}
+			clones = append(clones, &bugzilla.Bug{ID: cloneID, TargetRelease: []string{targetRelease}})
+			results = append(results, fmt.Sprintf("* %s: cloned the bug as "+bugLink+" for the %s target release.", branch, cloneID, bc.Endpoint(), cloneID, targetRelease))
+		}
+		title, err := updateTitleBugID(e.body, e.bugId, cloneID)
+		if err != nil {
+			return fmt.Errorf("failed to update the bug in the title %q: %w", e.body, err)
+		}
+		request, err := cherrypicker.CreateBackportRequest(branch, cherrypicker.BackportRequest{Title: title, Requestor: e.login})
+		if err != nil {
+			return fmt.Errorf("failed to create the cherry-pick request for %s: %w", branch, err)
+		}
+		requests = append(requests, request)
+	}
+
+	response := fmt.Sprintf("Backporting "+bugLink+":\n%s", e.bugId, bc.Endpoint(), e.bugId, strings.Join(results, "\n"))
+	if len(requests) > 0 {
+		response += "\n\nRequesting cherry-picks of this pull request that refer to the cloned bugs."
+	}
+	if err := comment(response); err != nil {
+		return err
+	}
+	for _, request := range requests {
+		// the cherrypicker only handles one branch per comment
+		if err := gc.CreateComment(e.org, e.repo, e.number, request); err != nil {
+			return fmt.Errorf("failed to request a cherry-pick: %w", err)
+		}
+	}
+	return nil
 }
 
 func updateTitleBugID(title string, oldID, newID int) (string, error) {
@@ -1158,6 +1777,16 @@ func bugIDFromTitle(title string) (int, bool, error) {
 
 func getBug(bc bugzilla.Client, bugId int, log *logrus.Entry, comment func(string) error) (*bugzilla.Bug, error) {
 	bug, err := bc.GetBug(bugId)
+	return checkBug(bc, bugId, bug, err, log, comment)
+}
+
+// checkBug comments on the pull request if the bug could not be retrieved.
+// The error of private bugs is not shown, as it may reveal their details.
+func checkBug(bc bugzilla.Client, bugId int, bug *bugzilla.Bug, err error, log *logrus.Entry, comment func(string) error) (*bugzilla.Bug, error) {
+	if bugzilla.IsAccessDenied(err) {
+		log.WithError(err).Debug("Bug is private.")
+		return nil, comment(fmt.Sprintf(bugLink+" is private and the bot is not permitted to access it.", bugId, bc.Endpoint(), bugId))
+	}
 	if err != nil && !bugzilla.IsNotFound(err) {
 		log.WithError(err).Warn("Unexpected error searching for Bugzilla bug.")
 		return nil, comment(formatError("searching", bc.Endpoint(), bugId, err))
@@ -1267,3 +1896,152 @@ func isBugAllowed(bug *bugzilla.Bug, allowedGroups []string) bool {
 
 	return true
 }
+
+// backportChainMarker identifies the comment showing the backport chain.
+const backportChainMarker = "<!-- bugzilla backport chain -->"
+
+// updateBackportChain shows the backport chain of the bug in a comment on the
+// pull requests of the original bug, so that the progress of the backports can
+// be seen at a glance. If create is unset, only existing comments are updated.
+// Bugs outside of the allowed groups are left out of the chain.
+func updateBackportChain(gc githubClient, bc bugzilla.Client, bugID int, options plugins.BugzillaBranchOptions, allRepos sets.Set[string], githubURL string, create bool, log *logrus.Entry) error {
+	bug, err := bc.GetBug(bugID)
+	if err != nil {
+		return fmt.Errorf("failed to get bug %d: %w", bugID, err)
+	}
+	root, err := bc.GetRootForClone(bug)
+	if err != nil {
+		return fmt.Errorf("failed to get the original bug of bug %d: %w", bugID, err)
+	}
+	if !isBugAllowed(root, options.AllowedGroups) {
+		return nil
+	}
+	clones, err := bc.GetAllClones(root)
+	if err != nil {
+		return fmt.Errorf("failed to get the clones of bug %d: %w", root.ID, err)
+	}
+	var bugs []*bugzilla.Bug
+	for _, clone := range clones {
+		if isBugAllowed(clone, options.AllowedGroups) {
+			bugs = append(bugs, clone)
+		}
+	}
+	if len(bugs) < 2 {
+		// the bug has not been backported
+		return nil
+	}
+
+	prs := map[int][]bugzilla.ExternalBug{}
+	states := map[bugzilla.ExternalBug]string{}
+	for _, bug := range bugs {
+		if prs[bug.ID], err = bc.GetExternalBugPRsOnBug(bug.ID); err != nil {
+			return fmt.Errorf("failed to get the pull requests of bug %d: %w", bug.ID, err)
+		}
+		for _, item := range prs[bug.ID] {
+			if !allRepos.Has(item.Org + "/" + item.Repo) {
+				continue
+			}
+			pr, err := gc.GetPullRequest(item.Org, item.Repo, item.Num)
+			if err != nil {
+				return fmt.Errorf("failed to get pull request %s/%s#%d: %w", item.Org, item.Repo, item.Num, err)
+			}
+			states[item] = pr.State
+			if pr.Merged {
+				states[item] = "merged"
+			}
+		}
+	}
+	body := backportChain(bc.Endpoint(), githubURL, bugs, prs, states)
+
+	botUserChecker, err := gc.BotUserChecker()
+	if err != nil {
+		return fmt.Errorf("failed to get the bot user: %w", err)
+	}
+	for _, item := range prs[root.ID] {
+		if !allRepos.Has(item.Org + "/" + item.Repo) {
+			continue
+		}
+		comments, err := gc.ListIssueComments(item.Org, item.Repo, item.Num)
+		if err != nil {
+			return fmt.Errorf("failed to list the comments of %s/%s#%d: %w", item.Org, item.Repo, item.Num, err)
+		}
+		var existing *github.IssueComment
+		for i := range comments {
+			if botUserChecker(comments[i].User.Login) && strings.Contains(comments[i].Body, backportChainMarker) {
+				existing = &comments[i]
+				break
+			}
+		}
+		switch {
+		case existing != nil && existing.Body != body:
+			if err := gc.EditComment(item.Org, item.Repo, existing.ID, body); err != nil {
+				return fmt.Errorf("failed to update the backport chain on %s/%s#%d: %w", item.Org, item.Repo, item.Num, err)
+			}
+		case existing == nil && create:
+			if err := gc.CreateComment(item.Org, item.Repo, item.Num, body); err != nil {
+				return fmt.Errorf("failed to comment the backport chain on %s/%s#%d: %w", item.Org, item.Repo, item.Num, err)
+			}
+		default:
+			continue
+		}
+		log.WithField("pr", fmt.Sprintf("%s/%s#%d", item.Org, item.Repo, item.Num)).Debug("Updated the backport chain.")
+	}
+	return nil
+}
+
+// backportChain renders a table of the bugs of a backport chain, each below
+// the bug it was cloned from, with their pull requests and merge states.
+func backportChain(endpoint, githubURL string, bugs []*bugzilla.Bug, prs map[int][]bugzilla.ExternalBug, states map[bugzilla.ExternalBug]string) string {
+	inChain := map[int]bool{}
+	for _, bug := range bugs {
+		inChain[bug.ID] = true
+	}
+	// bugs are sorted by ID, so clones come after the bugs they were cloned from
+	children := map[int][]*bugzilla.Bug{}
+	var roots []*bugzilla.Bug
+	for _, bug := range bugs {
+		parent := 0
+		for _, id := range bug.DependsOn {
+			if inChain[id] {
+				parent = id
+				break
+			}
+		}
+		if parent == 0 {
+			roots = append(roots, bug)
+		} else {
+			children[parent] = append(children[parent], bug)
+		}
+	}
+
+	lines := []string{
+		backportChainMarker,
+		"Backport chain:",
+		"",
+		"| Bug | Target release | Status | Pull requests |",
+		"| --- | --- | --- | --- |",
+	}
+	var add func(bug *bugzilla.Bug, depth int)
+	add = func(bug *bugzilla.Bug, depth int) {
+		var indent string
+		if depth > 0 {
+			indent = strings.Repeat("&nbsp;&nbsp;", depth-1) + "↳ "
+		}
+		var links []string
+		for _, item := range prs[bug.ID] {
+			link := fmt.Sprintf("[%s/%s#%d](%s/%s/%s/pull/%d)", item.Org, item.Repo, item.Num, githubURL, item.Org, item.Repo, item.Num)
+			if state, ok := states[item]; ok {
+				link += " (" + state + ")"
+			}
+			links = append(links, link)
+		}
+		lines = append(lines, fmt.Sprintf("| %s"+bugLink+" | %s | %s | %s |", indent, bug.ID, endpoint, bug.ID, strings.Join(bug.TargetRelease, ", "), bugzilla.PrettyStatus(bug.Status, bug.Resolution), strings.Join(links, "<br>")))
+		for _, child := range children[bug.ID] {
+			add(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		add(root, 0)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package bugzilla
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	githubql "github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
@@ -31,6 +34,7 @@ import (
 	prowconfig "sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
@@ -72,6 +76,7 @@ orgs:
             add_external_link: true
             state_after_merge:
               status: MODIFIED
+            exemption_label: bugzilla/no-bug-needed
           "branch-that-likes-closed-bugs":
             valid_states:
             - status: VERIFIED
@@ -120,7 +125,7 @@ orgs:
 <li>by default, valid bugs must be closed, target the "my-repo-default" release, and be in one of the following states: VALIDATED. After being linked to a pull request, bugs will be moved to the PRE state.</li>
 <li>on the "branch-that-likes-closed-bugs" branch, valid bugs must be closed, target the "my-repo-default" release, be in one of the following states: VERIFIED, CLOSED (ERRATA), depend on at least one other bug, and have all dependent bugs in one of the following states: CLOSED (ERRATA). After being linked to a pull request, bugs will be moved to the CLOSED (VALIDATED) state and moved to the CLOSED (FIXED) state when all linked pull requests are merged.</li>
 <li>on the "my-org-branch" branch, valid bugs must be closed, target the "my-repo-default" release, and be in one of the following states: VALIDATED. After being linked to a pull request, bugs will be moved to the POST state and updated to refer to the pull request using the external bug tracker.</li>
-<li>on the "my-repo-branch" branch, valid bugs must be closed, target the "my-repo-branch" release, and be in one of the following states: MODIFIED. After being linked to a pull request, bugs will be moved to the PRE state, updated to refer to the pull request using the external bug tracker, and moved to the MODIFIED state when all linked pull requests are merged.</li>
+<li>on the "my-repo-branch" branch, valid bugs must be closed, target the "my-repo-branch" release, and be in one of the following states: MODIFIED. After being linked to a pull request, bugs will be moved to the PRE state, updated to refer to the pull request using the external bug tracker, and moved to the MODIFIED state when all linked pull requests are merged. Collaborators may exempt pull requests from this requirement with <code>/bugzilla skip &lt;reason&gt;</code>, which adds the "bugzilla/no-bug-needed" label.</li>
 </ul>`,
 		},
 		Commands: []pluginhelp.Command{
@@ -130,18 +135,41 @@ orgs:
 				Featured:    false,
 				WhoCanUse:   "Anyone",
 				Examples:    []string{"/bugzilla refresh"},
+				Name:        "bugzilla refresh",
 			}, {
 				Usage:       "/bugzilla assign-qa",
 				Description: "(DEPRECATED) Assign PR to QA contact specified in Bugzilla",
 				Featured:    false,
 				WhoCanUse:   "Anyone",
 				Examples:    []string{"/bugzilla assign-qa"},
+				Name:        "bugzilla assign-qa",
 			}, {
 				Usage:       "/bugzilla cc-qa",
 				Description: "Request PR review from QA contact specified in Bugzilla",
 				Featured:    false,
 				WhoCanUse:   "Anyone",
 				Examples:    []string{"/bugzilla cc-qa"},
+				Name:        "bugzilla cc-qa",
+			}, {
+				Usage:       "/bugzilla skip <reason>",
+				Description: "Exempt the PR from referencing a valid Bugzilla bug by adding the configured exemption label, recording the reason",
+				Featured:    false,
+				WhoCanUse:   "Collaborators of the repository, if an exemption label is configured for the target branch",
+				Examples:    []string{"/bugzilla skip emergency fix for a broken release build"},
+				Name:        "bugzilla skip",
+				Arguments: []pluginhelp.Argument{
+					{Name: "reason", Type: pluginhelp.ArgumentText, Description: "Why the PR does not need a bug."},
+				},
+			}, {
+				Usage:       "/bugzilla backport <branch>[,<branch>...]",
+				Description: "Clone the bug of a merged PR for the target release of each branch and request cherry-picks of the PR to the branches, which open referencing the cloned bugs",
+				Featured:    false,
+				WhoCanUse:   "Collaborators of the repository, for branches that have backporting enabled",
+				Examples:    []string{"/bugzilla backport release-4.5,release-4.4"},
+				Name:        "bugzilla backport",
+				Arguments: []pluginhelp.Argument{
+					{Name: "branches", Type: pluginhelp.ArgumentString, Description: "The comma separated branches to backport the PR to."},
+				},
 			},
 		},
 	}
@@ -685,6 +713,76 @@ Instructions for interacting with me using PR comments are available [here](http
 				org: "org", repo: "repo", baseRef: "branch", number: 1, bugId: 123, body: "/bugzilla cc-qa", htmlUrl: "www.com", login: "user", assign: false, cc: true,
 			},
 		},
+		{
+			name: "skip comment event has skip bool and reason set",
+			e: github.GenericCommentEvent{
+				Action: github.GenericCommentActionCreated,
+				IsPR:   true,
+				Body:   "/bugzilla skip fixing a broken release build",
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+				Number: 1,
+				User: github.User{
+					Login: "user",
+				},
+				HTMLURL: "www.com",
+			},
+			title: "fixing a broken release build",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, missing: true, body: "/bugzilla skip fixing a broken release build", htmlUrl: "www.com", login: "user", skip: true, skipReason: "fixing a broken release build",
+			},
+		},
+		{
+			name: "skip comment event without a reason has skip bool set",
+			e: github.GenericCommentEvent{
+				Action: github.GenericCommentActionCreated,
+				IsPR:   true,
+				Body:   "/bugzilla skip",
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+				Number: 1,
+				User: github.User{
+					Login: "user",
+				},
+				HTMLURL: "www.com",
+			},
+			title: "fixing a broken release build",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, missing: true, body: "/bugzilla skip", htmlUrl: "www.com", login: "user", skip: true,
+			},
+		},
+		{
+			name: "backport comment event has backport bool and branches set",
+			e: github.GenericCommentEvent{
+				Action: github.GenericCommentActionCreated,
+				IsPR:   true,
+				Body:   "/bugzilla backport release-4.5, release-4.4",
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+				Number: 1,
+				User: github.User{
+					Login: "user",
+				},
+				HTMLURL: "www.com",
+			},
+			title:  "Bug 123: oopsie doopsie",
+			merged: true,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, bugId: 123, merged: true, body: "/bugzilla backport release-4.5, release-4.4", htmlUrl: "www.com", login: "user", backport: true, backportBranches: []string{"release-4.5", "release-4.4"},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -718,6 +816,8 @@ func TestHandle(t *testing.T) {
 	updated := plugins.BugzillaBugState{Status: "UPDATED"}
 	modified := plugins.BugzillaBugState{Status: "MODIFIED"}
 	verified := []plugins.BugzillaBugState{{Status: "VERIFIED"}}
+	exemptionLabel := "bugzilla/no-bug-needed"
+	privateLabel := "bugzilla/private-bug"
 	base := &event{
 		org: "org", repo: "repo", baseRef: "branch", number: 1, bugId: 123, body: "Bug 123: fixed it!", htmlUrl: "http.com", login: "user",
 	}
@@ -732,6 +832,10 @@ func TestHandle(t *testing.T) {
 		cherryPick          bool
 		cherryPickFromPRNum int
 		cherryPickTo        string
+		bugId               int
+		skip                bool
+		skipReason          string
+		collaborators       []string
 		// the "e.body" for PRs is the PR title; this field can be used to replace the "body" for PR handles for cases where the body != description
 		body                  string
 		externalBugs          []bugzilla.ExternalBug
@@ -739,6 +843,7 @@ func TestHandle(t *testing.T) {
 		bugs                  []bugzilla.Bug
 		bugComments           map[int][]bugzilla.Comment
 		bugErrors             []int
+		privateBugs           []int
 		bugErrorMessages      map[int]string
 		bugCreateErrors       []string
 		subComponents         map[int]map[string][]string
@@ -1592,6 +1697,36 @@ In response to [this](http.com):
 >[v1] Bug 123: fixed it!
 
 
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		}, {
+			name: "Cherry-pick that already refers to the clone is validated",
+			bugs: []bugzilla.Bug{
+				{Summary: "This is a test bug", Product: "Test", Component: []string{"TestComponent"}, TargetRelease: []string{"v2"}, ID: 123, Status: "CLOSED", Severity: "urgent", Blocks: []int{124}},
+				{Summary: "This is a test bug", Product: "Test", Component: []string{"TestComponent"}, TargetRelease: []string{"v1"}, ID: 124, Status: "NEW", Severity: "urgent", DependsOn: []int{123}},
+			},
+			bugComments:         map[int][]bugzilla.Comment{123: {{BugID: 123, Count: 0, Text: "This is a bug"}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.body}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] Bug 124: fixed it!"}},
+			body:                "[v1] Bug 124: fixed it!",
+			bugId:               124,
+			cherryPick:          true,
+			cherryPickFromPRNum: 1,
+			cherryPickTo:        "v1",
+			options:             plugins.BugzillaBranchOptions{TargetRelease: &v1, EnableBackporting: &yes},
+			expectedLabels:      []string{"bugzilla/valid-bug", "bugzilla/severity-urgent"},
+			expectedComment: `org/repo#1:@user: This pull request references [Bugzilla bug 124](www.bugzilla/show_bug.cgi?id=124), which is valid.
+
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug target release (v1) matches configured target release for branch (v1)</details>
+
+<details>
+
+In response to [this](http.com):
+
+>[v1] Bug 124: fixed it!
+
+
 Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
 </details>`,
 		}, {
@@ -1745,6 +1880,210 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 			expectedBug: &bugzilla.Bug{ID: 123, Status: "UPDATED", Severity: "medium", Groups: []string{"security"}},
 		},
+		{
+			name:           "private bug is invalid and its error is not shown",
+			privateBugs:    []int{123},
+			options:        plugins.BugzillaBranchOptions{PrivateBugLabel: &privateLabel},
+			labels:         []string{"bugzilla/valid-bug"},
+			expectedLabels: []string{"bugzilla/invalid-bug", "bugzilla/private-bug"},
+			expectedComment: `org/repo#1:@user: This pull request references [Bugzilla bug 123](www.bugzilla/show_bug.cgi?id=123), which is private. The bot is not permitted to access the bug, so it cannot be validated and its details are not shown.
+Private bugs are not considered valid for this branch. Edit the title of this pull request to link to a bug the bot may access, or request a bug refresh with <code>/bugzilla refresh</code> once the bot was given access to the bug.
+
+<details>
+
+In response to [this](http.com):
+
+>Bug 123: fixed it!
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:           "private bug is valid if private bugs are allowed",
+			privateBugs:    []int{123},
+			options:        plugins.BugzillaBranchOptions{AllowPrivateBugs: &yes},
+			labels:         []string{"bugzilla/invalid-bug"},
+			expectedLabels: []string{"bugzilla/valid-bug"},
+			expectedComment: `org/repo#1:@user: This pull request references [Bugzilla bug 123](www.bugzilla/show_bug.cgi?id=123), which is private. The bot is not permitted to access the bug, so it cannot be validated and its details are not shown. Private bugs are considered valid for this branch.
+
+<details>
+
+In response to [this](http.com):
+
+>Bug 123: fixed it!
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:           "private bug label is removed once the bug is accessible",
+			bugs:           []bugzilla.Bug{{ID: 123}},
+			options:        plugins.BugzillaBranchOptions{PrivateBugLabel: &privateLabel},
+			labels:         []string{"bugzilla/invalid-bug", "bugzilla/private-bug"},
+			expectedLabels: []string{"bugzilla/valid-bug"},
+			expectedComment: `org/repo#1:@user: This pull request references [Bugzilla bug 123](www.bugzilla/show_bug.cgi?id=123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](http.com):
+
+>Bug 123: fixed it!
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:        "private bug is not updated on merge",
+			merged:      true,
+			privateBugs: []int{123},
+			options:     plugins.BugzillaBranchOptions{StateAfterMerge: &modified},
+		},
+		{
+			name:           "validations of a bug restricted to groups are not shown",
+			bugs:           []bugzilla.Bug{{ID: 123, Status: "NEW", Groups: []string{"security"}}},
+			options:        plugins.BugzillaBranchOptions{IsOpen: &open, ValidStates: &verified, AllowedGroups: []string{"security"}},
+			expectedLabels: []string{"bugzilla/invalid-bug"},
+			expectedComment: `org/repo#1:@user: This pull request references [Bugzilla bug 123](www.bugzilla/show_bug.cgi?id=123), which is invalid:
+ - 2 validation(s) failed, which are not shown as the bug is restricted to bug groups
+
+Comment <code>/bugzilla refresh</code> to re-evaluate validity if changes to the Bugzilla bug are made, or edit the title of this pull request to link to a different bug.
+
+<details>
+
+In response to [this](http.com):
+
+>Bug 123: fixed it!
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:           "skip by a collaborator adds the exemption label, valid label and records the reason",
+			missing:        true,
+			skip:           true,
+			skipReason:     "fixing a broken release build",
+			body:           "/bugzilla skip fixing a broken release build",
+			collaborators:  []string{"user"},
+			options:        plugins.BugzillaBranchOptions{ExemptionLabel: &exemptionLabel},
+			labels:         []string{"bugzilla/invalid-bug"},
+			expectedLabels: []string{"bugzilla/no-bug-needed", "bugzilla/valid-bug"},
+			expectedComment: `org/repo#1:@user: Bugzilla bug validation has been skipped for this pull request for the following reason:
+
+> fixing a broken release build
+
+Remove the bugzilla/no-bug-needed label to require a valid bug again.
+
+<details>
+
+In response to [this](http.com):
+
+>/bugzilla skip fixing a broken release build
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:           "skip by a non-collaborator is refused",
+			missing:        true,
+			skip:           true,
+			skipReason:     "fixing a broken release build",
+			body:           "/bugzilla skip fixing a broken release build",
+			options:        plugins.BugzillaBranchOptions{ExemptionLabel: &exemptionLabel},
+			labels:         []string{"bugzilla/invalid-bug"},
+			expectedLabels: []string{"bugzilla/invalid-bug"},
+			expectedComment: `org/repo#1:@user: Only collaborators of this repository may skip Bugzilla bug validation.
+
+<details>
+
+In response to [this](http.com):
+
+>/bugzilla skip fixing a broken release build
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:          "skip without an exemption label configured is refused",
+			missing:       true,
+			skip:          true,
+			skipReason:    "fixing a broken release build",
+			body:          "/bugzilla skip fixing a broken release build",
+			collaborators: []string{"user"},
+			expectedComment: `org/repo#1:@user: Skipping Bugzilla bug validation is not enabled for this branch.
+
+<details>
+
+In response to [this](http.com):
+
+>/bugzilla skip fixing a broken release build
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:          "skip without a reason is refused",
+			missing:       true,
+			skip:          true,
+			body:          "/bugzilla skip",
+			collaborators: []string{"user"},
+			options:       plugins.BugzillaBranchOptions{ExemptionLabel: &exemptionLabel},
+			expectedComment: `org/repo#1:@user: A reason is required to skip Bugzilla bug validation, comment <code>/bugzilla skip &lt;reason&gt;</code> to record it.
+
+<details>
+
+In response to [this](http.com):
+
+>/bugzilla skip
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:           "no bug on an exempt pull request keeps the valid label",
+			missing:        true,
+			options:        plugins.BugzillaBranchOptions{ExemptionLabel: &exemptionLabel},
+			labels:         []string{"bugzilla/no-bug-needed", "bugzilla/valid-bug"},
+			expectedLabels: []string{"bugzilla/no-bug-needed", "bugzilla/valid-bug"},
+			expectedComment: `org/repo#1:@user: This pull request has the bugzilla/no-bug-needed label and is exempt from referencing a valid Bugzilla bug.
+
+<details>
+
+In response to [this](http.com):
+
+>Bug 123: fixed it!
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
+		{
+			name:           "invalid bug on an exempt pull request gets the valid label",
+			bugs:           []bugzilla.Bug{{ID: 123}},
+			options:        plugins.BugzillaBranchOptions{IsOpen: &open, ExemptionLabel: &exemptionLabel},
+			labels:         []string{"bugzilla/no-bug-needed", "bugzilla/invalid-bug"},
+			expectedLabels: []string{"bugzilla/no-bug-needed", "bugzilla/valid-bug"},
+			expectedComment: `org/repo#1:@user: This pull request has the bugzilla/no-bug-needed label and is exempt from referencing a valid Bugzilla bug.
+
+<details>
+
+In response to [this](http.com):
+
+>Bug 123: fixed it!
+
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository.
+</details>`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1768,6 +2107,7 @@ Instructions for interacting with me using PR comments are available [here](http
 				SubComponents:    map[int]map[string][]string{},
 				BugComments:      testCase.bugComments,
 				BugErrors:        sets.New[int](),
+				PrivateBugs:      sets.New[int](testCase.privateBugs...),
 				BugErrorMessages: testCase.bugErrorMessages,
 				BugCreateErrors:  sets.New[string](),
 				ExternalBugs:     map[int][]bugzilla.ExternalBug{},
@@ -1790,10 +2130,16 @@ Instructions for interacting with me using PR comments are available [here](http
 			e.cherrypick = testCase.cherryPick
 			e.cherrypickFromPRNum = testCase.cherryPickFromPRNum
 			e.cherrypickTo = testCase.cherryPickTo
+			e.skip = testCase.skip
+			e.skipReason = testCase.skipReason
+			gc.Collaborators = testCase.collaborators
 			if testCase.body != "" {
 				e.body = testCase.body
 			}
-			err := handle(e, gc, &bc, testCase.options, logrus.WithField("testCase", testCase.name), sets.New[string]("org/repo"))
+			if testCase.bugId != 0 {
+				e.bugId = testCase.bugId
+			}
+			err := handle(e, gc, &bc, testCase.options, logrus.WithField("testCase", testCase.name), sets.New[string]("org/repo"), "https://github.com")
 			if err != nil {
 				t.Errorf("%s: expected no error but got one: %v", testCase.name, err)
 			}
@@ -1858,6 +2204,407 @@ func checkComments(client *fakegithub.FakeClient, name, expectedComment string,
 	}
 }
 
+func TestAttachOnMerge(t *testing.T) {
+	yes, no := true, false
+	largeDiff := strings.Repeat("+", maxAttachmentSize+1)
+	testCases := []struct {
+		name     string
+		diff     string
+		missing  bool
+		closed   bool
+		options  plugins.BugzillaBranchOptions
+		expected []bugzilla.Attachment
+	}{
+		{
+			name:    "diff is attached on merge",
+			diff:    "diff --git a/file b/file",
+			closed:  true,
+			options: plugins.BugzillaBranchOptions{AttachDiffOnMerge: &yes},
+			expected: []bugzilla.Attachment{{
+				ID:          1,
+				BugID:       123,
+				Data:        []byte("diff --git a/file b/file"),
+				Size:        24,
+				FileName:    "org-repo-pr-1.diff",
+				Summary:     "Diff of https://github.com/org/repo/pull/1",
+				ContentType: "text/plain",
+				IsPatch:     true,
+			}},
+		},
+		{
+			name:    "test results are attached if the diff is too large",
+			diff:    largeDiff,
+			closed:  true,
+			options: plugins.BugzillaBranchOptions{AttachDiffOnMerge: &yes},
+			expected: []bugzilla.Attachment{{
+				ID:    1,
+				BugID: 123,
+				Data: []byte(fmt.Sprintf(`The diff of https://github.com/org/repo/pull/1 is too large to attach (%d bytes).
+Test results of the merged commit abcdef: failure
+
+e2e: failure https://prow.example.com/e2e
+unit: success
+`, len(largeDiff))),
+				Size:        194,
+				FileName:    "org-repo-pr-1-test-results.txt",
+				Summary:     "Test results of https://github.com/org/repo/pull/1",
+				ContentType: "text/plain",
+			}},
+		},
+		{
+			name:    "nothing is attached on refresh of a merged pull request",
+			diff:    "diff --git a/file b/file",
+			options: plugins.BugzillaBranchOptions{AttachDiffOnMerge: &yes},
+		},
+		{
+			name:    "nothing is attached if disabled",
+			diff:    "diff --git a/file b/file",
+			closed:  true,
+			options: plugins.BugzillaBranchOptions{AttachDiffOnMerge: &no},
+		},
+		{
+			name:    "nothing is attached without a bug",
+			diff:    "diff --git a/file b/file",
+			missing: true,
+			closed:  true,
+			options: plugins.BugzillaBranchOptions{AttachDiffOnMerge: &yes},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			gc.PullRequests = map[int]*github.PullRequest{1: {Number: 1, Merged: true, Head: github.PullRequestBranch{SHA: "abcdef"}}}
+			gc.PullRequestDiffs = map[int][]byte{1: []byte(tc.diff)}
+			gc.CombinedStatuses = map[string]*github.CombinedStatus{"abcdef": {
+				SHA:   "abcdef",
+				State: "failure",
+				Statuses: []github.Status{
+					{Context: "unit", State: "success"},
+					{Context: "e2e", State: "failure", TargetURL: "https://prow.example.com/e2e"},
+				},
+			}}
+			bc := &bugzilla.Fake{}
+			if err := bc.AddFixture(bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{{Bug: bugzilla.Bug{ID: 123}}}}); err != nil {
+				t.Fatalf("failed to add fixture: %v", err)
+			}
+			e := event{org: "org", repo: "repo", number: 1, bugId: 123, merged: true, closed: tc.closed, missing: tc.missing}
+			if err := handle(e, gc, bc, tc.options, logrus.WithField("testCase", tc.name), sets.New[string]("org/repo"), "https://github.com"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, bc.Attachments[123]); diff != "" {
+				t.Errorf("attachments differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// emailQueryClient answers the queries for users by email.
+type emailQueryClient struct {
+	*fakegithub.FakeClient
+	loginsByEmail map[string][]string
+	queries       int
+}
+
+func (c *emailQueryClient) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
+	c.queries++
+	query, ok := q.(*emailToLoginQuery)
+	if !ok {
+		return fmt.Errorf("unexpected query %T", q)
+	}
+	for _, login := range c.loginsByEmail[string(vars["email"].(githubql.String))] {
+		query.Search.Edges = append(query.Search.Edges, queryEdge{Node: queryNode{User: queryUser{Login: githubql.String(login)}}})
+	}
+	return nil
+}
+
+func TestRequireQAContact(t *testing.T) {
+	yes := true
+	testCases := []struct {
+		name            string
+		bug             bugzilla.Bug
+		expectedValid   bool
+		expectedComment string
+	}{
+		{
+			name:            "bug without QA contact is invalid",
+			bug:             bugzilla.Bug{ID: 123},
+			expectedComment: "expected the bug to have a QA contact, but none is set",
+		},
+		{
+			name:            "QA contact without email is invalid",
+			bug:             bugzilla.Bug{ID: 123, QAContact: "qa", QAContactDetail: &bugzilla.User{Name: "qa"}},
+			expectedComment: "expected the QA contact of the bug to have a listed email, but none is listed",
+		},
+		{
+			name:            "QA contact without GitHub user is invalid",
+			bug:             bugzilla.Bug{ID: 123, QAContact: "nobody", QAContactDetail: &bugzilla.User{Email: "nobody@example.com"}},
+			expectedComment: "expected the QA contact of the bug (nobody@example.com) to be a GitHub user, but no GitHub user has that public email",
+		},
+		{
+			name:            "QA contact with multiple GitHub users is invalid",
+			bug:             bugzilla.Bug{ID: 123, QAContact: "shared", QAContactDetail: &bugzilla.User{Email: "shared@example.com"}},
+			expectedComment: "expected the QA contact of the bug (shared@example.com) to be a single GitHub user, but multiple GitHub users have that public email: alice, bob",
+		},
+		{
+			name:            "QA contact with GitHub user is valid",
+			bug:             bugzilla.Bug{ID: 123, QAContact: "qa", QAContactDetail: &bugzilla.User{Email: "qa@example.com"}},
+			expectedValid:   true,
+			expectedComment: "bug has the QA contact qa@example.com, who is the GitHub user @qa-user",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := &emailQueryClient{
+				FakeClient: fakegithub.NewFakeClient(),
+				loginsByEmail: map[string][]string{
+					"qa@example.com":     {"qa-user"},
+					"shared@example.com": {"alice", "bob"},
+				},
+			}
+			gc.IssueComments = map[int][]github.IssueComment{}
+			bc := &bugzilla.Fake{}
+			if err := bc.AddFixture(bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{{Bug: tc.bug}}}); err != nil {
+				t.Fatalf("failed to add fixture: %v", err)
+			}
+			e := event{org: "org", repo: "repo", number: 1, bugId: 123, body: "Bug 123: fixed it!"}
+			if err := handle(e, gc, bc, plugins.BugzillaBranchOptions{RequireQAContact: &yes}, logrus.WithField("testCase", tc.name), sets.New[string]("org/repo"), "https://github.com"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			expectedLabel := labels.InvalidBug
+			if tc.expectedValid {
+				expectedLabel = labels.ValidBug
+			}
+			if added := sets.New[string](gc.IssueLabelsAdded...); !added.Has("org/repo#1:" + expectedLabel) {
+				t.Errorf("expected the %s label to be added, got %v", expectedLabel, gc.IssueLabelsAdded)
+			}
+			if len(gc.IssueComments[1]) != 1 {
+				t.Fatalf("expected one comment, got %d", len(gc.IssueComments[1]))
+			}
+			if body := gc.IssueComments[1][0].Body; !strings.Contains(body, tc.expectedComment) {
+				t.Errorf("expected the comment to contain %q, got %s", tc.expectedComment, body)
+			}
+			if gc.queries > 1 {
+				t.Errorf("expected the QA contact to be queried at most once, got %d queries", gc.queries)
+			}
+		})
+	}
+}
+
+func TestUpdateFlags(t *testing.T) {
+	testCases := []struct {
+		name            string
+		flags           []bugzilla.Flag
+		merged          bool
+		options         plugins.BugzillaBranchOptions
+		expectedFlags   []bugzilla.Flag
+		expectedComment string
+	}{
+		{
+			name:            "flags are set after validation",
+			flags:           []bugzilla.Flag{{Name: "blocker", Status: "-"}},
+			options:         plugins.BugzillaBranchOptions{FlagsAfterValidation: &[]plugins.BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}, {Name: "blocker", Status: "+"}}},
+			expectedFlags:   []bugzilla.Flag{{Name: "blocker", Status: "+"}, {Name: "qe_test_coverage", Status: "+"}},
+			expectedComment: "The qe_test_coverage+, blocker+ flags have been set on the bug.",
+		},
+		{
+			name:            "flags that are already set are not updated after validation",
+			flags:           []bugzilla.Flag{{Name: "qe_test_coverage", Status: "+"}},
+			options:         plugins.BugzillaBranchOptions{FlagsAfterValidation: &[]plugins.BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}}},
+			expectedFlags:   []bugzilla.Flag{{Name: "qe_test_coverage", Status: "+"}},
+			expectedComment: "which is valid.\n",
+		},
+		{
+			name:            "flags are set after merge",
+			merged:          true,
+			options:         plugins.BugzillaBranchOptions{FlagsAfterMerge: &[]plugins.BugzillaFlag{{Name: "requires_doc_text", Status: "?"}}},
+			expectedFlags:   []bugzilla.Flag{{Name: "requires_doc_text", Status: "?"}},
+			expectedComment: "The requires_doc_text? flags have been set on [Bugzilla bug 123]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			gc.PullRequests = map[int]*github.PullRequest{1: {Number: 1, Merged: tc.merged}}
+			bc := &bugzilla.Fake{}
+			if err := bc.AddFixture(bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{{
+				Bug:          bugzilla.Bug{ID: 123, Flags: tc.flags},
+				ExternalBugs: []bugzilla.ExternalBug{{ExternalBugID: "org/repo/pull/1"}},
+			}}}); err != nil {
+				t.Fatalf("failed to add fixture: %v", err)
+			}
+			e := event{org: "org", repo: "repo", number: 1, bugId: 123, body: "Bug 123: fixed it!"}
+			if tc.merged {
+				e.merged, e.closed, e.state = true, true, "closed"
+			}
+			if err := handle(e, gc, bc, tc.options, logrus.WithField("testCase", tc.name), sets.New[string]("org/repo"), "https://github.com"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedFlags, bc.Bugs[123].Flags); diff != "" {
+				t.Errorf("flags differ from expected (-want +got):\n%s", diff)
+			}
+			if len(gc.IssueComments[1]) != 1 {
+				t.Fatalf("expected one comment, got %d", len(gc.IssueComments[1]))
+			}
+			if body := gc.IssueComments[1][0].Body; !strings.Contains(body, tc.expectedComment) {
+				t.Errorf("expected the comment to contain %q, got %s", tc.expectedComment, body)
+			}
+		})
+	}
+}
+
+func TestCommentOnLink(t *testing.T) {
+	yes, no := true, false
+	deck := "https://prow.example.com/"
+	testCases := []struct {
+		name     string
+		merged   bool
+		options  plugins.BugzillaBranchOptions
+		expected []string
+	}{
+		{
+			name:    "pull request is summarized when it is linked",
+			options: plugins.BugzillaBranchOptions{AddExternalLink: &yes, CommentOnLink: &yes, DeckURL: &deck},
+			expected: []string{`Pull request https://github.com/org/repo/pull/1 was linked to this bug.
+Title: Bug 123: fixed it!
+Author: alice
+Target branch: master
+History: https://prow.example.com/pr-history?org=org&repo=repo&pr=1`},
+		},
+		{
+			name:    "commits are listed when the pull request merges",
+			merged:  true,
+			options: plugins.BugzillaBranchOptions{AddExternalLink: &yes, CommentOnLink: &yes},
+			expected: []string{`Pull request https://github.com/org/repo/pull/1 was merged into master.
+Merge commit: 123456
+CI status of abcdef: success
+Commits:
+* abcdef Fix the bug
+* fedcba Add a test`},
+		},
+		{
+			name:    "no comment is posted if disabled",
+			options: plugins.BugzillaBranchOptions{AddExternalLink: &yes, CommentOnLink: &no},
+		},
+		{
+			name:    "no comment is posted without external links",
+			merged:  true,
+			options: plugins.BugzillaBranchOptions{CommentOnLink: &yes},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mergeSHA := "123456"
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			gc.PullRequests = map[int]*github.PullRequest{1: {
+				Number:   1,
+				Title:    "Bug 123: fixed it!",
+				User:     github.User{Login: "alice"},
+				Base:     github.PullRequestBranch{Ref: "master"},
+				Head:     github.PullRequestBranch{SHA: "abcdef"},
+				Merged:   tc.merged,
+				MergeSHA: &mergeSHA,
+			}}
+			gc.CommitMap = map[string][]github.RepositoryCommit{"org/repo#1": {
+				{SHA: "abcdef", Commit: github.GitCommit{Message: "Fix the bug\n\nIt was broken."}},
+				{SHA: "fedcba", Commit: github.GitCommit{Message: "Add a test"}},
+			}}
+			gc.CombinedStatuses = map[string]*github.CombinedStatus{"abcdef": {SHA: "abcdef", State: "success"}}
+			bc := &bugzilla.Fake{}
+			fixtureBug := bugzilla.FixtureBug{Bug: bugzilla.Bug{ID: 123}}
+			if tc.merged {
+				fixtureBug.ExternalBugs = []bugzilla.ExternalBug{{ExternalBugID: "org/repo/pull/1"}}
+			}
+			if err := bc.AddFixture(bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{fixtureBug}}); err != nil {
+				t.Fatalf("failed to add fixture: %v", err)
+			}
+			e := event{org: "org", repo: "repo", baseRef: "master", number: 1, bugId: 123, body: "Bug 123: fixed it!"}
+			if tc.merged {
+				e.merged, e.closed, e.state = true, true, "closed"
+			}
+			if err := handle(e, gc, bc, tc.options, logrus.WithField("testCase", tc.name), sets.New[string]("org/repo"), "https://github.com"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var actual []string
+			for _, comment := range bc.BugComments[123] {
+				actual = append(actual, comment.Text)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("comments differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBugLabels(t *testing.T) {
+	mapping := map[string]string{"TestBlocker": "kind/release-blocker", "blocker+": "kind/release-blocker", "UpgradeBlocker": "kind/upgrade-blocker"}
+	testCases := []struct {
+		name            string
+		bug             bugzilla.Bug
+		labels          []string
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		{
+			name:          "label is added for a keyword",
+			bug:           bugzilla.Bug{ID: 123, Keywords: []string{"TestBlocker"}},
+			expectedAdded: []string{"org/repo#1:kind/release-blocker"},
+		},
+		{
+			name:          "label is added for a flag",
+			bug:           bugzilla.Bug{ID: 123, Flags: []bugzilla.Flag{{Name: "blocker", Status: "+"}}},
+			expectedAdded: []string{"org/repo#1:kind/release-blocker"},
+		},
+		{
+			name:   "flag with another status does not add the label",
+			bug:    bugzilla.Bug{ID: 123, Flags: []bugzilla.Flag{{Name: "blocker", Status: "?"}}},
+			labels: []string{},
+		},
+		{
+			name:          "present label is kept",
+			bug:           bugzilla.Bug{ID: 123, Keywords: []string{"TestBlocker", "UpgradeBlocker"}},
+			labels:        []string{"kind/release-blocker"},
+			expectedAdded: []string{"org/repo#1:kind/upgrade-blocker"},
+		},
+		{
+			name:            "label is removed when the keyword disappears",
+			bug:             bugzilla.Bug{ID: 123},
+			labels:          []string{"kind/release-blocker"},
+			expectedRemoved: []string{"org/repo#1:kind/release-blocker"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			for _, label := range tc.labels {
+				gc.IssueLabelsExisting = append(gc.IssueLabelsExisting, "org/repo#1:"+label)
+			}
+			bc := &bugzilla.Fake{}
+			if err := bc.AddFixture(bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{{Bug: tc.bug}}}); err != nil {
+				t.Fatalf("failed to add fixture: %v", err)
+			}
+			e := event{org: "org", repo: "repo", number: 1, bugId: 123, body: "Bug 123: fixed it!"}
+			if err := handle(e, gc, bc, plugins.BugzillaBranchOptions{BugLabels: &mapping}, logrus.WithField("testCase", tc.name), sets.New[string]("org/repo"), "https://github.com"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var added []string
+			for _, label := range gc.IssueLabelsAdded {
+				if strings.Contains(label, "kind/") {
+					added = append(added, label)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedAdded, added); diff != "" {
+				t.Errorf("added labels differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedRemoved, gc.IssueLabelsRemoved); diff != "" {
+				t.Errorf("removed labels differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestBugIDFromTitle(t *testing.T) {
 	var testCases = []struct {
 		title            string
@@ -2341,3 +3088,154 @@ func TestIsBugAllowed(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateBackportChain(t *testing.T) {
+	gc := fakegithub.NewFakeClient()
+	gc.PullRequests = map[int]*github.PullRequest{
+		1: {Number: 1, State: "closed", Merged: true},
+		2: {Number: 2, State: "open"},
+	}
+	bc := &bugzilla.Fake{EndpointString: "www.bugzilla"}
+	fixture := bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{
+		{
+			Bug:          bugzilla.Bug{ID: 1, Summary: "crash", Status: "MODIFIED", TargetRelease: []string{"4.9.0"}, Blocks: []int{2}},
+			ExternalBugs: []bugzilla.ExternalBug{{ExternalBugID: "org/repo/pull/1"}},
+		},
+		{
+			Bug:          bugzilla.Bug{ID: 2, Summary: "crash", Status: "POST", TargetRelease: []string{"4.8.z"}, DependsOn: []int{1}, Blocks: []int{3, 4}},
+			ExternalBugs: []bugzilla.ExternalBug{{ExternalBugID: "org/repo/pull/2"}, {ExternalBugID: "other/repo/pull/5"}},
+		},
+		{Bug: bugzilla.Bug{ID: 3, Summary: "crash", Status: "NEW", TargetRelease: []string{"4.7.z"}, DependsOn: []int{2}}},
+		// a clone outside of the allowed groups
+		{Bug: bugzilla.Bug{ID: 5, Summary: "crash", Status: "NEW", TargetRelease: []string{"4.6.z"}, DependsOn: []int{2}, Groups: []string{"private"}}},
+		// not a clone, as the summary differs
+		{Bug: bugzilla.Bug{ID: 4, Summary: "another crash", DependsOn: []int{2}}},
+	}}
+	if err := bc.AddFixture(fixture); err != nil {
+		t.Fatalf("failed to add fixture: %v", err)
+	}
+	log := logrus.WithField("test", t.Name())
+	options := plugins.BugzillaBranchOptions{AllowedGroups: []string{"public"}}
+	allRepos := sets.New[string]("org/repo")
+	githubURL := "https://github.example.com"
+
+	if err := updateBackportChain(gc, bc, 3, options, allRepos, githubURL, false, log); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(gc.IssueCommentsAdded) != 0 {
+		t.Fatalf("expected no comment to be created, got %v", gc.IssueCommentsAdded)
+	}
+
+	if err := updateBackportChain(gc, bc, 3, options, allRepos, githubURL, true, log); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []string{`org/repo#1:<!-- bugzilla backport chain -->
+Backport chain:
+
+| Bug | Target release | Status | Pull requests |
+| --- | --- | --- | --- |
+| [Bugzilla bug 1](www.bugzilla/show_bug.cgi?id=1) | 4.9.0 | MODIFIED | [org/repo#1](https://github.example.com/org/repo/pull/1) (merged) |
+| ↳ [Bugzilla bug 2](www.bugzilla/show_bug.cgi?id=2) | 4.8.z | POST | [org/repo#2](https://github.example.com/org/repo/pull/2) (open)<br>[other/repo#5](https://github.example.com/other/repo/pull/5) |
+| &nbsp;&nbsp;↳ [Bugzilla bug 3](www.bugzilla/show_bug.cgi?id=3) | 4.7.z | NEW |  |`}
+	if diff := cmp.Diff(expected, gc.IssueCommentsAdded); diff != "" {
+		t.Fatalf("comments differ from expected (-want +got):\n%s", diff)
+	}
+
+	// The comment is updated once the pull request of the backport merges.
+	gc.PullRequests[2].State, gc.PullRequests[2].Merged = "closed", true
+	if err := updateBackportChain(gc, bc, 2, options, allRepos, githubURL, false, log); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := len(gc.IssueCommentsAdded); n != 1 {
+		t.Errorf("expected no other comment to be created, got %d comments", n)
+	}
+	if n := len(gc.IssueCommentsEdited); n != 1 || !strings.Contains(gc.IssueCommentsEdited[0], "[org/repo#2](https://github.example.com/org/repo/pull/2) (merged)") {
+		t.Errorf("expected the comment to be updated with the merged backport, got %v", gc.IssueCommentsEdited)
+	}
+}
+
+func TestHandleBackport(t *testing.T) {
+	yes := true
+	v45, v44 := "4.5.z", "4.4.z"
+	config := plugins.Bugzilla{Default: map[string]plugins.BugzillaBranchOptions{
+		"release-4.5": {EnableBackporting: &yes, TargetRelease: &v45},
+		"release-4.4": {EnableBackporting: &yes, TargetRelease: &v44},
+		"release-4.3": {},
+	}}
+	base := event{
+		org: "org", repo: "repo", baseRef: "master", number: 1, bugId: 1, body: "Bug 1: fix crash", merged: true, login: "user", htmlUrl: "www.com",
+		backport: true, backportBranches: []string{"release-4.5", "release-4.4", "release-4.3", "release-4.5"},
+	}
+	testCases := []struct {
+		name              string
+		modify            func(e *event)
+		collaborators     []string
+		expectedComments  []string // substrings of the comments
+		expectedNewClones int
+	}{
+		{
+			name:             "unmerged pull requests cannot be backported",
+			modify:           func(e *event) { e.merged = false },
+			collaborators:    []string{"user"},
+			expectedComments: []string{"org/repo#1:@user: Only merged pull requests can be backported."},
+		},
+		{
+			name:             "only collaborators can request backports",
+			expectedComments: []string{"org/repo#1:@user: Only collaborators of this repository may request backports."},
+		},
+		{
+			name:          "clones are created or reused and cherry-picks are requested",
+			collaborators: []string{"user"},
+			expectedComments: []string{
+				"org/repo#1:@user: Backporting [Bugzilla bug 1](www.bugzilla/show_bug.cgi?id=1):\n" +
+					"* release-4.5: [Bugzilla bug 2](www.bugzilla/show_bug.cgi?id=2) already is the clone for the 4.5.z target release.\n" +
+					"* release-4.4: cloned the bug as [Bugzilla bug 3](www.bugzilla/show_bug.cgi?id=3) for the 4.4.z target release.\n" +
+					"* release-4.3: backporting is not enabled for the branch.\n\n" +
+					"Requesting cherry-picks of this pull request that refer to the cloned bugs.",
+				"org/repo#1:/cherrypick release-4.5\n<!-- cherrypick-request: {\"title\":\"Bug 2: fix crash\",\"requestor\":\"user\"} -->",
+				"org/repo#1:/cherrypick release-4.4\n<!-- cherrypick-request: {\"title\":\"Bug 3: fix crash\",\"requestor\":\"user\"} -->",
+			},
+			expectedNewClones: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			gc.Collaborators = tc.collaborators
+			bc := &bugzilla.Fake{EndpointString: "www.bugzilla"}
+			fixture := bugzilla.Fixture{Bugs: []bugzilla.FixtureBug{
+				{
+					Bug:      bugzilla.Bug{ID: 1, Summary: "crash", TargetRelease: []string{"4.6.0"}, Blocks: []int{2}},
+					Comments: []bugzilla.Comment{{BugID: 1, Count: 0, Text: "it crashes"}},
+				},
+				{Bug: bugzilla.Bug{ID: 2, Summary: "crash", TargetRelease: []string{"4.5.z"}, DependsOn: []int{1}}},
+			}}
+			if err := bc.AddFixture(fixture); err != nil {
+				t.Fatalf("failed to add fixture: %v", err)
+			}
+			e := base
+			if tc.modify != nil {
+				tc.modify(&e)
+			}
+			if err := handleBackport(e, gc, bc, config, logrus.WithField("testCase", tc.name)); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(gc.IssueCommentsAdded) != len(tc.expectedComments) {
+				t.Fatalf("expected %d comments, got %v", len(tc.expectedComments), gc.IssueCommentsAdded)
+			}
+			for i, expected := range tc.expectedComments {
+				if !strings.Contains(gc.IssueCommentsAdded[i], expected) {
+					t.Errorf("expected comment %d to contain %q, got %q", i, expected, gc.IssueCommentsAdded[i])
+				}
+			}
+			if got := len(bc.Bugs) - 2; got != tc.expectedNewClones {
+				t.Errorf("expected %d new clones, got %d", tc.expectedNewClones, got)
+			}
+			if tc.expectedNewClones > 0 {
+				if diff := cmp.Diff([]string{"4.4.z"}, bc.Bugs[3].TargetRelease); diff != "" {
+					t.Errorf("target release of the clone differs from expected (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
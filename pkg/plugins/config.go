@@ -20,7 +20,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"slices"
@@ -38,6 +41,7 @@ import (
 
 	"sigs.k8s.io/prow/pkg/bugzilla"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/logrusutil"
@@ -46,6 +50,7 @@ import (
 
 const (
 	defaultBlunderbussReviewerCount = 2
+	defaultArtifactGateContext      = "artifacts-verified"
 )
 
 // Configuration is the top-level serialization target for plugin Configuration.
@@ -63,11 +68,16 @@ type Configuration struct {
 	// external plugins.
 	ExternalPlugins map[string][]ExternalPlugin `json:"external_plugins,omitempty"`
 
+	// PluginDispatch is a map of plugin names, of both built-in and
+	// external plugins, to how hook dispatches events to them.
+	PluginDispatch map[string]PluginDispatch `json:"plugin_dispatch,omitempty"`
+
 	// Owners contains configuration related to handling OWNERS files.
 	Owners Owners `json:"owners,omitempty"`
 
 	// Built-in plugins specific configuration.
 	Approve              []Approve                    `json:"approve,omitempty"`
+	ArtifactGate         map[string]*ArtifactGate     `json:"artifact_gate,omitempty"`
 	Blockades            []Blockade                   `json:"blockades,omitempty"`
 	Blunderbuss          Blunderbuss                  `json:"blunderbuss,omitempty"`
 	Bugzilla             Bugzilla                     `json:"bugzilla,omitempty"`
@@ -80,10 +90,13 @@ type Configuration struct {
 	Golint               Golint                       `json:"golint,omitempty"`
 	Goose                Goose                        `json:"goose,omitempty"`
 	Heart                Heart                        `json:"heart,omitempty"`
+	Hold                 map[string]*Hold             `json:"hold,omitempty"`
 	Label                Label                        `json:"label,omitempty"`
 	Lgtm                 []Lgtm                       `json:"lgtm,omitempty"`
 	Jira                 *Jira                        `json:"jira,omitempty"`
+	LinkedIssue          map[string]*LinkedIssue      `json:"linked_issue,omitempty"`
 	MilestoneApplier     map[string]BranchToMilestone `json:"milestone_applier,omitempty"`
+	ReleaseNote          map[string]*ReleaseNote      `json:"release_note,omitempty"`
 	RepoMilestone        map[string]Milestone         `json:"repo_milestone,omitempty"`
 	Project              ProjectConfig                `json:"project_config,omitempty"`
 	ProjectManager       ProjectManager               `json:"project_manager,omitempty"`
@@ -95,7 +108,125 @@ type Configuration struct {
 	Triggers             []Trigger                    `json:"triggers,omitempty"`
 	Welcome              []Welcome                    `json:"welcome,omitempty"`
 	Override             Override                     `json:"override,omitempty"`
+	Skip                 Skip                         `json:"skip,omitempty"`
 	Help                 Help                         `json:"help,omitempty"`
+
+	// RepoOptions is a map of organizations (eg "o") or repositories (eg "o/r")
+	// to plugin options that override the ones above for the events of the
+	// organization or repository, using the same fields. The options of a
+	// repository override the ones of its organization. Maps are merged key by
+	// key, while all other values, including lists, replace the overridden ones.
+	// The plugins, external_plugins, plugin_dispatch and config_updater options
+	// cannot be overridden. The options apply to the plugins in hook and to the
+	// trigger options other components read for a repository, external plugins
+	// have to look up the options of a repository with ForRepo.
+	RepoOptions map[string]RepoOptions `json:"repo_options,omitempty"`
+	// RepoConfigs are the configurations resolved from RepoOptions, keyed
+	// like RepoOptions.
+	RepoConfigs map[string]*Configuration `json:"-"`
+}
+
+// RepoOptions are plugin options that override the ones of the configuration
+// for an organization or repository.
+type RepoOptions map[string]interface{}
+
+// nonOverridableOptions are the options that RepoOptions may not override, as
+// they do not configure how plugins handle the events of a repository.
+var nonOverridableOptions = sets.New[string]("plugins", "external_plugins", "plugin_dispatch", "config_updater", "repo_options")
+
+// ForRepo returns the configuration for the events of a repository, which is
+// c with the RepoOptions of the organization and the repository applied.
+func (c *Configuration) ForRepo(org, repo string) *Configuration {
+	if resolved, ok := c.RepoConfigs[org+"/"+repo]; ok {
+		return resolved
+	}
+	if resolved, ok := c.RepoConfigs[org]; ok {
+		return resolved
+	}
+	return c
+}
+
+// resolveRepoOptions resolves RepoConfigs by applying the RepoOptions to c.
+// It has to be called before c is defaulted, and the resolved configurations
+// are validated. Fields unknown in RepoOptions are always rejected.
+func (c *Configuration) resolveRepoOptions() error {
+	c.RepoConfigs = nil
+	if len(c.RepoOptions) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plugin config: %w", err)
+	}
+	var base map[string]interface{}
+	if err := json.Unmarshal(raw, &base); err != nil {
+		return fmt.Errorf("failed to unmarshal plugin config: %w", err)
+	}
+	delete(base, "repo_options")
+
+	var errs []error
+	resolved := map[string]*Configuration{}
+	for _, orgRepo := range sets.List(sets.KeySet(c.RepoOptions)) {
+		org, repo, isRepo := strings.Cut(orgRepo, "/")
+		if org == "" || (isRepo && (repo == "" || strings.Contains(repo, "/"))) {
+			errs = append(errs, fmt.Errorf("repo_options: %q is neither an organization nor a repository", orgRepo))
+			continue
+		}
+		var overrides []RepoOptions
+		if isRepo {
+			overrides = append(overrides, c.RepoOptions[org])
+		}
+		overrides = append(overrides, c.RepoOptions[orgRepo])
+
+		options := base
+		for _, override := range overrides {
+			for option := range override {
+				if nonOverridableOptions.Has(option) {
+					errs = append(errs, fmt.Errorf("repo_options of %s: the %s option cannot be overridden", orgRepo, option))
+				}
+			}
+			options = mergeOptions(options, override)
+		}
+		raw, err := json.Marshal(options)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("repo_options of %s: %w", orgRepo, err))
+			continue
+		}
+		cfg := &Configuration{}
+		if err := yaml.Unmarshal(raw, cfg, yaml.DisallowUnknownFields); err != nil {
+			errs = append(errs, fmt.Errorf("repo_options of %s: %w", orgRepo, err))
+			continue
+		}
+		if err := cfg.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("repo_options of %s: %w", orgRepo, err))
+			continue
+		}
+		resolved[orgRepo] = cfg
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return err
+	}
+	c.RepoConfigs = resolved
+	return nil
+}
+
+// mergeOptions returns the options with the overrides applied, without
+// changing either. Maps are merged key by key, any other value is replaced.
+func mergeOptions(options, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(options))
+	for key, value := range options {
+		merged[key] = value
+	}
+	for key, override := range overrides {
+		overrideMap, isMap := override.(map[string]interface{})
+		value, isMergeable := merged[key].(map[string]interface{})
+		if isMap && isMergeable {
+			merged[key] = mergeOptions(value, overrideMap)
+		} else {
+			merged[key] = override
+		}
+	}
+	return merged
 }
 
 type Help struct {
@@ -145,6 +276,38 @@ type ExternalPlugin struct {
 	// server to the external plugin. If no events are specified,
 	// everything is sent.
 	Events []string `json:"events,omitempty"`
+	// GRPCEndpoint is the address of the gRPC server of an external
+	// plugin built with the externalplugin package, e.g. "name:9090".
+	// If set, hook dispatches typed events to it over gRPC instead of
+	// sending webhooks to the endpoint, which still serves the help.
+	GRPCEndpoint string `json:"grpc_endpoint,omitempty"`
+}
+
+// PluginDispatch configures how hook dispatches events to a plugin, so that
+// a slow plugin cannot starve the others.
+type PluginDispatch struct {
+	// MaxConcurrency is the maximum number of events the plugin handles at
+	// the same time. Further events wait until a handler finishes.
+	// Defaults to 0 meaning no limit.
+	MaxConcurrency int `json:"max_concurrency,omitempty"`
+	// Events are the event types, e.g. "pull_request" or "issue_comment",
+	// that are dispatched to the plugin. Comment handlers are filtered by
+	// the type of event the comment was made in. If no events are
+	// specified, everything is dispatched.
+	Events []string `json:"events,omitempty"`
+}
+
+// Dispatches returns whether events of the type are dispatched to the plugin.
+func (d PluginDispatch) Dispatches(eventType string) bool {
+	if len(d.Events) == 0 {
+		return true
+	}
+	for _, e := range d.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
 }
 
 // Blunderbuss defines configuration for the blunderbuss plugin.
@@ -172,6 +335,52 @@ type Blunderbuss struct {
 	// This is useful when a bot user or admin opens a PR that will be
 	// merged regardless of approvals.
 	IgnoreAuthors []string `json:"ignore_authors,omitempty"`
+	// UseReviewLoad controls whether blunderbuss prefers candidates with fewer
+	// open pull requests in the org that request their review. This will use one
+	// additional token per candidate.
+	UseReviewLoad bool `json:"use_review_load,omitempty"`
+	// MaxReviewLoad is the number of open review requests at which a candidate
+	// is no longer requested automatically. An explicit /auto-cc command, in a
+	// comment or in the PR body, still requests them. Defaults to 0 meaning no
+	// limit. Only used if UseReviewLoad is true.
+	MaxReviewLoad int `json:"max_review_load,omitempty"`
+	// TimeZonesFile is the path to an optional YAML file mapping GitHub logins
+	// to IANA time zones, e.g. "alice: Europe/Berlin". Candidates that are
+	// currently within working hours (09:00-17:00 on weekdays) in their time
+	// zone are preferred over those that are not. Candidates without a time zone
+	// are considered to be within working hours. The file is read whenever the
+	// plugin config is loaded.
+	TimeZonesFile string `json:"time_zones_file,omitempty"`
+	// TimeZones are the time zones of TimeZonesFile by normalized login, they
+	// are loaded along with the plugin config.
+	TimeZones map[string]*time.Location `json:"-"`
+}
+
+// loadTimeZones reads the YAML file mapping GitHub logins to IANA time zones
+// into TimeZones.
+func (b *Blunderbuss) loadTimeZones() error {
+	b.TimeZones = nil
+	if b.TimeZonesFile == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(b.TimeZonesFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", b.TimeZonesFile, err)
+	}
+	var names map[string]string
+	if err := yaml.Unmarshal(raw, &names); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", b.TimeZonesFile, err)
+	}
+	timeZones := map[string]*time.Location{}
+	for login, name := range names {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("invalid time zone %q for %s: %w", name, login, err)
+		}
+		timeZones[github.NormLogin(login)] = loc
+	}
+	b.TimeZones = timeZones
+	return nil
 }
 
 // Owners contains configuration related to handling OWNERS files.
@@ -204,6 +413,27 @@ type Owners struct {
 	// Filenames allows configuring repos to use a separate set of filenames for
 	// any plugin that interacts with these files. Keys are in "org" or "org/repo" format.
 	Filenames map[string]ownersconfig.Filenames `json:"filenames,omitempty"`
+
+	// MembershipScan configures the verify-owners plugin to periodically scan
+	// all OWNERS and OWNERS_ALIASES files of some repos for users who are no
+	// longer trusted, e.g. because they left the org.
+	MembershipScan *OwnersMembershipScan `json:"membership_scan,omitempty"`
+}
+
+// OwnersMembershipScan configures the periodic scan of OWNERS files for users
+// who are no longer trusted. Users are trusted as configured for the trigger
+// plugin of the repo. In every repo where such users are found, an issue is
+// opened with a patch removing them. Only repos that enable the verify-owners
+// plugin are scanned. The scan runs on the replica of hook that holds the
+// prow-hook-membership-scan-leaderlock lease, and hook only starts it if it
+// is configured when hook starts.
+type OwnersMembershipScan struct {
+	// Repos is a list of "org/repo" strings of the repos to scan.
+	Repos []string `json:"repos,omitempty"`
+	// Interval is how often the repos are scanned.
+	// Defaults to '24h'.
+	Interval         string        `json:"interval,omitempty"`
+	IntervalDuration time.Duration `json:"-"`
 }
 
 // OwnersFilenames determines which filenames to use for OWNERS and OWNERS_ALIASES for a repo.
@@ -284,6 +514,24 @@ type Size struct {
 	L   int `json:"l"`
 	Xl  int `json:"xl"`
 	Xxl int `json:"xxl"`
+
+	// ExcludedPaths allows to configure regular expressions of files that are
+	// not counted, e.g. vendored dependencies or generated protobufs. The key
+	// defines to which repos this applies and can be `*` for global, an org
+	// or a repo in org/repo notation.
+	// Compiles into ExcludedPathRes during config load.
+	ExcludedPaths   map[string][]string         `json:"excluded_paths,omitempty"`
+	ExcludedPathRes map[string][]*regexp.Regexp `json:"-"`
+}
+
+// ExcludedPathResFor returns the regular expressions of the files that are not
+// counted in the repo.
+func (s Size) ExcludedPathResFor(org, repo string) []*regexp.Regexp {
+	var result []*regexp.Regexp
+	for _, orgRepoKey := range []string{"*", org, org + "/" + repo} {
+		result = append(result, s.ExcludedPathRes[orgRepoKey]...)
+	}
+	return result
 }
 
 // Blockade specifies a configuration for a single blockade.
@@ -332,6 +580,11 @@ type Approve struct {
 	// PrProcessLink is the link to the help page which explains the code review process.
 	// The default value is "https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process".
 	PrProcessLink string `json:"pr_process_link,omitempty"`
+	// ProgressComment renders a checklist of the top-level directories that still
+	// need approval, with suggested approvers for each, in the notification comment.
+	// The comment is then edited in place as approvals accumulate instead of being
+	// deleted and posted again.
+	ProgressComment bool `json:"progress_comment,omitempty"`
 }
 
 var (
@@ -373,6 +626,10 @@ type Lgtm struct {
 	// StickyLgtmTeam specifies the GitHub team whose members are trusted with sticky LGTM,
 	// which eliminates the need to re-lgtm minor fixes/updates.
 	StickyLgtmTeam string `json:"trusted_team_for_sticky_lgtm,omitempty"`
+	// LgtmThreshold is the number of distinct users that must give an LGTM before
+	// the lgtm label is added. The users that gave an LGTM so far are tracked in a
+	// comment. New changes pushed to the PR reset them. Defaults to 1.
+	LgtmThreshold int `json:"lgtm_threshold,omitempty"`
 }
 
 // Jira holds the config for the jira plugin.
@@ -383,6 +640,77 @@ type Jira struct {
 	DisabledJiraProjects []string `json:"disabled_jira_projects,omitempty"`
 }
 
+// LinkedIssue is the config for the linked-issue plugin.
+type LinkedIssue struct {
+	// JiraProjects are the keys of the Jira projects whose tickets satisfy the
+	// linked issue requirement, for example `PROJ` for `PROJ-123`. Matching is
+	// case-insensitive. Jira references are ignored if this is empty.
+	JiraProjects []string `json:"jira_projects,omitempty"`
+	// Bugzilla allows a `Bug 123:` reference in the PR title to satisfy the
+	// linked issue requirement.
+	Bugzilla bool `json:"bugzilla,omitempty"`
+	// KindMapping maps labels of a referenced GitHub issue or the issue type
+	// of a referenced Jira ticket (e.g. `Story`) to the `kind/*` label that
+	// is applied to the PR. `kind/*` labels of a referenced GitHub issue are
+	// always copied to the PR. Labels are only applied if the PR has no
+	// `kind/*` label yet.
+	KindMapping map[string]string `json:"kind_mapping,omitempty"`
+	// ExemptLabels are labels that waive the linked issue requirement, for
+	// example `kind/cleanup` or `trivial`.
+	ExemptLabels []string `json:"exempt_labels,omitempty"`
+}
+
+// Hold is the config for the hold plugin.
+type Hold struct {
+	// RiskyPaths are patterns of paths that automatically put a hold on pull
+	// requests that change them. Patterns are matched against the changed
+	// files with the syntax of .gitignore files, for example `vendor/`,
+	// `*/migration/*` or `db/**/*.sql`.
+	RiskyPaths []string `json:"risky_paths,omitempty"`
+	// ApproversAlias is the alias in the OWNERS_ALIASES file of the repo
+	// whose members may cancel a hold that was put on automatically.
+	// Required if RiskyPaths are set.
+	ApproversAlias string `json:"approvers_alias,omitempty"`
+}
+
+// ReleaseNote is the config for the release-note plugin.
+type ReleaseNote struct {
+	// Categories are the fields of structured release-note blocks, which
+	// precede the text of the note as `name: value` lines, for example
+	// `kind: feature`. An `action-required: true|false` field is always
+	// accepted if categories are configured. Release notes are not parsed
+	// for fields if this is empty.
+	Categories []ReleaseNoteCategory `json:"categories,omitempty"`
+}
+
+// ReleaseNoteCategory is a field of structured release-note blocks.
+type ReleaseNoteCategory struct {
+	// Name of the field, for example `kind` or `area`.
+	Name string `json:"name"`
+	// Values are the values accepted for the field. Any value is accepted if
+	// this is empty.
+	Values []string `json:"values,omitempty"`
+	// Required rejects release notes that do not set the field.
+	Required bool `json:"required,omitempty"`
+	// LabelPrefix, if set, applies a label made of the prefix and the value
+	// of the field to the PR, for example `kind/` for `kind/feature`.
+	LabelPrefix string `json:"label_prefix,omitempty"`
+}
+
+// ArtifactGate is the config for the artifact-gate plugin.
+type ArtifactGate struct {
+	// Jobs are the names of the presubmits that build the artifacts of the
+	// repo, e.g. container images.
+	Jobs []string `json:"jobs,omitempty"`
+	// PolicyURL is the endpoint of the policy service that is asked whether the
+	// artifacts built by a job were scanned and signed. The service identifies
+	// the artifacts and their digests by the job and its build ID.
+	PolicyURL string `json:"policy_url,omitempty"`
+	// Context is the status context that only turns green once all artifacts
+	// are verified. Defaults to "artifacts-verified".
+	Context string `json:"context,omitempty"`
+}
+
 // Cat contains the configuration for the cat plugin.
 type Cat struct {
 	// Path to file containing an api key for thecatapi.com
@@ -469,6 +797,12 @@ type Trigger struct {
 	IgnoreOkToTest bool `json:"ignore_ok_to_test,omitempty"`
 	// TriggerGitHubWorkflows enables workflows run by github to be triggered by prow.
 	TriggerGitHubWorkflows bool `json:"trigger_github_workflows,omitempty"`
+	// RetriggerLimits maps job names to the maximum number of times the job
+	// may run on the same PR within an hour. Once the limit is reached,
+	// `/test` and `/retest` comments no longer start the job and trigger
+	// comments that the job is cooling down instead. Jobs that are not listed
+	// are not rate limited.
+	RetriggerLimits map[string]int `json:"retrigger_limits,omitempty"`
 }
 
 // Heart contains the configuration for the heart plugin.
@@ -499,9 +833,22 @@ type Milestone struct {
 }
 
 // BranchToMilestone is a map of the branch name to the configured milestone for that branch.
+// Keys enclosed in slashes are regular expressions, which branches without a
+// milestone of their own are matched against. Their submatches can be
+// referenced in the milestone, e.g. `/release-(\d+\.\d+)/`: `v$1`.
 // This is used by the milestoneapplier plugin.
 type BranchToMilestone map[string]string
 
+// BranchPattern returns the regular expression of a key of BranchToMilestone
+// and whether the key is a regular expression at all. Branch names cannot
+// start or end with a slash, so such keys are never branch names.
+func BranchPattern(key string) (string, bool) {
+	if len(key) < 2 || !strings.HasPrefix(key, "/") || !strings.HasSuffix(key, "/") {
+		return "", false
+	}
+	return key[1 : len(key)-1], true
+}
+
 // Slack contains the configuration for the slack plugin.
 type Slack struct {
 	MentionChannels []string       `json:"mentionchannels,omitempty"`
@@ -687,6 +1034,21 @@ type Welcome struct {
 	// Post welcome message in all cases, even if PR author is not an existing
 	// contributor or part of the organization
 	AlwaysPost bool `json:"always_post,omitempty"`
+	// AreaDocs lists the contributing docs of areas of the repo. The docs of
+	// the areas a PR changes files in are provided to the message template.
+	AreaDocs []WelcomeAreaDoc `json:"area_docs,omitempty"`
+}
+
+// WelcomeAreaDoc is the contributing doc of an area of a repo.
+type WelcomeAreaDoc struct {
+	// Name of the area, e.g. "networking".
+	Name string `json:"name"`
+	// PathRegexp matches the files of the area.
+	// Compiles into PathRe during config load.
+	PathRegexp string         `json:"path_regexp"`
+	PathRe     *regexp.Regexp `json:"-"`
+	// URL of the contributing doc of the area.
+	URL string `json:"url"`
 }
 
 func (w Welcome) getRepos() []string {
@@ -712,6 +1074,30 @@ type Dco struct {
 	ContributingBranch string `json:"contributing_branch,omitempty"`
 	// ContributingPath is used to override the default path to CONTRIBUTING.md
 	ContributingPath string `json:"contributing_path,omitempty"`
+	// CLA configures the plugin to verify that the authors of all commits
+	// signed a CLA with an external service, instead of checking the commits
+	// for Signed-off-by trailers.
+	CLA *DcoCLA `json:"cla,omitempty"`
+}
+
+// DcoCLA configures the external CLA service the dco plugin verifies commit
+// authors with.
+type DcoCLA struct {
+	// URL is the endpoint of the CLA service. It is queried with the "login"
+	// and "email" query parameters of a commit author and must respond with a
+	// JSON object like {"signed": true}.
+	URL string `json:"url"`
+	// SignURL is where authors can sign the CLA. It is linked from the status
+	// context and the comment listing the authors who did not sign yet.
+	SignURL string `json:"sign_url,omitempty"`
+	// RequireSignoff makes the plugin check the commits for Signed-off-by
+	// trailers in addition to the CLA.
+	RequireSignoff bool `json:"require_signoff,omitempty"`
+	// CacheTTL is how long the plugin remembers that an author signed the CLA.
+	// Authors who did not sign yet are looked up again on every check.
+	// Defaults to '1h'.
+	CacheTTL         string        `json:"cache_ttl,omitempty"`
+	CacheTTLDuration time.Duration `json:"-"`
 }
 
 // CherryPickApproved is the config for the cherrypick-approved plugin.
@@ -954,6 +1340,54 @@ func (c *Configuration) DcoFor(org, repo string) *Dco {
 	return &Dco{}
 }
 
+// ArtifactGateFor finds the ArtifactGate config for a repo, if one exists.
+// An ArtifactGate config can be listed for the repo itself, for the owning
+// organization or globally with "*".
+func (c *Configuration) ArtifactGateFor(org, repo string) *ArtifactGate {
+	for _, key := range []string{fmt.Sprintf("%s/%s", org, repo), org, "*"} {
+		if c.ArtifactGate[key] != nil {
+			return c.ArtifactGate[key]
+		}
+	}
+	return nil
+}
+
+// HoldFor finds the Hold config for a repo, if one exists.
+// A Hold config can be listed for the repo itself, for the owning
+// organization or globally with "*".
+func (c *Configuration) HoldFor(org, repo string) *Hold {
+	for _, key := range []string{fmt.Sprintf("%s/%s", org, repo), org, "*"} {
+		if c.Hold[key] != nil {
+			return c.Hold[key]
+		}
+	}
+	return &Hold{}
+}
+
+// ReleaseNoteFor finds the ReleaseNote config for a repo, if one exists.
+// A ReleaseNote config can be listed for the repo itself, for the owning
+// organization or globally with "*".
+func (c *Configuration) ReleaseNoteFor(org, repo string) *ReleaseNote {
+	for _, key := range []string{fmt.Sprintf("%s/%s", org, repo), org, "*"} {
+		if c.ReleaseNote[key] != nil {
+			return c.ReleaseNote[key]
+		}
+	}
+	return &ReleaseNote{}
+}
+
+// LinkedIssueFor finds the LinkedIssue config for a repo, if one exists.
+// A LinkedIssue config can be listed for the repo itself, for the owning
+// organization or globally with "*".
+func (c *Configuration) LinkedIssueFor(org, repo string) *LinkedIssue {
+	for _, key := range []string{fmt.Sprintf("%s/%s", org, repo), org, "*"} {
+		if c.LinkedIssue[key] != nil {
+			return c.LinkedIssue[key]
+		}
+	}
+	return &LinkedIssue{}
+}
+
 func OldToNewPlugins(oldPlugins map[string][]string) Plugins {
 	newPlugins := make(Plugins)
 	for repo, plugins := range oldPlugins {
@@ -1075,6 +1509,11 @@ func (c *Configuration) setDefaults() {
 	for i := range c.Triggers {
 		c.Triggers[i].SetDefaults()
 	}
+	for _, gate := range c.ArtifactGate {
+		if gate != nil && gate.Context == "" {
+			gate.Context = defaultArtifactGateContext
+		}
+	}
 	if c.SigMention.Regexp == "" {
 		c.SigMention.Regexp = `(?m)@kubernetes/sig-([\w-]*)-(misc|test-failures|bugs|feature-requests|proposals|pr-reviews|api-reviews)`
 	}
@@ -1104,6 +1543,16 @@ func (c *Configuration) setDefaults() {
 			c.RequireMatchingLabel[i].GracePeriod = "5s"
 		}
 	}
+
+	if c.Owners.MembershipScan != nil && c.Owners.MembershipScan.Interval == "" {
+		c.Owners.MembershipScan.Interval = "24h"
+	}
+
+	for _, dco := range c.Dco {
+		if dco != nil && dco.CLA != nil && dco.CLA.CacheTTL == "" {
+			dco.CLA.CacheTTL = "1h"
+		}
+	}
 }
 
 // validatePluginsDupes will return an error if there are duplicated plugins.
@@ -1192,6 +1641,21 @@ func validateExternalPlugins(pluginMap map[string][]ExternalPlugin) error {
 	return nil
 }
 
+func validatePluginDispatch(dispatch map[string]PluginDispatch) error {
+	var errs []error
+	for plugin, d := range dispatch {
+		if d.MaxConcurrency < 0 {
+			errs = append(errs, fmt.Errorf("plugin_dispatch.%s.max_concurrency must not be negative", plugin))
+		}
+		for _, e := range d.Events {
+			if e == "" {
+				errs = append(errs, fmt.Errorf("plugin_dispatch.%s.events must not contain empty event types", plugin))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func validateBlunderbuss(b *Blunderbuss) error {
 	if b.ReviewerCount != nil && *b.ReviewerCount < 1 {
 		return fmt.Errorf("invalid request_count: %v (needs to be positive)", *b.ReviewerCount)
@@ -1323,6 +1787,20 @@ func validateTrigger(triggers []Trigger) error {
 		if trigger.TrustedOrg != "" {
 			logrusutil.ThrottledWarnf(&warnTriggerTrustedOrg, 5*time.Minute, "trusted_org functionality is deprecated. Please ensure your configuration is updated before the end of December 2019.")
 		}
+		for job, limit := range trigger.RetriggerLimits {
+			if limit < 1 {
+				return fmt.Errorf("retrigger limit for job %q in trigger for %v must be at least 1, got %d", job, trigger.Repos, limit)
+			}
+		}
+	}
+	return nil
+}
+
+func validateLgtm(lgtms []Lgtm) error {
+	for _, lgtm := range lgtms {
+		if lgtm.LgtmThreshold < 0 {
+			return fmt.Errorf("lgtm_threshold for %v must not be negative, got %d", lgtm.Repos, lgtm.LgtmThreshold)
+		}
 	}
 	return nil
 }
@@ -1369,6 +1847,30 @@ func compileRegexpsAndDurations(pc *Configuration) error {
 		pc.Blockades[i].BranchRe = branchRe
 	}
 
+	if len(pc.Size.ExcludedPaths) > 0 {
+		pc.Size.ExcludedPathRes = map[string][]*regexp.Regexp{}
+	}
+	for orgRepo, paths := range pc.Size.ExcludedPaths {
+		for _, path := range paths {
+			pathRe, err := regexp.Compile(path)
+			if err != nil {
+				return fmt.Errorf("failed to compile size excluded path for %s: %q, error: %w", orgRepo, path, err)
+			}
+			pc.Size.ExcludedPathRes[orgRepo] = append(pc.Size.ExcludedPathRes[orgRepo], pathRe)
+		}
+	}
+
+	for i := range pc.Welcome {
+		for j := range pc.Welcome[i].AreaDocs {
+			doc := &pc.Welcome[i].AreaDocs[j]
+			pathRe, err := regexp.Compile(doc.PathRegexp)
+			if err != nil {
+				return fmt.Errorf("failed to compile welcome area doc path_regexp: %q, error: %w", doc.PathRegexp, err)
+			}
+			doc.PathRe = pathRe
+		}
+	}
+
 	commentRe, err := regexp.Compile(pc.Heart.CommentRegexp)
 	if err != nil {
 		return err
@@ -1390,6 +1892,142 @@ func compileRegexpsAndDurations(pc *Configuration) error {
 		}
 		rs[i].GracePeriodDuration = dur
 	}
+
+	if scan := pc.Owners.MembershipScan; scan != nil {
+		dur, err := time.ParseDuration(scan.Interval)
+		if err != nil {
+			return fmt.Errorf("failed to compile owners membership scan interval: %q, error: %w", scan.Interval, err)
+		}
+		scan.IntervalDuration = dur
+	}
+
+	for orgRepo, dco := range pc.Dco {
+		if dco == nil || dco.CLA == nil {
+			continue
+		}
+		dur, err := time.ParseDuration(dco.CLA.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("failed to compile dco[%s].cla.cache_ttl: %q, error: %w", orgRepo, dco.CLA.CacheTTL, err)
+		}
+		dco.CLA.CacheTTLDuration = dur
+	}
+	return nil
+}
+
+func validateLinkedIssue(configs map[string]*LinkedIssue) error {
+	for orgRepo, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		for from, to := range cfg.KindMapping {
+			if !strings.HasPrefix(to, "kind/") {
+				return fmt.Errorf("linked_issue[%s].kind_mapping: %q maps to %q, which is not a kind/* label", orgRepo, from, to)
+			}
+		}
+	}
+	return nil
+}
+
+func validateMilestoneApplier(configs map[string]BranchToMilestone) error {
+	for orgRepo, branchToMilestone := range configs {
+		for branch := range branchToMilestone {
+			pattern, ok := BranchPattern(branch)
+			if !ok {
+				continue
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("milestone_applier[%s]: branch %q is not a valid regular expression: %w", orgRepo, branch, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateHold(configs map[string]*Hold) error {
+	for orgRepo, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		for _, pattern := range cfg.RiskyPaths {
+			if _, err := path.Match(strings.TrimSuffix(pattern, "/"), ""); err != nil {
+				return fmt.Errorf("hold[%s].risky_paths: invalid pattern %q: %w", orgRepo, pattern, err)
+			}
+		}
+		if len(cfg.RiskyPaths) > 0 && cfg.ApproversAlias == "" {
+			return fmt.Errorf("hold[%s]: approvers_alias is required when risky_paths are set", orgRepo)
+		}
+	}
+	return nil
+}
+
+func validateReleaseNote(configs map[string]*ReleaseNote) error {
+	for orgRepo, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		names := sets.New[string]()
+		for i, category := range cfg.Categories {
+			switch {
+			case category.Name == "":
+				return fmt.Errorf("release_note[%s].categories[%d]: name must not be empty", orgRepo, i)
+			case category.Name == "action-required":
+				return fmt.Errorf("release_note[%s].categories[%d]: action-required is always accepted and can not be configured", orgRepo, i)
+			case names.Has(category.Name):
+				return fmt.Errorf("release_note[%s].categories[%d]: category %q is configured more than once", orgRepo, i, category.Name)
+			}
+			names.Insert(category.Name)
+		}
+	}
+	return nil
+}
+
+func validateOwnersMembershipScan(scan *OwnersMembershipScan) error {
+	if scan == nil {
+		return nil
+	}
+	for _, orgRepo := range scan.Repos {
+		if parts := strings.Split(orgRepo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("owners.membership_scan.repos: %q is not in org/repo format", orgRepo)
+		}
+	}
+	if scan.IntervalDuration <= 0 {
+		return fmt.Errorf("owners.membership_scan.interval: %q must be positive", scan.Interval)
+	}
+	return nil
+}
+
+func validateDco(configs map[string]*Dco) error {
+	for orgRepo, cfg := range configs {
+		if cfg == nil || cfg.CLA == nil {
+			continue
+		}
+		if _, err := url.ParseRequestURI(cfg.CLA.URL); err != nil {
+			return fmt.Errorf("dco[%s].cla: invalid url: %w", orgRepo, err)
+		}
+		if cfg.CLA.SignURL != "" {
+			if _, err := url.ParseRequestURI(cfg.CLA.SignURL); err != nil {
+				return fmt.Errorf("dco[%s].cla: invalid sign_url: %w", orgRepo, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateArtifactGate(configs map[string]*ArtifactGate) error {
+	for orgRepo, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		if len(cfg.Jobs) == 0 {
+			return fmt.Errorf("artifact_gate[%s]: jobs must not be empty", orgRepo)
+		}
+		if cfg.PolicyURL == "" {
+			return fmt.Errorf("artifact_gate[%s]: policy_url must be set", orgRepo)
+		}
+		if _, err := url.ParseRequestURI(cfg.PolicyURL); err != nil {
+			return fmt.Errorf("artifact_gate[%s]: invalid policy_url: %w", orgRepo, err)
+		}
+	}
 	return nil
 }
 
@@ -1411,6 +2049,9 @@ func (c *Configuration) Validate() error {
 	if err := validateExternalPlugins(c.ExternalPlugins); err != nil {
 		return err
 	}
+	if err := validatePluginDispatch(c.PluginDispatch); err != nil {
+		return err
+	}
 	if err := validateBlunderbuss(&c.Blunderbuss); err != nil {
 		return err
 	}
@@ -1429,6 +2070,33 @@ func (c *Configuration) Validate() error {
 	if err := validateTrigger(c.Triggers); err != nil {
 		return err
 	}
+	if err := validateLgtm(c.Lgtm); err != nil {
+		return err
+	}
+	if err := validateLinkedIssue(c.LinkedIssue); err != nil {
+		return err
+	}
+	if err := validateArtifactGate(c.ArtifactGate); err != nil {
+		return err
+	}
+	if err := validateHold(c.Hold); err != nil {
+		return err
+	}
+	if err := validateOwnersMembershipScan(c.Owners.MembershipScan); err != nil {
+		return err
+	}
+	if err := validateDco(c.Dco); err != nil {
+		return err
+	}
+	if err := validateMilestoneApplier(c.MilestoneApplier); err != nil {
+		return err
+	}
+	if err := validateReleaseNote(c.ReleaseNote); err != nil {
+		return err
+	}
+	if err := validateBugzilla(c.Bugzilla); err != nil {
+		return err
+	}
 	if err := validateRepoDupes(c.Approve); err != nil {
 		return err
 	}
@@ -1440,6 +2108,42 @@ func (c *Configuration) Validate() error {
 	return nil
 }
 
+func validateBugzilla(b Bugzilla) error {
+	var errs []error
+	validateBranches := func(scope string, branches map[string]BugzillaBranchOptions) {
+		for branch, options := range branches {
+			for name, flags := range map[string]*[]BugzillaFlag{"flags_after_validation": options.FlagsAfterValidation, "flags_after_merge": options.FlagsAfterMerge} {
+				if flags == nil {
+					continue
+				}
+				for _, flag := range *flags {
+					if flag.Name == "" {
+						errs = append(errs, fmt.Errorf("bugzilla options for %s branch %s: %s: flag name must not be empty", scope, branch, name))
+					}
+					if flag.Status != "+" && flag.Status != "-" && flag.Status != "?" {
+						errs = append(errs, fmt.Errorf("bugzilla options for %s branch %s: %s: status of flag %q must be one of +, - or ?, not %q", scope, branch, name, flag.Name, flag.Status))
+					}
+				}
+			}
+			if options.BugLabels != nil {
+				for match, label := range *options.BugLabels {
+					if match == "" || label == "" {
+						errs = append(errs, fmt.Errorf("bugzilla options for %s branch %s: bug_labels: keyword or flag %q and label %q must not be empty", scope, branch, match, label))
+					}
+				}
+			}
+		}
+	}
+	validateBranches("default", b.Default)
+	for org, orgOptions := range b.Orgs {
+		validateBranches(org, orgOptions.Default)
+		for repo, repoOptions := range orgOptions.Repos {
+			validateBranches(org+"/"+repo, repoOptions.Branches)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 type ListableRepos interface {
 	getRepos() []string
 }
@@ -1582,6 +2286,19 @@ func (s *BugzillaBugState) Matches(bug *bugzilla.Bug) bool {
 	return true
 }
 
+// BugzillaFlag describes a flag that the Bugzilla plugin sets on bugs, for
+// example the `qe_test_coverage+` flag.
+type BugzillaFlag struct {
+	Name string `json:"name"`
+	// Status is one of +, - or ?.
+	Status string `json:"status"`
+}
+
+// String converts a Bugzilla flag into its usual notation, e.g. qe_test_coverage+
+func (f BugzillaFlag) String() string {
+	return f.Name + f.Status
+}
+
 // BugzillaBranchOptions describes how to check if a Bugzilla bug is valid or not.
 //
 // Note on `Status` vs `State` fields: `State` fields implement a superset of
@@ -1598,6 +2315,12 @@ type BugzillaBranchOptions struct {
 	// EnableBackporting enables functionality to create new backport bugs for
 	// cherrypick PRs created by the cherrypick plugin that reference bugzilla bugs.
 	EnableBackporting *bool `json:"enable_backporting,omitempty"`
+	// BackportChainComment determines whether a comment showing the backport
+	// chain of a bug (the original bug, its clones per release, their pull
+	// requests and merge states) is posted on the pull requests of the original
+	// bug when a backport bug is created, and kept up to date as the pull
+	// requests of the chain are linked and merged.
+	BackportChainComment *bool `json:"backport_chain_comment,omitempty"`
 
 	// ValidateByDefault determines whether a validation check is run for all pull
 	// requests by default
@@ -1657,6 +2380,54 @@ type BugzillaBranchOptions struct {
 	// link to in PRs. If a bug is part of a group that is not in this list, the bugzilla
 	// plugin will not link the bug to the PR.
 	AllowedGroups []string `json:"allowed_groups,omitempty"`
+	// AllowPrivateBugs determines whether a pull request referencing a bug that
+	// the bot is not permitted to access may be deemed valid. Such bugs cannot
+	// be validated, so they are deemed invalid unless this is set.
+	AllowPrivateBugs *bool `json:"allow_private_bugs,omitempty"`
+	// PrivateBugLabel is the label added to pull requests referencing a bug that
+	// the bot is not permitted to access, e.g. `bugzilla/private-bug`. No label is
+	// added unless this is set.
+	PrivateBugLabel *string `json:"private_bug_label,omitempty"`
+
+	// ExemptionLabel is the label added to a pull request when a collaborator
+	// comments `/bugzilla skip <reason>`. Pull requests carrying this label are
+	// considered to have a valid bug without one being referenced, which is
+	// meant for emergency fixes where filing a bug first is impractical. The
+	// skip command is disabled unless this is set.
+	ExemptionLabel *string `json:"exemption_label,omitempty"`
+
+	// AttachDiffOnMerge determines whether the diff of a pull request is attached
+	// to the referenced bug when the pull request merges. If the diff is too large
+	// for Bugzilla, a summary of the test results of the merged commit is attached
+	// instead.
+	AttachDiffOnMerge *bool `json:"attach_diff_on_merge,omitempty"`
+
+	// RequireQAContact determines whether a bug needs a QA contact whose public
+	// email resolves to exactly one GitHub user to be valid.
+	RequireQAContact *bool `json:"require_qa_contact,omitempty"`
+
+	// FlagsAfterValidation are the flags which will be set on the bug after it
+	// was deemed valid and linked to a PR.
+	FlagsAfterValidation *[]BugzillaFlag `json:"flags_after_validation,omitempty"`
+	// FlagsAfterMerge are the flags which will be set on the bug after all pull
+	// requests in the external bug tracker have been merged.
+	FlagsAfterMerge *[]BugzillaFlag `json:"flags_after_merge,omitempty"`
+
+	// CommentOnLink determines whether a comment summarizing the pull request is
+	// posted on the bug when the pull request is added to its external bugs, and
+	// another one listing the merged commits and their CI status when the pull
+	// request merges.
+	// Requires AddExternalLink.
+	CommentOnLink *bool `json:"comment_on_link,omitempty"`
+	// DeckURL is the root URL of Deck, which is used to link the history of the
+	// pull request in the comments on the bug.
+	DeckURL *string `json:"deck_url,omitempty"`
+
+	// BugLabels maps keywords (e.g. TestBlocker) and flags with their status
+	// (e.g. blocker+) of the referenced bug to labels which are added to the pull
+	// request during validation. The labels are removed again once the bug no
+	// longer has the keyword or flag.
+	BugLabels *map[string]string `json:"bug_labels,omitempty"`
 }
 
 type BugzillaBugStateSet map[BugzillaBugState]interface{}
@@ -1823,6 +2594,42 @@ func ResolveBugzillaOptions(parent, child BugzillaBranchOptions) BugzillaBranchO
 		if parent.AllowedGroups != nil {
 			output.AllowedGroups = sets.List(sets.New[string](output.AllowedGroups...).Insert(parent.AllowedGroups...))
 		}
+		if parent.ExemptionLabel != nil {
+			output.ExemptionLabel = parent.ExemptionLabel
+		}
+		if parent.AttachDiffOnMerge != nil {
+			output.AttachDiffOnMerge = parent.AttachDiffOnMerge
+		}
+		if parent.RequireQAContact != nil {
+			output.RequireQAContact = parent.RequireQAContact
+		}
+		if parent.FlagsAfterValidation != nil {
+			output.FlagsAfterValidation = parent.FlagsAfterValidation
+		}
+		if parent.FlagsAfterMerge != nil {
+			output.FlagsAfterMerge = parent.FlagsAfterMerge
+		}
+		if parent.CommentOnLink != nil {
+			output.CommentOnLink = parent.CommentOnLink
+		}
+		if parent.EnableBackporting != nil {
+			output.EnableBackporting = parent.EnableBackporting
+		}
+		if parent.AllowPrivateBugs != nil {
+			output.AllowPrivateBugs = parent.AllowPrivateBugs
+		}
+		if parent.PrivateBugLabel != nil {
+			output.PrivateBugLabel = parent.PrivateBugLabel
+		}
+		if parent.BackportChainComment != nil {
+			output.BackportChainComment = parent.BackportChainComment
+		}
+		if parent.DeckURL != nil {
+			output.DeckURL = parent.DeckURL
+		}
+		if parent.BugLabels != nil {
+			output.BugLabels = parent.BugLabels
+		}
 	}
 
 	// override with the child
@@ -1899,6 +2706,42 @@ func ResolveBugzillaOptions(parent, child BugzillaBranchOptions) BugzillaBranchO
 	if child.AllowedGroups != nil {
 		output.AllowedGroups = sets.List(sets.New[string](output.AllowedGroups...).Insert(child.AllowedGroups...))
 	}
+	if child.ExemptionLabel != nil {
+		output.ExemptionLabel = child.ExemptionLabel
+	}
+	if child.AttachDiffOnMerge != nil {
+		output.AttachDiffOnMerge = child.AttachDiffOnMerge
+	}
+	if child.RequireQAContact != nil {
+		output.RequireQAContact = child.RequireQAContact
+	}
+	if child.FlagsAfterValidation != nil {
+		output.FlagsAfterValidation = child.FlagsAfterValidation
+	}
+	if child.FlagsAfterMerge != nil {
+		output.FlagsAfterMerge = child.FlagsAfterMerge
+	}
+	if child.CommentOnLink != nil {
+		output.CommentOnLink = child.CommentOnLink
+	}
+	if child.EnableBackporting != nil {
+		output.EnableBackporting = child.EnableBackporting
+	}
+	if child.AllowPrivateBugs != nil {
+		output.AllowPrivateBugs = child.AllowPrivateBugs
+	}
+	if child.PrivateBugLabel != nil {
+		output.PrivateBugLabel = child.PrivateBugLabel
+	}
+	if child.BackportChainComment != nil {
+		output.BackportChainComment = child.BackportChainComment
+	}
+	if child.DeckURL != nil {
+		output.DeckURL = child.DeckURL
+	}
+	if child.BugLabels != nil {
+		output.BugLabels = child.BugLabels
+	}
 
 	// Status fields should not be used anywhere now when they were mirrored to states
 	output.Statuses = nil
@@ -2016,12 +2859,24 @@ type Override struct {
 	AllowedGitHubTeams map[string][]string `json:"allowed_github_teams,omitempty"`
 }
 
+// Skip holds options for the skip plugin
+type Skip struct {
+	// SkippableJobs is a map of orgs and/or repositories (eg "org" or "org/repo") to the names of
+	// required presubmits that users with write access may skip with `/skip job-name reason`.
+	SkippableJobs map[string][]string `json:"skippable_jobs,omitempty"`
+}
+
+// SkippableJobsFor returns the names of the required presubmits of a repo that may be skipped.
+func (s Skip) SkippableJobsFor(org, repo string) sets.Set[string] {
+	return sets.New[string](append(s.SkippableJobs[org], s.SkippableJobs[org+"/"+repo]...)...)
+}
+
 func (c *Configuration) mergeFrom(other *Configuration) error {
 	var errs []error
 
 	diff := cmp.Diff(other, &Configuration{Approve: other.Approve, Bugzilla: other.Bugzilla,
 		ExternalPlugins: other.ExternalPlugins, Label: Label{RestrictedLabels: other.Label.RestrictedLabels},
-		Lgtm: other.Lgtm, Plugins: other.Plugins, Triggers: other.Triggers, Welcome: other.Welcome},
+		Lgtm: other.Lgtm, Plugins: other.Plugins, RepoOptions: other.RepoOptions, Triggers: other.Triggers, Welcome: other.Welcome},
 		config.DefaultDiffOpts...)
 
 	if diff != "" {
@@ -2052,6 +2907,56 @@ func (c *Configuration) mergeFrom(other *Configuration) error {
 		errs = append(errs, fmt.Errorf("failed to merge .label from supplemental config: %w", err))
 	}
 
+	for orgRepo, options := range other.RepoOptions {
+		if _, exists := c.RepoOptions[orgRepo]; exists {
+			errs = append(errs, fmt.Errorf("found duplicate repo_options for %s", orgRepo))
+			continue
+		}
+		if c.RepoOptions == nil {
+			c.RepoOptions = map[string]RepoOptions{}
+		}
+		c.RepoOptions[orgRepo] = options
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// ReadExtensionConfig reads the plugin config of the config extension at dir,
+// the path of an extension source in a checkout of its repository. It returns
+// nil if the extension does not configure any plugins.
+func ReadExtensionConfig(dir string) ([]byte, *Configuration, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, config.ExtensionPluginsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	var c Configuration
+	if err := yaml.Unmarshal(raw, &c, yaml.DisallowUnknownFields); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal %s: %w", config.ExtensionPluginsFile, err)
+	}
+	return raw, &c, nil
+}
+
+// ValidateExtension validates the plugin config of the config extension of
+// an org. It may only enable plugins for the org and its repositories, and not
+// for ones that c already configures.
+func (c *Configuration) ValidateExtension(org string, extension *Configuration) error {
+	if diff := cmp.Diff(extension, &Configuration{Plugins: extension.Plugins}, config.DefaultDiffOpts...); diff != "" {
+		return fmt.Errorf("only 'plugins' may be set in config extensions, diff: %s", diff)
+	}
+
+	var errs []error
+	for orgOrRepo := range extension.Plugins {
+		if orgOrRepo != org && !strings.HasPrefix(orgOrRepo, org+"/") {
+			errs = append(errs, fmt.Errorf("plugins.%s is not allowed for org %s", orgOrRepo, org))
+			continue
+		}
+		if _, ok := c.Plugins[orgOrRepo]; ok {
+			errs = append(errs, fmt.Errorf("plugins.%s is already configured", orgOrRepo))
+		}
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
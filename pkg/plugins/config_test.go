@@ -17,8 +17,11 @@ limitations under the License.
 package plugins
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -275,6 +278,378 @@ func TestTriggerFor(t *testing.T) {
 	}
 }
 
+func TestLinkedIssueFor(t *testing.T) {
+	config := Configuration{
+		LinkedIssue: map[string]*LinkedIssue{
+			"*":        {ExemptLabels: []string{"global"}},
+			"org":      {ExemptLabels: []string{"org"}},
+			"org/repo": {ExemptLabels: []string{"repo"}},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		org, repo string
+		expected  string
+	}{
+		{
+			name:     "repo config",
+			org:      "org",
+			repo:     "repo",
+			expected: "repo",
+		},
+		{
+			name:     "org config",
+			org:      "org",
+			repo:     "other",
+			expected: "org",
+		},
+		{
+			name:     "global config",
+			org:      "other",
+			repo:     "other",
+			expected: "global",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := config.LinkedIssueFor(tc.org, tc.repo)
+			if diff := cmp.Diff([]string{tc.expected}, actual.ExemptLabels); diff != "" {
+				t.Errorf("unexpected exempt labels (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := validateLinkedIssue(map[string]*LinkedIssue{"org": {KindMapping: map[string]string{"Bug": "bug"}}}); err == nil {
+		t.Error("expected an error for a mapping to a non-kind label")
+	}
+}
+
+func TestSizeExcludedPathResFor(t *testing.T) {
+	config := Configuration{
+		Size: Size{
+			ExcludedPaths: map[string][]string{
+				"*":        {"^vendor/"},
+				"org":      {`\.pb\.go$`},
+				"org/repo": {"^generated/"},
+			},
+		},
+	}
+	if err := compileRegexpsAndDurations(&config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		org, repo string
+		expected  []string
+	}{
+		{
+			name:     "repo config",
+			org:      "org",
+			repo:     "repo",
+			expected: []string{"^vendor/", `\.pb\.go$`, "^generated/"},
+		},
+		{
+			name:     "org config",
+			org:      "org",
+			repo:     "other",
+			expected: []string{"^vendor/", `\.pb\.go$`},
+		},
+		{
+			name:     "global config",
+			org:      "other",
+			repo:     "other",
+			expected: []string{"^vendor/"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for _, re := range config.Size.ExcludedPathResFor(tc.org, tc.repo) {
+				actual = append(actual, re.String())
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected excluded paths (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	config.Size.ExcludedPaths = map[string][]string{"org": {"("}}
+	if err := compileRegexpsAndDurations(&config); err == nil {
+		t.Error("expected an error for an invalid excluded path")
+	}
+}
+
+func TestArtifactGateFor(t *testing.T) {
+	config := Configuration{
+		ArtifactGate: map[string]*ArtifactGate{
+			"org":      {Jobs: []string{"org"}},
+			"org/repo": {Jobs: []string{"repo"}},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		org, repo string
+		expected  []string
+	}{
+		{
+			name:     "repo config",
+			org:      "org",
+			repo:     "repo",
+			expected: []string{"repo"},
+		},
+		{
+			name:     "org config",
+			org:      "org",
+			repo:     "other",
+			expected: []string{"org"},
+		},
+		{
+			name: "no config",
+			org:  "other",
+			repo: "other",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			if cfg := config.ArtifactGateFor(tc.org, tc.repo); cfg != nil {
+				actual = cfg.Jobs
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateArtifactGate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      *ArtifactGate
+		expectedErr bool
+	}{
+		{
+			name:   "valid config",
+			config: &ArtifactGate{Jobs: []string{"build"}, PolicyURL: "https://policy.example.com/verify"},
+		},
+		{
+			name:        "no jobs",
+			config:      &ArtifactGate{PolicyURL: "https://policy.example.com/verify"},
+			expectedErr: true,
+		},
+		{
+			name:        "no policy url",
+			config:      &ArtifactGate{Jobs: []string{"build"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid policy url",
+			config:      &ArtifactGate{Jobs: []string{"build"}, PolicyURL: "policy"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateArtifactGate(map[string]*ArtifactGate{"org": tc.config})
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateHold(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      *Hold
+		expectedErr bool
+	}{
+		{
+			name:   "valid config",
+			config: &Hold{RiskyPaths: []string{"vendor/", "*/migration/*"}, ApproversAlias: "db-approvers"},
+		},
+		{
+			name:   "no risky paths",
+			config: &Hold{},
+		},
+		{
+			name:        "no approvers alias",
+			config:      &Hold{RiskyPaths: []string{"vendor/"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid pattern",
+			config:      &Hold{RiskyPaths: []string{"[migration"}, ApproversAlias: "db-approvers"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHold(map[string]*Hold{"org": tc.config})
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateOwnersMembershipScan(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      *OwnersMembershipScan
+		expectedErr bool
+	}{
+		{
+			name:   "valid config",
+			config: &OwnersMembershipScan{Repos: []string{"org/repo"}, IntervalDuration: time.Hour},
+		},
+		{
+			name:        "repo without org",
+			config:      &OwnersMembershipScan{Repos: []string{"repo"}, IntervalDuration: time.Hour},
+			expectedErr: true,
+		},
+		{
+			name:        "no interval",
+			config:      &OwnersMembershipScan{Repos: []string{"org/repo"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateOwnersMembershipScan(tc.config)
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateDco(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      *Dco
+		expectedErr bool
+	}{
+		{
+			name:   "signoff only",
+			config: &Dco{},
+		},
+		{
+			name:   "valid CLA config",
+			config: &Dco{CLA: &DcoCLA{URL: "https://cla.example.com/check", SignURL: "https://cla.example.com/sign"}},
+		},
+		{
+			name:        "CLA without url",
+			config:      &Dco{CLA: &DcoCLA{SignURL: "https://cla.example.com/sign"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid sign url",
+			config:      &Dco{CLA: &DcoCLA{URL: "https://cla.example.com/check", SignURL: "sign here"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDco(map[string]*Dco{"org": tc.config})
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateMilestoneApplier(t *testing.T) {
+	if err := validateMilestoneApplier(map[string]BranchToMilestone{"org/repo": {"release-1.19": "v1.19", "c++": "v1", `/release-(\d+\.\d+)/`: "v$1"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateMilestoneApplier(map[string]BranchToMilestone{"org/repo": {"/release-(1.19/": "v1.19"}}); err == nil {
+		t.Error("expected an error for an invalid regular expression")
+	}
+}
+
+func TestValidateReleaseNote(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      *ReleaseNote
+		expectedErr bool
+	}{
+		{
+			name: "valid config",
+			config: &ReleaseNote{Categories: []ReleaseNoteCategory{
+				{Name: "kind", Values: []string{"feature", "bug"}, Required: true, LabelPrefix: "kind/"},
+				{Name: "area"},
+			}},
+		},
+		{
+			name:        "category without name",
+			config:      &ReleaseNote{Categories: []ReleaseNoteCategory{{Values: []string{"feature"}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "action-required category",
+			config:      &ReleaseNote{Categories: []ReleaseNoteCategory{{Name: "action-required"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate category",
+			config:      &ReleaseNote{Categories: []ReleaseNoteCategory{{Name: "kind"}, {Name: "kind"}}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateReleaseNote(map[string]*ReleaseNote{"org": tc.config})
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateBugzilla(t *testing.T) {
+	testCases := []struct {
+		name        string
+		flags       []BugzillaFlag
+		labels      map[string]string
+		expectedErr bool
+	}{
+		{
+			name:  "valid flags",
+			flags: []BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}, {Name: "blocker", Status: "-"}, {Name: "requires_doc_text", Status: "?"}},
+		},
+		{
+			name:        "flag without name",
+			flags:       []BugzillaFlag{{Status: "+"}},
+			expectedErr: true,
+		},
+		{
+			name:        "flag with invalid status",
+			flags:       []BugzillaFlag{{Name: "blocker", Status: "X"}},
+			expectedErr: true,
+		},
+		{
+			name:        "bug label without label",
+			labels:      map[string]string{"TestBlocker": ""},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Bugzilla{Orgs: map[string]BugzillaOrgOptions{"org": {Repos: map[string]BugzillaRepoOptions{"repo": {Branches: map[string]BugzillaBranchOptions{
+				"master": {FlagsAfterMerge: &tc.flags, BugLabels: &tc.labels},
+			}}}}}}
+			err := validateBugzilla(config)
+			if err != nil != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
 func TestSetApproveDefaults(t *testing.T) {
 	c := &Configuration{
 		Approve: []Approve{
@@ -441,6 +816,139 @@ func TestSetTriggerDefaults(t *testing.T) {
 	}
 }
 
+func TestValidateTrigger(t *testing.T) {
+	testCases := []struct {
+		name        string
+		triggers    []Trigger
+		expectedErr string
+	}{
+		{
+			name:     "no retrigger limits",
+			triggers: []Trigger{{Repos: []string{"org"}}},
+		},
+		{
+			name:     "valid retrigger limits",
+			triggers: []Trigger{{Repos: []string{"org"}, RetriggerLimits: map[string]int{"pull-e2e": 3}}},
+		},
+		{
+			name:        "retrigger limit below one",
+			triggers:    []Trigger{{Repos: []string{"org"}, RetriggerLimits: map[string]int{"pull-e2e": 0}}},
+			expectedErr: `retrigger limit for job "pull-e2e" in trigger for [org] must be at least 1, got 0`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateTrigger(tc.triggers); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestValidatePluginDispatch(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dispatch    map[string]PluginDispatch
+		expectedErr string
+	}{
+		{
+			name:     "valid dispatch",
+			dispatch: map[string]PluginDispatch{"bugzilla": {MaxConcurrency: 2, Events: []string{"pull_request"}}},
+		},
+		{
+			name:        "negative max concurrency",
+			dispatch:    map[string]PluginDispatch{"bugzilla": {MaxConcurrency: -1}},
+			expectedErr: "plugin_dispatch.bugzilla.max_concurrency must not be negative",
+		},
+		{
+			name:        "empty event type",
+			dispatch:    map[string]PluginDispatch{"bugzilla": {Events: []string{""}}},
+			expectedErr: "plugin_dispatch.bugzilla.events must not contain empty event types",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validatePluginDispatch(tc.dispatch); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestPluginDispatchDispatches(t *testing.T) {
+	testCases := []struct {
+		name      string
+		dispatch  PluginDispatch
+		eventType string
+		expected  bool
+	}{
+		{
+			name:      "no filter",
+			eventType: "push",
+			expected:  true,
+		},
+		{
+			name:      "listed event type",
+			dispatch:  PluginDispatch{Events: []string{"issue_comment", "pull_request"}},
+			eventType: "pull_request",
+			expected:  true,
+		},
+		{
+			name:      "unlisted event type",
+			dispatch:  PluginDispatch{Events: []string{"issue_comment", "pull_request"}},
+			eventType: "push",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.dispatch.Dispatches(tc.eventType); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateLgtm(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lgtms       []Lgtm
+		expectedErr string
+	}{
+		{
+			name:  "no threshold",
+			lgtms: []Lgtm{{Repos: []string{"org"}}},
+		},
+		{
+			name:  "valid threshold",
+			lgtms: []Lgtm{{Repos: []string{"org"}, LgtmThreshold: 2}},
+		},
+		{
+			name:        "negative threshold",
+			lgtms:       []Lgtm{{Repos: []string{"org"}, LgtmThreshold: -1}},
+			expectedErr: "lgtm_threshold for [org] must not be negative, got -1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateLgtm(tc.lgtms); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
 func TestSetCherryPickUnapprovedDefaults(t *testing.T) {
 	defaultBranchRegexp := `^release-.*$`
 	defaultComment := `This PR is not for the master branch but does not have the ` + "`cherry-pick-approved`" + `  label. Adding the ` + "`do-not-merge/cherry-pick-not-approved`" + `  label.`
@@ -730,6 +1238,102 @@ func TestResolveBugzillaOptions(t *testing.T) {
 			child:    BugzillaBranchOptions{TargetRelease: &one},
 			expected: BugzillaBranchOptions{DependentBugTargetReleases: &[]string{one}, TargetRelease: &one, ExcludeDefaults: &yes},
 		},
+		{
+			name:     "parent exemption label is inherited by child",
+			parent:   BugzillaBranchOptions{ExemptionLabel: &one},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{TargetRelease: &one, ExemptionLabel: &one},
+		},
+		{
+			name:     "child exemption label overrides parent",
+			parent:   BugzillaBranchOptions{ExemptionLabel: &one},
+			child:    BugzillaBranchOptions{ExemptionLabel: &two},
+			expected: BugzillaBranchOptions{ExemptionLabel: &two},
+		},
+		{
+			name:     "parent attach diff on merge is inherited by child",
+			parent:   BugzillaBranchOptions{AttachDiffOnMerge: &yes},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{TargetRelease: &one, AttachDiffOnMerge: &yes},
+		},
+		{
+			name:     "child attach diff on merge overrides parent",
+			parent:   BugzillaBranchOptions{AttachDiffOnMerge: &yes},
+			child:    BugzillaBranchOptions{AttachDiffOnMerge: &no},
+			expected: BugzillaBranchOptions{AttachDiffOnMerge: &no},
+		},
+		{
+			name:     "parent require qa contact is inherited by child",
+			parent:   BugzillaBranchOptions{RequireQAContact: &yes},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{TargetRelease: &one, RequireQAContact: &yes},
+		},
+		{
+			name:     "child require qa contact overrides parent",
+			parent:   BugzillaBranchOptions{RequireQAContact: &yes},
+			child:    BugzillaBranchOptions{RequireQAContact: &no},
+			expected: BugzillaBranchOptions{RequireQAContact: &no},
+		},
+		{
+			name:     "parent flags are inherited by child",
+			parent:   BugzillaBranchOptions{FlagsAfterValidation: &[]BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}}, FlagsAfterMerge: &[]BugzillaFlag{{Name: "requires_doc_text", Status: "?"}}},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{TargetRelease: &one, FlagsAfterValidation: &[]BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}}, FlagsAfterMerge: &[]BugzillaFlag{{Name: "requires_doc_text", Status: "?"}}},
+		},
+		{
+			name:     "child flags override parent",
+			parent:   BugzillaBranchOptions{FlagsAfterValidation: &[]BugzillaFlag{{Name: "qe_test_coverage", Status: "+"}}, FlagsAfterMerge: &[]BugzillaFlag{{Name: "requires_doc_text", Status: "?"}}},
+			child:    BugzillaBranchOptions{FlagsAfterValidation: &[]BugzillaFlag{}, FlagsAfterMerge: &[]BugzillaFlag{{Name: "requires_doc_text", Status: "-"}}},
+			expected: BugzillaBranchOptions{FlagsAfterValidation: &[]BugzillaFlag{}, FlagsAfterMerge: &[]BugzillaFlag{{Name: "requires_doc_text", Status: "-"}}},
+		},
+		{
+			name:     "parent comment on link and deck url are inherited by child",
+			parent:   BugzillaBranchOptions{CommentOnLink: &yes, DeckURL: &one},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{TargetRelease: &one, CommentOnLink: &yes, DeckURL: &one},
+		},
+		{
+			name:     "child comment on link and deck url override parent",
+			parent:   BugzillaBranchOptions{CommentOnLink: &yes, DeckURL: &one},
+			child:    BugzillaBranchOptions{CommentOnLink: &no, DeckURL: &two},
+			expected: BugzillaBranchOptions{CommentOnLink: &no, DeckURL: &two},
+		},
+		{
+			name:     "child private bug options override parent",
+			parent:   BugzillaBranchOptions{AllowPrivateBugs: &yes, PrivateBugLabel: &one},
+			child:    BugzillaBranchOptions{AllowPrivateBugs: &no, PrivateBugLabel: &two},
+			expected: BugzillaBranchOptions{AllowPrivateBugs: &no, PrivateBugLabel: &two},
+		},
+		{
+			name:     "child enable backporting overrides parent",
+			parent:   BugzillaBranchOptions{EnableBackporting: &yes},
+			child:    BugzillaBranchOptions{EnableBackporting: &no},
+			expected: BugzillaBranchOptions{EnableBackporting: &no},
+		},
+		{
+			name:     "parent enable backporting is inherited by child",
+			parent:   BugzillaBranchOptions{EnableBackporting: &yes},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{EnableBackporting: &yes, TargetRelease: &one},
+		},
+		{
+			name:     "child backport chain comment overrides parent",
+			parent:   BugzillaBranchOptions{BackportChainComment: &yes},
+			child:    BugzillaBranchOptions{BackportChainComment: &no},
+			expected: BugzillaBranchOptions{BackportChainComment: &no},
+		},
+		{
+			name:     "parent bug labels are inherited by child",
+			parent:   BugzillaBranchOptions{BugLabels: &map[string]string{"TestBlocker": "kind/release-blocker"}},
+			child:    BugzillaBranchOptions{TargetRelease: &one},
+			expected: BugzillaBranchOptions{TargetRelease: &one, BugLabels: &map[string]string{"TestBlocker": "kind/release-blocker"}},
+		},
+		{
+			name:     "child bug labels override parent",
+			parent:   BugzillaBranchOptions{BugLabels: &map[string]string{"TestBlocker": "kind/release-blocker"}},
+			child:    BugzillaBranchOptions{BugLabels: &map[string]string{"blocker+": "kind/release-blocker"}},
+			expected: BugzillaBranchOptions{BugLabels: &map[string]string{"blocker+": "kind/release-blocker"}},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -1649,7 +2253,12 @@ func TestConfigMergingProperties(t *testing.T) {
 	seed := time.Now().UnixNano()
 	// Print the seed so failures can easily be reproduced
 	t.Logf("Seed: %d", seed)
-	fuzzer := fuzz.NewWithSeed(seed)
+	// The fuzzer can neither fill the interface values of RepoOptions nor the
+	// recursive RepoConfigs, which are resolved from RepoOptions anyways.
+	fuzzer := fuzz.NewWithSeed(seed).Funcs(
+		func(*RepoOptions, fuzz.Continue) {},
+		func(*map[string]*Configuration, fuzz.Continue) {},
+	)
 
 	for _, tc := range testCases {
 		tc := tc
@@ -1728,6 +2337,60 @@ func TestPluginsMergeFrom(t *testing.T) {
 	}
 }
 
+func TestValidateExtension(t *testing.T) {
+	t.Parallel()
+	central := &Configuration{Plugins: Plugins{"org/central": OrgPlugins{Plugins: []string{"wip"}}}}
+	testCases := []struct {
+		name string
+
+		extension *Configuration
+
+		expectedErrMsg string
+	}{
+		{
+			name: "Plugins of the org and its repos are valid",
+
+			extension: &Configuration{Plugins: Plugins{
+				"org":        OrgPlugins{Plugins: []string{"lgtm"}},
+				"org/repo-1": OrgPlugins{Plugins: []string{"wip"}},
+			}},
+		},
+		{
+			name: "Plugins of another org are invalid",
+
+			extension: &Configuration{Plugins: Plugins{"other/repo-1": OrgPlugins{Plugins: []string{"wip"}}}},
+
+			expectedErrMsg: "plugins.other/repo-1 is not allowed for org org",
+		},
+		{
+			name: "Plugins configured centrally are invalid",
+
+			extension: &Configuration{Plugins: Plugins{"org/central": OrgPlugins{Plugins: []string{"lgtm"}}}},
+
+			expectedErrMsg: "plugins.org/central is already configured",
+		},
+		{
+			name: "Plugin config other than plugins is invalid",
+
+			extension: &Configuration{Owners: Owners{MDYAMLRepos: []string{"org/repo-1"}}},
+
+			expectedErrMsg: "only 'plugins' may be set in config extensions",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := central.ValidateExtension("org", tc.extension); err != nil {
+				errMsg = err.Error()
+			}
+			if !strings.HasPrefix(errMsg, tc.expectedErrMsg) || (tc.expectedErrMsg == "") != (errMsg == "") {
+				t.Errorf("expected error message %q, got %q", tc.expectedErrMsg, errMsg)
+			}
+		})
+	}
+}
+
 func TestBugzillaMergeFrom(t *testing.T) {
 	t.Parallel()
 
@@ -2221,7 +2884,12 @@ func TestHasConfigFor(t *testing.T) {
 	seed := time.Now().UnixNano()
 	// Print the seed so failures can easily be reproduced
 	t.Logf("Seed: %d", seed)
-	fuzzer := fuzz.NewWithSeed(seed)
+	// The fuzzer can neither fill the interface values of RepoOptions nor the
+	// recursive RepoConfigs, which are resolved from RepoOptions anyways.
+	fuzzer := fuzz.NewWithSeed(seed).Funcs(
+		func(*RepoOptions, fuzz.Continue) {},
+		func(*map[string]*Configuration, fuzz.Continue) {},
+	)
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2416,3 +3084,75 @@ func TestValidatePluginsDupes(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveRepoOptionsRoundTrip verifies that all plugin options survive
+// the marshalling with which repo options are resolved.
+func TestResolveRepoOptionsRoundTrip(t *testing.T) {
+	raw, err := os.ReadFile("plugin-config-documented.yaml")
+	if err != nil {
+		t.Fatalf("failed to read documented config: %v", err)
+	}
+	var documented Configuration
+	if err := yaml.Unmarshal(raw, &documented); err != nil {
+		t.Fatalf("failed to unmarshal documented config: %v", err)
+	}
+	marshalled, err := json.Marshal(&documented)
+	if err != nil {
+		t.Fatalf("failed to marshal documented config: %v", err)
+	}
+	var roundTripped Configuration
+	if err := yaml.Unmarshal(marshalled, &roundTripped, yaml.DisallowUnknownFields); err != nil {
+		t.Fatalf("failed to unmarshal marshalled config: %v", err)
+	}
+	if diff := cmp.Diff(&documented, &roundTripped); diff != "" {
+		t.Errorf("config differs after the round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestBlunderbussLoadTimeZones(t *testing.T) {
+	testcases := []struct {
+		name        string
+		content     string
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			name:     "valid time zones",
+			content:  "Alice: Europe/Berlin\nbob: UTC\n",
+			expected: map[string]string{"alice": "Europe/Berlin", "bob": "UTC"},
+		},
+		{
+			name:        "invalid time zone",
+			content:     "alice: Mars/Olympus_Mons\n",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid yaml",
+			content:     "- alice\n",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "time-zones.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("failed to write time zones: %v", err)
+			}
+			b := Blunderbuss{TimeZonesFile: path}
+			err := b.loadTimeZones()
+			if err != nil != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			got := map[string]string{}
+			for login, loc := range b.TimeZones {
+				got[login] = loc.String()
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("time zones differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dco
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// claVerifier looks up whether the author of a commit signed the CLA.
+type claVerifier interface {
+	signed(login, email string) (bool, error)
+}
+
+// claResponse is the response of the CLA service for one author.
+type claResponse struct {
+	Signed bool `json:"signed"`
+}
+
+// httpCLAVerifier queries a CLA service over HTTP and remembers the authors
+// who signed the CLA for a while.
+type httpCLAVerifier struct {
+	client   *http.Client
+	endpoint string
+	ttl      time.Duration
+	cache    *claCache
+}
+
+// claCache remembers the authors who signed the CLA until their entry
+// expires. It is shared by all repos, keyed by the endpoint of the service.
+type claCache struct {
+	lock    sync.Mutex
+	now     func() time.Time
+	expires map[string]time.Time
+}
+
+var (
+	sharedCLACache = &claCache{now: time.Now, expires: map[string]time.Time{}}
+	claHTTPClient  = &http.Client{Timeout: 10 * time.Second}
+)
+
+func (c *claCache) has(key string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	expires, ok := c.expires[key]
+	if ok && c.now().After(expires) {
+		delete(c.expires, key)
+		return false
+	}
+	return ok
+}
+
+func (c *claCache) add(key string, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expires[key] = c.now().Add(ttl)
+}
+
+func newCLAVerifier(cfg plugins.DcoCLA) claVerifier {
+	return &httpCLAVerifier{
+		client:   claHTTPClient,
+		endpoint: cfg.URL,
+		ttl:      cfg.CacheTTLDuration,
+		cache:    sharedCLACache,
+	}
+}
+
+func (v *httpCLAVerifier) signed(login, email string) (bool, error) {
+	key := fmt.Sprintf("%s|%s|%s", v.endpoint, github.NormLogin(login), email)
+	if v.cache.has(key) {
+		return true, nil
+	}
+
+	u, err := url.Parse(v.endpoint)
+	if err != nil {
+		return false, fmt.Errorf("invalid CLA service url: %w", err)
+	}
+	query := u.Query()
+	query.Set("login", login)
+	query.Set("email", email)
+	u.RawQuery = query.Encode()
+
+	resp, err := v.client.Get(u.String())
+	if err != nil {
+		return false, fmt.Errorf("error querying CLA service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("CLA service responded with status %d", resp.StatusCode)
+	}
+	var response claResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return false, fmt.Errorf("error decoding CLA service response: %w", err)
+	}
+	if response.Signed {
+		v.cache.add(key, v.ttl)
+	}
+	return response.Signed, nil
+}
+
+// checkCLA returns the commits whose authors did not sign the CLA. Every
+// author is only looked up once.
+func checkCLA(v claVerifier, l *logrus.Entry, commits []github.RepositoryCommit) ([]github.RepositoryCommit, error) {
+	signed := map[string]bool{}
+	var commitsMissingCLA []github.RepositoryCommit
+	for _, commit := range commits {
+		if len(commit.Parents) > 1 {
+			continue
+		}
+		login, email := commit.Author.Login, commit.Commit.Author.Email
+		author := login + "|" + email
+		ok, checked := signed[author]
+		if !checked {
+			var err error
+			if ok, err = v.signed(login, email); err != nil {
+				return nil, err
+			}
+			signed[author] = ok
+		}
+		if !ok {
+			commitsMissingCLA = append(commitsMissingCLA, commit)
+		}
+	}
+	l.Debugf("Commits in PR whose authors did not sign the CLA: %d", len(commitsMissingCLA))
+	return commitsMissingCLA, nil
+}
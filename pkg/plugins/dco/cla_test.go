@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dco
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// fakeCLAService serves the CLA service API for the given signers and counts
+// the lookups.
+func fakeCLAService(signers ...string) (*httptest.Server, *int) {
+	lookups := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*lookups++
+		signed := false
+		for _, signer := range signers {
+			if r.URL.Query().Get("login") == signer {
+				signed = true
+			}
+		}
+		fmt.Fprintf(w, `{"signed": %t}`, signed)
+	}))
+	return server, lookups
+}
+
+func commitBy(sha, login, message string) github.RepositoryCommit {
+	return github.RepositoryCommit{SHA: sha, Author: github.User{Login: login}, Commit: github.GitCommit{Message: message}}
+}
+
+func TestHTTPCLAVerifierCaches(t *testing.T) {
+	server, lookups := fakeCLAService("alice")
+	defer server.Close()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	v := &httpCLAVerifier{
+		client:   server.Client(),
+		endpoint: server.URL,
+		ttl:      time.Hour,
+		cache:    &claCache{now: func() time.Time { return now }, expires: map[string]time.Time{}},
+	}
+
+	for i, expected := range []struct {
+		login   string
+		signed  bool
+		lookups int
+	}{
+		{login: "alice", signed: true, lookups: 1},
+		{login: "alice", signed: true, lookups: 1},
+		{login: "bob", signed: false, lookups: 2},
+		{login: "bob", signed: false, lookups: 3},
+	} {
+		signed, err := v.signed(expected.login, "")
+		if err != nil {
+			t.Fatalf("lookup %d: unexpected error: %v", i, err)
+		}
+		if signed != expected.signed || *lookups != expected.lookups {
+			t.Errorf("lookup %d: expected signed %t after %d lookups, got %t after %d", i, expected.signed, expected.lookups, signed, *lookups)
+		}
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := v.signed("alice", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *lookups != 4 {
+		t.Errorf("expected an expired entry to be looked up again, got %d lookups", *lookups)
+	}
+}
+
+func TestHandleCLA(t *testing.T) {
+	server, _ := fakeCLAService("alice")
+	defer server.Close()
+
+	testCases := []struct {
+		name    string
+		cla     plugins.DcoCLA
+		commits []github.RepositoryCommit
+
+		expectedState       string
+		expectedDescription string
+		expectedTargetURL   string
+		expectedInComment   []string
+	}{
+		{
+			name:                "all authors signed the CLA",
+			cla:                 plugins.DcoCLA{SignURL: "https://cla.example.com/sign"},
+			commits:             []github.RepositoryCommit{commitBy("sha1", "alice", "no sign off")},
+			expectedState:       github.StatusSuccess,
+			expectedDescription: claContextMessageSuccess,
+			expectedTargetURL:   "https://github.com/org/repo/blob/master/CONTRIBUTING.md",
+		},
+		{
+			name:                "an author did not sign the CLA",
+			cla:                 plugins.DcoCLA{SignURL: "https://cla.example.com/sign"},
+			commits:             []github.RepositoryCommit{commitBy("sha1", "alice", "first"), commitBy("sha2", "bob", "second")},
+			expectedState:       github.StatusFailure,
+			expectedDescription: claContextMessageFailed,
+			expectedTargetURL:   "https://cla.example.com/sign",
+			expectedInComment:   []string{claMsgPruneMatch, "[here](https://cla.example.com/sign)", "[sha2](https://github.com/org/repo/commits/sha2) second"},
+		},
+		{
+			name:                "signed CLA but missing signoff",
+			cla:                 plugins.DcoCLA{RequireSignoff: true},
+			commits:             []github.RepositoryCommit{commitBy("sha1", "alice", "no sign off")},
+			expectedState:       github.StatusFailure,
+			expectedDescription: bothContextMessageFailed,
+			expectedTargetURL:   "https://github.com/org/repo/blob/master/CONTRIBUTING.md",
+			expectedInComment:   []string{dcoMsgPruneMatch, "[sha1](https://github.com/org/repo/commits/sha1) no sign off"},
+		},
+		{
+			name:                "signed CLA and signoff",
+			cla:                 plugins.DcoCLA{RequireSignoff: true},
+			commits:             []github.RepositoryCommit{commitBy("sha1", "alice", "fix\n\nSigned-off-by: Alice <alice@example.com>")},
+			expectedState:       github.StatusSuccess,
+			expectedDescription: bothContextMessageSuccess,
+			expectedTargetURL:   "https://github.com/org/repo/blob/master/CONTRIBUTING.md",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.CombinedStatuses = map[string]*github.CombinedStatus{"sha": {}}
+			fc.CreatedStatuses = make(map[string][]github.Status)
+			fc.CommitMap = map[string][]github.RepositoryCommit{"org/repo#3": tc.commits}
+			tc.cla.URL = server.URL
+			pr := github.PullRequest{Number: 3, Head: github.PullRequestBranch{SHA: "sha"}}
+
+			if err := handle(plugins.Dco{CLA: &tc.cla}, fc, &fakePruner{}, logrus.WithField("plugin", pluginName), "org", "repo", pr, true); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			statuses := fc.CreatedStatuses["sha"]
+			if len(statuses) != 1 {
+				t.Fatalf("expected one status, got %v", statuses)
+			}
+			if statuses[0].State != tc.expectedState || statuses[0].Description != tc.expectedDescription || statuses[0].TargetURL != tc.expectedTargetURL {
+				t.Errorf("expected status %q %q %q, got %q %q %q", tc.expectedState, tc.expectedDescription, tc.expectedTargetURL, statuses[0].State, statuses[0].Description, statuses[0].TargetURL)
+			}
+			if len(tc.expectedInComment) == 0 {
+				if len(fc.IssueCommentsAdded) != 0 {
+					t.Errorf("expected no comment, got %v", fc.IssueCommentsAdded)
+				}
+				return
+			}
+			if len(fc.IssueCommentsAdded) != 1 {
+				t.Fatalf("expected one comment, got %v", fc.IssueCommentsAdded)
+			}
+			for _, expected := range tc.expectedInComment {
+				if !strings.Contains(fc.IssueCommentsAdded[0], expected) {
+					t.Errorf("expected comment to contain %q, got:\n%s", expected, fc.IssueCommentsAdded[0])
+				}
+			}
+		})
+	}
+}
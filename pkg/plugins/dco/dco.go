@@ -32,10 +32,14 @@ import (
 )
 
 const (
-	pluginName               = "dco"
-	dcoContextName           = "dco"
-	dcoContextMessageFailed  = "Commits in PR missing Signed-off-by"
-	dcoContextMessageSuccess = "All commits have Signed-off-by"
+	pluginName                = "dco"
+	dcoContextName            = "dco"
+	dcoContextMessageFailed   = "Commits in PR missing Signed-off-by"
+	dcoContextMessageSuccess  = "All commits have Signed-off-by"
+	claContextMessageFailed   = "Commit authors in PR did not sign the CLA"
+	claContextMessageSuccess  = "All commit authors signed the CLA"
+	bothContextMessageFailed  = "Commits in PR missing Signed-off-by or a signed CLA"
+	bothContextMessageSuccess = "All commits have Signed-off-by and a signed CLA"
 
 	dcoYesLabel        = "dco-signoff: yes"
 	dcoNoLabel         = "dco-signoff: no"
@@ -54,6 +58,15 @@ Full details of the Developer Certificate of Origin can be found at [developerce
 
 %s
 </details>
+`
+	claMsgPruneMatch    = "Thanks for your pull request. Before we can look at it, the authors of its commits need to sign the CLA."
+	claNotSignedMessage = `Thanks for your pull request. Before we can look at it, the authors of its commits need to sign the CLA.
+
+:memo: **Please sign the CLA [here](%s)**, then comment ` + "`/check-dco`" + ` to check again.
+
+**The list of commits whose authors did not sign the CLA**:
+
+%s
 `
 )
 
@@ -74,6 +87,13 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		if opts.SkipDCOCheckForMembers || opts.SkipDCOCheckForCollaborators {
 			configInfo[repo.String()] = fmt.Sprintf("The trusted GitHub organization for this repository is %q.", repo)
 		}
+		if opts.CLA != nil {
+			info := fmt.Sprintf("Commit authors must sign the CLA verified by %s.", opts.CLA.URL)
+			if opts.CLA.RequireSignoff {
+				info += " Commits must also have Signed-off-by."
+			}
+			configInfo[repo.String()] = strings.TrimSpace(configInfo[repo.String()] + " " + info)
+		}
 	}
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
 		Dco: map[string]*plugins.Dco{
@@ -84,6 +104,12 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 				ContributingRepo:             "other-org/other-repo",
 				ContributingBranch:           "main",
 				ContributingPath:             "docs/CONTRIBUTING.md",
+				CLA: &plugins.DcoCLA{
+					URL:            "https://cla.example.com/api/check",
+					SignURL:        "https://cla.example.com/sign",
+					RequireSignoff: true,
+					CacheTTL:       "1h",
+				},
 			},
 		},
 	})
@@ -91,7 +117,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", pluginName)
 	}
 	pluginHelp := &pluginhelp.PluginHelp{
-		Description: "The dco plugin checks pull request commits for 'DCO sign off' and maintains the '" + dcoContextName + "' status context, as well as the 'dco' label.",
+		Description: "The dco plugin checks pull request commits for 'DCO sign off' or, if configured, that their authors signed a CLA, and maintains the '" + dcoContextName + "' status context, as well as the 'dco' label.",
 		Config:      configInfo,
 		Snippet:     yamlSnippet,
 	}
@@ -142,16 +168,10 @@ func filterTrustedUsers(gc gitHubClient, l *logrus.Entry, skipDCOCheckForCollabo
 	return untrustedCommits, nil
 }
 
-// checkCommitMessages will perform the actual DCO check by retrieving all
-// commits contained within the PR with the given number.
+// checkCommitMessages will perform the actual DCO check on all commits
+// contained within the PR.
 // *All* commits in the pull request *must* match the 'testRe' in order to pass.
-func checkCommitMessages(gc gitHubClient, l *logrus.Entry, org, repo string, number int) ([]github.RepositoryCommit, error) {
-	allCommits, err := gc.ListPullRequestCommits(org, repo, number)
-	if err != nil {
-		return nil, fmt.Errorf("error listing commits for pull request: %w", err)
-	}
-	l.Debugf("Found %d commits in PR", len(allCommits))
-
+func checkCommitMessages(l *logrus.Entry, allCommits []github.RepositoryCommit) []github.RepositoryCommit {
 	var commitsMissingDCO []github.RepositoryCommit
 	for _, commit := range allCommits {
 		isMerge := len(commit.Parents) > 1
@@ -161,7 +181,7 @@ func checkCommitMessages(gc gitHubClient, l *logrus.Entry, org, repo string, num
 	}
 
 	l.Debugf("Commits in PR missing DCO signoff: %d", len(commitsMissingDCO))
-	return commitsMissingDCO, nil
+	return commitsMissingDCO
 }
 
 // checkExistingStatus will retrieve the current status of the DCO context for
@@ -206,8 +226,19 @@ func checkExistingLabels(gc gitHubClient, l *logrus.Entry, org, repo string, num
 
 // takeAction will take appropriate action on the pull request according to its
 // current state.
-func takeAction(gc gitHubClient, cp commentPruner, l *logrus.Entry, org, repo string, pr github.PullRequest, commitsMissingDCO []github.RepositoryCommit, existingStatus, contributingUrl string, hasYesLabel, hasNoLabel, addComment bool) error {
-	signedOff := len(commitsMissingDCO) == 0
+func takeAction(gc gitHubClient, cp commentPruner, l *logrus.Entry, org, repo string, pr github.PullRequest, cla *plugins.DcoCLA, commitsMissingDCO, commitsMissingCLA []github.RepositoryCommit, existingStatus, contributingUrl string, hasYesLabel, hasNoLabel, addComment bool) error {
+	signedOff := len(commitsMissingDCO) == 0 && len(commitsMissingCLA) == 0
+	successMessage, failedMessage := dcoContextMessageSuccess, dcoContextMessageFailed
+	targetURL := contributingUrl
+	if cla != nil {
+		successMessage, failedMessage = claContextMessageSuccess, claContextMessageFailed
+		if cla.RequireSignoff {
+			successMessage, failedMessage = bothContextMessageSuccess, bothContextMessageFailed
+		}
+		if len(commitsMissingCLA) > 0 && cla.SignURL != "" {
+			targetURL = cla.SignURL
+		}
+	}
 
 	// handle the 'all commits signed off' case by adding appropriate labels
 	// TODO: clean-up old comments?
@@ -231,8 +262,8 @@ func takeAction(gc gitHubClient, cp commentPruner, l *logrus.Entry, org, repo st
 			if err := gc.CreateStatus(org, repo, pr.Head.SHA, github.Status{
 				Context:     dcoContextName,
 				State:       github.StatusSuccess,
-				TargetURL:   contributingUrl,
-				Description: dcoContextMessageSuccess,
+				TargetURL:   targetURL,
+				Description: successMessage,
 			}); err != nil {
 				return fmt.Errorf("error setting pull request status: %w", err)
 			}
@@ -262,8 +293,8 @@ func takeAction(gc gitHubClient, cp commentPruner, l *logrus.Entry, org, repo st
 		if err := gc.CreateStatus(org, repo, pr.Head.SHA, github.Status{
 			Context:     dcoContextName,
 			State:       github.StatusFailure,
-			TargetURL:   contributingUrl,
-			Description: dcoContextMessageFailed,
+			TargetURL:   targetURL,
+			Description: failedMessage,
 		}); err != nil {
 			return fmt.Errorf("error setting pull request status: %w", err)
 		}
@@ -274,7 +305,16 @@ func takeAction(gc gitHubClient, cp commentPruner, l *logrus.Entry, org, repo st
 		// failing commits
 		cp.PruneComments(shouldPrune(l))
 		l.Debugf("Commenting on PR to advise users of DCO check")
-		if err := gc.CreateComment(org, repo, pr.Number, fmt.Sprintf(dcoNotFoundMessage, contributingUrl, MarkdownSHAList(org, repo, commitsMissingDCO), plugins.AboutThisBot)); err != nil {
+		var comments []string
+		if len(commitsMissingCLA) > 0 {
+			comments = append(comments, fmt.Sprintf(claNotSignedMessage, targetURL, MarkdownSHAList(org, repo, commitsMissingCLA)))
+		}
+		if len(commitsMissingDCO) > 0 {
+			comments = append(comments, fmt.Sprintf(dcoNotFoundMessage, contributingUrl, MarkdownSHAList(org, repo, commitsMissingDCO), plugins.AboutThisBot))
+		} else {
+			comments = append(comments, fmt.Sprintf("<details>\n\n%s\n</details>\n", plugins.AboutThisBot))
+		}
+		if err := gc.CreateComment(org, repo, pr.Number, strings.Join(comments, "\n")); err != nil {
 			l.WithError(err).Warning("Could not create DCO not found comment.")
 		}
 	}
@@ -282,25 +322,40 @@ func takeAction(gc gitHubClient, cp commentPruner, l *logrus.Entry, org, repo st
 	return nil
 }
 
-// 1. Check should commit messages from trusted users be checked
-// 2. Check commit messages in the pull request for the sign-off string
-// 3. Check the existing status context value
-// 4. Check the existing PR labels
-// 5. If signed off, apply appropriate labels and status context.
-// 6. If not signed off, apply appropriate labels and status context and add a comment.
+//  1. Check should commit messages from trusted users be checked
+//  2. Check commit messages in the pull request for the sign-off string and,
+//     if configured, whether their authors signed the CLA
+//  3. Check the existing status context value
+//  4. Check the existing PR labels
+//  5. If signed off, apply appropriate labels and status context.
+//  6. If not signed off, apply appropriate labels and status context and add a comment.
 func handle(config plugins.Dco, gc gitHubClient, cp commentPruner, log *logrus.Entry, org, repo string, pr github.PullRequest, addComment bool) error {
 	l := log.WithField("pr", pr.Number)
 
-	commitsMissingDCO, err := checkCommitMessages(gc, l, org, repo, pr.Number)
+	allCommits, err := gc.ListPullRequestCommits(org, repo, pr.Number)
 	if err != nil {
 		l.WithError(err).Infof("Error running DCO check against commits in PR")
-		return err
+		return fmt.Errorf("error listing commits for pull request: %w", err)
+	}
+	l.Debugf("Found %d commits in PR", len(allCommits))
+
+	var commitsMissingDCO []github.RepositoryCommit
+	if config.CLA == nil || config.CLA.RequireSignoff {
+		commitsMissingDCO = checkCommitMessages(l, allCommits)
+		if config.SkipDCOCheckForMembers || config.SkipDCOCheckForCollaborators {
+			commitsMissingDCO, err = filterTrustedUsers(gc, l, config.SkipDCOCheckForCollaborators, config.TrustedApps, config.TrustedOrg, org, repo, commitsMissingDCO)
+			if err != nil {
+				l.WithError(err).Infof("Error running trusted org member check against commits in PR")
+				return err
+			}
+		}
 	}
 
-	if config.SkipDCOCheckForMembers || config.SkipDCOCheckForCollaborators {
-		commitsMissingDCO, err = filterTrustedUsers(gc, l, config.SkipDCOCheckForCollaborators, config.TrustedApps, config.TrustedOrg, org, repo, commitsMissingDCO)
+	var commitsMissingCLA []github.RepositoryCommit
+	if config.CLA != nil {
+		commitsMissingCLA, err = checkCLA(newCLAVerifier(*config.CLA), l, allCommits)
 		if err != nil {
-			l.WithError(err).Infof("Error running trusted org member check against commits in PR")
+			l.WithError(err).Infof("Error running CLA check against commits in PR")
 			return err
 		}
 	}
@@ -334,7 +389,7 @@ func handle(config plugins.Dco, gc gitHubClient, cp commentPruner, log *logrus.E
 
 	contributingUrl := fmt.Sprintf("https://github.com/%s/blob/%s/%s", contributingRepo, contributingBranch, contributingPath)
 
-	return takeAction(gc, cp, l, org, repo, pr, commitsMissingDCO, existingStatus, contributingUrl, hasYesLabel, hasNoLabel, addComment)
+	return takeAction(gc, cp, l, org, repo, pr, config.CLA, commitsMissingDCO, commitsMissingCLA, existingStatus, contributingUrl, hasYesLabel, hasNoLabel, addComment)
 }
 
 // MarkdownSHAList prints the list of commits in a markdown-friendly way.
@@ -363,7 +418,7 @@ func MarkdownSHAList(org, repo string, list []github.RepositoryCommit) string {
 // shouldPrune finds comments left by this plugin.
 func shouldPrune(log *logrus.Entry) func(github.IssueComment) bool {
 	return func(comment github.IssueComment) bool {
-		return strings.Contains(comment.Body, dcoMsgPruneMatch)
+		return strings.Contains(comment.Body, dcoMsgPruneMatch) || strings.Contains(comment.Body, claMsgPruneMatch)
 	}
 }
 
@@ -17,13 +17,17 @@ limitations under the License.
 // Package hold contains a plugin which will allow users to label their
 // own pull requests as not ready or ready for merge. The submit queue
 // will honor the label to ensure pull requests do not merge when it is
-// applied.
+// applied. Pull requests that change configured risky paths are held
+// automatically until a member of a designated OWNERS alias cancels the hold.
 package hold
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/prow/pkg/config"
@@ -31,6 +35,7 @@ import (
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/repoowners"
 )
 
 const (
@@ -43,22 +48,47 @@ var (
 	labelCancelRe = regexp.MustCompile(`(?mi)^/(remove-hold|hold\s+cancel|unhold)\s*$`)
 )
 
+// riskyPathsHoldBody identifies the comment left when a pull request is held
+// automatically, which marks the hold as one that only approvers may cancel.
+const riskyPathsHoldBody = "This PR changes paths that need extra care and was put on hold."
+
 type hasLabelFunc func(label string, issueLabels []github.Label) bool
 
 func init() {
 	plugins.RegisterGenericCommentHandler(PluginName, handleGenericComment, helpProvider)
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
 }
 
-func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
-	// The Config field is omitted because this plugin is not configurable.
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		cfg := config.HoldFor(repo.Org, repo.Repo)
+		if len(cfg.RiskyPaths) == 0 {
+			continue
+		}
+		configInfo[repo.String()] = fmt.Sprintf("PRs changing any of %s are held automatically. Only members of the %s alias can cancel the hold.", strings.Join(cfg.RiskyPaths, ", "), cfg.ApproversAlias)
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		Hold: map[string]*plugins.Hold{
+			"org/repo": {
+				RiskyPaths:     []string{"vendor/", "*/migration/*"},
+				ApproversAlias: "migration-approvers",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
 	pluginHelp := &pluginhelp.PluginHelp{
-		Description: "The hold plugin allows anyone to add or remove the '" + labels.Hold + "' Label from a pull request in order to temporarily prevent the PR from merging without withholding approval.",
+		Description: "The hold plugin allows anyone to add or remove the '" + labels.Hold + "' Label from a pull request in order to temporarily prevent the PR from merging without withholding approval. PRs that change configured risky paths are held automatically.",
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
 	}
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/[remove-][un]hold [cancel]",
 		Description: "Adds or removes the `" + labels.Hold + "` Label which is used to indicate that the PR should not be automatically merged.",
 		Featured:    false,
-		WhoCanUse:   "Anyone can use the /hold command to add or remove the '" + labels.Hold + "' Label.",
+		WhoCanUse:   "Anyone can use the /hold command to add or remove the '" + labels.Hold + "' Label. Holds that were added automatically can only be removed by members of the configured approvers alias.",
 		Examples:    []string{"/hold", "/hold cancel", "/unhold", "/remove-hold"},
 	})
 	return pluginHelp, nil
@@ -68,19 +98,31 @@ type githubClient interface {
 	AddLabel(owner, repo string, number int, label string) error
 	RemoveLabel(owner, repo string, number int, label string) error
 	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	CreateComment(owner, repo string, number int, comment string) error
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetFile(org, repo, filepath, commit string) ([]byte, error)
+	BotUserChecker() (func(candidate string) bool, error)
 }
 
 func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
 	hasLabel := func(label string, labels []github.Label) bool {
 		return github.HasLabel(label, labels)
 	}
-	return handle(pc.GitHubClient, pc.Logger, &e, hasLabel)
+	org, repo := e.Repo.Owner.Login, e.Repo.Name
+	return handle(pc.GitHubClient, pc.Logger, &e, hasLabel, pc.PluginConfig.HoldFor(org, repo), pc.PluginConfig.OwnersFilenames(org, repo).OwnersAliases)
+}
+
+func handlePullRequest(pc plugins.Agent, pe github.PullRequestEvent) error {
+	return handlePR(pc.GitHubClient, pc.Logger, pc.PluginConfig.HoldFor(pe.Repo.Owner.Login, pe.Repo.Name), &pe)
 }
 
 // handle drives the pull request to the desired state. If any user adds
 // a /hold directive, we want to add a label if one does not already exist.
-// If they add /hold cancel, we want to remove the label if it exists.
-func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, f hasLabelFunc) error {
+// If they add /hold cancel, we want to remove the label if it exists, unless
+// the hold was added automatically and the user is not one of the approvers
+// allowed to cancel it.
+func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, f hasLabelFunc, cfg *plugins.Hold, aliasesFile string) error {
 	if !e.IsPR {
 		return nil
 	}
@@ -105,6 +147,17 @@ func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, f
 
 	hasLabel := f(labels.Hold, issueLabels)
 	if hasLabel && !needsLabel {
+		if len(cfg.RiskyPaths) > 0 {
+			allowed, err := canCancel(gc, org, repo, e.Number, e.User.Login, cfg.ApproversAlias, aliasesFile)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				log.Infof("Not removing %q Label for %s/%s#%d, %s is not in the %s alias", labels.Hold, org, repo, e.Number, e.User.Login, cfg.ApproversAlias)
+				resp := fmt.Sprintf("this PR was put on hold because it changes risky paths. Only members of the `%s` alias in `%s` can cancel the hold.", cfg.ApproversAlias, aliasesFile)
+				return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, resp))
+			}
+		}
 		log.Infof("Removing %q Label for %s/%s#%d", labels.Hold, org, repo, e.Number)
 		return gc.RemoveLabel(org, repo, e.Number, labels.Hold)
 	} else if !hasLabel && needsLabel {
@@ -113,3 +166,115 @@ func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, f
 	}
 	return nil
 }
+
+// handlePR puts a hold on pull requests that change risky paths and explains
+// why in a comment. A pull request is only held automatically once, so that
+// pushing more changes does not undo a cancelled hold.
+func handlePR(gc githubClient, log *logrus.Entry, cfg *plugins.Hold, pe *github.PullRequestEvent) error {
+	if len(cfg.RiskyPaths) == 0 {
+		return nil
+	}
+	if pe.Action != github.PullRequestActionOpened &&
+		pe.Action != github.PullRequestActionReopened &&
+		pe.Action != github.PullRequestActionSynchronize {
+		return nil
+	}
+
+	org := pe.Repo.Owner.Login
+	repo := pe.Repo.Name
+	changes, err := gc.GetPullRequestChanges(org, repo, pe.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get the changes of %s/%s#%d: %w", org, repo, pe.Number, err)
+	}
+	var risky []github.PullRequestChange
+	for _, change := range changes {
+		if isRiskyPath(change.Filename, cfg.RiskyPaths) {
+			risky = append(risky, change)
+		}
+	}
+	if len(risky) == 0 {
+		return nil
+	}
+
+	held, err := heldAutomatically(gc, org, repo, pe.Number)
+	if err != nil {
+		return err
+	}
+	if held {
+		return nil
+	}
+	issueLabels, err := gc.GetIssueLabels(org, repo, pe.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels on %s/%s#%d: %w", org, repo, pe.Number, err)
+	}
+	if !github.HasLabel(labels.Hold, issueLabels) {
+		log.Infof("Adding %q Label for %s/%s#%d, it changes risky paths", labels.Hold, org, repo, pe.Number)
+		if err := gc.AddLabel(org, repo, pe.Number, labels.Hold); err != nil {
+			return err
+		}
+	}
+
+	var details bytes.Buffer
+	fmt.Fprintf(&details, "A member of the `%s` alias has to review the changes and comment `/hold cancel` to remove the hold. The following files match the risky paths %s:\n\n", cfg.ApproversAlias, strings.Join(cfg.RiskyPaths, ", "))
+	for _, change := range risky {
+		fmt.Fprintf(&details, "- [%s](%s)\n", change.Filename, change.BlobURL)
+	}
+	return gc.CreateComment(org, repo, pe.Number, plugins.FormatResponse(pe.PullRequest.User.Login, riskyPathsHoldBody, details.String()))
+}
+
+// isRiskyPath returns whether the file matches one of the patterns, which
+// follow the syntax of .gitignore files.
+func isRiskyPath(file string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if gitignore.ParsePattern(pattern, nil).Match(strings.Split(file, "/"), false) == gitignore.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
+// heldAutomatically returns whether the most recent hold on the pull request
+// was put on by the bot because it changes risky paths.
+func heldAutomatically(gc githubClient, org, repo string, number int) (bool, error) {
+	isBot, err := gc.BotUserChecker()
+	if err != nil {
+		return false, fmt.Errorf("failed to get the bot's name: %w", err)
+	}
+	comments, err := gc.ListIssueComments(org, repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to list the comments on %s/%s#%d: %w", org, repo, number, err)
+	}
+	held := false
+	for _, comment := range comments {
+		if isBot(comment.User.Login) {
+			if strings.Contains(comment.Body, riskyPathsHoldBody) {
+				held = true
+			}
+		} else if labelRe.MatchString(comment.Body) && !labelCancelRe.MatchString(comment.Body) {
+			held = false
+		}
+	}
+	return held, nil
+}
+
+// canCancel returns whether the user may cancel the hold on the pull request.
+// Holds that were added automatically can only be cancelled by members of
+// the approvers alias in the OWNERS_ALIASES file of the default branch.
+func canCancel(gc githubClient, org, repo string, number int, user, alias, aliasesFile string) (bool, error) {
+	held, err := heldAutomatically(gc, org, repo, number)
+	if err != nil {
+		return false, err
+	}
+	if !held {
+		return true, nil
+	}
+	b, err := gc.GetFile(org, repo, aliasesFile, "")
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s of %s/%s: %w", aliasesFile, org, repo, err)
+	}
+	aliases, err := repoowners.ParseAliasesConfig(b)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s of %s/%s: %w", aliasesFile, org, repo, err)
+	}
+	return aliases.ExpandAlias(alias).Has(github.NormLogin(user)), nil
+}
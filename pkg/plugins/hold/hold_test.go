@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
 	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
 )
 
 func TestHandle(t *testing.T) {
@@ -189,7 +190,7 @@ func TestHandle(t *testing.T) {
 			return tc.hasLabel
 		}
 
-		if err := handle(fc, logrus.WithField("plugin", PluginName), e, hasLabel); err != nil {
+		if err := handle(fc, logrus.WithField("plugin", PluginName), e, hasLabel, &plugins.Hold{}, ""); err != nil {
 			t.Errorf("For case %s, didn't expect error from hold: %v", tc.name, err)
 			continue
 		}
@@ -211,3 +212,169 @@ func TestHandle(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlePR(t *testing.T) {
+	cfg := &plugins.Hold{RiskyPaths: []string{"vendor/", "*/migration/*", "schema/**/*.sql"}, ApproversAlias: "db-approvers"}
+	var tests = []struct {
+		name        string
+		action      github.PullRequestEventAction
+		files       []string
+		comments    []github.IssueComment
+		shouldLabel bool
+		shouldHold  bool
+	}{
+		{
+			name:        "risky directory",
+			action:      github.PullRequestActionOpened,
+			files:       []string{"README.md", "vendor/github.com/foo/foo.go"},
+			shouldLabel: true,
+			shouldHold:  true,
+		},
+		{
+			name:        "risky pattern",
+			action:      github.PullRequestActionSynchronize,
+			files:       []string{"db/migration/v2/0002_users.sql"},
+			shouldLabel: true,
+			shouldHold:  true,
+		},
+		{
+			name:        "risky pattern in any directory",
+			action:      github.PullRequestActionOpened,
+			files:       []string{"schema/v1/tables/users.sql"},
+			shouldLabel: true,
+			shouldHold:  true,
+		},
+		{
+			name:   "no risky paths",
+			action: github.PullRequestActionOpened,
+			files:  []string{"db/schema.sql", "pkg/vendor.go", "schema/README.md"},
+		},
+		{
+			name:   "ignored action",
+			action: github.PullRequestActionEdited,
+			files:  []string{"vendor/foo.go"},
+		},
+		{
+			name:     "already held automatically",
+			action:   github.PullRequestActionSynchronize,
+			files:    []string{"vendor/foo.go"},
+			comments: []github.IssueComment{{Body: riskyPathsHoldBody, User: github.User{Login: "k8s-ci-robot"}}},
+		},
+		{
+			name:        "hold comment quoted by a user",
+			action:      github.PullRequestActionSynchronize,
+			files:       []string{"vendor/foo.go"},
+			comments:    []github.IssueComment{{Body: "> " + riskyPathsHoldBody, User: github.User{Login: "mallory"}}},
+			shouldLabel: true,
+			shouldHold:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.IssueComments = map[int][]github.IssueComment{}
+			fc.IssueComments[1] = tc.comments
+			var changes []github.PullRequestChange
+			for _, file := range tc.files {
+				changes = append(changes, github.PullRequestChange{Filename: file})
+			}
+			fc.PullRequestChanges = map[int][]github.PullRequestChange{1: changes}
+
+			pe := &github.PullRequestEvent{
+				Action:      tc.action,
+				Number:      1,
+				Repo:        github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				PullRequest: github.PullRequest{User: github.User{Login: "author"}},
+			}
+			if err := handlePR(fc, logrus.WithField("plugin", PluginName), cfg, pe); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if labeled := len(fc.IssueLabelsAdded) > 0; labeled != tc.shouldLabel {
+				t.Errorf("expected labeled to be %t, got labels added: %v", tc.shouldLabel, fc.IssueLabelsAdded)
+			}
+			if commented := len(fc.IssueComments[1]) > len(tc.comments); commented != tc.shouldHold {
+				t.Errorf("expected hold comment to be created to be %t, got comments: %v", tc.shouldHold, fc.IssueComments[1])
+			}
+		})
+	}
+}
+
+func TestHandleCancelRiskyHold(t *testing.T) {
+	cfg := &plugins.Hold{RiskyPaths: []string{"vendor/"}, ApproversAlias: "db-approvers"}
+	automaticHold := github.IssueComment{Body: riskyPathsHoldBody, User: github.User{Login: "k8s-ci-robot"}}
+	var tests = []struct {
+		name          string
+		user          string
+		comments      []github.IssueComment
+		heldByPlugin  bool
+		shouldUnlabel bool
+	}{
+		{
+			name:          "approver cancels automatic hold",
+			user:          "Alice",
+			comments:      []github.IssueComment{automaticHold},
+			heldByPlugin:  true,
+			shouldUnlabel: true,
+		},
+		{
+			name:         "non-approver cannot cancel automatic hold",
+			user:         "bob",
+			comments:     []github.IssueComment{automaticHold},
+			heldByPlugin: true,
+		},
+		{
+			name:          "non-approver cancels manual hold",
+			user:          "bob",
+			shouldUnlabel: true,
+		},
+		{
+			name: "non-approver cancels manual hold put on after an automatic hold",
+			user: "bob",
+			comments: []github.IssueComment{
+				automaticHold,
+				{Body: "/hold cancel", User: github.User{Login: "alice"}},
+				{Body: "/hold", User: github.User{Login: "bob"}},
+			},
+			shouldUnlabel: true,
+		},
+		{
+			name:          "non-approver cancels hold after a user posted the hold message",
+			user:          "bob",
+			comments:      []github.IssueComment{{Body: riskyPathsHoldBody, User: github.User{Login: "mallory"}}},
+			shouldUnlabel: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.IssueComments = map[int][]github.IssueComment{1: tc.comments}
+			fc.RemoteFiles = map[string]map[string]string{
+				"OWNERS_ALIASES": {"master": "aliases:\n  db-approvers:\n  - alice\n"},
+			}
+			e := &github.GenericCommentEvent{
+				Action: github.GenericCommentActionCreated,
+				Body:   "/hold cancel",
+				Number: 1,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				User:   github.User{Login: tc.user},
+				IsPR:   true,
+			}
+			hasLabel := func(label string, issueLabels []github.Label) bool {
+				return true
+			}
+
+			if err := handle(fc, logrus.WithField("plugin", PluginName), e, hasLabel, cfg, "OWNERS_ALIASES"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if unlabeled := len(fc.IssueLabelsRemoved) > 0; unlabeled != tc.shouldUnlabel {
+				t.Errorf("expected unlabeled to be %t, got labels removed: %v", tc.shouldUnlabel, fc.IssueLabelsRemoved)
+			}
+			if commented := len(fc.IssueComments[1]) > len(tc.comments); commented == tc.shouldUnlabel && tc.heldByPlugin {
+				t.Errorf("expected a comment only when the hold is kept, got comments: %v", fc.IssueComments[1])
+			}
+		})
+	}
+}
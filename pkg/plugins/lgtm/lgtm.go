@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
@@ -50,12 +51,18 @@ var (
 	// LGTMCancelRe is the regex that matches lgtm cancel comments
 	LGTMCancelRe        = regexp.MustCompile(`(?mi)^/(remove-lgtm|lgtm cancel)\s*$`)
 	removeLGTMLabelNoti = "New changes are detected. LGTM label has been removed."
+	lgtmVotesNoti       = "%d/%d LGTMs so far, from %s. The `lgtm` label is added once %d different users have given an LGTM.\n<!-- lgtm-votes: %s -->"
+	lgtmVotesNotiRe     = regexp.MustCompile(`<!-- lgtm-votes: (\S*) -->`)
 )
 
 func configInfoStickyLgtmTeam(team string) string {
 	return fmt.Sprintf(`Commits from "%s" do not remove LGTM.`, team)
 }
 
+func configInfoLgtmThreshold(threshold int) string {
+	return fmt.Sprintf(`The lgtm label is added once %d different users have given an LGTM.`, threshold)
+}
+
 type commentPruner interface {
 	PruneComments(shouldPrune func(github.IssueComment) bool)
 }
@@ -87,6 +94,10 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			configInfoStrings = append(configInfoStrings, "<li>"+configInfoStickyLgtmTeam(opts.StickyLgtmTeam)+"</li>")
 			isConfigured = true
 		}
+		if opts.LgtmThreshold > 1 {
+			configInfoStrings = append(configInfoStrings, "<li>"+configInfoLgtmThreshold(opts.LgtmThreshold)+"</li>")
+			isConfigured = true
+		}
 		configInfoStrings = append(configInfoStrings, "</ul>")
 		if isConfigured {
 			configInfo[repo.String()] = strings.Join(configInfoStrings, "\n")
@@ -99,6 +110,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 				ReviewActsAsLgtm: true,
 				StickyLgtmTeam:   "team1",
 				StoreTreeHash:    true,
+				LgtmThreshold:    2,
 			},
 		},
 	})
@@ -116,6 +128,10 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		Featured:    true,
 		WhoCanUse:   "Collaborators on the repository. '/lgtm cancel' can be used additionally by the PR author.",
 		Examples:    []string{"/lgtm", "/lgtm cancel", "/remove-lgtm", "<a href=\"https://help.github.com/articles/about-pull-request-reviews/\">'Approve' or 'Request Changes'</a>"},
+		Name:        "lgtm",
+		Arguments: []pluginhelp.Argument{
+			{Name: "cancel", Type: pluginhelp.ArgumentEnum, Values: []string{"cancel"}, Optional: true, Description: "Removes the label instead."},
+		},
 	})
 	return pluginHelp, nil
 }
@@ -331,8 +347,27 @@ func handle(wantLGTM bool, config *plugins.Configuration, ownersClient repoowner
 	}
 	hasLGTM := github.HasLabel(LGTMLabel, labels)
 
-	// remove the label if necessary, we're done after this
 	opts := config.LgtmFor(rc.repo.Owner.Login, rc.repo.Name)
+	if opts.LgtmThreshold > 1 {
+		votes, votesComments, err := lgtmVotes(gc, org, repoName, number)
+		if err != nil {
+			return err
+		}
+		if wantLGTM {
+			votes.Insert(github.NormLogin(author))
+		} else {
+			votes.Delete(github.NormLogin(author))
+		}
+		if err := updateLGTMVotes(gc, org, repoName, number, votes, opts.LgtmThreshold, votesComments); err != nil {
+			return err
+		}
+		if !hasLGTM && wantLGTM && votes.Len() < opts.LgtmThreshold {
+			log.Infof("Not adding LGTM label yet, %d/%d LGTMs so far.", votes.Len(), opts.LgtmThreshold)
+			return nil
+		}
+	}
+
+	// remove the label if necessary, we're done after this
 	if hasLGTM && !wantLGTM {
 		log.Info("Removing LGTM label.")
 		if err := removeLGTMAndRequestReview(gc, org, repoName, number, getLogins(assignees), opts.StoreTreeHash); err != nil {
@@ -429,6 +464,10 @@ func handlePullRequest(log *logrus.Entry, gc githubClient, config *plugins.Confi
 		log.WithError(err).Error("Failed to get labels.")
 	}
 	if !github.HasLabel(LGTMLabel, labels) {
+		if opts.LgtmThreshold > 1 {
+			// New changes invalidate the LGTMs given so far.
+			return clearLGTMVotes(gc, org, repo, number)
+		}
 		return nil
 	}
 
@@ -471,6 +510,11 @@ func handlePullRequest(log *logrus.Entry, gc githubClient, config *plugins.Confi
 	if err := removeLGTMAndRequestReview(gc, org, repo, number, getLogins(pe.PullRequest.Assignees), opts.StoreTreeHash); err != nil {
 		return fmt.Errorf("failed removing lgtm label: %w", err)
 	}
+	if opts.LgtmThreshold > 1 {
+		if err := clearLGTMVotes(gc, org, repo, number); err != nil {
+			return err
+		}
+	}
 
 	// Create a comment to inform participants that LGTM label is removed due to new
 	// pull request changes.
@@ -493,6 +537,64 @@ func removeLGTMAndRequestReview(gc githubClient, org, repo string, number int, l
 	return nil
 }
 
+// lgtmVotes returns the users that have given an LGTM so far, as recorded in
+// the latest comment of the bot tracking them, along with all such comments.
+func lgtmVotes(gc githubClient, org, repo string, number int) (sets.Set[string], []github.IssueComment, error) {
+	botUserChecker, err := gc.BotUserChecker()
+	if err != nil {
+		return nil, nil, err
+	}
+	comments, err := gc.ListIssueComments(org, repo, number)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	votes := sets.New[string]()
+	var votesComments []github.IssueComment
+	for _, comment := range comments {
+		if !botUserChecker(comment.User.Login) {
+			continue
+		}
+		m := lgtmVotesNotiRe.FindStringSubmatch(comment.Body)
+		if m == nil {
+			continue
+		}
+		votesComments = append(votesComments, comment)
+		votes = sets.New[string]()
+		if m[1] != "" {
+			votes.Insert(strings.Split(m[1], ",")...)
+		}
+	}
+	return votes, votesComments, nil
+}
+
+// updateLGTMVotes replaces the comments tracking the LGTM votes with one
+// reporting the current progress, unless nothing changed.
+func updateLGTMVotes(gc githubClient, org, repo string, number int, votes sets.Set[string], threshold int, votesComments []github.IssueComment) error {
+	logins := sets.List(votes)
+	body := fmt.Sprintf(lgtmVotesNoti, len(logins), threshold, strings.Join(logins, ", "), threshold, strings.Join(logins, ","))
+	if len(votesComments) == 1 && votesComments[0].Body == body {
+		return nil
+	}
+	for _, comment := range votesComments {
+		if err := gc.DeleteComment(org, repo, comment.ID); err != nil {
+			return fmt.Errorf("failed to delete comment %d: %w", comment.ID, err)
+		}
+	}
+	if len(logins) == 0 {
+		return nil
+	}
+	return gc.CreateComment(org, repo, number, body)
+}
+
+// clearLGTMVotes removes the comments tracking the LGTM votes.
+func clearLGTMVotes(gc githubClient, org, repo string, number int) error {
+	_, votesComments, err := lgtmVotes(gc, org, repo, number)
+	if err != nil {
+		return err
+	}
+	return updateLGTMVotes(gc, org, repo, number, nil, 0, votesComments)
+}
+
 func getLogins(usrs []github.User) []string {
 	res := []string{}
 	for _, usr := range usrs {
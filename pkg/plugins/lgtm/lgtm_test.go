@@ -1215,6 +1215,118 @@ func TestHandlePullRequest(t *testing.T) {
 	}
 }
 
+func TestLGTMThreshold(t *testing.T) {
+	votesComment := func(votes ...string) github.IssueComment {
+		body := fmt.Sprintf(lgtmVotesNoti, len(votes), 2, strings.Join(votes, ", "), 2, strings.Join(votes, ","))
+		return github.IssueComment{ID: 1, Body: body, User: github.User{Login: fakegithub.Bot}}
+	}
+	cases := []struct {
+		name     string
+		body     string
+		hasLGTM  bool
+		comments []github.IssueComment
+
+		expectAdded   bool
+		expectRemoved bool
+		expectVotes   []string
+	}{
+		{
+			name:        "first lgtm is recorded without adding the label",
+			body:        "/lgtm",
+			expectVotes: []string{"collab1"},
+		},
+		{
+			name:        "repeated lgtm by the same user does not add the label",
+			body:        "/lgtm",
+			comments:    []github.IssueComment{votesComment("collab1")},
+			expectVotes: []string{"collab1"},
+		},
+		{
+			name:        "second lgtm adds the label",
+			body:        "/lgtm",
+			comments:    []github.IssueComment{votesComment("collab2")},
+			expectAdded: true,
+			expectVotes: []string{"collab1", "collab2"},
+		},
+		{
+			name:          "lgtm cancel removes the vote and the label",
+			body:          "/lgtm cancel",
+			hasLGTM:       true,
+			comments:      []github.IssueComment{votesComment("collab1", "collab2")},
+			expectRemoved: true,
+			expectVotes:   []string{"collab2"},
+		},
+		{
+			name:     "lgtm cancel of the only vote removes the progress comment",
+			body:     "/lgtm cancel",
+			comments: []github.IssueComment{votesComment("collab1")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.IssueComments = map[int][]github.IssueComment{5: tc.comments}
+			fc.IssueCommentID = len(tc.comments)
+			fc.Collaborators = []string{"collab1", "collab2"}
+			if tc.hasLGTM {
+				fc.IssueLabelsAdded = []string{"org/repo#5:" + LGTMLabel}
+			}
+			pc := &plugins.Configuration{Lgtm: []plugins.Lgtm{{Repos: []string{"org/repo"}, LgtmThreshold: 2}}}
+			e := github.GenericCommentEvent{
+				Action:      github.GenericCommentActionCreated,
+				IssueState:  "open",
+				IsPR:        true,
+				Body:        tc.body,
+				User:        github.User{Login: "collab1"},
+				IssueAuthor: github.User{Login: "author"},
+				Number:      5,
+				Assignees:   []github.User{{Login: "collab1"}},
+				Repo:        github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+			}
+			fp := &fakePruner{GitHubClient: fc, IssueComments: fc.IssueComments[5]}
+			if err := handleGenericComment(fc, pc, &fakeOwnersClient{}, logrus.WithField("plugin", PluginName), fp, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if added := len(fc.IssueLabelsAdded) > 0 && !tc.hasLGTM; added != tc.expectAdded {
+				t.Errorf("expected label added: %t, got: %t", tc.expectAdded, added)
+			}
+			if removed := len(fc.IssueLabelsRemoved) > 0; removed != tc.expectRemoved {
+				t.Errorf("expected label removed: %t, got: %t", tc.expectRemoved, removed)
+			}
+			votes, votesComments, err := lgtmVotes(fc, "org", "repo", 5)
+			if err != nil {
+				t.Fatalf("failed to get votes: %v", err)
+			}
+			if len(votesComments) > 1 {
+				t.Errorf("expected at most one progress comment, got %d", len(votesComments))
+			}
+			if !votes.Equal(sets.New[string](tc.expectVotes...)) {
+				t.Errorf("expected votes %v, got %v", tc.expectVotes, sets.List(votes))
+			}
+		})
+	}
+
+	t.Run("new changes reset the votes", func(t *testing.T) {
+		fc := fakegithub.NewFakeClient()
+		fc.IssueComments = map[int][]github.IssueComment{101: {votesComment("collab1")}}
+		pc := &plugins.Configuration{Lgtm: []plugins.Lgtm{{Repos: []string{"org/repo"}, LgtmThreshold: 2}}}
+		pe := &github.PullRequestEvent{
+			Action: github.PullRequestActionSynchronize,
+			PullRequest: github.PullRequest{
+				Number: 101,
+				Base:   github.PullRequestBranch{Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}},
+			},
+		}
+		if err := handlePullRequest(logrus.WithField("plugin", PluginName), fc, pc, pe); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(fc.IssueComments[101]) != 0 {
+			t.Errorf("expected the progress comment to be deleted, got %v", fc.IssueComments[101])
+		}
+	})
+}
+
 func TestAddTreeHashComment(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package linkedissue implements the `linked-issue` plugin. It requires
+// PRs to reference a GitHub issue, Jira ticket or Bugzilla bug and applies
+// the `kind/*` label of the referenced issue to the PR. PRs without a valid
+// reference are labeled with `do-not-merge/needs-issue`.
+package linkedissue
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	jiraclient "sigs.k8s.io/prow/pkg/jira"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "linked-issue"
+
+const kindPrefix = "kind/"
+
+var (
+	handlePRActions = map[github.PullRequestEventAction]bool{
+		github.PullRequestActionOpened:    true,
+		github.PullRequestActionReopened:  true,
+		github.PullRequestActionEdited:    true,
+		github.PullRequestActionLabeled:   true,
+		github.PullRequestActionUnlabeled: true,
+	}
+
+	// githubRefRE matches `#123` and `org/repo#123` references.
+	githubRefRE = regexp.MustCompile(`(?:^|[\s(\[])(?:([\w.-]+)/([\w.-]+))?#(\d+)\b`)
+	// githubURLRE matches links to GitHub issues.
+	githubURLRE = regexp.MustCompile(`https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)\b`)
+	// jiraRefRE matches Jira keys like `PROJ-123`.
+	jiraRefRE = regexp.MustCompile(`\b([a-zA-Z]+)-([0-9]+)\b`)
+	// bugzillaRefRE matches the `Bug 123:` title prefix also used by the bugzilla plugin.
+	bugzillaRefRE = regexp.MustCompile(`(?i)Bug\s+([0-9]+):`)
+
+	missingIssueComment = fmt.Sprintf("This PR does not reference an issue. Please link an issue in the PR description and the %q label will be removed.", labels.NeedsIssue)
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		cfg := config.LinkedIssueFor(repo.Org, repo.Repo)
+		var sources []string
+		sources = append(sources, "GitHub issues")
+		if len(cfg.JiraProjects) > 0 {
+			sources = append(sources, fmt.Sprintf("Jira tickets of the %s projects", strings.Join(cfg.JiraProjects, ", ")))
+		}
+		if cfg.Bugzilla {
+			sources = append(sources, "Bugzilla bugs referenced in the title")
+		}
+		desc := fmt.Sprintf("PRs must reference one of: %s.", strings.Join(sources, "; "))
+		if len(cfg.ExemptLabels) > 0 {
+			desc += fmt.Sprintf(" PRs with any of the labels %s are exempt.", strings.Join(cfg.ExemptLabels, ", "))
+		}
+		configInfo[repo.String()] = desc
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		LinkedIssue: map[string]*plugins.LinkedIssue{
+			"org/repo": {
+				JiraProjects: []string{"PROJ"},
+				Bugzilla:     true,
+				KindMapping: map[string]string{
+					"Bug":   "kind/bug",
+					"Story": "kind/feature",
+				},
+				ExemptLabels: []string{"kind/cleanup"},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The linked-issue plugin requires PRs to reference an issue in their title or description. PRs without a reference are labeled %q. The kind/* label of the referenced issue is applied to PRs that do not have one.", labels.NeedsIssue),
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	CreateComment(org, repo string, number int, content string) error
+	GetIssue(org, repo string, number int) (*github.Issue, error)
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+}
+
+type jiraClient interface {
+	GetIssue(id string) (*jira.Issue, error)
+}
+
+type commentPruner interface {
+	PruneComments(shouldPrune func(github.IssueComment) bool)
+}
+
+func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
+	if !handlePRActions[pre.Action] || pre.PullRequest.State != github.PullRequestStateOpen {
+		return nil
+	}
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	var jc jiraClient
+	if pc.JiraClient != nil {
+		jc = pc.JiraClient
+	}
+	cfg := pc.PluginConfig.LinkedIssueFor(pre.Repo.Owner.Login, pre.Repo.Name)
+	return handle(pc.Logger, pc.GitHubClient, jc, cp, cfg, &pre.PullRequest)
+}
+
+// reference is an issue referenced by a PR.
+type reference struct {
+	org, repo string
+	number    int
+}
+
+func handle(log *logrus.Entry, ghc githubClient, jc jiraClient, cp commentPruner, cfg *plugins.LinkedIssue, pr *github.PullRequest) error {
+	org := pr.Base.Repo.Owner.Login
+	repo := pr.Base.Repo.Name
+	number := pr.Number
+
+	currentLabels, err := ghc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get labels of %s/%s#%d: %w", org, repo, number, err)
+	}
+	prLabels := sets.New[string]()
+	hasKind := false
+	for _, label := range currentLabels {
+		prLabels.Insert(label.Name)
+		hasKind = hasKind || strings.HasPrefix(label.Name, kindPrefix)
+	}
+
+	linked := prLabels.HasAny(cfg.ExemptLabels...)
+	kinds := sets.New[string]()
+	if !linked {
+		linked, kinds = resolveReferences(log, ghc, jc, cfg, org, repo, pr)
+	}
+
+	if !linked {
+		if prLabels.Has(labels.NeedsIssue) {
+			return nil
+		}
+		if err := ghc.AddLabel(org, repo, number, labels.NeedsIssue); err != nil {
+			return fmt.Errorf("failed to add %q label: %w", labels.NeedsIssue, err)
+		}
+		return ghc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(missingIssueComment))
+	}
+
+	if prLabels.Has(labels.NeedsIssue) {
+		if err := ghc.RemoveLabel(org, repo, number, labels.NeedsIssue); err != nil {
+			return fmt.Errorf("failed to remove %q label: %w", labels.NeedsIssue, err)
+		}
+		cp.PruneComments(func(comment github.IssueComment) bool {
+			return strings.Contains(comment.Body, missingIssueComment)
+		})
+	}
+	if hasKind {
+		return nil
+	}
+	for _, kind := range sets.List(kinds) {
+		if err := ghc.AddLabel(org, repo, number, kind); err != nil {
+			log.WithError(err).Errorf("Failed to add %q label.", kind)
+		}
+	}
+	return nil
+}
+
+// resolveReferences returns whether the PR references at least one existing
+// issue and the kind labels that the referenced issues map to.
+func resolveReferences(log *logrus.Entry, ghc githubClient, jc jiraClient, cfg *plugins.LinkedIssue, org, repo string, pr *github.PullRequest) (bool, sets.Set[string]) {
+	text := pr.Title + "\n" + pr.Body
+	linked := false
+	kinds := sets.New[string]()
+
+	for _, ref := range githubReferences(org, repo, text) {
+		issue, err := ghc.GetIssue(ref.org, ref.repo, ref.number)
+		if err != nil {
+			log.WithError(err).Debugf("Failed to get referenced issue %s/%s#%d.", ref.org, ref.repo, ref.number)
+			continue
+		}
+		if issue.IsPullRequest() {
+			continue
+		}
+		linked = true
+		for _, label := range issue.Labels {
+			if strings.HasPrefix(label.Name, kindPrefix) {
+				kinds.Insert(label.Name)
+			} else if kind, ok := cfg.KindMapping[label.Name]; ok {
+				kinds.Insert(kind)
+			}
+		}
+	}
+
+	for _, key := range jiraReferences(cfg.JiraProjects, text) {
+		if jc == nil {
+			// Without a Jira client the reference can not be verified,
+			// so trust it.
+			linked = true
+			continue
+		}
+		issue, err := jc.GetIssue(key)
+		if err != nil {
+			if !jiraclient.IsNotFound(err) {
+				log.WithError(err).Warnf("Failed to get referenced Jira ticket %s.", key)
+			}
+			continue
+		}
+		linked = true
+		if issue.Fields == nil {
+			continue
+		}
+		if kind, ok := cfg.KindMapping[issue.Fields.Type.Name]; ok {
+			kinds.Insert(kind)
+		}
+	}
+
+	if cfg.Bugzilla && bugzillaRefRE.MatchString(pr.Title) {
+		linked = true
+	}
+	return linked, kinds
+}
+
+// githubReferences returns all GitHub issues referenced in text. References
+// without an org and repo refer to the given repository.
+func githubReferences(org, repo, text string) []reference {
+	var refs []reference
+	seen := sets.New[string]()
+	add := func(refOrg, refRepo, num string) {
+		if refOrg == "" {
+			refOrg, refRepo = org, repo
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return
+		}
+		key := fmt.Sprintf("%s/%s#%d", refOrg, refRepo, n)
+		if seen.Has(key) {
+			return
+		}
+		seen.Insert(key)
+		refs = append(refs, reference{org: refOrg, repo: refRepo, number: n})
+	}
+	for _, m := range githubRefRE.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[2], m[3])
+	}
+	for _, m := range githubURLRE.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[2], m[3])
+	}
+	return refs
+}
+
+// jiraReferences returns the keys of all tickets of the given projects
+// referenced in text.
+func jiraReferences(projects []string, text string) []string {
+	if len(projects) == 0 {
+		return nil
+	}
+	allowed := sets.New[string]()
+	for _, project := range projects {
+		allowed.Insert(strings.ToUpper(project))
+	}
+	var keys []string
+	seen := sets.New[string]()
+	for _, m := range jiraRefRE.FindAllStringSubmatch(text, -1) {
+		project := strings.ToUpper(m[1])
+		if !allowed.Has(project) {
+			continue
+		}
+		key := project + "-" + m[2]
+		if seen.Has(key) {
+			continue
+		}
+		seen.Insert(key)
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linkedissue
+
+import (
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+type fakePruner struct {
+	pruned bool
+}
+
+func (fp *fakePruner) PruneComments(shouldPrune func(github.IssueComment) bool) {
+	fp.pruned = shouldPrune(github.IssueComment{Body: plugins.FormatSimpleResponse(missingIssueComment)})
+}
+
+func TestHandle(t *testing.T) {
+	cfg := &plugins.LinkedIssue{
+		JiraProjects: []string{"proj"},
+		Bugzilla:     true,
+		KindMapping: map[string]string{
+			"bug":   labels.Bug,
+			"Story": "kind/feature",
+		},
+		ExemptLabels: []string{"kind/cleanup"},
+	}
+	issues := map[int]*github.Issue{
+		2: {Number: 2, Labels: []github.Label{{Name: labels.Bug}}},
+		3: {Number: 3, Labels: []github.Label{{Name: "bug"}, {Name: "priority/important-soon"}}},
+		4: {Number: 4, PullRequest: &struct{}{}},
+		5: {Number: 5},
+	}
+	jiraIssues := []*jira.Issue{
+		{Key: "PROJ-1", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Story"}}},
+	}
+
+	testCases := []struct {
+		name           string
+		title          string
+		body           string
+		labels         []string
+		noJira         bool
+		expectedAdded  []string
+		expectedRemove []string
+		expectComment  bool
+		expectPrune    bool
+	}{
+		{
+			name:          "no reference adds label and comments",
+			body:          "Some change.",
+			expectedAdded: []string{labels.NeedsIssue},
+			expectComment: true,
+		},
+		{
+			name:   "no reference with label already present does nothing",
+			body:   "Some change.",
+			labels: []string{labels.NeedsIssue},
+		},
+		{
+			name:           "github issue reference copies kind label",
+			body:           "Fixes #2",
+			labels:         []string{labels.NeedsIssue},
+			expectedAdded:  []string{labels.Bug},
+			expectedRemove: []string{labels.NeedsIssue},
+			expectPrune:    true,
+		},
+		{
+			name:          "github issue link maps labels",
+			body:          "See https://github.com/org/repo/issues/3 for details.",
+			expectedAdded: []string{labels.Bug},
+		},
+		{
+			name:          "issue without kind is linked",
+			body:          "Part of other/repo#5.",
+			expectedAdded: nil,
+		},
+		{
+			name:   "kind labels are not applied if the PR already has one",
+			body:   "Fixes #2",
+			labels: []string{"kind/feature"},
+		},
+		{
+			name:          "reference to a pull request is not a linked issue",
+			body:          "Follow-up to #4",
+			expectedAdded: []string{labels.NeedsIssue},
+			expectComment: true,
+		},
+		{
+			name:          "reference to a missing issue is not a linked issue",
+			body:          "Fixes #42",
+			expectedAdded: []string{labels.NeedsIssue},
+			expectComment: true,
+		},
+		{
+			name:           "exempt label removes needs-issue label",
+			labels:         []string{labels.NeedsIssue, "kind/cleanup"},
+			expectedRemove: []string{labels.NeedsIssue},
+			expectPrune:    true,
+		},
+		{
+			name:          "jira ticket maps issue type",
+			title:         "PROJ-1: add feature",
+			expectedAdded: []string{"kind/feature"},
+		},
+		{
+			name:          "jira ticket of another project is ignored",
+			title:         "OTHER-1: add feature",
+			expectedAdded: []string{labels.NeedsIssue},
+			expectComment: true,
+		},
+		{
+			name:          "missing jira ticket is not a linked issue",
+			title:         "PROJ-2: add feature",
+			expectedAdded: []string{labels.NeedsIssue},
+			expectComment: true,
+		},
+		{
+			name:   "jira reference is trusted without a jira client",
+			title:  "PROJ-2: add feature",
+			noJira: true,
+		},
+		{
+			name:  "bugzilla bug in title is linked",
+			title: "Bug 123: fix crash",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ghc := fakegithub.NewFakeClient()
+			ghc.Issues = issues
+			for _, label := range tc.labels {
+				ghc.IssueLabelsExisting = append(ghc.IssueLabelsExisting, "org/repo#1:"+label)
+			}
+			var jc jiraClient
+			if !tc.noJira {
+				jc = &fakejira.FakeClient{Issues: jiraIssues}
+			}
+			cp := &fakePruner{}
+			pr := &github.PullRequest{
+				Number: 1,
+				Title:  tc.title,
+				Body:   tc.body,
+				Base: github.PullRequestBranch{
+					Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				},
+			}
+
+			if err := handle(logrus.WithField("plugin", PluginName), ghc, jc, cp, cfg, pr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var expectedAdded, expectedRemoved []string
+			for _, label := range tc.expectedAdded {
+				expectedAdded = append(expectedAdded, "org/repo#1:"+label)
+			}
+			for _, label := range tc.expectedRemove {
+				expectedRemoved = append(expectedRemoved, "org/repo#1:"+label)
+			}
+			if diff := cmp.Diff(expectedAdded, ghc.IssueLabelsAdded); diff != "" {
+				t.Errorf("unexpected added labels (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(expectedRemoved, ghc.IssueLabelsRemoved); diff != "" {
+				t.Errorf("unexpected removed labels (-want +got):\n%s", diff)
+			}
+			if commented := len(ghc.IssueComments[1]) > 0; commented != tc.expectComment {
+				t.Errorf("expected comment: %t, got %t", tc.expectComment, commented)
+			}
+			if cp.pruned != tc.expectPrune {
+				t.Errorf("expected prune: %t, got %t", tc.expectPrune, cp.pruned)
+			}
+		})
+	}
+}
+
+func TestGithubReferences(t *testing.T) {
+	text := "Fixes #1, relates to other/repo#2 and (#1).\nSee https://github.com/org/repo/issues/3 and https://github.com/org/repo/pull/4#issuecomment-5."
+	expected := []reference{
+		{org: "org", repo: "repo", number: 1},
+		{org: "other", repo: "repo", number: 2},
+		{org: "org", repo: "repo", number: 3},
+	}
+	if diff := cmp.Diff(expected, githubReferences("org", "repo", text), cmp.AllowUnexported(reference{})); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+}
+
+func TestJiraReferences(t *testing.T) {
+	actual := jiraReferences([]string{"Proj"}, "PROJ-1: fix, see proj-1 and OTHER-2 and PROJ-3")
+	if diff := cmp.Diff([]string{"PROJ-1", "PROJ-3"}, actual); diff != "" {
+		t.Errorf("unexpected keys (-want +got):\n%s", diff)
+	}
+	if actual := jiraReferences(nil, "PROJ-1"); len(actual) != 0 {
+		t.Errorf("expected no keys without configured projects, got %v", actual)
+	}
+}
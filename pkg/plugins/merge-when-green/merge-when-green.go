@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mergewhengreen implements the `/merge-when-green` command for repos
+// that are not part of any Tide query. The command labels an approved PR with
+// the `merge-when-green` label, Tide then merges it once its required contexts
+// pass.
+package mergewhengreen
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "merge-when-green"
+
+var mergeWhenGreenRe = regexp.MustCompile(`(?mi)^/merge-when-green(\s+cancel)?\s*$`)
+
+func init() {
+	plugins.RegisterGenericCommentHandler(PluginName, handleGenericComment, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	// The Config field is omitted because the repos are configured in the Tide config.
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The merge-when-green plugin allows collaborators to have approved PRs in repos that are not part of any Tide query merged once all required contexts pass. The repos need to be listed in `tide.merge_when_green` of the Prow config.",
+	}
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/merge-when-green [cancel]",
+		Description: "Adds or removes the `" + labels.MergeWhenGreen + "` label which makes Tide merge the approved PR once all required contexts pass.",
+		Featured:    false,
+		WhoCanUse:   "Collaborators of the repo.",
+		Examples:    []string{"/merge-when-green", "/merge-when-green cancel"},
+	})
+	return pluginHelp, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	IsCollaborator(org, repo, user string) (bool, error)
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
+	return handle(pc.GitHubClient, pc.Logger, pc.Config.Tide, &e)
+}
+
+func handle(gc githubClient, log *logrus.Entry, tide config.Tide, e *github.GenericCommentEvent) error {
+	if !e.IsPR || e.Action != github.GenericCommentActionCreated {
+		return nil
+	}
+	match := mergeWhenGreenRe.FindStringSubmatch(e.Body)
+	if match == nil {
+		return nil
+	}
+	cancel := match[1] != ""
+
+	org := e.Repo.Owner.Login
+	repo := e.Repo.Name
+	respond := func(message string) error {
+		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, message))
+	}
+
+	isCollaborator, err := gc.IsCollaborator(org, repo, e.User.Login)
+	if err != nil {
+		return fmt.Errorf("failed to check if %s is a collaborator of %s/%s: %w", e.User.Login, org, repo, err)
+	}
+	if !isCollaborator {
+		return respond("Only collaborators of this repository can use `/merge-when-green`.")
+	}
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, e.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels on %s/%s#%d: %w", org, repo, e.Number, err)
+	}
+	hasLabel := github.HasLabel(labels.MergeWhenGreen, issueLabels)
+
+	if cancel {
+		if !hasLabel {
+			return nil
+		}
+		log.Infof("Removing %q label for %s/%s#%d", labels.MergeWhenGreen, org, repo, e.Number)
+		return gc.RemoveLabel(org, repo, e.Number, labels.MergeWhenGreen)
+	}
+
+	orgRepo := config.OrgRepo{Org: org, Repo: repo}
+	if len(tide.Queries.QueryMap().ForRepo(orgRepo)) > 0 {
+		return respond("This repository is already merged by Tide, PRs are merged automatically once they meet the merge requirements.")
+	}
+	if !tide.MergeWhenGreen.ForRepo(orgRepo) {
+		return respond(fmt.Sprintf("`/merge-when-green` is not enabled for %s. It needs to be added to `tide.merge_when_green` in the Prow config.", orgRepo.String()))
+	}
+	if !github.HasLabel(labels.Approved, issueLabels) {
+		return respond(fmt.Sprintf("This PR needs the %q label before it can be merged when green.", labels.Approved))
+	}
+	if hasLabel {
+		return nil
+	}
+	log.Infof("Adding %q label for %s/%s#%d", labels.MergeWhenGreen, org, repo, e.Number)
+	return gc.AddLabel(org, repo, e.Number, labels.MergeWhenGreen)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mergewhengreen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+)
+
+func TestHandle(t *testing.T) {
+	enabled := config.Tide{TideGitHubConfig: config.TideGitHubConfig{
+		MergeWhenGreen: &config.TideMergeWhenGreen{Repos: []string{"org/repo"}},
+	}}
+	testCases := []struct {
+		name            string
+		body            string
+		commenter       string
+		tide            config.Tide
+		existingLabels  []string
+		expectedAdded   []string
+		expectedRemoved []string
+		expectedComment string
+	}{
+		{
+			name: "unrelated comment",
+			body: "/merge-when-greenish",
+			tide: enabled,
+		},
+		{
+			name:           "approved PR is labeled",
+			body:           "/merge-when-green",
+			tide:           enabled,
+			existingLabels: []string{labels.Approved},
+			expectedAdded:  []string{"org/repo#1:" + labels.MergeWhenGreen},
+		},
+		{
+			name:           "label is only added once",
+			body:           "/merge-when-green",
+			tide:           enabled,
+			existingLabels: []string{labels.Approved, labels.MergeWhenGreen},
+		},
+		{
+			name:            "unapproved PR is not labeled",
+			body:            "/merge-when-green",
+			tide:            enabled,
+			expectedComment: `needs the "approved" label`,
+		},
+		{
+			name:            "non-collaborators cannot use the command",
+			body:            "/merge-when-green",
+			commenter:       "stranger",
+			tide:            enabled,
+			existingLabels:  []string{labels.Approved},
+			expectedComment: "Only collaborators",
+		},
+		{
+			name:            "repo is not enabled",
+			body:            "/merge-when-green",
+			existingLabels:  []string{labels.Approved},
+			expectedComment: "not enabled for org/repo",
+		},
+		{
+			name: "repo is part of a Tide query",
+			body: "/merge-when-green",
+			tide: config.Tide{TideGitHubConfig: config.TideGitHubConfig{
+				Queries:        config.TideQueries{{Orgs: []string{"org"}}},
+				MergeWhenGreen: &config.TideMergeWhenGreen{Orgs: []string{"org"}},
+			}},
+			existingLabels:  []string{labels.Approved},
+			expectedComment: "already merged by Tide",
+		},
+		{
+			name:            "cancel removes the label",
+			body:            "/merge-when-green cancel",
+			tide:            enabled,
+			existingLabels:  []string{labels.Approved, labels.MergeWhenGreen},
+			expectedRemoved: []string{"org/repo#1:" + labels.MergeWhenGreen},
+		},
+		{
+			name:           "cancel without label is a no-op",
+			body:           "/merge-when-green cancel",
+			tide:           enabled,
+			existingLabels: []string{labels.Approved},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.Collaborators = []string{"collaborator"}
+			for _, label := range tc.existingLabels {
+				fc.IssueLabelsExisting = append(fc.IssueLabelsExisting, "org/repo#1:"+label)
+			}
+			commenter := tc.commenter
+			if commenter == "" {
+				commenter = "collaborator"
+			}
+			e := &github.GenericCommentEvent{
+				Action: github.GenericCommentActionCreated,
+				IsPR:   true,
+				Body:   tc.body,
+				Number: 1,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				User:   github.User{Login: commenter},
+			}
+			if err := handle(fc, logrus.WithField("plugin", PluginName), tc.tide, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedAdded, fc.IssueLabelsAdded); diff != "" {
+				t.Errorf("unexpected added labels (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedRemoved, fc.IssueLabelsRemoved); diff != "" {
+				t.Errorf("unexpected removed labels (-want +got):\n%s", diff)
+			}
+			if tc.expectedComment == "" {
+				if len(fc.IssueCommentsAdded) != 0 {
+					t.Errorf("expected no comment, got %v", fc.IssueCommentsAdded)
+				}
+			} else if len(fc.IssueCommentsAdded) != 1 || !strings.Contains(fc.IssueCommentsAdded[0], tc.expectedComment) {
+				t.Errorf("expected a comment containing %q, got %v", tc.expectedComment, fc.IssueCommentsAdded)
+			}
+		})
+	}
+}
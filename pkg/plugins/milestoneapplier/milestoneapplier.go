@@ -19,7 +19,10 @@ limitations under the License.
 package milestoneapplier
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -35,6 +38,7 @@ const pluginName = "milestoneapplier"
 
 type githubClient interface {
 	SetMilestone(org, repo string, issueNum, milestoneNum int) error
+	ClearMilestone(org, repo string, issueNum int) error
 	ListMilestones(org, repo string) ([]github.Milestone, error)
 }
 
@@ -56,8 +60,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
 		MilestoneApplier: map[string]plugins.BranchToMilestone{
 			"kubernetes/kubernetes": {
-				"release-1.19": "v1.19",
-				"release-1.18": "v1.18",
+				"release-1.19":         "v1.19",
+				"release-1.18":         "v1.18",
+				`/release-(\d+\.\d+)/`: "v$1",
 			},
 		},
 	})
@@ -65,7 +70,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", pluginName)
 	}
 	return &pluginhelp.PluginHelp{
-		Description: "The milestoneapplier plugin automatically applies the configured milestone for the base branch after a PR is merged. If a PR targets a non-default branch, it also adds the milestone when the PR is opened.",
+		Description: "The milestoneapplier plugin automatically applies the configured milestone for the base branch after a PR is merged. If a PR targets a non-default branch, it also adds the milestone when the PR is opened or retargeted to the branch. When a PR is retargeted, the milestone of its previous base branch is removed. Branches without a milestone of their own are matched against the configured branches enclosed in slashes, which are regular expressions whose submatches can be used in the milestone.",
 		Config:      configInfo,
 		Snippet:     yamlSnippet,
 	}, nil
@@ -81,37 +86,47 @@ func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
 	if !ok {
 		return nil
 	}
-	// if the repo does not define milestones for this branch, return early
-	milestone, ok := branchToMilestone[baseBranch]
-	if !ok {
-		return nil
+	// if the repo does not define milestones for this branch or the one
+	// the PR was retargeted from, return early
+	if _, ok := milestoneForBranch(branchToMilestone, baseBranch); !ok {
+		if _, ok := milestoneForBranch(branchToMilestone, retargetedFrom(pre)); !ok {
+			return nil
+		}
 	}
 
-	return handle(pc.GitHubClient, pc.Logger, milestone, pre)
+	return handle(pc.GitHubClient, pc.Logger, branchToMilestone, pre)
 }
 
-func handle(gc githubClient, log *logrus.Entry, configuredMilestone string, pre github.PullRequestEvent) error {
+func handle(gc githubClient, log *logrus.Entry, branchToMilestone plugins.BranchToMilestone, pre github.PullRequestEvent) error {
 	pr := pre.PullRequest
+	number := pre.Number
+	org := pr.Base.Repo.Owner.Login
+	repo := pr.Base.Repo.Name
+	previousBranch := retargetedFrom(pre)
+	configuredMilestone, configured := milestoneForBranch(branchToMilestone, pr.Base.Ref)
 
-	// if the current milestone is equal to the configured milestone, return early
-	if pr.Milestone != nil && pr.Milestone.Title == configuredMilestone {
-		return nil
-	}
-
-	// if a PR targets a non-default branch, apply milestone when opened and on merge
+	// if a PR targets a non-default branch, apply milestone when opened, retargeted and on merge
 	// if a PR targets the default branch, apply the milestone only on merge
 	merged := pre.Action == github.PullRequestActionClosed && pr.Merged
+	apply := merged
 	if pr.Base.Repo.DefaultBranch != pr.Base.Ref {
-		if !merged && pre.Action != github.PullRequestActionOpened {
-			return nil
+		apply = apply || pre.Action == github.PullRequestActionOpened || previousBranch != ""
+	}
+	if !configured || !apply {
+		// the milestone of the previous base branch of a retargeted PR does not apply anymore
+		if previousMilestone, ok := milestoneForBranch(branchToMilestone, previousBranch); ok && pr.Milestone != nil && pr.Milestone.Title == previousMilestone {
+			if err := gc.ClearMilestone(org, repo, number); err != nil {
+				log.WithError(err).Errorf("Error removing the milestone %s from %s/%s#%d.", previousMilestone, org, repo, number)
+				return err
+			}
 		}
-	} else if !merged {
 		return nil
 	}
 
-	number := pre.Number
-	org := pr.Base.Repo.Owner.Login
-	repo := pr.Base.Repo.Name
+	// if the current milestone is equal to the configured milestone, return early
+	if pr.Milestone != nil && pr.Milestone.Title == configuredMilestone {
+		return nil
+	}
 
 	milestones, err := gc.ListMilestones(org, repo)
 	if err != nil {
@@ -132,3 +147,54 @@ func handle(gc githubClient, log *logrus.Entry, configuredMilestone string, pre
 
 	return nil
 }
+
+// milestoneForBranch returns the milestone configured for the branch. Branches
+// without a milestone of their own are matched against the configured branches
+// as regular expressions, whose submatches can be referenced in the milestone,
+// e.g. `release-(\d+\.\d+)`: `v$1`.
+func milestoneForBranch(branchToMilestone plugins.BranchToMilestone, branch string) (string, bool) {
+	if branch == "" {
+		return "", false
+	}
+	if milestone, ok := branchToMilestone[branch]; ok {
+		return milestone, true
+	}
+	keys := make([]string, 0, len(branchToMilestone))
+	for key := range branchToMilestone {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pattern, ok := plugins.BranchPattern(key)
+		if !ok {
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			continue
+		}
+		if match := re.FindStringSubmatchIndex(branch); match != nil {
+			return string(re.ExpandString(nil, branchToMilestone[key], branch, match)), true
+		}
+	}
+	return "", false
+}
+
+// retargetedFrom returns the previous base branch if the event changed the
+// base branch of the PR.
+func retargetedFrom(pre github.PullRequestEvent) string {
+	if pre.Action != github.PullRequestActionEdited {
+		return ""
+	}
+	var changes struct {
+		Base struct {
+			Ref struct {
+				From string `json:"from"`
+			} `json:"ref"`
+		} `json:"base"`
+	}
+	if err := json.Unmarshal(pre.Changes, &changes); err != nil {
+		return ""
+	}
+	return changes.Base.Ref.From
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package milestoneapplier
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
 )
 
 func TestMilestoneApplier(t *testing.T) {
@@ -173,7 +176,8 @@ func TestMilestoneApplier(t *testing.T) {
 				}
 			}
 
-			if err := handle(fakeClient, logrus.WithField("plugin", pluginName), configuredMilestoneTitle, event); err != nil {
+			branchToMilestone := plugins.BranchToMilestone{tc.baseBranch: configuredMilestoneTitle}
+			if err := handle(fakeClient, logrus.WithField("plugin", pluginName), branchToMilestone, event); err != nil {
 				t.Fatalf("(%s): Unexpected error from handle: %v.", tc.name, err)
 			}
 
@@ -183,3 +187,101 @@ func TestMilestoneApplier(t *testing.T) {
 		})
 	}
 }
+
+func TestMilestoneApplierRetarget(t *testing.T) {
+	branchToMilestone := plugins.BranchToMilestone{
+		"master":               "v1.30",
+		"c++":                  "other",
+		`/release-(\d+\.\d+)/`: "v$1",
+	}
+	milestonesMap := map[string]int{"v1.27": 1, "v1.28": 2, "v1.30": 3, "other": 4}
+	testcases := []struct {
+		name              string
+		previousBranch    string
+		baseBranch        string
+		previousMilestone string
+		expectedMilestone int
+	}{
+		{
+			name:              "retargeted to another release branch => update milestone",
+			previousBranch:    "release-1.27",
+			baseBranch:        "release-1.28",
+			previousMilestone: "v1.27",
+			expectedMilestone: 2,
+		},
+		{
+			name:              "retargeted to a release branch without milestone => add milestone",
+			previousBranch:    "feature",
+			baseBranch:        "release-1.27",
+			expectedMilestone: 1,
+		},
+		{
+			name:              "retargeted to the default branch => clear milestone",
+			previousBranch:    "release-1.27",
+			baseBranch:        "master",
+			previousMilestone: "v1.27",
+			expectedMilestone: 0,
+		},
+		{
+			name:              "retargeted to an unmapped branch => clear milestone",
+			previousBranch:    "release-1.27",
+			baseBranch:        "feature",
+			previousMilestone: "v1.27",
+			expectedMilestone: 0,
+		},
+		{
+			name:              "retargeted to an unmapped branch with a manually set milestone => keep milestone",
+			previousBranch:    "release-1.27",
+			baseBranch:        "feature",
+			previousMilestone: "other",
+			expectedMilestone: 4,
+		},
+		{
+			name:              "retargeted to a branch that only matches a plain branch as a pattern => do nothing",
+			previousBranch:    "feature",
+			baseBranch:        "cc",
+			expectedMilestone: 0,
+		},
+		{
+			name:              "edited without retargeting => do nothing",
+			baseBranch:        "release-1.28",
+			previousMilestone: "other",
+			expectedMilestone: 4,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := github.PullRequest{
+				Number: 1,
+				Base: github.PullRequestBranch{
+					Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo", DefaultBranch: "master"},
+					Ref:  tc.baseBranch,
+				},
+			}
+			fakeClient := fakegithub.NewFakeClient()
+			fakeClient.MilestoneMap = milestonesMap
+			if tc.previousMilestone != "" {
+				pr.Milestone = &github.Milestone{Title: tc.previousMilestone, Number: milestonesMap[tc.previousMilestone]}
+				fakeClient.Milestone = milestonesMap[tc.previousMilestone]
+			}
+			changes := "{}"
+			if tc.previousBranch != "" {
+				changes = fmt.Sprintf(`{"base":{"ref":{"from":%q}}}`, tc.previousBranch)
+			}
+			event := github.PullRequestEvent{
+				Action:      github.PullRequestActionEdited,
+				Number:      pr.Number,
+				PullRequest: pr,
+				Changes:     json.RawMessage(changes),
+			}
+
+			if err := handle(fakeClient, logrus.WithField("plugin", pluginName), branchToMilestone, event); err != nil {
+				t.Fatalf("Unexpected error from handle: %v.", err)
+			}
+			if fakeClient.Milestone != tc.expectedMilestone {
+				t.Errorf("expected milestone: %d, received milestone: %d", tc.expectedMilestone, fakeClient.Milestone)
+			}
+		})
+	}
+}
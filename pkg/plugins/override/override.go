@@ -161,7 +161,20 @@ func init() {
 	plugins.RegisterGenericCommentHandler(pluginName, handleGenericComment, helpProvider)
 }
 
-func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+// overrideCommand describes the /override command, the arguments of every
+// /override command are validated against it.
+var overrideCommand = pluginhelp.Command{
+	Usage:       "/override [context1] [context2]",
+	Description: "Forces github status contexts to green (multiple can be given). If the desired context has spaces, it must be quoted.",
+	Featured:    false,
+	Examples:    []string{"/override pull-repo-whatever", "/override \"test / Unit Tests\"", "/override ci/circleci", "/override deleted-job other-job"},
+	Name:        "override",
+	Arguments: []pluginhelp.Argument{
+		{Name: "context", Type: pluginhelp.ArgumentContext, Repeated: true, Description: "A status context or check run to force to green."},
+	},
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
 		Override: plugins.Override{
 			AllowTopLevelOwners: true,
@@ -181,13 +194,15 @@ func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhel
 	if config != nil {
 		overrideConfig = config.Override
 	}
-	pluginHelp.AddCommand(pluginhelp.Command{
-		Usage:       "/override [context1] [context2]",
-		Description: "Forces github status contexts to green (multiple can be given). If the desired context has spaces, it must be quoted.",
-		Featured:    false,
-		WhoCanUse:   whoCanUse(overrideConfig, "", ""),
-		Examples:    []string{"/override pull-repo-whatever", "/override \"test / Unit Tests\"", "/override ci/circleci", "/override deleted-job other-job"},
-	})
+	// The teams allowed to override are only specific to a repo when the help
+	// is generated for that repo alone.
+	var org, repo string
+	if len(enabledRepos) == 1 {
+		org, repo = enabledRepos[0].Org, enabledRepos[0].Repo
+	}
+	command := overrideCommand
+	command.WhoCanUse = whoCanUse(overrideConfig, org, repo)
+	pluginHelp.AddCommand(command)
 	return pluginHelp, nil
 }
 
@@ -339,12 +354,13 @@ func handle(oc overrideClient, log *logrus.Entry, e *github.GenericCommentEvent,
 
 	overrides := sets.New[string]()
 	for _, m := range mat {
-		if m[1] == "" {
-			resp := "/override requires failed status contexts to operate on, but none was given"
+		contexts := parseOverrideInput(m[2])
+		if err := overrideCommand.ValidateArgs(contexts); err != nil {
+			resp := fmt.Sprintf("Invalid command `%s`: %v. /override requires failed status contexts to operate on.", strings.TrimSpace(m[0]), err)
 			log.Debug(resp)
 			return oc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, resp))
 		}
-		overrides.Insert(parseOverrideInput(m[2])...)
+		overrides.Insert(contexts...)
 	}
 
 	authorized := authorizedUser(oc, log, org, repo, user)
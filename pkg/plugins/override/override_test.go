@@ -617,7 +617,7 @@ func TestHandle(t *testing.T) {
 				},
 			},
 			user:          "rando",
-			checkComments: []string{"but none was given"},
+			checkComments: []string{"missing argument \"context\""},
 			expected: []github.Status{
 				{
 					Context: "broken-test",
@@ -1303,10 +1303,30 @@ func TestHelpProvider(t *testing.T) {
 			expectedWho: "Repo administrators, and the following github teams:" +
 				"org1/repo1: team-foo team-bar.",
 		},
+		{
+			name: "WhoCanUse only includes the github teams of the single enabled repo",
+			config: plugins.Configuration{
+				Override: plugins.Override{
+					AllowedGitHubTeams: map[string][]string{
+						"org1/repo1": {"team-foo"},
+						"org2/repo2": {"team-bar"},
+						"org1":       {"team-foo-bar"},
+					},
+				},
+			},
+			org:  "org1",
+			repo: "repo1",
+			expectedWho: "Repo administrators, and the following github teams:" +
+				"org1/repo1: team-foo, org1: team-foo-bar.",
+		},
 	}
 
 	for _, tc := range cases {
-		help, err := helpProvider(&tc.config, []config.OrgRepo{})
+		var enabledRepos []config.OrgRepo
+		if tc.org != "" {
+			enabledRepos = append(enabledRepos, config.OrgRepo{Org: tc.org, Repo: tc.repo})
+		}
+		help, err := helpProvider(&tc.config, enabledRepos)
 		if err != nil {
 			t.Errorf("%s: unexpected error: %v", tc.name, err)
 		}
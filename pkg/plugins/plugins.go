@@ -48,6 +48,7 @@ import (
 	"sigs.k8s.io/prow/pkg/jira"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/repoowners"
+	"sigs.k8s.io/prow/pkg/scm"
 	"sigs.k8s.io/prow/pkg/slack"
 	"sigs.k8s.io/prow/pkg/version"
 )
@@ -201,11 +202,18 @@ type Agent struct {
 }
 
 // NewAgent bootstraps a new config.Agent struct from the passed dependencies.
-func NewAgent(configAgent *config.Agent, pluginConfigAgent *ConfigAgent, clientAgent *ClientAgent, githubOrg string, metrics *Metrics, logger *logrus.Entry, plugin string) Agent {
+// Its plugin config is the one for the events of githubOrg/githubRepo.
+func NewAgent(configAgent *config.Agent, pluginConfigAgent *ConfigAgent, clientAgent *ClientAgent, githubOrg, githubRepo string, metrics *Metrics, logger *logrus.Entry, plugin string) Agent {
 	logger = logger.WithField("plugin", plugin)
 	prowConfig := configAgent.Config()
-	pluginConfig := pluginConfigAgent.Config()
-	gitHubClient := &githubV4OrgAddingWrapper{org: githubOrg, Client: clientAgent.GitHubClient.WithFields(logger.Data).ForPlugin(plugin)}
+	pluginConfig := pluginConfigAgent.Config().ForRepo(githubOrg, githubRepo)
+	pluginClient := clientAgent.GitHubClient.WithFields(logger.Data).ForPlugin(plugin)
+	if len(clientAgent.SCMProviders) > 0 {
+		if provider, ok := clientAgent.SCMProviders[prowConfig.SCMProviderOf(githubOrg)]; ok {
+			pluginClient = scm.NewGitHubClient(provider, pluginClient)
+		}
+	}
+	gitHubClient := &githubV4OrgAddingWrapper{org: githubOrg, Client: pluginClient}
 	jiraClient := clientAgent.JiraClient
 	if jiraClient != nil {
 		jiraClient = clientAgent.JiraClient.WithFields(logger.Data).ForPlugin(plugin)
@@ -265,6 +273,9 @@ type ClientAgent struct {
 	OwnersClient              repoowners.Interface
 	BugzillaClient            bugzilla.Client
 	JiraClient                jira.Client
+	// SCMProviders serve the orgs that are configured to be on providers
+	// other than GitHub, keyed by the name of the provider.
+	SCMProviders map[string]scm.Provider
 }
 
 // ConfigAgent contains the agent mutex and the Agent configuration.
@@ -281,14 +292,20 @@ func NewFakeConfigAgent() ConfigAgent {
 // the file can't be read or the configuration is invalid.
 // If checkUnknownPlugins is true, unrecognized plugin names will make config
 // loading fail.
+// If checkUnknownFields is true, unrecognized fields will make config loading
+// fail. Unrecognized fields of repo_options always make config loading fail.
 // If skipResolveConfigUpdater is true, the ConfigUpdater of the config will not be resolved.
-func (pa *ConfigAgent) Load(path string, supplementalPluginConfigDirs []string, supplementalPluginConfigFileSuffix string, checkUnknownPlugins, skipResolveConfigUpdater bool) error {
+func (pa *ConfigAgent) Load(path string, supplementalPluginConfigDirs []string, supplementalPluginConfigFileSuffix string, checkUnknownPlugins, checkUnknownFields, skipResolveConfigUpdater bool) error {
+	var yamlOpts []yaml.JSONOpt
+	if checkUnknownFields {
+		yamlOpts = append(yamlOpts, yaml.DisallowUnknownFields)
+	}
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return err
 	}
 	np := &Configuration{}
-	if err := yaml.Unmarshal(b, np); err != nil {
+	if err := yaml.Unmarshal(b, np, yamlOpts...); err != nil {
 		return err
 	}
 
@@ -324,7 +341,7 @@ func (pa *ConfigAgent) Load(path string, supplementalPluginConfigDirs []string,
 			}
 
 			cfg := &Configuration{}
-			if err := yaml.Unmarshal(data, cfg); err != nil {
+			if err := yaml.Unmarshal(data, cfg, yamlOpts...); err != nil {
 				errs = append(errs, fmt.Errorf("failed to unmarshal %s: %w", path, err))
 				return nil
 			}
@@ -343,6 +360,10 @@ func (pa *ConfigAgent) Load(path string, supplementalPluginConfigDirs []string,
 		return err
 	}
 
+	// repo options are resolved before the config is defaulted
+	if err := np.resolveRepoOptions(); err != nil {
+		return err
+	}
 	if err := np.Validate(); err != nil {
 		return err
 	}
@@ -356,6 +377,19 @@ func (pa *ConfigAgent) Load(path string, supplementalPluginConfigDirs []string,
 			return err
 		}
 	}
+	for _, resolved := range np.RepoConfigs {
+		resolved.ConfigUpdater = np.ConfigUpdater
+	}
+	// The time zones only order review candidates, so carry on without them.
+	configs := []*Configuration{np}
+	for _, resolved := range np.RepoConfigs {
+		configs = append(configs, resolved)
+	}
+	for _, cfg := range configs {
+		if err := cfg.Blunderbuss.loadTimeZones(); err != nil {
+			logrus.WithError(err).Error("Failed to load blunderbuss reviewer time zones.")
+		}
+	}
 
 	pa.Set(np)
 	return nil
@@ -382,14 +416,16 @@ func (pa *ConfigAgent) Set(pc *Configuration) {
 // then start returns the error. Future errors will halt updates but not stop.
 // If checkUnknownPlugins is true, unrecognized plugin names will make config
 // loading fail.
-func (pa *ConfigAgent) Start(path string, supplementalPluginConfigDirs []string, supplementalPluginConfigFileSuffix string, checkUnknownPlugins, skipResolveConfigUpdater bool) error {
-	if err := pa.Load(path, supplementalPluginConfigDirs, supplementalPluginConfigFileSuffix, checkUnknownPlugins, skipResolveConfigUpdater); err != nil {
+// If checkUnknownFields is true, unrecognized fields will make config loading
+// fail.
+func (pa *ConfigAgent) Start(path string, supplementalPluginConfigDirs []string, supplementalPluginConfigFileSuffix string, checkUnknownPlugins, checkUnknownFields, skipResolveConfigUpdater bool) error {
+	if err := pa.Load(path, supplementalPluginConfigDirs, supplementalPluginConfigFileSuffix, checkUnknownPlugins, checkUnknownFields, skipResolveConfigUpdater); err != nil {
 		return err
 	}
 	ticker := time.NewTicker(time.Minute)
 	go func() {
 		for range ticker.C {
-			if err := pa.Load(path, supplementalPluginConfigDirs, supplementalPluginConfigFileSuffix, checkUnknownPlugins, skipResolveConfigUpdater); err != nil {
+			if err := pa.Load(path, supplementalPluginConfigDirs, supplementalPluginConfigFileSuffix, checkUnknownPlugins, checkUnknownFields, skipResolveConfigUpdater); err != nil {
 				logrus.WithField("path", path).WithError(err).Error("Error loading plugin config.")
 			}
 		}
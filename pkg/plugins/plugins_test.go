@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -333,7 +334,7 @@ plugins:
 			}
 
 			agent := &ConfigAgent{}
-			if err := agent.Load(filepath.Join(tempDir, "_plugins.yaml"), []string{tempDir}, tc.supplementalPluginConfigFileSuffix, false, false); err != nil {
+			if err := agent.Load(filepath.Join(tempDir, "_plugins.yaml"), []string{tempDir}, tc.supplementalPluginConfigFileSuffix, false, false, false); err != nil {
 				t.Fatalf("failed to load: %v", err)
 			}
 
@@ -421,7 +422,7 @@ func TestLoadConfigUpdater(t *testing.T) {
 				t.Fatalf("failed to write config: %v", err)
 			}
 			agent := &ConfigAgent{}
-			if err := agent.Load(filepath.Join(tempDir, "_plugins.yaml"), nil, "", false, tc.skipResolveConfigUpdater); err != nil {
+			if err := agent.Load(filepath.Join(tempDir, "_plugins.yaml"), nil, "", false, false, tc.skipResolveConfigUpdater); err != nil {
 				t.Fatalf("failed to load: %v", err)
 			}
 			if diff := cmp.Diff(tc.expected, agent.Config().ConfigUpdater); diff != "" {
@@ -431,3 +432,157 @@ func TestLoadConfigUpdater(t *testing.T) {
 	}
 
 }
+
+func TestLoadRepoOptions(t *testing.T) {
+	t.Parallel()
+
+	const config = `
+plugins:
+  org:
+    plugins:
+    - size
+size:
+  s: 10
+  m: 30
+  l: 100
+  xl: 500
+  xxl: 1000
+lgtm:
+- repos:
+  - org
+  review_acts_as_lgtm: true
+repo_options:
+  org:
+    size:
+      m: 20
+  org/repo:
+    size:
+      s: 5
+    lgtm:
+    - repos:
+      - org/repo
+      review_acts_as_lgtm: false
+`
+	testCases := []struct {
+		name               string
+		config             string
+		supplementalConfig string
+		checkUnknownFields bool
+		expectedErr        string
+	}{
+		{
+			name:   "valid repo options",
+			config: config,
+		},
+		{
+			name:   "repo options in a supplemental config",
+			config: "plugins:\n  org:\n    plugins:\n    - size\nsize:\n  s: 10\n  m: 30\n  l: 100\n  xl: 500\n  xxl: 1000\nlgtm:\n- repos:\n  - org\n  review_acts_as_lgtm: true\n",
+			supplementalConfig: `
+repo_options:
+  org:
+    size:
+      m: 20
+  org/repo:
+    size:
+      s: 5
+    lgtm:
+    - repos:
+      - org/repo
+      review_acts_as_lgtm: false
+`,
+		},
+		{
+			name:               "duplicate repo options in a supplemental config",
+			config:             config,
+			supplementalConfig: "repo_options:\n  org:\n    size:\n      m: 20\n",
+			expectedErr:        "found duplicate repo_options for org",
+		},
+		{
+			name:        "unknown field in repo options",
+			config:      "repo_options:\n  org/repo:\n    size:\n      xxxl: 5\n",
+			expectedErr: `repo_options of org/repo: error unmarshaling JSON: while decoding JSON: json: unknown field "xxxl"`,
+		},
+		{
+			name:        "plugins cannot be overridden",
+			config:      "repo_options:\n  org:\n    plugins:\n      org/repo:\n        plugins:\n        - size\n",
+			expectedErr: "repo_options of org: the plugins option cannot be overridden",
+		},
+		{
+			name:        "invalid key",
+			config:      "repo_options:\n  org/repo/path: {}\n",
+			expectedErr: `repo_options: "org/repo/path" is neither an organization nor a repository`,
+		},
+		{
+			name:        "invalid resolved config",
+			config:      "repo_options:\n  org:\n    size:\n      s: 100\n",
+			expectedErr: "repo_options of org: invalid size plugin configuration",
+		},
+		{
+			name:   "unknown field is ignored",
+			config: "sizes:\n  s: 5\n",
+		},
+		{
+			name:               "unknown field is rejected in strict mode",
+			config:             "sizes:\n  s: 5\n",
+			checkUnknownFields: true,
+			expectedErr:        `unknown field "sizes"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tempDir, "_plugins.yaml"), []byte(tc.config), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			if tc.supplementalConfig != "" {
+				if err := os.WriteFile(filepath.Join(tempDir, "org_pluginconfig.yaml"), []byte(tc.supplementalConfig), 0644); err != nil {
+					t.Fatalf("failed to write supplemental config: %v", err)
+				}
+			}
+			agent := &ConfigAgent{}
+			err := agent.Load(filepath.Join(tempDir, "_plugins.yaml"), []string{tempDir}, "_pluginconfig.yaml", false, tc.checkUnknownFields, false)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load: %v", err)
+			}
+			if tc.config != config && tc.supplementalConfig == "" {
+				return
+			}
+
+			cfg := agent.Config()
+			for _, expected := range []struct {
+				org, repo        string
+				size             Size
+				reviewActsAsLgtm bool
+			}{
+				{org: "other", repo: "repo", size: Size{S: 10, M: 30, L: 100, Xl: 500, Xxl: 1000}, reviewActsAsLgtm: false},
+				{org: "org", repo: "other", size: Size{S: 10, M: 20, L: 100, Xl: 500, Xxl: 1000}, reviewActsAsLgtm: true},
+				{org: "org", repo: "repo", size: Size{S: 5, M: 20, L: 100, Xl: 500, Xxl: 1000}, reviewActsAsLgtm: false},
+			} {
+				repoCfg := cfg.ForRepo(expected.org, expected.repo)
+				if diff := cmp.Diff(expected.size, repoCfg.Size); diff != "" {
+					t.Errorf("size of %s/%s differs from expected (-want +got):\n%s", expected.org, expected.repo, diff)
+				}
+				lgtm := repoCfg.LgtmFor(expected.org, expected.repo)
+				if lgtm.ReviewActsAsLgtm != expected.reviewActsAsLgtm {
+					t.Errorf("expected review_acts_as_lgtm of %s/%s to be %t", expected.org, expected.repo, expected.reviewActsAsLgtm)
+				}
+				if diff := cmp.Diff(cfg.Plugins, repoCfg.Plugins); diff != "" {
+					t.Errorf("plugins of %s/%s differ from expected (-want +got):\n%s", expected.org, expected.repo, diff)
+				}
+				if diff := cmp.Diff(cfg.ConfigUpdater, repoCfg.ConfigUpdater); diff != "" {
+					t.Errorf("config updater of %s/%s differs from expected (-want +got):\n%s", expected.org, expected.repo, diff)
+				}
+			}
+		})
+	}
+}
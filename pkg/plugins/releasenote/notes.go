@@ -0,0 +1,246 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasenote
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// Note is the release note of a merged pull request.
+type Note struct {
+	Number         int               `json:"number"`
+	Title          string            `json:"title"`
+	URL            string            `json:"url"`
+	Author         string            `json:"author"`
+	Text           string            `json:"text"`
+	ActionRequired bool              `json:"action_required"`
+	Fields         map[string]string `json:"fields,omitempty"`
+}
+
+// searchResultLimit is the maximum number of results the search API returns
+// for a query.
+const searchResultLimit = 1000
+
+type notesClient interface {
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	GetRepo(owner, name string) (github.FullRepo, error)
+}
+
+// NotesHandler serves the release notes of the pull requests merged into a
+// milestone for release tooling. The repo and the milestone are given by the
+// "repo" and "milestone" query parameters. Notes are served as JSON, or as
+// markdown if the "format" query parameter is "markdown". As the handler is
+// not authenticated, it only serves public repos that enable the plugin.
+type NotesHandler struct {
+	gc        notesClient
+	getConfig func() *plugins.Configuration
+	log       *logrus.Entry
+}
+
+// NewNotesHandler returns a handler serving the release notes of milestones.
+func NewNotesHandler(gc notesClient, getConfig func() *plugins.Configuration) *NotesHandler {
+	return &NotesHandler{
+		gc:        gc,
+		getConfig: getConfig,
+		log:       logrus.WithField("plugin", PluginName),
+	}
+}
+
+func (h *NotesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method != http.MethodGet {
+		http.Error(w, "405 Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	org, repo, found := strings.Cut(r.URL.Query().Get("repo"), "/")
+	milestone := r.URL.Query().Get("milestone")
+	if !found || org == "" || repo == "" || milestone == "" {
+		http.Error(w, "400 Bad request: the repo (as org/repo) and milestone query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	if !pluginEnabled(h.getConfig(), org, repo) {
+		http.Error(w, fmt.Sprintf("404 Not found: the %s plugin is not enabled for %s/%s", PluginName, org, repo), http.StatusNotFound)
+		return
+	}
+	if fullRepo, err := h.gc.GetRepo(org, repo); err != nil {
+		h.log.WithError(err).Errorf("Failed to get %s/%s.", org, repo)
+		http.Error(w, fmt.Sprintf("500 Internal server error: %v", err), http.StatusInternalServerError)
+		return
+	} else if fullRepo.Private {
+		http.Error(w, fmt.Sprintf("404 Not found: the release notes of private repo %s/%s are not served", org, repo), http.StatusNotFound)
+		return
+	}
+
+	cfg := h.getConfig().ReleaseNoteFor(org, repo)
+	notes, err := notesForMilestone(h.gc, cfg, org, repo, milestone)
+	if err != nil {
+		h.log.WithError(err).Errorf("Failed to get the release notes of milestone %q of %s/%s.", milestone, org, repo)
+		http.Error(w, fmt.Sprintf("500 Internal server error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "markdown" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		fmt.Fprint(w, notesMarkdown(notes, cfg))
+		return
+	}
+	b, err := json.Marshal(notes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("500 Internal server error marshaling release notes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, string(b))
+}
+
+// pluginEnabled determines if the plugin is enabled for the repo.
+func pluginEnabled(cfg *plugins.Configuration, org, repo string) bool {
+	orgs, repos, orgExceptions := cfg.EnabledReposForPlugin(PluginName)
+	for _, enabled := range repos {
+		if enabled == org+"/"+repo {
+			return true
+		}
+	}
+	for _, enabled := range orgs {
+		if enabled == org && !orgExceptions[org].Has(org+"/"+repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// notesForMilestone collects the release notes of the pull requests merged
+// into the milestone. Pull requests without a release note are skipped.
+func notesForMilestone(gc notesClient, cfg *plugins.ReleaseNote, org, repo, milestone string) ([]Note, error) {
+	prs, err := mergedPullRequests(gc, org, repo, milestone)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := []Note{}
+	for _, pr := range prs {
+		text := getReleaseNote(pr.Body)
+		if text == "" || noneRe.MatchString(text) || pr.HasLabel(labels.ReleaseNoteNone) {
+			continue
+		}
+		note := Note{
+			Number:         pr.Number,
+			Title:          pr.Title,
+			URL:            pr.HTMLURL,
+			Author:         pr.User.Login,
+			Text:           text,
+			ActionRequired: pr.HasLabel(labels.ReleaseNoteActionRequired),
+		}
+		if len(cfg.Categories) > 0 {
+			parsed, _ := parseNote(text, cfg)
+			note.Text = parsed.Text
+			note.Fields = parsed.Fields
+			note.ActionRequired = note.ActionRequired || parsed.ActionRequired
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// mergedPullRequests searches for the pull requests merged into the
+// milestone. The search API returns at most searchResultLimit results, so the
+// search continues from the creation time of the last result until a search
+// returns fewer results.
+func mergedPullRequests(gc notesClient, org, repo, milestone string) ([]github.Issue, error) {
+	query := fmt.Sprintf("is:pr is:merged repo:%s/%s milestone:%q", org, repo, milestone)
+	seen := map[int]bool{}
+	var prs []github.Issue
+	for q := query; ; {
+		results, err := gc.FindIssuesWithOrg(org, q, "created", true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for the pull requests of milestone %q: %w", milestone, err)
+		}
+		found := false
+		for _, pr := range results {
+			if !seen[pr.Number] {
+				seen[pr.Number] = true
+				found = true
+				prs = append(prs, pr)
+			}
+		}
+		if len(results) < searchResultLimit || !found {
+			return prs, nil
+		}
+		q = fmt.Sprintf("%s created:>=%s", query, results[len(results)-1].CreatedAt.UTC().Format(time.RFC3339))
+	}
+}
+
+// notesMarkdown renders the notes as markdown. Notes that require action are
+// listed first, the others are grouped by the value of the first category.
+func notesMarkdown(notes []Note, cfg *plugins.ReleaseNote) string {
+	var groupBy string
+	if len(cfg.Categories) > 0 {
+		groupBy = cfg.Categories[0].Name
+	}
+
+	var actionRequired []Note
+	groups := map[string][]Note{}
+	for _, note := range notes {
+		if note.ActionRequired {
+			actionRequired = append(actionRequired, note)
+			continue
+		}
+		groups[note.Fields[groupBy]] = append(groups[note.Fields[groupBy]], note)
+	}
+
+	var buf bytes.Buffer
+	writeSection := func(title string, notes []Note) {
+		if len(notes) == 0 {
+			return
+		}
+		fmt.Fprintf(&buf, "## %s\n\n", title)
+		for _, note := range notes {
+			fmt.Fprintf(&buf, "- %s ([#%d](%s), @%s)\n", strings.ReplaceAll(note.Text, "\n", "\n  "), note.Number, note.URL, note.Author)
+		}
+		buf.WriteString("\n")
+	}
+	writeSection("Action Required", actionRequired)
+	var values []string
+	for value := range groups {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		writeSection(fmt.Sprintf("%s: %s", groupBy, value), groups[value])
+	}
+	other := "Other"
+	if groupBy == "" {
+		other = "Changes"
+	}
+	writeSection(other, groups[""])
+	return buf.String()
+}
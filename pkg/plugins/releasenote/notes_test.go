@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasenote
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestNotesHandler(t *testing.T) {
+	fc := fakegithub.NewFakeClient()
+	fc.Issues = map[int]*github.Issue{
+		1: {Number: 1, Title: "Speed up Tide", HTMLURL: "https://github.com/org/repo/pull/1", User: github.User{Login: "alice"},
+			Body: "```release-note\nkind: feature\narea: tide\nTide merges faster.\n```"},
+		2: {Number: 2, Title: "Rename a flag", HTMLURL: "https://github.com/org/repo/pull/2", User: github.User{Login: "bob"},
+			Body:   "```release-note\nkind: cleanup\nThe --foo flag was renamed to --bar.\n```",
+			Labels: []github.Label{{Name: labels.ReleaseNoteActionRequired}}},
+		3: {Number: 3, Title: "Fix a typo", Body: "```release-note\nNONE\n```", Labels: []github.Label{{Name: labels.ReleaseNoteNone}}},
+	}
+	cfg := &plugins.Configuration{
+		Plugins: plugins.Plugins{"org": {Plugins: []string{PluginName}, ExcludedRepos: []string{"excluded"}}},
+		ReleaseNote: map[string]*plugins.ReleaseNote{
+			"org": {Categories: []plugins.ReleaseNoteCategory{{Name: "kind"}, {Name: "area"}}},
+		},
+	}
+	handler := NewNotesHandler(fc, func() *plugins.Configuration { return cfg })
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/release-notes?repo=org/repo&milestone=v1.0", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	var notes []Note
+	if err := json.Unmarshal(recorder.Body.Bytes(), &notes); err != nil {
+		t.Fatalf("failed to unmarshal notes: %v", err)
+	}
+	expected := map[int]Note{
+		1: {Number: 1, Title: "Speed up Tide", URL: "https://github.com/org/repo/pull/1", Author: "alice", Text: "Tide merges faster.", Fields: map[string]string{"kind": "feature", "area": "tide"}},
+		2: {Number: 2, Title: "Rename a flag", URL: "https://github.com/org/repo/pull/2", Author: "bob", Text: "The --foo flag was renamed to --bar.", ActionRequired: true, Fields: map[string]string{"kind": "cleanup"}},
+	}
+	actual := map[int]Note{}
+	for _, note := range notes {
+		actual[note.Number] = note
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected notes (-want +got):\n%s", diff)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/release-notes?repo=org/repo&milestone=v1.0&format=markdown", nil))
+	expectedMarkdown := `## Action Required
+
+- The --foo flag was renamed to --bar. ([#2](https://github.com/org/repo/pull/2), @bob)
+
+## kind: feature
+
+- Tide merges faster. ([#1](https://github.com/org/repo/pull/1), @alice)
+
+`
+	if diff := cmp.Diff(expectedMarkdown, recorder.Body.String()); diff != "" {
+		t.Errorf("unexpected markdown (-want +got):\n%s", diff)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/release-notes?repo=org", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a request without a milestone, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	for _, repo := range []string{"org/excluded", "other/repo"} {
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/release-notes?repo="+repo+"&milestone=v1.0", nil))
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status %d for %s without the plugin, got %d", http.StatusNotFound, repo, recorder.Code)
+		}
+	}
+
+	private := NewNotesHandler(&privateRepoClient{fc}, func() *plugins.Configuration { return cfg })
+	recorder = httptest.NewRecorder()
+	private.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/release-notes?repo=org/repo&milestone=v1.0", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a private repo, got %d", http.StatusNotFound, recorder.Code)
+	}
+}
+
+type privateRepoClient struct {
+	*fakegithub.FakeClient
+}
+
+func (c *privateRepoClient) GetRepo(owner, name string) (github.FullRepo, error) {
+	repo, err := c.FakeClient.GetRepo(owner, name)
+	repo.Private = true
+	return repo, err
+}
+
+// pagedSearchClient returns at most searchResultLimit results per search,
+// continuing from the creation time in the query.
+type pagedSearchClient struct {
+	*fakegithub.FakeClient
+	prs     []github.Issue
+	queries []string
+}
+
+func (c *pagedSearchClient) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	c.queries = append(c.queries, query)
+	var from time.Time
+	if _, created, found := strings.Cut(query, " created:>="); found {
+		from, _ = time.Parse(time.RFC3339, created)
+	}
+	var results []github.Issue
+	for _, pr := range c.prs {
+		if !pr.CreatedAt.Before(from) && len(results) < searchResultLimit {
+			results = append(results, pr)
+		}
+	}
+	return results, nil
+}
+
+func TestMergedPullRequestsBeyondSearchLimit(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	gc := &pagedSearchClient{FakeClient: fakegithub.NewFakeClient()}
+	for i := 0; i < 2500; i++ {
+		// Two pull requests are created every second.
+		gc.prs = append(gc.prs, github.Issue{Number: i + 1, CreatedAt: start.Add(time.Duration(i/2) * time.Second)})
+	}
+	prs, err := mergedPullRequests(gc, "org", "repo", "v1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != len(gc.prs) {
+		t.Errorf("expected %d pull requests, got %d", len(gc.prs), len(prs))
+	}
+	if len(gc.queries) != 3 {
+		t.Errorf("expected 3 searches, got %d: %v", len(gc.queries), gc.queries)
+	}
+}
@@ -41,6 +41,7 @@ const (
 	releaseNoteFormat            = `Adding the "%s" label because no release-note block was detected, please follow our [release note process](https://git.k8s.io/community/contributors/guide/release-notes.md) to remove it.`
 	parentReleaseNoteFormat      = `All 'parent' PRs of a cherry-pick PR must have one of the %q or %q labels, or this PR must follow the standard/parent release note labeling requirement.`
 	releaseNoteDeprecationFormat = `Adding the "%s" label and removing any existing "%s" label because there is a "%s" label on the PR.`
+	invalidReleaseNoteFormat     = `Adding the "%s" label because the release-note block does not follow the release note schema of this repository.`
 
 	actionRequiredNote = "action required"
 )
@@ -49,6 +50,7 @@ var (
 	releaseNoteBody            = fmt.Sprintf(releaseNoteFormat, labels.ReleaseNoteLabelNeeded)
 	parentReleaseNoteBody      = fmt.Sprintf(parentReleaseNoteFormat, labels.ReleaseNote, labels.ReleaseNoteActionRequired)
 	releaseNoteDeprecationBody = fmt.Sprintf(releaseNoteDeprecationFormat, labels.ReleaseNoteLabelNeeded, labels.ReleaseNoteNone, labels.DeprecationLabel)
+	invalidReleaseNoteBody     = fmt.Sprintf(invalidReleaseNoteFormat, labels.ReleaseNoteLabelNeeded)
 
 	noteMatcherRE = regexp.MustCompile(`(?s)(?:Release note\*\*:\s*(?:<!--[^<>]*-->\s*)?` + "```(?:release-note)?|```release-note)(.+?)```")
 	cpRe          = regexp.MustCompile(`Cherry pick of #([[:digit:]]+) on release-([[:digit:]]+\.[[:digit:]]+).`)
@@ -72,13 +74,48 @@ func init() {
 	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
 }
 
-func helpProvider(_ *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		cfg := config.ReleaseNoteFor(repo.Org, repo.Repo)
+		if len(cfg.Categories) == 0 {
+			continue
+		}
+		var fields []string
+		for _, category := range cfg.Categories {
+			field := "`" + category.Name + "`"
+			if len(category.Values) > 0 {
+				field += " (one of " + strings.Join(category.Values, ", ") + ")"
+			}
+			if category.Required {
+				field += ", required"
+			}
+			fields = append(fields, field)
+		}
+		configInfo[repo.String()] = fmt.Sprintf("Release notes start with the fields %s and `%s: true|false`.", strings.Join(fields, "; "), actionRequiredField)
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		ReleaseNote: map[string]*plugins.ReleaseNote{
+			"org/repo": {
+				Categories: []plugins.ReleaseNoteCategory{
+					{Name: "kind", Values: []string{"feature", "bug", "cleanup"}, Required: true, LabelPrefix: "kind/"},
+					{Name: "area", LabelPrefix: "area/"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
 	pluginHelp := &pluginhelp.PluginHelp{
+		Config:  configInfo,
+		Snippet: yamlSnippet,
 		Description: `The releasenote plugin implements a release note process that uses a markdown 'release-note' code block to associate a release note with a pull request. Until the 'release-note' block in the pull request body is populated the PR will be assigned the '` + labels.ReleaseNoteLabelNeeded + `' label.
 <br>There are three valid types of release notes that can replace this label:
 <ol><li>PRs with a normal release note in the 'release-note' block are given the label '` + labels.ReleaseNote + `'.</li>
 <li>PRs that have a release note of 'none' in the block are given the label '` + labels.ReleaseNoteNone + `' to indicate that the PR does not warrant a release note.</li>
 <li>PRs that contain 'action required' in their 'release-note' block are given the label '` + labels.ReleaseNoteActionRequired + `' to indicate that the PR introduces potentially breaking changes that necessitate user action before upgrading to the release.</li></ol>
+<br>Repositories can configure categories for structured release notes, which are given as ` + "`name: value`" + ` lines before the release note content, for example ` + "`kind: feature`" + `. Release notes that do not follow the configured categories keep the '` + labels.ReleaseNoteLabelNeeded + `' label, and the values of the categories can be applied as labels.
 ` + "To use the plugin, in the pull request body text:\n\n```release-note\n<release note content>\n```",
 	}
 	// NOTE: the other two commands re deprecated, so we're not documenting them
@@ -215,7 +252,7 @@ func removeOtherLabels(remover func(string) error, label string, labelSet []stri
 }
 
 func handlePullRequest(pc plugins.Agent, pr github.PullRequestEvent) error {
-	return handlePR(pc.GitHubClient, pc.Logger, &pr)
+	return handlePR(pc.GitHubClient, pc.Logger, pc.PluginConfig.ReleaseNoteFor(pr.Repo.Owner.Login, pr.Repo.Name), &pr)
 }
 
 func shouldHandlePR(pr *github.PullRequestEvent) bool {
@@ -239,7 +276,7 @@ func shouldHandlePR(pr *github.PullRequestEvent) bool {
 	return true
 }
 
-func handlePR(gc githubClient, log *logrus.Entry, pr *github.PullRequestEvent) error {
+func handlePR(gc githubClient, log *logrus.Entry, cfg *plugins.ReleaseNote, pr *github.PullRequestEvent) error {
 	if !shouldHandlePR(pr) {
 		return nil
 	}
@@ -288,6 +325,10 @@ func handlePR(gc githubClient, log *logrus.Entry, pr *github.PullRequestEvent) e
 		}
 	}
 
+	if len(cfg.Categories) > 0 && (labelToAdd == labels.ReleaseNote || labelToAdd == labels.ReleaseNoteActionRequired) {
+		labelToAdd = applyStructuredNote(gc, log, cfg, pr, prLabels, labelToAdd)
+	}
+
 	// Add the label if needed
 	if !prLabels.Has(labelToAdd) {
 		if err = gc.AddLabel(org, repo, pr.Number, labelToAdd); err != nil {
@@ -329,11 +370,62 @@ func clearStaleComments(gc githubClient, log *logrus.Entry, pr *github.PullReque
 		func(c github.IssueComment) bool { // isStale function
 			return botUserChecker(c.User.Login) &&
 				(strings.Contains(c.Body, releaseNoteBody) ||
-					strings.Contains(c.Body, parentReleaseNoteBody))
+					strings.Contains(c.Body, parentReleaseNoteBody) ||
+					strings.Contains(c.Body, invalidReleaseNoteBody))
 		},
 	)
 }
 
+// applyStructuredNote validates the release note of the PR against the
+// categories of the repo and applies the labels of its fields. It returns the
+// release note label the PR should have, which is the needed label together
+// with a comment listing the problems if the release note is invalid.
+func applyStructuredNote(gc githubClient, log *logrus.Entry, cfg *plugins.ReleaseNote, pr *github.PullRequestEvent, prLabels sets.Set[string], labelToAdd string) string {
+	org := pr.Repo.Owner.Login
+	repo := pr.Repo.Name
+	note, problems := parseNote(getReleaseNote(pr.PullRequest.Body), cfg)
+	if len(problems) > 0 {
+		botUserChecker, err := gc.BotUserChecker()
+		if err != nil {
+			log.WithError(err).Error("Failed to get the bot user checker.")
+			return labels.ReleaseNoteLabelNeeded
+		}
+		// Only keep the comment about the current problems of the release note.
+		if err := gc.DeleteStaleComments(org, repo, pr.Number, nil, func(c github.IssueComment) bool {
+			return botUserChecker(c.User.Login) && strings.Contains(c.Body, invalidReleaseNoteBody)
+		}); err != nil {
+			log.WithError(err).Errorf("Failed to delete stale comments on %s/%s#%d.", org, repo, pr.Number)
+		}
+		comment := plugins.FormatResponse(pr.PullRequest.User.Login, invalidReleaseNoteBody, "- "+strings.Join(problems, "\n- "))
+		if err := gc.CreateComment(org, repo, pr.Number, comment); err != nil {
+			log.WithError(err).Errorf("Failed to comment on %s/%s#%d with comment %q.", org, repo, pr.Number, comment)
+		}
+		return labels.ReleaseNoteLabelNeeded
+	}
+
+	toAdd, toRemove := categoryLabels(note, cfg)
+	for _, label := range toAdd {
+		if prLabels.Has(label) {
+			continue
+		}
+		if err := gc.AddLabel(org, repo, pr.Number, label); err != nil {
+			log.WithError(err).Errorf("Failed to add the label %q to %s/%s#%d.", label, org, repo, pr.Number)
+		}
+	}
+	for _, label := range toRemove {
+		if !prLabels.Has(label) {
+			continue
+		}
+		if err := gc.RemoveLabel(org, repo, pr.Number, label); err != nil {
+			log.WithError(err).Errorf("Failed to remove the label %q from %s/%s#%d.", label, org, repo, pr.Number)
+		}
+	}
+	if note.ActionRequired {
+		return labels.ReleaseNoteActionRequired
+	}
+	return labelToAdd
+}
+
 func containsNoneCommand(comments []github.IssueComment) bool {
 	for _, c := range comments {
 		if releaseNoteNoneRe.MatchString(c.Body) {
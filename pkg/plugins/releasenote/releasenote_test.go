@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
 	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
 )
 
 func TestReleaseNoteComment(t *testing.T) {
@@ -489,7 +490,7 @@ func TestReleaseNotePR(t *testing.T) {
 		fc, pr := newFakeClient(test.body, test.branch, test.initialLabels, test.issueComments, test.parentPRs)
 		pr.PullRequest.Merged = test.merged
 
-		err := handlePR(fc, logrus.WithField("plugin", PluginName), pr)
+		err := handlePR(fc, logrus.WithField("plugin", PluginName), &plugins.ReleaseNote{}, pr)
 		if err != nil {
 			t.Fatalf("Unexpected error from handlePR: %v", err)
 		}
@@ -785,3 +786,82 @@ func Test_editReleaseNote(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuredReleaseNotePR(t *testing.T) {
+	cfg := &plugins.ReleaseNote{Categories: []plugins.ReleaseNoteCategory{
+		{Name: "kind", Values: []string{"feature", "bug"}, Required: true, LabelPrefix: "kind/"},
+		{Name: "area"},
+	}}
+	tests := []struct {
+		name               string
+		initialLabels      []string
+		body               string
+		IssueLabelsAdded   []string
+		IssueLabelsRemoved []string
+		expectComment      bool
+	}{
+		{
+			name:             "valid structured release note",
+			body:             "```release-note\nkind: feature\narea: tide\nTide merges faster.\n```",
+			IssueLabelsAdded: []string{labels.ReleaseNote, "kind/feature"},
+		},
+		{
+			name:               "structured release note requiring action",
+			initialLabels:      []string{labels.ReleaseNoteLabelNeeded, "kind/bug"},
+			body:               "```release-note\nkind: feature\naction-required: true\nThe flag was renamed.\n```",
+			IssueLabelsAdded:   []string{labels.ReleaseNoteActionRequired, "kind/feature"},
+			IssueLabelsRemoved: []string{labels.ReleaseNoteLabelNeeded, "kind/bug"},
+		},
+		{
+			name:             "release note without required category",
+			body:             "```release-note\narea: tide\nTide merges faster.\n```",
+			IssueLabelsAdded: []string{labels.ReleaseNoteLabelNeeded},
+			expectComment:    true,
+		},
+		{
+			name:               "release note with unknown value",
+			initialLabels:      []string{labels.ReleaseNote},
+			body:               "```release-note\nkind: feat\nTide merges faster.\n```",
+			IssueLabelsAdded:   []string{labels.ReleaseNoteLabelNeeded},
+			IssueLabelsRemoved: []string{labels.ReleaseNote},
+			expectComment:      true,
+		},
+		{
+			name:             "none release note is not validated",
+			body:             "```release-note\nNONE\n```",
+			IssueLabelsAdded: []string{labels.ReleaseNoteNone},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fc, pr := newFakeClient(test.body, "master", test.initialLabels, nil, nil)
+			fc.RepoLabelsExisting = append(fc.RepoLabelsExisting, "kind/feature", "kind/bug")
+
+			if err := handlePR(fc, logrus.WithField("plugin", PluginName), cfg, pr); err != nil {
+				t.Fatalf("Unexpected error from handlePR: %v", err)
+			}
+
+			expectAdded := formatLabels(1, append(test.initialLabels, test.IssueLabelsAdded...)...)
+			sort.Strings(expectAdded)
+			sort.Strings(fc.IssueLabelsAdded)
+			if !reflect.DeepEqual(expectAdded, fc.IssueLabelsAdded) {
+				t.Errorf("Expected labels to be added: %q, but got: %q.", expectAdded, fc.IssueLabelsAdded)
+			}
+			expectRemoved := formatLabels(1, test.IssueLabelsRemoved...)
+			sort.Strings(expectRemoved)
+			sort.Strings(fc.IssueLabelsRemoved)
+			if !reflect.DeepEqual(expectRemoved, fc.IssueLabelsRemoved) {
+				t.Errorf("Expected labels to be removed: %q, but got %q.", expectRemoved, fc.IssueLabelsRemoved)
+			}
+			var commented bool
+			for _, comment := range fc.IssueComments[1] {
+				if strings.Contains(comment.Body, invalidReleaseNoteBody) {
+					commented = true
+				}
+			}
+			if commented != test.expectComment {
+				t.Errorf("Expected a comment about an invalid release note: %t, got comments: %v", test.expectComment, fc.IssueComments[1])
+			}
+		})
+	}
+}
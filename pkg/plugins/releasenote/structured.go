@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasenote
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// actionRequiredField is the field of structured release-note blocks that
+// marks a release note as requiring action from users.
+const actionRequiredField = "action-required"
+
+var fieldRe = regexp.MustCompile(`^([A-Za-z][\w-]*):\s*(.*?)\s*$`)
+
+// structuredNote is a release note parsed from a structured release-note
+// block.
+type structuredNote struct {
+	// Fields maps the names of the categories set in the block to their values.
+	Fields         map[string]string
+	ActionRequired bool
+	Text           string
+}
+
+// parseNote parses the leading `name: value` lines of a release note into the
+// categories of the repo and returns the problems that make the note invalid.
+// Parsing stops at the first line that does not set a known field, which
+// starts the text of the note.
+func parseNote(note string, cfg *plugins.ReleaseNote) (structuredNote, []string) {
+	categories := map[string]plugins.ReleaseNoteCategory{}
+	for _, category := range cfg.Categories {
+		categories[category.Name] = category
+	}
+
+	parsed := structuredNote{Fields: map[string]string{}}
+	var problems []string
+	lines := strings.Split(strings.ReplaceAll(note, "\r\n", "\n"), "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		match := fieldRe.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if match == nil {
+			break
+		}
+		name, value := strings.ToLower(match[1]), match[2]
+		if name == actionRequiredField {
+			actionRequired, err := strconv.ParseBool(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("`%s` must be `true` or `false`, not %q.", actionRequiredField, value))
+			}
+			parsed.ActionRequired = actionRequired
+			continue
+		}
+		category, known := categories[name]
+		if !known {
+			break
+		}
+		if len(category.Values) > 0 && !slices.Contains(category.Values, value) {
+			problems = append(problems, fmt.Sprintf("`%s` must be one of %s, not %q.", name, strings.Join(category.Values, ", "), value))
+		}
+		parsed.Fields[name] = value
+	}
+	parsed.Text = strings.TrimSpace(strings.Join(lines[i:], "\n"))
+
+	for _, category := range cfg.Categories {
+		if category.Required && parsed.Fields[category.Name] == "" {
+			problems = append(problems, fmt.Sprintf("`%s` is required.", category.Name))
+		}
+	}
+	if parsed.Text == "" {
+		problems = append(problems, "The release note has no text after its fields.")
+	}
+	return parsed, problems
+}
+
+// categoryLabels returns the labels the fields of the note apply and the
+// labels of the other values of the same categories, which are removed.
+func categoryLabels(note structuredNote, cfg *plugins.ReleaseNote) (toAdd, toRemove []string) {
+	for _, category := range cfg.Categories {
+		if category.LabelPrefix == "" {
+			continue
+		}
+		value, set := note.Fields[category.Name]
+		if set {
+			toAdd = append(toAdd, category.LabelPrefix+value)
+		}
+		for _, other := range category.Values {
+			if !set || other != value {
+				toRemove = append(toRemove, category.LabelPrefix+other)
+			}
+		}
+	}
+	return toAdd, toRemove
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasenote
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestParseNote(t *testing.T) {
+	cfg := &plugins.ReleaseNote{Categories: []plugins.ReleaseNoteCategory{
+		{Name: "kind", Values: []string{"feature", "bug"}, Required: true},
+		{Name: "area"},
+	}}
+	testCases := []struct {
+		name             string
+		note             string
+		expected         structuredNote
+		expectedProblems int
+	}{
+		{
+			name:     "all fields",
+			note:     "kind: feature\r\nArea: tide\r\naction-required: true\r\nTide merges faster.\r\nSee the docs.",
+			expected: structuredNote{Fields: map[string]string{"kind": "feature", "area": "tide"}, ActionRequired: true, Text: "Tide merges faster.\nSee the docs."},
+		},
+		{
+			name:     "text that looks like a field",
+			note:     "kind: bug\nNote: the cache is flushed on upgrade.",
+			expected: structuredNote{Fields: map[string]string{"kind": "bug"}, Text: "Note: the cache is flushed on upgrade."},
+		},
+		{
+			name:             "missing required field and text",
+			note:             "area: tide",
+			expected:         structuredNote{Fields: map[string]string{"area": "tide"}},
+			expectedProblems: 2,
+		},
+		{
+			name:             "invalid values",
+			note:             "kind: feat\naction-required: maybe\nTide merges faster.",
+			expected:         structuredNote{Fields: map[string]string{"kind": "feat"}, Text: "Tide merges faster."},
+			expectedProblems: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, problems := parseNote(tc.note, cfg)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected note (-want +got):\n%s", diff)
+			}
+			if len(problems) != tc.expectedProblems {
+				t.Errorf("expected %d problems, got %v", tc.expectedProblems, problems)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ package size
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -58,13 +59,16 @@ func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhel
 			L:   100,
 			Xl:  500,
 			Xxl: 1000,
+			ExcludedPaths: map[string][]string{
+				"org/repo": {"^vendor/", `\.pb\.go$`},
+			},
 		},
 	})
 	if err != nil {
 		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", pluginName)
 	}
 	return &pluginhelp.PluginHelp{
-			Description: "The size plugin manages the 'size/*' labels, maintaining the appropriate label on each pull request as it is updated. Generated files identified by the config file '.generated_files' at the repo root are ignored. Labels are applied based on the total number of lines of changes (additions and deletions).",
+			Description: "The size plugin manages the 'size/*' labels, maintaining the appropriate label on each pull request as it is updated. Generated files identified by the config file '.generated_files' at the repo root, files marked as linguist-generated or linguist-vendored in the '.gitattributes' file at the repo root and files matching the excluded paths of the repo are ignored. Labels are applied based on the total number of lines of changes (additions and deletions). If ignoring files changes the label, a comment explains how many lines were counted.",
 			Config: map[string]string{
 				"": fmt.Sprintf(`The plugin has the following thresholds:<ul>
 <li>size/XS:  0-%d</li>
@@ -91,6 +95,10 @@ type githubClient interface {
 	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
 	GetFile(org, repo, filepath, commit string) ([]byte, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	CreateComment(org, repo string, number int, comment string) error
+	EditComment(org, repo string, id int, comment string) error
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	BotUserChecker() (func(candidate string) bool, error)
 }
 
 func handlePR(gc githubClient, sizes plugins.Size, le *logrus.Entry, pe github.PullRequestEvent) error {
@@ -126,10 +134,13 @@ func handlePR(gc githubClient, sizes plugins.Size, le *logrus.Entry, pe github.P
 		return fmt.Errorf("can not get PR changes for size plugin: %w", err)
 	}
 
-	var count int
+	excludedPathRes := sizes.ExcludedPathResFor(owner, repo)
+	var count, excludedCount, excludedFiles int
 	for _, change := range changes {
-		// Skip generated and linguist-generated files.
-		if gf.Match(change.Filename) || ga.IsLinguistGenerated(change.Filename) {
+		// Skip generated, linguist-generated, vendored and excluded files.
+		if gf.Match(change.Filename) || ga.IsLinguistGenerated(change.Filename) || ga.IsLinguistVendored(change.Filename) || matchesAny(excludedPathRes, change.Filename) {
+			excludedCount += change.Additions + change.Deletions
+			excludedFiles++
 			continue
 		}
 
@@ -165,9 +176,61 @@ func handlePR(gc githubClient, sizes plugins.Size, le *logrus.Entry, pe github.P
 		return fmt.Errorf("error adding label to %s/%s PR #%d: %w", owner, repo, num, err)
 	}
 
+	// Only explain the count if the ignored files make a difference for the label.
+	explain := bucket(count+excludedCount, sizes) != bucket(count, sizes)
+	if err := explainCount(gc, owner, repo, num, newLabel, count, excludedCount, excludedFiles, explain); err != nil {
+		le.WithError(err).Warn("error while explaining the counted lines")
+	}
+
 	return nil
 }
 
+func matchesAny(res []*regexp.Regexp, path string) bool {
+	for _, re := range res {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// explainCount is called when the size label changes. It maintains a comment
+// with the number of lines the label is based on if explain is set, so that
+// the label is not mistaken for the size of the whole diff. An existing
+// comment is kept up to date even when it is not needed anymore.
+func explainCount(gc githubClient, owner, repo string, num int, label string, count, excludedCount, excludedFiles int, explain bool) error {
+	botUserChecker, err := gc.BotUserChecker()
+	if err != nil {
+		return err
+	}
+	comments, err := gc.ListIssueComments(owner, repo, num)
+	if err != nil {
+		return err
+	}
+	var existing *github.IssueComment
+	for i := range comments {
+		if botUserChecker(comments[i].User.Login) && strings.HasPrefix(comments[i].Body, commentTag) {
+			existing = &comments[i]
+			break
+		}
+	}
+	if existing == nil && !explain {
+		return nil
+	}
+
+	body := fmt.Sprintf("%s\nThis PR is labeled `%s` based on %d changed lines.", commentTag, label, count)
+	if excludedFiles > 0 {
+		body += fmt.Sprintf(" %d changed lines in %d generated, vendored or excluded files are not counted.", excludedCount, excludedFiles)
+	}
+	if existing == nil {
+		return gc.CreateComment(owner, repo, num, body)
+	}
+	if existing.Body == body {
+		return nil
+	}
+	return gc.EditComment(owner, repo, existing.ID, body)
+}
+
 // One of a set of discrete buckets.
 type size int
 
@@ -182,6 +245,7 @@ const (
 
 const (
 	labelPrefix = "size/"
+	commentTag  = "<!-- size -->"
 
 	labelXS      = "size/XS"
 	labelS       = "size/S"
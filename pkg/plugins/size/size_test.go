@@ -17,6 +17,8 @@ limitations under the License.
 package size
 
 import (
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -31,6 +33,7 @@ type ghc struct {
 	labels    map[github.Label]bool
 	files     map[string][]byte
 	prChanges []github.PullRequestChange
+	comments  []github.IssueComment
 
 	addLabelErr, removeLabelErr, getIssueLabelsErr,
 	getFileErr, getPullRequestChangesErr error
@@ -76,6 +79,33 @@ func (c *ghc) GetPullRequestChanges(_, _ string, _ int) ([]github.PullRequestCha
 	return c.prChanges, c.getPullRequestChangesErr
 }
 
+func (c *ghc) CreateComment(_, _ string, _ int, comment string) error {
+	c.T.Logf("CreateComment: %s", comment)
+	c.comments = append(c.comments, github.IssueComment{ID: len(c.comments) + 1, Body: comment, User: github.User{Login: botName}})
+	return nil
+}
+
+func (c *ghc) EditComment(_, _ string, id int, comment string) error {
+	c.T.Logf("EditComment: %d %s", id, comment)
+	for i := range c.comments {
+		if c.comments[i].ID == id {
+			c.comments[i].Body = comment
+		}
+	}
+	return nil
+}
+
+func (c *ghc) ListIssueComments(_, _ string, _ int) ([]github.IssueComment, error) {
+	c.T.Log("ListIssueComments")
+	return c.comments, nil
+}
+
+func (c *ghc) BotUserChecker() (func(candidate string) bool, error) {
+	return func(candidate string) bool { return candidate == botName }, nil
+}
+
+const botName = "k8s-ci-robot"
+
 func TestSizesOrDefault(t *testing.T) {
 	for _, c := range []struct {
 		input    plugins.Size
@@ -106,7 +136,7 @@ func TestSizesOrDefault(t *testing.T) {
 			expected: defaultSizes,
 		},
 	} {
-		if c.expected != sizesOrDefault(c.input) {
+		if !reflect.DeepEqual(c.expected, sizesOrDefault(c.input)) {
 			t.Fatalf("Unexpected sizes from sizesOrDefault - expected %+v but got %+v", c.expected, sizesOrDefault(c.input))
 		}
 	}
@@ -120,6 +150,8 @@ func TestHandlePR(t *testing.T) {
 		err         error
 		sizes       plugins.Size
 		finalLabels []github.Label
+		// finalComments are the bodies of the comments after handling the event.
+		finalComments []string
 	}{
 		{
 			name: "simple size/S, no .generated_files",
@@ -225,7 +257,8 @@ func TestHandlePR(t *testing.T) {
 			finalLabels: []github.Label{
 				{Name: "size/M"},
 			},
-			sizes: defaultSizes,
+			finalComments: []string{"<!-- size -->\nThis PR is labeled `size/M` based on 50 changed lines. 350 changed lines in 3 generated, vendored or excluded files are not counted."},
+			sizes:         defaultSizes,
 		},
 		{
 			name: "simple size/M, with .gitattributes",
@@ -288,7 +321,8 @@ func TestHandlePR(t *testing.T) {
 			finalLabels: []github.Label{
 				{Name: "size/M"},
 			},
-			sizes: defaultSizes,
+			finalComments: []string{"<!-- size -->\nThis PR is labeled `size/M` based on 50 changed lines. 350 changed lines in 3 generated, vendored or excluded files are not counted."},
+			sizes:         defaultSizes,
 		},
 		{
 			name: "simple size/XS, with .generated_files and paths-from-repo",
@@ -377,7 +411,8 @@ func TestHandlePR(t *testing.T) {
 			finalLabels: []github.Label{
 				{Name: "size/XS"},
 			},
-			sizes: defaultSizes,
+			finalComments: []string{"<!-- size -->\nThis PR is labeled `size/XS` based on 5 changed lines. 950 changed lines in 5 generated, vendored or excluded files are not counted."},
+			sizes:         defaultSizes,
 		},
 		{
 			name:   "pr closed event",
@@ -609,6 +644,162 @@ func TestHandlePR(t *testing.T) {
 				Xxl: 4,
 			},
 		},
+		{
+			name: "vendored and excluded files, existing comment is updated",
+			client: &ghc{
+				labels: map[github.Label]bool{},
+				files: map[string][]byte{
+					".gitattributes": []byte(`vendor/** linguist-vendored`),
+				},
+				comments: []github.IssueComment{
+					{ID: 1, Body: "<!-- size -->\noutdated", User: github.User{Login: botName}},
+					{ID: 2, Body: "<!-- size -->\nnot from the bot", User: github.User{Login: "someone"}},
+				},
+				prChanges: []github.PullRequestChange{
+					{Filename: "main.go", Additions: 40, Deletions: 10},
+					{Filename: "vendor/github.com/a/b.go", Additions: 2000},
+					{Filename: "api/types.pb.go", Additions: 1000, Deletions: 500},
+					{Filename: "other/types.pb.go", Additions: 20},
+				},
+			},
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				Number: 101,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						SHA: "abcd",
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+				},
+			},
+			finalLabels: []github.Label{
+				{Name: "size/M"},
+			},
+			finalComments: []string{
+				"<!-- size -->\nThis PR is labeled `size/M` based on 70 changed lines. 3500 changed lines in 2 generated, vendored or excluded files are not counted.",
+				"<!-- size -->\nnot from the bot",
+			},
+			sizes: func() plugins.Size {
+				sizes := defaultSizes
+				sizes.ExcludedPathRes = map[string][]*regexp.Regexp{
+					"kubernetes/kubernetes": {regexp.MustCompile(`^api/.*\.pb\.go$`)},
+					"kubernetes/other":      {regexp.MustCompile(`\.pb\.go$`)},
+				}
+				return sizes
+			}(),
+		},
+		{
+			name: "ignored files that do not change the label are not explained",
+			client: &ghc{
+				labels: map[github.Label]bool{},
+				files: map[string][]byte{
+					".gitattributes": []byte(`vendor/** linguist-vendored`),
+				},
+				prChanges: []github.PullRequestChange{
+					{Filename: "main.go", Additions: 40, Deletions: 10},
+					{Filename: "vendor/github.com/a/b.go", Additions: 5},
+				},
+			},
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				Number: 101,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						SHA: "abcd",
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+				},
+			},
+			finalLabels: []github.Label{
+				{Name: "size/M"},
+			},
+			sizes: defaultSizes,
+		},
+		{
+			name: "unchanged label leaves the comment alone",
+			client: &ghc{
+				labels: map[github.Label]bool{{Name: "size/M"}: true},
+				files: map[string][]byte{
+					".gitattributes": []byte(`vendor/** linguist-vendored`),
+				},
+				comments: []github.IssueComment{
+					{ID: 1, Body: "<!-- size -->\noutdated", User: github.User{Login: botName}},
+				},
+				prChanges: []github.PullRequestChange{
+					{Filename: "main.go", Additions: 40, Deletions: 10},
+					{Filename: "vendor/github.com/a/b.go", Additions: 2000},
+				},
+			},
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				Number: 101,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						SHA: "abcd",
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+				},
+			},
+			finalLabels: []github.Label{
+				{Name: "size/M"},
+			},
+			finalComments: []string{"<!-- size -->\noutdated"},
+			sizes:         defaultSizes,
+		},
+		{
+			name: "no files ignored anymore, existing comment is updated",
+			client: &ghc{
+				labels:     map[github.Label]bool{},
+				getFileErr: &github.FileNotFound{},
+				comments: []github.IssueComment{
+					{ID: 1, Body: "<!-- size -->\noutdated", User: github.User{Login: botName}},
+				},
+				prChanges: []github.PullRequestChange{
+					{Filename: "main.go", Additions: 40, Deletions: 10},
+				},
+			},
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				Number: 101,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						SHA: "abcd",
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+				},
+			},
+			finalLabels: []github.Label{
+				{Name: "size/M"},
+			},
+			finalComments: []string{
+				"<!-- size -->\nThis PR is labeled `size/M` based on 50 changed lines.",
+			},
+			sizes: defaultSizes,
+		},
 	}
 
 	for _, c := range cases {
@@ -644,6 +835,14 @@ func TestHandlePR(t *testing.T) {
 					t.Fatalf("github client labels missing %v", l)
 				}
 			}
+
+			var comments []string
+			for _, comment := range c.client.comments {
+				comments = append(comments, comment.Body)
+			}
+			if !reflect.DeepEqual(comments, c.finalComments) {
+				t.Fatalf("comments mismatch: got %q, want %q", comments, c.finalComments)
+			}
 		})
 	}
 }
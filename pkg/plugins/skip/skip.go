@@ -15,14 +15,19 @@ limitations under the License.
 */
 
 // Package skip implements the `/skip` command which allows users
-// to clean up commit statuses of non-blocking presubmits on PRs.
+// to clean up commit statuses of non-blocking presubmits on PRs, and
+// users with write access to skip required presubmits that are
+// configured as skippable.
 package skip
 
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
@@ -34,9 +39,14 @@ import (
 const pluginName = "skip"
 
 var (
-	skipRe = regexp.MustCompile(`(?mi)^/skip\s*$`)
+	skipRe    = regexp.MustCompile(`(?mi)^/skip\s*$`)
+	skipJobRe = regexp.MustCompile(`(?mi)^/skip[ \t]+(\S+)(?:[ \t]+([^\r\n]*[^\s]))?[ \t]*\r?$`)
 )
 
+// maxDescriptionLength is the length GitHub limits the description of a
+// status to.
+const maxDescriptionLength = 140
+
 type githubClient interface {
 	CreateComment(owner, repo string, number int, comment string) error
 	CreateStatus(org, repo, ref string, s github.Status) error
@@ -44,15 +54,25 @@ type githubClient interface {
 	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
 	GetRef(org, repo, ref string) (string, error)
+	HasPermission(org, repo, user string, role ...string) (bool, error)
+	CreateCheckRun(org, repo string, checkRun github.CheckRun) error
+	UsesAppAuth() bool
 }
 
 func init() {
 	plugins.RegisterGenericCommentHandler(pluginName, handleGenericComment, helpProvider)
 }
 
-func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		if skippable := config.Skip.SkippableJobsFor(repo.Org, repo.Repo); skippable.Len() > 0 {
+			configInfo[repo.String()] = fmt.Sprintf("The following required jobs may be skipped: %s.", strings.Join(sets.List(skippable), ", "))
+		}
+	}
 	pluginHelp := &pluginhelp.PluginHelp{
-		Description: "The skip plugin allows users to clean up GitHub stale commit statuses for non-blocking jobs on a PR.",
+		Description: "The skip plugin allows users to clean up GitHub stale commit statuses for non-blocking jobs on a PR, and to skip required jobs configured as skippable.",
+		Config:      configInfo,
 	}
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/skip",
@@ -61,20 +81,28 @@ func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhel
 		WhoCanUse:   "Anyone can trigger this command on a PR.",
 		Examples:    []string{"/skip"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/skip <job-name> <reason>",
+		Description: "Marks the job as skipped on a PR. Required jobs can only be skipped if they are configured as skippable.",
+		Featured:    false,
+		WhoCanUse:   "Anyone can skip non-blocking jobs. Required jobs can only be skipped by users with write access to the repo.",
+		Examples:    []string{"/skip pull-e2e the e2e cluster is down, tested manually"},
+	})
 	return pluginHelp, nil
 }
 
 func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
 	honorOkToTest := trigger.HonorOkToTest(pc.PluginConfig.TriggerFor(e.Repo.Owner.Login, e.Repo.Name))
-	return handle(pc.GitHubClient, pc.Logger, &e, pc.Config, pc.GitClient, honorOkToTest)
+	return handle(pc.GitHubClient, pc.Logger, &e, pc.Config, pc.GitClient, honorOkToTest, pc.PluginConfig.Skip)
 }
 
-func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, c *config.Config, gitClient git.ClientFactory, honorOkToTest bool) error {
+func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, c *config.Config, gitClient git.ClientFactory, honorOkToTest bool, options plugins.Skip) error {
 	if !e.IsPR || e.IssueState != "open" || e.Action != github.GenericCommentActionCreated {
 		return nil
 	}
 
-	if !skipRe.MatchString(e.Body) {
+	skipJobs := skipJobRe.FindAllStringSubmatch(e.Body, -1)
+	if !skipRe.MatchString(e.Body) && len(skipJobs) == 0 {
 		return nil
 	}
 
@@ -109,10 +137,16 @@ func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, c
 		log.Warn(resp)
 		return gc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, resp))
 	}
-	if combinedStatus.State == github.StatusSuccess {
+	statuses := combinedStatus.Statuses
+
+	for _, m := range skipJobs {
+		if err := skipJob(gc, log, e, pr, presubmits, statuses, options, m[1], m[2]); err != nil {
+			return err
+		}
+	}
+	if !skipRe.MatchString(e.Body) || combinedStatus.State == github.StatusSuccess {
 		return nil
 	}
-	statuses := combinedStatus.Statuses
 
 	filteredPresubmits, err := trigger.FilterPresubmits(honorOkToTest, gc, e.Body, pr, presubmits, log)
 	if err != nil {
@@ -162,6 +196,109 @@ func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, c
 	return nil
 }
 
+// skipJob marks a single job as skipped on behalf of the commenter. Required
+// jobs may only be skipped if they are skippable and by users with write
+// access, and their skipping is recorded for auditing.
+func skipJob(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, pr *github.PullRequest, presubmits []config.Presubmit, statuses []github.Status, options plugins.Skip, name, reason string) error {
+	org := e.Repo.Owner.Login
+	repo := e.Repo.Name
+	number := e.Number
+	user := e.User.Login
+	respond := func(resp string) error {
+		log.Debug(resp)
+		return gc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, resp))
+	}
+
+	var job *config.Presubmit
+	for i := range presubmits {
+		if presubmits[i].Name == name {
+			job = &presubmits[i]
+			break
+		}
+	}
+	if job == nil {
+		return respond(fmt.Sprintf("Cannot skip `%s`: there is no such job for this PR.", name))
+	}
+	if reason == "" {
+		return respond(fmt.Sprintf("Cannot skip `%s`: a reason is required, use `/skip %s <reason>`.", name, name))
+	}
+	if job.ContextRequired() {
+		if skippable := options.SkippableJobsFor(org, repo); !skippable.Has(name) {
+			return respond(fmt.Sprintf("Cannot skip `%s`: it is required and not configured as skippable.", name))
+		}
+		authorized, err := gc.HasPermission(org, repo, user, github.RoleAdmin, string(github.Write))
+		if err != nil {
+			log.WithError(err).Warnf("Cannot determine whether %s has write access to %s/%s.", user, org, repo)
+		}
+		if !authorized {
+			return respond(fmt.Sprintf("%s unauthorized: skipping required jobs is restricted to users with write access.", user))
+		}
+	}
+	if isSuccess(*job, statuses) {
+		return nil
+	}
+
+	status := github.Status{
+		State:       github.StatusSuccess,
+		Description: truncate(fmt.Sprintf("Skipped by %s: %s", user, reason), maxDescriptionLength),
+		Context:     job.Context,
+	}
+	if err := gc.CreateStatus(org, repo, pr.Head.SHA, status); err != nil {
+		resp := fmt.Sprintf("Cannot update PR status for context %s: %v", job.Context, err)
+		log.Warn(resp)
+		return gc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, resp))
+	}
+	if !job.ContextRequired() {
+		return nil
+	}
+
+	log.WithFields(logrus.Fields{
+		"audit":   pluginName,
+		"user":    user,
+		"job":     name,
+		"context": job.Context,
+		"sha":     pr.Head.SHA,
+		"reason":  reason,
+		"comment": e.HTMLURL,
+	}).Info("Skipped required job.")
+	// Check runs can only be created by GitHub Apps.
+	if gc.UsesAppAuth() {
+		title := fmt.Sprintf("%s skipped by %s", name, user)
+		checkRun := github.CheckRun{
+			Name:       fmt.Sprintf("skip/%s", job.Context),
+			HeadSHA:    pr.Head.SHA,
+			DetailsURL: e.HTMLURL,
+			Status:     "completed",
+			Conclusion: "neutral",
+			Output: github.CheckRunOutput{
+				Title:   title,
+				Summary: fmt.Sprintf("The required job `%s` was skipped by @%s.", name, user),
+				Annotations: []github.CheckRunAnnotation{{
+					Path:            ".",
+					StartLine:       1,
+					EndLine:         1,
+					AnnotationLevel: "notice",
+					Title:           title,
+					Message:         reason,
+				}},
+			},
+		}
+		if err := gc.CreateCheckRun(org, repo, checkRun); err != nil {
+			log.WithError(err).Warnf("Cannot record skipping %s in a check run.", name)
+		}
+	}
+	return gc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, fmt.Sprintf("Skipped the required job `%s` on behalf of %s: %s", name, user, reason)))
+}
+
+// truncate shortens s to at most n bytes, marking that it was shortened.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	const ellipsis = "..."
+	return strings.ToValidUTF8(s[:n-len(ellipsis)], "") + ellipsis
+}
+
 func statusExists(job config.Presubmit, statuses []github.Status) bool {
 	for _, status := range statuses {
 		if status.Context == job.Context {
@@ -18,13 +18,16 @@ package skip
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
 )
 
 func TestSkipStatus(t *testing.T) {
@@ -323,7 +326,7 @@ func TestSkipStatus(t *testing.T) {
 			},
 		}
 
-		if err := handle(fghc, l, test.event, c, nil, true); err != nil {
+		if err := handle(fghc, l, test.event, c, nil, true, plugins.Skip{}); err != nil {
 			t.Errorf("%s: unexpected error: %v", test.name, err)
 			continue
 		}
@@ -351,3 +354,181 @@ func TestSkipStatus(t *testing.T) {
 		}
 	}
 }
+
+// appClient is a GitHub client authenticated as a GitHub App, which can
+// create check runs.
+type appClient struct {
+	*fakegithub.FakeClient
+}
+
+func (appClient) UsesAppAuth() bool {
+	return true
+}
+
+func TestSkipJob(t *testing.T) {
+	presubmits := []config.Presubmit{
+		{
+			JobBase:   config.JobBase{Name: "pull-unit"},
+			AlwaysRun: true,
+			Reporter:  config.Reporter{Context: "unit"},
+		},
+		{
+			JobBase:   config.JobBase{Name: "pull-e2e"},
+			AlwaysRun: true,
+			Reporter:  config.Reporter{Context: "e2e"},
+		},
+		{
+			JobBase:   config.JobBase{Name: "pull-lint"},
+			AlwaysRun: true,
+			Optional:  true,
+			Reporter:  config.Reporter{Context: "lint"},
+		},
+	}
+	if err := config.SetPresubmitRegexes(presubmits); err != nil {
+		t.Fatalf("could not set presubmit regexes: %v", err)
+	}
+	options := plugins.Skip{SkippableJobs: map[string][]string{"org/repo": {"pull-e2e"}}}
+
+	tests := []struct {
+		name      string
+		body      string
+		user      string
+		appAuth   bool
+		expected  []github.Status
+		checkRuns []github.CheckRun
+		comment   string
+	}{
+		{
+			name: "skippable required job is skipped by a user with write access",
+			body: "/skip pull-e2e the e2e cluster is down",
+			user: "maintainer",
+			expected: []github.Status{{
+				State:       github.StatusSuccess,
+				Description: "Skipped by maintainer: the e2e cluster is down",
+				Context:     "e2e",
+			}},
+			comment: "Skipped the required job `pull-e2e` on behalf of maintainer: the e2e cluster is down",
+		},
+		{
+			name:    "skipping a required job is recorded in a check run with apps auth",
+			body:    "/skip pull-e2e the e2e cluster is down",
+			user:    "maintainer",
+			appAuth: true,
+			expected: []github.Status{{
+				State:       github.StatusSuccess,
+				Description: "Skipped by maintainer: the e2e cluster is down",
+				Context:     "e2e",
+			}},
+			checkRuns: []github.CheckRun{{
+				Name:       "skip/e2e",
+				HeadSHA:    "sha",
+				DetailsURL: "https://github.com/org/repo/pull/1#comment",
+				Status:     "completed",
+				Conclusion: "neutral",
+				Output: github.CheckRunOutput{
+					Title:   "pull-e2e skipped by maintainer",
+					Summary: "The required job `pull-e2e` was skipped by @maintainer.",
+					Annotations: []github.CheckRunAnnotation{{
+						Path:            ".",
+						StartLine:       1,
+						EndLine:         1,
+						AnnotationLevel: "notice",
+						Title:           "pull-e2e skipped by maintainer",
+						Message:         "the e2e cluster is down",
+					}},
+				},
+			}},
+			comment: "Skipped the required job `pull-e2e` on behalf of maintainer",
+		},
+		{
+			name:    "required job that is not skippable is not skipped",
+			body:    "/skip pull-unit flaky",
+			user:    "maintainer",
+			comment: "Cannot skip `pull-unit`: it is required and not configured as skippable.",
+		},
+		{
+			name:    "skippable required job is not skipped by a user without write access",
+			body:    "/skip pull-e2e the e2e cluster is down",
+			user:    "contributor",
+			comment: "contributor unauthorized: skipping required jobs is restricted to users with write access.",
+		},
+		{
+			name:    "a reason is required",
+			body:    "/skip pull-e2e",
+			user:    "maintainer",
+			comment: "Cannot skip `pull-e2e`: a reason is required, use `/skip pull-e2e <reason>`.",
+		},
+		{
+			name:    "unknown job is not skipped",
+			body:    "/skip pull-unknown because",
+			user:    "maintainer",
+			comment: "Cannot skip `pull-unknown`: there is no such job for this PR.",
+		},
+		{
+			name: "optional job is skipped by anyone",
+			body: "/skip pull-lint the linter is broken",
+			user: "contributor",
+			expected: []github.Status{{
+				State:       github.StatusSuccess,
+				Description: "Skipped by contributor: the linter is broken",
+				Context:     "lint",
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fghc := fakegithub.NewFakeClient()
+			fghc.IssueComments = make(map[int][]github.IssueComment)
+			fghc.PullRequests = map[int]*github.PullRequest{1: {Head: github.PullRequestBranch{SHA: "sha"}}}
+			fghc.UserPermissions = map[string]string{"maintainer": string(github.Write), "contributor": string(github.Read)}
+			existing := []github.Status{
+				{State: github.StatusFailure, Context: "unit"},
+				{State: github.StatusFailure, Context: "e2e"},
+				{State: github.StatusFailure, Context: "lint"},
+			}
+			fghc.CombinedStatuses = map[string]*github.CombinedStatus{"sha": {State: github.StatusFailure, Statuses: existing}}
+			var gc githubClient = fghc
+			if test.appAuth {
+				gc = appClient{fghc}
+			}
+			event := &github.GenericCommentEvent{
+				Action:     github.GenericCommentActionCreated,
+				IsPR:       true,
+				IssueState: "open",
+				Body:       test.body,
+				HTMLURL:    "https://github.com/org/repo/pull/1#comment",
+				Number:     1,
+				Repo:       github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				User:       github.User{Login: test.user},
+			}
+			c := &config.Config{JobConfig: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": presubmits}}}
+
+			if err := handle(gc, logrus.WithField("plugin", pluginName), event, c, nil, true, options); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.expected, fghc.CreatedStatuses["sha"]); diff != "" {
+				t.Errorf("created statuses differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.checkRuns, fghc.CheckRuns["sha"]); diff != "" {
+				t.Errorf("created check runs differ from expected (-want +got):\n%s", diff)
+			}
+			comments := fghc.IssueComments[1]
+			if test.comment == "" {
+				if len(comments) != 0 {
+					t.Errorf("expected no comment, got %v", comments)
+				}
+			} else if len(comments) != 1 || !strings.Contains(comments[0].Body, test.comment) {
+				t.Errorf("expected a comment containing %q, got %v", test.comment, comments)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("expected a short string to be kept, got %q", got)
+	}
+	if got := truncate("a long description", 10); got != "a long ..." {
+		t.Errorf("expected a truncated string, got %q", got)
+	}
+}
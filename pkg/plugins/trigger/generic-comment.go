@@ -17,11 +17,16 @@ limitations under the License.
 package trigger
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/prow/pkg/kube"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
@@ -61,6 +66,7 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 		!pjutil.RetestRequiredRe.MatchString(gc.Body) &&
 		!pjutil.OkToTestRe.MatchString(gc.Body) &&
 		!pjutil.TestAllRe.MatchString(gc.Body) &&
+		!pjutil.TestRequiredRe.MatchString(gc.Body) &&
 		!pjutil.MayNeedHelpComment(gc.Body) {
 		matched := false
 		for _, presubmit := range presubmits {
@@ -126,13 +132,27 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 		return err
 	}
 
-	toTest, err := FilterPresubmits(HonorOkToTest(trigger), c.GitHubClient, gc.Body, pr, presubmits, c.Logger)
+	toTest, filter, err := filterPresubmits(HonorOkToTest(trigger), c.GitHubClient, gc.Body, pr, presubmits, c.Logger)
 	if err != nil {
 		return err
 	}
 	if needsHelp, note := pjutil.ShouldRespondWithHelp(gc.Body, len(toTest)); needsHelp {
 		return addHelpComment(c.GitHubClient, gc.Body, org, repo, pr.Base.Ref, pr.Number, presubmits, gc.HTMLURL, commentAuthor, note, c.Logger)
 	}
+	toTest, err = filterChangedContent(c, filter, pr, toTest)
+	if err != nil {
+		return err
+	}
+	toTest, coolDown, err := filterRetriggerLimited(c, trigger.RetriggerLimits, pr, toTest, time.Now())
+	if err != nil {
+		return err
+	}
+	if coolDown != "" {
+		c.Logger.Infof("Commenting \"%s\".", coolDown)
+		if err := c.GitHubClient.CreateComment(org, repo, number, plugins.FormatResponseRaw(gc.Body, gc.HTMLURL, commentAuthor, coolDown)); err != nil {
+			return err
+		}
+	}
 	// we want to be able to track re-tests separately from the general body of tests
 	additionalLabels := map[string]string{}
 	if pjutil.RetestRe.MatchString(gc.Body) || pjutil.RetestRequiredRe.MatchString(gc.Body) {
@@ -167,7 +187,69 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 			}
 		}
 	}
-	return RunRequestedWithLabels(c, pr, baseSHA, toTest, gc.GUID, additionalLabels)
+	// record who triggered the jobs with which comment
+	annotations := map[string]string{
+		kube.TriggeredByAnnotation:    gc.User.Login,
+		kube.TriggerCommentAnnotation: gc.HTMLURL,
+	}
+	return runRequested(c, pr, baseSHA, toTest, gc.GUID, additionalLabels, annotations)
+}
+
+// retriggerWindow is the period in which runs of a job on a PR count
+// towards its retrigger limit.
+const retriggerWindow = time.Hour
+
+// filterRetriggerLimited drops the jobs that already ran on the PR as often
+// within the retrigger window as their retrigger limit allows. If any job was
+// dropped, the returned message explains when it can be triggered again.
+func filterRetriggerLimited(c Client, limits map[string]int, pr *github.PullRequest, toTest []config.Presubmit, now time.Time) ([]config.Presubmit, string, error) {
+	limited := false
+	for _, job := range toTest {
+		if _, ok := limits[job.Name]; ok {
+			limited = true
+			break
+		}
+	}
+	if !limited {
+		return toTest, "", nil
+	}
+
+	selector, err := labelSelectorForPR(pr)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to construct label selector: %w", err)
+	}
+	jobs, err := c.ProwJobClient.List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list prowjobs for pr: %w", err)
+	}
+	windowStart := now.Add(-retriggerWindow)
+	recentStarts := map[string][]time.Time{}
+	for _, job := range jobs.Items {
+		if _, ok := limits[job.Spec.Job]; !ok || job.Status.StartTime.Time.Before(windowStart) {
+			continue
+		}
+		recentStarts[job.Spec.Job] = append(recentStarts[job.Spec.Job], job.Status.StartTime.Time)
+	}
+
+	var allowed []config.Presubmit
+	var coolingDown []string
+	for _, job := range toTest {
+		limit, ok := limits[job.Name]
+		starts := recentStarts[job.Name]
+		if !ok || len(starts) < limit {
+			allowed = append(allowed, job)
+			continue
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+		// The job may run again once enough runs have left the window to get below the limit.
+		availableAt := starts[len(starts)-limit].Add(retriggerWindow)
+		coolingDown = append(coolingDown, fmt.Sprintf("* `%s` ran %d times in the last hour (limit %d), it can be triggered again after %s.", job.Name, len(starts), limit, availableAt.UTC().Format(time.RFC1123)))
+	}
+	if len(coolingDown) == 0 {
+		return allowed, "", nil
+	}
+	message := fmt.Sprintf("The following jobs were not triggered because they reached their limit of runs per hour on this pull request:\n%s", strings.Join(coolingDown, "\n"))
+	return allowed, message, nil
 }
 
 func HonorOkToTest(trigger plugins.Trigger) bool {
@@ -196,6 +278,13 @@ type GitHubClient interface {
 // consider the set of matching presubmits the union of the results from the
 // matching cases.
 func FilterPresubmits(honorOkToTest bool, gitHubClient GitHubClient, body string, pr *github.PullRequest, presubmits []config.Presubmit, logger *logrus.Entry) ([]config.Presubmit, error) {
+	toTest, _, err := filterPresubmits(honorOkToTest, gitHubClient, body, pr, presubmits, logger)
+	return toTest, err
+}
+
+// filterPresubmits is FilterPresubmits but also returns the filter that was
+// built from the comment.
+func filterPresubmits(honorOkToTest bool, gitHubClient GitHubClient, body string, pr *github.PullRequest, presubmits []config.Presubmit, logger *logrus.Entry) ([]config.Presubmit, pjutil.Filter, error) {
 	org, repo, sha := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Head.SHA
 
 	contextGetter := func() (sets.Set[string], sets.Set[string], error) {
@@ -209,12 +298,13 @@ func FilterPresubmits(honorOkToTest bool, gitHubClient GitHubClient, body string
 
 	filter, err := pjutil.PresubmitFilter(honorOkToTest, contextGetter, body, logger)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	number, branch := pr.Number, pr.Base.Ref
 	changes := config.NewGitHubDeferredChangedFilesProvider(gitHubClient, org, repo, number)
-	return pjutil.FilterPresubmits(filter, changes, branch, presubmits, logger)
+	toTest, err := pjutil.FilterPresubmits(filter, changes, branch, presubmits, logger)
+	return toTest, filter, err
 }
 
 func getContexts(combinedStatus *github.CombinedStatus) (sets.Set[string], sets.Set[string]) {
@@ -233,11 +323,19 @@ func getContexts(combinedStatus *github.CombinedStatus) (sets.Set[string], sets.
 
 func addHelpComment(githubClient githubClient, body, org, repo, branch string, number int, presubmits []config.Presubmit, HTMLURL, user, note string, logger *logrus.Entry) error {
 	changes := config.NewGitHubDeferredChangedFilesProvider(githubClient, org, repo, number)
-	testAllNames, optionalJobsCommands, requiredJobsCommands, err := pjutil.AvailablePresubmits(changes, branch, presubmits, logger)
-	if err != nil {
-		return err
+	var resp string
+	if pjutil.TestHelpRe.MatchString(body) {
+		groups, err := pjutil.AvailablePresubmitGroups(changes, branch, presubmits, logger)
+		if err != nil {
+			return err
+		}
+		resp = pjutil.PresubmitGroupsMessage(org, repo, branch, groups)
+	} else {
+		testAllNames, optionalJobsCommands, requiredJobsCommands, err := pjutil.AvailablePresubmits(changes, branch, presubmits, logger)
+		if err != nil {
+			return err
+		}
+		resp = pjutil.HelpMessage(org, repo, branch, note, testAllNames, optionalJobsCommands, requiredJobsCommands)
 	}
-
-	resp := pjutil.HelpMessage(org, repo, branch, note, testAllNames, optionalJobsCommands, requiredJobsCommands)
 	return githubClient.CreateComment(org, repo, number, plugins.FormatResponseRaw(body, HTMLURL, user, resp))
 }
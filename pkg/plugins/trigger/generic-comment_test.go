@@ -22,8 +22,12 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clienttesting "k8s.io/client-go/testing"
 
@@ -32,6 +36,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/plugins"
@@ -67,6 +72,10 @@ type testcase struct {
 func TestHandleGenericComment(t *testing.T) {
 	helpComment := "The following commands are available to trigger required jobs:\n* `/test jib`\n* `/test job`\n\n"
 	helpTestAllWithJobsComment := fmt.Sprintf("Use `/test all` to run the following jobs that were automatically triggered:%s\n\n", "\n* `job`")
+	groupsComment := "Required jobs that always run:\n* `/test jib`\n* `/test job`\n\n"
+	groupsFooter := "Use `/test all` to run all jobs that are triggered automatically, or `/test required` to only run the required ones."
+	eligibleGroupsComment := "Required jobs that always run:\n* `/test job`\n\n" +
+		"Required jobs that are not triggered by `/test all`:\n* `/test jib`\n\n" + groupsFooter
 	var testcases = []testcase{
 		{
 			name: "Not a PR.",
@@ -994,7 +1003,7 @@ func TestHandleGenericComment(t *testing.T) {
 					},
 				},
 			},
-			AddedComment: helpComment + "Use `/test all` to run all jobs.",
+			AddedComment: groupsComment + groupsFooter,
 		},
 		{
 			name:   `help command "/test ?" uses unique RerunCommand field of presubmits`,
@@ -1038,9 +1047,8 @@ func TestHandleGenericComment(t *testing.T) {
 					},
 				},
 			},
-			AddedComment: "@trusted-member: The following commands are available to trigger required jobs:\n" +
-				"* `/command_foo`\n* `/rerun_command`\n\n" +
-				"Use `/test all` to run all jobs.",
+			AddedComment: "@trusted-member: Required jobs that always run:\n* `/command_foo`\n* `/rerun_command`\n\n" +
+				groupsFooter,
 		},
 		{
 			name:         "/test with no target results in a help message",
@@ -1114,7 +1122,7 @@ func TestHandleGenericComment(t *testing.T) {
 					},
 				},
 			},
-			AddedComment: helpComment + helpTestAllWithJobsComment,
+			AddedComment: eligibleGroupsComment,
 		},
 		{
 			name:   "when no jobs can be run with /test all, respond accordingly",
@@ -1196,7 +1204,7 @@ func TestHandleGenericComment(t *testing.T) {
 					},
 				},
 			},
-			AddedComment: helpComment + helpTestAllWithJobsComment,
+			AddedComment: eligibleGroupsComment,
 		},
 		{
 			name:   `help command "/test ?" differs between optional and required jobs`,
@@ -1242,9 +1250,108 @@ func TestHandleGenericComment(t *testing.T) {
 					},
 				},
 			},
-			AddedComment: helpComment +
-				"The following commands are available to trigger optional jobs:\n* `/test jub`\n\n" +
-				"Use `/test all` to run all jobs.",
+			AddedComment: groupsComment +
+				"Optional jobs:\n* `/test jub`\n\n" +
+				groupsFooter,
+		},
+		{
+			name:   "/test required only runs required jobs",
+			Author: "trusted-member",
+			Body:   "/test required",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:      config.JobBase{Name: "job"},
+						AlwaysRun:    true,
+						Reporter:     config.Reporter{Context: "pull-job"},
+						Trigger:      `(?m)^/test (?:.*? )?job(?: .*?)?$`,
+						RerunCommand: `/test job`,
+					},
+					{
+						JobBase:      config.JobBase{Name: "jub"},
+						AlwaysRun:    true,
+						Optional:     true,
+						Reporter:     config.Reporter{Context: "pull-jub"},
+						Trigger:      `(?m)^/test (?:.*? )?jub(?: .*?)?$`,
+						RerunCommand: `/test jub`,
+					},
+					{
+						JobBase:      config.JobBase{Name: "jib"},
+						Reporter:     config.Reporter{Context: "pull-jib"},
+						Trigger:      `(?m)^/test (?:.*? )?jib(?: .*?)?$`,
+						RerunCommand: `/test jib`,
+					},
+				},
+			},
+			ShouldBuild:   true,
+			StartsExactly: "pull-job",
+		},
+		{
+			name:   "/test required without required jobs responds accordingly",
+			Author: "trusted-member",
+			Body:   "/test required",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:      config.JobBase{Name: "jub"},
+						AlwaysRun:    true,
+						Optional:     true,
+						Reporter:     config.Reporter{Context: "pull-jub"},
+						Trigger:      `(?m)^/test (?:.*? )?jub(?: .*?)?$`,
+						RerunCommand: `/test jub`,
+					},
+				},
+			},
+			AddedComment: pjutil.ThereAreNoRequiredJobsNote + "The following commands are available to trigger optional jobs:\n* `/test jub`\n\n",
+		},
+		{
+			name:   `help command "/test ?" groups run_if_changed jobs by the changed files`,
+			Author: "trusted-member",
+			Body:   "/test ?",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:      config.JobBase{Name: "job"},
+						AlwaysRun:    true,
+						Reporter:     config.Reporter{Context: "pull-job"},
+						Trigger:      `(?m)^/test (?:.*? )?job(?: .*?)?$`,
+						RerunCommand: `/test job`,
+					},
+					{
+						JobBase:             config.JobBase{Name: "jeb"},
+						RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "CHANGED"},
+						Reporter:            config.Reporter{Context: "pull-jeb"},
+						Trigger:             `(?m)^/test (?:.*? )?jeb(?: .*?)?$`,
+						RerunCommand:        `/test jeb`,
+					},
+					{
+						JobBase:             config.JobBase{Name: "jab"},
+						RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "UNCHANGED"},
+						Reporter:            config.Reporter{Context: "pull-jab"},
+						Trigger:             `(?m)^/test (?:.*? )?jab(?: .*?)?$`,
+						RerunCommand:        `/test jab`,
+					},
+					{
+						JobBase:             config.JobBase{Name: "jub"},
+						RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "CHANGED"},
+						Optional:            true,
+						Reporter:            config.Reporter{Context: "pull-jub"},
+						Trigger:             `(?m)^/test (?:.*? )?jub(?: .*?)?$`,
+						RerunCommand:        `/test jub`,
+					},
+				},
+			},
+			AddedComment: "Required jobs that always run:\n* `/test job`\n\n" +
+				"Required jobs that run because of the changed files:\n* `/test jeb`\n\n" +
+				"Required jobs that are not run for the changed files:\n* `/test jab`\n\n" +
+				"Optional jobs:\n* `/test jub`\n\n" +
+				groupsFooter,
 		},
 	}
 	for _, tc := range testcases {
@@ -1368,6 +1475,9 @@ func validate(t *testing.T, actions []clienttesting.Action, g *fakegithub.FakeCl
 		case clienttesting.CreateActionImpl:
 			if prowJob, ok := action.Object.(*prowapi.ProwJob); ok {
 				startedContexts.Insert(prowJob.Spec.Context)
+				if triggeredBy := prowJob.Annotations[kube.TriggeredByAnnotation]; triggeredBy != tc.Author {
+					t.Errorf("expected job to be triggered by %q, got %q", tc.Author, triggeredBy)
+				}
 			}
 		}
 	}
@@ -1481,3 +1591,102 @@ func TestRetestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterRetriggerLimited(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 15, 0, 0, 0, time.UTC)
+	pr := &github.PullRequest{
+		Number: 1,
+		Base: github.PullRequestBranch{
+			Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+		},
+	}
+	prowJob := func(name, job string, pull string, started time.Time) runtime.Object {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "prowjobs",
+				Labels: map[string]string{
+					kube.OrgLabel:         "org",
+					kube.RepoLabel:        "repo",
+					kube.PullLabel:        pull,
+					kube.ProwJobTypeLabel: string(prowapi.PresubmitJob),
+				},
+			},
+			Spec:   prowapi.ProwJobSpec{Job: job},
+			Status: prowapi.ProwJobStatus{StartTime: metav1.NewTime(started)},
+		}
+	}
+	presubmits := []config.Presubmit{{JobBase: config.JobBase{Name: "e2e"}}, {JobBase: config.JobBase{Name: "unit"}}}
+
+	var testCases = []struct {
+		name            string
+		limits          map[string]int
+		existing        []runtime.Object
+		expectedJobs    []string
+		expectedMessage string
+	}{
+		{
+			name:         "no limits configured lets all jobs run",
+			existing:     []runtime.Object{prowJob("a", "e2e", "1", now.Add(-time.Minute))},
+			expectedJobs: []string{"e2e", "unit"},
+		},
+		{
+			name:         "job below its limit runs",
+			limits:       map[string]int{"e2e": 2},
+			existing:     []runtime.Object{prowJob("a", "e2e", "1", now.Add(-time.Minute))},
+			expectedJobs: []string{"e2e", "unit"},
+		},
+		{
+			name:   "job at its limit is cooling down",
+			limits: map[string]int{"e2e": 2},
+			existing: []runtime.Object{
+				prowJob("a", "e2e", "1", now.Add(-10*time.Minute)),
+				prowJob("b", "e2e", "1", now.Add(-40*time.Minute)),
+			},
+			expectedJobs:    []string{"unit"},
+			expectedMessage: "The following jobs were not triggered because they reached their limit of runs per hour on this pull request:\n* `e2e` ran 2 times in the last hour (limit 2), it can be triggered again after Fri, 02 Jan 2026 15:20:00 UTC.",
+		},
+		{
+			name:   "runs outside of the window and on other PRs or of other jobs are not counted",
+			limits: map[string]int{"e2e": 1},
+			existing: []runtime.Object{
+				prowJob("a", "e2e", "1", now.Add(-2*time.Hour)),
+				prowJob("b", "e2e", "2", now.Add(-time.Minute)),
+				prowJob("c", "unit", "1", now.Add(-time.Minute)),
+			},
+			expectedJobs: []string{"e2e", "unit"},
+		},
+		{
+			name:   "job over its limit can run again once enough runs left the window",
+			limits: map[string]int{"e2e": 1, "unit": 5},
+			existing: []runtime.Object{
+				prowJob("a", "e2e", "1", now.Add(-50*time.Minute)),
+				prowJob("b", "e2e", "1", now.Add(-20*time.Minute)),
+			},
+			expectedJobs:    []string{"unit"},
+			expectedMessage: "The following jobs were not triggered because they reached their limit of runs per hour on this pull request:\n* `e2e` ran 2 times in the last hour (limit 1), it can be triggered again after Fri, 02 Jan 2026 15:40:00 UTC.",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Client{
+				ProwJobClient: fake.NewSimpleClientset(tc.existing...).ProwV1().ProwJobs("prowjobs"),
+				Logger:        logrus.WithField("plugin", PluginName),
+			}
+			allowed, message, err := filterRetriggerLimited(c, tc.limits, pr, presubmits, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var jobs []string
+			for _, job := range allowed {
+				jobs = append(jobs, job.Name)
+			}
+			if diff := cmp.Diff(tc.expectedJobs, jobs); diff != "" {
+				t.Errorf("allowed jobs differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedMessage, message); diff != "" {
+				t.Errorf("message differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
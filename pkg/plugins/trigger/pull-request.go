@@ -356,7 +356,12 @@ func buildAllButDrafts(c Client, pr *github.PullRequest, eventGUID string, baseS
 func buildAll(c Client, pr *github.PullRequest, eventGUID string, baseSHA string, presubmits []config.Presubmit) error {
 	org, repo, number, branch := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Number, pr.Base.Ref
 	changes := config.NewGitHubDeferredChangedFilesProvider(c.GitHubClient, org, repo, number)
-	toTest, err := pjutil.FilterPresubmits(pjutil.NewTestAllFilter(), changes, branch, presubmits, c.Logger)
+	filter := pjutil.NewTestAllFilter()
+	toTest, err := pjutil.FilterPresubmits(filter, changes, branch, presubmits, c.Logger)
+	if err != nil {
+		return err
+	}
+	toTest, err = filterChangedContent(c, filter, pr, toTest)
 	if err != nil {
 		return err
 	}
@@ -55,6 +55,7 @@ func createRefs(pe github.PushEvent) prowapi.Refs {
 		BaseRef:  pe.Branch(),
 		BaseSHA:  pe.After,
 		BaseLink: pe.Compare,
+		CloneURI: pe.Repo.CloneURI,
 	}
 }
 
@@ -74,6 +75,10 @@ func handlePE(c Client, pe github.PushEvent) error {
 	postsubmits := getPostsubmits(c.Logger, c.GitClient, c.Config, org+"/"+repo, shaGetter)
 
 	for _, j := range postsubmits {
+		if j.RunsAfterSuccess() {
+			// Triggered by the dependent-jobs controller instead.
+			continue
+		}
 		if shouldRun, err := j.ShouldRun(pe.Branch(), listPushEventChanges(pe)); err != nil {
 			return err
 		} else if !shouldRun {
@@ -58,6 +58,12 @@ func TestCreateRefs(t *testing.T) {
 	if actual := createRefs(pe); !equality.Semantic.DeepEqual(expected, actual) {
 		t.Errorf("diff between expected and actual refs:%s", diff.ObjectReflectDiff(expected, actual))
 	}
+
+	pe.Repo.CloneURI = "https://gitlab.example.com/kubernetes/repo.git"
+	expected.CloneURI = pe.Repo.CloneURI
+	if actual := createRefs(pe); !equality.Semantic.DeepEqual(expected, actual) {
+		t.Errorf("diff between expected and actual refs of repo not on GitHub:%s", diff.ObjectReflectDiff(expected, actual))
+	}
 }
 
 func TestHandlePE(t *testing.T) {
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/cache"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
@@ -97,6 +98,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 				JoinOrgURL:     "https://github.com/kubernetes/community/blob/master/community-membership.md",
 				OnlyOrgMembers: true,
 				IgnoreOkToTest: true,
+				RetriggerLimits: map[string]int{
+					"pull-org-repo-e2e": 3,
+				},
 			},
 		},
 	})
@@ -106,10 +110,12 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 	pluginHelp := &pluginhelp.PluginHelp{
 		Description: `The trigger plugin starts jobs in reaction to various events.
 <br>Presubmit jobs are run automatically on pull requests that are trusted and not in a draft state with file changes matching the file filters and targeting a branch matching the branch filters.
+<br>Presubmit jobs configured with 'run_if_changed_content' additionally require a line added or removed in the matching files to match, required jobs skipped this way are reported as successful.
 <br>A pull request is considered trusted if the author is a member of the 'trusted organization' for the repository or if such a member has left an '/ok-to-test' command on the PR.
 <br>Trigger will not automatically start jobs for a PR in draft state, and if a PR is changed to draft it cancels pending jobs.
 <br>If jobs are not run automatically for a PR because it is not trusted or is in draft state, a trusted user can still start jobs manually via the '/test' command.
 <br>The '/retest' command can be used to rerun jobs that have reported failure.
+<br>Jobs may be configured with a limit of runs per hour on the same PR, after which '/test' and '/retest' will not start them again until the hour has passed.
 <br>Trigger starts postsubmit jobs when commits are pushed if the filters on the job match files and branches affected by that push.`,
 		Config:  configInfo,
 		Snippet: yamlSnippet,
@@ -128,6 +134,13 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
 		Examples:    []string{"/test all", "/test pull-bazel-test"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/test required",
+		Description: "Manually starts the automatically triggered test jobs that are required for merge.",
+		Featured:    false,
+		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
+		Examples:    []string{"/test required"},
+	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/retest",
 		Description: "Rerun test jobs that have failed.",
@@ -137,7 +150,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/test ?",
-		Description: "List available test job(s) for a trusted PR.",
+		Description: "List available test job(s) for a trusted PR, grouped by whether they always run, run because of the changed files or are optional.",
 		Featured:    true,
 		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
 		Examples:    []string{"/test ?"},
@@ -309,17 +322,108 @@ func validateContextOverlap(toRun, toSkip []config.Presubmit) error {
 	return nil
 }
 
+// changedContentCacheSize is the number of pull request heads whose changes are
+// cached to evaluate run_if_changed_content.
+const changedContentCacheSize = 100
+
+// changedContentCache holds the changes of recently seen pull request heads, so
+// that evaluating run_if_changed_content for every comment and push on a pull
+// request does not use up the GitHub rate limit.
+var changedContentCache = newChangedContentCache()
+
+func newChangedContentCache() *cache.LRUCache {
+	lruCache, err := cache.NewLRUCache(changedContentCacheSize, cache.Callbacks{})
+	if err != nil {
+		panic(fmt.Sprintf("failed to create changed content cache: %v", err))
+	}
+	return lruCache
+}
+
+type changedContentKey struct {
+	org, repo, base string
+	number          int
+	sha             string
+}
+
+// changedContentProvider lazily fetches the changes of the pull request including
+// their patches. They are cached per head SHA and base branch as they do not
+// change unless either does.
+func changedContentProvider(ghc githubClient, pr *github.PullRequest) config.ChangedContentProvider {
+	key := changedContentKey{
+		org:    pr.Base.Repo.Owner.Login,
+		repo:   pr.Base.Repo.Name,
+		base:   pr.Base.Ref,
+		number: pr.Number,
+		sha:    pr.Head.SHA,
+	}
+	return func() ([]github.PullRequestChange, error) {
+		changes, _, err := changedContentCache.GetOrAdd(key, func() (interface{}, error) {
+			changes, err := ghc.GetPullRequestChanges(key.org, key.repo, key.number)
+			return changes, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting pull request changes: %w", err)
+		}
+		return changes.([]github.PullRequestChange), nil
+	}
+}
+
+// filterChangedContent removes the presubmits whose run_if_changed_content does
+// not match the changes of the pull request, unless the filter forces them to
+// run. Required contexts of the skipped presubmits are reported as successful,
+// as they would otherwise block merging the pull request.
+func filterChangedContent(c Client, filter pjutil.Filter, pr *github.PullRequest, presubmits []config.Presubmit) ([]config.Presubmit, error) {
+	toTest, skipped, err := pjutil.FilterPresubmitsByContent(filter, changedContentProvider(c.GitHubClient, pr), presubmits)
+	if err != nil {
+		return nil, err
+	}
+	var required []config.Presubmit
+	for _, job := range skipped {
+		c.Logger.WithField("job", job.Name).Info("Not starting job, no changed lines match run_if_changed_content.")
+		if job.ContextRequired() {
+			required = append(required, job)
+		}
+	}
+	if len(required) == 0 {
+		return toTest, nil
+	}
+
+	// Failing to report the skipped contexts must not keep the other jobs from
+	// starting, the contexts will be reported again on the next trigger.
+	org, repo, sha := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Head.SHA
+	combinedStatus, err := c.GitHubClient.GetCombinedStatus(org, repo, sha)
+	if err != nil {
+		c.Logger.WithError(err).Warn("Failed to get statuses to report skipped jobs.")
+		return toTest, nil
+	}
+	_, allContexts := getContexts(combinedStatus)
+	for _, job := range required {
+		// Do not overwrite the result of an explicitly triggered run.
+		if allContexts.Has(job.Context) {
+			continue
+		}
+		if err := c.GitHubClient.CreateStatus(org, repo, sha, github.Status{
+			State:       github.StatusSuccess,
+			Context:     job.Context,
+			Description: "Skipped: no changed lines match run_if_changed_content.",
+		}); err != nil {
+			c.Logger.WithError(err).WithField("job", job.Name).Warn("Failed to report skipped job.")
+		}
+	}
+	return toTest, nil
+}
+
 // RunRequested executes the config.Presubmits that are requested
 func RunRequested(c Client, pr *github.PullRequest, baseSHA string, requestedJobs []config.Presubmit, eventGUID string) error {
-	return runRequested(c, pr, baseSHA, requestedJobs, eventGUID, nil)
+	return runRequested(c, pr, baseSHA, requestedJobs, eventGUID, nil, nil)
 }
 
 // RunRequestedWithLabels executes the config.Presubmits that are requested with the additional labels
 func RunRequestedWithLabels(c Client, pr *github.PullRequest, baseSHA string, requestedJobs []config.Presubmit, eventGUID string, labels map[string]string) error {
-	return runRequested(c, pr, baseSHA, requestedJobs, eventGUID, labels)
+	return runRequested(c, pr, baseSHA, requestedJobs, eventGUID, labels, nil)
 }
 
-func runRequested(c Client, pr *github.PullRequest, baseSHA string, requestedJobs []config.Presubmit, eventGUID string, labels map[string]string, millisecondOverride ...time.Duration) error {
+func runRequested(c Client, pr *github.PullRequest, baseSHA string, requestedJobs []config.Presubmit, eventGUID string, labels, annotations map[string]string, millisecondOverride ...time.Duration) error {
 	var errors []error
 
 	// If the PR is not mergeable (e.g. due to merge conflicts),we will not trigger any jobs,
@@ -331,6 +435,9 @@ func runRequested(c Client, pr *github.PullRequest, baseSHA string, requestedJob
 	for _, job := range requestedJobs {
 		c.Logger.Infof("Starting %s build.", job.Name)
 		pj := pjutil.NewPresubmit(*pr, baseSHA, job, eventGUID, labels, pjutil.RequireScheduling(c.Config.Scheduler.Enabled))
+		for k, v := range annotations {
+			pj.Annotations[k] = v
+		}
 		c.Logger.WithFields(pjutil.ProwJobFields(&pj)).Info("Creating a new prowjob.")
 		if err := createWithRetry(context.TODO(), c.ProwJobClient, &pj, millisecondOverride...); err != nil {
 			c.Logger.WithError(err).Error("Failed to create prowjob.")
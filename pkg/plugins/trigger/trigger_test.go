@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
@@ -242,7 +244,7 @@ func TestRunRequested(t *testing.T) {
 				Logger:        logrus.WithField("testcase", testCase.name),
 			}
 
-			err := runRequested(client, testCase.pr, fakegithub.TestRef, testCase.requestedJobs, "event-guid", nil, time.Nanosecond)
+			err := runRequested(client, testCase.pr, fakegithub.TestRef, testCase.requestedJobs, "event-guid", nil, nil, time.Nanosecond)
 			if err == nil && testCase.expectedErr {
 				t.Error("failed to receive an error")
 			}
@@ -270,6 +272,113 @@ func TestRunRequested(t *testing.T) {
 	}
 }
 
+func TestFilterChangedContent(t *testing.T) {
+	presubmits := []config.Presubmit{
+		{
+			JobBase:  config.JobBase{Name: "unit"},
+			Reporter: config.Reporter{Context: "unit"},
+		},
+		{
+			JobBase:  config.JobBase{Name: "api-compat"},
+			Reporter: config.Reporter{Context: "api-compat"},
+			RegexpChangeMatcher: config.RegexpChangeMatcher{
+				RunIfChanged:        `^pkg/apis/`,
+				RunIfChangedContent: `^type .* struct`,
+			},
+		},
+		{
+			JobBase:  config.JobBase{Name: "api-docs"},
+			Reporter: config.Reporter{Context: "api-docs"},
+			Optional: true,
+			RegexpChangeMatcher: config.RegexpChangeMatcher{
+				RunIfChanged:        `^pkg/apis/`,
+				RunIfChangedContent: `^// `,
+			},
+		},
+	}
+	if err := config.SetPresubmitRegexes(presubmits); err != nil {
+		t.Fatalf("failed to set presubmit regexes: %v", err)
+	}
+
+	testCases := []struct {
+		name             string
+		sha              string
+		patch            string
+		existingContexts []string
+		expectedJobs     []string
+		expectedStatuses []github.Status
+	}{
+		{
+			name:         "struct definition changed",
+			sha:          "struct",
+			patch:        "@@ -1 +1,2 @@\n package apis\n+type Foo struct{}",
+			expectedJobs: []string{"unit", "api-compat"},
+		},
+		{
+			name:         "comment changed, required job is reported as skipped",
+			sha:          "comment",
+			patch:        "@@ -1 +1,2 @@\n package apis\n+// Foo is a comment.",
+			expectedJobs: []string{"unit", "api-docs"},
+			expectedStatuses: []github.Status{{
+				State:       github.StatusSuccess,
+				Context:     "api-compat",
+				Description: "Skipped: no changed lines match run_if_changed_content.",
+			}},
+		},
+		{
+			name:             "existing status is not overwritten",
+			sha:              "existing",
+			patch:            "@@ -1 +1,2 @@\n package apis\n+var foo = 1",
+			existingContexts: []string{"api-compat"},
+			expectedJobs:     []string{"unit"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ghc := fakegithub.NewFakeClient()
+			ghc.PullRequestChanges[1] = []github.PullRequestChange{{Filename: "pkg/apis/types.go", Patch: tc.patch}}
+			combinedStatus := &github.CombinedStatus{}
+			for _, context := range tc.existingContexts {
+				combinedStatus.Statuses = append(combinedStatus.Statuses, github.Status{State: github.StatusFailure, Context: context})
+			}
+			ghc.CombinedStatuses[tc.sha] = combinedStatus
+			c := Client{GitHubClient: ghc, Logger: logrus.WithField("test", tc.name)}
+			pr := &github.PullRequest{Number: 1, Head: github.PullRequestBranch{SHA: tc.sha}}
+			pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Base.Ref = "org", "repo", "main"
+
+			toTest, err := filterChangedContent(c, pjutil.NewTestAllFilter(), pr, presubmits)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var jobs []string
+			for _, job := range toTest {
+				jobs = append(jobs, job.Name)
+			}
+			if diff := cmp.Diff(tc.expectedJobs, jobs); diff != "" {
+				t.Errorf("jobs to test differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedStatuses, ghc.CreatedStatuses[tc.sha]); diff != "" {
+				t.Errorf("created statuses differ from expected (-want +got):\n%s", diff)
+			}
+
+			// The changes of the same head are served from the cache.
+			ghc.PullRequestChanges[1] = nil
+			cached, err := filterChangedContent(c, pjutil.NewTestAllFilter(), pr, presubmits)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var cachedJobs []string
+			for _, job := range cached {
+				cachedJobs = append(cachedJobs, job.Name)
+			}
+			if diff := cmp.Diff(jobs, cachedJobs); diff != "" {
+				t.Errorf("jobs to test with cached changes differ (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateContextOverlap(t *testing.T) {
 	var testCases = []struct {
 		name          string
@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifyowners
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/golint"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/plugins/trigger"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+// membershipIssueTitle is the title of the issue the periodic membership scan
+// keeps up to date in every repo it finds untrusted owners in.
+const membershipIssueTitle = "OWNERS files reference users who are no longer trusted"
+
+// ownerEntryRe matches a list entry of an OWNERS or OWNERS_ALIASES file and
+// captures the login it names.
+var ownerEntryRe = regexp.MustCompile(`^\s*-\s*["']?@?([\w-]+)["']?\s*(#.*)?$`)
+
+// ownerEntryLines returns the 1-based numbers of the lines of an OWNERS or
+// OWNERS_ALIASES file that name one of the users, mapped to the user.
+func ownerEntryLines(content []byte, users sets.Set[string]) map[int]string {
+	lines := map[int]string{}
+	for i, line := range strings.Split(string(content), "\n") {
+		match := ownerEntryRe.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if user := github.NormLogin(match[1]); users.Has(user) {
+			lines[i+1] = user
+		}
+	}
+	return lines
+}
+
+// suggestRemovals creates review comments suggesting to remove the lines a
+// pull request adds for untrusted users to the given files.
+func suggestRemovals(dir string, changes []github.PullRequestChange, nonTrustedUsers map[string]nonTrustedReasons, log *logrus.Entry) []github.DraftReviewComment {
+	reasons := map[string]string{}
+	for user, r := range nonTrustedUsers {
+		reasons[github.NormLogin(user)] = r.triggerReason
+	}
+	users := sets.KeySet(reasons)
+	var comments []github.DraftReviewComment
+	for _, change := range changes {
+		content, err := os.ReadFile(filepath.Join(dir, change.Filename))
+		if err != nil {
+			log.WithError(err).Warnf("Cannot read %s to suggest removing untrusted users.", change.Filename)
+			continue
+		}
+		added, err := golint.AddedLines(strings.TrimSuffix(change.Patch, "\n"))
+		if err != nil {
+			log.WithError(err).Warnf("Failed to compute added lines in %s.", change.Filename)
+			continue
+		}
+		lines := ownerEntryLines(content, users)
+		for _, line := range sets.List(sets.KeySet(lines)) {
+			position, ok := added[line]
+			if !ok {
+				continue
+			}
+			comments = append(comments, github.DraftReviewComment{
+				Path:     change.Filename,
+				Position: position,
+				Body:     fmt.Sprintf("%s is untrusted: %s\n```suggestion\n```", lines[line], reasons[lines[line]]),
+			})
+		}
+	}
+	return comments
+}
+
+// removalPatch returns a unified diff removing the given lines from a file.
+func removalPatch(filename string, content []byte, remove sets.Set[int]) string {
+	const context = 3
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	removed := sets.List(remove)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", filename, filename)
+	var removedBefore int
+	for i := 0; i < len(removed); {
+		start := max(removed[i]-context, 1)
+		end := min(removed[i]+context, len(lines))
+		j := i + 1
+		for j < len(removed) && removed[j]-context <= end+1 {
+			end = min(removed[j]+context, len(lines))
+			j++
+		}
+		inHunk := j - i
+		oldCount := end - start + 1
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", start, oldCount, start-removedBefore, oldCount-inHunk)
+		for line := start; line <= end; line++ {
+			prefix := " "
+			if remove.Has(line) {
+				prefix = "-"
+			}
+			fmt.Fprintf(&b, "%s%s\n", prefix, lines[line-1])
+		}
+		removedBefore += inHunk
+		i = j
+	}
+	return b.String()
+}
+
+// ownersFileUsers returns the users an OWNERS file references, without
+// aliases and team references.
+func ownersFileUsers(content []byte, aliases repoowners.RepoAliases) ([]string, error) {
+	var users []string
+	simple, err := repoowners.LoadSimpleConfig(content)
+	if err != nil || simple.Empty() {
+		full, err := repoowners.LoadFullConfig(content)
+		if err != nil {
+			return nil, err
+		}
+		for _, filter := range full.Filters {
+			users = append(users, filter.Reviewers...)
+			users = append(users, filter.Approvers...)
+		}
+	} else {
+		users = append(simple.Config.Reviewers, simple.Config.Approvers...)
+	}
+	var result []string
+	for _, user := range users {
+		user = github.NormLogin(user)
+		if _, isAlias := aliases[user]; isAlias || repoowners.IsTeamRef(user) {
+			continue
+		}
+		result = append(result, user)
+	}
+	return result, nil
+}
+
+type membershipScanClient interface {
+	IsCollaborator(owner, repo, login string) (bool, error)
+	IsMember(org, user string) (bool, error)
+	BotUserChecker() (func(candidate string) bool, error)
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	CreateIssue(org, repo, title, body string, milestone int, labels, assignees []string) (int, error)
+	EditIssue(org, repo string, number int, issue *github.Issue) (*github.Issue, error)
+	CloseIssue(org, repo string, number int) error
+}
+
+// RunMembershipScan periodically scans the OWNERS files of the repos
+// configured in owners.membership_scan for users who are no longer trusted,
+// until the context is done. Repos that do not enable the plugin are skipped.
+// Nothing is scanned while the scan is not configured. Only one replica of
+// hook may run the scan, so it runs behind leader election.
+func RunMembershipScan(ctx context.Context, ghc membershipScanClient, gc git.ClientFactory, getConfig func() *plugins.Configuration) error {
+	for {
+		interval := time.Hour
+		if cfg := getConfig(); cfg.Owners.MembershipScan != nil {
+			interval = cfg.Owners.MembershipScan.IntervalDuration
+			log := logrus.WithField("plugin", PluginName)
+			start := time.Now()
+			for _, orgRepo := range cfg.Owners.MembershipScan.Repos {
+				org, repo, _ := strings.Cut(orgRepo, "/")
+				repoLog := log.WithField("repo", orgRepo)
+				if !pluginEnabled(cfg, org, repo) {
+					repoLog.Warnf("Not scanning OWNERS files for untrusted users, the %s plugin is not enabled.", PluginName)
+					continue
+				}
+				if err := scanMembership(ghc, gc, repoLog, org, repo, cfg.OwnersFilenames(org, repo), cfg.TriggerFor(org, repo)); err != nil {
+					repoLog.WithError(err).Error("Failed to scan OWNERS files for untrusted users.")
+				}
+			}
+			log.WithField("duration", time.Since(start).String()).Info("Scanned OWNERS files for untrusted users.")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pluginEnabled returns whether the plugin is enabled for the repo.
+func pluginEnabled(cfg *plugins.Configuration, org, repo string) bool {
+	orgs, repos, orgExceptions := cfg.EnabledReposForPlugin(PluginName)
+	orgRepo := org + "/" + repo
+	for _, enabled := range repos {
+		if enabled == orgRepo {
+			return true
+		}
+	}
+	for _, enabled := range orgs {
+		if enabled == org && !orgExceptions[org].Has(orgRepo) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanMembership checks every user referenced in the OWNERS and
+// OWNERS_ALIASES files of a repo and keeps an issue listing the untrusted ones
+// up to date. The issue is closed once no untrusted users are left.
+func scanMembership(ghc membershipScanClient, gc git.ClientFactory, log *logrus.Entry, org, repo string, filenames ownersconfig.Filenames, triggerConfig plugins.Trigger) error {
+	r, err := gc.ClientFor(org, repo)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.Clean(); err != nil {
+			log.WithError(err).Error("Error cleaning up repo.")
+		}
+	}()
+
+	aliases := repoowners.RepoAliases{}
+	contents := map[string][]byte{}
+	users := map[string][]string{}
+	if b, err := os.ReadFile(filepath.Join(r.Directory(), filenames.OwnersAliases)); err == nil {
+		if aliases, err = repoowners.ParseAliasesConfig(b); err != nil {
+			return fmt.Errorf("error parsing %s: %w", filenames.OwnersAliases, err)
+		}
+		contents[filenames.OwnersAliases] = b
+		users[filenames.OwnersAliases] = sets.List(aliases.ExpandAllAliases())
+	}
+	err = filepath.WalkDir(r.Directory(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != filenames.Owners {
+			return nil
+		}
+		rel, err := filepath.Rel(r.Directory(), path)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fileUsers, err := ownersFileUsers(b, aliases)
+		if err != nil {
+			log.WithError(err).Warnf("Skipping %s that cannot be parsed.", rel)
+			return nil
+		}
+		contents[rel] = b
+		users[rel] = fileUsers
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error walking the repo: %w", err)
+	}
+
+	untrusted := map[string]string{}
+	checked := sets.New[string]()
+	for _, fileUsers := range users {
+		for _, user := range fileUsers {
+			if checked.Has(user) {
+				continue
+			}
+			checked.Insert(user)
+			resp, err := trigger.TrustedUser(ghc, triggerConfig.OnlyOrgMembers, triggerConfig.TrustedApps, triggerConfig.TrustedOrg, user, org, repo)
+			if err != nil {
+				return err
+			}
+			if !resp.IsTrusted {
+				untrusted[user] = resp.Reason
+			}
+		}
+	}
+
+	var body string
+	if len(untrusted) > 0 {
+		body = membershipIssueBody(org, untrusted, contents)
+	}
+	return reportMembership(ghc, log, org, repo, body)
+}
+
+func membershipIssueBody(org string, untrusted map[string]string, contents map[string][]byte) string {
+	users := sets.KeySet(untrusted)
+	var lines []string
+	lines = append(lines, fmt.Sprintf("The following users are referenced in OWNERS files but are no longer trusted, e.g. because they left the %s org:", org))
+	for _, user := range sets.List(users) {
+		lines = append(lines, fmt.Sprintf("- %s: %s", user, untrusted[user]))
+	}
+	lines = append(lines, "", "The following patch removes them:", "", "```diff")
+	files := make([]string, 0, len(contents))
+	for file := range contents {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		remove := sets.KeySet(ownerEntryLines(contents[file], users))
+		if remove.Len() == 0 {
+			continue
+		}
+		lines = append(lines, strings.TrimSuffix(removalPatch(file, contents[file], remove), "\n"))
+	}
+	lines = append(lines, "```")
+	return strings.Join(lines, "\n")
+}
+
+// reportMembership updates the open membership issue of the repo with the
+// body, opens one if there is none, or closes it if the body is empty.
+func reportMembership(ghc membershipScanClient, log *logrus.Entry, org, repo, body string) error {
+	query := fmt.Sprintf("is:issue is:open repo:%s/%s in:title %q", org, repo, membershipIssueTitle)
+	issues, err := ghc.FindIssuesWithOrg(org, query, "", false)
+	if err != nil {
+		return fmt.Errorf("error searching for the membership issue: %w", err)
+	}
+	var existing *github.Issue
+	for i := range issues {
+		if issues[i].Title == membershipIssueTitle && issues[i].State != "closed" {
+			existing = &issues[i]
+			break
+		}
+	}
+
+	switch {
+	case existing == nil && body == "":
+		return nil
+	case existing == nil:
+		number, err := ghc.CreateIssue(org, repo, membershipIssueTitle, body, 0, nil, nil)
+		if err != nil {
+			return fmt.Errorf("error creating the membership issue: %w", err)
+		}
+		log.WithField("issue", number).Info("Opened an issue listing untrusted users in OWNERS files.")
+	case body == "":
+		if err := ghc.CloseIssue(org, repo, existing.Number); err != nil {
+			return fmt.Errorf("error closing the membership issue: %w", err)
+		}
+	case existing.Body != body:
+		if _, err := ghc.EditIssue(org, repo, existing.Number, &github.Issue{Title: membershipIssueTitle, Body: body}); err != nil {
+			return fmt.Errorf("error updating the membership issue: %w", err)
+		}
+	}
+	return nil
+}
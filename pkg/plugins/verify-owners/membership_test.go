@@ -0,0 +1,261 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifyowners
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/git/localgit"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+)
+
+func TestRemovalPatch(t *testing.T) {
+	content := []byte(`approvers:
+- alice
+- phippy
+- bob
+reviewers:
+- carol
+- dave
+- erin
+- frank
+- phippy # emeritus soon
+- grace
+- heidi
+- ivan
+- judy
+- kate
+- leo
+- mallory
+- phippy
+`)
+	expected := `--- a/OWNERS
++++ b/OWNERS
+@@ -1,13 +1,11 @@
+ approvers:
+ - alice
+-- phippy
+ - bob
+ reviewers:
+ - carol
+ - dave
+ - erin
+ - frank
+-- phippy # emeritus soon
+ - grace
+ - heidi
+ - ivan
+@@ -15,4 +13,3 @@
+ - kate
+ - leo
+ - mallory
+-- phippy
+`
+	lines := ownerEntryLines(content, sets.New[string]("phippy"))
+	if diff := cmp.Diff(map[int]string{3: "phippy", 10: "phippy", 18: "phippy"}, lines); diff != "" {
+		t.Errorf("lines differ from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expected, removalPatch("OWNERS", content, sets.KeySet(lines))); diff != "" {
+		t.Errorf("patch differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestSuggestRemovals(t *testing.T) {
+	lg, c, err := localgit.NewV2()
+	if err != nil {
+		t.Fatalf("Making localgit: %v", err)
+	}
+	defer func() {
+		if err := lg.Clean(); err != nil {
+			t.Errorf("Cleaning up localgit: %v", err)
+		}
+		if err := c.Clean(); err != nil {
+			t.Errorf("Cleaning up client: %v", err)
+		}
+	}()
+	if err := lg.MakeFakeRepo("org", "repo"); err != nil {
+		t.Fatalf("Making fake repo: %v", err)
+	}
+	if err := lg.AddCommit("org", "repo", map[string][]byte{"OWNERS": ownersFiles["nonCollaborators"]}); err != nil {
+		t.Fatalf("Adding commit: %v", err)
+	}
+	r, err := c.ClientFor("org", "repo")
+	if err != nil {
+		t.Fatalf("Cloning repo: %v", err)
+	}
+	defer r.Clean()
+
+	changes := []github.PullRequestChange{{Filename: "OWNERS", Patch: ownersPatch["nonCollaboratorAdditions"]}}
+	nonTrustedUsers := map[string]nonTrustedReasons{
+		"phippy": {triggerReason: "User is not a member of the org."},
+		"zee":    {triggerReason: "User is not a member of the org."},
+	}
+	comments := suggestRemovals(r.Directory(), changes, nonTrustedUsers, logrus.WithField("plugin", PluginName))
+	expected := []github.DraftReviewComment{
+		{Path: "OWNERS", Position: 2, Body: "phippy is untrusted: User is not a member of the org.\n```suggestion\n```"},
+		{Path: "OWNERS", Position: 5, Body: "zee is untrusted: User is not a member of the org.\n```suggestion\n```"},
+	}
+	if diff := cmp.Diff(expected, comments); diff != "" {
+		t.Errorf("suggestions differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+// removedEntryRe matches the lines of a patch that remove an owner.
+var removedEntryRe = regexp.MustCompile(`(?m)^-\s*- (\S+)$`)
+
+func TestScanMembership(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string][]byte
+		existing *github.Issue
+
+		expectedUsers  []string
+		expectIssue    bool
+		expectedClosed bool
+	}{
+		{
+			name: "users who left the org across the repo",
+			files: map[string][]byte{
+				"OWNERS":         ownersFiles["nonCollaboratorsWithAliases"],
+				"OWNERS_ALIASES": ownersAliases["nonCollaborators"],
+				"sub/OWNERS":     []byte("approvers:\n- alice\n- zee\n"),
+			},
+			expectedUsers: []string{"goldie", "phippy", "zee"},
+			expectIssue:   true,
+		},
+		{
+			name: "existing issue is updated",
+			files: map[string][]byte{
+				"OWNERS": ownersFiles["nonCollaborators"],
+			},
+			existing:      &github.Issue{Number: 1, Title: membershipIssueTitle, Body: "outdated"},
+			expectedUsers: []string{"phippy", "zee"},
+			expectIssue:   true,
+		},
+		{
+			name: "existing issue is closed once all users are trusted",
+			files: map[string][]byte{
+				"OWNERS":         ownersFiles["collaboratorsWithAliases"],
+				"OWNERS_ALIASES": ownersAliases["collaborators"],
+			},
+			existing:       &github.Issue{Number: 1, Title: membershipIssueTitle, Body: "outdated"},
+			expectedClosed: true,
+		},
+		{
+			name: "all users are trusted",
+			files: map[string][]byte{
+				"OWNERS":         ownersFiles["collaboratorsWithAliases"],
+				"OWNERS_ALIASES": ownersAliases["collaborators"],
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lg, c, err := localgit.NewV2()
+			if err != nil {
+				t.Fatalf("Making localgit: %v", err)
+			}
+			defer func() {
+				if err := lg.Clean(); err != nil {
+					t.Errorf("Cleaning up localgit: %v", err)
+				}
+				if err := c.Clean(); err != nil {
+					t.Errorf("Cleaning up client: %v", err)
+				}
+			}()
+			if err := lg.MakeFakeRepo("org", "repo"); err != nil {
+				t.Fatalf("Making fake repo: %v", err)
+			}
+			if err := lg.AddCommit("org", "repo", tc.files); err != nil {
+				t.Fatalf("Adding commit: %v", err)
+			}
+
+			fghc := fakegithub.NewFakeClient()
+			fghc.Collaborators = []string{"alice", "bob"}
+			if tc.existing != nil {
+				fghc.Issues[tc.existing.Number] = tc.existing
+			}
+
+			if err := scanMembership(fghc, c, logrus.WithField("plugin", PluginName), "org", "repo", ownersconfig.FakeFilenames, plugins.Trigger{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var issue *github.Issue
+			for _, i := range fghc.Issues {
+				if i.Title == membershipIssueTitle {
+					issue = i
+				}
+			}
+			if !tc.expectIssue && !tc.expectedClosed {
+				if issue != nil {
+					t.Fatalf("expected no issue, got %#v", issue)
+				}
+				return
+			}
+			if issue == nil {
+				t.Fatal("expected an issue, got none")
+			}
+			if closed := issue.State == "closed"; closed != tc.expectedClosed {
+				t.Errorf("expected issue to be closed to be %t, got %t", tc.expectedClosed, closed)
+			}
+			if tc.expectedClosed {
+				return
+			}
+			removed := sets.New[string]()
+			for _, match := range removedEntryRe.FindAllStringSubmatch(issue.Body, -1) {
+				removed.Insert(match[1])
+			}
+			if diff := cmp.Diff(tc.expectedUsers, sets.List(removed)); diff != "" {
+				t.Errorf("removed users differ from expected (-want +got):\n%s\nissue:\n%s", diff, issue.Body)
+			}
+			for _, user := range tc.expectedUsers {
+				if !strings.Contains(issue.Body, "- "+user+": ") {
+					t.Errorf("expected issue to list %s, got:\n%s", user, issue.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestPluginEnabled(t *testing.T) {
+	cfg := &plugins.Configuration{Plugins: plugins.Plugins{
+		"org":         {Plugins: []string{PluginName}, ExcludedRepos: []string{"excluded"}},
+		"other/repo":  {Plugins: []string{PluginName}},
+		"other/other": {Plugins: []string{"trigger"}},
+	}}
+	for orgRepo, expected := range map[string]bool{
+		"org/repo":     true,
+		"org/excluded": false,
+		"other/repo":   true,
+		"other/other":  false,
+		"third/repo":   false,
+	} {
+		org, repo, _ := strings.Cut(orgRepo, "/")
+		if actual := pluginEnabled(cfg, org, repo); actual != expected {
+			t.Errorf("%s: expected enabled to be %t, got %t", orgRepo, expected, actual)
+		}
+	}
+}
@@ -66,7 +66,7 @@ func init() {
 
 func helpProvider(c *plugins.Configuration, orgRepo []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 	pluginHelp := &pluginhelp.PluginHelp{
-		Description: fmt.Sprintf("The verify-owners plugin validates %s and %s files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the '%s' label to the PR, and a review comment on the incriminating file(s). Per-repo configuration for filenames is possible.", ownersconfig.DefaultOwnersFile, ownersconfig.DefaultOwnersAliasesFile, labels.InvalidOwners),
+		Description: fmt.Sprintf("The verify-owners plugin validates %s and %s files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the '%s' label to the PR, and a review comment on the incriminating file(s), suggesting to remove untrusted users. Per-repo configuration for filenames is possible.", ownersconfig.DefaultOwnersFile, ownersconfig.DefaultOwnersAliasesFile, labels.InvalidOwners),
 		Config:      map[string]string{},
 	}
 	defaultFilenames := c.OwnersFilenames("", "")
@@ -87,6 +87,12 @@ func helpProvider(c *plugins.Configuration, orgRepo []config.OrgRepo) (*pluginhe
 			pluginHelp.Config[item.String()] = descriptionFor(filenames)
 		}
 	}
+	if scan := c.Owners.MembershipScan; scan != nil {
+		for _, orgRepo := range scan.Repos {
+			org, repo, _ := strings.Cut(orgRepo, "/")
+			pluginHelp.Config[orgRepo] = fmt.Sprintf("%s All of them are scanned every %s for users who are no longer trusted, and an issue with a patch removing them is opened.", descriptionFor(c.OwnersFilenames(org, repo)), scan.Interval)
+		}
+	}
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/verify-owners",
 		Description: labels.InvalidOwners,
@@ -283,6 +289,11 @@ func handle(ghc githubClient, gc git.ClientFactory, roc repoownersClient, log *l
 		return fmt.Errorf("error loading RepoOwners: %w", err)
 	}
 
+	// checkedFiles are the changed files whose owners were checked for trust.
+	var checkedFiles []github.PullRequestChange
+	if ownerAliasesModified && !skipTrustedUserCheck {
+		checkedFiles = append(checkedFiles, modifiedOwnerAliasesFile)
+	}
 	for _, c := range modifiedOwnersFiles {
 		path := filepath.Join(r.Directory(), c.Filename)
 		msg, owners := parseOwnersFile(oc, path, c, log, bannedLabels, filenames)
@@ -291,11 +302,12 @@ func handle(ghc githubClient, gc git.ClientFactory, roc repoownersClient, log *l
 			continue
 		}
 
-		if !skipTrustedUserCheck {
+		if !skipTrustedUserCheck && owners != nil {
 			nonTrustedUsers, err = nonTrustedUsersInOwners(ghc, log, triggerConfig, org, repo, c.Patch, c.Filename, owners, nonTrustedUsers, trustedUsers, repoAliases)
 			if err != nil {
 				return err
 			}
+			checkedFiles = append(checkedFiles, c)
 		}
 	}
 
@@ -355,6 +367,21 @@ func handle(ghc githubClient, gc git.ClientFactory, roc repoownersClient, log *l
 		if err := ghc.CreateComment(org, repo, number, markdownFriendlyComment(org, joinOrgURL, nonTrustedUsers, filenames)); err != nil {
 			log.WithError(err).Errorf("Could not create comment for listing non-collaborators in %s files", filenames.Owners)
 		}
+
+		// Suggest removing the untrusted users right where they are added.
+		if suggestions := suggestRemovals(r.Directory(), checkedFiles, nonTrustedUsers, log); len(suggestions) > 0 {
+			draftReview := github.DraftReview{
+				Body:     fmt.Sprintf("Suggesting to remove the untrusted users from the %s files.", filenames.Owners),
+				Action:   github.Comment,
+				Comments: suggestions,
+			}
+			if pr.Head.SHA != "" {
+				draftReview.CommitSHA = pr.Head.SHA
+			}
+			if err := ghc.CreateReview(org, repo, number, draftReview); err != nil {
+				log.WithError(err).Error("Could not create a review suggesting to remove untrusted users.")
+			}
+		}
 	}
 
 	if len(wrongOwnersFiles) == 0 && len(nonTrustedUsers) == 0 {
@@ -505,6 +532,10 @@ func nonTrustedUsersInOwners(ghc githubClient, log *logrus.Entry, triggerConfig
 // and then checks if the owner is a trusted user.
 // returns a map from user to reasons for not being trusted
 func checkIfTrustedUser(ghc githubClient, log *logrus.Entry, triggerConfig plugins.Trigger, owner, patch, fileName, org, repo string, nonTrustedUsers map[string]nonTrustedReasons, trustedUsers sets.Set[string], repoAliases repoowners.RepoAliases) (map[string]nonTrustedReasons, error) {
+	// teams are resolved to their members when the OWNERS files are loaded.
+	if repoowners.IsTeamRef(owner) {
+		return nonTrustedUsers, nil
+	}
 	// cap the number of checks to avoid exhausting tokens in case of large OWNERS refactors.
 	if len(nonTrustedUsers)+trustedUsers.Len() > 50 {
 		return nonTrustedUsers, nil
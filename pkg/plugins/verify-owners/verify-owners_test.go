@@ -698,7 +698,7 @@ func TestHelpProvider(t *testing.T) {
 			config:       &plugins.Configuration{},
 			enabledRepos: enabledRepos,
 			expected: &pluginhelp.PluginHelp{
-				Description: "The verify-owners plugin validates OWNERS and OWNERS_ALIASES files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the 'do-not-merge/invalid-owners-file' label to the PR, and a review comment on the incriminating file(s). Per-repo configuration for filenames is possible.",
+				Description: "The verify-owners plugin validates OWNERS and OWNERS_ALIASES files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the 'do-not-merge/invalid-owners-file' label to the PR, and a review comment on the incriminating file(s), suggesting to remove untrusted users. Per-repo configuration for filenames is possible.",
 				Config: map[string]string{
 					"default": "OWNERS and OWNERS_ALIASES files are validated.",
 				},
@@ -719,7 +719,7 @@ func TestHelpProvider(t *testing.T) {
 			},
 			enabledRepos: enabledRepos,
 			expected: &pluginhelp.PluginHelp{
-				Description: "The verify-owners plugin validates OWNERS and OWNERS_ALIASES files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the 'do-not-merge/invalid-owners-file' label to the PR, and a review comment on the incriminating file(s). Per-repo configuration for filenames is possible.",
+				Description: "The verify-owners plugin validates OWNERS and OWNERS_ALIASES files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the 'do-not-merge/invalid-owners-file' label to the PR, and a review comment on the incriminating file(s), suggesting to remove untrusted users. Per-repo configuration for filenames is possible.",
 				Config: map[string]string{
 					"default": "OWNERS and OWNERS_ALIASES files are validated. The verify-owners plugin will complain if OWNERS files contain any of the following banned labels: label1, label2.",
 				},
@@ -731,6 +731,28 @@ func TestHelpProvider(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "membership scan configured",
+			config: &plugins.Configuration{
+				Owners: plugins.Owners{
+					MembershipScan: &plugins.OwnersMembershipScan{Repos: []string{"org1/repo"}, Interval: "24h"},
+				},
+			},
+			enabledRepos: enabledRepos,
+			expected: &pluginhelp.PluginHelp{
+				Description: "The verify-owners plugin validates OWNERS and OWNERS_ALIASES files (by default) and ensures that they always contain collaborators of the org, if they are modified in a PR. On validation failure it automatically adds the 'do-not-merge/invalid-owners-file' label to the PR, and a review comment on the incriminating file(s), suggesting to remove untrusted users. Per-repo configuration for filenames is possible.",
+				Config: map[string]string{
+					"default":   "OWNERS and OWNERS_ALIASES files are validated.",
+					"org1/repo": "OWNERS and OWNERS_ALIASES files are validated. All of them are scanned every 24h for users who are no longer trusted, and an issue with a patch removing them is opened.",
+				},
+				Commands: []pluginhelp.Command{{
+					Usage:       "/verify-owners",
+					Description: "do-not-merge/invalid-owners-file",
+					Examples:    []string{"/verify-owners"},
+					WhoCanUse:   "Anyone",
+				}},
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"sort"
 
 	"github.com/sirupsen/logrus"
 
@@ -30,6 +31,7 @@ import (
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/plugins"
 	"sigs.k8s.io/prow/pkg/plugins/trigger"
+	"sigs.k8s.io/prow/pkg/repoowners"
 )
 
 const (
@@ -43,6 +45,20 @@ type PRInfo struct {
 	Repo        string
 	AuthorLogin string
 	AuthorName  string
+	// ChangedFiles are the files the PR changes.
+	ChangedFiles []string
+	// Tests are the names of the presubmits that run for the changed files.
+	Tests []string
+	// Owners are the approvers of the changed files.
+	Owners []string
+	// AreaDocs are the contributing docs of the areas the changed files are in.
+	AreaDocs []AreaDoc
+}
+
+// AreaDoc is the contributing doc of an area of the repo.
+type AreaDoc struct {
+	Name string
+	URL  string
 }
 
 func init() {
@@ -64,8 +80,15 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 					"org/repo1",
 					"org/repo2",
 				},
-				MessageTemplate: "Welcome @{{.AuthorLogin}}!",
+				MessageTemplate: "Welcome @{{.AuthorLogin}}!{{if .Owners}} Reviews are done by {{range .Owners}}@{{.}} {{end}}{{end}}",
 				AlwaysPost:      false,
+				AreaDocs: []plugins.WelcomeAreaDoc{
+					{
+						Name:       "networking",
+						PathRegexp: "^pkg/network/",
+						URL:        "https://github.com/org/repo/blob/main/pkg/network/CONTRIBUTING.md",
+					},
+				},
 			},
 		},
 	})
@@ -82,31 +105,44 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 
 type githubClient interface {
 	CreateComment(owner, repo string, number int, comment string) error
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
 	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
 	IsCollaborator(org, repo, user string) (bool, error)
 	IsMember(org, user string) (bool, error)
 	BotUserChecker() (func(candidate string) bool, error)
 }
 
+type repoownersClient interface {
+	LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error)
+}
+
 type client struct {
 	GitHubClient githubClient
 	Logger       *logrus.Entry
+	// OwnersClient and ProwConfig are optional. Without them, the owners and
+	// the tests of the changed files are not provided to the template.
+	OwnersClient repoownersClient
+	ProwConfig   *config.Config
 }
 
 func getClient(pc plugins.Agent) client {
 	return client{
 		GitHubClient: pc.GitHubClient,
 		Logger:       pc.Logger,
+		OwnersClient: pc.OwnersClient,
+		ProwConfig:   pc.Config,
 	}
 }
 
 func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
 	t := pc.PluginConfig.TriggerFor(pre.PullRequest.Base.Repo.Owner.Login, pre.PullRequest.Base.Repo.Name)
 	options := optionsForRepo(pc.PluginConfig, pre.Repo.Owner.Login, pre.Repo.Name)
-	return handlePR(getClient(pc), t, pre, welcomeMessageForRepo(options), options.AlwaysPost)
+	return handlePR(getClient(pc), t, pre, *options)
 }
 
-func handlePR(c client, t plugins.Trigger, pre github.PullRequestEvent, welcomeTemplate string, alwaysPost bool) error {
+func handlePR(c client, t plugins.Trigger, pre github.PullRequestEvent, options plugins.Welcome) error {
+	welcomeTemplate, alwaysPost := welcomeMessageForRepo(&options), options.AlwaysPost
+
 	// Only consider newly opened PRs
 	if pre.Action != github.PullRequestActionOpened {
 		return nil
@@ -148,13 +184,17 @@ func handlePR(c client, t plugins.Trigger, pre github.PullRequestEvent, welcomeT
 		if err != nil {
 			return err
 		}
-		var msgBuffer bytes.Buffer
-		err = parsedTemplate.Execute(&msgBuffer, PRInfo{
+		info := PRInfo{
 			Org:         org,
 			Repo:        repo,
 			AuthorLogin: user,
 			AuthorName:  pre.PullRequest.User.Name,
-		})
+		}
+		if err := c.addOnboarding(log, &info, pre.PullRequest, options.AreaDocs); err != nil {
+			return err
+		}
+		var msgBuffer bytes.Buffer
+		err = parsedTemplate.Execute(&msgBuffer, info)
 		if err != nil {
 			return err
 		}
@@ -168,6 +208,56 @@ func handlePR(c client, t plugins.Trigger, pre github.PullRequestEvent, welcomeT
 	return nil
 }
 
+// addOnboarding adds the changed files of the PR, the tests that run for them,
+// their approvers and the docs of their areas to the info. The owners are
+// best effort: if they cannot be loaded, the welcome message is posted
+// without them.
+func (c client) addOnboarding(log *logrus.Entry, info *PRInfo, pr github.PullRequest, areaDocs []plugins.WelcomeAreaDoc) error {
+	changes, err := c.GitHubClient.GetPullRequestChanges(info.Org, info.Repo, pr.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get changed files: %w", err)
+	}
+	for _, change := range changes {
+		info.ChangedFiles = append(info.ChangedFiles, change.Filename)
+	}
+
+	if c.ProwConfig != nil {
+		changedFiles := func() ([]string, error) { return info.ChangedFiles, nil }
+		for _, presubmit := range c.ProwConfig.GetPresubmitsStatic(info.Org + "/" + info.Repo) {
+			if presubmit.NeedsExplicitTrigger() {
+				continue
+			}
+			if shouldRun, err := presubmit.ShouldRun(pr.Base.Ref, changedFiles, false, false); err == nil && shouldRun {
+				info.Tests = append(info.Tests, presubmit.Name)
+			}
+		}
+		sort.Strings(info.Tests)
+	}
+
+	if c.OwnersClient != nil {
+		owners, err := c.OwnersClient.LoadRepoOwners(info.Org, info.Repo, pr.Base.Ref)
+		if err != nil {
+			log.WithError(err).Warn("Cannot load OWNERS, not adding them to the welcome message.")
+		} else {
+			approvers := sets.New[string]()
+			for _, file := range info.ChangedFiles {
+				approvers = approvers.Union(owners.LeafApprovers(owners.FindApproverOwnersForFile(file)))
+			}
+			info.Owners = sets.List(approvers)
+		}
+	}
+
+	for _, doc := range areaDocs {
+		for _, file := range info.ChangedFiles {
+			if doc.PathRe != nil && doc.PathRe.MatchString(file) {
+				info.AreaDocs = append(info.AreaDocs, AreaDoc{Name: doc.Name, URL: doc.URL})
+				break
+			}
+		}
+	}
+	return nil
+}
+
 func welcomeMessageForRepo(options *plugins.Welcome) string {
 	if options.MessageTemplate != "" {
 		return options.MessageTemplate
@@ -19,6 +19,7 @@ package welcome
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -28,6 +29,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/repoowners"
 )
 
 const (
@@ -43,6 +45,9 @@ type fakeClient struct {
 
 	// collaborators is a list of collaborators names.
 	collaborators []string
+
+	// changes maps PR number to the files it changes.
+	changes map[int][]github.PullRequestChange
 }
 
 func newFakeClient() *fakeClient {
@@ -97,6 +102,11 @@ func (fc *fakeClient) IsCollaborator(org, repo, login string) (bool, error) {
 	return false, nil
 }
 
+// GetPullRequestChanges returns the recorded changes of the PR.
+func (fc *fakeClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	return fc.changes[number], nil
+}
+
 func (fc *fakeClient) addOrgMember(org, user string) {
 	fc.orgMembers[org] = append(fc.orgMembers[org], user)
 }
@@ -364,7 +374,7 @@ func TestHandlePR(t *testing.T) {
 		}
 
 		// try handling it
-		if err := handlePR(c, tr, event, testWelcomeTemplate, tc.alwaysPost); err != nil {
+		if err := handlePR(c, tr, event, plugins.Welcome{MessageTemplate: testWelcomeTemplate, AlwaysPost: tc.alwaysPost}); err != nil {
 			t.Fatalf("did not expect error handling PR for case '%s': %v", tc.name, err)
 		}
 
@@ -381,6 +391,110 @@ func TestHandlePR(t *testing.T) {
 	}
 }
 
+type fakeOwnersClient struct {
+	err error
+}
+
+func (foc fakeOwnersClient) LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error) {
+	if foc.err != nil {
+		return nil, foc.err
+	}
+	return fakeRepoOwners{
+		approvers: map[string]sets.Set[string]{
+			"":         sets.New[string]("root"),
+			"pkg/net":  sets.New[string]("alice", "bob"),
+			"pkg/disk": sets.New[string]("carol"),
+		},
+	}, nil
+}
+
+type fakeRepoOwners struct {
+	repoowners.RepoOwner
+	approvers map[string]sets.Set[string]
+}
+
+func (fro fakeRepoOwners) FindApproverOwnersForFile(path string) string {
+	for dir := filepath.Dir(path); dir != "."; dir = filepath.Dir(dir) {
+		if _, ok := fro.approvers[dir]; ok {
+			return dir
+		}
+	}
+	return ""
+}
+
+func (fro fakeRepoOwners) LeafApprovers(path string) sets.Set[string] {
+	return fro.approvers[path]
+}
+
+func TestHandlePROnboarding(t *testing.T) {
+	const onboardingTemplate = "tests:{{range .Tests}} {{.}}{{end}}\nowners:{{range .Owners}} @{{.}}{{end}}\ndocs:{{range .AreaDocs}} {{.Name}}={{.URL}}{{end}}"
+
+	var jobs config.JobConfig
+	if err := jobs.SetPresubmits(map[string][]config.Presubmit{
+		"kubernetes/test-infra": {
+			{JobBase: config.JobBase{Name: "unit"}, AlwaysRun: true},
+			{JobBase: config.JobBase{Name: "net-e2e"}, RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "^pkg/net/"}},
+			{JobBase: config.JobBase{Name: "disk-e2e"}, RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "^pkg/disk/"}},
+			{JobBase: config.JobBase{Name: "manual"}},
+		},
+	}); err != nil {
+		t.Fatalf("failed to set presubmits: %v", err)
+	}
+	areaDocs := []plugins.WelcomeAreaDoc{
+		{Name: "networking", PathRe: regexp.MustCompile("^pkg/net/"), URL: "https://example.com/net"},
+		{Name: "storage", PathRe: regexp.MustCompile("^pkg/disk/"), URL: "https://example.com/disk"},
+	}
+
+	testCases := []struct {
+		name         string
+		changes      []string
+		ownersErr    error
+		expectedBody string
+	}{
+		{
+			name:         "changes in one area",
+			changes:      []string{"pkg/net/dial.go", "README.md"},
+			expectedBody: "tests: net-e2e unit\nowners: @alice @bob @root\ndocs: networking=https://example.com/net",
+		},
+		{
+			name:         "changes outside of any area",
+			changes:      []string{"README.md"},
+			expectedBody: "tests: unit\nowners: @root\ndocs:",
+		},
+		{
+			name:         "owners cannot be loaded",
+			changes:      []string{"pkg/disk/mount.go"},
+			ownersErr:    errors.New("injected error"),
+			expectedBody: "tests: disk-e2e unit\nowners:\ndocs: storage=https://example.com/disk",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient()
+			fc.changes = map[int][]github.PullRequestChange{}
+			for _, file := range tc.changes {
+				fc.changes[1] = append(fc.changes[1], github.PullRequestChange{Filename: file})
+			}
+			c := client{
+				GitHubClient: fc,
+				Logger:       logrus.WithField("testcase", tc.name),
+				OwnersClient: fakeOwnersClient{err: tc.ownersErr},
+				ProwConfig:   &config.Config{JobConfig: jobs},
+			}
+			event := makeFakePullRequestEvent("kubernetes", "test-infra", github.User{Login: "newContributor", Type: github.UserTypeUser}, 1, github.PullRequestActionOpened)
+			event.PullRequest.Number = 1
+			options := plugins.Welcome{MessageTemplate: onboardingTemplate, AreaDocs: areaDocs}
+
+			if err := handlePR(c, plugins.Trigger{TrustedOrg: "kubernetes"}, event, options); err != nil {
+				t.Fatalf("did not expect error handling PR: %v", err)
+			}
+			if comments := fc.commentsAdded[1]; len(comments) != 1 || comments[0] != tc.expectedBody {
+				t.Errorf("expected comment %q, got %q", tc.expectedBody, comments)
+			}
+		})
+	}
+}
+
 func TestWelcomeConfig(t *testing.T) {
 	var (
 		orgMessage  = "defined message for an org"
@@ -174,6 +174,11 @@ func gitCtxForRefs(refs prowapi.Refs, baseDir string, env []string, user, token
 	if refs.CloneURI != "" {
 		g.repositoryURI = refs.CloneURI
 	}
+	if refs.FetchLFS {
+		// LFS objects are fetched explicitly once all refs are checked out,
+		// rather than by the smudge filter on every checkout.
+		g.env = append(append([]string{}, env...), "GIT_LFS_SKIP_SMUDGE=1")
+	}
 
 	if token != "" {
 		u, err := url.Parse(g.repositoryURI)
@@ -238,9 +243,16 @@ func (g *gitCtx) commandsForBaseRef(refs prowapi.Refs, gitUserName, gitUserEmail
 	if cookiePath != "" && refs.SkipSubmodules {
 		commands = append(commands, g.gitCommand("config", "http.cookiefile", cookiePath))
 	}
+	if len(refs.SparseCheckout) > 0 {
+		args := append([]string{"sparse-checkout", "set", "--cone"}, refs.SparseCheckout...)
+		commands = append(commands, g.gitCommand(args...))
+	}
 
 	var depthArgs []string
-	if d := refs.CloneDepth; d > 0 {
+	if refs.ShallowSince != "" {
+		// git does not allow limiting the history by both date and depth.
+		depthArgs = append(depthArgs, "--shallow-since="+refs.ShallowSince)
+	} else if d := refs.CloneDepth; d > 0 {
 		depthArgs = append(depthArgs, "--depth", strconv.Itoa(d))
 	}
 	var filterArgs []string
@@ -366,6 +378,13 @@ func (g *gitCtx) commandsForPullRefs(refs prowapi.Refs, fakeTimestamp int) []run
 		commands = append(commands, g.gitCommand("submodule", "update", "--init", "--recursive"))
 	}
 
+	if refs.FetchLFS {
+		commands = append(commands, g.gitCommand("lfs", "pull", g.repositoryURI))
+		if !refs.SkipSubmodules {
+			commands = append(commands, g.gitCommand("submodule", "foreach", "--recursive", "git lfs pull"))
+		}
+	}
+
 	return commands
 }
 
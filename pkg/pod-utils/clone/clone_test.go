@@ -610,6 +610,35 @@ func TestCommandsForRefs(t *testing.T) {
 				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"submodule", "update", "--init", "--recursive"}},
 			},
 		},
+		{
+			name: "sparse checkout with shallow since overriding clone depth",
+			refs: prowapi.Refs{
+				Org:            "org",
+				Repo:           "repo",
+				BaseRef:        "master",
+				CloneDepth:     2,
+				ShallowSince:   "2026-01-01",
+				SparseCheckout: []string{"cmd/foo", "pkg"},
+				SkipSubmodules: true,
+			},
+			dir: "/go",
+			expectedBase: []runnable{
+				cloneCommand{dir: "/", command: "mkdir", args: []string{"-p", "/go/src/github.com/org/repo"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"init"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"sparse-checkout", "set", "--cone", "cmd/foo", "pkg"}},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--shallow-since=2026-01-01", "https://github.com/org/repo.git", "--tags", "--prune"}},
+					fetchRetries,
+				},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--shallow-since=2026-01-01", "https://github.com/org/repo.git", "master"}},
+					fetchRetries,
+				},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"checkout", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"branch", "--force", "master", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"checkout", "master"}},
+			},
+		},
 		{
 			name: "refs with pr ref with specific sha",
 			refs: prowapi.Refs{
@@ -768,6 +797,67 @@ func TestCommandsForRefs(t *testing.T) {
 				cloneCommand{dir: "/go/src/github.enterprise.com/org/repo", command: "git", args: []string{"submodule", "update", "--init", "--recursive"}},
 			},
 		},
+		{
+			name: "fetch LFS objects",
+			refs: prowapi.Refs{
+				Org:      "org",
+				Repo:     "repo",
+				BaseRef:  "master",
+				FetchLFS: true,
+			},
+			dir: "/go",
+			env: []string{"GIT_TERMINAL_PROMPT=0"},
+			expectedBase: []runnable{
+				cloneCommand{dir: "/", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "mkdir", args: []string{"-p", "/go/src/github.com/org/repo"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"init"}},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"fetch", "https://github.com/org/repo.git", "--tags", "--prune"}},
+					fetchRetries,
+				},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"fetch", "https://github.com/org/repo.git", "master"}},
+					fetchRetries,
+				},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"checkout", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"branch", "--force", "master", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"checkout", "master"}},
+			},
+			expectedPull: []runnable{
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"submodule", "update", "--init", "--recursive"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"lfs", "pull", "https://github.com/org/repo.git"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"submodule", "foreach", "--recursive", "git lfs pull"}},
+			},
+		},
+		{
+			name: "fetch LFS objects without submodules",
+			refs: prowapi.Refs{
+				Org:            "org",
+				Repo:           "repo",
+				BaseRef:        "master",
+				BaseSHA:        "abcdef",
+				FetchLFS:       true,
+				SkipSubmodules: true,
+			},
+			dir: "/go",
+			expectedBase: []runnable{
+				cloneCommand{dir: "/", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "mkdir", args: []string{"-p", "/go/src/github.com/org/repo"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"init"}},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"fetch", "https://github.com/org/repo.git", "--tags", "--prune"}},
+					fetchRetries,
+				},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"fetch", "https://github.com/org/repo.git", "abcdef"}},
+					fetchRetries,
+				},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"checkout", "abcdef"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"branch", "--force", "master", "abcdef"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"checkout", "master"}},
+			},
+			expectedPull: []runnable{
+				cloneCommand{dir: "/go/src/github.com/org/repo", env: []string{"GIT_LFS_SKIP_SMUDGE=1"}, command: "git", args: []string{"lfs", "pull", "https://github.com/org/repo.git"}},
+			},
+		},
 	}
 
 	allow := cmp.AllowUnexported(retryCommand{}, cloneCommand{})
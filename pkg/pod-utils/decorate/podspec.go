@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -60,6 +61,11 @@ const (
 	s3CredentialsMountPath  = "/secrets/s3-storage"
 	outputMountName         = "output"
 	outputMountPath         = "/output"
+	vaultSecretsMountName   = "vault-secrets"
+	vaultSecretsMountPath   = "/etc/vault-secrets"
+	vaultAppRoleMountName   = "vault-approle"
+	vaultAppRoleMountPath   = "/secrets/vault-approle"
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 )
 
 // Labels returns a string slice with label consts from kube.
@@ -80,6 +86,12 @@ func VolumeMounts(dc *prowapi.DecorationConfig) sets.Set[string] {
 	for _, sshKeySecret := range dc.SSHKeySecrets {
 		ret.Insert(sshKeySecret)
 	}
+	for _, credentials := range dc.SubmoduleCredentialSecrets {
+		ret.Insert("submodule-credentials-" + credentials.Name)
+	}
+	if dc.Vault != nil {
+		ret.Insert(vaultSecretsMountName, vaultAppRoleMountName)
+	}
 	return ret
 }
 
@@ -272,6 +284,10 @@ const (
 	cloneRefsName  = "clonerefs"
 )
 
+// CloneRefsContainerName is the name of the init container that clones the
+// refs of a job.
+const CloneRefsContainerName = cloneRefsName
+
 // cloneEnv encodes clonerefs Options into json and puts it into an environment variable
 func cloneEnv(opt clonerefs.Options) ([]coreapi.EnvVar, error) {
 	// TODO(fejta): use flags
@@ -339,6 +355,30 @@ func githubAppVolume(secret, key string) (coreapi.Volume, coreapi.VolumeMount) {
 		}
 }
 
+// submoduleCredentialsVolume converts a secret holding credentials for
+// submodule hosts into the corresponding volume and mount, projecting only
+// the given keys.
+func submoduleCredentialsVolume(secret string, keys []string) (coreapi.Volume, coreapi.VolumeMount) {
+	name := strings.Join([]string{"submodule-credentials", secret}, "-")
+	var items []coreapi.KeyToPath
+	for _, key := range keys {
+		items = append(items, coreapi.KeyToPath{Key: key, Path: fmt.Sprintf("./%s", key)})
+	}
+	return coreapi.Volume{
+			Name: name,
+			VolumeSource: coreapi.VolumeSource{
+				Secret: &coreapi.SecretVolumeSource{
+					SecretName: secret,
+					Items:      items,
+				},
+			},
+		}, coreapi.VolumeMount{
+			Name:      name,
+			MountPath: path.Join("/secrets/submodule-credentials", secret),
+			ReadOnly:  true,
+		}
+}
+
 // sshVolume converts a secret holding ssh keys into the corresponding volume and mount.
 //
 // This is used by CloneRefs to attach the mount to the clonerefs container.
@@ -461,6 +501,31 @@ func CloneRefs(pj prowapi.ProwJob, codeMount, logMount coreapi.VolumeMount) (*co
 		cloneVolumes = append(cloneVolumes, keyVolume)
 	}
 
+	var submoduleCredentialFiles map[string]string
+	if credentials := pj.Spec.DecorationConfig.SubmoduleCredentialSecrets; len(credentials) > 0 {
+		submoduleCredentialFiles = map[string]string{}
+		var secrets []string
+		secretKeys := map[string][]string{}
+		for _, c := range credentials {
+			if _, ok := secretKeys[c.Name]; !ok {
+				secrets = append(secrets, c.Name)
+			}
+			if !slices.Contains(secretKeys[c.Name], c.Key) {
+				secretKeys[c.Name] = append(secretKeys[c.Name], c.Key)
+			}
+		}
+		mountPaths := map[string]string{}
+		for _, secret := range secrets {
+			volume, mount := submoduleCredentialsVolume(secret, secretKeys[secret])
+			cloneMounts = append(cloneMounts, mount)
+			cloneVolumes = append(cloneVolumes, volume)
+			mountPaths[secret] = mount.MountPath
+		}
+		for _, c := range credentials {
+			submoduleCredentialFiles[c.Host] = filepath.Join(mountPaths[c.Name], c.Key)
+		}
+	}
+
 	volume, mount := tmpVolume("clonerefs-tmp")
 	cloneMounts = append(cloneMounts, mount)
 	cloneVolumes = append(cloneVolumes, volume)
@@ -489,6 +554,8 @@ func CloneRefs(pj prowapi.ProwJob, codeMount, logMount coreapi.VolumeMount) (*co
 		GitHubAPIEndpoints:      githubAPIEndpoints,
 		GitHubAppID:             pj.Spec.DecorationConfig.GitHubAppID,
 		GitHubAppPrivateKeyFile: githubAppPrivateKeyMountPath,
+
+		SubmoduleCredentialFiles: submoduleCredentialFiles,
 	})
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("clone env: %w", err)
@@ -539,7 +606,7 @@ func entrypointLocation(tools coreapi.VolumeMount) string {
 }
 
 // InjectEntrypoint will make the entrypoint binary in the tools volume the container's entrypoint, which will output to the log volume.
-func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration, prefix, previousMarker string, propagateErrorCode bool, exitZero bool, log, tools coreapi.VolumeMount) (*wrapper.Options, error) {
+func InjectEntrypoint(c *coreapi.Container, config *prowapi.DecorationConfig, prefix, previousMarker string, propagateErrorCode bool, exitZero bool, log, tools coreapi.VolumeMount) (*wrapper.Options, error) {
 	wrapperOptions := &wrapper.Options{
 		Args:          append(c.Command, c.Args...),
 		ContainerName: c.Name,
@@ -547,15 +614,30 @@ func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration,
 		MarkerFile:    markerFile(log, prefix),
 		MetadataFile:  metadataFile(log, prefix),
 	}
+	var steps []entrypoint.Step
+	for _, step := range config.Steps {
+		steps = append(steps, entrypoint.Step{Name: step.Name, Args: step.Command, Timeout: step.Timeout.Get()})
+	}
+	var escalation []entrypoint.EscalationStep
+	for _, step := range config.SignalEscalation {
+		escalation = append(escalation, entrypoint.EscalationStep{Signal: step.Signal, GracePeriod: step.GracePeriod.Get()})
+	}
+	if len(steps) > 0 {
+		wrapperOptions.Args = nil
+	}
 	// TODO(fejta): use flags
 	entrypointConfigEnv, err := entrypoint.Encode(entrypoint.Options{
 		ArtifactDir:        artifactsDir(log),
-		GracePeriod:        gracePeriod,
+		GracePeriod:        config.GracePeriod.Get(),
 		Options:            wrapperOptions,
-		Timeout:            timeout,
+		Timeout:            config.Timeout.Get(),
 		PropagateErrorCode: propagateErrorCode,
 		AlwaysZero:         exitZero,
 		PreviousMarker:     previousMarker,
+		Steps:              steps,
+		SignalEscalation:   escalation,
+		CaptureProcessTree: config.CaptureProcessTree != nil && *config.CaptureProcessTree,
+		Vault:              vaultOptions(config.Vault),
 	})
 	if err != nil {
 		return nil, err
@@ -565,9 +647,102 @@ func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration,
 	c.Args = nil
 	c.Env = append(c.Env, KubeEnv(map[string]string{entrypoint.JSONConfigEnvVar: entrypointConfigEnv})...)
 	c.VolumeMounts = append(c.VolumeMounts, log, tools)
+	c.VolumeMounts = append(c.VolumeMounts, vaultMounts(config.Vault)...)
 	return wrapperOptions, nil
 }
 
+// vaultOptions translates the Vault decoration config into the options the
+// entrypoint uses to fetch the secrets before starting the test process.
+func vaultOptions(config *prowapi.VaultConfig) *entrypoint.VaultOptions {
+	if config == nil {
+		return nil
+	}
+	options := &entrypoint.VaultOptions{
+		Address:    config.Address,
+		AuthMethod: config.AuthMethod,
+		AuthMount:  config.AuthMount,
+		Role:       config.Role,
+		SecretDir:  vaultSecretsMountPath,
+	}
+	switch config.AuthMethod {
+	case prowapi.VaultAuthKubernetes:
+		options.TokenFile = serviceAccountTokenPath
+	case prowapi.VaultAuthAppRole:
+		options.AppRoleDir = vaultAppRoleMountPath
+	}
+	for _, secret := range config.Secrets {
+		options.Secrets = append(options.Secrets, entrypoint.VaultSecret{
+			Path: secret.Path,
+			Key:  secret.Key,
+			File: secret.FileName(),
+			Env:  secret.Env,
+		})
+	}
+	return options
+}
+
+// vaultMounts returns the mounts the entrypoint needs to authenticate to
+// Vault and to hand the fetched secrets to the test process.
+func vaultMounts(config *prowapi.VaultConfig) []coreapi.VolumeMount {
+	if config == nil {
+		return nil
+	}
+	mounts := []coreapi.VolumeMount{{
+		Name:      vaultSecretsMountName,
+		MountPath: vaultSecretsMountPath,
+	}}
+	if config.AuthMethod == prowapi.VaultAuthAppRole {
+		mounts = append(mounts, coreapi.VolumeMount{
+			Name:      vaultAppRoleMountName,
+			MountPath: vaultAppRoleMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
+
+// vaultVolumes returns the volumes backing the mounts from vaultMounts. The
+// fetched secrets are kept in memory so that they never touch the node's disk.
+func vaultVolumes(config *prowapi.VaultConfig) []coreapi.Volume {
+	if config == nil {
+		return nil
+	}
+	volumes := []coreapi.Volume{{
+		Name: vaultSecretsMountName,
+		VolumeSource: coreapi.VolumeSource{
+			EmptyDir: &coreapi.EmptyDirVolumeSource{Medium: coreapi.StorageMediumMemory},
+		},
+	}}
+	if config.AuthMethod == prowapi.VaultAuthAppRole {
+		volumes = append(volumes, coreapi.Volume{
+			Name: vaultAppRoleMountName,
+			VolumeSource: coreapi.VolumeSource{
+				Secret: &coreapi.SecretVolumeSource{SecretName: config.AppRoleSecret},
+			},
+		})
+	}
+	return volumes
+}
+
+// terminationGracePeriod is the longest the entrypoint may wait for the test
+// process to exit after it is signalled, across all signal escalation steps.
+func terminationGracePeriod(config *prowapi.DecorationConfig) time.Duration {
+	if len(config.SignalEscalation) == 0 {
+		return config.GracePeriod.Get()
+	}
+	var total time.Duration
+	for _, step := range config.SignalEscalation {
+		if step.GracePeriod != nil {
+			total += step.GracePeriod.Get()
+		} else if config.GracePeriod != nil {
+			total += config.GracePeriod.Get()
+		} else {
+			total += entrypoint.DefaultGracePeriod
+		}
+	}
+	return total
+}
+
 // PlaceEntrypoint will copy entrypoint from the entrypoint image to the tools volume
 func PlaceEntrypoint(config *prowapi.DecorationConfig, toolsMount coreapi.VolumeMount) coreapi.Container {
 	container := coreapi.Container{
@@ -630,6 +805,23 @@ func BlobStorageOptions(dc prowapi.DecorationConfig, localMode bool) ([]coreapi.
 	return volumes, mounts, opt
 }
 
+// blobStorageEnvFrom exposes the Azure credentials secret to containers
+// uploading to blob storage, as the Azure driver reads them from the environment.
+func blobStorageEnvFrom(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options) []coreapi.EnvFromSource {
+	if config.AzureCredentialsSecret == nil || *config.AzureCredentialsSecret == "" {
+		return nil
+	}
+	if gcsOptions.GCSConfiguration != nil && gcsOptions.LocalOutputDir != "" {
+		// the credentials are not needed for local mode
+		return nil
+	}
+	return []coreapi.EnvFromSource{{
+		SecretRef: &coreapi.SecretEnvSource{
+			LocalObjectReference: coreapi.LocalObjectReference{Name: *config.AzureCredentialsSecret},
+		},
+	}}
+}
+
 func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, cloneLogMount *coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string) (*coreapi.Container, error) {
 	// TODO(fejta): remove encodedJobSpec
 	initUploadOptions := initupload.Options{
@@ -656,6 +848,7 @@ func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options,
 			downwardapi.JobSpecEnv:      encodedJobSpec,
 			initupload.JSONConfigEnvVar: initUploadConfigEnv,
 		}),
+		EnvFrom:      blobStorageEnvFrom(config, gcsOptions),
 		VolumeMounts: mounts,
 	}
 	if config.Resources != nil && config.Resources.InitUpload != nil {
@@ -764,6 +957,10 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 			secretVolumes.Insert(volume.Name)
 		}
 	}
+	if pj.Spec.DecorationConfig.Vault != nil {
+		// Secrets fetched from Vault are censored like any other mounted secret.
+		secretVolumes.Insert(vaultSecretsMountName)
+	}
 	containsSecretData := func(volumeName string) bool {
 		if censor := pj.Spec.DecorationConfig.CensorSecrets; censor == nil || !*censor {
 			return false
@@ -784,7 +981,7 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 		if len(spec.Containers) == 1 {
 			prefix = ""
 		}
-		wrapperOptions, err := InjectEntrypoint(&spec.Containers[i], pj.Spec.DecorationConfig.Timeout.Get(), pj.Spec.DecorationConfig.GracePeriod.Get(), prefix, previous, propagateErrorCode, exitZero, logMount, toolsMount)
+		wrapperOptions, err := InjectEntrypoint(&spec.Containers[i], pj.Spec.DecorationConfig, prefix, previous, propagateErrorCode, exitZero, logMount, toolsMount)
 		if err != nil {
 			return fmt.Errorf("wrap container: %w", err)
 		}
@@ -805,6 +1002,7 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 
 	spec.Volumes = append(spec.Volumes, logVolume, toolsVolume)
 	spec.Volumes = append(spec.Volumes, blobStorageVolumes...)
+	spec.Volumes = append(spec.Volumes, vaultVolumes(pj.Spec.DecorationConfig.Vault)...)
 	if outputVolume != nil {
 		spec.Volumes = append(spec.Volumes, *outputVolume)
 	}
@@ -871,12 +1069,12 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 
 	spec.Containers = append(spec.Containers, *sidecar)
 
-	if spec.TerminationGracePeriodSeconds == nil && pj.Spec.DecorationConfig.GracePeriod != nil {
+	if spec.TerminationGracePeriodSeconds == nil && (pj.Spec.DecorationConfig.GracePeriod != nil || len(pj.Spec.DecorationConfig.SignalEscalation) > 0) {
 		// Unless the user's asked for something specific, we want to set the grace period on the Pod to
 		// a reasonable value, as the overall grace period for the Pod must encompass both the time taken
 		// to gracefully terminate the test process *and* the time taken to process and upload the resulting
 		// artifacts to the cloud. As a reasonable rule of thumb, assume a 80/20 split between these tasks.
-		gracePeriodSeconds := int64(pj.Spec.DecorationConfig.GracePeriod.Seconds()) * 5 / 4
+		gracePeriodSeconds := int64(terminationGracePeriod(pj.Spec.DecorationConfig).Seconds()) * 5 / 4
 		spec.TerminationGracePeriodSeconds = &gracePeriodSeconds
 	}
 
@@ -919,11 +1117,13 @@ func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blo
 		censoringOptions.ExcludeDirectories = config.CensoringOptions.ExcludeDirectories
 	}
 	sidecarConfigEnv, err := sidecar.Encode(sidecar.Options{
-		GcsOptions:       &gcsOptions,
-		Entries:          wrappers,
-		EntryError:       requirePassingEntries,
-		IgnoreInterrupts: ignoreInterrupts,
-		CensoringOptions: censoringOptions,
+		GcsOptions:              &gcsOptions,
+		Entries:                 wrappers,
+		EntryError:              requirePassingEntries,
+		IgnoreInterrupts:        ignoreInterrupts,
+		LogStreamInterval:       config.LogStreamInterval.Get(),
+		ResourceMetricsInterval: config.ResourceMetricsInterval.Get(),
+		CensoringOptions:        censoringOptions,
 	})
 
 	if err != nil {
@@ -943,9 +1143,23 @@ func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blo
 			sidecar.JSONConfigEnvVar: sidecarConfigEnv,
 			downwardapi.JobSpecEnv:   encodedJobSpec, // TODO: shouldn't need this?
 		}),
+		EnvFrom:                  blobStorageEnvFrom(config, gcsOptions),
 		VolumeMounts:             mounts,
 		TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
 	}
+	if config.ResourceMetricsInterval.Get() > 0 {
+		// Identify the pod to record the resource usage of.
+		for name, field := range map[string]string{
+			sidecar.PodNameEnv:      "metadata.name",
+			sidecar.PodNamespaceEnv: "metadata.namespace",
+		} {
+			container.Env = append(container.Env, coreapi.EnvVar{
+				Name:      name,
+				ValueFrom: &coreapi.EnvVarSource{FieldRef: &coreapi.ObjectFieldSelector{FieldPath: field}},
+			})
+		}
+		sort.Slice(container.Env, func(i, j int) bool { return container.Env[i].Name < container.Env[j].Name })
+	}
 	if config.Resources != nil && config.Resources.Sidecar != nil {
 		container.Resources = *config.Resources.Sidecar
 	}
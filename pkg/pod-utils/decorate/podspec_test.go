@@ -530,6 +530,78 @@ func TestCloneRefs(t *testing.T) {
 				tmpVolume,
 			},
 		},
+		{
+			name: "include submodule credential secrets when set",
+			pj: prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					ExtraRefs: []prowapi.Refs{{}},
+					DecorationConfig: &prowapi.DecorationConfig{
+						UtilityImages: &prowapi.UtilityImages{},
+						SubmoduleCredentialSecrets: []prowapi.SubmoduleCredentialSecret{
+							{Host: "gitlab.example.com", Name: "git-credentials", Key: "gitlab"},
+							{Host: "gitlab.internal", Name: "git-credentials", Key: "gitlab"},
+							{Host: "bitbucket.org", Name: "git-credentials", Key: "bitbucket"},
+							{Host: "git.example.com", Name: "other", Key: "token"},
+						},
+					},
+				},
+			},
+			expected: &coreapi.Container{
+				Name: cloneRefsName,
+				Env: envOrDie(clonerefs.Options{
+					GitRefs:            []prowapi.Refs{{}},
+					GitUserEmail:       clonerefs.DefaultGitUserEmail,
+					GitUserName:        clonerefs.DefaultGitUserName,
+					SrcRoot:            codeMount.MountPath,
+					Log:                CloneLogPath(logMount),
+					GitHubAPIEndpoints: []string{github.DefaultAPIEndpoint},
+					SubmoduleCredentialFiles: map[string]string{
+						"gitlab.example.com": "/secrets/submodule-credentials/git-credentials/gitlab",
+						"gitlab.internal":    "/secrets/submodule-credentials/git-credentials/gitlab",
+						"bitbucket.org":      "/secrets/submodule-credentials/git-credentials/bitbucket",
+						"git.example.com":    "/secrets/submodule-credentials/other/token",
+					},
+				}),
+				VolumeMounts: []coreapi.VolumeMount{
+					logMount, codeMount,
+					{
+						Name:      "submodule-credentials-git-credentials",
+						ReadOnly:  true,
+						MountPath: "/secrets/submodule-credentials/git-credentials",
+					},
+					{
+						Name:      "submodule-credentials-other",
+						ReadOnly:  true,
+						MountPath: "/secrets/submodule-credentials/other",
+					},
+					tmpMount,
+				},
+			},
+			volumes: []coreapi.Volume{
+				{
+					Name: "submodule-credentials-git-credentials",
+					VolumeSource: coreapi.VolumeSource{
+						Secret: &coreapi.SecretVolumeSource{
+							SecretName: "git-credentials",
+							Items: []coreapi.KeyToPath{
+								{Key: "gitlab", Path: "./gitlab"},
+								{Key: "bitbucket", Path: "./bitbucket"},
+							},
+						},
+					},
+				},
+				{
+					Name: "submodule-credentials-other",
+					VolumeSource: coreapi.VolumeSource{
+						Secret: &coreapi.SecretVolumeSource{
+							SecretName: "other",
+							Items:      []coreapi.KeyToPath{{Key: "token", Path: "./token"}},
+						},
+					},
+				},
+				tmpVolume,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1335,6 +1407,23 @@ func TestProwJobToPod_setsTerminationGracePeriodSeconds(t *testing.T) {
 			},
 			expectedTerminationGracePeriodSeconds: 12,
 		},
+		{
+			name: "GracePeriodSeconds from signal escalation",
+			prowjob: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					PodSpec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}},
+					DecorationConfig: &prowapi.DecorationConfig{
+						UtilityImages: &prowapi.UtilityImages{},
+						GracePeriod:   &prowapi.Duration{Duration: 10 * time.Second},
+						SignalEscalation: []prowapi.SignalEscalationStep{
+							{Signal: "SIGTERM", GracePeriod: &prowapi.Duration{Duration: 20 * time.Second}},
+							{Signal: "SIGQUIT"},
+						},
+					},
+				},
+			},
+			expectedTerminationGracePeriodSeconds: 37,
+		},
 		{
 			name: "Existing GracePeriodSeconds is not overwritten",
 			prowjob: &prowapi.ProwJob{
@@ -1416,6 +1505,37 @@ func TestSidecar(t *testing.T) {
 			},
 			wrappers: []wrapper.Options{{Args: []string{"yes"}}},
 		},
+		{
+			name: "with blob storage credentials in the environment",
+			config: &prowapi.DecorationConfig{
+				UtilityImages:          &prowapi.UtilityImages{Sidecar: "sidecar-image"},
+				AzureCredentialsSecret: ptr.To("azure-credentials"),
+			},
+			gcsOptions: gcsupload.Options{
+				Items:            []string{"first", "second"},
+				GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "azblob://bucket"},
+			},
+			logMount:              coreapi.VolumeMount{Name: "logs", MountPath: "/logs"},
+			outputMount:           &coreapi.VolumeMount{Name: "outputs", MountPath: "/outputs"},
+			encodedJobSpec:        "spec",
+			requirePassingEntries: true,
+			ignoreInterrupts:      true,
+			wrappers:              []wrapper.Options{{Args: []string{"yes"}}},
+		},
+		{
+			name: "recording resource usage",
+			config: &prowapi.DecorationConfig{
+				UtilityImages:           &prowapi.UtilityImages{Sidecar: "sidecar-image"},
+				ResourceMetricsInterval: &prowapi.Duration{Duration: 15 * time.Second},
+			},
+			gcsOptions: gcsupload.Options{
+				Items:            []string{"first", "second"},
+				GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "bucket"},
+			},
+			logMount:       coreapi.VolumeMount{Name: "logs", MountPath: "/logs"},
+			encodedJobSpec: "spec",
+			wrappers:       []wrapper.Options{{Args: []string{"yes"}}},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1662,6 +1782,62 @@ func TestDecorate(t *testing.T) {
 			},
 			rawEnv: map[string]string{"custom": "env"},
 		},
+		{
+			name: "secrets from vault",
+			spec: &coreapi.PodSpec{
+				Volumes: []coreapi.Volume{
+					{Name: "secret", VolumeSource: coreapi.VolumeSource{Secret: &coreapi.SecretVolumeSource{SecretName: "secretname"}}},
+				},
+				Containers: []coreapi.Container{
+					{Name: "test", Command: []string{"/bin/ls"}, Args: []string{"-l", "-a"}, VolumeMounts: []coreapi.VolumeMount{{Name: "secret", MountPath: "/secret"}}},
+				},
+				ServiceAccountName: "tester",
+			},
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Hour},
+						UtilityImages: &prowapi.UtilityImages{
+							CloneRefs:  "cloneimage",
+							InitUpload: "initimage",
+							Entrypoint: "entrypointimage",
+							Sidecar:    "sidecarimage",
+						},
+						Resources: &prowapi.Resources{
+							CloneRefs:       &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+							InitUpload:      &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+							PlaceEntrypoint: &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+							Sidecar:         &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+						},
+						GCSConfiguration: &prowapi.GCSConfiguration{
+							Bucket:       "bucket",
+							PathStrategy: "single",
+							DefaultOrg:   "org",
+							DefaultRepo:  "repo",
+						},
+						GCSCredentialsSecret:      &gCSCredentialsSecret,
+						DefaultServiceAccountName: &defaultServiceAccountName,
+						CensorSecrets:             &censor,
+						Vault: &prowapi.VaultConfig{
+							Address:       "https://vault.example.com",
+							AuthMethod:    prowapi.VaultAuthAppRole,
+							AppRoleSecret: "vault-approle",
+							Secrets: []prowapi.VaultSecret{
+								{Path: "secret/data/ci/github", Key: "token", File: "github-token", Env: "GITHUB_TOKEN"},
+								{Path: "secret/data/ci/registry", Key: "password"},
+							},
+						},
+					},
+					Refs: &prowapi.Refs{
+						Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcd1234",
+						Pulls: []prowapi.Pull{{Number: 1, SHA: "aksdjhfkds"}},
+					},
+					ExtraRefs: []prowapi.Refs{{Org: "other", Repo: "something", BaseRef: "release", BaseSHA: "sldijfsd"}},
+				},
+			},
+			rawEnv: map[string]string{"custom": "env"},
+		},
 		{
 			name: "ignore interrupts in sidecar",
 			spec: &coreapi.PodSpec{
@@ -121,7 +121,7 @@ func EnvForSpec(spec JobSpec) (map[string]string, error) {
 	// for backwards compatibility, we provide the build ID
 	// in both $BUILD_ID and $BUILD_NUMBER for Prow agents
 	// and in both $buildId and $BUILD_NUMBER for Jenkins
-	if spec.agent == prowapi.KubernetesAgent {
+	if spec.agent == prowapi.KubernetesAgent || spec.agent == prowapi.KubernetesJobAgent {
 		env[ProwBuildIDEnv] = spec.BuildID
 	}
 
@@ -148,3 +148,15 @@ func NewExplicitRepoPathBuilder() RepoPathBuilder {
 		return fmt.Sprintf("%s_%s", org, repo)
 	}
 }
+
+// LogChunksDir determines the directory in a job's artifacts that the chunks
+// of a build log are streamed to while the job runs.
+func LogChunksDir(logName string) string {
+	return logName + ".chunks"
+}
+
+// LogChunkName determines the name of a chunk of a streamed build log. The
+// names of the chunks sort in the order they were written in.
+func LogChunkName(logName string, index int) string {
+	return path.Join(LogChunksDir(logName), fmt.Sprintf("%08d", index))
+}
@@ -33,19 +33,35 @@ import (
 type configToWatch struct {
 	config.PubSubTriggers
 	config.PubsubSubscriptions
+	NATSTriggers  []config.NATSTrigger
+	KafkaTriggers []config.KafkaTrigger
 }
 
-// PullServer listen to Pull Pub/Sub subscriptions and handle them.
+func configToWatchFrom(c *config.Config) configToWatch {
+	return configToWatch{
+		c.PubSubTriggers,
+		c.PubSubSubscriptions,
+		c.NATSTriggers,
+		c.KafkaTriggers,
+	}
+}
+
+// PullServer listen to Pull Pub/Sub subscriptions, NATS subjects and Kafka
+// topics and handle them.
 type PullServer struct {
-	Subscriber *Subscriber
-	Client     pubsubClientInterface
+	Subscriber  *Subscriber
+	Client      pubsubClientInterface
+	NATSClient  natsClientInterface
+	KafkaClient kafkaClientInterface
 }
 
 // NewPullServer creates a new PullServer
 func NewPullServer(s *Subscriber) *PullServer {
 	return &PullServer{
-		Subscriber: s,
-		Client:     &pubSubClient{},
+		Subscriber:  s,
+		Client:      &pubSubClient{},
+		NATSClient:  &natsClient{},
+		KafkaClient: &kafkaClient{},
 	}
 }
 
@@ -106,12 +122,17 @@ func (c *pubSubClient) subscription(id string, maxOutstandingMessages int) subsc
 	}
 }
 
-// handlePulls pull for Pub/Sub subscriptions and handle them.
-func (s *PullServer) handlePulls(ctx context.Context, projectSubscriptions config.PubSubTriggers) (*errgroup.Group, context.Context, error) {
+// handlePulls pull for Pub/Sub subscriptions, NATS subjects and Kafka topics
+// and handle them.
+func (s *PullServer) handlePulls(ctx context.Context, triggers configToWatch) (*errgroup.Group, context.Context, error) {
 	// Since config might change we need be able to cancel the current run
 	errGroup, derivedCtx := errgroup.WithContext(ctx)
-	for _, topics := range projectSubscriptions {
+	for _, topics := range triggers.PubSubTriggers {
 		project, subscriptions, allowedClusters := topics.Project, topics.Topics, topics.AllowedClusters
+		var reply *replyTopic
+		if topics.ReplyTopic != "" {
+			reply = &replyTopic{project: project, topic: topics.ReplyTopic, schemaVersion: topics.ReplySchemaVersion}
+		}
 		client, err := s.Client.new(ctx, project)
 		if err != nil {
 			return errGroup, derivedCtx, err
@@ -122,36 +143,62 @@ func (s *PullServer) handlePulls(ctx context.Context, projectSubscriptions confi
 				"subscription": sub.string(),
 				"project":      project,
 			})
-			errGroup.Go(func() error {
-				logger.Info("Listening for subscription")
-				defer logger.Warn("Stopped Listening for subscription")
-				err := sub.receive(derivedCtx, func(ctx context.Context, msg messageInterface) {
-					if err = s.Subscriber.handleMessage(msg, sub.string(), allowedClusters); err != nil {
-						s.Subscriber.Metrics.ACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
-					} else {
-						s.Subscriber.Metrics.NACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
-					}
-					msg.ack()
-				})
-				if err != nil {
-					if errors.Is(derivedCtx.Err(), context.Canceled) {
-						logger.WithError(err).Debug("Exiting as context cancelled")
-						return nil
-					}
-					if strings.Contains(err.Error(), "code = PermissionDenied") {
-						logger.WithError(err).Warn("Seems like missing permission.")
-						return nil
-					}
-					logger.WithError(err).Error("Failed to listen for subscription")
-					return err
-				}
-				return nil
+			s.listen(errGroup, derivedCtx, sub, allowedClusters, reply, logger)
+		}
+	}
+	for _, trigger := range triggers.NATSTriggers {
+		for _, subject := range trigger.Subjects {
+			sub := s.NATSClient.subscription(trigger, subject)
+			logger := logrus.WithFields(logrus.Fields{
+				"subscription": sub.string(),
+				"nats-url":     trigger.URL,
+			})
+			s.listen(errGroup, derivedCtx, sub, trigger.AllowedClusters, nil, logger)
+		}
+	}
+	for _, trigger := range triggers.KafkaTriggers {
+		for _, topic := range trigger.Topics {
+			sub := s.KafkaClient.subscription(trigger, topic)
+			logger := logrus.WithFields(logrus.Fields{
+				"subscription":   sub.string(),
+				"kafka-group-id": trigger.GroupID,
 			})
+			s.listen(errGroup, derivedCtx, sub, trigger.AllowedClusters, nil, logger)
 		}
 	}
 	return errGroup, derivedCtx, nil
 }
 
+// listen handles the messages of the subscription until the context is
+// cancelled or receiving fails.
+func (s *PullServer) listen(errGroup *errgroup.Group, ctx context.Context, sub subscriptionInterface, allowedClusters []string, reply *replyTopic, logger *logrus.Entry) {
+	errGroup.Go(func() error {
+		logger.Info("Listening for subscription")
+		defer logger.Warn("Stopped Listening for subscription")
+		err := sub.receive(ctx, func(_ context.Context, msg messageInterface) {
+			if err := s.Subscriber.handleMessage(msg, sub.string(), allowedClusters, reply); err != nil {
+				s.Subscriber.Metrics.ACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
+			} else {
+				s.Subscriber.Metrics.NACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
+			}
+			msg.ack()
+		})
+		if err != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				logger.WithError(err).Debug("Exiting as context cancelled")
+				return nil
+			}
+			if strings.Contains(err.Error(), "code = PermissionDenied") {
+				logger.WithError(err).Warn("Seems like missing permission.")
+				return nil
+			}
+			logger.WithError(err).Error("Failed to listen for subscription")
+			return err
+		}
+		return nil
+	})
+}
+
 // Run will block listening to all subscriptions and return once the context is cancelled
 // or one of the subscription has a unrecoverable error.
 func (s *PullServer) Run(ctx context.Context) error {
@@ -165,11 +212,8 @@ func (s *PullServer) Run(ctx context.Context) error {
 		}
 		logrus.Debug("Pull server shutting down.")
 	}()
-	currentConfig := configToWatch{
-		s.Subscriber.ConfigAgent.Config().PubSubTriggers,
-		s.Subscriber.ConfigAgent.Config().PubSubSubscriptions,
-	}
-	errGroup, derivedCtx, err := s.handlePulls(ctx, currentConfig.PubSubTriggers)
+	currentConfig := configToWatchFrom(s.Subscriber.ConfigAgent.Config())
+	errGroup, derivedCtx, err := s.handlePulls(ctx, currentConfig)
 	if err != nil {
 		return err
 	}
@@ -185,17 +229,14 @@ func (s *PullServer) Run(ctx context.Context) error {
 			return err
 		// Checking for update config
 		case event := <-configEvent:
-			newConfig := configToWatch{
-				event.After.PubSubTriggers,
-				event.After.PubSubSubscriptions,
-			}
+			newConfig := configToWatchFrom(&event.After)
 			logrus.Info("Received new config")
 			if !reflect.DeepEqual(currentConfig, newConfig) {
 				logrus.Info("New config found, reloading pull Server")
 				// Making sure the current thread finishes before starting a new one.
 				errGroup.Wait()
 				// Starting a new thread with new config
-				errGroup, derivedCtx, err = s.handlePulls(ctx, newConfig.PubSubTriggers)
+				errGroup, derivedCtx, err = s.handlePulls(ctx, newConfig)
 				if err != nil {
 					return err
 				}
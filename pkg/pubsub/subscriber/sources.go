@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// natsClientInterface creates subscriptions for NATS subjects, it is an
+// interface for testing reason.
+type natsClientInterface interface {
+	subscription(trigger config.NATSTrigger, subject string) subscriptionInterface
+}
+
+type natsClient struct{}
+
+func (c *natsClient) subscription(trigger config.NATSTrigger, subject string) subscriptionInterface {
+	return &natsSubscription{url: trigger.URL, subject: subject, queueGroup: trigger.QueueGroup}
+}
+
+// natsSubscription is a queue subscription of a NATS subject. Messages are
+// delivered at most once, as core NATS does not support redelivery.
+type natsSubscription struct {
+	url        string
+	subject    string
+	queueGroup string
+}
+
+func (s *natsSubscription) string() string {
+	return fmt.Sprintf("nats/%s", s.subject)
+}
+
+func (s *natsSubscription) receive(ctx context.Context, f func(context.Context, messageInterface)) error {
+	conn, err := nats.Connect(s.url, nats.Name("prow-sub"), nats.MaxReconnects(-1))
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+	defer conn.Close()
+	sub, err := conn.QueueSubscribe(s.subject, s.queueGroup, func(msg *nats.Msg) {
+		f(ctx, &natsMessage{msg: msg})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to NATS subject %s: %w", s.subject, err)
+	}
+	<-ctx.Done()
+	if err := sub.Drain(); err != nil {
+		logrus.WithError(err).WithField("subject", s.subject).Warn("Failed to drain NATS subscription.")
+	}
+	return ctx.Err()
+}
+
+type natsMessage struct {
+	msg *nats.Msg
+}
+
+func (m *natsMessage) getAttributes() map[string]string {
+	attributes := map[string]string{}
+	for key := range m.msg.Header {
+		attributes[key] = m.msg.Header.Get(key)
+	}
+	return attributes
+}
+
+func (m *natsMessage) getPayload() []byte {
+	return m.msg.Data
+}
+
+func (m *natsMessage) getID() string {
+	return m.msg.Header.Get(nats.MsgIdHdr)
+}
+
+// Core NATS messages are not acknowledged.
+func (m *natsMessage) ack()  {}
+func (m *natsMessage) nack() {}
+
+// kafkaClientInterface creates subscriptions for Kafka topics, it is an
+// interface for testing reason.
+type kafkaClientInterface interface {
+	subscription(trigger config.KafkaTrigger, topic string) subscriptionInterface
+}
+
+type kafkaClient struct{}
+
+func (c *kafkaClient) subscription(trigger config.KafkaTrigger, topic string) subscriptionInterface {
+	return &kafkaSubscription{brokers: trigger.Brokers, topic: topic, groupID: trigger.GroupID}
+}
+
+// kafkaSubscription consumes a Kafka topic as part of a consumer group.
+// Messages are handled one at a time and their offsets are committed once
+// they are acknowledged.
+type kafkaSubscription struct {
+	brokers []string
+	topic   string
+	groupID string
+}
+
+func (s *kafkaSubscription) string() string {
+	return fmt.Sprintf("kafka/%s", s.topic)
+}
+
+func (s *kafkaSubscription) receive(ctx context.Context, f func(context.Context, messageInterface)) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: s.brokers,
+		GroupID: s.groupID,
+		Topic:   s.topic,
+	})
+	defer reader.Close()
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			return err
+		}
+		f(ctx, &kafkaMessage{ctx: ctx, reader: reader, msg: msg})
+	}
+}
+
+type kafkaMessage struct {
+	ctx    context.Context
+	reader *kafka.Reader
+	msg    kafka.Message
+}
+
+func (m *kafkaMessage) getAttributes() map[string]string {
+	attributes := map[string]string{}
+	for _, header := range m.msg.Headers {
+		attributes[header.Key] = string(header.Value)
+	}
+	return attributes
+}
+
+func (m *kafkaMessage) getPayload() []byte {
+	return m.msg.Value
+}
+
+func (m *kafkaMessage) getID() string {
+	return fmt.Sprintf("%s/%d/%d", m.msg.Topic, m.msg.Partition, m.msg.Offset)
+}
+
+func (m *kafkaMessage) ack() {
+	if err := m.reader.CommitMessages(m.ctx, m.msg); err != nil {
+		logrus.WithError(err).WithField("message", m.getID()).Warn("Failed to commit Kafka message.")
+	}
+}
+
+// Kafka does not support redelivering single messages, they are only
+// committed once acknowledged.
+func (m *kafkaMessage) nack() {}
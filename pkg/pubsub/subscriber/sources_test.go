@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+)
+
+func TestNATSMessage(t *testing.T) {
+	msg := &natsMessage{msg: &nats.Msg{
+		Subject: "prow.jobs",
+		Data:    []byte(`{"name":"job"}`),
+		Header: nats.Header{
+			ProwEventType: []string{PeriodicProwJobEvent},
+			nats.MsgIdHdr: []string{"id"},
+		},
+	}}
+	expected := map[string]string{ProwEventType: PeriodicProwJobEvent, nats.MsgIdHdr: "id"}
+	if diff := cmp.Diff(expected, msg.getAttributes()); diff != "" {
+		t.Errorf("unexpected attributes (-want +got):\n%s", diff)
+	}
+	if id := msg.getID(); id != "id" {
+		t.Errorf("expected ID id, got %s", id)
+	}
+	if payload := string(msg.getPayload()); payload != `{"name":"job"}` {
+		t.Errorf("unexpected payload %s", payload)
+	}
+}
+
+func TestKafkaMessage(t *testing.T) {
+	msg := &kafkaMessage{msg: kafka.Message{
+		Topic:     "prow-jobs",
+		Partition: 2,
+		Offset:    42,
+		Value:     []byte(`{"name":"job"}`),
+		Headers:   []kafka.Header{{Key: ProwEventType, Value: []byte(PresubmitProwJobEvent)}},
+	}}
+	if diff := cmp.Diff(map[string]string{ProwEventType: PresubmitProwJobEvent}, msg.getAttributes()); diff != "" {
+		t.Errorf("unexpected attributes (-want +got):\n%s", diff)
+	}
+	if id := msg.getID(); id != "prow-jobs/2/42" {
+		t.Errorf("expected ID prow-jobs/2/42, got %s", id)
+	}
+	if payload := string(msg.getPayload()); payload != `{"name":"job"}` {
+		t.Errorf("unexpected payload %s", payload)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"cloud.google.com/go/pubsub"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	pubsubreporter "sigs.k8s.io/prow/pkg/crier/reporters/pubsub"
 	"sigs.k8s.io/prow/pkg/gangway"
 	"sigs.k8s.io/prow/pkg/kube"
 )
@@ -38,6 +40,10 @@ const (
 	PeriodicProwJobEvent   = "prow.k8s.io/pubsub.PeriodicProwJobEvent"
 	PresubmitProwJobEvent  = "prow.k8s.io/pubsub.PresubmitProwJobEvent"
 	PostsubmitProwJobEvent = "prow.k8s.io/pubsub.PostsubmitProwJobEvent"
+	// CorrelationIDAttribute is the message attribute that carries the ID
+	// the completion published to the reply topic is correlated with.
+	// Defaults to the ID of the message.
+	CorrelationIDAttribute = "prow.k8s.io/pubsub.correlationID"
 )
 
 // ProwJobEvent contains the minimum information required to start a ProwJob.
@@ -97,6 +103,36 @@ type messageInterface interface {
 	nack()
 }
 
+// replyTopic is the Pub/Sub topic the completion of ProwJobs triggered by a
+// subscription is published to.
+type replyTopic struct {
+	project       string
+	topic         string
+	schemaVersion int
+}
+
+// annotate makes the ProwJob report its completion to the reply topic, unless
+// the message already picked a topic to report to.
+func (r *replyTopic) annotate(annotations map[string]string, msg messageInterface) {
+	if annotations[pubsubreporter.PubSubProjectLabel] != "" || annotations[pubsubreporter.PubSubTopicLabel] != "" {
+		return
+	}
+	annotations[pubsubreporter.PubSubProjectLabel] = r.project
+	annotations[pubsubreporter.PubSubTopicLabel] = r.topic
+	annotations[pubsubreporter.PubSubCompletionOnlyLabel] = "true"
+	if r.schemaVersion != 0 {
+		annotations[pubsubreporter.PubSubSchemaVersionLabel] = strconv.Itoa(r.schemaVersion)
+	}
+	if annotations[pubsubreporter.PubSubCorrelationIDLabel] != "" {
+		return
+	}
+	correlationID := msg.getAttributes()[CorrelationIDAttribute]
+	if correlationID == "" {
+		correlationID = msg.getID()
+	}
+	annotations[pubsubreporter.PubSubCorrelationIDLabel] = correlationID
+}
+
 type reportClient interface {
 	Report(ctx context.Context, log *logrus.Entry, pj *prowcrd.ProwJob) ([]*prowcrd.ProwJob, *reconcile.Result, error)
 	ShouldReport(ctx context.Context, log *logrus.Entry, pj *prowcrd.ProwJob) bool
@@ -148,7 +184,7 @@ func (s *Subscriber) getReporterFunc(l *logrus.Entry) gangway.ReporterFunc {
 	}
 }
 
-func (s *Subscriber) handleMessage(msg messageInterface, subscription string, allowedClusters []string) error {
+func (s *Subscriber) handleMessage(msg messageInterface, subscription string, allowedClusters []string, reply *replyTopic) error {
 
 	msgID := msg.getID()
 	l := logrus.WithFields(logrus.Fields{
@@ -160,6 +196,10 @@ func (s *Subscriber) handleMessage(msg messageInterface, subscription string, al
 	if err != nil {
 		return err
 	}
+	cjer.PodSpecOptions.Annotations[kube.PubSubMessageIDAnnotation] = msgID
+	if reply != nil {
+		reply.annotate(cjer.PodSpecOptions.Annotations, msg)
+	}
 
 	// Do not check for HTTP client authorization, because we're handling a
 	// PubSub message.
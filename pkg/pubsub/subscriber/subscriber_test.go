@@ -26,6 +26,9 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
 	clienttesting "k8s.io/client-go/testing"
@@ -103,6 +106,26 @@ func (c *pubSubTestClient) subscription(id string, maxOutstandingMessages int) s
 	return &fakeSubscription{name: id, messageChan: c.messageChan}
 }
 
+type natsTestClient struct {
+	messageChan chan fakeMessage
+	subscribed  []string
+}
+
+func (c *natsTestClient) subscription(trigger config.NATSTrigger, subject string) subscriptionInterface {
+	c.subscribed = append(c.subscribed, trigger.QueueGroup+"/"+subject)
+	return &fakeSubscription{name: "nats/" + subject, messageChan: c.messageChan}
+}
+
+type kafkaTestClient struct {
+	messageChan chan fakeMessage
+	subscribed  []string
+}
+
+func (c *kafkaTestClient) subscription(trigger config.KafkaTrigger, topic string) subscriptionInterface {
+	c.subscribed = append(c.subscribed, trigger.GroupID+"/"+topic)
+	return &fakeSubscription{name: "kafka/" + topic, messageChan: c.messageChan}
+}
+
 type fakeReporter struct {
 	reported bool
 }
@@ -354,7 +377,7 @@ func TestHandleMessage(t *testing.T) {
 				m.ID = "id"
 				tc.msg = &pubSubMessage{*m}
 			}
-			if err := s.handleMessage(tc.msg, "", []string{"*"}); err != nil {
+			if err := s.handleMessage(tc.msg, "", []string{"*"}, nil); err != nil {
 				if err.Error() != tc.err {
 					t1.Errorf("Expected error '%v' got '%v'", tc.err, err.Error())
 				} else if tc.err == "" {
@@ -381,6 +404,69 @@ func TestHandleMessage(t *testing.T) {
 	}
 }
 
+func TestReplyTopicAnnotate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		attributes  map[string]string
+		schema      int
+		expected    map[string]string
+	}{
+		{
+			name:        "reply to the topic correlated by message ID",
+			annotations: map[string]string{},
+			expected: map[string]string{
+				reporter.PubSubProjectLabel:        "project",
+				reporter.PubSubTopicLabel:          "replies",
+				reporter.PubSubCompletionOnlyLabel: "true",
+				reporter.PubSubCorrelationIDLabel:  "id",
+			},
+		},
+		{
+			name:        "correlation ID from the message attributes",
+			annotations: map[string]string{},
+			attributes:  map[string]string{CorrelationIDAttribute: "build-42"},
+			expected: map[string]string{
+				reporter.PubSubProjectLabel:        "project",
+				reporter.PubSubTopicLabel:          "replies",
+				reporter.PubSubCompletionOnlyLabel: "true",
+				reporter.PubSubCorrelationIDLabel:  "build-42",
+			},
+		},
+		{
+			name:        "reply with schema version 2",
+			annotations: map[string]string{},
+			schema:      2,
+			expected: map[string]string{
+				reporter.PubSubProjectLabel:        "project",
+				reporter.PubSubTopicLabel:          "replies",
+				reporter.PubSubCompletionOnlyLabel: "true",
+				reporter.PubSubSchemaVersionLabel:  "2",
+				reporter.PubSubCorrelationIDLabel:  "id",
+			},
+		},
+		{
+			name: "message picked its own topic",
+			annotations: map[string]string{
+				reporter.PubSubProjectLabel: "other-project",
+				reporter.PubSubTopicLabel:   "statuses",
+			},
+			expected: map[string]string{
+				reporter.PubSubProjectLabel: "other-project",
+				reporter.PubSubTopicLabel:   "statuses",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := &fakeMessage{ID: "id", Attributes: tc.attributes}
+			(&replyTopic{project: "project", topic: "replies", schemaVersion: tc.schema}).annotate(tc.annotations, msg)
+			if diff := cmp.Diff(tc.expected, tc.annotations); diff != "" {
+				t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func CheckProwJob(pe *ProwJobEvent, pj *prowapi.ProwJob) error {
 	// checking labels
 	for label, value := range pe.Labels {
@@ -743,6 +829,82 @@ func TestPullServer_RunConfigChange(t *testing.T) {
 	}
 }
 
+func TestPullServer_RunMessageBusTriggers(t *testing.T) {
+	testCases := []struct {
+		name               string
+		config             config.ProwConfig
+		expectedNATS       []string
+		expectedKafka      []string
+		expectedSubscriber string
+	}{
+		{
+			name: "NATS subjects",
+			config: config.ProwConfig{
+				NATSTriggers: []config.NATSTrigger{{
+					URL:             "nats://nats:4222",
+					Subjects:        []string{"prow.jobs"},
+					QueueGroup:      "prow-sub",
+					AllowedClusters: []string{"*"},
+				}},
+			},
+			expectedNATS:       []string{"prow-sub/prow.jobs"},
+			expectedSubscriber: "nats/prow.jobs",
+		},
+		{
+			name: "Kafka topics",
+			config: config.ProwConfig{
+				KafkaTriggers: []config.KafkaTrigger{{
+					Brokers:         []string{"kafka:9092"},
+					Topics:          []string{"prow-jobs"},
+					GroupID:         "prow-sub",
+					AllowedClusters: []string{"*"},
+				}},
+			},
+			expectedKafka:      []string{"prow-sub/prow-jobs"},
+			expectedSubscriber: "kafka/prow-jobs",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Subscriber{
+				ConfigAgent:   &config.Agent{},
+				ProwJobClient: fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"),
+				Metrics:       NewMetrics(),
+			}
+			s.ConfigAgent.Set(&config.Config{ProwConfig: tc.config})
+			messageChan := make(chan fakeMessage, 1)
+			natsClient := &natsTestClient{messageChan: messageChan}
+			kafkaClient := &kafkaTestClient{messageChan: messageChan}
+			pullServer := PullServer{
+				Subscriber:  s,
+				Client:      &pubSubTestClient{},
+				NATSClient:  natsClient,
+				KafkaClient: kafkaClient,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			messageChan <- fakeMessage{
+				Attributes: map[string]string{},
+				ID:         "test",
+			}
+			err := pullServer.Run(ctx)
+			if err == nil || !strings.HasPrefix(err.Error(), "message processed") {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(natsClient.subscribed, tc.expectedNATS) {
+				t.Errorf("expected NATS subscriptions %v, got %v", tc.expectedNATS, natsClient.subscribed)
+			}
+			if !reflect.DeepEqual(kafkaClient.subscribed, tc.expectedKafka) {
+				t.Errorf("expected Kafka subscriptions %v, got %v", tc.expectedKafka, kafkaClient.subscribed)
+			}
+			// The message has no event type, so it is counted as an error of the subscription.
+			if count := testutil.ToFloat64(s.Metrics.ACKMessageCounter.With(prometheus.Labels{subscriptionLabel: tc.expectedSubscriber})); count != 1 {
+				t.Errorf("expected one failed message for subscription %s, got %v", tc.expectedSubscriber, count)
+			}
+		})
+	}
+}
+
 func TestTryGetCloneURIAndHost(t *testing.T) {
 	tests := []struct {
 		name             string
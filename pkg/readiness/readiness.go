@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package readiness keeps the gates a component has to pass before it reports
+// ready: its config is loaded, its informers are synced and its external
+// dependencies, like GitHub and storage, were reachable. Gates are registered
+// globally and checked in the background until they pass; the readiness
+// endpoint served by pjutil.Health reports the gates that did not pass yet.
+//
+// A gate that passed stays passed. An outage of a dependency after startup
+// takes every replica out of service at the same time, which does not help
+// anybody, so it is left to the liveness checks and metrics.
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// Check returns nil once the gate passed.
+type Check func(ctx context.Context) error
+
+var (
+	// checkInterval is the pause between failed checks of a gate.
+	checkInterval = 10 * time.Second
+	// checkTimeout bounds a single check of a gate.
+	checkTimeout = 30 * time.Second
+)
+
+type gate struct {
+	name   string
+	passed bool
+	err    error
+}
+
+var gates = struct {
+	sync.Mutex
+	registered []*gate
+}{}
+
+// Register adds a gate and starts checking it in the background until it
+// passes or an interrupt is received.
+func Register(name string, check Check) {
+	g := &gate{name: name}
+	gates.Lock()
+	gates.registered = append(gates.registered, g)
+	gates.Unlock()
+
+	log := logrus.WithField("gate", name)
+	interrupts.Run(func(ctx context.Context) {
+		for {
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			err := check(checkCtx)
+			cancel()
+
+			gates.Lock()
+			g.passed, g.err = err == nil, err
+			gates.Unlock()
+			if err == nil {
+				log.Info("Readiness gate passed.")
+				return
+			}
+			log.WithError(err).Debug("Readiness gate did not pass yet.")
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(checkInterval):
+			}
+		}
+	})
+}
+
+// Ready returns an error naming the gates that did not pass yet.
+func Ready() error {
+	gates.Lock()
+	defer gates.Unlock()
+	var pending []string
+	for _, g := range gates.registered {
+		if g.passed {
+			continue
+		}
+		if g.err != nil {
+			pending = append(pending, fmt.Sprintf("%s: %v", g.name, g.err))
+		} else {
+			pending = append(pending, g.name+": not checked yet")
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("waiting for readiness gates: %s", strings.Join(pending, "; "))
+	}
+	return nil
+}
+
+// Config passes once the config getter returns a config.
+func Config[T any](getter func() *T) Check {
+	return func(context.Context) error {
+		if getter() == nil {
+			return errors.New("config is not loaded")
+		}
+		return nil
+	}
+}
+
+type cacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CacheSynced passes once the informers of the cache are synced.
+func CacheSynced(cache cacheSyncer) Check {
+	return func(ctx context.Context) error {
+		if !cache.WaitForCacheSync(ctx) {
+			return errors.New("informers are not synced")
+		}
+		return nil
+	}
+}
+
+type gitHubClient interface {
+	BotUserCheckerWithContext(ctx context.Context) (func(candidate string) bool, error)
+}
+
+// GitHub passes once the identity of the client was fetched from GitHub,
+// which proves that GitHub is reachable and the credentials are valid.
+func GitHub(gc gitHubClient) Check {
+	return func(ctx context.Context) error {
+		if _, err := gc.BotUserCheckerWithContext(ctx); err != nil {
+			return fmt.Errorf("GitHub is not reachable: %w", err)
+		}
+		return nil
+	}
+}
+
+// Storage passes once the attributes of every path could be read, or the
+// path was found not to exist.
+func Storage(opener io.Opener, paths ...string) Check {
+	return func(ctx context.Context) error {
+		for _, path := range paths {
+			if _, err := opener.Attributes(ctx, path); err != nil && !io.IsNotExist(err) {
+				return fmt.Errorf("storage is not reachable for %s: %w", path, err)
+			}
+		}
+		return nil
+	}
+}
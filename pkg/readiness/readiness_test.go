@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestRegister(t *testing.T) {
+	checkInterval = time.Millisecond
+	gates.registered = nil
+
+	var reachable atomic.Bool
+	var calls atomic.Int32
+	Register("config", Config(func() *struct{} { return &struct{}{} }))
+	Register("github", func(context.Context) error {
+		calls.Add(1)
+		if !reachable.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	waitFor(t, func() bool { return calls.Load() > 1 })
+	err := Ready()
+	if err == nil || !strings.Contains(err.Error(), "github: connection refused") || strings.Contains(err.Error(), "config") {
+		t.Fatalf("expected only the github gate to be pending, got: %v", err)
+	}
+
+	reachable.Store(true)
+	waitFor(t, func() bool { return Ready() == nil })
+
+	// A gate that passed is not checked again.
+	reachable.Store(false)
+	passedAfter := calls.Load()
+	time.Sleep(10 * checkInterval)
+	if calls.Load() != passedAfter {
+		t.Errorf("expected the gate not to be checked after it passed")
+	}
+	if err := Ready(); err != nil {
+		t.Errorf("expected the gate to stay passed, got: %v", err)
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type fakeOpener struct {
+	io.Opener
+	errs map[string]error
+}
+
+func (f *fakeOpener) Attributes(_ context.Context, path string) (io.Attributes, error) {
+	return io.Attributes{}, f.errs[path]
+}
+
+func TestStorage(t *testing.T) {
+	opener := &fakeOpener{errs: map[string]error{
+		"gs://bucket/missing.json":   io.ErrNotFoundTest,
+		"gs://forbidden/status.json": errors.New("permission denied"),
+	}}
+	if err := Storage(opener, "gs://bucket/history.json", "gs://bucket/missing.json")(context.Background()); err != nil {
+		t.Errorf("expected existing and missing paths to pass, got: %v", err)
+	}
+	if err := Storage(opener, "gs://bucket/history.json", "gs://forbidden/status.json")(context.Background()); err == nil {
+		t.Error("expected an error when a path can't be read")
+	}
+}
@@ -41,6 +41,13 @@ import (
 const (
 	// GitHub's api uses "" (empty) string as basedir by convention but it's clearer to use "/"
 	baseDirConvention = ""
+
+	// TeamPrefix marks OWNERS entries that reference the members of a GitHub
+	// team, e.g. team:org/team-slug.
+	TeamPrefix = "team:"
+	// teamCacheTTL is how long team members are cached before they are
+	// listed again.
+	teamCacheTTL = 5 * time.Minute
 )
 
 type dirOptions struct {
@@ -79,6 +86,7 @@ type FullConfig struct {
 type githubClient interface {
 	ListCollaborators(org, repo string) ([]github.User, error)
 	GetRef(org, repo, ref string) (string, error)
+	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
 }
 
 func newCache() *cache {
@@ -140,6 +148,44 @@ func (entry cacheEntry) fullyLoaded() bool {
 	return entry.sha != "" && entry.aliases != nil && entry.owners != nil
 }
 
+// teamCache holds the members of the teams referenced in OWNERS files. Team
+// membership changes independently of the repo, so entries expire.
+type teamCache struct {
+	lock    sync.Mutex
+	entries map[string]teamCacheEntry
+}
+
+type teamCacheEntry struct {
+	members sets.Set[string]
+	expires time.Time
+}
+
+func newTeamCache() *teamCache {
+	return &teamCache{entries: map[string]teamCacheEntry{}}
+}
+
+func (c *teamCache) get(team string) (sets.Set[string], bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[team]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.members, true
+}
+
+func (c *teamCache) set(team string, members sets.Set[string]) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[team] = teamCacheEntry{members: members, expires: time.Now().Add(teamCacheTTL)}
+}
+
 // Interface is an interface to work with OWNERS files.
 type Interface interface {
 	LoadRepoOwners(org, repo, base string) (RepoOwner, error)
@@ -171,6 +217,7 @@ type delegate struct {
 	filenames         ownersconfig.Resolver
 
 	cache *cache
+	teams *teamCache
 }
 
 // WithFields clones the client, keeping the underlying delegate the same but adding
@@ -226,6 +273,7 @@ func NewClient(
 		delegate: &delegate{
 			git:   gc,
 			cache: newCache(),
+			teams: newTeamCache(),
 
 			mdYAMLEnabled:     mdYAMLEnabled,
 			skipCollaborators: skipCollaborators,
@@ -314,15 +362,23 @@ func (c *Client) LoadRepoOwnersSha(org, repo, base, sha string, updateCache bool
 		return nil, err
 	}
 
+	// Resolve teams even if the RepoOwners struct came from the cache because
+	// team membership could have changed without the git SHA changing.
+	owners := entry.owners
+	if refs := owners.teamRefs(); len(refs) > 0 {
+		start := time.Now()
+		owners = owners.expandTeams(c.resolveTeams(refs, log))
+		log.WithField("duration", time.Since(start).String()).Debugf("Completed resolving %d teams", len(refs))
+	}
+
 	start := time.Now()
 	if c.skipCollaborators(org, repo) {
 		log.WithField("duration", time.Since(start).String()).Debugf("Completed c.skipCollaborators(%s, %s)", org, repo)
 		log.Debugf("Skipping collaborator checks for %s/%s", org, repo)
-		return entry.owners, nil
+		return owners, nil
 	}
 	log.WithField("duration", time.Since(start).String()).Debugf("Completed c.skipCollaborators(%s, %s)", org, repo)
 
-	var owners *RepoOwners
 	// Filter collaborators. We must filter the RepoOwners struct even if it came from the cache
 	// because the list of collaborators could have changed without the git SHA changing.
 	start = time.Now()
@@ -330,15 +386,57 @@ func (c *Client) LoadRepoOwnersSha(org, repo, base, sha string, updateCache bool
 	log.WithField("duration", time.Since(start).String()).Debugf("Completed ghc.ListCollaborators(%s, %s)", org, repo)
 	if err != nil {
 		log.WithError(err).Errorf("Failed to list collaborators while loading RepoOwners. Skipping collaborator filtering.")
-		owners = entry.owners
 	} else {
 		start = time.Now()
-		owners = entry.owners.filterCollaborators(collaborators)
+		owners = owners.filterCollaborators(collaborators)
 		log.WithField("duration", time.Since(start).String()).Debugf("Completed owners.filterCollaborators(collaborators)")
 	}
 	return owners, nil
 }
 
+// IsTeamRef returns true if the OWNERS entry references a GitHub team.
+func IsTeamRef(entry string) bool {
+	return strings.HasPrefix(entry, TeamPrefix)
+}
+
+// parseTeamRef splits a team:org/team-slug reference into the org and slug.
+func parseTeamRef(ref string) (org, slug string, err error) {
+	org, slug, ok := strings.Cut(strings.TrimPrefix(ref, TeamPrefix), "/")
+	if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return "", "", fmt.Errorf("invalid team reference %q, expected %sorg/team-slug", ref, TeamPrefix)
+	}
+	return org, slug, nil
+}
+
+// resolveTeams lists the members of the referenced teams. Teams that cannot
+// be resolved have no members, so they never grant approval.
+func (c *Client) resolveTeams(refs sets.Set[string], log *logrus.Entry) map[string]sets.Set[string] {
+	resolved := make(map[string]sets.Set[string], len(refs))
+	for ref := range refs {
+		if members, ok := c.teams.get(ref); ok {
+			resolved[ref] = members
+			continue
+		}
+		org, slug, err := parseTeamRef(ref)
+		if err != nil {
+			log.WithError(err).Warn("Ignoring invalid team reference.")
+			continue
+		}
+		teamMembers, err := c.ghc.ListTeamMembersBySlug(org, slug, github.RoleAll)
+		if err != nil {
+			log.WithError(err).WithField("team", ref).Error("Failed to list team members. Ignoring team.")
+			continue
+		}
+		members := sets.New[string]()
+		for _, member := range teamMembers {
+			members.Insert(github.NormLogin(member.Login))
+		}
+		c.teams.set(ref, members)
+		resolved[ref] = members
+	}
+	return resolved
+}
+
 func (c *Client) cacheEntryFor(org, repo, base, cloneRef, fullName, sha string, setEntry bool, log *logrus.Entry) (cacheEntry, error) {
 	mdYaml := c.mdYAMLEnabled(org, repo)
 	lockStart := time.Now()
@@ -736,6 +834,52 @@ func (o *RepoOwners) applyOptionsToPath(path string, opts dirOptions) {
 	}
 }
 
+// teamRefs returns the team references among the approvers and reviewers.
+func (o *RepoOwners) teamRefs() sets.Set[string] {
+	refs := sets.New[string]()
+	for _, ownerMap := range []map[string]map[*regexp.Regexp]sets.Set[string]{o.approvers, o.reviewers, o.requiredReviewers} {
+		for _, reMap := range ownerMap {
+			for _, entries := range reMap {
+				for entry := range entries {
+					if IsTeamRef(entry) {
+						refs.Insert(entry)
+					}
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// expandTeams returns a copy of the RepoOwners with team references replaced
+// by the members of the teams.
+func (o *RepoOwners) expandTeams(teams map[string]sets.Set[string]) *RepoOwners {
+	expand := func(ownerMap map[string]map[*regexp.Regexp]sets.Set[string]) map[string]map[*regexp.Regexp]sets.Set[string] {
+		expanded := make(map[string]map[*regexp.Regexp]sets.Set[string], len(ownerMap))
+		for path, reMap := range ownerMap {
+			expanded[path] = make(map[*regexp.Regexp]sets.Set[string], len(reMap))
+			for re, entries := range reMap {
+				result := sets.New[string]()
+				for entry := range entries {
+					if IsTeamRef(entry) {
+						result = result.Union(teams[entry])
+					} else {
+						result.Insert(entry)
+					}
+				}
+				expanded[path][re] = result
+			}
+		}
+		return expanded
+	}
+
+	result := *o
+	result.approvers = expand(o.approvers)
+	result.reviewers = expand(o.reviewers)
+	result.requiredReviewers = expand(o.requiredReviewers)
+	return &result
+}
+
 func (o *RepoOwners) filterCollaborators(toKeep []github.User) *RepoOwners {
 	collabs := sets.New[string]()
 	for _, keeper := range toKeep {
@@ -127,7 +127,10 @@ type cacheOptions struct {
 
 type fakeGitHubClient struct {
 	Collaborators []string
+	Teams         map[string][]string
 	ref           string
+
+	teamListings int
 }
 
 func (f *fakeGitHubClient) ListCollaborators(org, repo string) ([]github.User, error) {
@@ -142,6 +145,19 @@ func (f *fakeGitHubClient) GetRef(org, repo, ref string) (string, error) {
 	return f.ref, nil
 }
 
+func (f *fakeGitHubClient) ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error) {
+	f.teamListings++
+	logins, ok := f.Teams[org+"/"+teamSlug]
+	if !ok {
+		return nil, fmt.Errorf("team %s/%s not found", org, teamSlug)
+	}
+	var members []github.TeamMember
+	for _, login := range logins {
+		members = append(members, github.TeamMember{Login: login})
+	}
+	return members, nil
+}
+
 func getTestClient(
 	files map[string][]byte,
 	enableMdYaml,
@@ -1427,3 +1443,94 @@ func TestRepoOwners_AllReviewers(t *testing.T) {
 		t.Errorf("Expected reviewers: %v\tFound reviewers: %v ", expectedReviewers, sets.List(foundReviewers))
 	}
 }
+
+func TestLoadRepoOwnersTeams(t *testing.T) {
+	files := map[string][]byte{
+		"OWNERS": []byte(`approvers:
+- cjwagner
+- team:org/Leads
+reviewers:
+- team:org/missing`),
+		"src/OWNERS": []byte(`approvers:
+- team:org/src-approvers
+reviewers:
+- bob`),
+	}
+	for _, tc := range []struct {
+		name       string
+		skipCollab bool
+
+		expectedRoot sets.Set[string]
+		expectedSrc  sets.Set[string]
+	}{
+		{
+			name:         "team members are filtered to collaborators",
+			expectedRoot: sets.New[string]("cjwagner", "alice"),
+			expectedSrc:  sets.New[string]("carl"),
+		},
+		{
+			name:         "all team members are owners without collaborator checks",
+			skipCollab:   true,
+			expectedRoot: sets.New[string]("cjwagner", "alice", "zed"),
+			expectedSrc:  sets.New[string]("carl", "yan"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, cleanup, err := getTestClient(files, false, tc.skipCollab, false, false, nil, nil, nil, nil, localgit.NewV2)
+			if err != nil {
+				t.Fatalf("error creating test client: %v", err)
+			}
+			defer cleanup()
+			ghc := client.ghc.(*fakeGitHubClient)
+			ghc.Teams = map[string][]string{
+				"org/leads":         {"Alice", "zed"},
+				"org/src-approvers": {"carl", "yan"},
+			}
+			client.teams = newTeamCache()
+
+			for i := 0; i < 2; i++ {
+				ro, err := client.LoadRepoOwners("org", "repo", defaultBranch)
+				if err != nil {
+					t.Fatalf("unexpected error loading RepoOwners: %v", err)
+				}
+				if approvers := ro.LeafApprovers("file.go"); !approvers.Equal(tc.expectedRoot) {
+					t.Errorf("expected root approvers %v, got %v", sets.List(tc.expectedRoot), sets.List(approvers))
+				}
+				if approvers := ro.LeafApprovers("src/file.go"); !approvers.Equal(tc.expectedSrc) {
+					t.Errorf("expected src approvers %v, got %v", sets.List(tc.expectedSrc), sets.List(approvers))
+				}
+				if reviewers := ro.LeafReviewers("file.go"); reviewers.Len() != 0 {
+					t.Errorf("expected unresolvable team to have no reviewers, got %v", sets.List(reviewers))
+				}
+			}
+			// The missing team is listed every time, the others are cached.
+			if ghc.teamListings != 4 {
+				t.Errorf("expected 4 team listings, got %d", ghc.teamListings)
+			}
+		})
+	}
+}
+
+func TestParseTeamRef(t *testing.T) {
+	for _, tc := range []struct {
+		ref          string
+		expectedOrg  string
+		expectedSlug string
+		expectedErr  bool
+	}{
+		{ref: "team:org/slug", expectedOrg: "org", expectedSlug: "slug"},
+		{ref: "team:org", expectedErr: true},
+		{ref: "team:/slug", expectedErr: true},
+		{ref: "team:org/slug/extra", expectedErr: true},
+	} {
+		t.Run(tc.ref, func(t *testing.T) {
+			org, slug, err := parseTeamRef(tc.ref)
+			if err != nil != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if org != tc.expectedOrg || slug != tc.expectedSlug {
+				t.Errorf("expected %s/%s, got %s/%s", tc.expectedOrg, tc.expectedSlug, org, slug)
+			}
+		})
+	}
+}
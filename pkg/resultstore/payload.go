@@ -184,6 +184,12 @@ func jobProperties(pj *v1.ProwJob) []*resultstore.Property {
 			Value: pj.Status.URL,
 		},
 	}
+	if pj.Status.FailureReason != "" {
+		ps = append(ps, &resultstore.Property{
+			Key:   "Failure_Reason",
+			Value: string(pj.Status.FailureReason),
+		})
+	}
 	ps = append(ps, podSpecProperties(pj.Spec.PodSpec)...)
 	return ps
 }
@@ -461,6 +461,37 @@ func TestInvocationProperties(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "failure reason",
+			job: &v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Job: "spec-job",
+				},
+				Status: v1.ProwJobStatus{
+					URL:           "https://prow/url",
+					BuildID:       "build-id",
+					FailureReason: v1.TimeoutFailure,
+				},
+			},
+			want: []*resultstore.Property{
+				{
+					Key:   "Instance",
+					Value: "build-id",
+				},
+				{
+					Key:   "Job",
+					Value: "spec-job",
+				},
+				{
+					Key:   "Prow_Dashboard_URL",
+					Value: "https://prow/url",
+				},
+				{
+					Key:   "Failure_Reason",
+					Value: "timeout",
+				},
+			},
+		},
 		{
 			desc: "job nil",
 			job:  nil,
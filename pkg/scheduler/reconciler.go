@@ -34,13 +34,20 @@ import (
 
 const ControllerName = "scheduler"
 
-func Add(mgr controllerruntime.Manager, cfg config.Getter, numWorkers int) error {
+// ClusterGate tells whether ProwJobs can be scheduled to a build cluster.
+type ClusterGate interface {
+	Schedulable(cluster string) bool
+}
+
+// Add adds the scheduler to the manager. The clusterGate is optional, when
+// set ProwJobs are only scheduled to clusters it deems schedulable.
+func Add(mgr controllerruntime.Manager, cfg config.Getter, clusterGate ClusterGate, numWorkers int) error {
 	predicates := predicate.NewPredicateFuncs(func(object client.Object) bool {
 		pj, isPJ := object.(*prowv1.ProwJob)
 		return isPJ && pj.Status.State == prowv1.SchedulingState
 	})
 
-	reconciler := NewReconciler(mgr.GetClient(), cfg, strategy.Get)
+	reconciler := NewReconciler(mgr.GetClient(), cfg, strategy.Get, clusterGate)
 	if err := controllerruntime.NewControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&prowv1.ProwJob{}).
@@ -61,6 +68,7 @@ type Reconciler struct {
 	log         *logrus.Entry
 	cfg         config.Getter
 	strategy    StrategyGetter
+	clusterGate ClusterGate
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -81,7 +89,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	// So far only k8s and tekton use the cluster field in a meaningful way. Hence
 	// if we're reconciling a job having a different agent (or no agent at all) applying
 	// the passthrough strategy may be the safest approach.
-	if pj.Spec.Agent == prowv1.KubernetesAgent || pj.Spec.Agent == prowv1.TektonAgent {
+	if pj.Spec.Agent == prowv1.KubernetesAgent || pj.Spec.Agent == prowv1.KubernetesJobAgent || pj.Spec.Agent == prowv1.TektonAgent {
 		result, err = r.strategy(r.cfg(), log).Schedule(ctx, pj)
 	} else {
 		result, err = r.passthrough.Schedule(ctx, pj)
@@ -90,6 +98,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("schedule prowjob %s: %w", request.Name, err)
 	}
+	if r.clusterGate != nil && r.cfg().Scheduler.ClusterHealth != nil && !r.clusterGate.Schedulable(result.Cluster) {
+		// Keep the job waiting rather than letting it error out in a cluster
+		// that cannot run it.
+		log.WithField("cluster", result.Cluster).Info("Cluster is unhealthy, postponing scheduling")
+		return reconcile.Result{RequeueAfter: r.cfg().Scheduler.ClusterHealth.ProbeInterval.Duration}, nil
+	}
+
 	log.WithField("cluster", result.Cluster).Info("Cluster assigned")
 
 	// Don't mess the cache up
@@ -104,12 +119,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return reconcile.Result{}, nil
 }
 
-func NewReconciler(pjClient client.Client, cfg config.Getter, strtgy StrategyGetter) *Reconciler {
+func NewReconciler(pjClient client.Client, cfg config.Getter, strtgy StrategyGetter, clusterGate ClusterGate) *Reconciler {
 	return &Reconciler{
 		pjClient:    pjClient,
 		passthrough: &strategy.Passthrough{},
 		log:         logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
 		cfg:         cfg,
 		strategy:    strtgy,
+		clusterGate: clusterGate,
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
@@ -176,7 +177,7 @@ func TestReconcile(t *testing.T) {
 				func() *config.Config { return nil },
 				func(_ *config.Config, _ *logrus.Entry) strategy.Interface {
 					return &fakeStrategy{cluster: tc.cluster, err: tc.schedulingError}
-				})
+				}, nil)
 			_, err := r.Reconcile(context.TODO(), tc.request)
 
 			if tc.wantError != nil && err != nil {
@@ -298,7 +299,7 @@ func TestConfigHotReload(t *testing.T) {
 
 			var cfg *config.Config
 			pjClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.pjs...).Build()
-			reconciler := scheduler.NewReconciler(pjClient, func() *config.Config { return cfg }, strategy.Get)
+			reconciler := scheduler.NewReconciler(pjClient, func() *config.Config { return cfg }, strategy.Get, nil)
 
 			for i := range tc.configs {
 				cfg = &tc.configs[i]
@@ -320,3 +321,59 @@ func TestConfigHotReload(t *testing.T) {
 		})
 	}
 }
+
+type fakeClusterGate map[string]bool
+
+func (g fakeClusterGate) Schedulable(cluster string) bool {
+	return g[cluster]
+}
+
+func TestReconcileUnhealthyCluster(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		cfg         config.Config
+		wantState   prowv1.ProwJobState
+		wantRequeue time.Duration
+	}{
+		{
+			name: "Postpone scheduling to an unhealthy cluster",
+			cfg: config.Config{ProwConfig: config.ProwConfig{Scheduler: config.Scheduler{
+				ClusterHealth: &config.ClusterHealthGating{ProbeInterval: &v1.Duration{Duration: time.Minute}},
+			}}},
+			wantState:   prowv1.SchedulingState,
+			wantRequeue: time.Minute,
+		},
+		{
+			name:      "Schedule to an unhealthy cluster when health gating is disabled",
+			wantState: prowv1.TriggeredState,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pjClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(&prowv1.ProwJob{
+				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns"},
+				Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "broken"},
+				Status:     prowv1.ProwJobStatus{State: prowv1.SchedulingState},
+			}).Build()
+			r := scheduler.NewReconciler(pjClient, func() *config.Config { return &tc.cfg }, strategy.Get, fakeClusterGate{"broken": false})
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "pj", Namespace: "ns"}}
+			result, err := r.Reconcile(context.TODO(), request)
+			if err != nil {
+				t.Fatalf("Failed to reconcile %v: %s", request, err)
+			}
+			if result.RequeueAfter != tc.wantRequeue {
+				t.Errorf("Expected requeue after %s but got %s", tc.wantRequeue, result.RequeueAfter)
+			}
+
+			pj := prowv1.ProwJob{}
+			if err := pjClient.Get(context.TODO(), request.NamespacedName, &pj); err != nil {
+				t.Fatalf("Couldn't get PJ from the fake client: %s", err)
+			}
+			if pj.Status.State != tc.wantState {
+				t.Errorf("Expected state %s but got %s", tc.wantState, pj.Status.State)
+			}
+		})
+	}
+}
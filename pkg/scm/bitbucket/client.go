@@ -0,0 +1,380 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bitbucket implements the SCM provider for Bitbucket Server and
+// Bitbucket Data Center: a client for the parts of their REST API that Prow
+// uses and the conversion of their webhooks into GitHub events. Projects are
+// treated as orgs, their repositories as repos.
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// Name is the name of the provider, which is also the source of its events
+// in the event queue.
+const Name = "bitbucket-server"
+
+// NewProvider returns the provider for the Bitbucket Server at endpoint, the
+// base URL of the server without any API path. Requests authenticate with the
+// HTTP access token from token and webhooks are validated against the secret
+// from webhookSecret. In dry run mode only GET requests are sent.
+func NewProvider(endpoint string, token, webhookSecret func() []byte, dryRun bool) scm.Provider {
+	return &provider{
+		client: &client{
+			endpoint: strings.TrimSuffix(endpoint, "/"),
+			token:    token,
+			client:   &http.Client{Timeout: time.Minute},
+			dryRun:   dryRun,
+			logger:   logrus.WithField("client", Name),
+		},
+		webhookSecret: webhookSecret,
+	}
+}
+
+type provider struct {
+	*client
+	webhookSecret func() []byte
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+type client struct {
+	endpoint string
+	token    func() []byte
+	client   *http.Client
+	dryRun   bool
+	logger   *logrus.Entry
+}
+
+// StatusError is returned for responses outside the 2XX range.
+type StatusError struct {
+	Method, Path string
+	Code         int
+	Body         string
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("%s %s: status code %d: %s", e.Method, e.Path, e.Code, e.Body)
+}
+
+// IsNotFound returns whether err is a 404 response from Bitbucket Server.
+func IsNotFound(err error) bool {
+	var statusErr StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound
+}
+
+// request sends a request to path below the endpoint. Responses are decoded
+// into out if it is a pointer, or copied into it if it is a *bytes.Buffer.
+func (c *client) request(method, path string, query url.Values, body, out interface{}) error {
+	logger := c.logger.WithFields(logrus.Fields{"method": method, "path": path})
+	if c.dryRun && method != http.MethodGet {
+		logger.Info("Not executing request in dry run mode.")
+		return nil
+	}
+	logger.Debug("Sending request.")
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+string(c.token()))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return StatusError{Method: method, Path: path, Code: resp.StatusCode, Body: string(data)}
+	}
+	if buf, ok := out.(*bytes.Buffer); ok {
+		buf.Write(data)
+		return nil
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return nil
+}
+
+// listAll follows nextPageStart to collect every page of a list.
+func listAll[T any](c *client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", "100")
+	var all []T
+	start := 0
+	for {
+		query.Set("start", strconv.Itoa(start))
+		var p page[T]
+		if err := c.request(http.MethodGet, path, query, nil, &p); err != nil {
+			return nil, err
+		}
+		all = append(all, p.Values...)
+		if p.IsLastPage || len(p.Values) == 0 {
+			return all, nil
+		}
+		start = p.NextPageStart
+	}
+}
+
+func projectPath(project string) string {
+	return "/rest/api/1.0/projects/" + url.PathEscape(project)
+}
+
+func repoPath(project, repo string) string {
+	return projectPath(project) + "/repos/" + url.PathEscape(repo)
+}
+
+func pullRequestPath(project, repo string, id int) string {
+	return fmt.Sprintf("%s/pull-requests/%d", repoPath(project, repo), id)
+}
+
+func buildStatusPath(sha string) string {
+	return "/rest/build-status/1.0/commits/" + url.PathEscape(sha)
+}
+
+// BotLogin returns the username the token belongs to.
+func (c *client) BotLogin() (string, error) {
+	var buf bytes.Buffer
+	if err := c.request(http.MethodGet, "/plugins/servlet/applinks/whoami", nil, nil, &buf); err != nil {
+		return "", err
+	}
+	login := strings.TrimSpace(buf.String())
+	if login == "" {
+		return "", errors.New("the token does not belong to a user")
+	}
+	return login, nil
+}
+
+// permissions lists the permissions user has been granted directly, not
+// through groups, on the project or repo below path. Listing permissions
+// requires admin access to the repo.
+func (c *client) permissions(path, user string) ([]Permission, error) {
+	permissions, err := listAll[Permission](c, path+"/permissions/users", url.Values{"filter": []string{user}})
+	if err != nil {
+		return nil, err
+	}
+	var matching []Permission
+	for _, p := range permissions {
+		if github.NormLogin(p.User.Name) == github.NormLogin(user) {
+			matching = append(matching, p)
+		}
+	}
+	return matching, nil
+}
+
+// IsCollaborator returns whether user may push to the repo, either through
+// permissions on the repo or on its project.
+func (c *client) IsCollaborator(org, repo, user string) (bool, error) {
+	for _, path := range []string{repoPath(org, repo), projectPath(org)} {
+		permissions, err := c.permissions(path, user)
+		if err != nil {
+			return false, err
+		}
+		for _, p := range permissions {
+			if p.canWrite() {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// IsMember returns whether user has any permission on the project org.
+func (c *client) IsMember(org, user string) (bool, error) {
+	permissions, err := c.permissions(projectPath(org), user)
+	if err != nil {
+		return false, err
+	}
+	return len(permissions) > 0, nil
+}
+
+func (c *client) getPullRequest(org, repo string, number int) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.request(http.MethodGet, pullRequestPath(org, repo, number), nil, nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (c *client) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	pr, err := c.getPullRequest(org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	converted := GitHubPullRequest(*pr)
+	return &converted, nil
+}
+
+func (c *client) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	changes, err := listAll[Change](c, pullRequestPath(org, repo, number)+"/changes", nil)
+	if err != nil {
+		return nil, err
+	}
+	return gitHubChanges(changes), nil
+}
+
+// compare lists the files changed between the commits from and to.
+func (c *client) compare(org, repo, from, to string) ([]Change, error) {
+	// Bitbucket compares the source from against the target to.
+	return listAll[Change](c, repoPath(org, repo)+"/compare/changes", url.Values{"from": []string{to}, "to": []string{from}})
+}
+
+func (c *client) GetRef(org, repo, ref string) (string, error) {
+	branch, ok := strings.CutPrefix(ref, "heads/")
+	if !ok {
+		return "", fmt.Errorf("unsupported ref %q, only branches are supported on Bitbucket Server", ref)
+	}
+	path := repoPath(org, repo) + "/branches"
+	branches, err := listAll[Branch](c, path, url.Values{"filterText": []string{branch}})
+	if err != nil {
+		return "", err
+	}
+	for _, b := range branches {
+		if b.ID == "refs/heads/"+branch {
+			return b.LatestCommit, nil
+		}
+	}
+	return "", StatusError{Method: http.MethodGet, Path: path, Code: http.StatusNotFound, Body: fmt.Sprintf("branch %s not found", branch)}
+}
+
+func (c *client) ListComments(org, repo string, number int) ([]github.IssueComment, error) {
+	activities, err := listAll[Activity](c, pullRequestPath(org, repo, number)+"/activities", nil)
+	if err != nil {
+		return nil, err
+	}
+	var comments []github.IssueComment
+	// Activities are listed newest first, comments oldest first.
+	for i := len(activities) - 1; i >= 0; i-- {
+		a := activities[i]
+		if a.Action != ActivityActionCommented || a.Comment == nil {
+			continue
+		}
+		comments = append(comments, gitHubComment(*a.Comment))
+	}
+	return comments, nil
+}
+
+func (c *client) CreateComment(org, repo string, number int, body string) (*github.IssueComment, error) {
+	var comment Comment
+	if err := c.request(http.MethodPost, pullRequestPath(org, repo, number)+"/comments", nil, map[string]string{"text": body}, &comment); err != nil {
+		return nil, err
+	}
+	converted := gitHubComment(comment)
+	return &converted, nil
+}
+
+// commentVersion returns the current version of a comment, which has to be
+// passed when changing the comment.
+func (c *client) commentVersion(org, repo string, number, id int) (int, error) {
+	var comment Comment
+	if err := c.request(http.MethodGet, fmt.Sprintf("%s/comments/%d", pullRequestPath(org, repo, number), id), nil, nil, &comment); err != nil {
+		return 0, err
+	}
+	return comment.Version, nil
+}
+
+func (c *client) EditComment(org, repo string, number, id int, body string) error {
+	version, err := c.commentVersion(org, repo, number, id)
+	if err != nil {
+		return err
+	}
+	update := struct {
+		Text    string `json:"text"`
+		Version int    `json:"version"`
+	}{Text: body, Version: version}
+	return c.request(http.MethodPut, fmt.Sprintf("%s/comments/%d", pullRequestPath(org, repo, number), id), nil, update, nil)
+}
+
+func (c *client) DeleteComment(org, repo string, number, id int) error {
+	version, err := c.commentVersion(org, repo, number, id)
+	if err != nil {
+		return err
+	}
+	query := url.Values{"version": []string{strconv.Itoa(version)}}
+	return c.request(http.MethodDelete, fmt.Sprintf("%s/comments/%d", pullRequestPath(org, repo, number), id), query, nil, nil)
+}
+
+func (c *client) ListStatuses(org, repo, sha string) ([]github.Status, error) {
+	statuses, err := listAll[BuildStatus](c, buildStatusPath(sha), nil)
+	if err != nil {
+		return nil, err
+	}
+	var converted []github.Status
+	for _, s := range statuses {
+		converted = append(converted, GitHubStatus(s))
+	}
+	return converted, nil
+}
+
+// CreateStatus sets the build status of the commit. Build statuses are
+// global to the server, so org and repo are not needed.
+func (c *client) CreateStatus(_, _, sha string, status github.Status) error {
+	state := BuildStateInProgress
+	switch status.State {
+	case github.StatusSuccess:
+		state = BuildStateSuccessful
+	case github.StatusError, github.StatusFailure:
+		state = BuildStateFailed
+	}
+	return c.request(http.MethodPost, buildStatusPath(sha), nil, BuildStatus{
+		State:       state,
+		Key:         status.Context,
+		Name:        status.Context,
+		URL:         status.TargetURL,
+		Description: status.Description,
+	}, nil)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestClientRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("expected token to be sent, got %q", auth)
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/activities":
+			if r.URL.Query().Get("start") == "0" {
+				json.NewEncoder(w).Encode(page[Activity]{
+					Values:        []Activity{{ID: 4, Action: ActivityActionCommented, Comment: &Comment{ID: 2, Text: "second"}}, {ID: 3, Action: "APPROVED"}},
+					NextPageStart: 2,
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(page[Activity]{
+				Values:     []Activity{{ID: 1, Action: ActivityActionCommented, Comment: &Comment{ID: 1, Text: "first"}}},
+				IsLastPage: true,
+			})
+		case "/rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/comments/2":
+			if r.Method == http.MethodGet {
+				json.NewEncoder(w).Encode(Comment{ID: 2, Version: 5})
+			}
+		case "/rest/api/1.0/projects/PRJ/repos/repo/branches":
+			json.NewEncoder(w).Encode(page[Branch]{
+				Values:     []Branch{{ID: "refs/heads/main-old", LatestCommit: "old"}, {ID: "refs/heads/main", LatestCommit: "abc"}},
+				IsLastPage: true,
+			})
+		case "/rest/build-status/1.0/commits/abc":
+			if r.Method == http.MethodGet {
+				json.NewEncoder(w).Encode(page[BuildStatus]{
+					Values:     []BuildStatus{{State: BuildStateFailed, Key: "unit", URL: "https://prow"}},
+					IsLastPage: true,
+				})
+			}
+		case "/plugins/servlet/applinks/whoami":
+			w.Write([]byte("prow-bot\n"))
+		default:
+			http.Error(w, `{"errors":[{"message":"Not Found"}]}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewProvider(server.URL+"/", func() []byte { return []byte("token") }, nil, false)
+	comments, err := c.ListComments("PRJ", "repo", 3)
+	if err != nil {
+		t.Fatalf("failed to list comments: %v", err)
+	}
+	if diff := cmp.Diff([]github.IssueComment{{ID: 1, Body: "first", User: github.User{Type: github.UserTypeUser}}, {ID: 2, Body: "second", User: github.User{Type: github.UserTypeUser}}}, comments); diff != "" {
+		t.Errorf("comments differ from expected (-want +got):\n%s", diff)
+	}
+	if err := c.EditComment("PRJ", "repo", 3, 2, "edited"); err != nil {
+		t.Errorf("failed to edit comment: %v", err)
+	}
+	if sha, err := c.GetRef("PRJ", "repo", "heads/main"); err != nil || sha != "abc" {
+		t.Errorf("expected main to be at abc, got %q and %v", sha, err)
+	}
+	if _, err := c.GetRef("PRJ", "repo", "tags/v1"); err == nil {
+		t.Error("expected tags to be unsupported")
+	}
+	statuses, err := c.ListStatuses("PRJ", "repo", "abc")
+	if err != nil {
+		t.Fatalf("failed to list statuses: %v", err)
+	}
+	if diff := cmp.Diff([]github.Status{{State: github.StatusFailure, Context: "unit", TargetURL: "https://prow"}}, statuses); diff != "" {
+		t.Errorf("statuses differ from expected (-want +got):\n%s", diff)
+	}
+	if err := c.CreateStatus("PRJ", "repo", "abc", github.Status{State: github.StatusPending, Context: "unit"}); err != nil {
+		t.Errorf("failed to create status: %v", err)
+	}
+	if login, err := c.BotLogin(); err != nil || login != "prow-bot" {
+		t.Errorf("expected bot login prow-bot, got %q and %v", login, err)
+	}
+	if _, err := c.GetPullRequest("PRJ", "repo", 4); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	dry := NewProvider(server.URL, func() []byte { return []byte("token") }, nil, true)
+	if err := dry.DeleteComment("PRJ", "repo", 3, 2); err != nil {
+		t.Errorf("expected no error in dry run mode, got %v", err)
+	}
+
+	expected := []string{
+		"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/activities?limit=100&start=0",
+		"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/activities?limit=100&start=2",
+		"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/comments/2",
+		"PUT /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/comments/2",
+		"GET /rest/api/1.0/projects/PRJ/repos/repo/branches?filterText=main&limit=100&start=0",
+		"GET /rest/build-status/1.0/commits/abc?limit=100&start=0",
+		"POST /rest/build-status/1.0/commits/abc",
+		"GET /plugins/servlet/applinks/whoami",
+		"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/4",
+		"GET /rest/api/1.0/projects/PRJ/repos/repo/pull-requests/3/comments/2",
+	}
+	if diff := cmp.Diff(expected, requests); diff != "" {
+		t.Errorf("requests differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsCollaborator(t *testing.T) {
+	permissions := map[string][]Permission{
+		"/rest/api/1.0/projects/PRJ/repos/repo/permissions/users": {{User: User{Name: "reader"}, Permission: "REPO_READ"}, {User: User{Name: "writer"}, Permission: "REPO_WRITE"}},
+		"/rest/api/1.0/projects/PRJ/permissions/users":            {{User: User{Name: "admin"}, Permission: "PROJECT_ADMIN"}, {User: User{Name: "reader"}, Permission: "PROJECT_READ"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var matching []Permission
+		for _, p := range permissions[r.URL.Path] {
+			if p.User.Name == r.URL.Query().Get("filter") {
+				matching = append(matching, p)
+			}
+		}
+		json.NewEncoder(w).Encode(page[Permission]{Values: matching, IsLastPage: true})
+	}))
+	defer server.Close()
+	c := NewProvider(server.URL, func() []byte { return []byte("token") }, nil, false)
+
+	testCases := []struct {
+		user                   string
+		collaborator, isMember bool
+	}{
+		{user: "reader", isMember: true},
+		{user: "writer", collaborator: true},
+		{user: "admin", collaborator: true, isMember: true},
+		{user: "stranger"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.user, func(t *testing.T) {
+			collaborator, err := c.IsCollaborator("PRJ", "repo", tc.user)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if collaborator != tc.collaborator {
+				t.Errorf("expected collaborator %t, got %t", tc.collaborator, collaborator)
+			}
+			isMember, err := c.IsMember("PRJ", tc.user)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if isMember != tc.isMember {
+				t.Errorf("expected member %t, got %t", tc.isMember, isMember)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// Event keys sent in the X-Event-Key header, see
+// https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html
+const (
+	EventPullRequestOpened         = "pr:opened"
+	EventPullRequestFromRefUpdated = "pr:from_ref_updated"
+	EventPullRequestToRefUpdated   = "pr:to_ref_updated"
+	EventPullRequestModified       = "pr:modified"
+	EventPullRequestMerged         = "pr:merged"
+	EventPullRequestDeclined       = "pr:declined"
+	EventPullRequestDeleted        = "pr:deleted"
+	EventCommentAdded              = "pr:comment:added"
+	EventCommentEdited             = "pr:comment:edited"
+	EventRefsChanged               = "repo:refs_changed"
+	EventPing                      = "diagnostics:ping"
+)
+
+// PullRequestEvent is the payload of the pr:* webhooks.
+type PullRequestEvent struct {
+	EventKey    string      `json:"eventKey"`
+	Actor       User        `json:"actor"`
+	PullRequest PullRequest `json:"pullRequest"`
+	// PreviousTarget is set by pr:modified if the target branch changed.
+	PreviousTarget *Ref `json:"previousTarget,omitempty"`
+}
+
+// CommentEvent is the payload of the pr:comment:* webhooks.
+type CommentEvent struct {
+	EventKey    string      `json:"eventKey"`
+	Actor       User        `json:"actor"`
+	PullRequest PullRequest `json:"pullRequest"`
+	Comment     Comment     `json:"comment"`
+}
+
+// RefChange is the change of a single ref by a push.
+type RefChange struct {
+	Ref struct {
+		ID        string `json:"id"`
+		DisplayID string `json:"displayId"`
+		Type      string `json:"type"`
+	} `json:"ref"`
+	RefID    string `json:"refId"`
+	FromHash string `json:"fromHash"`
+	ToHash   string `json:"toHash"`
+	Type     string `json:"type"`
+}
+
+// RefsChangedEvent is the payload of the repo:refs_changed webhook.
+type RefsChangedEvent struct {
+	EventKey   string      `json:"eventKey"`
+	Actor      User        `json:"actor"`
+	Repository Repository  `json:"repository"`
+	Changes    []RefChange `json:"changes"`
+}
+
+// GitHubUser converts a Bitbucket Server user into a GitHub user.
+func GitHubUser(u User) github.User {
+	return github.User{
+		Login:   u.Name,
+		Name:    u.DisplayName,
+		Email:   u.EmailAddress,
+		ID:      u.ID,
+		HTMLURL: u.Links.URL(),
+		Type:    github.UserTypeUser,
+	}
+}
+
+// GitHubRepo converts a Bitbucket Server repository into a GitHub repo. Jobs
+// clone the repo from its HTTP clone link.
+func GitHubRepo(r Repository) github.Repo {
+	return github.Repo{
+		Owner:    github.User{Login: r.Project.Key, Name: r.Project.Key},
+		Name:     r.Slug,
+		FullName: r.Project.Key + "/" + r.Slug,
+		HTMLURL:  r.Links.URL(),
+		CloneURI: r.Links.CloneURL(),
+	}
+}
+
+func millis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+// GitHubPullRequest converts a Bitbucket Server pull request into a GitHub
+// pull request.
+func GitHubPullRequest(pr PullRequest) github.PullRequest {
+	converted := github.PullRequest{
+		ID:        pr.ID,
+		Number:    pr.ID,
+		HTMLURL:   pr.Links.URL(),
+		User:      GitHubUser(pr.Author.User),
+		Base:      github.PullRequestBranch{Ref: pr.ToRef.DisplayID, SHA: pr.ToRef.LatestCommit, Repo: GitHubRepo(pr.ToRef.Repository)},
+		Head:      github.PullRequestBranch{Ref: pr.FromRef.DisplayID, SHA: pr.FromRef.LatestCommit, Repo: GitHubRepo(pr.FromRef.Repository)},
+		Title:     pr.Title,
+		Body:      pr.Description,
+		State:     github.PullRequestStateOpen,
+		Draft:     pr.Draft,
+		CreatedAt: millis(pr.CreatedDate),
+		UpdatedAt: millis(pr.UpdatedDate),
+		CloneRef:  fmt.Sprintf("refs/pull-requests/%d/from", pr.ID),
+	}
+	for _, r := range pr.Reviewers {
+		converted.RequestedReviewers = append(converted.RequestedReviewers, GitHubUser(r.User))
+	}
+	switch pr.State {
+	case PullRequestStateMerged:
+		converted.State = github.PullRequestStateClosed
+		converted.Merged = true
+	case PullRequestStateDeclined:
+		converted.State = github.PullRequestStateClosed
+	}
+	return converted
+}
+
+func gitHubChanges(changes []Change) []github.PullRequestChange {
+	var converted []github.PullRequestChange
+	for _, c := range changes {
+		change := github.PullRequestChange{Filename: c.Path.ToString, Status: string(github.PullRequestFileModified)}
+		switch c.Type {
+		case ChangeTypeAdd, ChangeTypeCopy:
+			change.Status = github.PullRequestFileAdded
+		case ChangeTypeDelete:
+			change.Status = github.PullRequestFileRemoved
+		case ChangeTypeMove:
+			change.Status = github.PullRequestFileRenamed
+			if c.SrcPath != nil {
+				change.PreviousFilename = c.SrcPath.ToString
+			}
+		}
+		converted = append(converted, change)
+	}
+	return converted
+}
+
+func gitHubComment(c Comment) github.IssueComment {
+	return github.IssueComment{
+		ID:        c.ID,
+		Body:      c.Text,
+		User:      GitHubUser(c.Author),
+		CreatedAt: millis(c.CreatedDate),
+		UpdatedAt: millis(c.UpdatedDate),
+	}
+}
+
+// GitHubStatus converts the build status of a commit into a GitHub status.
+func GitHubStatus(s BuildStatus) github.Status {
+	state := github.StatusPending
+	switch s.State {
+	case BuildStateSuccessful:
+		state = github.StatusSuccess
+	case BuildStateFailed:
+		state = github.StatusFailure
+	}
+	return github.Status{State: state, TargetURL: s.URL, Description: s.Description, Context: s.Key}
+}
+
+// GitHubPullRequestEvent converts a pull request webhook into the pull
+// request event GitHub would have sent for the same change. It returns false
+// for webhooks without an equivalent.
+func GitHubPullRequestEvent(e PullRequestEvent, guid string) (github.PullRequestEvent, bool) {
+	pr := GitHubPullRequest(e.PullRequest)
+	event := github.PullRequestEvent{
+		Number:      pr.Number,
+		PullRequest: pr,
+		Repo:        pr.Base.Repo,
+		Sender:      GitHubUser(e.Actor),
+		GUID:        guid,
+	}
+	switch e.EventKey {
+	case EventPullRequestOpened:
+		event.Action = github.PullRequestActionOpened
+	case EventPullRequestFromRefUpdated:
+		event.Action = github.PullRequestActionSynchronize
+	case EventPullRequestModified:
+		event.Action = github.PullRequestActionEdited
+		if e.PreviousTarget != nil && e.PreviousTarget.DisplayID != pr.Base.Ref {
+			// Trigger retests pull requests whose base changed.
+			var changes struct {
+				Base struct {
+					Ref struct {
+						From string `json:"from"`
+					} `json:"ref"`
+				} `json:"base"`
+			}
+			changes.Base.Ref.From = e.PreviousTarget.DisplayID
+			event.Changes, _ = json.Marshal(changes)
+		}
+	case EventPullRequestMerged, EventPullRequestDeclined, EventPullRequestDeleted:
+		event.Action = github.PullRequestActionClosed
+	default:
+		return github.PullRequestEvent{}, false
+	}
+	return event, true
+}
+
+// GitHubIssueCommentEvent converts a comment on a pull request into the
+// equivalent GitHub comment.
+func GitHubIssueCommentEvent(e CommentEvent, guid string) github.IssueCommentEvent {
+	action := github.IssueCommentActionCreated
+	if e.EventKey == EventCommentEdited {
+		action = github.IssueCommentActionEdited
+	}
+	pr := GitHubPullRequest(e.PullRequest)
+	return github.IssueCommentEvent{
+		Action: action,
+		Issue: github.Issue{
+			ID:          pr.ID,
+			User:        pr.User,
+			Number:      pr.Number,
+			Title:       pr.Title,
+			State:       pr.State,
+			HTMLURL:     pr.HTMLURL,
+			Body:        pr.Body,
+			CreatedAt:   pr.CreatedAt,
+			UpdatedAt:   pr.UpdatedAt,
+			PullRequest: &struct{}{},
+		},
+		Comment: gitHubComment(e.Comment),
+		Repo:    pr.Base.Repo,
+		GUID:    guid,
+	}
+}
+
+// GitHubPushEvent converts the change of a single ref into a GitHub push
+// event. Bitbucket Server does not send the pushed commits, so the files
+// changed by the push are passed as a single commit.
+func GitHubPushEvent(e RefsChangedEvent, change RefChange, changes []Change, guid string) github.PushEvent {
+	pusher := GitHubUser(e.Actor)
+	pe := github.PushEvent{
+		Ref:     change.Ref.ID,
+		Before:  change.FromHash,
+		After:   change.ToHash,
+		Created: change.Type == ChangeTypeAdd,
+		Deleted: change.Type == ChangeTypeDelete,
+		Pusher:  pusher,
+		Sender:  pusher,
+		Repo:    GitHubRepo(e.Repository),
+		GUID:    guid,
+	}
+	if pe.Ref == "" {
+		pe.Ref = change.RefID
+	}
+	if pe.Deleted {
+		return pe
+	}
+	commit := github.Commit{ID: change.ToHash}
+	for _, c := range changes {
+		switch c.Type {
+		case ChangeTypeAdd, ChangeTypeCopy:
+			commit.Added = append(commit.Added, c.Path.ToString)
+		case ChangeTypeDelete:
+			commit.Removed = append(commit.Removed, c.Path.ToString)
+		case ChangeTypeMove:
+			commit.Added = append(commit.Added, c.Path.ToString)
+			if c.SrcPath != nil {
+				commit.Removed = append(commit.Removed, c.SrcPath.ToString)
+			}
+		default:
+			commit.Modified = append(commit.Modified, c.Path.ToString)
+		}
+	}
+	pe.Commits = []github.Commit{commit}
+	return pe
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"strings"
+)
+
+// Pull request states, see
+// https://developer.atlassian.com/server/bitbucket/rest/v811/api-group-pull-requests/
+const (
+	PullRequestStateOpen     = "OPEN"
+	PullRequestStateDeclined = "DECLINED"
+	PullRequestStateMerged   = "MERGED"
+)
+
+// Build states of commits, see
+// https://developer.atlassian.com/server/bitbucket/rest/v811/api-group-builds-and-deployments/
+const (
+	BuildStateInProgress = "INPROGRESS"
+	BuildStateSuccessful = "SUCCESSFUL"
+	BuildStateFailed     = "FAILED"
+)
+
+// Types of changes to files and refs.
+const (
+	ChangeTypeAdd    = "ADD"
+	ChangeTypeModify = "MODIFY"
+	ChangeTypeDelete = "DELETE"
+	ChangeTypeMove   = "MOVE"
+	ChangeTypeCopy   = "COPY"
+	ChangeTypeUpdate = "UPDATE"
+)
+
+// ActivityActionCommented is the action of pull request activities that
+// carry a comment.
+const ActivityActionCommented = "COMMENTED"
+
+// User is a Bitbucket Server user as embedded in API responses and webhooks.
+// Name is the username, which Prow uses as the login.
+type User struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug,omitempty"`
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	Links        Links  `json:"links,omitempty"`
+}
+
+// Links are the links of an entity. Only the links to the web UI and the
+// clone links of repositories are used.
+type Links struct {
+	Self  []Link `json:"self,omitempty"`
+	Clone []Link `json:"clone,omitempty"`
+}
+
+// Link is a single link. Clone links are named after their protocol.
+type Link struct {
+	Href string `json:"href"`
+	Name string `json:"name,omitempty"`
+}
+
+// URL returns the first link to the web UI, if any.
+func (l Links) URL() string {
+	if len(l.Self) == 0 {
+		return ""
+	}
+	return l.Self[0].Href
+}
+
+// CloneURL returns the HTTP clone link, if any.
+func (l Links) CloneURL() string {
+	for _, link := range l.Clone {
+		if link.Name == "http" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// Project is a Bitbucket Server project, which Prow treats as an org.
+type Project struct {
+	ID   int    `json:"id,omitempty"`
+	Key  string `json:"key"`
+	Name string `json:"name,omitempty"`
+}
+
+// Repository is a repository in a project.
+type Repository struct {
+	ID      int     `json:"id,omitempty"`
+	Slug    string  `json:"slug"`
+	Name    string  `json:"name,omitempty"`
+	Project Project `json:"project"`
+	Links   Links   `json:"links,omitempty"`
+}
+
+// Ref is a ref of a repository, as found on both ends of a pull request.
+type Ref struct {
+	// ID is the fully qualified ref, such as refs/heads/main.
+	ID           string     `json:"id"`
+	DisplayID    string     `json:"displayId"`
+	LatestCommit string     `json:"latestCommit"`
+	Repository   Repository `json:"repository"`
+}
+
+// Participant is the author or a reviewer of a pull request.
+type Participant struct {
+	User     User   `json:"user"`
+	Role     string `json:"role,omitempty"`
+	Approved bool   `json:"approved,omitempty"`
+}
+
+// PullRequest is a Bitbucket Server pull request. Dates are in milliseconds
+// since the epoch.
+type PullRequest struct {
+	ID          int           `json:"id"`
+	Version     int           `json:"version"`
+	Title       string        `json:"title"`
+	Description string        `json:"description,omitempty"`
+	State       string        `json:"state"`
+	Open        bool          `json:"open"`
+	Closed      bool          `json:"closed"`
+	Draft       bool          `json:"draft,omitempty"`
+	CreatedDate int64         `json:"createdDate"`
+	UpdatedDate int64         `json:"updatedDate"`
+	FromRef     Ref           `json:"fromRef"`
+	ToRef       Ref           `json:"toRef"`
+	Author      Participant   `json:"author"`
+	Reviewers   []Participant `json:"reviewers,omitempty"`
+	Links       Links         `json:"links,omitempty"`
+}
+
+// Path is the path of a changed file.
+type Path struct {
+	ToString string `json:"toString"`
+}
+
+// Change is a changed file of a pull request or between two commits.
+type Change struct {
+	Path    Path   `json:"path"`
+	SrcPath *Path  `json:"srcPath,omitempty"`
+	Type    string `json:"type"`
+}
+
+// Comment is a comment on a pull request.
+type Comment struct {
+	ID          int    `json:"id"`
+	Version     int    `json:"version"`
+	Text        string `json:"text"`
+	Author      User   `json:"author"`
+	CreatedDate int64  `json:"createdDate"`
+	UpdatedDate int64  `json:"updatedDate"`
+}
+
+// Activity is an entry of the activity stream of a pull request.
+type Activity struct {
+	ID      int      `json:"id"`
+	Action  string   `json:"action"`
+	Comment *Comment `json:"comment,omitempty"`
+}
+
+// BuildStatus is the status of a build of a commit. Key identifies the build
+// and plays the role of the context of a GitHub status.
+type BuildStatus struct {
+	State       string `json:"state"`
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Branch is a branch of a repository.
+type Branch struct {
+	ID           string `json:"id"`
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+}
+
+// Permission is the permission of a user on a repository or project.
+type Permission struct {
+	User       User   `json:"user"`
+	Permission string `json:"permission"`
+}
+
+// canWrite returns whether the permission allows pushing to repositories.
+func (p Permission) canWrite() bool {
+	return strings.HasSuffix(p.Permission, "_WRITE") || strings.HasSuffix(p.Permission, "_ADMIN")
+}
+
+// page is a page of a paged API response.
+type page[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+// ValidateWebhook ensures that the provided request conforms to the format
+// of a Bitbucket Server webhook and that it was signed with the webhook
+// secret. The returned values mirror github.ValidateWebhook: the event key,
+// the request ID, the payload, whether the webhook is valid and the HTTP
+// status code that was responded with.
+func (p *provider) ValidateWebhook(w http.ResponseWriter, r *http.Request) (string, string, []byte, bool, int) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPost {
+		responseHTTPError(w, http.StatusMethodNotAllowed, "405 Method not allowed")
+		return "", "", nil, false, http.StatusMethodNotAllowed
+	}
+	eventKey := r.Header.Get("X-Event-Key")
+	if eventKey == "" {
+		responseHTTPError(w, http.StatusBadRequest, "400 Bad Request: Missing X-Event-Key Header")
+		return "", "", nil, false, http.StatusBadRequest
+	}
+	requestID := r.Header.Get("X-Request-Id")
+	sig, ok := strings.CutPrefix(r.Header.Get("X-Hub-Signature"), "sha256=")
+	if !ok {
+		responseHTTPError(w, http.StatusForbidden, "403 Forbidden: Missing X-Hub-Signature")
+		return "", "", nil, false, http.StatusForbidden
+	}
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		responseHTTPError(w, http.StatusInternalServerError, "500 Internal Server Error: Failed to read request body")
+		return "", "", nil, false, http.StatusInternalServerError
+	}
+	if !validSignature(payload, sig, p.webhookSecret()) {
+		responseHTTPError(w, http.StatusForbidden, "403 Forbidden: Invalid X-Hub-Signature")
+		return "", "", nil, false, http.StatusForbidden
+	}
+
+	return eventKey, requestID, payload, true, http.StatusOK
+}
+
+func validSignature(payload []byte, sig string, secret []byte) bool {
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ParseWebhook converts a webhook into the GitHub events it is equivalent
+// to. The files changed by pushes are looked up through the API.
+func (p *provider) ParseWebhook(eventKey, guid string, payload []byte) (*scm.Events, error) {
+	events := &scm.Events{}
+	switch {
+	case eventKey == EventCommentAdded || eventKey == EventCommentEdited:
+		var e CommentEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		events.IssueComments = append(events.IssueComments, GitHubIssueCommentEvent(e, guid))
+	case strings.HasPrefix(eventKey, "pr:"):
+		var e PullRequestEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		if pe, ok := GitHubPullRequestEvent(e, guid); ok {
+			events.PullRequests = append(events.PullRequests, pe)
+		}
+	case eventKey == EventRefsChanged:
+		var e RefsChangedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		for _, change := range e.Changes {
+			var changes []Change
+			if change.Type == ChangeTypeUpdate {
+				var err error
+				changes, err = p.compare(e.Repository.Project.Key, e.Repository.Slug, change.FromHash, change.ToHash)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list the files changed by the push to %s: %w", change.RefID, err)
+				}
+			}
+			events.Pushes = append(events.Pushes, GitHubPushEvent(e, change, changes, guid))
+		}
+	}
+	return events, nil
+}
+
+func responseHTTPError(w http.ResponseWriter, statusCode int, response string) {
+	logrus.WithFields(logrus.Fields{
+		"response":    response,
+		"status-code": statusCode,
+	}).Debug(response)
+	http.Error(w, response, statusCode)
+}
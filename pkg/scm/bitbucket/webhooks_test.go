@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/scm"
+)
+
+func sign(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateWebhook(t *testing.T) {
+	const payload = `{"eventKey":"pr:opened"}`
+	testCases := []struct {
+		name      string
+		method    string
+		eventKey  string
+		signature string
+		code      int
+	}{
+		{name: "valid", method: http.MethodPost, eventKey: EventPullRequestOpened, signature: sign(payload, "secret"), code: http.StatusOK},
+		{name: "wrong method", method: http.MethodGet, eventKey: EventPullRequestOpened, signature: sign(payload, "secret"), code: http.StatusMethodNotAllowed},
+		{name: "missing event key", method: http.MethodPost, signature: sign(payload, "secret"), code: http.StatusBadRequest},
+		{name: "missing signature", method: http.MethodPost, eventKey: EventPullRequestOpened, code: http.StatusForbidden},
+		{name: "wrong secret", method: http.MethodPost, eventKey: EventPullRequestOpened, signature: sign(payload, "other"), code: http.StatusForbidden},
+		{name: "malformed signature", method: http.MethodPost, eventKey: EventPullRequestOpened, signature: "sha256=zz", code: http.StatusForbidden},
+	}
+	p := NewProvider("https://bitbucket.example.com", nil, func() []byte { return []byte("secret") }, false)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/hook/bitbucket-server", strings.NewReader(payload))
+			r.Header.Set("X-Request-Id", "guid")
+			if tc.eventKey != "" {
+				r.Header.Set("X-Event-Key", tc.eventKey)
+			}
+			if tc.signature != "" {
+				r.Header.Set("X-Hub-Signature", tc.signature)
+			}
+			w := httptest.NewRecorder()
+			eventKey, guid, body, ok, code := p.ValidateWebhook(w, r)
+			if code != tc.code || w.Code != tc.code {
+				t.Fatalf("expected code %d, got %d and responded with %d", tc.code, code, w.Code)
+			}
+			if ok != (tc.code == http.StatusOK) {
+				t.Fatalf("expected valid %t, got %t", tc.code == http.StatusOK, ok)
+			}
+			if ok && (eventKey != tc.eventKey || guid != "guid" || string(body) != payload) {
+				t.Errorf("unexpected event %q, GUID %q and payload %q", eventKey, guid, body)
+			}
+		})
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	repo := Repository{Slug: "repo", Project: Project{Key: "PRJ"}, Links: Links{Clone: []Link{
+		{Href: "ssh://git@bitbucket.example.com:7999/prj/repo.git", Name: "ssh"},
+		{Href: "https://bitbucket.example.com/scm/prj/repo.git", Name: "http"},
+	}}}
+	pr := PullRequest{
+		ID:      7,
+		Title:   "Fix it",
+		State:   PullRequestStateOpen,
+		FromRef: Ref{ID: "refs/heads/fix", DisplayID: "fix", LatestCommit: "head", Repository: repo},
+		ToRef:   Ref{ID: "refs/heads/main", DisplayID: "main", LatestCommit: "base", Repository: repo},
+		Author:  Participant{User: User{Name: "author"}},
+	}
+	ghRepo := github.Repo{Owner: github.User{Login: "PRJ", Name: "PRJ"}, Name: "repo", FullName: "PRJ/repo", CloneURI: "https://bitbucket.example.com/scm/prj/repo.git"}
+	ghPR := github.PullRequest{
+		ID:       7,
+		Number:   7,
+		User:     github.User{Login: "author", Type: github.UserTypeUser},
+		Base:     github.PullRequestBranch{Ref: "main", SHA: "base", Repo: ghRepo},
+		Head:     github.PullRequestBranch{Ref: "fix", SHA: "head", Repo: ghRepo},
+		Title:    "Fix it",
+		State:    github.PullRequestStateOpen,
+		CloneRef: "refs/pull-requests/7/from",
+	}
+	actor := User{Name: "actor"}
+	ghActor := github.User{Login: "actor", Type: github.UserTypeUser}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/1.0/projects/PRJ/repos/repo/compare/changes" || r.URL.Query().Get("from") != "new" || r.URL.Query().Get("to") != "old" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(page[Change]{
+			Values: []Change{
+				{Path: Path{ToString: "added"}, Type: ChangeTypeAdd},
+				{Path: Path{ToString: "changed"}, Type: ChangeTypeModify},
+				{Path: Path{ToString: "moved"}, SrcPath: &Path{ToString: "original"}, Type: ChangeTypeMove},
+			},
+			IsLastPage: true,
+		})
+	}))
+	defer server.Close()
+
+	marshal := func(v interface{}) []byte {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal payload: %v", err)
+		}
+		return b
+	}
+	mergedPR := pr
+	mergedPR.State = PullRequestStateMerged
+	ghMergedPR := ghPR
+	ghMergedPR.State = github.PullRequestStateClosed
+	ghMergedPR.Merged = true
+	refsChanged := RefsChangedEvent{EventKey: EventRefsChanged, Actor: actor, Repository: repo, Changes: []RefChange{
+		{RefID: "refs/heads/main", FromHash: "old", ToHash: "new", Type: ChangeTypeUpdate},
+		{RefID: "refs/heads/gone", FromHash: "old", ToHash: "0000000000000000000000000000000000000000", Type: ChangeTypeDelete},
+	}}
+	refsChanged.Changes[0].Ref.ID = "refs/heads/main"
+
+	testCases := []struct {
+		name     string
+		eventKey string
+		payload  []byte
+		expected *scm.Events
+	}{
+		{
+			name:     "opened pull request",
+			eventKey: EventPullRequestOpened,
+			payload:  marshal(PullRequestEvent{EventKey: EventPullRequestOpened, Actor: actor, PullRequest: pr}),
+			expected: &scm.Events{PullRequests: []github.PullRequestEvent{{Action: github.PullRequestActionOpened, Number: 7, PullRequest: ghPR, Repo: ghRepo, Sender: ghActor, GUID: "guid"}}},
+		},
+		{
+			name:     "pushed to pull request",
+			eventKey: EventPullRequestFromRefUpdated,
+			payload:  marshal(PullRequestEvent{EventKey: EventPullRequestFromRefUpdated, Actor: actor, PullRequest: pr}),
+			expected: &scm.Events{PullRequests: []github.PullRequestEvent{{Action: github.PullRequestActionSynchronize, Number: 7, PullRequest: ghPR, Repo: ghRepo, Sender: ghActor, GUID: "guid"}}},
+		},
+		{
+			name:     "retargeted pull request",
+			eventKey: EventPullRequestModified,
+			payload:  marshal(PullRequestEvent{EventKey: EventPullRequestModified, Actor: actor, PullRequest: pr, PreviousTarget: &Ref{DisplayID: "release"}}),
+			expected: &scm.Events{PullRequests: []github.PullRequestEvent{{Action: github.PullRequestActionEdited, Number: 7, PullRequest: ghPR, Repo: ghRepo, Sender: ghActor, GUID: "guid", Changes: json.RawMessage(`{"base":{"ref":{"from":"release"}}}`)}}},
+		},
+		{
+			name:     "merged pull request",
+			eventKey: EventPullRequestMerged,
+			payload:  marshal(PullRequestEvent{EventKey: EventPullRequestMerged, Actor: actor, PullRequest: mergedPR}),
+			expected: &scm.Events{PullRequests: []github.PullRequestEvent{{Action: github.PullRequestActionClosed, Number: 7, PullRequest: ghMergedPR, Repo: ghRepo, Sender: ghActor, GUID: "guid"}}},
+		},
+		{
+			name:     "reviewed pull request is ignored",
+			eventKey: "pr:reviewer:approved",
+			payload:  marshal(PullRequestEvent{EventKey: "pr:reviewer:approved", Actor: actor, PullRequest: pr}),
+			expected: &scm.Events{},
+		},
+		{
+			name:     "comment",
+			eventKey: EventCommentAdded,
+			payload:  marshal(CommentEvent{EventKey: EventCommentAdded, Actor: actor, PullRequest: pr, Comment: Comment{ID: 3, Text: "/test all", Author: actor}}),
+			expected: &scm.Events{IssueComments: []github.IssueCommentEvent{{
+				Action:  github.IssueCommentActionCreated,
+				Issue:   github.Issue{ID: 7, User: ghPR.User, Number: 7, Title: "Fix it", State: github.PullRequestStateOpen, PullRequest: &struct{}{}},
+				Comment: github.IssueComment{ID: 3, Body: "/test all", User: ghActor},
+				Repo:    ghRepo,
+				GUID:    "guid",
+			}}},
+		},
+		{
+			name:     "push",
+			eventKey: EventRefsChanged,
+			payload:  marshal(refsChanged),
+			expected: &scm.Events{Pushes: []github.PushEvent{
+				{
+					Ref:     "refs/heads/main",
+					Before:  "old",
+					After:   "new",
+					Commits: []github.Commit{{ID: "new", Added: []string{"added", "moved"}, Removed: []string{"original"}, Modified: []string{"changed"}}},
+					Pusher:  ghActor,
+					Sender:  ghActor,
+					Repo:    ghRepo,
+					GUID:    "guid",
+				},
+				{
+					Ref:     "refs/heads/gone",
+					Before:  "old",
+					After:   "0000000000000000000000000000000000000000",
+					Deleted: true,
+					Pusher:  ghActor,
+					Sender:  ghActor,
+					Repo:    ghRepo,
+					GUID:    "guid",
+				},
+			}},
+		},
+		{
+			name:     "ping",
+			eventKey: EventPing,
+			payload:  []byte(`{"test":true}`),
+			expected: &scm.Events{},
+		},
+	}
+	p := NewProvider(server.URL, func() []byte { return []byte("token") }, nil, false)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := p.ParseWebhook(tc.eventKey, "guid", tc.payload)
+			if err != nil {
+				t.Fatalf("failed to parse webhook: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, events); diff != "" {
+				t.Errorf("events differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
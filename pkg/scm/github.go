@@ -0,0 +1,257 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// gitHubClient serves the GitHub API calls of plugins and crier from a
+// provider. Only the calls of the core CI flow are translated, all others are
+// passed through to the embedded GitHub client and fail for repos that are
+// not on GitHub.
+type gitHubClient struct {
+	github.Client
+	c Client
+
+	lock sync.Mutex
+	used bool
+	// comments maps the IDs of comments, which providers may only address
+	// through their pull request, to the number of the pull request.
+	comments map[int]int
+	// isBot is populated lazily by BotUserChecker.
+	isBot func(string) bool
+}
+
+// NewGitHubClient returns a GitHub client that operates on the pull requests
+// of the provider. Calls that are not translated are passed through to gh,
+// which may be nil if only the translated calls are made. Providers that
+// implement GitHubTranslator return their own translation.
+func NewGitHubClient(c Client, gh github.Client) github.Client {
+	if t, ok := c.(GitHubTranslator); ok {
+		return t.GitHubClient(gh)
+	}
+	return &gitHubClient{Client: gh, c: c, comments: map[int]int{}}
+}
+
+func (c *gitHubClient) mutated() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.used = true
+}
+
+// Used reports whether the client mutated anything on the provider or GitHub.
+func (c *gitHubClient) Used() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.used || (c.Client != nil && c.Client.Used())
+}
+
+func (c *gitHubClient) rememberComment(id, number int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.comments[id] = number
+}
+
+func (c *gitHubClient) commentNumber(id int) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	number, ok := c.comments[id]
+	if !ok {
+		return 0, fmt.Errorf("unknown pull request of comment %d, comments must be listed before they are changed", id)
+	}
+	return number, nil
+}
+
+func (c *gitHubClient) BotUser() (*github.UserData, error) {
+	login, err := c.c.BotLogin()
+	if err != nil {
+		return nil, err
+	}
+	return &github.UserData{Login: login}, nil
+}
+
+func (c *gitHubClient) BotUserChecker() (func(candidate string) bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.isBot == nil {
+		login, err := c.c.BotLogin()
+		if err != nil {
+			return nil, err
+		}
+		login = github.NormLogin(login)
+		c.isBot = func(candidate string) bool {
+			return github.NormLogin(candidate) == login
+		}
+	}
+	return c.isBot, nil
+}
+
+func (c *gitHubClient) BotUserCheckerWithContext(_ context.Context) (func(candidate string) bool, error) {
+	return c.BotUserChecker()
+}
+
+func (c *gitHubClient) IsCollaborator(org, repo, user string) (bool, error) {
+	return c.c.IsCollaborator(org, repo, user)
+}
+
+func (c *gitHubClient) IsMember(org, user string) (bool, error) {
+	return c.c.IsMember(org, user)
+}
+
+func (c *gitHubClient) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	return c.c.GetPullRequest(org, repo, number)
+}
+
+func (c *gitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	return c.c.GetPullRequestChanges(org, repo, number)
+}
+
+// GetIssueLabels returns no labels, the core CI flow does not rely on labels
+// and not all providers have them.
+func (c *gitHubClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
+	return nil, nil
+}
+
+// AddLabel fails, labels are not supported on providers.
+func (c *gitHubClient) AddLabel(org, repo string, number int, label string) error {
+	return fmt.Errorf("cannot add label %q to %s/%s#%d: labels are not supported on this provider", label, org, repo, number)
+}
+
+// RemoveLabel fails, labels are not supported on providers.
+func (c *gitHubClient) RemoveLabel(org, repo string, number int, label string) error {
+	return fmt.Errorf("cannot remove label %q from %s/%s#%d: labels are not supported on this provider", label, org, repo, number)
+}
+
+func (c *gitHubClient) ListIssueComments(org, repo string, number int) ([]github.IssueComment, error) {
+	comments, err := c.c.ListComments(org, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		c.rememberComment(comment.ID, number)
+	}
+	return comments, nil
+}
+
+func (c *gitHubClient) ListIssueCommentsWithContext(_ context.Context, org, repo string, number int) ([]github.IssueComment, error) {
+	return c.ListIssueComments(org, repo, number)
+}
+
+func (c *gitHubClient) CreateComment(org, repo string, number int, comment string) error {
+	c.mutated()
+	created, err := c.c.CreateComment(org, repo, number, comment)
+	if err != nil {
+		return err
+	}
+	c.rememberComment(created.ID, number)
+	return nil
+}
+
+func (c *gitHubClient) CreateCommentWithContext(_ context.Context, org, repo string, number int, comment string) error {
+	return c.CreateComment(org, repo, number, comment)
+}
+
+func (c *gitHubClient) EditComment(org, repo string, id int, comment string) error {
+	number, err := c.commentNumber(id)
+	if err != nil {
+		return err
+	}
+	c.mutated()
+	return c.c.EditComment(org, repo, number, id, comment)
+}
+
+func (c *gitHubClient) EditCommentWithContext(_ context.Context, org, repo string, id int, comment string) error {
+	return c.EditComment(org, repo, id, comment)
+}
+
+func (c *gitHubClient) DeleteComment(org, repo string, id int) error {
+	number, err := c.commentNumber(id)
+	if err != nil {
+		return err
+	}
+	c.mutated()
+	return c.c.DeleteComment(org, repo, number, id)
+}
+
+func (c *gitHubClient) DeleteCommentWithContext(_ context.Context, org, repo string, id int) error {
+	return c.DeleteComment(org, repo, id)
+}
+
+func (c *gitHubClient) DeleteStaleComments(org, repo string, number int, comments []github.IssueComment, isStale func(github.IssueComment) bool) error {
+	// Listing the comments also records their pull request.
+	listed, err := c.ListIssueComments(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to list comments while deleting stale comments. err: %w", err)
+	}
+	if comments == nil {
+		comments = listed
+	}
+	for _, comment := range comments {
+		if isStale(comment) {
+			if err := c.DeleteComment(org, repo, comment.ID); err != nil {
+				return fmt.Errorf("failed to delete stale comment with ID '%d'", comment.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *gitHubClient) GetRef(org, repo, ref string) (string, error) {
+	return c.c.GetRef(org, repo, ref)
+}
+
+func (c *gitHubClient) CreateStatus(org, repo, sha string, status github.Status) error {
+	c.mutated()
+	return c.c.CreateStatus(org, repo, sha, status)
+}
+
+func (c *gitHubClient) CreateStatusWithContext(_ context.Context, org, repo, sha string, status github.Status) error {
+	return c.CreateStatus(org, repo, sha, status)
+}
+
+// GetCombinedStatus combines the commit statuses the way GitHub does: it is
+// failed if any status failed, pending if any is still pending and
+// successful otherwise.
+func (c *gitHubClient) GetCombinedStatus(org, repo, sha string) (*github.CombinedStatus, error) {
+	statuses, err := c.c.ListStatuses(org, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	combined := &github.CombinedStatus{SHA: sha, State: github.StatusSuccess, Statuses: statuses}
+	for _, s := range statuses {
+		switch s.State {
+		case github.StatusFailure, github.StatusError:
+			combined.State = github.StatusFailure
+		case github.StatusPending:
+			if combined.State == github.StatusSuccess {
+				combined.State = github.StatusPending
+			}
+		}
+	}
+	return combined, nil
+}
+
+// GetFailedActionRunsByHeadBranch returns no runs, GitHub Actions only exist
+// on GitHub.
+func (c *gitHubClient) GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]github.WorkflowRun, error) {
+	return nil, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+type fakeClient struct {
+	Client
+	comments map[int][]github.IssueComment
+	statuses []github.Status
+	deleted  []string
+	nextID   int
+}
+
+func (f *fakeClient) BotLogin() (string, error) {
+	return "prow-bot", nil
+}
+
+func (f *fakeClient) ListComments(org, repo string, number int) ([]github.IssueComment, error) {
+	return f.comments[number], nil
+}
+
+func (f *fakeClient) CreateComment(org, repo string, number int, body string) (*github.IssueComment, error) {
+	f.nextID++
+	comment := github.IssueComment{ID: f.nextID, Body: body}
+	f.comments[number] = append(f.comments[number], comment)
+	return &comment, nil
+}
+
+func (f *fakeClient) DeleteComment(org, repo string, number, id int) error {
+	f.deleted = append(f.deleted, fmt.Sprintf("%s/%s#%d:%d", org, repo, number, id))
+	return nil
+}
+
+func (f *fakeClient) ListStatuses(org, repo, sha string) ([]github.Status, error) {
+	return f.statuses, nil
+}
+
+func TestGitHubClientComments(t *testing.T) {
+	fake := &fakeClient{comments: map[int][]github.IssueComment{3: {{ID: 1, Body: "stale"}, {ID: 2, Body: "fresh"}}}, nextID: 10}
+	c := NewGitHubClient(fake, nil)
+
+	if err := c.DeleteComment("PRJ", "repo", 1); err == nil {
+		t.Error("expected deleting a comment that was not listed to fail")
+	}
+	if err := c.DeleteStaleComments("PRJ", "repo", 3, nil, func(c github.IssueComment) bool { return c.Body == "stale" }); err != nil {
+		t.Fatalf("failed to delete stale comments: %v", err)
+	}
+	if err := c.CreateComment("PRJ", "repo", 4, "new"); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+	if err := c.DeleteComment("PRJ", "repo", 11); err != nil {
+		t.Fatalf("failed to delete created comment: %v", err)
+	}
+	if diff := cmp.Diff([]string{"PRJ/repo#3:1", "PRJ/repo#4:11"}, fake.deleted); diff != "" {
+		t.Errorf("deleted comments differ from expected (-want +got):\n%s", diff)
+	}
+	if !c.Used() {
+		t.Error("expected client to be used")
+	}
+
+	isBot, err := c.BotUserChecker()
+	if err != nil {
+		t.Fatalf("failed to get bot user checker: %v", err)
+	}
+	if !isBot("Prow-Bot") || isBot("someone") {
+		t.Error("expected only prow-bot to be the bot")
+	}
+}
+
+func TestGitHubClientCombinedStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		statuses []github.Status
+		expected string
+	}{
+		{name: "no statuses", expected: github.StatusSuccess},
+		{name: "all successful", statuses: []github.Status{{State: github.StatusSuccess}, {State: github.StatusSuccess}}, expected: github.StatusSuccess},
+		{name: "pending", statuses: []github.Status{{State: github.StatusSuccess}, {State: github.StatusPending}}, expected: github.StatusPending},
+		{name: "failed", statuses: []github.Status{{State: github.StatusFailure}, {State: github.StatusPending}}, expected: github.StatusFailure},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewGitHubClient(&fakeClient{statuses: tc.statuses}, nil)
+			combined, err := c.GetCombinedStatus("PRJ", "repo", "abc")
+			if err != nil {
+				t.Fatalf("failed to get combined status: %v", err)
+			}
+			if combined.State != tc.expected {
+				t.Errorf("expected state %q, got %q", tc.expected, combined.State)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scm abstracts the source code management systems that host repos
+// served by Prow besides GitHub. Providers translate their webhooks into the
+// GitHub events that plugins handle, and implement the API calls of the core
+// CI flow: triggering jobs on pull requests and reporting their statuses.
+package scm
+
+import (
+	"net/http"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// Client is the API of a provider that the core CI flow uses. Orgs and
+// repos identify the repos the way the provider does, e.g. the project key
+// and the repo slug, and pull requests are identified by their number within
+// the repo.
+type Client interface {
+	// BotLogin returns the login of the user the client authenticates as.
+	BotLogin() (string, error)
+	// IsCollaborator returns whether the user may push to the repo.
+	IsCollaborator(org, repo, user string) (bool, error)
+	// IsMember returns whether the user has access to the org.
+	IsMember(org, user string) (bool, error)
+
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	// GetRef resolves a ref, e.g. heads/main, to its commit.
+	GetRef(org, repo, ref string) (string, error)
+
+	// ListComments returns the comments of the pull request, oldest first.
+	ListComments(org, repo string, number int) ([]github.IssueComment, error)
+	CreateComment(org, repo string, number int, body string) (*github.IssueComment, error)
+	EditComment(org, repo string, number, id int, body string) error
+	DeleteComment(org, repo string, number, id int) error
+
+	// ListStatuses returns the latest status of every context of the commit.
+	ListStatuses(org, repo, sha string) ([]github.Status, error)
+	CreateStatus(org, repo, sha string, status github.Status) error
+}
+
+// Events are the GitHub events that a webhook of a provider translates to.
+type Events struct {
+	PullRequests  []github.PullRequestEvent
+	Reviews       []github.ReviewEvent
+	IssueComments []github.IssueCommentEvent
+	Pushes        []github.PushEvent
+}
+
+// Provider serves the repos hosted on a source code management system.
+type Provider interface {
+	Client
+	// Name identifies the provider, e.g. in the `scm` section of the Prow
+	// config and in the path its webhooks are served on.
+	Name() string
+	// ValidateWebhook ensures that the request is an authentic webhook of
+	// the provider. The returned values mirror github.ValidateWebhook: the
+	// event type, the delivery ID, the payload, whether the webhook is valid
+	// and the HTTP status code that was responded with.
+	ValidateWebhook(w http.ResponseWriter, r *http.Request) (string, string, []byte, bool, int)
+	// ParseWebhook translates a validated webhook into GitHub events. It may
+	// call the API for details the webhook lacks.
+	ParseWebhook(eventType, eventGUID string, payload []byte) (*Events, error)
+}
+
+// GitHubTranslator is implemented by providers that translate more GitHub API
+// calls than those of Client, e.g. the ones of the labeling plugins.
+type GitHubTranslator interface {
+	// GitHubClient returns a GitHub client that operates on the repos of the
+	// provider and passes the calls it does not translate through to gh.
+	GitHubClient(gh github.Client) github.Client
+}
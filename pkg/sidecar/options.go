@@ -21,6 +21,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/prow/pkg/gcsupload"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
@@ -75,6 +76,16 @@ type Options struct {
 	// taken by `sidecar` to upload all relevant artifacts.
 	IgnoreInterrupts bool `json:"ignore_interrupts,omitempty"`
 
+	// LogStreamInterval makes the process upload the build logs in chunks at
+	// this interval while the test runs. The chunks are censored like the logs
+	// uploaded at the end. Zero disables streaming.
+	LogStreamInterval time.Duration `json:"log_stream_interval,omitempty"`
+
+	// ResourceMetricsInterval makes the process record the CPU and memory
+	// usage of the containers of the pod at this interval while the test runs
+	// and upload it with the logs. Zero disables recording.
+	ResourceMetricsInterval time.Duration `json:"resource_metrics_interval,omitempty"`
+
 	// WriteMemoryProfile makes the program write a memory profile periodically while
 	// it runs. Use the sigs.k8s.io/prow/hack/analyze-memory-profiles.py script to
 	// load the data into time series and plot it for analysis.
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+)
+
+const (
+	// ResourceUsageFile is the name the recorded resource usage is uploaded as.
+	ResourceUsageFile = "resource-usage.json"
+
+	// PodNameEnv and PodNamespaceEnv identify the pod whose resource usage
+	// is recorded. They are set from the downward API.
+	PodNameEnv      = "POD_NAME"
+	PodNamespaceEnv = "POD_NAMESPACE"
+
+	// maxResourceSamples bounds the number of samples kept per container.
+	// Once reached, every other sample is dropped.
+	maxResourceSamples = 2000
+)
+
+// ResourceUsage is the resource usage of the containers of a pod over the
+// runtime of the test.
+type ResourceUsage struct {
+	Containers []ContainerUsage `json:"containers"`
+}
+
+// ContainerUsage holds the samples recorded for a container.
+type ContainerUsage struct {
+	Name    string           `json:"name"`
+	Samples []ResourceSample `json:"samples"`
+}
+
+// ResourceSample is the resource usage of a container at a point in time.
+type ResourceSample struct {
+	Time time.Time `json:"time"`
+	// CPU is the CPU usage in cores.
+	CPU float64 `json:"cpu"`
+	// Memory is the working set in bytes.
+	Memory uint64 `json:"memory"`
+}
+
+// podMetrics is the part of the PodMetrics of the metrics.k8s.io API that is
+// recorded.
+type podMetrics struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// resourceRecorder records the resource usage of the containers of a pod
+// from its PodMetrics.
+type resourceRecorder struct {
+	client *http.Client
+	url    string
+
+	lock  sync.Mutex
+	usage map[string]*ContainerUsage
+}
+
+// newResourceRecorder returns a recorder for the pod identified by the
+// environment, which reads the PodMetrics of the pod from the metrics.k8s.io
+// API. This only requires the service account of the pod to be allowed to get
+// the PodMetrics of its own namespace.
+func newResourceRecorder() (*resourceRecorder, error) {
+	pod, namespace := os.Getenv(PodNameEnv), os.Getenv(PodNamespaceEnv)
+	if pod == "" || namespace == "" {
+		return nil, fmt.Errorf("%s and %s must be set", PodNameEnv, PodNamespaceEnv)
+	}
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not load in-cluster config: %w", err)
+	}
+	client, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create client: %w", err)
+	}
+	return &resourceRecorder{
+		client: client,
+		url:    fmt.Sprintf("%s/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods/%s", cfg.Host, url.PathEscape(namespace), url.PathEscape(pod)),
+		usage:  map[string]*ContainerUsage{},
+	}, nil
+}
+
+// record adds a sample for every container of the pod.
+func (r *resourceRecorder) record(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not get pod metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("could not get pod metrics: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	var metrics podMetrics
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return fmt.Errorf("could not decode pod metrics: %w", err)
+	}
+	if metrics.Timestamp.IsZero() {
+		return errors.New("the pod metrics have no timestamp")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, container := range metrics.Containers {
+		cpu, hasCPU := container.Usage[corev1.ResourceCPU]
+		memory, hasMemory := container.Usage[corev1.ResourceMemory]
+		if !hasCPU || !hasMemory {
+			// No metrics were collected for the container yet.
+			continue
+		}
+		usage, ok := r.usage[container.Name]
+		if !ok {
+			usage = &ContainerUsage{Name: container.Name}
+			r.usage[container.Name] = usage
+		}
+		if n := len(usage.Samples); n > 0 && !metrics.Timestamp.After(usage.Samples[n-1].Time) {
+			// No new metrics were collected since the last sample.
+			continue
+		}
+		if len(usage.Samples) >= maxResourceSamples {
+			thinned := usage.Samples[:0]
+			for i := 0; i < len(usage.Samples); i += 2 {
+				thinned = append(thinned, usage.Samples[i])
+			}
+			usage.Samples = thinned
+		}
+		usage.Samples = append(usage.Samples, ResourceSample{
+			Time:   metrics.Timestamp,
+			CPU:    cpu.AsApproximateFloat64(),
+			Memory: uint64(memory.Value()),
+		})
+	}
+	return nil
+}
+
+// run records the resource usage every interval until the context is
+// cancelled.
+func (r *resourceRecorder) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.record(ctx); err != nil && ctx.Err() == nil {
+			logrus.WithError(err).Warn("Could not record resource usage.")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// snapshot returns the resource usage recorded so far, sorted by container.
+func (r *resourceRecorder) snapshot() ResourceUsage {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	usage := ResourceUsage{Containers: []ContainerUsage{}}
+	for _, container := range r.usage {
+		usage.Containers = append(usage.Containers, ContainerUsage{
+			Name:    container.Name,
+			Samples: append([]ResourceSample(nil), container.Samples...),
+		})
+	}
+	sort.Slice(usage.Containers, func(i, j int) bool {
+		return usage.Containers[i].Name < usage.Containers[j].Name
+	})
+	return usage
+}
+
+// addTo adds the recorded resource usage to the readers that are uploaded.
+// It does nothing if the resource usage is not recorded.
+func (r *resourceRecorder) addTo(readerFuncs map[string]gcs.ReaderFunc) {
+	if r == nil {
+		return
+	}
+	data, err := json.Marshal(r.snapshot())
+	if err != nil {
+		logrus.WithError(err).Warn("Could not marshal resource usage.")
+		return
+	}
+	readerFuncs[ResourceUsageFile] = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+}
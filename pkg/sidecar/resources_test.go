@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+)
+
+func TestResourceRecorder(t *testing.T) {
+	first := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	second := first.Add(15 * time.Second)
+	metrics := func(at time.Time, cpu, memory string) string {
+		return fmt.Sprintf(`{"kind": "PodMetrics", "apiVersion": "metrics.k8s.io/v1beta1",
+  "metadata": {"name": "job", "namespace": "test-pods"}, "timestamp": %q, "window": "15s",
+  "containers": [
+    {"name": "test", "usage": {"cpu": %q, "memory": %q}},
+    {"name": "sidecar", "usage": {"cpu": "1000000n", "memory": "1Ki"}},
+    {"name": "starting", "usage": {"cpu": "0"}}
+  ]
+}`, at.Format(time.RFC3339), cpu, memory)
+	}
+	responses := []string{
+		metrics(first, "500m", "1Gi"),
+		// No new metrics were collected.
+		metrics(first, "500m", "1Gi"),
+		metrics(second, "2", "2Gi"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/metrics.k8s.io/v1beta1/namespaces/test-pods/pods/job" {
+			http.NotFound(w, r)
+			return
+		}
+		if len(responses) == 0 {
+			http.Error(w, "gone", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, responses[0])
+		responses = responses[1:]
+	}))
+	defer server.Close()
+
+	recorder := &resourceRecorder{
+		client: server.Client(),
+		url:    server.URL + "/apis/metrics.k8s.io/v1beta1/namespaces/test-pods/pods/job",
+		usage:  map[string]*ContainerUsage{},
+	}
+	for i := 0; i < 3; i++ {
+		if err := recorder.record(context.Background()); err != nil {
+			t.Fatalf("recording %d failed: %v", i, err)
+		}
+	}
+	if err := recorder.record(context.Background()); err == nil {
+		t.Error("expected an error when the metrics are unavailable")
+	}
+
+	readers := map[string]gcs.ReaderFunc{}
+	recorder.addTo(readers)
+	reader, err := readers[ResourceUsageFile]()
+	if err != nil {
+		t.Fatalf("could not read resource usage: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("could not read resource usage: %v", err)
+	}
+	var actual ResourceUsage
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("could not unmarshal resource usage: %v", err)
+	}
+	expected := ResourceUsage{Containers: []ContainerUsage{
+		{
+			Name: "sidecar",
+			Samples: []ResourceSample{
+				{Time: first, CPU: 0.001, Memory: 1024},
+				{Time: second, CPU: 0.001, Memory: 1024},
+			},
+		},
+		{
+			Name: "test",
+			Samples: []ResourceSample{
+				{Time: first, CPU: 0.5, Memory: 1 << 30},
+				{Time: second, CPU: 2, Memory: 2 << 30},
+			},
+		},
+	}}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("resource usage differs from expected (-want +got):\n%s", diff)
+	}
+
+	var disabled *resourceRecorder
+	disabled.addTo(readers)
+	if len(readers) != 1 {
+		t.Errorf("expected a disabled recorder to add nothing, got %d readers", len(readers))
+	}
+}
@@ -103,6 +103,14 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 
 	ctx, cancel := context.WithCancel(ctx)
 
+	var recorder *resourceRecorder
+	if o.ResourceMetricsInterval > 0 {
+		if recorder, err = newResourceRecorder(); err != nil {
+			logrus.WithError(err).Warn("Not recording resource usage.")
+			recorder = nil
+		}
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -123,6 +131,7 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 				o.preUpload()
 
 				buildLogs := logReadersFuncs(entries)
+				recorder.addTo(buildLogs)
 				metadata := combineMetadata(entries)
 
 				// perform best-effort upload
@@ -137,9 +146,28 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 		}
 	}()
 
+	streamed := make(chan struct{})
+	go func() {
+		defer close(streamed)
+		if o.LogStreamInterval > 0 {
+			o.streamLogs(ctx, spec, entries)
+		}
+	}()
+
+	recorded := make(chan struct{})
+	go func() {
+		defer close(recorded)
+		if recorder != nil {
+			recorder.run(ctx, o.ResourceMetricsInterval)
+		}
+	}()
+
 	passed, aborted, failures := wait(ctx, entries)
 
 	cancel()
+	// Stop streaming before the logs are censored and uploaded as a whole.
+	<-streamed
+	<-recorded
 	// If we are being asked to terminate by the kubelet but we have
 	// seen the test process exit cleanly, we need a chance to upload
 	// artifacts to GCS. The only valid way for this program to exit
@@ -150,30 +178,41 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 	o.preUpload()
 
 	buildLogs := logReadersFuncs(entries)
+	recorder.addTo(buildLogs)
 	metadata := combineMetadata(entries)
 	return failures, o.doUpload(context.Background(), spec, passed, aborted, metadata, buildLogs, logFile, &once)
 }
 
 const errorKey = "sidecar-errors"
 
+// logPaths maps the names the build logs of the entries are uploaded as to
+// their paths.
+func logPaths(entries []wrapper.Options) map[string]string {
+	paths := make(map[string]string)
+	for _, opt := range entries {
+		buildLog := "build-log.txt"
+		if len(entries) > 1 {
+			buildLog = fmt.Sprintf("%s-build-log.txt", opt.ContainerName)
+		}
+		paths[buildLog] = opt.ProcessLog
+	}
+	return paths
+}
+
 func logReadersFuncs(entries []wrapper.Options) map[string]gcs.ReaderFunc {
 	readerFuncs := make(map[string]gcs.ReaderFunc)
-	for _, opt := range entries {
-		opt := opt
+	for buildLog, processLog := range logPaths(entries) {
+		processLog := processLog
 		f := func() (io.ReadCloser, error) {
-			log, err := os.Open(opt.ProcessLog)
+			log, err := os.Open(processLog)
 			if err != nil {
-				logrus.WithError(err).Errorf("Failed to open %s", opt.ProcessLog)
-				r := strings.NewReader(fmt.Sprintf("Failed to open %s: %v\n", opt.ProcessLog, err))
+				logrus.WithError(err).Errorf("Failed to open %s", processLog)
+				r := strings.NewReader(fmt.Sprintf("Failed to open %s: %v\n", processLog, err))
 				return io.NopCloser(r), nil
 			} else {
 				return log, nil
 			}
 		}
-		buildLog := "build-log.txt"
-		if len(entries) > 1 {
-			buildLog = fmt.Sprintf("%s-build-log.txt", opt.ContainerName)
-		}
 		readerFuncs[buildLog] = f
 	}
 	return readerFuncs
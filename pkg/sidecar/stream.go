@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+	"sigs.k8s.io/prow/pkg/secretutil"
+)
+
+// maxChunkSize is the maximum number of bytes read from a log for one chunk.
+const maxChunkSize = 64 * 1024 * 1024
+
+// logStreamer cuts a growing log file into chunks.
+//
+// When censoring, the last bytes read are held back until more of the log is
+// read, as they may be the beginning of a secret. The held back bytes and the
+// same number of bytes before them are censored again with the next chunk, so
+// that secrets spanning the end of a chunk are censored as a whole.
+type logStreamer struct {
+	path     string
+	censorer secretutil.Censorer
+	holdback int
+
+	// offset is the number of bytes read from the log.
+	offset int64
+	// window holds the read bytes that were not cut into a chunk yet,
+	// preceded by up to holdback bytes that were.
+	window []byte
+	// cut is the number of bytes at the start of window that were already
+	// cut into a chunk.
+	cut int
+}
+
+func newLogStreamer(path string, censorer secretutil.Censorer, holdback int) *logStreamer {
+	return &logStreamer{path: path, censorer: censorer, holdback: holdback}
+}
+
+// next returns the next chunk of the log, which is empty if nothing new
+// can be uploaded yet.
+func (s *logStreamer) next() ([]byte, error) {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		// The test has not started writing yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(s.offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("could not seek log: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(f, maxChunkSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not read log: %w", err)
+	}
+	s.offset += int64(len(data))
+	s.window = append(s.window, data...)
+
+	end := len(s.window) - s.holdback
+	if end <= s.cut {
+		return nil, nil
+	}
+	censored := bytes.Clone(s.window)
+	if s.censorer != nil {
+		s.censorer.Censor(&censored)
+	}
+	chunk := censored[s.cut:end]
+
+	keep := max(end-s.holdback, 0)
+	s.window = bytes.Clone(s.window[keep:])
+	s.cut = end - keep
+	return chunk, nil
+}
+
+// streamCensorer censors the streamed logs with the secrets currently in the
+// secret directories. Secrets can appear while the test runs, e.g. the
+// entrypoint writes the secrets it fetched from Vault before starting the
+// test, so they are reloaded before the next chunks are cut.
+type streamCensorer struct {
+	options  *CensoringOptions
+	censorer *secretutil.ReloadingCensorer
+}
+
+// reload loads the secrets again and makes the streamers hold back enough
+// bytes for the largest of them.
+func (c *streamCensorer) reload(streamers ...*logStreamer) error {
+	secrets, err := loadSecrets(c.options.SecretDirectories, c.options.IniFilenames)
+	if err != nil {
+		return err
+	}
+	c.censorer.RefreshBytes(secrets...)
+	for _, streamer := range streamers {
+		streamer.holdback = c.censorer.LargestSecret()
+	}
+	return nil
+}
+
+// streamedLog uploads the chunks of a log under consecutive names.
+type streamedLog struct {
+	name     string
+	streamer *logStreamer
+	index    int
+	// pending is cut from the log but not uploaded yet.
+	pending []byte
+}
+
+// streamLogs uploads the logs of the entries in chunks every interval until
+// the context is cancelled.
+func (o Options) streamLogs(ctx context.Context, spec *downwardapi.JobSpec, entries []wrapper.Options) {
+	var censorer *streamCensorer
+	var c secretutil.Censorer
+	if o.CensoringOptions != nil {
+		censorer = &streamCensorer{options: o.CensoringOptions, censorer: secretutil.NewCensorer()}
+		c = censorer.censorer
+	}
+	var logs []*streamedLog
+	var streamers []*logStreamer
+	for name, path := range logPaths(entries) {
+		streamer := newLogStreamer(path, c, 0)
+		streamers = append(streamers, streamer)
+		logs = append(logs, &streamedLog{name: name, streamer: streamer})
+	}
+	if censorer != nil {
+		if err := censorer.reload(streamers...); err != nil {
+			// Never stream logs that cannot be censored.
+			logrus.WithError(err).Error("Could not load secrets, not streaming logs.")
+			return
+		}
+	}
+
+	ticker := time.NewTicker(o.LogStreamInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if censorer != nil {
+			if err := censorer.reload(streamers...); err != nil {
+				// The chunks are cut once the secrets can be loaded again.
+				logrus.WithError(err).Warn("Could not reload secrets, not streaming logs until they can be loaded.")
+				continue
+			}
+		}
+		uploadTargets := map[string]gcs.UploadFunc{}
+		var uploaded []*streamedLog
+		for _, log := range logs {
+			chunk, err := log.streamer.next()
+			if err != nil {
+				logrus.WithError(err).WithField("log", log.name).Warn("Could not read the next chunk of the log.")
+				continue
+			}
+			log.pending = append(log.pending, chunk...)
+			if len(log.pending) == 0 {
+				continue
+			}
+			data := log.pending
+			uploadTargets[gcs.LogChunkName(log.name, log.index)] = gcs.DataUpload(func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			})
+			uploaded = append(uploaded, log)
+		}
+		if len(uploadTargets) == 0 {
+			continue
+		}
+		if err := o.GcsOptions.UploadExtra(ctx, spec, uploadTargets); err != nil {
+			// The pending chunks are uploaded with the next ones.
+			logrus.WithError(err).Warn("Failed to upload log chunks.")
+			continue
+		}
+		for _, log := range uploaded {
+			log.index++
+			log.pending = nil
+		}
+	}
+}
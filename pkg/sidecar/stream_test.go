@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/secretutil"
+)
+
+func TestLogStreamer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		secrets  []string
+		writes   []string
+		expected []string
+	}{
+		{
+			name:     "no censoring streams everything",
+			writes:   []string{"first\n", "", "second\n"},
+			expected: []string{"first\n", "", "second\n"},
+		},
+		{
+			name:     "secrets within a chunk are censored",
+			secrets:  []string{"secret"},
+			writes:   []string{"the secret is out\n", "and more\n"},
+			expected: []string{"the XXXXXX", " is out\na"},
+		},
+		{
+			name:     "secrets spanning chunks are censored",
+			secrets:  []string{"secret"},
+			writes:   []string{"a sec", "ret b", "c\n", "end of the log\n"},
+			expected: []string{"", "a ", "XX", "XXXX bc\nend of "},
+		},
+		{
+			name:     "nothing is streamed until the log exists",
+			secrets:  []string{"secret"},
+			writes:   nil,
+			expected: []string{""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "build-log.txt")
+			var censorer secretutil.Censorer
+			var holdback int
+			if tc.secrets != nil {
+				c := secretutil.NewCensorer()
+				c.Refresh(tc.secrets...)
+				censorer, holdback = c, c.LargestSecret()
+			}
+			streamer := newLogStreamer(path, censorer, holdback)
+
+			var chunks []string
+			next := func() {
+				chunk, err := streamer.next()
+				if err != nil {
+					t.Fatalf("failed to read the next chunk: %v", err)
+				}
+				chunks = append(chunks, string(chunk))
+			}
+			if tc.writes == nil {
+				next()
+			}
+			for _, write := range tc.writes {
+				f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+				if err != nil {
+					t.Fatalf("failed to open log: %v", err)
+				}
+				if _, err := f.WriteString(write); err != nil {
+					t.Fatalf("failed to write log: %v", err)
+				}
+				if err := f.Close(); err != nil {
+					t.Fatalf("failed to close log: %v", err)
+				}
+				next()
+			}
+			if diff := cmp.Diff(tc.expected, chunks); diff != "" {
+				t.Errorf("chunks differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamCensorerReloadsSecrets(t *testing.T) {
+	secretDir, logDir := t.TempDir(), t.TempDir()
+	path := filepath.Join(logDir, "build-log.txt")
+	censorer := &streamCensorer{
+		options:  &CensoringOptions{SecretDirectories: []string{secretDir}},
+		censorer: secretutil.NewCensorer(),
+	}
+	streamer := newLogStreamer(path, censorer.censorer, 0)
+	if err := censorer.reload(streamer); err != nil {
+		t.Fatalf("failed to load secrets: %v", err)
+	}
+
+	// The entrypoint writes the secrets fetched from Vault before it starts
+	// the test, which then writes them to its log.
+	if err := os.WriteFile(filepath.Join(secretDir, "token"), []byte("vault-token"), 0644); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("using vault-token now\n"+strings.Repeat(".", 32)), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	if err := censorer.reload(streamer); err != nil {
+		t.Fatalf("failed to reload secrets: %v", err)
+	}
+	chunk, err := streamer.next()
+	if err != nil {
+		t.Fatalf("failed to read the next chunk: %v", err)
+	}
+	if expected := "using XXXXXXXXXXX now\n"; !strings.HasPrefix(string(chunk), expected) {
+		t.Errorf("expected chunk to start with %q, got %q", expected, string(chunk))
+	}
+	if streamer.holdback != censorer.censorer.LargestSecret() {
+		t.Errorf("expected the streamer to hold back %d bytes, got %d", censorer.censorer.LargestSecret(), streamer.holdback)
+	}
+}
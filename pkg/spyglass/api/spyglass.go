@@ -62,6 +62,13 @@ type Artifact interface {
 	UpdateMetadata(map[string]string) error
 }
 
+// LiveArtifact is an artifact that may still grow, like the build log of a running job
+type LiveArtifact interface {
+	Artifact
+	// Live returns whether more bytes may be appended to the artifact
+	Live() bool
+}
+
 // RequestAction defines the action for a request
 type RequestAction string
 
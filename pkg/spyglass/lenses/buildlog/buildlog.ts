@@ -54,6 +54,48 @@ interface ArtifactRequest {
   startLine: number;
   top?: number;
   saveEnd?: number;
+  tail?: boolean;
+}
+
+// How often the logs of running jobs are polled for new lines.
+const tailInterval = 10 * 1000;
+
+// tailLog appends the lines written to a live log since the last poll, and
+// keeps polling until the complete log is available.
+async function tailLog(container: HTMLDivElement): Promise<void> {
+  const {artifact, offset, startLine} = container.dataset;
+  const r: ArtifactRequest = {
+    artifact,
+    offset: Number(offset),
+    startLine: Number(startLine),
+    tail: true,
+  };
+  interface TailResponse {
+    content: string;
+    offset: number;
+    startLine: number;
+    live: boolean;
+  }
+  let result: TailResponse;
+  try {
+    result = JSON.parse(await spyglass.request(JSON.stringify(r)));
+  } catch (err) {
+    console.log("Failed to tail log", err);
+    setTimeout(() => tailLog(container), tailInterval);
+    return;
+  }
+  if (result.content) {
+    container.insertAdjacentHTML('beforeend', ansiToHTML(result.content));
+    fixLinks(container);
+    spyglass.contentUpdated();
+  }
+  container.dataset.offset = String(result.offset);
+  container.dataset.startLine = String(result.startLine);
+  if (!result.live) {
+    container.classList.remove("live");
+    return;
+  }
+  setTimeout(() => tailLog(container), tailInterval);
 }
 
 async function replaceElementWithContent(element: HTMLDivElement, top: number, bottom: number) {
@@ -140,8 +182,14 @@ async function handleShowAll(this: HTMLButtonElement) {
   }
 
   const {artifact} = this.dataset;
-  const content = await spyglass.request(JSON.stringify({artifact, offset: 0, length: -1}));
-  document.getElementById(`${artifact}-content`)!.innerHTML = `<tbody class="shown">${ansiToHTML(content)}</tbody>`;
+  const container = document.getElementById(`${artifact}-content`)!;
+  let length = -1;
+  if (container.classList.contains("live")) {
+    // Only show the lines tailed so far, new ones are appended by tailLog.
+    length = Math.max(Number(container.dataset.offset) - 1, 0);
+  }
+  const content = await spyglass.request(JSON.stringify({artifact, offset: 0, length}));
+  container.innerHTML = `<tbody class="shown">${ansiToHTML(content)}</tbody>`;
   spyglass.contentUpdated();
 }
 
@@ -420,5 +468,9 @@ window.addEventListener('load', () => {
   }
   fixLinks(document.documentElement);
 
+  for (const container of Array.from(document.querySelectorAll<HTMLDivElement>('.loglines.live'))) {
+    setTimeout(() => tailLog(container), tailInterval);
+  }
+
   handleHash();
 });
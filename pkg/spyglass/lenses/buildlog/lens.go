@@ -37,6 +37,7 @@ import (
 	pkgio "sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
 	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/stacktrace"
 )
 
 const (
@@ -54,6 +55,16 @@ type config struct {
 	HideRawLog         bool             `json:"hide_raw_log,omitempty"`
 	Highlighter        *highlightConfig `json:"highlighter,omitempty"`
 	HighlightLengthMax *int             `json:"highlight_line_length_max,omitempty"`
+	// LinkStackTraces links Go, Python and Java stack trace frames to the
+	// source at the tested revision. Requires prowjob.json in the lens'
+	// optional_files.
+	LinkStackTraces bool `json:"link_stack_traces,omitempty"`
+	// JavaSourceRoot is the directory Java frames are resolved against.
+	// Defaults to src/main/java.
+	JavaSourceRoot string `json:"java_source_root,omitempty"`
+	// JavaPackages are the Java packages of the repository. Only frames of
+	// classes in them or their subpackages are linked.
+	JavaPackages []string `json:"java_packages,omitempty"`
 }
 
 type highlightConfig struct {
@@ -72,6 +83,9 @@ type parsedConfig struct {
 	showRawLog         bool
 	highlighter        *highlightConfig
 	highlightLengthMax int
+	linkStackTraces    bool
+	javaSourceRoot     string
+	javaPackages       []string
 }
 
 var _ api.Lens = Lens{}
@@ -105,6 +119,7 @@ func init() {
 type SubLine struct {
 	Highlighted bool
 	Text        string
+	Link        string
 }
 
 // LogLine represents a line displayed in the LogArtifactView.
@@ -145,6 +160,20 @@ type callbackRequest struct {
 	Bottom    int    `json:"bottom"`
 	SaveEnd   *int   `json:"saveEnd"`
 	Analyze   bool   `json:"analyze"`
+	// Tail requests the lines appended to a live log after Offset.
+	Tail bool `json:"tail"`
+}
+
+// tailResponse holds the lines appended to a live log.
+type tailResponse struct {
+	// Content is the rendered line group of the new lines.
+	Content string `json:"content"`
+	// Offset is the byte offset to continue tailing from.
+	Offset int64 `json:"offset"`
+	// StartLine is the number of lines read so far.
+	StartLine int `json:"startLine"`
+	// Live is false once the complete log is available.
+	Live bool `json:"live"`
 }
 
 // LinesSkipped returns the number of lines skipped in a line group.
@@ -161,6 +190,11 @@ type LogArtifactView struct {
 	ShowRawLog   bool
 	CanSave      bool
 	CanAnalyze   bool
+	// Live is set for logs of running jobs, which are tailed from
+	// LiveOffset, the end of the last complete line shown.
+	Live       bool
+	LiveOffset int64
+	LiveLines  int
 }
 
 // buildLogsView holds each log file view
@@ -189,6 +223,9 @@ func getConfig(rawConfig json.RawMessage) parsedConfig {
 		conf.highlighter = nil
 	}
 	conf.showRawLog = !c.HideRawLog
+	conf.linkStackTraces = c.LinkStackTraces
+	conf.javaSourceRoot = c.JavaSourceRoot
+	conf.javaPackages = c.JavaPackages
 	if len(c.HighlightRegexes) == 0 {
 		return conf
 	}
@@ -214,6 +251,7 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 	}
 
 	conf := getConfig(rawConfig)
+	linker, artifacts := stackTraceLinker(artifacts, conf)
 	// Read log artifacts and construct template structs
 	for _, a := range artifacts {
 		av := LogArtifactView{
@@ -226,6 +264,11 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 			logrus.WithError(err).Info("Error reading log.")
 			continue
 		}
+		if isLive(a) {
+			av.Live = true
+			lines, av.LiveOffset = completeLines(lines)
+			av.LiveLines = len(lines)
+		}
 		artifact := av.ArtifactName
 		meta, _ := a.Metadata()
 		start, end := -1, -1
@@ -255,7 +298,8 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 				start, end = resp.Min, resp.Max
 			}
 		}
-		av.LineGroups = groupLines(&artifact, start, end, highlightLines(lines, 0, &artifact, conf.highlightRegex, conf.highlightLengthMax)...)
+		logLines := linkStackTraces(highlightLines(lines, 0, &artifact, conf.highlightRegex, conf.highlightLengthMax), linker)
+		av.LineGroups = groupLines(&artifact, start, end, logLines...)
 		av.ViewAll = true
 		av.CanSave = canSave(a.CanonicalLink())
 		av.CanAnalyze = analyze
@@ -281,6 +325,7 @@ func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data str
 	if err != nil {
 		return failedUnmarshal
 	}
+	linker, artifacts := stackTraceLinker(artifacts, getConfig(rawConfig))
 	artifact, ok := artifactByName(artifacts, request.Artifact)
 	if !ok {
 		return fmt.Sprintf(missingArtifact, request.Artifact)
@@ -300,7 +345,10 @@ func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data str
 	if request.SaveEnd != nil {
 		return storeHighlightedLines(&request, artifact)
 	}
-	return loadLines(&request, artifact, resourceDir, rawConfig)
+	if request.Tail {
+		return tailLines(&request, artifact, resourceDir, rawConfig, linker)
+	}
+	return loadLines(&request, artifact, resourceDir, rawConfig, linker)
 }
 
 type highlightRequest struct {
@@ -397,7 +445,7 @@ func storeHighlightedLines(request *callbackRequest, artifact api.Artifact) stri
 	return ""
 }
 
-func loadLines(request *callbackRequest, artifact api.Artifact, resourceDir string, rawConfig json.RawMessage) string {
+func loadLines(request *callbackRequest, artifact api.Artifact, resourceDir string, rawConfig json.RawMessage, linker *stacktrace.Linker) string {
 
 	var err error
 	var lines []string
@@ -435,7 +483,7 @@ func loadLines(request *callbackRequest, artifact api.Artifact, resourceDir stri
 	var skipGroup *LineGroup
 	conf := getConfig(rawConfig)
 	if len(skipLines) > 0 {
-		logLines := highlightLines(skipLines, skipRequest.StartLine, &request.Artifact, conf.highlightRegex, conf.highlightLengthMax)
+		logLines := linkStackTraces(highlightLines(skipLines, skipRequest.StartLine, &request.Artifact, conf.highlightRegex, conf.highlightLengthMax), linker)
 		skipGroup = &LineGroup{
 			Skip:         true,
 			Start:        skipRequest.StartLine,
@@ -452,7 +500,7 @@ func loadLines(request *callbackRequest, artifact api.Artifact, resourceDir stri
 		groups = append(groups, skipGroup)
 		skipGroup = nil
 	}
-	logLines := highlightLines(lines, request.StartLine, &request.Artifact, conf.highlightRegex, conf.highlightLengthMax)
+	logLines := linkStackTraces(highlightLines(lines, request.StartLine, &request.Artifact, conf.highlightRegex, conf.highlightLengthMax), linker)
 	groups = append(groups, &LineGroup{
 		LogLines:     logLines,
 		ArtifactName: &request.Artifact,
@@ -463,6 +511,63 @@ func loadLines(request *callbackRequest, artifact api.Artifact, resourceDir stri
 	return executeTemplate(resourceDir, "line groups", groups)
 }
 
+// tailLines renders the lines appended to a log after the requested offset. Only
+// complete lines are rendered while the log is live.
+func tailLines(request *callbackRequest, artifact api.Artifact, resourceDir string, rawConfig json.RawMessage, linker *stacktrace.Linker) string {
+	size, err := artifact.Size()
+	if err != nil {
+		return fmt.Sprintf("Failed to retrieve log size: %v", err)
+	}
+	response := tailResponse{
+		Offset:    request.Offset,
+		StartLine: request.StartLine,
+		Live:      isLive(artifact),
+	}
+	if size > request.Offset {
+		lines, err := logLines(artifact, request.Offset, size-request.Offset)
+		if err != nil {
+			return fmt.Sprintf("Failed to retrieve log lines: %v", err)
+		}
+		var read int64
+		if response.Live {
+			lines, read = completeLines(lines)
+		} else {
+			read = size - request.Offset
+		}
+		if len(lines) > 0 {
+			conf := getConfig(rawConfig)
+			logLines := linkStackTraces(highlightLines(lines, request.StartLine, &request.Artifact, conf.highlightRegex, conf.highlightLengthMax), linker)
+			response.Content = executeTemplate(resourceDir, "line groups", []LineGroup{{
+				LogLines:     logLines,
+				ArtifactName: &request.Artifact,
+			}})
+		}
+		response.Offset += read
+		response.StartLine += len(lines)
+	}
+	buf, err := json.Marshal(response)
+	if err != nil {
+		return err.Error()
+	}
+	return string(buf)
+}
+
+func isLive(artifact api.Artifact) bool {
+	live, ok := artifact.(api.LiveArtifact)
+	return ok && live.Live()
+}
+
+// completeLines drops the last line, which is either empty or still being
+// written, and returns the number of bytes of the remaining lines.
+func completeLines(lines []string) ([]string, int64) {
+	lines = lines[:len(lines)-1]
+	var length int64
+	for _, line := range lines {
+		length += int64(len(line) + 1)
+	}
+	return lines, length
+}
+
 func artifactByName(artifacts []api.Artifact, name string) (api.Artifact, bool) {
 	for _, a := range artifacts {
 		if a.JobPath() == name {
@@ -508,13 +613,13 @@ func highlightLines(lines []string, startLine int, artifact *string, highlightRe
 		if length <= maxLen {
 			loc := highlightRegex.FindStringIndex(text)
 			for loc != nil {
-				subLines = append(subLines, SubLine{false, text[:loc[0]]})
-				subLines = append(subLines, SubLine{true, text[loc[0]:loc[1]]})
+				subLines = append(subLines, SubLine{Highlighted: false, Text: text[:loc[0]]})
+				subLines = append(subLines, SubLine{Highlighted: true, Text: text[loc[0]:loc[1]]})
 				text = text[loc[1]:]
 				loc = highlightRegex.FindStringIndex(text)
 			}
 		}
-		subLines = append(subLines, SubLine{false, text})
+		subLines = append(subLines, SubLine{Highlighted: false, Text: text})
 		logLines = append(logLines, LogLine{
 			Length:       length + 1, // counting the "\n"
 			SubLines:     subLines,
@@ -527,6 +632,33 @@ func highlightLines(lines []string, startLine int, artifact *string, highlightRe
 	return logLines
 }
 
+// stackTraceLinker returns a linker for stack trace frames if enabled in the
+// config, and the artifacts without the prowjob.json it was created from.
+func stackTraceLinker(artifacts []api.Artifact, conf parsedConfig) (*stacktrace.Linker, []api.Artifact) {
+	if !conf.linkStackTraces {
+		return nil, artifacts
+	}
+	return stacktrace.FromArtifacts(artifacts, conf.javaSourceRoot, conf.javaPackages)
+}
+
+// linkStackTraces splits the sublines of each line so that stack trace
+// frames can be rendered as links.
+func linkStackTraces(logLines []LogLine, linker *stacktrace.Linker) []LogLine {
+	if linker == nil {
+		return logLines
+	}
+	for i, line := range logLines {
+		var subLines []SubLine
+		for _, sub := range line.SubLines {
+			for _, segment := range linker.Split(sub.Text) {
+				subLines = append(subLines, SubLine{Highlighted: sub.Highlighted, Text: segment.Text, Link: segment.Link})
+			}
+		}
+		logLines[i].SubLines = subLines
+	}
+	return logLines
+}
+
 // breaks lines into important/unimportant groups
 func groupLines(artifact *string, start, end int, logLines ...LogLine) []LineGroup {
 	// show highlighted lines and their neighboring lines
@@ -948,6 +948,78 @@ func TestCallback(t *testing.T) {
 	}
 }
 
+func TestTailLines(t *testing.T) {
+	render := func(start int, lines ...string) string {
+		return executeTemplate(".", "line groups", []LineGroup{{
+			LogLines:     highlightLines(lines, start, pstr("foo"), defaultErrRE, 0),
+			ArtifactName: pstr("foo"),
+		}})
+	}
+
+	cases := []struct {
+		name     string
+		artifact *fake.Artifact
+		data     string
+		want     tailResponse
+	}{
+		{
+			name: "live log renders complete lines only",
+			data: `{"artifact": "foo", "tail": true, "offset": 6, "startLine": 1}`,
+			artifact: &fake.Artifact{
+				Path:    "foo",
+				Content: []byte("hello\nworld\nagain\npart"),
+				IsLive:  true,
+			},
+			want: tailResponse{
+				Content:   render(1, "world", "again"),
+				Offset:    18,
+				StartLine: 3,
+				Live:      true,
+			},
+		},
+		{
+			name: "live log without new complete lines",
+			data: `{"artifact": "foo", "tail": true, "offset": 6, "startLine": 1}`,
+			artifact: &fake.Artifact{
+				Path:    "foo",
+				Content: []byte("hello\nwor"),
+				IsLive:  true,
+			},
+			want: tailResponse{
+				Offset:    6,
+				StartLine: 1,
+				Live:      true,
+			},
+		},
+		{
+			name: "complete log renders the rest",
+			data: `{"artifact": "foo", "tail": true, "offset": 6, "startLine": 1}`,
+			artifact: &fake.Artifact{
+				Path:    "foo",
+				Content: []byte("hello\nworld\npart"),
+			},
+			want: tailResponse{
+				Content:   render(1, "world", "part"),
+				Offset:    16,
+				StartLine: 3,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Lens{}.Callback([]api.Artifact{tc.artifact}, ".", tc.data, nil, prowconfig.Spyglass{})
+			var resp tailResponse
+			if err := json.Unmarshal([]byte(got), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response %q: %v", got, err)
+			}
+			if diff := cmp.Diff(tc.want, resp); diff != "" {
+				t.Errorf("Callback() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func marshalHighlightResponse(t *testing.T, hr highlightResponse) string {
 	b, err := json.Marshal(hr)
 	if err != nil {
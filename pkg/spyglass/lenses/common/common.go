@@ -167,6 +167,12 @@ type ArtifactFetcher interface {
 	Artifact(ctx context.Context, key string, artifactName string, sizeLimit int64) (api.Artifact, error)
 }
 
+// streamedLogFetcher knows how to fetch build logs that are uploaded in chunks
+// while the job is running
+type streamedLogFetcher interface {
+	StreamedLog(ctx context.Context, key string, logName string, sizeLimit int64) (api.Artifact, error)
+}
+
 // FetchArtifacts fetches artifacts.
 // TODO: Unexport once we only have remote lenses
 func FetchArtifacts(
@@ -222,6 +228,14 @@ func FetchArtifacts(
 	}
 
 	for _, logName := range logsNeeded {
+		if slf, ok := storageArtifactFetcher.(streamedLogFetcher); ok {
+			art, err := slf.StreamedLog(ctx, gcsKey, logName, sizeLimit)
+			if err == nil {
+				arts = append(arts, art)
+				continue
+			}
+			logrus.WithError(err).WithField("artifact", logName).Debug("Failed to fetch streamed log")
+		}
 		art, err := podLogArtifactFetcher.Artifact(ctx, src, logName, sizeLimit)
 		if config.IsNotAllowedBucketError(err) {
 			logrus.Debugf("Failed to fetch pod log: %v", err)
@@ -27,6 +27,11 @@ type Artifact struct {
 	Content []byte
 	Meta    map[string]string
 	Link    *string
+	IsLive  bool
+}
+
+func (fa *Artifact) Live() bool {
+	return fa.IsLive
 }
 
 func (fa *Artifact) JobPath() string {
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
 	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/stacktrace"
 )
 
 const (
@@ -58,6 +59,31 @@ type JVD struct {
 	Failed   []TestResult
 	Skipped  []TestResult
 	Flaky    []TestResult
+	// Linker links stack trace frames in failures to the source, if enabled.
+	Linker *stacktrace.Linker
+}
+
+// lensConfig is the junit lens' configuration.
+type lensConfig struct {
+	// LinkStackTraces links Go, Python and Java stack trace frames in test
+	// failures to the source at the tested revision. Requires prowjob.json
+	// in the lens' optional_files.
+	LinkStackTraces bool `json:"link_stack_traces,omitempty"`
+	// JavaSourceRoot is the directory Java frames are resolved against.
+	// Defaults to src/main/java.
+	JavaSourceRoot string `json:"java_source_root,omitempty"`
+	// JavaPackages are the Java packages of the repository. Only frames of
+	// classes in them or their subpackages are linked.
+	JavaPackages []string `json:"java_packages,omitempty"`
+}
+
+// FailureHTML renders the failure of a test, linking stack trace frames if
+// a linker is configured.
+func (jvd JVD) FailureHTML(jr JunitResult) template.HTML {
+	if jr.Failure == nil {
+		return ""
+	}
+	return jvd.Linker.HTML(fmt.Sprint(*jr.Failure))
 }
 
 // Config returns the lens's configuration.
@@ -120,8 +146,19 @@ type TestResult struct {
 }
 
 // Body renders the <body> for JUnit tests
-func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, rawConfig json.RawMessage, spyglassConfig config.Spyglass) string {
+	var conf lensConfig
+	if len(rawConfig) > 0 {
+		if err := json.Unmarshal(rawConfig, &conf); err != nil {
+			logrus.WithError(err).Error("Failed to decode junit config")
+		}
+	}
+	var linker *stacktrace.Linker
+	if conf.LinkStackTraces {
+		linker, artifacts = stacktrace.FromArtifacts(artifacts, conf.JavaSourceRoot, conf.JavaPackages)
+	}
 	jvd := lens.getJvd(artifacts)
+	jvd.Linker = linker
 
 	junitTemplate, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
 	if err != nil {
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resources provides a viewer for the CPU and memory usage of the
+// containers of a job recorded by sidecar.
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/sidecar"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+const (
+	name     = "resources"
+	title    = "Resource Usage"
+	priority = 15
+
+	chartWidth  = 600
+	chartHeight = 120
+
+	// overRequestedRatio is the ratio of the peak usage to the request below
+	// which a request is reported as too large.
+	overRequestedRatio = 0.5
+)
+
+func init() {
+	lenses.RegisterLens(Lens{})
+}
+
+// Lens renders the resource usage recorded by sidecar.
+type Lens struct{}
+
+// Config returns the lens's configuration.
+func (lens Lens) Config() lenses.LensConfig {
+	return lenses.LensConfig{
+		Name:     name,
+		Title:    title,
+		Priority: priority,
+	}
+}
+
+// Header renders the content of <head> from template.html.
+func (lens Lens) Header(artifacts []api.Artifact, resourceDir string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	t, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		return fmt.Sprintf("<!-- FAILED LOADING HEADER: %v -->", err)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "header", nil); err != nil {
+		return fmt.Sprintf("<!-- FAILED EXECUTING HEADER TEMPLATE: %v -->", err)
+	}
+	return buf.String()
+}
+
+// Callback does nothing.
+func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	return ""
+}
+
+// chart is a line chart of a resource of a container.
+type chart struct {
+	// Points of the usage for a polyline.
+	Points string
+	// RequestY and LimitY are the heights of the request and limit lines, or
+	// negative if not set.
+	RequestY float64
+	LimitY   float64
+	Width    int
+	Height   int
+	Max      string
+}
+
+// resourceView summarizes the usage of a resource by a container.
+type resourceView struct {
+	Title   string
+	Peak    string
+	Average string
+	Request string
+	Limit   string
+	// Advice suggests how to change the request, if it should be.
+	Advice string
+	Chart  chart
+}
+
+type containerView struct {
+	Name     string
+	Samples  int
+	Duration time.Duration
+	CPU      resourceView
+	Memory   resourceView
+}
+
+// Body renders the <body>
+func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, rawConfig json.RawMessage, spyglassConfig config.Spyglass) string {
+	var usage *sidecar.ResourceUsage
+	var pj prowapi.ProwJob
+	for _, artifact := range artifacts {
+		switch artifact.JobPath() {
+		case sidecar.ResourceUsageFile:
+			content, err := artifact.ReadAll()
+			if err != nil {
+				logrus.WithError(err).Warn("Couldn't read a resource usage file that should exist.")
+				return fmt.Sprintf("Failed to read the resource usage file: %v", err)
+			}
+			usage = &sidecar.ResourceUsage{}
+			if err := json.Unmarshal(content, usage); err != nil {
+				logrus.WithError(err).Info("Error unmarshalling resource usage")
+				return fmt.Sprintf("Couldn't unmarshal %s: %v", sidecar.ResourceUsageFile, err)
+			}
+		case prowapi.ProwJobFile:
+			// The requests and limits of the containers are part of the job.
+			content, err := artifact.ReadAll()
+			if err != nil {
+				logrus.WithError(err).Warn("Couldn't read a prowjob file that should exist.")
+				continue
+			}
+			if err := json.Unmarshal(content, &pj); err != nil {
+				logrus.WithError(err).Info("Error unmarshalling prowjob")
+			}
+		}
+	}
+	if usage == nil {
+		return fmt.Sprintf("There is no %s file.", sidecar.ResourceUsageFile)
+	}
+
+	t, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error parsing template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "body", containerViews(*usage, pj.Spec.PodSpec)); err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+	}
+	return buf.String()
+}
+
+// containerViews summarizes the usage of the containers that were sampled.
+func containerViews(usage sidecar.ResourceUsage, spec *coreapi.PodSpec) []containerView {
+	resources := map[string]coreapi.ResourceRequirements{}
+	if spec != nil {
+		for _, container := range spec.Containers {
+			resources[container.Name] = container.Resources
+		}
+		if len(spec.Containers) == 1 {
+			// Decoration renames a lone test container.
+			resources[kube.TestContainerName] = spec.Containers[0].Resources
+		}
+	}
+
+	var views []containerView
+	for _, container := range usage.Containers {
+		if len(container.Samples) == 0 {
+			continue
+		}
+		cpu := make([]float64, 0, len(container.Samples))
+		memory := make([]float64, 0, len(container.Samples))
+		for _, sample := range container.Samples {
+			cpu = append(cpu, sample.CPU)
+			memory = append(memory, float64(sample.Memory))
+		}
+		res, known := resources[container.Name]
+		view := containerView{
+			Name:     container.Name,
+			Samples:  len(container.Samples),
+			Duration: container.Samples[len(container.Samples)-1].Time.Sub(container.Samples[0].Time).Round(time.Second),
+			CPU:      summarize(cpu, quantity(res.Requests, coreapi.ResourceCPU, cores), quantity(res.Limits, coreapi.ResourceCPU, cores), formatCores),
+			Memory:   summarize(memory, quantity(res.Requests, coreapi.ResourceMemory, bytesOf), quantity(res.Limits, coreapi.ResourceMemory, bytesOf), formatBytes),
+		}
+		view.CPU.Title, view.Memory.Title = "CPU", "Memory"
+		if !known {
+			// The resources of utility containers and of jobs without a
+			// prowjob.json are not known, so they cannot be judged.
+			for _, r := range []*resourceView{&view.CPU, &view.Memory} {
+				r.Request, r.Limit, r.Advice = "unknown", "unknown", ""
+			}
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func cores(q resource.Quantity) float64 {
+	return float64(q.MilliValue()) / 1000
+}
+
+func bytesOf(q resource.Quantity) float64 {
+	return float64(q.Value())
+}
+
+// quantity returns the value of a resource in a list, or a negative value if
+// it is not set.
+func quantity(list coreapi.ResourceList, name coreapi.ResourceName, value func(resource.Quantity) float64) float64 {
+	q, ok := list[name]
+	if !ok {
+		return -1
+	}
+	return value(q)
+}
+
+func formatCores(v float64) string {
+	return fmt.Sprintf("%.2f cores", v)
+}
+
+func formatBytes(v float64) string {
+	const unit = 1024
+	if v < unit {
+		return fmt.Sprintf("%.0f B", v)
+	}
+	div, exp := float64(unit), 0
+	for n := v / unit; n >= unit && exp < 4; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", v/div, "KMGTP"[exp])
+}
+
+// summarize computes the peak and average of the samples of a resource and
+// charts them against the request and the limit.
+func summarize(samples []float64, request, limit float64, format func(float64) string) resourceView {
+	var peak, sum float64
+	for _, v := range samples {
+		peak = max(peak, v)
+		sum += v
+	}
+	view := resourceView{
+		Peak:    format(peak),
+		Average: format(sum / float64(len(samples))),
+		Request: "not set",
+		Limit:   "not set",
+	}
+	if request >= 0 {
+		view.Request = format(request)
+		switch {
+		case peak > request:
+			view.Advice = fmt.Sprintf("The peak usage exceeds the request, consider requesting at least %s.", format(peak))
+		case request > 0 && peak < request*overRequestedRatio:
+			view.Advice = fmt.Sprintf("The peak usage is %.0f%% of the request, consider requesting less.", 100*peak/request)
+		}
+	} else {
+		view.Advice = fmt.Sprintf("There is no request, consider requesting about %s.", format(peak))
+	}
+	if limit >= 0 {
+		view.Limit = format(limit)
+	}
+
+	top := max(peak, request, limit)
+	if top <= 0 {
+		top = 1
+	}
+	y := func(v float64) float64 {
+		return chartHeight - v/top*chartHeight
+	}
+	points := make([]string, 0, len(samples))
+	for i, v := range samples {
+		x := 0.0
+		if len(samples) > 1 {
+			x = float64(i) / float64(len(samples)-1) * chartWidth
+		}
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y(v)))
+	}
+	view.Chart = chart{
+		Points:   strings.Join(points, " "),
+		RequestY: -1,
+		LimitY:   -1,
+		Width:    chartWidth,
+		Height:   chartHeight,
+		Max:      format(top),
+	}
+	if request >= 0 {
+		view.Chart.RequestY = y(request)
+	}
+	if limit >= 0 {
+		view.Chart.LimitY = y(limit)
+	}
+	return view
+}
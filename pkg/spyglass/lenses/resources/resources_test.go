@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/sidecar"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+func TestContainerViews(t *testing.T) {
+	start := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	usage := sidecar.ResourceUsage{Containers: []sidecar.ContainerUsage{
+		{Name: "empty"},
+		{
+			Name: "sidecar",
+			Samples: []sidecar.ResourceSample{
+				{Time: start, CPU: 0.01, Memory: 10 << 20},
+			},
+		},
+		{
+			Name: "test",
+			Samples: []sidecar.ResourceSample{
+				{Time: start, CPU: 0.5, Memory: 512 << 20},
+				{Time: start.Add(time.Minute), CPU: 1.5, Memory: 1 << 30},
+			},
+		},
+	}}
+	spec := &coreapi.PodSpec{Containers: []coreapi.Container{{
+		Resources: coreapi.ResourceRequirements{
+			Requests: coreapi.ResourceList{
+				coreapi.ResourceCPU:    resource.MustParse("1"),
+				coreapi.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			Limits: coreapi.ResourceList{
+				coreapi.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}}}
+
+	expected := []containerView{
+		{
+			Name:    "sidecar",
+			Samples: 1,
+			CPU: resourceView{
+				Title:   "CPU",
+				Peak:    "0.01 cores",
+				Average: "0.01 cores",
+				Request: "unknown",
+				Limit:   "unknown",
+				Chart:   chart{Points: "0.0,0.0", RequestY: -1, LimitY: -1, Width: chartWidth, Height: chartHeight, Max: "0.01 cores"},
+			},
+			Memory: resourceView{
+				Title:   "Memory",
+				Peak:    "10.0 MiB",
+				Average: "10.0 MiB",
+				Request: "unknown",
+				Limit:   "unknown",
+				Chart:   chart{Points: "0.0,0.0", RequestY: -1, LimitY: -1, Width: chartWidth, Height: chartHeight, Max: "10.0 MiB"},
+			},
+		},
+		{
+			Name:     "test",
+			Samples:  2,
+			Duration: time.Minute,
+			CPU: resourceView{
+				Title:   "CPU",
+				Peak:    "1.50 cores",
+				Average: "1.00 cores",
+				Request: "1.00 cores",
+				Limit:   "not set",
+				Advice:  "The peak usage exceeds the request, consider requesting at least 1.50 cores.",
+				Chart:   chart{Points: "0.0,80.0 600.0,0.0", RequestY: 40, LimitY: -1, Width: chartWidth, Height: chartHeight, Max: "1.50 cores"},
+			},
+			Memory: resourceView{
+				Title:   "Memory",
+				Peak:    "1.0 GiB",
+				Average: "768.0 MiB",
+				Request: "4.0 GiB",
+				Limit:   "8.0 GiB",
+				Advice:  "The peak usage is 25% of the request, consider requesting less.",
+				Chart:   chart{Points: "0.0,112.5 600.0,105.0", RequestY: 60, LimitY: 0, Width: chartWidth, Height: chartHeight, Max: "8.0 GiB"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, containerViews(usage, spec), cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("container views differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestBody(t *testing.T) {
+	testCases := []struct {
+		name      string
+		artifacts []api.Artifact
+		contains  []string
+	}{
+		{
+			name: "usage with requests",
+			artifacts: []api.Artifact{
+				&fake.Artifact{
+					Path:    sidecar.ResourceUsageFile,
+					Content: []byte(`{"containers": [{"name": "test", "samples": [{"time": "2026-10-17T10:00:00Z", "cpu": 0.25, "memory": 1048576}]}]}`),
+				},
+				&fake.Artifact{
+					Path:    "prowjob.json",
+					Content: []byte(`{"spec": {"pod_spec": {"containers": [{"resources": {"requests": {"cpu": "2"}}}]}}}`),
+				},
+			},
+			contains: []string{
+				"<h4>test</h4>",
+				"0.25 cores",
+				`<line class="request"`,
+				"The peak usage is 12% of the request, consider requesting less.",
+				"There is no request, consider requesting about 1.0 MiB.",
+			},
+		},
+		{
+			name: "no usage recorded",
+			artifacts: []api.Artifact{
+				&fake.Artifact{
+					Path:    sidecar.ResourceUsageFile,
+					Content: []byte(`{"containers": []}`),
+				},
+			},
+			contains: []string{"No resource usage was recorded."},
+		},
+		{
+			name: "invalid usage",
+			artifacts: []api.Artifact{
+				&fake.Artifact{
+					Path:    sidecar.ResourceUsageFile,
+					Content: []byte(`{`),
+				},
+			},
+			contains: []string{"Couldn't unmarshal resource-usage.json"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := Lens{}.Body(tc.artifacts, ".", "", nil, config.Spyglass{})
+			for _, s := range tc.contains {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected body to contain %q, got:\n%s", s, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stacktrace detects stack trace frames of common languages in
+// job output and links them to the source at the tested revision.
+package stacktrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+)
+
+// DefaultJavaSourceRoot is the directory relative to the repository root
+// that Java frames are resolved against if nothing else is configured.
+const DefaultJavaSourceRoot = "src/main/java"
+
+var (
+	// goFrameRE matches frames like `/go/src/k8s.io/foo/bar.go:42 +0x1d`.
+	goFrameRE = regexp.MustCompile(`(/[\w.\-/@+]+\.go):(\d+)`)
+	// pythonFrameRE matches frames like `File "/src/foo/bar.py", line 42, in baz`.
+	pythonFrameRE = regexp.MustCompile(`File "([^"]+\.py)", line (\d+)`)
+	// javaFrameRE matches frames like `at com.example.Foo.bar(Foo.java:42)`.
+	javaFrameRE = regexp.MustCompile(`at ((?:[\w$]+\.)+)[\w$<>]+\(([\w$]+\.java):(\d+)\)`)
+)
+
+// dependencyDirs hold sources of dependencies rather than of the repository,
+// even if they are inside of its checkout.
+var dependencyDirs = map[string]bool{
+	"vendor":        true,
+	"node_modules":  true,
+	"site-packages": true,
+	"dist-packages": true,
+}
+
+// Segment is a part of a line of output. Link is set if the segment
+// is a stack trace frame that could be resolved to a source file.
+type Segment struct {
+	Text string
+	Link string
+}
+
+// frame is the location of a stack trace frame within a line.
+type frame struct {
+	start, end int
+	path       string
+	line       string
+}
+
+// Linker builds links to source files in the repository under test.
+type Linker struct {
+	repoLink string
+	sha      string
+	gitiles  bool
+	prefixes []string
+	javaRoot string
+	javaPkgs []string
+}
+
+// NewLinker returns a Linker for the primary refs of a job, or nil if the
+// refs do not identify a revision that can be linked to. Java frames are
+// resolved relative to javaRoot, which defaults to DefaultJavaSourceRoot.
+// Java frames don't tell which files are part of the repository, so only
+// frames of classes in javaPackages or their subpackages are linked.
+func NewLinker(refs *prowv1.Refs, javaRoot string, javaPackages []string) *Linker {
+	if refs == nil || refs.Org == "" || refs.Repo == "" {
+		return nil
+	}
+	l := &Linker{
+		repoLink: strings.TrimSuffix(refs.RepoLink, "/"),
+		sha:      refs.BaseSHA,
+		gitiles:  strings.Contains(refs.BaseLink, "/+/"),
+	}
+	if len(refs.Pulls) > 0 {
+		l.sha = refs.Pulls[0].SHA
+		l.gitiles = l.gitiles || strings.Contains(refs.Pulls[0].CommitLink, "/+/")
+	}
+	if l.sha == "" {
+		return nil
+	}
+	if l.repoLink == "" {
+		l.repoLink = fmt.Sprintf("https://github.com/%s/%s", refs.Org, refs.Repo)
+	}
+	if refs.PathAlias != "" {
+		l.prefixes = append(l.prefixes, "/"+strings.Trim(refs.PathAlias, "/")+"/")
+	}
+	l.prefixes = append(l.prefixes, fmt.Sprintf("/%s/%s/", refs.Org, refs.Repo))
+	l.javaRoot = strings.Trim(javaRoot, "/")
+	if l.javaRoot == "" {
+		l.javaRoot = DefaultJavaSourceRoot
+	}
+	l.javaPkgs = javaPackages
+	return l
+}
+
+// FromArtifacts returns a Linker for the job described by the prowjob.json
+// artifact and the remaining artifacts. The Linker is nil if there is no
+// prowjob.json or the job can not be linked to.
+func FromArtifacts(artifacts []api.Artifact, javaRoot string, javaPackages []string) (*Linker, []api.Artifact) {
+	var linker *Linker
+	remaining := make([]api.Artifact, 0, len(artifacts))
+	for _, a := range artifacts {
+		if a.JobPath() != prowv1.ProwJobFile {
+			remaining = append(remaining, a)
+			continue
+		}
+		raw, err := a.ReadAll()
+		if err != nil {
+			continue
+		}
+		var pj prowv1.ProwJob
+		if err := json.Unmarshal(raw, &pj); err != nil {
+			continue
+		}
+		linker = NewLinker(pj.Spec.Refs, javaRoot, javaPackages)
+	}
+	return linker, remaining
+}
+
+// Link returns the URL of the given line in a file relative to the
+// repository root.
+func (l *Linker) Link(path string, line string) string {
+	if l.gitiles {
+		return fmt.Sprintf("%s/+/%s/%s#%s", l.repoLink, l.sha, path, line)
+	}
+	return fmt.Sprintf("%s/blob/%s/%s#L%s", l.repoLink, l.sha, path, line)
+}
+
+// relativePath resolves an absolute file path from a stack trace to
+// a path relative to the repository root. Files of dependencies are not
+// resolved.
+func (l *Linker) relativePath(path string) (string, bool) {
+	for _, prefix := range l.prefixes {
+		if idx := strings.LastIndex(path, prefix); idx != -1 {
+			rel := path[idx+len(prefix):]
+			for _, dir := range strings.Split(rel, "/") {
+				if dependencyDirs[dir] {
+					return "", false
+				}
+			}
+			return rel, true
+		}
+	}
+	return "", false
+}
+
+// inJavaPackages returns whether the Java package is one of the packages of
+// the repository or a subpackage of them.
+func (l *Linker) inJavaPackages(pkg string) bool {
+	for _, p := range l.javaPkgs {
+		if pkg == p || strings.HasPrefix(pkg, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Linker) frames(text string) []frame {
+	var frames []frame
+	for _, re := range []*regexp.Regexp{goFrameRE, pythonFrameRE} {
+		for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+			rel, ok := l.relativePath(text[m[2]:m[3]])
+			if !ok {
+				continue
+			}
+			frames = append(frames, frame{start: m[0], end: m[1], path: rel, line: text[m[4]:m[5]]})
+		}
+	}
+	for _, m := range javaFrameRE.FindAllStringSubmatchIndex(text, -1) {
+		// The class name is the last element of the qualified name, everything
+		// before it is the package.
+		qualified := strings.Split(strings.TrimSuffix(text[m[2]:m[3]], "."), ".")
+		pkg := qualified[:len(qualified)-1]
+		if !l.inJavaPackages(strings.Join(pkg, ".")) {
+			// JDK or library frame.
+			continue
+		}
+		rel := text[m[4]:m[5]]
+		if len(pkg) > 0 {
+			rel = strings.Join(pkg, "/") + "/" + rel
+		}
+		frames = append(frames, frame{start: m[4], end: m[7], path: l.javaRoot + "/" + rel, line: text[m[6]:m[7]]})
+	}
+	sort.Slice(frames, func(i, j int) bool { return frames[i].start < frames[j].start })
+	return frames
+}
+
+// Split splits a line of output into segments, linking all stack trace
+// frames that belong to the repository under test. A nil Linker returns
+// the whole line as a single segment.
+func (l *Linker) Split(text string) []Segment {
+	if l == nil {
+		return []Segment{{Text: text}}
+	}
+	var segments []Segment
+	var pos int
+	for _, f := range l.frames(text) {
+		if f.start < pos {
+			// Overlapping match of another language.
+			continue
+		}
+		if f.start > pos {
+			segments = append(segments, Segment{Text: text[pos:f.start]})
+		}
+		segments = append(segments, Segment{Text: text[f.start:f.end], Link: l.Link(f.path, f.line)})
+		pos = f.end
+	}
+	if pos < len(text) || len(segments) == 0 {
+		segments = append(segments, Segment{Text: text[pos:]})
+	}
+	return segments
+}
+
+// HTML returns the escaped text with all resolvable stack trace frames
+// turned into links.
+func (l *Linker) HTML(text string) template.HTML {
+	var b strings.Builder
+	for _, s := range l.Split(text) {
+		if s.Link == "" {
+			b.WriteString(template.HTMLEscapeString(s.Text))
+			continue
+		}
+		fmt.Fprintf(&b, `<a href="%s" target="_blank">%s</a>`, template.HTMLEscapeString(s.Link), template.HTMLEscapeString(s.Text))
+	}
+	return template.HTML(b.String())
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stacktrace
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+func TestSplit(t *testing.T) {
+	githubRefs := &prowv1.Refs{
+		Org:      "org",
+		Repo:     "repo",
+		RepoLink: "https://github.com/org/repo",
+		BaseSHA:  "base",
+		Pulls:    []prowv1.Pull{{Number: 1, SHA: "head"}},
+	}
+	gerritRefs := &prowv1.Refs{
+		Org:       "gerrit.example.com",
+		Repo:      "project",
+		RepoLink:  "https://gerrit.example.com/project",
+		BaseSHA:   "base",
+		BaseLink:  "https://gerrit.example.com/project/+/base",
+		PathAlias: "example.com/project",
+	}
+	testCases := []struct {
+		name         string
+		refs         *prowv1.Refs
+		javaRoot     string
+		javaPackages []string
+		line         string
+		expected     []Segment
+	}{
+		{
+			name:     "no linker",
+			line:     "\t/home/prow/go/src/github.com/org/repo/pkg/foo.go:42 +0x1d",
+			expected: []Segment{{Text: "\t/home/prow/go/src/github.com/org/repo/pkg/foo.go:42 +0x1d"}},
+		},
+		{
+			name: "go frame in the repo is linked at the pull request head",
+			refs: githubRefs,
+			line: "\t/home/prow/go/src/github.com/org/repo/pkg/foo.go:42 +0x1d",
+			expected: []Segment{
+				{Text: "\t"},
+				{Text: "/home/prow/go/src/github.com/org/repo/pkg/foo.go:42", Link: "https://github.com/org/repo/blob/head/pkg/foo.go#L42"},
+				{Text: " +0x1d"},
+			},
+		},
+		{
+			name:     "go frame outside of the repo is not linked",
+			refs:     githubRefs,
+			line:     "\t/usr/local/go/src/runtime/panic.go:965 +0x1b9",
+			expected: []Segment{{Text: "\t/usr/local/go/src/runtime/panic.go:965 +0x1b9"}},
+		},
+		{
+			name:     "vendored go frame is not linked",
+			refs:     githubRefs,
+			line:     "\t/go/src/github.com/org/repo/vendor/k8s.io/foo/bar.go:1",
+			expected: []Segment{{Text: "\t/go/src/github.com/org/repo/vendor/k8s.io/foo/bar.go:1"}},
+		},
+		{
+			name:     "nested vendored go frame is not linked",
+			refs:     githubRefs,
+			line:     "\t/go/src/github.com/org/repo/staging/src/k8s.io/api/vendor/k8s.io/foo/bar.go:1",
+			expected: []Segment{{Text: "\t/go/src/github.com/org/repo/staging/src/k8s.io/api/vendor/k8s.io/foo/bar.go:1"}},
+		},
+		{
+			name: "python frame is linked",
+			refs: githubRefs,
+			line: `  File "/workspace/org/repo/hack/verify.py", line 12, in main`,
+			expected: []Segment{
+				{Text: "  "},
+				{Text: `File "/workspace/org/repo/hack/verify.py", line 12`, Link: "https://github.com/org/repo/blob/head/hack/verify.py#L12"},
+				{Text: ", in main"},
+			},
+		},
+		{
+			name:     "python frame of an installed package is not linked",
+			refs:     githubRefs,
+			line:     `  File "/workspace/org/repo/.venv/lib/python3.11/site-packages/yaml/parser.py", line 3, in parse`,
+			expected: []Segment{{Text: `  File "/workspace/org/repo/.venv/lib/python3.11/site-packages/yaml/parser.py", line 3, in parse`}},
+		},
+		{
+			name:         "java frame is linked relative to the source root",
+			refs:         githubRefs,
+			javaRoot:     "core/src/test/java",
+			javaPackages: []string{"com.example"},
+			line:         "\tat com.example.Foo$Bar.baz(Foo.java:7)",
+			expected: []Segment{
+				{Text: "\tat com.example.Foo$Bar.baz("},
+				{Text: "Foo.java:7", Link: "https://github.com/org/repo/blob/head/core/src/test/java/com/example/Foo.java#L7"},
+				{Text: ")"},
+			},
+		},
+		{
+			name:         "jdk frame is not linked",
+			refs:         githubRefs,
+			javaPackages: []string{"com.example"},
+			line:         "\tat java.util.ArrayList.get(ArrayList.java:427)",
+			expected:     []Segment{{Text: "\tat java.util.ArrayList.get(ArrayList.java:427)"}},
+		},
+		{
+			name:         "java frame of a package with the same prefix is not linked",
+			refs:         githubRefs,
+			javaPackages: []string{"com.example"},
+			line:         "\tat com.examples.Foo.bar(Foo.java:7)",
+			expected:     []Segment{{Text: "\tat com.examples.Foo.bar(Foo.java:7)"}},
+		},
+		{
+			name:     "java frame is not linked without java packages",
+			refs:     githubRefs,
+			line:     "\tat org.junit.Assert.fail(Assert.java:89)",
+			expected: []Segment{{Text: "\tat org.junit.Assert.fail(Assert.java:89)"}},
+		},
+		{
+			name: "gerrit frame is linked to gitiles using the path alias",
+			refs: gerritRefs,
+			line: "/home/prow/go/src/example.com/project/main.go:3",
+			expected: []Segment{
+				{Text: "/home/prow/go/src/example.com/project/main.go:3", Link: "https://gerrit.example.com/project/+/base/main.go#3"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var linker *Linker
+			if tc.refs != nil {
+				linker = NewLinker(tc.refs, tc.javaRoot, tc.javaPackages)
+			}
+			if diff := cmp.Diff(tc.expected, linker.Split(tc.line)); diff != "" {
+				t.Errorf("unexpected segments (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	linker := NewLinker(&prowv1.Refs{Org: "org", Repo: "repo", BaseSHA: "abc"}, "", nil)
+	expected := `&lt;panic&gt; <a href="https://github.com/org/repo/blob/abc/main.go#L1" target="_blank">/go/src/org/repo/main.go:1</a>`
+	if actual := string(linker.HTML("<panic> /go/src/org/repo/main.go:1")); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestFromArtifacts(t *testing.T) {
+	log := &fake.Artifact{Path: "build-log.txt"}
+	pj := &fake.Artifact{
+		Path:    prowv1.ProwJobFile,
+		Content: []byte(`{"spec":{"refs":{"org":"org","repo":"repo","base_sha":"abc"}}}`),
+	}
+
+	linker, remaining := FromArtifacts([]api.Artifact{log, pj}, "", nil)
+	if linker == nil {
+		t.Fatal("expected a linker")
+	}
+	if diff := cmp.Diff([]api.Artifact{log}, remaining); diff != "" {
+		t.Errorf("unexpected remaining artifacts (-want +got):\n%s", diff)
+	}
+
+	if linker, _ := FromArtifacts([]api.Artifact{log}, "", nil); linker != nil {
+		t.Error("expected no linker without prowjob.json")
+	}
+}
@@ -146,6 +146,16 @@ test/e2e/e2e.go:137 BeforeSuite on Node 1 failed test/e2e/e2e.go:137
 						  },
 						},`),
 		},
+		{
+			BucketName: "test-bucket",
+			Name:       "logs/example-ci-run/405/build-log.txt.chunks/00000001",
+			Content:    []byte("still\nrunning\n"),
+		},
+		{
+			BucketName: "test-bucket",
+			Name:       "logs/example-ci-run/405/build-log.txt.chunks/00000000",
+			Content:    []byte("this log\nis "),
+		},
 		{
 			BucketName: "test-bucket",
 			Name:       "logs/symlink-party/123.txt",
@@ -24,6 +24,7 @@ import (
 	"math/rand"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 
 	"sigs.k8s.io/prow/pkg/config"
 	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
 )
 
@@ -220,6 +222,54 @@ func (af *StorageArtifactFetcher) Artifact(ctx context.Context, key string, arti
 	return NewStorageArtifact(context.Background(), obj, signedURL, artifactName, sizeLimit), nil
 }
 
+// StreamedLog constructs an artifact from the chunks of a build log that the sidecar
+// uploads while the job is running. It fails if no chunks have been uploaded.
+func (af *StorageArtifactFetcher) StreamedLog(ctx context.Context, key string, logName string, sizeLimit int64) (api.Artifact, error) {
+	src, err := af.newStorageJobSource(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GCS job source from %s: %w", key, err)
+	}
+
+	_, prefix := extractBucketPrefixPair(src.jobPath())
+	chunksDir := path.Join(prefix, gcs.LogChunksDir(logName)) + "/"
+	it, err := af.opener.Iterator(ctx, fmt.Sprintf("%s%s/%s", src.linkPrefix, src.bucket, chunksDir), "")
+	if err != nil {
+		return nil, err
+	}
+	var attrs []pkgio.ObjectAttributes
+	for {
+		oAttrs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks of %s: %w", logName, err)
+		}
+		if oAttrs.IsDir {
+			continue
+		}
+		attrs = append(attrs, oAttrs)
+	}
+	if len(attrs) == 0 {
+		return nil, fmt.Errorf("no chunks of %s found", logName)
+	}
+	// Chunk names are zero-padded indices.
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+
+	var chunks []logChunk
+	for _, oAttrs := range attrs {
+		chunks = append(chunks, logChunk{
+			handle: &storageArtifactHandle{Opener: af.opener, Name: fmt.Sprintf("%s%s/%s", src.linkPrefix, src.bucket, oAttrs.Name)},
+			size:   oAttrs.Size,
+		})
+	}
+	signedURL, err := af.signURL(ctx, fmt.Sprintf("%s%s/%s", src.linkPrefix, src.bucket, attrs[0].Name))
+	if err != nil {
+		return nil, err
+	}
+	return newStreamedLogArtifact(context.Background(), chunks, signedURL, logName, sizeLimit), nil
+}
+
 func extractBucketPrefixPair(storagePath string) (string, string) {
 	split := strings.SplitN(storagePath, "/", 2)
 	return split[0], split[1]
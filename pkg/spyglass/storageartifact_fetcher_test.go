@@ -348,6 +348,60 @@ func TestFetchArtifacts_GCS(t *testing.T) {
 	}
 }
 
+func TestStreamedLog(t *testing.T) {
+	cfg := createConfigGetter("test-bucket")
+	fakeGCSClient := fakeGCSServer.Client()
+	testAf := NewStorageArtifactFetcher(io.NewGCSOpener(fakeGCSClient), cfg, false)
+	testCases := []struct {
+		name         string
+		source       string
+		expectedLog  string
+		expectedTail string
+		expectErr    bool
+	}{
+		{
+			name:         "chunks are read in order",
+			source:       "gs://test-bucket/logs/example-ci-run/405",
+			expectedLog:  "this log\nis still\nrunning\n",
+			expectedTail: "is still\n",
+		},
+		{
+			name:      "no chunks",
+			source:    "gs://test-bucket/logs/example-ci-run/403",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact, err := testAf.StreamedLog(context.Background(), tc.source, "build-log.txt", 500e6)
+			if err != nil != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if diff := cmp.Diff("build-log.txt", artifact.JobPath()); diff != "" {
+				t.Errorf("unexpected job path (-want +got):\n%s", diff)
+			}
+			content, err := artifact.ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read log: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedLog, string(content)); diff != "" {
+				t.Errorf("unexpected log content (-want +got):\n%s", diff)
+			}
+			tail := make([]byte, len(tc.expectedTail))
+			if _, err := artifact.ReadAt(tail, 9); err != nil {
+				t.Fatalf("failed to read log across chunks: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedTail, string(tail)); diff != "" {
+				t.Errorf("unexpected content read across chunks (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSignURL(t *testing.T) {
 	// This fake key is revoked and thus worthless but still make its contents less obvious
 	fakeKeyBuf, err := base64.StdEncoding.DecodeString(`
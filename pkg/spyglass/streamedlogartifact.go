@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+// logChunk is one object of a streamed log
+type logChunk struct {
+	handle artifactHandle
+	size   int64
+}
+
+// StreamedLogArtifact represents a build log that is uploaded in chunks by the
+// sidecar while the job is running
+type StreamedLogArtifact struct {
+	chunks    []logChunk
+	link      string
+	path      string
+	sizeLimit int64
+	ctx       context.Context
+}
+
+var _ api.LiveArtifact = &StreamedLogArtifact{}
+
+// newStreamedLogArtifact returns a new StreamedLogArtifact reading the given chunks in order
+func newStreamedLogArtifact(ctx context.Context, chunks []logChunk, link string, path string, sizeLimit int64) *StreamedLogArtifact {
+	return &StreamedLogArtifact{
+		chunks:    chunks,
+		link:      link,
+		path:      path,
+		sizeLimit: sizeLimit,
+		ctx:       ctx,
+	}
+}
+
+// Live returns true, as the log is replaced by the complete one when the job finishes
+func (a *StreamedLogArtifact) Live() bool {
+	return true
+}
+
+// Size returns the size of all chunks uploaded so far
+func (a *StreamedLogArtifact) Size() (int64, error) {
+	var size int64
+	for _, chunk := range a.chunks {
+		size += chunk.size
+	}
+	return size, nil
+}
+
+// JobPath gets the path of the complete log within the job
+func (a *StreamedLogArtifact) JobPath() string {
+	return a.path
+}
+
+// CanonicalLink gets a link to the first chunk, as the log is not stored as a
+// single object until the job finishes
+func (a *StreamedLogArtifact) CanonicalLink() string {
+	return a.link
+}
+
+// Metadata returns no metadata, as chunks do not carry any
+func (a *StreamedLogArtifact) Metadata() (map[string]string, error) {
+	return nil, nil
+}
+
+// UpdateMetadata is not supported for logs that are still being written
+func (a *StreamedLogArtifact) UpdateMetadata(meta map[string]string) error {
+	return errors.New("cannot update metadata of a streamed log")
+}
+
+// ReadAt reads len(p) bytes from the log at offset off, spanning chunks as needed
+func (a *StreamedLogArtifact) ReadAt(p []byte, off int64) (n int, err error) {
+	if int64(len(p)) > a.sizeLimit {
+		return 0, lenses.ErrRequestSizeTooLarge
+	}
+	return a.readAt(p, off)
+}
+
+func (a *StreamedLogArtifact) readAt(p []byte, off int64) (int, error) {
+	var n int
+	var start int64
+	for _, chunk := range a.chunks {
+		if n == len(p) {
+			break
+		}
+		end := start + chunk.size
+		if off+int64(n) >= end {
+			start = end
+			continue
+		}
+		chunkOff := off + int64(n) - start
+		length := min(chunk.size-chunkOff, int64(len(p)-n))
+		reader, err := chunk.handle.NewRangeReader(a.ctx, chunkOff, length)
+		if err != nil {
+			return n, fmt.Errorf("error getting chunk reader: %w", err)
+		}
+		read, err := io.ReadFull(reader, p[n:n+int(length)])
+		reader.Close()
+		n += read
+		if err != nil {
+			return n, fmt.Errorf("error reading chunk: %w", err)
+		}
+		start = end
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// ReadAll reads all chunks uploaded so far, failing if they are too large
+func (a *StreamedLogArtifact) ReadAll() ([]byte, error) {
+	size, _ := a.Size()
+	if size > a.sizeLimit {
+		return nil, lenses.ErrFileTooLarge
+	}
+	p := make([]byte, size)
+	n, err := a.readAt(p, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return p[:n], nil
+}
+
+// ReadAtMost reads at most n bytes from the beginning of the log
+func (a *StreamedLogArtifact) ReadAtMost(n int64) ([]byte, error) {
+	if n > a.sizeLimit {
+		return nil, lenses.ErrRequestSizeTooLarge
+	}
+	size, _ := a.Size()
+	p := make([]byte, min(n, size))
+	read, err := a.readAt(p, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if n > size {
+		return p[:read], io.EOF
+	}
+	return p[:read], nil
+}
+
+// ReadTail reads the last n bytes of the log
+func (a *StreamedLogArtifact) ReadTail(n int64) ([]byte, error) {
+	if n > a.sizeLimit {
+		return nil, lenses.ErrRequestSizeTooLarge
+	}
+	size, _ := a.Size()
+	n = min(n, size)
+	p := make([]byte, n)
+	read, err := a.readAt(p, size-n)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return p[:read], nil
+}
@@ -37,8 +37,9 @@ import (
 	"sigs.k8s.io/prow/pkg/statusreconciler/migrator"
 )
 
-// NewController constructs a new controller to reconcile stauses on config change
-func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitDenylistAll sets.Set[string], opener io.Opener, configOpts configflagutil.ConfigOptions, statusURI string, prowJobClient prowv1.ProwJobInterface, githubClient github.Client, pluginAgent *plugins.ConfigAgent) *Controller {
+// NewController constructs a new controller to reconcile stauses on config change.
+// If report is set, the controller only reports the actions it would take.
+func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitDenylistAll sets.Set[string], opener io.Opener, configOpts configflagutil.ConfigOptions, statusURI string, prowJobClient prowv1.ProwJobInterface, githubClient github.Client, pluginAgent *plugins.ConfigAgent, report *ReportOptions) *Controller {
 	sc := &statusController{
 		logger:     logrus.WithField("client", "statusController"),
 		opener:     opener,
@@ -46,7 +47,7 @@ func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitD
 		configOpts: configOpts,
 	}
 
-	return &Controller{
+	c := &Controller{
 		continueOnError:           continueOnError,
 		addedPresubmitDenylist:    addedPresubmitDenylist,
 		addedPresubmitDenylistAll: addedPresubmitDenylistAll,
@@ -67,6 +68,12 @@ func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitD
 		},
 		statusClient: sc,
 	}
+	if report != nil {
+		c.reporter = newReporter(*report, opener, githubClient, continueOnError)
+		c.prowJobTriggerer = c.reporter
+		c.statusMigrator = c.reporter
+	}
+	return c
 }
 
 type statusMigrator interface {
@@ -138,7 +145,7 @@ type githubTrustedChecker struct {
 func (c *githubTrustedChecker) trustedPullRequest(author, org, repo string, num int) (bool, error) {
 	_, trusted, err := trigger.TrustedPullRequest(
 		c.githubClient,
-		c.pluginAgent.Config().TriggerFor(org, repo),
+		c.pluginAgent.Config().ForRepo(org, repo).TriggerFor(org, repo),
 		author, org, repo, num, nil,
 	)
 	return trusted, err
@@ -154,6 +161,9 @@ type Controller struct {
 	statusMigrator            statusMigrator
 	trustedChecker            trustedChecker
 	statusClient              statusClient
+	// reporter is set in report mode, where it replaces both the
+	// prowJobTriggerer and the statusMigrator.
+	reporter *reporter
 }
 
 // Run monitors the incoming configuration changes to determine when statuses need to be
@@ -173,6 +183,11 @@ func (c *Controller) Run(ctx context.Context) {
 			if err := c.reconcile(change, log); err != nil {
 				log.WithError(err).Error("Error reconciling statuses.")
 			}
+			if c.reporter != nil {
+				if err := c.reporter.flush(change, log); err != nil {
+					log.WithError(err).Error("Error reporting planned actions.")
+				}
+			}
 			log.WithField("duration", fmt.Sprintf("%v", time.Since(start))).Info("Statuses reconciled")
 			c.statusClient.Save()
 		case <-ctx.Done():
@@ -229,16 +229,24 @@ func New(mode Mode, client github.Client, org, repo string, targetBranchFilter f
 	}
 }
 
-func (m *Migrator) processPR(pr github.PullRequest) error {
+// actionsForPR returns the statuses to create on a PR.
+func (m *Migrator) actionsForPR(pr github.PullRequest) ([]github.Status, error) {
 	if !m.targetBranchFilter(pr.Base.Ref) {
-		return nil
+		return nil, nil
 	}
 
 	combined, err := m.client.GetCombinedStatus(m.org, m.repo, pr.Head.SHA)
+	if err != nil {
+		return nil, err
+	}
+	return m.processStatuses(combined), nil
+}
+
+func (m *Migrator) processPR(pr github.PullRequest) error {
+	actions, err := m.actionsForPR(pr)
 	if err != nil {
 		return err
 	}
-	actions := m.processStatuses(combined)
 
 	for _, action := range actions {
 		if err := m.client.CreateStatus(m.org, m.repo, pr.Head.SHA, action); err != nil {
@@ -267,3 +275,28 @@ func (m *Migrator) Migrate() error {
 	}
 	return utilerrors.NewAggregate(errors)
 }
+
+// Plan returns the PRs whose statuses Migrate would change, without changing them.
+func (m *Migrator) Plan() ([]github.PullRequest, error) {
+	prs, err := m.client.GetPullRequests(m.org, m.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []github.PullRequest
+	var errors []error
+	for _, pr := range prs {
+		actions, err := m.actionsForPR(pr)
+		if err != nil {
+			if m.continueOnError {
+				errors = append(errors, err)
+				continue
+			}
+			return nil, err
+		}
+		if len(actions) > 0 {
+			planned = append(planned, pr)
+		}
+	}
+	return planned, utilerrors.NewAggregate(errors)
+}
@@ -434,3 +434,90 @@ func TestProcessPR(t *testing.T) {
 		}
 	}
 }
+
+type planGitHubClient struct {
+	prs      []github.PullRequest
+	statuses map[string][]github.Status
+	created  int
+}
+
+func (c *planGitHubClient) GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error) {
+	if ref == "broken" {
+		return nil, errors.New("injected error")
+	}
+	return &github.CombinedStatus{SHA: ref, Statuses: c.statuses[ref]}, nil
+}
+
+func (c *planGitHubClient) CreateStatus(org, repo, SHA string, s github.Status) error {
+	c.created++
+	return nil
+}
+
+func (c *planGitHubClient) GetPullRequests(org, repo string) ([]github.PullRequest, error) {
+	return c.prs, nil
+}
+
+func TestPlan(t *testing.T) {
+	pr := func(number int, branch, sha string) github.PullRequest {
+		return github.PullRequest{Number: number, Base: github.PullRequestBranch{Ref: branch}, Head: github.PullRequestBranch{SHA: sha}}
+	}
+	var testCases = []struct {
+		name            string
+		prs             []github.PullRequest
+		continueOnError bool
+		expected        []int
+		expectedErr     bool
+	}{
+		{
+			name:     "only PRs with the context on matching branches are planned",
+			prs:      []github.PullRequest{pr(1, "main", "with"), pr(2, "main", "without"), pr(3, "other", "with")},
+			expected: []int{1},
+		},
+		{
+			name:        "errors stop planning",
+			prs:         []github.PullRequest{pr(1, "main", "broken"), pr(2, "main", "with")},
+			expectedErr: true,
+		},
+		{
+			name:            "errors are collected when continuing on error",
+			prs:             []github.PullRequest{pr(1, "main", "broken"), pr(2, "main", "with")},
+			continueOnError: true,
+			expected:        []int{2},
+			expectedErr:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &planGitHubClient{
+				prs: testCase.prs,
+				statuses: map[string][]github.Status{
+					"with":    {makeStatus("ctx", "failure", "", "")},
+					"without": {makeStatus("other", "failure", "", "")},
+				},
+			}
+			migrator := Migrator{
+				org:                "org",
+				repo:               "repo",
+				targetBranchFilter: func(branch string) bool { return branch == "main" },
+				continueOnError:    testCase.continueOnError,
+				client:             client,
+				Mode:               *RetireMode("ctx", "", ""),
+			}
+			planned, err := migrator.Plan()
+			if (err != nil) != testCase.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectedErr, err)
+			}
+			var numbers []int
+			for _, pr := range planned {
+				numbers = append(numbers, pr.Number)
+			}
+			if fmt.Sprint(numbers) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected planned PRs %v, got %v", testCase.expected, numbers)
+			}
+			if client.created != 0 {
+				t.Errorf("expected no statuses to be created, got %d", client.created)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/statusreconciler/migrator"
+)
+
+// maxSummaryRows is the number of pull requests listed in the summary comment,
+// to stay well below the maximum size of GitHub comments.
+const maxSummaryRows = 100
+
+// ReportOptions make the controller report the actions it would take for a
+// config change instead of taking them.
+type ReportOptions struct {
+	// URI is the /local/path, gs://path/to/object or s3://path/to/object that
+	// the JSON report of the latest config change is written to.
+	URI string
+	// IssueOrg, IssueRepo and IssueNumber identify an issue to comment a
+	// summary of the report on. Optional.
+	IssueOrg    string
+	IssueRepo   string
+	IssueNumber int
+}
+
+// Report lists the actions the controller would take for a config change.
+type Report struct {
+	OldConfigRevision string `json:"old_config_revision,omitempty"`
+	ConfigRevision    string `json:"config_revision,omitempty"`
+	// PullRequests are the pull requests with planned actions, sorted by
+	// org, repo and number.
+	PullRequests []PullRequestActions `json:"pull_requests"`
+}
+
+// PullRequestActions are the actions planned for a single pull request.
+type PullRequestActions struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	// Triggered are the names of the jobs that would be triggered.
+	Triggered []string `json:"triggered,omitempty"`
+	// Retired are the contexts that would be retired.
+	Retired []string `json:"retired,omitempty"`
+	// Migrated are the contexts that would be migrated.
+	Migrated []ContextMigration `json:"migrated,omitempty"`
+}
+
+// ContextMigration is a context that would be migrated.
+type ContextMigration struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type reportGitHubClient interface {
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+// planner returns the pull requests whose statuses a migrator in the given
+// mode would change.
+type planner func(mode migrator.Mode, org, repo string, targetBranchFilter func(string) bool) ([]github.PullRequest, error)
+
+// reporter records the actions of the controller instead of taking them, and
+// writes them out as a Report. It implements both prowJobTriggerer and
+// statusMigrator.
+type reporter struct {
+	githubClient reportGitHubClient
+	opener       io.Opener
+	options      ReportOptions
+	plan         planner
+
+	pullRequests map[string]*PullRequestActions
+}
+
+func newReporter(options ReportOptions, opener io.Opener, githubClient github.Client, continueOnError bool) *reporter {
+	return &reporter{
+		githubClient: githubClient,
+		opener:       opener,
+		options:      options,
+		plan: func(mode migrator.Mode, org, repo string, targetBranchFilter func(string) bool) ([]github.PullRequest, error) {
+			return migrator.New(mode, githubClient, org, repo, targetBranchFilter, continueOnError).Plan()
+		},
+		pullRequests: map[string]*PullRequestActions{},
+	}
+}
+
+func (r *reporter) actionsFor(org, repo string, number int) *PullRequestActions {
+	key := fmt.Sprintf("%s/%s#%d", org, repo, number)
+	if _, ok := r.pullRequests[key]; !ok {
+		r.pullRequests[key] = &PullRequestActions{Org: org, Repo: repo, Number: number}
+	}
+	return r.pullRequests[key]
+}
+
+func (r *reporter) runAndSkip(pr *github.PullRequest, requestedJobs []config.Presubmit) error {
+	actions := r.actionsFor(pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Number)
+	for _, job := range requestedJobs {
+		actions.Triggered = append(actions.Triggered, job.Name)
+	}
+	return nil
+}
+
+func (r *reporter) retire(org, repo, context string, targetBranchFilter func(string) bool) error {
+	prs, err := r.plan(*migrator.RetireMode(context, "", ""), org, repo, targetBranchFilter)
+	for _, pr := range prs {
+		actions := r.actionsFor(org, repo, pr.Number)
+		actions.Retired = append(actions.Retired, context)
+	}
+	return err
+}
+
+func (r *reporter) migrate(org, repo, from, to string, targetBranchFilter func(string) bool) error {
+	prs, err := r.plan(*migrator.MoveMode(from, to, ""), org, repo, targetBranchFilter)
+	for _, pr := range prs {
+		actions := r.actionsFor(org, repo, pr.Number)
+		actions.Migrated = append(actions.Migrated, ContextMigration{From: from, To: to})
+	}
+	return err
+}
+
+// flush writes out the report of the recorded actions for a config change,
+// and starts recording a new one.
+func (r *reporter) flush(delta config.Delta, log *logrus.Entry) error {
+	report := Report{
+		OldConfigRevision: delta.Before.ConfigVersionSHA,
+		ConfigRevision:    delta.After.ConfigVersionSHA,
+		PullRequests:      []PullRequestActions{},
+	}
+	for _, actions := range r.pullRequests {
+		report.PullRequests = append(report.PullRequests, *actions)
+	}
+	r.pullRequests = map[string]*PullRequestActions{}
+	sort.Slice(report.PullRequests, func(i, j int) bool {
+		a, b := report.PullRequests[i], report.PullRequests[j]
+		if a.Org != b.Org {
+			return a.Org < b.Org
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Number < b.Number
+	})
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := io.WriteContent(ctx, log, r.opener, r.options.URI, content); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	log.WithField("pull_requests", len(report.PullRequests)).Info("Wrote report of planned actions.")
+
+	if r.options.IssueNumber == 0 || len(report.PullRequests) == 0 {
+		return nil
+	}
+	if err := r.githubClient.CreateComment(r.options.IssueOrg, r.options.IssueRepo, r.options.IssueNumber, summarize(report, r.options.URI)); err != nil {
+		return fmt.Errorf("failed to comment summary on %s/%s#%d: %w", r.options.IssueOrg, r.options.IssueRepo, r.options.IssueNumber, err)
+	}
+	return nil
+}
+
+// summarize renders a report as a markdown comment.
+func summarize(report Report, uri string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "status-reconciler planned actions on %d pull requests for the config change from `%s` to `%s`.\n", len(report.PullRequests), report.OldConfigRevision, report.ConfigRevision)
+	fmt.Fprintf(&b, "The full report is at `%s`.\n\n", uri)
+	b.WriteString("| Pull request | Triggered jobs | Retired contexts | Migrated contexts |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for i, pr := range report.PullRequests {
+		if i == maxSummaryRows {
+			fmt.Fprintf(&b, "\nand %d more pull requests.\n", len(report.PullRequests)-maxSummaryRows)
+			break
+		}
+		var migrated []string
+		for _, migration := range pr.Migrated {
+			migrated = append(migrated, fmt.Sprintf("%s → %s", migration.From, migration.To))
+		}
+		fmt.Fprintf(&b, "| %s/%s#%d | %s | %s | %s |\n", pr.Org, pr.Repo, pr.Number, strings.Join(pr.Triggered, ", "), strings.Join(pr.Retired, ", "), strings.Join(migrated, ", "))
+	}
+	return b.String()
+}
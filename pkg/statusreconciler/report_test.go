@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusreconciler
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+	"sigs.k8s.io/prow/pkg/statusreconciler/migrator"
+)
+
+type fakeCommentClient struct {
+	comments map[string]string
+	err      error
+}
+
+func (c *fakeCommentClient) CreateComment(org, repo string, number int, comment string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.comments[org+"/"+repo] = comment
+	return nil
+}
+
+func TestReporter(t *testing.T) {
+	pr := func(org, repo string, number int) *github.PullRequest {
+		pr := &github.PullRequest{Number: number}
+		pr.Base.Repo.Owner.Login = org
+		pr.Base.Repo.Name = repo
+		return pr
+	}
+	delta := config.Delta{}
+	delta.Before.ConfigVersionSHA = "old"
+	delta.After.ConfigVersionSHA = "new"
+
+	testCases := []struct {
+		name            string
+		record          func(r *reporter) error
+		issue           bool
+		commentErr      error
+		expected        Report
+		expectedComment bool
+		expectedErr     bool
+	}{
+		{
+			name:   "no actions",
+			record: func(r *reporter) error { return nil },
+			issue:  true,
+			expected: Report{
+				OldConfigRevision: "old",
+				ConfigRevision:    "new",
+				PullRequests:      []PullRequestActions{},
+			},
+		},
+		{
+			name: "actions are grouped by pull request",
+			record: func(r *reporter) error {
+				if err := r.runAndSkip(pr("org", "repo", 2), []config.Presubmit{{JobBase: config.JobBase{Name: "a"}}, {JobBase: config.JobBase{Name: "b"}}}); err != nil {
+					return err
+				}
+				if err := r.retire("org", "repo", "retired", nil); err != nil {
+					return err
+				}
+				return r.migrate("org", "other", "from", "to", nil)
+			},
+			issue: true,
+			expected: Report{
+				OldConfigRevision: "old",
+				ConfigRevision:    "new",
+				PullRequests: []PullRequestActions{
+					{Org: "org", Repo: "other", Number: 1, Migrated: []ContextMigration{{From: "from", To: "to"}}},
+					{Org: "org", Repo: "repo", Number: 1, Retired: []string{"retired"}},
+					{Org: "org", Repo: "repo", Number: 2, Triggered: []string{"a", "b"}, Retired: []string{"retired"}},
+				},
+			},
+			expectedComment: true,
+		},
+		{
+			name: "no comment without issue",
+			record: func(r *reporter) error {
+				return r.runAndSkip(pr("org", "repo", 2), []config.Presubmit{{JobBase: config.JobBase{Name: "a"}}})
+			},
+			expected: Report{
+				OldConfigRevision: "old",
+				ConfigRevision:    "new",
+				PullRequests:      []PullRequestActions{{Org: "org", Repo: "repo", Number: 2, Triggered: []string{"a"}}},
+			},
+		},
+		{
+			name: "comment error is returned",
+			record: func(r *reporter) error {
+				return r.runAndSkip(pr("org", "repo", 2), []config.Presubmit{{JobBase: config.JobBase{Name: "a"}}})
+			},
+			issue:      true,
+			commentErr: errors.New("injected error"),
+			expected: Report{
+				OldConfigRevision: "old",
+				ConfigRevision:    "new",
+				PullRequests:      []PullRequestActions{{Org: "org", Repo: "repo", Number: 2, Triggered: []string{"a"}}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := &fakeopener.FakeOpener{}
+			client := &fakeCommentClient{comments: map[string]string{}, err: tc.commentErr}
+			options := ReportOptions{URI: "gs://bucket/report.json"}
+			if tc.issue {
+				options.IssueOrg, options.IssueRepo, options.IssueNumber = "admin", "issues", 1
+			}
+			r := &reporter{
+				githubClient: client,
+				opener:       opener,
+				options:      options,
+				plan: func(_ migrator.Mode, org, repo string, _ func(string) bool) ([]github.PullRequest, error) {
+					prs := []github.PullRequest{*pr(org, repo, 1)}
+					if repo == "repo" {
+						prs = append(prs, *pr(org, repo, 2))
+					}
+					return prs, nil
+				},
+				pullRequests: map[string]*PullRequestActions{},
+			}
+			if err := tc.record(r); err != nil {
+				t.Fatalf("failed to record actions: %v", err)
+			}
+			err := r.flush(delta, logrusEntry())
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+
+			var report Report
+			if err := json.Unmarshal(opener.Buffer[options.URI].Bytes(), &report); err != nil {
+				t.Fatalf("failed to unmarshal report: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, report); diff != "" {
+				t.Errorf("report differs from expected (-want +got):\n%s", diff)
+			}
+
+			comment, commented := client.comments["admin/issues"]
+			if commented != tc.expectedComment {
+				t.Fatalf("expected comment: %t, got: %q", tc.expectedComment, comment)
+			}
+			if commented && !strings.Contains(comment, "| org/repo#2 | a, b | retired |  |") {
+				t.Errorf("comment does not list the planned actions:\n%s", comment)
+			}
+
+			if len(r.pullRequests) != 0 {
+				t.Errorf("expected recorded actions to be reset, got %v", r.pullRequests)
+			}
+		})
+	}
+}
@@ -249,6 +249,14 @@ type provider interface {
 	// nilcheck that pointer before accessing it.
 	GetPresubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Presubmit, error)
 	GetChangedFiles(org, repo string, number int) ([]string, error)
+	// isMerged returns whether the PR is merged. It is used for PRs that are
+	// linked with pool PRs but are not part of the pool themselves.
+	isMerged(org, repo string, number int) (bool, error)
+	// isUpToDate returns whether the PR contains the base HEAD of the subpool,
+	// and updateBranch updates the PR with the base HEAD. They are used for
+	// subpools that require PRs to be up to date before they merge.
+	isUpToDate(sp subpool, crc *CodeReviewCommon) (bool, error)
+	updateBranch(crc *CodeReviewCommon) error
 
 	refsForJob(sp subpool, prs []CodeReviewCommon) (prowapi.Refs, error)
 	labelsAndAnnotations(instance string, jobLabels, jobAnnotations map[string]string, changes ...CodeReviewCommon) (labels, annotations map[string]string)
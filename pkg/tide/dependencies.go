@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// dependsOnRe matches the lines of PR descriptions that link a PR with a PR of
+// another repo, e.g. "Depends-On: org/repo#123".
+var dependsOnRe = regexp.MustCompile(`(?mi)^[ \t]*Depends-On:[ \t]*([\w.-]+)/([\w.-]+)#(\d+)[ \t]*\r?$`)
+
+// linkedPR identifies a PR that is linked with other PRs.
+type linkedPR struct {
+	org    string
+	repo   string
+	number int
+}
+
+func (l linkedPR) String() string {
+	return fmt.Sprintf("%s/%s#%d", l.org, l.repo, l.number)
+}
+
+// dependencies returns the PRs that the PR depends on.
+func dependencies(pr *CodeReviewCommon) []linkedPR {
+	var deps []linkedPR
+	for _, match := range dependsOnRe.FindAllStringSubmatch(pr.Body, -1) {
+		number, err := strconv.Atoi(match[3])
+		if err != nil {
+			continue
+		}
+		deps = append(deps, linkedPR{org: match[1], repo: match[2], number: number})
+	}
+	return deps
+}
+
+// dependencyGroups partitions the PRs that are linked through Depends-On lines,
+// directly or transitively, into groups that have to merge together. The
+// groups also contain the linked PRs that are not part of prs.
+func dependencyGroups(prs []CodeReviewCommon) [][]linkedPR {
+	parent := map[linkedPR]linkedPR{}
+	find := func(pr linkedPR) linkedPR {
+		for parent[pr] != pr {
+			pr = parent[pr]
+		}
+		return pr
+	}
+	add := func(pr linkedPR) {
+		if _, ok := parent[pr]; !ok {
+			parent[pr] = pr
+		}
+	}
+	for _, pr := range prs {
+		deps := dependencies(&pr)
+		if len(deps) == 0 {
+			continue
+		}
+		self := linkedPR{org: pr.Org, repo: pr.Repo, number: pr.Number}
+		add(self)
+		for _, dep := range deps {
+			add(dep)
+			parent[find(dep)] = find(self)
+		}
+	}
+
+	members := map[linkedPR][]linkedPR{}
+	for pr := range parent {
+		root := find(pr)
+		members[root] = append(members[root], pr)
+	}
+	var groups [][]linkedPR
+	for _, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].String() < group[j].String() })
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].String() < groups[j][0].String() })
+	return groups
+}
+
+// linkDependentPRs links the PRs of the subpools with the PRs they depend on,
+// if cross repo dependencies are enabled. All pool PRs of a group are held back
+// from merging until every PR of the group is either merged or ready to merge.
+func (c *syncController) linkDependentPRs(sps map[string]*subpool) {
+	if !c.config().Tide.CrossRepoDependencies {
+		return
+	}
+
+	type poolPR struct {
+		sp *subpool
+		pr CodeReviewCommon
+	}
+	poolPRs := map[linkedPR]poolPR{}
+	var prs []CodeReviewCommon
+	for _, sp := range sps {
+		for _, pr := range sp.prs {
+			poolPRs[linkedPR{org: pr.Org, repo: pr.Repo, number: pr.Number}] = poolPR{sp: sp, pr: pr}
+			prs = append(prs, pr)
+		}
+	}
+
+	for _, group := range dependencyGroups(prs) {
+		for _, member := range group {
+			poolPR, ok := poolPRs[member]
+			if !ok {
+				continue
+			}
+			sp := poolPR.sp
+			for _, other := range group {
+				otherPR, ok := poolPRs[other]
+				if !ok || otherPR.sp == sp {
+					continue
+				}
+				refs, err := c.provider.refsForJob(*otherPR.sp, []CodeReviewCommon{otherPR.pr})
+				if err != nil {
+					sp.log.WithError(err).Warnf("Failed creating refs for linked PR %s.", other)
+					continue
+				}
+				if sp.linked == nil {
+					sp.linked = map[int][]prowapi.Refs{}
+				}
+				sp.linked[member.number] = append(sp.linked[member.number], refs)
+			}
+		}
+
+		ready := true
+		var waitingFor []string
+		for _, member := range group {
+			if poolPR, ok := poolPRs[member]; ok {
+				if !c.readyToMerge(poolPR.sp, poolPR.pr) {
+					ready = false
+					waitingFor = append(waitingFor, member.String())
+				}
+				continue
+			}
+			if !c.dependencyMerged(member) {
+				ready = false
+				waitingFor = append(waitingFor, member.String())
+			}
+		}
+
+		for _, member := range group {
+			poolPR, ok := poolPRs[member]
+			if !ok {
+				continue
+			}
+			sp := poolPR.sp
+			if !ready {
+				if sp.held == nil {
+					sp.held = sets.New[int]()
+				}
+				sp.held.Insert(member.number)
+				sp.log.WithFields(poolPR.pr.logFields()).WithField("waiting-for", waitingFor).Debug("Holding linked PR.")
+			}
+		}
+	}
+}
+
+// readyToMerge returns whether the pool PR passed its tests. Decorated jobs
+// only count if they tested the PR together with the current heads of all the
+// pool PRs it is linked with, so that a push to a linked PR requires testing
+// again. Undecorated jobs never clone the linked PRs.
+func (c *syncController) readyToMerge(sp *subpool, pr CodeReviewCommon) bool {
+	var pjs []prowapi.ProwJob
+	for _, pj := range sp.pjs {
+		if testedWithLinkedRefs(pj, sp.linked[pr.Number]) {
+			pjs = append(pjs, pj)
+		}
+	}
+	successes, _, _, _ := c.accumulate(sp.presubmits, []CodeReviewCommon{pr}, pjs, sp.sha)
+	return len(successes) > 0 && c.isPassingTests(sp.log, &pr, sp.cc[pr.Number])
+}
+
+// testedWithLinkedRefs returns whether the extra refs of the decorated job
+// contain the linked refs, with the same base and pull request heads.
+func testedWithLinkedRefs(pj prowapi.ProwJob, linked []prowapi.Refs) bool {
+	if pj.Spec.DecorationConfig == nil {
+		return true
+	}
+	for _, refs := range linked {
+		found := false
+		for _, extra := range pj.Spec.ExtraRefs {
+			if extra.Org == refs.Org && extra.Repo == refs.Repo && extra.BaseSHA == refs.BaseSHA && samePulls(extra.Pulls, refs.Pulls) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func samePulls(a, b []prowapi.Pull) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Number != b[i].Number || a[i].SHA != b[i].SHA {
+			return false
+		}
+	}
+	return true
+}
+
+// dependencyMerged returns whether the linked PR that is not part of the pool
+// is merged.
+func (c *syncController) dependencyMerged(pr linkedPR) bool {
+	if c.mergedDependencies.Has(pr.String()) {
+		return true
+	}
+	merged, err := c.provider.isMerged(pr.org, pr.repo, pr.number)
+	if err != nil {
+		c.logger.WithError(err).Warnf("Failed to check if linked PR %s is merged.", pr)
+		return false
+	}
+	if merged {
+		if c.mergedDependencies == nil {
+			c.mergedDependencies = sets.New[string]()
+		}
+		c.mergedDependencies.Insert(pr.String())
+	}
+	return merged
+}
+
+// withoutPRs returns the PRs whose numbers are not excluded.
+func withoutPRs(prs []CodeReviewCommon, exclude func(number int) bool) []CodeReviewCommon {
+	var res []CodeReviewCommon
+	for _, pr := range prs {
+		if !exclude(pr.Number) {
+			res = append(res, pr)
+		}
+	}
+	return res
+}
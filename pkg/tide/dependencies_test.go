@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/tide/history"
+)
+
+func TestDependencies(t *testing.T) {
+	pr := CodeReviewCommon{Body: "Fixes the API.\n\nDepends-On: org/repo#12\r\ndepends-on:   kubernetes/test-infra.git#3  \nDepends-On: org/repo#abc\nSee Depends-On: org/other#1"}
+	expected := []linkedPR{
+		{org: "org", repo: "repo", number: 12},
+		{org: "kubernetes", repo: "test-infra.git", number: 3},
+	}
+	if diff := cmp.Diff(expected, dependencies(&pr), cmp.AllowUnexported(linkedPR{})); diff != "" {
+		t.Errorf("dependencies differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestDependencyGroups(t *testing.T) {
+	pr := func(org, repo string, number int, body string) CodeReviewCommon {
+		return CodeReviewCommon{Org: org, Repo: repo, Number: number, Body: body}
+	}
+	prs := []CodeReviewCommon{
+		pr("org", "a", 1, "Depends-On: org/b#2"),
+		pr("org", "b", 2, "Depends-On: org/c#3"),
+		pr("org", "c", 3, ""),
+		pr("org", "a", 4, ""),
+		pr("org", "a", 5, "Depends-On: org/a#5"),
+		pr("org", "d", 6, "Depends-On: other/e#7"),
+	}
+	expected := [][]linkedPR{
+		{{org: "org", repo: "a", number: 1}, {org: "org", repo: "b", number: 2}, {org: "org", repo: "c", number: 3}},
+		{{org: "org", repo: "d", number: 6}, {org: "other", repo: "e", number: 7}},
+	}
+	if diff := cmp.Diff(expected, dependencyGroups(prs), cmp.AllowUnexported(linkedPR{})); diff != "" {
+		t.Errorf("groups differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestSyncLinkedPRs(t *testing.T) {
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	linked := func(org, repo string, number int, body string) PullRequest {
+		pr := testPR(org, repo, "main", number, githubql.MergeableStateMergeable)
+		pr.Body = githubql.String(body)
+		return *pr
+	}
+	testcases := []struct {
+		name      string
+		prs       []PullRequest
+		mergedPRs sets.Set[string]
+
+		expectedActions map[string]Action
+		expectedMerged  int
+	}{
+		{
+			name: "all linked PRs are ready",
+			prs: []PullRequest{
+				linked("org", "a", 1, "Depends-On: org/b#2"),
+				linked("org", "b", 2, ""),
+			},
+			expectedActions: map[string]Action{"org/a": Merge, "org/b": Merge},
+			expectedMerged:  2,
+		},
+		{
+			name: "linked PR is not in the pool",
+			prs: []PullRequest{
+				linked("org", "a", 1, "Depends-On: org/b#2"),
+			},
+			expectedActions: map[string]Action{"org/a": Wait},
+		},
+		{
+			name: "linked PR is already merged",
+			prs: []PullRequest{
+				linked("org", "a", 1, "Depends-On: org/b#2"),
+			},
+			mergedPRs:       sets.New("org/b#2"),
+			expectedActions: map[string]Action{"org/a": Merge},
+			expectedMerged:  1,
+		},
+		{
+			name: "linked PR is not mergeable",
+			prs: []PullRequest{
+				linked("org", "a", 1, "Depends-On: org/b#2"),
+				*testPR("org", "b", "main", 2, githubql.MergeableStateConflicting),
+				linked("org", "a", 3, ""),
+			},
+			expectedActions: map[string]Action{"org/a": Merge},
+			expectedMerged:  1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fgc := &fgc{
+				prs: map[string][]PullRequest{"": tc.prs},
+				refs: map[string]string{
+					"org/a heads/main": "SHA",
+					"org/b heads/main": "SHA",
+				},
+				mergedPRs: tc.mergedPRs,
+			}
+			ca := &config.Agent{}
+			ca.Set(&config.Config{
+				ProwConfig: config.ProwConfig{
+					Tide: config.Tide{
+						MaxGoroutines: 4,
+						TideGitHubConfig: config.TideGitHubConfig{
+							Queries:               []config.TideQuery{{}},
+							StatusUpdatePeriod:    &metav1.Duration{Duration: time.Second * 0},
+							CrossRepoDependencies: true,
+						},
+					},
+				},
+			})
+			hist, err := history.New(100, nil, "")
+			if err != nil {
+				t.Fatalf("Failed to create history client: %v", err)
+			}
+			ctx := context.Background()
+			mgr := newFakeManager(t, ctx)
+			log := logrus.WithField("controller", "sync")
+			ghProvider := newGitHubProvider(log, fgc, nil, ca.Config, newMergeChecker(ca.Config, fgc), false)
+			c := &syncController{
+				config:        ca.Config,
+				provider:      ghProvider,
+				prowJobClient: mgr.GetClient(),
+				logger:        log,
+				changedFiles: &changedFilesAgent{
+					provider:        ghProvider,
+					nextChangeCache: make(map[changeCacheKey][]string),
+				},
+				History: hist,
+				statusUpdate: &statusUpdate{
+					dontUpdateStatus: &threadSafePRSet{},
+					newPoolPending:   make(chan bool),
+				},
+			}
+
+			if err := c.Sync(); err != nil {
+				t.Fatalf("Unexpected error from 'Sync()': %v.", err)
+			}
+			actions := map[string]Action{}
+			for _, pool := range c.pools {
+				actions[pool.Org+"/"+pool.Repo] = pool.Action
+			}
+			if diff := cmp.Diff(tc.expectedActions, actions); diff != "" {
+				t.Errorf("actions differ from expected (-want +got):\n%s", diff)
+			}
+			if fgc.merged != tc.expectedMerged {
+				t.Errorf("expected %d merges, got %d", tc.expectedMerged, fgc.merged)
+			}
+		})
+	}
+}
+
+func TestTriggerLinkedPR(t *testing.T) {
+	linkedRefs := prowapi.Refs{Org: "org", Repo: "b", BaseRef: "main", BaseSHA: "SHA", Pulls: []prowapi.Pull{{Number: 2, SHA: "head"}}}
+	ca := &config.Agent{}
+	ca.Set(&config.Config{})
+	ctx := context.Background()
+	mgr := newFakeManager(t, ctx)
+	log := logrus.WithField("controller", "sync")
+	ghProvider := newGitHubProvider(log, &fgc{}, nil, ca.Config, nil, false)
+	c := &syncController{
+		ctx:           ctx,
+		config:        ca.Config,
+		provider:      ghProvider,
+		prowJobClient: mgr.GetClient(),
+		logger:        log,
+	}
+	sp := subpool{
+		log:    log,
+		org:    "org",
+		repo:   "a",
+		branch: "main",
+		sha:    "SHA",
+		linked: map[int][]prowapi.Refs{1: {linkedRefs}},
+	}
+	decorated := config.Presubmit{JobBase: config.JobBase{Name: "decorated", UtilityConfig: config.UtilityConfig{Decorate: ptr.To(true)}}, Reporter: config.Reporter{Context: "decorated"}}
+	undecorated := config.Presubmit{JobBase: config.JobBase{Name: "undecorated"}, Reporter: config.Reporter{Context: "undecorated"}}
+	if err := c.trigger(sp, []config.Presubmit{decorated, undecorated}, []CodeReviewCommon{{Number: 1, HeadRefOID: "head"}}); err != nil {
+		t.Fatalf("failed to trigger jobs: %v", err)
+	}
+
+	pjs := &prowapi.ProwJobList{}
+	if err := c.prowJobClient.List(ctx, pjs); err != nil {
+		t.Fatalf("failed to list ProwJobs: %v", err)
+	}
+	extraRefs := map[string][]prowapi.Refs{}
+	for _, pj := range pjs.Items {
+		extraRefs[pj.Spec.Job] = pj.Spec.ExtraRefs
+	}
+	expected := map[string][]prowapi.Refs{"decorated": {linkedRefs}, "undecorated": nil}
+	if diff := cmp.Diff(expected, extraRefs); diff != "" {
+		t.Errorf("extra refs differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestTestedWithLinkedRefs(t *testing.T) {
+	linkedRefs := prowapi.Refs{Org: "org", Repo: "b", BaseRef: "main", BaseSHA: "SHA", Pulls: []prowapi.Pull{{Number: 2, SHA: "head"}}}
+	pushed := linkedRefs
+	pushed.Pulls = []prowapi.Pull{{Number: 2, SHA: "new-head"}}
+	decorated := func(extraRefs ...prowapi.Refs) prowapi.ProwJob {
+		return prowapi.ProwJob{Spec: prowapi.ProwJobSpec{DecorationConfig: &prowapi.DecorationConfig{}, ExtraRefs: extraRefs}}
+	}
+	testcases := []struct {
+		name     string
+		pj       prowapi.ProwJob
+		linked   []prowapi.Refs
+		expected bool
+	}{
+		{
+			name:     "no linked PRs",
+			pj:       decorated(),
+			expected: true,
+		},
+		{
+			name:     "tested with the current head",
+			pj:       decorated(linkedRefs),
+			linked:   []prowapi.Refs{linkedRefs},
+			expected: true,
+		},
+		{
+			name:   "tested with an old head",
+			pj:     decorated(linkedRefs),
+			linked: []prowapi.Refs{pushed},
+		},
+		{
+			name:   "tested without the linked PR",
+			pj:     decorated(),
+			linked: []prowapi.Refs{linkedRefs},
+		},
+		{
+			name:     "undecorated job",
+			pj:       prowapi.ProwJob{},
+			linked:   []prowapi.Refs{linkedRefs},
+			expected: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := testedWithLinkedRefs(tc.pj, tc.linked); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
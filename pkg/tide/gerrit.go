@@ -18,6 +18,7 @@ package tide
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -415,6 +416,20 @@ func (p *GerritProvider) GetChangedFiles(org, repo string, number int) ([]string
 	return client.ChangedFilesProvider(change)()
 }
 
+func (p *GerritProvider) isMerged(org, repo string, number int) (bool, error) {
+	// Linking changes of different repos is only supported on GitHub.
+	return false, nil
+}
+
+func (p *GerritProvider) isUpToDate(sp subpool, crc *CodeReviewCommon) (bool, error) {
+	// Requiring changes to be up to date is only supported on GitHub.
+	return true, nil
+}
+
+func (p *GerritProvider) updateBranch(crc *CodeReviewCommon) error {
+	return errors.New("updating the branch of a change is not supported on Gerrit")
+}
+
 func (p *GerritProvider) refsForJob(sp subpool, prs []CodeReviewCommon) (prowapi.Refs, error) {
 	var changes []client.ChangeInfo
 	for _, pr := range prs {
@@ -136,6 +136,11 @@ func (gi *GitHubProvider) Query() (map[string]CodeReviewCommon, error) {
 				}
 
 				for _, pr := range results {
+					// The search cannot exclude labels by pattern.
+					if label, ok := missingLabel(query, pr); ok {
+						gi.logger.WithFields(pr.logFields()).WithField("label", label).Debug("Ignoring PR with a label matching a missing label pattern.")
+						continue
+					}
 					crc := CodeReviewCommonFromPullRequest(&pr)
 					prs[prKey(crc)] = *crc
 				}
@@ -147,6 +152,17 @@ func (gi *GitHubProvider) Query() (map[string]CodeReviewCommon, error) {
 	return prs, utilerrors.NewAggregate(errs)
 }
 
+// missingLabel returns the first label of the PR that the query requires to be
+// missing.
+func missingLabel(query config.TideQuery, pr PullRequest) (string, bool) {
+	for _, label := range pr.Labels.Nodes {
+		if query.IsMissingLabel(string(label.Name)) {
+			return string(label.Name), true
+		}
+	}
+	return "", false
+}
+
 func (gi *GitHubProvider) GetRef(org, repo, ref string) (string, error) {
 	return gi.ghc.GetRef(org, repo, ref)
 }
@@ -391,6 +407,28 @@ func (gi *GitHubProvider) GetChangedFiles(org, repo string, number int) ([]strin
 	return files, nil
 }
 
+func (gi *GitHubProvider) isMerged(org, repo string, number int) (bool, error) {
+	pr, err := gi.ghc.GetPullRequest(org, repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed get PR: %v", err)
+	}
+	return pr.Merged, nil
+}
+
+func (gi *GitHubProvider) isUpToDate(sp subpool, crc *CodeReviewCommon) (bool, error) {
+	r, err := gi.gc.ClientFor(sp.org, sp.repo)
+	if err != nil {
+		return false, err
+	}
+	defer r.Clean()
+	return r.IsAncestor(sp.sha, crc.HeadRefOID)
+}
+
+func (gi *GitHubProvider) updateBranch(crc *CodeReviewCommon) error {
+	// Fails if the PR changed in the meantime, so that it is not updated twice.
+	return gi.ghc.UpdatePullRequestBranch(crc.Org, crc.Repo, crc.Number, &crc.HeadRefOID)
+}
+
 func (gi *GitHubProvider) refsForJob(sp subpool, prs []CodeReviewCommon) (prowapi.Refs, error) {
 	refs := prowapi.Refs{
 		Org:     sp.org,
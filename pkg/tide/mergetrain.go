@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// maxJobDurations is the number of the latest completed runs per job whose
+// durations are used to predict how long testing takes.
+const maxJobDurations = 10
+
+// MergeTrain is the predicted order in which the PRs of a pool merge.
+type MergeTrain struct {
+	Org    string
+	Repo   string
+	Branch string
+
+	// Cars are the PRs of the pool in the order in which they are predicted
+	// to merge.
+	Cars []MergeTrainCar
+	// TestDuration is the predicted duration of testing a PR or a batch, based
+	// on the durations of the latest completed ProwJobs of the pool. It is zero
+	// if no ProwJob of the pool completed yet, in which case no ETAs are
+	// predicted.
+	TestDuration time.Duration
+
+	// All of the TenantIDs associated with PRs in the pool.
+	TenantIDs []string
+}
+
+// MergeTrainCar is a PR of a MergeTrain.
+type MergeTrainCar struct {
+	Number int
+	Title  string
+	Author string
+	SHA    string
+
+	// Batch is the index of the batch the PR is predicted to merge in. PRs
+	// that already passed tests are in batch 0.
+	Batch int
+	// ETA is the predicted time of the merge, if it can be predicted.
+	ETA *time.Time `json:",omitempty"`
+}
+
+// predictMergeTrain orders the PRs of the pool in the way Tide merges them:
+// PRs that passed tests merge first, followed by the pending batch, the PRs
+// that are being tested and the PRs that still need to be tested, each in the
+// order of their numbers. The PRs after the passing ones are grouped into
+// batches of at most batchSizeLimit PRs, which are tested one after the other.
+// pendingSince is the start of testing the pending batch.
+func predictMergeTrain(pool Pool, batchSizeLimit int, testDuration time.Duration, pendingSince, now time.Time) MergeTrain {
+	train := MergeTrain{
+		Org:          pool.Org,
+		Repo:         pool.Repo,
+		Branch:       pool.Branch,
+		TestDuration: testDuration,
+		TenantIDs:    pool.TenantIDs,
+	}
+	seen := map[int]bool{}
+	add := func(pr CodeReviewCommon, batch int, eta *time.Time) {
+		seen[pr.Number] = true
+		train.Cars = append(train.Cars, MergeTrainCar{
+			Number: pr.Number,
+			Title:  pr.Title,
+			Author: pr.AuthorLogin,
+			SHA:    pr.HeadRefOID,
+			Batch:  batch,
+			ETA:    eta,
+		})
+	}
+	eta := func(at time.Time) *time.Time {
+		if testDuration == 0 {
+			return nil
+		}
+		if at.Before(now) {
+			at = now
+		}
+		return &at
+	}
+
+	batch := 0
+	for _, pr := range byNumber(pool.SuccessPRs) {
+		add(pr, batch, eta(now))
+	}
+	if len(pool.SuccessPRs) > 0 {
+		batch++
+	}
+	// The pending batch finishes testing first, every later batch needs a
+	// full test run.
+	next := now.Add(testDuration)
+	if len(pool.BatchPending) > 0 {
+		if pendingSince.IsZero() {
+			pendingSince = now
+		}
+		pendingETA := eta(pendingSince.Add(testDuration))
+		added := false
+		for _, pr := range byNumber(pool.BatchPending) {
+			if seen[pr.Number] {
+				continue
+			}
+			add(pr, batch, pendingETA)
+			added = true
+		}
+		if added {
+			batch++
+			if pendingETA != nil {
+				next = pendingETA.Add(testDuration)
+			}
+		}
+	}
+
+	// A limit of 0 means unlimited batches, a negative one disables batching.
+	if batchSizeLimit < 0 {
+		batchSizeLimit = 1
+	}
+	inBatch := 0
+	for _, prs := range [][]CodeReviewCommon{pool.PendingPRs, pool.MissingPRs} {
+		for _, pr := range byNumber(prs) {
+			if seen[pr.Number] {
+				continue
+			}
+			if batchSizeLimit > 0 && inBatch == batchSizeLimit {
+				batch++
+				inBatch = 0
+				next = next.Add(testDuration)
+			}
+			add(pr, batch, eta(next))
+			inBatch++
+		}
+	}
+	return train
+}
+
+// byNumber returns a copy of the PRs sorted by their numbers.
+func byNumber(prs []CodeReviewCommon) []CodeReviewCommon {
+	sorted := append([]CodeReviewCommon(nil), prs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+	return sorted
+}
+
+// pendingBatchStart returns the earliest start of the pending batch jobs, or
+// the zero time if there are none.
+func pendingBatchStart(pjs []prowapi.ProwJob) time.Time {
+	var start time.Time
+	for _, pj := range pjs {
+		if pj.Spec.Type != prowapi.BatchJob || pj.Complete() {
+			continue
+		}
+		if start.IsZero() || pj.Status.StartTime.Time.Before(start) {
+			start = pj.Status.StartTime.Time
+		}
+	}
+	return start
+}
+
+// jobDurations remembers the durations of the latest completed ProwJobs of each
+// pool to predict how long testing takes.
+type jobDurations struct {
+	sync.Mutex
+	// runs maps pool keys to job names to the latest completed runs.
+	runs map[string]map[string][]jobRun
+}
+
+type jobRun struct {
+	name     string
+	duration time.Duration
+}
+
+// observe records the durations of the completed ProwJobs of the pool.
+func (d *jobDurations) observe(poolKey string, pjs []prowapi.ProwJob) {
+	d.Lock()
+	defer d.Unlock()
+	if d.runs == nil {
+		d.runs = map[string]map[string][]jobRun{}
+	}
+	if d.runs[poolKey] == nil {
+		d.runs[poolKey] = map[string][]jobRun{}
+	}
+	for _, pj := range pjs {
+		if !pj.Complete() || pj.Status.StartTime.IsZero() {
+			continue
+		}
+		runs := d.runs[poolKey][pj.Spec.Job]
+		known := false
+		for _, run := range runs {
+			if run.name == pj.Name {
+				known = true
+				break
+			}
+		}
+		if known {
+			continue
+		}
+		runs = append(runs, jobRun{name: pj.Name, duration: pj.Status.CompletionTime.Sub(pj.Status.StartTime.Time)})
+		if len(runs) > maxJobDurations {
+			runs = runs[len(runs)-maxJobDurations:]
+		}
+		d.runs[poolKey][pj.Spec.Job] = runs
+	}
+}
+
+// expected returns the predicted duration of testing in the pool. As the jobs
+// run in parallel, this is the longest of the average durations of the jobs.
+func (d *jobDurations) expected(poolKey string) time.Duration {
+	d.Lock()
+	defer d.Unlock()
+	var longest time.Duration
+	for _, runs := range d.runs[poolKey] {
+		if len(runs) == 0 {
+			continue
+		}
+		var total time.Duration
+		for _, run := range runs {
+			total += run.duration
+		}
+		if average := total / time.Duration(len(runs)); average > longest {
+			longest = average
+		}
+	}
+	return longest
+}
+
+func sortMergeTrains(trains []MergeTrain) {
+	sort.Slice(trains, func(i, j int) bool {
+		if trains[i].Org != trains[j].Org {
+			return trains[i].Org < trains[j].Org
+		}
+		if trains[i].Repo != trains[j].Repo {
+			return trains[i].Repo < trains[j].Repo
+		}
+		return trains[i].Branch < trains[j].Branch
+	})
+}
+
+// mergeTrain predicts the merge train of the synced subpool.
+func (c *syncController) mergeTrain(sp *subpool, pool Pool) MergeTrain {
+	key := poolKey(sp.org, sp.repo, sp.branch)
+	c.jobDurations.observe(key, sp.pjs)
+	batchSizeLimit := c.config().Tide.BatchSizeLimit(config.OrgRepo{Org: sp.org, Repo: sp.repo})
+	return predictMergeTrain(pool, batchSizeLimit, c.jobDurations.expected(key), pendingBatchStart(sp.pjs), time.Now())
+}
+
+func (c *syncController) serveMergeTrains(w http.ResponseWriter, r *http.Request) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	b, err := json.Marshal(c.mergeTrains)
+	if err != nil {
+		c.logger.WithError(err).Error("Encoding JSON.")
+		b = []byte("[]")
+	}
+	if _, err = w.Write(b); err != nil {
+		c.logger.WithError(err).Error("Writing JSON response.")
+	}
+}
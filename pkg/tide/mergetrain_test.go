@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestPredictMergeTrain(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	prs := func(numbers ...int) []CodeReviewCommon {
+		var res []CodeReviewCommon
+		for _, number := range numbers {
+			res = append(res, CodeReviewCommon{Number: number})
+		}
+		return res
+	}
+	testCases := []struct {
+		name           string
+		pool           Pool
+		batchSizeLimit int
+		testDuration   time.Duration
+		pendingSince   time.Time
+		expected       []MergeTrainCar
+	}{
+		{
+			name:           "passing PRs merge first, then the pending batch and the rest in batches",
+			pool:           Pool{SuccessPRs: prs(3), BatchPending: prs(2, 1), PendingPRs: prs(4), MissingPRs: prs(6, 5)},
+			batchSizeLimit: 2,
+			testDuration:   time.Hour,
+			pendingSince:   now.Add(-15 * time.Minute),
+			expected: []MergeTrainCar{
+				{Number: 3, Batch: 0, ETA: at(0)},
+				{Number: 1, Batch: 1, ETA: at(45 * time.Minute)},
+				{Number: 2, Batch: 1, ETA: at(45 * time.Minute)},
+				{Number: 4, Batch: 2, ETA: at(105 * time.Minute)},
+				{Number: 5, Batch: 2, ETA: at(105 * time.Minute)},
+				{Number: 6, Batch: 3, ETA: at(165 * time.Minute)},
+			},
+		},
+		{
+			name:           "unlimited batch size",
+			pool:           Pool{PendingPRs: prs(1), MissingPRs: prs(2, 3)},
+			batchSizeLimit: 0,
+			testDuration:   time.Hour,
+			expected: []MergeTrainCar{
+				{Number: 1, Batch: 0, ETA: at(time.Hour)},
+				{Number: 2, Batch: 0, ETA: at(time.Hour)},
+				{Number: 3, Batch: 0, ETA: at(time.Hour)},
+			},
+		},
+		{
+			name:           "disabled batching tests PRs one after the other",
+			pool:           Pool{MissingPRs: prs(1, 2)},
+			batchSizeLimit: -1,
+			testDuration:   time.Hour,
+			expected: []MergeTrainCar{
+				{Number: 1, Batch: 0, ETA: at(time.Hour)},
+				{Number: 2, Batch: 1, ETA: at(2 * time.Hour)},
+			},
+		},
+		{
+			name:           "overdue pending batch is expected now",
+			pool:           Pool{BatchPending: prs(1)},
+			batchSizeLimit: 0,
+			testDuration:   time.Hour,
+			pendingSince:   now.Add(-2 * time.Hour),
+			expected: []MergeTrainCar{
+				{Number: 1, Batch: 0, ETA: at(0)},
+			},
+		},
+		{
+			name:           "no ETAs without test durations",
+			pool:           Pool{SuccessPRs: prs(1), MissingPRs: prs(2)},
+			batchSizeLimit: 0,
+			expected: []MergeTrainCar{
+				{Number: 1, Batch: 0},
+				{Number: 2, Batch: 1},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			train := predictMergeTrain(tc.pool, tc.batchSizeLimit, tc.testDuration, tc.pendingSince, now)
+			if diff := cmp.Diff(tc.expected, train.Cars); diff != "" {
+				t.Errorf("cars differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobDurations(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	pj := func(name, job string, duration time.Duration) prowapi.ProwJob {
+		pj := prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       prowapi.ProwJobSpec{Job: job, Type: prowapi.BatchJob},
+			Status:     prowapi.ProwJobStatus{StartTime: metav1.NewTime(start)},
+		}
+		if duration > 0 {
+			completion := metav1.NewTime(start.Add(duration))
+			pj.Status.CompletionTime = &completion
+		}
+		return pj
+	}
+
+	var durations jobDurations
+	if expected := durations.expected("org/repo:main"); expected != 0 {
+		t.Errorf("expected no duration without jobs, got %v", expected)
+	}
+	durations.observe("org/repo:main", []prowapi.ProwJob{
+		pj("a", "unit", 10*time.Minute),
+		pj("b", "unit", 20*time.Minute),
+		pj("c", "e2e", 40*time.Minute),
+		pj("d", "e2e", 0),
+	})
+	// observing the same runs again must not change the average
+	durations.observe("org/repo:main", []prowapi.ProwJob{pj("a", "unit", 10*time.Minute), pj("e", "e2e", 20*time.Minute)})
+	if expected, actual := 30*time.Minute, durations.expected("org/repo:main"); expected != actual {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if expected := durations.expected("org/other:main"); expected != 0 {
+		t.Errorf("expected no duration for another pool, got %v", expected)
+	}
+}
+
+func TestPendingBatchStart(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	completion := metav1.NewTime(start)
+	pjs := []prowapi.ProwJob{
+		{Spec: prowapi.ProwJobSpec{Type: prowapi.BatchJob}, Status: prowapi.ProwJobStatus{StartTime: metav1.NewTime(start.Add(time.Minute))}},
+		{Spec: prowapi.ProwJobSpec{Type: prowapi.BatchJob}, Status: prowapi.ProwJobStatus{StartTime: metav1.NewTime(start.Add(-time.Hour)), CompletionTime: &completion}},
+		{Spec: prowapi.ProwJobSpec{Type: prowapi.PresubmitJob}, Status: prowapi.ProwJobStatus{StartTime: metav1.NewTime(start.Add(-time.Minute))}},
+		{Spec: prowapi.ProwJobSpec{Type: prowapi.BatchJob}, Status: prowapi.ProwJobStatus{StartTime: metav1.NewTime(start.Add(2 * time.Minute))}},
+	}
+	if expected, actual := start.Add(time.Minute), pendingBatchStart(pjs); !expected.Equal(actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+)
+
+type mergeWhenGreenGitHubClient interface {
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
+	ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
+	Merge(org, repo string, number int, details github.MergeDetails) error
+	RemoveLabel(org, repo string, number int, label string) error
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+// mergeWhenGreenController merges PRs labeled by the merge-when-green plugin
+// in repos that are not part of any Tide query. Unlike the sync controller it
+// neither retests nor batches PRs, it only merges a PR once the contexts
+// required by the Tide context policy of its branch pass on its head commit.
+type mergeWhenGreenController struct {
+	logger *logrus.Entry
+	ghc    mergeWhenGreenGitHubClient
+	config config.Getter
+	gc     git.ClientFactory
+}
+
+func newMergeWhenGreenController(logger *logrus.Entry, ghc mergeWhenGreenGitHubClient, cfg config.Getter, gc git.ClientFactory) *mergeWhenGreenController {
+	return &mergeWhenGreenController{
+		logger: logger.WithField("controller", "merge-when-green"),
+		ghc:    ghc,
+		config: cfg,
+		gc:     gc,
+	}
+}
+
+// sync merges all labeled PRs whose required contexts pass.
+func (c *mergeWhenGreenController) sync() error {
+	mwg := c.config().Tide.MergeWhenGreen
+	if !mwg.Enabled() {
+		return nil
+	}
+	var errs []error
+	for org, orgRepoQuery := range orgRepoQueryStrings(mwg.Orgs, mwg.Repos, nil) {
+		query := fmt.Sprintf(`is:pr is:open archived:false label:"%s" %s`, labels.MergeWhenGreen, orgRepoQuery)
+		issues, err := c.ghc.FindIssuesWithOrg(org, query, "", false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to search for PRs in %s: %w", org, err))
+			continue
+		}
+		for _, issue := range issues {
+			issueOrg, issueRepo, err := issue.OrgRepo()
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			orgRepo := config.OrgRepo{Org: issueOrg, Repo: issueRepo}
+			if err := c.syncPR(orgRepo, issue.Number); err != nil {
+				errs = append(errs, fmt.Errorf("failed to sync %s#%d: %w", orgRepo.String(), issue.Number, err))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *mergeWhenGreenController) syncPR(orgRepo config.OrgRepo, number int) error {
+	cfg := c.config()
+	log := c.logger.WithFields(logrus.Fields{"org": orgRepo.Org, "repo": orgRepo.Repo, "pr": number})
+	if len(cfg.Tide.Queries.QueryMap().ForRepo(orgRepo)) > 0 {
+		log.Debug("Repo is part of a Tide query, leaving the PR to the sync controller.")
+		return nil
+	}
+	if !cfg.Tide.MergeWhenGreen.ForRepo(orgRepo) {
+		return nil
+	}
+
+	pr, err := c.ghc.GetPullRequest(orgRepo.Org, orgRepo.Repo, number)
+	if err != nil {
+		return err
+	}
+	if pr.Merged || pr.State != github.PullRequestStateOpen {
+		return nil
+	}
+	if !github.HasLabel(labels.Approved, pr.Labels) {
+		log.Debug("PR is not approved.")
+		return nil
+	}
+	for _, label := range pr.Labels {
+		if strings.HasPrefix(label.Name, "do-not-merge/") {
+			log.WithField("label", label.Name).Debug("PR has a do-not-merge label.")
+			return nil
+		}
+	}
+	if pr.Mergable != nil && !*pr.Mergable {
+		log.Debug("PR is not mergeable.")
+		return nil
+	}
+
+	cc, err := cfg.GetTideContextPolicy(c.gc, orgRepo.Org, orgRepo.Repo, pr.Base.Ref, refGetterFactory(pr.Base.SHA), pr.Head.SHA)
+	if err != nil {
+		return fmt.Errorf("failed to get the context policy: %w", err)
+	}
+	contexts, err := c.headContexts(orgRepo, pr.Head.SHA)
+	if err != nil {
+		return err
+	}
+	if unsuccessful := unsuccessfulContexts(contexts, cc, log); len(unsuccessful) > 0 {
+		log.WithField("contexts", contextsToStrings(unsuccessful)).Debug("Waiting for contexts to pass.")
+		return nil
+	}
+
+	details := github.MergeDetails{
+		SHA:         pr.Head.SHA,
+		MergeMethod: string(cfg.Tide.OrgRepoBranchMergeMethod(orgRepo, pr.Base.Ref)),
+	}
+	err = c.ghc.Merge(orgRepo.Org, orgRepo.Repo, number, details)
+	if err == nil {
+		log.Info("Merged.")
+		tideMetrics.merges.WithLabelValues(orgRepo.Org, orgRepo.Repo, pr.Base.Ref).Observe(1)
+		return nil
+	}
+	var modifiedHeadErr github.ModifiedHeadError
+	if errors.As(err, &modifiedHeadErr) {
+		// The new head will be picked up by the next sync.
+		log.WithError(err).Debug("PR was modified while merging.")
+		return nil
+	}
+	var unmergableErr github.UnmergablePRError
+	var unauthorizedErr github.UnauthorizedToPushError
+	var mergeCommitsErr github.MergeCommitsForbiddenError
+	if errors.As(err, &unmergableErr) || errors.As(err, &unauthorizedErr) || errors.As(err, &mergeCommitsErr) {
+		// These are rejections by the branch protection or the repo settings,
+		// retrying them would not change anything.
+		log.WithError(err).Info("GitHub refused to merge the PR.")
+		comment := fmt.Sprintf("GitHub refused to merge this PR even though all required contexts passed:\n\n```\n%v\n```\n\nThe `%s` label was removed, comment `/merge-when-green` once the problem is fixed.", err, labels.MergeWhenGreen)
+		if err := c.ghc.CreateComment(orgRepo.Org, orgRepo.Repo, number, comment); err != nil {
+			return err
+		}
+		return c.ghc.RemoveLabel(orgRepo.Org, orgRepo.Repo, number, labels.MergeWhenGreen)
+	}
+	return err
+}
+
+// headContexts returns the statuses and check runs of the head commit of a
+// PR, translated into the contexts used by the sync controller.
+func (c *mergeWhenGreenController) headContexts(orgRepo config.OrgRepo, sha string) ([]Context, error) {
+	combined, err := c.ghc.GetCombinedStatus(orgRepo.Org, orgRepo.Repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the combined status: %w", err)
+	}
+	checkRuns, err := c.ghc.ListCheckRuns(orgRepo.Org, orgRepo.Repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the check runs: %w", err)
+	}
+	var contexts []Context
+	if combined != nil {
+		for _, status := range combined.Statuses {
+			contexts = append(contexts, Context{
+				Context:     githubql.String(status.Context),
+				Description: githubql.String(status.Description),
+				State:       githubql.StatusState(strings.ToUpper(status.State)),
+			})
+		}
+	}
+	if checkRuns != nil {
+		for _, checkRun := range checkRuns.CheckRuns {
+			contexts = append(contexts, checkRunToContext(CheckRun{
+				Name:       githubql.String(checkRun.Name),
+				Conclusion: githubql.String(strings.ToUpper(checkRun.Conclusion)),
+				Status:     githubql.String(strings.ToUpper(checkRun.Status)),
+			}))
+		}
+	}
+	return deduplicateContexts(contexts), nil
+}
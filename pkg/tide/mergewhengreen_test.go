@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+)
+
+type fakeMergeWhenGreenClient struct {
+	pr        github.PullRequest
+	statuses  []github.Status
+	checkRuns []github.CheckRun
+	mergeErr  error
+
+	merged   []github.MergeDetails
+	removed  []string
+	comments []string
+}
+
+func (f *fakeMergeWhenGreenClient) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	return []github.Issue{{Number: f.pr.Number, HTMLURL: f.pr.HTMLURL}}, nil
+}
+
+func (f *fakeMergeWhenGreenClient) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	return &f.pr, nil
+}
+
+func (f *fakeMergeWhenGreenClient) GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error) {
+	return &github.CombinedStatus{SHA: ref, Statuses: f.statuses}, nil
+}
+
+func (f *fakeMergeWhenGreenClient) ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error) {
+	return &github.CheckRunList{CheckRuns: f.checkRuns}, nil
+}
+
+func (f *fakeMergeWhenGreenClient) Merge(org, repo string, number int, details github.MergeDetails) error {
+	if f.mergeErr != nil {
+		return f.mergeErr
+	}
+	f.merged = append(f.merged, details)
+	return nil
+}
+
+func (f *fakeMergeWhenGreenClient) RemoveLabel(org, repo string, number int, label string) error {
+	f.removed = append(f.removed, label)
+	return nil
+}
+
+func (f *fakeMergeWhenGreenClient) CreateComment(org, repo string, number int, comment string) error {
+	f.comments = append(f.comments, comment)
+	return nil
+}
+
+func TestMergeWhenGreenSync(t *testing.T) {
+	approved := []github.Label{{Name: labels.Approved}, {Name: labels.MergeWhenGreen}}
+	passing := []github.Status{{Context: "job", State: github.StatusSuccess}}
+	testCases := []struct {
+		name             string
+		labels           []github.Label
+		mergeable        *bool
+		statuses         []github.Status
+		checkRuns        []github.CheckRun
+		queries          config.TideQueries
+		mergeErr         error
+		expectedMerged   []github.MergeDetails
+		expectedRemoved  []string
+		expectedComments int
+	}{
+		{
+			name:           "required contexts pass",
+			labels:         approved,
+			statuses:       passing,
+			expectedMerged: []github.MergeDetails{{SHA: "head", MergeMethod: "squash"}},
+		},
+		{
+			name:     "passing check runs are considered",
+			labels:   approved,
+			statuses: passing,
+			checkRuns: []github.CheckRun{
+				{Name: "external", Status: "completed", Conclusion: "success"},
+			},
+			expectedMerged: []github.MergeDetails{{SHA: "head", MergeMethod: "squash"}},
+		},
+		{
+			name:     "pending check run blocks the merge",
+			labels:   approved,
+			statuses: passing,
+			checkRuns: []github.CheckRun{
+				{Name: "external", Status: "in_progress"},
+			},
+		},
+		{
+			name:     "required context is pending",
+			labels:   approved,
+			statuses: []github.Status{{Context: "job", State: github.StatusPending}},
+		},
+		{
+			name:   "required context is missing",
+			labels: approved,
+		},
+		{
+			name:           "optional context may fail",
+			labels:         approved,
+			statuses:       append([]github.Status{{Context: "optional-job", State: github.StatusFailure}}, passing...),
+			expectedMerged: []github.MergeDetails{{SHA: "head", MergeMethod: "squash"}},
+		},
+		{
+			name:     "PR is not approved",
+			labels:   []github.Label{{Name: labels.MergeWhenGreen}},
+			statuses: passing,
+		},
+		{
+			name:     "PR is on hold",
+			labels:   append([]github.Label{{Name: labels.Hold}}, approved...),
+			statuses: passing,
+		},
+		{
+			name:      "PR is not mergeable",
+			labels:    approved,
+			mergeable: &[]bool{false}[0],
+			statuses:  passing,
+		},
+		{
+			name:     "repo is part of a Tide query",
+			labels:   approved,
+			statuses: passing,
+			queries:  config.TideQueries{{Repos: []string{"org/repo"}}},
+		},
+		{
+			name:             "branch protection refuses the merge",
+			labels:           approved,
+			statuses:         passing,
+			mergeErr:         github.UnmergablePRError("required reviews missing"),
+			expectedRemoved:  []string{labels.MergeWhenGreen},
+			expectedComments: 1,
+		},
+		{
+			name:     "head was modified while merging",
+			labels:   approved,
+			statuses: passing,
+			mergeErr: github.ModifiedHeadError("head modified"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{ProwConfig: config.ProwConfig{Tide: config.Tide{TideGitHubConfig: config.TideGitHubConfig{
+				Queries:        tc.queries,
+				MergeType:      map[string]config.TideOrgMergeType{"org": {MergeType: "squash"}},
+				MergeWhenGreen: &config.TideMergeWhenGreen{Orgs: []string{"org"}},
+			}}}}
+			if err := cfg.SetPresubmits(map[string][]config.Presubmit{
+				"org/repo": {
+					{JobBase: config.JobBase{Name: "job"}, AlwaysRun: true, Reporter: config.Reporter{Context: "job"}},
+					{JobBase: config.JobBase{Name: "optional-job"}, AlwaysRun: true, Optional: true, Reporter: config.Reporter{Context: "optional-job"}},
+				},
+			}); err != nil {
+				t.Fatalf("failed to set presubmits: %v", err)
+			}
+			ghc := &fakeMergeWhenGreenClient{
+				pr: github.PullRequest{
+					Number:   1,
+					HTMLURL:  "https://github.com/org/repo/pull/1",
+					State:    github.PullRequestStateOpen,
+					Labels:   tc.labels,
+					Mergable: tc.mergeable,
+					Base:     github.PullRequestBranch{Ref: "main", SHA: "base"},
+					Head:     github.PullRequestBranch{SHA: "head"},
+				},
+				statuses:  tc.statuses,
+				checkRuns: tc.checkRuns,
+				mergeErr:  tc.mergeErr,
+			}
+			c := newMergeWhenGreenController(logrus.NewEntry(logrus.StandardLogger()), ghc, func() *config.Config { return cfg }, nil)
+			if err := c.sync(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedMerged, ghc.merged); diff != "" {
+				t.Errorf("unexpected merges (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedRemoved, ghc.removed); diff != "" {
+				t.Errorf("unexpected removed labels (-want +got):\n%s", diff)
+			}
+			if len(ghc.comments) != tc.expectedComments {
+				t.Errorf("expected %d comments, got %v", tc.expectedComments, ghc.comments)
+			}
+		})
+	}
+}
@@ -214,12 +214,9 @@ func requirementDiff(pr *PullRequest, q *config.TideQuery, cc contextChecker) (s
 	}
 
 	var presentLabels []string
-	for _, l1 := range q.MissingLabels {
-		for _, l2 := range pr.Labels.Nodes {
-			if string(l2.Name) == l1 {
-				presentLabels = append(presentLabels, l1)
-				break
-			}
+	for _, l := range pr.Labels.Nodes {
+		if q.IsMissingLabel(string(l.Name)) {
+			presentLabels = append(presentLabels, string(l.Name))
 		}
 	}
 	diff += len(presentLabels)
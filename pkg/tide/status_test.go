@@ -45,6 +45,7 @@ func TestExpectedStatus(t *testing.T) {
 	neededLabelsWithAlt := []string{"need-1", "need-2", "need-a-very-super-duper-extra-not-short-at-all-label-name,need-3"}
 	neededLabels := []string{"need-1", "need-2", "need-a-very-super-duper-extra-not-short-at-all-label-name"}
 	forbiddenLabels := []string{"forbidden-1", "forbidden-2"}
+	forbiddenLabelPatterns := append(append([]string{}, forbiddenLabels...), "blocked/*")
 	testcases := []struct {
 		name string
 
@@ -132,6 +133,18 @@ func TestExpectedStatus(t *testing.T) {
 			state: github.StatusPending,
 			desc:  fmt.Sprintf(statusNotInPool, " Should not have forbidden-1 label."),
 		},
+		{
+			name:              "has label matching a forbidden label pattern",
+			labels:            append(append([]string{}, neededLabels...), "blocked/by-infra"),
+			author:            "batman",
+			firstQueryAuthor:  "batman",
+			secondQueryAuthor: "batman",
+			milestone:         "v1.0",
+			inPool:            false,
+
+			state: github.StatusPending,
+			desc:  fmt.Sprintf(statusNotInPool, " Should not have blocked/by-infra label."),
+		},
 		{
 			name:              "only mention one requirement class",
 			labels:            append(append([]string{}, neededLabels[1:]...), forbiddenLabels[0]),
@@ -751,7 +764,7 @@ func TestExpectedStatus(t *testing.T) {
 					ExcludedBranches: tc.branchDenyList,
 					IncludedBranches: tc.branchAllowList,
 					Labels:           neededLabelsWithAlt,
-					MissingLabels:    forbiddenLabels,
+					MissingLabels:    forbiddenLabelPatterns,
 					Author:           tc.firstQueryAuthor,
 					Milestone:        "v1.0",
 				},
@@ -58,11 +58,13 @@ type githubClient interface {
 	CreateStatus(string, string, string, github.Status) error
 	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
 	ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
 	GetRef(string, string, string) (string, error)
 	GetRepo(owner, name string) (github.FullRepo, error)
 	Merge(string, string, int, github.MergeDetails) error
 	QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error
+	UpdatePullRequestBranch(org, repo string, number int, expectedHeadSha *string) error
 }
 
 type contextChecker interface {
@@ -81,8 +83,16 @@ type syncController struct {
 	provider      provider
 	pickNewBatch  func(sp subpool, candidates []CodeReviewCommon, maxBatchSize int) ([]CodeReviewCommon, error)
 
-	m     sync.Mutex
-	pools []Pool
+	m           sync.Mutex
+	pools       []Pool
+	mergeTrains []MergeTrain
+
+	// jobDurations are used to predict the ETAs of the merge trains.
+	jobDurations jobDurations
+
+	// mergedDependencies caches the keys of merged PRs that pool PRs depend
+	// on, as a merged PR stays merged.
+	mergedDependencies sets.Set[string]
 
 	// changedFiles caches the names of files changed by PRs.
 	// Cache entries expire if they are not used during a sync loop.
@@ -105,6 +115,7 @@ const (
 	TriggerBatch Action = "TRIGGER_BATCH"
 	Merge        Action = "MERGE"
 	MergeBatch   Action = "MERGE_BATCH"
+	UpdateBranch Action = "UPDATE_BRANCH"
 	PoolBlocked  Action = "BLOCKED"
 )
 
@@ -115,6 +126,7 @@ var recordableActions = map[Action]bool{
 	TriggerBatch: true,
 	Merge:        true,
 	MergeBatch:   true,
+	UpdateBranch: true,
 }
 
 // Pool represents information about a tide pool. There is one for every
@@ -299,6 +311,8 @@ type manager interface {
 type Controller struct {
 	syncCtrl   *syncController
 	statusCtrl *statusController
+	// mergeWhenGreenCtrl is only set for GitHub.
+	mergeWhenGreenCtrl *mergeWhenGreenController
 }
 
 // Shutdown signals the statusController to stop working and waits for it to
@@ -310,13 +324,24 @@ func (c *Controller) Shutdown() {
 }
 
 func (c *Controller) Sync() error {
-	return c.syncCtrl.Sync()
+	errs := []error{c.syncCtrl.Sync()}
+	if c.mergeWhenGreenCtrl != nil {
+		if err := c.mergeWhenGreenCtrl.sync(); err != nil {
+			errs = append(errs, fmt.Errorf("merge-when-green: %w", err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
 }
 
 func (c *Controller) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c.syncCtrl.ServeHTTP(w, r)
 }
 
+// MergeTrains serves the predicted merge order of the PRs of each pool.
+func (c *Controller) MergeTrains() http.Handler {
+	return http.HandlerFunc(c.syncCtrl.serveMergeTrains)
+}
+
 func (c *Controller) History() *history.History {
 	return c.syncCtrl.History
 }
@@ -363,7 +388,8 @@ func NewController(
 	if err != nil {
 		return nil, err
 	}
-	return &Controller{syncCtrl: syncCtrl, statusCtrl: sc}, nil
+	mergeWhenGreenCtrl := newMergeWhenGreenController(logger, ghcSync, cfg, gc)
+	return &Controller{syncCtrl: syncCtrl, statusCtrl: sc, mergeWhenGreenCtrl: mergeWhenGreenCtrl}, nil
 }
 
 func newStatusController(
@@ -508,6 +534,7 @@ func (c *syncController) Sync() error {
 		return err
 	}
 	filteredPools := c.filterSubpools(c.provider.isAllowedToMerge, rawPools)
+	c.linkDependentPRs(filteredPools)
 
 	// Notify statusController about the new pool.
 	c.statusUpdate.Lock()
@@ -524,6 +551,7 @@ func (c *syncController) Sync() error {
 
 	// Sync subpools in parallel.
 	poolChan := make(chan Pool, len(filteredPools))
+	trainChan := make(chan MergeTrain, len(filteredPools))
 	subpoolsInParallel(
 		c.config().Tide.MaxGoroutines,
 		filteredPools,
@@ -538,17 +566,25 @@ func (c *syncController) Sync() error {
 				sp.log.WithError(err).Errorf("Error syncing subpool.")
 			}
 			poolChan <- pool
+			trainChan <- c.mergeTrain(sp, pool)
 		},
 	)
 
 	close(poolChan)
+	close(trainChan)
 	pools := make([]Pool, 0, len(poolChan))
 	for pool := range poolChan {
 		pools = append(pools, pool)
 	}
 	sortPools(pools)
+	mergeTrains := make([]MergeTrain, 0, len(trainChan))
+	for train := range trainChan {
+		mergeTrains = append(mergeTrains, train)
+	}
+	sortMergeTrains(mergeTrains)
 	c.m.Lock()
 	c.pools = pools
+	c.mergeTrains = mergeTrains
 	c.m.Unlock()
 
 	c.History.Flush()
@@ -1374,6 +1410,9 @@ func (c *syncController) trigger(sp subpool, presubmits []config.Presubmit, prs
 		var spec prowapi.ProwJobSpec
 		if len(prs) == 1 {
 			spec = pjutil.PresubmitSpec(ps, refs)
+			if ps.Decorate != nil && *ps.Decorate {
+				spec.ExtraRefs = append(spec.ExtraRefs, sp.linked[prs[0].Number]...)
+			}
 		} else {
 			if c.nonFailedBatchForJobAndRefsExists(ps.Name, &refs) {
 				continue
@@ -1422,6 +1461,18 @@ func (c *syncController) takeAction(sp subpool, batchPending, successes, pending
 		}
 	}()
 
+	// Linked PRs only merge together with the PRs they are linked with.
+	successes = withoutPRs(successes, sp.held.Has)
+	if linked := withoutPRs(successes, func(number int) bool { return len(sp.linked[number]) == 0 }); len(linked) > 0 {
+		successes = linked
+	}
+	for _, pr := range batchMerges {
+		if sp.held.Has(pr.Number) {
+			batchMerges = nil
+			break
+		}
+	}
+
 	// Merge the batch!
 	if len(batchMerges) > 0 {
 		merged, err = c.provider.mergePRs(sp, batchMerges, c.statusUpdate.dontUpdateStatus)
@@ -1431,6 +1482,9 @@ func (c *syncController) takeAction(sp subpool, batchPending, successes, pending
 	// invalidate the old batch result.
 	if len(successes) > 0 && len(batchPending) == 0 {
 		if ok, pr := pickHighestPriorityPR(sp.log, successes, sp.cc, c.isPassingTests, c.config().Tide.Priority); ok {
+			if updated, err := c.updateIfBehind(sp, pr); err != nil || updated {
+				return UpdateBranch, []CodeReviewCommon{pr}, err
+			}
 			merged, err = c.provider.mergePRs(sp, []CodeReviewCommon{pr}, c.statusUpdate.dontUpdateStatus)
 			return Merge, []CodeReviewCommon{pr}, err
 		}
@@ -1440,8 +1494,12 @@ func (c *syncController) takeAction(sp subpool, batchPending, successes, pending
 		return Wait, nil, nil
 	}
 	// If we have no batch, trigger one.
-	if len(sp.prs) > 1 && len(batchPending) == 0 {
-		batch, presubmits, err := c.pickBatch(sp, sp.cc, c.pickNewBatch)
+	// Linked PRs are tested on their own, so that they can merge together with
+	// the PRs they are linked with.
+	batchSP := sp
+	batchSP.prs = withoutPRs(sp.prs, func(number int) bool { return len(sp.linked[number]) > 0 || sp.held.Has(number) })
+	if len(batchSP.prs) > 1 && len(batchPending) == 0 {
+		batch, presubmits, err := c.pickBatch(batchSP, sp.cc, c.pickNewBatch)
 		if err != nil {
 			return Wait, nil, err
 		}
@@ -1452,12 +1510,37 @@ func (c *syncController) takeAction(sp subpool, batchPending, successes, pending
 	// If we have no serial jobs pending or successful, trigger one.
 	if len(missings) > 0 && len(pendings) == 0 && len(successes) == 0 {
 		if ok, pr := pickHighestPriorityPR(sp.log, missings, sp.cc, c.isRetestEligible, c.config().Tide.Priority); ok {
+			// Updating the branch makes GitHub trigger the tests of the new head.
+			if updated, err := c.updateIfBehind(sp, pr); err != nil || updated {
+				return UpdateBranch, []CodeReviewCommon{pr}, err
+			}
 			return Trigger, []CodeReviewCommon{pr}, c.trigger(sp, missingSerialTests[pr.Number], []CodeReviewCommon{pr})
 		}
 	}
 	return Wait, nil, nil
 }
 
+// updateIfBehind updates the branch of a PR of a strict subpool that does not
+// contain the base HEAD yet and reports whether it did, so that the PR is
+// tested and merged as it is instead of merged with a newer base HEAD.
+func (c *syncController) updateIfBehind(sp subpool, pr CodeReviewCommon) (bool, error) {
+	if !sp.strictRebase {
+		return false, nil
+	}
+	upToDate, err := c.provider.isUpToDate(sp, &pr)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if PR #%d is up to date: %w", pr.Number, err)
+	}
+	if upToDate {
+		return false, nil
+	}
+	sp.log.WithFields(pr.logFields()).Info("Updating the branch of the PR with the base HEAD.")
+	if err := c.provider.updateBranch(&pr); err != nil {
+		return true, fmt.Errorf("failed to update the branch of PR #%d: %w", pr.Number, err)
+	}
+	return true, nil
+}
+
 // changedFilesAgent queries and caches the names of files changed by PRs.
 // Cache entries expire if they are not used during a sync loop.
 type changedFilesAgent struct {
@@ -1573,6 +1656,12 @@ func (c *syncController) presubmitsByPull(sp *subpool) (map[int][]config.Presubm
 			if !c.provider.jobIsRequiredByTide(&ps, &pr) {
 				continue
 			}
+			// Jobs that run after the success of other jobs are triggered by
+			// the dependent-jobs controller, like trigger leaves them to it.
+			if ps.RunsAfterSuccess() {
+				log.WithField("context", ps.Context).Debug("Presubmit excluded as it runs after the success of other jobs")
+				continue
+			}
 
 			// Only keep the jobs that are required for this PR. Order of
 			// filters:
@@ -1633,6 +1722,12 @@ func (c *syncController) presubmitsForBatch(prs []CodeReviewCommon, org, repo, b
 		if !c.provider.jobIsRequiredByTide(&ps, &prs[0]) {
 			continue
 		}
+		// The dependent-jobs controller only triggers jobs that run after the
+		// success of other jobs for single PRs, batches don't wait for them.
+		if ps.RunsAfterSuccess() {
+			log.WithField("context", ps.Context).Debug("Presubmit excluded as it runs after the success of other jobs")
+			continue
+		}
 
 		forceRun := (requireManuallyTriggeredJobs && ps.ContextRequired() && ps.NeedsExplicitTrigger()) || ps.RunBeforeMerge
 		shouldRun, err := ps.ShouldRun(baseBranch, c.changedFiles.batchChanges(prs), forceRun, false)
@@ -1768,6 +1863,17 @@ type subpool struct {
 	// presubmit contains all required presubmits for each PR
 	// in this subpool
 	presubmits map[int][]config.Presubmit
+
+	// strictRebase requires PRs to be up to date with the base HEAD before
+	// they are tested and merged on their own.
+	strictRebase bool
+
+	// linked contains the refs of the pool PRs of other subpools that each
+	// PR is linked with through Depends-On lines.
+	linked map[int][]prowapi.Refs
+	// held contains the linked PRs that must not merge because not all of
+	// the PRs they are linked with are ready to merge.
+	held sets.Set[int]
 }
 
 func (sp subpool) TenantIDs() []string {
@@ -1808,10 +1914,11 @@ func (c *syncController) dividePool(pool map[string]CodeReviewCommon) (map[strin
 					"branch":   branch,
 					"base-sha": sha,
 				}),
-				org:    org,
-				repo:   repo,
-				branch: branch,
-				sha:    sha,
+				org:          org,
+				repo:         repo,
+				branch:       branch,
+				sha:          sha,
+				strictRebase: c.config().Tide.Queries.StrictRebase(config.OrgRepo{Org: org, Repo: repo}, branch),
 			}
 		}
 		sps[fn].prs = append(sps[fn].prs, pr)
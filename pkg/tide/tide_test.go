@@ -712,6 +712,12 @@ type fgc struct {
 	skipExpectedShaCheck bool
 	combinedStatus       map[string]string
 	checkRuns            *github.CheckRunList
+	mergedPRs            sets.Set[string]
+	updatedBranches      []int
+}
+
+func (f *fgc) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	return &github.PullRequest{Number: number, Merged: f.mergedPRs.Has(fmt.Sprintf("%s/%s#%d", org, repo, number))}, nil
 }
 
 func (f *fgc) GetRepo(o, r string) (github.FullRepo, error) {
@@ -800,6 +806,13 @@ func (f *fgc) ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
 	return &github.CheckRunList{}, nil
 }
 
+func (f *fgc) UpdatePullRequestBranch(org, repo string, number int, expectedHeadSha *string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.updatedBranches = append(f.updatedBranches, number)
+	return nil
+}
+
 func (f *fgc) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
 	if number != 100 {
 		return nil, nil
@@ -1428,10 +1441,13 @@ func testTakeAction(clients localgit.Clients, t *testing.T) {
 		preExistingJobs  []runtime.Object
 		mergeErrs        map[int]error
 		enableScheduling bool
+		strictRebase     bool
+		baseAdvanced     bool
 
 		merged           int
 		triggered        int
 		triggeredBatches int
+		updatedBranches  int
 		action           Action
 	}{
 		{
@@ -1843,6 +1859,47 @@ func testTakeAction(clients localgit.Clients, t *testing.T) {
 			action:           Trigger,
 			enableScheduling: true,
 		},
+		{
+			name: "strict rebase, up to date PR merges",
+
+			strictRebase: true,
+			successes:    []int{1},
+			merged:       1,
+			action:       Merge,
+		},
+		{
+			name: "strict rebase, PR behind the base is updated instead of merged",
+
+			strictRebase:    true,
+			baseAdvanced:    true,
+			successes:       []int{1},
+			updatedBranches: 1,
+			action:          UpdateBranch,
+		},
+		{
+			name: "strict rebase, PR behind the base is updated instead of retested",
+
+			strictRebase: true,
+			baseAdvanced: true,
+			batchPending: true,
+			nones:        []int{0, 1, 2},
+			presubmits: map[int][]config.Presubmit{
+				100: {
+					{Reporter: config.Reporter{Context: "foo"}},
+				},
+			},
+			updatedBranches: 1,
+			action:          UpdateBranch,
+		},
+		{
+			name: "strict rebase, batches of PRs behind the base merge",
+
+			strictRebase: true,
+			baseAdvanced: true,
+			batchMerges:  []int{1, 2, 3},
+			merged:       3,
+			action:       MergeBatch,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1928,10 +1985,11 @@ func testTakeAction(clients localgit.Clients, t *testing.T) {
 					8:   &config.TideContextPolicy{},
 					100: &config.TideContextPolicy{},
 				},
-				org:    "o",
-				repo:   "r",
-				branch: defaultBranch,
-				sha:    defaultBranch,
+				org:          "o",
+				repo:         "r",
+				branch:       defaultBranch,
+				sha:          defaultBranch,
+				strictRebase: tc.strictRebase,
 			}
 			genPulls := func(nums []int) []CodeReviewCommon {
 				var prs []CodeReviewCommon
@@ -1987,9 +2045,18 @@ func testTakeAction(clients localgit.Clients, t *testing.T) {
 			if tc.batchPending {
 				batchPending = []CodeReviewCommon{{}}
 			}
-			if act, _, _ := c.takeAction(sp, batchPending, genPulls(tc.successes), genPulls(tc.pendings), genPulls(tc.nones), genPulls(tc.batchMerges), sp.presubmits); act != tc.action {
+			successes, pendings, nones, batchMerges := genPulls(tc.successes), genPulls(tc.pendings), genPulls(tc.nones), genPulls(tc.batchMerges)
+			if tc.baseAdvanced {
+				if err := lg.AddCommit("o", "r", map[string][]byte{"base": []byte("advanced")}); err != nil {
+					t.Fatalf("Error adding commit: %v", err)
+				}
+			}
+			if act, _, _ := c.takeAction(sp, batchPending, successes, pendings, nones, batchMerges, sp.presubmits); act != tc.action {
 				t.Errorf("Wrong action. Got %v, wanted %v.", act, tc.action)
 			}
+			if n := len(fgc.updatedBranches); n != tc.updatedBranches {
+				t.Errorf("Wrong number of updated branches. Got %d, expected %d.", n, tc.updatedBranches)
+			}
 
 			prowJobs := &prowapi.ProwJobList{}
 			if err := c.prowJobClient.List(ctx, prowJobs); err != nil {
@@ -2994,6 +3061,24 @@ func TestPresubmitsByPull(t *testing.T) {
 				AlwaysRun: true,
 			}}},
 		},
+		{
+			name: "jobs that run after the success of other jobs are excluded",
+			presubmits: []config.Presubmit{
+				{
+					Reporter:  config.Reporter{Context: "always"},
+					AlwaysRun: true,
+				},
+				{
+					Reporter:        config.Reporter{Context: "after"},
+					AlwaysRun:       true,
+					RunAfterSuccess: []string{"always"},
+				},
+			},
+			expectedPresubmits: map[int][]config.Presubmit{100: {{
+				Reporter:  config.Reporter{Context: "always"},
+				AlwaysRun: true,
+			}}},
+		},
 		{
 			name: "runs against branch",
 			presubmits: []config.Presubmit{
@@ -3696,6 +3781,25 @@ func TestPresubmitsForBatch(t *testing.T) {
 				Brancher:  config.Brancher{Branches: []string{defaultBranch}},
 			}},
 		},
+		{
+			name: "Jobs that run after the success of other jobs are excluded",
+			prs:  []CodeReviewCommon{*CodeReviewCommonFromPullRequest(getPR("org", "repo", 1))},
+			jobs: []config.Presubmit{
+				{
+					AlwaysRun: true,
+					Reporter:  config.Reporter{Context: "foo"},
+				},
+				{
+					AlwaysRun:       true,
+					Reporter:        config.Reporter{Context: "bar"},
+					RunAfterSuccess: []string{"foo"},
+				},
+			},
+			expected: []config.Presubmit{{
+				AlwaysRun: true,
+				Reporter:  config.Reporter{Context: "foo"},
+			}},
+		},
 		{
 			name: "Optional jobs are excluded",
 			prs:  []CodeReviewCommon{*CodeReviewCommonFromPullRequest(getPR("org", "repo", 1))},
@@ -4455,6 +4559,33 @@ func TestQueryShardsByOrgWhenAppsAuthIsEnabledOnly(t *testing.T) {
 	}
 }
 
+func TestQueryFiltersMissingLabelPatterns(t *testing.T) {
+	t.Parallel()
+	provider := &GitHubProvider{
+		cfg: func() *config.Config {
+			return &config.Config{ProwConfig: config.ProwConfig{Tide: config.Tide{
+				TideGitHubConfig: config.TideGitHubConfig{Queries: []config.TideQuery{{Orgs: []string{"org"}, MissingLabels: []string{"do-not-merge/*"}}}}}}}
+		},
+		ghc: &fgc{prs: map[string][]PullRequest{"": {
+			*testPRWithLabels("org", "repo", "A", 1, githubql.MergeableStateMergeable, []string{"lgtm"}),
+			*testPRWithLabels("org", "repo", "A", 2, githubql.MergeableStateMergeable, []string{"lgtm", "do-not-merge/hold"}),
+		}}},
+		logger: logrus.WithField("test", "TestQueryFiltersMissingLabelPatterns"),
+	}
+
+	prs, err := provider.Query()
+	if err != nil {
+		t.Fatalf("query() failed: %v", err)
+	}
+	var numbers []int
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	if diff := cmp.Diff([]int{1}, numbers); diff != "" {
+		t.Errorf("queried PRs differ from expected (-want +got):\n%s", diff)
+	}
+}
+
 func TestPickBatchPrefersBatchesWithPreexistingJobs(t *testing.T) {
 	t.Parallel()
 	const org, repo = "org", "repo"